@@ -146,3 +146,16 @@ localhost:30658
     {{- fail "either oidc.upstreamIDPs or oidc.mockIDP must be set in non-LOCAL deployments" }}
 {{- end }}
 {{- end }}
+
+{{- define "pachyderm.pipelinePriorityClassName" -}}
+{{ .namespace }}-pipeline-{{ .name }}
+{{- end -}}
+
+{{- define "pachyderm.pipelinePriorityClasses" -}}
+{{- $classes := list -}}
+{{- range .Values.pachd.pipelinePriorityClasses -}}
+{{- $className := include "pachyderm.pipelinePriorityClassName" (dict "namespace" $.Release.Namespace "name" .name) -}}
+{{- $classes = append $classes (printf "%s=%s" .name $className) -}}
+{{- end -}}
+{{ join "," $classes }}
+{{- end -}}
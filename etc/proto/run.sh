@@ -41,6 +41,29 @@ Mgoogle/protobuf/any.proto=github.com/gogo/protobuf/types,\
     "${i}" >/dev/stderr
 done
 
+# Generate the REST gateway and its OpenAPI specs for the APIs that pachd
+# serves over HTTP. Every RPC is exposed at POST /<package>.<service>/<method>,
+# so the protos don't need any HTTP annotations.
+mkdir -p v2/src/server/restgateway/openapi
+for i in src/pfs/pfs.proto src/pps/pps.proto src/auth/auth.proto; do \
+    protoc \
+        "-I${GOPATH}/pkg/mod/github.com/gogo/protobuf@${GOGO_PROTO_VERSION}" \
+        -Isrc \
+        --grpc-gateway_out=generate_unbound_methods=true,\
+Mgoogle/protobuf/duration.proto=github.com/gogo/protobuf/types,\
+Mgoogle/protobuf/empty.proto=github.com/gogo/protobuf/types,\
+Mgoogle/protobuf/timestamp.proto=github.com/gogo/protobuf/types,\
+Mgoogle/protobuf/wrappers.proto=github.com/gogo/protobuf/types,\
+Mgogoproto/gogo.proto=github.com/gogo/protobuf/gogoproto,\
+Mgoogle/protobuf/any.proto=github.com/gogo/protobuf/types,\
+":${GOPATH}/src" \
+        --swagger_out=generate_unbound_methods=true:v2/src/server/restgateway/openapi \
+        "${i}" >/dev/stderr
+done
+# protoc-gen-grpc-gateway names the well-known types after their golang
+# packages, but our protos use gogo's
+find v2/src -name "*.pb.gw.go" -print0 | xargs -0 sed -i 's/\bemptypb\./types./g'
+
 pushd src > /dev/stderr
 read -ra proto_files < <(find . -name "*.proto" -print0 | xargs -0)
 protoc \
@@ -55,4 +78,4 @@ popd > /dev/stderr
 # TODO (brendon): figure out how to configure protoc
 pushd v2 > /dev/stderr
 gofmt -w src > /dev/stderr
-find src -regex ".*\.go" -print0 | xargs -0 tar cf -
+find src \( -regex ".*\.go" -o -name "*.swagger.json" \) -print0 | xargs -0 tar cf -
@@ -78,6 +78,17 @@ rules:
           - create
           - update
           - delete
+    - apiGroups:
+          - "batch"
+      resources:
+          - jobs
+      verbs:
+          - get
+          - list
+          - watch
+          - create
+          - update
+          - delete
     - apiGroups:
           - ""
       resources:
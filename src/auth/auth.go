@@ -57,6 +57,16 @@ const (
 	// RepoOwnerRole is a role which grants access to read, write and modify the role bindings for a repo
 	RepoOwnerRole = "repoOwner"
 
+	// ProjectOwnerRole is a role which grants the ability to create repos in
+	// a project, modify its role bindings and delete it, plus the
+	// permissions of RepoOwnerRole on the repos in it
+	ProjectOwnerRole = "projectOwner"
+
+	// DelegatedAdminRole is a role which grants the ability to read a repo and
+	// modify its role bindings, but only to grant or revoke roles whose
+	// permissions the holder already has.
+	DelegatedAdminRole = "delegatedAdmin"
+
 	// RepoWriterRole is a role which grants ability to both read from and write to a repo
 	RepoWriterRole = "repoWriter"
 
@@ -122,6 +132,10 @@ var (
 	// ErrExpiredToken is returned by the Auth API if a restored token expired in
 	// the past.
 	ErrExpiredToken = status.Error(codes.Internal, "token expiration is in the past")
+
+	// ErrACLsFrozen is returned by the Auth API if the caller tries to change
+	// the cluster's ACLs while they're frozen.
+	ErrACLsFrozen = status.Error(codes.FailedPrecondition, "the cluster's ACLs are frozen (see 'pachctl auth get-freeze')")
 )
 
 var DefaultOIDCScopes = []string{"email", "profile", "groups", oidc.ScopeOpenID}
@@ -179,6 +193,14 @@ func IsErrExpiredToken(err error) bool {
 	return strings.Contains(err.Error(), status.Convert(ErrExpiredToken).Message())
 }
 
+// IsErrACLsFrozen returns true if 'err' is a ErrACLsFrozen
+func IsErrACLsFrozen(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(err.Error(), status.Convert(ErrACLsFrozen).Message())
+}
+
 const errNoRoleBindingMsg = "no role binding exists for"
 
 // ErrNoRoleBinding is returned if no role binding exists for a resource.
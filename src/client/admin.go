@@ -2,8 +2,12 @@
 package client
 
 import (
+	"context"
+	"time"
+
 	"github.com/gogo/protobuf/types"
 	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/clientsdk"
 	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
 )
 
@@ -15,3 +19,218 @@ func (c APIClient) InspectCluster() (*admin.ClusterInfo, error) {
 	}
 	return clusterInfo, nil
 }
+
+// CreateWebhook registers a webhook, or replaces the webhook of the same name
+// if update is true.
+func (c APIClient) CreateWebhook(webhook *admin.Webhook, update bool) error {
+	_, err := c.AdminAPIClient.CreateWebhook(c.Ctx(), &admin.CreateWebhookRequest{
+		Webhook: webhook,
+		Update:  update,
+	})
+	return grpcutil.ScrubGRPC(err)
+}
+
+// InspectWebhook returns info about a webhook, including its recent
+// deliveries.
+func (c APIClient) InspectWebhook(name string) (*admin.WebhookInfo, error) {
+	webhookInfo, err := c.AdminAPIClient.InspectWebhook(c.Ctx(), &admin.InspectWebhookRequest{Name: name})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return webhookInfo, nil
+}
+
+// ListWebhook returns info about all webhooks.
+func (c APIClient) ListWebhook() ([]*admin.WebhookInfo, error) {
+	ctx, cf := context.WithCancel(c.Ctx())
+	defer cf()
+	client, err := c.AdminAPIClient.ListWebhook(ctx, &admin.ListWebhookRequest{})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	webhookInfos, err := clientsdk.ListWebhookInfo(client)
+	return webhookInfos, grpcutil.ScrubGRPC(err)
+}
+
+// DeleteWebhook deletes a webhook.
+func (c APIClient) DeleteWebhook(name string) error {
+	_, err := c.AdminAPIClient.DeleteWebhook(c.Ctx(), &admin.DeleteWebhookRequest{Name: name})
+	return grpcutil.ScrubGRPC(err)
+}
+
+// ListInflightRequests returns the requests that the pachd serving c is
+// handling, which are at least minAge old and whose method contains method.
+func (c APIClient) ListInflightRequests(minAge time.Duration, method string) (*admin.ListInflightRequestsResponse, error) {
+	request := &admin.ListInflightRequestsRequest{Method: method}
+	if minAge > 0 {
+		request.MinAge = types.DurationProto(minAge)
+	}
+	response, err := c.AdminAPIClient.ListInflightRequests(c.Ctx(), request)
+	return response, grpcutil.ScrubGRPC(err)
+}
+
+// CancelInflightRequest cancels a request that the pachd serving c is
+// handling.
+func (c APIClient) CancelInflightRequest(id string) error {
+	_, err := c.AdminAPIClient.CancelInflightRequest(c.Ctx(), &admin.CancelInflightRequestRequest{ID: id})
+	return grpcutil.ScrubGRPC(err)
+}
+
+// GetClusterDefaults returns the cluster's defaults for pipeline specs.
+func (c APIClient) GetClusterDefaults() (*admin.ClusterDefaults, error) {
+	defaults, err := c.AdminAPIClient.GetClusterDefaults(c.Ctx(), &types.Empty{})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return defaults, nil
+}
+
+// SetClusterDefaults replaces the cluster's defaults for pipeline specs, or
+// clears them if defaults is nil.
+func (c APIClient) SetClusterDefaults(defaults *admin.ClusterDefaults) error {
+	_, err := c.AdminAPIClient.SetClusterDefaults(c.Ctx(), &admin.SetClusterDefaultsRequest{Defaults: defaults})
+	return grpcutil.ScrubGRPC(err)
+}
+
+// GetUsageReport returns the storage, compute and egress used by each repo,
+// pipeline and user, for the jobs that finished in [start, end). A zero end
+// is now, and a zero start is 30 days before end.
+func (c APIClient) GetUsageReport(start, end time.Time) (*admin.UsageReport, error) {
+	request := &admin.GetUsageReportRequest{}
+	var err error
+	if !start.IsZero() {
+		if request.Start, err = types.TimestampProto(start); err != nil {
+			return nil, err
+		}
+	}
+	if !end.IsZero() {
+		if request.End, err = types.TimestampProto(end); err != nil {
+			return nil, err
+		}
+	}
+	report, err := c.AdminAPIClient.GetUsageReport(c.Ctx(), request)
+	return report, grpcutil.ScrubGRPC(err)
+}
+
+// GetCapacityReport returns the cluster's storage usage, and when it's
+// projected to run out from its growth over window. A zero window is 7 days.
+func (c APIClient) GetCapacityReport(window time.Duration) (*admin.CapacityReport, error) {
+	request := &admin.GetCapacityReportRequest{}
+	if window != 0 {
+		request.Window = types.DurationProto(window)
+	}
+	report, err := c.AdminAPIClient.GetCapacityReport(c.Ctx(), request)
+	return report, grpcutil.ScrubGRPC(err)
+}
+
+// SubscribeEvents calls cb with each change to the commits, jobs and
+// pipelines that request selects, until cb returns an error or c's context is
+// done. Returning errutil.ErrBreak from cb stops the subscription without an
+// error.
+func (c APIClient) SubscribeEvents(request *admin.SubscribeEventsRequest, cb func(*admin.Event) error) error {
+	ctx, cf := context.WithCancel(c.Ctx())
+	defer cf()
+	client, err := c.AdminAPIClient.SubscribeEvents(ctx, request)
+	if err != nil {
+		return grpcutil.ScrubGRPC(err)
+	}
+	return grpcutil.ScrubGRPC(clientsdk.ForEachEvent(client, cb))
+}
+
+// ValidateConfiguration returns the problems with the Helm values that a
+// cluster would be deployed with. Unless offline is set, pachd also checks
+// that it can reach the object storage and identity providers they configure.
+func (c APIClient) ValidateConfiguration(values []byte, offline bool) ([]*admin.ConfigurationProblem, error) {
+	response, err := c.AdminAPIClient.ValidateConfiguration(c.Ctx(), &admin.ValidateConfigurationRequest{
+		Values:  values,
+		Offline: offline,
+	})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return response.Problems, nil
+}
+
+// InspectUpgrade returns the migrations applied to the cluster's state, the
+// progress of its latest upgrade, and the pachd replicas that are running.
+func (c APIClient) InspectUpgrade() (*admin.InspectUpgradeResponse, error) {
+	response, err := c.AdminAPIClient.InspectUpgrade(c.Ctx(), &admin.InspectUpgradeRequest{})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return response, nil
+}
+
+// Backup takes a backup of the cluster's metadata to location, which
+// defaults to pachd's BACKUP_LOCATION if it's empty.
+func (c APIClient) Backup(location string) (*admin.BackupInfo, error) {
+	info, err := c.AdminAPIClient.Backup(c.Ctx(), &admin.BackupRequest{Location: location})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return info, nil
+}
+
+// ListBackups returns the backups at location, newest first.
+func (c APIClient) ListBackups(location string) ([]*admin.BackupInfo, error) {
+	ctx, cf := context.WithCancel(c.Ctx())
+	defer cf()
+	client, err := c.AdminAPIClient.ListBackups(ctx, &admin.ListBackupsRequest{Location: location})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	backupInfos, err := clientsdk.ListBackupInfo(client)
+	return backupInfos, grpcutil.ScrubGRPC(err)
+}
+
+// VerifyBackup checks that a backup at location, or the latest one if id is
+// empty, is complete. If deep is set, it also checks the backup's contents.
+func (c APIClient) VerifyBackup(location, id string, deep bool) (*admin.VerifyBackupResponse, error) {
+	response, err := c.AdminAPIClient.VerifyBackup(c.Ctx(), &admin.VerifyBackupRequest{
+		Location: location,
+		ID:       id,
+		Deep:     deep,
+	})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return response, nil
+}
+
+// Restore replaces the cluster's metadata with a backup at location, or the
+// latest one if id is empty. Unless force is set, the cluster must not have
+// any repos.
+func (c APIClient) Restore(location, id string, force bool) (*admin.RestoreResponse, error) {
+	response, err := c.AdminAPIClient.Restore(c.Ctx(), &admin.RestoreRequest{
+		Location: location,
+		ID:       id,
+		Force:    force,
+	})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return response, nil
+}
+
+// SetReadOnly makes the cluster read-only, so that its state can't change
+// while it's backed up or migrated, or makes it writable again. reason is
+// included in the errors of the requests that are rejected.
+func (c APIClient) SetReadOnly(readOnly bool, reason string) (*admin.ReadOnlyState, error) {
+	state, err := c.AdminAPIClient.SetReadOnly(c.Ctx(), &admin.SetReadOnlyRequest{
+		ReadOnly: readOnly,
+		Reason:   reason,
+	})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return state, nil
+}
+
+// InspectReadOnly returns whether the cluster is read-only, and why.
+func (c APIClient) InspectReadOnly() (*admin.ReadOnlyState, error) {
+	state, err := c.AdminAPIClient.InspectReadOnly(c.Ctx(), &types.Empty{})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return state, nil
+}
@@ -68,3 +68,25 @@ func (c APIClient) ModifyRepoRoleBinding(repo, principal string, roles []string)
 	}
 	return nil
 }
+
+func (c APIClient) GetProjectRoleBinding(project string) (*auth.RoleBinding, error) {
+	resp, err := c.GetRoleBinding(c.Ctx(), &auth.GetRoleBindingRequest{
+		Resource: &auth.Resource{Type: auth.ResourceType_PROJECT, Name: project},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Binding, nil
+}
+
+func (c APIClient) ModifyProjectRoleBinding(project, principal string, roles []string) error {
+	_, err := c.ModifyRoleBinding(c.Ctx(), &auth.ModifyRoleBindingRequest{
+		Resource:  &auth.Resource{Type: auth.ResourceType_PROJECT, Name: project},
+		Principal: principal,
+		Roles:     roles,
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/pfs"
 	"github.com/pachyderm/pachyderm/v2/src/pps"
 	"github.com/pachyderm/pachyderm/v2/src/proxy"
+	"github.com/pachyderm/pachyderm/v2/src/search"
 	"github.com/pachyderm/pachyderm/v2/src/transaction"
 	"github.com/pachyderm/pachyderm/v2/src/version/versionpb"
 )
@@ -84,6 +85,9 @@ type DebugClient debug.DebugClient
 // ProxyClient is an alias of proxy.APIClient
 type ProxyClient proxy.APIClient
 
+// SearchAPIClient is an alias of search.APIClient
+type SearchAPIClient search.APIClient
+
 // An APIClient is a wrapper around pfs, pps and block APIClients.
 type APIClient struct {
 	PfsAPIClient
@@ -95,6 +99,7 @@ type APIClient struct {
 	TransactionAPIClient
 	DebugClient
 	ProxyClient
+	SearchAPIClient
 	Enterprise enterprise.APIClient // not embedded--method name conflicts with AuthAPIClient
 	License    license.APIClient
 
@@ -107,6 +112,10 @@ type APIClient struct {
 	// gzipCompress configures whether to enable compression by default for all calls
 	gzipCompress bool
 
+	// streamCompression configures the compression of file transfer streams,
+	// nil if they aren't compressed
+	streamCompression *streamCompression
+
 	// clientConn is a cached grpc connection to 'addr'
 	clientConn *grpc.ClientConn
 
@@ -156,6 +165,7 @@ const DefaultDialTimeout = 30 * time.Second
 type clientSettings struct {
 	maxConcurrentStreams int
 	gzipCompress         bool
+	streamCompressors    []string
 	dialTimeout          time.Duration
 	caCerts              *x509.CertPool
 	unaryInterceptors    []grpc.UnaryClientInterceptor
@@ -199,6 +209,9 @@ func NewFromPachdAddress(pachdAddress *grpcutil.PachdAddress, options ...Option)
 		caCerts:      settings.caCerts,
 		gzipCompress: settings.gzipCompress,
 	}
+	if len(settings.streamCompressors) > 0 {
+		c.streamCompression = &streamCompression{preferred: settings.streamCompressors}
+	}
 	if err := c.connect(settings.dialTimeout, settings.unaryInterceptors, settings.streamInterceptors); err != nil {
 		return nil, err
 	}
@@ -286,6 +299,16 @@ func WithGZIPCompression() Option {
 	}
 }
 
+// WithStreamCompression compresses file transfer streams (PutFile, GetFile
+// and their variants) with the first of 'compressors' that pachd allows. If
+// pachd allows none of them, the streams aren't compressed.
+func WithStreamCompression(compressors ...string) Option {
+	return func(settings *clientSettings) error {
+		settings.streamCompressors = compressors
+		return nil
+	}
+}
+
 // WithAdditionalPachdCert instructs the New* functions to additionally trust
 // the signed cert mounted in Pachd's cert volume. This is used by Pachd
 // when connecting to itself (if no cert is present, the clients cert pool
@@ -814,6 +837,7 @@ func (c *APIClient) connect(timeout time.Duration, unaryInterceptors []grpc.Unar
 	c.TransactionAPIClient = transaction.NewAPIClient(clientConn)
 	c.DebugClient = debug.NewDebugClient(clientConn)
 	c.ProxyClient = proxy.NewAPIClient(clientConn)
+	c.SearchAPIClient = search.NewAPIClient(clientConn)
 	c.clientConn = clientConn
 	c.healthClient = grpc_health_v1.NewHealthClient(clientConn)
 	return nil
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"sync"
+
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc"
+)
+
+// streamCompression negotiates the compressor used for file transfer streams
+// with pachd the first time one is opened.
+type streamCompression struct {
+	preferred []string
+
+	once sync.Once
+	name string
+}
+
+// fileStreamOptions returns the call options for file transfer streams.
+func (c APIClient) fileStreamOptions() []grpc.CallOption {
+	sc := c.streamCompression
+	if sc == nil {
+		return nil
+	}
+	sc.once.Do(func() {
+		info, err := c.AdminAPIClient.InspectCluster(c.Ctx(), &types.Empty{})
+		if err != nil {
+			// pachd will report the error on the next call, transfers
+			// just aren't compressed
+			return
+		}
+		sc.name = negotiateCompressor(sc.preferred, info.StreamCompressors)
+	})
+	if sc.name == "" {
+		return nil
+	}
+	return []grpc.CallOption{grpc.UseCompressor(sc.name)}
+}
+
+// negotiateCompressor returns the first of the preferred compressors that's
+// allowed, or "" if none of them are.
+func negotiateCompressor(preferred, allowed []string) string {
+	for _, p := range preferred {
+		for _, a := range allowed {
+			if p == a {
+				return p
+			}
+		}
+	}
+	return ""
+}
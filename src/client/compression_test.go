@@ -0,0 +1,15 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestNegotiateCompressor(t *testing.T) {
+	require.Equal(t, "zstd", negotiateCompressor([]string{"zstd", "gzip"}, []string{"gzip", "zstd"}))
+	require.Equal(t, "gzip", negotiateCompressor([]string{"zstd", "gzip"}, []string{"gzip"}))
+	require.Equal(t, "", negotiateCompressor([]string{"zstd"}, []string{"gzip"}))
+	// old clusters don't report any compressors
+	require.Equal(t, "", negotiateCompressor([]string{"zstd", "gzip"}, nil))
+}
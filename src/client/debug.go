@@ -4,8 +4,12 @@ package client
 import (
 	"context"
 	"io"
+	"time"
+
+	"github.com/gogo/protobuf/types"
 
 	"github.com/pachyderm/pachyderm/v2/src/debug"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
 )
 
@@ -36,19 +40,50 @@ func (c APIClient) Binary(filter *debug.Filter, w io.Writer) (retErr error) {
 	return grpcutil.WriteFromStreamingBytesClient(binaryC, w)
 }
 
-// Dump collects a standard set of debugging information.
-func (c APIClient) Dump(filter *debug.Filter, limit int64, w io.Writer) (retErr error) {
+// Dump collects a standard set of debugging information. If collectors are
+// given, only the information they collect is.
+func (c APIClient) Dump(filter *debug.Filter, limit int64, w io.Writer, collectors ...debug.Collector) (retErr error) {
 	defer func() {
 		retErr = grpcutil.ScrubGRPC(retErr)
 	}()
 	ctx, cf := context.WithCancel(c.Ctx())
 	defer cf()
 	dumpC, err := c.DebugClient.Dump(ctx, &debug.DumpRequest{
-		Filter: filter,
-		Limit:  limit,
+		Filter:     filter,
+		Limit:      limit,
+		Collectors: collectors,
 	})
 	if err != nil {
 		return err
 	}
 	return grpcutil.WriteFromStreamingBytesClient(dumpC, w)
 }
+
+// GetProfileHistory collects the profiles, "cpu" and "heap", that pachd and
+// the workers captured continuously between start and end. A zero start or
+// end leaves that end of the range open, and no profiles selects all of them.
+func (c APIClient) GetProfileHistory(filter *debug.Filter, profiles []string, start, end time.Time, w io.Writer) (retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	request := &debug.ProfileHistoryRequest{
+		Filter:   filter,
+		Profiles: profiles,
+	}
+	var err error
+	if !start.IsZero() {
+		if request.Start, err = types.TimestampProto(start); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	if !end.IsZero() {
+		if request.End, err = types.TimestampProto(end); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	historyC, err := c.DebugClient.GetProfileHistory(c.Ctx(), request)
+	if err != nil {
+		return err
+	}
+	return grpcutil.WriteFromStreamingBytesClient(historyC, w)
+}
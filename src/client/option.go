@@ -4,8 +4,9 @@ package client
 import "github.com/pachyderm/pachyderm/v2/src/pfs"
 
 type putFileConfig struct {
-	datum  string
-	append bool
+	datum       string
+	append      bool
+	contentType string
 }
 
 // PutFileOption configures a PutFile call.
@@ -25,6 +26,14 @@ func WithDatumPutFile(datum string) PutFileOption {
 	}
 }
 
+// WithContentTypePutFile configures the PutFile call to set the content type
+// of the files, rather than having it detected from their content.
+func WithContentTypePutFile(contentType string) PutFileOption {
+	return func(pf *putFileConfig) {
+		pf.contentType = contentType
+	}
+}
+
 type deleteFileConfig struct {
 	datum     string
 	recursive bool
@@ -74,8 +83,74 @@ func WithDatumGetFile(datum string) GetFileOption {
 	}
 }
 
+// WithOffset sets the byte offset the get file request starts reading from
 func WithOffset(offset int64) GetFileOption {
 	return func(gf *pfs.GetFileRequest) {
 		gf.Offset = offset
 	}
 }
+
+// WithSizeBytes limits the get file request to at most size bytes
+func WithSizeBytes(size int64) GetFileOption {
+	return func(gf *pfs.GetFileRequest) {
+		gf.SizeBytes = size
+	}
+}
+
+// WithSample configures the get file request to return a random sample of
+// the file's lines, each of which is kept with probability fraction. Requests
+// with the same seed return the same sample.
+func WithSample(fraction float64, seed int64) GetFileOption {
+	return func(gf *pfs.GetFileRequest) {
+		gf.SampleFraction = fraction
+		gf.SampleSeed = seed
+	}
+}
+
+// PreviewFileOption configures a PreviewFile call.
+type PreviewFileOption func(*pfs.PreviewFileRequest)
+
+// WithPreviewFormat sets the format of the previewed file, instead of
+// inferring it from the file's extension.
+func WithPreviewFormat(format pfs.FileFormat) PreviewFileOption {
+	return func(pf *pfs.PreviewFileRequest) {
+		pf.Format = format
+	}
+}
+
+// WithPreviewRows sets the number of rows to preview.
+func WithPreviewRows(rows int64) PreviewFileOption {
+	return func(pf *pfs.PreviewFileRequest) {
+		pf.Rows = rows
+	}
+}
+
+// WithPreviewNoHeader configures the preview of a CSV file to treat its first
+// record as a row rather than the names of its columns.
+func WithPreviewNoHeader() PreviewFileOption {
+	return func(pf *pfs.PreviewFileRequest) {
+		pf.NoHeader = true
+	}
+}
+
+// ListFileOption configures a ListFile call.
+type ListFileOption func(*pfs.ListFileRequest)
+
+// WithContentTypeListFile configures the ListFile call to only return
+// regular files of a content type, such as "text/csv" or "image/*".
+func WithContentTypeListFile(contentType string) ListFileOption {
+	return func(lf *pfs.ListFileRequest) {
+		lf.ContentType = contentType
+	}
+}
+
+// GlobFileOption configures a GlobFile call.
+type GlobFileOption func(*pfs.GlobFileRequest)
+
+// WithContentTypeGlobFile configures the GlobFile call to only return
+// regular files of a content type, such as "text/csv" or "image/*".
+func WithContentTypeGlobFile(contentType string) GlobFileOption {
+	return func(gf *pfs.GlobFileRequest) {
+		gf.ContentType = contentType
+	}
+}
@@ -22,6 +22,11 @@ func NewRepo(repoName string) *pfs.Repo {
 	return &pfs.Repo{Name: repoName, Type: pfs.UserRepoType}
 }
 
+// NewProject creates a pfs.Project.
+func NewProject(projectName string) *pfs.Project {
+	return &pfs.Project{Name: projectName}
+}
+
 // NewSystemRepo creates a pfs.Repo of the given type
 func NewSystemRepo(repoName string, repoType string) *pfs.Repo {
 	return &pfs.Repo{Name: repoName, Type: repoType}
@@ -111,6 +116,23 @@ func (c APIClient) ListRepoByType(repoType string) (_ []*pfs.RepoInfo, retErr er
 	return clientsdk.ListRepoInfo(client)
 }
 
+// ListProjectRepo returns info about the user Repos in a project.
+func (c APIClient) ListProjectRepo(projectName string) (_ []*pfs.RepoInfo, retErr error) {
+	ctx, cf := context.WithCancel(c.Ctx())
+	defer cf()
+	client, err := c.PfsAPIClient.ListRepo(
+		ctx,
+		&pfs.ListRepoRequest{
+			Type:    pfs.UserRepoType,
+			Project: NewProject(projectName),
+		},
+	)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return clientsdk.ListRepoInfo(client)
+}
+
 // DeleteRepo deletes a repo and reclaims the storage space it was using. Note
 // that as of 1.0 we do not reclaim the blocks that the Repo was referencing,
 // this is because they may also be referenced by other Repos and deleting them
@@ -130,6 +152,31 @@ func (c APIClient) DeleteRepo(repoName string, force bool) error {
 	return grpcutil.ScrubGRPC(err)
 }
 
+// CordonRepo stops new commits from being started in a repo until it's
+// uncordoned with UncordonRepo. reason is included in the errors of the
+// commits that are rejected.
+func (c APIClient) CordonRepo(repoName string, reason string) error {
+	_, err := c.PfsAPIClient.CordonRepo(
+		c.Ctx(),
+		&pfs.CordonRepoRequest{
+			Repo:   NewRepo(repoName),
+			Reason: reason,
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// UncordonRepo lets new commits be started in a cordoned repo again.
+func (c APIClient) UncordonRepo(repoName string) error {
+	_, err := c.PfsAPIClient.UncordonRepo(
+		c.Ctx(),
+		&pfs.UncordonRepoRequest{
+			Repo: NewRepo(repoName),
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
 // StartCommit begins the process of committing data to a Repo. Once started
 // you can write to the Commit with PutFile and when all the data has been
 // written you must finish the Commit with FinishCommit. NOTE, data is not
@@ -504,6 +551,59 @@ func (c APIClient) ClearCommit(repoName string, branchName string, commitID stri
 	return err
 }
 
+// CheckpointCommit marks the files written to an open commit so far, so that
+// they can be read before the commit is finished.
+func (c APIClient) CheckpointCommit(repoName string, branchName string, commitID string) (_ *pfs.CheckpointInfo, retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	checkpointInfo, err := c.PfsAPIClient.CheckpointCommit(
+		c.Ctx(),
+		&pfs.CheckpointCommitRequest{
+			Commit: NewCommit(repoName, branchName, commitID),
+		},
+	)
+	return checkpointInfo, err
+}
+
+// SubscribeCheckpoint calls cb with the checkpoints of a commit made after
+// checkpoint from, as they're made, until the commit is finished. The files
+// as of a checkpoint can be read through the commit returned by
+// NewCheckpointCommit.
+func (c APIClient) SubscribeCheckpoint(commit *pfs.Commit, from int64, cb func(*pfs.CheckpointInfo) error) (retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	client, err := c.PfsAPIClient.SubscribeCheckpoint(c.Ctx(), &pfs.SubscribeCheckpointRequest{
+		Commit: commit,
+		From:   from,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		checkpointInfo, err := client.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := cb(checkpointInfo); err != nil {
+			if errors.Is(err, errutil.ErrBreak) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// NewCheckpointCommit returns the virtual commit with the files of a commit as
+// of a checkpoint.
+func NewCheckpointCommit(checkpointInfo *pfs.CheckpointInfo) *pfs.Commit {
+	return NewRepo(FileSetsRepoName).NewCommit("", checkpointInfo.FileSetId)
+}
+
 // Fsck performs checks on pfs. Errors that are encountered will be passed
 // onError. These aren't errors in the traditional sense, in that they don't
 // prevent the completion of fsck. Errors that do prevent completion will be
@@ -553,3 +653,292 @@ func (c APIClient) FsckFastExit() error {
 		}
 	}
 }
+
+// SetSchema sets the schema that files in a repo matching pathPattern are
+// validated against when commits to the repo are finished. Commits whose
+// files don't conform to the schema finish with an error.
+func (c APIClient) SetSchema(repoName string, pathPattern string, schema *pfs.Schema) (*pfs.SchemaInfo, error) {
+	schemaInfo, err := c.PfsAPIClient.SetSchema(c.Ctx(), &pfs.SetSchemaRequest{
+		Repo:        NewRepo(repoName),
+		PathPattern: pathPattern,
+		Schema:      schema,
+	})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return schemaInfo, nil
+}
+
+// ListSchema returns the schemas of a repo.
+func (c APIClient) ListSchema(repoName string) ([]*pfs.SchemaInfo, error) {
+	ctx, cf := context.WithCancel(c.Ctx())
+	defer cf()
+	client, err := c.PfsAPIClient.ListSchema(ctx, &pfs.ListSchemaRequest{Repo: NewRepo(repoName)})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	schemaInfos, err := clientsdk.ListSchemaInfo(client)
+	return schemaInfos, grpcutil.ScrubGRPC(err)
+}
+
+// DeleteSchema deletes the schema for pathPattern in a repo.
+func (c APIClient) DeleteSchema(repoName string, pathPattern string) error {
+	_, err := c.PfsAPIClient.DeleteSchema(c.Ctx(), &pfs.DeleteSchemaRequest{
+		Repo:        NewRepo(repoName),
+		PathPattern: pathPattern,
+	})
+	return grpcutil.ScrubGRPC(err)
+}
+
+// CreateProject creates a new project, whose repos and pipelines can be
+// managed together.
+func (c APIClient) CreateProject(projectName string, description string) error {
+	_, err := c.PfsAPIClient.CreateProject(
+		c.Ctx(),
+		&pfs.CreateProjectRequest{
+			Project:     NewProject(projectName),
+			Description: description,
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// CreateProjectRepo creates a new Repo object in a project.
+func (c APIClient) CreateProjectRepo(projectName string, repoName string) error {
+	_, err := c.PfsAPIClient.CreateRepo(
+		c.Ctx(),
+		&pfs.CreateRepoRequest{
+			Repo:    NewRepo(repoName),
+			Project: NewProject(projectName),
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// InspectProject returns info about a project.
+func (c APIClient) InspectProject(projectName string) (*pfs.ProjectInfo, error) {
+	projectInfo, err := c.PfsAPIClient.InspectProject(
+		c.Ctx(),
+		&pfs.InspectProjectRequest{
+			Project: NewProject(projectName),
+		},
+	)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return projectInfo, nil
+}
+
+// ListProject returns info about all projects, including the default
+// project.
+func (c APIClient) ListProject() ([]*pfs.ProjectInfo, error) {
+	ctx, cf := context.WithCancel(c.Ctx())
+	defer cf()
+	client, err := c.PfsAPIClient.ListProject(ctx, &pfs.ListProjectRequest{})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	projectInfos, err := clientsdk.ListProjectInfo(client)
+	return projectInfos, grpcutil.ScrubGRPC(err)
+}
+
+// DeleteProject deletes a project. A project can only be deleted once its
+// repos and pipelines have been deleted.
+func (c APIClient) DeleteProject(projectName string) error {
+	_, err := c.PfsAPIClient.DeleteProject(
+		c.Ctx(),
+		&pfs.DeleteProjectRequest{
+			Project: NewProject(projectName),
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// InspectCommitSchema waits for a commit to finish, and returns the versions
+// of its repo's schemas it was validated against and whether its files
+// conform to them.
+func (c APIClient) InspectCommitSchema(repoName string, branchName string, commitID string) (*pfs.CommitSchemaInfo, error) {
+	commitSchemaInfo, err := c.PfsAPIClient.InspectCommitSchema(c.Ctx(), &pfs.InspectCommitSchemaRequest{
+		Commit: NewCommit(repoName, branchName, commitID),
+	})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return commitSchemaInfo, nil
+}
+
+// SetRetentionPolicy sets the retention policy of a branch, or of all the
+// branches of a repo that don't have their own policy if branchName is empty.
+// Commits that aren't kept by the policy are squashed.
+func (c APIClient) SetRetentionPolicy(repoName string, branchName string, policy *pfs.RetentionPolicy) (*pfs.RetentionPolicyInfo, error) {
+	policyInfo, err := c.PfsAPIClient.SetRetentionPolicy(c.Ctx(), &pfs.SetRetentionPolicyRequest{
+		Repo:   NewRepo(repoName),
+		Branch: branchName,
+		Policy: policy,
+	})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return policyInfo, nil
+}
+
+// ListRetentionPolicy returns the retention policies of a repo.
+func (c APIClient) ListRetentionPolicy(repoName string) ([]*pfs.RetentionPolicyInfo, error) {
+	ctx, cf := context.WithCancel(c.Ctx())
+	defer cf()
+	client, err := c.PfsAPIClient.ListRetentionPolicy(ctx, &pfs.ListRetentionPolicyRequest{Repo: NewRepo(repoName)})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	policyInfos, err := clientsdk.ListRetentionPolicyInfo(client)
+	return policyInfos, grpcutil.ScrubGRPC(err)
+}
+
+// DeleteRetentionPolicy deletes the retention policy of a branch, or of a
+// repo if branchName is empty.
+func (c APIClient) DeleteRetentionPolicy(repoName string, branchName string) error {
+	_, err := c.PfsAPIClient.DeleteRetentionPolicy(c.Ctx(), &pfs.DeleteRetentionPolicyRequest{
+		Repo:   NewRepo(repoName),
+		Branch: branchName,
+	})
+	return grpcutil.ScrubGRPC(err)
+}
+
+// ApplyRetentionPolicy squashes the commits of a repo that aren't kept by its
+// retention policies, and returns them. If dryRun is set, the commits are
+// only returned.
+func (c APIClient) ApplyRetentionPolicy(repoName string, dryRun bool) ([]*pfs.Commit, error) {
+	resp, err := c.PfsAPIClient.ApplyRetentionPolicy(c.Ctx(), &pfs.ApplyRetentionPolicyRequest{
+		Repo:   NewRepo(repoName),
+		DryRun: dryRun,
+	})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return resp.Expired, nil
+}
+
+// EraseFile removes the file at path, or the directory at path and
+// everything under it, from every commit of a repo, along with the stored
+// content of the files. The reason is recorded in the returned audit record.
+func (c APIClient) EraseFile(repoName string, path string, reason string) (*pfs.ErasureInfo, error) {
+	erasure, err := c.PfsAPIClient.EraseFile(c.Ctx(), &pfs.EraseFileRequest{
+		Repo:   NewRepo(repoName),
+		Path:   path,
+		Reason: reason,
+	})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return erasure, nil
+}
+
+// ListErasure returns the audit records of the files erased from a repo.
+func (c APIClient) ListErasure(repoName string) ([]*pfs.ErasureInfo, error) {
+	ctx, cf := context.WithCancel(c.Ctx())
+	defer cf()
+	client, err := c.PfsAPIClient.ListErasure(ctx, &pfs.ListErasureRequest{Repo: NewRepo(repoName)})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	erasures, err := clientsdk.ListErasureInfo(client)
+	return erasures, grpcutil.ScrubGRPC(err)
+}
+
+// VerifyErasure checks that the content of the files erased by an erasure
+// is no longer stored.
+func (c APIClient) VerifyErasure(repoName string, id string) (*pfs.VerifyErasureResponse, error) {
+	resp, err := c.PfsAPIClient.VerifyErasure(c.Ctx(), &pfs.VerifyErasureRequest{
+		Repo: NewRepo(repoName),
+		Id:   id,
+	})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return resp, nil
+}
+
+// SetValidationPolicy sets the policy that the files of a repo's commits are
+// validated against when they're finished.
+func (c APIClient) SetValidationPolicy(repoName string, policy *pfs.ValidationPolicy) (*pfs.ValidationPolicyInfo, error) {
+	policyInfo, err := c.PfsAPIClient.SetValidationPolicy(c.Ctx(), &pfs.SetValidationPolicyRequest{
+		Repo:   NewRepo(repoName),
+		Policy: policy,
+	})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return policyInfo, nil
+}
+
+// InspectValidationPolicy returns the validation policy of a repo.
+func (c APIClient) InspectValidationPolicy(repoName string) (*pfs.ValidationPolicyInfo, error) {
+	policyInfo, err := c.PfsAPIClient.InspectValidationPolicy(c.Ctx(), &pfs.InspectValidationPolicyRequest{
+		Repo: NewRepo(repoName),
+	})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return policyInfo, nil
+}
+
+// DeleteValidationPolicy deletes the validation policy of a repo.
+func (c APIClient) DeleteValidationPolicy(repoName string) error {
+	_, err := c.PfsAPIClient.DeleteValidationPolicy(c.Ctx(), &pfs.DeleteValidationPolicyRequest{
+		Repo: NewRepo(repoName),
+	})
+	return grpcutil.ScrubGRPC(err)
+}
+
+// CreateView creates a view, which combines branches of several repos into
+// a read-only repo named after the view.
+func (c APIClient) CreateView(view *pfs.View) (*pfs.ViewInfo, error) {
+	viewInfo, err := c.PfsAPIClient.CreateView(c.Ctx(), &pfs.CreateViewRequest{View: view})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return viewInfo, nil
+}
+
+// UpdateView replaces the sources and description of an existing view.
+func (c APIClient) UpdateView(view *pfs.View) (*pfs.ViewInfo, error) {
+	viewInfo, err := c.PfsAPIClient.CreateView(c.Ctx(), &pfs.CreateViewRequest{
+		View:   view,
+		Update: true,
+	})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return viewInfo, nil
+}
+
+// InspectView returns info about a view.
+func (c APIClient) InspectView(name string) (*pfs.ViewInfo, error) {
+	viewInfo, err := c.PfsAPIClient.InspectView(c.Ctx(), &pfs.InspectViewRequest{Name: name})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return viewInfo, nil
+}
+
+// ListView returns info about all views.
+func (c APIClient) ListView() ([]*pfs.ViewInfo, error) {
+	ctx, cf := context.WithCancel(c.Ctx())
+	defer cf()
+	client, err := c.PfsAPIClient.ListView(ctx, &pfs.ListViewRequest{})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	viewInfos, err := clientsdk.ListViewInfo(client)
+	return viewInfos, grpcutil.ScrubGRPC(err)
+}
+
+// DeleteView deletes a view and its repo. If force is set, the repo is
+// deleted even if other branches are provenant on it.
+func (c APIClient) DeleteView(name string, force bool) error {
+	_, err := c.PfsAPIClient.DeleteView(c.Ctx(), &pfs.DeleteViewRequest{
+		Name:  name,
+		Force: force,
+	})
+	return grpcutil.ScrubGRPC(err)
+}
@@ -95,7 +95,7 @@ func (c APIClient) NewModifyFileClient(commit *pfs.Commit) (_ *ModifyFileClient,
 	defer func() {
 		retErr = grpcutil.ScrubGRPC(retErr)
 	}()
-	client, err := c.PfsAPIClient.ModifyFile(c.Ctx())
+	client, err := c.PfsAPIClient.ModifyFile(c.Ctx(), c.fileStreamOptions()...)
 	if err != nil {
 		return nil, err
 	}
@@ -147,14 +147,16 @@ func (mfc *modifyFileCore) PutFile(path string, r io.Reader, opts ...PutFileOpti
 				Source: &pfs.AddFile_Raw{
 					Raw: &types.BytesValue{Value: data},
 				},
+				ContentType: config.contentType,
 			})
 		}); err != nil {
 			return err
 		}
 		if emptyFile {
 			return mfc.sendPutFile(&pfs.AddFile{
-				Path:  path,
-				Datum: config.datum,
+				Path:        path,
+				Datum:       config.datum,
+				ContentType: config.contentType,
 			})
 		}
 		return nil
@@ -207,8 +209,9 @@ func (mfc *modifyFileCore) PutFileTAR(r io.Reader, opts ...PutFileOption) error
 			}
 			if hdr.Size == 0 {
 				if err := mfc.sendPutFile(&pfs.AddFile{
-					Path:  p,
-					Datum: config.datum,
+					Path:        p,
+					Datum:       config.datum,
+					ContentType: config.contentType,
 				}); err != nil {
 					return err
 				}
@@ -220,6 +223,7 @@ func (mfc *modifyFileCore) PutFileTAR(r io.Reader, opts ...PutFileOption) error
 						Source: &pfs.AddFile_Raw{
 							Raw: &types.BytesValue{Value: data},
 						},
+						ContentType: config.contentType,
 					})
 				}); err != nil {
 					return err
@@ -253,6 +257,7 @@ func (mfc *modifyFileCore) PutFileURL(path, url string, recursive bool, opts ...
 					Recursive: recursive,
 				},
 			},
+			ContentType: config.contentType,
 		}
 		return mfc.sendPutFile(pf)
 	})
@@ -470,7 +475,7 @@ func (c APIClient) GetFile(commit *pfs.Commit, path string, w io.Writer, opts ..
 		opt(gf)
 	}
 
-	gfc, err := c.PfsAPIClient.GetFile(ctx, gf)
+	gfc, err := c.PfsAPIClient.GetFile(ctx, gf, c.fileStreamOptions()...)
 	if err != nil {
 		return err
 	}
@@ -498,7 +503,7 @@ func (c APIClient) getFileTar(commit *pfs.Commit, path string) (_ io.ReadCloser,
 		File: commit.NewFile(path),
 	}
 	ctx, cf := context.WithCancel(c.Ctx())
-	client, err := c.PfsAPIClient.GetFileTAR(ctx, req)
+	client, err := c.PfsAPIClient.GetFileTAR(ctx, req, c.fileStreamOptions()...)
 	if err != nil {
 		cf()
 		return nil, err
@@ -594,7 +599,7 @@ func (c APIClient) GetFileURL(commit *pfs.Commit, path, URL string) (retErr erro
 		File: commit.NewFile(path),
 		URL:  URL,
 	}
-	client, err := c.PfsAPIClient.GetFileTAR(c.Ctx(), req)
+	client, err := c.PfsAPIClient.GetFileTAR(c.Ctx(), req, c.fileStreamOptions()...)
 	if err != nil {
 		return err
 	}
@@ -616,17 +621,33 @@ func (c APIClient) InspectFile(commit *pfs.Commit, path string) (_ *pfs.FileInfo
 	return fi, err
 }
 
+// PreviewFile returns the first rows and the columns of a CSV, JSON or
+// Parquet file, without downloading the rest of it.
+func (c APIClient) PreviewFile(commit *pfs.Commit, path string, opts ...PreviewFileOption) (_ *pfs.PreviewFileResponse, retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	req := &pfs.PreviewFileRequest{
+		File: commit.NewFile(path),
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	return c.PfsAPIClient.PreviewFile(c.Ctx(), req)
+}
+
 // ListFile returns info about all files in a Commit under path, calling cb with each FileInfo.
-func (c APIClient) ListFile(commit *pfs.Commit, path string, cb func(fi *pfs.FileInfo) error) (retErr error) {
+func (c APIClient) ListFile(commit *pfs.Commit, path string, cb func(fi *pfs.FileInfo) error, opts ...ListFileOption) (retErr error) {
 	defer func() {
 		retErr = grpcutil.ScrubGRPC(retErr)
 	}()
-	client, err := c.PfsAPIClient.ListFile(
-		c.Ctx(),
-		&pfs.ListFileRequest{
-			File: commit.NewFile(path),
-		},
-	)
+	req := &pfs.ListFileRequest{
+		File: commit.NewFile(path),
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	client, err := c.PfsAPIClient.ListFile(c.Ctx(), req)
 	if err != nil {
 		return err
 	}
@@ -648,7 +669,7 @@ func (c APIClient) ListFile(commit *pfs.Commit, path string, cb func(fi *pfs.Fil
 }
 
 // ListFileAll returns info about all files in a Commit under path.
-func (c APIClient) ListFileAll(commit *pfs.Commit, path string) (_ []*pfs.FileInfo, retErr error) {
+func (c APIClient) ListFileAll(commit *pfs.Commit, path string, opts ...ListFileOption) (_ []*pfs.FileInfo, retErr error) {
 	defer func() {
 		retErr = grpcutil.ScrubGRPC(retErr)
 	}()
@@ -656,7 +677,7 @@ func (c APIClient) ListFileAll(commit *pfs.Commit, path string) (_ []*pfs.FileIn
 	if err := c.ListFile(commit, path, func(fi *pfs.FileInfo) error {
 		fis = append(fis, fi)
 		return nil
-	}); err != nil {
+	}, opts...); err != nil {
 		return nil, err
 	}
 	return fis, nil
@@ -665,17 +686,18 @@ func (c APIClient) ListFileAll(commit *pfs.Commit, path string) (_ []*pfs.FileIn
 // GlobFile returns files that match a given glob pattern in a given commit,
 // calling cb with each FileInfo. The pattern is documented here:
 // https://golang.org/pkg/path/filepath/#Match
-func (c APIClient) GlobFile(commit *pfs.Commit, pattern string, cb func(fi *pfs.FileInfo) error) (retErr error) {
+func (c APIClient) GlobFile(commit *pfs.Commit, pattern string, cb func(fi *pfs.FileInfo) error, opts ...GlobFileOption) (retErr error) {
 	defer func() {
 		retErr = grpcutil.ScrubGRPC(retErr)
 	}()
-	client, err := c.PfsAPIClient.GlobFile(
-		c.Ctx(),
-		&pfs.GlobFileRequest{
-			Commit:  commit,
-			Pattern: pattern,
-		},
-	)
+	req := &pfs.GlobFileRequest{
+		Commit:  commit,
+		Pattern: pattern,
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	client, err := c.PfsAPIClient.GlobFile(c.Ctx(), req)
 	if err != nil {
 		return err
 	}
@@ -698,7 +720,7 @@ func (c APIClient) GlobFile(commit *pfs.Commit, pattern string, cb func(fi *pfs.
 
 // GlobFileAll returns files that match a given glob pattern in a given commit.
 // The pattern is documented here: https://golang.org/pkg/path/filepath/#Match
-func (c APIClient) GlobFileAll(commit *pfs.Commit, pattern string) (_ []*pfs.FileInfo, retErr error) {
+func (c APIClient) GlobFileAll(commit *pfs.Commit, pattern string, opts ...GlobFileOption) (_ []*pfs.FileInfo, retErr error) {
 	defer func() {
 		retErr = grpcutil.ScrubGRPC(retErr)
 	}()
@@ -706,7 +728,7 @@ func (c APIClient) GlobFileAll(commit *pfs.Commit, pattern string) (_ []*pfs.Fil
 	if err := c.GlobFile(commit, pattern, func(fi *pfs.FileInfo) error {
 		fis = append(fis, fi)
 		return nil
-	}); err != nil {
+	}, opts...); err != nil {
 		return nil, err
 	}
 	return fis, nil
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+// DefaultUploadBlockSize is the size of the blocks of a resumable upload.
+const DefaultUploadBlockSize = 8 * 1024 * 1024
+
+// StartUpload starts a resumable upload of a file of sizeBytes bytes, split
+// into blocks of blockSizeBytes bytes whose hex-encoded SHA-256 checksums are
+// blockHashes.
+func (c APIClient) StartUpload(commit *pfs.Commit, path string, sizeBytes, blockSizeBytes int64, blockHashes []string, opts ...PutFileOption) (*pfs.UploadSession, error) {
+	config := &putFileConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	session, err := c.PfsAPIClient.StartUpload(c.Ctx(), &pfs.StartUploadRequest{
+		File:           commit.NewFile(path),
+		Datum:          config.datum,
+		Append:         config.append,
+		SizeBytes:      sizeBytes,
+		BlockSizeBytes: blockSizeBytes,
+		BlockHashes:    blockHashes,
+	})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return session, nil
+}
+
+// UploadBlock uploads the block at index of a resumable upload.
+func (c APIClient) UploadBlock(session string, index int64, data []byte) error {
+	_, err := c.PfsAPIClient.UploadBlock(c.Ctx(), &pfs.UploadBlockRequest{
+		Session: session,
+		Index:   index,
+		Data:    data,
+	})
+	return grpcutil.ScrubGRPC(err)
+}
+
+// InspectUpload returns a resumable upload, including the blocks that
+// haven't been uploaded yet.
+func (c APIClient) InspectUpload(session string) (*pfs.UploadSession, error) {
+	resp, err := c.PfsAPIClient.InspectUpload(c.Ctx(), &pfs.InspectUploadRequest{Session: session})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return resp, nil
+}
+
+// FinishUpload adds the file of a resumable upload to its commit.
+func (c APIClient) FinishUpload(session string) error {
+	_, err := c.PfsAPIClient.FinishUpload(c.Ctx(), &pfs.FinishUploadRequest{Session: session})
+	return grpcutil.ScrubGRPC(err)
+}
+
+// DeleteUpload abandons a resumable upload.
+func (c APIClient) DeleteUpload(session string) error {
+	_, err := c.PfsAPIClient.DeleteUpload(c.Ctx(), &pfs.DeleteUploadRequest{Session: session})
+	return grpcutil.ScrubGRPC(err)
+}
+
+// PutFileResumable puts a file of sizeBytes bytes into PFS from r, with a
+// resumable upload. The file is uploaded in blocks, parallelism at a time,
+// and failed blocks are retried. If session is set, the upload it names is
+// resumed, and only its missing blocks are uploaded. The ID of the upload is
+// returned even if it fails, so that it can be resumed.
+func (c APIClient) PutFileResumable(commit *pfs.Commit, path string, r io.ReaderAt, sizeBytes int64, session string, parallelism int, opts ...PutFileOption) (string, error) {
+	hashes, err := blockHashes(r, sizeBytes, DefaultUploadBlockSize)
+	if err != nil {
+		return session, err
+	}
+	var uploadSession *pfs.UploadSession
+	if session == "" {
+		uploadSession, err = c.StartUpload(commit, path, sizeBytes, DefaultUploadBlockSize, hashes, opts...)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		uploadSession, err = c.InspectUpload(session)
+		if err != nil {
+			return session, err
+		}
+		if !sameBlocks(uploadSession, sizeBytes, hashes) {
+			return session, errors.Errorf("the file has changed since upload %s started", session)
+		}
+	}
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	eg, ctx := errgroup.WithContext(c.Ctx())
+	bc := c.WithCtx(ctx)
+	indexes := make(chan int64)
+	for i := 0; i < parallelism; i++ {
+		eg.Go(func() error {
+			for index := range indexes {
+				offset := index * uploadSession.BlockSizeBytes
+				size := uploadSession.BlockSizeBytes
+				if offset+size > sizeBytes {
+					size = sizeBytes - offset
+				}
+				data := make([]byte, size)
+				if _, err := io.ReadFull(io.NewSectionReader(r, offset, size), data); err != nil {
+					return errors.EnsureStack(err)
+				}
+				if err := backoff.RetryUntilCancel(ctx, func() error {
+					return bc.UploadBlock(uploadSession.Id, index, data)
+				}, backoff.New60sBackOff(), nil); err != nil {
+					return errors.Wrapf(err, "upload block %d", index)
+				}
+			}
+			return nil
+		})
+	}
+	eg.Go(func() error {
+		defer close(indexes)
+		for _, index := range uploadSession.MissingBlocks {
+			select {
+			case indexes <- index:
+			case <-ctx.Done():
+				return errors.EnsureStack(ctx.Err())
+			}
+		}
+		return nil
+	})
+	if err := eg.Wait(); err != nil {
+		return uploadSession.Id, err
+	}
+	return uploadSession.Id, c.FinishUpload(uploadSession.Id)
+}
+
+// blockHashes returns the hex-encoded SHA-256 checksums of the blocks of the
+// sizeBytes bytes of r.
+func blockHashes(r io.ReaderAt, sizeBytes, blockSizeBytes int64) ([]string, error) {
+	var hashes []string
+	for offset := int64(0); offset < sizeBytes; offset += blockSizeBytes {
+		size := blockSizeBytes
+		if offset+size > sizeBytes {
+			size = sizeBytes - offset
+		}
+		h := sha256.New()
+		if _, err := io.Copy(h, io.NewSectionReader(r, offset, size)); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		hashes = append(hashes, hex.EncodeToString(h.Sum(nil)))
+	}
+	return hashes, nil
+}
+
+func sameBlocks(session *pfs.UploadSession, sizeBytes int64, hashes []string) bool {
+	if session.SizeBytes != sizeBytes || session.BlockSizeBytes != DefaultUploadBlockSize || len(session.BlockHashes) != len(hashes) {
+		return false
+	}
+	for i, hash := range hashes {
+		if session.BlockHashes[i] != hash {
+			return false
+		}
+	}
+	return true
+}
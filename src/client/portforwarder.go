@@ -9,7 +9,9 @@ import (
 	"math/rand"
 	"net/http"
 	"sync"
+	"time"
 
+	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
 	"github.com/pachyderm/pachyderm/v2/src/internal/config"
 
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
@@ -73,6 +75,26 @@ func NewPortForwarder(context *config.Context, namespace string) (*PortForwarder
 // Run starts the port forwarder. Returns after initialization is begun with
 // the locally bound port and any initialization errors.
 func (f *PortForwarder) Run(appName string, localPort, remotePort uint16, selectors ...string) (uint16, error) {
+	c, err := f.forward(appName, localPort, remotePort, selectors...)
+	if err != nil {
+		return 0, err
+	}
+	return c.localPort, nil
+}
+
+// forwarding is a port forwarded to a single pod.
+type forwarding struct {
+	pod       string
+	localPort uint16
+	// done is closed when the forwarding stops, because it was stopped or
+	// because its connection to the pod was lost.
+	done chan struct{}
+	stop chan struct{}
+}
+
+// forward forwards localPort to remotePort of a random pod of appName, and
+// returns once the local port is bound.
+func (f *PortForwarder) forward(appName string, localPort, remotePort uint16, selectors ...string) (*forwarding, error) {
 	podNameSelector := map[string]string{
 		"suite": "pachyderm",
 		"app":   appName,
@@ -90,10 +112,10 @@ func (f *PortForwarder) Run(appName string, localPort, remotePort uint16, select
 		},
 	})
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	if len(podList.Items) == 0 {
-		return 0, errors.Errorf("no pods found for app %s", appName)
+		return nil, errors.Errorf("no pods found for app %s", appName)
 	}
 
 	// Choose a random pod
@@ -108,7 +130,7 @@ func (f *PortForwarder) Run(appName string, localPort, remotePort uint16, select
 
 	transport, upgrader, err := spdy.RoundTripperFor(f.config)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", url)
@@ -121,43 +143,152 @@ func (f *PortForwarder) Run(appName string, localPort, remotePort uint16, select
 	f.stopChansLock.Lock()
 	if f.shutdown {
 		f.stopChansLock.Unlock()
-		return 0, errors.Errorf("port forwarder is shutdown")
+		return nil, errors.Errorf("port forwarder is shutdown")
 	}
 	f.stopChans = append(f.stopChans, stopChan)
 	f.stopChansLock.Unlock()
 
 	fw, err := portforward.New(dialer, ports, stopChan, readyChan, ioutil.Discard, f.logger)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
+	c := &forwarding{pod: podName, localPort: localPort, done: make(chan struct{}), stop: stopChan}
 	errChan := make(chan error, 1)
-	go func() { errChan <- fw.ForwardPorts() }()
+	go func() {
+		// ForwardPorts returns when stopChan is closed, or when the
+		// connection to the pod is lost
+		errChan <- fw.ForwardPorts()
+		close(c.done)
+	}()
 
 	select {
 	case err = <-errChan:
-		return 0, errors.Wrap(err, "port forwarding failed")
+		return nil, errors.Wrap(err, "port forwarding failed")
 	case <-fw.Ready:
 	}
 
 	// don't discover the locally bound port if we already know what it is
 	if localPort != 0 {
-		return localPort, nil
+		return c, nil
 	}
 
 	// discover the locally bound port if we don't know what it is
 	bindings, err := fw.GetPorts()
 	if err != nil {
-		return 0, errors.Wrap(err, "failed to fetch local bound ports")
+		return nil, errors.Wrap(err, "failed to fetch local bound ports")
 	}
 
 	for _, binding := range bindings {
 		if binding.Remote == remotePort {
-			return binding.Local, nil
+			c.localPort = binding.Local
+			return c, nil
 		}
 	}
 
-	return 0, errors.New("failed to discover local bound port")
+	return nil, errors.New("failed to discover local bound port")
+}
+
+// A Tunnel is a local port that's forwarded to a port of one of the pods of
+// an app.
+type Tunnel struct {
+	// Name is the name of the tunnel in a context's port forwarders, e.g.
+	// "pachd" or "s3g".
+	Name       string
+	App        string
+	LocalPort  uint16
+	RemotePort uint16
+}
+
+// A TunnelEvent is a change to the state of a tunnel kept up by
+// KeepTunnel.
+type TunnelEvent struct {
+	Tunnel Tunnel
+	// Up is true if the tunnel was established, and false if its
+	// connection was lost or re-establishing it failed.
+	Up bool
+	// Pod is the pod the tunnel forwards to, if it's up.
+	Pod string
+	// Reconnects is the number of times the tunnel was re-established.
+	Reconnects int
+	Err        error
+}
+
+// KeepTunnel forwards the tunnel's local port until ctx is done or the port
+// forwarder is closed, re-establishing it, possibly to a different pod, when
+// its connection is lost. It returns the locally bound port once the tunnel
+// is first established, or an error if it can't be. notify, if set, is called
+// each time the tunnel is re-established or lost after that.
+func (f *PortForwarder) KeepTunnel(ctx context.Context, t Tunnel, notify func(TunnelEvent)) (uint16, error) {
+	c, err := f.forward(t.App, t.LocalPort, t.RemotePort)
+	if err != nil {
+		return 0, err
+	}
+	// reconnect to the same local port, so that clients can keep using it
+	t.LocalPort = c.localPort
+	if notify == nil {
+		notify = func(TunnelEvent) {}
+	}
+	go func() {
+		var reconnects int
+		b := backoff.NewExponentialBackOff()
+		b.InitialInterval = time.Second
+		b.MaxInterval = 30 * time.Second
+		b.MaxElapsedTime = 0
+		for {
+			select {
+			case <-ctx.Done():
+				f.stopForwarding(c)
+				return
+			case <-c.done:
+			}
+			if f.isShutdown() || ctx.Err() != nil {
+				return
+			}
+			notify(TunnelEvent{Tunnel: t, Reconnects: reconnects, Err: errors.Errorf("lost connection to pod %s", c.pod)})
+			b.Reset()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(b.NextBackOff()):
+				}
+				if f.isShutdown() {
+					return
+				}
+				if c, err = f.forward(t.App, t.LocalPort, t.RemotePort); err == nil {
+					break
+				}
+				notify(TunnelEvent{Tunnel: t, Reconnects: reconnects, Err: err})
+			}
+			reconnects++
+			notify(TunnelEvent{Tunnel: t, Up: true, Pod: c.pod, Reconnects: reconnects})
+		}
+	}()
+	return t.LocalPort, nil
+}
+
+func (f *PortForwarder) isShutdown() bool {
+	f.stopChansLock.Lock()
+	defer f.stopChansLock.Unlock()
+	return f.shutdown
+}
+
+// stopForwarding stops c, unless the port forwarder was closed, which stops
+// it already.
+func (f *PortForwarder) stopForwarding(c *forwarding) {
+	f.stopChansLock.Lock()
+	defer f.stopChansLock.Unlock()
+	if f.shutdown {
+		return
+	}
+	for i, stopChan := range f.stopChans {
+		if stopChan == c.stop {
+			close(stopChan)
+			f.stopChans = append(f.stopChans[:i], f.stopChans[i+1:]...)
+			return
+		}
+	}
 }
 
 // RunForDaemon creates a port forwarder for the pachd daemon.
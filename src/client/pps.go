@@ -37,6 +37,9 @@ const (
 	PPSJobIDEnv = "PPS_JOB_ID"
 	// PPSSpecCommitEnv is the namespace in which pachyderm is deployed
 	PPSSpecCommitEnv = "PPS_SPEC_COMMIT"
+	// PPSWorkerPoolEnv is the env var that holds the key of the warm worker
+	// pool that started the worker, if it wasn't started by its pipeline.
+	PPSWorkerPoolEnv = "PPS_WORKER_POOL"
 	// PPSInputPrefix is the prefix of the path where datums are downloaded
 	// to.  A datum of an input named `XXX` is downloaded to `/pfs/XXX/`.
 	PPSInputPrefix = "/pfs"
@@ -212,6 +215,19 @@ func (c APIClient) InspectJob(pipelineName string, jobID string, details bool) (
 	return jobInfo, grpcutil.ScrubGRPC(err)
 }
 
+// InspectJobProfile returns the resource usage of a job's datums, including
+// its slowest datums. If slowest is 0, the 10 slowest are returned.
+func (c APIClient) InspectJobProfile(pipelineName string, jobID string, slowest int64) (*pps.JobProfile, error) {
+	profile, err := c.PpsAPIClient.InspectJobProfile(
+		c.Ctx(),
+		&pps.InspectJobProfileRequest{
+			Job:     NewJob(pipelineName, jobID),
+			Slowest: slowest,
+		},
+	)
+	return profile, grpcutil.ScrubGRPC(err)
+}
+
 // WaitJob is a blocking version on InspectJob that will wait
 // until the job has reached a terminal state.
 func (c APIClient) WaitJob(pipelineName string, jobID string, details bool) (_ *pps.JobInfo, retErr error) {
@@ -488,6 +504,18 @@ func (c APIClient) ListDatumInputAll(input *pps.Input) (_ []*pps.DatumInfo, retE
 	return dis, nil
 }
 
+// CountDatums returns the number of datums of a job in each state, without
+// listing them.
+func (c APIClient) CountDatums(pipelineName string, jobID string) (*pps.DatumCounts, error) {
+	counts, err := c.PpsAPIClient.CountDatums(
+		c.Ctx(),
+		&pps.CountDatumsRequest{
+			Job: NewJob(pipelineName, jobID),
+		},
+	)
+	return counts, grpcutil.ScrubGRPC(err)
+}
+
 func (c APIClient) listDatum(req *pps.ListDatumRequest, cb func(*pps.DatumInfo) error) (retErr error) {
 	ctx, cf := context.WithCancel(c.Ctx())
 	defer cf()
@@ -512,6 +540,146 @@ func (c APIClient) listDatum(req *pps.ListDatumRequest, cb func(*pps.DatumInfo)
 	}
 }
 
+// ListQuarantinedDatum returns info about the datums that were quarantined by
+// the most recent successful job of a pipeline.
+func (c APIClient) ListQuarantinedDatum(pipelineName string, cb func(*pps.DatumInfo) error) (retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	ctx, cf := context.WithCancel(c.Ctx())
+	defer cf()
+	client, err := c.PpsAPIClient.ListQuarantinedDatum(ctx, &pps.ListQuarantinedDatumRequest{
+		Pipeline: NewPipeline(pipelineName),
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		di, err := client.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := cb(di); err != nil {
+			if errors.Is(err, errutil.ErrBreak) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// RequeueQuarantinedDatums starts a new job for a pipeline that processes its
+// quarantined datums again.
+func (c APIClient) RequeueQuarantinedDatums(pipelineName string) (*pps.RequeueQuarantinedDatumsResponse, error) {
+	resp, err := c.PpsAPIClient.RequeueQuarantinedDatums(
+		c.Ctx(),
+		&pps.RequeueQuarantinedDatumsRequest{
+			Pipeline: NewPipeline(pipelineName),
+		},
+	)
+	return resp, grpcutil.ScrubGRPC(err)
+}
+
+// FinishCanary promotes a pipeline's canary, updating the pipeline to the
+// canary's spec, if promote is true, or rolls it back otherwise.
+func (c APIClient) FinishCanary(pipelineName string, promote bool) error {
+	_, err := c.PpsAPIClient.FinishCanary(
+		c.Ctx(),
+		&pps.FinishCanaryRequest{
+			Pipeline: NewPipeline(pipelineName),
+			Promote:  promote,
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// ListSLOStatus returns the current status of a pipeline's SLOs, or of all
+// pipelines' SLOs if pipelineName is "".
+func (c APIClient) ListSLOStatus(pipelineName string) ([]*pps.SLOStatus, error) {
+	request := &pps.ListSLOStatusRequest{}
+	if pipelineName != "" {
+		request.Pipeline = NewPipeline(pipelineName)
+	}
+	resp, err := c.PpsAPIClient.ListSLOStatus(c.Ctx(), request)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return resp.Statuses, nil
+}
+
+// InspectDatumCache returns info about a pipeline's datum cache.
+func (c APIClient) InspectDatumCache(pipelineName string) (*pps.DatumCacheInfo, error) {
+	info, err := c.PpsAPIClient.InspectDatumCache(
+		c.Ctx(),
+		&pps.InspectDatumCacheRequest{
+			Pipeline: NewPipeline(pipelineName),
+		},
+	)
+	return info, grpcutil.ScrubGRPC(err)
+}
+
+// ClearDatumCache removes all of the datum outputs a pipeline has cached, so
+// that its datums are processed again the next time they're in a job.
+func (c APIClient) ClearDatumCache(pipelineName string) error {
+	_, err := c.PpsAPIClient.ClearDatumCache(
+		c.Ctx(),
+		&pps.ClearDatumCacheRequest{
+			Pipeline: NewPipeline(pipelineName),
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// ListDatumProvenance returns info about the datums that produced a file, or
+// the files in a directory, in a pipeline's output commit.
+func (c APIClient) ListDatumProvenance(commit *pfs.Commit, path string, cb func(*pps.DatumInfo) error) (retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	ctx, cf := context.WithCancel(c.Ctx())
+	defer cf()
+	client, err := c.PpsAPIClient.ListDatumProvenance(ctx, &pps.ListDatumProvenanceRequest{
+		File: commit.NewFile(path),
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		di, err := client.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := cb(di); err != nil {
+			if errors.Is(err, errutil.ErrBreak) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// QueryLineage returns the commits upstream or downstream of commit, up to
+// maxDepth provenance hops from it (0 for any number) and maxCommits commits
+// (0 for the server's default).
+func (c APIClient) QueryLineage(commit *pfs.Commit, direction pps.QueryLineageRequest_Direction, maxDepth, maxCommits int64) (*pps.Lineage, error) {
+	lineage, err := c.PpsAPIClient.QueryLineage(c.Ctx(), &pps.QueryLineageRequest{
+		Commit:     commit,
+		Direction:  direction,
+		MaxDepth:   maxDepth,
+		MaxCommits: maxCommits,
+	})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return lineage, nil
+}
+
 // InspectDatum returns info about a single datum
 func (c APIClient) InspectDatum(pipelineName string, jobID string, datumID string) (*pps.DatumInfo, error) {
 	datumInfo, err := c.PpsAPIClient.InspectDatum(
@@ -533,19 +701,24 @@ func (c APIClient) InspectDatum(pipelineName string, jobID string, datumID strin
 // be fetched with 'Next()'. The log message received can be examined with
 // 'Message()', and any errors can be examined with 'Err()'.
 type LogsIter struct {
-	logsClient pps.API_GetLogsClient
+	logsClient interface{ Recv() (*pps.LogMessage, error) }
 	msg        *pps.LogMessage
 	err        error
 }
 
-// Next retrieves the next relevant log message from pachd
+// Next retrieves the next relevant log message from pachd, skipping
+// heartbeats.
 func (l *LogsIter) Next() bool {
-	if l.err != nil {
-		l.msg = nil
-		return false
+	for {
+		if l.err != nil {
+			l.msg = nil
+			return false
+		}
+		l.msg, l.err = l.logsClient.Recv()
+		if l.err != nil || !l.msg.Heartbeat {
+			return l.err == nil
+		}
 	}
-	l.msg, l.err = l.logsClient.Recv()
-	return l.err == nil
 }
 
 // Message returns the most recently retrieve log message (as an annotated log
@@ -623,8 +796,25 @@ func (c APIClient) getLogs(
 			ID:  datumID,
 		}
 	}
+	return c.GetLogsForRequest(&request)
+}
+
+// GetLogsForRequest gets the logs selected by request, which may set
+// server-side filters on the logs' messages and levels that the other
+// GetLogs methods don't.
+func (c APIClient) GetLogsForRequest(request *pps.GetLogsRequest) *LogsIter {
 	resp := &LogsIter{}
-	resp.logsClient, resp.err = c.PpsAPIClient.GetLogs(c.Ctx(), &request)
+	resp.logsClient, resp.err = c.PpsAPIClient.GetLogs(c.Ctx(), request)
+	resp.err = grpcutil.ScrubGRPC(resp.err)
+	return resp
+}
+
+// GetArchivedLogs gets the logs of a finished job from the cluster's log
+// archive, which keeps them after the job's pods and Loki's retention period
+// are gone.
+func (c APIClient) GetArchivedLogs(request *pps.GetArchivedLogsRequest) *LogsIter {
+	resp := &LogsIter{}
+	resp.logsClient, resp.err = c.PpsAPIClient.GetArchivedLogs(c.Ctx(), request)
 	resp.err = grpcutil.ScrubGRPC(resp.err)
 	return resp
 }
@@ -705,6 +895,21 @@ func (c APIClient) ListPipeline(details bool) ([]*pps.PipelineInfo, error) {
 	return clientsdk.ListPipelineInfo(client)
 }
 
+// GetDAG returns the repos and pipelines, and the edges data flows along
+// between them. If project isn't empty, the DAG is restricted to the
+// project's repos and pipelines, and the repos its pipelines read.
+func (c APIClient) GetDAG(project string) (*pps.DAG, error) {
+	request := &pps.GetDAGRequest{}
+	if project != "" {
+		request.Project = NewProject(project)
+	}
+	dag, err := c.PpsAPIClient.GetDAG(c.Ctx(), request)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return dag, nil
+}
+
 // ListPipelineHistory returns historical information about pipelines.
 // `pipeline` specifies which pipeline to return history about, if it's equal
 // to "" then ListPipelineHistory returns historical information about all
@@ -771,6 +976,47 @@ func (c APIClient) StopPipeline(name string) error {
 	return grpcutil.ScrubGRPC(err)
 }
 
+// CordonPipeline stops a pipeline's new jobs from starting until it's
+// uncordoned with UncordonPipeline. Unlike StopPipeline, the jobs that are
+// running keep running, and the jobs of new input commits are created and
+// wait to start.
+func (c APIClient) CordonPipeline(name string, reason string) error {
+	_, err := c.PpsAPIClient.CordonPipeline(
+		c.Ctx(),
+		&pps.CordonPipelineRequest{
+			Pipeline: NewPipeline(name),
+			Reason:   reason,
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// UncordonPipeline lets a cordoned pipeline's jobs start again.
+func (c APIClient) UncordonPipeline(name string) error {
+	_, err := c.PpsAPIClient.UncordonPipeline(
+		c.Ctx(),
+		&pps.UncordonPipelineRequest{
+			Pipeline: NewPipeline(name),
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// DrainPipeline stops a pipeline like StopPipeline, but lets its running jobs
+// finish the datums they're processing rather than killing them. The jobs
+// continue from where they stopped when the pipeline is started with
+// StartPipeline. InspectPipeline reports the progress of the drain.
+func (c APIClient) DrainPipeline(name string) error {
+	_, err := c.PpsAPIClient.StopPipeline(
+		c.Ctx(),
+		&pps.StopPipelineRequest{
+			Pipeline: NewPipeline(name),
+			Drain:    true,
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
 // RunPipeline runs a pipeline. It can be passed a list of commit provenance.
 // This will trigger a new job provenant on those commits, effectively running the pipeline on the data in those commits.
 func (c APIClient) RunPipeline(name string, provenance []*pfs.Commit, jobID string) error {
@@ -808,6 +1054,32 @@ func (c APIClient) CreateSecret(file []byte) error {
 	return grpcutil.ScrubGRPC(err)
 }
 
+// CreatePipelineSecret creates a secret scoped to a pipeline, or replaces the
+// data of the one that exists if update is set.
+func (c APIClient) CreatePipelineSecret(pipeline string, file []byte, update bool) error {
+	_, err := c.PpsAPIClient.CreateSecret(
+		c.Ctx(),
+		&pps.CreateSecretRequest{
+			File:     file,
+			Pipeline: NewPipeline(pipeline),
+			Update:   update,
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// ListPipelineSecret returns info about the secrets scoped to a pipeline.
+func (c APIClient) ListPipelineSecret(pipeline string) ([]*pps.SecretInfo, error) {
+	secretInfos, err := c.PpsAPIClient.ListSecret(
+		c.Ctx(),
+		&pps.ListSecretRequest{Pipeline: NewPipeline(pipeline)},
+	)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return secretInfos.SecretInfo, nil
+}
+
 // DeleteSecret deletes a secret from the cluster.
 func (c APIClient) DeleteSecret(secret string) error {
 	_, err := c.PpsAPIClient.DeleteSecret(
@@ -834,7 +1106,7 @@ func (c APIClient) InspectSecret(secret string) (*pps.SecretInfo, error) {
 func (c APIClient) ListSecret() ([]*pps.SecretInfo, error) {
 	secretInfos, err := c.PpsAPIClient.ListSecret(
 		c.Ctx(),
-		&types.Empty{},
+		&pps.ListSecretRequest{},
 	)
 	if err != nil {
 		return nil, grpcutil.ScrubGRPC(err)
@@ -0,0 +1,24 @@
+//nolint:wrapcheck
+package client
+
+import (
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/search"
+)
+
+// Search returns a page of the repos, files, pipelines and commits that match
+// query, restricted to types if it's non-empty. pageToken is the
+// NextPageToken of the previous page, or "" for the first page.
+func (c APIClient) Search(query string, mode search.SearchRequest_Mode, types []search.ResultType, pageSize int64, pageToken string) (*search.SearchResponse, error) {
+	response, err := c.SearchAPIClient.Search(c.Ctx(), &search.SearchRequest{
+		Query:     query,
+		Mode:      mode,
+		Types:     types,
+		PageSize:  pageSize,
+		PageToken: pageToken,
+	})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return response, nil
+}
@@ -15,6 +15,7 @@ import (
 	pfs_v2 "github.com/pachyderm/pachyderm/v2/src/pfs"
 	pps_v2 "github.com/pachyderm/pachyderm/v2/src/pps"
 	proxy "github.com/pachyderm/pachyderm/v2/src/proxy"
+	search_v2 "github.com/pachyderm/pachyderm/v2/src/search"
 	taskapi "github.com/pachyderm/pachyderm/v2/src/task"
 	transaction_v2 "github.com/pachyderm/pachyderm/v2/src/transaction"
 	versionpb_v2 "github.com/pachyderm/pachyderm/v2/src/version/versionpb"
@@ -29,10 +30,86 @@ func unsupportedError(name string) error {
 
 type unsupportedAdminBuilderClient struct{}
 
+func (c *unsupportedAdminBuilderClient) Backup(_ context.Context, _ *admin_v2.BackupRequest, opts ...grpc.CallOption) (*admin_v2.BackupInfo, error) {
+	return nil, unsupportedError("Backup")
+}
+
+func (c *unsupportedAdminBuilderClient) CancelInflightRequest(_ context.Context, _ *admin_v2.CancelInflightRequestRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("CancelInflightRequest")
+}
+
+func (c *unsupportedAdminBuilderClient) CreateWebhook(_ context.Context, _ *admin_v2.CreateWebhookRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("CreateWebhook")
+}
+
+func (c *unsupportedAdminBuilderClient) DeleteWebhook(_ context.Context, _ *admin_v2.DeleteWebhookRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("DeleteWebhook")
+}
+
+func (c *unsupportedAdminBuilderClient) GetCapacityReport(_ context.Context, _ *admin_v2.GetCapacityReportRequest, opts ...grpc.CallOption) (*admin_v2.CapacityReport, error) {
+	return nil, unsupportedError("GetCapacityReport")
+}
+
+func (c *unsupportedAdminBuilderClient) GetClusterDefaults(_ context.Context, _ *types.Empty, opts ...grpc.CallOption) (*admin_v2.ClusterDefaults, error) {
+	return nil, unsupportedError("GetClusterDefaults")
+}
+
+func (c *unsupportedAdminBuilderClient) GetUsageReport(_ context.Context, _ *admin_v2.GetUsageReportRequest, opts ...grpc.CallOption) (*admin_v2.UsageReport, error) {
+	return nil, unsupportedError("GetUsageReport")
+}
+
 func (c *unsupportedAdminBuilderClient) InspectCluster(_ context.Context, _ *types.Empty, opts ...grpc.CallOption) (*admin_v2.ClusterInfo, error) {
 	return nil, unsupportedError("InspectCluster")
 }
 
+func (c *unsupportedAdminBuilderClient) InspectReadOnly(_ context.Context, _ *types.Empty, opts ...grpc.CallOption) (*admin_v2.ReadOnlyState, error) {
+	return nil, unsupportedError("InspectReadOnly")
+}
+
+func (c *unsupportedAdminBuilderClient) InspectUpgrade(_ context.Context, _ *admin_v2.InspectUpgradeRequest, opts ...grpc.CallOption) (*admin_v2.InspectUpgradeResponse, error) {
+	return nil, unsupportedError("InspectUpgrade")
+}
+
+func (c *unsupportedAdminBuilderClient) InspectWebhook(_ context.Context, _ *admin_v2.InspectWebhookRequest, opts ...grpc.CallOption) (*admin_v2.WebhookInfo, error) {
+	return nil, unsupportedError("InspectWebhook")
+}
+
+func (c *unsupportedAdminBuilderClient) ListBackups(_ context.Context, _ *admin_v2.ListBackupsRequest, opts ...grpc.CallOption) (admin_v2.API_ListBackupsClient, error) {
+	return nil, unsupportedError("ListBackups")
+}
+
+func (c *unsupportedAdminBuilderClient) ListInflightRequests(_ context.Context, _ *admin_v2.ListInflightRequestsRequest, opts ...grpc.CallOption) (*admin_v2.ListInflightRequestsResponse, error) {
+	return nil, unsupportedError("ListInflightRequests")
+}
+
+func (c *unsupportedAdminBuilderClient) ListWebhook(_ context.Context, _ *admin_v2.ListWebhookRequest, opts ...grpc.CallOption) (admin_v2.API_ListWebhookClient, error) {
+	return nil, unsupportedError("ListWebhook")
+}
+
+func (c *unsupportedAdminBuilderClient) Restore(_ context.Context, _ *admin_v2.RestoreRequest, opts ...grpc.CallOption) (*admin_v2.RestoreResponse, error) {
+	return nil, unsupportedError("Restore")
+}
+
+func (c *unsupportedAdminBuilderClient) SetClusterDefaults(_ context.Context, _ *admin_v2.SetClusterDefaultsRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("SetClusterDefaults")
+}
+
+func (c *unsupportedAdminBuilderClient) SetReadOnly(_ context.Context, _ *admin_v2.SetReadOnlyRequest, opts ...grpc.CallOption) (*admin_v2.ReadOnlyState, error) {
+	return nil, unsupportedError("SetReadOnly")
+}
+
+func (c *unsupportedAdminBuilderClient) SubscribeEvents(_ context.Context, _ *admin_v2.SubscribeEventsRequest, opts ...grpc.CallOption) (admin_v2.API_SubscribeEventsClient, error) {
+	return nil, unsupportedError("SubscribeEvents")
+}
+
+func (c *unsupportedAdminBuilderClient) ValidateConfiguration(_ context.Context, _ *admin_v2.ValidateConfigurationRequest, opts ...grpc.CallOption) (*admin_v2.ValidateConfigurationResponse, error) {
+	return nil, unsupportedError("ValidateConfiguration")
+}
+
+func (c *unsupportedAdminBuilderClient) VerifyBackup(_ context.Context, _ *admin_v2.VerifyBackupRequest, opts ...grpc.CallOption) (*admin_v2.VerifyBackupResponse, error) {
+	return nil, unsupportedError("VerifyBackup")
+}
+
 type unsupportedAuthBuilderClient struct{}
 
 func (c *unsupportedAuthBuilderClient) Activate(_ context.Context, _ *auth_v2.ActivateRequest, opts ...grpc.CallOption) (*auth_v2.ActivateResponse, error) {
@@ -59,6 +136,14 @@ func (c *unsupportedAuthBuilderClient) ExtractAuthTokens(_ context.Context, _ *a
 	return nil, unsupportedError("ExtractAuthTokens")
 }
 
+func (c *unsupportedAuthBuilderClient) FreezeACLs(_ context.Context, _ *auth_v2.FreezeACLsRequest, opts ...grpc.CallOption) (*auth_v2.FreezeACLsResponse, error) {
+	return nil, unsupportedError("FreezeACLs")
+}
+
+func (c *unsupportedAuthBuilderClient) GetACLFreeze(_ context.Context, _ *auth_v2.GetACLFreezeRequest, opts ...grpc.CallOption) (*auth_v2.GetACLFreezeResponse, error) {
+	return nil, unsupportedError("GetACLFreeze")
+}
+
 func (c *unsupportedAuthBuilderClient) GetConfiguration(_ context.Context, _ *auth_v2.GetConfigurationRequest, opts ...grpc.CallOption) (*auth_v2.GetConfigurationResponse, error) {
 	return nil, unsupportedError("GetConfiguration")
 }
@@ -99,6 +184,10 @@ func (c *unsupportedAuthBuilderClient) GetUsers(_ context.Context, _ *auth_v2.Ge
 	return nil, unsupportedError("GetUsers")
 }
 
+func (c *unsupportedAuthBuilderClient) ListRoleRequests(_ context.Context, _ *auth_v2.ListRoleRequestsRequest, opts ...grpc.CallOption) (*auth_v2.ListRoleRequestsResponse, error) {
+	return nil, unsupportedError("ListRoleRequests")
+}
+
 func (c *unsupportedAuthBuilderClient) ModifyMembers(_ context.Context, _ *auth_v2.ModifyMembersRequest, opts ...grpc.CallOption) (*auth_v2.ModifyMembersResponse, error) {
 	return nil, unsupportedError("ModifyMembers")
 }
@@ -107,10 +196,18 @@ func (c *unsupportedAuthBuilderClient) ModifyRoleBinding(_ context.Context, _ *a
 	return nil, unsupportedError("ModifyRoleBinding")
 }
 
+func (c *unsupportedAuthBuilderClient) RequestRole(_ context.Context, _ *auth_v2.RequestRoleRequest, opts ...grpc.CallOption) (*auth_v2.RequestRoleResponse, error) {
+	return nil, unsupportedError("RequestRole")
+}
+
 func (c *unsupportedAuthBuilderClient) RestoreAuthToken(_ context.Context, _ *auth_v2.RestoreAuthTokenRequest, opts ...grpc.CallOption) (*auth_v2.RestoreAuthTokenResponse, error) {
 	return nil, unsupportedError("RestoreAuthToken")
 }
 
+func (c *unsupportedAuthBuilderClient) ReviewRoleRequest(_ context.Context, _ *auth_v2.ReviewRoleRequestRequest, opts ...grpc.CallOption) (*auth_v2.ReviewRoleRequestResponse, error) {
+	return nil, unsupportedError("ReviewRoleRequest")
+}
+
 func (c *unsupportedAuthBuilderClient) RevokeAuthToken(_ context.Context, _ *auth_v2.RevokeAuthTokenRequest, opts ...grpc.CallOption) (*auth_v2.RevokeAuthTokenResponse, error) {
 	return nil, unsupportedError("RevokeAuthToken")
 }
@@ -131,6 +228,10 @@ func (c *unsupportedAuthBuilderClient) SetGroupsForUser(_ context.Context, _ *au
 	return nil, unsupportedError("SetGroupsForUser")
 }
 
+func (c *unsupportedAuthBuilderClient) UnfreezeACLs(_ context.Context, _ *auth_v2.UnfreezeACLsRequest, opts ...grpc.CallOption) (*auth_v2.UnfreezeACLsResponse, error) {
+	return nil, unsupportedError("UnfreezeACLs")
+}
+
 func (c *unsupportedAuthBuilderClient) WhoAmI(_ context.Context, _ *auth_v2.WhoAmIRequest, opts ...grpc.CallOption) (*auth_v2.WhoAmIResponse, error) {
 	return nil, unsupportedError("WhoAmI")
 }
@@ -145,6 +246,10 @@ func (c *unsupportedDebugBuilderClient) Dump(_ context.Context, _ *debug_v2.Dump
 	return nil, unsupportedError("Dump")
 }
 
+func (c *unsupportedDebugBuilderClient) GetProfileHistory(_ context.Context, _ *debug_v2.ProfileHistoryRequest, opts ...grpc.CallOption) (debug_v2.Debug_GetProfileHistoryClient, error) {
+	return nil, unsupportedError("GetProfileHistory")
+}
+
 func (c *unsupportedDebugBuilderClient) Profile(_ context.Context, _ *debug_v2.ProfileRequest, opts ...grpc.CallOption) (debug_v2.Debug_ProfileClient, error) {
 	return nil, unsupportedError("Profile")
 }
@@ -185,6 +290,10 @@ func (c *unsupportedEnterpriseBuilderClient) Unpause(_ context.Context, _ *enter
 
 type unsupportedIdentityBuilderClient struct{}
 
+func (c *unsupportedIdentityBuilderClient) CheckIdentityProvider(_ context.Context, _ *identity_v2.CheckIdentityProviderRequest, opts ...grpc.CallOption) (*identity_v2.CheckIdentityProviderResponse, error) {
+	return nil, unsupportedError("CheckIdentityProvider")
+}
+
 func (c *unsupportedIdentityBuilderClient) CreateIDPConnector(_ context.Context, _ *identity_v2.CreateIDPConnectorRequest, opts ...grpc.CallOption) (*identity_v2.CreateIDPConnectorResponse, error) {
 	return nil, unsupportedError("CreateIDPConnector")
 }
@@ -285,10 +394,18 @@ func (c *unsupportedPfsBuilderClient) AddFileSet(_ context.Context, _ *pfs_v2.Ad
 	return nil, unsupportedError("AddFileSet")
 }
 
+func (c *unsupportedPfsBuilderClient) ApplyRetentionPolicy(_ context.Context, _ *pfs_v2.ApplyRetentionPolicyRequest, opts ...grpc.CallOption) (*pfs_v2.ApplyRetentionPolicyResponse, error) {
+	return nil, unsupportedError("ApplyRetentionPolicy")
+}
+
 func (c *unsupportedPfsBuilderClient) CheckStorage(_ context.Context, _ *pfs_v2.CheckStorageRequest, opts ...grpc.CallOption) (*pfs_v2.CheckStorageResponse, error) {
 	return nil, unsupportedError("CheckStorage")
 }
 
+func (c *unsupportedPfsBuilderClient) CheckpointCommit(_ context.Context, _ *pfs_v2.CheckpointCommitRequest, opts ...grpc.CallOption) (*pfs_v2.CheckpointInfo, error) {
+	return nil, unsupportedError("CheckpointCommit")
+}
+
 func (c *unsupportedPfsBuilderClient) ClearCache(_ context.Context, _ *pfs_v2.ClearCacheRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	return nil, unsupportedError("ClearCache")
 }
@@ -301,6 +418,10 @@ func (c *unsupportedPfsBuilderClient) ComposeFileSet(_ context.Context, _ *pfs_v
 	return nil, unsupportedError("ComposeFileSet")
 }
 
+func (c *unsupportedPfsBuilderClient) CordonRepo(_ context.Context, _ *pfs_v2.CordonRepoRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("CordonRepo")
+}
+
 func (c *unsupportedPfsBuilderClient) CreateBranch(_ context.Context, _ *pfs_v2.CreateBranchRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	return nil, unsupportedError("CreateBranch")
 }
@@ -309,10 +430,18 @@ func (c *unsupportedPfsBuilderClient) CreateFileSet(_ context.Context, opts ...g
 	return nil, unsupportedError("CreateFileSet")
 }
 
+func (c *unsupportedPfsBuilderClient) CreateProject(_ context.Context, _ *pfs_v2.CreateProjectRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("CreateProject")
+}
+
 func (c *unsupportedPfsBuilderClient) CreateRepo(_ context.Context, _ *pfs_v2.CreateRepoRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	return nil, unsupportedError("CreateRepo")
 }
 
+func (c *unsupportedPfsBuilderClient) CreateView(_ context.Context, _ *pfs_v2.CreateViewRequest, opts ...grpc.CallOption) (*pfs_v2.ViewInfo, error) {
+	return nil, unsupportedError("CreateView")
+}
+
 func (c *unsupportedPfsBuilderClient) DeleteAll(_ context.Context, _ *types.Empty, opts ...grpc.CallOption) (*types.Empty, error) {
 	return nil, unsupportedError("DeleteAll")
 }
@@ -321,10 +450,34 @@ func (c *unsupportedPfsBuilderClient) DeleteBranch(_ context.Context, _ *pfs_v2.
 	return nil, unsupportedError("DeleteBranch")
 }
 
+func (c *unsupportedPfsBuilderClient) DeleteProject(_ context.Context, _ *pfs_v2.DeleteProjectRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("DeleteProject")
+}
+
 func (c *unsupportedPfsBuilderClient) DeleteRepo(_ context.Context, _ *pfs_v2.DeleteRepoRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	return nil, unsupportedError("DeleteRepo")
 }
 
+func (c *unsupportedPfsBuilderClient) DeleteRetentionPolicy(_ context.Context, _ *pfs_v2.DeleteRetentionPolicyRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("DeleteRetentionPolicy")
+}
+
+func (c *unsupportedPfsBuilderClient) DeleteSchema(_ context.Context, _ *pfs_v2.DeleteSchemaRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("DeleteSchema")
+}
+
+func (c *unsupportedPfsBuilderClient) DeleteUpload(_ context.Context, _ *pfs_v2.DeleteUploadRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("DeleteUpload")
+}
+
+func (c *unsupportedPfsBuilderClient) DeleteValidationPolicy(_ context.Context, _ *pfs_v2.DeleteValidationPolicyRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("DeleteValidationPolicy")
+}
+
+func (c *unsupportedPfsBuilderClient) DeleteView(_ context.Context, _ *pfs_v2.DeleteViewRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("DeleteView")
+}
+
 func (c *unsupportedPfsBuilderClient) DiffFile(_ context.Context, _ *pfs_v2.DiffFileRequest, opts ...grpc.CallOption) (pfs_v2.API_DiffFileClient, error) {
 	return nil, unsupportedError("DiffFile")
 }
@@ -337,10 +490,18 @@ func (c *unsupportedPfsBuilderClient) Egress(_ context.Context, _ *pfs_v2.Egress
 	return nil, unsupportedError("Egress")
 }
 
+func (c *unsupportedPfsBuilderClient) EraseFile(_ context.Context, _ *pfs_v2.EraseFileRequest, opts ...grpc.CallOption) (*pfs_v2.ErasureInfo, error) {
+	return nil, unsupportedError("EraseFile")
+}
+
 func (c *unsupportedPfsBuilderClient) FinishCommit(_ context.Context, _ *pfs_v2.FinishCommitRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	return nil, unsupportedError("FinishCommit")
 }
 
+func (c *unsupportedPfsBuilderClient) FinishUpload(_ context.Context, _ *pfs_v2.FinishUploadRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("FinishUpload")
+}
+
 func (c *unsupportedPfsBuilderClient) Fsck(_ context.Context, _ *pfs_v2.FsckRequest, opts ...grpc.CallOption) (pfs_v2.API_FsckClient, error) {
 	return nil, unsupportedError("Fsck")
 }
@@ -369,10 +530,18 @@ func (c *unsupportedPfsBuilderClient) InspectBranch(_ context.Context, _ *pfs_v2
 	return nil, unsupportedError("InspectBranch")
 }
 
+func (c *unsupportedPfsBuilderClient) InspectCache(_ context.Context, _ *pfs_v2.InspectCacheRequest, opts ...grpc.CallOption) (*pfs_v2.InspectCacheResponse, error) {
+	return nil, unsupportedError("InspectCache")
+}
+
 func (c *unsupportedPfsBuilderClient) InspectCommit(_ context.Context, _ *pfs_v2.InspectCommitRequest, opts ...grpc.CallOption) (*pfs_v2.CommitInfo, error) {
 	return nil, unsupportedError("InspectCommit")
 }
 
+func (c *unsupportedPfsBuilderClient) InspectCommitSchema(_ context.Context, _ *pfs_v2.InspectCommitSchemaRequest, opts ...grpc.CallOption) (*pfs_v2.CommitSchemaInfo, error) {
+	return nil, unsupportedError("InspectCommitSchema")
+}
+
 func (c *unsupportedPfsBuilderClient) InspectCommitSet(_ context.Context, _ *pfs_v2.InspectCommitSetRequest, opts ...grpc.CallOption) (pfs_v2.API_InspectCommitSetClient, error) {
 	return nil, unsupportedError("InspectCommitSet")
 }
@@ -381,10 +550,26 @@ func (c *unsupportedPfsBuilderClient) InspectFile(_ context.Context, _ *pfs_v2.I
 	return nil, unsupportedError("InspectFile")
 }
 
+func (c *unsupportedPfsBuilderClient) InspectProject(_ context.Context, _ *pfs_v2.InspectProjectRequest, opts ...grpc.CallOption) (*pfs_v2.ProjectInfo, error) {
+	return nil, unsupportedError("InspectProject")
+}
+
 func (c *unsupportedPfsBuilderClient) InspectRepo(_ context.Context, _ *pfs_v2.InspectRepoRequest, opts ...grpc.CallOption) (*pfs_v2.RepoInfo, error) {
 	return nil, unsupportedError("InspectRepo")
 }
 
+func (c *unsupportedPfsBuilderClient) InspectUpload(_ context.Context, _ *pfs_v2.InspectUploadRequest, opts ...grpc.CallOption) (*pfs_v2.UploadSession, error) {
+	return nil, unsupportedError("InspectUpload")
+}
+
+func (c *unsupportedPfsBuilderClient) InspectValidationPolicy(_ context.Context, _ *pfs_v2.InspectValidationPolicyRequest, opts ...grpc.CallOption) (*pfs_v2.ValidationPolicyInfo, error) {
+	return nil, unsupportedError("InspectValidationPolicy")
+}
+
+func (c *unsupportedPfsBuilderClient) InspectView(_ context.Context, _ *pfs_v2.InspectViewRequest, opts ...grpc.CallOption) (*pfs_v2.ViewInfo, error) {
+	return nil, unsupportedError("InspectView")
+}
+
 func (c *unsupportedPfsBuilderClient) ListBranch(_ context.Context, _ *pfs_v2.ListBranchRequest, opts ...grpc.CallOption) (pfs_v2.API_ListBranchClient, error) {
 	return nil, unsupportedError("ListBranch")
 }
@@ -397,22 +582,46 @@ func (c *unsupportedPfsBuilderClient) ListCommitSet(_ context.Context, _ *pfs_v2
 	return nil, unsupportedError("ListCommitSet")
 }
 
+func (c *unsupportedPfsBuilderClient) ListErasure(_ context.Context, _ *pfs_v2.ListErasureRequest, opts ...grpc.CallOption) (pfs_v2.API_ListErasureClient, error) {
+	return nil, unsupportedError("ListErasure")
+}
+
 func (c *unsupportedPfsBuilderClient) ListFile(_ context.Context, _ *pfs_v2.ListFileRequest, opts ...grpc.CallOption) (pfs_v2.API_ListFileClient, error) {
 	return nil, unsupportedError("ListFile")
 }
 
+func (c *unsupportedPfsBuilderClient) ListProject(_ context.Context, _ *pfs_v2.ListProjectRequest, opts ...grpc.CallOption) (pfs_v2.API_ListProjectClient, error) {
+	return nil, unsupportedError("ListProject")
+}
+
 func (c *unsupportedPfsBuilderClient) ListRepo(_ context.Context, _ *pfs_v2.ListRepoRequest, opts ...grpc.CallOption) (pfs_v2.API_ListRepoClient, error) {
 	return nil, unsupportedError("ListRepo")
 }
 
+func (c *unsupportedPfsBuilderClient) ListRetentionPolicy(_ context.Context, _ *pfs_v2.ListRetentionPolicyRequest, opts ...grpc.CallOption) (pfs_v2.API_ListRetentionPolicyClient, error) {
+	return nil, unsupportedError("ListRetentionPolicy")
+}
+
+func (c *unsupportedPfsBuilderClient) ListSchema(_ context.Context, _ *pfs_v2.ListSchemaRequest, opts ...grpc.CallOption) (pfs_v2.API_ListSchemaClient, error) {
+	return nil, unsupportedError("ListSchema")
+}
+
 func (c *unsupportedPfsBuilderClient) ListTask(_ context.Context, _ *taskapi.ListTaskRequest, opts ...grpc.CallOption) (pfs_v2.API_ListTaskClient, error) {
 	return nil, unsupportedError("ListTask")
 }
 
+func (c *unsupportedPfsBuilderClient) ListView(_ context.Context, _ *pfs_v2.ListViewRequest, opts ...grpc.CallOption) (pfs_v2.API_ListViewClient, error) {
+	return nil, unsupportedError("ListView")
+}
+
 func (c *unsupportedPfsBuilderClient) ModifyFile(_ context.Context, opts ...grpc.CallOption) (pfs_v2.API_ModifyFileClient, error) {
 	return nil, unsupportedError("ModifyFile")
 }
 
+func (c *unsupportedPfsBuilderClient) PreviewFile(_ context.Context, _ *pfs_v2.PreviewFileRequest, opts ...grpc.CallOption) (*pfs_v2.PreviewFileResponse, error) {
+	return nil, unsupportedError("PreviewFile")
+}
+
 func (c *unsupportedPfsBuilderClient) PutCache(_ context.Context, _ *pfs_v2.PutCacheRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	return nil, unsupportedError("PutCache")
 }
@@ -429,6 +638,18 @@ func (c *unsupportedPfsBuilderClient) RunLoadTestDefault(_ context.Context, _ *t
 	return nil, unsupportedError("RunLoadTestDefault")
 }
 
+func (c *unsupportedPfsBuilderClient) SetRetentionPolicy(_ context.Context, _ *pfs_v2.SetRetentionPolicyRequest, opts ...grpc.CallOption) (*pfs_v2.RetentionPolicyInfo, error) {
+	return nil, unsupportedError("SetRetentionPolicy")
+}
+
+func (c *unsupportedPfsBuilderClient) SetSchema(_ context.Context, _ *pfs_v2.SetSchemaRequest, opts ...grpc.CallOption) (*pfs_v2.SchemaInfo, error) {
+	return nil, unsupportedError("SetSchema")
+}
+
+func (c *unsupportedPfsBuilderClient) SetValidationPolicy(_ context.Context, _ *pfs_v2.SetValidationPolicyRequest, opts ...grpc.CallOption) (*pfs_v2.ValidationPolicyInfo, error) {
+	return nil, unsupportedError("SetValidationPolicy")
+}
+
 func (c *unsupportedPfsBuilderClient) SquashCommitSet(_ context.Context, _ *pfs_v2.SquashCommitSetRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	return nil, unsupportedError("SquashCommitSet")
 }
@@ -437,10 +658,30 @@ func (c *unsupportedPfsBuilderClient) StartCommit(_ context.Context, _ *pfs_v2.S
 	return nil, unsupportedError("StartCommit")
 }
 
+func (c *unsupportedPfsBuilderClient) StartUpload(_ context.Context, _ *pfs_v2.StartUploadRequest, opts ...grpc.CallOption) (*pfs_v2.UploadSession, error) {
+	return nil, unsupportedError("StartUpload")
+}
+
+func (c *unsupportedPfsBuilderClient) SubscribeCheckpoint(_ context.Context, _ *pfs_v2.SubscribeCheckpointRequest, opts ...grpc.CallOption) (pfs_v2.API_SubscribeCheckpointClient, error) {
+	return nil, unsupportedError("SubscribeCheckpoint")
+}
+
 func (c *unsupportedPfsBuilderClient) SubscribeCommit(_ context.Context, _ *pfs_v2.SubscribeCommitRequest, opts ...grpc.CallOption) (pfs_v2.API_SubscribeCommitClient, error) {
 	return nil, unsupportedError("SubscribeCommit")
 }
 
+func (c *unsupportedPfsBuilderClient) UncordonRepo(_ context.Context, _ *pfs_v2.UncordonRepoRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("UncordonRepo")
+}
+
+func (c *unsupportedPfsBuilderClient) UploadBlock(_ context.Context, _ *pfs_v2.UploadBlockRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("UploadBlock")
+}
+
+func (c *unsupportedPfsBuilderClient) VerifyErasure(_ context.Context, _ *pfs_v2.VerifyErasureRequest, opts ...grpc.CallOption) (*pfs_v2.VerifyErasureResponse, error) {
+	return nil, unsupportedError("VerifyErasure")
+}
+
 func (c *unsupportedPfsBuilderClient) WalkFile(_ context.Context, _ *pfs_v2.WalkFileRequest, opts ...grpc.CallOption) (pfs_v2.API_WalkFileClient, error) {
 	return nil, unsupportedError("WalkFile")
 }
@@ -451,6 +692,18 @@ func (c *unsupportedPpsBuilderClient) ActivateAuth(_ context.Context, _ *pps_v2.
 	return nil, unsupportedError("ActivateAuth")
 }
 
+func (c *unsupportedPpsBuilderClient) ClearDatumCache(_ context.Context, _ *pps_v2.ClearDatumCacheRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("ClearDatumCache")
+}
+
+func (c *unsupportedPpsBuilderClient) CordonPipeline(_ context.Context, _ *pps_v2.CordonPipelineRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("CordonPipeline")
+}
+
+func (c *unsupportedPpsBuilderClient) CountDatums(_ context.Context, _ *pps_v2.CountDatumsRequest, opts ...grpc.CallOption) (*pps_v2.DatumCounts, error) {
+	return nil, unsupportedError("CountDatums")
+}
+
 func (c *unsupportedPpsBuilderClient) CreatePipeline(_ context.Context, _ *pps_v2.CreatePipelineRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	return nil, unsupportedError("CreatePipeline")
 }
@@ -475,6 +728,18 @@ func (c *unsupportedPpsBuilderClient) DeleteSecret(_ context.Context, _ *pps_v2.
 	return nil, unsupportedError("DeleteSecret")
 }
 
+func (c *unsupportedPpsBuilderClient) FinishCanary(_ context.Context, _ *pps_v2.FinishCanaryRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("FinishCanary")
+}
+
+func (c *unsupportedPpsBuilderClient) GetArchivedLogs(_ context.Context, _ *pps_v2.GetArchivedLogsRequest, opts ...grpc.CallOption) (pps_v2.API_GetArchivedLogsClient, error) {
+	return nil, unsupportedError("GetArchivedLogs")
+}
+
+func (c *unsupportedPpsBuilderClient) GetDAG(_ context.Context, _ *pps_v2.GetDAGRequest, opts ...grpc.CallOption) (*pps_v2.DAG, error) {
+	return nil, unsupportedError("GetDAG")
+}
+
 func (c *unsupportedPpsBuilderClient) GetLogs(_ context.Context, _ *pps_v2.GetLogsRequest, opts ...grpc.CallOption) (pps_v2.API_GetLogsClient, error) {
 	return nil, unsupportedError("GetLogs")
 }
@@ -483,10 +748,18 @@ func (c *unsupportedPpsBuilderClient) InspectDatum(_ context.Context, _ *pps_v2.
 	return nil, unsupportedError("InspectDatum")
 }
 
+func (c *unsupportedPpsBuilderClient) InspectDatumCache(_ context.Context, _ *pps_v2.InspectDatumCacheRequest, opts ...grpc.CallOption) (*pps_v2.DatumCacheInfo, error) {
+	return nil, unsupportedError("InspectDatumCache")
+}
+
 func (c *unsupportedPpsBuilderClient) InspectJob(_ context.Context, _ *pps_v2.InspectJobRequest, opts ...grpc.CallOption) (*pps_v2.JobInfo, error) {
 	return nil, unsupportedError("InspectJob")
 }
 
+func (c *unsupportedPpsBuilderClient) InspectJobProfile(_ context.Context, _ *pps_v2.InspectJobProfileRequest, opts ...grpc.CallOption) (*pps_v2.JobProfile, error) {
+	return nil, unsupportedError("InspectJobProfile")
+}
+
 func (c *unsupportedPpsBuilderClient) InspectJobSet(_ context.Context, _ *pps_v2.InspectJobSetRequest, opts ...grpc.CallOption) (pps_v2.API_InspectJobSetClient, error) {
 	return nil, unsupportedError("InspectJobSet")
 }
@@ -499,10 +772,18 @@ func (c *unsupportedPpsBuilderClient) InspectSecret(_ context.Context, _ *pps_v2
 	return nil, unsupportedError("InspectSecret")
 }
 
+func (c *unsupportedPpsBuilderClient) LintPipeline(_ context.Context, _ *pps_v2.LintPipelineRequest, opts ...grpc.CallOption) (*pps_v2.LintPipelineResponse, error) {
+	return nil, unsupportedError("LintPipeline")
+}
+
 func (c *unsupportedPpsBuilderClient) ListDatum(_ context.Context, _ *pps_v2.ListDatumRequest, opts ...grpc.CallOption) (pps_v2.API_ListDatumClient, error) {
 	return nil, unsupportedError("ListDatum")
 }
 
+func (c *unsupportedPpsBuilderClient) ListDatumProvenance(_ context.Context, _ *pps_v2.ListDatumProvenanceRequest, opts ...grpc.CallOption) (pps_v2.API_ListDatumProvenanceClient, error) {
+	return nil, unsupportedError("ListDatumProvenance")
+}
+
 func (c *unsupportedPpsBuilderClient) ListJob(_ context.Context, _ *pps_v2.ListJobRequest, opts ...grpc.CallOption) (pps_v2.API_ListJobClient, error) {
 	return nil, unsupportedError("ListJob")
 }
@@ -515,7 +796,15 @@ func (c *unsupportedPpsBuilderClient) ListPipeline(_ context.Context, _ *pps_v2.
 	return nil, unsupportedError("ListPipeline")
 }
 
-func (c *unsupportedPpsBuilderClient) ListSecret(_ context.Context, _ *types.Empty, opts ...grpc.CallOption) (*pps_v2.SecretInfos, error) {
+func (c *unsupportedPpsBuilderClient) ListQuarantinedDatum(_ context.Context, _ *pps_v2.ListQuarantinedDatumRequest, opts ...grpc.CallOption) (pps_v2.API_ListQuarantinedDatumClient, error) {
+	return nil, unsupportedError("ListQuarantinedDatum")
+}
+
+func (c *unsupportedPpsBuilderClient) ListSLOStatus(_ context.Context, _ *pps_v2.ListSLOStatusRequest, opts ...grpc.CallOption) (*pps_v2.ListSLOStatusResponse, error) {
+	return nil, unsupportedError("ListSLOStatus")
+}
+
+func (c *unsupportedPpsBuilderClient) ListSecret(_ context.Context, _ *pps_v2.ListSecretRequest, opts ...grpc.CallOption) (*pps_v2.SecretInfos, error) {
 	return nil, unsupportedError("ListSecret")
 }
 
@@ -523,10 +812,22 @@ func (c *unsupportedPpsBuilderClient) ListTask(_ context.Context, _ *taskapi.Lis
 	return nil, unsupportedError("ListTask")
 }
 
+func (c *unsupportedPpsBuilderClient) PlanPipeline(_ context.Context, _ *pps_v2.PlanPipelineRequest, opts ...grpc.CallOption) (*pps_v2.PipelinePlan, error) {
+	return nil, unsupportedError("PlanPipeline")
+}
+
+func (c *unsupportedPpsBuilderClient) QueryLineage(_ context.Context, _ *pps_v2.QueryLineageRequest, opts ...grpc.CallOption) (*pps_v2.Lineage, error) {
+	return nil, unsupportedError("QueryLineage")
+}
+
 func (c *unsupportedPpsBuilderClient) RenderTemplate(_ context.Context, _ *pps_v2.RenderTemplateRequest, opts ...grpc.CallOption) (*pps_v2.RenderTemplateResponse, error) {
 	return nil, unsupportedError("RenderTemplate")
 }
 
+func (c *unsupportedPpsBuilderClient) RequeueQuarantinedDatums(_ context.Context, _ *pps_v2.RequeueQuarantinedDatumsRequest, opts ...grpc.CallOption) (*pps_v2.RequeueQuarantinedDatumsResponse, error) {
+	return nil, unsupportedError("RequeueQuarantinedDatums")
+}
+
 func (c *unsupportedPpsBuilderClient) RestartDatum(_ context.Context, _ *pps_v2.RestartDatumRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	return nil, unsupportedError("RestartDatum")
 }
@@ -563,6 +864,10 @@ func (c *unsupportedPpsBuilderClient) SubscribeJob(_ context.Context, _ *pps_v2.
 	return nil, unsupportedError("SubscribeJob")
 }
 
+func (c *unsupportedPpsBuilderClient) UncordonPipeline(_ context.Context, _ *pps_v2.UncordonPipelineRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("UncordonPipeline")
+}
+
 func (c *unsupportedPpsBuilderClient) UpdateJobState(_ context.Context, _ *pps_v2.UpdateJobStateRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	return nil, unsupportedError("UpdateJobState")
 }
@@ -573,6 +878,12 @@ func (c *unsupportedProxyBuilderClient) Listen(_ context.Context, _ *proxy.Liste
 	return nil, unsupportedError("Listen")
 }
 
+type unsupportedSearchBuilderClient struct{}
+
+func (c *unsupportedSearchBuilderClient) Search(_ context.Context, _ *search_v2.SearchRequest, opts ...grpc.CallOption) (*search_v2.SearchResponse, error) {
+	return nil, unsupportedError("Search")
+}
+
 type unsupportedTransactionBuilderClient struct{}
 
 func (c *unsupportedTransactionBuilderClient) BatchTransaction(_ context.Context, _ *transaction_v2.BatchTransactionRequest, opts ...grpc.CallOption) (*transaction_v2.TransactionInfo, error) {
@@ -28,6 +28,57 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// Collector is a kind of debugging information that a dump collects.
+type Collector int32
+
+const (
+	Collector_COLLECTOR_UNKNOWN Collector = 0
+	// The version of pachd.
+	Collector_VERSION Collector = 1
+	// The output of 'kubectl describe' for each pod.
+	Collector_KUBERNETES Collector = 2
+	// The logs of each container, from Kubernetes and Loki.
+	Collector_LOGS Collector = 3
+	// Goroutine and heap profiles of pachd and the workers.
+	Collector_PROFILES Collector = 4
+	// The specs of each version of each pipeline.
+	Collector_SPECS Collector = 5
+	// The commits of each repo, with a chart of their durations.
+	Collector_COMMITS Collector = 6
+	// The jobs of each pipeline, with a chart of their durations.
+	Collector_JOBS Collector = 7
+)
+
+var Collector_name = map[int32]string{
+	0: "COLLECTOR_UNKNOWN",
+	1: "VERSION",
+	2: "KUBERNETES",
+	3: "LOGS",
+	4: "PROFILES",
+	5: "SPECS",
+	6: "COMMITS",
+	7: "JOBS",
+}
+
+var Collector_value = map[string]int32{
+	"COLLECTOR_UNKNOWN": 0,
+	"VERSION":           1,
+	"KUBERNETES":        2,
+	"LOGS":              3,
+	"PROFILES":          4,
+	"SPECS":             5,
+	"COMMITS":           6,
+	"JOBS":              7,
+}
+
+func (x Collector) String() string {
+	return proto.EnumName(Collector_name, int32(x))
+}
+
+func (Collector) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{0}
+}
+
 type ProfileRequest struct {
 	Profile              *Profile `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
 	Filter               *Filter  `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
@@ -344,10 +395,13 @@ func (m *BinaryRequest) GetFilter() *Filter {
 type DumpRequest struct {
 	Filter *Filter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
 	// Limit sets the limit for the number of commits / jobs that are returned for each repo / pipeline in the dump.
-	Limit                int64    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Limit int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Collectors selects the debugging information that's collected. All of it
+	// is collected if it's empty.
+	Collectors           []Collector `protobuf:"varint,3,rep,packed,name=collectors,proto3,enum=debug_v2.Collector" json:"collectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *DumpRequest) Reset()         { *m = DumpRequest{} }
@@ -397,47 +451,147 @@ func (m *DumpRequest) GetLimit() int64 {
 	return 0
 }
 
+func (m *DumpRequest) GetCollectors() []Collector {
+	if m != nil {
+		return m.Collectors
+	}
+	return nil
+}
+
+// ProfileHistoryRequest selects the profiles, captured continuously by pachd
+// and the workers, that GetProfileHistory returns.
+type ProfileHistoryRequest struct {
+	Filter *Filter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Profiles are the kinds of profile to return, "cpu" or "heap". Both are
+	// returned if it's empty.
+	Profiles []string `protobuf:"bytes,2,rep,name=profiles,proto3" json:"profiles,omitempty"`
+	// Start and end bound the times that the profiles were captured at. Either
+	// may be unset to leave the range open.
+	Start                *types.Timestamp `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	End                  *types.Timestamp `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ProfileHistoryRequest) Reset()         { *m = ProfileHistoryRequest{} }
+func (m *ProfileHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*ProfileHistoryRequest) ProtoMessage()    {}
+func (*ProfileHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{6}
+}
+func (m *ProfileHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProfileHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProfileHistoryRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProfileHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProfileHistoryRequest.Merge(m, src)
+}
+func (m *ProfileHistoryRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProfileHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProfileHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProfileHistoryRequest proto.InternalMessageInfo
+
+func (m *ProfileHistoryRequest) GetFilter() *Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *ProfileHistoryRequest) GetProfiles() []string {
+	if m != nil {
+		return m.Profiles
+	}
+	return nil
+}
+
+func (m *ProfileHistoryRequest) GetStart() *types.Timestamp {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *ProfileHistoryRequest) GetEnd() *types.Timestamp {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterEnum("debug_v2.Collector", Collector_name, Collector_value)
 	proto.RegisterType((*ProfileRequest)(nil), "debug_v2.ProfileRequest")
 	proto.RegisterType((*Profile)(nil), "debug_v2.Profile")
 	proto.RegisterType((*Filter)(nil), "debug_v2.Filter")
 	proto.RegisterType((*Worker)(nil), "debug_v2.Worker")
 	proto.RegisterType((*BinaryRequest)(nil), "debug_v2.BinaryRequest")
 	proto.RegisterType((*DumpRequest)(nil), "debug_v2.DumpRequest")
+	proto.RegisterType((*ProfileHistoryRequest)(nil), "debug_v2.ProfileHistoryRequest")
 }
 
 func init() { proto.RegisterFile("debug/debug.proto", fileDescriptor_5ae24eab94cb53d5) }
 
 var fileDescriptor_5ae24eab94cb53d5 = []byte{
-	// 447 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xce, 0x92, 0xc6, 0x35, 0x53, 0x15, 0xb5, 0x23, 0x7e, 0x42, 0x91, 0x2c, 0xe4, 0x53, 0x45,
-	0x25, 0x1b, 0x05, 0x71, 0x28, 0x07, 0x0e, 0x56, 0x84, 0x72, 0x41, 0x42, 0x2b, 0x04, 0x12, 0x17,
-	0xe4, 0xc4, 0x13, 0x77, 0x85, 0x93, 0x5d, 0xd6, 0x76, 0xa3, 0xbc, 0x00, 0xcf, 0xc6, 0x11, 0xde,
-	0x00, 0xe5, 0x49, 0x90, 0x77, 0xd7, 0x71, 0x20, 0x87, 0xa8, 0x97, 0x68, 0xe6, 0x9b, 0x6f, 0xbe,
-	0x99, 0x6f, 0x27, 0x86, 0xf3, 0x8c, 0xa6, 0x75, 0x1e, 0x9b, 0xdf, 0x48, 0x69, 0x59, 0x49, 0xf4,
-	0x4d, 0xf2, 0xf5, 0x76, 0x74, 0x11, 0xe4, 0x52, 0xe6, 0x05, 0xc5, 0x06, 0x9f, 0xd6, 0xf3, 0x78,
-	0xa5, 0x53, 0xa5, 0x48, 0x97, 0x96, 0xb9, 0x5f, 0xcf, 0x6a, 0x9d, 0x56, 0x42, 0x2e, 0x5d, 0xfd,
-	0x54, 0xa9, 0x32, 0x56, 0xca, 0xd1, 0xc3, 0x1c, 0x1e, 0x7c, 0xd0, 0x72, 0x2e, 0x0a, 0xe2, 0xf4,
-	0xbd, 0xa6, 0xb2, 0xc2, 0x2b, 0x38, 0x56, 0x16, 0x19, 0xb2, 0xe7, 0xec, 0xf2, 0x64, 0x74, 0x1e,
-	0xb5, 0xc3, 0xa3, 0x96, 0xda, 0x32, 0xf0, 0x12, 0xbc, 0xb9, 0x28, 0x2a, 0xd2, 0xc3, 0x7b, 0x86,
-	0x7b, 0xd6, 0x71, 0xdf, 0x19, 0x9c, 0xbb, 0x7a, 0xf8, 0x11, 0x8e, 0x5d, 0x37, 0x22, 0x1c, 0x2d,
-	0xd3, 0x85, 0x95, 0xbf, 0xcf, 0x4d, 0x8c, 0xaf, 0xc1, 0x6f, 0x17, 0x75, 0x52, 0x4f, 0x23, 0xeb,
-	0x24, 0x6a, 0x9d, 0x44, 0x63, 0x47, 0xe0, 0x5b, 0x6a, 0xf8, 0x83, 0x81, 0x67, 0x07, 0xe1, 0x63,
-	0x18, 0xa8, 0x74, 0x76, 0x93, 0x19, 0x59, 0x7f, 0xd2, 0xe3, 0x36, 0xc5, 0x08, 0x7c, 0x25, 0x14,
-	0x15, 0x62, 0x49, 0xdb, 0x25, 0x95, 0x2a, 0x8d, 0x1d, 0x87, 0x4f, 0x7a, 0x7c, 0xcb, 0xc1, 0x17,
-	0xe0, 0xad, 0xa4, 0xfe, 0x46, 0x7a, 0xd8, 0xff, 0xdf, 0xd2, 0x67, 0x83, 0x4f, 0x7a, 0xdc, 0x31,
-	0x12, 0xbf, 0xb5, 0x1f, 0xbe, 0x01, 0xcf, 0x56, 0xf1, 0x0c, 0xfa, 0x4a, 0x66, 0xce, 0x5c, 0x13,
-	0x62, 0x00, 0xa0, 0x29, 0x13, 0x9a, 0x66, 0x15, 0x65, 0x66, 0x07, 0x9f, 0xef, 0x20, 0xe1, 0x35,
-	0x9c, 0x26, 0x62, 0x99, 0xea, 0x75, 0x7b, 0x82, 0xee, 0x55, 0xd9, 0x81, 0x57, 0x7d, 0x0f, 0x27,
-	0xe3, 0x7a, 0xa1, 0xee, 0xdc, 0x88, 0x0f, 0x61, 0x50, 0x88, 0x85, 0xa8, 0xcc, 0x3a, 0x7d, 0x6e,
-	0x93, 0xd1, 0x6f, 0x06, 0x83, 0x71, 0xd3, 0x81, 0xe3, 0xee, 0x5c, 0xc3, 0xfd, 0xfb, 0xdb, 0x71,
-	0x17, 0xcf, 0xf6, 0x4e, 0x94, 0xac, 0x2b, 0x2a, 0x3f, 0xa5, 0x45, 0x4d, 0x61, 0xef, 0x25, 0xc3,
-	0x04, 0x3c, 0xeb, 0x0c, 0x9f, 0x74, 0x22, 0xff, 0x78, 0x3d, 0xac, 0xf1, 0x16, 0x8e, 0x1a, 0x8b,
-	0xf8, 0xa8, 0x53, 0xd8, 0xb1, 0x7c, 0xb0, 0x3f, 0xb9, 0xfe, 0xb9, 0x09, 0xd8, 0xaf, 0x4d, 0xc0,
-	0xfe, 0x6c, 0x02, 0xf6, 0xe5, 0x2a, 0x17, 0xd5, 0x4d, 0x3d, 0x8d, 0x66, 0x72, 0x11, 0x37, 0xff,
-	0x8f, 0x75, 0x46, 0x7a, 0x37, 0xba, 0x1d, 0xc5, 0xa5, 0x9e, 0xd9, 0x6f, 0x6f, 0xea, 0x19, 0xcd,
-	0x57, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x2c, 0x84, 0xe8, 0x49, 0x91, 0x03, 0x00, 0x00,
+	// 658 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xcd, 0x6e, 0xda, 0x4c,
+	0x14, 0xc5, 0x98, 0x1f, 0x73, 0xf3, 0x25, 0x32, 0xf3, 0x35, 0xad, 0x4b, 0x25, 0x12, 0x79, 0x85,
+	0x92, 0xca, 0x44, 0x44, 0x5d, 0xa4, 0x8b, 0x2e, 0x0c, 0x4e, 0x48, 0x43, 0x70, 0x34, 0x26, 0x89,
+	0xd4, 0x4d, 0x64, 0xf0, 0x84, 0x58, 0x35, 0x78, 0x3a, 0x1e, 0x27, 0x62, 0xdb, 0x45, 0x9f, 0xa5,
+	0x2f, 0xd1, 0x7d, 0x97, 0x7d, 0x84, 0x2a, 0x4f, 0x52, 0xf9, 0x0f, 0x48, 0x58, 0xa0, 0x76, 0x83,
+	0xe6, 0xde, 0x7b, 0xee, 0xe1, 0x9e, 0x3b, 0x67, 0x0c, 0x55, 0x87, 0x0c, 0xc3, 0x71, 0x33, 0xfe,
+	0xd5, 0x28, 0xf3, 0xb9, 0x8f, 0xa4, 0x38, 0xb8, 0xb9, 0x6f, 0xd5, 0xea, 0x63, 0xdf, 0x1f, 0x7b,
+	0xa4, 0x19, 0xe7, 0x87, 0xe1, 0x6d, 0xf3, 0x81, 0xd9, 0x94, 0x12, 0x16, 0x24, 0xc8, 0xd5, 0xba,
+	0x13, 0x32, 0x9b, 0xbb, 0xfe, 0x34, 0xad, 0xef, 0x3c, 0xaf, 0x73, 0x77, 0x42, 0x02, 0x6e, 0x4f,
+	0x68, 0x0a, 0xd8, 0xa4, 0x34, 0x68, 0x52, 0x9a, 0xf2, 0xa9, 0x63, 0xd8, 0xba, 0x60, 0xfe, 0xad,
+	0xeb, 0x11, 0x4c, 0xbe, 0x84, 0x24, 0xe0, 0x68, 0x1f, 0xca, 0x34, 0xc9, 0x28, 0xc2, 0xae, 0xd0,
+	0xd8, 0x68, 0x55, 0xb5, 0x6c, 0x3a, 0x2d, 0x83, 0x66, 0x08, 0xd4, 0x80, 0xd2, 0xad, 0xeb, 0x71,
+	0xc2, 0x94, 0x7c, 0x8c, 0x95, 0x17, 0xd8, 0xe3, 0x38, 0x8f, 0xd3, 0xba, 0x3a, 0x80, 0x72, 0xda,
+	0x8d, 0x10, 0x14, 0xa6, 0xf6, 0x24, 0xa1, 0xaf, 0xe0, 0xf8, 0x8c, 0xde, 0x81, 0x94, 0x29, 0x49,
+	0xa9, 0x5e, 0x6b, 0x89, 0x14, 0x2d, 0x93, 0xa2, 0x75, 0x52, 0x00, 0x9e, 0x43, 0xd5, 0x6f, 0x02,
+	0x94, 0x92, 0x3f, 0x42, 0x2f, 0xa1, 0x48, 0xed, 0xd1, 0x9d, 0x13, 0xd3, 0x4a, 0xdd, 0x1c, 0x4e,
+	0x42, 0xa4, 0x81, 0x44, 0x5d, 0x4a, 0x3c, 0x77, 0x4a, 0xe6, 0x43, 0x52, 0x1a, 0xc4, 0x72, 0xd2,
+	0x7c, 0x37, 0x87, 0xe7, 0x18, 0xb4, 0x07, 0xa5, 0x07, 0x9f, 0x7d, 0x26, 0x4c, 0x11, 0x9f, 0x4b,
+	0xba, 0x8e, 0xf3, 0xdd, 0x1c, 0x4e, 0x11, 0xba, 0x94, 0xc9, 0x57, 0xdf, 0x43, 0x29, 0xa9, 0x22,
+	0x19, 0x44, 0xea, 0x3b, 0xa9, 0xb8, 0xe8, 0x88, 0xea, 0x00, 0x8c, 0x38, 0x2e, 0x23, 0x23, 0x4e,
+	0x9c, 0x78, 0x06, 0x09, 0x2f, 0x65, 0xd4, 0x23, 0xd8, 0xd4, 0xdd, 0xa9, 0xcd, 0x66, 0xd9, 0x15,
+	0x2c, 0xb6, 0x2a, 0xac, 0xd9, 0xea, 0x57, 0x01, 0x36, 0x3a, 0xe1, 0x84, 0xfe, 0x75, 0x27, 0x7a,
+	0x01, 0x45, 0xcf, 0x9d, 0xb8, 0x3c, 0x9e, 0x47, 0xc4, 0x49, 0x80, 0x0e, 0x01, 0x46, 0xbe, 0xe7,
+	0x91, 0x11, 0xf7, 0x59, 0xa0, 0x88, 0xbb, 0x62, 0x63, 0xab, 0xf5, 0xff, 0x82, 0xa3, 0x9d, 0xd5,
+	0xf0, 0x12, 0x4c, 0xfd, 0x21, 0xc0, 0x76, 0x7a, 0xb7, 0x5d, 0x37, 0xe0, 0xfe, 0x3f, 0x08, 0x41,
+	0x35, 0x90, 0x52, 0x4f, 0x05, 0x4a, 0x7e, 0x57, 0x6c, 0x54, 0xf0, 0x3c, 0x46, 0x07, 0x50, 0x0c,
+	0xb8, 0xcd, 0x78, 0x7a, 0x21, 0xb5, 0x15, 0x63, 0x0c, 0x32, 0x8f, 0xe3, 0x04, 0x88, 0xde, 0x82,
+	0x48, 0xa6, 0x8e, 0x52, 0x58, 0x8b, 0x8f, 0x60, 0x7b, 0x33, 0xa8, 0xcc, 0x85, 0xa1, 0x6d, 0xa8,
+	0xb6, 0xcd, 0x5e, 0xcf, 0x68, 0x0f, 0x4c, 0x7c, 0x73, 0xd9, 0x3f, 0xeb, 0x9b, 0xd7, 0x7d, 0x39,
+	0x87, 0x36, 0xa0, 0x7c, 0x65, 0x60, 0xeb, 0xd4, 0xec, 0xcb, 0x02, 0xda, 0x02, 0x38, 0xbb, 0xd4,
+	0x0d, 0xdc, 0x37, 0x06, 0x86, 0x25, 0xe7, 0x91, 0x04, 0x85, 0x9e, 0x79, 0x62, 0xc9, 0x22, 0xfa,
+	0x0f, 0xa4, 0x0b, 0x6c, 0x1e, 0x9f, 0xf6, 0x0c, 0x4b, 0x2e, 0xa0, 0x0a, 0x14, 0xad, 0x0b, 0xa3,
+	0x6d, 0xc9, 0xc5, 0xa8, 0xbf, 0x6d, 0x9e, 0x9f, 0x9f, 0x0e, 0x2c, 0xb9, 0x14, 0xe1, 0x3f, 0x9a,
+	0xba, 0x25, 0x97, 0x5b, 0xdf, 0xf3, 0x50, 0xec, 0x44, 0x2b, 0x41, 0x9d, 0xc5, 0xfb, 0x50, 0x56,
+	0x1f, 0x5c, 0xb2, 0xcf, 0xda, 0x9b, 0x15, 0x29, 0xfa, 0x8c, 0x93, 0xe0, 0xca, 0xf6, 0x42, 0xa2,
+	0xe6, 0x0e, 0x04, 0xa4, 0x43, 0x29, 0xb1, 0x12, 0x7a, 0xb5, 0x20, 0x79, 0x62, 0xae, 0xf5, 0x1c,
+	0x1f, 0xa0, 0x10, 0x59, 0x0a, 0x6d, 0x2f, 0x18, 0x96, 0x2c, 0xb6, 0xbe, 0xff, 0x12, 0xaa, 0x27,
+	0x84, 0x3f, 0x35, 0x04, 0xda, 0x59, 0xd1, 0xf4, 0xd4, 0x2a, 0x6b, 0x69, 0xf5, 0xa3, 0x9f, 0x8f,
+	0x75, 0xe1, 0xd7, 0x63, 0x5d, 0xf8, 0xfd, 0x58, 0x17, 0x3e, 0xed, 0x8f, 0x5d, 0x7e, 0x17, 0x0e,
+	0xb5, 0x91, 0x3f, 0x69, 0x46, 0xef, 0x7c, 0xe6, 0x10, 0xb6, 0x7c, 0xba, 0x6f, 0x35, 0x03, 0x36,
+	0x4a, 0x3e, 0xb2, 0xc3, 0x52, 0xcc, 0x79, 0xf8, 0x67, 0x00, 0x25, 0x29, 0x27, 0x18, 0x7a, 0x05,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -455,6 +609,7 @@ type DebugClient interface {
 	Profile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (Debug_ProfileClient, error)
 	Binary(ctx context.Context, in *BinaryRequest, opts ...grpc.CallOption) (Debug_BinaryClient, error)
 	Dump(ctx context.Context, in *DumpRequest, opts ...grpc.CallOption) (Debug_DumpClient, error)
+	GetProfileHistory(ctx context.Context, in *ProfileHistoryRequest, opts ...grpc.CallOption) (Debug_GetProfileHistoryClient, error)
 }
 
 type debugClient struct {
@@ -561,11 +716,44 @@ func (x *debugDumpClient) Recv() (*types.BytesValue, error) {
 	return m, nil
 }
 
+func (c *debugClient) GetProfileHistory(ctx context.Context, in *ProfileHistoryRequest, opts ...grpc.CallOption) (Debug_GetProfileHistoryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Debug_serviceDesc.Streams[3], "/debug_v2.Debug/GetProfileHistory", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &debugGetProfileHistoryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Debug_GetProfileHistoryClient interface {
+	Recv() (*types.BytesValue, error)
+	grpc.ClientStream
+}
+
+type debugGetProfileHistoryClient struct {
+	grpc.ClientStream
+}
+
+func (x *debugGetProfileHistoryClient) Recv() (*types.BytesValue, error) {
+	m := new(types.BytesValue)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DebugServer is the server API for Debug service.
 type DebugServer interface {
 	Profile(*ProfileRequest, Debug_ProfileServer) error
 	Binary(*BinaryRequest, Debug_BinaryServer) error
 	Dump(*DumpRequest, Debug_DumpServer) error
+	GetProfileHistory(*ProfileHistoryRequest, Debug_GetProfileHistoryServer) error
 }
 
 // UnimplementedDebugServer can be embedded to have forward compatible implementations.
@@ -581,6 +769,9 @@ func (*UnimplementedDebugServer) Binary(req *BinaryRequest, srv Debug_BinaryServ
 func (*UnimplementedDebugServer) Dump(req *DumpRequest, srv Debug_DumpServer) error {
 	return status.Errorf(codes.Unimplemented, "method Dump not implemented")
 }
+func (*UnimplementedDebugServer) GetProfileHistory(req *ProfileHistoryRequest, srv Debug_GetProfileHistoryServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetProfileHistory not implemented")
+}
 
 func RegisterDebugServer(s *grpc.Server, srv DebugServer) {
 	s.RegisterService(&_Debug_serviceDesc, srv)
@@ -649,6 +840,27 @@ func (x *debugDumpServer) Send(m *types.BytesValue) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Debug_GetProfileHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ProfileHistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DebugServer).GetProfileHistory(m, &debugGetProfileHistoryServer{stream})
+}
+
+type Debug_GetProfileHistoryServer interface {
+	Send(*types.BytesValue) error
+	grpc.ServerStream
+}
+
+type debugGetProfileHistoryServer struct {
+	grpc.ServerStream
+}
+
+func (x *debugGetProfileHistoryServer) Send(m *types.BytesValue) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Debug_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "debug_v2.Debug",
 	HandlerType: (*DebugServer)(nil),
@@ -669,6 +881,11 @@ var _Debug_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Debug_Dump_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetProfileHistory",
+			Handler:       _Debug_GetProfileHistory_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "debug/debug.proto",
 }
@@ -972,6 +1189,24 @@ func (m *DumpRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Collectors) > 0 {
+		dAtA8 := make([]byte, len(m.Collectors)*10)
+		var j7 int
+		for _, num := range m.Collectors {
+			for num >= 1<<7 {
+				dAtA8[j7] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j7++
+			}
+			dAtA8[j7] = uint8(num)
+			j7++
+		}
+		i -= j7
+		copy(dAtA[i:], dAtA8[:j7])
+		i = encodeVarintDebug(dAtA, i, uint64(j7))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if m.Limit != 0 {
 		i = encodeVarintDebug(dAtA, i, uint64(m.Limit))
 		i--
@@ -992,6 +1227,78 @@ func (m *DumpRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ProfileHistoryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProfileHistoryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProfileHistoryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.End != nil {
+		{
+			size, err := m.End.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDebug(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Start != nil {
+		{
+			size, err := m.Start.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDebug(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Profiles) > 0 {
+		for iNdEx := len(m.Profiles) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Profiles[iNdEx])
+			copy(dAtA[i:], m.Profiles[iNdEx])
+			i = encodeVarintDebug(dAtA, i, uint64(len(m.Profiles[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Filter != nil {
+		{
+			size, err := m.Filter.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDebug(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintDebug(dAtA []byte, offset int, v uint64) int {
 	offset -= sovDebug(v)
 	base := offset
@@ -1139,6 +1446,43 @@ func (m *DumpRequest) Size() (n int) {
 	if m.Limit != 0 {
 		n += 1 + sovDebug(uint64(m.Limit))
 	}
+	if len(m.Collectors) > 0 {
+		l = 0
+		for _, e := range m.Collectors {
+			l += sovDebug(uint64(e))
+		}
+		n += 1 + sovDebug(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ProfileHistoryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Filter != nil {
+		l = m.Filter.Size()
+		n += 1 + l + sovDebug(uint64(l))
+	}
+	if len(m.Profiles) > 0 {
+		for _, s := range m.Profiles {
+			l = len(s)
+			n += 1 + l + sovDebug(uint64(l))
+		}
+	}
+	if m.Start != nil {
+		l = m.Start.Size()
+		n += 1 + l + sovDebug(uint64(l))
+	}
+	if m.End != nil {
+		l = m.End.Size()
+		n += 1 + l + sovDebug(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1809,6 +2153,266 @@ func (m *DumpRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType == 0 {
+				var v Collector
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDebug
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= Collector(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Collectors = append(m.Collectors, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDebug
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthDebug
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthDebug
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				if elementCount != 0 && len(m.Collectors) == 0 {
+					m.Collectors = make([]Collector, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v Collector
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDebug
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= Collector(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Collectors = append(m.Collectors, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Collectors", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDebug(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDebug
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProfileHistoryRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDebug
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProfileHistoryRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProfileHistoryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Filter", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDebug
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDebug
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Filter == nil {
+				m.Filter = &Filter{}
+			}
+			if err := m.Filter.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Profiles", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDebug
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDebug
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Profiles = append(m.Profiles, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDebug
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDebug
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Start == nil {
+				m.Start = &types.Timestamp{}
+			}
+			if err := m.Start.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDebug
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDebug
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.End == nil {
+				m.End = &types.Timestamp{}
+			}
+			if err := m.End.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDebug(dAtA[iNdEx:])
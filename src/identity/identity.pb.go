@@ -498,6 +498,104 @@ func (m *CreateIDPConnectorResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CreateIDPConnectorResponse proto.InternalMessageInfo
 
+// CheckIdentityProviderRequest checks a connector's configuration without
+// creating it.
+type CheckIdentityProviderRequest struct {
+	Connector            *IDPConnector `protobuf:"bytes,1,opt,name=connector,proto3" json:"connector,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *CheckIdentityProviderRequest) Reset()         { *m = CheckIdentityProviderRequest{} }
+func (m *CheckIdentityProviderRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckIdentityProviderRequest) ProtoMessage()    {}
+func (*CheckIdentityProviderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2433c1f46177a3e0, []int{9}
+}
+func (m *CheckIdentityProviderRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CheckIdentityProviderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CheckIdentityProviderRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CheckIdentityProviderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckIdentityProviderRequest.Merge(m, src)
+}
+func (m *CheckIdentityProviderRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CheckIdentityProviderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckIdentityProviderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckIdentityProviderRequest proto.InternalMessageInfo
+
+func (m *CheckIdentityProviderRequest) GetConnector() *IDPConnector {
+	if m != nil {
+		return m.Connector
+	}
+	return nil
+}
+
+type CheckIdentityProviderResponse struct {
+	// warnings are problems with the configuration that don't prevent the
+	// connector from being created, but are likely to make logins fail.
+	Warnings             []string `protobuf:"bytes,1,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckIdentityProviderResponse) Reset()         { *m = CheckIdentityProviderResponse{} }
+func (m *CheckIdentityProviderResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckIdentityProviderResponse) ProtoMessage()    {}
+func (*CheckIdentityProviderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2433c1f46177a3e0, []int{10}
+}
+func (m *CheckIdentityProviderResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CheckIdentityProviderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CheckIdentityProviderResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CheckIdentityProviderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckIdentityProviderResponse.Merge(m, src)
+}
+func (m *CheckIdentityProviderResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CheckIdentityProviderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckIdentityProviderResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckIdentityProviderResponse proto.InternalMessageInfo
+
+func (m *CheckIdentityProviderResponse) GetWarnings() []string {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
+}
+
 type UpdateIDPConnectorRequest struct {
 	Connector            *IDPConnector `protobuf:"bytes,1,opt,name=connector,proto3" json:"connector,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
@@ -509,7 +607,7 @@ func (m *UpdateIDPConnectorRequest) Reset()         { *m = UpdateIDPConnectorReq
 func (m *UpdateIDPConnectorRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateIDPConnectorRequest) ProtoMessage()    {}
 func (*UpdateIDPConnectorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{9}
+	return fileDescriptor_2433c1f46177a3e0, []int{11}
 }
 func (m *UpdateIDPConnectorRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -555,7 +653,7 @@ func (m *UpdateIDPConnectorResponse) Reset()         { *m = UpdateIDPConnectorRe
 func (m *UpdateIDPConnectorResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateIDPConnectorResponse) ProtoMessage()    {}
 func (*UpdateIDPConnectorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{10}
+	return fileDescriptor_2433c1f46177a3e0, []int{12}
 }
 func (m *UpdateIDPConnectorResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -594,7 +692,7 @@ func (m *ListIDPConnectorsRequest) Reset()         { *m = ListIDPConnectorsReque
 func (m *ListIDPConnectorsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListIDPConnectorsRequest) ProtoMessage()    {}
 func (*ListIDPConnectorsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{11}
+	return fileDescriptor_2433c1f46177a3e0, []int{13}
 }
 func (m *ListIDPConnectorsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -634,7 +732,7 @@ func (m *ListIDPConnectorsResponse) Reset()         { *m = ListIDPConnectorsResp
 func (m *ListIDPConnectorsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListIDPConnectorsResponse) ProtoMessage()    {}
 func (*ListIDPConnectorsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{12}
+	return fileDescriptor_2433c1f46177a3e0, []int{14}
 }
 func (m *ListIDPConnectorsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -681,7 +779,7 @@ func (m *GetIDPConnectorRequest) Reset()         { *m = GetIDPConnectorRequest{}
 func (m *GetIDPConnectorRequest) String() string { return proto.CompactTextString(m) }
 func (*GetIDPConnectorRequest) ProtoMessage()    {}
 func (*GetIDPConnectorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{13}
+	return fileDescriptor_2433c1f46177a3e0, []int{15}
 }
 func (m *GetIDPConnectorRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -728,7 +826,7 @@ func (m *GetIDPConnectorResponse) Reset()         { *m = GetIDPConnectorResponse
 func (m *GetIDPConnectorResponse) String() string { return proto.CompactTextString(m) }
 func (*GetIDPConnectorResponse) ProtoMessage()    {}
 func (*GetIDPConnectorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{14}
+	return fileDescriptor_2433c1f46177a3e0, []int{16}
 }
 func (m *GetIDPConnectorResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -775,7 +873,7 @@ func (m *DeleteIDPConnectorRequest) Reset()         { *m = DeleteIDPConnectorReq
 func (m *DeleteIDPConnectorRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteIDPConnectorRequest) ProtoMessage()    {}
 func (*DeleteIDPConnectorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{15}
+	return fileDescriptor_2433c1f46177a3e0, []int{17}
 }
 func (m *DeleteIDPConnectorRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -821,7 +919,7 @@ func (m *DeleteIDPConnectorResponse) Reset()         { *m = DeleteIDPConnectorRe
 func (m *DeleteIDPConnectorResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteIDPConnectorResponse) ProtoMessage()    {}
 func (*DeleteIDPConnectorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{16}
+	return fileDescriptor_2433c1f46177a3e0, []int{18}
 }
 func (m *DeleteIDPConnectorResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -865,7 +963,7 @@ func (m *OIDCClient) Reset()         { *m = OIDCClient{} }
 func (m *OIDCClient) String() string { return proto.CompactTextString(m) }
 func (*OIDCClient) ProtoMessage()    {}
 func (*OIDCClient) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{17}
+	return fileDescriptor_2433c1f46177a3e0, []int{19}
 }
 func (m *OIDCClient) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -940,7 +1038,7 @@ func (m *CreateOIDCClientRequest) Reset()         { *m = CreateOIDCClientRequest
 func (m *CreateOIDCClientRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateOIDCClientRequest) ProtoMessage()    {}
 func (*CreateOIDCClientRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{18}
+	return fileDescriptor_2433c1f46177a3e0, []int{20}
 }
 func (m *CreateOIDCClientRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -987,7 +1085,7 @@ func (m *CreateOIDCClientResponse) Reset()         { *m = CreateOIDCClientRespon
 func (m *CreateOIDCClientResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateOIDCClientResponse) ProtoMessage()    {}
 func (*CreateOIDCClientResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{19}
+	return fileDescriptor_2433c1f46177a3e0, []int{21}
 }
 func (m *CreateOIDCClientResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1034,7 +1132,7 @@ func (m *GetOIDCClientRequest) Reset()         { *m = GetOIDCClientRequest{} }
 func (m *GetOIDCClientRequest) String() string { return proto.CompactTextString(m) }
 func (*GetOIDCClientRequest) ProtoMessage()    {}
 func (*GetOIDCClientRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{20}
+	return fileDescriptor_2433c1f46177a3e0, []int{22}
 }
 func (m *GetOIDCClientRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1081,7 +1179,7 @@ func (m *GetOIDCClientResponse) Reset()         { *m = GetOIDCClientResponse{} }
 func (m *GetOIDCClientResponse) String() string { return proto.CompactTextString(m) }
 func (*GetOIDCClientResponse) ProtoMessage()    {}
 func (*GetOIDCClientResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{21}
+	return fileDescriptor_2433c1f46177a3e0, []int{23}
 }
 func (m *GetOIDCClientResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1127,7 +1225,7 @@ func (m *ListOIDCClientsRequest) Reset()         { *m = ListOIDCClientsRequest{}
 func (m *ListOIDCClientsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListOIDCClientsRequest) ProtoMessage()    {}
 func (*ListOIDCClientsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{22}
+	return fileDescriptor_2433c1f46177a3e0, []int{24}
 }
 func (m *ListOIDCClientsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1167,7 +1265,7 @@ func (m *ListOIDCClientsResponse) Reset()         { *m = ListOIDCClientsResponse
 func (m *ListOIDCClientsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListOIDCClientsResponse) ProtoMessage()    {}
 func (*ListOIDCClientsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{23}
+	return fileDescriptor_2433c1f46177a3e0, []int{25}
 }
 func (m *ListOIDCClientsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1214,7 +1312,7 @@ func (m *UpdateOIDCClientRequest) Reset()         { *m = UpdateOIDCClientRequest
 func (m *UpdateOIDCClientRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateOIDCClientRequest) ProtoMessage()    {}
 func (*UpdateOIDCClientRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{24}
+	return fileDescriptor_2433c1f46177a3e0, []int{26}
 }
 func (m *UpdateOIDCClientRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1260,7 +1358,7 @@ func (m *UpdateOIDCClientResponse) Reset()         { *m = UpdateOIDCClientRespon
 func (m *UpdateOIDCClientResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateOIDCClientResponse) ProtoMessage()    {}
 func (*UpdateOIDCClientResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{25}
+	return fileDescriptor_2433c1f46177a3e0, []int{27}
 }
 func (m *UpdateOIDCClientResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1300,7 +1398,7 @@ func (m *DeleteOIDCClientRequest) Reset()         { *m = DeleteOIDCClientRequest
 func (m *DeleteOIDCClientRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteOIDCClientRequest) ProtoMessage()    {}
 func (*DeleteOIDCClientRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{26}
+	return fileDescriptor_2433c1f46177a3e0, []int{28}
 }
 func (m *DeleteOIDCClientRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1346,7 +1444,7 @@ func (m *DeleteOIDCClientResponse) Reset()         { *m = DeleteOIDCClientRespon
 func (m *DeleteOIDCClientResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteOIDCClientResponse) ProtoMessage()    {}
 func (*DeleteOIDCClientResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{27}
+	return fileDescriptor_2433c1f46177a3e0, []int{29}
 }
 func (m *DeleteOIDCClientResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1385,7 +1483,7 @@ func (m *DeleteAllRequest) Reset()         { *m = DeleteAllRequest{} }
 func (m *DeleteAllRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteAllRequest) ProtoMessage()    {}
 func (*DeleteAllRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{28}
+	return fileDescriptor_2433c1f46177a3e0, []int{30}
 }
 func (m *DeleteAllRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1424,7 +1522,7 @@ func (m *DeleteAllResponse) Reset()         { *m = DeleteAllResponse{} }
 func (m *DeleteAllResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteAllResponse) ProtoMessage()    {}
 func (*DeleteAllResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2433c1f46177a3e0, []int{29}
+	return fileDescriptor_2433c1f46177a3e0, []int{31}
 }
 func (m *DeleteAllResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1463,6 +1561,8 @@ func init() {
 	proto.RegisterType((*IDPConnector)(nil), "identity_v2.IDPConnector")
 	proto.RegisterType((*CreateIDPConnectorRequest)(nil), "identity_v2.CreateIDPConnectorRequest")
 	proto.RegisterType((*CreateIDPConnectorResponse)(nil), "identity_v2.CreateIDPConnectorResponse")
+	proto.RegisterType((*CheckIdentityProviderRequest)(nil), "identity_v2.CheckIdentityProviderRequest")
+	proto.RegisterType((*CheckIdentityProviderResponse)(nil), "identity_v2.CheckIdentityProviderResponse")
 	proto.RegisterType((*UpdateIDPConnectorRequest)(nil), "identity_v2.UpdateIDPConnectorRequest")
 	proto.RegisterType((*UpdateIDPConnectorResponse)(nil), "identity_v2.UpdateIDPConnectorResponse")
 	proto.RegisterType((*ListIDPConnectorsRequest)(nil), "identity_v2.ListIDPConnectorsRequest")
@@ -1489,70 +1589,73 @@ func init() {
 func init() { proto.RegisterFile("identity/identity.proto", fileDescriptor_2433c1f46177a3e0) }
 
 var fileDescriptor_2433c1f46177a3e0 = []byte{
-	// 998 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xd1, 0x6e, 0xe3, 0x44,
-	0x14, 0x8d, 0x9b, 0x6e, 0x50, 0x6e, 0xb7, 0xec, 0x76, 0x36, 0xdb, 0x38, 0xd6, 0x92, 0xa4, 0xde,
-	0x6e, 0x09, 0x5a, 0x94, 0x88, 0xf0, 0x80, 0x40, 0x3c, 0xd0, 0xa6, 0x28, 0x14, 0x2a, 0x51, 0x79,
-	0xdb, 0x15, 0x02, 0x44, 0xe4, 0xda, 0x77, 0xd3, 0x59, 0x12, 0x3b, 0xcc, 0x4c, 0x2a, 0xfa, 0x07,
-	0x3c, 0xf0, 0x80, 0xf8, 0x09, 0x7e, 0x84, 0x07, 0x1e, 0xf9, 0x82, 0x82, 0xfa, 0x09, 0xfd, 0x02,
-	0x64, 0x7b, 0x9c, 0x3a, 0x63, 0x3b, 0xd9, 0x2d, 0xf0, 0x36, 0xbe, 0x73, 0xe6, 0x9e, 0x3b, 0xe7,
-	0xde, 0x9c, 0x51, 0xa0, 0x4a, 0x5d, 0xf4, 0x04, 0x15, 0x17, 0x9d, 0x78, 0xd1, 0x9e, 0x30, 0x5f,
-	0xf8, 0x64, 0x2d, 0xfe, 0x1e, 0x9c, 0x77, 0x8d, 0xc6, 0xd0, 0xf7, 0x87, 0x23, 0xec, 0x84, 0x5b,
-	0xa7, 0xd3, 0x17, 0x1d, 0x41, 0xc7, 0xc8, 0x85, 0x3d, 0x9e, 0x44, 0x68, 0xa3, 0x32, 0xf4, 0x87,
-	0x7e, 0xb8, 0xec, 0x04, 0xab, 0x28, 0x6a, 0xfe, 0xa4, 0xc1, 0xea, 0x09, 0x47, 0x46, 0x2a, 0x70,
-	0x07, 0xc7, 0x36, 0x1d, 0xe9, 0x5a, 0x53, 0x6b, 0x95, 0xad, 0xe8, 0x83, 0xbc, 0x04, 0x32, 0xb2,
-	0xb9, 0x18, 0xd8, 0x53, 0x71, 0x16, 0x70, 0x39, 0xb6, 0x40, 0x57, 0x5f, 0x69, 0x6a, 0xad, 0xb5,
-	0xae, 0xd1, 0x8e, 0x28, 0xdb, 0x31, 0x65, 0xfb, 0x38, 0xa6, 0xdc, 0x6b, 0x5c, 0x5f, 0x36, 0xaa,
-	0xee, 0xe9, 0x47, 0x66, 0xfa, 0xb4, 0xf9, 0xcb, 0x5f, 0x0d, 0xcd, 0xda, 0x08, 0x36, 0x76, 0xe7,
-	0xe2, 0xbf, 0x6b, 0x50, 0x39, 0x90, 0x37, 0x7a, 0x86, 0xec, 0x1c, 0x59, 0xcf, 0xf7, 0x5e, 0xd0,
-	0x21, 0xd9, 0x84, 0x12, 0xe5, 0x7c, 0x8a, 0x4c, 0xd6, 0x26, 0xbf, 0xc8, 0x27, 0x70, 0x8f, 0xba,
-	0x03, 0xe1, 0x7f, 0x8f, 0xde, 0x00, 0x7f, 0x9c, 0x50, 0x76, 0x11, 0x56, 0x56, 0xde, 0xd3, 0xaf,
-	0x2f, 0x1b, 0x95, 0x80, 0x5d, 0xd9, 0x36, 0xad, 0x75, 0xea, 0x1e, 0x07, 0x81, 0x4f, 0xc3, 0x6f,
-	0x62, 0xc1, 0x43, 0xe6, 0x0b, 0x5b, 0x50, 0xdf, 0x9b, 0xcf, 0x53, 0x0c, 0xf3, 0xd4, 0xaf, 0x2f,
-	0x1b, 0x46, 0x90, 0x27, 0x13, 0x64, 0x5a, 0x0f, 0xe2, 0x78, 0x22, 0xa7, 0xf9, 0x0d, 0xd4, 0x9f,
-	0xa1, 0xc8, 0xba, 0x88, 0x85, 0x3f, 0x4c, 0x91, 0x0b, 0xf2, 0x21, 0x94, 0x9c, 0x30, 0x10, 0xde,
-	0x67, 0xad, 0xbb, 0xd5, 0x4e, 0x34, 0xb2, 0x9d, 0x79, 0x52, 0x1e, 0x30, 0xb7, 0xa0, 0x91, 0x9b,
-	0x9c, 0x4f, 0x7c, 0x8f, 0xa3, 0xd9, 0x84, 0x7a, 0x7f, 0x21, 0xbf, 0xf9, 0x2d, 0x34, 0xfa, 0x8b,
-	0x93, 0xfc, 0x9b, 0x12, 0x7f, 0xd6, 0xe0, 0xee, 0xc1, 0xfe, 0x51, 0xcf, 0xf7, 0x3c, 0x74, 0x84,
-	0xcf, 0xc8, 0x9b, 0xb0, 0x42, 0x5d, 0xd9, 0xba, 0x15, 0xea, 0x12, 0x02, 0xab, 0x9e, 0x3d, 0xc6,
-	0xa8, 0x57, 0x56, 0xb8, 0x0e, 0x62, 0xe2, 0x62, 0x82, 0x91, 0xee, 0x56, 0xb8, 0x26, 0xdb, 0xb0,
-	0x1e, 0xa5, 0x7c, 0x8e, 0x8c, 0x53, 0xdf, 0xd3, 0x57, 0x9b, 0x5a, 0xab, 0x68, 0xcd, 0x07, 0x49,
-	0x1d, 0xe0, 0x25, 0xf7, 0xbd, 0xa8, 0x08, 0xfd, 0x4e, 0x78, 0x3e, 0x11, 0x31, 0x8f, 0xa1, 0xd6,
-	0x63, 0x68, 0x0b, 0x4c, 0xd6, 0x14, 0x77, 0xe2, 0x03, 0x28, 0x3b, 0x71, 0x4c, 0xde, 0xb4, 0x36,
-	0x7f, 0xd3, 0xe4, 0xa1, 0x1b, 0xac, 0xf9, 0x08, 0x8c, 0xac, 0xac, 0xb2, 0x05, 0xc7, 0x50, 0x3b,
-	0x99, 0xb8, 0xff, 0x03, 0x67, 0x56, 0x56, 0xc9, 0x69, 0x80, 0x7e, 0x48, 0xb9, 0x48, 0xee, 0xf1,
-	0xb8, 0xe1, 0xcf, 0xa1, 0x96, 0xb1, 0x37, 0x6b, 0x35, 0xcc, 0x38, 0xb8, 0xae, 0x35, 0x8b, 0x8b,
-	0x0b, 0x4a, 0x80, 0xcd, 0x16, 0x6c, 0x06, 0x83, 0x94, 0x71, 0x49, 0xa5, 0xe7, 0xa6, 0x05, 0xd5,
-	0x14, 0x52, 0xf2, 0xdf, 0x5a, 0x8f, 0xa7, 0x50, 0xdb, 0xc7, 0x11, 0x66, 0xab, 0xac, 0x16, 0xf0,
-	0x08, 0x8c, 0x2c, 0xb0, 0x14, 0xef, 0x57, 0x0d, 0xe0, 0xcb, 0x83, 0xfd, 0x5e, 0x6f, 0x44, 0xd1,
-	0x4b, 0x1d, 0x26, 0x8f, 0x61, 0x9d, 0xa1, 0x4b, 0x19, 0x3a, 0x62, 0x30, 0x65, 0x94, 0xeb, 0x2b,
-	0xcd, 0x62, 0xab, 0x6c, 0xdd, 0x8d, 0x83, 0x27, 0x8c, 0xf2, 0x00, 0x24, 0xd8, 0x94, 0x0b, 0x74,
-	0x07, 0x13, 0x44, 0xc6, 0xf5, 0x62, 0x04, 0x92, 0xc1, 0xa3, 0x20, 0x36, 0x9b, 0xfd, 0xd5, 0xc4,
-	0xec, 0x6f, 0x42, 0x89, 0xa3, 0xc3, 0x50, 0xc8, 0xe9, 0x95, 0x5f, 0xe6, 0xe7, 0x50, 0x8d, 0x66,
-	0xec, 0xa6, 0xb2, 0xf8, 0x76, 0x1d, 0x28, 0x39, 0x61, 0x40, 0x0a, 0x56, 0x9d, 0x13, 0x2c, 0x81,
-	0x97, 0x30, 0xf3, 0x0b, 0xd0, 0xd3, 0xb9, 0x64, 0x03, 0x5e, 0x3b, 0xd9, 0x0e, 0x54, 0xfa, 0x28,
-	0xd2, 0x55, 0xa9, 0x9a, 0x7f, 0x06, 0x0f, 0x15, 0xdc, 0x6d, 0x19, 0x75, 0xd8, 0x0c, 0x06, 0xf8,
-	0x66, 0x67, 0x36, 0xda, 0x87, 0x50, 0x4d, 0xed, 0x48, 0x96, 0xf7, 0xe0, 0x8d, 0xe8, 0x78, 0x3c,
-	0xd5, 0xb9, 0x34, 0x31, 0x2e, 0x90, 0x3c, 0xfa, 0x89, 0xfd, 0x07, 0x92, 0x1b, 0xa0, 0xa7, 0x73,
-	0xc9, 0x79, 0x7b, 0x07, 0xaa, 0xd1, 0x34, 0x2e, 0x17, 0xd1, 0x00, 0x3d, 0x0d, 0x95, 0x69, 0x08,
-	0xdc, 0x8f, 0xf6, 0x76, 0x47, 0xa3, 0x58, 0x90, 0x07, 0xb0, 0x91, 0x88, 0x45, 0xc0, 0xee, 0x6f,
-	0x00, 0xc5, 0xdd, 0xa3, 0x03, 0x72, 0x0e, 0xd5, 0x9c, 0xe7, 0x83, 0x3c, 0x9d, 0xbb, 0xcf, 0xe2,
-	0x17, 0xcc, 0x78, 0xf7, 0xd5, 0xc0, 0xb2, 0xcc, 0x42, 0xc0, 0xdb, 0x7f, 0x25, 0xde, 0xfe, 0xeb,
-	0xf0, 0xf6, 0x97, 0xf2, 0x0e, 0x81, 0xa4, 0x6d, 0x9a, 0xec, 0xcc, 0x65, 0xc9, 0x7d, 0x1d, 0x8c,
-	0xb7, 0x97, 0xe2, 0x92, 0x44, 0x69, 0x6f, 0x56, 0x88, 0x72, 0x9f, 0x04, 0x85, 0x68, 0x81, 0xc9,
-	0x17, 0x88, 0x0b, 0x1b, 0x29, 0x2b, 0x27, 0x4f, 0xe6, 0xce, 0xe7, 0x3d, 0x03, 0xc6, 0xce, 0x32,
-	0xd8, 0x8c, 0xe5, 0x3b, 0xb8, 0xa7, 0xd8, 0x35, 0x79, 0x9c, 0x92, 0x3e, 0xe3, 0x22, 0xdb, 0x8b,
-	0x41, 0x49, 0xb9, 0xd2, 0x6e, 0xac, 0xc8, 0x95, 0xeb, 0xed, 0x8a, 0x5c, 0x0b, 0x6c, 0xbd, 0x40,
-	0x6c, 0xb8, 0xaf, 0xfa, 0x1e, 0xd9, 0xce, 0x68, 0x6b, 0xea, 0x77, 0x68, 0x3c, 0x59, 0x82, 0x4a,
-	0x52, 0xa8, 0xbf, 0x73, 0x85, 0x22, 0xc7, 0x52, 0x14, 0x8a, 0x5c, 0xb3, 0x28, 0x90, 0xaf, 0x60,
-	0x7d, 0xce, 0x48, 0xc9, 0x96, 0xaa, 0x73, 0x3a, 0xb9, 0xb9, 0x08, 0x92, 0x6c, 0xb4, 0x62, 0x9f,
-	0x4a, 0xa3, 0xb3, 0x6d, 0x57, 0x69, 0x74, 0x8e, 0x03, 0x47, 0xe2, 0xa8, 0xee, 0xa5, 0x88, 0x93,
-	0xe3, 0x83, 0x8a, 0x38, 0xb9, 0x16, 0x58, 0x20, 0x87, 0x50, 0x9e, 0x19, 0x1e, 0x79, 0x2b, 0xe3,
-	0xd4, 0x8d, 0x39, 0x1a, 0xf5, 0xbc, 0xed, 0x38, 0xdb, 0xde, 0xc7, 0x7f, 0x5c, 0xd5, 0xb5, 0x3f,
-	0xaf, 0xea, 0xda, 0xdf, 0x57, 0x75, 0xed, 0xeb, 0xf6, 0x90, 0x8a, 0xb3, 0xe9, 0x69, 0xdb, 0xf1,
-	0xc7, 0x9d, 0x89, 0xed, 0x9c, 0x5d, 0xb8, 0xc8, 0x92, 0xab, 0xf3, 0x6e, 0x87, 0x33, 0x67, 0xf6,
-	0xbf, 0xec, 0xb4, 0x14, 0xfe, 0x15, 0x7a, 0xff, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x90, 0x3d,
-	0x4d, 0x55, 0xb3, 0x0d, 0x00, 0x00,
+	// 1047 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xdd, 0x72, 0xdb, 0x44,
+	0x14, 0x46, 0x49, 0x6a, 0xf0, 0x49, 0x43, 0x9b, 0xad, 0x13, 0x2b, 0x3b, 0xa9, 0xed, 0xa8, 0xf9,
+	0x71, 0x29, 0x63, 0x0f, 0xe6, 0x82, 0xe1, 0xe7, 0x82, 0xc4, 0x61, 0x4c, 0x20, 0x33, 0x64, 0xd4,
+	0xa4, 0x30, 0xc0, 0xe0, 0x51, 0xa4, 0xad, 0xb3, 0xad, 0x2d, 0x99, 0xdd, 0xb5, 0x21, 0x6f, 0xc0,
+	0x05, 0x17, 0x0c, 0x77, 0xbc, 0x0f, 0x17, 0x5c, 0xf2, 0x04, 0x81, 0xc9, 0x23, 0xe4, 0x09, 0x18,
+	0x49, 0x2b, 0x45, 0xbf, 0x56, 0xda, 0xd2, 0x3b, 0xed, 0xd9, 0x6f, 0xcf, 0xb7, 0xe7, 0x3b, 0x67,
+	0xcf, 0x19, 0x41, 0x95, 0x5a, 0xc4, 0x16, 0x54, 0x9c, 0xb7, 0x83, 0x8f, 0xd6, 0x98, 0x39, 0xc2,
+	0x41, 0x8b, 0xc1, 0xba, 0x3f, 0xed, 0xe0, 0xfa, 0xc0, 0x71, 0x06, 0x43, 0xd2, 0xf6, 0xb6, 0x4e,
+	0x27, 0x4f, 0xdb, 0x82, 0x8e, 0x08, 0x17, 0xc6, 0x68, 0xec, 0xa3, 0x71, 0x65, 0xe0, 0x0c, 0x1c,
+	0xef, 0xb3, 0xed, 0x7e, 0xf9, 0x56, 0xed, 0x17, 0x05, 0x16, 0x4e, 0x38, 0x61, 0xa8, 0x02, 0xb7,
+	0xc8, 0xc8, 0xa0, 0x43, 0x55, 0x69, 0x28, 0xcd, 0xb2, 0xee, 0x2f, 0xd0, 0x33, 0x40, 0x43, 0x83,
+	0x8b, 0xbe, 0x31, 0x11, 0x67, 0x2e, 0x97, 0x69, 0x08, 0x62, 0xa9, 0x73, 0x0d, 0xa5, 0xb9, 0xd8,
+	0xc1, 0x2d, 0x9f, 0xb2, 0x15, 0x50, 0xb6, 0x8e, 0x03, 0xca, 0xbd, 0xfa, 0xd5, 0x45, 0xbd, 0x6a,
+	0x9d, 0x7e, 0xa4, 0xa5, 0x4f, 0x6b, 0xbf, 0xfd, 0x53, 0x57, 0xf4, 0x65, 0x77, 0x63, 0x37, 0x66,
+	0xff, 0x53, 0x81, 0xca, 0x81, 0x8c, 0xe8, 0x31, 0x61, 0x53, 0xc2, 0xba, 0x8e, 0xfd, 0x94, 0x0e,
+	0xd0, 0x2a, 0x94, 0x28, 0xe7, 0x13, 0xc2, 0xe4, 0xdd, 0xe4, 0x0a, 0x7d, 0x0a, 0x77, 0xa8, 0xd5,
+	0x17, 0xce, 0x73, 0x62, 0xf7, 0xc9, 0xcf, 0x63, 0xca, 0xce, 0xbd, 0x9b, 0x95, 0xf7, 0xd4, 0xab,
+	0x8b, 0x7a, 0xc5, 0x65, 0x4f, 0x6c, 0x6b, 0xfa, 0x12, 0xb5, 0x8e, 0x5d, 0xc3, 0x67, 0xde, 0x1a,
+	0xe9, 0xb0, 0xc2, 0x1c, 0x61, 0x08, 0xea, 0xd8, 0x71, 0x3f, 0xf3, 0x9e, 0x9f, 0xda, 0xd5, 0x45,
+	0x1d, 0xbb, 0x7e, 0x32, 0x41, 0x9a, 0x7e, 0x2f, 0xb0, 0x47, 0x7c, 0x6a, 0xdf, 0x41, 0xed, 0x31,
+	0x11, 0x59, 0x81, 0xe8, 0xe4, 0xc7, 0x09, 0xe1, 0x02, 0x7d, 0x08, 0x25, 0xd3, 0x33, 0x78, 0xf1,
+	0x2c, 0x76, 0x36, 0x5a, 0x91, 0x44, 0xb6, 0x32, 0x4f, 0xca, 0x03, 0xda, 0x06, 0xd4, 0x73, 0x9d,
+	0xf3, 0xb1, 0x63, 0x73, 0xa2, 0x35, 0xa0, 0xd6, 0x9b, 0xc9, 0xaf, 0x7d, 0x0f, 0xf5, 0xde, 0x6c,
+	0x27, 0xaf, 0x72, 0xc5, 0x5f, 0x15, 0xb8, 0x7d, 0xb0, 0x7f, 0xd4, 0x75, 0x6c, 0x9b, 0x98, 0xc2,
+	0x61, 0xe8, 0x6d, 0x98, 0xa3, 0x96, 0x4c, 0xdd, 0x1c, 0xb5, 0x10, 0x82, 0x05, 0xdb, 0x18, 0x11,
+	0x3f, 0x57, 0xba, 0xf7, 0xed, 0xda, 0xc4, 0xf9, 0x98, 0xf8, 0xba, 0xeb, 0xde, 0x37, 0xda, 0x84,
+	0x25, 0xdf, 0xe5, 0x13, 0xc2, 0x38, 0x75, 0x6c, 0x75, 0xa1, 0xa1, 0x34, 0xe7, 0xf5, 0xb8, 0x11,
+	0xd5, 0x00, 0x9e, 0x71, 0xc7, 0xf6, 0x2f, 0xa1, 0xde, 0xf2, 0xce, 0x47, 0x2c, 0xda, 0x31, 0xac,
+	0x75, 0x19, 0x31, 0x04, 0x89, 0xde, 0x29, 0xc8, 0xc4, 0x07, 0x50, 0x36, 0x03, 0x9b, 0x8c, 0x74,
+	0x2d, 0x1e, 0x69, 0xf4, 0xd0, 0x35, 0x56, 0x5b, 0x07, 0x9c, 0xe5, 0x55, 0xa6, 0xe0, 0x6b, 0x58,
+	0xef, 0x9e, 0x11, 0xf3, 0x79, 0xa0, 0xd3, 0x11, 0x73, 0xa6, 0xd4, 0x22, 0xaf, 0x4e, 0xfb, 0x31,
+	0xdc, 0xcf, 0x71, 0x2c, 0xf3, 0x86, 0xe1, 0xad, 0x9f, 0x0c, 0x66, 0x53, 0x7b, 0xc0, 0x55, 0xa5,
+	0x31, 0xdf, 0x2c, 0xeb, 0xe1, 0xda, 0x55, 0xe2, 0x64, 0x6c, 0xbd, 0x06, 0x25, 0xb2, 0xbc, 0x4a,
+	0x25, 0x30, 0xa8, 0x87, 0x94, 0x8b, 0xe8, 0x1e, 0x0f, 0xca, 0xf0, 0x09, 0xac, 0x65, 0xec, 0x85,
+	0x05, 0x08, 0x21, 0x87, 0x1f, 0xca, 0xcc, 0x0b, 0x45, 0xc0, 0x5a, 0x13, 0x56, 0xdd, 0xf2, 0xce,
+	0x08, 0x32, 0x51, 0x89, 0x9a, 0x0e, 0xd5, 0x14, 0x52, 0xf2, 0xbf, 0xb4, 0x1e, 0x8f, 0x60, 0x6d,
+	0x9f, 0x0c, 0x89, 0x20, 0x37, 0xb9, 0xc0, 0x3a, 0xe0, 0x2c, 0xb0, 0x14, 0xef, 0x77, 0x05, 0xe0,
+	0xab, 0x83, 0xfd, 0x6e, 0x77, 0x48, 0x89, 0x9d, 0x3a, 0x8c, 0x1e, 0xc0, 0x12, 0x23, 0x16, 0x65,
+	0xc4, 0x14, 0xfd, 0x09, 0xa3, 0x5c, 0x9d, 0xf3, 0x12, 0x7e, 0x3b, 0x30, 0x9e, 0x30, 0xca, 0x5d,
+	0x90, 0x60, 0x13, 0x2e, 0x88, 0xd5, 0x1f, 0x13, 0xc2, 0xb8, 0x3a, 0xef, 0x83, 0xa4, 0xf1, 0xc8,
+	0xb5, 0x85, 0x2f, 0x72, 0x21, 0xf2, 0x22, 0x57, 0xa1, 0xc4, 0x89, 0xc9, 0x88, 0x90, 0x6f, 0x4a,
+	0xae, 0xb4, 0x2f, 0xa0, 0xea, 0x57, 0xfe, 0xf5, 0xcd, 0x82, 0xe8, 0xda, 0x50, 0x32, 0x3d, 0x83,
+	0x14, 0xac, 0x1a, 0x13, 0x2c, 0x82, 0x97, 0x30, 0xed, 0x4b, 0x50, 0xd3, 0xbe, 0x64, 0x02, 0x5e,
+	0xd8, 0xd9, 0x36, 0x54, 0x7a, 0x44, 0xa4, 0x6f, 0x95, 0xd4, 0xfc, 0x73, 0x58, 0x49, 0xe0, 0x5e,
+	0x96, 0x51, 0x85, 0x55, 0xb7, 0x80, 0xaf, 0x77, 0xc2, 0xd2, 0x3e, 0x84, 0x6a, 0x6a, 0x47, 0xb2,
+	0xbc, 0x07, 0x6f, 0xfa, 0xc7, 0x83, 0xaa, 0xce, 0xa5, 0x09, 0x70, 0xae, 0xe4, 0xfe, 0x13, 0xfb,
+	0x1f, 0x24, 0xc7, 0xa0, 0xa6, 0x7d, 0xc9, 0x7a, 0x7b, 0x08, 0x55, 0xbf, 0x1a, 0x8b, 0x45, 0xc4,
+	0xa0, 0xa6, 0xa1, 0xd2, 0x0d, 0x82, 0xbb, 0xfe, 0xde, 0xee, 0x70, 0x18, 0x08, 0x72, 0x0f, 0x96,
+	0x23, 0x36, 0x1f, 0xd8, 0xf9, 0x63, 0x11, 0xe6, 0x77, 0x8f, 0x0e, 0xd0, 0x14, 0xaa, 0x39, 0x43,
+	0x0d, 0x3d, 0x8a, 0xc5, 0x33, 0x7b, 0xae, 0xe2, 0x77, 0x6f, 0x06, 0x96, 0xd7, 0x7c, 0x03, 0x4d,
+	0xfd, 0xe7, 0x5f, 0xcc, 0xdb, 0x7b, 0x11, 0xde, 0x5e, 0x21, 0xef, 0x00, 0x50, 0x7a, 0x78, 0xa0,
+	0xed, 0x98, 0x97, 0xdc, 0x99, 0x85, 0x77, 0x0a, 0x71, 0x21, 0xd1, 0x18, 0x56, 0x32, 0xc7, 0x05,
+	0x7a, 0x18, 0xf7, 0x31, 0x63, 0x56, 0xe1, 0x77, 0x6e, 0x02, 0x8d, 0x86, 0x96, 0x9e, 0x06, 0x89,
+	0xd0, 0x72, 0x87, 0x10, 0xde, 0x29, 0xc4, 0x85, 0x44, 0x16, 0x2c, 0xa7, 0x86, 0x07, 0xda, 0x8a,
+	0x9d, 0xcf, 0x1b, 0x3c, 0x78, 0xbb, 0x08, 0x16, 0xb2, 0xfc, 0x00, 0x77, 0x12, 0x03, 0x02, 0x3d,
+	0x48, 0x25, 0x3b, 0x23, 0x90, 0xcd, 0xd9, 0xa0, 0xa8, 0x5c, 0xe9, 0xfe, 0x9f, 0x90, 0x2b, 0x77,
+	0x9a, 0xe0, 0x9d, 0x42, 0x5c, 0x48, 0x64, 0xc0, 0xdd, 0x64, 0xa7, 0x45, 0x9b, 0x19, 0x85, 0x94,
+	0x7a, 0xf9, 0x78, 0xab, 0x00, 0x15, 0xa5, 0x48, 0x76, 0x96, 0x04, 0x45, 0x4e, 0x13, 0xc3, 0x5b,
+	0x05, 0xa8, 0x90, 0xe2, 0x1b, 0x58, 0x8a, 0xb5, 0x6e, 0xb4, 0x91, 0xd4, 0x39, 0xed, 0x5c, 0x9b,
+	0x05, 0x89, 0x26, 0x3a, 0xd1, 0xb0, 0x13, 0x89, 0xce, 0x6e, 0xf4, 0x78, 0x73, 0x36, 0x28, 0x2a,
+	0x4e, 0xb2, 0x5f, 0x26, 0xc4, 0xc9, 0xe9, 0xbc, 0x78, 0xab, 0x00, 0x15, 0x52, 0x1c, 0x42, 0x39,
+	0x6c, 0xb1, 0xe8, 0x7e, 0xc6, 0xa9, 0xeb, 0x76, 0x8c, 0x6b, 0x79, 0xdb, 0x81, 0xb7, 0xbd, 0x4f,
+	0xfe, 0xba, 0xac, 0x29, 0x7f, 0x5f, 0xd6, 0x94, 0x7f, 0x2f, 0x6b, 0xca, 0xb7, 0xad, 0x01, 0x15,
+	0x67, 0x93, 0xd3, 0x96, 0xe9, 0x8c, 0xda, 0x63, 0xc3, 0x3c, 0x3b, 0xb7, 0x08, 0x8b, 0x7e, 0x4d,
+	0x3b, 0x6d, 0xce, 0xcc, 0xf0, 0xff, 0xf4, 0xb4, 0xe4, 0xfd, 0x12, 0xbe, 0xff, 0xdf, 0x00, 0x57,
+	0x85, 0x8c, 0x88, 0xbb, 0x0e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1570,6 +1673,10 @@ type APIClient interface {
 	SetIdentityServerConfig(ctx context.Context, in *SetIdentityServerConfigRequest, opts ...grpc.CallOption) (*SetIdentityServerConfigResponse, error)
 	GetIdentityServerConfig(ctx context.Context, in *GetIdentityServerConfigRequest, opts ...grpc.CallOption) (*GetIdentityServerConfigResponse, error)
 	CreateIDPConnector(ctx context.Context, in *CreateIDPConnectorRequest, opts ...grpc.CallOption) (*CreateIDPConnectorResponse, error)
+	// CheckIdentityProvider validates a connector's configuration, including
+	// contacting the identity provider if its type requires it, without
+	// creating the connector.
+	CheckIdentityProvider(ctx context.Context, in *CheckIdentityProviderRequest, opts ...grpc.CallOption) (*CheckIdentityProviderResponse, error)
 	UpdateIDPConnector(ctx context.Context, in *UpdateIDPConnectorRequest, opts ...grpc.CallOption) (*UpdateIDPConnectorResponse, error)
 	ListIDPConnectors(ctx context.Context, in *ListIDPConnectorsRequest, opts ...grpc.CallOption) (*ListIDPConnectorsResponse, error)
 	GetIDPConnector(ctx context.Context, in *GetIDPConnectorRequest, opts ...grpc.CallOption) (*GetIDPConnectorResponse, error)
@@ -1617,6 +1724,15 @@ func (c *aPIClient) CreateIDPConnector(ctx context.Context, in *CreateIDPConnect
 	return out, nil
 }
 
+func (c *aPIClient) CheckIdentityProvider(ctx context.Context, in *CheckIdentityProviderRequest, opts ...grpc.CallOption) (*CheckIdentityProviderResponse, error) {
+	out := new(CheckIdentityProviderResponse)
+	err := c.cc.Invoke(ctx, "/identity_v2.API/CheckIdentityProvider", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) UpdateIDPConnector(ctx context.Context, in *UpdateIDPConnectorRequest, opts ...grpc.CallOption) (*UpdateIDPConnectorResponse, error) {
 	out := new(UpdateIDPConnectorResponse)
 	err := c.cc.Invoke(ctx, "/identity_v2.API/UpdateIDPConnector", in, out, opts...)
@@ -1712,6 +1828,10 @@ type APIServer interface {
 	SetIdentityServerConfig(context.Context, *SetIdentityServerConfigRequest) (*SetIdentityServerConfigResponse, error)
 	GetIdentityServerConfig(context.Context, *GetIdentityServerConfigRequest) (*GetIdentityServerConfigResponse, error)
 	CreateIDPConnector(context.Context, *CreateIDPConnectorRequest) (*CreateIDPConnectorResponse, error)
+	// CheckIdentityProvider validates a connector's configuration, including
+	// contacting the identity provider if its type requires it, without
+	// creating the connector.
+	CheckIdentityProvider(context.Context, *CheckIdentityProviderRequest) (*CheckIdentityProviderResponse, error)
 	UpdateIDPConnector(context.Context, *UpdateIDPConnectorRequest) (*UpdateIDPConnectorResponse, error)
 	ListIDPConnectors(context.Context, *ListIDPConnectorsRequest) (*ListIDPConnectorsResponse, error)
 	GetIDPConnector(context.Context, *GetIDPConnectorRequest) (*GetIDPConnectorResponse, error)
@@ -1737,6 +1857,9 @@ func (*UnimplementedAPIServer) GetIdentityServerConfig(ctx context.Context, req
 func (*UnimplementedAPIServer) CreateIDPConnector(ctx context.Context, req *CreateIDPConnectorRequest) (*CreateIDPConnectorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateIDPConnector not implemented")
 }
+func (*UnimplementedAPIServer) CheckIdentityProvider(ctx context.Context, req *CheckIdentityProviderRequest) (*CheckIdentityProviderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckIdentityProvider not implemented")
+}
 func (*UnimplementedAPIServer) UpdateIDPConnector(ctx context.Context, req *UpdateIDPConnectorRequest) (*UpdateIDPConnectorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateIDPConnector not implemented")
 }
@@ -1826,6 +1949,24 @@ func _API_CreateIDPConnector_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_CheckIdentityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckIdentityProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CheckIdentityProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/identity_v2.API/CheckIdentityProvider",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CheckIdentityProvider(ctx, req.(*CheckIdentityProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_UpdateIDPConnector_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateIDPConnectorRequest)
 	if err := dec(in); err != nil {
@@ -2022,6 +2163,10 @@ var _API_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateIDPConnector",
 			Handler:    _API_CreateIDPConnector_Handler,
 		},
+		{
+			MethodName: "CheckIdentityProvider",
+			Handler:    _API_CheckIdentityProvider_Handler,
+		},
 		{
 			MethodName: "UpdateIDPConnector",
 			Handler:    _API_UpdateIDPConnector_Handler,
@@ -2417,6 +2562,81 @@ func (m *CreateIDPConnectorResponse) MarshalToSizedBuffer(dAtA []byte) (int, err
 	return len(dAtA) - i, nil
 }
 
+func (m *CheckIdentityProviderRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CheckIdentityProviderRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CheckIdentityProviderRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Connector != nil {
+		{
+			size, err := m.Connector.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIdentity(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CheckIdentityProviderResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CheckIdentityProviderResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CheckIdentityProviderResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Warnings) > 0 {
+		for iNdEx := len(m.Warnings) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Warnings[iNdEx])
+			copy(dAtA[i:], m.Warnings[iNdEx])
+			i = encodeVarintIdentity(dAtA, i, uint64(len(m.Warnings[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *UpdateIDPConnectorRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -3321,6 +3541,40 @@ func (m *CreateIDPConnectorResponse) Size() (n int) {
 	return n
 }
 
+func (m *CheckIdentityProviderRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Connector != nil {
+		l = m.Connector.Size()
+		n += 1 + l + sovIdentity(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CheckIdentityProviderResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Warnings) > 0 {
+		for _, s := range m.Warnings {
+			l = len(s)
+			n += 1 + l + sovIdentity(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *UpdateIDPConnectorRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -4533,6 +4787,176 @@ func (m *CreateIDPConnectorResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *CheckIdentityProviderRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIdentity
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CheckIdentityProviderRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CheckIdentityProviderRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Connector", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIdentity
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIdentity
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIdentity
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Connector == nil {
+				m.Connector = &IDPConnector{}
+			}
+			if err := m.Connector.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIdentity(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIdentity
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CheckIdentityProviderResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIdentity
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CheckIdentityProviderResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CheckIdentityProviderResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Warnings", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIdentity
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIdentity
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIdentity
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Warnings = append(m.Warnings, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIdentity(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIdentity
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *UpdateIDPConnectorRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
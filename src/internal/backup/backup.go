@@ -0,0 +1,564 @@
+// Package backup takes point-in-time backups of a cluster's metadata to
+// object storage, and restores them.
+//
+// A backup location is an object storage URL, such as s3://bucket/backups.
+// Each backup at a location is under backups/<id>/, where its id is the time
+// it was started, and is made of:
+//
+//   - a gzipped file of JSON lines for each postgres table,
+//   - a gzipped file of JSON lines of the etcd keys that aren't attached to
+//     a lease,
+//   - a gzipped file of JSON lines of the chunks that the tables reference,
+//     and
+//   - manifest.json, which is written last, so that a backup without one is
+//     incomplete, and ignored.
+//
+// The chunks themselves are under chunk/, at the same paths as in the
+// cluster's object storage. They're shared by the backups at a location, so
+// a backup only copies the chunks that aren't there yet.
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	etcd "go.etcd.io/etcd/client/v3"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dbutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/middleware/readonly"
+	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pacherr"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/chunk"
+	"github.com/pachyderm/pachyderm/v2/src/version"
+)
+
+const (
+	// Format is the version of the layout of backups. Only backups of this
+	// format can be restored.
+	Format = 1
+
+	backupsDir   = "backups"
+	tablesDir    = "tables"
+	manifestName = "manifest.json"
+	etcdName     = "etcd.jsonl.gz"
+	chunksName   = "chunks.jsonl.gz"
+	idLayout     = "20060102T150405Z"
+
+	// etcdPageSize is the number of keys read from etcd at a time, and
+	// etcdTxnSize the number written in each transaction.
+	etcdPageSize = 1000
+	etcdTxnSize  = 100
+	// insertBatchSize is the number of rows restored with each insert.
+	insertBatchSize = 1000
+	// maxConcurrentChunks is the number of chunks copied or checked at once.
+	maxConcurrentChunks = 20
+	// maxLineSize is the size of the largest row or key a backup can hold.
+	maxLineSize = 64 * 1024 * 1024
+)
+
+// tablesQuery lists the tables that are backed up: all of them but the
+// migrations, which a backup must match to be restored, and the etcd schema,
+// whose keys are backed up through etcd's API.
+const tablesQuery = `
+	SELECT format('%I.%I', schemaname, tablename) FROM pg_tables
+	WHERE schemaname NOT IN ('pg_catalog', 'information_schema', 'etcd')
+	AND NOT (schemaname = 'public' AND tablename = 'migrations')
+	ORDER BY schemaname, tablename
+`
+
+// Env is what backups and restores need from the cluster.
+type Env struct {
+	DB         *pachsql.DB
+	EtcdClient *etcd.Client
+	// ObjectClient is the cluster's object storage, which holds its chunks.
+	ObjectClient obj.Client
+}
+
+// Manifest describes a backup, and is the last object written for it.
+type Manifest struct {
+	Format    int       `json:"format"`
+	ID        string    `json:"id"`
+	Started   time.Time `json:"started"`
+	Finished  time.Time `json:"finished"`
+	Version   string    `json:"version"`
+	Migration int64     `json:"migration"`
+	Parent    string    `json:"parent,omitempty"`
+
+	EtcdRevision  int64   `json:"etcd_revision"`
+	EtcdKeys      int64   `json:"etcd_keys"`
+	Tables        []Table `json:"tables"`
+	MetadataBytes int64   `json:"metadata_bytes"`
+
+	Chunks        int64 `json:"chunks"`
+	ChunkBytes    int64 `json:"chunk_bytes"`
+	NewChunks     int64 `json:"new_chunks"`
+	NewChunkBytes int64 `json:"new_chunk_bytes"`
+}
+
+// Table is a postgres table in a backup.
+type Table struct {
+	// Name is the table's qualified name, quoted as needed.
+	Name string `json:"name"`
+	Rows int64  `json:"rows"`
+}
+
+// Info returns the BackupInfo of the backup m describes, at location.
+func (m *Manifest) Info(location string) (*admin.BackupInfo, error) {
+	info := &admin.BackupInfo{
+		ID:            m.ID,
+		Location:      location,
+		Version:       m.Version,
+		Migration:     m.Migration,
+		Parent:        m.Parent,
+		EtcdRevision:  m.EtcdRevision,
+		EtcdKeys:      m.EtcdKeys,
+		Tables:        int64(len(m.Tables)),
+		MetadataBytes: m.MetadataBytes,
+		Chunks:        m.Chunks,
+		ChunkBytes:    m.ChunkBytes,
+		NewChunks:     m.NewChunks,
+		NewChunkBytes: m.NewChunkBytes,
+	}
+	for _, t := range m.Tables {
+		info.Rows += t.Rows
+	}
+	var err error
+	if info.Started, err = types.TimestampProto(m.Started); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if info.Finished, err = types.TimestampProto(m.Finished); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return info, nil
+}
+
+// etcdKey is a line of a backup's etcd keys.
+type etcdKey struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// chunkRef is a line of a backup's chunks.
+type chunkRef struct {
+	ID   chunk.ID `json:"id"`
+	Gen  uint64   `json:"gen"`
+	Size int64    `json:"size"`
+}
+
+func (c *chunkRef) path() string {
+	return chunk.ObjectPath(c.ID, c.Gen)
+}
+
+// location is where backups are kept: an object storage client, and the
+// prefix of the backups' objects in it.
+type location struct {
+	url    string
+	client obj.Client
+	prefix string
+}
+
+func openLocation(url string) (*location, error) {
+	if url == "" {
+		return nil, errors.New("no backup location; set one in the request, or set pachd's BACKUP_LOCATION")
+	}
+	u, err := obj.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+	client, err := obj.NewClientFromURLAndSecret(u, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not connect to backup location %s", url)
+	}
+	return &location{url: url, client: client, prefix: u.Object}, nil
+}
+
+// backupPath returns the path of an object of a backup.
+func (l *location) backupPath(id string, elem ...string) string {
+	return path.Join(append([]string{l.prefix, backupsDir, id}, elem...)...)
+}
+
+// chunkPath returns the path of a chunk's copy.
+func (l *location) chunkPath(c *chunkRef) string {
+	return path.Join(l.prefix, c.path())
+}
+
+// tablePath returns the path of a table's rows in a backup.
+func (l *location) tablePath(id, table string) string {
+	return l.backupPath(id, tablesDir, table+".jsonl.gz")
+}
+
+// ids returns the IDs of the complete backups at the location, oldest first.
+func (l *location) ids(ctx context.Context) ([]string, error) {
+	dir := path.Join(l.prefix, backupsDir) + "/"
+	var ids []string
+	if err := l.client.Walk(ctx, dir, func(name string) error {
+		rest := strings.TrimPrefix(name, dir)
+		if id := strings.TrimSuffix(rest, "/"+manifestName); id != rest && !strings.Contains(id, "/") {
+			ids = append(ids, id)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// manifest reads the manifest of a backup, or of the latest backup if id is
+// empty.
+func (l *location) manifest(ctx context.Context, id string) (*Manifest, error) {
+	if id == "" {
+		ids, err := l.ids(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			return nil, errors.Errorf("there are no backups at %s", l.url)
+		}
+		id = ids[len(ids)-1]
+	}
+	buf := &bytes.Buffer{}
+	if err := l.client.Get(ctx, l.backupPath(id, manifestName), buf); err != nil {
+		if pacherr.IsNotExist(err) {
+			return nil, errors.Errorf("backup %q not found at %s", id, l.url)
+		}
+		return nil, errors.EnsureStack(err)
+	}
+	m := &Manifest{}
+	if err := json.Unmarshal(buf.Bytes(), m); err != nil {
+		return nil, errors.Wrapf(err, "invalid manifest for backup %q", id)
+	}
+	if m.Format != Format {
+		return nil, errors.Errorf("backup %q has format %d, but this version of pachd only reads format %d", id, m.Format, Format)
+	}
+	return m, nil
+}
+
+// write writes the lines that cb writes to an object, gzipped, and returns
+// the object's size.
+func (l *location) write(ctx context.Context, name string, cb func(w io.Writer) error) (int64, error) {
+	cw := &countWriter{}
+	err := miscutil.WithPipe(func(w io.Writer) error {
+		cw.w = w
+		gw := gzip.NewWriter(cw)
+		bw := bufio.NewWriter(gw)
+		if err := cb(bw); err != nil {
+			return err
+		}
+		if err := bw.Flush(); err != nil {
+			return errors.EnsureStack(err)
+		}
+		return errors.EnsureStack(gw.Close())
+	}, func(r io.Reader) error {
+		return errors.EnsureStack(l.client.Put(ctx, name, r))
+	})
+	return cw.n, err
+}
+
+// read calls cb with each line of a gzipped object.
+func (l *location) read(ctx context.Context, name string, cb func(line []byte) error) error {
+	return miscutil.WithPipe(func(w io.Writer) error {
+		return errors.EnsureStack(l.client.Get(ctx, name, w))
+	}, func(r io.Reader) error {
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		scanner := bufio.NewScanner(gr)
+		scanner.Buffer(nil, maxLineSize)
+		for scanner.Scan() {
+			if err := cb(scanner.Bytes()); err != nil {
+				return err
+			}
+		}
+		return errors.EnsureStack(scanner.Err())
+	})
+}
+
+// chunks reads the chunks of a backup.
+func (l *location) chunks(ctx context.Context, id string) ([]*chunkRef, error) {
+	var chunks []*chunkRef
+	if err := l.read(ctx, l.backupPath(id, chunksName), func(line []byte) error {
+		c := &chunkRef{}
+		if err := json.Unmarshal(line, c); err != nil {
+			return errors.EnsureStack(err)
+		}
+		chunks = append(chunks, c)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return chunks, nil
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, errors.EnsureStack(err)
+}
+
+// writeJSONLine writes v to w as a line of JSON.
+func writeJSONLine(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return errors.EnsureStack(err)
+	}
+	return nil
+}
+
+// Backup takes a backup of the cluster's metadata to the location at url,
+// and copies the chunks it references that aren't there yet. Only one
+// backup or restore should run at a time.
+func Backup(ctx context.Context, env Env, url string) (*admin.BackupInfo, error) {
+	loc, err := openLocation(url)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := loc.ids(ctx)
+	if err != nil {
+		return nil, err
+	}
+	started := time.Now().UTC()
+	m := &Manifest{
+		Format:  Format,
+		ID:      started.Format(idLayout),
+		Started: started,
+		Version: version.PrettyVersion(),
+	}
+	if len(ids) > 0 {
+		m.Parent = ids[len(ids)-1]
+		if m.Parent >= m.ID {
+			return nil, errors.Errorf("backup %q already exists at %s", m.Parent, url)
+		}
+	}
+	var chunks []*chunkRef
+	if err := dbutil.WithTx(ctx, env.DB, func(tx *pachsql.Tx) error {
+		chunks = nil
+		m.Tables, m.MetadataBytes = nil, 0
+		// the snapshot the tables are read from is taken by the first
+		// query, and the etcd keys are read at the revision right after it
+		if err := tx.GetContext(ctx, &m.Migration, `SELECT COALESCE(MAX(id), 0) FROM migrations`); err != nil {
+			return errors.EnsureStack(err)
+		}
+		resp, err := env.EtcdClient.Get(ctx, "\x00", etcd.WithFromKey(), etcd.WithLimit(1))
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		m.EtcdRevision = resp.Header.Revision
+		var names []string
+		if err := tx.SelectContext(ctx, &names, tablesQuery); err != nil {
+			return errors.EnsureStack(err)
+		}
+		for _, name := range names {
+			t := Table{Name: name}
+			n, err := loc.write(ctx, loc.tablePath(m.ID, name), func(w io.Writer) error {
+				rows, err := tx.QueryContext(ctx, `SELECT row_to_json(t)::TEXT FROM `+name+` t`)
+				if err != nil {
+					return errors.EnsureStack(err)
+				}
+				defer rows.Close()
+				for rows.Next() {
+					var row []byte
+					if err := rows.Scan(&row); err != nil {
+						return errors.EnsureStack(err)
+					}
+					if _, err := w.Write(append(row, '\n')); err != nil {
+						return errors.EnsureStack(err)
+					}
+					t.Rows++
+				}
+				return errors.EnsureStack(rows.Err())
+			})
+			if err != nil {
+				return errors.Wrapf(err, "could not back up table %s", name)
+			}
+			m.Tables = append(m.Tables, t)
+			m.MetadataBytes += n
+		}
+		n, err := loc.write(ctx, loc.backupPath(m.ID, chunksName), func(w io.Writer) error {
+			rows, err := tx.QueryxContext(ctx, `
+				SELECT chunk_id, gen, size FROM storage.chunk_objects
+				WHERE uploaded AND NOT tombstone
+				ORDER BY chunk_id, gen
+			`)
+			if err != nil {
+				return errors.EnsureStack(err)
+			}
+			defer rows.Close()
+			for rows.Next() {
+				var row struct {
+					ChunkID chunk.ID `db:"chunk_id"`
+					Gen     uint64   `db:"gen"`
+					Size    int64    `db:"size"`
+				}
+				if err := rows.StructScan(&row); err != nil {
+					return errors.EnsureStack(err)
+				}
+				c := &chunkRef{ID: row.ChunkID, Gen: row.Gen, Size: row.Size}
+				if err := writeJSONLine(w, c); err != nil {
+					return err
+				}
+				chunks = append(chunks, c)
+			}
+			return errors.EnsureStack(rows.Err())
+		})
+		if err != nil {
+			return errors.Wrapf(err, "could not back up the chunk list")
+		}
+		m.MetadataBytes += n
+		return nil
+	}, dbutil.WithIsolationLevel(sql.LevelRepeatableRead), dbutil.WithReadOnly()); err != nil {
+		return nil, err
+	}
+	n, err := loc.write(ctx, loc.backupPath(m.ID, etcdName), func(w io.Writer) error {
+		keys, err := dumpEtcd(ctx, env.EtcdClient, m.EtcdRevision, w)
+		m.EtcdKeys = keys
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not back up etcd")
+	}
+	m.MetadataBytes += n
+	for _, c := range chunks {
+		m.Chunks++
+		m.ChunkBytes += c.Size
+	}
+	if m.NewChunks, m.NewChunkBytes, err = copyChunks(ctx, chunks, env.ObjectClient, "", loc.client, loc.prefix); err != nil {
+		return nil, errors.Wrapf(err, "could not copy chunks")
+	}
+	m.Finished = time.Now().UTC()
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := loc.client.Put(ctx, loc.backupPath(m.ID, manifestName), bytes.NewReader(data)); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return m.Info(url)
+}
+
+// dumpEtcd writes the keys that aren't attached to a lease, as of revision,
+// to w, and returns their number. Leased keys, such as locks and the state
+// of running workers, belong to processes that won't be running when the
+// backup is restored. The read-only state isn't backed up either, as backups
+// are usually taken while the cluster is read-only.
+func dumpEtcd(ctx context.Context, client *etcd.Client, revision int64, w io.Writer) (int64, error) {
+	var n int64
+	key := "\x00"
+	for {
+		resp, err := client.Get(ctx, key, etcd.WithFromKey(), etcd.WithLimit(etcdPageSize), etcd.WithRev(revision))
+		if err != nil {
+			return 0, errors.EnsureStack(err)
+		}
+		for _, kv := range resp.Kvs {
+			if kv.Lease != 0 || string(kv.Key) == readonly.StateKey {
+				continue
+			}
+			if err := writeJSONLine(w, &etcdKey{Key: kv.Key, Value: kv.Value}); err != nil {
+				return 0, err
+			}
+			n++
+		}
+		if !resp.More || len(resp.Kvs) == 0 {
+			return n, nil
+		}
+		key = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+	}
+}
+
+// List calls cb with each complete backup at the location at url, newest
+// first.
+func List(ctx context.Context, url string, cb func(*admin.BackupInfo) error) error {
+	loc, err := openLocation(url)
+	if err != nil {
+		return err
+	}
+	ids, err := loc.ids(ctx)
+	if err != nil {
+		return err
+	}
+	for i := len(ids) - 1; i >= 0; i-- {
+		m, err := loc.manifest(ctx, ids[i])
+		if err != nil {
+			return err
+		}
+		info, err := m.Info(url)
+		if err != nil {
+			return err
+		}
+		if err := cb(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyChunks copies the chunks that are missing from dst, under dstPrefix,
+// from src, under srcPrefix, and returns the number and size of those it
+// copied.
+func copyChunks(ctx context.Context, chunks []*chunkRef, src obj.Client, srcPrefix string, dst obj.Client, dstPrefix string) (int64, int64, error) {
+	var n, size int64
+	err := forEachChunk(ctx, chunks, func(ctx context.Context, c *chunkRef) error {
+		dstPath := path.Join(dstPrefix, c.path())
+		exists, err := dst.Exists(ctx, dstPath)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		if exists {
+			return nil
+		}
+		if err := obj.Copy(ctx, src, dst, path.Join(srcPrefix, c.path()), dstPath); err != nil {
+			return errors.Wrapf(err, "could not copy chunk %v", c.ID)
+		}
+		atomic.AddInt64(&n, 1)
+		atomic.AddInt64(&size, c.Size)
+		return nil
+	})
+	return n, size, err
+}
+
+// forEachChunk calls cb with each chunk, maxConcurrentChunks at a time,
+// until it returns an error.
+func forEachChunk(ctx context.Context, chunks []*chunkRef, cb func(context.Context, *chunkRef) error) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	sem := semaphore.NewWeighted(maxConcurrentChunks)
+	for _, c := range chunks {
+		c := c
+		if err := sem.Acquire(egCtx, 1); err != nil {
+			break
+		}
+		eg.Go(func() error {
+			defer sem.Release(1)
+			return cb(egCtx, c)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+	return errors.EnsureStack(ctx.Err())
+}
@@ -0,0 +1,174 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/chunk"
+)
+
+func newTestLocation(t *testing.T, prefix string) *location {
+	client, err := obj.NewLocalClient(t.TempDir())
+	require.NoError(t, err)
+	return &location{url: "local://test/" + prefix, client: client, prefix: prefix}
+}
+
+// putChunk stores data as a chunk in client, under prefix.
+func putChunk(t *testing.T, client obj.Client, prefix string, data string) *chunkRef {
+	c := &chunkRef{ID: chunk.Hash([]byte(data)), Gen: 1, Size: int64(len(data))}
+	require.NoError(t, client.Put(context.Background(), prefix+c.path(), strings.NewReader(data)))
+	return c
+}
+
+// putBackup writes a complete backup to loc, with a table of rows and chunks.
+func putBackup(t *testing.T, loc *location, id string, rows []string, chunks []*chunkRef) *Manifest {
+	ctx := context.Background()
+	m := &Manifest{Format: Format, ID: id, Started: time.Now(), Finished: time.Now(), Chunks: int64(len(chunks))}
+	_, err := loc.write(ctx, loc.tablePath(id, "pfs.repos"), func(w io.Writer) error {
+		for _, row := range rows {
+			if _, err := fmt.Fprintln(w, row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	m.Tables = append(m.Tables, Table{Name: "pfs.repos", Rows: int64(len(rows))})
+	_, err = loc.write(ctx, loc.backupPath(id, etcdName), func(w io.Writer) error {
+		return writeJSONLine(w, &etcdKey{Key: []byte("key"), Value: []byte("value")})
+	})
+	require.NoError(t, err)
+	m.EtcdKeys = 1
+	_, err = loc.write(ctx, loc.backupPath(id, chunksName), func(w io.Writer) error {
+		for _, c := range chunks {
+			if err := writeJSONLine(w, c); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	require.NoError(t, loc.client.Put(ctx, loc.backupPath(id, manifestName), bytes.NewReader(data)))
+	return m
+}
+
+func TestPaths(t *testing.T) {
+	loc := &location{prefix: "cluster"}
+	require.Equal(t, "cluster/backups/20220101T000000Z/manifest.json", loc.backupPath("20220101T000000Z", manifestName))
+	require.Equal(t, "cluster/backups/20220101T000000Z/tables/pfs.repos.jsonl.gz", loc.tablePath("20220101T000000Z", "pfs.repos"))
+	c := &chunkRef{ID: chunk.ID{0xab, 0xcd}, Gen: 2}
+	require.Equal(t, "cluster/chunk/abcd.0000000000000002", loc.chunkPath(c))
+	require.Equal(t, "backups/x/etcd.jsonl.gz", (&location{}).backupPath("x", etcdName))
+}
+
+func TestReadWrite(t *testing.T) {
+	ctx := context.Background()
+	loc := newTestLocation(t, "")
+	lines := []string{`{"a": 1}`, `{"b": "` + strings.Repeat("x", 100000) + `"}`}
+	n, err := loc.write(ctx, "lines.gz", func(w io.Writer) error {
+		for _, line := range lines {
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.True(t, n > 0)
+	var read []string
+	require.NoError(t, loc.read(ctx, "lines.gz", func(line []byte) error {
+		read = append(read, string(line))
+		return nil
+	}))
+	require.Equal(t, lines, read)
+}
+
+func TestManifests(t *testing.T) {
+	ctx := context.Background()
+	loc := newTestLocation(t, "prefix")
+	_, err := loc.manifest(ctx, "")
+	require.YesError(t, err)
+	putBackup(t, loc, "20220101T000000Z", nil, nil)
+	putBackup(t, loc, "20220102T000000Z", []string{"{}"}, nil)
+	// a backup without a manifest is incomplete, and ignored
+	require.NoError(t, loc.client.Put(ctx, loc.backupPath("20220103T000000Z", etcdName), strings.NewReader("")))
+	ids, err := loc.ids(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"20220101T000000Z", "20220102T000000Z"}, ids)
+	m, err := loc.manifest(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, "20220102T000000Z", m.ID)
+	info, err := m.Info(loc.url)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), info.Tables)
+	require.Equal(t, int64(1), info.Rows)
+	_, err = loc.manifest(ctx, "20220103T000000Z")
+	require.YesError(t, err)
+}
+
+func TestCopyChunks(t *testing.T) {
+	ctx := context.Background()
+	cluster, err := obj.NewLocalClient(t.TempDir())
+	require.NoError(t, err)
+	loc := newTestLocation(t, "prefix")
+	chunks := []*chunkRef{putChunk(t, cluster, "", "a"), putChunk(t, cluster, "", "bb")}
+	n, size, err := copyChunks(ctx, chunks, cluster, "", loc.client, loc.prefix)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+	require.Equal(t, int64(3), size)
+	// the chunks are already at the location, so a second backup is
+	// incremental
+	chunks = append(chunks, putChunk(t, cluster, "", "ccc"))
+	n, size, err = copyChunks(ctx, chunks, cluster, "", loc.client, loc.prefix)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+	require.Equal(t, int64(3), size)
+	exists, err := loc.client.Exists(ctx, loc.chunkPath(chunks[2]))
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	loc := newTestLocation(t, "prefix")
+	chunks := []*chunkRef{
+		putChunk(t, loc.client, "prefix/", "a"),
+		putChunk(t, loc.client, "prefix/", "bb"),
+	}
+	m := putBackup(t, loc, "20220101T000000Z", []string{"{}", "{}"}, chunks)
+	for _, deep := range []bool{false, true} {
+		response, err := verify(ctx, loc, m, deep)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(response.Problems), "%v", response.Problems)
+		require.Equal(t, int64(2), response.ChunksChecked)
+	}
+	// corrupt a chunk, which only a deep verification notices
+	require.NoError(t, loc.client.Put(ctx, loc.chunkPath(chunks[0]), strings.NewReader("b")))
+	response, err := verify(ctx, loc, m, false)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(response.Problems))
+	response, err = verify(ctx, loc, m, true)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(response.Problems))
+	require.True(t, strings.Contains(response.Problems[0], "corrupt"))
+	// and a missing chunk, and a table with fewer rows than were backed up
+	require.NoError(t, loc.client.Delete(ctx, loc.chunkPath(chunks[1])))
+	m.Tables[0].Rows = 3
+	response, err = verify(ctx, loc, m, false)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(response.Problems))
+	require.True(t, strings.Contains(response.Problems[0], "missing"))
+	response, err = verify(ctx, loc, m, true)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(response.Problems))
+}
@@ -0,0 +1,210 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dbutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/middleware/readonly"
+	"github.com/pachyderm/pachyderm/v2/src/internal/migrations"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+)
+
+// Restore replaces the cluster's metadata with that of a backup at the
+// location at url, or of the latest one if id is empty, and copies the
+// backup's chunks that are missing from the cluster's object storage. Unless
+// force is set, the cluster must not have any repos. pachd must be restarted
+// once it's restored.
+func Restore(ctx context.Context, env Env, url, id string, force bool) (*admin.RestoreResponse, error) {
+	loc, err := openLocation(url)
+	if err != nil {
+		return nil, err
+	}
+	m, err := loc.manifest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	current, err := migrations.CurrentState(ctx, env.DB)
+	if err != nil {
+		return nil, err
+	}
+	if int64(current) != m.Migration {
+		return nil, errors.Errorf("backup %q was taken at migration %d, by pachd %s, but the cluster is at migration %d; restore it to a cluster of the same version",
+			m.ID, m.Migration, m.Version, current)
+	}
+	verified, err := verify(ctx, loc, m, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(verified.Problems) > 0 {
+		return nil, errors.Errorf("backup %q is incomplete (%s); run pachctl verify backup for details", m.ID, verified.Problems[0])
+	}
+	if !force {
+		var hasRepos bool
+		if err := env.DB.GetContext(ctx, &hasRepos, `SELECT EXISTS (SELECT 1 FROM collections.repos)`); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		if hasRepos {
+			return nil, errors.New("the cluster already has repos, which restoring would replace; set force to restore anyway")
+		}
+	}
+	response := &admin.RestoreResponse{Backup: verified.Backup}
+	chunks, err := loc.chunks(ctx, m.ID)
+	if err != nil {
+		return nil, err
+	}
+	if response.ChunksRestored, _, err = copyChunks(ctx, chunks, loc.client, loc.prefix, env.ObjectClient, ""); err != nil {
+		return nil, errors.Wrapf(err, "could not restore chunks")
+	}
+	if err := dbutil.WithTx(ctx, env.DB, func(tx *pachsql.Tx) error {
+		return restoreTables(ctx, tx, loc, m)
+	}); err != nil {
+		return nil, err
+	}
+	if err := restoreEtcd(ctx, env.EtcdClient, loc, m); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// restoreTables replaces the rows of every table that's backed up with the
+// backup's, and resets the sequences of their serial columns.
+func restoreTables(ctx context.Context, tx *pachsql.Tx, loc *location, m *Manifest) error {
+	var names []string
+	if err := tx.SelectContext(ctx, &names, tablesQuery); err != nil {
+		return errors.EnsureStack(err)
+	}
+	exists := make(map[string]bool)
+	for _, name := range names {
+		exists[name] = true
+	}
+	for _, t := range m.Tables {
+		if !exists[t.Name] {
+			return errors.Errorf("table %s of the backup doesn't exist in the cluster", t.Name)
+		}
+	}
+	if len(names) > 0 {
+		if _, err := tx.ExecContext(ctx, `TRUNCATE `+strings.Join(names, ", ")); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	for _, t := range m.Tables {
+		insert := func(rows [][]byte) error {
+			if len(rows) == 0 {
+				return nil
+			}
+			batch := append([]byte{'['}, bytes.Join(rows, []byte{','})...)
+			batch = append(batch, ']')
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO `+t.Name+` OVERRIDING SYSTEM VALUE
+				SELECT * FROM json_populate_recordset(NULL::`+t.Name+`, $1)
+			`, string(batch))
+			return errors.EnsureStack(err)
+		}
+		var rows [][]byte
+		if err := loc.read(ctx, loc.tablePath(m.ID, t.Name), func(line []byte) error {
+			rows = append(rows, append([]byte{}, line...))
+			if len(rows) < insertBatchSize {
+				return nil
+			}
+			err := insert(rows)
+			rows = rows[:0]
+			return err
+		}); err != nil {
+			return errors.Wrapf(err, "could not restore table %s", t.Name)
+		}
+		if err := insert(rows); err != nil {
+			return errors.Wrapf(err, "could not restore table %s", t.Name)
+		}
+	}
+	var serials []struct {
+		Table    string `db:"table_name"`
+		Column   string `db:"column_name"`
+		Sequence string `db:"sequence"`
+	}
+	if err := tx.SelectContext(ctx, &serials, `
+		SELECT format('%I.%I', table_schema, table_name) AS table_name, quote_ident(column_name) AS column_name,
+			pg_get_serial_sequence(format('%I.%I', table_schema, table_name), column_name) AS sequence
+		FROM information_schema.columns
+		WHERE table_schema NOT IN ('pg_catalog', 'information_schema')
+		AND pg_get_serial_sequence(format('%I.%I', table_schema, table_name), column_name) IS NOT NULL
+	`); err != nil {
+		return errors.EnsureStack(err)
+	}
+	for _, s := range serials {
+		if !exists[s.Table] {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `SELECT setval($1, COALESCE((SELECT MAX(`+s.Column+`) FROM `+s.Table+`), 0) + 1, false)`, s.Sequence); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	return nil
+}
+
+// restoreEtcd replaces the keys that aren't attached to a lease with the
+// backup's. The cluster's read-only state is kept.
+func restoreEtcd(ctx context.Context, client *etcd.Client, loc *location, m *Manifest) error {
+	var ops []etcd.Op
+	commit := func() error {
+		if len(ops) == 0 {
+			return nil
+		}
+		if _, err := client.Txn(ctx).Then(ops...).Commit(); err != nil {
+			return errors.EnsureStack(err)
+		}
+		ops = ops[:0]
+		return nil
+	}
+	// the keys are listed before any are deleted, so that the pages aren't
+	// shifted under the listing
+	var keys []string
+	key := "\x00"
+	for {
+		resp, err := client.Get(ctx, key, etcd.WithFromKey(), etcd.WithLimit(etcdPageSize), etcd.WithKeysOnly())
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		for _, kv := range resp.Kvs {
+			if kv.Lease == 0 && string(kv.Key) != readonly.StateKey {
+				keys = append(keys, string(kv.Key))
+			}
+		}
+		if !resp.More || len(resp.Kvs) == 0 {
+			break
+		}
+		key = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+	}
+	for _, key := range keys {
+		ops = append(ops, etcd.OpDelete(key))
+		if len(ops) < etcdTxnSize {
+			continue
+		}
+		if err := commit(); err != nil {
+			return errors.Wrapf(err, "could not delete etcd keys")
+		}
+	}
+	if err := commit(); err != nil {
+		return errors.Wrapf(err, "could not delete etcd keys")
+	}
+	if err := loc.read(ctx, loc.backupPath(m.ID, etcdName), func(line []byte) error {
+		k := &etcdKey{}
+		if err := json.Unmarshal(line, k); err != nil {
+			return errors.EnsureStack(err)
+		}
+		ops = append(ops, etcd.OpPut(string(k.Key), string(k.Value)))
+		if len(ops) < etcdTxnSize {
+			return nil
+		}
+		return commit()
+	}); err != nil {
+		return errors.Wrapf(err, "could not restore etcd keys")
+	}
+	return commit()
+}
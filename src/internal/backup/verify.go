@@ -0,0 +1,129 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pacherr"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/chunk"
+)
+
+// maxProblems is the number of problems a verification reports in full.
+const maxProblems = 100
+
+// Verify checks that a backup at the location at url, or the latest one if
+// id is empty, is complete. If deep is set, it reads every object of the
+// backup, and checks the hash of each chunk and the number of rows and keys.
+func Verify(ctx context.Context, url, id string, deep bool) (*admin.VerifyBackupResponse, error) {
+	loc, err := openLocation(url)
+	if err != nil {
+		return nil, err
+	}
+	m, err := loc.manifest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return verify(ctx, loc, m, deep)
+}
+
+func verify(ctx context.Context, loc *location, m *Manifest, deep bool) (*admin.VerifyBackupResponse, error) {
+	info, err := m.Info(loc.url)
+	if err != nil {
+		return nil, err
+	}
+	response := &admin.VerifyBackupResponse{Backup: info}
+	var mu sync.Mutex
+	var problems []string
+	problem := func(format string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+	// checkLines checks that an object exists, and, if deep is set, that it
+	// has the expected number of lines.
+	checkLines := func(what, name string, expected int64) error {
+		if !deep {
+			exists, err := loc.client.Exists(ctx, name)
+			if err != nil {
+				return errors.EnsureStack(err)
+			}
+			if !exists {
+				problem("%s: %s is missing", what, name)
+			}
+			return nil
+		}
+		var n int64
+		if err := loc.read(ctx, name, func([]byte) error {
+			n++
+			return nil
+		}); err != nil {
+			if pacherr.IsNotExist(err) {
+				problem("%s: %s is missing", what, name)
+				return nil
+			}
+			problem("%s: %s can't be read: %v", what, name, err)
+			return nil
+		}
+		if n != expected {
+			problem("%s: %s has %d lines, but %d were backed up", what, name, n, expected)
+		}
+		return nil
+	}
+	for _, t := range m.Tables {
+		if err := checkLines("table "+t.Name, loc.tablePath(m.ID, t.Name), t.Rows); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkLines("etcd", loc.backupPath(m.ID, etcdName), m.EtcdKeys); err != nil {
+		return nil, err
+	}
+	chunks, err := loc.chunks(ctx, m.ID)
+	if err != nil {
+		if !pacherr.IsNotExist(err) {
+			return nil, err
+		}
+		problem("chunks: %s is missing", loc.backupPath(m.ID, chunksName))
+	} else if int64(len(chunks)) != m.Chunks {
+		problem("chunks: %d are listed, but %d were backed up", len(chunks), m.Chunks)
+	}
+	if err := forEachChunk(ctx, chunks, func(ctx context.Context, c *chunkRef) error {
+		defer atomic.AddInt64(&response.ChunksChecked, 1)
+		name := loc.chunkPath(c)
+		if !deep {
+			exists, err := loc.client.Exists(ctx, name)
+			if err != nil {
+				return errors.EnsureStack(err)
+			}
+			if !exists {
+				problem("chunk %v: %s is missing", c.ID, name)
+			}
+			return nil
+		}
+		buf := &bytes.Buffer{}
+		if err := loc.client.Get(ctx, name, buf); err != nil {
+			if pacherr.IsNotExist(err) {
+				problem("chunk %v: %s is missing", c.ID, name)
+				return nil
+			}
+			return errors.EnsureStack(err)
+		}
+		if !bytes.Equal(chunk.Hash(buf.Bytes()), c.ID) {
+			problem("chunk %v: %s is corrupt", c.ID, name)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Strings(problems)
+	if len(problems) > maxProblems {
+		problems = append(problems[:maxProblems], fmt.Sprintf("and %d more problems", len(problems)-maxProblems))
+	}
+	response.Problems = problems
+	return response, nil
+}
@@ -0,0 +1,88 @@
+package clientsdk
+
+import (
+	"io"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pacherr"
+)
+
+func ForEachWebhookInfo(client admin.API_ListWebhookClient, cb func(*admin.WebhookInfo) error) error {
+	for {
+		x, err := client.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return errors.EnsureStack(err)
+		}
+		if err := cb(x); err != nil {
+			if errors.Is(err, pacherr.ErrBreak) {
+				err = nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func ListWebhookInfo(client admin.API_ListWebhookClient) ([]*admin.WebhookInfo, error) {
+	var results []*admin.WebhookInfo
+	if err := ForEachWebhookInfo(client, func(x *admin.WebhookInfo) error {
+		results = append(results, x)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func ForEachEvent(client admin.API_SubscribeEventsClient, cb func(*admin.Event) error) error {
+	for {
+		x, err := client.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return errors.EnsureStack(err)
+		}
+		if err := cb(x); err != nil {
+			if errors.Is(err, pacherr.ErrBreak) {
+				err = nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func ForEachBackupInfo(client admin.API_ListBackupsClient, cb func(*admin.BackupInfo) error) error {
+	for {
+		x, err := client.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return errors.EnsureStack(err)
+		}
+		if err := cb(x); err != nil {
+			if errors.Is(err, pacherr.ErrBreak) {
+				err = nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func ListBackupInfo(client admin.API_ListBackupsClient) ([]*admin.BackupInfo, error) {
+	var results []*admin.BackupInfo
+	if err := ForEachBackupInfo(client, func(x *admin.BackupInfo) error {
+		results = append(results, x)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
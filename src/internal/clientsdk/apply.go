@@ -0,0 +1,326 @@
+package clientsdk
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// The Apply functions below create a resource if it doesn't exist, update it
+// if the fields the caller manages differ from what the caller wants, and
+// do nothing otherwise, so that a program declaring the resources it wants
+// converges to them no matter how many times it runs. Fields the caller
+// doesn't manage keep the values they were given by pachd or by other
+// callers.
+//
+// The caller manages the fields it sets in the spec it passes, unless it
+// passes the JSON names of the fields it manages, which lets it clear a
+// field by managing it and leaving it unset.
+
+// ApplyResult says what an Apply function did.
+type ApplyResult int
+
+const (
+	// ApplyUnchanged means the resource already had the desired fields.
+	ApplyUnchanged ApplyResult = iota
+	// ApplyCreated means the resource didn't exist, and was created.
+	ApplyCreated
+	// ApplyUpdated means the resource existed, and was updated.
+	ApplyUpdated
+)
+
+func (r ApplyResult) String() string {
+	switch r {
+	case ApplyCreated:
+		return "created"
+	case ApplyUpdated:
+		return "updated"
+	default:
+		return "unchanged"
+	}
+}
+
+// LastAppliedAnnotation is the pipeline annotation in which ApplyPipeline
+// records the fields it last applied.
+const LastAppliedAnnotation = "pachyderm.io/last-applied"
+
+// lastApplied is the value of LastAppliedAnnotation.
+type lastApplied struct {
+	// Version is the pipeline version the apply created. If the pipeline's
+	// version differs, it was updated by someone else since.
+	Version uint64 `json:"version"`
+	// Fields are the fields that were managed.
+	Fields []string `json:"fields"`
+	// Spec is the JSON of the managed fields.
+	Spec string `json:"spec"`
+}
+
+// pipelineIdentityFields are the fields of a CreatePipelineRequest that
+// aren't part of the pipeline's spec, so they can't be managed.
+var pipelineIdentityFields = map[string]bool{
+	"pipeline":    true,
+	"update":      true,
+	"reprocess":   true,
+	"spec_commit": true,
+}
+
+// ApplyRepo creates or updates the repo in request. The only field a repo
+// has is its description.
+func ApplyRepo(ctx context.Context, c pfs.APIClient, request *pfs.CreateRepoRequest, managed ...string) (ApplyResult, error) {
+	fields, err := managedFields(request, managed, map[string]bool{"repo": true, "update": true})
+	if err != nil {
+		return ApplyUnchanged, err
+	}
+	repoInfo, err := c.InspectRepo(ctx, &pfs.InspectRepoRequest{Repo: request.Repo})
+	if err != nil {
+		if !errutil.IsNotFoundError(err) {
+			return ApplyUnchanged, errors.EnsureStack(err)
+		}
+		if _, err := c.CreateRepo(ctx, request); err != nil {
+			return ApplyUnchanged, errors.EnsureStack(err)
+		}
+		return ApplyCreated, nil
+	}
+	if len(fields) == 0 || repoInfo.Description == request.Description {
+		return ApplyUnchanged, nil
+	}
+	if _, err := c.CreateRepo(ctx, &pfs.CreateRepoRequest{
+		Repo:        request.Repo,
+		Description: request.Description,
+		Update:      true,
+	}); err != nil {
+		return ApplyUnchanged, errors.EnsureStack(err)
+	}
+	return ApplyUpdated, nil
+}
+
+// ApplyRoleBinding sets the roles principal has on resource. It only manages
+// principal's roles, the roles of other principals are left alone. If roles
+// is empty, principal's roles are removed.
+func ApplyRoleBinding(ctx context.Context, c auth.APIClient, resource *auth.Resource, principal string, roles []string) (ApplyResult, error) {
+	resp, err := c.GetRoleBinding(ctx, &auth.GetRoleBindingRequest{Resource: resource})
+	if err != nil {
+		return ApplyUnchanged, errors.EnsureStack(err)
+	}
+	var current []string
+	for role, ok := range resp.GetBinding().GetEntries()[principal].GetRoles() {
+		if ok {
+			current = append(current, role)
+		}
+	}
+	desired := append([]string(nil), roles...)
+	sort.Strings(current)
+	sort.Strings(desired)
+	if reflect.DeepEqual(current, desired) {
+		return ApplyUnchanged, nil
+	}
+	if _, err := c.ModifyRoleBinding(ctx, &auth.ModifyRoleBindingRequest{
+		Resource:  resource,
+		Principal: principal,
+		Roles:     desired,
+	}); err != nil {
+		return ApplyUnchanged, errors.EnsureStack(err)
+	}
+	if len(current) == 0 {
+		return ApplyCreated, nil
+	}
+	return ApplyUpdated, nil
+}
+
+// ApplyPipeline creates or updates the pipeline in request.
+//
+// pachd fills in defaults for fields a spec leaves unset, so the pipeline's
+// spec can't be compared with request directly. Instead, ApplyPipeline
+// records the managed fields in the pipeline's LastAppliedAnnotation, and
+// only updates the pipeline if they've changed since, or if the pipeline
+// was updated by someone else, which may have changed a managed field. A
+// field that was managed by the last apply, but isn't any more, is cleared.
+func ApplyPipeline(ctx context.Context, c pps.APIClient, request *pps.CreatePipelineRequest, managed ...string) (ApplyResult, error) {
+	fields, err := managedFields(request, managed, pipelineIdentityFields)
+	if err != nil {
+		return ApplyUnchanged, err
+	}
+	spec, err := managedSpec(request, fields)
+	if err != nil {
+		return ApplyUnchanged, err
+	}
+	pipelineInfo, err := c.InspectPipeline(ctx, &pps.InspectPipelineRequest{Pipeline: request.Pipeline, Details: true})
+	if err != nil {
+		if !errutil.IsNotFoundError(err) {
+			return ApplyUnchanged, errors.EnsureStack(err)
+		}
+		create := proto.Clone(request).(*pps.CreatePipelineRequest)
+		if err := annotateLastApplied(create, lastApplied{Version: 1, Fields: fields, Spec: spec}); err != nil {
+			return ApplyUnchanged, err
+		}
+		if _, err := c.CreatePipeline(ctx, create); err != nil {
+			return ApplyUnchanged, errors.EnsureStack(err)
+		}
+		return ApplyCreated, nil
+	}
+	current := pipelineSpec(pipelineInfo)
+	var last lastApplied
+	if value, ok := current.GetMetadata().GetAnnotations()[LastAppliedAnnotation]; ok {
+		if err := json.Unmarshal([]byte(value), &last); err != nil {
+			// a mangled annotation is treated as if there were none
+			last = lastApplied{}
+		}
+	}
+	if last.Version == pipelineInfo.Version && last.Spec == spec && reflect.DeepEqual(last.Fields, fields) {
+		return ApplyUnchanged, nil
+	}
+	update := proto.Clone(current).(*pps.CreatePipelineRequest)
+	for _, f := range last.Fields {
+		if err := setField(update, request, f, false); err != nil {
+			return ApplyUnchanged, err
+		}
+	}
+	for _, f := range fields {
+		if err := setField(update, request, f, true); err != nil {
+			return ApplyUnchanged, err
+		}
+	}
+	if err := annotateLastApplied(update, lastApplied{Version: pipelineInfo.Version + 1, Fields: fields, Spec: spec}); err != nil {
+		return ApplyUnchanged, err
+	}
+	update.Update = true
+	if _, err := c.CreatePipeline(ctx, update); err != nil {
+		return ApplyUnchanged, errors.EnsureStack(err)
+	}
+	return ApplyUpdated, nil
+}
+
+// pipelineSpec returns the spec of a pipeline, from its details.
+func pipelineSpec(pipelineInfo *pps.PipelineInfo) *pps.CreatePipelineRequest {
+	spec := &pps.CreatePipelineRequest{Pipeline: pipelineInfo.Pipeline}
+	if pipelineInfo.Details == nil {
+		return spec
+	}
+	// the spec's fields are stored in the details under the same names
+	details := reflect.ValueOf(pipelineInfo.Details).Elem()
+	v := reflect.ValueOf(spec).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Name
+		if pipelineIdentityFields[jsonName(v.Type().Field(i))] {
+			continue
+		}
+		if d := details.FieldByName(name); d.IsValid() && d.Type() == v.Field(i).Type() {
+			v.Field(i).Set(d)
+		}
+	}
+	return proto.Clone(spec).(*pps.CreatePipelineRequest)
+}
+
+// annotateLastApplied records last in request's LastAppliedAnnotation.
+func annotateLastApplied(request *pps.CreatePipelineRequest, last lastApplied) error {
+	value, err := json.Marshal(last)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if request.Metadata == nil {
+		request.Metadata = &pps.Metadata{}
+	}
+	if request.Metadata.Annotations == nil {
+		request.Metadata.Annotations = make(map[string]string)
+	}
+	request.Metadata.Annotations[LastAppliedAnnotation] = string(value)
+	return nil
+}
+
+// managedFields returns the sorted JSON names of the fields of spec that are
+// managed: the fields in managed if it's set, and otherwise the fields that
+// are set in spec. Fields in exclude can't be managed.
+func managedFields(spec proto.Message, managed []string, exclude map[string]bool) ([]string, error) {
+	v := reflect.ValueOf(spec).Elem()
+	names := make(map[string]bool)
+	var fields []string
+	for i := 0; i < v.NumField(); i++ {
+		name := jsonName(v.Type().Field(i))
+		if name == "" || exclude[name] {
+			continue
+		}
+		names[name] = true
+		if len(managed) == 0 && !v.Field(i).IsZero() {
+			fields = append(fields, name)
+		}
+	}
+	for _, name := range managed {
+		if !names[name] {
+			return nil, errors.Errorf("%q isn't a field that can be managed", name)
+		}
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// managedSpec returns the JSON of the managed fields of spec.
+func managedSpec(spec *pps.CreatePipelineRequest, fields []string) (string, error) {
+	subset := &pps.CreatePipelineRequest{}
+	for _, f := range fields {
+		if err := setField(subset, spec, f, true); err != nil {
+			return "", err
+		}
+	}
+	s, err := (&jsonpb.Marshaler{OrigName: true}).MarshalToString(subset)
+	return s, errors.EnsureStack(err)
+}
+
+// setField sets the field of dst with the JSON name name to its value in src
+// if set is true, and clears it otherwise. LastAppliedAnnotation is kept if
+// the field is metadata.
+func setField(dst, src proto.Message, name string, set bool) error {
+	d, s := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem()
+	for i := 0; i < d.NumField(); i++ {
+		if jsonName(d.Type().Field(i)) != name {
+			continue
+		}
+		var annotation *string
+		if m, ok := d.Field(i).Interface().(*pps.Metadata); ok {
+			if value, ok := m.GetAnnotations()[LastAppliedAnnotation]; ok {
+				annotation = &value
+			}
+		}
+		if set {
+			d.Field(i).Set(s.Field(i))
+			if f, ok := d.Field(i).Interface().(proto.Message); ok && !d.Field(i).IsNil() {
+				d.Field(i).Set(reflect.ValueOf(proto.Clone(f)))
+			}
+		} else {
+			d.Field(i).Set(reflect.Zero(d.Field(i).Type()))
+		}
+		if annotation != nil {
+			m, _ := d.Field(i).Interface().(*pps.Metadata)
+			if m == nil {
+				m = &pps.Metadata{}
+				d.Field(i).Set(reflect.ValueOf(m))
+			}
+			if m.Annotations == nil {
+				m.Annotations = make(map[string]string)
+			}
+			m.Annotations[LastAppliedAnnotation] = *annotation
+		}
+		return nil
+	}
+	return errors.Errorf("%q isn't a field of %T", name, dst)
+}
+
+// jsonName returns the JSON name of a field of a generated protobuf struct,
+// or "" if it isn't a protobuf field.
+func jsonName(f reflect.StructField) string {
+	if f.Tag.Get("protobuf") == "" && f.Tag.Get("protobuf_oneof") == "" {
+		return ""
+	}
+	return strings.Split(f.Tag.Get("json"), ",")[0]
+}
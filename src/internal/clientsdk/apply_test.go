@@ -0,0 +1,151 @@
+package clientsdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// fakePPS stores a single pipeline, and fills in a salt like pachd does.
+type fakePPS struct {
+	pps.APIClient
+	info    *pps.PipelineInfo
+	creates int
+}
+
+func (f *fakePPS) InspectPipeline(_ context.Context, req *pps.InspectPipelineRequest, _ ...grpc.CallOption) (*pps.PipelineInfo, error) {
+	if f.info == nil {
+		return nil, errors.Errorf("pipeline %q not found", req.Pipeline.Name)
+	}
+	return proto.Clone(f.info).(*pps.PipelineInfo), nil
+}
+
+func (f *fakePPS) CreatePipeline(_ context.Context, req *pps.CreatePipelineRequest, _ ...grpc.CallOption) (*types.Empty, error) {
+	f.creates++
+	if f.info != nil && !req.Update {
+		return nil, errors.Errorf("pipeline %q already exists", req.Pipeline.Name)
+	}
+	info := &pps.PipelineInfo{Pipeline: req.Pipeline, Version: 1, Details: &pps.PipelineInfo_Details{
+		Transform:       req.Transform,
+		Description:     req.Description,
+		ParallelismSpec: req.ParallelismSpec,
+		Metadata:        req.Metadata,
+		Salt:            req.Salt,
+	}}
+	if f.info != nil {
+		info.Version = f.info.Version + 1
+	}
+	if info.Details.Salt == "" {
+		info.Details.Salt = "salt"
+	}
+	f.info = proto.Clone(info).(*pps.PipelineInfo)
+	return &types.Empty{}, nil
+}
+
+func TestApplyPipeline(t *testing.T) {
+	ctx := context.Background()
+	c := &fakePPS{}
+	req := &pps.CreatePipelineRequest{
+		Pipeline:    &pps.Pipeline{Name: "edges"},
+		Transform:   &pps.Transform{Image: "edges:1"},
+		Description: "finds edges",
+	}
+	result, err := ApplyPipeline(ctx, c, req)
+	require.NoError(t, err)
+	require.Equal(t, ApplyCreated, result)
+	// the salt pachd filled in isn't managed, so it isn't a change
+	result, err = ApplyPipeline(ctx, c, req)
+	require.NoError(t, err)
+	require.Equal(t, ApplyUnchanged, result)
+	require.Equal(t, 1, c.creates)
+
+	// someone else scales the pipeline, which applying the same spec keeps
+	other := proto.Clone(c.info.Details).(*pps.PipelineInfo_Details)
+	_, err = c.CreatePipeline(ctx, &pps.CreatePipelineRequest{
+		Pipeline:        req.Pipeline,
+		Transform:       other.Transform,
+		Description:     other.Description,
+		ParallelismSpec: &pps.ParallelismSpec{Constant: 4},
+		Metadata:        other.Metadata,
+		Salt:            other.Salt,
+		Update:          true,
+	})
+	require.NoError(t, err)
+	req.Transform = &pps.Transform{Image: "edges:2"}
+	result, err = ApplyPipeline(ctx, c, req)
+	require.NoError(t, err)
+	require.Equal(t, ApplyUpdated, result)
+	require.Equal(t, "edges:2", c.info.Details.Transform.Image)
+	require.Equal(t, uint64(4), c.info.Details.ParallelismSpec.Constant)
+	require.Equal(t, "salt", c.info.Details.Salt)
+	result, err = ApplyPipeline(ctx, c, req)
+	require.NoError(t, err)
+	require.Equal(t, ApplyUnchanged, result)
+
+	// a field that's no longer managed is cleared
+	req.Description = ""
+	result, err = ApplyPipeline(ctx, c, req)
+	require.NoError(t, err)
+	require.Equal(t, ApplyUpdated, result)
+	require.Equal(t, "", c.info.Details.Description)
+	require.Equal(t, uint64(4), c.info.Details.ParallelismSpec.Constant)
+
+	// the caller's request isn't modified
+	require.Nil(t, req.Metadata)
+
+	_, err = ApplyPipeline(ctx, c, req, "no_such_field")
+	require.YesError(t, err)
+}
+
+type fakeAuth struct {
+	auth.APIClient
+	binding *auth.RoleBinding
+}
+
+func (f *fakeAuth) GetRoleBinding(context.Context, *auth.GetRoleBindingRequest, ...grpc.CallOption) (*auth.GetRoleBindingResponse, error) {
+	return &auth.GetRoleBindingResponse{Binding: f.binding}, nil
+}
+
+func (f *fakeAuth) ModifyRoleBinding(_ context.Context, req *auth.ModifyRoleBindingRequest, _ ...grpc.CallOption) (*auth.ModifyRoleBindingResponse, error) {
+	if len(req.Roles) == 0 {
+		delete(f.binding.Entries, req.Principal)
+		return &auth.ModifyRoleBindingResponse{}, nil
+	}
+	roles := make(map[string]bool)
+	for _, r := range req.Roles {
+		roles[r] = true
+	}
+	f.binding.Entries[req.Principal] = &auth.Roles{Roles: roles}
+	return &auth.ModifyRoleBindingResponse{}, nil
+}
+
+func TestApplyRoleBinding(t *testing.T) {
+	ctx := context.Background()
+	c := &fakeAuth{binding: &auth.RoleBinding{Entries: map[string]*auth.Roles{
+		"user:bob": {Roles: map[string]bool{auth.RepoOwnerRole: true}},
+	}}}
+	repo := &auth.Resource{Type: auth.ResourceType_REPO, Name: "images"}
+	result, err := ApplyRoleBinding(ctx, c, repo, "user:alice", []string{auth.RepoWriterRole, auth.RepoReaderRole})
+	require.NoError(t, err)
+	require.Equal(t, ApplyCreated, result)
+	result, err = ApplyRoleBinding(ctx, c, repo, "user:alice", []string{auth.RepoReaderRole, auth.RepoWriterRole})
+	require.NoError(t, err)
+	require.Equal(t, ApplyUnchanged, result)
+	result, err = ApplyRoleBinding(ctx, c, repo, "user:alice", []string{auth.RepoReaderRole})
+	require.NoError(t, err)
+	require.Equal(t, ApplyUpdated, result)
+	result, err = ApplyRoleBinding(ctx, c, repo, "user:alice", nil)
+	require.NoError(t, err)
+	require.Equal(t, ApplyUpdated, result)
+	// the other principal's roles are left alone
+	require.Equal(t, 1, len(c.binding.Entries))
+	require.True(t, c.binding.Entries["user:bob"].Roles[auth.RepoOwnerRole])
+}
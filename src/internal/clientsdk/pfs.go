@@ -135,3 +135,153 @@ func ForEachSubscribeCommit(client pfs.API_SubscribeCommitClient, cb func(*pfs.C
 	}
 	return nil
 }
+
+func ForEachSchemaInfo(client pfs.API_ListSchemaClient, cb func(*pfs.SchemaInfo) error) error {
+	for {
+		x, err := client.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return errors.EnsureStack(err)
+		}
+		if err := cb(x); err != nil {
+			if errors.Is(err, pacherr.ErrBreak) {
+				err = nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func ListSchemaInfo(client pfs.API_ListSchemaClient) ([]*pfs.SchemaInfo, error) {
+	var results []*pfs.SchemaInfo
+	if err := ForEachSchemaInfo(client, func(x *pfs.SchemaInfo) error {
+		results = append(results, x)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func ForEachRetentionPolicyInfo(client pfs.API_ListRetentionPolicyClient, cb func(*pfs.RetentionPolicyInfo) error) error {
+	for {
+		x, err := client.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return errors.EnsureStack(err)
+		}
+		if err := cb(x); err != nil {
+			if errors.Is(err, pacherr.ErrBreak) {
+				err = nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func ListRetentionPolicyInfo(client pfs.API_ListRetentionPolicyClient) ([]*pfs.RetentionPolicyInfo, error) {
+	var results []*pfs.RetentionPolicyInfo
+	if err := ForEachRetentionPolicyInfo(client, func(x *pfs.RetentionPolicyInfo) error {
+		results = append(results, x)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func ForEachErasureInfo(client pfs.API_ListErasureClient, cb func(*pfs.ErasureInfo) error) error {
+	for {
+		x, err := client.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return errors.EnsureStack(err)
+		}
+		if err := cb(x); err != nil {
+			if errors.Is(err, pacherr.ErrBreak) {
+				err = nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func ListErasureInfo(client pfs.API_ListErasureClient) ([]*pfs.ErasureInfo, error) {
+	var results []*pfs.ErasureInfo
+	if err := ForEachErasureInfo(client, func(x *pfs.ErasureInfo) error {
+		results = append(results, x)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func ForEachViewInfo(client pfs.API_ListViewClient, cb func(*pfs.ViewInfo) error) error {
+	for {
+		x, err := client.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return errors.EnsureStack(err)
+		}
+		if err := cb(x); err != nil {
+			if errors.Is(err, pacherr.ErrBreak) {
+				err = nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func ListViewInfo(client pfs.API_ListViewClient) ([]*pfs.ViewInfo, error) {
+	var results []*pfs.ViewInfo
+	if err := ForEachViewInfo(client, func(x *pfs.ViewInfo) error {
+		results = append(results, x)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func ForEachProjectInfo(client pfs.API_ListProjectClient, cb func(*pfs.ProjectInfo) error) error {
+	for {
+		x, err := client.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return errors.EnsureStack(err)
+		}
+		if err := cb(x); err != nil {
+			if errors.Is(err, pacherr.ErrBreak) {
+				err = nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func ListProjectInfo(client pfs.API_ListProjectClient) ([]*pfs.ProjectInfo, error) {
+	var results []*pfs.ProjectInfo
+	if err := ForEachProjectInfo(client, func(x *pfs.ProjectInfo) error {
+		results = append(results, x)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
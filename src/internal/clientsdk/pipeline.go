@@ -0,0 +1,623 @@
+package clientsdk
+
+import (
+	"bytes"
+	"time"
+	"unicode"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/ancestry"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/serde"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	"github.com/robfig/cron"
+)
+
+// The builders below construct pipeline specs one field at a time, checking
+// each field as it's set, so that programs generating pipelines find invalid
+// specs where they're built rather than when CreatePipeline rejects them.
+// A builder remembers the first error and ignores every later call, Build
+// returns that error. The checks mirror those done by pachd, but pachd
+// remains the authority: a spec that builds may still be rejected, e.g.
+// because an input repo doesn't exist.
+//
+// Builders must not be modified after they've been passed to another
+// builder.
+
+// TransformBuilder builds a pps.Transform.
+type TransformBuilder struct {
+	t   *pps.Transform
+	err error
+}
+
+// NewTransformBuilder returns a builder for a transform that runs image.
+func NewTransformBuilder(image string) *TransformBuilder {
+	b := &TransformBuilder{t: &pps.Transform{Image: image}}
+	if image == "" {
+		b.err = errors.Errorf("transform must specify an image")
+	}
+	return b
+}
+
+func (b *TransformBuilder) set(f func() error) *TransformBuilder {
+	if b.err == nil {
+		b.err = f()
+	}
+	return b
+}
+
+// Cmd sets the command, and optionally the lines of its stdin.
+func (b *TransformBuilder) Cmd(cmd []string, stdin ...string) *TransformBuilder {
+	return b.set(func() error {
+		if len(cmd) == 0 {
+			return errors.Errorf("transform cmd must not be empty")
+		}
+		b.t.Cmd, b.t.Stdin = cmd, stdin
+		return nil
+	})
+}
+
+// ErrCmd sets the command run for failed datums, and optionally the lines of
+// its stdin.
+func (b *TransformBuilder) ErrCmd(cmd []string, stdin ...string) *TransformBuilder {
+	return b.set(func() error {
+		if len(cmd) == 0 {
+			return errors.Errorf("transform err_cmd must not be empty")
+		}
+		b.t.ErrCmd, b.t.ErrStdin = cmd, stdin
+		return nil
+	})
+}
+
+// Env sets an environment variable.
+func (b *TransformBuilder) Env(name, value string) *TransformBuilder {
+	return b.set(func() error {
+		if name == "" {
+			return errors.Errorf("transform env names must not be empty")
+		}
+		if _, ok := b.t.Env[name]; ok {
+			return errors.Errorf("transform env %q was set more than once", name)
+		}
+		if b.t.Env == nil {
+			b.t.Env = make(map[string]string)
+		}
+		b.t.Env[name] = value
+		return nil
+	})
+}
+
+// Secret exposes a Kubernetes secret to the transform, mounted at mountPath
+// if it's set, and with its key in the environment variable envVar if that's
+// set.
+func (b *TransformBuilder) Secret(name, mountPath, envVar, key string) *TransformBuilder {
+	return b.set(func() error {
+		switch {
+		case name == "":
+			return errors.Errorf("transform secrets must specify a name")
+		case mountPath == "" && envVar == "":
+			return errors.Errorf("transform secret %q must specify a mount path or an env var", name)
+		case envVar != "" && key == "":
+			return errors.Errorf("transform secret %q must specify a key to set env var %q", name, envVar)
+		}
+		b.t.Secrets = append(b.t.Secrets, &pps.SecretMount{
+			Name:      name,
+			MountPath: mountPath,
+			EnvVar:    envVar,
+			Key:       key,
+		})
+		return nil
+	})
+}
+
+// ImagePullSecret adds a Kubernetes secret used to pull the image.
+func (b *TransformBuilder) ImagePullSecret(name string) *TransformBuilder {
+	return b.set(func() error {
+		if name == "" {
+			return errors.Errorf("transform image pull secrets must not be empty")
+		}
+		b.t.ImagePullSecrets = append(b.t.ImagePullSecrets, name)
+		return nil
+	})
+}
+
+// AcceptReturnCode adds return codes that are considered successful.
+func (b *TransformBuilder) AcceptReturnCode(codes ...int64) *TransformBuilder {
+	b.t.AcceptReturnCode = append(b.t.AcceptReturnCode, codes...)
+	return b
+}
+
+// User sets the user the command runs as.
+func (b *TransformBuilder) User(user string) *TransformBuilder {
+	b.t.User = user
+	return b
+}
+
+// WorkingDir sets the directory the command runs in.
+func (b *TransformBuilder) WorkingDir(dir string) *TransformBuilder {
+	b.t.WorkingDir = dir
+	return b
+}
+
+// Debug enables debug logging for the pipeline's workers.
+func (b *TransformBuilder) Debug(debug bool) *TransformBuilder {
+	b.t.Debug = debug
+	return b
+}
+
+// Build returns the transform, or the first error encountered while building
+// it.
+func (b *TransformBuilder) Build() (*pps.Transform, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.t, nil
+}
+
+// InputBuilder builds a pps.Input.
+type InputBuilder struct {
+	in  *pps.Input
+	err error
+}
+
+// NewPFSInputBuilder returns a builder for an input that reads repo, which is
+// split into datums by glob. The input is named after the repo and reads the
+// master branch unless Name or Branch are called.
+func NewPFSInputBuilder(repo, glob string) *InputBuilder {
+	b := &InputBuilder{in: &pps.Input{Pfs: &pps.PFSInput{Repo: repo, Glob: glob}}}
+	switch {
+	case repo == "":
+		b.err = errors.Errorf("input must specify a repo")
+	case glob == "":
+		b.err = errors.Errorf("input %q must specify a glob", repo)
+	}
+	return b
+}
+
+// NewCronInputBuilder returns a builder for an input that ticks on the
+// schedule spec, in cron syntax.
+func NewCronInputBuilder(name, spec string) *InputBuilder {
+	b := &InputBuilder{in: &pps.Input{Cron: &pps.CronInput{Name: name, Spec: spec}}}
+	if err := validateInputName(name); err != nil {
+		b.err = err
+	} else if _, err := cron.ParseStandard(spec); err != nil {
+		b.err = errors.Wrapf(err, "error parsing cron-spec of input %q", name)
+	}
+	return b
+}
+
+// NewUnionInputBuilder returns a builder for the union of inputs. The inputs
+// may have the same names, since each datum only comes from one of them.
+func NewUnionInputBuilder(inputs ...*InputBuilder) *InputBuilder {
+	return combineInputs("union", false, inputs, func(in *pps.Input, children []*pps.Input) { in.Union = children })
+}
+
+// NewCrossInputBuilder returns a builder for the cross product of inputs.
+func NewCrossInputBuilder(inputs ...*InputBuilder) *InputBuilder {
+	return combineInputs("cross", true, inputs, func(in *pps.Input, children []*pps.Input) { in.Cross = children })
+}
+
+// NewJoinInputBuilder returns a builder for the join of inputs on their
+// join_on patterns. Every input must be a PFS input with JoinOn set.
+func NewJoinInputBuilder(inputs ...*InputBuilder) *InputBuilder {
+	b := combineInputs("join", true, inputs, func(in *pps.Input, children []*pps.Input) { in.Join = children })
+	return b.check(func() error {
+		for _, child := range b.in.Join {
+			if child.Pfs == nil || child.Pfs.JoinOn == "" {
+				return errors.Errorf("join inputs must be PFS inputs with join_on set")
+			}
+		}
+		return nil
+	})
+}
+
+// NewGroupInputBuilder returns a builder for the grouping of inputs by their
+// group_by patterns. Every input must be a PFS input with GroupBy set.
+func NewGroupInputBuilder(inputs ...*InputBuilder) *InputBuilder {
+	b := combineInputs("group", true, inputs, func(in *pps.Input, children []*pps.Input) { in.Group = children })
+	return b.check(func() error {
+		for _, child := range b.in.Group {
+			if child.Pfs == nil || child.Pfs.GroupBy == "" {
+				return errors.Errorf("group inputs must be PFS inputs with group_by set")
+			}
+		}
+		return nil
+	})
+}
+
+func combineInputs(kind string, uniqueNames bool, inputs []*InputBuilder, set func(*pps.Input, []*pps.Input)) *InputBuilder {
+	b := &InputBuilder{in: &pps.Input{}}
+	var children []*pps.Input
+	for _, input := range inputs {
+		child, err := input.Build()
+		if err != nil {
+			b.err = err
+			return b
+		}
+		children = append(children, child)
+	}
+	set(b.in, children)
+	return b.check(func() error {
+		if len(children) == 0 {
+			return errors.Errorf("%s input must have at least one input", kind)
+		}
+		if kind != "cross" {
+			for _, child := range children {
+				if containsS3Inputs(child) {
+					return errors.Errorf("S3 inputs in %s expressions are not supported", kind)
+				}
+			}
+		}
+		if uniqueNames {
+			names := make(map[string]bool)
+			for _, child := range children {
+				for name := range inputNames(child) {
+					if names[name] {
+						return errors.Errorf(`name "%s" was used more than once`, name)
+					}
+					names[name] = true
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (b *InputBuilder) check(f func() error) *InputBuilder {
+	if b.err == nil {
+		b.err = f()
+	}
+	return b
+}
+
+// pfs applies f to the builder's PFS input.
+func (b *InputBuilder) pfs(field string, f func(*pps.PFSInput) error) *InputBuilder {
+	if b.in.Pfs == nil {
+		if b.err == nil {
+			b.err = errors.Errorf("%s can only be set on PFS inputs", field)
+		}
+		return b
+	}
+	if b.err == nil {
+		b.err = f(b.in.Pfs)
+	}
+	return b
+}
+
+// Name sets the name of a PFS input, which is where its datums are found under
+// /pfs.
+func (b *InputBuilder) Name(name string) *InputBuilder {
+	return b.pfs("name", func(in *pps.PFSInput) error {
+		if err := validateInputName(name); err != nil {
+			return err
+		}
+		in.Name = name
+		return nil
+	})
+}
+
+// Branch sets the branch a PFS input reads.
+func (b *InputBuilder) Branch(branch string) *InputBuilder {
+	return b.pfs("branch", func(in *pps.PFSInput) error {
+		if err := ancestry.ValidateName(branch); err != nil {
+			return errors.Wrapf(err, "invalid branch of input %q", in.Repo)
+		}
+		in.Branch = branch
+		return nil
+	})
+}
+
+// JoinOn sets the pattern a PFS input is joined on.
+func (b *InputBuilder) JoinOn(pattern string, outerJoin bool) *InputBuilder {
+	return b.pfs("join_on", func(in *pps.PFSInput) error {
+		in.JoinOn, in.OuterJoin = pattern, outerJoin
+		return nil
+	})
+}
+
+// GroupBy sets the pattern a PFS input is grouped by.
+func (b *InputBuilder) GroupBy(pattern string) *InputBuilder {
+	return b.pfs("group_by", func(in *pps.PFSInput) error {
+		in.GroupBy = pattern
+		return nil
+	})
+}
+
+// Lazy makes a PFS input's files be downloaded when they're read.
+func (b *InputBuilder) Lazy() *InputBuilder {
+	return b.pfs("lazy", func(in *pps.PFSInput) error {
+		if in.S3 {
+			return errors.Errorf("input cannot specify both 's3' and 'lazy'")
+		}
+		in.Lazy = true
+		return nil
+	})
+}
+
+// EmptyFiles makes a PFS input's files be presented as empty files.
+func (b *InputBuilder) EmptyFiles() *InputBuilder {
+	return b.pfs("empty_files", func(in *pps.PFSInput) error {
+		if in.S3 {
+			return errors.Errorf("input cannot specify both 's3' and 'empty_files'")
+		}
+		in.EmptyFiles = true
+		return nil
+	})
+}
+
+// S3 makes a PFS input be served by an S3 gateway rather than the file
+// system. The input's glob must be "/".
+func (b *InputBuilder) S3() *InputBuilder {
+	return b.pfs("s3", func(in *pps.PFSInput) error {
+		switch {
+		case in.Glob != "/":
+			return errors.Errorf("inputs that set 's3' to 'true' must also set " +
+				"'glob', to \"/\", as the S3 gateway is only able to expose data " +
+				"at the commit level")
+		case in.Lazy || in.EmptyFiles:
+			return errors.Errorf("input cannot specify 's3' with 'lazy' or 'empty_files'")
+		}
+		in.S3 = true
+		return nil
+	})
+}
+
+// Trigger sets when a PFS input is processed.
+func (b *InputBuilder) Trigger(trigger *pfs.Trigger) *InputBuilder {
+	return b.pfs("trigger", func(in *pps.PFSInput) error {
+		in.Trigger = trigger
+		return nil
+	})
+}
+
+// cron applies f to the builder's cron input.
+func (b *InputBuilder) cron(field string, f func(*pps.CronInput) error) *InputBuilder {
+	if b.in.Cron == nil {
+		if b.err == nil {
+			b.err = errors.Errorf("%s can only be set on cron inputs", field)
+		}
+		return b
+	}
+	if b.err == nil {
+		b.err = f(b.in.Cron)
+	}
+	return b
+}
+
+// Timezone sets the IANA time zone a cron input's spec is evaluated in.
+func (b *InputBuilder) Timezone(tz string) *InputBuilder {
+	return b.cron("timezone", func(in *pps.CronInput) error {
+		if _, err := time.LoadLocation(tz); err != nil {
+			return errors.Wrapf(err, "invalid timezone of input %q", in.Name)
+		}
+		in.Timezone = tz
+		return nil
+	})
+}
+
+// Jitter sets the most a cron input's ticks are randomly delayed by.
+func (b *InputBuilder) Jitter(jitter time.Duration) *InputBuilder {
+	return b.cron("jitter", func(in *pps.CronInput) error {
+		if jitter < 0 {
+			return errors.Errorf("jitter of input %q must be non-negative", in.Name)
+		}
+		in.Jitter = types.DurationProto(jitter)
+		return nil
+	})
+}
+
+// Backfill makes a new pipeline's cron input tick for every scheduled time
+// in the window before the pipeline is created.
+func (b *InputBuilder) Backfill(window time.Duration) *InputBuilder {
+	return b.cron("backfill", func(in *pps.CronInput) error {
+		if window < 0 {
+			return errors.Errorf("backfill of input %q must be non-negative", in.Name)
+		}
+		in.Backfill = types.DurationProto(window)
+		return nil
+	})
+}
+
+// Build returns the input, or the first error encountered while building it.
+func (b *InputBuilder) Build() (*pps.Input, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.in.Pfs != nil && b.in.Pfs.Repo == "out" && b.in.Pfs.Name == "" {
+		return nil, errors.Errorf("inputs based on repos named \"out\" must have " +
+			"'name' set, as pachyderm already creates /pfs/out to collect " +
+			"job output")
+	}
+	return b.in, nil
+}
+
+func validateInputName(name string) error {
+	switch name {
+	case "":
+		return errors.Errorf("input must specify a name")
+	case "out":
+		return errors.Errorf("input cannot be named \"out\", as pachyderm " +
+			"already creates /pfs/out to collect job output")
+	}
+	return nil
+}
+
+// inputNames returns the names an input's datums may appear under, the
+// defaults pachd would fill in included.
+func inputNames(in *pps.Input) map[string]bool {
+	names := make(map[string]bool)
+	pps.VisitInput(in, func(in *pps.Input) error {
+		switch {
+		case in.Pfs != nil && in.Pfs.Name != "":
+			names[in.Pfs.Name] = true
+		case in.Pfs != nil:
+			names[in.Pfs.Repo] = true
+		case in.Cron != nil:
+			names[in.Cron.Name] = true
+		}
+		return nil
+	})
+	return names
+}
+
+func containsS3Inputs(in *pps.Input) bool {
+	var found bool
+	pps.VisitInput(in, func(in *pps.Input) error {
+		if in.Pfs != nil && in.Pfs.S3 {
+			found = true
+		}
+		return nil
+	})
+	return found
+}
+
+// PipelineBuilder builds a pps.CreatePipelineRequest.
+type PipelineBuilder struct {
+	req *pps.CreatePipelineRequest
+	err error
+}
+
+// NewPipelineBuilder returns a builder for the pipeline name.
+func NewPipelineBuilder(name string) *PipelineBuilder {
+	b := &PipelineBuilder{req: &pps.CreatePipelineRequest{Pipeline: &pps.Pipeline{Name: name}}}
+	switch {
+	case name == "":
+		b.err = errors.Errorf("pipeline must specify a name")
+	case len(name) > 63:
+		b.err = errors.Errorf("pipeline name is %d characters long, but must have at most 63: %q", len(name), name)
+	case !unicode.IsLetter(rune(name[0])) && !unicode.IsDigit(rune(name[0])):
+		b.err = errors.Errorf("pipeline names must start with an alphanumeric character")
+	default:
+		if err := ancestry.ValidateName(name); err != nil {
+			b.err = errors.Wrapf(err, "invalid pipeline name")
+		}
+	}
+	return b
+}
+
+func (b *PipelineBuilder) set(f func() error) *PipelineBuilder {
+	if b.err == nil {
+		b.err = f()
+	}
+	return b
+}
+
+// Transform sets the pipeline's transform.
+func (b *PipelineBuilder) Transform(t *TransformBuilder) *PipelineBuilder {
+	return b.set(func() error {
+		transform, err := t.Build()
+		if err != nil {
+			return err
+		}
+		b.req.Transform = transform
+		return nil
+	})
+}
+
+// Input sets the pipeline's input.
+func (b *PipelineBuilder) Input(in *InputBuilder) *PipelineBuilder {
+	return b.set(func() error {
+		if b.req.Spout != nil {
+			return errors.Errorf("spout pipelines must not have an input")
+		}
+		input, err := in.Build()
+		if err != nil {
+			return err
+		}
+		b.req.Input = input
+		return nil
+	})
+}
+
+// Spout makes the pipeline a spout, which has no input.
+func (b *PipelineBuilder) Spout() *PipelineBuilder {
+	return b.set(func() error {
+		if b.req.Input != nil {
+			return errors.Errorf("spout pipelines must not have an input")
+		}
+		b.req.Spout = &pps.Spout{}
+		return nil
+	})
+}
+
+// Description sets the pipeline's description.
+func (b *PipelineBuilder) Description(description string) *PipelineBuilder {
+	b.req.Description = description
+	return b
+}
+
+// OutputBranch sets the branch of the output repo that jobs commit to.
+func (b *PipelineBuilder) OutputBranch(branch string) *PipelineBuilder {
+	return b.set(func() error {
+		if err := ancestry.ValidateName(branch); err != nil {
+			return errors.Wrapf(err, "invalid output branch")
+		}
+		b.req.OutputBranch = branch
+		return nil
+	})
+}
+
+// Parallelism sets the number of workers.
+func (b *PipelineBuilder) Parallelism(workers uint64) *PipelineBuilder {
+	return b.set(func() error {
+		if workers == 0 {
+			return errors.Errorf("pipeline parallelism must be at least 1")
+		}
+		b.req.ParallelismSpec = &pps.ParallelismSpec{Constant: workers}
+		return nil
+	})
+}
+
+// DatumTries sets the number of times a datum is tried before it fails.
+func (b *PipelineBuilder) DatumTries(tries int64) *PipelineBuilder {
+	return b.set(func() error {
+		if tries < 1 {
+			return errors.Errorf("pipeline datum_tries must be at least 1")
+		}
+		b.req.DatumTries = tries
+		return nil
+	})
+}
+
+// Egress sets where the pipeline's output commits are exported to.
+func (b *PipelineBuilder) Egress(egress *pps.Egress) *PipelineBuilder {
+	b.req.Egress = egress
+	return b
+}
+
+// Update makes the request update the pipeline if it exists, reprocessing
+// all datums if reprocess is set.
+func (b *PipelineBuilder) Update(reprocess bool) *PipelineBuilder {
+	b.req.Update, b.req.Reprocess = true, reprocess
+	return b
+}
+
+// Build returns the request, or the first error encountered while building
+// it.
+func (b *PipelineBuilder) Build() (*pps.CreatePipelineRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.req.Transform == nil {
+		return nil, errors.Errorf("pipeline must specify a transform")
+	}
+	if b.req.Input == nil && b.req.Spout == nil {
+		return nil, errors.Errorf("pipeline must specify an input")
+	}
+	return b.req, nil
+}
+
+// MarshalJSON returns the pipeline spec in the format read by 'pachctl
+// create pipeline'.
+func (b *PipelineBuilder) MarshalJSON() ([]byte, error) {
+	req, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := serde.NewJSONEncoder(&buf, serde.WithIndent(2), serde.WithOrigName(true)).EncodeProto(req); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,81 @@
+package clientsdk
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/internal/serde"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestPipelineBuilder(t *testing.T) {
+	b := NewPipelineBuilder("edges").
+		Description("finds edges").
+		Transform(NewTransformBuilder("pachyderm/opencv").
+			Cmd([]string{"python3", "/edges.py"}).
+			Env("MODE", "fast")).
+		Input(NewCrossInputBuilder(
+			NewPFSInputBuilder("images", "/*").Lazy(),
+			NewUnionInputBuilder(
+				NewPFSInputBuilder("a", "/*").Name("params"),
+				NewPFSInputBuilder("b", "/").Name("params").Branch("staging"),
+			),
+		)).
+		Parallelism(2)
+	req, err := b.Build()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(req.Input.Cross))
+	require.Equal(t, "staging", req.Input.Cross[1].Union[1].Pfs.Branch)
+
+	// The JSON is parsed back to the same request, as 'pachctl create
+	// pipeline' would parse it.
+	data, err := json.Marshal(b)
+	require.NoError(t, err)
+	read := &pps.CreatePipelineRequest{}
+	require.NoError(t, serde.Decode(data, read))
+	require.Equal(t, req, read)
+}
+
+func TestPipelineBuilderErrors(t *testing.T) {
+	transform := func() *TransformBuilder { return NewTransformBuilder("ubuntu").Cmd([]string{"true"}) }
+	for name, b := range map[string]*PipelineBuilder{
+		"bad name":      NewPipelineBuilder("-bad").Transform(transform()).Input(NewPFSInputBuilder("in", "/")),
+		"no transform":  NewPipelineBuilder("p").Input(NewPFSInputBuilder("in", "/")),
+		"no input":      NewPipelineBuilder("p").Transform(transform()),
+		"no image":      NewPipelineBuilder("p").Transform(NewTransformBuilder("")).Input(NewPFSInputBuilder("in", "/")),
+		"spout input":   NewPipelineBuilder("p").Transform(transform()).Spout().Input(NewPFSInputBuilder("in", "/")),
+		"no glob":       NewPipelineBuilder("p").Transform(transform()).Input(NewPFSInputBuilder("in", "")),
+		"unnamed out":   NewPipelineBuilder("p").Transform(transform()).Input(NewPFSInputBuilder("out", "/")),
+		"cross names":   NewPipelineBuilder("p").Transform(transform()).Input(NewCrossInputBuilder(NewPFSInputBuilder("in", "/"), NewPFSInputBuilder("x", "/").Name("in"))),
+		"union names":   NewPipelineBuilder("p").Transform(transform()).Input(NewCrossInputBuilder(NewPFSInputBuilder("in", "/"), NewUnionInputBuilder(NewPFSInputBuilder("in", "/")))),
+		"s3 glob":       NewPipelineBuilder("p").Transform(transform()).Input(NewPFSInputBuilder("in", "/*").S3()),
+		"s3 join":       NewPipelineBuilder("p").Transform(transform()).Input(NewJoinInputBuilder(NewPFSInputBuilder("in", "/").S3().JoinOn("$1", false))),
+		"join without":  NewPipelineBuilder("p").Transform(transform()).Input(NewJoinInputBuilder(NewPFSInputBuilder("in", "/"))),
+		"cron spec":     NewPipelineBuilder("p").Transform(transform()).Input(NewCronInputBuilder("tick", "every day")),
+		"cron branch":   NewPipelineBuilder("p").Transform(transform()).Input(NewCronInputBuilder("tick", "@daily").Branch("master")),
+		"cron tz":       NewPipelineBuilder("p").Transform(transform()).Input(NewCronInputBuilder("tick", "@daily").Timezone("Mars/Olympus")),
+		"pfs jitter":    NewPipelineBuilder("p").Transform(transform()).Input(NewPFSInputBuilder("in", "/").Jitter(time.Minute)),
+		"neg backfill":  NewPipelineBuilder("p").Transform(transform()).Input(NewCronInputBuilder("tick", "@daily").Backfill(-time.Hour)),
+		"empty cross":   NewPipelineBuilder("p").Transform(transform()).Input(NewCrossInputBuilder()),
+		"zero parallel": NewPipelineBuilder("p").Transform(transform()).Input(NewPFSInputBuilder("in", "/")).Parallelism(0),
+	} {
+		_, err := b.Build()
+		require.YesError(t, err, name)
+		_, err = json.Marshal(b)
+		require.YesError(t, err, name)
+	}
+
+	// A repo named "out" is fine as long as the input is renamed.
+	_, err := NewPipelineBuilder("p").Transform(transform()).Input(NewPFSInputBuilder("out", "/").Name("upstream")).Build()
+	require.NoError(t, err)
+}
+
+func TestCronInputBuilder(t *testing.T) {
+	in, err := NewCronInputBuilder("tick", "0 9 * * *").Timezone("America/New_York").Jitter(time.Minute).Backfill(24 * time.Hour).Build()
+	require.NoError(t, err)
+	require.Equal(t, "America/New_York", in.Cron.Timezone)
+	require.Equal(t, int64(60), in.Cron.Jitter.Seconds)
+	require.Equal(t, int64(24*60*60), in.Cron.Backfill.Seconds)
+}
@@ -3,9 +3,15 @@ package clusterstate
 import (
 	"context"
 
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
 	"github.com/pachyderm/pachyderm/v2/src/internal/migrations"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
 	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
+	adminserver "github.com/pachyderm/pachyderm/v2/src/server/admin/server"
+	authserver "github.com/pachyderm/pachyderm/v2/src/server/auth/server"
 	enterpriseserver "github.com/pachyderm/pachyderm/v2/src/server/enterprise/server"
+	pfsserver "github.com/pachyderm/pachyderm/v2/src/server/pfs/server"
+	searchserver "github.com/pachyderm/pachyderm/v2/src/server/search/server"
 )
 
 var state_2_1_0 migrations.State = state_2_0_0.
@@ -17,4 +23,46 @@ var state_2_1_0 migrations.State = state_2_0_0.
 	}).
 	Apply("create pfs cache v1", func(ctx context.Context, env migrations.Env) error {
 		return fileset.CreatePostgresCacheV1(ctx, env.Tx)
+	}).
+	Apply("create auth role requests collection", func(ctx context.Context, env migrations.Env) error {
+		return col.SetupPostgresCollections(ctx, env.Tx, authserver.RoleRequestsCollectionsV0()...)
+	}).
+	Apply("create admin webhooks collection", func(ctx context.Context, env migrations.Env) error {
+		return col.SetupPostgresCollections(ctx, env.Tx, adminserver.WebhooksCollectionsV0()...)
+	}).
+	Apply("create admin cluster defaults collection", func(ctx context.Context, env migrations.Env) error {
+		return col.SetupPostgresCollections(ctx, env.Tx, adminserver.ClusterDefaultsCollectionsV0()...)
+	}).
+	Apply("create pfs schemas collection", func(ctx context.Context, env migrations.Env) error {
+		return col.SetupPostgresCollections(ctx, env.Tx, pfsdb.SchemasCollectionsV0()...)
+	}).
+	Apply("create auth acl freeze collection", func(ctx context.Context, env migrations.Env) error {
+		return col.SetupPostgresCollections(ctx, env.Tx, authserver.ACLFreezeCollectionsV0()...)
+	}).
+	Apply("create pfs projects collection", func(ctx context.Context, env migrations.Env) error {
+		return col.SetupPostgresCollections(ctx, env.Tx, pfsdb.ProjectsCollectionsV0()...)
+	}).
+	Apply("create pfs commit checkpoints table", func(ctx context.Context, env migrations.Env) error {
+		return pfsserver.SetupPostgresCommitCheckpointsV0(ctx, env.Tx)
+	}).
+	Apply("create admin capacity samples table", func(ctx context.Context, env migrations.Env) error {
+		return adminserver.SetupPostgresCapacitySamplesV0(ctx, env.Tx)
+	}).
+	Apply("create search index tables", func(ctx context.Context, env migrations.Env) error {
+		return searchserver.SetupPostgresSearchV0(ctx, env.Tx)
+	}).
+	Apply("create pfs retention policies collection", func(ctx context.Context, env migrations.Env) error {
+		return col.SetupPostgresCollections(ctx, env.Tx, pfsdb.RetentionPoliciesCollectionsV0()...)
+	}).
+	Apply("create pfs erasures collection", func(ctx context.Context, env migrations.Env) error {
+		return col.SetupPostgresCollections(ctx, env.Tx, pfsdb.ErasuresCollectionsV0()...)
+	}).
+	Apply("create pfs validation policies collection", func(ctx context.Context, env migrations.Env) error {
+		return col.SetupPostgresCollections(ctx, env.Tx, pfsdb.ValidationPoliciesCollectionsV0()...)
+	}).
+	Apply("create pfs views collection", func(ctx context.Context, env migrations.Env) error {
+		return col.SetupPostgresCollections(ctx, env.Tx, pfsdb.ViewsCollectionsV0()...)
+	}).
+	Apply("create pfs uploads collection", func(ctx context.Context, env migrations.Env) error {
+		return col.SetupPostgresCollections(ctx, env.Tx, pfsdb.UploadsCollectionsV0()...)
 	})
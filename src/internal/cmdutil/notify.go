@@ -0,0 +1,109 @@
+package cmdutil
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/spf13/pflag"
+)
+
+// NotifyCmdEnv is the environment variable that holds the command that
+// --notify runs when a command finishes. A desktop notification is shown if
+// it's unset.
+const NotifyCmdEnv = "PACHCTL_NOTIFY_CMD"
+
+// NotifyFlags returns the --notify flag of long-running commands.
+func NotifyFlags(notify *bool) *pflag.FlagSet {
+	notifyFlags := pflag.NewFlagSet("", pflag.ContinueOnError)
+	notifyFlags.BoolVar(notify, "notify", false, fmt.Sprintf("When the command finishes, run $%s with its result, or show a desktop notification if it's unset.", NotifyCmdEnv))
+	return notifyFlags
+}
+
+// Notify wraps the run function of a command so that, if notify is set when
+// it's run, the user is notified of its result when it finishes. The command's
+// result isn't changed if the notification fails.
+func Notify(notify *bool, run func([]string) error) func([]string) error {
+	return func(args []string) error {
+		if !*notify {
+			return run(args)
+		}
+		start := time.Now()
+		err := run(args)
+		n := &notification{
+			command:  commandLine(os.Args),
+			err:      err,
+			duration: time.Since(start).Round(time.Second),
+		}
+		if nErr := n.send(os.Getenv(NotifyCmdEnv)); nErr != nil {
+			fmt.Fprintf(os.Stderr, "could not send notification: %v\n", nErr)
+		}
+		return err
+	}
+}
+
+// commandLine returns the command line that pachctl was run with, for
+// notifications.
+func commandLine(args []string) string {
+	if len(args) == 0 {
+		return "pachctl"
+	}
+	return strings.Join(append([]string{filepath.Base(args[0])}, args[1:]...), " ")
+}
+
+type notification struct {
+	command  string
+	err      error
+	duration time.Duration
+}
+
+func (n *notification) message() string {
+	if n.err != nil {
+		return fmt.Sprintf("%q failed after %v: %v", n.command, n.duration, n.err)
+	}
+	return fmt.Sprintf("%q succeeded after %v", n.command, n.duration)
+}
+
+// env returns the environment variables that describe the notification to
+// the notify command.
+func (n *notification) env() []string {
+	status, exitCode, errMsg := "succeeded", "0", ""
+	if n.err != nil {
+		status, exitCode, errMsg = "failed", "1", n.err.Error()
+	}
+	return []string{
+		"PACHCTL_NOTIFY_COMMAND=" + n.command,
+		"PACHCTL_NOTIFY_STATUS=" + status,
+		"PACHCTL_NOTIFY_EXIT_CODE=" + exitCode,
+		"PACHCTL_NOTIFY_ERROR=" + errMsg,
+		fmt.Sprintf("PACHCTL_NOTIFY_DURATION=%d", int64(n.duration.Seconds())),
+		"PACHCTL_NOTIFY_MESSAGE=" + n.message(),
+	}
+}
+
+// send runs notifyCmd with a shell, or shows a desktop notification if it's
+// empty.
+func (n *notification) send(notifyCmd string) error {
+	var cmd *exec.Cmd
+	switch {
+	case notifyCmd != "" && runtime.GOOS == "windows":
+		cmd = exec.Command("cmd", "/C", notifyCmd)
+	case notifyCmd != "":
+		cmd = exec.Command("sh", "-c", notifyCmd)
+	case runtime.GOOS == "darwin":
+		cmd = exec.Command("osascript", "-e", fmt.Sprintf("display notification %q with title \"pachctl\"", n.message()))
+	case runtime.GOOS == "linux":
+		cmd = exec.Command("notify-send", "pachctl", n.message())
+	default:
+		return errors.Errorf("no desktop notifications on %s, set %s to a command to notify you", runtime.GOOS, NotifyCmdEnv)
+	}
+	cmd.Env = append(os.Environ(), n.env()...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	return errors.EnsureStack(cmd.Run())
+}
@@ -0,0 +1,38 @@
+package cmdutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestNotify(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+	t.Setenv(NotifyCmdEnv, `echo "$PACHCTL_NOTIFY_STATUS $PACHCTL_NOTIFY_EXIT_CODE $PACHCTL_NOTIFY_ERROR" > `+out)
+	notify := true
+	err := Notify(&notify, func([]string) error { return errors.New("commit not found") })(nil)
+	require.YesError(t, err)
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, "failed 1 commit not found\n", string(data))
+
+	notify = false
+	require.NoError(t, os.Remove(out))
+	require.NoError(t, Notify(&notify, func([]string) error { return nil })(nil))
+	_, err = os.Stat(out)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestNotificationMessage(t *testing.T) {
+	n := &notification{
+		command:  commandLine([]string{"/usr/local/bin/pachctl", "put", "file", "-r", "images@master", "-f", "data"}),
+		duration: 2 * time.Hour,
+	}
+	require.Equal(t, `"pachctl put file -r images@master -f data" succeeded after 2h0m0s`, n.message())
+	n.err = errors.New("connection reset")
+	require.Equal(t, `"pachctl put file -r images@master -f data" failed after 2h0m0s: connection reset`, n.message())
+}
@@ -0,0 +1,45 @@
+package cmdutil
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/spf13/pflag"
+)
+
+// TimeRangeFlags returns the --<name>-after and --<name>-before flags of list
+// commands, which restrict the results to those <name> in a time range, e.g.
+// --created-after. The flags are parsed with ParseTimestamp.
+func TimeRangeFlags(name string, after, before *string) *pflag.FlagSet {
+	timeRangeFlags := pflag.NewFlagSet("", pflag.ContinueOnError)
+	timeRangeFlags.StringVar(after, name+"-after", "", fmt.Sprintf("Return only results %s after this time: an RFC 3339 timestamp, a date (YYYY-MM-DD), or a duration ago (e.g. 24h).", name))
+	timeRangeFlags.StringVar(before, name+"-before", "", fmt.Sprintf("Return only results %s before this time: an RFC 3339 timestamp, a date (YYYY-MM-DD), or a duration ago (e.g. 24h).", name))
+	return timeRangeFlags
+}
+
+// ParseTimestamp parses an RFC 3339 timestamp, a date, or a duration, which is
+// the time that long ago. It returns nil if s is empty.
+func ParseTimestamp(s string) (*types.Timestamp, error) {
+	return parseTimestamp(s, time.Now())
+}
+
+func parseTimestamp(s string, now time.Time) (*types.Timestamp, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t, err = time.Parse("2006-01-02", s)
+	}
+	if err != nil {
+		d, dErr := time.ParseDuration(s)
+		if dErr != nil {
+			return nil, errors.Errorf("could not parse %q as a timestamp, date or duration", s)
+		}
+		t = now.Add(-d)
+	}
+	ts, err := types.TimestampProto(t)
+	return ts, errors.EnsureStack(err)
+}
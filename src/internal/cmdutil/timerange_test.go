@@ -0,0 +1,32 @@
+package cmdutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	check := func(s string, expected time.Time) {
+		t.Helper()
+		ts, err := parseTimestamp(s, now)
+		require.NoError(t, err)
+		actual, err := types.TimestampFromProto(ts)
+		require.NoError(t, err)
+		require.True(t, expected.Equal(actual), "%q: expected %v, got %v", s, expected, actual)
+	}
+	check("2022-05-01T10:30:00Z", time.Date(2022, 5, 1, 10, 30, 0, 0, time.UTC))
+	check("2022-05-01", time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC))
+	check("90m", now.Add(-90*time.Minute))
+
+	ts, err := parseTimestamp("", now)
+	require.NoError(t, err)
+	require.Nil(t, ts)
+
+	_, err = parseTimestamp("yesterday", now)
+	require.YesError(t, err)
+}
@@ -17,6 +17,7 @@ import (
 	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pgetcd"
 	"github.com/pachyderm/pachyderm/v2/src/internal/ppsdb"
 	"github.com/pachyderm/pachyderm/v2/src/internal/require"
 	"github.com/pachyderm/pachyderm/v2/src/internal/testetcd"
@@ -36,20 +37,49 @@ var (
 
 func TestEtcdCollections(suite *testing.T) {
 	etcdEnv := testetcd.NewEnv(suite)
-	newCollection := func(ctx context.Context, t *testing.T, noIndex ...bool) (ReadCallback, WriteCallback) {
+	newCollection := newEtcdCollectionFunc(etcdEnv.EtcdClient)
+	collectionTests(suite, newCollection)
+	watchTests(suite, newCollection)
+}
+
+// TestPostgresBackedEtcdCollections runs the etcd collection tests against
+// the postgres metadata backend.
+func TestPostgresBackedEtcdCollections(suite *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	suite.Cleanup(cancel)
+	db, dsn := newTestDirectDB(suite)
+	listener := col.NewPostgresListener(dsn)
+	suite.Cleanup(func() {
+		require.NoError(suite, listener.Close())
+	})
+	server := pgetcd.NewServer(db, listener)
+	require.NoError(suite, server.Setup(ctx))
+	etcdClient, err := server.NewClient(ctx)
+	require.NoError(suite, err)
+	suite.Cleanup(func() {
+		require.NoError(suite, etcdClient.Close())
+	})
+
+	newCollection := newEtcdCollectionFunc(etcdClient)
+	collectionTests(suite, newCollection)
+	watchTests(suite, newCollection)
+}
+
+func newEtcdCollectionFunc(etcdClient *etcd.Client) func(context.Context, *testing.T, ...bool) (ReadCallback, WriteCallback) {
+	return func(ctx context.Context, t *testing.T, noIndex ...bool) (ReadCallback, WriteCallback) {
 		prefix := testutil.UniqueString("test-etcd-collections-")
 		index := []*col.Index{TestSecondaryIndex}
 		if len(noIndex) > 0 && noIndex[0] {
 			index = nil
 		}
-		testCol := col.NewEtcdCollection(etcdEnv.EtcdClient, prefix, index, &col.TestItem{}, nil, nil)
+		testCol := col.NewEtcdCollection(etcdClient, prefix, index, &col.TestItem{}, nil, nil)
 
 		readCallback := func(ctx context.Context) col.ReadOnlyCollection {
 			return testCol.ReadOnly(ctx)
 		}
 
 		writeCallback := func(ctx context.Context, f func(col.ReadWriteCollection) error) error {
-			_, err := col.NewSTM(ctx, etcdEnv.EtcdClient, func(stm col.STM) (retErr error) {
+			_, err := col.NewSTM(ctx, etcdClient, func(stm col.STM) (retErr error) {
 				return f(testCol.ReadWrite(stm))
 			})
 			return errors.EnsureStack(err)
@@ -57,9 +87,6 @@ func TestEtcdCollections(suite *testing.T) {
 
 		return readCallback, writeCallback
 	}
-
-	collectionTests(suite, newCollection)
-	watchTests(suite, newCollection)
 }
 
 func TestDryrun(t *testing.T) {
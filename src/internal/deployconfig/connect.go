@@ -0,0 +1,100 @@
+package deployconfig
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/api/option"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
+)
+
+// connectTimeout bounds each check that connects to a service.
+const connectTimeout = 10 * time.Second
+
+// probeObject is the object that the storage check reads. It isn't expected
+// to exist.
+const probeObject = "pachyderm-validate-configuration"
+
+// newStorageClient returns a client for the bucket that the values configure
+// backend with.
+func newStorageClient(v values, backend string) (obj.Client, error) {
+	prefix := "pachd.storage." + strings.ToLower(backend) + "."
+	switch backend {
+	case "AMAZON":
+		var creds *obj.AmazonCreds
+		if id := v.str(prefix + "id"); id != "" {
+			creds = &obj.AmazonCreds{ID: id, Secret: v.str(prefix + "secret"), Token: v.str(prefix + "token")}
+		}
+		return obj.NewAmazonClient(v.str(prefix+"region"), v.str(prefix+"bucket"), creds, v.str(prefix+"cloudFrontDistribution"), v.str(prefix+"customEndpoint"))
+	case "GOOGLE":
+		var opts []option.ClientOption
+		if cred := v.str(prefix + "cred"); cred != "" {
+			opts = append(opts, option.WithCredentialsJSON([]byte(cred)))
+		}
+		return obj.NewGoogleClient(v.str(prefix+"bucket"), opts)
+	case "MICROSOFT":
+		return obj.NewMicrosoftClient(v.str(prefix+"container"), v.str(prefix+"id"), v.str(prefix+"secret"))
+	case "MINIO":
+		return obj.NewMinioClient(v.str(prefix+"endpoint"), v.str(prefix+"bucket"), v.str(prefix+"id"), v.str(prefix+"secret"),
+			v.str(prefix+"secure") == "true", v.str(prefix+"signature") == "1")
+	}
+	return nil, nil
+}
+
+// checkStorageAccess checks that the bucket that the values configure can be
+// read with their credentials.
+func checkStorageAccess(ctx context.Context, v values, backend string, ps *problems) {
+	path := "pachd.storage." + strings.ToLower(backend)
+	client, err := newStorageClient(v, backend)
+	if err != nil {
+		ps.errorf(path, "could not create an object storage client: %v", err)
+		return
+	}
+	if client == nil {
+		// local storage is on the nodes, so it can't be checked
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+	if _, err := client.Exists(ctx, probeObject); err != nil {
+		ps.errorf(path, "could not read the bucket %s, check that it exists and that the credentials can read it: %v", client.BucketURL().String(), err)
+	}
+}
+
+// checkIssuer checks that an OIDC identity provider's discovery document can
+// be fetched, and that it's for the configured issuer.
+func checkIssuer(ctx context.Context, provider idp, ps *problems) {
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+	discoveryURL := strings.TrimSuffix(provider.issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		ps.errorf(provider.path, "could not create a request for the issuer's discovery document: %v", err)
+		return
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		ps.errorf(provider.path, "the issuer %s isn't reachable: %v", provider.issuer, errors.EnsureStack(err))
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		ps.errorf(provider.path, "the issuer %s has no discovery document, %s returned %s", provider.issuer, discoveryURL, resp.Status)
+		return
+	}
+	var document struct {
+		Issuer string `json:"issuer"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		ps.errorf(provider.path, "could not parse the issuer's discovery document %s: %v", discoveryURL, err)
+		return
+	}
+	if strings.TrimSuffix(document.Issuer, "/") != strings.TrimSuffix(provider.issuer, "/") {
+		ps.errorf(provider.path, "the issuer should be %q, the issuer of its discovery document, not %q", document.Issuer, provider.issuer)
+	}
+}
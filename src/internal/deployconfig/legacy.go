@@ -0,0 +1,660 @@
+package deployconfig
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// LegacyTargets are the targets of 1.x's 'pachctl deploy', which the Helm
+// chart replaced.
+var LegacyTargets = []string{"local", "amazon", "google", "microsoft", "custom"}
+
+// legacyArgs are the arguments of each legacy target. Arguments in brackets
+// are optional.
+var legacyArgs = map[string][]string{
+	"local":     nil,
+	"amazon":    {"bucket-name", "region", "disk-size"},
+	"google":    {"bucket-name", "disk-size", "[credentials-file]"},
+	"microsoft": {"container", "account-name", "account-key", "disk-size"},
+	"custom":    {"disk-name", "disk-size", "bucket", "id", "secret", "endpoint"},
+}
+
+// LegacyUsage returns the arguments of a legacy target, as its usage shows
+// them.
+func LegacyUsage(target string) string {
+	var usage []string
+	for _, arg := range legacyArgs[target] {
+		if strings.HasPrefix(arg, "[") {
+			usage = append(usage, "[<"+strings.Trim(arg, "[]")+">]")
+		} else {
+			usage = append(usage, "<"+arg+">")
+		}
+	}
+	return strings.Join(usage, " ")
+}
+
+type flagKind int
+
+const (
+	stringFlag flagKind = iota
+	intFlag
+	boolFlag
+	// negatedFlag is a boolean flag that sets a value to false.
+	negatedFlag
+)
+
+// legacyFlag is a flag of 1.x's 'pachctl deploy'.
+type legacyFlag struct {
+	name      string
+	shorthand string
+	kind      flagKind
+	// def is the flag's default, as a string.
+	def   string
+	usage string
+	// targets are the targets that have the flag, or nil if they all do.
+	targets []string
+	// path is the chart value that the flag sets. Flags without a path or
+	// an explanation are converted by legacyToValues and valuesToLegacy.
+	path []string
+	// unsupported explains why the chart has no equivalent of the flag.
+	unsupported string
+	// ignored flags only changed what pachctl did, not the cluster.
+	ignored bool
+}
+
+func (f *legacyFlag) appliesTo(target string) bool {
+	return f.targets == nil || contains(f.targets, target)
+}
+
+func keys(path string) []string {
+	return strings.Split(path, ".")
+}
+
+const (
+	noDashboard     = "the dashboard is replaced by the console, see the console values"
+	noObjectOptions = "the chart's MINIO storage backend has no equivalent"
+)
+
+var legacyFlags = []*legacyFlag{
+	{name: "block-cache-size", usage: "Size of pachd's in-memory cache for PFS files.", unsupported: "pachd no longer has a block cache"},
+	{name: "cluster-deployment-id", usage: "Set an ID for the cluster deployment.", path: keys("pachd.clusterDeploymentID")},
+	{name: "context", shorthand: "c", usage: "Name of the context to add to the pachyderm config.", ignored: true},
+	{name: "create-context", kind: boolFlag, def: "false", usage: "Create a context, even with --dry-run.", ignored: true},
+	{name: "dash-image", usage: "Image URL for pachyderm dashboard.", unsupported: noDashboard},
+	{name: "dashboard-only", kind: boolFlag, def: "false", usage: "Only deploy the Pachyderm UI.", unsupported: noDashboard},
+	{name: "dev", shorthand: "d", kind: boolFlag, def: "false", usage: "Deploy pachd with local version tags.", unsupported: "set pachd.image.tag to deploy a development build"},
+	{name: "dry-run", kind: boolFlag, def: "false", usage: "Print the manifest rather than deploying it.", ignored: true},
+	{name: "dynamic-etcd-nodes", kind: intFlag, def: "0", usage: "Deploy etcd as a StatefulSet with the given number of pods.", path: keys("etcd.dynamicNodes")},
+	{name: "etcd-cpu-request", usage: "The size of etcd's CPU request.", path: keys("etcd.resources.requests.cpu")},
+	{name: "etcd-memory-request", usage: "The size of etcd's memory request.", path: keys("etcd.resources.requests.memory")},
+	{name: "etcd-storage-class", usage: "The name of an existing StorageClass to use for etcd storage.", path: keys("etcd.storageClass")},
+	{name: "expose-object-api", kind: boolFlag, def: "false", usage: "Serve pachd's object API on its public port.", unsupported: "pachd no longer has an object API"},
+	{name: "image-pull-secret", usage: "A secret in Kubernetes that's needed to pull from your private registry."},
+	{name: "local-roles", kind: boolFlag, def: "false", usage: "Use namespace-local roles instead of cluster roles.", unsupported: "the chart always creates cluster roles"},
+	{name: "log-level", def: "info", usage: "The level of log messages to print.", path: keys("pachd.logLevel")},
+	{name: "namespace", usage: "Kubernetes namespace to deploy Pachyderm to.", unsupported: "pass --namespace to helm instead"},
+	{name: "no-dashboard", kind: boolFlag, def: "false", usage: "Don't deploy the Pachyderm UI.", unsupported: noDashboard},
+	{name: "no-expose-docker-socket", kind: boolFlag, def: "false", usage: "Don't expose the Docker socket to worker containers.", unsupported: "workers no longer use the Docker socket"},
+	{name: "no-guaranteed", kind: boolFlag, def: "false", usage: "Don't use guaranteed QoS for etcd and pachd deployments.", unsupported: "set the limits of pachd.resources and etcd.resources instead"},
+	{name: "no-rbac", kind: negatedFlag, def: "false", usage: "Don't deploy RBAC roles for Pachyderm.", path: keys("pachd.rbac.create")},
+	{name: "output", shorthand: "o", def: "json", usage: "Output format of the manifest.", ignored: true},
+	{name: "pachd-cpu-request", usage: "The size of pachd's CPU request.", path: keys("pachd.resources.requests.cpu")},
+	{name: "pachd-memory-request", usage: "The size of pachd's memory request.", path: keys("pachd.resources.requests.memory")},
+	{name: "put-file-concurrency-limit", kind: intFlag, def: "100", usage: "The maximum number of files to upload or fetch from remote sources concurrently.", path: keys("pachd.storage.putFileConcurrencyLimit")},
+	{name: "registry", usage: "The registry to pull images from."},
+	{name: "require-critical-servers-only", kind: boolFlag, def: "false", usage: "Only require the critical pachd servers to start.", path: keys("pachd.requireCriticalServersOnly")},
+	{name: "shards", kind: intFlag, def: "16", usage: "The maximum number of pachd nodes allowed in the cluster.", unsupported: "pachd no longer shards its data"},
+	{name: "static-etcd-volume", usage: "Deploy etcd with one pod, which uses the given persistent volume.", unsupported: "the chart provisions etcd's volume dynamically, set etcd.storageClass to choose its storage class"},
+	{name: "storage-v2", kind: boolFlag, def: "false", usage: "Deploy Pachyderm using V2 storage.", unsupported: "2.x always uses V2 storage"},
+	{name: "tls", usage: `The "<cert path>,<key path>" of the certificate and private key that pachd serves TLS with.`},
+	{name: "upload-concurrency-limit", kind: intFlag, def: "100", usage: "The maximum number of concurrent object storage uploads per pachd instance.", path: keys("pachd.storage.uploadConcurrencyLimit")},
+	{name: "worker-service-account", def: "pachyderm-worker", usage: "The Kubernetes service account for workers to use.", path: keys("pachd.worker.serviceAccount.name")},
+
+	{name: "host-path", def: "/var/pachyderm", usage: "Location on the host machine where PFS metadata will be stored.", targets: []string{"local"}, path: keys("pachd.storage.local.hostPath")},
+
+	{name: "cloudfront-distribution", usage: "The CloudFront distribution in front of the bucket.", targets: []string{"amazon"}, path: keys("pachd.storage.amazon.cloudFrontDistribution")},
+	{name: "credentials", usage: `Use the format "<id>,<secret>[,<token>]".`, targets: []string{"amazon"}},
+	{name: "disable-ssl", kind: boolFlag, def: "false", usage: "Disable SSL.", targets: []string{"amazon"}, path: keys("pachd.storage.amazon.disableSSL")},
+	{name: "iam-role", usage: "Use the given IAM role for authorization.", targets: []string{"amazon"}},
+	{name: "max-upload-parts", kind: intFlag, def: "10000", usage: "Set a custom maximum number of upload parts.", targets: []string{"amazon"}, path: keys("pachd.storage.amazon.maxUploadParts")},
+	{name: "no-verify-ssl", kind: negatedFlag, def: "false", usage: "Skip SSL certificate verification.", targets: []string{"amazon"}, path: keys("pachd.storage.amazon.verifySSL")},
+	{name: "obj-log-options", usage: "Enable verbose logging in Pachyderm's internal S3 client.", targets: []string{"amazon"}, path: keys("pachd.storage.amazon.logOptions")},
+	{name: "part-size", def: "5242880", usage: "Set a custom part size for object storage uploads.", targets: []string{"amazon"}, path: keys("pachd.storage.amazon.partSize")},
+	{name: "retries", kind: intFlag, def: "10", usage: "Set a custom number of retries for object storage requests.", targets: []string{"amazon"}, path: keys("pachd.storage.amazon.retries")},
+	{name: "reverse", kind: boolFlag, def: "true", usage: "Reverse object storage paths.", targets: []string{"amazon"}, path: keys("pachd.storage.amazon.reverse")},
+	{name: "timeout", def: "5m", usage: "Set a custom timeout for object storage requests.", targets: []string{"amazon"}, path: keys("pachd.storage.amazon.timeout")},
+	{name: "upload-acl", def: "bucket-owner-full-control", usage: "Set a custom upload ACL for object storage uploads.", targets: []string{"amazon"}, path: keys("pachd.storage.amazon.uploadACL")},
+	{name: "vault", usage: `Use the format "<address/hostport>,<role>,<token>".`, targets: []string{"amazon"}, unsupported: "pachd no longer reads credentials from Vault"},
+
+	{name: "disable-ssl", kind: boolFlag, def: "false", usage: "Disable SSL.", targets: []string{"custom"}, unsupported: noObjectOptions},
+	{name: "isS3V2", kind: boolFlag, def: "false", usage: "Enable S3V2 client.", targets: []string{"custom"}},
+	{name: "max-upload-parts", kind: intFlag, def: "10000", usage: "Set a custom maximum number of upload parts.", targets: []string{"custom"}, unsupported: noObjectOptions},
+	{name: "no-verify-ssl", kind: boolFlag, def: "false", usage: "Skip SSL certificate verification.", targets: []string{"custom"}, unsupported: noObjectOptions},
+	{name: "obj-log-options", usage: "Enable verbose logging in Pachyderm's internal S3 client.", targets: []string{"custom"}, unsupported: noObjectOptions},
+	{name: "object-store", def: "s3", usage: "Backend providing an object-storage API to pachyderm.", targets: []string{"custom"}},
+	{name: "part-size", def: "5242880", usage: "Set a custom part size for object storage uploads.", targets: []string{"custom"}, unsupported: noObjectOptions},
+	{name: "persistent-disk", def: "aws", usage: "Backend providing persistent local volumes to stateful pods.", targets: []string{"custom"}, unsupported: "the chart provisions volumes with the cluster's storage classes"},
+	{name: "retries", kind: intFlag, def: "10", usage: "Set a custom number of retries for object storage requests.", targets: []string{"custom"}, unsupported: noObjectOptions},
+	{name: "reverse", kind: boolFlag, def: "true", usage: "Reverse object storage paths.", targets: []string{"custom"}, unsupported: noObjectOptions},
+	{name: "secure", shorthand: "s", kind: boolFlag, def: "false", usage: "Enable secure access to a Minio server.", targets: []string{"custom"}},
+	{name: "timeout", def: "5m", usage: "Set a custom timeout for object storage requests.", targets: []string{"custom"}, unsupported: noObjectOptions},
+	{name: "upload-acl", def: "bucket-owner-full-control", usage: "Set a custom upload ACL for object storage uploads.", targets: []string{"custom"}, unsupported: noObjectOptions},
+}
+
+func lookupFlag(target, name string) *legacyFlag {
+	for _, f := range legacyFlags {
+		if f.name == name && f.appliesTo(target) {
+			return f
+		}
+	}
+	return nil
+}
+
+// NewLegacyFlags returns the flags of a legacy target.
+func NewLegacyFlags(target string) *pflag.FlagSet {
+	flags := pflag.NewFlagSet(target, pflag.ContinueOnError)
+	for _, f := range legacyFlags {
+		if !f.appliesTo(target) {
+			continue
+		}
+		switch f.kind {
+		case stringFlag:
+			flags.StringP(f.name, f.shorthand, f.def, f.usage)
+		case intFlag:
+			def, _ := strconv.Atoi(f.def)
+			flags.IntP(f.name, f.shorthand, def, f.usage)
+		case boolFlag, negatedFlag:
+			flags.BoolP(f.name, f.shorthand, f.def == "true", f.usage)
+		}
+	}
+	return flags
+}
+
+// legacyImages are the images that --registry applies to, and the values of
+// their repositories.
+var legacyImages = []struct {
+	repository string
+	path       []string
+}{
+	{"pachyderm/pachd", keys("pachd.image.repository")},
+	{"pachyderm/worker", keys("pachd.worker.image.repository")},
+	{"pachyderm/etcd", keys("etcd.image.repository")},
+}
+
+// LegacyCommand is an invocation of 1.x's 'pachctl deploy'.
+type LegacyCommand struct {
+	Target string
+	Args   []string
+	// Flags are the values of the flags that are set, by name.
+	Flags map[string]string
+	// Files are the contents of files that Args and Flags name, by path.
+	// Other files are read from disk.
+	Files map[string][]byte
+}
+
+// NewLegacyCommand returns the invocation of a legacy target with args and
+// the flags that are set in flags. The flags may have been parsed by another
+// flag set that they were added to, such as a cobra command's.
+func NewLegacyCommand(target string, args []string, flags *pflag.FlagSet) *LegacyCommand {
+	c := &LegacyCommand{Target: target, Args: args, Flags: make(map[string]string)}
+	flags.VisitAll(func(f *pflag.Flag) {
+		if f.Changed {
+			c.Flags[f.Name] = f.Value.String()
+		}
+	})
+	return c
+}
+
+func (c *LegacyCommand) readFile(path string) (string, error) {
+	if data, ok := c.Files[path]; ok {
+		return string(data), nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not read %s", path)
+	}
+	return string(data), nil
+}
+
+func (c *LegacyCommand) flagNames() []string {
+	names := make([]string, 0, len(c.Flags))
+	for name := range c.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// String returns the command line of the invocation.
+func (c *LegacyCommand) String() string {
+	words := append([]string{"pachctl", "deploy", c.Target}, c.Args...)
+	for _, name := range c.flagNames() {
+		value := c.Flags[name]
+		if f := lookupFlag(c.Target, name); f != nil && (f.kind == boolFlag || f.kind == negatedFlag) && value == "true" {
+			words = append(words, "--"+name)
+		} else {
+			words = append(words, "--"+name+"="+value)
+		}
+	}
+	for i, word := range words {
+		words[i] = shellQuote(word)
+	}
+	return strings.Join(words, " ")
+}
+
+func shellQuote(s string) string {
+	unsafe := strings.IndexFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("-_./:,=@+%", r)
+	})
+	if s != "" && unsafe < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// diskSize converts a legacy disk size, in GB, to the size of etcd's volume.
+func diskSize(size string) (string, error) {
+	gb, err := strconv.Atoi(size)
+	if err != nil || gb <= 0 {
+		return "", errors.Errorf("disk-size must be a number of GB, not %q", size)
+	}
+	return fmt.Sprintf("%dGi", gb), nil
+}
+
+func parseFlag(f *legacyFlag, value string) (interface{}, error) {
+	switch f.kind {
+	case intFlag:
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.Errorf("--%s must be an integer, not %q", f.name, value)
+		}
+		return i, nil
+	case boolFlag, negatedFlag:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, errors.Errorf("--%s must be true or false, not %q", f.name, value)
+		}
+		return b != (f.kind == negatedFlag), nil
+	}
+	return value, nil
+}
+
+// legacyToValues converts an invocation of 'pachctl deploy' to the Helm
+// values that deploy an equivalent cluster.
+func legacyToValues(c *LegacyCommand) (values, problems, error) {
+	var ps problems
+	args, ok := legacyArgs[c.Target]
+	if !ok {
+		return nil, nil, errors.Errorf("%q isn't a target of pachctl deploy, it must be one of %s", c.Target, strings.Join(LegacyTargets, ", "))
+	}
+	required := 0
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "[") {
+			required++
+		}
+	}
+	if len(c.Args) < required || len(c.Args) > len(args) {
+		return nil, nil, errors.Errorf("pachctl deploy %s takes the arguments %s", c.Target, LegacyUsage(c.Target))
+	}
+	// 1.x activated auth after deploying, with 'pachctl auth activate'
+	v := values{"pachd": map[string]interface{}{"activateAuth": false}}
+	setDiskSize := func(size string) error {
+		etcdSize, err := diskSize(size)
+		v.set(etcdSize, "etcd", "storageSize")
+		return err
+	}
+	switch c.Target {
+	case "local":
+		v.set("LOCAL", "deployTarget")
+	case "amazon":
+		v.set("AMAZON", "deployTarget")
+		v.set(c.Args[0], keys("pachd.storage.amazon.bucket")...)
+		v.set(c.Args[1], keys("pachd.storage.amazon.region")...)
+		if err := setDiskSize(c.Args[2]); err != nil {
+			return nil, nil, err
+		}
+	case "google":
+		v.set("GOOGLE", "deployTarget")
+		v.set(c.Args[0], keys("pachd.storage.google.bucket")...)
+		if err := setDiskSize(c.Args[1]); err != nil {
+			return nil, nil, err
+		}
+		if len(c.Args) > 2 {
+			cred, err := c.readFile(c.Args[2])
+			if err != nil {
+				return nil, nil, err
+			}
+			v.set(cred, keys("pachd.storage.google.cred")...)
+		}
+	case "microsoft":
+		v.set("MICROSOFT", "deployTarget")
+		v.set(c.Args[0], keys("pachd.storage.microsoft.container")...)
+		v.set(c.Args[1], keys("pachd.storage.microsoft.id")...)
+		v.set(c.Args[2], keys("pachd.storage.microsoft.secret")...)
+		if err := setDiskSize(c.Args[3]); err != nil {
+			return nil, nil, err
+		}
+	case "custom":
+		if store, ok := c.Flags["object-store"]; ok && store != "s3" {
+			return nil, nil, errors.Errorf("only the s3 object store of pachctl deploy custom has an equivalent in the chart, not %q", store)
+		}
+		v.set("CUSTOM", "deployTarget")
+		v.set("MINIO", keys("pachd.storage.backend")...)
+		if err := setDiskSize(c.Args[1]); err != nil {
+			return nil, nil, err
+		}
+		v.set(c.Args[2], keys("pachd.storage.minio.bucket")...)
+		v.set(c.Args[3], keys("pachd.storage.minio.id")...)
+		v.set(c.Args[4], keys("pachd.storage.minio.secret")...)
+		v.set(c.Args[5], keys("pachd.storage.minio.endpoint")...)
+		ps.warnf("<disk-name>", "the chart provisions etcd's volume dynamically, so %q isn't used", c.Args[0])
+	}
+	for _, name := range c.flagNames() {
+		value := c.Flags[name]
+		f := lookupFlag(c.Target, name)
+		if f == nil {
+			return nil, nil, errors.Errorf("--%s isn't a flag of pachctl deploy %s", name, c.Target)
+		}
+		switch {
+		case f.ignored:
+		case f.unsupported != "":
+			ps.warnf("--"+name, "has no equivalent in the chart, %s", f.unsupported)
+		case f.path != nil:
+			parsed, err := parseFlag(f, value)
+			if err != nil {
+				return nil, nil, err
+			}
+			v.set(parsed, f.path...)
+		default:
+			if err := setSpecialFlag(c, v, name, value); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return v, ps, nil
+}
+
+// setSpecialFlag sets the values of a flag that doesn't map to one value.
+func setSpecialFlag(c *LegacyCommand, v values, name, value string) error {
+	switch name {
+	case "credentials":
+		parts := strings.Split(value, ",")
+		if len(parts) < 2 || len(parts) > 3 {
+			return errors.Errorf(`--credentials must be "<id>,<secret>[,<token>]"`)
+		}
+		v.set(parts[0], keys("pachd.storage.amazon.id")...)
+		v.set(parts[1], keys("pachd.storage.amazon.secret")...)
+		if len(parts) == 3 {
+			v.set(parts[2], keys("pachd.storage.amazon.token")...)
+		}
+	case "iam-role":
+		v.set(value, "pachd", "annotations", "iam.amazonaws.com/role")
+	case "image-pull-secret":
+		v.set([]interface{}{value}, "global", "imagePullSecrets")
+	case "registry":
+		for _, image := range legacyImages {
+			v.set(value+"/"+image.repository, image.path...)
+		}
+	case "tls":
+		parts := strings.Split(value, ",")
+		if len(parts) != 2 {
+			return errors.Errorf(`--tls must be "<cert path>,<key path>"`)
+		}
+		crt, err := c.readFile(parts[0])
+		if err != nil {
+			return err
+		}
+		key, err := c.readFile(parts[1])
+		if err != nil {
+			return err
+		}
+		v.set(true, keys("pachd.tls.enabled")...)
+		v.set("pachd-tls", keys("pachd.tls.secretName")...)
+		v.set(map[string]interface{}{"create": true, "crt": crt, "key": key}, keys("pachd.tls.newSecret")...)
+	case "isS3V2":
+		if value == "true" {
+			v.set("1", keys("pachd.storage.minio.signature")...)
+		}
+	case "secure":
+		v.set(value, keys("pachd.storage.minio.secure")...)
+	case "object-store":
+		// checked with the target's arguments
+	}
+	return nil
+}
+
+// legacyTarget returns the legacy target that deploys the storage backend
+// of v.
+func legacyTarget(v values) (string, error) {
+	backend := v.str("pachd.storage.backend")
+	if deployTarget := v.str("deployTarget"); backend == "" && contains(deployTargets, deployTarget) {
+		backend = deployTarget
+	}
+	switch backend {
+	case "LOCAL", "AMAZON", "GOOGLE", "MICROSOFT":
+		return strings.ToLower(backend), nil
+	case "MINIO":
+		return "custom", nil
+	}
+	return "", errors.Errorf("pachctl deploy has no target for the storage backend %q", backend)
+}
+
+// valuesToLegacy converts Helm values to the invocation of 'pachctl deploy'
+// that deploys an equivalent cluster. It also returns the paths of values
+// that have their flag's default, so the flag isn't set.
+func valuesToLegacy(v values) (*LegacyCommand, map[string]bool, error) {
+	target, err := legacyTarget(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	c := &LegacyCommand{Target: target, Flags: make(map[string]string), Files: make(map[string][]byte)}
+	defaults := make(map[string]bool)
+	disk := func() (string, error) {
+		size := v.str("etcd.storageSize")
+		if size == "" {
+			size = "10Gi"
+		}
+		gb, err := strconv.Atoi(strings.TrimSuffix(size, "Gi"))
+		if err != nil || !strings.HasSuffix(size, "Gi") {
+			return "", errors.Errorf("etcd.storageSize must be a number of Gi to convert it to a disk size, not %q", size)
+		}
+		return strconv.Itoa(gb), nil
+	}
+	switch target {
+	case "amazon":
+		size, err := disk()
+		if err != nil {
+			return nil, nil, err
+		}
+		c.Args = []string{v.str("pachd.storage.amazon.bucket"), v.str("pachd.storage.amazon.region"), size}
+		if id := v.str("pachd.storage.amazon.id"); id != "" {
+			credentials := id + "," + v.str("pachd.storage.amazon.secret")
+			if token := v.str("pachd.storage.amazon.token"); token != "" {
+				credentials += "," + token
+			}
+			c.Flags["credentials"] = credentials
+		}
+		if role := v.lookupKeys("pachd", "annotations", "iam.amazonaws.com/role"); role != nil {
+			c.Flags["iam-role"] = fmt.Sprint(role)
+		}
+	case "google":
+		size, err := disk()
+		if err != nil {
+			return nil, nil, err
+		}
+		c.Args = []string{v.str("pachd.storage.google.bucket"), size}
+		if cred := v.str("pachd.storage.google.cred"); cred != "" {
+			c.Args = append(c.Args, "google-credentials.json")
+			c.Files["google-credentials.json"] = []byte(cred)
+		}
+	case "microsoft":
+		size, err := disk()
+		if err != nil {
+			return nil, nil, err
+		}
+		c.Args = []string{v.str("pachd.storage.microsoft.container"), v.str("pachd.storage.microsoft.id"), v.str("pachd.storage.microsoft.secret"), size}
+	case "custom":
+		size, err := disk()
+		if err != nil {
+			return nil, nil, err
+		}
+		c.Args = []string{"etcd-volume", size, v.str("pachd.storage.minio.bucket"), v.str("pachd.storage.minio.id"), v.str("pachd.storage.minio.secret"), v.str("pachd.storage.minio.endpoint")}
+		c.Flags["object-store"] = "s3"
+		if v.str("pachd.storage.minio.secure") == "true" {
+			c.Flags["secure"] = "true"
+		}
+		if v.str("pachd.storage.minio.signature") == "1" {
+			c.Flags["isS3V2"] = "true"
+		}
+	}
+	for _, f := range legacyFlags {
+		if f.path == nil || !f.appliesTo(target) {
+			continue
+		}
+		value := v.lookupKeys(f.path...)
+		if value == nil {
+			continue
+		}
+		flag := fmt.Sprint(value)
+		if f.kind == negatedFlag {
+			flag = strconv.FormatBool(flag == "false")
+		}
+		if flag == f.def {
+			defaults[strings.Join(f.path, ".")] = true
+			continue
+		}
+		c.Flags[f.name] = flag
+	}
+	if secrets, ok := v.lookup("global.imagePullSecrets").([]interface{}); ok && len(secrets) == 1 {
+		c.Flags["image-pull-secret"] = fmt.Sprint(secrets[0])
+	}
+	for _, image := range legacyImages[:1] {
+		repository := v.str(strings.Join(image.path, "."))
+		if registry := strings.TrimSuffix(repository, "/"+image.repository); registry != repository {
+			c.Flags["registry"] = registry
+		}
+	}
+	if v.boolean("pachd.tls.enabled", false) && v.boolean("pachd.tls.newSecret.create", false) {
+		c.Flags["tls"] = "tls.crt,tls.key"
+		c.Files["tls.crt"] = []byte(v.str("pachd.tls.newSecret.crt"))
+		c.Files["tls.key"] = []byte(v.str("pachd.tls.newSecret.key"))
+		defaults["pachd.tls.secretName"] = true
+	}
+	return c, defaults, nil
+}
+
+// LegacyToValues converts an invocation of 1.x's 'pachctl deploy' to the
+// Helm values, as YAML, that deploy an equivalent cluster. Flags that have
+// no equivalent in the chart are warnings, and flags whose values don't
+// convert back to them are errors.
+func LegacyToValues(c *LegacyCommand) ([]byte, []*admin.ConfigurationProblem, error) {
+	v, ps, err := legacyToValues(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	back, _, err := valuesToLegacy(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, name := range c.flagNames() {
+		f := lookupFlag(c.Target, name)
+		if f.ignored || f.unsupported != "" || name == "object-store" || name == "tls" {
+			// --tls names files, which aren't part of the values
+			continue
+		}
+		got, ok := back.Flags[name]
+		if !ok {
+			got = f.def
+		}
+		if got != c.Flags[name] {
+			ps.errorf("--"+name, "the values convert back to %q, so they aren't equivalent", got)
+		}
+	}
+	for i, arg := range c.Args {
+		if strings.HasSuffix(legacyArgs[c.Target][i], "-file]") || legacyArgs[c.Target][i] == "disk-name" {
+			// files and volume names aren't part of the values
+			continue
+		}
+		if back.Args[i] != arg {
+			ps.errorf("<"+legacyArgs[c.Target][i]+">", "the values convert back to %q, so they aren't equivalent", back.Args[i])
+		}
+	}
+	data, err := yaml.Marshal(map[string]interface{}(v))
+	if err != nil {
+		return nil, nil, errors.EnsureStack(err)
+	}
+	return data, ps, nil
+}
+
+// ValuesToLegacy converts Helm values to the invocation of 1.x's 'pachctl
+// deploy' that deploys an equivalent cluster. Values that have no equivalent
+// flag are warnings. The files that the invocation names, such as a TLS
+// certificate, are in its Files.
+func ValuesToLegacy(data []byte) (*LegacyCommand, []*admin.ConfigurationProblem, error) {
+	v, err := decodeValues(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, defaults, err := valuesToLegacy(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	back, _, err := legacyToValues(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	var ps problems
+	if v.boolean("pachd.activateAuth", true) {
+		ps.warnf("pachd.activateAuth", "pachctl deploy doesn't activate auth, run 'pachctl auth activate' after deploying")
+	}
+	walkValues(v, nil, func(path []string, value interface{}) {
+		p := strings.Join(path, ".")
+		if defaults[p] || p == "pachd.activateAuth" || isEmpty(value) {
+			return
+		}
+		if got := back.lookupKeys(path...); got == nil || fmt.Sprint(got) != fmt.Sprint(value) {
+			ps.warnf(p, "has no equivalent flag of pachctl deploy, so it's dropped")
+		}
+	})
+	return c, ps, nil
+}
+
+// walkValues calls f with the path and value of each value in m that isn't
+// a map, in order.
+func walkValues(m map[string]interface{}, path []string, f func([]string, interface{})) {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		p := append(append([]string{}, path...), name)
+		if child, ok := m[name].(map[string]interface{}); ok {
+			walkValues(child, p, f)
+			continue
+		}
+		f(p, m[name])
+	}
+}
+
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
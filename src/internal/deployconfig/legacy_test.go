@@ -0,0 +1,131 @@
+package deployconfig
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func legacyCommand(t *testing.T, target string, args ...string) *LegacyCommand {
+	flags := NewLegacyFlags(target)
+	require.NoError(t, flags.Parse(args))
+	return NewLegacyCommand(target, flags.Args(), flags)
+}
+
+func formatProblems(ps []*admin.ConfigurationProblem) []string {
+	var result []string
+	for _, p := range ps {
+		result = append(result, fmt.Sprintf("%s %s: %s", p.Severity, p.Path, p.Message))
+	}
+	return result
+}
+
+func TestLegacyToValues(t *testing.T) {
+	c := legacyCommand(t, "amazon", "data", "us-west-2", "20",
+		"--credentials=AKIA,secret", "--iam-role=pachd", "--dynamic-etcd-nodes=3",
+		"--no-rbac", "--reverse=false", "--registry=registry.example.com",
+		"--shards=32", "--dry-run", "-o", "yaml")
+	data, problems, err := LegacyToValues(c)
+	require.NoError(t, err)
+	got := formatProblems(problems)
+	require.Equal(t, 1, len(got), "%v", got)
+	require.Matches(t, "^WARNING --shards: has no equivalent in the chart", got[0])
+
+	v, err := decodeValues(data)
+	require.NoError(t, err)
+	require.Equal(t, "AMAZON", v.str("deployTarget"))
+	require.Equal(t, "20Gi", v.str("etcd.storageSize"))
+	require.Equal(t, 3, v.integer("etcd.dynamicNodes", 0))
+	require.Equal(t, "AKIA", v.str("pachd.storage.amazon.id"))
+	require.Equal(t, "pachd", v.lookupKeys("pachd", "annotations", "iam.amazonaws.com/role"))
+	require.False(t, v.boolean("pachd.rbac.create", true))
+	require.False(t, v.boolean("pachd.storage.amazon.reverse", true))
+	require.False(t, v.boolean("pachd.activateAuth", true))
+	require.Equal(t, "registry.example.com/pachyderm/worker", v.str("pachd.worker.image.repository"))
+
+	// the values are valid, and convert back to the same flags, without the
+	// ones that have no equivalent
+	validation, err := Validate(context.Background(), data, true)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(validation), "%v", formatProblems(validation))
+	back, problems, err := ValuesToLegacy(data)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(problems), "%v", formatProblems(problems))
+	require.Equal(t, "pachctl deploy amazon data us-west-2 20 --credentials=AKIA,secret --dynamic-etcd-nodes=3 --iam-role=pachd --no-rbac --registry=registry.example.com --reverse=false", back.String())
+}
+
+func TestLegacyToValuesErrors(t *testing.T) {
+	_, _, err := LegacyToValues(legacyCommand(t, "amazon", "data", "us-west-2"))
+	require.YesError(t, err)
+	require.Matches(t, "takes the arguments <bucket-name> <region> <disk-size>", err.Error())
+
+	_, _, err = LegacyToValues(legacyCommand(t, "custom", "--object-store=gcs", "pv", "10", "data", "id", "secret", "minio:9000"))
+	require.YesError(t, err)
+	require.Matches(t, "only the s3 object store", err.Error())
+
+	_, _, err = LegacyToValues(legacyCommand(t, "local", "--tls=missing.crt,missing.key"))
+	require.YesError(t, err)
+	require.Matches(t, "could not read missing.crt", err.Error())
+}
+
+func TestLegacyFiles(t *testing.T) {
+	crt, key := testCertificate(t, time.Now().Add(365*24*time.Hour))
+	c := legacyCommand(t, "google", "data", "10", "creds.json", "--tls=pachd.crt,pachd.key")
+	c.Files = map[string][]byte{"creds.json": []byte(`{"type": "service_account"}`), "pachd.crt": []byte(crt), "pachd.key": []byte(key)}
+	data, problems, err := LegacyToValues(c)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(problems), "%v", formatProblems(problems))
+
+	back, problems, err := ValuesToLegacy(data)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(problems), "%v", formatProblems(problems))
+	require.Equal(t, "pachctl deploy google data 10 google-credentials.json --tls=tls.crt,tls.key", back.String())
+	require.Equal(t, crt, string(back.Files["tls.crt"]))
+	require.Equal(t, `{"type": "service_account"}`, string(back.Files["google-credentials.json"]))
+}
+
+func TestValuesToLegacy(t *testing.T) {
+	c, problems, err := ValuesToLegacy([]byte(`
+deployTarget: CUSTOM
+etcd:
+  storageSize: 50Gi
+pachd:
+  logLevel: info
+  replicas: 3
+  storage:
+    backend: MINIO
+    minio:
+      bucket: data
+      endpoint: "minio:9000"
+      id: minio
+      secret: "it's secret"
+      secure: "true"
+`))
+	require.NoError(t, err)
+	got := formatProblems(problems)
+	require.Equal(t, 2, len(got), "%v", got)
+	require.Matches(t, "^WARNING pachd.activateAuth: pachctl deploy doesn't activate auth", got[0])
+	require.Matches(t, "^WARNING pachd.replicas: has no equivalent flag", got[1])
+	require.Equal(t, `pachctl deploy custom etcd-volume 50 data minio 'it'\''s secret' minio:9000 --object-store=s3 --secure`, c.String())
+
+	_, _, err = ValuesToLegacy([]byte("deployTarget: LOCAL\netcd: {storageSize: 1Ti}\npachd: {storage: {backend: AMAZON}}"))
+	require.YesError(t, err)
+	require.Matches(t, "etcd.storageSize must be a number of Gi", err.Error())
+}
+
+func TestNewLegacyCommand(t *testing.T) {
+	// cobra parses a command's flags with its own flag set
+	flags := NewLegacyFlags("local")
+	cmdFlags := pflag.NewFlagSet("values", pflag.ContinueOnError)
+	cmdFlags.AddFlagSet(flags)
+	require.NoError(t, cmdFlags.Parse([]string{"--log-level=debug", "--no-rbac"}))
+	c := NewLegacyCommand("local", cmdFlags.Args(), flags)
+	require.Equal(t, map[string]string{"log-level": "debug", "no-rbac": "true"}, c.Flags)
+	require.Equal(t, "pachctl deploy local --log-level=debug --no-rbac", c.String())
+}
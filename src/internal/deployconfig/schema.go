@@ -0,0 +1,156 @@
+package deployconfig
+
+import (
+	_ "embed" // embed the chart's schema
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// valuesSchema is a copy of the Helm chart's values.schema.json, which 'make
+// schema' in etc/helm updates.
+//go:embed values.schema.json
+var valuesSchema []byte
+
+// schema is the subset of JSON schema that the chart's schema uses.
+type schema struct {
+	// Type is a type name, or a list of them.
+	Type       interface{}        `json:"type"`
+	Properties map[string]*schema `json:"properties"`
+	Items      *schema            `json:"items"`
+	Required   []string           `json:"required"`
+	Enum       []interface{}      `json:"enum"`
+}
+
+func parseSchema(data []byte) (*schema, error) {
+	var s schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, errors.Wrapf(err, "could not parse the chart's schema")
+	}
+	return &s, nil
+}
+
+func (s *schema) types() []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		var result []string
+		for _, name := range t {
+			result = append(result, fmt.Sprint(name))
+		}
+		return result
+	}
+	return nil
+}
+
+// typeOf returns the JSON schema type of a value decoded from YAML.
+func typeOf(value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case int, int64, uint64:
+		return "integer"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func hasType(types []string, t string) bool {
+	for _, want := range types {
+		if want == t || (want == "number" && t == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// validate adds the problems of the value at path to ps. Values that the
+// schema doesn't describe are warnings, since Helm accepts them but the
+// chart ignores them, so they're usually typos.
+func (s *schema) validate(path string, value interface{}, ps *problems) {
+	if value == nil {
+		// Helm treats null as unsetting the chart's default
+		return
+	}
+	if types := s.types(); len(types) > 0 && !hasType(types, typeOf(value)) {
+		ps.errorf(path, "must be of type %s, not %s", joinOr(types), typeOf(value))
+		return
+	}
+	if len(s.Enum) > 0 {
+		found := false
+		for _, e := range s.Enum {
+			if fmt.Sprint(e) == fmt.Sprint(value) {
+				found = true
+			}
+		}
+		if !found {
+			ps.errorf(path, "must be one of %v, not %v", s.Enum, value)
+		}
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				ps.errorf(join(path, name), "is required")
+			}
+		}
+		if len(s.Properties) == 0 {
+			// a free-form object, e.g. annotations
+			return
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			property, ok := s.Properties[key]
+			if !ok {
+				ps.warnf(join(path, key), "isn't a value of the chart, so it's ignored")
+				continue
+			}
+			property.validate(join(path, key), v[key], ps)
+		}
+	case []interface{}:
+		if s.Items == nil {
+			return
+		}
+		for i, item := range v {
+			s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, ps)
+		}
+	}
+}
+
+func joinOr(names []string) string {
+	if len(names) == 1 {
+		return names[0]
+	}
+	result := ""
+	for i, name := range names {
+		switch {
+		case i == 0:
+		case i == len(names)-1:
+			result += " or "
+		default:
+			result += ", "
+		}
+		result += name
+	}
+	return result
+}
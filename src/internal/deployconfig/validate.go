@@ -0,0 +1,447 @@
+// Package deployconfig validates the Helm values that a cluster is deployed
+// with, before they're applied, and converts the flags of 1.x's 'pachctl
+// deploy' to them.
+package deployconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// problems collects the problems that validation finds.
+type problems []*admin.ConfigurationProblem
+
+func (ps *problems) errorf(path, format string, args ...interface{}) {
+	*ps = append(*ps, &admin.ConfigurationProblem{
+		Severity: admin.ConfigurationProblem_ERROR,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (ps *problems) warnf(path, format string, args ...interface{}) {
+	*ps = append(*ps, &admin.ConfigurationProblem{
+		Severity: admin.ConfigurationProblem_WARNING,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// values are Helm values, decoded from YAML.
+type values map[string]interface{}
+
+// lookup returns the value at path, a dotted path such as
+// pachd.storage.backend, or nil if it isn't set.
+func (v values) lookup(path string) interface{} {
+	return v.lookupKeys(strings.Split(path, ".")...)
+}
+
+// lookupKeys returns the value at the path of keys, which may contain dots,
+// or nil if it isn't set.
+func (v values) lookupKeys(keys ...string) interface{} {
+	var value interface{} = map[string]interface{}(v)
+	for _, key := range keys {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = m[key]
+	}
+	return value
+}
+
+// set sets the value at the path of keys, creating the maps that hold it.
+func (v values) set(value interface{}, keys ...string) {
+	m := map[string]interface{}(v)
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := m[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			m[key] = next
+		}
+		m = next
+	}
+	m[keys[len(keys)-1]] = value
+}
+
+func (v values) str(path string) string {
+	if value := v.lookup(path); value != nil {
+		return fmt.Sprint(value)
+	}
+	return ""
+}
+
+func (v values) boolean(path string, def bool) bool {
+	if b, ok := v.lookup(path).(bool); ok {
+		return b
+	}
+	return def
+}
+
+func (v values) integer(path string, def int) int {
+	switch i := v.lookup(path).(type) {
+	case int:
+		return i
+	case float64:
+		return int(i)
+	}
+	return def
+}
+
+// Validate returns the problems with the Helm values in data. Unless offline
+// is set, it also checks that the object storage and the OIDC identity
+// providers that the values configure can be reached.
+func Validate(ctx context.Context, data []byte, offline bool) ([]*admin.ConfigurationProblem, error) {
+	var ps problems
+	v, err := decodeValues(data)
+	if err != nil {
+		ps.errorf("", "%v", err)
+		return ps, nil
+	}
+	s, err := parseSchema(valuesSchema)
+	if err != nil {
+		return nil, err
+	}
+	s.validate("", map[string]interface{}(v), &ps)
+	if len(ps.errors()) > 0 {
+		// the cross-field checks assume that values have the right types
+		return ps, nil
+	}
+	backend := checkStorage(v, &ps)
+	checkAuth(v, &ps)
+	idps := checkIDPs(v, &ps)
+	checkTLS(v, &ps, time.Now())
+	checkPorts(v, &ps)
+	checkPostgres(v, &ps)
+	if !offline && len(ps.errors()) == 0 {
+		checkStorageAccess(ctx, v, backend, &ps)
+		for _, idp := range idps {
+			checkIssuer(ctx, idp, &ps)
+		}
+	}
+	return ps, nil
+}
+
+func decodeValues(data []byte) (values, error) {
+	// nested maps are decoded as the type of the outer one, so it's a plain
+	// map rather than values
+	var decoded map[string]interface{}
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		return nil, errors.Errorf("could not parse the values as YAML: %v", err)
+	}
+	if decoded == nil {
+		return values{}, nil
+	}
+	return values(decoded), nil
+}
+
+func (ps problems) errors() []*admin.ConfigurationProblem {
+	var result []*admin.ConfigurationProblem
+	for _, p := range ps {
+		if p.Severity == admin.ConfigurationProblem_ERROR {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// storageFields are the values that each storage backend requires.
+var storageFields = map[string][]string{
+	"AMAZON":    {"bucket", "region"},
+	"GOOGLE":    {"bucket"},
+	"MICROSOFT": {"container", "id", "secret"},
+	"MINIO":     {"bucket", "endpoint", "id", "secret"},
+	"LOCAL":     nil,
+}
+
+// deployTargets are the deploy targets that imply a storage backend; other
+// deploy targets, such as CUSTOM, need pachd.storage.backend.
+var deployTargets = []string{"AMAZON", "GOOGLE", "MICROSOFT", "LOCAL"}
+
+// checkStorage checks the storage backend's values, and returns the backend.
+func checkStorage(v values, ps *problems) string {
+	deployTarget := v.str("deployTarget")
+	if deployTarget == "" {
+		ps.errorf("deployTarget", "is required")
+		return ""
+	}
+	backend := v.str("pachd.storage.backend")
+	if backend == "" && contains(deployTargets, deployTarget) {
+		backend = deployTarget
+	}
+	if backend == "" {
+		ps.errorf("pachd.storage.backend", "is required when deployTarget isn't one of %s", strings.Join(deployTargets, ", "))
+		return ""
+	}
+	fields, ok := storageFields[backend]
+	if !ok {
+		ps.errorf("pachd.storage.backend", "must be one of AMAZON, GOOGLE, MICROSOFT, MINIO or LOCAL, not %q", backend)
+		return ""
+	}
+	prefix := "pachd.storage." + strings.ToLower(backend)
+	for _, field := range fields {
+		if v.str(join(prefix, field)) == "" {
+			ps.errorf(join(prefix, field), "is required by the %s storage backend", backend)
+		}
+	}
+	if backend == "AMAZON" && (v.str(prefix+".id") == "") != (v.str(prefix+".secret") == "") {
+		ps.errorf(prefix+".secret", "the access key ID and secret must both be set, or neither to use the node's IAM role")
+	}
+	if endpoint := v.str("pachd.storage.minio.endpoint"); backend == "MINIO" && endpoint != "" {
+		if strings.Contains(endpoint, "://") {
+			ps.errorf("pachd.storage.minio.endpoint", "must be host:port, without a scheme, not %q", endpoint)
+		} else if _, _, err := net.SplitHostPort(endpoint); err != nil {
+			ps.errorf("pachd.storage.minio.endpoint", "must be host:port: %v", err)
+		}
+	}
+	if backend == "GOOGLE" {
+		if cred := v.str(prefix + ".cred"); cred != "" && !json.Valid([]byte(cred)) {
+			ps.errorf(prefix+".cred", "must be the JSON credentials of a service account")
+		}
+	}
+	return backend
+}
+
+func checkAuth(v values, ps *problems) {
+	if !v.boolean("pachd.activateAuth", true) {
+		return
+	}
+	if v.str("pachd.enterpriseLicenseKey") == "" && v.str("pachd.enterpriseLicenseKeySecretName") == "" &&
+		!v.boolean("pachd.activateEnterpriseMember", false) {
+		ps.errorf("pachd.enterpriseLicenseKey", "activating auth requires an enterprise license, "+
+			"set pachd.enterpriseLicenseKey or pachd.enterpriseLicenseKeySecretName, or set pachd.activateAuth to false")
+	}
+	if list, _ := v.lookup("oidc.upstreamIDPs").([]interface{}); len(list) == 0 {
+		if v.str("oidc.upstreamIDPsSecretName") == "" && v.boolean("oidc.mockIDP", true) {
+			ps.warnf("oidc.mockIDP", "the mock identity provider lets anyone log in as admin, configure oidc.upstreamIDPs for production clusters")
+		}
+	}
+}
+
+// idp is an OIDC identity provider that can be checked for reachability.
+type idp struct {
+	path   string
+	issuer string
+}
+
+// checkIDPs checks the upstream identity providers, and returns the OIDC
+// ones.
+func checkIDPs(v values, ps *problems) []idp {
+	list, _ := v.lookup("oidc.upstreamIDPs").([]interface{})
+	if len(list) > 0 && v.str("oidc.upstreamIDPsSecretName") != "" {
+		ps.warnf("oidc.upstreamIDPs", "is ignored, since oidc.upstreamIDPsSecretName is set")
+		return nil
+	}
+	callbackHost, callbackPath := idpCallback(v)
+	var result []idp
+	for i, item := range list {
+		path := fmt.Sprintf("oidc.upstreamIDPs[%d]", i)
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			ps.errorf(path, "must be an object")
+			continue
+		}
+		entry := values(m)
+		for _, field := range []string{"id", "name", "type", "jsonConfig"} {
+			if entry.str(field) == "" {
+				ps.errorf(join(path, field), "is required")
+			}
+		}
+		if entry.str("jsonConfig") == "" {
+			continue
+		}
+		var config struct {
+			Issuer       string `json:"issuer"`
+			ClientID     string `json:"clientID"`
+			ClientSecret string `json:"clientSecret"`
+			RedirectURI  string `json:"redirectURI"`
+		}
+		if err := json.Unmarshal([]byte(entry.str("jsonConfig")), &config); err != nil {
+			ps.errorf(join(path, "jsonConfig"), "must be a JSON object: %v", err)
+			continue
+		}
+		for _, field := range []struct{ name, value string }{
+			{"clientID", config.ClientID},
+			{"clientSecret", config.ClientSecret},
+			{"redirectURI", config.RedirectURI},
+		} {
+			if field.value == "" {
+				ps.errorf(join(path, "jsonConfig"), "%s is required", field.name)
+			}
+		}
+		if config.RedirectURI != "" {
+			checkRedirectURI(join(path, "jsonConfig"), config.RedirectURI, callbackHost, callbackPath, ps)
+		}
+		if entry.str("type") != "oidc" {
+			continue
+		}
+		if config.Issuer == "" {
+			ps.errorf(join(path, "jsonConfig"), "issuer is required by oidc identity providers")
+		} else if u, err := url.Parse(config.Issuer); err != nil || u.Scheme == "" || u.Host == "" {
+			ps.errorf(join(path, "jsonConfig"), "issuer must be a URL, not %q", config.Issuer)
+		} else {
+			result = append(result, idp{path: join(path, "jsonConfig"), issuer: config.Issuer})
+		}
+	}
+	return result
+}
+
+// idpCallback returns the host and path of the identity server's callback,
+// that identity providers must redirect to, as the chart sets them. The host
+// is empty if it can't be known from the values.
+func idpCallback(v values) (string, string) {
+	if host := v.str("ingress.host"); host != "" && v.str("oidc.issuerURI") == "" {
+		return host, "/dex/callback"
+	}
+	if issuer := v.str("oidc.issuerURI"); issuer != "" {
+		u, err := url.Parse(issuer)
+		if err != nil {
+			return "", ""
+		}
+		host := u.Host
+		if userHost := v.str("oidc.userAccessibleOauthIssuerHost"); userHost != "" {
+			host = userHost
+		}
+		return host, strings.TrimSuffix(u.Path, "/") + "/callback"
+	}
+	if v.boolean("proxy.enabled", false) {
+		return "", "/dex/callback"
+	}
+	if userHost := v.str("oidc.userAccessibleOauthIssuerHost"); userHost != "" {
+		return userHost, "/callback"
+	}
+	return fmt.Sprintf("localhost:%d", v.integer("pachd.service.identityPort", 30658)), "/callback"
+}
+
+func checkRedirectURI(path, redirectURI, host, callbackPath string, ps *problems) {
+	u, err := url.Parse(redirectURI)
+	if err != nil || u.Host == "" {
+		ps.errorf(path, "redirectURI must be a URL, not %q", redirectURI)
+		return
+	}
+	if host != "" && u.Host != host {
+		if u.Hostname() == strings.Split(host, ":")[0] {
+			ps.errorf(path, "the port of redirectURI %q doesn't match the identity server's callback port, it should be %s", redirectURI, host)
+		} else {
+			ps.errorf(path, "the host of redirectURI %q doesn't match the identity server's, it should be %s", redirectURI, host)
+		}
+		return
+	}
+	if callbackPath != "" && u.Path != callbackPath {
+		ps.errorf(path, "the path of redirectURI %q should be %s, the identity server's callback", redirectURI, callbackPath)
+	}
+}
+
+// checkTLS checks that TLS has a certificate, and that a certificate given
+// in the values matches its key and hasn't expired.
+func checkTLS(v values, ps *problems, now time.Time) {
+	if !v.boolean("pachd.tls.enabled", false) {
+		return
+	}
+	if v.str("pachd.tls.secretName") == "" {
+		ps.errorf("pachd.tls.secretName", "is required when TLS is enabled, it's the secret that holds the certificate")
+	}
+	crt, key := v.str("pachd.tls.newSecret.crt"), v.str("pachd.tls.newSecret.key")
+	if !v.boolean("pachd.tls.newSecret.create", false) {
+		if crt != "" || key != "" {
+			ps.errorf("pachd.tls.newSecret.create", "must be true when pachd.tls.newSecret.crt or key is set")
+		}
+		return
+	}
+	if crt == "" || key == "" {
+		ps.errorf("pachd.tls.newSecret", "crt and key are required to create a TLS secret")
+		return
+	}
+	if _, err := tls.X509KeyPair([]byte(crt), []byte(key)); err != nil {
+		ps.errorf("pachd.tls.newSecret", "the certificate and key aren't a valid pair: %v", err)
+		return
+	}
+	block, _ := pem.Decode([]byte(crt))
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		ps.errorf("pachd.tls.newSecret.crt", "could not parse the certificate: %v", err)
+		return
+	}
+	switch {
+	case now.After(cert.NotAfter):
+		ps.errorf("pachd.tls.newSecret.crt", "the certificate expired at %v", cert.NotAfter)
+	case now.Add(30 * 24 * time.Hour).After(cert.NotAfter):
+		ps.warnf("pachd.tls.newSecret.crt", "the certificate expires at %v", cert.NotAfter)
+	}
+}
+
+// servicePorts are the ports of pachd's service, and their defaults.
+var servicePorts = []struct {
+	name string
+	def  int
+}{
+	{"apiGRPCPort", 30650},
+	{"prometheusPort", 30656},
+	{"oidcPort", 30657},
+	{"identityPort", 30658},
+	{"s3GatewayPort", 30600},
+	{"restGatewayPort", 30659},
+}
+
+// checkPorts checks that pachd's service ports are valid and distinct.
+func checkPorts(v values, ps *problems) {
+	used := make(map[int]string)
+	nodePort := v.str("pachd.service.type") == "NodePort"
+	for _, port := range servicePorts {
+		path := "pachd.service." + port.name
+		value := v.integer(path, port.def)
+		switch {
+		case value < 1 || value > 65535:
+			ps.errorf(path, "must be between 1 and 65535, not %d", value)
+			continue
+		case nodePort && (value < 30000 || value > 32767):
+			ps.errorf(path, "must be between 30000 and 32767 when pachd.service.type is NodePort, not %d", value)
+		}
+		if other, ok := used[value]; ok {
+			ps.errorf(path, "port %d is also used by pachd.service.%s", value, other)
+		}
+		used[value] = port.name
+	}
+}
+
+func checkPostgres(v values, ps *problems) {
+	if v.boolean("postgresql.enabled", true) {
+		return
+	}
+	// the chart's default host is the postgres that it deploys
+	if host := v.str("global.postgresql.postgresqlHost"); host == "" || host == "postgres" {
+		ps.errorf("global.postgresql.postgresqlHost", "must be set to the host of the external postgres when postgresql.enabled is false")
+	}
+	if v.str("global.postgresql.postgresqlPassword") == "" && v.str("global.postgresql.postgresqlExistingSecretName") == "" {
+		ps.errorf("global.postgresql.postgresqlPassword", "a password, or a secret holding one, is required when postgresql.enabled is false")
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
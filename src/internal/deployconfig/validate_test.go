@@ -0,0 +1,259 @@
+package deployconfig
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+// problemStrings formats problems as "severity path: message" for matching.
+func problemStrings(t *testing.T, values string, offline bool) []string {
+	problems, err := Validate(context.Background(), []byte(values), offline)
+	require.NoError(t, err)
+	var result []string
+	for _, p := range problems {
+		result = append(result, fmt.Sprintf("%s %s: %s", p.Severity, p.Path, p.Message))
+	}
+	return result
+}
+
+func requireProblems(t *testing.T, values string, want ...string) {
+	t.Helper()
+	got := problemStrings(t, values, true)
+	require.Equal(t, len(want), len(got), "%v", got)
+	for i := range want {
+		require.Matches(t, want[i], got[i])
+	}
+}
+
+func TestSchemaIsChartSchema(t *testing.T) {
+	chartSchema, err := ioutil.ReadFile("../../../etc/helm/pachyderm/values.schema.json")
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(chartSchema, valuesSchema), "run 'make schema' in etc/helm to update the copy of the chart's schema")
+}
+
+const minimal = `
+deployTarget: LOCAL
+pachd:
+  activateAuth: false
+`
+
+func TestValidateSchema(t *testing.T) {
+	requireProblems(t, minimal)
+	requireProblems(t, "pachd: [", `ERROR : could not parse the values as YAML`)
+	requireProblems(t, minimal+`  replicas: "two"
+  stroage: {}
+`,
+		`ERROR pachd.replicas: must be of type integer, not string`,
+		`WARNING pachd.stroage: isn't a value of the chart`)
+	// free-form objects and null values are accepted
+	requireProblems(t, minimal+`  annotations:
+    example.com/team: data
+  nodeSelector: null
+`)
+}
+
+func TestValidateStorage(t *testing.T) {
+	requireProblems(t, "pachd: {activateAuth: false}",
+		`ERROR deployTarget: is required`)
+	requireProblems(t, "deployTarget: CUSTOM\npachd: {activateAuth: false}",
+		`ERROR pachd.storage.backend: is required when deployTarget isn't one of`)
+	requireProblems(t, `
+deployTarget: AMAZON
+pachd:
+  activateAuth: false
+  storage:
+    amazon:
+      bucket: data
+      id: AKIA
+`,
+		`ERROR pachd.storage.amazon.region: is required by the AMAZON storage backend`,
+		`ERROR pachd.storage.amazon.secret: the access key ID and secret must both be set`)
+	requireProblems(t, `
+deployTarget: CUSTOM
+pachd:
+  activateAuth: false
+  storage:
+    backend: MINIO
+    minio:
+      bucket: data
+      endpoint: http://minio:9000
+      id: minio
+      secret: minio123
+`,
+		`ERROR pachd.storage.minio.endpoint: must be host:port, without a scheme`)
+}
+
+func TestValidateAuth(t *testing.T) {
+	requireProblems(t, "deployTarget: LOCAL",
+		`ERROR pachd.enterpriseLicenseKey: activating auth requires an enterprise license`,
+		`WARNING oidc.mockIDP: the mock identity provider lets anyone log in`)
+
+	idp := func(redirectURI string) string {
+		config, err := json.Marshal(map[string]string{
+			"issuer":       "https://idp.example.com",
+			"clientID":     "pachyderm",
+			"clientSecret": "notsecret",
+			"redirectURI":  redirectURI,
+		})
+		require.NoError(t, err)
+		return fmt.Sprintf(`
+deployTarget: LOCAL
+pachd:
+  enterpriseLicenseKey: key
+oidc:
+  upstreamIDPs:
+  - id: okta
+    name: okta
+    type: oidc
+    jsonConfig: '%s'
+`, config)
+	}
+	requireProblems(t, idp("http://localhost:30658/callback"))
+	requireProblems(t, idp("http://localhost:30657/callback"),
+		`ERROR oidc.upstreamIDPs\[0\].jsonConfig: the port of redirectURI "http://localhost:30657/callback" doesn't match the identity server's callback port, it should be localhost:30658`)
+	requireProblems(t, idp("http://localhost:30658/dex/callback"),
+		`ERROR oidc.upstreamIDPs\[0\].jsonConfig: the path of redirectURI .* should be /callback`)
+	requireProblems(t, idp("https://pachyderm.example.com/dex/callback")+`
+ingress:
+  enabled: true
+  host: pachyderm.example.com
+`)
+	requireProblems(t, idp("https://pachyderm.example.com/callback")+`
+  issuerURI: https://pachyderm.example.com/dex
+`,
+		`ERROR oidc.upstreamIDPs\[0\].jsonConfig: the path of redirectURI .* should be /dex/callback`)
+}
+
+func testCertificate(t *testing.T, notAfter time.Time) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "pachd"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestValidateTLS(t *testing.T) {
+	now := time.Now()
+	check := func(tls map[string]interface{}) []string {
+		var ps problems
+		checkTLS(values{"pachd": map[string]interface{}{"tls": tls}}, &ps, now)
+		var result []string
+		for _, p := range ps {
+			result = append(result, fmt.Sprintf("%s %s: %s", p.Severity, p.Path, p.Message))
+		}
+		return result
+	}
+	newSecret := func(crt, key string) map[string]interface{} {
+		return map[string]interface{}{
+			"enabled":    true,
+			"secretName": "pachd-tls",
+			"newSecret":  map[string]interface{}{"create": true, "crt": crt, "key": key},
+		}
+	}
+	crt, key := testCertificate(t, now.Add(365*24*time.Hour))
+	require.Equal(t, 0, len(check(newSecret(crt, key))))
+	require.Equal(t, 0, len(check(map[string]interface{}{"enabled": true, "secretName": "pachd-tls"})))
+
+	problems := check(map[string]interface{}{"enabled": true})
+	require.Equal(t, 1, len(problems))
+	require.Matches(t, "secretName: is required", problems[0])
+
+	problems = check(map[string]interface{}{
+		"enabled":    true,
+		"secretName": "pachd-tls",
+		"newSecret":  map[string]interface{}{"crt": crt, "key": key},
+	})
+	require.Equal(t, 1, len(problems))
+	require.Matches(t, "newSecret.create: must be true", problems[0])
+
+	_, otherKey := testCertificate(t, now.Add(365*24*time.Hour))
+	problems = check(newSecret(crt, otherKey))
+	require.Equal(t, 1, len(problems))
+	require.Matches(t, "aren't a valid pair", problems[0])
+
+	crt, key = testCertificate(t, now.Add(-time.Hour))
+	problems = check(newSecret(crt, key))
+	require.Equal(t, 1, len(problems))
+	require.Matches(t, "^ERROR .*the certificate expired", problems[0])
+
+	crt, key = testCertificate(t, now.Add(24*time.Hour))
+	problems = check(newSecret(crt, key))
+	require.Equal(t, 1, len(problems))
+	require.Matches(t, "^WARNING .*the certificate expires", problems[0])
+}
+
+func TestValidatePortsAndPostgres(t *testing.T) {
+	requireProblems(t, minimal+`  service:
+    type: NodePort
+    restGatewayPort: 30650
+    prometheusPort: 1656
+postgresql:
+  enabled: false
+`,
+		`ERROR pachd.service.prometheusPort: must be between 30000 and 32767 when pachd.service.type is NodePort`,
+		`ERROR pachd.service.restGatewayPort: port 30650 is also used by pachd.service.apiGRPCPort`,
+		`ERROR global.postgresql.postgresqlHost: must be set to the host of the external postgres`,
+		`ERROR global.postgresql.postgresqlPassword: a password, or a secret holding one, is required`)
+}
+
+func TestValidateIssuer(t *testing.T) {
+	var issuer string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/openid-configuration" {
+			http.NotFound(w, r)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]string{"issuer": issuer}))
+	}))
+	defer server.Close()
+
+	check := func(configured string) []*admin.ConfigurationProblem {
+		var ps problems
+		checkIssuer(context.Background(), idp{path: "oidc.upstreamIDPs[0].jsonConfig", issuer: configured}, &ps)
+		return ps
+	}
+	issuer = server.URL
+	require.Equal(t, 0, len(check(server.URL)))
+	require.Equal(t, 0, len(check(server.URL+"/")))
+
+	issuer = "https://idp.example.com"
+	problems := check(server.URL)
+	require.Equal(t, 1, len(problems))
+	require.Matches(t, `the issuer should be "https://idp.example.com"`, problems[0].Message)
+
+	problems = check(server.URL + "/tenant")
+	require.Equal(t, 1, len(problems))
+	require.Matches(t, "has no discovery document", problems[0].Message)
+
+	server.Close()
+	problems = check(server.URL)
+	require.Equal(t, 1, len(problems))
+	require.True(t, strings.Contains(problems[0].Message, "isn't reachable"), problems[0].Message)
+}
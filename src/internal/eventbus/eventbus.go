@@ -0,0 +1,151 @@
+// Package eventbus publishes messages to, and consumes messages from, Kafka
+// topics and NATS subjects.
+//
+// A topic is identified by a URL whose scheme selects the bus:
+//
+//	kafka://broker-1:9092,broker-2:9092/topic
+//	nats://[user:password@|token@]host:4222/subject
+//
+// The clients implement only what Pachyderm needs of each protocol: Kafka
+// messages are produced and fetched without compression or consumer groups,
+// and NATS is used without JetStream, so NATS subscribers only receive the
+// messages published while they're connected.
+package eventbus
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+const (
+	// KafkaScheme is the URL scheme of Kafka topics.
+	KafkaScheme = "kafka"
+	// NATSScheme is the URL scheme of NATS subjects.
+	NATSScheme = "nats"
+
+	dialTimeout = 10 * time.Second
+)
+
+// Message is a message consumed from a topic.
+type Message struct {
+	// Partition and Offset locate a Kafka message in its topic. They're
+	// unset for NATS messages.
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Time      time.Time
+}
+
+// Publisher publishes messages to a topic.
+type Publisher interface {
+	// Publish publishes a message and returns once the bus has accepted it.
+	// The key selects the partition of Kafka messages and is ignored by
+	// NATS.
+	Publish(ctx context.Context, key, value []byte) error
+	Close() error
+}
+
+// Subscriber consumes messages from a topic.
+type Subscriber interface {
+	// Next blocks until the next message is available.
+	Next(ctx context.Context) (*Message, error)
+	Close() error
+}
+
+// Topic is a parsed topic URL.
+type Topic struct {
+	Scheme string
+	// Addrs is the addresses of the Kafka bootstrap brokers, or of the NATS
+	// server.
+	Addrs []string
+	// Name is the Kafka topic or NATS subject.
+	Name string
+	// User, Password and Token authenticate to NATS.
+	User, Password, Token string
+}
+
+// ParseTopic parses a topic URL.
+func ParseTopic(rawURL string) (*Topic, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid topic URL")
+	}
+	t := &Topic{
+		Scheme: u.Scheme,
+		Name:   strings.TrimPrefix(u.Path, "/"),
+	}
+	if t.Name == "" {
+		return nil, errors.Errorf("topic URL %q has no topic", rawURL)
+	}
+	defaultPort := ""
+	switch u.Scheme {
+	case KafkaScheme:
+		defaultPort = "9092"
+		if u.User != nil {
+			return nil, errors.Errorf("authentication to Kafka is not supported")
+		}
+	case NATSScheme:
+		defaultPort = "4222"
+		if u.User != nil {
+			if password, ok := u.User.Password(); ok {
+				t.User, t.Password = u.User.Username(), password
+			} else {
+				t.Token = u.User.Username()
+			}
+		}
+	default:
+		return nil, errors.Errorf("unsupported topic URL scheme %q, must be %q or %q", u.Scheme, KafkaScheme, NATSScheme)
+	}
+	for _, addr := range strings.Split(u.Host, ",") {
+		if addr == "" {
+			continue
+		}
+		if !strings.Contains(addr, ":") {
+			addr += ":" + defaultPort
+		}
+		t.Addrs = append(t.Addrs, addr)
+	}
+	if len(t.Addrs) == 0 {
+		return nil, errors.Errorf("topic URL %q has no host", rawURL)
+	}
+	if t.Scheme == NATSScheme && len(t.Addrs) > 1 {
+		return nil, errors.Errorf("topic URL %q has more than one NATS server", rawURL)
+	}
+	return t, nil
+}
+
+// String returns the topic's URL, without credentials.
+func (t *Topic) String() string {
+	return t.Scheme + "://" + strings.Join(t.Addrs, ",") + "/" + t.Name
+}
+
+// NewPublisher connects to the bus of the topic at rawURL.
+func NewPublisher(ctx context.Context, rawURL string) (Publisher, error) {
+	t, err := ParseTopic(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if t.Scheme == KafkaScheme {
+		return newKafkaPublisher(ctx, t)
+	}
+	return newNATSPublisher(ctx, t)
+}
+
+// NewSubscriber subscribes to the topic at rawURL. Kafka partitions are
+// consumed from the offsets in offsets, or from their earliest message if
+// they're not in offsets.
+func NewSubscriber(ctx context.Context, rawURL string, offsets map[int32]int64) (Subscriber, error) {
+	t, err := ParseTopic(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if t.Scheme == KafkaScheme {
+		return newKafkaSubscriber(ctx, t, offsets)
+	}
+	return newNATSSubscriber(ctx, t)
+}
@@ -0,0 +1,421 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+const (
+	kafkaClientID = "pachyderm"
+	// fetchMaxWait is how long a fetch waits for new messages.
+	fetchMaxWait = 500 * time.Millisecond
+	// fetchMaxBytes bounds the size of a fetch response per partition.
+	fetchMaxBytes = 4 << 20
+	// produceTimeout is how long the leader waits for the message to be
+	// written.
+	produceTimeout = 10 * time.Second
+
+	// listOffsets timestamps that select the earliest offset.
+	earliestOffset = -2
+)
+
+// kafkaBroker is a connection to a Kafka broker. Requests are sent one at a
+// time.
+type kafkaBroker struct {
+	mu            sync.Mutex
+	conn          net.Conn
+	correlationID int32
+}
+
+func dialKafka(ctx context.Context, addr string) (*kafkaBroker, error) {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return &kafkaBroker{conn: conn}, nil
+}
+
+// roundTrip sends a request and returns a decoder for the body of its
+// response.
+func (b *kafkaBroker) roundTrip(ctx context.Context, apiKey, apiVersion int16, body []byte) (*decoder, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.correlationID++
+	req := &encoder{}
+	req.int32(0) // size, filled in below
+	req.int16(apiKey)
+	req.int16(apiVersion)
+	req.int32(b.correlationID)
+	req.string(kafkaClientID)
+	req.b = append(req.b, body...)
+	binary.BigEndian.PutUint32(req.b, uint32(len(req.b)-4))
+
+	deadline := time.Now().Add(dialTimeout + produceTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	b.conn.SetDeadline(deadline)
+	if _, err := b.conn.Write(req.b); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	var size [4]byte
+	if _, err := io.ReadFull(b.conn, size[:]); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	resp := make([]byte, binary.BigEndian.Uint32(size[:]))
+	if _, err := io.ReadFull(b.conn, resp); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	d := &decoder{b: resp}
+	if id := d.int32(); id != b.correlationID {
+		return nil, errors.Errorf("kafka: response to request %d received for request %d", id, b.correlationID)
+	}
+	return d, nil
+}
+
+func (b *kafkaBroker) Close() error {
+	return errors.EnsureStack(b.conn.Close())
+}
+
+// kafkaClient routes the requests about a topic to the leaders of its
+// partitions.
+type kafkaClient struct {
+	topic *Topic
+	// addrs maps broker IDs to their addresses, and leaders partitions to
+	// the IDs of their leaders.
+	addrs      map[int32]string
+	leaders    map[int32]int32
+	partitions []int32
+
+	mu      sync.Mutex
+	brokers map[string]*kafkaBroker
+}
+
+func newKafkaClient(ctx context.Context, t *Topic) (*kafkaClient, error) {
+	c := &kafkaClient{topic: t, brokers: make(map[string]*kafkaBroker)}
+	if err := c.refreshMetadata(ctx); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *kafkaClient) broker(ctx context.Context, addr string) (*kafkaBroker, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if b, ok := c.brokers[addr]; ok {
+		return b, nil
+	}
+	b, err := dialKafka(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	c.brokers[addr] = b
+	return b, nil
+}
+
+// refreshMetadata finds the partitions of the topic and their leaders,
+// asking each bootstrap broker in turn.
+func (c *kafkaClient) refreshMetadata(ctx context.Context) error {
+	req := &encoder{}
+	req.int32(1)
+	req.string(c.topic.Name)
+	var errs error
+	for _, addr := range c.topic.Addrs {
+		b, err := c.broker(ctx, addr)
+		if err != nil {
+			errs = errors.Wrapf(err, "kafka broker %s", addr)
+			continue
+		}
+		d, err := b.roundTrip(ctx, apiMetadata, metadataVersion, req.b)
+		if err != nil {
+			errs = errors.Wrapf(err, "kafka broker %s", addr)
+			continue
+		}
+		return c.parseMetadata(d)
+	}
+	return errs
+}
+
+func (c *kafkaClient) parseMetadata(d *decoder) error {
+	addrs := make(map[int32]string)
+	for i, n := 0, d.arrayLen(); i < n; i++ {
+		id := d.int32()
+		host := d.string()
+		port := d.int32()
+		d.string() // rack
+		addrs[id] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+	d.int32() // controller ID
+	leaders := make(map[int32]int32)
+	var partitions []int32
+	for i, n := 0, d.arrayLen(); i < n; i++ {
+		topicErr := d.int16()
+		name := d.string()
+		d.int8() // internal
+		for j, m := 0, d.arrayLen(); j < m; j++ {
+			d.int16() // partition error
+			partition := d.int32()
+			leader := d.int32()
+			for k, l := 0, d.arrayLen(); k < l; k++ {
+				d.int32() // replica
+			}
+			for k, l := 0, d.arrayLen(); k < l; k++ {
+				d.int32() // in-sync replica
+			}
+			if name == c.topic.Name {
+				leaders[partition] = leader
+				partitions = append(partitions, partition)
+			}
+		}
+		if name == c.topic.Name && topicErr != 0 {
+			return errors.Wrapf(kafkaError(topicErr), "topic %q", name)
+		}
+	}
+	if d.err != nil {
+		return d.err
+	}
+	if len(partitions) == 0 {
+		return errors.Errorf("kafka: topic %q has no partitions", c.topic.Name)
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+	c.addrs, c.leaders, c.partitions = addrs, leaders, partitions
+	return nil
+}
+
+// leader returns the connection to the leader of partition.
+func (c *kafkaClient) leader(ctx context.Context, partition int32) (*kafkaBroker, error) {
+	addr, ok := c.addrs[c.leaders[partition]]
+	if !ok {
+		return nil, errors.Wrapf(kafkaError(5), "partition %d", partition)
+	}
+	return c.broker(ctx, addr)
+}
+
+// produce writes messages to partition and returns the offset of the first.
+func (c *kafkaClient) produce(ctx context.Context, partition int32, messages []*Message) (int64, error) {
+	req := &encoder{}
+	req.nullableString() // transactional ID
+	req.int16(1)         // acks: the leader's
+	req.int32(int32(produceTimeout.Milliseconds()))
+	req.int32(1)
+	req.string(c.topic.Name)
+	req.int32(1)
+	req.int32(partition)
+	req.bytes(encodeRecordBatch(messages))
+	b, err := c.leader(ctx, partition)
+	if err != nil {
+		return 0, err
+	}
+	d, err := b.roundTrip(ctx, apiProduce, produceVersion, req.b)
+	if err != nil {
+		return 0, err
+	}
+	var offset int64
+	var errCode int16
+	for i, n := 0, d.arrayLen(); i < n; i++ {
+		d.string() // topic
+		for j, m := 0, d.arrayLen(); j < m; j++ {
+			d.int32() // partition
+			errCode = d.int16()
+			offset = d.int64()
+			d.int64() // log append time
+		}
+	}
+	if d.err != nil {
+		return 0, d.err
+	}
+	if errCode != 0 {
+		return 0, errors.Wrapf(kafkaError(errCode), "partition %d", partition)
+	}
+	return offset, nil
+}
+
+// fetch returns the messages of partition from offset on, and the offset
+// the next fetch starts at.
+func (c *kafkaClient) fetch(ctx context.Context, partition int32, offset int64) ([]*Message, int64, error) {
+	req := &encoder{}
+	req.int32(-1) // replica ID
+	req.int32(int32(fetchMaxWait.Milliseconds()))
+	req.int32(1) // min bytes
+	req.int32(fetchMaxBytes)
+	req.int8(0) // isolation level: read uncommitted
+	req.int32(1)
+	req.string(c.topic.Name)
+	req.int32(1)
+	req.int32(partition)
+	req.int64(offset)
+	req.int32(fetchMaxBytes)
+	b, err := c.leader(ctx, partition)
+	if err != nil {
+		return nil, 0, err
+	}
+	d, err := b.roundTrip(ctx, apiFetch, fetchVersion, req.b)
+	if err != nil {
+		return nil, 0, err
+	}
+	d.int32() // throttle time
+	var records []byte
+	var errCode int16
+	for i, n := 0, d.arrayLen(); i < n; i++ {
+		d.string() // topic
+		for j, m := 0, d.arrayLen(); j < m; j++ {
+			d.int32() // partition
+			errCode = d.int16()
+			d.int64() // high watermark
+			d.int64() // last stable offset
+			for k, l := 0, d.arrayLen(); k < l; k++ {
+				d.int64() // aborted producer ID
+				d.int64() // aborted first offset
+			}
+			records = d.bytes()
+		}
+	}
+	if d.err != nil {
+		return nil, 0, d.err
+	}
+	if errCode != 0 {
+		return nil, 0, errors.Wrapf(kafkaError(errCode), "partition %d", partition)
+	}
+	return decodeRecordBatches(partition, offset, records)
+}
+
+// listOffset returns the offset of partition at timestamp, which may be
+// earliestOffset.
+func (c *kafkaClient) listOffset(ctx context.Context, partition int32, timestamp int64) (int64, error) {
+	req := &encoder{}
+	req.int32(-1) // replica ID
+	req.int32(1)
+	req.string(c.topic.Name)
+	req.int32(1)
+	req.int32(partition)
+	req.int64(timestamp)
+	b, err := c.leader(ctx, partition)
+	if err != nil {
+		return 0, err
+	}
+	d, err := b.roundTrip(ctx, apiListOffsets, listOffsetsVersion, req.b)
+	if err != nil {
+		return 0, err
+	}
+	var offset int64
+	var errCode int16
+	for i, n := 0, d.arrayLen(); i < n; i++ {
+		d.string() // topic
+		for j, m := 0, d.arrayLen(); j < m; j++ {
+			d.int32() // partition
+			errCode = d.int16()
+			d.int64() // timestamp
+			offset = d.int64()
+		}
+	}
+	if d.err != nil {
+		return 0, d.err
+	}
+	if errCode != 0 {
+		return 0, errors.Wrapf(kafkaError(errCode), "partition %d", partition)
+	}
+	return offset, nil
+}
+
+func (c *kafkaClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var errs error
+	for _, b := range c.brokers {
+		if err := b.Close(); err != nil && errs == nil {
+			errs = err
+		}
+	}
+	return errs
+}
+
+type kafkaPublisher struct {
+	*kafkaClient
+	// next is the partition of the next message without a key.
+	next int
+}
+
+func newKafkaPublisher(ctx context.Context, t *Topic) (*kafkaPublisher, error) {
+	c, err := newKafkaClient(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaPublisher{kafkaClient: c}, nil
+}
+
+// Publish writes a message to the partition selected by the hash of its
+// key, so that the messages with the same key stay in order. Messages
+// without a key are spread over the partitions.
+func (p *kafkaPublisher) Publish(ctx context.Context, key, value []byte) error {
+	var partition int32
+	if key != nil {
+		h := fnv.New32a()
+		h.Write(key)
+		partition = p.partitions[h.Sum32()%uint32(len(p.partitions))]
+	} else {
+		partition = p.partitions[p.next%len(p.partitions)]
+		p.next++
+	}
+	_, err := p.produce(ctx, partition, []*Message{{Key: key, Value: value}})
+	return err
+}
+
+type kafkaSubscriber struct {
+	*kafkaClient
+	offsets map[int32]int64
+	// buf holds fetched messages that haven't been returned by Next, and
+	// turn is the index of the partition fetched next.
+	buf  []*Message
+	turn int
+}
+
+func newKafkaSubscriber(ctx context.Context, t *Topic, offsets map[int32]int64) (*kafkaSubscriber, error) {
+	c, err := newKafkaClient(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	s := &kafkaSubscriber{kafkaClient: c, offsets: make(map[int32]int64)}
+	for _, partition := range c.partitions {
+		offset, ok := offsets[partition]
+		if !ok {
+			if offset, err = c.listOffset(ctx, partition, earliestOffset); err != nil {
+				c.Close()
+				return nil, err
+			}
+		}
+		s.offsets[partition] = offset
+	}
+	return s, nil
+}
+
+// Next returns the next message, fetching from the partitions in turn.
+// Messages are in order within a partition.
+func (s *kafkaSubscriber) Next(ctx context.Context) (*Message, error) {
+	for len(s.buf) == 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		partition := s.partitions[s.turn%len(s.partitions)]
+		s.turn++
+		messages, next, err := s.fetch(ctx, partition, s.offsets[partition])
+		if err != nil {
+			return nil, err
+		}
+		s.offsets[partition] = next
+		s.buf = messages
+	}
+	msg := s.buf[0]
+	s.buf = s.buf[1:]
+	return msg, nil
+}
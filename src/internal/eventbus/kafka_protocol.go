@@ -0,0 +1,336 @@
+package eventbus
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"strconv"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// Kafka API keys and the versions of them that are used.
+const (
+	apiProduce     = 0
+	apiFetch       = 1
+	apiListOffsets = 2
+	apiMetadata    = 3
+
+	produceVersion     = 3
+	fetchVersion       = 4
+	listOffsetsVersion = 1
+	metadataVersion    = 1
+
+	// recordBatchMagic is the version of the record batch format.
+	recordBatchMagic = 2
+	// compressionMask masks the compression codec in record batch
+	// attributes, and controlBatchFlag marks the batches of transaction
+	// markers.
+	compressionMask  = 0x07
+	controlBatchFlag = 0x20
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// kafkaError is an error code returned by a Kafka broker.
+type kafkaError int16
+
+func (e kafkaError) Error() string {
+	switch e {
+	case 1:
+		return "kafka: offset out of range"
+	case 3:
+		return "kafka: unknown topic or partition"
+	case 5:
+		return "kafka: leader not available"
+	case 6:
+		return "kafka: not leader for partition"
+	case 7:
+		return "kafka: request timed out"
+	case 10:
+		return "kafka: message too large"
+	default:
+		return "kafka: error code " + strconv.Itoa(int(e))
+	}
+}
+
+// encoder appends Kafka protocol primitives to a buffer.
+type encoder struct {
+	b []byte
+}
+
+func (e *encoder) int8(v int8) { e.b = append(e.b, byte(v)) }
+
+func (e *encoder) int16(v int16) {
+	var buf [2]byte
+	binary.BigEndian.PutUint16(buf[:], uint16(v))
+	e.b = append(e.b, buf[:]...)
+}
+
+func (e *encoder) int32(v int32) {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(v))
+	e.b = append(e.b, buf[:]...)
+}
+
+func (e *encoder) int64(v int64) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(v))
+	e.b = append(e.b, buf[:]...)
+}
+
+func (e *encoder) varint(v int64) {
+	var buf [binary.MaxVarintLen64]byte
+	e.b = append(e.b, buf[:binary.PutVarint(buf[:], v)]...)
+}
+
+func (e *encoder) string(s string) {
+	e.int16(int16(len(s)))
+	e.b = append(e.b, s...)
+}
+
+func (e *encoder) nullableString() { e.int16(-1) }
+
+func (e *encoder) bytes(b []byte) {
+	e.int32(int32(len(b)))
+	e.b = append(e.b, b...)
+}
+
+// varBytes encodes b with a varint length, as in records, where nil is
+// encoded as -1.
+func (e *encoder) varBytes(b []byte) {
+	if b == nil {
+		e.varint(-1)
+		return
+	}
+	e.varint(int64(len(b)))
+	e.b = append(e.b, b...)
+}
+
+// decoder reads Kafka protocol primitives from a buffer. The first error
+// is kept and makes later reads return zero values.
+type decoder struct {
+	b   []byte
+	err error
+}
+
+var errShortBuffer = errors.New("kafka: short buffer")
+
+func (d *decoder) need(n int) bool {
+	if d.err != nil {
+		return false
+	}
+	if n < 0 || len(d.b) < n {
+		d.err = errShortBuffer
+		return false
+	}
+	return true
+}
+
+func (d *decoder) int8() int8 {
+	if !d.need(1) {
+		return 0
+	}
+	v := int8(d.b[0])
+	d.b = d.b[1:]
+	return v
+}
+
+func (d *decoder) int16() int16 {
+	if !d.need(2) {
+		return 0
+	}
+	v := int16(binary.BigEndian.Uint16(d.b))
+	d.b = d.b[2:]
+	return v
+}
+
+func (d *decoder) int32() int32 {
+	if !d.need(4) {
+		return 0
+	}
+	v := int32(binary.BigEndian.Uint32(d.b))
+	d.b = d.b[4:]
+	return v
+}
+
+func (d *decoder) int64() int64 {
+	if !d.need(8) {
+		return 0
+	}
+	v := int64(binary.BigEndian.Uint64(d.b))
+	d.b = d.b[8:]
+	return v
+}
+
+func (d *decoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.b)
+	if n <= 0 {
+		d.err = errShortBuffer
+		return 0
+	}
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *decoder) raw(n int) []byte {
+	if !d.need(n) {
+		return nil
+	}
+	v := d.b[:n:n]
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *decoder) string() string {
+	n := d.int16()
+	if n < 0 {
+		return ""
+	}
+	return string(d.raw(int(n)))
+}
+
+func (d *decoder) bytes() []byte {
+	n := d.int32()
+	if n < 0 {
+		return nil
+	}
+	return d.raw(int(n))
+}
+
+func (d *decoder) varBytes() []byte {
+	n := d.varint()
+	if n < 0 {
+		return nil
+	}
+	return d.raw(int(n))
+}
+
+// arrayLen reads an array length, treating null arrays as empty.
+func (d *decoder) arrayLen() int {
+	n := d.int32()
+	if n < 0 {
+		return 0
+	}
+	// Each element is at least one byte, which bounds the allocations of
+	// corrupt lengths.
+	if !d.need(int(n)) {
+		return 0
+	}
+	return int(n)
+}
+
+// encodeRecordBatch encodes messages as an uncompressed record batch.
+func encodeRecordBatch(messages []*Message) []byte {
+	now := time.Now()
+	records := &encoder{}
+	for i, m := range messages {
+		ts := m.Time
+		if ts.IsZero() {
+			ts = now
+		}
+		r := &encoder{}
+		r.int8(0) // attributes
+		r.varint(ts.UnixMilli() - now.UnixMilli())
+		r.varint(int64(i))
+		r.varBytes(m.Key)
+		r.varBytes(m.Value)
+		r.varint(0) // headers
+		records.varint(int64(len(r.b)))
+		records.b = append(records.b, r.b...)
+	}
+	// the part of the batch covered by the CRC
+	body := &encoder{}
+	body.int16(0) // attributes
+	body.int32(int32(len(messages) - 1))
+	body.int64(now.UnixMilli())
+	body.int64(now.UnixMilli())
+	body.int64(-1) // producer ID
+	body.int16(-1) // producer epoch
+	body.int32(-1) // base sequence
+	body.int32(int32(len(messages)))
+	body.b = append(body.b, records.b...)
+
+	batch := &encoder{}
+	batch.int64(0) // base offset, assigned by the broker
+	// batch length: leader epoch, magic, CRC and body
+	batch.int32(int32(4 + 1 + 4 + len(body.b)))
+	batch.int32(-1) // partition leader epoch
+	batch.int8(recordBatchMagic)
+	batch.int32(int32(crc32.Checksum(body.b, crc32c)))
+	batch.b = append(batch.b, body.b...)
+	return batch.b
+}
+
+// decodeRecordBatches decodes the messages of partition in records, which
+// is a sequence of record batches whose last batch may be truncated.
+// Messages before offset are skipped. The returned offset is the offset
+// after the last decoded batch, which is where the next fetch starts.
+func decodeRecordBatches(partition int32, offset int64, records []byte) ([]*Message, int64, error) {
+	var messages []*Message
+	next := offset
+	for len(records) >= 12 {
+		d := &decoder{b: records}
+		baseOffset := d.int64()
+		length := d.int32()
+		if int(length) > len(d.b) {
+			// truncated by the fetch's max bytes
+			break
+		}
+		batch := &decoder{b: d.raw(int(length))}
+		records = d.b
+		batch.int32() // partition leader epoch
+		if magic := batch.int8(); magic != recordBatchMagic {
+			return nil, 0, errors.Errorf("kafka: unsupported message format version %d", magic)
+		}
+		crc := uint32(batch.int32())
+		if batch.err == nil && crc32.Checksum(batch.b, crc32c) != crc {
+			return nil, 0, errors.Errorf("kafka: corrupt record batch at offset %d", baseOffset)
+		}
+		attributes := batch.int16()
+		lastOffsetDelta := batch.int32()
+		firstTimestamp := batch.int64()
+		batch.int64() // max timestamp
+		batch.int64() // producer ID
+		batch.int16() // producer epoch
+		batch.int32() // base sequence
+		count := batch.arrayLen()
+		if batch.err != nil {
+			return nil, 0, batch.err
+		}
+		if end := baseOffset + int64(lastOffsetDelta) + 1; end > next {
+			next = end
+		}
+		if attributes&controlBatchFlag != 0 {
+			continue
+		}
+		if attributes&compressionMask != 0 {
+			return nil, 0, errors.Errorf("kafka: record batch at offset %d is compressed, which is not supported", baseOffset)
+		}
+		for i := 0; i < count; i++ {
+			r := &decoder{b: batch.raw(int(batch.varint()))}
+			r.int8() // attributes
+			timestampDelta := r.varint()
+			offsetDelta := r.varint()
+			key := r.varBytes()
+			value := r.varBytes()
+			if r.err != nil || batch.err != nil {
+				return nil, 0, errors.Errorf("kafka: corrupt record in batch at offset %d", baseOffset)
+			}
+			if baseOffset+offsetDelta < offset {
+				continue
+			}
+			messages = append(messages, &Message{
+				Partition: partition,
+				Offset:    baseOffset + offsetDelta,
+				Key:       key,
+				Value:     value,
+				Time:      time.UnixMilli(firstTimestamp + timestampDelta),
+			})
+		}
+	}
+	return messages, next, nil
+}
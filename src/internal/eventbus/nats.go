@@ -0,0 +1,259 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// natsConn is a connection to a NATS server, speaking the core NATS text
+// protocol.
+type natsConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+
+	// mu protects writes to conn.
+	mu sync.Mutex
+	// pongs receives a value for each PONG from the server, and errs each
+	// -ERR, both in reply to the PINGs sent by flush.
+	pongs chan struct{}
+	errs  chan error
+	// msgs receives the messages of the connection's subscription.
+	msgs chan *Message
+	// done is closed, and err set, when the connection's reader exits.
+	done chan struct{}
+	err  error
+}
+
+type natsInfo struct {
+	TLSRequired bool `json:"tls_required"`
+}
+
+type natsConnect struct {
+	Verbose   bool   `json:"verbose"`
+	Pedantic  bool   `json:"pedantic"`
+	Name      string `json:"name"`
+	Lang      string `json:"lang"`
+	Version   string `json:"version"`
+	Protocol  int    `json:"protocol"`
+	User      string `json:"user,omitempty"`
+	Pass      string `json:"pass,omitempty"`
+	AuthToken string `json:"auth_token,omitempty"`
+}
+
+func dialNATS(ctx context.Context, t *Topic) (*natsConn, error) {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", t.Addrs[0])
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	c := &natsConn{
+		conn:  conn,
+		r:     bufio.NewReader(conn),
+		pongs: make(chan struct{}, 1),
+		errs:  make(chan error, 1),
+		msgs:  make(chan *Message, 1024),
+		done:  make(chan struct{}),
+	}
+	if err := c.handshake(ctx, t); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	go c.read()
+	if err := c.flush(ctx); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *natsConn) handshake(ctx context.Context, t *Topic) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		c.conn.SetDeadline(deadline)
+	} else {
+		c.conn.SetDeadline(time.Now().Add(dialTimeout))
+	}
+	defer c.conn.SetDeadline(time.Time{})
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return errors.Errorf("unexpected NATS greeting %q", strings.TrimSpace(line))
+	}
+	info := &natsInfo{}
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), info); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if info.TLSRequired {
+		return errors.Errorf("NATS server %s requires TLS, which is not supported", t.Addrs[0])
+	}
+	connect, err := json.Marshal(&natsConnect{
+		Name:      "pachyderm",
+		Lang:      "go",
+		Version:   "1.0.0",
+		Protocol:  1,
+		User:      t.User,
+		Pass:      t.Password,
+		AuthToken: t.Token,
+	})
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	return c.write(fmt.Sprintf("CONNECT %s\r\n", connect))
+}
+
+func (c *natsConn) write(s string, payload ...[]byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := bufio.NewWriter(c.conn)
+	w.WriteString(s)
+	for _, p := range payload {
+		w.Write(p)
+		w.WriteString("\r\n")
+	}
+	return errors.EnsureStack(w.Flush())
+}
+
+// flush waits for the server to process everything written so far, and
+// returns the error it reports, if any.
+func (c *natsConn) flush(ctx context.Context) error {
+	if err := c.write("PING\r\n"); err != nil {
+		return err
+	}
+	select {
+	case <-c.pongs:
+		return nil
+	case err := <-c.errs:
+		return err
+	case <-c.done:
+		return c.err
+	case <-ctx.Done():
+		return errors.EnsureStack(ctx.Err())
+	}
+}
+
+// read reads server messages until the connection fails.
+func (c *natsConn) read() {
+	defer close(c.done)
+	c.err = c.readLoop()
+}
+
+func (c *natsConn) readLoop() error {
+	for {
+		line, err := c.r.ReadString('\n')
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		op := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch op {
+		case "PING":
+			if err := c.write("PONG\r\n"); err != nil {
+				return err
+			}
+		case "PONG":
+			select {
+			case c.pongs <- struct{}{}:
+			default:
+			}
+		case "+OK", "INFO":
+		case "-ERR":
+			err := errors.Errorf("NATS error: %s", strings.Trim(strings.TrimPrefix(line, "-ERR "), "'"))
+			select {
+			case c.errs <- err:
+			default:
+			}
+		case "MSG":
+			// MSG <subject> <sid> [reply-to] <#bytes>
+			fields := strings.Fields(line)
+			if len(fields) < 4 {
+				return errors.Errorf("malformed NATS message %q", line)
+			}
+			n, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil {
+				return errors.Errorf("malformed NATS message %q", line)
+			}
+			payload := make([]byte, n+2)
+			if _, err := io.ReadFull(c.r, payload); err != nil {
+				return errors.EnsureStack(err)
+			}
+			c.msgs <- &Message{
+				Value: payload[:n],
+				Time:  time.Now(),
+			}
+		default:
+			return errors.Errorf("unexpected NATS message %q", line)
+		}
+	}
+}
+
+func (c *natsConn) Close() error {
+	return errors.EnsureStack(c.conn.Close())
+}
+
+type natsPublisher struct {
+	*natsConn
+	subject string
+}
+
+func newNATSPublisher(ctx context.Context, t *Topic) (*natsPublisher, error) {
+	c, err := dialNATS(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	return &natsPublisher{natsConn: c, subject: t.Name}, nil
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, _, value []byte) error {
+	if err := p.write(fmt.Sprintf("PUB %s %d\r\n", p.subject, len(value)), value); err != nil {
+		return err
+	}
+	return p.flush(ctx)
+}
+
+type natsSubscriber struct {
+	*natsConn
+}
+
+func newNATSSubscriber(ctx context.Context, t *Topic) (*natsSubscriber, error) {
+	c, err := dialNATS(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.write(fmt.Sprintf("SUB %s 1\r\n", t.Name)); err != nil {
+		c.Close()
+		return nil, err
+	}
+	if err := c.flush(ctx); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return &natsSubscriber{natsConn: c}, nil
+}
+
+func (s *natsSubscriber) Next(ctx context.Context) (*Message, error) {
+	select {
+	case msg := <-s.msgs:
+		return msg, nil
+	case <-s.done:
+		// deliver the messages read before the connection failed
+		select {
+		case msg := <-s.msgs:
+			return msg, nil
+		default:
+		}
+		return nil, s.err
+	case <-ctx.Done():
+		return nil, errors.EnsureStack(ctx.Err())
+	}
+}
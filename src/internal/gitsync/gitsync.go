@@ -0,0 +1,379 @@
+// Package gitsync ingests a Git repository, or a subdirectory of one, into a
+// Pachyderm branch.
+//
+// Every Git commit on the first-parent history of the synced Git branch
+// becomes one PFS commit containing the files that changed in it, so
+// pipelines that take the branch as input re-run whenever the Git source
+// changes. The commit message and authorship of each Git commit are kept in
+// the description of the PFS commit as trailers, which is also how a sync
+// resumes: the Git commit recorded on the head of the PFS branch is the
+// starting point for the next sync. The first sync of a branch only imports
+// the Git commit at the tip of the Git branch.
+//
+// A sync can be run once, or by a Webhook that syncs whenever the Git server
+// reports a push.
+package gitsync
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+const (
+	// CommitTrailer precedes the Git commit hash in the description of a
+	// synced commit.
+	CommitTrailer = "git-commit: "
+	// AuthorTrailer precedes the Git commit author.
+	AuthorTrailer = "git-author: "
+	// DateTrailer precedes the Git commit author date, in RFC 3339 format.
+	DateTrailer = "git-date: "
+
+	// maxCommits bounds the number of Git commits imported by a single sync,
+	// so that a sync after a long outage doesn't replay the whole history.
+	maxCommits = 100
+)
+
+// Spec describes a Git branch and the PFS branch it's synced to.
+type Spec struct {
+	// URL is the Git clone URL.
+	URL string
+	// Ref is the Git branch to sync, "" means the remote's default branch.
+	Ref string
+	// Path is the subdirectory of the Git repository to sync, "" means the
+	// whole repository. Files are written relative to it.
+	Path string
+	// Username and Password are used for HTTP basic auth. For most Git
+	// hosts, Password is an access token.
+	Username, Password string
+	// Repo and Branch are the PFS branch the Git branch is synced to.
+	Repo, Branch string
+}
+
+func (s Spec) String() string {
+	src := s.URL
+	if s.Ref != "" {
+		src += "#" + s.Ref
+	}
+	if s.Path != "" {
+		src += ":" + s.Path
+	}
+	return fmt.Sprintf("%s -> %s@%s", src, s.Repo, s.Branch)
+}
+
+// Result describes a completed sync.
+type Result struct {
+	// Commits is the Git commits that were synced, oldest first. Git commits
+	// that don't change anything under Spec.Path aren't synced.
+	Commits []string
+	// Head is the Git commit at the tip of the Git branch.
+	Head string
+}
+
+// Syncer syncs a Git branch into a PFS branch.
+type Syncer struct {
+	c    *client.APIClient
+	spec Spec
+	// mu serializes syncs, since two concurrent syncs would both append the
+	// same Git commits to the PFS branch.
+	mu sync.Mutex
+}
+
+// NewSyncer creates a new Syncer.
+func NewSyncer(c *client.APIClient, spec Spec) *Syncer {
+	if spec.Branch == "" {
+		spec.Branch = "master"
+	}
+	spec.Path = strings.Trim(path.Clean("/"+spec.Path), "/")
+	return &Syncer{c: c, spec: spec}
+}
+
+// Spec returns the spec the Syncer was created with.
+func (s *Syncer) Spec() Spec {
+	return s.spec
+}
+
+// SourceCommit returns the Git commit hash recorded in a synced commit's
+// description, or "" if the commit was not created by a sync.
+func SourceCommit(ci *pfs.CommitInfo) string {
+	return trailer(ci.Description, CommitTrailer)
+}
+
+func trailer(description, key string) string {
+	var value string
+	scanner := bufio.NewScanner(strings.NewReader(description))
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.HasPrefix(line, key) {
+			value = strings.TrimSpace(strings.TrimPrefix(line, key))
+		}
+	}
+	return value
+}
+
+// Description returns the PFS commit description for a Git commit.
+func Description(commit *object.Commit) string {
+	var b strings.Builder
+	if msg := strings.TrimSpace(commit.Message); msg != "" {
+		b.WriteString(msg)
+		b.WriteString("\n\n")
+	}
+	fmt.Fprintf(&b, "%s%s\n", CommitTrailer, commit.Hash)
+	fmt.Fprintf(&b, "%s%s <%s>\n", AuthorTrailer, commit.Author.Name, commit.Author.Email)
+	fmt.Fprintf(&b, "%s%s\n", DateTrailer, commit.Author.When.Format(time.RFC3339))
+	return b.String()
+}
+
+// SyncOnce clones the Git repository and syncs the Git commits that are not
+// yet in the PFS branch. The PFS repo is created if it doesn't exist.
+func (s *Syncer) SyncOnce(ctx context.Context) (*Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.ensureRepo(); err != nil {
+		return nil, err
+	}
+	last, err := s.lastSynced()
+	if err != nil {
+		return nil, err
+	}
+	repo, err := s.clone(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := repo.Head()
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	head, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	pending, prev, err := pendingCommits(head, last)
+	if err != nil {
+		return nil, err
+	}
+	res := &Result{Head: head.Hash.String()}
+	for _, commit := range pending {
+		changes, err := Diff(prev, commit, s.spec.Path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error diffing git commit %v", commit.Hash)
+		}
+		// The first sync always creates a commit, so that the PFS branch
+		// records where the sync started.
+		if len(changes) > 0 || prev == nil {
+			if err := s.syncCommit(commit, changes, prev == nil); err != nil {
+				return nil, errors.Wrapf(err, "error syncing git commit %v", commit.Hash)
+			}
+			res.Commits = append(res.Commits, commit.Hash.String())
+		}
+		prev = commit
+	}
+	return res, nil
+}
+
+func (s *Syncer) clone(ctx context.Context) (*git.Repository, error) {
+	opts := &git.CloneOptions{
+		URL:  s.spec.URL,
+		Tags: git.NoTags,
+	}
+	if s.spec.Ref != "" {
+		opts.ReferenceName = plumbing.NewBranchReferenceName(s.spec.Ref)
+		opts.SingleBranch = true
+	}
+	if s.spec.Username != "" || s.spec.Password != "" {
+		opts.Auth = &http.BasicAuth{Username: s.spec.Username, Password: s.spec.Password}
+	}
+	// The repository is cloned without a worktree, files are read directly
+	// from the object store.
+	repo, err := git.CloneContext(ctx, memory.NewStorage(), nil, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error cloning %v", s.spec.URL)
+	}
+	return repo, nil
+}
+
+func (s *Syncer) ensureRepo() error {
+	if _, err := s.c.InspectRepo(s.spec.Repo); err != nil {
+		if !errutil.IsNotFoundError(err) {
+			return err
+		}
+		if _, err := s.c.PfsAPIClient.CreateRepo(s.c.Ctx(), &pfs.CreateRepoRequest{
+			Repo:        client.NewRepo(s.spec.Repo),
+			Description: "Synced from " + s.spec.URL,
+		}); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	return nil
+}
+
+// lastSynced returns the Git commit recorded on the head of the PFS branch.
+func (s *Syncer) lastSynced() (string, error) {
+	ci, err := s.c.InspectCommit(s.spec.Repo, s.spec.Branch, "")
+	if err != nil {
+		if errutil.IsNotFoundError(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return SourceCommit(ci), nil
+}
+
+// pendingCommits walks the first-parent history of head back to last and
+// returns the commits after last, oldest first, along with the commit for
+// last. If last is not in the history, because nothing has been synced yet
+// or the Git branch was rewritten, only head is returned and the returned
+// parent is nil, meaning head should replace the PFS branch's contents.
+func pendingCommits(head *object.Commit, last string) ([]*object.Commit, *object.Commit, error) {
+	var result []*object.Commit
+	var parent *object.Commit
+	if last != "" {
+		for commit := head; ; {
+			if commit.Hash.String() == last {
+				parent = commit
+				break
+			}
+			if len(result) == maxCommits || commit.NumParents() == 0 {
+				break
+			}
+			result = append(result, commit)
+			var err error
+			if commit, err = commit.Parent(0); err != nil {
+				return nil, nil, errors.EnsureStack(err)
+			}
+		}
+	}
+	if parent == nil {
+		return []*object.Commit{head}, nil, nil
+	}
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result, parent, nil
+}
+
+// Change is a file added, modified or deleted by a Git commit. Paths are
+// relative to the synced subdirectory.
+type Change struct {
+	Path string
+	// File is the new version of the file, nil if it was deleted.
+	File *object.File
+}
+
+// Diff returns the file changes between prev and commit under subdir. If
+// prev is nil every file in commit is returned.
+func Diff(prev, commit *object.Commit, subdir string) ([]Change, error) {
+	from, err := subtree(prev, subdir)
+	if err != nil {
+		return nil, err
+	}
+	to, err := subtree(commit, subdir)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := object.DiffTree(from, to)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	var result []Change
+	for _, change := range changes {
+		// A rename or a change from a file to something else (e.g. a
+		// submodule) removes the old path.
+		if change.From.Name != "" && change.From.TreeEntry.Mode.IsFile() &&
+			(change.To.Name != change.From.Name || !change.To.TreeEntry.Mode.IsFile()) {
+			result = append(result, Change{Path: "/" + change.From.Name})
+		}
+		if change.To.Name != "" && change.To.TreeEntry.Mode.IsFile() {
+			f, err := change.To.Tree.TreeEntryFile(&change.To.TreeEntry)
+			if err != nil {
+				return nil, errors.EnsureStack(err)
+			}
+			result = append(result, Change{Path: "/" + change.To.Name, File: f})
+		}
+	}
+	return result, nil
+}
+
+// subtree returns the tree for subdir in commit, or nil if commit is nil or
+// doesn't contain subdir.
+func subtree(commit *object.Commit, subdir string) (*object.Tree, error) {
+	if commit == nil {
+		return nil, nil
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if subdir == "" {
+		return tree, nil
+	}
+	tree, err = tree.Tree(subdir)
+	if err != nil {
+		if errors.Is(err, object.ErrDirectoryNotFound) {
+			return nil, nil
+		}
+		return nil, errors.EnsureStack(err)
+	}
+	return tree, nil
+}
+
+// syncCommit writes changes to a new commit on the PFS branch. If replace is
+// true the branch's existing files are deleted first.
+func (s *Syncer) syncCommit(commit *object.Commit, changes []Change, replace bool) (retErr error) {
+	pfsCommit, err := s.c.StartCommit(s.spec.Repo, s.spec.Branch)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if retErr != nil {
+			return
+		}
+		_, retErr = s.c.PfsAPIClient.FinishCommit(s.c.Ctx(), &pfs.FinishCommitRequest{
+			Commit:      pfsCommit,
+			Description: Description(commit),
+		})
+		retErr = errors.EnsureStack(retErr)
+	}()
+	return s.c.WithModifyFileClient(pfsCommit, func(mf client.ModifyFile) error {
+		if replace {
+			if err := mf.DeleteFile("/"); err != nil {
+				return errors.EnsureStack(err)
+			}
+		}
+		for _, change := range changes {
+			if change.File == nil {
+				if err := mf.DeleteFile(change.Path); err != nil {
+					return errors.EnsureStack(err)
+				}
+				continue
+			}
+			if err := putFile(mf, change); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func putFile(mf client.ModifyFile, change Change) error {
+	r, err := change.File.Reader()
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer r.Close()
+	return errors.EnsureStack(mf.PutFile(change.Path, r))
+}
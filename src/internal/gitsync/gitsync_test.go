@@ -0,0 +1,191 @@
+package gitsync
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+type testRepo struct {
+	t    *testing.T
+	repo *git.Repository
+	wt   *git.Worktree
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	require.NoError(t, err)
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	return &testRepo{t: t, repo: repo, wt: wt}
+}
+
+// commit writes files (a nil value deletes the file) and commits them.
+func (r *testRepo) commit(msg string, files map[string][]byte) *object.Commit {
+	for name, content := range files {
+		if content == nil {
+			_, err := r.wt.Remove(name)
+			require.NoError(r.t, err)
+			continue
+		}
+		f, err := r.wt.Filesystem.Create(name)
+		require.NoError(r.t, err)
+		_, err = f.Write(content)
+		require.NoError(r.t, err)
+		require.NoError(r.t, f.Close())
+		_, err = r.wt.Add(name)
+		require.NoError(r.t, err)
+	}
+	hash, err := r.wt.Commit(msg, &git.CommitOptions{
+		Author: &object.Signature{Name: "Ada", Email: "ada@example.com", When: time.Unix(1600000000, 0).UTC()},
+	})
+	require.NoError(r.t, err)
+	commit, err := r.repo.CommitObject(hash)
+	require.NoError(r.t, err)
+	return commit
+}
+
+func changedPaths(t *testing.T, changes []Change) (put, deleted []string) {
+	for _, c := range changes {
+		if c.File == nil {
+			deleted = append(deleted, c.Path)
+		} else {
+			put = append(put, c.Path)
+		}
+	}
+	sort.Strings(put)
+	sort.Strings(deleted)
+	return put, deleted
+}
+
+func TestDiff(t *testing.T) {
+	r := newTestRepo(t)
+	c1 := r.commit("first", map[string][]byte{
+		"README":          []byte("readme"),
+		"config/a.yaml":   []byte("a"),
+		"config/b.yaml":   []byte("b"),
+		"src/main.go":     []byte("package main"),
+		"config/x/c.yaml": []byte("c"),
+	})
+	c2 := r.commit("second", map[string][]byte{
+		"config/a.yaml": []byte("a2"),
+		"config/b.yaml": nil,
+		"src/main.go":   []byte("package main // changed"),
+	})
+
+	put, deleted := changedPaths(t, mustDiff(t, nil, c1, ""))
+	require.Equal(t, []string{"/README", "/config/a.yaml", "/config/b.yaml", "/config/x/c.yaml", "/src/main.go"}, put)
+	require.Equal(t, 0, len(deleted))
+
+	put, deleted = changedPaths(t, mustDiff(t, c1, c2, ""))
+	require.Equal(t, []string{"/config/a.yaml", "/src/main.go"}, put)
+	require.Equal(t, []string{"/config/b.yaml"}, deleted)
+
+	// Paths are relative to the subdirectory, and changes outside of it
+	// are ignored.
+	put, deleted = changedPaths(t, mustDiff(t, c1, c2, "config"))
+	require.Equal(t, []string{"/a.yaml"}, put)
+	require.Equal(t, []string{"/b.yaml"}, deleted)
+
+	changes := mustDiff(t, c1, c2, "config/x")
+	require.Equal(t, 0, len(changes))
+
+	changes = mustDiff(t, nil, c2, "missing")
+	require.Equal(t, 0, len(changes))
+}
+
+func mustDiff(t *testing.T, prev, commit *object.Commit, subdir string) []Change {
+	changes, err := Diff(prev, commit, subdir)
+	require.NoError(t, err)
+	return changes
+}
+
+func TestPendingCommits(t *testing.T) {
+	r := newTestRepo(t)
+	c1 := r.commit("1", map[string][]byte{"f": []byte("1")})
+	c2 := r.commit("2", map[string][]byte{"f": []byte("2")})
+	c3 := r.commit("3", map[string][]byte{"f": []byte("3")})
+
+	// Nothing synced yet, only the head is imported.
+	pending, parent, err := pendingCommits(c3, "")
+	require.NoError(t, err)
+	require.Nil(t, parent)
+	require.Equal(t, 1, len(pending))
+	require.Equal(t, c3.Hash, pending[0].Hash)
+
+	pending, parent, err = pendingCommits(c3, c1.Hash.String())
+	require.NoError(t, err)
+	require.Equal(t, c1.Hash, parent.Hash)
+	require.Equal(t, 2, len(pending))
+	require.Equal(t, c2.Hash, pending[0].Hash)
+	require.Equal(t, c3.Hash, pending[1].Hash)
+
+	pending, _, err = pendingCommits(c3, c3.Hash.String())
+	require.NoError(t, err)
+	require.Equal(t, 0, len(pending))
+
+	// A commit that isn't in the history, e.g. after a force push.
+	pending, parent, err = pendingCommits(c3, "0000000000000000000000000000000000000000")
+	require.NoError(t, err)
+	require.Nil(t, parent)
+	require.Equal(t, 1, len(pending))
+}
+
+func TestDescription(t *testing.T) {
+	r := newTestRepo(t)
+	c := r.commit("Update config\n\nLonger explanation.\n", map[string][]byte{"f": []byte("1")})
+	desc := Description(c)
+	require.Equal(t, "Update config\n\nLonger explanation.\n\n"+
+		CommitTrailer+c.Hash.String()+"\n"+
+		AuthorTrailer+"Ada <ada@example.com>\n"+
+		DateTrailer+"2020-09-13T12:26:40Z\n", desc)
+	require.Equal(t, c.Hash.String(), SourceCommit(&pfs.CommitInfo{Description: desc}))
+	require.Equal(t, "", SourceCommit(&pfs.CommitInfo{Description: "manual commit"}))
+}
+
+func TestWebhook(t *testing.T) {
+	h := NewWebhook(NewSyncer(nil, Spec{Ref: "main"}), "s3cret")
+	post := func(payload string, header ...string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(payload))
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		_, err := ioutil.ReadAll(w.Result().Body)
+		require.NoError(t, err)
+		return w.Code
+	}
+	sign := func(payload string) string {
+		mac := hmac.New(sha256.New, []byte("s3cret"))
+		mac.Write([]byte(payload))
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	push := `{"ref": "refs/heads/main"}`
+	other := `{"ref": "refs/heads/dev"}`
+
+	require.Equal(t, http.StatusUnauthorized, post(push))
+	require.Equal(t, http.StatusUnauthorized, post(push, "X-Hub-Signature-256", sign(other)))
+	require.Equal(t, http.StatusAccepted, post(push, "X-Hub-Signature-256", sign(push), "X-GitHub-Event", "push"))
+	require.Equal(t, http.StatusNoContent, post(push, "X-Hub-Signature-256", sign(push), "X-GitHub-Event", "ping"))
+	require.Equal(t, http.StatusNoContent, post(other, "X-Hub-Signature-256", sign(other)))
+	require.Equal(t, http.StatusAccepted, post(push, "X-Gitlab-Token", "s3cret"))
+	require.Equal(t, http.StatusUnauthorized, post(push, "X-Gitlab-Token", "wrong"))
+	// Pushes are coalesced into a single queued sync.
+	require.Equal(t, 1, len(h.queue))
+}
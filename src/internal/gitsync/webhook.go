@@ -0,0 +1,143 @@
+package gitsync
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxPayloadSize bounds the size of webhook payloads that are read.
+const maxPayloadSize = 25 << 20
+
+// Webhook is an http.Handler that syncs a Git branch whenever the Git server
+// reports a push to it. GitHub, GitLab and Gitea style push events are
+// understood. Pushes are coalesced, so a burst of pushes results in at most
+// one sync in progress and one queued.
+type Webhook struct {
+	syncer *Syncer
+	secret []byte
+	// Sync is called after each sync with its result or error.
+	Sync  func(*Result, error)
+	queue chan struct{}
+}
+
+// NewWebhook creates a new Webhook. If secret is not empty, requests must be
+// signed with it (X-Hub-Signature-256 or X-Gitea-Signature) or carry it as a
+// token (X-Gitlab-Token).
+func NewWebhook(syncer *Syncer, secret string) *Webhook {
+	return &Webhook{
+		syncer: syncer,
+		secret: []byte(secret),
+		queue:  make(chan struct{}, 1),
+	}
+}
+
+// Run performs an initial sync and then syncs each time a push is received,
+// until ctx is cancelled.
+func (h *Webhook) Run(ctx context.Context) error {
+	h.trigger()
+	for {
+		select {
+		case <-h.queue:
+			res, err := h.syncer.SyncOnce(ctx)
+			if err != nil {
+				logrus.Errorf("error syncing %v: %v", h.syncer.Spec(), err)
+			}
+			if h.Sync != nil {
+				h.Sync(res, err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (h *Webhook) trigger() {
+	select {
+	case h.queue <- struct{}{}:
+	default:
+		// A sync is already queued and will pick up this push.
+	}
+}
+
+type pushEvent struct {
+	Ref string `json:"ref"`
+}
+
+func (h *Webhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	payload, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !h.authorized(r, payload) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	// GitHub sends a ping when a webhook is created, and other events if
+	// the webhook is subscribed to them.
+	if event := r.Header.Get("X-GitHub-Event"); event != "" && event != "push" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	var push pushEvent
+	if err := json.Unmarshal(payload, &push); err != nil {
+		http.Error(w, "invalid push event: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !h.matches(push.Ref) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	h.trigger()
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// matches returns true if a push to ref should trigger a sync. Without a
+// configured Git branch, which branch is the default can't be known from
+// the push, so every branch push triggers a sync.
+func (h *Webhook) matches(ref string) bool {
+	if want := h.syncer.Spec().Ref; want != "" {
+		return ref == "refs/heads/"+want
+	}
+	return strings.HasPrefix(ref, "refs/heads/")
+}
+
+func (h *Webhook) authorized(r *http.Request, payload []byte) bool {
+	if len(h.secret) == 0 {
+		return true
+	}
+	if sig := r.Header.Get("X-Hub-Signature-256"); sig != "" {
+		return validSignature(h.secret, payload, strings.TrimPrefix(sig, "sha256="))
+	}
+	if sig := r.Header.Get("X-Gitea-Signature"); sig != "" {
+		return validSignature(h.secret, payload, sig)
+	}
+	if token := r.Header.Get("X-Gitlab-Token"); token != "" {
+		return subtle.ConstantTimeCompare([]byte(token), h.secret) == 1
+	}
+	return false
+}
+
+// validSignature checks a hex encoded HMAC-SHA256 of payload.
+func validSignature(secret, payload []byte, sig string) bool {
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return hmac.Equal(got, mac.Sum(nil))
+}
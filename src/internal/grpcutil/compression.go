@@ -0,0 +1,145 @@
+package grpcutil
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// ZstdCompressor is the name of the zstd gRPC compressor.
+const ZstdCompressor = "zstd"
+
+// StreamCompressors are the compressors that file transfer streams may use, in
+// order of preference.
+var StreamCompressors = []string{ZstdCompressor, gzip.Name}
+
+func init() {
+	encoding.RegisterCompressor(&zstdCompressor{})
+}
+
+// zstdCompressor is a gRPC compressor that pools its encoders and decoders,
+// since they're expensive to create.
+type zstdCompressor struct {
+	encoders, decoders sync.Pool
+}
+
+func (c *zstdCompressor) Name() string {
+	return ZstdCompressor
+}
+
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	enc, ok := c.encoders.Get().(*zstd.Encoder)
+	if ok {
+		enc.Reset(w)
+	} else {
+		var err error
+		enc, err = zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+	}
+	return &zstdWriter{Encoder: enc, pool: &c.encoders}, nil
+}
+
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	dec, ok := c.decoders.Get().(*zstd.Decoder)
+	if ok {
+		if err := dec.Reset(r); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+	} else {
+		var err error
+		dec, err = zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+	}
+	return &zstdReader{Decoder: dec, pool: &c.decoders}, nil
+}
+
+type zstdWriter struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+func (w *zstdWriter) Close() error {
+	err := w.Encoder.Close()
+	w.pool.Put(w.Encoder)
+	return errors.EnsureStack(err)
+}
+
+// zstdReader returns its decoder to the pool once the message has been read.
+type zstdReader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+}
+
+func (r *zstdReader) Read(p []byte) (int, error) {
+	if r.Decoder == nil {
+		return 0, io.EOF
+	}
+	n, err := r.Decoder.Read(p)
+	if errors.Is(err, io.EOF) {
+		r.pool.Put(r.Decoder)
+		r.Decoder = nil
+	}
+	return n, err
+}
+
+// ParseCompressionPolicy parses a comma-separated list of the compressors
+// file transfer streams may use. An empty policy allows all of
+// StreamCompressors, and "none" only allows uncompressed streams.
+func ParseCompressionPolicy(policy string) ([]string, error) {
+	policy = strings.TrimSpace(policy)
+	switch policy {
+	case "":
+		return StreamCompressors, nil
+	case "none":
+		return nil, nil
+	}
+	var result []string
+	for _, name := range strings.Split(policy, ",") {
+		name = strings.TrimSpace(name)
+		if encoding.GetCompressor(name) == nil {
+			return nil, errors.Errorf("unknown stream compressor %q, must be one of %s", name, strings.Join(StreamCompressors, ", "))
+		}
+		result = append(result, name)
+	}
+	return result, nil
+}
+
+// RecvCompressor returns the compressor the client used for the request
+// stream in ctx, or "" if the request isn't compressed.
+func RecvCompressor(ctx context.Context) string {
+	s, ok := grpc.ServerTransportStreamFromContext(ctx).(interface{ RecvCompress() string })
+	if !ok {
+		return ""
+	}
+	if name := s.RecvCompress(); name != "identity" {
+		return name
+	}
+	return ""
+}
+
+// CheckCompression returns an error if the request stream in ctx is
+// compressed with a compressor that isn't in allowed.
+func CheckCompression(ctx context.Context, allowed []string) error {
+	name := RecvCompressor(ctx)
+	if name == "" {
+		return nil
+	}
+	for _, a := range allowed {
+		if a == name {
+			return nil
+		}
+	}
+	return errors.Errorf("this cluster doesn't allow %s compressed file transfers (allowed: %q)", name, allowed)
+}
@@ -0,0 +1,51 @@
+package grpcutil
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/encoding"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestZstdCompressor(t *testing.T) {
+	c := encoding.GetCompressor(ZstdCompressor)
+	require.NotNil(t, c)
+	data := []byte(strings.Repeat("compressible data,", 10000))
+	// run twice so the pooled encoder and decoder are reused
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		w, err := c.Compress(&buf)
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		require.True(t, buf.Len() < len(data)/10)
+
+		r, err := c.Decompress(&buf)
+		require.NoError(t, err)
+		result, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, data, result)
+	}
+}
+
+func TestParseCompressionPolicy(t *testing.T) {
+	compressors, err := ParseCompressionPolicy("")
+	require.NoError(t, err)
+	require.Equal(t, StreamCompressors, compressors)
+
+	compressors, err = ParseCompressionPolicy("none")
+	require.NoError(t, err)
+	require.Equal(t, 0, len(compressors))
+
+	compressors, err = ParseCompressionPolicy("gzip, zstd")
+	require.NoError(t, err)
+	require.Equal(t, []string{"gzip", "zstd"}, compressors)
+
+	_, err = ParseCompressionPolicy("zstd,lz4")
+	require.YesError(t, err)
+}
@@ -18,6 +18,7 @@ import (
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	logutil "github.com/pachyderm/pachyderm/v2/src/internal/log"
 	"github.com/pachyderm/pachyderm/v2/src/internal/tls"
 	log "github.com/sirupsen/logrus"
 )
@@ -46,8 +47,8 @@ func NewServer(ctx context.Context, publicPortTLSAllowed bool, options ...grpc.S
 			MinTime:             5 * time.Second,
 			PermitWithoutStream: true,
 		}),
-		grpc.ChainUnaryInterceptor(grpc_prometheus.UnaryServerInterceptor),
-		grpc.ChainStreamInterceptor(grpc_prometheus.StreamServerInterceptor),
+		grpc.ChainUnaryInterceptor(grpc_prometheus.UnaryServerInterceptor, recoverUnary),
+		grpc.ChainStreamInterceptor(grpc_prometheus.StreamServerInterceptor, recoverStream),
 	}, options...)
 
 	var cLoader *tls.CertLoader
@@ -114,3 +115,16 @@ func (s *Server) ListenTCP(host string, port uint16) (net.Listener, error) {
 func (s *Server) Wait() error {
 	return errors.EnsureStack(s.eg.Wait())
 }
+
+// recoverUnary converts a panic in a unary RPC into an error, so that it's
+// reported to the caller instead of crashing the server.
+func recoverUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ interface{}, retErr error) {
+	defer logutil.Recover(info.FullMethod, &retErr)
+	return handler(ctx, req)
+}
+
+// recoverStream is the streaming equivalent of recoverUnary.
+func recoverStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (retErr error) {
+	defer logutil.Recover(info.FullMethod, &retErr)
+	return handler(srv, stream)
+}
@@ -0,0 +1,86 @@
+package healthcheck
+
+import (
+	"context"
+
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+)
+
+// probeKey is the etcd key and object name that the checks read. Neither is
+// expected to exist.
+const probeKey = "pachyderm-health-check"
+
+// Etcd checks that etcd can serve reads.
+func Etcd(client *etcd.Client) Check {
+	return Check{
+		Name: "etcd",
+		Check: func(ctx context.Context) (string, error) {
+			if _, err := client.Get(ctx, probeKey); err != nil {
+				return "", errors.EnsureStack(err)
+			}
+			return "", nil
+		},
+	}
+}
+
+// Postgres checks that a connection to postgres can be made through db.
+func Postgres(name string, db *pachsql.DB) Check {
+	return Check{
+		Name: name,
+		Check: func(ctx context.Context) (string, error) {
+			return "", errors.EnsureStack(db.PingContext(ctx))
+		},
+	}
+}
+
+// ObjectStorage checks that the bucket of client can be read. newClient is
+// called once, on the first check, so that an object storage that's
+// misconfigured is reported by the check rather than failing startup.
+func ObjectStorage(newClient func() (obj.Client, error)) Check {
+	var client obj.Client
+	return Check{
+		Name: "object-storage",
+		Check: func(ctx context.Context) (string, error) {
+			if client == nil {
+				c, err := newClient()
+				if err != nil {
+					return "", errors.Wrap(err, "could not create object storage client")
+				}
+				client = c
+			}
+			if _, err := client.Exists(ctx, probeKey); err != nil {
+				return "", errors.EnsureStack(err)
+			}
+			url := client.BucketURL()
+			return url.String(), nil
+		},
+	}
+}
+
+// Auth checks that the auth server returned by server can tell whether auth
+// is activated. The detail of the check is "activated" or "not activated".
+func Auth(server func() auth.APIServer) Check {
+	return Check{
+		Name: "auth",
+		Check: func(ctx context.Context) (string, error) {
+			s := server()
+			if s == nil {
+				return "", errors.New("auth server not yet initialized")
+			}
+			// the check has no token, so it isn't expected to succeed
+			_, err := s.WhoAmI(ctx, &auth.WhoAmIRequest{})
+			switch {
+			case err == nil, auth.IsErrNoMetadata(err), auth.IsErrNotSignedIn(err), auth.IsErrBadToken(err):
+				return "activated", nil
+			case auth.IsErrNotActivated(err):
+				return "not activated", nil
+			}
+			return "", errors.EnsureStack(err)
+		},
+	}
+}
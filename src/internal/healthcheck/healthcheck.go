@@ -0,0 +1,207 @@
+// Package healthcheck periodically checks the dependencies of pachd, such as
+// etcd, postgres and object storage, and reports the results through the gRPC
+// health checking protocol and an HTTP readiness endpoint.
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// A Check checks that a dependency is available. It returns an error if it
+// isn't, and otherwise an optional detail about its state.
+type Check struct {
+	Name  string
+	Check func(ctx context.Context) (string, error)
+}
+
+// Result is the result of a check.
+type Result struct {
+	Name    string    `json:"name"`
+	Healthy bool      `json:"healthy"`
+	Detail  string    `json:"detail,omitempty"`
+	Error   string    `json:"error,omitempty"`
+	Latency string    `json:"latency"`
+	Checked time.Time `json:"checked"`
+}
+
+// Checker runs checks periodically and reports their results. The results are
+// only reported once every check has run.
+type Checker struct {
+	checks   []Check
+	interval time.Duration
+	timeout  time.Duration
+
+	mu      sync.Mutex
+	results []Result
+	servers []*health.Server
+}
+
+// NewChecker returns a Checker that runs checks every interval, each with
+// the given timeout.
+func NewChecker(interval, timeout time.Duration, checks ...Check) *Checker {
+	return &Checker{
+		checks:   checks,
+		interval: interval,
+		timeout:  timeout,
+	}
+}
+
+// AddServer makes the statuses of s follow the checks. The overall status
+// ("") of s is SERVING when every check passes, and the status of each check
+// is reported under the check's name. Until the checks first run, every
+// service of s is NOT_SERVING.
+func (c *Checker) AddServer(s *health.Server) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.servers = append(c.servers, s)
+	c.updateServer(s)
+}
+
+// Run runs the checks until ctx is done.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		c.CheckNow(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckNow runs every check concurrently, records their results and returns
+// them.
+func (c *Checker) CheckNow(ctx context.Context) []Result {
+	results := make([]Result, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		i, check := i, check
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range results {
+		if !r.Healthy && c.wasHealthy(r.Name) {
+			log.Errorf("health check %q failed: %s", r.Name, r.Error)
+		}
+	}
+	c.results = results
+	for _, s := range c.servers {
+		c.updateServer(s)
+	}
+	return results
+}
+
+func (c *Checker) run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	start := time.Now()
+	detail, err := check.Check(ctx)
+	r := Result{
+		Name:    check.Name,
+		Healthy: err == nil,
+		Detail:  detail,
+		Latency: time.Since(start).Round(time.Millisecond).String(),
+		Checked: start,
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// wasHealthy returns true if the last result of a check, if it has run,
+// passed. c.mu must be held.
+func (c *Checker) wasHealthy(name string) bool {
+	for _, r := range c.results {
+		if r.Name == name {
+			return r.Healthy
+		}
+	}
+	return true
+}
+
+// updateServer sets the statuses of s to the last results. c.mu must be
+// held.
+func (c *Checker) updateServer(s *health.Server) {
+	healthy := c.results != nil
+	for _, r := range c.results {
+		status := grpc_health_v1.HealthCheckResponse_SERVING
+		if !r.Healthy {
+			status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+			healthy = false
+		}
+		s.SetServingStatus(r.Name, status)
+	}
+	if c.results == nil {
+		for _, check := range c.checks {
+			s.SetServingStatus(check.Name, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+		}
+	}
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	if !healthy {
+		status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	s.SetServingStatus("", status)
+}
+
+// Results returns the last results of the checks, and whether they all
+// passed. It returns nil and false if the checks haven't run yet.
+func (c *Checker) Results() ([]Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.results == nil {
+		return nil, false
+	}
+	healthy := true
+	for _, r := range c.results {
+		healthy = healthy && r.Healthy
+	}
+	return append([]Result(nil), c.results...), healthy
+}
+
+// readyzResponse is the body of a /readyz response.
+type readyzResponse struct {
+	Status string   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+// ServeHTTP serves the last results of the checks as JSON, with status 200
+// if they all passed and 503 otherwise. It's meant to be served at /readyz.
+func (c *Checker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	results, healthy := c.Results()
+	resp := readyzResponse{Status: "ok", Checks: results}
+	code := http.StatusOK
+	switch {
+	case results == nil:
+		resp.Status, resp.Checks = "starting", []Result{}
+		code = http.StatusServiceUnavailable
+	case !healthy:
+		resp.Status = "unavailable"
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(code)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(resp); err != nil {
+		log.Errorf("error writing /readyz response: %v", err)
+	}
+}
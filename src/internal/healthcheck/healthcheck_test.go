@@ -0,0 +1,111 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func servingStatus(t *testing.T, s *health.Server, service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	resp, err := s.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return resp.Status
+}
+
+func readyz(t *testing.T, c *Checker) (int, readyzResponse) {
+	w := httptest.NewRecorder()
+	c.ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+	var resp readyzResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	return w.Code, resp
+}
+
+func TestChecker(t *testing.T) {
+	var postgresErr error
+	c := NewChecker(time.Minute, time.Second,
+		Check{Name: "etcd", Check: func(context.Context) (string, error) { return "", nil }},
+		Check{Name: "postgres", Check: func(context.Context) (string, error) { return "", postgresErr }},
+		Check{Name: "slow", Check: func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", errors.EnsureStack(ctx.Err())
+		}},
+	)
+	c.timeout = 10 * time.Millisecond
+	s := health.NewServer()
+	c.AddServer(s)
+
+	// nothing is ready until the checks have run
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, servingStatus(t, s, ""))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, servingStatus(t, s, "etcd"))
+	code, resp := readyz(t, c)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "starting", resp.Status)
+
+	results := c.CheckNow(context.Background())
+	require.Equal(t, 3, len(results))
+	require.True(t, results[0].Healthy)
+	require.True(t, results[1].Healthy)
+	require.False(t, results[2].Healthy)
+	require.Matches(t, "deadline exceeded", results[2].Error)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, servingStatus(t, s, ""))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, servingStatus(t, s, "etcd"))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, servingStatus(t, s, "slow"))
+
+	c.checks = c.checks[:2]
+	postgresErr = errors.New("connection refused")
+	c.CheckNow(context.Background())
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, servingStatus(t, s, "postgres"))
+	code, resp = readyz(t, c)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "unavailable", resp.Status)
+	require.Equal(t, "connection refused", resp.Checks[1].Error)
+
+	postgresErr = nil
+	c.CheckNow(context.Background())
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, servingStatus(t, s, ""))
+	code, resp = readyz(t, c)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "ok", resp.Status)
+	require.Equal(t, 2, len(resp.Checks))
+}
+
+type testAuthServer struct {
+	auth.APIServer
+	err error
+}
+
+func (s *testAuthServer) WhoAmI(context.Context, *auth.WhoAmIRequest) (*auth.WhoAmIResponse, error) {
+	return nil, s.err
+}
+
+func TestAuthCheck(t *testing.T) {
+	var server auth.APIServer
+	check := Auth(func() auth.APIServer { return server })
+	_, err := check.Check(context.Background())
+	require.YesError(t, err)
+
+	for _, test := range []struct {
+		err     error
+		detail  string
+		healthy bool
+	}{
+		{auth.ErrNotActivated, "not activated", true},
+		{auth.ErrNoMetadata, "activated", true},
+		{errors.New("database is down"), "", false},
+	} {
+		server = &testAuthServer{err: test.err}
+		detail, err := check.Check(context.Background())
+		require.Equal(t, test.detail, detail)
+		require.Equal(t, test.healthy, err == nil)
+	}
+}
@@ -0,0 +1,186 @@
+// Package imagepolicy enforces the policy that cluster administrators set on
+// the images pipelines may run: that they're pinned by digest, that they come
+// from approved registries, and that a vulnerability scanner, called through
+// a webhook, accepts them.
+package imagepolicy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// DefaultScanTimeout is how long the scan webhook has to respond if the
+// policy doesn't set a timeout.
+const DefaultScanTimeout = 30 * time.Second
+
+// dockerHub is the registry of images that don't name one.
+const dockerHub = "docker.io"
+
+var digestRE = regexp.MustCompile(`@sha256:[0-9a-f]{64}$`)
+
+// Policy is the policy on the images pipelines may run. The zero Policy
+// allows every image.
+type Policy struct {
+	// RequireDigest requires images to be pinned by digest, such as
+	// "alpine@sha256:...", so that the image can't change after it's
+	// approved.
+	RequireDigest bool
+	// AllowedRegistries are the registries images may come from, such as
+	// "docker.io" or "gcr.io/my-project". Images may come from any registry
+	// if it's empty.
+	AllowedRegistries []string
+	// ScanWebhookURL, if set, receives a POST with a ScanRequest for each
+	// image, and must respond with a ScanResponse that allows it.
+	ScanWebhookURL string
+	ScanTimeout    time.Duration
+}
+
+// ScanRequest is the body of the requests sent to the scan webhook.
+type ScanRequest struct {
+	Image    string `json:"image"`
+	Pipeline string `json:"pipeline"`
+}
+
+// ScanResponse is the body of the scan webhook's responses. Reason explains
+// why an image isn't allowed, such as the vulnerabilities it has.
+type ScanResponse struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason"`
+}
+
+// ViolationError is returned for images that violate the policy.
+type ViolationError struct {
+	Image  string
+	Reason string
+}
+
+func (e *ViolationError) Error() string {
+	return fmt.Sprintf("image %q violates the cluster's image policy: %s", e.Image, e.Reason)
+}
+
+// IsViolation returns true if err is caused by an image violating the policy.
+func IsViolation(err error) bool {
+	var vErr *ViolationError
+	return errors.As(err, &vErr)
+}
+
+// Enabled returns whether the policy restricts images at all.
+func (p *Policy) Enabled() bool {
+	return p.RequireDigest || len(p.AllowedRegistries) > 0 || p.ScanWebhookURL != ""
+}
+
+// Check checks that the images of pipeline satisfy the policy. It returns a
+// ViolationError for the first image that doesn't, and other errors if the
+// scan webhook can't be called.
+func (p *Policy) Check(ctx context.Context, pipeline string, images []string) error {
+	for _, image := range images {
+		if p.RequireDigest && !digestRE.MatchString(image) {
+			return &ViolationError{Image: image, Reason: "images must be pinned by digest (image@sha256:<digest>)"}
+		}
+		if len(p.AllowedRegistries) > 0 && !p.registryAllowed(image) {
+			return &ViolationError{
+				Image:  image,
+				Reason: fmt.Sprintf("registry %q isn't one of the allowed registries (%s)", Registry(image), strings.Join(p.AllowedRegistries, ", ")),
+			}
+		}
+	}
+	if p.ScanWebhookURL == "" {
+		return nil
+	}
+	for _, image := range images {
+		if err := p.scan(ctx, pipeline, image); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Policy) registryAllowed(image string) bool {
+	// compare against the image's name, without its tag or digest, so that
+	// allowed registries can include a path, such as gcr.io/my-project
+	name := Registry(image) + "/" + repository(image)
+	for _, allowed := range p.AllowedRegistries {
+		allowed = strings.TrimSuffix(allowed, "/")
+		if name == allowed || strings.HasPrefix(name, allowed+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) scan(ctx context.Context, pipeline, image string) error {
+	body, err := json.Marshal(&ScanRequest{Image: image, Pipeline: pipeline})
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	timeout := p.ScanTimeout
+	if timeout == 0 {
+		timeout = DefaultScanTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.ScanWebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "could not scan image %q", image)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("could not scan image %q: scan webhook returned status %v", image, resp.Status)
+	}
+	var scan ScanResponse
+	if err := json.NewDecoder(resp.Body).Decode(&scan); err != nil {
+		return errors.Wrapf(err, "could not decode the scan of image %q", image)
+	}
+	if !scan.Allowed {
+		reason := scan.Reason
+		if reason == "" {
+			reason = "rejected by the vulnerability scan"
+		}
+		return &ViolationError{Image: image, Reason: reason}
+	}
+	return nil
+}
+
+// Registry returns the registry of image, following Docker's rules: the first
+// component of the image's name is its registry if it contains a "." or a ":",
+// or is "localhost", and otherwise the image is from Docker Hub.
+func Registry(image string) string {
+	if i := strings.Index(image, "/"); i >= 0 {
+		first := image[:i]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			return first
+		}
+	}
+	return dockerHub
+}
+
+// repository returns the name of image without its registry, tag or digest.
+func repository(image string) string {
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	registry := Registry(image)
+	name = strings.TrimPrefix(name, registry+"/")
+	if registry == dockerHub && !strings.Contains(name, "/") {
+		// official Docker Hub images are in the library namespace
+		name = "library/" + name
+	}
+	return name
+}
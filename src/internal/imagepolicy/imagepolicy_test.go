@@ -0,0 +1,63 @@
+package imagepolicy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+const testDigest = "@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestRegistry(t *testing.T) {
+	require.Equal(t, "docker.io", Registry("alpine"))
+	require.Equal(t, "docker.io", Registry("pachyderm/opencv:1.0"))
+	require.Equal(t, "gcr.io", Registry("gcr.io/project/image"+testDigest))
+	require.Equal(t, "localhost:5000", Registry("localhost:5000/image"))
+	require.Equal(t, "library/alpine", repository("docker.io/alpine:3"))
+	require.Equal(t, "project/image", repository("gcr.io/project/image"+testDigest))
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, (&Policy{}).Check(ctx, "p", []string{"alpine"}))
+
+	digest := &Policy{RequireDigest: true}
+	require.True(t, IsViolation(digest.Check(ctx, "p", []string{"alpine:3"})))
+	require.NoError(t, digest.Check(ctx, "p", []string{"alpine" + testDigest}))
+
+	registries := &Policy{AllowedRegistries: []string{"docker.io/library", "gcr.io/project/"}}
+	require.NoError(t, registries.Check(ctx, "p", []string{"alpine", "gcr.io/project/image:1"}))
+	require.True(t, IsViolation(registries.Check(ctx, "p", []string{"pachyderm/opencv"})))
+	require.True(t, IsViolation(registries.Check(ctx, "p", []string{"gcr.io/project-2/image"})))
+}
+
+func TestScanWebhook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ScanRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "p", req.Pipeline)
+		resp := ScanResponse{Allowed: !strings.Contains(req.Image, "vulnerable")}
+		if !resp.Allowed {
+			resp.Reason = "CVE-2021-44228"
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(&resp))
+	}))
+	defer server.Close()
+	policy := &Policy{ScanWebhookURL: server.URL}
+	ctx := context.Background()
+	require.NoError(t, policy.Check(ctx, "p", []string{"alpine"}))
+	err := policy.Check(ctx, "p", []string{"alpine", "vulnerable"})
+	require.True(t, IsViolation(err))
+	require.Matches(t, "CVE-2021-44228", err.Error())
+
+	// the policy fails closed if the scanner can't be reached
+	server.Close()
+	err = policy.Check(ctx, "p", []string{"alpine"})
+	require.YesError(t, err)
+	require.False(t, IsViolation(err))
+}
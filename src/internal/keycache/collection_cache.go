@@ -0,0 +1,127 @@
+package keycache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/hashicorp/golang-lru/simplelru"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/watch"
+)
+
+// CollectionCache is a read-through cache of the items in a postgres
+// collection. An item is read from the collection the first time it's
+// requested, and evicted when a watch on the collection sees it change, so a
+// cached item may be stale for as long as the change's notification takes to
+// arrive. This is useful for frequently read but infrequently updated items
+// whose reads would otherwise dominate the database's load.
+type CollectionCache struct {
+	ctx        context.Context
+	collection col.PostgresCollection
+	template   proto.Message
+
+	mu    sync.Mutex
+	items *simplelru.LRU
+	// watching is set while the watch is running. Items are only cached while
+	// it is, since nothing would evict them otherwise.
+	watching bool
+	// gen is incremented whenever items are evicted, so that a read that
+	// races with an eviction doesn't cache what it read.
+	gen uint64
+}
+
+// NewCollectionCache returns a cache of up to size items of the collection.
+// If size isn't positive, nothing is cached, and every read goes to the
+// collection.
+func NewCollectionCache(ctx context.Context, collection col.PostgresCollection, template proto.Message, size int) *CollectionCache {
+	c := &CollectionCache{
+		ctx:        ctx,
+		collection: collection,
+		template:   template,
+	}
+	if size > 0 {
+		// simplelru.NewLRU only errors for size < 1
+		c.items, _ = simplelru.NewLRU(size, nil)
+	}
+	return c
+}
+
+// Get reads the item with the given key into val, from the cache if it's
+// there and from the collection otherwise.
+func (c *CollectionCache) Get(ctx context.Context, key string, val proto.Message) error {
+	c.mu.Lock()
+	if c.items != nil {
+		if cached, ok := c.items.Get(key); ok {
+			c.mu.Unlock()
+			val.Reset()
+			proto.Merge(val, cached.(proto.Message))
+			return nil
+		}
+	}
+	gen := c.gen
+	c.mu.Unlock()
+	if err := c.collection.ReadOnly(ctx).Get(key, val); err != nil {
+		return errors.EnsureStack(err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.watching && c.gen == gen {
+		c.items.Add(key, proto.Clone(val))
+	}
+	return nil
+}
+
+// Watch should be called in a goroutine to start the watch that evicts items
+// from the cache when they change.
+func (c *CollectionCache) Watch() {
+	if c.items == nil {
+		return
+	}
+	backoff.RetryNotify(func() error {
+		watcher, err := c.collection.ReadOnly(c.ctx).Watch()
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		defer watcher.Close()
+		// The watcher receives every change made after it's created, so items
+		// read from here on are evicted when they change
+		c.setWatching(true)
+		defer c.setWatching(false)
+		for {
+			select {
+			case ev, ok := <-watcher.Watch():
+				if !ok {
+					return errors.New("watch closed unexpectedly")
+				}
+				if ev.Type == watch.EventError {
+					return ev.Err
+				}
+				c.evict(string(ev.Key))
+			case <-c.ctx.Done():
+				return errors.EnsureStack(c.ctx.Err())
+			}
+		}
+	}, backoff.NewInfiniteBackOff(), backoff.NotifyCtx(c.ctx, fmt.Sprintf("cache of %T", c.template)))
+}
+
+func (c *CollectionCache) evict(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items.Remove(key)
+	c.gen++
+}
+
+// setWatching records whether the watch is running, and empties the cache,
+// since changes made while it wasn't weren't evicted.
+func (c *CollectionCache) setWatching(watching bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.watching = watching
+	c.items.Purge()
+	c.gen++
+}
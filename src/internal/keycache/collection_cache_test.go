@@ -0,0 +1,96 @@
+package keycache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dbutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dockertestenv"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/keycache"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func newTestCollection(ctx context.Context, t *testing.T) (*pachsql.DB, col.PostgresCollection) {
+	options := dockertestenv.NewTestDirectDBOptions(t)
+	db, err := dbutil.NewDB(options...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	listener := col.NewPostgresListener(dbutil.GetDSN(options...))
+	t.Cleanup(func() {
+		require.NoError(t, listener.Close())
+	})
+	testCol := col.NewPostgresCollection("test_items", db, listener, &col.TestItem{}, nil)
+	require.NoError(t, dbutil.WithTx(ctx, db, func(tx *pachsql.Tx) error {
+		if err := col.CreatePostgresSchema(ctx, tx); err != nil {
+			return err
+		}
+		if err := col.SetupPostgresV0(ctx, tx); err != nil {
+			return err
+		}
+		return col.SetupPostgresCollections(ctx, tx, testCol)
+	}))
+	return db, testCol
+}
+
+func putItem(ctx context.Context, t *testing.T, db *pachsql.DB, testCol col.PostgresCollection, id, value string) {
+	require.NoError(t, dbutil.WithTx(ctx, db, func(tx *pachsql.Tx) error {
+		return errors.EnsureStack(testCol.ReadWrite(tx).Put(id, &col.TestItem{ID: id, Value: value}))
+	}))
+}
+
+func requireValue(ctx context.Context, t *testing.T, cache *keycache.CollectionCache, id, value string) {
+	require.NoErrorWithinTRetry(t, 30*time.Second, func() error {
+		item := &col.TestItem{}
+		if err := cache.Get(ctx, id, item); err != nil {
+			return err
+		}
+		if item.Value != value {
+			return errors.Errorf("expected %q, got %q", value, item.Value)
+		}
+		return nil
+	})
+}
+
+func TestCollectionCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db, testCol := newTestCollection(ctx, t)
+	cache := keycache.NewCollectionCache(ctx, testCol, &col.TestItem{}, 10)
+	go cache.Watch()
+
+	putItem(ctx, t, db, testCol, "a", "1")
+	requireValue(ctx, t, cache, "a", "1")
+	// updates evict the cached item
+	putItem(ctx, t, db, testCol, "a", "2")
+	requireValue(ctx, t, cache, "a", "2")
+	// as do deletes
+	require.NoError(t, dbutil.WithTx(ctx, db, func(tx *pachsql.Tx) error {
+		return errors.EnsureStack(testCol.ReadWrite(tx).Delete("a"))
+	}))
+	require.NoErrorWithinTRetry(t, 30*time.Second, func() error {
+		if err := cache.Get(ctx, "a", &col.TestItem{}); !col.IsErrNotFound(err) {
+			return errors.Errorf("expected a not found error, got %v", err)
+		}
+		return nil
+	})
+}
+
+func TestCollectionCacheDisabled(t *testing.T) {
+	ctx := context.Background()
+	db, testCol := newTestCollection(ctx, t)
+	// a cache of size 0 reads every item from the collection
+	cache := keycache.NewCollectionCache(ctx, testCol, &col.TestItem{}, 0)
+	go cache.Watch()
+	for _, value := range []string{"1", "2"} {
+		putItem(ctx, t, db, testCol, "a", value)
+		item := &col.TestItem{}
+		require.NoError(t, cache.Get(ctx, "a", item))
+		require.Equal(t, value, item.Value)
+	}
+}
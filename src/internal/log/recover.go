@@ -0,0 +1,43 @@
+package log
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// WithStack adds the stack trace of the calling goroutine to entry, under the
+// "stack" field.
+func WithStack(entry *logrus.Entry) *logrus.Entry {
+	return entry.WithField("stack", string(debug.Stack()))
+}
+
+// Recover reports a panic in the calling goroutine. It must be deferred
+// directly, e.g.:
+// ```
+// defer log.Recover("pps.master", &retErr)
+// ```
+// The panic is logged along with its stack trace and `subsystem`. If `errp`
+// is non-nil, the panic is then converted into an error and stored in
+// `*errp`, so the function returns normally; otherwise the panic continues
+// once it has been logged.
+func Recover(subsystem string, errp *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	WithStack(logrus.WithFields(logrus.Fields{
+		"subsystem": subsystem,
+		"panic":     fmt.Sprint(r),
+	})).Errorf("panic in %s: %v", subsystem, r)
+	if errp == nil {
+		panic(r)
+	}
+	if err, ok := r.(error); ok {
+		*errp = errors.Wrapf(err, "panic in %s", subsystem)
+	} else {
+		*errp = errors.Errorf("panic in %s: %v", subsystem, r)
+	}
+}
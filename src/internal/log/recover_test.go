@@ -0,0 +1,34 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestRecover(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	f := func(r interface{}) (retErr error) {
+		defer Recover("test", &retErr)
+		if r != nil {
+			panic(r)
+		}
+		return nil
+	}
+	require.NoError(t, f(nil))
+	err := f("boom")
+	require.YesError(t, err)
+	require.Matches(t, "panic in test: boom", err.Error())
+	err = f(sentinel)
+	require.True(t, errors.Is(err, sentinel))
+
+	// without an error to store the panic in, it continues
+	defer func() {
+		require.Equal(t, "boom", recover())
+	}()
+	func() {
+		defer Recover("test", nil)
+		panic("boom")
+	}()
+}
@@ -22,7 +22,26 @@ var authHandlers = map[string]authHandler{
 	//
 
 	// Allow InspectCluster to succeed before a user logs in
-	"/admin_v2.API/InspectCluster": unauthenticated,
+	"/admin_v2.API/InspectCluster":        unauthenticated,
+	"/admin_v2.API/CreateWebhook":         authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_MODIFY_WEBHOOKS)),
+	"/admin_v2.API/InspectWebhook":        authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_LIST_WEBHOOKS)),
+	"/admin_v2.API/ListWebhook":           authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_LIST_WEBHOOKS)),
+	"/admin_v2.API/DeleteWebhook":         authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_MODIFY_WEBHOOKS)),
+	"/admin_v2.API/ListInflightRequests":  authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_LIST_REQUESTS)),
+	"/admin_v2.API/CancelInflightRequest": authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_CANCEL_REQUESTS)),
+	"/admin_v2.API/GetClusterDefaults":    authDisabledOr(authenticated),
+	"/admin_v2.API/SetClusterDefaults":    authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_SET_DEFAULTS)),
+	"/admin_v2.API/GetUsageReport":        authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_GET_USAGE_REPORT)),
+	"/admin_v2.API/GetCapacityReport":     authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_GET_CAPACITY_REPORT)),
+	"/admin_v2.API/SubscribeEvents":       authDisabledOr(authenticated),
+	"/admin_v2.API/ValidateConfiguration": authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_VALIDATE_CONFIGURATION)),
+	"/admin_v2.API/InspectUpgrade":        unauthenticated,
+	"/admin_v2.API/Backup":                authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_BACKUP)),
+	"/admin_v2.API/ListBackups":           authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_BACKUP)),
+	"/admin_v2.API/VerifyBackup":          authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_BACKUP)),
+	"/admin_v2.API/Restore":               authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_RESTORE)),
+	"/admin_v2.API/SetReadOnly":           authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_SET_READ_ONLY)),
+	"/admin_v2.API/InspectReadOnly":       unauthenticated,
 
 	//
 	// Auth API
@@ -44,6 +63,14 @@ var authHandlers = map[string]authHandler{
 	"/auth_v2.API/GetGroups":             authenticated,
 	"/auth_v2.API/GetPermissions":        authenticated,
 	"/auth_v2.API/GetRolesForPermission": authenticated,
+	// Only principals that can modify the resource's role bindings can review
+	// role requests, which ReviewRoleRequest checks itself.
+	"/auth_v2.API/RequestRole":       authenticated,
+	"/auth_v2.API/ListRoleRequests":  authenticated,
+	"/auth_v2.API/ReviewRoleRequest": authenticated,
+	// Anyone can check whether the ACLs are frozen, to find out why their
+	// changes are rejected.
+	"/auth_v2.API/GetACLFreeze": authenticated,
 
 	"/auth_v2.API/GetGroupsForPrincipal":      clusterPermissions(auth.Permission_CLUSTER_AUTH_GET_GROUPS),
 	"/auth_v2.API/GetPermissionsForPrincipal": clusterPermissions(auth.Permission_CLUSTER_AUTH_GET_PERMISSIONS_FOR_PRINCIPAL),
@@ -59,14 +86,17 @@ var authHandlers = map[string]authHandler{
 	"/auth_v2.API/DeleteExpiredAuthTokens":    clusterPermissions(auth.Permission_CLUSTER_AUTH_DELETE_EXPIRED_TOKENS),
 	"/auth_v2.API/RevokeAuthTokensForUser":    clusterPermissions(auth.Permission_CLUSTER_AUTH_REVOKE_USER_TOKENS),
 	"/auth_v2.API/RotateRootToken":            clusterPermissions(auth.Permission_CLUSTER_AUTH_ROTATE_ROOT_TOKEN),
+	"/auth_v2.API/FreezeACLs":                 clusterPermissions(auth.Permission_CLUSTER_AUTH_FREEZE_ACLS),
+	"/auth_v2.API/UnfreezeACLs":               clusterPermissions(auth.Permission_CLUSTER_AUTH_FREEZE_ACLS),
 
 	//
 	// Debug API
 	//
 
-	"/debug_v2.Debug/Profile": authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_DEBUG_DUMP)),
-	"/debug_v2.Debug/Binary":  authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_DEBUG_DUMP)),
-	"/debug_v2.Debug/Dump":    authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_DEBUG_DUMP)),
+	"/debug_v2.Debug/Profile":           authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_DEBUG_DUMP)),
+	"/debug_v2.Debug/Binary":            authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_DEBUG_DUMP)),
+	"/debug_v2.Debug/Dump":              authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_DEBUG_DUMP)),
+	"/debug_v2.Debug/GetProfileHistory": authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_DEBUG_DUMP)),
 
 	//
 	// Enterprise API
@@ -93,6 +123,7 @@ var authHandlers = map[string]authHandler{
 	"/identity_v2.API/SetIdentityServerConfig": clusterPermissions(auth.Permission_CLUSTER_IDENTITY_SET_CONFIG),
 	"/identity_v2.API/GetIdentityServerConfig": clusterPermissions(auth.Permission_CLUSTER_IDENTITY_GET_CONFIG),
 	"/identity_v2.API/CreateIDPConnector":      clusterPermissions(auth.Permission_CLUSTER_IDENTITY_CREATE_IDP),
+	"/identity_v2.API/CheckIdentityProvider":   clusterPermissions(auth.Permission_CLUSTER_IDENTITY_CREATE_IDP),
 	"/identity_v2.API/UpdateIDPConnector":      clusterPermissions(auth.Permission_CLUSTER_IDENTITY_UPDATE_IDP),
 	"/identity_v2.API/ListIDPConnectors":       clusterPermissions(auth.Permission_CLUSTER_IDENTITY_LIST_IDPS),
 	"/identity_v2.API/GetIDPConnector":         clusterPermissions(auth.Permission_CLUSTER_IDENTITY_GET_IDP),
@@ -123,51 +154,84 @@ var authHandlers = map[string]authHandler{
 	//
 
 	// TODO: Add methods to handle repo permissions
-	"/pfs_v2.API/ActivateAuth":     clusterPermissions(auth.Permission_CLUSTER_AUTH_ACTIVATE),
-	"/pfs_v2.API/CreateRepo":       authDisabledOr(authenticated),
-	"/pfs_v2.API/InspectRepo":      authDisabledOr(authenticated),
-	"/pfs_v2.API/ListRepo":         authDisabledOr(authenticated),
-	"/pfs_v2.API/DeleteRepo":       authDisabledOr(authenticated),
-	"/pfs_v2.API/StartCommit":      authDisabledOr(authenticated),
-	"/pfs_v2.API/FinishCommit":     authDisabledOr(authenticated),
-	"/pfs_v2.API/InspectCommit":    authDisabledOr(authenticated),
-	"/pfs_v2.API/ListCommit":       authDisabledOr(authenticated),
-	"/pfs_v2.API/SubscribeCommit":  authDisabledOr(authenticated),
-	"/pfs_v2.API/ClearCommit":      authDisabledOr(authenticated),
-	"/pfs_v2.API/InspectCommitSet": authDisabledOr(authenticated),
-	"/pfs_v2.API/ListCommitSet":    authDisabledOr(authenticated),
-	"/pfs_v2.API/SquashCommitSet":  authDisabledOr(authenticated),
-	"/pfs_v2.API/DropCommitSet":    authDisabledOr(authenticated),
-	"/pfs_v2.API/CreateBranch":     authDisabledOr(authenticated),
-	"/pfs_v2.API/InspectBranch":    authDisabledOr(authenticated),
-	"/pfs_v2.API/ListBranch":       authDisabledOr(authenticated),
-	"/pfs_v2.API/DeleteBranch":     authDisabledOr(authenticated),
-	"/pfs_v2.API/ModifyFile":       authDisabledOr(authenticated),
-	"/pfs_v2.API/GetFile":          authDisabledOr(authenticated),
+	"/pfs_v2.API/ActivateAuth":        clusterPermissions(auth.Permission_CLUSTER_AUTH_ACTIVATE),
+	"/pfs_v2.API/CreateRepo":          authDisabledOr(authenticated),
+	"/pfs_v2.API/InspectRepo":         authDisabledOr(authenticated),
+	"/pfs_v2.API/ListRepo":            authDisabledOr(authenticated),
+	"/pfs_v2.API/DeleteRepo":          authDisabledOr(authenticated),
+	"/pfs_v2.API/CordonRepo":          authDisabledOr(authenticated),
+	"/pfs_v2.API/UncordonRepo":        authDisabledOr(authenticated),
+	"/pfs_v2.API/StartCommit":         authDisabledOr(authenticated),
+	"/pfs_v2.API/FinishCommit":        authDisabledOr(authenticated),
+	"/pfs_v2.API/InspectCommit":       authDisabledOr(authenticated),
+	"/pfs_v2.API/ListCommit":          authDisabledOr(authenticated),
+	"/pfs_v2.API/SubscribeCommit":     authDisabledOr(authenticated),
+	"/pfs_v2.API/ClearCommit":         authDisabledOr(authenticated),
+	"/pfs_v2.API/CheckpointCommit":    authDisabledOr(authenticated),
+	"/pfs_v2.API/SubscribeCheckpoint": authDisabledOr(authenticated),
+	"/pfs_v2.API/InspectCommitSet":    authDisabledOr(authenticated),
+	"/pfs_v2.API/ListCommitSet":       authDisabledOr(authenticated),
+	"/pfs_v2.API/SquashCommitSet":     authDisabledOr(authenticated),
+	"/pfs_v2.API/DropCommitSet":       authDisabledOr(authenticated),
+	"/pfs_v2.API/CreateBranch":        authDisabledOr(authenticated),
+	"/pfs_v2.API/InspectBranch":       authDisabledOr(authenticated),
+	"/pfs_v2.API/ListBranch":          authDisabledOr(authenticated),
+	"/pfs_v2.API/DeleteBranch":        authDisabledOr(authenticated),
+	"/pfs_v2.API/ModifyFile":          authDisabledOr(authenticated),
+	"/pfs_v2.API/GetFile":             authDisabledOr(authenticated),
 	// TODO: GetFileTAR is unauthenticated for performance reasons. Normal authentication
 	// will be applied internally when a commit is used. When a file set id is used, we lean
 	// on the capability based authentication of file sets.
-	"/pfs_v2.API/GetFileTAR":         unauthenticated,
-	"/pfs_v2.API/InspectFile":        authDisabledOr(authenticated),
-	"/pfs_v2.API/ListFile":           authDisabledOr(authenticated),
-	"/pfs_v2.API/WalkFile":           authDisabledOr(authenticated),
-	"/pfs_v2.API/GlobFile":           authDisabledOr(authenticated),
-	"/pfs_v2.API/DiffFile":           authDisabledOr(authenticated),
-	"/pfs_v2.API/DeleteAll":          authDisabledOr(authenticated),
-	"/pfs_v2.API/Fsck":               authDisabledOr(authenticated),
-	"/pfs_v2.API/CreateFileSet":      authDisabledOr(authenticated),
-	"/pfs_v2.API/GetFileSet":         authDisabledOr(authenticated),
-	"/pfs_v2.API/AddFileSet":         authDisabledOr(authenticated),
-	"/pfs_v2.API/RenewFileSet":       authDisabledOr(authenticated),
-	"/pfs_v2.API/ComposeFileSet":     authDisabledOr(authenticated),
-	"/pfs_v2.API/CheckStorage":       authDisabledOr(authenticated),
-	"/pfs_v2.API/PutCache":           authDisabledOr(authenticated),
-	"/pfs_v2.API/GetCache":           authDisabledOr(authenticated),
-	"/pfs_v2.API/ClearCache":         authDisabledOr(authenticated),
-	"/pfs_v2.API/RunLoadTest":        authDisabledOr(authenticated),
-	"/pfs_v2.API/RunLoadTestDefault": authDisabledOr(authenticated),
-	"/pfs_v2.API/ListTask":           authDisabledOr(authenticated),
-	"/pfs_v2.API/Egress":             authDisabledOr(authenticated),
+	"/pfs_v2.API/GetFileTAR":              unauthenticated,
+	"/pfs_v2.API/InspectFile":             authDisabledOr(authenticated),
+	"/pfs_v2.API/ListFile":                authDisabledOr(authenticated),
+	"/pfs_v2.API/WalkFile":                authDisabledOr(authenticated),
+	"/pfs_v2.API/GlobFile":                authDisabledOr(authenticated),
+	"/pfs_v2.API/DiffFile":                authDisabledOr(authenticated),
+	"/pfs_v2.API/DeleteAll":               authDisabledOr(authenticated),
+	"/pfs_v2.API/Fsck":                    authDisabledOr(authenticated),
+	"/pfs_v2.API/CreateFileSet":           authDisabledOr(authenticated),
+	"/pfs_v2.API/GetFileSet":              authDisabledOr(authenticated),
+	"/pfs_v2.API/AddFileSet":              authDisabledOr(authenticated),
+	"/pfs_v2.API/RenewFileSet":            authDisabledOr(authenticated),
+	"/pfs_v2.API/ComposeFileSet":          authDisabledOr(authenticated),
+	"/pfs_v2.API/CheckStorage":            authDisabledOr(authenticated),
+	"/pfs_v2.API/PutCache":                authDisabledOr(authenticated),
+	"/pfs_v2.API/GetCache":                authDisabledOr(authenticated),
+	"/pfs_v2.API/ClearCache":              authDisabledOr(authenticated),
+	"/pfs_v2.API/InspectCache":            authDisabledOr(authenticated),
+	"/pfs_v2.API/SetSchema":               authDisabledOr(authenticated),
+	"/pfs_v2.API/ListSchema":              authDisabledOr(authenticated),
+	"/pfs_v2.API/DeleteSchema":            authDisabledOr(authenticated),
+	"/pfs_v2.API/InspectCommitSchema":     authDisabledOr(authenticated),
+	"/pfs_v2.API/SetRetentionPolicy":      authDisabledOr(authenticated),
+	"/pfs_v2.API/ListRetentionPolicy":     authDisabledOr(authenticated),
+	"/pfs_v2.API/DeleteRetentionPolicy":   authDisabledOr(authenticated),
+	"/pfs_v2.API/ApplyRetentionPolicy":    authDisabledOr(authenticated),
+	"/pfs_v2.API/EraseFile":               authDisabledOr(authenticated),
+	"/pfs_v2.API/ListErasure":             authDisabledOr(authenticated),
+	"/pfs_v2.API/VerifyErasure":           authDisabledOr(authenticated),
+	"/pfs_v2.API/SetValidationPolicy":     authDisabledOr(authenticated),
+	"/pfs_v2.API/InspectValidationPolicy": authDisabledOr(authenticated),
+	"/pfs_v2.API/DeleteValidationPolicy":  authDisabledOr(authenticated),
+	"/pfs_v2.API/CreateView":              authDisabledOr(authenticated),
+	"/pfs_v2.API/InspectView":             authDisabledOr(authenticated),
+	"/pfs_v2.API/ListView":                authDisabledOr(authenticated),
+	"/pfs_v2.API/DeleteView":              authDisabledOr(authenticated),
+	"/pfs_v2.API/StartUpload":             authDisabledOr(authenticated),
+	"/pfs_v2.API/UploadBlock":             authDisabledOr(authenticated),
+	"/pfs_v2.API/InspectUpload":           authDisabledOr(authenticated),
+	"/pfs_v2.API/FinishUpload":            authDisabledOr(authenticated),
+	"/pfs_v2.API/DeleteUpload":            authDisabledOr(authenticated),
+	"/pfs_v2.API/PreviewFile":             authDisabledOr(authenticated),
+	"/pfs_v2.API/CreateProject":           authDisabledOr(authenticated),
+	"/pfs_v2.API/InspectProject":          authDisabledOr(authenticated),
+	"/pfs_v2.API/ListProject":             authDisabledOr(authenticated),
+	"/pfs_v2.API/DeleteProject":           authDisabledOr(authenticated),
+	"/pfs_v2.API/RunLoadTest":             authDisabledOr(authenticated),
+	"/pfs_v2.API/RunLoadTestDefault":      authDisabledOr(authenticated),
+	"/pfs_v2.API/ListTask":                authDisabledOr(authenticated),
+	"/pfs_v2.API/Egress":                  authDisabledOr(authenticated),
 
 	//
 	// PPS API
@@ -175,36 +239,54 @@ var authHandlers = map[string]authHandler{
 
 	// TODO: Add per-repo permissions checks for these
 	// TODO: split GetLogs into master and not-master and add check for pipeline permissions
-	"/pps_v2.API/InspectJob":      authDisabledOr(authenticated),
-	"/pps_v2.API/ListJob":         authDisabledOr(authenticated),
-	"/pps_v2.API/ListJobStream":   authDisabledOr(authenticated),
-	"/pps_v2.API/SubscribeJob":    authDisabledOr(authenticated),
-	"/pps_v2.API/DeleteJob":       authDisabledOr(authenticated),
-	"/pps_v2.API/StopJob":         authDisabledOr(authenticated),
-	"/pps_v2.API/InspectJobSet":   authDisabledOr(authenticated),
-	"/pps_v2.API/ListJobSet":      authDisabledOr(authenticated),
-	"/pps_v2.API/InspectDatum":    authDisabledOr(authenticated),
-	"/pps_v2.API/ListDatum":       authDisabledOr(authenticated),
-	"/pps_v2.API/ListDatumStream": authDisabledOr(authenticated),
-	"/pps_v2.API/RestartDatum":    authDisabledOr(authenticated),
-	"/pps_v2.API/CreatePipeline":  authDisabledOr(authenticated),
-	"/pps_v2.API/InspectPipeline": authDisabledOr(authenticated),
-	"/pps_v2.API/DeletePipeline":  authDisabledOr(authenticated),
-	"/pps_v2.API/StartPipeline":   authDisabledOr(authenticated),
-	"/pps_v2.API/StopPipeline":    authDisabledOr(authenticated),
-	"/pps_v2.API/RunPipeline":     authDisabledOr(authenticated),
-	"/pps_v2.API/RunCron":         authDisabledOr(authenticated),
-	"/pps_v2.API/GetLogs":         authDisabledOr(authenticated),
-	"/pps_v2.API/GarbageCollect":  authDisabledOr(authenticated),
-	"/pps_v2.API/UpdateJobState":  authDisabledOr(authenticated),
-	"/pps_v2.API/ListPipeline":    authDisabledOr(authenticated),
-	"/pps_v2.API/ActivateAuth":    clusterPermissions(auth.Permission_CLUSTER_AUTH_ACTIVATE),
-	"/pps_v2.API/DeleteAll":       authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_DELETE_ALL)),
-
-	"/pps_v2.API/CreateSecret":       authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_CREATE_SECRET)),
-	"/pps_v2.API/ListSecret":         authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_LIST_SECRETS)),
-	"/pps_v2.API/DeleteSecret":       authDisabledOr(clusterPermissions(auth.Permission_SECRET_DELETE)),
-	"/pps_v2.API/InspectSecret":      authDisabledOr(clusterPermissions(auth.Permission_SECRET_INSPECT)),
+	"/pps_v2.API/InspectJob":               authDisabledOr(authenticated),
+	"/pps_v2.API/ListJob":                  authDisabledOr(authenticated),
+	"/pps_v2.API/ListJobStream":            authDisabledOr(authenticated),
+	"/pps_v2.API/SubscribeJob":             authDisabledOr(authenticated),
+	"/pps_v2.API/DeleteJob":                authDisabledOr(authenticated),
+	"/pps_v2.API/StopJob":                  authDisabledOr(authenticated),
+	"/pps_v2.API/InspectJobSet":            authDisabledOr(authenticated),
+	"/pps_v2.API/ListJobSet":               authDisabledOr(authenticated),
+	"/pps_v2.API/InspectDatum":             authDisabledOr(authenticated),
+	"/pps_v2.API/ListDatum":                authDisabledOr(authenticated),
+	"/pps_v2.API/ListDatumStream":          authDisabledOr(authenticated),
+	"/pps_v2.API/CountDatums":              authDisabledOr(authenticated),
+	"/pps_v2.API/RestartDatum":             authDisabledOr(authenticated),
+	"/pps_v2.API/ListQuarantinedDatum":     authDisabledOr(authenticated),
+	"/pps_v2.API/RequeueQuarantinedDatums": authDisabledOr(authenticated),
+	"/pps_v2.API/InspectJobProfile":        authDisabledOr(authenticated),
+	"/pps_v2.API/InspectDatumCache":        authDisabledOr(authenticated),
+	"/pps_v2.API/ClearDatumCache":          authDisabledOr(authenticated),
+	"/pps_v2.API/ListDatumProvenance":      authDisabledOr(authenticated),
+	"/pps_v2.API/QueryLineage":             authDisabledOr(authenticated),
+	"/pps_v2.API/CreatePipeline":           authDisabledOr(authenticated),
+	"/pps_v2.API/PlanPipeline":             authDisabledOr(authenticated),
+	"/pps_v2.API/LintPipeline":             authDisabledOr(authenticated),
+	"/pps_v2.API/FinishCanary":             authDisabledOr(authenticated),
+	"/pps_v2.API/ListSLOStatus":            authDisabledOr(authenticated),
+	"/pps_v2.API/InspectPipeline":          authDisabledOr(authenticated),
+	"/pps_v2.API/DeletePipeline":           authDisabledOr(authenticated),
+	"/pps_v2.API/StartPipeline":            authDisabledOr(authenticated),
+	"/pps_v2.API/StopPipeline":             authDisabledOr(authenticated),
+	"/pps_v2.API/CordonPipeline":           authDisabledOr(authenticated),
+	"/pps_v2.API/UncordonPipeline":         authDisabledOr(authenticated),
+	"/pps_v2.API/RunPipeline":              authDisabledOr(authenticated),
+	"/pps_v2.API/RunCron":                  authDisabledOr(authenticated),
+	"/pps_v2.API/GetLogs":                  authDisabledOr(authenticated),
+	"/pps_v2.API/GetArchivedLogs":          authDisabledOr(authenticated),
+	"/pps_v2.API/GarbageCollect":           authDisabledOr(authenticated),
+	"/pps_v2.API/UpdateJobState":           authDisabledOr(authenticated),
+	"/pps_v2.API/ListPipeline":             authDisabledOr(authenticated),
+	"/pps_v2.API/GetDAG":                   authDisabledOr(authenticated),
+	"/pps_v2.API/ActivateAuth":             clusterPermissions(auth.Permission_CLUSTER_AUTH_ACTIVATE),
+	"/pps_v2.API/DeleteAll":                authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_DELETE_ALL)),
+
+	// Secrets scoped to a pipeline need permissions on its output repo rather
+	// than the cluster, which the secret RPCs check themselves.
+	"/pps_v2.API/CreateSecret":       authDisabledOr(authenticated),
+	"/pps_v2.API/ListSecret":         authDisabledOr(authenticated),
+	"/pps_v2.API/DeleteSecret":       authDisabledOr(authenticated),
+	"/pps_v2.API/InspectSecret":      authDisabledOr(authenticated),
 	"/pps_v2.API/RunLoadTest":        authDisabledOr(authenticated),
 	"/pps_v2.API/RunLoadTestDefault": authDisabledOr(authenticated),
 	"/pps_v2.API/RenderTemplate":     authDisabledOr(authenticated),
@@ -234,6 +316,13 @@ var authHandlers = map[string]authHandler{
 
 	// TODO: Only the pachd sidecar instances should be able to use this endpoint.
 	"/proxy.API/Listen": unauthenticated,
+
+	//
+	// Search API
+	//
+
+	// Search only returns results from repos the caller can read
+	"/search_v2.API/Search": authDisabledOr(authenticated),
 }
 
 // NewInterceptor instantiates a new Interceptor
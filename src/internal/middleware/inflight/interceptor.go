@@ -0,0 +1,195 @@
+// Package inflight tracks the RPCs a server is handling, so that operators
+// can find requests that have been running for too long and cancel them.
+package inflight
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	authmw "github.com/pachyderm/pachyderm/v2/src/internal/middleware/auth"
+)
+
+// Request is a snapshot of an in-flight request.
+type Request struct {
+	ID     string
+	Method string
+	// Caller is the subject that made the request, "" if auth is disabled.
+	Caller string
+	// Peer is the address the request came from.
+	Peer      string
+	Started   time.Time
+	Streaming bool
+	// The number of messages received and sent, and their size in bytes.
+	MessagesReceived, MessagesSent int64
+	BytesReceived, BytesSent       int64
+}
+
+// request is the state of an in-flight request. The counters are updated
+// atomically by the request's goroutine and read by List, and come first to
+// be 64-bit aligned.
+type request struct {
+	messagesReceived, messagesSent int64
+	bytesReceived, bytesSent       int64
+
+	id, method, caller, peer string
+	started                  time.Time
+	streaming                bool
+	cancel                   context.CancelFunc
+}
+
+func (r *request) received(m interface{}) {
+	atomic.AddInt64(&r.messagesReceived, 1)
+	atomic.AddInt64(&r.bytesReceived, int64(size(m)))
+}
+
+func (r *request) sent(m interface{}) {
+	atomic.AddInt64(&r.messagesSent, 1)
+	atomic.AddInt64(&r.bytesSent, int64(size(m)))
+}
+
+func size(m interface{}) int {
+	if m, ok := m.(proto.Message); ok {
+		return proto.Size(m)
+	}
+	return 0
+}
+
+// Tracker records the requests handled by the servers whose interceptor
+// chains include its interceptors. They must come after the auth
+// interceptor, which identifies the caller.
+type Tracker struct {
+	mu       sync.Mutex
+	nextID   uint64
+	requests map[string]*request
+}
+
+// NewTracker returns a new Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{requests: make(map[string]*request)}
+}
+
+func (t *Tracker) start(ctx context.Context, method string, streaming bool) (context.Context, *request, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	r := &request{
+		method:    method,
+		caller:    authmw.GetWhoAmI(ctx),
+		started:   time.Now(),
+		streaming: streaming,
+		cancel:    cancel,
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		r.peer = p.Addr.String()
+	}
+	t.mu.Lock()
+	t.nextID++
+	r.id = strconv.FormatUint(t.nextID, 10)
+	t.requests[r.id] = r
+	t.mu.Unlock()
+	return ctx, r, func() {
+		t.mu.Lock()
+		delete(t.requests, r.id)
+		t.mu.Unlock()
+		cancel()
+	}
+}
+
+// List returns the in-flight requests, oldest first.
+func (t *Tracker) List() []*Request {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result := make([]*Request, 0, len(t.requests))
+	for _, r := range t.requests {
+		result = append(result, &Request{
+			ID:               r.id,
+			Method:           r.method,
+			Caller:           r.caller,
+			Peer:             r.peer,
+			Started:          r.started,
+			Streaming:        r.streaming,
+			MessagesReceived: atomic.LoadInt64(&r.messagesReceived),
+			MessagesSent:     atomic.LoadInt64(&r.messagesSent),
+			BytesReceived:    atomic.LoadInt64(&r.bytesReceived),
+			BytesSent:        atomic.LoadInt64(&r.bytesSent),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Started.Before(result[j].Started) })
+	return result
+}
+
+// Cancel cancels the context of the request with the given ID, and returns
+// false if there's no such request.
+func (t *Tracker) Cancel(id string) bool {
+	t.mu.Lock()
+	r, ok := t.requests[id]
+	t.mu.Unlock()
+	if ok {
+		r.cancel()
+	}
+	return ok
+}
+
+// UnaryServerInterceptor tracks unary requests.
+func (t *Tracker) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, r, done := t.start(ctx, info.FullMethod, false)
+	defer done()
+	r.received(req)
+	resp, err := handler(ctx, req)
+	if err == nil {
+		r.sent(resp)
+	}
+	return resp, err
+}
+
+// StreamServerInterceptor tracks streaming requests.
+func (t *Tracker) StreamServerInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, r, done := t.start(stream.Context(), info.FullMethod, true)
+	defer done()
+	return handler(srv, &streamWrapper{stream: stream, ctx: ctx, r: r})
+}
+
+type streamWrapper struct {
+	stream grpc.ServerStream
+	ctx    context.Context
+	r      *request
+}
+
+func (sw *streamWrapper) SetHeader(m metadata.MD) error {
+	return sw.stream.SetHeader(m) //nolint:wrapcheck
+}
+
+func (sw *streamWrapper) SendHeader(m metadata.MD) error {
+	return sw.stream.SendHeader(m) //nolint:wrapcheck
+}
+
+func (sw *streamWrapper) SetTrailer(m metadata.MD) {
+	sw.stream.SetTrailer(m)
+}
+
+func (sw *streamWrapper) Context() context.Context {
+	return sw.ctx
+}
+
+func (sw *streamWrapper) SendMsg(m interface{}) error {
+	err := sw.stream.SendMsg(m)
+	if err == nil {
+		sw.r.sent(m)
+	}
+	return err //nolint:wrapcheck
+}
+
+func (sw *streamWrapper) RecvMsg(m interface{}) error {
+	err := sw.stream.RecvMsg(m)
+	if err == nil {
+		sw.r.received(m)
+	}
+	return err //nolint:wrapcheck
+}
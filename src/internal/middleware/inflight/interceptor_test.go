@@ -0,0 +1,43 @@
+package inflight
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker()
+	started := make(chan struct{})
+	result := make(chan error, 1)
+	go func() {
+		_, err := tracker.UnaryServerInterceptor(context.Background(), &pfs.InspectRepoRequest{Repo: &pfs.Repo{Name: "repo"}},
+			&grpc.UnaryServerInfo{FullMethod: "/pfs_v2.API/InspectRepo"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				close(started)
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+		result <- err
+	}()
+	<-started
+
+	requests := tracker.List()
+	require.Equal(t, 1, len(requests))
+	r := requests[0]
+	require.Equal(t, "/pfs_v2.API/InspectRepo", r.Method)
+	require.False(t, r.Streaming)
+	require.Equal(t, int64(1), r.MessagesReceived)
+	require.True(t, r.BytesReceived > 0)
+	require.Equal(t, int64(0), r.MessagesSent)
+
+	require.False(t, tracker.Cancel("nonexistent"))
+	require.True(t, tracker.Cancel(r.ID))
+	require.True(t, errors.Is(<-result, context.Canceled))
+	require.Equal(t, 0, len(tracker.List()))
+}
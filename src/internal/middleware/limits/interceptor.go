@@ -0,0 +1,278 @@
+// Package limits enforces the limits that operators configure on the RPCs a
+// server handles: the size of the messages they receive and send, the
+// deadline of requests that don't set one, and the number of requests to each
+// method that are handled at once.
+package limits
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// A Class is a class of methods that share a default deadline.
+type Class string
+
+const (
+	// Read is the class of unary methods that only read state, such as
+	// InspectRepo and ListCommit.
+	Read Class = "read"
+	// Write is the class of the other unary methods.
+	Write Class = "write"
+	// Stream is the class of streaming methods.
+	Stream Class = "stream"
+)
+
+// readPrefixes are the prefixes of the names of unary methods in the Read
+// class.
+var readPrefixes = []string{"Inspect", "List", "Get", "Glob", "Diff", "Walk", "Find", "WhoAmI", "Query", "Search", "Export", "Version", "Count"}
+
+// MethodClass returns the class of the method fullMethod,
+// e.g. "/pfs_v2.API/InspectRepo".
+func MethodClass(fullMethod string, streaming bool) Class {
+	if streaming {
+		return Stream
+	}
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, prefix := range readPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return Read
+		}
+	}
+	return Write
+}
+
+// Config is the configuration of a Limiter. A limit that's zero, or missing
+// from a map, isn't enforced.
+type Config struct {
+	// MaxRequestBytes and MaxResponseBytes are the maximum sizes of each
+	// message received and sent by an RPC.
+	MaxRequestBytes, MaxResponseBytes int
+	// Deadlines are the deadlines of requests, by the class of their method,
+	// that don't set one.
+	Deadlines map[Class]time.Duration
+	// Concurrency is the maximum number of requests to a method, by its full
+	// name, that are handled at once.
+	Concurrency map[string]int
+}
+
+// ParseConfig returns the Config with the given message size limits, and the
+// deadlines and concurrency limits parsed by ParseDeadlines and
+// ParseConcurrency.
+func ParseConfig(maxRequestBytes, maxResponseBytes int, deadlines, concurrency string) (Config, error) {
+	config := Config{MaxRequestBytes: maxRequestBytes, MaxResponseBytes: maxResponseBytes}
+	var err error
+	if config.Deadlines, err = ParseDeadlines(deadlines); err != nil {
+		return Config{}, err
+	}
+	if config.Concurrency, err = ParseConcurrency(concurrency); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// ParseDeadlines parses a comma-separated list of class=duration pairs,
+// e.g. "read=1m,write=5m".
+func ParseDeadlines(deadlines string) (map[Class]time.Duration, error) {
+	result := make(map[Class]time.Duration)
+	if err := parsePairs(deadlines, func(key, value string) error {
+		class := Class(key)
+		if class != Read && class != Write && class != Stream {
+			return errors.Errorf("unknown method class %q, must be one of %s, %s or %s", key, Read, Write, Stream)
+		}
+		if _, ok := result[class]; ok {
+			return errors.Errorf("the deadline of %q is set more than once", key)
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return errors.Wrapf(err, "malformed deadline of %q", key)
+		}
+		if d < 0 {
+			return errors.Errorf("the deadline of %q can't be negative", key)
+		}
+		result[class] = d
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ParseConcurrency parses a comma-separated list of method=limit pairs,
+// e.g. "/pfs_v2.API/GlobFile=10,/pps_v2.API/ListDatum=5".
+func ParseConcurrency(concurrency string) (map[string]int, error) {
+	result := make(map[string]int)
+	if err := parsePairs(concurrency, func(key, value string) error {
+		if !strings.HasPrefix(key, "/") || strings.Count(key, "/") != 2 {
+			return errors.Errorf("malformed method %q, must be a full method name such as /pfs_v2.API/GlobFile", key)
+		}
+		if _, ok := result[key]; ok {
+			return errors.Errorf("the concurrency limit of %q is set more than once", key)
+		}
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			return errors.Errorf("malformed concurrency limit %q of %q, must be a non-negative integer", value, key)
+		}
+		result[key] = limit
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func parsePairs(pairs string, f func(key, value string) error) error {
+	for _, pair := range strings.Split(pairs, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return errors.Errorf("malformed limit %q, must be key=value", pair)
+		}
+		if err := f(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Limiter enforces the limits of a Config on the requests handled by the
+// servers whose interceptor chains include its interceptors.
+type Limiter struct {
+	config Config
+	// slots holds a buffered channel for each method with a concurrency
+	// limit, which holds a value for each of its requests being handled.
+	slots map[string]chan struct{}
+}
+
+// NewLimiter returns a Limiter that enforces config.
+func NewLimiter(config Config) *Limiter {
+	l := &Limiter{config: config, slots: make(map[string]chan struct{})}
+	for method, limit := range config.Concurrency {
+		if limit > 0 {
+			l.slots[method] = make(chan struct{}, limit)
+		}
+	}
+	return l
+}
+
+// start applies the limits that are checked when a request starts. It
+// returns the request's context and a function that's called when the
+// request finishes.
+func (l *Limiter) start(ctx context.Context, method string, streaming bool) (context.Context, func(), error) {
+	done := func() {}
+	if slots, ok := l.slots[method]; ok {
+		select {
+		case slots <- struct{}{}:
+		default:
+			log.WithFields(log.Fields{"method": method, "limit": cap(slots)}).Warn("rejected request over the concurrency limit")
+			return nil, nil, status.Errorf(codes.ResourceExhausted, "too many concurrent %s requests (the limit is %d), try again later", method, cap(slots))
+		}
+		done = func() { <-slots }
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		if d := l.config.Deadlines[MethodClass(method, streaming)]; d > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, d)
+			release := done
+			done = func() {
+				cancel()
+				release()
+			}
+		}
+	}
+	return ctx, done, nil
+}
+
+func checkSize(method, direction string, m interface{}, limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return nil
+	}
+	if size := proto.Size(msg); size > limit {
+		return status.Errorf(codes.ResourceExhausted, "%s %s is %d bytes, which is larger than the limit of %d bytes", method, direction, size, limit)
+	}
+	return nil
+}
+
+// UnaryServerInterceptor enforces the limits on unary requests.
+func (l *Limiter) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := checkSize(info.FullMethod, "request", req, l.config.MaxRequestBytes); err != nil {
+		return nil, err
+	}
+	ctx, done, err := l.start(ctx, info.FullMethod, false)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	if err := checkSize(info.FullMethod, "response", resp, l.config.MaxResponseBytes); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// StreamServerInterceptor enforces the limits on streaming requests.
+func (l *Limiter) StreamServerInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, done, err := l.start(stream.Context(), info.FullMethod, true)
+	if err != nil {
+		return err
+	}
+	defer done()
+	return handler(srv, &streamWrapper{stream: stream, ctx: ctx, method: info.FullMethod, config: &l.config})
+}
+
+type streamWrapper struct {
+	stream grpc.ServerStream
+	ctx    context.Context
+	method string
+	config *Config
+}
+
+func (sw *streamWrapper) SetHeader(m metadata.MD) error {
+	return sw.stream.SetHeader(m) //nolint:wrapcheck
+}
+
+func (sw *streamWrapper) SendHeader(m metadata.MD) error {
+	return sw.stream.SendHeader(m) //nolint:wrapcheck
+}
+
+func (sw *streamWrapper) SetTrailer(m metadata.MD) {
+	sw.stream.SetTrailer(m)
+}
+
+func (sw *streamWrapper) Context() context.Context {
+	return sw.ctx
+}
+
+func (sw *streamWrapper) SendMsg(m interface{}) error {
+	if err := checkSize(sw.method, "response", m, sw.config.MaxResponseBytes); err != nil {
+		return err
+	}
+	return sw.stream.SendMsg(m) //nolint:wrapcheck
+}
+
+func (sw *streamWrapper) RecvMsg(m interface{}) error {
+	if err := sw.stream.RecvMsg(m); err != nil {
+		return err //nolint:wrapcheck
+	}
+	return checkSize(sw.method, "request", m, sw.config.MaxRequestBytes)
+}
@@ -0,0 +1,117 @@
+package limits
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+func TestMethodClass(t *testing.T) {
+	require.Equal(t, Read, MethodClass("/pfs_v2.API/InspectRepo", false))
+	require.Equal(t, Read, MethodClass("/auth_v2.API/WhoAmI", false))
+	require.Equal(t, Read, MethodClass("/pps_v2.API/CountDatums", false))
+	require.Equal(t, Write, MethodClass("/pfs_v2.API/CreateRepo", false))
+	require.Equal(t, Stream, MethodClass("/pfs_v2.API/ListRepo", true))
+}
+
+func TestParseConfig(t *testing.T) {
+	config, err := ParseConfig(10, 20, " read=1m, write=5m ", "/pfs_v2.API/GlobFile=10")
+	require.NoError(t, err)
+	require.Equal(t, 10, config.MaxRequestBytes)
+	require.Equal(t, 20, config.MaxResponseBytes)
+	require.Equal(t, map[Class]time.Duration{Read: time.Minute, Write: 5 * time.Minute}, config.Deadlines)
+	require.Equal(t, map[string]int{"/pfs_v2.API/GlobFile": 10}, config.Concurrency)
+
+	config, err = ParseConfig(0, 0, "", "")
+	require.NoError(t, err)
+	require.Equal(t, 0, len(config.Deadlines))
+	require.Equal(t, 0, len(config.Concurrency))
+
+	for _, deadlines := range []string{"read", "other=1m", "read=1m,read=2m", "write=soon", "stream=-1s"} {
+		_, err := ParseDeadlines(deadlines)
+		require.YesError(t, err, deadlines)
+	}
+	for _, concurrency := range []string{"GlobFile=1", "/pfs_v2.API/GlobFile=many", "/pfs_v2.API/GlobFile=-1", "/pfs_v2.API/GlobFile=1,/pfs_v2.API/GlobFile=2"} {
+		_, err := ParseConcurrency(concurrency)
+		require.YesError(t, err, concurrency)
+	}
+}
+
+func TestConcurrencyLimit(t *testing.T) {
+	l := NewLimiter(Config{Concurrency: map[string]int{"/pfs_v2.API/GlobFile": 1}})
+	info := &grpc.UnaryServerInfo{FullMethod: "/pfs_v2.API/GlobFile"}
+	started, release := make(chan struct{}), make(chan struct{})
+	result := make(chan error, 1)
+	go func() {
+		_, err := l.UnaryServerInterceptor(context.Background(), &pfs.GlobFileRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			close(started)
+			<-release
+			return &pfs.FileInfo{}, nil
+		})
+		result <- err
+	}()
+	<-started
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return &pfs.FileInfo{}, nil }
+	_, err := l.UnaryServerInterceptor(context.Background(), &pfs.GlobFileRequest{}, info, handler)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	// other methods aren't limited
+	_, err = l.UnaryServerInterceptor(context.Background(), &pfs.InspectRepoRequest{}, &grpc.UnaryServerInfo{FullMethod: "/pfs_v2.API/InspectRepo"}, handler)
+	require.NoError(t, err)
+
+	close(release)
+	require.NoError(t, <-result)
+	_, err = l.UnaryServerInterceptor(context.Background(), &pfs.GlobFileRequest{}, info, handler)
+	require.NoError(t, err)
+}
+
+func TestDefaultDeadline(t *testing.T) {
+	l := NewLimiter(Config{Deadlines: map[Class]time.Duration{Read: time.Minute}})
+	deadline := func(ctx context.Context, method string) (time.Time, bool) {
+		var result time.Time
+		var ok bool
+		_, err := l.UnaryServerInterceptor(ctx, &pfs.InspectRepoRequest{}, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			result, ok = ctx.Deadline()
+			return &pfs.RepoInfo{}, nil
+		})
+		require.NoError(t, err)
+		return result, ok
+	}
+
+	d, ok := deadline(context.Background(), "/pfs_v2.API/InspectRepo")
+	require.True(t, ok)
+	require.True(t, time.Until(d) <= time.Minute)
+	// there's no default deadline for writes
+	_, ok = deadline(context.Background(), "/pfs_v2.API/CreateRepo")
+	require.False(t, ok)
+	// a request's own deadline is kept
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	d, ok = deadline(ctx, "/pfs_v2.API/InspectRepo")
+	require.True(t, ok)
+	require.True(t, time.Until(d) > time.Minute)
+}
+
+func TestMessageSize(t *testing.T) {
+	l := NewLimiter(Config{MaxRequestBytes: 100, MaxResponseBytes: 100})
+	info := &grpc.UnaryServerInfo{FullMethod: "/pfs_v2.API/InspectRepo"}
+	small := &pfs.Repo{Name: "repo"}
+	large := &pfs.Repo{Name: strings.Repeat("x", 200)}
+	call := func(req, resp *pfs.Repo) error {
+		_, err := l.UnaryServerInterceptor(context.Background(), &pfs.InspectRepoRequest{Repo: req}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &pfs.RepoInfo{Repo: resp}, nil
+		})
+		return err
+	}
+	require.NoError(t, call(small, small))
+	require.Equal(t, codes.ResourceExhausted, status.Code(call(large, small)))
+	require.Equal(t, codes.ResourceExhausted, status.Code(call(small, large)))
+}
@@ -0,0 +1,325 @@
+package limits
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	authmw "github.com/pachyderm/pachyderm/v2/src/internal/middleware/auth"
+)
+
+// idlePrincipalTimeout is how long the state of a principal without requests
+// in flight is kept after its last request.
+const idlePrincipalTimeout = 10 * time.Minute
+
+var (
+	rateLimitedMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Subsystem: "grpc",
+		Name:      "rate_limited_requests_total",
+		Help:      "Count of requests rejected by a per-principal limit, by the limit, requests or streams",
+	}, []string{"limit"})
+	throttledMetric = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Subsystem: "grpc",
+		Name:      "throttled_file_seconds_total",
+		Help:      "Seconds requests to file methods waited for their principal's file bytes per second limit",
+	})
+)
+
+// PrincipalLimits are the limits on the requests of a principal. A limit
+// that's zero isn't enforced.
+type PrincipalLimits struct {
+	// RequestsPerSecond is the rate of the principal's requests, in bursts
+	// of up to a second's worth.
+	RequestsPerSecond int
+	// Streams is the number of the principal's streaming requests that are
+	// handled at once.
+	Streams int
+	// FileBytesPerSecond is the rate at which the principal's requests to
+	// PFS's file methods, such as ModifyFile and GetFileTAR, send and receive
+	// messages. Requests over it are slowed down, rather than rejected.
+	FileBytesPerSecond int
+}
+
+// PrincipalConfig is the configuration of a PrincipalLimiter.
+type PrincipalConfig struct {
+	// Default are the limits of each principal without an override.
+	Default PrincipalLimits
+	// Overrides are the limits of the principals they're keyed by, such as
+	// "robot:etl".
+	Overrides map[string]PrincipalLimits
+}
+
+// ParsePrincipalConfig returns the PrincipalConfig with the default limits
+// parsed by ParsePrincipalLimits, and overrides, a semicolon-separated list
+// of principal=limits pairs, e.g. "robot:etl=requests=100,streams=20". The
+// limits an override doesn't set are the default limits.
+func ParsePrincipalConfig(limits, overrides string) (PrincipalConfig, error) {
+	config := PrincipalConfig{Overrides: make(map[string]PrincipalLimits)}
+	var err error
+	if config.Default, err = ParsePrincipalLimits(limits, PrincipalLimits{}); err != nil {
+		return PrincipalConfig{}, err
+	}
+	for _, override := range strings.Split(overrides, ";") {
+		override = strings.TrimSpace(override)
+		if override == "" {
+			continue
+		}
+		parts := strings.SplitN(override, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return PrincipalConfig{}, errors.Errorf("malformed override %q, must be principal=limits", override)
+		}
+		principal := strings.TrimSpace(parts[0])
+		if _, ok := config.Overrides[principal]; ok {
+			return PrincipalConfig{}, errors.Errorf("the limits of %q are overridden more than once", principal)
+		}
+		if config.Overrides[principal], err = ParsePrincipalLimits(parts[1], config.Default); err != nil {
+			return PrincipalConfig{}, errors.Wrapf(err, "malformed override of %q", principal)
+		}
+	}
+	return config, nil
+}
+
+// ParsePrincipalLimits parses a comma-separated list of limit=value pairs,
+// where the limit is requests, streams or file_bytes, e.g.
+// "requests=20,streams=10,file_bytes=104857600", into base.
+func ParsePrincipalLimits(limits string, base PrincipalLimits) (PrincipalLimits, error) {
+	result := base
+	seen := make(map[string]bool)
+	if err := parsePairs(limits, func(key, value string) error {
+		if seen[key] {
+			return errors.Errorf("the limit %q is set more than once", key)
+		}
+		seen[key] = true
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return errors.Errorf("malformed limit %q of %q, must be a non-negative integer", value, key)
+		}
+		switch key {
+		case "requests":
+			result.RequestsPerSecond = n
+		case "streams":
+			result.Streams = n
+		case "file_bytes":
+			result.FileBytesPerSecond = n
+		default:
+			return errors.Errorf("unknown limit %q, must be one of requests, streams or file_bytes", key)
+		}
+		return nil
+	}); err != nil {
+		return PrincipalLimits{}, err
+	}
+	return result, nil
+}
+
+// isFileMethod returns whether fullMethod is one of PFS's file methods,
+// whose messages are limited by PrincipalLimits.FileBytesPerSecond.
+func isFileMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/pfs_v2.API/") && strings.Contains(fullMethod[len("/pfs_v2.API/"):], "File")
+}
+
+// principalState is the state of a principal's limits.
+type principalState struct {
+	limits PrincipalLimits
+	// requests and fileBytes are nil if their limits aren't enforced.
+	requests, fileBytes *rate.Limiter
+	streams             int
+	lastUsed            time.Time
+}
+
+// PrincipalLimiter enforces the limits of a PrincipalConfig on the requests
+// of each principal handled by the servers whose interceptor chains include
+// its interceptors. They must come after the auth interceptor, which
+// identifies the principal. When auth is disabled, each client host is
+// limited as a principal.
+type PrincipalLimiter struct {
+	config     PrincipalConfig
+	mu         sync.Mutex
+	principals map[string]*principalState
+	lastSweep  time.Time
+}
+
+// NewPrincipalLimiter returns a PrincipalLimiter that enforces config.
+func NewPrincipalLimiter(config PrincipalConfig) *PrincipalLimiter {
+	return &PrincipalLimiter{config: config, principals: make(map[string]*principalState)}
+}
+
+func principal(ctx context.Context) string {
+	if principal := authmw.GetWhoAmI(ctx); principal != "" {
+		return principal
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return "host:" + host
+	}
+	return ""
+}
+
+// state returns the state of principal, creating it if it doesn't exist, and
+// removes the states of principals that have been idle for too long. l.mu
+// must be held.
+func (l *PrincipalLimiter) state(principal string, now time.Time) *principalState {
+	if now.Sub(l.lastSweep) > idlePrincipalTimeout {
+		for p, s := range l.principals {
+			if s.streams == 0 && now.Sub(s.lastUsed) > idlePrincipalTimeout {
+				delete(l.principals, p)
+			}
+		}
+		l.lastSweep = now
+	}
+	s, ok := l.principals[principal]
+	if !ok {
+		limits, ok := l.config.Overrides[principal]
+		if !ok {
+			limits = l.config.Default
+		}
+		s = &principalState{limits: limits}
+		if limits.RequestsPerSecond > 0 {
+			s.requests = rate.NewLimiter(rate.Limit(limits.RequestsPerSecond), limits.RequestsPerSecond)
+		}
+		if limits.FileBytesPerSecond > 0 {
+			s.fileBytes = rate.NewLimiter(rate.Limit(limits.FileBytesPerSecond), limits.FileBytesPerSecond)
+		}
+		l.principals[principal] = s
+	}
+	s.lastUsed = now
+	return s
+}
+
+// start applies the limits that are checked when a request starts. It
+// returns the principal's state and a function that's called when the
+// request finishes.
+func (l *PrincipalLimiter) start(ctx context.Context, method string, streaming bool) (*principalState, func(), error) {
+	principal := principal(ctx)
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s := l.state(principal, now)
+	if s.requests != nil && !s.requests.AllowN(now, 1) {
+		rateLimitedMetric.WithLabelValues("requests").Inc()
+		log.WithFields(log.Fields{"method": method, "principal": principal, "limit": s.requests.Burst()}).Warn("rejected request over the per-principal rate limit")
+		return nil, nil, status.Errorf(codes.ResourceExhausted, "%s is making more than %d requests per second, try again later", principal, s.requests.Burst())
+	}
+	if !streaming {
+		return s, func() {}, nil
+	}
+	if limit := s.limits.Streams; limit > 0 && s.streams >= limit {
+		rateLimitedMetric.WithLabelValues("streams").Inc()
+		log.WithFields(log.Fields{"method": method, "principal": principal, "limit": limit}).Warn("rejected stream over the per-principal concurrency limit")
+		return nil, nil, status.Errorf(codes.ResourceExhausted, "%s has too many concurrent streams (the limit is %d), try again later", principal, limit)
+	}
+	s.streams++
+	return s, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		s.streams--
+		s.lastUsed = time.Now()
+	}, nil
+}
+
+func size(m interface{}) int {
+	if msg, ok := m.(proto.Message); ok {
+		return proto.Size(msg)
+	}
+	return 0
+}
+
+// waitFileBytes waits until limiter allows n more bytes, in bursts of up to
+// its burst size.
+func waitFileBytes(ctx context.Context, limiter *rate.Limiter, n int) error {
+	if limiter == nil {
+		return nil
+	}
+	start := time.Now()
+	defer func() { throttledMetric.Add(time.Since(start).Seconds()) }()
+	for n > 0 {
+		burst := n
+		if burst > limiter.Burst() {
+			burst = limiter.Burst()
+		}
+		if err := limiter.WaitN(ctx, burst); err != nil {
+			return status.Error(codes.Canceled, err.Error())
+		}
+		n -= burst
+	}
+	return nil
+}
+
+// UnaryServerInterceptor enforces the per-principal limits on unary
+// requests.
+func (l *PrincipalLimiter) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if _, _, err := l.start(ctx, info.FullMethod, false); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor enforces the per-principal limits on streaming
+// requests.
+func (l *PrincipalLimiter) StreamServerInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	s, done, err := l.start(stream.Context(), info.FullMethod, true)
+	if err != nil {
+		return err
+	}
+	defer done()
+	if s.fileBytes == nil || !isFileMethod(info.FullMethod) {
+		return handler(srv, stream)
+	}
+	return handler(srv, &throttledStream{stream: stream, limiter: s.fileBytes})
+}
+
+// throttledStream limits the rate at which a stream sends and receives
+// messages.
+type throttledStream struct {
+	stream  grpc.ServerStream
+	limiter *rate.Limiter
+}
+
+func (ts *throttledStream) SetHeader(m metadata.MD) error {
+	return ts.stream.SetHeader(m) //nolint:wrapcheck
+}
+
+func (ts *throttledStream) SendHeader(m metadata.MD) error {
+	return ts.stream.SendHeader(m) //nolint:wrapcheck
+}
+
+func (ts *throttledStream) SetTrailer(m metadata.MD) {
+	ts.stream.SetTrailer(m)
+}
+
+func (ts *throttledStream) Context() context.Context {
+	return ts.stream.Context()
+}
+
+func (ts *throttledStream) SendMsg(m interface{}) error {
+	if err := waitFileBytes(ts.Context(), ts.limiter, size(m)); err != nil {
+		return err
+	}
+	return ts.stream.SendMsg(m) //nolint:wrapcheck
+}
+
+func (ts *throttledStream) RecvMsg(m interface{}) error {
+	if err := ts.stream.RecvMsg(m); err != nil {
+		return err //nolint:wrapcheck
+	}
+	return waitFileBytes(ts.Context(), ts.limiter, size(m))
+}
@@ -0,0 +1,100 @@
+package limits
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	authmw "github.com/pachyderm/pachyderm/v2/src/internal/middleware/auth"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+func TestParsePrincipalConfig(t *testing.T) {
+	config, err := ParsePrincipalConfig("requests=10, streams=2", "robot:etl=requests=100,file_bytes=1000; user:alice=streams=0")
+	require.NoError(t, err)
+	require.Equal(t, PrincipalLimits{RequestsPerSecond: 10, Streams: 2}, config.Default)
+	require.Equal(t, map[string]PrincipalLimits{
+		"robot:etl":  {RequestsPerSecond: 100, Streams: 2, FileBytesPerSecond: 1000},
+		"user:alice": {RequestsPerSecond: 10},
+	}, config.Overrides)
+
+	for _, limits := range []string{"requests", "other=1", "requests=1,requests=2", "streams=many", "file_bytes=-1"} {
+		_, err := ParsePrincipalLimits(limits, PrincipalLimits{})
+		require.YesError(t, err, limits)
+	}
+	for _, overrides := range []string{"requests=1", "=requests=1", "robot:a=streams=1;robot:a=streams=2", "robot:a=other=1"} {
+		_, err := ParsePrincipalConfig("", overrides)
+		require.YesError(t, err, overrides)
+	}
+}
+
+func TestPrincipalRequestLimit(t *testing.T) {
+	l := NewPrincipalLimiter(PrincipalConfig{
+		Default:   PrincipalLimits{RequestsPerSecond: 2},
+		Overrides: map[string]PrincipalLimits{"internal:exempt": {}},
+	})
+	info := &grpc.UnaryServerInfo{FullMethod: "/pfs_v2.API/InspectRepo"}
+	call := func(principal string) error {
+		ctx := authmw.AsInternalUser(context.Background(), principal)
+		_, err := l.UnaryServerInterceptor(ctx, &pfs.InspectRepoRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &pfs.RepoInfo{}, nil
+		})
+		return err
+	}
+	require.NoError(t, call("a"))
+	require.NoError(t, call("a"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(call("a")))
+	// each principal has its own limit
+	require.NoError(t, call("b"))
+	for i := 0; i < 10; i++ {
+		require.NoError(t, call("exempt"))
+	}
+}
+
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (ts *testStream) Context() context.Context {
+	return ts.ctx
+}
+
+func TestPrincipalStreamLimit(t *testing.T) {
+	l := NewPrincipalLimiter(PrincipalConfig{Default: PrincipalLimits{Streams: 1}})
+	stream := &testStream{ctx: authmw.AsInternalUser(context.Background(), "a")}
+	info := &grpc.StreamServerInfo{FullMethod: "/pfs_v2.API/ListRepo", IsServerStream: true}
+	started, release := make(chan struct{}), make(chan struct{})
+	result := make(chan error, 1)
+	go func() {
+		result <- l.StreamServerInterceptor(nil, stream, info, func(srv interface{}, stream grpc.ServerStream) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	handler := func(srv interface{}, stream grpc.ServerStream) error { return nil }
+	require.Equal(t, codes.ResourceExhausted, status.Code(l.StreamServerInterceptor(nil, stream, info, handler)))
+	// unary requests aren't streams
+	_, err := l.UnaryServerInterceptor(stream.ctx, &pfs.InspectRepoRequest{}, &grpc.UnaryServerInfo{FullMethod: "/pfs_v2.API/InspectRepo"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &pfs.RepoInfo{}, nil
+	})
+	require.NoError(t, err)
+
+	close(release)
+	require.NoError(t, <-result)
+	require.NoError(t, l.StreamServerInterceptor(nil, stream, info, handler))
+}
+
+func TestIsFileMethod(t *testing.T) {
+	require.True(t, isFileMethod("/pfs_v2.API/ModifyFile"))
+	require.True(t, isFileMethod("/pfs_v2.API/GetFileTAR"))
+	require.False(t, isFileMethod("/pfs_v2.API/ListRepo"))
+	require.False(t, isFileMethod("/pps_v2.API/GetFile"))
+}
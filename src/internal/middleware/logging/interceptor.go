@@ -202,6 +202,9 @@ var endpoints = map[string]logConfig{
 	"/auth_v2.API/GetRolesForPermission":      authConfig,
 	"/auth_v2.API/DeleteExpiredAuthTokens":    authConfig,
 	"/auth_v2.API/RevokeAuthTokensForUser":    authConfig,
+	"/auth_v2.API/RequestRole":                authConfig,
+	"/auth_v2.API/ListRoleRequests":           authConfig,
+	"/auth_v2.API/ReviewRoleRequest":          authConfig,
 
 	"/auth_v2.API/WhoAmI": {
 		level: func(err error) logrus.Level {
@@ -0,0 +1,196 @@
+// Package readonly rejects the requests that change a cluster's state while
+// it's read-only, so that operators can back it up or migrate it without
+// users changing it underneath them.
+package readonly
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	etcd "go.etcd.io/etcd/client/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/middleware/limits"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+// StateKey is the etcd key of the cluster's read-only state.
+const StateKey = "pachyderm_read_only"
+
+// exemptPrefixes are the methods that are handled while the cluster is
+// read-only, though their names don't start with a read prefix. The admin
+// API is exempt so that operators can back up the cluster and make it
+// writable again, and Authenticate so that users can log in to read.
+var exemptPrefixes = []string{
+	"/admin_v2.API/",
+	"/auth_v2.API/Authenticate",
+	"/auth_v2.API/Authorize",
+	"/auth_v2.API/RevokeAuthToken",
+	"/debug_v2.Debug/",
+	"/grpc.health.v1.Health/",
+	"/license_v2.API/Heartbeat",
+	"/pfs_v2.API/CheckStorage",
+	"/pps_v2.API/LintPipeline",
+	"/pps_v2.API/PlanPipeline",
+	"/pps_v2.API/RenderTemplate",
+	"/versionpb_v2.API/",
+}
+
+// writeStreams are the streaming methods that change the cluster's state.
+// The others only read it.
+var writeStreams = map[string]bool{
+	"/pfs_v2.API/ModifyFile":    true,
+	"/pfs_v2.API/CreateFileSet": true,
+}
+
+// IsWrite returns whether fullMethod, e.g. "/pfs_v2.API/PutFile", changes
+// the cluster's state, and so is rejected while it's read-only.
+func IsWrite(fullMethod string, streaming bool) bool {
+	for _, prefix := range exemptPrefixes {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return false
+		}
+	}
+	if streaming {
+		return writeStreams[fullMethod]
+	}
+	return limits.MethodClass(fullMethod, false) == limits.Write
+}
+
+// Get returns the cluster's read-only state.
+func Get(ctx context.Context, client *etcd.Client) (*admin.ReadOnlyState, error) {
+	resp, err := client.Get(ctx, StateKey)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	state := &admin.ReadOnlyState{}
+	if len(resp.Kvs) > 0 {
+		if err := proto.Unmarshal(resp.Kvs[0].Value, state); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+	}
+	return state, nil
+}
+
+// Set sets the cluster's read-only state, which the gates of every pachd
+// enforce once they see it.
+func Set(ctx context.Context, client *etcd.Client, state *admin.ReadOnlyState) error {
+	if !state.ReadOnly {
+		_, err := client.Delete(ctx, StateKey)
+		return errors.EnsureStack(err)
+	}
+	data, err := proto.Marshal(state)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	_, err = client.Put(ctx, StateKey, string(data))
+	return errors.EnsureStack(err)
+}
+
+// Gate rejects the write requests handled by the servers whose interceptor
+// chains include its interceptors while the cluster is read-only.
+type Gate struct {
+	client *etcd.Client
+	mu     sync.RWMutex
+	state  *admin.ReadOnlyState
+}
+
+// NewGate returns a Gate that follows the read-only state stored with
+// client, once it's running.
+func NewGate(client *etcd.Client) *Gate {
+	return &Gate{client: client, state: &admin.ReadOnlyState{}}
+}
+
+// Run keeps the gate's state up to date until ctx is done.
+func (g *Gate) Run(ctx context.Context) error {
+	watch := g.client.Watch(ctx, StateKey)
+	state, err := Get(ctx, g.client)
+	if err != nil {
+		return err
+	}
+	g.set(state)
+	for resp := range watch {
+		if err := resp.Err(); err != nil {
+			return errors.EnsureStack(err)
+		}
+		for _, ev := range resp.Events {
+			state := &admin.ReadOnlyState{}
+			if ev.Type == etcd.EventTypePut {
+				if err := proto.Unmarshal(ev.Kv.Value, state); err != nil {
+					return errors.EnsureStack(err)
+				}
+			}
+			g.set(state)
+		}
+	}
+	return errors.EnsureStack(ctx.Err())
+}
+
+func (g *Gate) set(state *admin.ReadOnlyState) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.state = state
+}
+
+// CheckWrite returns an error if the cluster is read-only.
+func (g *Gate) CheckWrite() error {
+	g.mu.RLock()
+	state := g.state
+	g.mu.RUnlock()
+	if !state.ReadOnly {
+		return nil
+	}
+	msg := "the cluster is read-only for maintenance"
+	if state.Reason != "" {
+		msg += fmt.Sprintf(" (%s)", state.Reason)
+	}
+	return status.Error(codes.FailedPrecondition, msg+", try again once it's finished")
+}
+
+// UnaryServerInterceptor rejects unary write requests while the cluster is
+// read-only.
+func (g *Gate) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if IsWrite(info.FullMethod, false) {
+		if err := g.CheckWrite(); err != nil {
+			return nil, err
+		}
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor rejects streaming write requests while the cluster
+// is read-only.
+func (g *Gate) StreamServerInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if IsWrite(info.FullMethod, true) {
+		if err := g.CheckWrite(); err != nil {
+			return err
+		}
+	}
+	if info.FullMethod == "/pfs_v2.API/Fsck" {
+		// Fsck only writes if it's asked to fix what it finds
+		return handler(srv, &fsckStream{ServerStream: stream, gate: g})
+	}
+	return handler(srv, stream)
+}
+
+type fsckStream struct {
+	grpc.ServerStream
+	gate *Gate
+}
+
+func (fs *fsckStream) RecvMsg(m interface{}) error {
+	if err := fs.ServerStream.RecvMsg(m); err != nil {
+		return err //nolint:wrapcheck
+	}
+	if req, ok := m.(*pfs.FsckRequest); ok && req.Fix {
+		return fs.gate.CheckWrite()
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package readonly
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+func TestIsWrite(t *testing.T) {
+	for method, write := range map[string]bool{
+		"/pfs_v2.API/CreateRepo":                true,
+		"/pfs_v2.API/StartCommit":               true,
+		"/pps_v2.API/CreatePipeline":            true,
+		"/pps_v2.API/DeletePipeline":            true,
+		"/auth_v2.API/ModifyRoleBinding":        true,
+		"/pfs_v2.API/InspectRepo":               false,
+		"/pps_v2.API/ListPipeline":              false,
+		"/pps_v2.API/RenderTemplate":            false,
+		"/auth_v2.API/Authenticate":             false,
+		"/admin_v2.API/SetReadOnly":             false,
+		"/admin_v2.API/Backup":                  false,
+		"/grpc.health.v1.Health/Check":          false,
+		"/versionpb_v2.API/GetVersion":          false,
+		"/license_v2.API/Heartbeat":             false,
+		"/enterprise_v2.API/Deactivate":         true,
+		"/transaction_v2.API/FinishTransaction": true,
+	} {
+		require.Equal(t, write, IsWrite(method, false), method)
+	}
+	for method, write := range map[string]bool{
+		"/pfs_v2.API/ModifyFile":      true,
+		"/pfs_v2.API/CreateFileSet":   true,
+		"/pfs_v2.API/GetFileTAR":      false,
+		"/pfs_v2.API/SubscribeCommit": false,
+		"/pfs_v2.API/Fsck":            false,
+	} {
+		require.Equal(t, write, IsWrite(method, true), method)
+	}
+}
+
+type testStream struct {
+	grpc.ServerStream
+	msgs []interface{}
+}
+
+func (ts *testStream) Context() context.Context {
+	return context.Background()
+}
+
+func (ts *testStream) RecvMsg(m interface{}) error {
+	*m.(*pfs.FsckRequest) = *ts.msgs[0].(*pfs.FsckRequest)
+	ts.msgs = ts.msgs[1:]
+	return nil
+}
+
+func TestGate(t *testing.T) {
+	g := NewGate(nil)
+	var handled int
+	unary := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handled++
+		return nil, nil
+	}
+	stream := func(srv interface{}, stream grpc.ServerStream) error {
+		handled++
+		return nil
+	}
+	write := &grpc.UnaryServerInfo{FullMethod: "/pfs_v2.API/CreateRepo"}
+	read := &grpc.UnaryServerInfo{FullMethod: "/pfs_v2.API/InspectRepo"}
+	modifyFile := &grpc.StreamServerInfo{FullMethod: "/pfs_v2.API/ModifyFile", IsClientStream: true}
+
+	_, err := g.UnaryServerInterceptor(context.Background(), nil, write, unary)
+	require.NoError(t, err)
+	require.NoError(t, g.StreamServerInterceptor(nil, &testStream{}, modifyFile, stream))
+	require.Equal(t, 2, handled)
+
+	g.set(&admin.ReadOnlyState{ReadOnly: true, Reason: "nightly backup"})
+	_, err = g.UnaryServerInterceptor(context.Background(), nil, write, unary)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.True(t, strings.Contains(err.Error(), "nightly backup"), err.Error())
+	err = g.StreamServerInterceptor(nil, &testStream{}, modifyFile, stream)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = g.UnaryServerInterceptor(context.Background(), nil, read, unary)
+	require.NoError(t, err)
+	require.Equal(t, 3, handled)
+
+	// fsck is allowed unless it's asked to fix what it finds
+	fsck := &grpc.StreamServerInfo{FullMethod: "/pfs_v2.API/Fsck", IsServerStream: true}
+	recv := func(srv interface{}, stream grpc.ServerStream) error {
+		return stream.RecvMsg(&pfs.FsckRequest{})
+	}
+	require.NoError(t, g.StreamServerInterceptor(nil, &testStream{msgs: []interface{}{&pfs.FsckRequest{}}}, fsck, recv))
+	err = g.StreamServerInterceptor(nil, &testStream{msgs: []interface{}{&pfs.FsckRequest{Fix: true}}}, fsck, recv)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	g.set(&admin.ReadOnlyState{})
+	_, err = g.UnaryServerInterceptor(context.Background(), nil, write, unary)
+	require.NoError(t, err)
+}
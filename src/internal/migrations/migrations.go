@@ -87,14 +87,20 @@ func InitialState() State {
 // ApplyMigrations does the necessary work to actualize state.
 // It will manipulate the objects available in baseEnv, and use the migrations table in db.
 func ApplyMigrations(ctx context.Context, db *pachsql.DB, baseEnv Env, state State) error {
-	for _, state := range collectStates(make([]State, 0, state.n+1), state) {
-		if err := applyMigration(ctx, db, baseEnv, state); err != nil {
+	for _, state := range States(state) {
+		if err := ApplyMigration(ctx, db, baseEnv, state); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// States returns the states that lead to state, starting with the initial
+// state and ending with state itself.
+func States(state State) []State {
+	return collectStates(make([]State, 0, state.n+1), state)
+}
+
 // collectStates does a reverse order traversal of a linked list and adds each item to a slice
 func collectStates(slice []State, s State) []State {
 	if s.prev != nil {
@@ -103,7 +109,9 @@ func collectStates(slice []State, s State) []State {
 	return append(slice, s)
 }
 
-func applyMigration(ctx context.Context, db *pachsql.DB, baseEnv Env, state State) error {
+// ApplyMigration actualizes state, which the states before it must already
+// have been, unless it's already actualized.
+func ApplyMigration(ctx context.Context, db *pachsql.DB, baseEnv Env, state State) error {
 	tx, err := db.BeginTxx(ctx, &sql.TxOptions{})
 	if err != nil {
 		return errors.EnsureStack(err)
@@ -153,32 +161,18 @@ func applyMigration(ctx context.Context, db *pachsql.DB, baseEnv Env, state Stat
 // by calling ApplyMigrations.
 // If the cluster ever enters a state newer than the state passed to BlockUntil, it errors.
 func BlockUntil(ctx context.Context, db *pachsql.DB, state State) error {
-	const (
-		schemaName = "public"
-		tableName  = "migrations"
-	)
 	// poll database until this state is registered
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
 	for {
-		var tableExists bool
-		if err := db.GetContext(ctx, &tableExists, `SELECT EXISTS (
-			SELECT FROM information_schema.tables
-			WHERE table_schema = $1
-			AND table_name = $2
-		)`, schemaName, tableName); err != nil {
-			return errors.EnsureStack(err)
+		latest, err := CurrentState(ctx, db)
+		if err != nil {
+			return err
 		}
-		if tableExists {
-			var latest int
-			if err := db.GetContext(ctx, &latest, `SELECT COALESCE(MAX(id), 0) FROM migrations`); err != nil && !errors.Is(err, sql.ErrNoRows) {
-				return errors.EnsureStack(err)
-			}
-			if latest == state.n {
-				return nil
-			} else if latest > state.n {
-				return errors.Errorf("database state is newer than application is expecting")
-			}
+		if latest == state.n {
+			return nil
+		} else if latest > state.n {
+			return errors.Errorf("database state is newer than application is expecting")
 		}
 		select {
 		case <-ctx.Done():
@@ -188,6 +182,51 @@ func BlockUntil(ctx context.Context, db *pachsql.DB, state State) error {
 	}
 }
 
+// CurrentState returns the number of the latest state that's been actualized,
+// or -1 if no state has been, not even the initial state.
+func CurrentState(ctx context.Context, db *pachsql.DB) (int, error) {
+	const (
+		schemaName = "public"
+		tableName  = "migrations"
+	)
+	var tableExists bool
+	if err := db.GetContext(ctx, &tableExists, `SELECT EXISTS (
+		SELECT FROM information_schema.tables
+		WHERE table_schema = $1
+		AND table_name = $2
+	)`, schemaName, tableName); err != nil {
+		return 0, errors.EnsureStack(err)
+	}
+	if !tableExists {
+		return -1, nil
+	}
+	var latest int
+	if err := db.GetContext(ctx, &latest, `SELECT COALESCE(MAX(id), 0) FROM migrations`); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, errors.EnsureStack(err)
+	}
+	return latest, nil
+}
+
+// Migration is a migration that has been applied.
+type Migration struct {
+	ID        int          `db:"id"`
+	Name      string       `db:"name"`
+	StartTime time.Time    `db:"start_time"`
+	EndTime   sql.NullTime `db:"end_time"`
+}
+
+// History returns the migrations that have been applied, in order.
+func History(ctx context.Context, db *pachsql.DB) ([]Migration, error) {
+	if current, err := CurrentState(ctx, db); err != nil || current < 0 {
+		return nil, err
+	}
+	var history []Migration
+	if err := db.SelectContext(ctx, &history, `SELECT id, name, start_time, end_time FROM migrations ORDER BY id`); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return history, nil
+}
+
 func isFinished(ctx context.Context, tx *pachsql.Tx, state State) (bool, error) {
 	var name string
 	if err := tx.GetContext(ctx, &name, `
@@ -0,0 +1,325 @@
+// Package mlflow is a client for the parts of the MLflow REST API that are
+// used to register a pipeline's output as a model version: experiments, runs
+// and the model registry. It works with any registry that implements the
+// MLflow 2.0 REST API.
+package mlflow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+const (
+	// ErrorCodeNotFound is the error code of requests for resources that
+	// don't exist.
+	ErrorCodeNotFound = "RESOURCE_DOES_NOT_EXIST"
+	// ErrorCodeAlreadyExists is the error code of requests that create
+	// resources that already exist.
+	ErrorCodeAlreadyExists = "RESOURCE_ALREADY_EXISTS"
+
+	// RunStatusFinished is the status of runs that finished successfully.
+	RunStatusFinished = "FINISHED"
+)
+
+// Error is an error returned by the MLflow API.
+type Error struct {
+	StatusCode int    `json:"-"`
+	Code       string `json:"error_code"`
+	Message    string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("mlflow error %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// IsNotFound returns true if err is an MLflow error for a resource that
+// doesn't exist.
+func IsNotFound(err error) bool {
+	var mErr *Error
+	return errors.As(err, &mErr) && (mErr.Code == ErrorCodeNotFound || mErr.StatusCode == http.StatusNotFound)
+}
+
+// IsAlreadyExists returns true if err is an MLflow error for a resource that
+// already exists.
+func IsAlreadyExists(err error) bool {
+	var mErr *Error
+	return errors.As(err, &mErr) && mErr.Code == ErrorCodeAlreadyExists
+}
+
+// Tag is a tag of a run or model version.
+type Tag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Metric is a metric of a run.
+type Metric struct {
+	Key       string  `json:"key"`
+	Value     float64 `json:"value"`
+	Timestamp int64   `json:"timestamp"`
+	Step      int64   `json:"step"`
+}
+
+// RunInfo is the metadata of a run.
+type RunInfo struct {
+	RunID        string `json:"run_id"`
+	ExperimentID string `json:"experiment_id"`
+	Status       string `json:"status"`
+}
+
+// Run is a run, of which only the metadata is decoded.
+type Run struct {
+	Info RunInfo `json:"info"`
+}
+
+// ModelVersion is a version of a registered model.
+type ModelVersion struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Source  string `json:"source"`
+	RunID   string `json:"run_id"`
+	RunLink string `json:"run_link,omitempty"`
+	Tags    []Tag  `json:"tags,omitempty"`
+}
+
+// Client makes requests to an MLflow tracking server.
+type Client struct {
+	url   string
+	token string
+	http  *http.Client
+}
+
+// NewClient returns a client for the tracking server at url, which sends
+// token as a bearer token if it's set.
+func NewClient(url, token string) *Client {
+	return &Client{
+		url:   strings.TrimSuffix(url, "/"),
+		token: token,
+		http:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// do sends a request to the API method at path, with the JSON encoding of
+// body, which is sent as query parameters if method is GET, and decodes the
+// response into result if it's set.
+func (c *Client) do(ctx context.Context, method, path string, body, result interface{}) error {
+	u := c.url + "/api/2.0/mlflow/" + path
+	var reqBody io.Reader
+	if method == http.MethodGet {
+		if params, ok := body.(url.Values); ok {
+			u += "?" + params.Encode()
+		}
+	} else {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if resp.StatusCode/100 != 2 {
+		mErr := &Error{StatusCode: resp.StatusCode}
+		if err := json.Unmarshal(data, mErr); err != nil || mErr.Code == "" {
+			mErr.Message = strings.TrimSpace(string(data))
+		}
+		return errors.EnsureStack(mErr)
+	}
+	if result == nil {
+		return nil
+	}
+	return errors.Wrapf(json.Unmarshal(data, result), "could not decode the response to %s", path)
+}
+
+// ExperimentID returns the ID of the experiment with the given name, creating
+// it if it doesn't exist.
+func (c *Client) ExperimentID(ctx context.Context, name string) (string, error) {
+	var got struct {
+		Experiment struct {
+			ExperimentID string `json:"experiment_id"`
+		} `json:"experiment"`
+	}
+	err := c.do(ctx, http.MethodGet, "experiments/get-by-name", url.Values{"experiment_name": {name}}, &got)
+	if err == nil {
+		return got.Experiment.ExperimentID, nil
+	}
+	if !IsNotFound(err) {
+		return "", err
+	}
+	var created struct {
+		ExperimentID string `json:"experiment_id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "experiments/create", map[string]interface{}{"name": name}, &created); err != nil {
+		if IsAlreadyExists(err) {
+			// created concurrently
+			return c.ExperimentID(ctx, name)
+		}
+		return "", err
+	}
+	return created.ExperimentID, nil
+}
+
+// FindRun returns the first run in the experiment with the given tag, or nil
+// if there isn't one.
+func (c *Client) FindRun(ctx context.Context, experimentID string, tag Tag) (*Run, error) {
+	var result struct {
+		Runs []*Run `json:"runs"`
+	}
+	if err := c.do(ctx, http.MethodPost, "runs/search", map[string]interface{}{
+		"experiment_ids": []string{experimentID},
+		"filter":         fmt.Sprintf("tags.`%s` = '%s'", tag.Key, strings.ReplaceAll(tag.Value, "'", "\\'")),
+		"max_results":    1,
+	}, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Runs) == 0 {
+		return nil, nil
+	}
+	return result.Runs[0], nil
+}
+
+// CreateRun starts a run in the experiment.
+func (c *Client) CreateRun(ctx context.Context, experimentID, name string, start time.Time, tags []Tag) (*Run, error) {
+	var result struct {
+		Run *Run `json:"run"`
+	}
+	if err := c.do(ctx, http.MethodPost, "runs/create", map[string]interface{}{
+		"experiment_id": experimentID,
+		"run_name":      name,
+		"start_time":    start.UnixMilli(),
+		"tags":          tags,
+	}, &result); err != nil {
+		return nil, err
+	}
+	if result.Run == nil {
+		return nil, errors.New("the registry didn't return the created run")
+	}
+	return result.Run, nil
+}
+
+// LogMetrics logs metrics to a run.
+func (c *Client) LogMetrics(ctx context.Context, runID string, metrics []Metric) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+	return c.do(ctx, http.MethodPost, "runs/log-batch", map[string]interface{}{
+		"run_id":  runID,
+		"metrics": metrics,
+	}, nil)
+}
+
+// FinishRun marks a run as finished at end.
+func (c *Client) FinishRun(ctx context.Context, runID string, end time.Time) error {
+	return c.do(ctx, http.MethodPost, "runs/update", map[string]interface{}{
+		"run_id":   runID,
+		"status":   RunStatusFinished,
+		"end_time": end.UnixMilli(),
+	}, nil)
+}
+
+// CreateRegisteredModel creates a registered model, if it doesn't exist.
+func (c *Client) CreateRegisteredModel(ctx context.Context, name string) error {
+	if err := c.do(ctx, http.MethodPost, "registered-models/create", map[string]interface{}{"name": name}, nil); err != nil && !IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// FindModelVersion returns the version of the model created from the run, or
+// nil if there isn't one.
+func (c *Client) FindModelVersion(ctx context.Context, name, runID string) (*ModelVersion, error) {
+	var result struct {
+		ModelVersions []*ModelVersion `json:"model_versions"`
+	}
+	filter := fmt.Sprintf("run_id = '%s'", runID)
+	if err := c.do(ctx, http.MethodGet, "model-versions/search", url.Values{"filter": {filter}}, &result); err != nil {
+		return nil, err
+	}
+	for _, mv := range result.ModelVersions {
+		if mv.Name == name {
+			return mv, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateModelVersion adds a version to a registered model.
+func (c *Client) CreateModelVersion(ctx context.Context, mv *ModelVersion, description string) (*ModelVersion, error) {
+	var result struct {
+		ModelVersion *ModelVersion `json:"model_version"`
+	}
+	if err := c.do(ctx, http.MethodPost, "model-versions/create", map[string]interface{}{
+		"name":        mv.Name,
+		"source":      mv.Source,
+		"run_id":      mv.RunID,
+		"run_link":    mv.RunLink,
+		"tags":        mv.Tags,
+		"description": description,
+	}, &result); err != nil {
+		return nil, err
+	}
+	if result.ModelVersion == nil {
+		return nil, errors.New("the registry didn't return the created model version")
+	}
+	return result.ModelVersion, nil
+}
+
+// ParseMetrics returns the numeric fields of a JSON object as metrics logged
+// at ts. Nested objects are flattened, with their keys joined by ".".
+func ParseMetrics(data []byte, ts time.Time) ([]Metric, error) {
+	var obj map[string]interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, errors.Wrap(err, "metrics must be a JSON object")
+	}
+	var metrics []Metric
+	var walk func(prefix string, obj map[string]interface{})
+	walk = func(prefix string, obj map[string]interface{}) {
+		for k, v := range obj {
+			switch v := v.(type) {
+			case float64:
+				metrics = append(metrics, Metric{Key: prefix + k, Value: v, Timestamp: ts.UnixMilli()})
+			case map[string]interface{}:
+				walk(prefix+k+".", v)
+			}
+		}
+	}
+	walk("", obj)
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Key < metrics[j].Key })
+	return metrics, nil
+}
+
+// IsRetryable returns true if a request that failed with err may succeed if
+// it's retried: if the registry couldn't be reached, or failed with a server
+// error or rate limit.
+func IsRetryable(err error) bool {
+	var mErr *Error
+	if !errors.As(err, &mErr) {
+		return true
+	}
+	return mErr.StatusCode >= 500 || mErr.StatusCode == http.StatusTooManyRequests
+}
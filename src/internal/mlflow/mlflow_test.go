@@ -0,0 +1,183 @@
+package mlflow
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+// fakeRegistry implements the parts of the MLflow API used by Client.
+type fakeRegistry struct {
+	experiments map[string]string
+	runs        map[string]*Run
+	runTags     map[string][]Tag
+	metrics     map[string][]Metric
+	models      map[string]bool
+	versions    []*ModelVersion
+}
+
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{
+		experiments: make(map[string]string),
+		runs:        make(map[string]*Run),
+		runTags:     make(map[string][]Tag),
+		metrics:     make(map[string][]Metric),
+		models:      make(map[string]bool),
+	}
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var req map[string]interface{}
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	reply := func(v interface{}) { json.NewEncoder(w).Encode(v) } //nolint:errcheck
+	fail := func(status int, code string) {
+		w.WriteHeader(status)
+		reply(map[string]string{"error_code": code, "message": code})
+	}
+	switch strings.TrimPrefix(r.URL.Path, "/api/2.0/mlflow/") {
+	case "experiments/get-by-name":
+		id, ok := f.experiments[r.URL.Query().Get("experiment_name")]
+		if !ok {
+			fail(http.StatusNotFound, ErrorCodeNotFound)
+			return
+		}
+		reply(map[string]interface{}{"experiment": map[string]string{"experiment_id": id}})
+	case "experiments/create":
+		id := string(rune('0' + len(f.experiments)))
+		f.experiments[req["name"].(string)] = id
+		reply(map[string]string{"experiment_id": id})
+	case "runs/search":
+		for id, tags := range f.runTags {
+			for _, tag := range tags {
+				if req["filter"] == "tags.`"+tag.Key+"` = '"+tag.Value+"'" {
+					reply(map[string]interface{}{"runs": []*Run{f.runs[id]}})
+					return
+				}
+			}
+		}
+		reply(map[string]interface{}{})
+	case "runs/create":
+		id := "run" + string(rune('0'+len(f.runs)))
+		f.runs[id] = &Run{Info: RunInfo{RunID: id, ExperimentID: req["experiment_id"].(string), Status: "RUNNING"}}
+		for _, tag := range req["tags"].([]interface{}) {
+			tag := tag.(map[string]interface{})
+			f.runTags[id] = append(f.runTags[id], Tag{Key: tag["key"].(string), Value: tag["value"].(string)})
+		}
+		reply(map[string]interface{}{"run": f.runs[id]})
+	case "runs/log-batch":
+		id := req["run_id"].(string)
+		for _, m := range req["metrics"].([]interface{}) {
+			m := m.(map[string]interface{})
+			f.metrics[id] = append(f.metrics[id], Metric{Key: m["key"].(string), Value: m["value"].(float64)})
+		}
+		reply(map[string]interface{}{})
+	case "runs/update":
+		f.runs[req["run_id"].(string)].Info.Status = req["status"].(string)
+		reply(map[string]interface{}{})
+	case "registered-models/create":
+		if f.models[req["name"].(string)] {
+			fail(http.StatusBadRequest, ErrorCodeAlreadyExists)
+			return
+		}
+		f.models[req["name"].(string)] = true
+		reply(map[string]interface{}{})
+	case "model-versions/search":
+		var result []*ModelVersion
+		for _, mv := range f.versions {
+			if r.URL.Query().Get("filter") == "run_id = '"+mv.RunID+"'" {
+				result = append(result, mv)
+			}
+		}
+		reply(map[string]interface{}{"model_versions": result})
+	case "model-versions/create":
+		mv := &ModelVersion{
+			Name:    req["name"].(string),
+			Version: string(rune('1' + len(f.versions))),
+			Source:  req["source"].(string),
+			RunID:   req["run_id"].(string),
+		}
+		f.versions = append(f.versions, mv)
+		reply(map[string]interface{}{"model_version": mv})
+	default:
+		fail(http.StatusNotFound, "ENDPOINT_NOT_FOUND")
+	}
+}
+
+func TestRegisterModelVersion(t *testing.T) {
+	fake := newFakeRegistry()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	ctx := context.Background()
+	c := NewClient(server.URL+"/", "token")
+
+	expID, err := c.ExperimentID(ctx, "exp")
+	require.NoError(t, err)
+	again, err := c.ExperimentID(ctx, "exp")
+	require.NoError(t, err)
+	require.Equal(t, expID, again)
+
+	tag := Tag{Key: "pachyderm.job", Value: "abc"}
+	run, err := c.FindRun(ctx, expID, tag)
+	require.NoError(t, err)
+	require.True(t, run == nil)
+	run, err = c.CreateRun(ctx, expID, "pipeline@abc", time.Now(), []Tag{tag})
+	require.NoError(t, err)
+	found, err := c.FindRun(ctx, expID, tag)
+	require.NoError(t, err)
+	require.Equal(t, run.Info.RunID, found.Info.RunID)
+
+	metrics, err := ParseMetrics([]byte(`{"accuracy": 0.9, "name": "x", "loss": {"train": 0.1}}`), time.Now())
+	require.NoError(t, err)
+	require.NoError(t, c.LogMetrics(ctx, run.Info.RunID, metrics))
+	require.NoError(t, c.FinishRun(ctx, run.Info.RunID, time.Now()))
+	require.Equal(t, RunStatusFinished, fake.runs[run.Info.RunID].Info.Status)
+	require.Equal(t, 2, len(fake.metrics[run.Info.RunID]))
+
+	require.NoError(t, c.CreateRegisteredModel(ctx, "model"))
+	require.NoError(t, c.CreateRegisteredModel(ctx, "model"))
+	mv, err := c.FindModelVersion(ctx, "model", run.Info.RunID)
+	require.NoError(t, err)
+	require.True(t, mv == nil)
+	mv, err = c.CreateModelVersion(ctx, &ModelVersion{Name: "model", Source: "pfs://repo@master=abc", RunID: run.Info.RunID}, "")
+	require.NoError(t, err)
+	require.Equal(t, "1", mv.Version)
+	found2, err := c.FindModelVersion(ctx, "model", run.Info.RunID)
+	require.NoError(t, err)
+	require.Equal(t, mv.Version, found2.Version)
+}
+
+func TestErrors(t *testing.T) {
+	server := httptest.NewServer(newFakeRegistry())
+	defer server.Close()
+	_, err := NewClient(server.URL, "wrong").ExperimentID(context.Background(), "exp")
+	require.YesError(t, err)
+	require.False(t, IsRetryable(err))
+	require.True(t, IsRetryable(&Error{StatusCode: http.StatusServiceUnavailable}))
+	require.True(t, IsNotFound(&Error{StatusCode: http.StatusNotFound, Code: ErrorCodeNotFound}))
+}
+
+func TestParseMetrics(t *testing.T) {
+	metrics, err := ParseMetrics([]byte(`{"b": 2, "a": {"x": 1, "y": "no"}, "c": [1]}`), time.Unix(1, 0))
+	require.NoError(t, err)
+	require.Equal(t, []Metric{
+		{Key: "a.x", Value: 1, Timestamp: 1000},
+		{Key: "b", Value: 2, Timestamp: 1000},
+	}, metrics)
+	_, err = ParseMetrics([]byte(`[1, 2]`), time.Now())
+	require.YesError(t, err)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudfront/sign"
 	"github.com/aws/aws-sdk-go/service/s3"
@@ -166,7 +167,7 @@ func (c *amazonClient) Put(ctx context.Context, name string, r io.Reader) (retEr
 		Bucket:          aws.String(c.bucket),
 		Key:             aws.String(name),
 		ContentEncoding: aws.String("application/octet-stream"),
-	})
+	}, s3manager.WithUploaderRequestOptions(principalOption(ctx)))
 	return errors.EnsureStack(err)
 }
 
@@ -191,6 +192,7 @@ func (c *amazonClient) Walk(ctx context.Context, name string, fn func(name strin
 			}
 			return true
 		},
+		principalOption(ctx),
 	); err != nil {
 		return errors.EnsureStack(err)
 	}
@@ -244,7 +246,7 @@ func (c *amazonClient) Get(ctx context.Context, name string, w io.Writer) (retEr
 			Bucket: aws.String(c.bucket),
 			Key:    aws.String(name),
 		}
-		getObjectOutput, err := c.s3.GetObjectWithContext(ctx, objIn)
+		getObjectOutput, err := c.s3.GetObjectWithContext(ctx, objIn, principalOption(ctx))
 		if err != nil {
 			return errors.EnsureStack(err)
 		}
@@ -264,7 +266,7 @@ func (c *amazonClient) Delete(ctx context.Context, name string) (retErr error) {
 	_, err := c.s3.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
 		Bucket: aws.String(c.bucket),
 		Key:    aws.String(name),
-	})
+	}, principalOption(ctx))
 	return errors.EnsureStack(err)
 }
 
@@ -272,7 +274,7 @@ func (c *amazonClient) Exists(ctx context.Context, name string) (bool, error) {
 	_, err := c.s3.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
 		Bucket: aws.String(c.bucket),
 		Key:    aws.String(name),
-	})
+	}, principalOption(ctx))
 	tracing.TagAnySpan(ctx, "err", err)
 	if err != nil {
 		err = c.transformError(err, name)
@@ -284,6 +286,24 @@ func (c *amazonClient) Exists(ctx context.Context, name string) (bool, error) {
 	return true, nil
 }
 
+// principalQueryParam is the query parameter that requests are tagged with
+// the principal they're made on behalf of. S3 ignores query parameters that
+// start with "x-", but records them in its server access logs.
+const principalQueryParam = "x-pachyderm-principal"
+
+// principalOption tags a request with the principal of ctx, if it has one.
+func principalOption(ctx context.Context) request.Option {
+	return func(r *request.Request) {
+		principal := PrincipalFromContext(ctx)
+		if principal == "" {
+			return
+		}
+		q := r.HTTPRequest.URL.Query()
+		q.Set(principalQueryParam, principal)
+		r.HTTPRequest.URL.RawQuery = q.Encode()
+	}
+}
+
 func (c *amazonClient) BucketURL() ObjectStoreURL {
 	return ObjectStoreURL{
 		Scheme: "s3",
@@ -0,0 +1,85 @@
+package obj
+
+import (
+	"context"
+	"io"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+type principalKey struct{}
+
+// WithPrincipal returns a context that attributes the object storage
+// operations made with it to principal.
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal that the object storage
+// operations made with ctx are attributed to, or "" if they're made by
+// Pachyderm itself.
+func PrincipalFromContext(ctx context.Context) string {
+	principal, _ := ctx.Value(principalKey{}).(string)
+	return principal
+}
+
+// AuditObjClient wraps the given object client 'c', attributing each call to
+// the principal that 'principal' returns for the call's context. Clients that
+// support it tag their requests with the principal, so that it's recorded in
+// the object store's access logs. If logAccess is set, each call is also
+// logged with its principal.
+func AuditObjClient(c Client, principal func(context.Context) string, logAccess bool) Client {
+	return &auditObjClient{Client: c, principal: principal, logAccess: logAccess}
+}
+
+var _ Client = &auditObjClient{}
+
+type auditObjClient struct {
+	Client
+	principal func(context.Context) string
+	logAccess bool
+}
+
+func (o *auditObjClient) audit(ctx context.Context, op, name string) context.Context {
+	principal := o.principal(ctx)
+	if o.logAccess {
+		logged := principal
+		if logged == "" {
+			logged = "pachd"
+		}
+		log.WithFields(log.Fields{
+			"op":        op,
+			"object":    name,
+			"bucket":    o.BucketURL().String(),
+			"principal": logged,
+		}).Info("object storage access")
+	}
+	return WithPrincipal(ctx, principal)
+}
+
+// Put implements the corresponding method in the Client interface
+func (o *auditObjClient) Put(ctx context.Context, name string, r io.Reader) error {
+	return errors.EnsureStack(o.Client.Put(o.audit(ctx, "put", name), name, r))
+}
+
+// Get implements the corresponding method in the Client interface
+func (o *auditObjClient) Get(ctx context.Context, name string, w io.Writer) error {
+	return errors.EnsureStack(o.Client.Get(o.audit(ctx, "get", name), name, w))
+}
+
+// Delete implements the corresponding method in the Client interface
+func (o *auditObjClient) Delete(ctx context.Context, name string) error {
+	return errors.EnsureStack(o.Client.Delete(o.audit(ctx, "delete", name), name))
+}
+
+// Walk implements the corresponding method in the Client interface
+func (o *auditObjClient) Walk(ctx context.Context, prefix string, fn func(name string) error) error {
+	return errors.EnsureStack(o.Client.Walk(o.audit(ctx, "walk", prefix), prefix, fn))
+}
+
+// Exists implements the corresponding method in the Client interface
+func (o *auditObjClient) Exists(ctx context.Context, name string) (bool, error) {
+	exists, err := o.Client.Exists(o.audit(ctx, "exists", name), name)
+	return exists, errors.EnsureStack(err)
+}
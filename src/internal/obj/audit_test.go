@@ -0,0 +1,62 @@
+package obj
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestAuditClient(t *testing.T) {
+	t.Parallel()
+	TestSuite(t, func(t testing.TB) Client {
+		c := newTestLocalClient(t)
+		return AuditObjClient(c, func(context.Context) string { return "pipeline:edges" }, true)
+	})
+}
+
+// principalClient records the principal of the last call made to it.
+type principalClient struct {
+	Client
+	principal string
+}
+
+func (c *principalClient) Put(ctx context.Context, name string, r io.Reader) error {
+	c.principal = PrincipalFromContext(ctx)
+	return c.Client.Put(ctx, name, r)
+}
+
+func TestAuditClientPrincipal(t *testing.T) {
+	type key struct{}
+	inner := &principalClient{Client: newTestLocalClient(t)}
+	c := AuditObjClient(inner, func(ctx context.Context) string {
+		principal, _ := ctx.Value(key{}).(string)
+		return principal
+	}, false)
+	ctx := context.WithValue(context.Background(), key{}, "robot:ci")
+	require.NoError(t, c.Put(ctx, "a", bytes.NewReader([]byte("a"))))
+	require.Equal(t, "robot:ci", inner.principal)
+	require.NoError(t, c.Put(context.Background(), "b", bytes.NewReader([]byte("b"))))
+	require.Equal(t, "", inner.principal)
+}
+
+func TestPrincipalOption(t *testing.T) {
+	newRequest := func() *request.Request {
+		httpReq, err := http.NewRequest(http.MethodGet, "https://bucket.s3.amazonaws.com/chunk?versionId=1", nil)
+		require.NoError(t, err)
+		return &request.Request{HTTPRequest: httpReq}
+	}
+	r := newRequest()
+	principalOption(WithPrincipal(context.Background(), "pipeline:edges"))(r)
+	require.Equal(t, "pipeline:edges", r.HTTPRequest.URL.Query().Get(principalQueryParam))
+	require.Equal(t, "1", r.HTTPRequest.URL.Query().Get("versionId"))
+
+	r = newRequest()
+	principalOption(context.Background())(r)
+	require.Equal(t, "versionId=1", r.HTTPRequest.URL.RawQuery)
+}
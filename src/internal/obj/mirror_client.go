@@ -0,0 +1,194 @@
+package obj
+
+import (
+	"context"
+	"io"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pacherr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	mirrorFailoverMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pfs_object_storage_mirror",
+		Name:      "failovers_total",
+		Help:      "Number of object storage operations served by the secondary bucket because the primary failed, by operation",
+	}, []string{"op"})
+	mirrorErrorMetric = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pfs_object_storage_mirror",
+		Name:      "async_errors_total",
+		Help:      "Number of asynchronous writes to the secondary bucket that failed",
+	})
+)
+
+var _ Client = &MirrorClient{}
+
+// MirrorClient is a Client that mirrors writes from a primary bucket to a
+// secondary bucket and fails over to the secondary bucket for reads when the
+// primary is unavailable.
+type MirrorClient struct {
+	primary, secondary Client
+	async              bool
+	log                *logrus.Logger
+}
+
+// MirrorReport is the result of a MirrorClient consistency check.
+type MirrorReport struct {
+	// MissingSecondary is the objects that are in the primary bucket but not
+	// in the secondary bucket.
+	MissingSecondary []string
+	// MissingPrimary is the objects that are in the secondary bucket but not
+	// in the primary bucket. These are usually objects whose deletion from
+	// the secondary bucket failed, so they are never repaired.
+	MissingPrimary []string
+	// Repaired is the number of objects copied to the secondary bucket.
+	Repaired int
+}
+
+// NewMirrorClient returns a client that writes to both primary and secondary.
+// If async is false, a write only succeeds once it has succeeded in both
+// buckets. If async is true, a write succeeds once it has succeeded in the
+// primary bucket and is copied to the secondary bucket in the background;
+// objects that fail to copy are repaired by Check.
+func NewMirrorClient(primary, secondary Client, async bool) *MirrorClient {
+	return &MirrorClient{
+		primary:   primary,
+		secondary: secondary,
+		async:     async,
+		log:       logrus.StandardLogger(),
+	}
+}
+
+func (c *MirrorClient) Put(ctx context.Context, name string, r io.Reader) error {
+	if c.async {
+		if err := c.primary.Put(ctx, name, r); err != nil {
+			return errors.EnsureStack(err)
+		}
+		go func() {
+			// The request context may be cancelled as soon as Put returns.
+			if err := Copy(context.Background(), c.primary, c.secondary, name, name); err != nil {
+				mirrorErrorMetric.Inc()
+				c.log.Errorf("obj.MirrorClient: mirroring %v to secondary: %v", name, err)
+			}
+		}()
+		return nil
+	}
+	return miscutil.WithPipe(func(w io.Writer) error {
+		return errors.EnsureStack(c.primary.Put(ctx, name, io.TeeReader(r, w)))
+	}, func(r2 io.Reader) error {
+		return errors.EnsureStack(c.secondary.Put(ctx, name, r2))
+	})
+}
+
+func (c *MirrorClient) Get(ctx context.Context, name string, w io.Writer) error {
+	cw := &countWriter{w: w}
+	err := c.primary.Get(ctx, name, cw)
+	if err == nil || cw.n > 0 || ctx.Err() != nil {
+		// Once data has been written to w it's not safe to retry.
+		return errors.EnsureStack(err)
+	}
+	if err2 := c.secondary.Get(ctx, name, w); err2 != nil {
+		return errors.EnsureStack(err)
+	}
+	mirrorFailoverMetric.WithLabelValues("get").Inc()
+	return nil
+}
+
+func (c *MirrorClient) Delete(ctx context.Context, name string) error {
+	if err := c.primary.Delete(ctx, name); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if err := c.secondary.Delete(ctx, name); err != nil && !pacherr.IsNotExist(err) {
+		return errors.EnsureStack(err)
+	}
+	return nil
+}
+
+func (c *MirrorClient) Walk(ctx context.Context, prefix string, fn func(name string) error) error {
+	var called bool
+	err := c.primary.Walk(ctx, prefix, func(name string) error {
+		called = true
+		return fn(name)
+	})
+	if err == nil || called || ctx.Err() != nil {
+		return errors.EnsureStack(err)
+	}
+	mirrorFailoverMetric.WithLabelValues("walk").Inc()
+	return errors.EnsureStack(c.secondary.Walk(ctx, prefix, fn))
+}
+
+func (c *MirrorClient) Exists(ctx context.Context, name string) (bool, error) {
+	exists, err := c.primary.Exists(ctx, name)
+	if err == nil || ctx.Err() != nil {
+		return exists, errors.EnsureStack(err)
+	}
+	mirrorFailoverMetric.WithLabelValues("exists").Inc()
+	exists, err = c.secondary.Exists(ctx, name)
+	return exists, errors.EnsureStack(err)
+}
+
+func (c *MirrorClient) BucketURL() ObjectStoreURL {
+	return c.primary.BucketURL()
+}
+
+// Check compares the objects under prefix in both buckets. If repair is
+// true, objects missing from the secondary bucket are copied to it.
+func (c *MirrorClient) Check(ctx context.Context, prefix string, repair bool) (*MirrorReport, error) {
+	primary, err := walkAll(ctx, c.primary, prefix)
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := walkAll(ctx, c.secondary, prefix)
+	if err != nil {
+		return nil, err
+	}
+	report := &MirrorReport{}
+	for name := range primary {
+		if !secondary[name] {
+			report.MissingSecondary = append(report.MissingSecondary, name)
+		}
+	}
+	for name := range secondary {
+		if !primary[name] {
+			report.MissingPrimary = append(report.MissingPrimary, name)
+		}
+	}
+	if !repair {
+		return report, nil
+	}
+	for _, name := range report.MissingSecondary {
+		if err := Copy(ctx, c.primary, c.secondary, name, name); err != nil {
+			return nil, err
+		}
+		report.Repaired++
+	}
+	return report, nil
+}
+
+func walkAll(ctx context.Context, c Client, prefix string) (map[string]bool, error) {
+	names := make(map[string]bool)
+	if err := c.Walk(ctx, prefix, func(name string) error {
+		names[name] = true
+		return nil
+	}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return names, nil
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, errors.EnsureStack(err)
+}
@@ -0,0 +1,49 @@
+package obj
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestMirrorClient(t *testing.T) {
+	t.Parallel()
+	TestSuite(t, func(t testing.TB) Client {
+		return NewMirrorClient(newTestLocalClient(t), newTestLocalClient(t), false)
+	})
+}
+
+func TestMirrorClientFailover(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	primary, secondary := newTestLocalClient(t), newTestLocalClient(t)
+	c := NewMirrorClient(primary, secondary, false)
+	require.NoError(t, c.Put(ctx, "a", strings.NewReader("foo")))
+	require.NoError(t, primary.Delete(ctx, "a"))
+	buf := &bytes.Buffer{}
+	require.NoError(t, c.Get(ctx, "a", buf))
+	require.Equal(t, "foo", buf.String())
+}
+
+func TestMirrorClientCheck(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	primary, secondary := newTestLocalClient(t), newTestLocalClient(t)
+	c := NewMirrorClient(primary, secondary, false)
+	require.NoError(t, primary.Put(ctx, "a", strings.NewReader("foo")))
+	require.NoError(t, secondary.Put(ctx, "b", strings.NewReader("bar")))
+	report, err := c.Check(ctx, "", false)
+	require.NoError(t, err)
+	require.ElementsEqual(t, []string{"a"}, report.MissingSecondary)
+	require.ElementsEqual(t, []string{"b"}, report.MissingPrimary)
+	report, err = c.Check(ctx, "", true)
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Repaired)
+	report, err = c.Check(ctx, "", false)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(report.MissingSecondary))
+	require.ElementsEqual(t, []string{"b"}, report.MissingPrimary)
+}
@@ -0,0 +1,295 @@
+package obj
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
+)
+
+var (
+	nodeCacheHitMetric = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pfs_node_cache",
+		Name:      "hits_total",
+		Help:      "Number of object storage gets served from the node's shared cache",
+	})
+	nodeCacheMissMetric = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pfs_node_cache",
+		Name:      "misses_total",
+		Help:      "Number of object storage gets that were not served from the node's shared cache",
+	})
+	nodeCacheEvictionMetric = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pfs_node_cache",
+		Name:      "evictions_total",
+		Help:      "Number of objects evicted from the node's shared cache",
+	})
+	nodeCacheSizeMetric = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pfs_node_cache",
+		Name:      "size_bytes",
+		Help:      "Size of the objects in the node's shared cache",
+	})
+	nodeCacheObjectsMetric = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pfs_node_cache",
+		Name:      "objects",
+		Help:      "Number of objects in the node's shared cache",
+	})
+)
+
+// staleStagingAge is how old a file in the node cache's staging directory
+// must be before it's considered abandoned, by a process that died while
+// writing it, and removed.
+const staleStagingAge = time.Hour
+
+var _ Client = &nodeCacheClient{}
+
+// nodeCacheClient caches the objects read from slow in a directory that's
+// shared by every process on a node, such as a hostPath volume, so that an
+// object read by one pod on the node doesn't have to be read from object
+// storage again by another. Objects are immutable once written, so they can
+// be shared without coordination. The processes that use the cache only add
+// to it; evicting objects when it's full is left to EvictNodeCache.
+type nodeCacheClient struct {
+	slow Client
+	dir  string
+	log  *logrus.Logger
+}
+
+// NewNodeCacheClient returns slow wrapped in a read-through cache, in the
+// shared directory dir.
+func NewNodeCacheClient(slow Client, dir string) (Client, error) {
+	c := &nodeCacheClient{
+		slow: slow,
+		dir:  filepath.Clean(dir),
+		log:  logrus.StandardLogger(),
+	}
+	// unlike the local client, the staging directory isn't cleared, since
+	// other processes may be writing to it
+	for _, d := range []string{c.objectsDir(), c.stagingDir()} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+	}
+	return c, nil
+}
+
+func (c *nodeCacheClient) Get(ctx context.Context, p string, w io.Writer) error {
+	if hit, err := c.getCached(p, w); err != nil {
+		return err
+	} else if hit {
+		nodeCacheHitMetric.Inc()
+		return nil
+	}
+	nodeCacheMissMetric.Inc()
+	return c.getSlow(ctx, p, w)
+}
+
+// getCached writes the cached object to w and returns true, if it's cached.
+func (c *nodeCacheClient) getCached(p string, w io.Writer) (_ bool, retErr error) {
+	final := c.objectPath(p)
+	f, err := os.Open(final)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errors.EnsureStack(err)
+	}
+	defer func() {
+		if err := f.Close(); retErr == nil {
+			retErr = errors.EnsureStack(err)
+		}
+	}()
+	// the modification time records when the object was last read, which
+	// is what EvictNodeCache evicts by
+	now := time.Now()
+	if err := os.Chtimes(final, now, now); err != nil && !os.IsNotExist(err) {
+		c.log.Warnf("obj.nodeCacheClient: updating access time: %v", err)
+	}
+	if _, err := io.Copy(w, f); err != nil {
+		return false, errors.EnsureStack(err)
+	}
+	return true, nil
+}
+
+// getSlow reads the object from c.slow, writing it to w and to the cache. A
+// failure to write the cache is logged, rather than failing the read.
+func (c *nodeCacheClient) getSlow(ctx context.Context, p string, w io.Writer) error {
+	staging := filepath.Join(c.stagingDir(), uuid.NewWithoutDashes())
+	f, err := os.Create(staging)
+	if err != nil {
+		c.log.Errorf("obj.nodeCacheClient: writing to cache: %v", err)
+		return errors.EnsureStack(c.slow.Get(ctx, p, w))
+	}
+	defer func() {
+		if err := os.Remove(staging); err != nil && !os.IsNotExist(err) {
+			c.log.Errorf("obj.nodeCacheClient: removing staged object: %v", err)
+		}
+	}()
+	sw := &stagingWriter{f: f}
+	err = c.slow.Get(ctx, p, io.MultiWriter(w, sw))
+	if closeErr := f.Close(); sw.err == nil {
+		sw.err = closeErr
+	}
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if sw.err != nil {
+		c.log.Errorf("obj.nodeCacheClient: writing to cache: %v", sw.err)
+		return nil
+	}
+	if err := os.Rename(staging, c.objectPath(p)); err != nil {
+		c.log.Errorf("obj.nodeCacheClient: writing to cache: %v", err)
+	}
+	return nil
+}
+
+func (c *nodeCacheClient) Put(ctx context.Context, p string, r io.Reader) error {
+	return errors.EnsureStack(c.slow.Put(ctx, p, r))
+}
+
+func (c *nodeCacheClient) Delete(ctx context.Context, p string) error {
+	if err := c.slow.Delete(ctx, p); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if err := os.Remove(c.objectPath(p)); err != nil && !os.IsNotExist(err) {
+		return errors.EnsureStack(err)
+	}
+	return nil
+}
+
+func (c *nodeCacheClient) Exists(ctx context.Context, p string) (bool, error) {
+	res, err := c.slow.Exists(ctx, p)
+	return res, errors.EnsureStack(err)
+}
+
+func (c *nodeCacheClient) Walk(ctx context.Context, p string, cb func(p string) error) error {
+	return errors.EnsureStack(c.slow.Walk(ctx, p, cb))
+}
+
+func (c *nodeCacheClient) BucketURL() ObjectStoreURL {
+	return c.slow.BucketURL()
+}
+
+func (c *nodeCacheClient) objectsDir() string {
+	return filepath.Join(c.dir, "objects")
+}
+
+func (c *nodeCacheClient) stagingDir() string {
+	return filepath.Join(c.dir, "staging")
+}
+
+func (c *nodeCacheClient) objectPath(p string) string {
+	return filepath.Join(c.objectsDir(), base64.URLEncoding.EncodeToString([]byte(p)))
+}
+
+// stagingWriter writes to the staged copy of an object until a write fails,
+// and then discards the rest of the object, so that a full disk doesn't fail
+// the read the object is being cached by.
+type stagingWriter struct {
+	f   *os.File
+	err error
+}
+
+func (sw *stagingWriter) Write(data []byte) (int, error) {
+	if sw.err == nil {
+		_, sw.err = sw.f.Write(data)
+	}
+	return len(data), nil
+}
+
+// InitNodeCache creates the directories of the node cache in dir, writable by
+// every user, since the pods that share it may run as different users.
+func InitNodeCache(dir string) error {
+	c := &nodeCacheClient{dir: filepath.Clean(dir)}
+	for _, d := range []string{c.objectsDir(), c.stagingDir()} {
+		if err := os.MkdirAll(d, 0777); err != nil {
+			return errors.EnsureStack(err)
+		}
+		// MkdirAll's permissions are subject to the umask
+		if err := os.Chmod(d, 0777); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	return nil
+}
+
+// NodeCacheStats describes the contents of a node cache.
+type NodeCacheStats struct {
+	Objects   int
+	SizeBytes int64
+	Evicted   int
+}
+
+// EvictNodeCache evicts the least recently read objects from the node cache
+// in dir until the objects in it take up at most maxBytes, and removes
+// abandoned staged objects. It should be run periodically by a single process
+// on each node, and returns the cache's stats after the eviction.
+func EvictNodeCache(ctx context.Context, dir string, maxBytes int64) (*NodeCacheStats, error) {
+	c := &nodeCacheClient{dir: filepath.Clean(dir)}
+	staged, err := os.ReadDir(c.stagingDir())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.EnsureStack(err)
+	}
+	for _, ent := range staged {
+		info, err := ent.Info()
+		if err != nil {
+			continue
+		}
+		if time.Since(info.ModTime()) > staleStagingAge {
+			if err := os.Remove(filepath.Join(c.stagingDir(), ent.Name())); err != nil && !os.IsNotExist(err) {
+				return nil, errors.EnsureStack(err)
+			}
+		}
+	}
+	ents, err := os.ReadDir(c.objectsDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &NodeCacheStats{}, nil
+		}
+		return nil, errors.EnsureStack(err)
+	}
+	stats := &NodeCacheStats{}
+	var infos []os.FileInfo
+	for _, ent := range ents {
+		info, err := ent.Info()
+		if err != nil {
+			// evicted by someone else since it was listed
+			continue
+		}
+		infos = append(infos, info)
+		stats.SizeBytes += info.Size()
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ModTime().Before(infos[j].ModTime())
+	})
+	for len(infos) > 0 && stats.SizeBytes > maxBytes {
+		if err := ctx.Err(); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		if err := os.Remove(filepath.Join(c.objectsDir(), infos[0].Name())); err != nil && !os.IsNotExist(err) {
+			return nil, errors.EnsureStack(err)
+		}
+		stats.SizeBytes -= infos[0].Size()
+		stats.Evicted++
+		infos = infos[1:]
+	}
+	stats.Objects = len(infos)
+	nodeCacheEvictionMetric.Add(float64(stats.Evicted))
+	nodeCacheSizeMetric.Set(float64(stats.SizeBytes))
+	nodeCacheObjectsMetric.Set(float64(stats.Objects))
+	return stats, nil
+}
@@ -0,0 +1,66 @@
+package obj
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestNodeCacheClient(t *testing.T) {
+	t.Parallel()
+	TestSuite(t, func(t testing.TB) Client {
+		c, err := NewNodeCacheClient(newTestLocalClient(t), t.TempDir())
+		require.NoError(t, err)
+		return c
+	})
+}
+
+func TestNodeCacheShared(t *testing.T) {
+	ctx := context.Background()
+	slow := newTestLocalClient(t)
+	dir := t.TempDir()
+	c1, err := NewNodeCacheClient(slow, dir)
+	require.NoError(t, err)
+	c2, err := NewNodeCacheClient(slow, dir)
+	require.NoError(t, err)
+	require.NoError(t, slow.Put(ctx, "a", bytes.NewReader([]byte("content"))))
+	buf := &bytes.Buffer{}
+	require.NoError(t, c1.Get(ctx, "a", buf))
+	require.Equal(t, "content", buf.String())
+	// the object was cached by c1, so c2 reads it without the slow client
+	require.NoError(t, slow.Delete(ctx, "a"))
+	buf.Reset()
+	require.NoError(t, c2.Get(ctx, "a", buf))
+	require.Equal(t, "content", buf.String())
+}
+
+func TestEvictNodeCache(t *testing.T) {
+	ctx := context.Background()
+	slow := newTestLocalClient(t)
+	dir := t.TempDir()
+	c, err := NewNodeCacheClient(slow, dir)
+	require.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		p := fmt.Sprintf("object%d", i)
+		require.NoError(t, slow.Put(ctx, p, bytes.NewReader(make([]byte, 10))))
+		require.NoError(t, c.Get(ctx, p, &bytes.Buffer{}))
+		// make each object read after the previous one
+		readAt := time.Now().Add(time.Duration(i-4) * time.Minute)
+		require.NoError(t, os.Chtimes(c.(*nodeCacheClient).objectPath(p), readAt, readAt))
+	}
+	stats, err := EvictNodeCache(ctx, dir, 25)
+	require.NoError(t, err)
+	require.Equal(t, 2, stats.Evicted)
+	require.Equal(t, 2, stats.Objects)
+	require.Equal(t, int64(20), stats.SizeBytes)
+	// the least recently read objects were evicted
+	for i, cached := range []bool{false, false, true, true} {
+		_, err := os.Stat(c.(*nodeCacheClient).objectPath(fmt.Sprintf("object%d", i)))
+		require.Equal(t, cached, err == nil)
+	}
+}
@@ -1,11 +1,15 @@
 package pachtmpl
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
 	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 )
 
@@ -36,6 +40,74 @@ func RenderTemplate(tmpl string, args map[string]string) (string, error) {
 	return output, nil
 }
 
+// Parameters returns the value of each parameter of the template tmpl when
+// it's rendered with args, including the parameters left at their defaults.
+// The parameters of a template are those of its top-level function. Values
+// that aren't strings are returned as JSON.
+func Parameters(tmpl string, args map[string]string) (map[string]string, error) {
+	node, err := jsonnet.SnippetToAST("main", tmpl)
+	if err != nil {
+		return nil, errors.Wrapf(err, "template err")
+	}
+	fn := topLevelFunction(node)
+	if fn == nil {
+		return map[string]string{}, nil
+	}
+	// replace the body of the top-level function with an object of its
+	// parameters, so that they are evaluated the way the template would
+	// evaluate them
+	var names, fields []string
+	for _, p := range fn.Parameters {
+		names = append(names, string(p.Name))
+		fields = append(fields, fmt.Sprintf("%q: %s", p.Name, p.Name))
+	}
+	helper, err := jsonnet.SnippetToAST("parameters", fmt.Sprintf("function(%s) {%s}", strings.Join(names, ", "), strings.Join(fields, ", ")))
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	fn.Body = topLevelFunction(helper).Body
+	vm := newVM(nil)
+	for key, value := range args {
+		vm.TLAVar(key, value)
+	}
+	output, err := vm.Evaluate(node)
+	if err != nil {
+		return nil, errors.Wrapf(err, "template err")
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(output), &values); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	result := make(map[string]string)
+	for name, value := range values {
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, value); err != nil {
+				return nil, errors.EnsureStack(err)
+			}
+			s = compact.String()
+		}
+		result[name] = s
+	}
+	return result, nil
+}
+
+// topLevelFunction returns the function a template evaluates to, or nil if it
+// doesn't evaluate to a function.
+func topLevelFunction(node ast.Node) *ast.Function {
+	for {
+		switch n := node.(type) {
+		case *ast.Function:
+			return n
+		case *ast.Local:
+			node = n.Body
+		default:
+			return nil
+		}
+	}
+}
+
 // Eval evaluates the jsonnet at entrypointPath using fsContext to resolve imports
 func Eval(fsContext map[string][]byte, entrypointPath string) ([]byte, error) {
 	vm := newVM(fsContext)
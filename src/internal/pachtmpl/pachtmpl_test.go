@@ -22,3 +22,31 @@ func TestEval(t *testing.T) {
 	require.NoError(t, err)
 	t.Log(string(output))
 }
+
+func TestParameters(t *testing.T) {
+	tmpl := `
+		local suffix(name) = name + "-v2";
+		function(name, image="pachyderm/opencv:1.0", parallelism=2, input=name + "-images")
+		{
+			pipeline: {name: suffix(name)},
+			transform: {image: image},
+			parallelism_spec: {constant: parallelism},
+			input: {pfs: {repo: input, glob: "/*"}},
+		}
+	`
+	params, err := Parameters(tmpl, map[string]string{"name": "edges", "image": "pachyderm/opencv:2.0"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"name":        "edges",
+		"image":       "pachyderm/opencv:2.0",
+		"parallelism": "2",
+		"input":       "edges-images",
+	}, params)
+
+	_, err = Parameters(tmpl, nil)
+	require.YesError(t, err)
+
+	params, err = Parameters(`{pipeline: {name: "edges"}}`, nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(params))
+}
@@ -0,0 +1,25 @@
+package pbutil
+
+import (
+	"github.com/gogo/protobuf/types"
+)
+
+// InTimeRange returns true if ts is strictly after 'after' and strictly before
+// 'before'. A nil bound isn't checked, and a nil ts is only in the range if
+// both bounds are nil.
+func InTimeRange(ts, after, before *types.Timestamp) bool {
+	if after != nil && (ts == nil || !timestampLess(after, ts)) {
+		return false
+	}
+	if before != nil && (ts == nil || !timestampLess(ts, before)) {
+		return false
+	}
+	return true
+}
+
+func timestampLess(a, b *types.Timestamp) bool {
+	if a.Seconds != b.Seconds {
+		return a.Seconds < b.Seconds
+	}
+	return a.Nanos < b.Nanos
+}
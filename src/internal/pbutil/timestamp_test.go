@@ -0,0 +1,28 @@
+package pbutil
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestInTimeRange(t *testing.T) {
+	ts := func(seconds int64, nanos int32) *types.Timestamp {
+		return &types.Timestamp{Seconds: seconds, Nanos: nanos}
+	}
+	require.True(t, InTimeRange(ts(10, 0), nil, nil))
+	require.True(t, InTimeRange(nil, nil, nil))
+	require.False(t, InTimeRange(nil, ts(1, 0), nil))
+	require.False(t, InTimeRange(nil, nil, ts(1, 0)))
+
+	require.True(t, InTimeRange(ts(10, 0), ts(9, 0), ts(11, 0)))
+	require.True(t, InTimeRange(ts(10, 1), ts(10, 0), nil))
+	require.True(t, InTimeRange(ts(10, 0), nil, ts(10, 1)))
+	// the bounds are exclusive
+	require.False(t, InTimeRange(ts(10, 0), ts(10, 0), nil))
+	require.False(t, InTimeRange(ts(10, 0), nil, ts(10, 0)))
+	require.False(t, InTimeRange(ts(8, 0), ts(9, 0), ts(11, 0)))
+	require.False(t, InTimeRange(ts(12, 0), ts(9, 0), ts(11, 0)))
+}
@@ -2,6 +2,7 @@
 package pfsdb
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/gogo/protobuf/proto"
@@ -18,6 +19,14 @@ const (
 	reposCollectionName    = "repos"
 	branchesCollectionName = "branches"
 	commitsCollectionName  = "commits"
+	schemasCollectionName  = "schemas"
+	projectsCollectionName = "projects"
+
+	retentionPoliciesCollectionName  = "retention_policies"
+	erasuresCollectionName           = "erasures"
+	validationPoliciesCollectionName = "validation_policies"
+	viewsCollectionName              = "views"
+	uploadsCollectionName            = "uploads"
 )
 
 var ReposTypeIndex = &col.Index{
@@ -187,3 +196,230 @@ func CollectionsV0() []col.PostgresCollection {
 		col.NewPostgresCollection(branchesCollectionName, nil, nil, nil, branchesIndexes),
 	}
 }
+
+var SchemasRepoIndex = &col.Index{
+	Name: "repo",
+	Extract: func(val proto.Message) string {
+		return RepoKey(val.(*pfs.SchemaInfo).Repo)
+	},
+}
+
+var schemasIndexes = []*col.Index{SchemasRepoIndex}
+
+// SchemaKey is the key of the schema for pathPattern in repo.
+func SchemaKey(repo *pfs.Repo, pathPattern string) string {
+	return RepoKey(repo) + ":" + pathPattern
+}
+
+// Schemas returns a collection of the schemas that files in repos are
+// validated against.
+func Schemas(db *pachsql.DB, listener col.PostgresListener) col.PostgresCollection {
+	return col.NewPostgresCollection(
+		schemasCollectionName,
+		db,
+		listener,
+		&pfs.SchemaInfo{},
+		schemasIndexes,
+	)
+}
+
+// SchemasCollectionsV0 returns the schemas collection for
+// postgres-initialization purposes. This collection is not usable for
+// querying.
+// DO NOT MODIFY THIS FUNCTION
+// IT HAS BEEN USED IN A RELEASED MIGRATION
+func SchemasCollectionsV0() []col.PostgresCollection {
+	return []col.PostgresCollection{
+		col.NewPostgresCollection(schemasCollectionName, nil, nil, nil, schemasIndexes),
+	}
+}
+
+// DefaultProjectName is the name of the project that repos which aren't
+// created in a project are in. It always exists, and isn't stored.
+const DefaultProjectName = "default"
+
+// ProjectName returns the name of project, treating nil as the default
+// project.
+func ProjectName(project *pfs.Project) string {
+	if project == nil || project.Name == "" {
+		return DefaultProjectName
+	}
+	return project.Name
+}
+
+// Projects returns a collection of projects
+func Projects(db *pachsql.DB, listener col.PostgresListener) col.PostgresCollection {
+	return col.NewPostgresCollection(
+		projectsCollectionName,
+		db,
+		listener,
+		&pfs.ProjectInfo{},
+		nil,
+		col.WithNotFoundMessage(func(key interface{}) string {
+			return fmt.Sprintf("project %s not found", key)
+		}),
+		col.WithExistsMessage(func(key interface{}) string {
+			return fmt.Sprintf("project %s already exists", key)
+		}),
+	)
+}
+
+// ProjectsCollectionsV0 returns the projects collection for
+// postgres-initialization purposes. This collection is not usable for
+// querying.
+// DO NOT MODIFY THIS FUNCTION
+// IT HAS BEEN USED IN A RELEASED MIGRATION
+func ProjectsCollectionsV0() []col.PostgresCollection {
+	return []col.PostgresCollection{
+		col.NewPostgresCollection(projectsCollectionName, nil, nil, nil, nil),
+	}
+}
+
+var RetentionPoliciesRepoIndex = &col.Index{
+	Name: "repo",
+	Extract: func(val proto.Message) string {
+		return RepoKey(val.(*pfs.RetentionPolicyInfo).Repo)
+	},
+}
+
+var retentionPoliciesIndexes = []*col.Index{RetentionPoliciesRepoIndex}
+
+// RetentionPolicyKey is the key of the retention policy of branch in repo,
+// or of repo itself if branch is empty.
+func RetentionPolicyKey(repo *pfs.Repo, branch string) string {
+	return RepoKey(repo) + ":" + branch
+}
+
+// RetentionPolicies returns a collection of the retention policies of repos
+// and branches.
+func RetentionPolicies(db *pachsql.DB, listener col.PostgresListener) col.PostgresCollection {
+	return col.NewPostgresCollection(
+		retentionPoliciesCollectionName,
+		db,
+		listener,
+		&pfs.RetentionPolicyInfo{},
+		retentionPoliciesIndexes,
+	)
+}
+
+// RetentionPoliciesCollectionsV0 returns the retention policies collection for
+// postgres-initialization purposes. This collection is not usable for
+// querying.
+// DO NOT MODIFY THIS FUNCTION
+// IT HAS BEEN USED IN A RELEASED MIGRATION
+func RetentionPoliciesCollectionsV0() []col.PostgresCollection {
+	return []col.PostgresCollection{
+		col.NewPostgresCollection(retentionPoliciesCollectionName, nil, nil, nil, retentionPoliciesIndexes),
+	}
+}
+
+var ErasuresRepoIndex = &col.Index{
+	Name: "repo",
+	Extract: func(val proto.Message) string {
+		return RepoKey(val.(*pfs.ErasureInfo).Repo)
+	},
+}
+
+var erasuresIndexes = []*col.Index{ErasuresRepoIndex}
+
+// ErasureKey is the key of the erasure with id in repo.
+func ErasureKey(repo *pfs.Repo, id string) string {
+	return RepoKey(repo) + ":" + id
+}
+
+// Erasures returns a collection of the audit records of erased files.
+func Erasures(db *pachsql.DB, listener col.PostgresListener) col.PostgresCollection {
+	return col.NewPostgresCollection(
+		erasuresCollectionName,
+		db,
+		listener,
+		&pfs.ErasureInfo{},
+		erasuresIndexes,
+	)
+}
+
+// ErasuresCollectionsV0 returns the erasures collection for
+// postgres-initialization purposes. This collection is not usable for
+// querying.
+// DO NOT MODIFY THIS FUNCTION
+// IT HAS BEEN USED IN A RELEASED MIGRATION
+func ErasuresCollectionsV0() []col.PostgresCollection {
+	return []col.PostgresCollection{
+		col.NewPostgresCollection(erasuresCollectionName, nil, nil, nil, erasuresIndexes),
+	}
+}
+
+// ValidationPolicies returns a collection of the validation policies of repos,
+// keyed by RepoKey.
+func ValidationPolicies(db *pachsql.DB, listener col.PostgresListener) col.PostgresCollection {
+	return col.NewPostgresCollection(
+		validationPoliciesCollectionName,
+		db,
+		listener,
+		&pfs.ValidationPolicyInfo{},
+		nil,
+	)
+}
+
+// ValidationPoliciesCollectionsV0 returns the validation policies collection
+// for postgres-initialization purposes. This collection is not usable for
+// querying.
+// DO NOT MODIFY THIS FUNCTION
+// IT HAS BEEN USED IN A RELEASED MIGRATION
+func ValidationPoliciesCollectionsV0() []col.PostgresCollection {
+	return []col.PostgresCollection{
+		col.NewPostgresCollection(validationPoliciesCollectionName, nil, nil, nil, nil),
+	}
+}
+
+// Views returns a collection of views, keyed by name.
+func Views(db *pachsql.DB, listener col.PostgresListener) col.PostgresCollection {
+	return col.NewPostgresCollection(
+		viewsCollectionName,
+		db,
+		listener,
+		&pfs.ViewInfo{},
+		nil,
+	)
+}
+
+// ViewsCollectionsV0 returns the views collection for postgres-initialization
+// purposes. This collection is not usable for querying.
+// DO NOT MODIFY THIS FUNCTION
+// IT HAS BEEN USED IN A RELEASED MIGRATION
+func ViewsCollectionsV0() []col.PostgresCollection {
+	return []col.PostgresCollection{
+		col.NewPostgresCollection(viewsCollectionName, nil, nil, nil, nil),
+	}
+}
+
+var UploadsRepoIndex = &col.Index{
+	Name: "repo",
+	Extract: func(val proto.Message) string {
+		return RepoKey(val.(*pfs.UploadSession).File.Commit.Branch.Repo)
+	},
+}
+
+var uploadsIndexes = []*col.Index{UploadsRepoIndex}
+
+// Uploads returns a collection of resumable upload sessions, keyed by ID.
+func Uploads(db *pachsql.DB, listener col.PostgresListener) col.PostgresCollection {
+	return col.NewPostgresCollection(
+		uploadsCollectionName,
+		db,
+		listener,
+		&pfs.UploadSession{},
+		uploadsIndexes,
+	)
+}
+
+// UploadsCollectionsV0 returns the uploads collection for
+// postgres-initialization purposes. This collection is not usable for
+// querying.
+// DO NOT MODIFY THIS FUNCTION
+// IT HAS BEEN USED IN A RELEASED MIGRATION
+func UploadsCollectionsV0() []col.PostgresCollection {
+	return []col.PostgresCollection{
+		col.NewPostgresCollection(uploadsCollectionName, nil, nil, nil, uploadsIndexes),
+	}
+}
@@ -25,3 +25,14 @@ func WithHeaderCallback(cb func(*tar.Header) error) DownloadOption {
 		dc.headerCallback = cb
 	}
 }
+
+// WithReadahead configures a lazy download call to prefetch the content of
+// up to files of the files after the one being read, buffering at most
+// sizeBytes of content. Files are prefetched in the order they're downloaded
+// in, across the download calls of a Downloader.
+func WithReadahead(files int, sizeBytes int64) DownloadOption {
+	return func(dc *downloadConfig) {
+		dc.readaheadFiles = files
+		dc.readaheadBytes = sizeBytes
+	}
+}
@@ -0,0 +1,133 @@
+package pfssync
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// readahead prefetches the content of lazily downloaded files while the
+// files before them are read. Files are assumed to be read in the order they
+// were downloaded, so when a file is opened, the next files are fetched into
+// memory, up to a limit on the buffered bytes, and are served from there
+// when they're opened in turn.
+type readahead struct {
+	files     int
+	sizeBytes int64
+	eg        *errgroup.Group
+
+	mu       sync.Mutex
+	buffered int64
+	entries  []*readaheadEntry
+	closed   bool
+}
+
+type readaheadState int
+
+const (
+	// pending files haven't been opened or prefetched yet.
+	pending readaheadState = iota
+	// streaming files were opened before they were prefetched, so they're
+	// streamed to the reader instead.
+	streaming
+	// prefetching files are being (or have been) fetched into a buffer.
+	prefetching
+)
+
+type readaheadEntry struct {
+	size  int64
+	fetch func(io.Writer) error
+	state readaheadState
+	done  chan struct{}
+	buf   *bytes.Buffer
+	err   error
+}
+
+func newReadahead(files int, sizeBytes int64, eg *errgroup.Group) *readahead {
+	return &readahead{
+		files:     files,
+		sizeBytes: sizeBytes,
+		eg:        eg,
+	}
+}
+
+// add registers a file of the given size, whose content fetch writes, and
+// returns its index, to be passed to read.
+func (r *readahead) add(size int64, fetch func(io.Writer) error) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, &readaheadEntry{
+		size:  size,
+		fetch: fetch,
+		done:  make(chan struct{}),
+	})
+	return len(r.entries) - 1
+}
+
+// read writes the content of the i'th file to w, from its buffer if it was
+// prefetched, and starts prefetching the files after it.
+func (r *readahead) read(i int, w io.Writer) error {
+	r.mu.Lock()
+	e := r.entries[i]
+	if e.state == pending {
+		e.state = streaming
+	}
+	state := e.state
+	r.prefetch(i)
+	r.mu.Unlock()
+	if state == streaming {
+		return e.fetch(w)
+	}
+	<-e.done
+	if e.err != nil {
+		return e.err
+	}
+	_, err := io.Copy(w, e.buf)
+	r.mu.Lock()
+	e.buf = nil
+	r.buffered -= e.size
+	// the window can move further now that there's room in the buffer
+	r.prefetch(i)
+	r.mu.Unlock()
+	return errors.EnsureStack(err)
+}
+
+// prefetch starts fetching the pending files in the window after the i'th
+// one, in order, until the buffer is full. r.mu must be held.
+func (r *readahead) prefetch(i int) {
+	if r.closed {
+		return
+	}
+	for j := i + 1; j < len(r.entries) && j <= i+r.files; j++ {
+		e := r.entries[j]
+		if e.state != pending {
+			continue
+		}
+		if r.buffered+e.size > r.sizeBytes {
+			// stop rather than skip ahead, so that the files that are
+			// prefetched are the next ones to be read
+			return
+		}
+		e.state = prefetching
+		r.buffered += e.size
+		r.eg.Go(func() error {
+			defer close(e.done)
+			buf := &bytes.Buffer{}
+			buf.Grow(int(e.size))
+			// errors are returned by read, if the file is ever read
+			e.err = e.fetch(buf)
+			e.buf = buf
+			return nil
+		})
+	}
+}
+
+// close stops prefetching files.
+func (r *readahead) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+}
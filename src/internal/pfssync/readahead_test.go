@@ -0,0 +1,89 @@
+package pfssync
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+type fetchCounter struct {
+	mu      sync.Mutex
+	fetches map[int]int
+}
+
+func (fc *fetchCounter) fetch(i int, content string) func(io.Writer) error {
+	return func(w io.Writer) error {
+		fc.mu.Lock()
+		fc.fetches[i]++
+		fc.mu.Unlock()
+		_, err := w.Write([]byte(content))
+		return errors.EnsureStack(err)
+	}
+}
+
+func (fc *fetchCounter) count(i int) int {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.fetches[i]
+}
+
+func TestReadahead(t *testing.T) {
+	eg := &errgroup.Group{}
+	fc := &fetchCounter{fetches: make(map[int]int)}
+	// a window of 2 files, and room for 2 of them in the buffer
+	r := newReadahead(2, 20, eg)
+	for i := 0; i < 5; i++ {
+		r.add(10, fc.fetch(i, fmt.Sprintf("file %04d", i)))
+	}
+	for i := 0; i < 5; i++ {
+		buf := &bytes.Buffer{}
+		require.NoError(t, r.read(i, buf))
+		require.Equal(t, fmt.Sprintf("file %04d", i), buf.String())
+	}
+	require.NoError(t, eg.Wait())
+	// every file is fetched exactly once, whether it was prefetched or not
+	for i := 0; i < 5; i++ {
+		require.Equal(t, 1, fc.count(i))
+	}
+	require.Equal(t, int64(0), r.buffered)
+}
+
+func TestReadaheadBufferLimit(t *testing.T) {
+	eg := &errgroup.Group{}
+	fc := &fetchCounter{fetches: make(map[int]int)}
+	r := newReadahead(3, 15, eg)
+	r.add(10, fc.fetch(0, "0"))
+	r.add(10, fc.fetch(1, "1"))
+	r.add(10, fc.fetch(2, "2"))
+	r.add(100, fc.fetch(3, "3"))
+	require.NoError(t, r.read(0, io.Discard))
+	require.NoError(t, eg.Wait())
+	// only the file after the one being read fits in the buffer
+	require.Equal(t, 1, fc.count(1))
+	require.Equal(t, 0, fc.count(2))
+	require.NoError(t, r.read(1, io.Discard))
+	require.NoError(t, r.read(2, io.Discard))
+	require.NoError(t, eg.Wait())
+	// files that are bigger than the buffer are streamed when they're read
+	require.Equal(t, 0, fc.count(3))
+	require.NoError(t, r.read(3, io.Discard))
+	require.Equal(t, 1, fc.count(3))
+}
+
+func TestReadaheadFetchError(t *testing.T) {
+	eg := &errgroup.Group{}
+	r := newReadahead(1, 100, eg)
+	r.add(10, func(w io.Writer) error { return nil })
+	r.add(10, func(w io.Writer) error { return errors.New("fetch failed") })
+	require.NoError(t, r.read(0, io.Discard))
+	// the prefetch's error is returned when the file is read
+	require.NoError(t, eg.Wait())
+	require.YesError(t, r.read(1, io.Discard))
+}
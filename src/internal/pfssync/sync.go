@@ -24,6 +24,7 @@ type downloader struct {
 	pipes      map[string]struct{}
 	eg         *errgroup.Group
 	done       bool
+	readahead  *readahead
 }
 
 // WithDownloader provides a scoped environment for a Downloader.
@@ -35,6 +36,9 @@ func WithDownloader(pachClient *CacheClient, cb func(Downloader) error) (retErr
 	}
 	defer func() {
 		d.done = true
+		if d.readahead != nil {
+			d.readahead.close()
+		}
 		if err := d.closePipes(); retErr == nil {
 			retErr = err
 		}
@@ -68,6 +72,8 @@ func (d *downloader) closePipes() (retErr error) {
 type downloadConfig struct {
 	lazy, empty    bool
 	headerCallback func(*tar.Header) error
+	readaheadFiles int
+	readaheadBytes int64
 }
 
 // Download a PFS file to a location on the local filesystem.
@@ -79,6 +85,9 @@ func (d *downloader) Download(storageRoot string, file *pfs.File, opts ...Downlo
 	for _, opt := range opts {
 		opt(dc)
 	}
+	if dc.lazy && dc.readaheadFiles > 0 && d.readahead == nil {
+		d.readahead = newReadahead(dc.readaheadFiles, dc.readaheadBytes, d.eg)
+	}
 	if dc.lazy || dc.empty {
 		return d.downloadInfo(storageRoot, file, dc)
 	}
@@ -102,7 +111,7 @@ func (d *downloader) downloadInfo(storageRoot string, file *pfs.File, config *do
 			return errors.EnsureStack(err)
 		}
 		if config.lazy {
-			return d.makePipe(fullPath, func(w io.Writer) error {
+			fetch := func(w io.Writer) error {
 				r, err := d.pachClient.GetFileTAR(file.Commit, fi.File.Path)
 				if err != nil {
 					return err
@@ -119,7 +128,14 @@ func (d *downloader) downloadInfo(storageRoot string, file *pfs.File, config *do
 					}
 					return errors.EnsureStack(f.Content(w))
 				}, true)
-			})
+			}
+			if d.readahead != nil && config.readaheadFiles > 0 {
+				i := d.readahead.add(int64(fi.SizeBytes), fetch)
+				return d.makePipe(fullPath, func(w io.Writer) error {
+					return d.readahead.read(i, w)
+				})
+			}
+			return d.makePipe(fullPath, fetch)
 		}
 		f, err := os.Create(fullPath)
 		if err != nil {
@@ -0,0 +1,51 @@
+package pgetcd
+
+import (
+	"context"
+	"math"
+	"net"
+	"time"
+
+	etcd "go.etcd.io/etcd/client/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// endpoint is the address the client is given, which it never dials.
+const endpoint = "http://postgres"
+
+// NewClient serves s in process, and returns an etcd client that's
+// connected to it. The server stops when ctx is done. opts are added to the
+// client's dial options.
+func (s *Server) NewClient(ctx context.Context, opts ...grpc.DialOption) (*etcd.Client, error) {
+	listener := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer(
+		grpc.MaxRecvMsgSize(math.MaxInt32),
+		grpc.MaxSendMsgSize(math.MaxInt32),
+	)
+	s.Register(gs)
+	go gs.Serve(listener)
+	go func() {
+		<-ctx.Done()
+		gs.Stop()
+	}()
+	opts = append(opts, grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		conn, err := listener.Dial()
+		return conn, errors.EnsureStack(err)
+	}))
+	client, err := etcd.New(etcd.Config{
+		Context:            ctx,
+		Endpoints:          []string{endpoint},
+		DialTimeout:        time.Minute,
+		DialOptions:        opts,
+		MaxCallSendMsgSize: math.MaxInt32,
+		MaxCallRecvMsgSize: math.MaxInt32,
+	})
+	if err != nil {
+		gs.Stop()
+		return nil, errors.EnsureStack(err)
+	}
+	return client, nil
+}
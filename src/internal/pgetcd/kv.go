@@ -0,0 +1,504 @@
+package pgetcd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+)
+
+// kv is a row of etcd.kv.
+type kv struct {
+	Key            []byte `db:"key"`
+	Value          []byte `db:"value"`
+	CreateRevision int64  `db:"create_revision"`
+	ModRevision    int64  `db:"mod_revision"`
+	Version        int64  `db:"version"`
+	Lease          int64  `db:"lease"`
+}
+
+const kvColumns = `key, value, create_revision, mod_revision, version, lease`
+
+func (r *kv) proto() *mvccpb.KeyValue {
+	return &mvccpb.KeyValue{
+		Key:            r.Key,
+		Value:          r.Value,
+		CreateRevision: r.CreateRevision,
+		ModRevision:    r.ModRevision,
+		Version:        r.Version,
+		Lease:          r.Lease,
+	}
+}
+
+// state is the store, as seen by a transaction.
+type state struct {
+	ctx context.Context
+	tx  *pachsql.Tx
+	// revision is the store's revision when the transaction started, its
+	// changes are made at revision+1.
+	revision  int64
+	compacted int64
+	// changed holds the keys that the transaction changed, as etcd doesn't
+	// allow a key to be changed twice at the same revision.
+	changed map[string]bool
+}
+
+// query builds the arguments of a statement.
+type query struct {
+	args []interface{}
+}
+
+func (q *query) arg(v interface{}) string {
+	q.args = append(q.args, v)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+// keyRange returns the condition that selects etcd's range of keys from key
+// to end: only key if end is empty, every key from key on if end is "\x00",
+// and otherwise the keys from key up to, but not including, end.
+func (q *query) keyRange(key, end []byte) string {
+	switch {
+	case len(end) == 0:
+		return "key = " + q.arg(key)
+	case bytes.Equal(end, []byte{0}):
+		return "key >= " + q.arg(key)
+	default:
+		return fmt.Sprintf("key >= %s AND key < %s", q.arg(key), q.arg(end))
+	}
+}
+
+// at returns the table of keys at a revision before the current one: the
+// keys that haven't changed since, and the previous values of the keys that
+// have.
+func (q *query) at(revision int64) string {
+	return fmt.Sprintf(`(
+		WITH changed AS (
+			SELECT DISTINCT ON (key) key, prev_value AS value, prev_create_revision AS create_revision,
+				prev_mod_revision AS mod_revision, prev_version AS version, prev_lease AS lease
+			FROM etcd.events WHERE revision > %[1]s ORDER BY key, revision, sub
+		)
+		SELECT %[2]s FROM changed WHERE value IS NOT NULL
+		UNION ALL
+		SELECT %[2]s FROM etcd.kv WHERE key NOT IN (SELECT key FROM changed)
+	) AS kv`, q.arg(revision), kvColumns)
+}
+
+// order returns the ordering of a range's keys.
+func order(req *pb.RangeRequest) string {
+	column := map[pb.RangeRequest_SortTarget]string{
+		pb.RangeRequest_KEY:     "key",
+		pb.RangeRequest_VERSION: "version",
+		pb.RangeRequest_CREATE:  "create_revision",
+		pb.RangeRequest_MOD:     "mod_revision",
+		pb.RangeRequest_VALUE:   "value",
+	}[req.SortTarget]
+	if column == "" {
+		column = "key"
+	}
+	direction := "ASC"
+	if req.SortOrder == pb.RangeRequest_DESCEND {
+		direction = "DESC"
+	}
+	if column == "key" {
+		return "key " + direction
+	}
+	return fmt.Sprintf("%s %s, key ASC", column, direction)
+}
+
+func (st *state) rangeKeys(req *pb.RangeRequest) (*pb.RangeResponse, error) {
+	if err := checkRevision(req.Revision, st.revision, st.compacted); err != nil {
+		return nil, err
+	}
+	q := &query{}
+	table := "etcd.kv"
+	if req.Revision > 0 && req.Revision < st.revision {
+		table = q.at(req.Revision)
+	}
+	conds := []string{q.keyRange(req.Key, req.RangeEnd)}
+	if req.MinModRevision > 0 {
+		conds = append(conds, "mod_revision >= "+q.arg(req.MinModRevision))
+	}
+	if req.MaxModRevision > 0 {
+		conds = append(conds, "mod_revision <= "+q.arg(req.MaxModRevision))
+	}
+	if req.MinCreateRevision > 0 {
+		conds = append(conds, "create_revision >= "+q.arg(req.MinCreateRevision))
+	}
+	if req.MaxCreateRevision > 0 {
+		conds = append(conds, "create_revision <= "+q.arg(req.MaxCreateRevision))
+	}
+	where := strings.Join(conds, " AND ")
+	resp := &pb.RangeResponse{}
+	if req.CountOnly {
+		if err := st.tx.GetContext(st.ctx, &resp.Count, `SELECT COUNT(*) FROM `+table+` WHERE `+where, q.args...); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		return resp, nil
+	}
+	stmt := fmt.Sprintf(`SELECT %s, COUNT(*) OVER () AS count FROM %s WHERE %s ORDER BY %s`, kvColumns, table, where, order(req))
+	if req.Limit > 0 {
+		stmt += " LIMIT " + q.arg(req.Limit)
+	}
+	var rows []struct {
+		kv
+		Count int64 `db:"count"`
+	}
+	if err := st.tx.SelectContext(st.ctx, &rows, stmt, q.args...); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	for _, row := range rows {
+		resp.Count = row.Count
+		if req.KeysOnly {
+			row.Value = nil
+		}
+		resp.Kvs = append(resp.Kvs, row.proto())
+	}
+	resp.More = int64(len(resp.Kvs)) < resp.Count
+	return resp, nil
+}
+
+func (st *state) get(key []byte) (*kv, error) {
+	var rows []*kv
+	if err := st.tx.SelectContext(st.ctx, &rows, `SELECT `+kvColumns+` FROM etcd.kv WHERE key = $1`, key); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return rows[0], nil
+}
+
+func (st *state) put(req *pb.PutRequest) (*pb.PutResponse, error) {
+	prev, err := st.get(req.Key)
+	if err != nil {
+		return nil, err
+	}
+	value, lease := req.Value, req.Lease
+	if req.IgnoreValue || req.IgnoreLease {
+		if prev == nil {
+			return nil, rpctypes.ErrGRPCKeyNotFound
+		}
+		if req.IgnoreValue {
+			value = prev.Value
+		}
+		if req.IgnoreLease {
+			lease = prev.Lease
+		}
+	}
+	if lease != 0 {
+		var exists bool
+		if err := st.tx.GetContext(st.ctx, &exists, `SELECT EXISTS (SELECT 1 FROM etcd.leases WHERE id = $1)`, lease); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		if !exists {
+			return nil, rpctypes.ErrGRPCLeaseNotFound
+		}
+	}
+	if value == nil {
+		value = []byte{}
+	}
+	next := &kv{
+		Key:            req.Key,
+		Value:          value,
+		CreateRevision: st.revision + 1,
+		ModRevision:    st.revision + 1,
+		Version:        1,
+		Lease:          lease,
+	}
+	if prev != nil {
+		next.CreateRevision = prev.CreateRevision
+		next.Version = prev.Version + 1
+	}
+	if _, err := st.tx.ExecContext(st.ctx, `
+		INSERT INTO etcd.kv (`+kvColumns+`) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (key) DO UPDATE SET value = $2, create_revision = $3, mod_revision = $4, version = $5, lease = $6
+	`, next.Key, next.Value, next.CreateRevision, next.ModRevision, next.Version, next.Lease); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := st.record(false, next, prev); err != nil {
+		return nil, err
+	}
+	resp := &pb.PutResponse{}
+	if req.PrevKv && prev != nil {
+		resp.PrevKv = prev.proto()
+	}
+	return resp, nil
+}
+
+func (st *state) deleteRange(req *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, error) {
+	q := &query{}
+	var deleted []*kv
+	if err := st.tx.SelectContext(st.ctx, &deleted, `DELETE FROM etcd.kv WHERE `+q.keyRange(req.Key, req.RangeEnd)+` RETURNING `+kvColumns, q.args...); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	sort.Slice(deleted, func(i, j int) bool { return bytes.Compare(deleted[i].Key, deleted[j].Key) < 0 })
+	resp := &pb.DeleteRangeResponse{Deleted: int64(len(deleted))}
+	for _, prev := range deleted {
+		if err := st.record(true, &kv{Key: prev.Key, Value: []byte{}, ModRevision: st.revision + 1}, prev); err != nil {
+			return nil, err
+		}
+		if req.PrevKv {
+			resp.PrevKvs = append(resp.PrevKvs, prev.proto())
+		}
+	}
+	return resp, nil
+}
+
+// record adds a change to etcd.events.
+func (st *state) record(deleted bool, next, prev *kv) error {
+	if st.changed[string(next.Key)] {
+		return rpctypes.ErrGRPCDuplicateKey
+	}
+	st.changed[string(next.Key)] = true
+	var prevValue, prevCreateRevision, prevModRevision, prevVersion, prevLease interface{}
+	if prev != nil {
+		prevValue, prevCreateRevision, prevModRevision, prevVersion, prevLease = prev.Value, prev.CreateRevision, prev.ModRevision, prev.Version, prev.Lease
+	}
+	_, err := st.tx.ExecContext(st.ctx, `
+		INSERT INTO etcd.events (revision, sub, deleted, key, value, create_revision, version, lease,
+			prev_value, prev_create_revision, prev_mod_revision, prev_version, prev_lease)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`, st.revision+1, len(st.changed), deleted, next.Key, next.Value, next.CreateRevision, next.Version, next.Lease,
+		prevValue, prevCreateRevision, prevModRevision, prevVersion, prevLease)
+	return errors.EnsureStack(err)
+}
+
+func (st *state) compact(revision int64) error {
+	if revision <= st.compacted {
+		return rpctypes.ErrGRPCCompacted
+	}
+	if revision > st.revision {
+		return rpctypes.ErrGRPCFutureRev
+	}
+	if _, err := st.tx.ExecContext(st.ctx, `DELETE FROM etcd.events WHERE revision < $1`, revision); err != nil {
+		return errors.EnsureStack(err)
+	}
+	_, err := st.tx.ExecContext(st.ctx, `UPDATE etcd.revision SET compacted = $1`, revision)
+	return errors.EnsureStack(err)
+}
+
+// compare reports whether every key in a comparison's range passes it. As
+// in etcd, a comparison of the value of an empty range fails, and other
+// comparisons of an empty range are made against a key's zero value.
+func (st *state) compare(c *pb.Compare) (bool, error) {
+	q := &query{}
+	var rows []*kv
+	if err := st.tx.SelectContext(st.ctx, &rows, `SELECT `+kvColumns+` FROM etcd.kv WHERE `+q.keyRange(c.Key, c.RangeEnd), q.args...); err != nil {
+		return false, errors.EnsureStack(err)
+	}
+	if len(rows) == 0 {
+		if c.Target == pb.Compare_VALUE {
+			return false, nil
+		}
+		return compareKV(c, &kv{}), nil
+	}
+	for _, row := range rows {
+		if !compareKV(c, row) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func compareKV(c *pb.Compare, r *kv) bool {
+	var result int
+	switch c.Target {
+	case pb.Compare_VALUE:
+		result = bytes.Compare(r.Value, c.GetValue())
+	case pb.Compare_CREATE:
+		result = compareInt(r.CreateRevision, c.GetCreateRevision())
+	case pb.Compare_MOD:
+		result = compareInt(r.ModRevision, c.GetModRevision())
+	case pb.Compare_VERSION:
+		result = compareInt(r.Version, c.GetVersion())
+	case pb.Compare_LEASE:
+		result = compareInt(r.Lease, c.GetLease())
+	}
+	switch c.Result {
+	case pb.Compare_EQUAL:
+		return result == 0
+	case pb.Compare_NOT_EQUAL:
+		return result != 0
+	case pb.Compare_GREATER:
+		return result > 0
+	case pb.Compare_LESS:
+		return result < 0
+	}
+	return false
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func (st *state) txn(req *pb.TxnRequest) (*pb.TxnResponse, error) {
+	resp := &pb.TxnResponse{Succeeded: true}
+	for _, c := range req.Compare {
+		ok, err := st.compare(c)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			resp.Succeeded = false
+			break
+		}
+	}
+	ops := req.Success
+	if !resp.Succeeded {
+		ops = req.Failure
+	}
+	for _, op := range ops {
+		var result *pb.ResponseOp
+		switch op := op.Request.(type) {
+		case *pb.RequestOp_RequestRange:
+			r, err := st.rangeKeys(op.RequestRange)
+			if err != nil {
+				return nil, err
+			}
+			result = &pb.ResponseOp{Response: &pb.ResponseOp_ResponseRange{ResponseRange: r}}
+		case *pb.RequestOp_RequestPut:
+			r, err := st.put(op.RequestPut)
+			if err != nil {
+				return nil, err
+			}
+			result = &pb.ResponseOp{Response: &pb.ResponseOp_ResponsePut{ResponsePut: r}}
+		case *pb.RequestOp_RequestDeleteRange:
+			r, err := st.deleteRange(op.RequestDeleteRange)
+			if err != nil {
+				return nil, err
+			}
+			result = &pb.ResponseOp{Response: &pb.ResponseOp_ResponseDeleteRange{ResponseDeleteRange: r}}
+		case *pb.RequestOp_RequestTxn:
+			r, err := st.txn(op.RequestTxn)
+			if err != nil {
+				return nil, err
+			}
+			result = &pb.ResponseOp{Response: &pb.ResponseOp_ResponseTxn{ResponseTxn: r}}
+		default:
+			return nil, rpctypes.ErrGRPCKeyNotFound
+		}
+		resp.Responses = append(resp.Responses, result)
+	}
+	return resp, nil
+}
+
+// readOnly reports whether a transaction can't change the store, so that it
+// needn't wait for other writes.
+func readOnly(req *pb.TxnRequest) bool {
+	for _, op := range append(append([]*pb.RequestOp{}, req.Success...), req.Failure...) {
+		switch op := op.Request.(type) {
+		case *pb.RequestOp_RequestRange:
+		case *pb.RequestOp_RequestTxn:
+			if !readOnly(op.RequestTxn) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// setHeaders sets the header of a transaction's response, and the headers
+// of the responses to its operations, which clients expect to be set too.
+func setHeaders(resp *pb.TxnResponse, header *pb.ResponseHeader) {
+	resp.Header = header
+	for _, op := range resp.Responses {
+		switch op := op.Response.(type) {
+		case *pb.ResponseOp_ResponseRange:
+			op.ResponseRange.Header = header
+		case *pb.ResponseOp_ResponsePut:
+			op.ResponsePut.Header = header
+		case *pb.ResponseOp_ResponseDeleteRange:
+			op.ResponseDeleteRange.Header = header
+		case *pb.ResponseOp_ResponseTxn:
+			setHeaders(op.ResponseTxn, header)
+		}
+	}
+}
+
+// Range returns the keys in a range.
+func (s *Server) Range(ctx context.Context, req *pb.RangeRequest) (*pb.RangeResponse, error) {
+	var resp *pb.RangeResponse
+	var revision int64
+	if err := s.read(ctx, func(st *state) error {
+		var err error
+		revision = st.revision
+		resp, err = st.rangeKeys(req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	resp.Header = s.header(revision)
+	return resp, nil
+}
+
+// Put sets a key's value.
+func (s *Server) Put(ctx context.Context, req *pb.PutRequest) (*pb.PutResponse, error) {
+	var resp *pb.PutResponse
+	revision, err := s.write(ctx, func(st *state) error {
+		var err error
+		resp, err = st.put(req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Header = s.header(revision)
+	return resp, nil
+}
+
+// DeleteRange deletes the keys in a range.
+func (s *Server) DeleteRange(ctx context.Context, req *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, error) {
+	var resp *pb.DeleteRangeResponse
+	revision, err := s.write(ctx, func(st *state) error {
+		var err error
+		resp, err = st.deleteRange(req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Header = s.header(revision)
+	return resp, nil
+}
+
+// Txn makes the operations of one of its branches, depending on whether
+// its comparisons pass, as one change to the store.
+func (s *Server) Txn(ctx context.Context, req *pb.TxnRequest) (*pb.TxnResponse, error) {
+	var resp *pb.TxnResponse
+	cb := func(st *state) error {
+		var err error
+		resp, err = st.txn(req)
+		return err
+	}
+	var revision int64
+	var err error
+	if readOnly(req) {
+		err = s.read(ctx, func(st *state) error {
+			revision = st.revision
+			return cb(st)
+		})
+	} else {
+		revision, err = s.write(ctx, cb)
+	}
+	if err != nil {
+		return nil, err
+	}
+	setHeaders(resp, s.header(revision))
+	return resp, nil
+}
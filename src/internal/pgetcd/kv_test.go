@@ -0,0 +1,80 @@
+package pgetcd
+
+import (
+	"testing"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestKeyRange(t *testing.T) {
+	q := &query{}
+	require.Equal(t, "key = $1", q.keyRange([]byte("a"), nil))
+	require.Equal(t, "key >= $2", q.keyRange([]byte("a"), []byte{0}))
+	require.Equal(t, "key >= $3 AND key < $4", q.keyRange([]byte("a"), []byte("b")))
+	require.Equal(t, 4, len(q.args))
+}
+
+func TestOrder(t *testing.T) {
+	require.Equal(t, "key ASC", order(&pb.RangeRequest{}))
+	require.Equal(t, "key DESC", order(&pb.RangeRequest{SortOrder: pb.RangeRequest_DESCEND}))
+	require.Equal(t, "mod_revision ASC, key ASC", order(&pb.RangeRequest{SortTarget: pb.RangeRequest_MOD}))
+	require.Equal(t, "create_revision DESC, key ASC", order(&pb.RangeRequest{SortTarget: pb.RangeRequest_CREATE, SortOrder: pb.RangeRequest_DESCEND}))
+}
+
+func TestCompareKV(t *testing.T) {
+	r := &kv{Key: []byte("a"), Value: []byte("b"), CreateRevision: 2, ModRevision: 3, Version: 2}
+	for _, c := range []struct {
+		cmp  etcd.Cmp
+		pass bool
+	}{
+		{etcd.Compare(etcd.Value("a"), "=", "b"), true},
+		{etcd.Compare(etcd.Value("a"), ">", "a"), true},
+		{etcd.Compare(etcd.Value("a"), "<", "b"), false},
+		{etcd.Compare(etcd.CreateRevision("a"), "=", 2), true},
+		{etcd.Compare(etcd.ModRevision("a"), "<", 3), false},
+		{etcd.Compare(etcd.Version("a"), "!=", 0), true},
+		{etcd.Compare(etcd.LeaseValue("a"), "=", etcd.NoLease), true},
+	} {
+		cmp := pb.Compare(c.cmp)
+		require.Equal(t, c.pass, compareKV(&cmp, r))
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	get := etcd.OpGet("a")
+	put := etcd.OpPut("a", "b")
+	require.True(t, readOnly(txnRequest(etcd.OpTxn(nil, []etcd.Op{get}, []etcd.Op{get}))))
+	require.False(t, readOnly(txnRequest(etcd.OpTxn(nil, []etcd.Op{get}, []etcd.Op{put}))))
+	require.False(t, readOnly(txnRequest(etcd.OpTxn(nil, []etcd.Op{etcd.OpTxn(nil, []etcd.Op{put}, nil)}, nil))))
+}
+
+func txnRequest(op etcd.Op) *pb.TxnRequest {
+	cmps, thenOps, elseOps := op.Txn()
+	req := &pb.TxnRequest{}
+	for _, c := range cmps {
+		c := pb.Compare(c)
+		req.Compare = append(req.Compare, &c)
+	}
+	for _, op := range thenOps {
+		req.Success = append(req.Success, requestOp(op))
+	}
+	for _, op := range elseOps {
+		req.Failure = append(req.Failure, requestOp(op))
+	}
+	return req
+}
+
+func requestOp(op etcd.Op) *pb.RequestOp {
+	switch {
+	case op.IsGet():
+		return &pb.RequestOp{Request: &pb.RequestOp_RequestRange{RequestRange: &pb.RangeRequest{Key: op.KeyBytes()}}}
+	case op.IsPut():
+		return &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: op.KeyBytes(), Value: op.ValueBytes()}}}
+	case op.IsTxn():
+		return &pb.RequestOp{Request: &pb.RequestOp_RequestTxn{RequestTxn: txnRequest(op)}}
+	}
+	return &pb.RequestOp{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: &pb.DeleteRangeRequest{Key: op.KeyBytes()}}}
+}
@@ -0,0 +1,192 @@
+package pgetcd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"math"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// LeaseGrant creates a lease, which expires unless it's kept alive.
+func (s *Server) LeaseGrant(ctx context.Context, req *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
+	id := req.ID
+	if id == 0 {
+		var err error
+		if id, err = newLeaseID(); err != nil {
+			return nil, err
+		}
+	}
+	var created bool
+	revision, err := s.write(ctx, func(st *state) error {
+		res, err := st.tx.ExecContext(st.ctx, `
+			INSERT INTO etcd.leases (id, ttl, expires) VALUES ($1, $2, CURRENT_TIMESTAMP + $2::FLOAT8 * INTERVAL '1 second')
+			ON CONFLICT DO NOTHING
+		`, id, req.TTL)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		n, err := res.RowsAffected()
+		created = n > 0
+		return errors.EnsureStack(err)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, rpctypes.ErrGRPCLeaseExist
+	}
+	return &pb.LeaseGrantResponse{Header: s.header(revision), ID: id, TTL: req.TTL}, nil
+}
+
+// newLeaseID returns a random lease ID. It doesn't use math/rand, as every
+// server would generate the same IDs unless it was seeded.
+func newLeaseID() (int64, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, errors.EnsureStack(err)
+	}
+	id := int64(binary.BigEndian.Uint64(b[:]) & math.MaxInt64)
+	if id == 0 {
+		id = 1
+	}
+	return id, nil
+}
+
+// LeaseRevoke deletes a lease, and the keys attached to it.
+func (s *Server) LeaseRevoke(ctx context.Context, req *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error) {
+	var found bool
+	revision, err := s.write(ctx, func(st *state) error {
+		var err error
+		found, err = st.revoke(req.ID, false)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, rpctypes.ErrGRPCLeaseNotFound
+	}
+	return &pb.LeaseRevokeResponse{Header: s.header(revision)}, nil
+}
+
+// revoke deletes a lease and its keys, and reports whether it existed. If
+// expired is set, the lease is only deleted if it has expired.
+func (st *state) revoke(id int64, expired bool) (bool, error) {
+	stmt := `DELETE FROM etcd.leases WHERE id = $1`
+	if expired {
+		// the lease may have been kept alive since it was found to expire
+		stmt += ` AND expires < CURRENT_TIMESTAMP`
+	}
+	res, err := st.tx.ExecContext(st.ctx, stmt, id)
+	if err != nil {
+		return false, errors.EnsureStack(err)
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, errors.EnsureStack(err)
+	}
+	var keys [][]byte
+	if err := st.tx.SelectContext(st.ctx, &keys, `SELECT key FROM etcd.kv WHERE lease = $1`, id); err != nil {
+		return false, errors.EnsureStack(err)
+	}
+	for _, key := range keys {
+		if _, err := st.deleteRange(&pb.DeleteRangeRequest{Key: key}); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// LeaseKeepAlive renews leases as the client asks, until it closes the
+// stream. A lease that has expired, or doesn't exist, is renewed with a TTL
+// of 0, as in etcd.
+func (s *Server) LeaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) error {
+	ctx := stream.Context()
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		var ttls []int64
+		if err := s.db.SelectContext(ctx, &ttls, `
+			UPDATE etcd.leases SET expires = CURRENT_TIMESTAMP + ttl::FLOAT8 * INTERVAL '1 second'
+			WHERE id = $1 AND expires >= CURRENT_TIMESTAMP
+			RETURNING ttl
+		`, req.ID); err != nil {
+			return errors.EnsureStack(err)
+		}
+		resp := &pb.LeaseKeepAliveResponse{Header: s.header(0), ID: req.ID}
+		if len(ttls) > 0 {
+			resp.TTL = ttls[0]
+		}
+		if err := stream.Send(resp); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+}
+
+// LeaseTimeToLive returns how long a lease has left, and optionally its
+// keys. The TTL of a lease that doesn't exist is -1, as in etcd.
+func (s *Server) LeaseTimeToLive(ctx context.Context, req *pb.LeaseTimeToLiveRequest) (*pb.LeaseTimeToLiveResponse, error) {
+	resp := &pb.LeaseTimeToLiveResponse{ID: req.ID, TTL: -1}
+	var revision int64
+	if err := s.read(ctx, func(st *state) error {
+		revision = st.revision
+		var leases []struct {
+			TTL       int64 `db:"ttl"`
+			Remaining int64 `db:"remaining"`
+		}
+		if err := st.tx.SelectContext(st.ctx, &leases, `
+			SELECT ttl, GREATEST(CEIL(EXTRACT(EPOCH FROM expires - CURRENT_TIMESTAMP)), 0)::BIGINT AS remaining
+			FROM etcd.leases WHERE id = $1
+		`, req.ID); err != nil {
+			return errors.EnsureStack(err)
+		}
+		if len(leases) == 0 {
+			return nil
+		}
+		resp.GrantedTTL, resp.TTL = leases[0].TTL, leases[0].Remaining
+		if req.Keys {
+			return errors.EnsureStack(st.tx.SelectContext(st.ctx, &resp.Keys, `SELECT key FROM etcd.kv WHERE lease = $1 ORDER BY key`, req.ID))
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	resp.Header = s.header(revision)
+	return resp, nil
+}
+
+// LeaseLeases lists the leases.
+func (s *Server) LeaseLeases(ctx context.Context, req *pb.LeaseLeasesRequest) (*pb.LeaseLeasesResponse, error) {
+	var ids []int64
+	if err := s.db.SelectContext(ctx, &ids, `SELECT id FROM etcd.leases ORDER BY id`); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	resp := &pb.LeaseLeasesResponse{Header: s.header(0)}
+	for _, id := range ids {
+		resp.Leases = append(resp.Leases, &pb.LeaseStatus{ID: id})
+	}
+	return resp, nil
+}
+
+// expireLeases revokes the leases that have expired.
+func (s *Server) expireLeases(ctx context.Context) error {
+	var ids []int64
+	if err := s.db.SelectContext(ctx, &ids, `SELECT id FROM etcd.leases WHERE expires < CURRENT_TIMESTAMP`); err != nil {
+		return errors.EnsureStack(err)
+	}
+	for _, id := range ids {
+		if _, err := s.write(ctx, func(st *state) error {
+			_, err := st.revoke(id, true)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
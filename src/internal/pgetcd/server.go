@@ -0,0 +1,380 @@
+// Package pgetcd serves etcd's KV, Watch, Lease and Maintenance APIs from
+// postgres, so that a cluster can keep all of its metadata in postgres.
+//
+// Everything in Pachyderm that uses etcd does so through an etcd client:
+// etcd collections, STM transactions, distributed locks, leases and watches.
+// Rather than reimplementing each of them, pgetcd implements etcd's gRPC
+// services, and NewClient returns an ordinary etcd client that talks to them
+// in process. Each pachd and worker runs its own server, and they share their
+// state through the tables in the etcd schema:
+//
+//   - etcd.revision holds the store's revision, and the revision that its
+//     history is compacted to. Writes lock its only row, so that they're
+//     serialized, and each write transaction increments the revision once,
+//     as etcd does.
+//   - etcd.kv holds the current value of each key.
+//   - etcd.events holds the changes made since the history was last
+//     compacted, with each key's previous value. Watches read their events
+//     from it, and reads at past revisions undo the later changes.
+//   - etcd.leases holds the leases and when they expire.
+//
+// Watches are woken by a NOTIFY on the etcd_revision channel when a write
+// commits, and fall back to polling if a notification is missed.
+package pgetcd
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	"google.golang.org/grpc"
+
+	log "github.com/sirupsen/logrus"
+
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dbutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
+)
+
+const (
+	// revisionChannel is notified when a write commits.
+	revisionChannel = "etcd_revision"
+	// pollInterval is how often watches check for events when they aren't
+	// notified.
+	pollInterval = 5 * time.Second
+	// expiryInterval is how often expired leases are revoked.
+	expiryInterval = time.Second
+	// compactionInterval is how often the history is compacted, and
+	// historyRetention is how long changes stay in it.
+	compactionInterval = time.Minute
+	historyRetention   = 15 * time.Minute
+	// setupLock is the key of the advisory lock that serializes the creation
+	// of the etcd schema.
+	setupLock = 0x70676574636400
+)
+
+var schema = []string{
+	`CREATE SCHEMA IF NOT EXISTS etcd`,
+	`CREATE TABLE IF NOT EXISTS etcd.revision (
+		id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+		revision BIGINT NOT NULL,
+		compacted BIGINT NOT NULL
+	)`,
+	`INSERT INTO etcd.revision (revision, compacted) VALUES (1, 0) ON CONFLICT DO NOTHING`,
+	`CREATE TABLE IF NOT EXISTS etcd.kv (
+		key BYTEA PRIMARY KEY,
+		value BYTEA NOT NULL,
+		create_revision BIGINT NOT NULL,
+		mod_revision BIGINT NOT NULL,
+		version BIGINT NOT NULL,
+		lease BIGINT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS kv_lease ON etcd.kv (lease) WHERE lease != 0`,
+	`CREATE TABLE IF NOT EXISTS etcd.events (
+		revision BIGINT NOT NULL,
+		sub BIGINT NOT NULL,
+		deleted BOOLEAN NOT NULL,
+		key BYTEA NOT NULL,
+		value BYTEA NOT NULL,
+		create_revision BIGINT NOT NULL,
+		version BIGINT NOT NULL,
+		lease BIGINT NOT NULL,
+		prev_value BYTEA,
+		prev_create_revision BIGINT,
+		prev_mod_revision BIGINT,
+		prev_version BIGINT,
+		prev_lease BIGINT,
+		created TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (revision, sub)
+	)`,
+	`CREATE INDEX IF NOT EXISTS events_key ON etcd.events (key, revision)`,
+	`CREATE TABLE IF NOT EXISTS etcd.leases (
+		id BIGINT PRIMARY KEY,
+		ttl BIGINT NOT NULL,
+		expires TIMESTAMPTZ NOT NULL
+	)`,
+}
+
+// Server implements etcd's KV, Watch, Lease and Maintenance services on
+// postgres.
+type Server struct {
+	pb.UnimplementedMaintenanceServer
+
+	db       *pachsql.DB
+	listener col.PostgresListener
+
+	// revision is the latest revision the server knows of, for the headers
+	// of responses that don't read it.
+	revision int64
+
+	notifier *notifier
+}
+
+// NewServer returns a Server that keeps its state in db, and is notified of
+// writes through listener. Watches only poll for events if listener is nil.
+func NewServer(db *pachsql.DB, listener col.PostgresListener) *Server {
+	return &Server{
+		db:       db,
+		listener: listener,
+		notifier: newNotifier(),
+	}
+}
+
+// Setup creates the etcd schema if it doesn't exist, and starts listening
+// for writes.
+func (s *Server) Setup(ctx context.Context) error {
+	if err := dbutil.WithTx(ctx, s.db, func(tx *pachsql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, setupLock); err != nil {
+			return errors.EnsureStack(err)
+		}
+		for _, stmt := range schema {
+			if _, err := tx.ExecContext(ctx, stmt); err != nil {
+				return errors.EnsureStack(err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "could not set up the etcd schema")
+	}
+	return s.listen()
+}
+
+// Register registers the server's services with gs.
+func (s *Server) Register(gs *grpc.Server) {
+	pb.RegisterKVServer(gs, s)
+	pb.RegisterWatchServer(gs, s)
+	pb.RegisterLeaseServer(gs, s)
+	pb.RegisterMaintenanceServer(gs, s)
+}
+
+// Run revokes the leases that expire, and compacts the history, until ctx
+// is done. Only one server needs to run, but they may all.
+func (s *Server) Run(ctx context.Context) error {
+	expiry := time.NewTicker(expiryInterval)
+	defer expiry.Stop()
+	compaction := time.NewTicker(compactionInterval)
+	defer compaction.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.EnsureStack(ctx.Err())
+		case <-expiry.C:
+			if err := s.expireLeases(ctx); err != nil && ctx.Err() == nil {
+				log.Errorf("could not expire etcd leases: %v", err)
+			}
+			if err := s.listen(); err != nil {
+				log.Errorf("could not listen for etcd writes: %v", err)
+			}
+		case <-compaction.C:
+			if err := s.compactHistory(ctx); err != nil && ctx.Err() == nil {
+				log.Errorf("could not compact the etcd history: %v", err)
+			}
+		}
+	}
+}
+
+func (s *Server) header(revision int64) *pb.ResponseHeader {
+	for {
+		known := atomic.LoadInt64(&s.revision)
+		if revision <= known {
+			break
+		}
+		if atomic.CompareAndSwapInt64(&s.revision, known, revision) {
+			break
+		}
+	}
+	if revision == 0 {
+		revision = atomic.LoadInt64(&s.revision)
+	}
+	return &pb.ResponseHeader{Revision: revision}
+}
+
+// read calls cb with the store as of a snapshot, in a read-only
+// transaction.
+func (s *Server) read(ctx context.Context, cb func(st *state) error) error {
+	return dbutil.WithTx(ctx, s.db, func(tx *pachsql.Tx) error {
+		st := &state{ctx: ctx, tx: tx}
+		if err := tx.QueryRowContext(ctx, `SELECT revision, compacted FROM etcd.revision`).Scan(&st.revision, &st.compacted); err != nil {
+			return errors.EnsureStack(err)
+		}
+		s.header(st.revision)
+		return cb(st)
+	}, dbutil.WithIsolationLevel(sql.LevelRepeatableRead), dbutil.WithReadOnly())
+}
+
+// write calls cb with the store while no other write is in progress, and
+// returns the store's revision afterwards. The changes cb makes are
+// committed at the next revision, unless it errors.
+func (s *Server) write(ctx context.Context, cb func(st *state) error) (int64, error) {
+	var revision int64
+	if err := dbutil.WithTx(ctx, s.db, func(tx *pachsql.Tx) error {
+		st := &state{ctx: ctx, tx: tx, changed: make(map[string]bool)}
+		if err := tx.QueryRowContext(ctx, `SELECT revision, compacted FROM etcd.revision FOR UPDATE`).Scan(&st.revision, &st.compacted); err != nil {
+			return errors.EnsureStack(err)
+		}
+		if err := cb(st); err != nil {
+			return err
+		}
+		revision = st.revision
+		if len(st.changed) == 0 {
+			return nil
+		}
+		revision++
+		if _, err := tx.ExecContext(ctx, `UPDATE etcd.revision SET revision = $1`, revision); err != nil {
+			return errors.EnsureStack(err)
+		}
+		if _, err := tx.ExecContext(ctx, `SELECT pg_notify($1, $2)`, revisionChannel, strconv.FormatInt(revision, 10)); err != nil {
+			return errors.EnsureStack(err)
+		}
+		return nil
+	}, dbutil.WithIsolationLevel(sql.LevelReadCommitted)); err != nil {
+		return 0, err
+	}
+	s.header(revision)
+	return revision, nil
+}
+
+// Compact discards the history before the requested revision.
+func (s *Server) Compact(ctx context.Context, req *pb.CompactionRequest) (*pb.CompactionResponse, error) {
+	revision, err := s.write(ctx, func(st *state) error {
+		return st.compact(req.Revision)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CompactionResponse{Header: s.header(revision)}, nil
+}
+
+// compactHistory compacts the changes older than historyRetention.
+func (s *Server) compactHistory(ctx context.Context) error {
+	var revision int64
+	if err := s.db.GetContext(ctx, &revision, `
+		SELECT COALESCE(MAX(revision), 0) FROM etcd.events
+		WHERE created < CURRENT_TIMESTAMP - $1::FLOAT8 * INTERVAL '1 second'
+	`, historyRetention.Seconds()); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if revision == 0 {
+		return nil
+	}
+	_, err := s.write(ctx, func(st *state) error {
+		if revision <= st.compacted {
+			return nil
+		}
+		return st.compact(revision)
+	})
+	return err
+}
+
+// Status returns the size of the etcd schema, the rest of the status only
+// makes sense for an etcd cluster.
+func (s *Server) Status(ctx context.Context, req *pb.StatusRequest) (*pb.StatusResponse, error) {
+	var size, revision int64
+	if err := s.db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COALESCE(SUM(pg_total_relation_size(format('%I.%I', schemaname, tablename)::regclass)), 0)::BIGINT
+			 FROM pg_tables WHERE schemaname = 'etcd'),
+			(SELECT revision FROM etcd.revision)
+	`).Scan(&size, &revision); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return &pb.StatusResponse{
+		Header:      s.header(revision),
+		Version:     "postgres",
+		DbSize:      size,
+		DbSizeInUse: size,
+	}, nil
+}
+
+// listen registers the server's notifier with the listener, unless it's
+// already registered.
+func (s *Server) listen() error {
+	if s.listener == nil || !s.notifier.unregistered() {
+		return nil
+	}
+	if err := s.listener.Register(s.notifier); err != nil {
+		s.notifier.Error(err)
+		return errors.EnsureStack(err)
+	}
+	return nil
+}
+
+// notifier wakes the server's watches when a write commits.
+type notifier struct {
+	id string
+
+	mu         sync.Mutex
+	wake       chan struct{}
+	registered bool
+}
+
+func newNotifier() *notifier {
+	return &notifier{
+		id:   uuid.NewWithoutDashes(),
+		wake: make(chan struct{}),
+	}
+}
+
+func (n *notifier) ID() string {
+	return n.id
+}
+
+func (n *notifier) Channel() string {
+	return revisionChannel
+}
+
+func (n *notifier) Notify(*col.Notification) {
+	n.broadcast(true)
+}
+
+// Error is called when the listener loses its connection, and drops the
+// notifier, which the server registers again.
+func (n *notifier) Error(error) {
+	n.broadcast(false)
+}
+
+func (n *notifier) broadcast(registered bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	close(n.wake)
+	n.wake = make(chan struct{})
+	n.registered = registered
+}
+
+func (n *notifier) unregistered() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.registered {
+		return false
+	}
+	// registering can't fail once the listener has accepted the notifier,
+	// so it's marked as registered until the listener reports an error
+	n.registered = true
+	return true
+}
+
+// woken returns a channel that's closed at the next notification.
+func (n *notifier) woken() <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.wake
+}
+
+// checkRevision returns etcd's error for a read at revision, if the store's
+// revision and compacted revision don't allow it.
+func checkRevision(revision, current, compacted int64) error {
+	switch {
+	case revision > current:
+		return rpctypes.ErrGRPCFutureRev
+	case revision > 0 && revision < compacted:
+		return rpctypes.ErrGRPCCompacted
+	}
+	return nil
+}
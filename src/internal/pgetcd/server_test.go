@@ -0,0 +1,163 @@
+package pgetcd_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	etcd "go.etcd.io/etcd/client/v3"
+
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dbutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dockertestenv"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pgetcd"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func newTestClient(t *testing.T) *etcd.Client {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	options := dockertestenv.NewTestDirectDBOptions(t)
+	db, err := dbutil.NewDB(options...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	listener := col.NewPostgresListener(dbutil.GetDSN(options...))
+	t.Cleanup(func() {
+		require.NoError(t, listener.Close())
+	})
+	server := pgetcd.NewServer(db, listener)
+	require.NoError(t, server.Setup(ctx))
+	go server.Run(ctx)
+	client, err := server.NewClient(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+	})
+	return client
+}
+
+func TestHistory(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	first, err := client.Put(ctx, "a", "1")
+	require.NoError(t, err)
+	_, err = client.Put(ctx, "a", "2")
+	require.NoError(t, err)
+	_, err = client.Put(ctx, "b", "1")
+	require.NoError(t, err)
+	deleted, err := client.Delete(ctx, "a")
+	require.NoError(t, err)
+
+	resp, err := client.Get(ctx, "", etcd.WithFromKey())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(resp.Kvs))
+	require.Equal(t, "b", string(resp.Kvs[0].Key))
+
+	resp, err = client.Get(ctx, "", etcd.WithFromKey(), etcd.WithRev(first.Header.Revision))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(resp.Kvs))
+	require.Equal(t, "1", string(resp.Kvs[0].Value))
+	require.Equal(t, int64(1), resp.Kvs[0].Version)
+
+	resp, err = client.Get(ctx, "a", etcd.WithRev(deleted.Header.Revision-1))
+	require.NoError(t, err)
+	require.Equal(t, "2", string(resp.Kvs[0].Value))
+	require.Equal(t, first.Header.Revision, resp.Kvs[0].CreateRevision)
+
+	_, err = client.Compact(ctx, deleted.Header.Revision)
+	require.NoError(t, err)
+	_, err = client.Get(ctx, "a", etcd.WithRev(first.Header.Revision))
+	require.True(t, rpctypes.Error(err) == rpctypes.ErrCompacted)
+}
+
+func TestTxn(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	resp, err := client.Txn(ctx).
+		If(etcd.Compare(etcd.CreateRevision("a"), "=", 0)).
+		Then(etcd.OpPut("a", "1"), etcd.OpPut("b", "1")).
+		Commit()
+	require.NoError(t, err)
+	require.True(t, resp.Succeeded)
+	rev := resp.Header.Revision
+
+	resp, err = client.Txn(ctx).
+		If(etcd.Compare(etcd.CreateRevision("a"), "=", 0)).
+		Then(etcd.OpPut("a", "2")).
+		Else(etcd.OpGet("a")).
+		Commit()
+	require.NoError(t, err)
+	require.False(t, resp.Succeeded)
+	require.Equal(t, rev, resp.Header.Revision)
+	get := resp.Responses[0].GetResponseRange()
+	require.Equal(t, rev, get.Header.Revision)
+	require.Equal(t, rev, get.Kvs[0].ModRevision)
+
+	_, err = client.Txn(ctx).Then(etcd.OpPut("a", "2"), etcd.OpDelete("a")).Commit()
+	require.True(t, rpctypes.Error(err) == rpctypes.ErrDuplicateKey)
+}
+
+func TestLease(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	lease, err := client.Grant(ctx, 1)
+	require.NoError(t, err)
+	_, err = client.Put(ctx, "a", "1", etcd.WithLease(lease.ID))
+	require.NoError(t, err)
+	ttl, err := client.TimeToLive(ctx, lease.ID, etcd.WithAttachedKeys())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), ttl.GrantedTTL)
+	require.Equal(t, 1, len(ttl.Keys))
+
+	// the lease expires and its key is deleted
+	require.NoErrorWithinTRetry(t, 10*time.Second, func() error {
+		resp, err := client.Get(ctx, "a")
+		if err != nil {
+			return err
+		}
+		if len(resp.Kvs) > 0 {
+			return errors.New("the lease's key still exists")
+		}
+		return nil
+	})
+	_, err = client.Put(ctx, "a", "1", etcd.WithLease(lease.ID))
+	require.True(t, rpctypes.Error(err) == rpctypes.ErrLeaseNotFound)
+}
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := newTestClient(t)
+	put, err := client.Put(ctx, "a", "1")
+	require.NoError(t, err)
+	_, err = client.Put(ctx, "b", "1")
+	require.NoError(t, err)
+	_, err = client.Delete(ctx, "a")
+	require.NoError(t, err)
+
+	// a watch from a past revision sees the changes since, in order
+	watch := client.Watch(ctx, "a", etcd.WithRev(put.Header.Revision), etcd.WithPrevKV())
+	resp := <-watch
+	require.NoError(t, resp.Err())
+	require.Equal(t, 2, len(resp.Events))
+	require.Equal(t, mvccpb.PUT, resp.Events[0].Type)
+	require.Equal(t, mvccpb.DELETE, resp.Events[1].Type)
+	require.Equal(t, "1", string(resp.Events[1].PrevKv.Value))
+
+	// and then the changes as they're made
+	_, err = client.Put(ctx, "a", "2")
+	require.NoError(t, err)
+	select {
+	case resp = <-watch:
+		require.NoError(t, resp.Err())
+		require.Equal(t, 1, len(resp.Events))
+		require.Equal(t, "2", string(resp.Events[0].Kv.Value))
+	case <-time.After(5 * time.Second):
+		t.Fatal("the watch wasn't woken by the put")
+	}
+}
@@ -0,0 +1,272 @@
+package pgetcd
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"sync"
+	"time"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// maxWatchRevisions is the most revisions whose events a watch sends in one
+// response.
+const maxWatchRevisions = 100
+
+// event is a row of etcd.events.
+type event struct {
+	Revision           int64         `db:"revision"`
+	Deleted            bool          `db:"deleted"`
+	Key                []byte        `db:"key"`
+	Value              []byte        `db:"value"`
+	CreateRevision     int64         `db:"create_revision"`
+	Version            int64         `db:"version"`
+	Lease              int64         `db:"lease"`
+	PrevValue          []byte        `db:"prev_value"`
+	PrevCreateRevision sql.NullInt64 `db:"prev_create_revision"`
+	PrevModRevision    sql.NullInt64 `db:"prev_mod_revision"`
+	PrevVersion        sql.NullInt64 `db:"prev_version"`
+	PrevLease          sql.NullInt64 `db:"prev_lease"`
+}
+
+func (e *event) proto(prevKV bool) *mvccpb.Event {
+	result := &mvccpb.Event{
+		Type: mvccpb.PUT,
+		Kv: &mvccpb.KeyValue{
+			Key:            e.Key,
+			Value:          e.Value,
+			CreateRevision: e.CreateRevision,
+			ModRevision:    e.Revision,
+			Version:        e.Version,
+			Lease:          e.Lease,
+		},
+	}
+	if e.Deleted {
+		result.Type = mvccpb.DELETE
+		result.Kv = &mvccpb.KeyValue{Key: e.Key, ModRevision: e.Revision}
+	}
+	if prevKV && e.PrevModRevision.Valid {
+		result.PrevKv = &mvccpb.KeyValue{
+			Key:            e.Key,
+			Value:          e.PrevValue,
+			CreateRevision: e.PrevCreateRevision.Int64,
+			ModRevision:    e.PrevModRevision.Int64,
+			Version:        e.PrevVersion.Int64,
+			Lease:          e.PrevLease.Int64,
+		}
+	}
+	return result
+}
+
+// Watch streams the changes to ranges of keys, from a revision on, as the
+// client asks.
+func (s *Server) Watch(stream pb.Watch_WatchServer) error {
+	ws := &watchStream{
+		s:        s,
+		stream:   stream,
+		watchers: make(map[int64]*watcher),
+	}
+	ctx, cancel := context.WithCancel(stream.Context())
+	// the watchers must stop before the stream is closed
+	defer ws.wg.Wait()
+	defer cancel()
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return errors.EnsureStack(err)
+		}
+		switch req := req.RequestUnion.(type) {
+		case *pb.WatchRequest_CreateRequest:
+			err = ws.create(ctx, req.CreateRequest)
+		case *pb.WatchRequest_CancelRequest:
+			err = ws.cancel(req.CancelRequest.WatchId)
+		case *pb.WatchRequest_ProgressRequest:
+			err = ws.send(&pb.WatchResponse{Header: s.header(0), WatchId: -1})
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+type watchStream struct {
+	s      *Server
+	stream pb.Watch_WatchServer
+	wg     sync.WaitGroup
+
+	sendMu sync.Mutex
+
+	mu       sync.Mutex
+	watchers map[int64]*watcher
+	nextID   int64
+}
+
+type watcher struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func (ws *watchStream) send(resp *pb.WatchResponse) error {
+	ws.sendMu.Lock()
+	defer ws.sendMu.Unlock()
+	return errors.EnsureStack(ws.stream.Send(resp))
+}
+
+func (ws *watchStream) create(ctx context.Context, req *pb.WatchCreateRequest) error {
+	var revision, compacted int64
+	if err := ws.s.db.QueryRowContext(ctx, `SELECT revision, compacted FROM etcd.revision`).Scan(&revision, &compacted); err != nil {
+		return errors.EnsureStack(err)
+	}
+	header := ws.s.header(revision)
+	ws.mu.Lock()
+	id := req.WatchId
+	if id == 0 {
+		for ws.watchers[ws.nextID] != nil {
+			ws.nextID++
+		}
+		id = ws.nextID
+	} else if ws.watchers[id] != nil {
+		ws.mu.Unlock()
+		return ws.send(&pb.WatchResponse{Header: header, WatchId: -1, Created: true, Canceled: true, CancelReason: "watch ID is already in use"})
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	w := &watcher{cancel: cancel, done: make(chan struct{})}
+	ws.watchers[id] = w
+	ws.mu.Unlock()
+
+	if err := ws.send(&pb.WatchResponse{Header: header, WatchId: id, Created: true}); err != nil {
+		ws.stop(id)
+		return err
+	}
+	next := req.StartRevision
+	if next == 0 {
+		next = revision + 1
+	}
+	ws.wg.Add(1)
+	go func() {
+		defer ws.wg.Done()
+		defer close(w.done)
+		ws.watch(ctx, id, req, next)
+	}()
+	return nil
+}
+
+// stop removes a watcher and stops it, and returns it unless it had
+// already been removed.
+func (ws *watchStream) stop(id int64) *watcher {
+	ws.mu.Lock()
+	w := ws.watchers[id]
+	delete(ws.watchers, id)
+	ws.mu.Unlock()
+	if w != nil {
+		w.cancel()
+	}
+	return w
+}
+
+// cancel stops a watcher at the client's request. The watcher must not send
+// anything once the client has been told it's canceled.
+func (ws *watchStream) cancel(id int64) error {
+	w := ws.stop(id)
+	if w == nil {
+		return nil
+	}
+	<-w.done
+	return ws.send(&pb.WatchResponse{Header: ws.s.header(0), WatchId: id, Canceled: true})
+}
+
+// watch sends the events from a revision on until ctx is done.
+func (ws *watchStream) watch(ctx context.Context, id int64, req *pb.WatchCreateRequest, next int64) {
+	for {
+		woken := ws.s.notifier.woken()
+		var events []*mvccpb.Event
+		var revision, last int64
+		if err := ws.s.read(ctx, func(st *state) error {
+			revision = st.revision
+			if next < st.compacted {
+				return errCompacted{st.compacted}
+			}
+			last = st.revision
+			if last >= next+maxWatchRevisions {
+				last = next + maxWatchRevisions - 1
+			}
+			var err error
+			events, err = st.events(req, next, last)
+			return err
+		}); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if ws.stop(id) == nil {
+				return
+			}
+			resp := &pb.WatchResponse{Header: ws.s.header(0), WatchId: id, Canceled: true, CancelReason: err.Error()}
+			compacted := errCompacted{}
+			if errors.As(err, &compacted) {
+				resp.CompactRevision = compacted.revision
+			}
+			ws.send(resp)
+			return
+		}
+		if len(events) > 0 {
+			if err := ws.send(&pb.WatchResponse{Header: ws.s.header(revision), WatchId: id, Events: events}); err != nil {
+				return
+			}
+		}
+		if last >= next {
+			next = last + 1
+		}
+		if last < revision {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-woken:
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// events returns the events in a watch's range, between two revisions.
+func (st *state) events(req *pb.WatchCreateRequest, from, to int64) ([]*mvccpb.Event, error) {
+	q := &query{}
+	stmt := `
+		SELECT revision, deleted, key, value, create_revision, version, lease,
+			prev_value, prev_create_revision, prev_mod_revision, prev_version, prev_lease
+		FROM etcd.events WHERE revision >= ` + q.arg(from) + ` AND revision <= ` + q.arg(to) + ` AND ` + q.keyRange(req.Key, req.RangeEnd)
+	for _, filter := range req.Filters {
+		switch filter {
+		case pb.WatchCreateRequest_NOPUT:
+			stmt += ` AND deleted`
+		case pb.WatchCreateRequest_NODELETE:
+			stmt += ` AND NOT deleted`
+		}
+	}
+	stmt += ` ORDER BY revision, sub`
+	var rows []*event
+	if err := st.tx.SelectContext(st.ctx, &rows, stmt, q.args...); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	var events []*mvccpb.Event
+	for _, row := range rows {
+		events = append(events, row.proto(req.PrevKv))
+	}
+	return events, nil
+}
+
+// errCompacted is returned when a watch's next events have been compacted.
+type errCompacted struct {
+	revision int64
+}
+
+func (e errCompacted) Error() string {
+	return "required revision has been compacted"
+}
@@ -15,6 +15,7 @@ import (
 	"bytes"
 	"crypto/md5"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -57,13 +58,87 @@ func PipelineRcName(name string, version uint64) string {
 	return fmt.Sprintf("pipeline-%s-v%d", strings.ToLower(name), version)
 }
 
+// WorkerPoolRcName returns the name of the RC of the warm worker pool with
+// key.
+func WorkerPoolRcName(key string) string {
+	return "worker-pool-" + key
+}
+
+// WorkerPoolAssignmentAnnotation is the annotation that a pool worker's pod
+// is given when it's claimed by a pipeline. Its value is the pipeline's name
+// and spec commit ID, as returned by WorkerPoolAssignment.
+const WorkerPoolAssignmentAnnotation = "workerPoolAssignment"
+
+// WorkerPoolAssignment returns the assignment of a pool worker to the
+// version of a pipeline with specCommitID.
+func WorkerPoolAssignment(pipelineName, specCommitID string) string {
+	return pipelineName + "@" + specCommitID
+}
+
+// ParseWorkerPoolAssignment parses an assignment returned by
+// WorkerPoolAssignment.
+func ParseWorkerPoolAssignment(assignment string) (pipelineName, specCommitID string, err error) {
+	parts := strings.SplitN(assignment, "@", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Errorf("invalid worker pool assignment %q", assignment)
+	}
+	return parts[0], parts[1], nil
+}
+
+// DatumCacheTag returns the cache tag of the entries in a pipeline's datum
+// cache.
+func DatumCacheTag(pipelineName string) string {
+	return "datum-cache/" + pipelineName + "/"
+}
+
+// ExecutorSecretName returns the name of the secret with the pachctl config
+// that a pipeline's executor uses to access the datums it runs. It's in
+// ppsutil because both PPS, which creates the secret, and the worker, which
+// passes its name to the executor, need to know it.
+func ExecutorSecretName(pipelineName string) string {
+	return "executor-pachctl-secret-" + pipelineName
+}
+
+// GPUSharing describes how the cluster's GPUs are time-sliced, which is what
+// fractional GPU requests are converted into.
+type GPUSharing struct {
+	// Replicas is the number of replicas the device plugin advertises for
+	// each GPU, 0 if GPUs aren't time-sliced.
+	Replicas int64
+	// Resource is the resource the replicas are advertised as.
+	Resource string
+}
+
+// GPUResource returns the resource and quantity of gpu that pods request.
+func GPUResource(gpu *pps.GPUSpec, sharing GPUSharing) (v1.ResourceName, int64, error) {
+	switch {
+	case gpu.MigProfile != "":
+		number := gpu.Number
+		if number == 0 {
+			number = 1
+		}
+		return v1.ResourceName("nvidia.com/mig-" + gpu.MigProfile), number, nil
+	case gpu.Fraction != 0:
+		if sharing.Replicas <= 0 || sharing.Resource == "" {
+			return "", 0, errors.New("fractional GPUs aren't supported, as this cluster's GPUs aren't time-sliced")
+		}
+		replicas := int64(math.Ceil(float64(gpu.Fraction) * float64(sharing.Replicas)))
+		if replicas < 1 {
+			replicas = 1
+		}
+		return v1.ResourceName(sharing.Resource), replicas, nil
+	default:
+		return v1.ResourceName(gpu.Type), gpu.Number, nil
+	}
+}
+
 // GetRequestsResourceListFromPipeline returns a list of resources that the pipeline,
 // minimally requires.
-func GetRequestsResourceListFromPipeline(pipelineInfo *pps.PipelineInfo) (*v1.ResourceList, error) {
-	return getResourceListFromSpec(pipelineInfo.Details.ResourceRequests)
+func GetRequestsResourceListFromPipeline(pipelineInfo *pps.PipelineInfo, sharing GPUSharing) (*v1.ResourceList, error) {
+	return getResourceListFromSpec(pipelineInfo.Details.ResourceRequests, sharing)
 }
 
-func getResourceListFromSpec(resources *pps.ResourceSpec) (*v1.ResourceList, error) {
+func getResourceListFromSpec(resources *pps.ResourceSpec, sharing GPUSharing) (*v1.ResourceList, error) {
 	result := make(v1.ResourceList)
 
 	if resources.Cpu != 0 {
@@ -95,22 +170,26 @@ func getResourceListFromSpec(resources *pps.ResourceSpec) (*v1.ResourceList, err
 	}
 
 	if resources.Gpu != nil {
-		gpuStr := fmt.Sprintf("%d", resources.Gpu.Number)
-		gpuQuantity, err := resource.ParseQuantity(gpuStr)
+		name, number, err := GPUResource(resources.Gpu, sharing)
 		if err != nil {
-			log.Warnf("error parsing gpu string: %s: %+v", gpuStr, err)
-		} else {
-			result[v1.ResourceName(resources.Gpu.Type)] = gpuQuantity
+			return nil, err
 		}
+		result[name] = *resource.NewQuantity(number, resource.DecimalSI)
 	}
 
 	return &result, nil
 }
 
+// GetRequestsResourceList returns a list of resources from a ResourceSpec
+// that it minimally requires.
+func GetRequestsResourceList(requests *pps.ResourceSpec, sharing GPUSharing) (*v1.ResourceList, error) {
+	return getResourceListFromSpec(requests, sharing)
+}
+
 // GetLimitsResourceList returns a list of resources from a pipeline
 // ResourceSpec that it is maximally limited to.
-func GetLimitsResourceList(limits *pps.ResourceSpec) (*v1.ResourceList, error) {
-	return getResourceListFromSpec(limits)
+func GetLimitsResourceList(limits *pps.ResourceSpec, sharing GPUSharing) (*v1.ResourceList, error) {
+	return getResourceListFromSpec(limits, sharing)
 }
 
 // FailPipeline updates the pipeline's state to failed and sets the failure reason
@@ -290,6 +369,27 @@ func PipelineReqFromInfo(pipelineInfo *pps.PipelineInfo) *pps.CreatePipelineRequ
 		Metadata:              pipelineInfo.Details.Metadata,
 		ReprocessSpec:         pipelineInfo.Details.ReprocessSpec,
 		Autoscaling:           pipelineInfo.Details.Autoscaling,
+		Budget:                pipelineInfo.Details.Budget,
+		DatumRetryPolicy:      pipelineInfo.Details.DatumRetryPolicy,
+		TemplateParameters:    pipelineInfo.Details.TemplateParameters,
+		Priority:              pipelineInfo.Details.Priority,
+		DatumAutoscaling:      pipelineInfo.Details.DatumAutoscaling,
+		Sidecars:              pipelineInfo.Details.Sidecars,
+		InitContainers:        pipelineInfo.Details.InitContainers,
+		SharedVolumes:         pipelineInfo.Details.SharedVolumes,
+		KubernetesJobs:        pipelineInfo.Details.KubernetesJobs,
+		DatumCache:            pipelineInfo.Details.DatumCache,
+		DatumCheckpoints:      pipelineInfo.Details.DatumCheckpoints,
+		NetworkPolicy:         pipelineInfo.Details.NetworkPolicy,
+		Canary:                pipelineInfo.Details.Canary,
+		RollbackPolicy:        pipelineInfo.Details.RollbackPolicy,
+		SLOs:                  pipelineInfo.Details.SLOs,
+		Project:               pipelineInfo.Details.Project,
+		Executor:              pipelineInfo.Details.Executor,
+		Readahead:             pipelineInfo.Details.Readahead,
+		ModelRegistry:         pipelineInfo.Details.ModelRegistry,
+		Build:                 pipelineInfo.Details.Build,
+		WorkerPool:            pipelineInfo.Details.WorkerPool,
 	}
 }
 
@@ -363,6 +463,11 @@ func WriteJobInfo(pachClient *client.APIClient, jobInfo *pps.JobInfo) error {
 		DataFailed:    jobInfo.DataFailed,
 		DataRecovered: jobInfo.DataRecovered,
 		Stats:         jobInfo.Stats,
+
+		DataQuarantined: jobInfo.DataQuarantined,
+		EgressBytes:     jobInfo.EgressBytes,
+		DataRestored:    jobInfo.DataRestored,
+		DataRecomputed:  jobInfo.DataRecomputed,
 	})
 	return errors.EnsureStack(err)
 }
@@ -0,0 +1,199 @@
+package profileutil
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path"
+	"runtime/pprof"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
+	"github.com/pachyderm/pachyderm/v2/src/internal/serviceenv"
+)
+
+const (
+	// ProfilesPrefix is the prefix of the objects that hold the profiles
+	// captured by ContinuousProfilers.
+	ProfilesPrefix = "profiles"
+	// ProfileCPU and ProfileHeap are the kinds of profile that a
+	// ContinuousProfiler captures.
+	ProfileCPU  = "cpu"
+	ProfileHeap = "heap"
+
+	// profileTimeFormat sorts lexically in time order.
+	profileTimeFormat = "20060102T150405Z"
+	profileExt        = ".pprof"
+)
+
+// cpuProfileMu serializes CPU profiles, since the runtime can only capture
+// one at a time.
+var cpuProfileMu sync.Mutex
+
+// WriteCPUProfile writes a CPU profile of this process, captured over
+// duration, to w. If another CPU profile is being captured through
+// WriteCPUProfile, it waits for that one to finish first.
+func WriteCPUProfile(ctx context.Context, w io.Writer, duration time.Duration) error {
+	cpuProfileMu.Lock()
+	defer cpuProfileMu.Unlock()
+	if err := pprof.StartCPUProfile(w); err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer pprof.StopCPUProfile()
+	select {
+	case <-ctx.Done():
+		return errors.EnsureStack(ctx.Err())
+	case <-time.After(duration):
+		return nil
+	}
+}
+
+// PachdProfileSource returns the source of the profiles of the pachd pod.
+func PachdProfileSource(pod string) string {
+	return path.Join("pachd", pod)
+}
+
+// WorkerProfileSource returns the source of the profiles of a container,
+// "user" or "storage", of a pipeline's worker pod.
+func WorkerProfileSource(pipeline, pod, container string) string {
+	return path.Join("pipelines", pipeline, pod, container)
+}
+
+// ProfileObject returns the name of the object that holds a profile of kind,
+// captured at t by source.
+func ProfileObject(source, kind string, t time.Time) string {
+	return path.Join(ProfilesPrefix, source, t.UTC().Format(profileTimeFormat)+"-"+kind+profileExt)
+}
+
+// ParseProfileObject returns the source, kind and capture time of the profile
+// held by the object name, which is returned by ProfileObject.
+func ParseProfileObject(name string) (string, string, time.Time, error) {
+	dir, file := path.Split(strings.TrimPrefix(name, ProfilesPrefix+"/"))
+	parts := strings.SplitN(strings.TrimSuffix(file, profileExt), "-", 2)
+	if dir == "" || len(parts) != 2 || !strings.HasSuffix(file, profileExt) {
+		return "", "", time.Time{}, errors.Errorf("%q is not a profile object", name)
+	}
+	t, err := time.Parse(profileTimeFormat, parts[0])
+	if err != nil {
+		return "", "", time.Time{}, errors.Wrapf(err, "%q is not a profile object", name)
+	}
+	return strings.TrimSuffix(dir, "/"), parts[1], t, nil
+}
+
+// ContinuousProfiler periodically captures CPU and heap profiles of this
+// process, and keeps those captured within its retention in object storage.
+type ContinuousProfiler struct {
+	client      obj.Client
+	source      string
+	interval    time.Duration
+	cpuDuration time.Duration
+	retention   time.Duration
+}
+
+// NewContinuousProfiler returns a ContinuousProfiler that writes the profiles
+// of source, e.g. "pachd/<pod>", to client.
+func NewContinuousProfiler(client obj.Client, source string, interval, cpuDuration, retention time.Duration) *ContinuousProfiler {
+	return &ContinuousProfiler{
+		client:      client,
+		source:      source,
+		interval:    interval,
+		cpuDuration: cpuDuration,
+		retention:   retention,
+	}
+}
+
+// Run captures profiles every interval until ctx is done. Failures are
+// logged, rather than returned, since profiling is best effort.
+func (p *ContinuousProfiler) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		if err := p.Capture(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			log.WithError(err).Errorf("could not capture continuous profiles of %s", p.source)
+		}
+		if err := p.DeleteExpired(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			log.WithError(err).Errorf("could not delete expired continuous profiles of %s", p.source)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Capture writes a heap profile, and a CPU profile captured over the
+// profiler's CPU duration, to object storage under the time t.
+func (p *ContinuousProfiler) Capture(ctx context.Context, t time.Time) error {
+	var buf bytes.Buffer
+	if err := pprof.Lookup(ProfileHeap).WriteTo(&buf, 0); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if err := p.client.Put(ctx, ProfileObject(p.source, ProfileHeap, t), &buf); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if p.cpuDuration <= 0 {
+		return nil
+	}
+	buf.Reset()
+	if err := WriteCPUProfile(ctx, &buf, p.cpuDuration); err != nil {
+		return err
+	}
+	return errors.EnsureStack(p.client.Put(ctx, ProfileObject(p.source, ProfileCPU, t), &buf))
+}
+
+// DeleteExpired deletes the profiles of the profiler's source that were
+// captured more than its retention before now.
+func (p *ContinuousProfiler) DeleteExpired(ctx context.Context, now time.Time) error {
+	cutoff := now.Add(-p.retention)
+	var expired []string
+	if err := p.client.Walk(ctx, path.Join(ProfilesPrefix, p.source)+"/", func(name string) error {
+		source, _, t, err := ParseProfileObject(name)
+		if err != nil || source != p.source {
+			// not one of this profiler's objects
+			return nil
+		}
+		if t.Before(cutoff) {
+			expired = append(expired, name)
+		}
+		return nil
+	}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	for _, name := range expired {
+		if err := p.client.Delete(ctx, name); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	return nil
+}
+
+// StartContinuousProfiler starts a ContinuousProfiler for source in the
+// background, if ContinuousProfilingIntervalSeconds is set in the provided
+// configuration. Profiles are written to the cluster's object storage, whose
+// backend is read from the environment.
+//
+// If there is a problem starting the profiler, it logs a message but we continue.
+func StartContinuousProfiler(ctx context.Context, source string, config *serviceenv.Configuration) {
+	if config == nil || config.ContinuousProfilingIntervalSeconds <= 0 {
+		return
+	}
+	client, err := obj.NewClientFromSecret(config.StorageRoot)
+	if err != nil {
+		log.WithError(err).Error("failed to create object storage client; continuous profiling not enabled")
+		return
+	}
+	interval := time.Duration(config.ContinuousProfilingIntervalSeconds) * time.Second
+	cpuDuration := time.Duration(config.ContinuousProfilingCPUSeconds) * time.Second
+	if cpuDuration > interval {
+		cpuDuration = interval
+	}
+	retention := time.Duration(config.ContinuousProfilingRetentionHours) * time.Hour
+	log.Infof("enabling continuous profiling of %s every %v; keeping profiles for %v", source, interval, retention)
+	go NewContinuousProfiler(client, source, interval, cpuDuration, retention).Run(ctx)
+}
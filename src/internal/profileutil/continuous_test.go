@@ -0,0 +1,65 @@
+package profileutil
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestParseProfileObject(t *testing.T) {
+	now := time.Date(2021, 6, 1, 10, 30, 0, 0, time.UTC)
+	name := ProfileObject(WorkerProfileSource("edges", "pipeline-edges-v1-abcde", "user"), ProfileCPU, now)
+	require.Equal(t, "profiles/pipelines/edges/pipeline-edges-v1-abcde/user/20210601T103000Z-cpu.pprof", name)
+	source, kind, captured, err := ParseProfileObject(name)
+	require.NoError(t, err)
+	require.Equal(t, "pipelines/edges/pipeline-edges-v1-abcde/user", source)
+	require.Equal(t, ProfileCPU, kind)
+	require.True(t, now.Equal(captured))
+
+	for _, name := range []string{"profiles/20210601T103000Z-cpu.pprof", "profiles/pachd/p/heap.pprof", "profiles/pachd/p/yesterday-heap.pprof", "chunk/abc"} {
+		_, _, _, err := ParseProfileObject(name)
+		require.YesError(t, err, name)
+	}
+}
+
+func TestContinuousProfiler(t *testing.T) {
+	ctx := context.Background()
+	client, err := obj.NewLocalClient(t.TempDir())
+	require.NoError(t, err)
+	listObjects := func() []string {
+		var names []string
+		require.NoError(t, client.Walk(ctx, ProfilesPrefix+"/", func(name string) error {
+			names = append(names, name)
+			return nil
+		}))
+		sort.Strings(names)
+		return names
+	}
+
+	source := PachdProfileSource("pachd-0")
+	p := NewContinuousProfiler(client, source, time.Minute, 10*time.Millisecond, time.Hour)
+	// another source's profiles aren't deleted
+	other := NewContinuousProfiler(client, PachdProfileSource("pachd-01"), time.Minute, 0, time.Hour)
+	now := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, other.Capture(ctx, now.Add(-2*time.Hour)))
+	require.NoError(t, p.Capture(ctx, now.Add(-2*time.Hour)))
+	require.NoError(t, p.Capture(ctx, now))
+	require.Equal(t, []string{
+		"profiles/pachd/pachd-0/20210601T080000Z-cpu.pprof",
+		"profiles/pachd/pachd-0/20210601T080000Z-heap.pprof",
+		"profiles/pachd/pachd-0/20210601T100000Z-cpu.pprof",
+		"profiles/pachd/pachd-0/20210601T100000Z-heap.pprof",
+		"profiles/pachd/pachd-01/20210601T080000Z-heap.pprof",
+	}, listObjects())
+
+	require.NoError(t, p.DeleteExpired(ctx, now))
+	require.Equal(t, []string{
+		"profiles/pachd/pachd-0/20210601T100000Z-cpu.pprof",
+		"profiles/pachd/pachd-0/20210601T100000Z-heap.pprof",
+		"profiles/pachd/pachd-01/20210601T080000Z-heap.pprof",
+	}, listObjects())
+}
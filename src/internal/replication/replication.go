@@ -0,0 +1,237 @@
+// Package replication mirrors repo branches from one Pachyderm cluster to
+// another.
+//
+// A Replicator holds a client for the source cluster and a client for the
+// destination cluster, so the same code is used for push based replication
+// (running next to the source) and pull based replication (running next to
+// the destination). Each replicated commit in the destination records the ID
+// of the source commit it was copied from in its description, which is how
+// replication resumes after a restart and how lag is computed. Only the
+// files that changed between consecutive source commits are transferred.
+package replication
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+const (
+	// descriptionPrefix is prepended to the source commit ID in the
+	// description of every commit created by replication.
+	descriptionPrefix = "replicated-from: "
+)
+
+// Spec identifies a branch to replicate.
+type Spec struct {
+	Repo   string
+	Branch string
+}
+
+func (s Spec) String() string {
+	return s.Repo + "@" + s.Branch
+}
+
+// Status describes how far behind the destination branch is.
+type Status struct {
+	Spec Spec
+	// LastReplicated is the ID of the most recent source commit that has
+	// been replicated, or "" if nothing has been replicated yet.
+	LastReplicated string
+	// Pending is the number of finished source commits that have not been
+	// replicated.
+	Pending int
+	// Lag is the time between the oldest pending source commit finishing
+	// and now. It is zero when there are no pending commits.
+	Lag time.Duration
+}
+
+// Replicator copies commits from a source cluster to a destination cluster.
+type Replicator struct {
+	src, dst *client.APIClient
+}
+
+// NewReplicator creates a new Replicator.
+func NewReplicator(src, dst *client.APIClient) *Replicator {
+	return &Replicator{src: src, dst: dst}
+}
+
+// SourceCommitID returns the source commit ID recorded in a replicated
+// commit's description, or "" if the commit was not created by replication.
+func SourceCommitID(ci *pfs.CommitInfo) string {
+	if !strings.HasPrefix(ci.Description, descriptionPrefix) {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(ci.Description, descriptionPrefix))
+}
+
+// Status computes the replication status of a spec.
+func (r *Replicator) Status(spec Spec) (*Status, error) {
+	last, err := r.lastReplicated(spec)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := r.pending(spec, last)
+	if err != nil {
+		return nil, err
+	}
+	s := &Status{
+		Spec:           spec,
+		LastReplicated: last,
+		Pending:        len(pending),
+	}
+	if len(pending) > 0 {
+		finished, err := types.TimestampFromProto(pending[0].Finished)
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		s.Lag = time.Since(finished)
+	}
+	return s, nil
+}
+
+// ReplicateOnce replicates all of the finished source commits that are not
+// yet in the destination and returns the number of commits replicated.
+func (r *Replicator) ReplicateOnce(spec Spec) (int, error) {
+	if err := r.ensureRepo(spec); err != nil {
+		return 0, err
+	}
+	last, err := r.lastReplicated(spec)
+	if err != nil {
+		return 0, err
+	}
+	pending, err := r.pending(spec, last)
+	if err != nil {
+		return 0, err
+	}
+	for i, ci := range pending {
+		var prev *pfs.Commit
+		if last != "" {
+			prev = client.NewCommit(spec.Repo, spec.Branch, last)
+		}
+		if err := r.replicateCommit(spec, ci.Commit, prev); err != nil {
+			return i, errors.Wrapf(err, "error replicating commit %v", ci.Commit.ID)
+		}
+		last = ci.Commit.ID
+	}
+	return len(pending), nil
+}
+
+// Follow replicates commits as they are finished in the source until the
+// source client's context is cancelled or an error occurs.
+func (r *Replicator) Follow(spec Spec, cb func(*pfs.CommitInfo) error) error {
+	if _, err := r.ReplicateOnce(spec); err != nil {
+		return err
+	}
+	return r.src.SubscribeCommit(client.NewRepo(spec.Repo), spec.Branch, "", pfs.CommitState_FINISHED, func(ci *pfs.CommitInfo) error {
+		if _, err := r.ReplicateOnce(spec); err != nil {
+			return err
+		}
+		if cb != nil {
+			return cb(ci)
+		}
+		return nil
+	})
+}
+
+func (r *Replicator) ensureRepo(spec Spec) error {
+	if _, err := r.dst.InspectRepo(spec.Repo); err != nil {
+		if !errutil.IsNotFoundError(err) {
+			return err
+		}
+		srcInfo, err := r.src.InspectRepo(spec.Repo)
+		if err != nil {
+			return err
+		}
+		if _, err := r.dst.PfsAPIClient.CreateRepo(r.dst.Ctx(), &pfs.CreateRepoRequest{
+			Repo:        client.NewRepo(spec.Repo),
+			Description: srcInfo.Description,
+		}); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	return nil
+}
+
+// lastReplicated returns the source commit ID recorded on the head of the
+// destination branch.
+func (r *Replicator) lastReplicated(spec Spec) (string, error) {
+	ci, err := r.dst.InspectCommit(spec.Repo, spec.Branch, "")
+	if err != nil {
+		if errutil.IsNotFoundError(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return SourceCommitID(ci), nil
+}
+
+// pending returns the finished source commits after last, oldest first.
+func (r *Replicator) pending(spec Spec, last string) ([]*pfs.CommitInfo, error) {
+	var result []*pfs.CommitInfo
+	head := client.NewCommit(spec.Repo, spec.Branch, "")
+	if err := r.src.ListCommitF(client.NewRepo(spec.Repo), head, nil, 0, false, func(ci *pfs.CommitInfo) error {
+		if ci.Commit.ID == last {
+			return errutil.ErrBreak
+		}
+		if ci.Finished != nil {
+			result = append(result, ci)
+		}
+		return nil
+	}); err != nil {
+		if errutil.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	// ListCommit returns newest first, replication needs oldest first.
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result, nil
+}
+
+// replicateCommit copies the changes between prev and commit in the source
+// into a new commit on the destination branch.
+func (r *Replicator) replicateCommit(spec Spec, commit, prev *pfs.Commit) (retErr error) {
+	dstCommit, err := r.dst.StartCommit(spec.Repo, spec.Branch)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if retErr != nil {
+			return
+		}
+		_, retErr = r.dst.PfsAPIClient.FinishCommit(r.dst.Ctx(), &pfs.FinishCommitRequest{
+			Commit:      dstCommit,
+			Description: fmt.Sprintf("%s%s", descriptionPrefix, commit.ID),
+		})
+		retErr = errors.EnsureStack(retErr)
+	}()
+	return r.dst.WithModifyFileClient(dstCommit, func(mf client.ModifyFile) error {
+		return r.src.DiffFile(commit, "/", prev, "/", false, func(newFile, oldFile *pfs.FileInfo) error {
+			if newFile == nil {
+				if oldFile.FileType == pfs.FileType_FILE {
+					return errors.EnsureStack(mf.DeleteFile(oldFile.File.Path))
+				}
+				return nil
+			}
+			if newFile.FileType != pfs.FileType_FILE {
+				return nil
+			}
+			rc, err := r.src.GetFileTAR(commit, newFile.File.Path)
+			if err != nil {
+				return err
+			}
+			defer rc.Close()
+			return errors.EnsureStack(mf.PutFileTAR(rc))
+		})
+	})
+}
@@ -0,0 +1,14 @@
+package replication
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+func TestSourceCommitID(t *testing.T) {
+	require.Equal(t, "", SourceCommitID(&pfs.CommitInfo{}))
+	require.Equal(t, "", SourceCommitID(&pfs.CommitInfo{Description: "initial import"}))
+	require.Equal(t, "abc123", SourceCommitID(&pfs.CommitInfo{Description: descriptionPrefix + "abc123\n"}))
+}
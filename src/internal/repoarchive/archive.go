@@ -0,0 +1,370 @@
+// Package repoarchive serializes a repo into a self-contained tar archive
+// and restores it, possibly on another cluster.
+//
+// An archive is a tar stream whose first entry is a JSON manifest describing
+// the repo, its commits (oldest first) and its branches. The manifest is
+// followed by one block of entries per commit, in manifest order: a
+// "deleted" entry listing the paths removed relative to the commit's parent,
+// followed by one entry per file added or changed in the commit. Commit IDs
+// are assigned by the destination cluster, so imports return a mapping from
+// the archived commit IDs to the new ones.
+package repoarchive
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/clientsdk"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/tarutil"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+const (
+	// Version is the version of the archive format written by Export.
+	Version = 1
+
+	manifestName = "manifest.json"
+	commitsDir   = "commits"
+	deletedName  = "deleted"
+	filesDir     = "files"
+)
+
+// Manifest describes the contents of an archive.
+type Manifest struct {
+	Version     int       `json:"version"`
+	Repo        string    `json:"repo"`
+	Description string    `json:"description,omitempty"`
+	Commits     []*Commit `json:"commits"`
+	Branches    []*Branch `json:"branches"`
+}
+
+// Commit describes an archived commit.
+type Commit struct {
+	ID          string `json:"id"`
+	Branch      string `json:"branch"`
+	Parent      string `json:"parent,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Branch describes an archived branch.
+type Branch struct {
+	Name       string       `json:"name"`
+	Head       string       `json:"head,omitempty"`
+	Provenance []string     `json:"provenance,omitempty"`
+	Trigger    *pfs.Trigger `json:"trigger,omitempty"`
+}
+
+// ImportResult describes the outcome of an import.
+type ImportResult struct {
+	// Commits maps the archived commit IDs to the IDs of the imported commits.
+	Commits map[string]string
+	// SkippedProvenance lists the branch provenance (as <repo>@<branch>)
+	// that was not restored because the upstream branch does not exist.
+	SkippedProvenance []string
+}
+
+// Export writes an archive of repo to w. Open commits are not exported.
+func Export(c *client.APIClient, repo string, w io.Writer) error {
+	repoInfo, err := c.InspectRepo(repo)
+	if err != nil {
+		return err
+	}
+	m := &Manifest{
+		Version:     Version,
+		Repo:        repo,
+		Description: repoInfo.Description,
+	}
+	// List every commit, including aliases, so that the parent chain of each
+	// exported commit is complete.
+	lc, err := c.PfsAPIClient.ListCommit(c.Ctx(), &pfs.ListCommitRequest{
+		Repo:    client.NewRepo(repo),
+		Reverse: true,
+		All:     true,
+	})
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	cis, err := clientsdk.ListCommit(lc)
+	if err != nil {
+		return err
+	}
+	exported := make(map[string]bool)
+	var commits []*pfs.Commit
+	for _, ci := range cis {
+		if ci.Finished == nil {
+			continue
+		}
+		commit := &Commit{
+			ID:          ci.Commit.ID,
+			Branch:      ci.Commit.Branch.Name,
+			Description: ci.Description,
+		}
+		if ci.ParentCommit != nil && exported[ci.ParentCommit.ID] {
+			commit.Parent = ci.ParentCommit.ID
+		}
+		exported[ci.Commit.ID] = true
+		m.Commits = append(m.Commits, commit)
+		commits = append(commits, ci.Commit)
+	}
+	bis, err := c.ListBranch(repo)
+	if err != nil {
+		return err
+	}
+	for _, bi := range bis {
+		branch := &Branch{
+			Name:    bi.Branch.Name,
+			Trigger: bi.Trigger,
+		}
+		if bi.Head != nil && exported[bi.Head.ID] {
+			branch.Head = bi.Head.ID
+		}
+		for _, prov := range bi.DirectProvenance {
+			branch.Provenance = append(branch.Provenance, prov.String())
+		}
+		m.Branches = append(m.Branches, branch)
+	}
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	return tarutil.WithWriter(w, func(tw *tar.Writer) error {
+		if err := tarutil.WriteFile(tw, tarutil.NewMemFile(manifestName, manifest)); err != nil {
+			return err
+		}
+		for i, commit := range commits {
+			var parent *pfs.Commit
+			if m.Commits[i].Parent != "" {
+				parent = client.NewCommit(repo, "", m.Commits[i].Parent)
+			}
+			if err := exportCommit(c, tw, commit, parent); err != nil {
+				return errors.Wrapf(err, "error exporting commit %v", commit.ID)
+			}
+		}
+		return nil
+	})
+}
+
+func exportCommit(c *client.APIClient, tw *tar.Writer, commit, parent *pfs.Commit) error {
+	prefix := path.Join(commitsDir, commit.ID)
+	var deleted bytes.Buffer
+	var changed []string
+	if parent == nil {
+		// Without a parent the diff is taken against the empty commit.
+		if err := c.WalkFile(commit, "/", func(fi *pfs.FileInfo) error {
+			if fi.FileType == pfs.FileType_FILE {
+				changed = append(changed, fi.File.Path)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	} else {
+		if err := c.DiffFile(commit, "/", parent, "/", false, func(newFile, oldFile *pfs.FileInfo) error {
+			if newFile == nil {
+				if oldFile.FileType == pfs.FileType_FILE {
+					deleted.WriteString(oldFile.File.Path + "\n")
+				}
+				return nil
+			}
+			if newFile.FileType == pfs.FileType_FILE {
+				changed = append(changed, newFile.File.Path)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	if err := tarutil.WriteFile(tw, tarutil.NewMemFile(path.Join(prefix, deletedName), deleted.Bytes())); err != nil {
+		return err
+	}
+	for _, p := range changed {
+		if err := func() error {
+			r, err := c.GetFileTAR(commit, p)
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+			return tarutil.Iterate(r, func(f tarutil.File) error {
+				hdr, err := f.Header()
+				if err != nil {
+					return err
+				}
+				if hdr.Typeflag == tar.TypeDir {
+					return nil
+				}
+				hdr.Name = path.Join(prefix, filesDir, hdr.Name)
+				if err := tw.WriteHeader(hdr); err != nil {
+					return errors.EnsureStack(err)
+				}
+				return f.Content(tw)
+			}, true)
+		}(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Import restores an archive into repo, which is created if it doesn't
+// exist. If repo is "" the archived repo name is used.
+func Import(c *client.APIClient, repo string, r io.Reader) (*ImportResult, error) {
+	res := &ImportResult{Commits: make(map[string]string)}
+	var m *Manifest
+	var next int // index in m.Commits of the next commit to start
+	var cur *pfs.Commit
+	var mf *client.ModifyFileClient
+	finish := func() error {
+		if mf == nil {
+			return nil
+		}
+		if err := mf.Close(); err != nil {
+			return err
+		}
+		mf = nil
+		_, err := c.PfsAPIClient.FinishCommit(c.Ctx(), &pfs.FinishCommitRequest{
+			Commit:      cur,
+			Description: m.Commits[next-1].Description,
+		})
+		return errors.EnsureStack(err)
+	}
+	start := func(id string) error {
+		if next >= len(m.Commits) || m.Commits[next].ID != id {
+			return errors.Errorf("archive entries for commit %v are out of order", id)
+		}
+		commit := m.Commits[next]
+		next++
+		req := &pfs.StartCommitRequest{
+			Branch: client.NewBranch(repo, commit.Branch),
+		}
+		if commit.Parent != "" {
+			req.Parent = client.NewCommit(repo, "", res.Commits[commit.Parent])
+		}
+		var err error
+		cur, err = c.PfsAPIClient.StartCommit(c.Ctx(), req)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		res.Commits[commit.ID] = cur.ID
+		mf, err = c.NewModifyFileClient(cur)
+		return err
+	}
+	if err := tarutil.Iterate(r, func(f tarutil.File) error {
+		hdr, err := f.Header()
+		if err != nil {
+			return err
+		}
+		if m == nil {
+			if hdr.Name != manifestName {
+				return errors.Errorf("archive must begin with %v, found %v", manifestName, hdr.Name)
+			}
+			m = &Manifest{}
+			var buf bytes.Buffer
+			if err := f.Content(&buf); err != nil {
+				return err
+			}
+			if err := json.Unmarshal(buf.Bytes(), m); err != nil {
+				return errors.EnsureStack(err)
+			}
+			if m.Version != Version {
+				return errors.Errorf("unsupported archive version %d", m.Version)
+			}
+			if repo == "" {
+				repo = m.Repo
+			}
+			return ensureRepo(c, repo, m.Description)
+		}
+		parts := strings.SplitN(hdr.Name, "/", 4)
+		if len(parts) < 3 || parts[0] != commitsDir {
+			return errors.Errorf("unexpected archive entry %v", hdr.Name)
+		}
+		id := parts[1]
+		switch {
+		case len(parts) == 3 && parts[2] == deletedName:
+			if err := finish(); err != nil {
+				return err
+			}
+			if err := start(id); err != nil {
+				return err
+			}
+			var buf bytes.Buffer
+			if err := f.Content(&buf); err != nil {
+				return err
+			}
+			scanner := bufio.NewScanner(&buf)
+			for scanner.Scan() {
+				if err := mf.DeleteFile(scanner.Text()); err != nil {
+					return errors.EnsureStack(err)
+				}
+			}
+			return errors.EnsureStack(scanner.Err())
+		case len(parts) == 4 && parts[2] == filesDir:
+			if cur == nil || res.Commits[id] != cur.ID {
+				return errors.Errorf("archive entry %v is not preceded by its commit's %v entry", hdr.Name, deletedName)
+			}
+			pr, pw := io.Pipe()
+			go func() {
+				pw.CloseWithError(f.Content(pw))
+			}()
+			// Closing the reader unblocks the writer if PutFile returns early.
+			defer pr.Close()
+			return errors.EnsureStack(mf.PutFile("/"+parts[3], pr))
+		default:
+			return errors.Errorf("unexpected archive entry %v", hdr.Name)
+		}
+	}, true); err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, errors.Errorf("archive is empty")
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+	if next != len(m.Commits) {
+		return nil, errors.Errorf("archive is truncated: found %d of %d commits", next, len(m.Commits))
+	}
+	for _, b := range m.Branches {
+		var prov []*pfs.Branch
+		for _, p := range b.Provenance {
+			parts := strings.SplitN(p, "@", 2)
+			if len(parts) != 2 {
+				return nil, errors.Errorf("invalid provenance %q on branch %v", p, b.Name)
+			}
+			if _, err := c.InspectBranch(parts[0], parts[1]); err != nil {
+				res.SkippedProvenance = append(res.SkippedProvenance, p)
+				continue
+			}
+			prov = append(prov, client.NewBranch(parts[0], parts[1]))
+		}
+		req := &pfs.CreateBranchRequest{
+			Branch:     client.NewBranch(repo, b.Name),
+			Provenance: prov,
+			Trigger:    b.Trigger,
+		}
+		if b.Head != "" {
+			req.Head = client.NewCommit(repo, "", res.Commits[b.Head])
+		}
+		if _, err := c.PfsAPIClient.CreateBranch(c.Ctx(), req); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+	}
+	return res, nil
+}
+
+func ensureRepo(c *client.APIClient, repo, description string) error {
+	if _, err := c.InspectRepo(repo); err == nil {
+		return nil
+	}
+	_, err := c.PfsAPIClient.CreateRepo(c.Ctx(), &pfs.CreateRepoRequest{
+		Repo:        client.NewRepo(repo),
+		Description: description,
+	})
+	return errors.EnsureStack(err)
+}
@@ -275,6 +275,12 @@ func asString(dst *string, x interface{}) error {
 	switch x := x.(type) {
 	case string:
 		*dst = x
+	case int64:
+		*dst = strconv.FormatInt(x, 10)
+	case float64:
+		*dst = strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		*dst = strconv.FormatBool(x)
 	case *string:
 		if x == nil {
 			return ErrCannotConvert{Dest: dst, Value: x}
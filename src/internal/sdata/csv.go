@@ -158,6 +158,24 @@ func NewCSVParser(r io.Reader) TupleReader {
 	}
 }
 
+// NewCSVParserWithHeader returns a parser of CSV data that starts with a
+// header row, along with the field names in the header.
+func NewCSVParserWithHeader(r io.Reader) (TupleReader, []string, error) {
+	p := &CSVParser{dec: csv.NewReader(r)}
+	rec, err := p.dec.Read()
+	if err != nil {
+		return nil, nil, errors.EnsureStack(err)
+	}
+	var header []string
+	for _, name := range rec {
+		if name == nil || *name == "" {
+			return nil, nil, errors.Errorf("csv parsing: header has an empty field name")
+		}
+		header = append(header, *name)
+	}
+	return p, header, nil
+}
+
 func (p *CSVParser) Next(row Tuple) error {
 	rec, err := p.dec.Read()
 	if err != nil {
@@ -0,0 +1,37 @@
+package sdata
+
+import (
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/tableexport"
+)
+
+// ParquetParser reads tuples from a Parquet file.
+type ParquetParser struct {
+	r *tableexport.ParquetReader
+}
+
+// NewParquetParser returns a parser of the Parquet file data, along with the
+// names of its columns, which are the fields of its tuples.
+func NewParquetParser(data []byte) (TupleReader, []string, error) {
+	r, err := tableexport.NewParquetReader(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &ParquetParser{r: r}, r.Columns(), nil
+}
+
+func (p *ParquetParser) Next(row Tuple) error {
+	values, err := p.r.Next()
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if len(values) != len(row) {
+		return errors.Errorf("parquet parsing: wrong number of fields HAVE: %d WANT: %d ", len(values), len(row))
+	}
+	for i := range row {
+		if err := convert(row[i], values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
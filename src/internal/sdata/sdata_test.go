@@ -313,6 +313,21 @@ func TestCSVNull(t *testing.T) {
 	require.Equal(t, row, row2)
 }
 
+func TestCSVHeader(t *testing.T) {
+	r, header, err := NewCSVParserWithHeader(strings.NewReader("id,name\n1,foo\n"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"id", "name"}, header)
+	var id int64
+	var name string
+	require.NoError(t, r.Next(Tuple{&id, &name}))
+	require.Equal(t, int64(1), id)
+	require.Equal(t, "foo", name)
+	require.True(t, errors.Is(r.Next(Tuple{&id, &name}), io.EOF))
+
+	_, _, err = NewCSVParserWithHeader(strings.NewReader("id,\n1,foo\n"))
+	require.YesError(t, err)
+}
+
 func newTupleFromTestRow(row interface{}) Tuple {
 	var process func(reflect.Type) Tuple
 	process = func(t reflect.Type) Tuple {
@@ -8,8 +8,9 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
 )
 
-// rowLimit limits the number of rows per batch in an INSERT statement
-const rowLimit = 1000
+// DefaultBatchSize is the default number of rows per batch in an INSERT
+// statement.
+const DefaultBatchSize = 1000
 
 // SQLTupleWriter writes tuples to a SQL database.
 type SQLTupleWriter struct {
@@ -17,11 +18,14 @@ type SQLTupleWriter struct {
 	tableInfo       *pachsql.TableInfo
 	insertStatement string
 	buf             []Tuple
+	batchSize       int
 }
 
 func (m *SQLTupleWriter) WriteTuple(t Tuple) error {
-	if len(m.buf) >= rowLimit {
-		m.Flush()
+	if len(m.buf) >= m.batchSize {
+		if err := m.Flush(); err != nil {
+			return err
+		}
 	}
 	m.buf = append(m.buf, CloneTuple(t))
 	return nil
@@ -76,6 +80,15 @@ func (m *SQLTupleWriter) GeneratePreparedStatement() (*pachsql.Stmt, error) {
 }
 
 func NewSQLTupleWriter(tx *pachsql.Tx, tableInfo *pachsql.TableInfo) *SQLTupleWriter {
+	return NewBatchedSQLTupleWriter(tx, tableInfo, DefaultBatchSize)
+}
+
+// NewBatchedSQLTupleWriter returns a SQLTupleWriter that inserts batchSize
+// rows per INSERT statement.
+func NewBatchedSQLTupleWriter(tx *pachsql.Tx, tableInfo *pachsql.TableInfo, batchSize int) *SQLTupleWriter {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
 	var s string
 	if tableInfo.Driver == "snowflake" {
 		var (
@@ -99,5 +112,5 @@ func NewSQLTupleWriter(tx *pachsql.Tx, tableInfo *pachsql.TableInfo) *SQLTupleWr
 			tableInfo.Name,
 			strings.Join(tableInfo.ColumnNames(), ", "))
 	}
-	return &SQLTupleWriter{tx, tableInfo, s, []Tuple{}}
+	return &SQLTupleWriter{tx, tableInfo, s, []Tuple{}, batchSize}
 }
@@ -8,16 +8,27 @@ type Configuration struct {
 	*EnterpriseSpecifcConfiguration
 }
 
+// The metadata backends that GlobalConfiguration.MetadataBackend may name.
+// The postgres backend serves etcd's API from postgres, so that a cluster
+// can run without etcd.
+const (
+	MetadataBackendEtcd     = "etcd"
+	MetadataBackendPostgres = "postgres"
+)
+
 // GlobalConfiguration contains the global configuration.
 type GlobalConfiguration struct {
 	FeatureFlags
-	EtcdHost                       string `env:"ETCD_SERVICE_HOST,required"`
-	EtcdPort                       string `env:"ETCD_SERVICE_PORT,required"`
+	MetadataBackend                string `env:"METADATA_BACKEND,default=etcd"`
+	MetadataCacheSize              int    `env:"METADATA_CACHE_SIZE,default=10000"`
+	EtcdHost                       string `env:"ETCD_SERVICE_HOST"`
+	EtcdPort                       string `env:"ETCD_SERVICE_PORT"`
 	PPSWorkerPort                  uint16 `env:"PPS_WORKER_GRPC_PORT,default=1080"`
 	Port                           uint16 `env:"PORT,default=1650"`
 	PrometheusPort                 uint16 `env:"PROMETHEUS_PORT,default=1656"`
 	PeerPort                       uint16 `env:"PEER_PORT,default=1653"`
 	S3GatewayPort                  uint16 `env:"S3GATEWAY_PORT,default=1600"`
+	RESTGatewayPort                uint16 `env:"REST_GATEWAY_PORT,default=1659"`
 	PPSEtcdPrefix                  string `env:"PPS_ETCD_PREFIX,default=pachyderm_pps"`
 	Namespace                      string `env:"PACH_NAMESPACE,default=default"`
 	StorageRoot                    string `env:"PACH_ROOT,default=/pach"`
@@ -62,6 +73,10 @@ type GlobalConfiguration struct {
 	PPSSpecCommitID string `env:"PPS_SPEC_COMMIT"`
 	// The name of the pipeline that this worker belongs to
 	PPSPipelineName string `env:"PPS_PIPELINE_NAME"`
+	// PPSWorkerPool is the key of the warm worker pool that started this
+	// worker. Pool workers wait to be claimed by a pipeline, and then set
+	// PPSPipelineName and PPSSpecCommitID to the pipeline's.
+	PPSWorkerPool string `env:"PPS_WORKER_POOL"`
 
 	// If set to the name of a GCP project, enable GCP-specific continuous profiling and send
 	// profiles to that project: https://cloud.google.com/profiler/docs.  Requires that pachd
@@ -71,8 +86,40 @@ type GlobalConfiguration struct {
 	// also need permission).
 	GoogleCloudProfilerProject string `env:"GOOGLE_CLOUD_PROFILER_PROJECT"`
 
+	// Pachd and the workers capture a heap profile, and a CPU profile over
+	// ContinuousProfilingCPUSeconds, every ContinuousProfilingIntervalSeconds,
+	// and keep them in object storage for ContinuousProfilingRetentionHours.
+	// An interval of 0 disables continuous profiling. If set on a pachd pod,
+	// propagates to workers and sidecars.
+	ContinuousProfilingIntervalSeconds int `env:"CONTINUOUS_PROFILING_INTERVAL_SECONDS,default=300"`
+	ContinuousProfilingCPUSeconds      int `env:"CONTINUOUS_PROFILING_CPU_SECONDS,default=10"`
+	ContinuousProfilingRetentionHours  int `env:"CONTINUOUS_PROFILING_RETENTION_HOURS,default=24"`
+
 	// The number of concurrent requests that the PPS Master can make against kubernetes
 	PPSMaxConcurrentK8sRequests int `env:"PPS_MAX_CONCURRENT_K8S_REQUESTS,default=10"`
+
+	// The limits on the RPCs handled by pachd's external gRPC server. A
+	// message size of 0 is only limited by gRPC's own limit.
+	// GRPCDefaultDeadlines is a comma-separated list of class=duration pairs,
+	// where the class is read, write or stream, which apply to requests
+	// without a deadline. GRPCConcurrencyLimits is a comma-separated list of
+	// method=limit pairs, such as /pfs_v2.API/GlobFile=10.
+	GRPCMaxRequestBytes   int    `env:"GRPC_MAX_REQUEST_BYTES,default=0"`
+	GRPCMaxResponseBytes  int    `env:"GRPC_MAX_RESPONSE_BYTES,default=0"`
+	GRPCDefaultDeadlines  string `env:"GRPC_DEFAULT_DEADLINES,default="`
+	GRPCConcurrencyLimits string `env:"GRPC_CONCURRENCY_LIMITS,default="`
+	// GRPCPrincipalLimits are the limits on each principal's requests, as a
+	// comma-separated list of limit=value pairs, where the limit is requests
+	// (per second), streams or file_bytes (per second).
+	// GRPCPrincipalLimitOverrides is a semicolon-separated list of
+	// principal=limits pairs, such as robot:etl=requests=100.
+	GRPCPrincipalLimits         string `env:"GRPC_PRINCIPAL_LIMITS,default="`
+	GRPCPrincipalLimitOverrides string `env:"GRPC_PRINCIPAL_LIMIT_OVERRIDES,default="`
+
+	// When a newer version of pachd upgrades the cluster's state, replicas
+	// of older versions stop accepting requests, and cancel the requests
+	// they're handling that don't finish within UpgradeDrainTimeoutSeconds.
+	UpgradeDrainTimeoutSeconds int `env:"UPGRADE_DRAIN_TIMEOUT_SECONDS,default=60"`
 }
 
 // PachdFullConfiguration contains the full pachd configuration.
@@ -101,6 +148,51 @@ type PachdSpecificConfiguration struct {
 	PachdPodName                 string `env:"PACHD_POD_NAME,required"`
 	EnableWorkerSecurityContexts bool   `env:"ENABLE_WORKER_SECURITY_CONTEXTS,default=true"`
 	TLSCertSecretName            string `env:"TLS_CERT_SECRET_NAME,default="`
+	// PeerTLSSecretName is the name of the secret, with a certificate, key
+	// and CA, that's mounted in pachd and its workers for them to
+	// authenticate each other with over mutual TLS.
+	PeerTLSSecretName string `env:"PEER_TLS_SECRET_NAME,default="`
+	// PipelinePriorityClasses is a comma-separated list of name=class pairs,
+	// mapping the priorities pipelines may set to Kubernetes PriorityClasses.
+	PipelinePriorityClasses string `env:"PIPELINE_PRIORITY_CLASSES,default="`
+	// WorkerEgressCIDRs is a comma-separated list of CIDRs that the workers of
+	// pipelines with network policies may always connect to, such as those of
+	// object storage and postgres, when they're outside of the cluster.
+	WorkerEgressCIDRs string `env:"WORKER_EGRESS_CIDRS,default="`
+	// The image policy that the images of created and updated pipelines must
+	// satisfy. PipelineImageAllowedRegistries is a comma-separated list of
+	// registries, optionally with a path, such as gcr.io/my-project, and
+	// PipelineImageScanWebhookURL is called to scan each image.
+	PipelineImageRequireDigest      bool   `env:"PIPELINE_IMAGE_REQUIRE_DIGEST,default=false"`
+	PipelineImageAllowedRegistries  string `env:"PIPELINE_IMAGE_ALLOWED_REGISTRIES,default="`
+	PipelineImageScanWebhookURL     string `env:"PIPELINE_IMAGE_SCAN_WEBHOOK_URL,default="`
+	PipelineImageScanTimeoutSeconds int    `env:"PIPELINE_IMAGE_SCAN_TIMEOUT_SECONDS,default=30"`
+	// GPUSharedReplicas is the number of replicas the GPU device plugin
+	// advertises for each time-sliced GPU, as GPUSharedResource. Pipelines
+	// can only request fractional GPUs if it's set.
+	GPUSharedReplicas int64  `env:"GPU_SHARED_REPLICAS,default=0"`
+	GPUSharedResource string `env:"GPU_SHARED_RESOURCE,default=nvidia.com/gpu.shared"`
+	// LogArchiveRetentionDays is the number of days the logs of finished jobs
+	// are kept in object storage. Logs aren't archived if it's 0.
+	LogArchiveRetentionDays int `env:"LOG_ARCHIVE_RETENTION_DAYS,default=0"`
+	// The capacities, as Kubernetes quantities such as 10Gi, that the
+	// capacity report projects storage growth against. A capacity that's
+	// empty isn't known. The etcd quota defaults to the one it's deployed
+	// with.
+	CapacityPostgresSize      string `env:"CAPACITY_POSTGRES_SIZE,default="`
+	CapacityObjectStorageSize string `env:"CAPACITY_OBJECT_STORAGE_SIZE,default="`
+	CapacityEtcdQuota         string `env:"CAPACITY_ETCD_QUOTA,default=8Gi"`
+	// SearchIndexPeriod is the number of seconds between updates of the
+	// search index. The index isn't updated if it's less than or equal to 0.
+	SearchIndexPeriod int64 `env:"SEARCH_INDEX_PERIOD,default=60"`
+	// HealthCheckIntervalSeconds is how often pachd checks that it can reach
+	// etcd, postgres and object storage, and that auth is available, and
+	// HealthCheckTimeoutSeconds is how long each check may take.
+	HealthCheckIntervalSeconds int `env:"HEALTH_CHECK_INTERVAL_SECONDS,default=10"`
+	HealthCheckTimeoutSeconds  int `env:"HEALTH_CHECK_TIMEOUT_SECONDS,default=5"`
+	// BackupLocation is the object storage URL that backups are taken to and
+	// restored from, when a request doesn't set one.
+	BackupLocation string `env:"BACKUP_LOCATION,default="`
 }
 
 // EnterpriseServerConfiguration contains the full configuration for an enterprise server
@@ -112,6 +204,16 @@ type EnterpriseServerConfiguration struct {
 // EnterpriseSpecifcConfiguration contains the configuration required for enterprise features
 type EnterpriseSpecifcConfiguration struct {
 	AuthRootToken string `env:"AUTH_ROOT_TOKEN,default="`
+	// AuthRoleRequestWebhookURL, if set, receives a POST whenever a role
+	// request is created, reviewed or expires.
+	AuthRoleRequestWebhookURL string `env:"AUTH_ROLE_REQUEST_WEBHOOK_URL,default="`
+	// AuthKubernetesAudiences is a comma-separated list of audiences. If set,
+	// workloads can log in with a service account token issued by the cluster
+	// for one of them.
+	AuthKubernetesAudiences string `env:"AUTH_KUBERNETES_AUDIENCES,default="`
+	// AuthKubernetesNamespaces is a comma-separated list of the namespaces
+	// whose service accounts can log in. If empty, any namespace can.
+	AuthKubernetesNamespaces string `env:"AUTH_KUBERNETES_NAMESPACES,default="`
 }
 
 // StorageConfiguration contains the storage configuration.
@@ -128,6 +230,50 @@ type StorageConfiguration struct {
 	StorageFileSetsMaxOpen               int   `env:"STORAGE_FILESETS_MAX_OPEN,default=50"`
 	StorageDiskCacheSize                 int   `env:"STORAGE_DISK_CACHE_SIZE,default=100"`
 	StorageMemoryCacheSize               int   `env:"STORAGE_MEMORY_CACHE_SIZE,default=100"`
+	// StorageFailedCommitCleanupPeriod is the number of seconds between runs
+	// of the failed commit cleanup, which drops the data of failed pipeline
+	// output and meta commits. The cleanup is disabled when this is 0.
+	StorageFailedCommitCleanupPeriod int64 `env:"STORAGE_FAILED_COMMIT_CLEANUP_PERIOD,default=0"`
+	// StorageFailedCommitMaxAge is the number of seconds a failed commit's
+	// data is kept after the commit finished (0 keeps it regardless of age).
+	StorageFailedCommitMaxAge int64 `env:"STORAGE_FAILED_COMMIT_MAX_AGE,default=0"`
+	// StorageFailedCommitKeepCount is the number of most recent failed
+	// commits per repo whose data is kept (0 keeps it regardless of count).
+	StorageFailedCommitKeepCount int `env:"STORAGE_FAILED_COMMIT_KEEP_COUNT,default=0"`
+	// StorageRetentionPeriod is the number of seconds between runs of the
+	// enforcement of repo and branch retention policies, which squashes the
+	// commits they don't keep. Enforcement is disabled when this is 0 or less.
+	StorageRetentionPeriod int64 `env:"STORAGE_RETENTION_PERIOD,default=600"`
+	// StorageSecondaryURL is the URL of an optional secondary bucket that
+	// object storage writes are mirrored to and reads fail over to. It uses
+	// the same credentials as the primary bucket.
+	StorageSecondaryURL string `env:"STORAGE_SECONDARY_URL,default="`
+	// StorageSecondaryAsync mirrors writes to the secondary bucket in the
+	// background rather than waiting for them.
+	StorageSecondaryAsync bool `env:"STORAGE_SECONDARY_ASYNC,default=false"`
+	// StorageMirrorCheckPeriod is the number of seconds between checks that
+	// repair objects missing from the secondary bucket. The check is disabled
+	// when this is 0.
+	StorageMirrorCheckPeriod int64 `env:"STORAGE_MIRROR_CHECK_PERIOD,default=0"`
+	// StorageStreamCompression is a comma-separated list of the compressors
+	// clients may use for file transfer streams, or "none" to only allow
+	// uncompressed transfers. All supported compressors are allowed when it's
+	// empty.
+	StorageStreamCompression string `env:"STORAGE_STREAM_COMPRESSION,default="`
+	// StorageAccessLog logs every object storage operation along with the
+	// principal it's made on behalf of.
+	StorageAccessLog bool `env:"STORAGE_ACCESS_LOG,default=false"`
+	// StorageNodeCachePath is a directory shared by the pods on a node, in
+	// which the chunks they read from object storage are cached, so that a
+	// chunk read by one pod isn't read from object storage again by another.
+	// Chunks aren't shared between pods when it's empty.
+	StorageNodeCachePath string `env:"STORAGE_NODE_CACHE_PATH,default="`
+	// StorageNodeCacheHostPath is the path on each node of the directory
+	// mounted at StorageNodeCachePath. Pachd mounts it in its workers.
+	StorageNodeCacheHostPath string `env:"STORAGE_NODE_CACHE_HOST_PATH,default="`
+	// StorageNodeCacheSizeBytes is the size that the chunk cache daemon
+	// evicts the least recently read chunks in a node's cache down to.
+	StorageNodeCacheSizeBytes int64 `env:"STORAGE_NODE_CACHE_SIZE_BYTES,default=10737418240"`
 }
 
 // WorkerFullConfiguration contains the full worker configuration.
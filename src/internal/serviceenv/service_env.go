@@ -27,6 +27,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/dbutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pgetcd"
 	"github.com/pachyderm/pachyderm/v2/src/internal/promutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/task"
 	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
@@ -155,14 +156,18 @@ func InitPachOnlyEnv(config *Configuration) *NonblockingServiceEnv {
 // until their respective clients are ready.
 func InitServiceEnv(config *Configuration) *NonblockingServiceEnv {
 	env := InitPachOnlyEnv(config)
-	env.etcdAddress = fmt.Sprintf("http://%s", net.JoinHostPort(env.config.EtcdHost, env.config.EtcdPort))
+	if env.config.EtcdHost != "" {
+		env.etcdAddress = fmt.Sprintf("http://%s", net.JoinHostPort(env.config.EtcdHost, env.config.EtcdPort))
+	}
+	// the listener must exist before the etcd client is initialized, as the
+	// postgres metadata backend listens for changes with it
+	env.listener = env.newListener()
 	env.etcdEg.Go(env.initEtcdClient)
 	env.clusterIdEg.Go(env.initClusterID)
 	env.dbEg.Go(env.initDBClient)
 	if !env.isWorker() {
 		env.dbEg.Go(env.initDirectDBClient)
 	}
-	env.listener = env.newListener()
 	if lokiHost, lokiPort := os.Getenv(env.config.LokiHostVar), os.Getenv(env.config.LokiPortVar); lokiHost != "" && lokiPort != "" {
 		env.lokiClient = &loki.Client{
 			Address: fmt.Sprintf("http://%s", net.JoinHostPort(lokiHost, lokiPort)),
@@ -228,15 +233,22 @@ func (env *NonblockingServiceEnv) initPachClient() error {
 }
 
 func (env *NonblockingServiceEnv) initEtcdClient() error {
+	opts := client.DefaultDialOptions() // SA1019 can't call grpc.Dial directly
+	opts = append(opts,
+		grpc.WithChainUnaryInterceptor(grpc_prometheus.UnaryClientInterceptor),
+		grpc.WithChainStreamInterceptor(grpc_prometheus.StreamClientInterceptor))
+	switch env.config.MetadataBackend {
+	case MetadataBackendEtcd:
+	case MetadataBackendPostgres:
+		return env.initPostgresEtcdClient(opts)
+	default:
+		return errors.Errorf("unknown metadata backend %q, it must be %q or %q", env.config.MetadataBackend, MetadataBackendEtcd, MetadataBackendPostgres)
+	}
 	// validate argument
 	if env.etcdAddress == "" {
 		return errors.New("cannot initialize etcd client with empty etcd address")
 	}
 	// Initialize etcd
-	opts := client.DefaultDialOptions() // SA1019 can't call grpc.Dial directly
-	opts = append(opts,
-		grpc.WithChainUnaryInterceptor(grpc_prometheus.UnaryClientInterceptor),
-		grpc.WithChainStreamInterceptor(grpc_prometheus.StreamClientInterceptor))
 	return backoff.Retry(func() error {
 		var err error
 		env.etcdClient, err = etcd.New(etcd.Config{
@@ -255,6 +267,27 @@ func (env *NonblockingServiceEnv) initEtcdClient() error {
 	}, backoff.RetryEvery(time.Second).For(5*time.Minute))
 }
 
+// initPostgresEtcdClient initializes an etcd client that's served from
+// postgres, rather than by etcd.
+func (env *NonblockingServiceEnv) initPostgresEtcdClient(opts []grpc.DialOption) error {
+	server := pgetcd.NewServer(env.GetDBClient(), env.GetPostgresListener())
+	if err := backoff.Retry(func() error {
+		return server.Setup(env.ctx)
+	}, backoff.RetryEvery(time.Second).For(5*time.Minute)); err != nil {
+		return err
+	}
+	if !env.isWorker() {
+		go func() {
+			if err := server.Run(env.ctx); err != nil && !errors.Is(err, context.Canceled) {
+				log.Errorf("postgres metadata backend stopped: %v", err)
+			}
+		}()
+	}
+	var err error
+	env.etcdClient, err = server.NewClient(env.ctx, opts...)
+	return errors.Wrapf(err, "failed to initialize etcd client")
+}
+
 func (env *NonblockingServiceEnv) initKubeClient() error {
 	return backoff.Retry(func() error {
 		// Get secure in-cluster config
@@ -240,6 +240,12 @@ func chunkPath(chunkID ID, gen uint64) string {
 	return path.Join(prefix, fmt.Sprintf("%s.%016x", chunkID.HexString(), gen))
 }
 
+// ObjectPath returns the path, in object storage, of the object that holds a
+// chunk's data.
+func ObjectPath(chunkID ID, gen uint64) string {
+	return chunkPath(chunkID, gen)
+}
+
 func chunkKey(chunkID ID, gen uint64) []byte {
 	return []byte(chunkPath(chunkID, gen))
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
 	"github.com/pachyderm/pachyderm/v2/src/internal/serviceenv"
 	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
+	"github.com/sirupsen/logrus"
 )
 
 // StorageOption configures a storage.
@@ -27,6 +28,20 @@ func WithObjectCache(fastLayer obj.Client, size int) StorageOption {
 	}
 }
 
+// WithNodeCache adds a cache, in a directory shared by the pods on a node,
+// around the currently configured object client. The cache isn't used if the
+// directory can't be set up, since the pods that share it don't depend on it.
+func WithNodeCache(dir string) StorageOption {
+	return func(s *Storage) {
+		objC, err := obj.NewNodeCacheClient(s.objClient, dir)
+		if err != nil {
+			logrus.Warnf("could not set up the node cache in %s: %v", dir, err)
+			return
+		}
+		s.objClient = objC
+	}
+}
+
 // WithSecret sets the secret used to generate chunk encryption keys
 func WithSecret(secret []byte) StorageOption {
 	return func(s *Storage) {
@@ -47,6 +62,9 @@ func StorageOptions(conf *serviceenv.StorageConfiguration) ([]StorageOption, err
 	if conf.StorageUploadConcurrencyLimit > 0 {
 		opts = append(opts, WithMaxConcurrentObjects(0, conf.StorageUploadConcurrencyLimit))
 	}
+	if conf.StorageNodeCachePath != "" {
+		opts = append(opts, WithNodeCache(conf.StorageNodeCachePath))
+	}
 	if conf.StorageDiskCacheSize > 0 {
 		diskCache, err := obj.NewLocalClient(filepath.Join(os.TempDir(), "pfs-cache", uuid.NewWithoutDashes()))
 		if err != nil {
@@ -17,6 +17,8 @@ type file struct {
 	path     string
 	datum    string
 	contents []fileContent
+	// opts apply to the first content, which is the start of the file.
+	opts []FileOption
 }
 
 // contents are either raw bytes to be appended or an existing file to be copied
@@ -49,8 +51,11 @@ func (b *Buffer) add(path, datum string) *file {
 	return datumFiles[datum]
 }
 
-func (b *Buffer) Add(path, datum string) io.Writer {
+func (b *Buffer) Add(path, datum string, opts ...FileOption) io.Writer {
 	f := b.add(path, datum)
+	if len(f.contents) == 0 {
+		f.opts = opts
+	}
 	if len(f.contents) > 0 && f.contents[len(f.contents)-1].copy == nil {
 		return f.contents[len(f.contents)-1].buf
 	}
@@ -92,14 +97,18 @@ func (b *Buffer) Copy(file File, datum string) {
 	f.contents = append(f.contents, fileContent{copy: file})
 }
 
-func (b *Buffer) WalkAdditive(onAdd func(path, datum string, r io.Reader) error, onCopy func(file File, datum string) error) error {
+func (b *Buffer) WalkAdditive(onAdd func(path, datum string, r io.Reader, opts ...FileOption) error, onCopy func(file File, datum string) error) error {
 	for _, file := range sortFiles(b.additive) {
-		for _, content := range file.contents {
+		for i, content := range file.contents {
+			var opts []FileOption
+			if i == 0 {
+				opts = file.opts
+			}
 			if content.copy != nil {
 				if err := onCopy(content.copy, file.datum); err != nil {
 					return err
 				}
-			} else if err := onAdd(file.path, file.datum, bytes.NewReader(content.buf.Bytes())); err != nil {
+			} else if err := onAdd(file.path, file.datum, bytes.NewReader(content.buf.Bytes()), opts...); err != nil {
 				return err
 			}
 		}
@@ -0,0 +1,43 @@
+package fileset
+
+import (
+	"bytes"
+	"mime"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// ParquetContentType is the content type of Parquet files.
+const ParquetContentType = "application/vnd.apache.parquet"
+
+func init() {
+	// Parquet files start (and end) with "PAR1".
+	mimetype.Extend(func(raw []byte, _ uint32) bool {
+		return bytes.HasPrefix(raw, []byte("PAR1"))
+	}, ParquetContentType, ".parquet")
+}
+
+// DetectContentType returns the content type of a file that starts with
+// data, which is empty for empty files.
+func DetectContentType(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	return mimetype.Detect(data).String()
+}
+
+// MatchContentType returns true if contentType is of the media type
+// pattern, which may be a type followed by "/*" to match all of its
+// subtypes. Parameters, such as charsets, are ignored.
+func MatchContentType(pattern, contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if strings.HasSuffix(pattern, "/*") {
+		return strings.HasPrefix(mediaType, strings.TrimSuffix(pattern, "*"))
+	}
+	return mediaType == pattern
+}
@@ -0,0 +1,25 @@
+package fileset
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestDetectContentType(t *testing.T) {
+	require.Equal(t, "", DetectContentType(nil))
+	require.Equal(t, ParquetContentType, DetectContentType([]byte("PAR1\x15\x04")))
+	require.Equal(t, "text/csv", DetectContentType([]byte("a,b\n1,2\n3,4\n")))
+	require.Equal(t, "application/json", DetectContentType([]byte(`{"a": [1, 2]}`)))
+	require.Equal(t, "image/png", DetectContentType([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR")))
+	require.Equal(t, "application/octet-stream", DetectContentType([]byte{0, 1, 2, 3, 0xff}))
+}
+
+func TestMatchContentType(t *testing.T) {
+	require.True(t, MatchContentType("text/plain", "text/plain; charset=utf-8"))
+	require.True(t, MatchContentType("text/*", "text/csv"))
+	require.True(t, MatchContentType("Text/CSV", "text/csv"))
+	require.False(t, MatchContentType("text/*", "application/json"))
+	require.False(t, MatchContentType("text/csv", "text/plain; charset=utf-8"))
+	require.False(t, MatchContentType("text/csv", ""))
+}
@@ -265,6 +265,23 @@ func TestStableHashFuzz(t *testing.T) {
 	testStableHash(t, randutil.Bytes(random, 100*units.MB), nil, msg)
 }
 
+// TestComputeHash checks that ComputeHash matches the stable hashes of files
+// without writing them.
+func TestComputeHash(t *testing.T) {
+	expected, err := pachhash.ParseHex([]byte("27e12145099615b6bf0364a4472452dfe0e8105e6d58d7fbc5d0c038c7a50736"))
+	require.NoError(t, err)
+	random := rand.New(rand.NewSource(1648577872380609229))
+	hash, err := ComputeHash(bytes.NewReader(randutil.Bytes(random, 100*units.KB)))
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(expected[:], hash))
+	expected, err = pachhash.ParseHex([]byte("5672e6f3e1841f3f1e284c2d4b7c12dc213ffc88878c9d3e2302be8acd0198ef"))
+	require.NoError(t, err)
+	random = rand.New(rand.NewSource(1648577872380609229))
+	hash, err = ComputeHash(bytes.NewReader(randutil.Bytes(random, 100*units.MB)))
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(expected[:], hash))
+}
+
 func testStableHash(t *testing.T, data, expected []byte, msg string) {
 	ctx := context.Background()
 	storage := newTestStorage(t)
@@ -302,6 +319,9 @@ func testStableHash(t *testing.T, data, expected []byte, msg string) {
 	if expected != nil {
 		require.True(t, bytes.Equal(expected, stableHash))
 	}
+	computedHash, err := ComputeHash(bytes.NewReader(data))
+	require.NoError(t, err, msg)
+	require.True(t, bytes.Equal(stableHash, computedHash), msg)
 	// Compute hash after writing to two writers.
 	ids = nil
 	size := len(data) / 2
@@ -151,11 +151,14 @@ func (m *Range) GetChunkRef() *chunk.DataRef {
 }
 
 type File struct {
-	Datum                string           `protobuf:"bytes,1,opt,name=datum,proto3" json:"datum,omitempty"`
-	DataRefs             []*chunk.DataRef `protobuf:"bytes,2,rep,name=data_refs,json=dataRefs,proto3" json:"data_refs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	Datum    string           `protobuf:"bytes,1,opt,name=datum,proto3" json:"datum,omitempty"`
+	DataRefs []*chunk.DataRef `protobuf:"bytes,2,rep,name=data_refs,json=dataRefs,proto3" json:"data_refs,omitempty"`
+	// content_type is the media type of the file, detected from the beginning
+	// of its content unless it was set when the file was written.
+	ContentType          string   `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *File) Reset()         { *m = File{} }
@@ -205,6 +208,13 @@ func (m *File) GetDataRefs() []*chunk.DataRef {
 	return nil
 }
 
+func (m *File) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Index)(nil), "index.Index")
 	proto.RegisterType((*Range)(nil), "index.Range")
@@ -216,26 +226,27 @@ func init() {
 }
 
 var fileDescriptor_dfa1b84c403551af = []byte{
-	// 300 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x51, 0xc1, 0x4a, 0xf4, 0x30,
-	0x10, 0x26, 0xdb, 0x76, 0xd9, 0x66, 0x7f, 0xfe, 0x43, 0x10, 0x29, 0x0a, 0xb5, 0xf4, 0xb4, 0x28,
-	0x34, 0xb0, 0xbe, 0x81, 0x2c, 0xc2, 0xde, 0x24, 0x47, 0x2f, 0x6b, 0xb6, 0x9d, 0xb4, 0xc1, 0x6e,
-	0x5b, 0x92, 0x54, 0xf4, 0x0d, 0x3d, 0xfa, 0x08, 0xd2, 0x27, 0x91, 0x24, 0x3d, 0x08, 0x8a, 0x97,
-	0x61, 0xbe, 0x99, 0x6f, 0xe6, 0xfb, 0x26, 0xc1, 0xd7, 0xb2, 0x33, 0xa0, 0x3a, 0xde, 0x52, 0x6d,
-	0x7a, 0xc5, 0x6b, 0xa0, 0x42, 0xb6, 0xa0, 0xc1, 0x50, 0xd9, 0x55, 0xf0, 0xea, 0x63, 0x31, 0xa8,
-	0xde, 0xf4, 0x24, 0x72, 0xe0, 0x22, 0xff, 0x31, 0x52, 0x36, 0x63, 0xf7, 0xec, 0xa3, 0xa7, 0xe6,
-	0x4f, 0x38, 0xda, 0x5b, 0x32, 0x21, 0x38, 0x1c, 0xb8, 0x69, 0x12, 0x94, 0xa1, 0x4d, 0xcc, 0x5c,
-	0x4e, 0x72, 0x1c, 0x29, 0xde, 0xd5, 0x90, 0x2c, 0x32, 0xb4, 0x59, 0x6f, 0xff, 0x15, 0x5e, 0x84,
-	0xd9, 0x1a, 0xf3, 0x2d, 0x72, 0x85, 0x43, 0x6b, 0x24, 0x09, 0x1c, 0x65, 0x3d, 0x53, 0xee, 0x65,
-	0x0b, 0xcc, 0x35, 0x72, 0x89, 0x23, 0x37, 0x40, 0xce, 0xf1, 0xb2, 0x17, 0x42, 0x83, 0x71, 0x1a,
-	0x01, 0x9b, 0x11, 0xb9, 0xc4, 0x71, 0xcb, 0xb5, 0x39, 0x38, 0xf9, 0x85, 0x93, 0x5f, 0xd9, 0xc2,
-	0x83, 0xb5, 0x70, 0x83, 0x63, 0x67, 0xf7, 0xa0, 0x40, 0xcc, 0x1a, 0xff, 0x0b, 0x7f, 0xc0, 0x8e,
-	0x1b, 0xce, 0x40, 0xb0, 0x95, 0x83, 0x0c, 0x44, 0xbe, 0xc7, 0xa1, 0x15, 0x26, 0x67, 0x38, 0xaa,
-	0xb8, 0x19, 0x4f, 0xf3, 0x31, 0x1e, 0xd8, 0x55, 0x15, 0x37, 0xdc, 0x6e, 0xd2, 0xc9, 0x22, 0x0b,
-	0x7e, 0x5b, 0x55, 0xf9, 0x44, 0xdf, 0xb1, 0xf7, 0x29, 0x45, 0x1f, 0x53, 0x8a, 0x3e, 0xa7, 0x14,
-	0x3d, 0xee, 0x6a, 0x69, 0x9a, 0xf1, 0x58, 0x94, 0xfd, 0x89, 0x0e, 0xbc, 0x6c, 0xde, 0x2a, 0x50,
-	0xdf, 0xb3, 0x97, 0x2d, 0xd5, 0xaa, 0xa4, 0x7f, 0x7f, 0xd1, 0x71, 0xe9, 0x9e, 0xfc, 0xf6, 0x2b,
-	0x00, 0x00, 0xff, 0xff, 0x33, 0x84, 0xa8, 0x77, 0xcb, 0x01, 0x00, 0x00,
+	// 316 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x51, 0xc1, 0x4a, 0xc3, 0x40,
+	0x10, 0x25, 0x6d, 0x53, 0x9a, 0x69, 0xf1, 0xb0, 0x88, 0x04, 0x85, 0x5a, 0x73, 0x2a, 0x0a, 0x09,
+	0xd4, 0x3f, 0x90, 0x22, 0x78, 0x93, 0xc5, 0x93, 0x97, 0xba, 0x4d, 0x26, 0x4d, 0x30, 0xdd, 0x0d,
+	0xbb, 0x53, 0xb1, 0x7f, 0xe8, 0xd1, 0x4f, 0x90, 0x7c, 0x89, 0xec, 0x6e, 0x0e, 0x82, 0xe2, 0x65,
+	0x98, 0x37, 0xf3, 0x66, 0xde, 0x3c, 0x06, 0xae, 0x6b, 0x49, 0xa8, 0xa5, 0x68, 0x32, 0x43, 0x4a,
+	0x8b, 0x1d, 0x66, 0x65, 0xdd, 0xa0, 0x41, 0xca, 0x6a, 0x59, 0xe0, 0xbb, 0x8f, 0x69, 0xab, 0x15,
+	0x29, 0x16, 0x3a, 0x70, 0x9e, 0xfc, 0x1a, 0xc9, 0xab, 0x83, 0x7c, 0xf5, 0xd1, 0x53, 0x93, 0x17,
+	0x08, 0x1f, 0x2c, 0x99, 0x31, 0x18, 0xb5, 0x82, 0xaa, 0x38, 0x58, 0x04, 0xcb, 0x88, 0xbb, 0x9c,
+	0x25, 0x10, 0x6a, 0x21, 0x77, 0x18, 0x0f, 0x16, 0xc1, 0x72, 0xba, 0x9a, 0xa5, 0x5e, 0x84, 0xdb,
+	0x1a, 0xf7, 0x2d, 0x76, 0x09, 0x23, 0x7b, 0x48, 0x3c, 0x74, 0x94, 0x69, 0x4f, 0xb9, 0xaf, 0x1b,
+	0xe4, 0xae, 0x91, 0xd4, 0x10, 0xba, 0x01, 0x76, 0x06, 0x63, 0x55, 0x96, 0x06, 0xc9, 0x69, 0x0c,
+	0x79, 0x8f, 0xd8, 0x05, 0x44, 0x8d, 0x30, 0xb4, 0x71, 0xf2, 0x03, 0x27, 0x3f, 0xb1, 0x85, 0x47,
+	0x7b, 0xc2, 0x0d, 0x44, 0xee, 0xdc, 0x8d, 0xc6, 0xb2, 0xd7, 0x38, 0x49, 0xbd, 0x81, 0xb5, 0x20,
+	0xc1, 0xb1, 0xe4, 0x13, 0x07, 0x39, 0x96, 0x49, 0x03, 0x23, 0x2b, 0xcc, 0x4e, 0x21, 0x2c, 0x04,
+	0x1d, 0xf6, 0xbd, 0x19, 0x0f, 0xec, 0xaa, 0x42, 0x90, 0xb0, 0x9b, 0x4c, 0x3c, 0x58, 0x0c, 0xff,
+	0x5a, 0x55, 0xf8, 0xc4, 0xb0, 0x2b, 0x98, 0xe5, 0x4a, 0x12, 0x4a, 0xda, 0xd0, 0xb1, 0xf5, 0xf6,
+	0x22, 0x3e, 0xed, 0x6b, 0x4f, 0xc7, 0x16, 0xef, 0xf8, 0x47, 0x37, 0x0f, 0x3e, 0xbb, 0x79, 0xf0,
+	0xd5, 0xcd, 0x83, 0xe7, 0xf5, 0xae, 0xa6, 0xea, 0xb0, 0x4d, 0x73, 0xb5, 0xcf, 0x5a, 0x91, 0x57,
+	0xc7, 0x02, 0xf5, 0xcf, 0xec, 0x6d, 0x95, 0x19, 0x9d, 0x67, 0xff, 0x7f, 0x71, 0x3b, 0x76, 0x5f,
+	0xb9, 0xfd, 0x1e, 0x00, 0x02, 0xf9, 0xe8, 0x95, 0xee, 0x01, 0x00, 0x00,
 }
 
 func (m *Index) Marshal() (dAtA []byte, err error) {
@@ -371,6 +382,13 @@ func (m *File) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.ContentType) > 0 {
+		i -= len(m.ContentType)
+		copy(dAtA[i:], m.ContentType)
+		i = encodeVarintIndex(dAtA, i, uint64(len(m.ContentType)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.DataRefs) > 0 {
 		for iNdEx := len(m.DataRefs) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -469,6 +487,10 @@ func (m *File) Size() (n int) {
 			n += 1 + l + sovIndex(uint64(l))
 		}
 	}
+	l = len(m.ContentType)
+	if l > 0 {
+		n += 1 + l + sovIndex(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -869,6 +891,38 @@ func (m *File) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContentType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIndex(dAtA[iNdEx:])
@@ -62,12 +62,19 @@ func (mr *MergeReader) iterate(ctx context.Context, cb func(File) error) error {
 			return cb(newFileReader(mr.chunks, fss[0].file.Index()))
 		}
 		var dataRefs []*chunk.DataRef
+		var contentType string
 		for _, fs := range fss {
 			idx := fs.file.Index()
 			dataRefs = append(dataRefs, idx.File.DataRefs...)
+			// The content type is detected from the start of the file, which
+			// is in the first part that isn't empty.
+			if contentType == "" {
+				contentType = idx.File.ContentType
+			}
 		}
 		mergeIdx := fss[0].file.Index()
 		mergeIdx.File.DataRefs = dataRefs
+		mergeIdx.File.ContentType = contentType
 		return cb(newMergeFileReader(mr.chunks, mergeIdx))
 
 	})
@@ -6,6 +6,7 @@ import (
 	"golang.org/x/sync/semaphore"
 
 	"github.com/pachyderm/pachyderm/v2/src/internal/serviceenv"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset/index"
 )
 
 // StorageOption configures a storage.
@@ -95,6 +96,17 @@ func WithTTL(ttl time.Duration) WriterOption {
 	}
 }
 
+// FileOption configures a file written to a file set.
+type FileOption func(*index.File)
+
+// WithContentType sets the content type of a file, rather than detecting it
+// from the file's content.
+func WithContentType(contentType string) FileOption {
+	return func(f *index.File) {
+		f.ContentType = contentType
+	}
+}
+
 // StorageOptions returns the fileset storage options for the config.
 func StorageOptions(conf *serviceenv.StorageConfiguration) []StorageOption {
 	var opts []StorageOption
@@ -151,3 +151,17 @@ func (c *Cache) Clear(ctx context.Context, tagPrefix string) error {
 	}
 	return nil
 }
+
+// Count returns the number of entries in the cache whose tag has the prefix
+// tagPrefix.
+func (c *Cache) Count(ctx context.Context, tagPrefix string) (int64, error) {
+	var count int64
+	if err := sqlx.GetContext(ctx, c.db, &count, `
+		SELECT COUNT(key)
+		FROM storage.cache
+		WHERE tag LIKE $1 || '%'
+	`, tagPrefix); err != nil {
+		return 0, errors.EnsureStack(err)
+	}
+	return count, nil
+}
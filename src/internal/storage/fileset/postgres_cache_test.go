@@ -87,6 +87,15 @@ func TestPostgresCache(t *testing.T) {
 	for i := 1; i < maxSize+1; i++ {
 		checkExists(i)
 	}
+	// Confirm the entries are counted by tag.
+	checkCount := func(tagPrefix string, expected int64) {
+		count, err := cache.Count(ctx, tagPrefix)
+		require.NoError(t, err)
+		require.Equal(t, expected, count)
+	}
+	checkCount("", int64(maxSize))
+	checkCount("odd", 3)
+	checkCount("even", 2)
 	// Clear the odd index entries and confirm they no longer exist.
 	require.NoError(t, cache.Clear(ctx, "odd"))
 	_, err = gc.RunOnce(ctx)
@@ -98,4 +107,6 @@ func TestPostgresCache(t *testing.T) {
 		}
 		checkExists(i)
 	}
+	checkCount("odd", 0)
+	checkCount("even", 2)
 }
@@ -49,7 +49,10 @@ func newUnorderedWriter(ctx context.Context, storage *Storage, memThreshold, fil
 	return uw, nil
 }
 
-func (uw *UnorderedWriter) Put(p, datum string, appendFile bool, r io.Reader) (retErr error) {
+// Put writes a file to the file set, appending to the file if appendFile is
+// set. opts only apply if the file is new, since they describe the start of
+// the file.
+func (uw *UnorderedWriter) Put(p, datum string, appendFile bool, r io.Reader, opts ...FileOption) (retErr error) {
 	if err := uw.validate(p); err != nil {
 		return err
 	}
@@ -59,7 +62,7 @@ func (uw *UnorderedWriter) Put(p, datum string, appendFile bool, r io.Reader) (r
 	if !appendFile {
 		uw.buffer.Delete(p, datum)
 	}
-	w := uw.buffer.Add(p, datum)
+	w := uw.buffer.Add(p, datum, opts...)
 	for {
 		n, err := io.CopyN(w, r, uw.memAvailable)
 		uw.memAvailable -= n
@@ -96,8 +99,8 @@ func (uw *UnorderedWriter) serialize() error {
 		return nil
 	}
 	return uw.withWriter(func(w *Writer) error {
-		if err := uw.buffer.WalkAdditive(func(path, datum string, r io.Reader) error {
-			return w.Add(path, datum, r)
+		if err := uw.buffer.WalkAdditive(func(path, datum string, r io.Reader, opts ...FileOption) error {
+			return w.Add(path, datum, r, opts...)
 		}, func(f File, datum string) error {
 			return w.Copy(f, datum)
 		}); err != nil {
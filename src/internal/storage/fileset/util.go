@@ -164,3 +164,18 @@ func SizeFromIndex(idx *index.Index) (size int64) {
 	}
 	return size
 }
+
+// ComputeHash returns the hash that a file with the content of r has when
+// it's written in a single operation, without writing it. Files that are
+// written in parts, for example by appending to them, may have a different
+// hash.
+func ComputeHash(r io.Reader) ([]byte, error) {
+	var hashes [][]byte
+	if err := chunk.ComputeChunks(r, func(chunkBytes []byte) error {
+		hashes = append(hashes, chunk.Hash(chunkBytes))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return computeFileHash(hashes)
+}
@@ -63,13 +63,18 @@ func newWriter(ctx context.Context, storage *Storage, opts ...WriterOption) *Wri
 	return w
 }
 
-func (w *Writer) Add(path, datum string, r io.Reader) error {
+// Add adds a file to the file set. The file's content type is detected from
+// its content, unless it's set by opts.
+func (w *Writer) Add(path, datum string, r io.Reader, opts ...FileOption) error {
 	idx := &index.Index{
 		Path: path,
 		File: &index.File{
 			Datum: datum,
 		},
 	}
+	for _, opt := range opts {
+		opt(idx.File)
+	}
 	if err := w.checkIndex(w.idx, idx); err != nil {
 		return err
 	}
@@ -77,6 +82,9 @@ func (w *Writer) Add(path, datum string, r io.Reader) error {
 	// Handle files less than the batch threshold.
 	buf := &bytes.Buffer{}
 	_, err := io.CopyN(buf, r, int64(w.batchThreshold))
+	if idx.File.ContentType == "" {
+		idx.File.ContentType = DetectContentType(buf.Bytes())
+	}
 	if err != nil {
 		if errors.Is(err, io.EOF) {
 			return w.batcher.Add(idx, buf.Bytes(), nil)
@@ -128,7 +136,8 @@ func (w *Writer) Copy(file File, datum string) error {
 		copyIdx := &index.Index{
 			Path: idx.Path,
 			File: &index.File{
-				Datum: datum,
+				Datum:       datum,
+				ContentType: idx.File.ContentType,
 			},
 		}
 		return w.uploader.Copy(copyIdx, idx.File.DataRefs)
@@ -138,7 +147,7 @@ func (w *Writer) Copy(file File, datum string) error {
 		r := w.storage.ChunkStorage().NewReader(w.ctx, idx.File.DataRefs)
 		return r.Get(w2)
 	}, func(r io.Reader) error {
-		return w.Add(idx.Path, datum, r)
+		return w.Add(idx.Path, datum, r, WithContentType(idx.File.ContentType))
 	})
 }
 
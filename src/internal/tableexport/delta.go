@@ -0,0 +1,245 @@
+package tableexport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
+)
+
+const deltaLogDir = "_delta_log"
+
+var deltaLogRe = regexp.MustCompile(`^(\d{20})\.json$`)
+
+// deltaAction is a line of a Delta Lake log entry, exactly one field is set.
+type deltaAction struct {
+	Protocol   *deltaProtocol   `json:"protocol,omitempty"`
+	MetaData   *deltaMetaData   `json:"metaData,omitempty"`
+	Add        *deltaAdd        `json:"add,omitempty"`
+	Remove     *deltaRemove     `json:"remove,omitempty"`
+	CommitInfo *deltaCommitInfo `json:"commitInfo,omitempty"`
+}
+
+type deltaProtocol struct {
+	MinReaderVersion int `json:"minReaderVersion"`
+	MinWriterVersion int `json:"minWriterVersion"`
+}
+
+type deltaFormat struct {
+	Provider string            `json:"provider"`
+	Options  map[string]string `json:"options"`
+}
+
+type deltaMetaData struct {
+	ID               string            `json:"id"`
+	Format           deltaFormat       `json:"format"`
+	SchemaString     string            `json:"schemaString"`
+	PartitionColumns []string          `json:"partitionColumns"`
+	Configuration    map[string]string `json:"configuration"`
+	CreatedTime      int64             `json:"createdTime"`
+}
+
+type deltaAdd struct {
+	Path             string            `json:"path"`
+	PartitionValues  map[string]string `json:"partitionValues"`
+	Size             int64             `json:"size"`
+	ModificationTime int64             `json:"modificationTime"`
+	DataChange       bool              `json:"dataChange"`
+	Stats            string            `json:"stats,omitempty"`
+}
+
+type deltaRemove struct {
+	Path              string `json:"path"`
+	DeletionTimestamp int64  `json:"deletionTimestamp"`
+	DataChange        bool   `json:"dataChange"`
+}
+
+type deltaCommitInfo struct {
+	Timestamp           int64             `json:"timestamp"`
+	Operation           string            `json:"operation"`
+	OperationParameters map[string]string `json:"operationParameters"`
+	// PachydermCommit is the commit the version was exported from. Readers
+	// ignore unknown commit info fields.
+	PachydermCommit string `json:"pachydermCommit,omitempty"`
+}
+
+type deltaStats struct {
+	NumRecords int64 `json:"numRecords"`
+}
+
+// deltaTable writes a Delta Lake table. Versions are written as JSON log
+// entries, checkpoints are not written.
+type deltaTable struct {
+	e *Exporter
+	// id is the table ID from the latest metadata.
+	id string
+}
+
+func (d *deltaTable) load(ctx context.Context) (*tableState, error) {
+	state := &tableState{version: -1, files: make(map[string]*dataFile)}
+	var versions []int64
+	if err := d.e.c.Walk(ctx, d.e.object(deltaLogDir)+"/", func(name string) error {
+		if m := deltaLogRe.FindStringSubmatch(path.Base(name)); m != nil {
+			v, err := strconv.ParseInt(m[1], 10, 64)
+			if err != nil {
+				return errors.EnsureStack(err)
+			}
+			versions = append(versions, v)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	for i, v := range versions {
+		if v != int64(i) {
+			return nil, errors.Errorf("delta log is missing version %d", i)
+		}
+		data, err := d.e.get(ctx, deltaLogPath(v))
+		if err != nil {
+			return nil, err
+		}
+		if err := d.apply(state, data); err != nil {
+			return nil, errors.Wrapf(err, "error reading delta log version %d", v)
+		}
+		state.version = v
+	}
+	return state, nil
+}
+
+// apply replays a log entry on state.
+func (d *deltaTable) apply(state *tableState, data []byte) error {
+	state.commit = ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, maxFooterSize)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var action deltaAction
+		if err := json.Unmarshal(scanner.Bytes(), &action); err != nil {
+			return errors.EnsureStack(err)
+		}
+		switch {
+		case action.MetaData != nil:
+			d.id = action.MetaData.ID
+			ds := &deltaSchema{}
+			if err := json.Unmarshal([]byte(action.MetaData.SchemaString), ds); err != nil {
+				return errors.EnsureStack(err)
+			}
+			s, err := schemaFromDelta(ds)
+			if err != nil {
+				return err
+			}
+			state.schema = s
+		case action.Add != nil:
+			df := &dataFile{Path: action.Add.Path, Size: action.Add.Size}
+			if action.Add.Stats != "" {
+				var stats deltaStats
+				if err := json.Unmarshal([]byte(action.Add.Stats), &stats); err == nil {
+					df.Records = stats.NumRecords
+				}
+			}
+			state.files[df.Path] = df
+		case action.Remove != nil:
+			delete(state.files, action.Remove.Path)
+		case action.CommitInfo != nil:
+			state.commit = action.CommitInfo.PachydermCommit
+		}
+	}
+	return errors.EnsureStack(scanner.Err())
+}
+
+func (d *deltaTable) normalize(s Schema) (Schema, error) {
+	ds, err := s.delta()
+	if err != nil {
+		return nil, err
+	}
+	return schemaFromDelta(ds)
+}
+
+func (d *deltaTable) commit(ctx context.Context, prev *tableState, schema Schema, files map[string]*dataFile, commit string) (int64, error) {
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	version := prev.version + 1
+	var actions []deltaAction
+	if version == 0 {
+		actions = append(actions, deltaAction{Protocol: &deltaProtocol{MinReaderVersion: 1, MinWriterVersion: 2}})
+	}
+	if version == 0 || !schema.Equal(prev.schema) {
+		if d.id == "" {
+			d.id = uuid.New()
+		}
+		ds, err := schema.delta()
+		if err != nil {
+			return 0, err
+		}
+		schemaString, err := json.Marshal(ds)
+		if err != nil {
+			return 0, errors.EnsureStack(err)
+		}
+		actions = append(actions, deltaAction{MetaData: &deltaMetaData{
+			ID:               d.id,
+			Format:           deltaFormat{Provider: "parquet", Options: map[string]string{}},
+			SchemaString:     string(schemaString),
+			PartitionColumns: []string{},
+			Configuration:    map[string]string{},
+			CreatedTime:      now,
+		}})
+	}
+	for _, df := range sortedFiles(prev.files) {
+		if _, ok := files[df.Path]; !ok {
+			actions = append(actions, deltaAction{Remove: &deltaRemove{
+				Path:              df.Path,
+				DeletionTimestamp: now,
+				DataChange:        true,
+			}})
+		}
+	}
+	for _, df := range sortedFiles(files) {
+		if _, ok := prev.files[df.Path]; ok {
+			continue
+		}
+		stats, err := json.Marshal(deltaStats{NumRecords: df.Records})
+		if err != nil {
+			return 0, errors.EnsureStack(err)
+		}
+		actions = append(actions, deltaAction{Add: &deltaAdd{
+			Path:             df.Path,
+			PartitionValues:  map[string]string{},
+			Size:             df.Size,
+			ModificationTime: now,
+			DataChange:       true,
+			Stats:            string(stats),
+		}})
+	}
+	actions = append(actions, deltaAction{CommitInfo: &deltaCommitInfo{
+		Timestamp:           now,
+		Operation:           "WRITE",
+		OperationParameters: map[string]string{"mode": "Overwrite"},
+		PachydermCommit:     commit,
+	}})
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, action := range actions {
+		if err := enc.Encode(action); err != nil {
+			return 0, errors.EnsureStack(err)
+		}
+	}
+	if err := d.e.putNew(ctx, deltaLogPath(version), buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+func deltaLogPath(version int64) string {
+	return path.Join(deltaLogDir, fmt.Sprintf("%020d.json", version))
+}
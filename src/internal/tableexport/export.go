@@ -0,0 +1,312 @@
+// Package tableexport materializes commits of Parquet and CSV files as
+// versions of a Delta Lake or Apache Iceberg table in object storage.
+//
+// Each exported commit becomes one table version (a Delta Lake log entry or
+// an Iceberg snapshot) whose data files are exactly the commit's Parquet and
+// CSV files, so analytics engines reading the table see the commit's data
+// and can time travel to the versions of earlier commits. Data files are
+// named after the hash of their content and are only uploaded if they're
+// not already in the table, so unchanged files don't cost anything to
+// export. CSV files are converted to Parquet, with a string column for each
+// column of their header row. Identical files in a commit are exported as a
+// single data file.
+//
+// All of a commit's files must have the same flat schema. A table version
+// may have a different schema than the previous version, the table's schema
+// is updated accordingly. The table must only be written by a single
+// exporter at a time, since neither format's commit protocol can be
+// implemented atomically on top of the object storage interface.
+package tableexport
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	dataDir = "data"
+	// maxFooterSize bounds the size of the Parquet footers that can be
+	// read, since the footer is buffered while the file is uploaded.
+	maxFooterSize = 16 << 20
+)
+
+// File is a file in the commit being exported.
+type File struct {
+	Path string
+	// Hash is a hash of the file's content, used to name its data file.
+	Hash string
+	// Content writes the file's content to w.
+	Content func(w io.Writer) error
+}
+
+// Result describes an export.
+type Result struct {
+	// Version is the table version created for the commit, which is the
+	// log version for Delta Lake and the metadata version for Iceberg. It's
+	// -1 if the commit doesn't contain any table files and the table
+	// doesn't exist yet.
+	Version int64
+	// FilesAdded and FilesRemoved count the data files added to and removed
+	// from the table.
+	FilesAdded, FilesRemoved int64
+	// BytesWritten is the size of the data files uploaded.
+	BytesWritten int64
+}
+
+type dataFile struct {
+	// Path is relative to the table's location.
+	Path    string
+	Size    int64
+	Records int64
+	// Snapshot is the Iceberg snapshot that added the file.
+	Snapshot int64
+}
+
+type tableState struct {
+	// version is the latest table version, -1 if the table doesn't exist.
+	version int64
+	schema  Schema
+	files   map[string]*dataFile
+	// commit is the ID of the commit the latest version was exported from.
+	commit string
+}
+
+type tableFormat interface {
+	load(ctx context.Context) (*tableState, error)
+	// normalize converts a schema to what the format will read back.
+	normalize(Schema) (Schema, error)
+	commit(ctx context.Context, prev *tableState, schema Schema, files map[string]*dataFile, commit string) (int64, error)
+}
+
+// Exporter exports commits to a table.
+type Exporter struct {
+	c        obj.Client
+	prefix   string
+	location string
+	format   tableFormat
+}
+
+// NewExporter creates an Exporter for the table at url, which is written
+// with c.
+func NewExporter(c obj.Client, url *obj.ObjectStoreURL, format pfs.TableEgress_Format) (*Exporter, error) {
+	e := &Exporter{
+		c:        c,
+		prefix:   strings.Trim(url.Object, "/"),
+		location: strings.TrimSuffix(url.String(), "/"),
+	}
+	switch format {
+	case pfs.TableEgress_DELTA:
+		e.format = &deltaTable{e: e}
+	case pfs.TableEgress_ICEBERG:
+		e.format = &icebergTable{e: e}
+	default:
+		return nil, errors.Errorf("unsupported table format %v", format)
+	}
+	return e, nil
+}
+
+// object returns the name of the object at p relative to the table.
+func (e *Exporter) object(p string) string {
+	return path.Join(e.prefix, p)
+}
+
+func (e *Exporter) put(ctx context.Context, p string, data []byte) error {
+	return errors.EnsureStack(e.c.Put(ctx, e.object(p), bytes.NewReader(data)))
+}
+
+func (e *Exporter) get(ctx context.Context, p string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := e.c.Get(ctx, e.object(p), &buf); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// putNew writes an object that must not exist yet, which detects (most)
+// concurrent writers.
+func (e *Exporter) putNew(ctx context.Context, p string, data []byte) error {
+	exists, err := e.c.Exists(ctx, e.object(p))
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if exists {
+		return errors.Errorf("%v already exists, is another exporter writing to the table?", p)
+	}
+	return e.put(ctx, p, data)
+}
+
+// Export exports a commit's files as a new table version. iterate must call
+// cb with each file in the commit, files that aren't Parquet or CSV files
+// are ignored. Exporting the commit of the latest table version again does
+// nothing, which makes retries safe.
+func (e *Exporter) Export(ctx context.Context, commit string, iterate func(cb func(*File) error) error) (*Result, error) {
+	prev, err := e.format.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading table")
+	}
+	res := &Result{Version: prev.version}
+	if prev.commit == commit {
+		return res, nil
+	}
+	var schema Schema
+	var schemaFile string
+	files := make(map[string]*dataFile)
+	checkSchema := func(p string, s Schema) error {
+		if schemaFile == "" {
+			schema, schemaFile = s, p
+			return nil
+		}
+		if !s.Equal(schema) {
+			return errors.Errorf("schema of %v %v doesn't match the schema of %v %v", p, s, schemaFile, schema)
+		}
+		return nil
+	}
+	if err := iterate(func(f *File) error {
+		ext := strings.ToLower(path.Ext(f.Path))
+		if ext != ".parquet" && ext != ".csv" {
+			return nil
+		}
+		p := path.Join(dataDir, f.Hash+".parquet")
+		if df, ok := prev.files[p]; ok {
+			files[p] = df
+			return checkSchema(f.Path, prev.schema)
+		}
+		if _, ok := files[p]; ok {
+			return nil
+		}
+		df, s, err := e.upload(ctx, f, p, ext == ".csv")
+		if err != nil {
+			return errors.Wrapf(err, "error exporting %v", f.Path)
+		}
+		res.BytesWritten += df.Size
+		files[p] = df
+		return checkSchema(f.Path, s)
+	}); err != nil {
+		return nil, err
+	}
+	if schema == nil {
+		if prev.version < 0 {
+			// There is nothing to create the table from.
+			return res, nil
+		}
+		schema = prev.schema
+	}
+	for p := range files {
+		if _, ok := prev.files[p]; !ok {
+			res.FilesAdded++
+		}
+	}
+	for p := range prev.files {
+		if _, ok := files[p]; !ok {
+			res.FilesRemoved++
+		}
+	}
+	if res.Version, err = e.format.commit(ctx, prev, schema, files, commit); err != nil {
+		return nil, errors.Wrap(err, "error committing table version")
+	}
+	return res, nil
+}
+
+// upload writes a data file, converting it from CSV if necessary, and
+// returns its metadata and schema.
+func (e *Exporter) upload(ctx context.Context, f *File, p string, csv bool) (*dataFile, Schema, error) {
+	tail := &tailBuffer{max: maxFooterSize}
+	counter := &countWriter{}
+	var md *parquetMetadata
+	put := func(r io.Reader) error {
+		return errors.EnsureStack(e.c.Put(ctx, e.object(p), r))
+	}
+	var err error
+	if csv {
+		err = miscutil.WithPipe(f.Content, func(r io.Reader) error {
+			return miscutil.WithPipe(func(w io.Writer) error {
+				var err error
+				md, err = csvToParquet(r, io.MultiWriter(w, counter))
+				return err
+			}, put)
+		})
+	} else {
+		err = miscutil.WithPipe(func(w io.Writer) error {
+			return f.Content(io.MultiWriter(w, tail, counter))
+		}, put)
+		if err == nil {
+			md, err = readParquetMetadata(tail.Bytes())
+		}
+	}
+	if err == nil {
+		md.Schema, err = e.format.normalize(md.Schema)
+	}
+	if err != nil {
+		// Don't leave unreferenced data files behind.
+		if err := e.c.Delete(ctx, e.object(p)); err != nil {
+			logrus.Errorf("error deleting %v: %v", e.object(p), err)
+		}
+		return nil, nil, err
+	}
+	return &dataFile{Path: p, Size: counter.n, Records: md.NumRows}, md.Schema, nil
+}
+
+// sortedFiles returns files sorted by path, so that table metadata is
+// deterministic.
+func sortedFiles(files map[string]*dataFile) []*dataFile {
+	var result []*dataFile
+	for _, df := range files {
+		result = append(result, df)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Path < result[j].Path })
+	return result
+}
+
+// tailBuffer keeps the last max bytes written to it.
+type tailBuffer struct {
+	max int
+	buf []byte
+	// pos is where the next byte is written once buf is full.
+	pos int
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if len(p) > t.max {
+		p = p[len(p)-t.max:]
+	}
+	if room := t.max - len(t.buf); room > 0 {
+		if room > len(p) {
+			room = len(p)
+		}
+		t.buf = append(t.buf, p[:room]...)
+		p = p[room:]
+	}
+	for len(p) > 0 {
+		k := copy(t.buf[t.pos:], p)
+		t.pos = (t.pos + k) % t.max
+		p = p[k:]
+	}
+	return n, nil
+}
+
+func (t *tailBuffer) Bytes() []byte {
+	if t.pos == 0 {
+		return t.buf
+	}
+	return append(append([]byte{}, t.buf[t.pos:]...), t.buf[:t.pos]...)
+}
+
+type countWriter struct {
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,451 @@
+package tableexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pacherr"
+	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
+)
+
+const (
+	icebergMetadataDir = "metadata"
+	icebergVersionHint = "version-hint.text"
+	icebergCommitKey   = "pachyderm-commit"
+
+	// Manifest entry statuses.
+	icebergExisting = 0
+	icebergAdded    = 1
+	icebergDeleted  = 2
+)
+
+// The Avro schemas of format version 1 manifests and manifest lists, with
+// the field IDs required by the Iceberg spec. Tables are unpartitioned, so
+// the partition struct is empty.
+const (
+	icebergManifestSchema = `{
+  "type": "record",
+  "name": "manifest_entry",
+  "fields": [
+    {"name": "status", "type": "int", "field-id": 0},
+    {"name": "snapshot_id", "type": "long", "field-id": 1},
+    {"name": "data_file", "field-id": 2, "type": {
+      "type": "record",
+      "name": "r2",
+      "fields": [
+        {"name": "file_path", "type": "string", "field-id": 100},
+        {"name": "file_format", "type": "string", "field-id": 101},
+        {"name": "partition", "field-id": 102, "type": {"type": "record", "name": "r102", "fields": []}},
+        {"name": "record_count", "type": "long", "field-id": 103},
+        {"name": "file_size_in_bytes", "type": "long", "field-id": 104},
+        {"name": "block_size_in_bytes", "type": "long", "field-id": 105}
+      ]
+    }}
+  ]
+}`
+	icebergManifestListSchema = `{
+  "type": "record",
+  "name": "manifest_file",
+  "fields": [
+    {"name": "manifest_path", "type": "string", "field-id": 500},
+    {"name": "manifest_length", "type": "long", "field-id": 501},
+    {"name": "partition_spec_id", "type": "int", "field-id": 502},
+    {"name": "added_snapshot_id", "type": ["null", "long"], "default": null, "field-id": 503},
+    {"name": "added_data_files_count", "type": ["null", "int"], "default": null, "field-id": 504},
+    {"name": "existing_data_files_count", "type": ["null", "int"], "default": null, "field-id": 505},
+    {"name": "deleted_data_files_count", "type": ["null", "int"], "default": null, "field-id": 506},
+    {"name": "added_rows_count", "type": ["null", "long"], "default": null, "field-id": 512},
+    {"name": "existing_rows_count", "type": ["null", "long"], "default": null, "field-id": 513},
+    {"name": "deleted_rows_count", "type": ["null", "long"], "default": null, "field-id": 514}
+  ]
+}`
+)
+
+type icebergMetadata struct {
+	FormatVersion      int                       `json:"format-version"`
+	TableUUID          string                    `json:"table-uuid"`
+	Location           string                    `json:"location"`
+	LastUpdatedMS      int64                     `json:"last-updated-ms"`
+	LastColumnID       int                       `json:"last-column-id"`
+	Schema             *icebergSchema            `json:"schema"`
+	Schemas            []*icebergSchema          `json:"schemas"`
+	CurrentSchemaID    int                       `json:"current-schema-id"`
+	PartitionSpec      []interface{}             `json:"partition-spec"`
+	PartitionSpecs     []icebergPartitionSpec    `json:"partition-specs"`
+	DefaultSpecID      int                       `json:"default-spec-id"`
+	LastPartitionID    int                       `json:"last-partition-id"`
+	Properties         map[string]string         `json:"properties"`
+	CurrentSnapshotID  int64                     `json:"current-snapshot-id"`
+	Snapshots          []*icebergSnapshot        `json:"snapshots"`
+	SnapshotLog        []icebergSnapshotLogEntry `json:"snapshot-log"`
+	MetadataLog        []icebergMetadataLogEntry `json:"metadata-log"`
+	SortOrders         []icebergSortOrder        `json:"sort-orders"`
+	DefaultSortOrderID int                       `json:"default-sort-order-id"`
+}
+
+type icebergPartitionSpec struct {
+	SpecID int           `json:"spec-id"`
+	Fields []interface{} `json:"fields"`
+}
+
+type icebergSortOrder struct {
+	OrderID int           `json:"order-id"`
+	Fields  []interface{} `json:"fields"`
+}
+
+type icebergSnapshot struct {
+	SnapshotID       int64             `json:"snapshot-id"`
+	ParentSnapshotID *int64            `json:"parent-snapshot-id,omitempty"`
+	TimestampMS      int64             `json:"timestamp-ms"`
+	ManifestList     string            `json:"manifest-list"`
+	Summary          map[string]string `json:"summary"`
+	SchemaID         int               `json:"schema-id"`
+}
+
+type icebergSnapshotLogEntry struct {
+	TimestampMS int64 `json:"timestamp-ms"`
+	SnapshotID  int64 `json:"snapshot-id"`
+}
+
+type icebergMetadataLogEntry struct {
+	TimestampMS  int64  `json:"timestamp-ms"`
+	MetadataFile string `json:"metadata-file"`
+}
+
+// icebergTable writes an Iceberg table using the file system catalog layout:
+// metadata/v<N>.metadata.json is version N of the table metadata and
+// metadata/version-hint.text contains the latest N. Every snapshot has a
+// single manifest listing all of its data files.
+type icebergTable struct {
+	e *Exporter
+	// md is the latest table metadata, nil if the table doesn't exist.
+	md *icebergMetadata
+}
+
+func (t *icebergTable) load(ctx context.Context) (*tableState, error) {
+	state := &tableState{version: -1, files: make(map[string]*dataFile)}
+	hint, err := t.e.get(ctx, path.Join(icebergMetadataDir, icebergVersionHint))
+	if err != nil {
+		if pacherr.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, err
+	}
+	if state.version, err = strconv.ParseInt(strings.TrimSpace(string(hint)), 10, 64); err != nil {
+		return nil, errors.Wrapf(err, "error parsing %v", icebergVersionHint)
+	}
+	data, err := t.e.get(ctx, icebergMetadataPath(state.version))
+	if err != nil {
+		return nil, err
+	}
+	t.md = &icebergMetadata{}
+	if err := json.Unmarshal(data, t.md); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	for _, s := range t.md.Schemas {
+		if s.SchemaID == t.md.CurrentSchemaID {
+			state.schema = schemaFromIceberg(s)
+		}
+	}
+	if state.schema == nil && t.md.Schema != nil {
+		state.schema = schemaFromIceberg(t.md.Schema)
+	}
+	snapshot := t.currentSnapshot()
+	if snapshot == nil {
+		return state, nil
+	}
+	state.commit = snapshot.Summary[icebergCommitKey]
+	manifests, err := t.readAvro(ctx, snapshot.ManifestList)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range manifests {
+		manifestPath, ok := m["manifest_path"].(string)
+		if !ok {
+			return nil, errors.Errorf("malformed manifest list %v", snapshot.ManifestList)
+		}
+		entries, err := t.readAvro(ctx, manifestPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			status, ok1 := entry["status"].(int32)
+			snapshotID, ok2 := entry["snapshot_id"].(int64)
+			df, ok3 := entry["data_file"].(map[string]interface{})
+			if !ok1 || !ok2 || !ok3 {
+				return nil, errors.Errorf("malformed manifest %v", manifestPath)
+			}
+			if status == icebergDeleted {
+				continue
+			}
+			p, ok1 := df["file_path"].(string)
+			size, ok2 := df["file_size_in_bytes"].(int64)
+			records, ok3 := df["record_count"].(int64)
+			if !ok1 || !ok2 || !ok3 {
+				return nil, errors.Errorf("malformed manifest %v", manifestPath)
+			}
+			if rel, ok := t.relative(p); ok {
+				p = rel
+			}
+			state.files[p] = &dataFile{Path: p, Size: size, Records: records, Snapshot: snapshotID}
+		}
+	}
+	return state, nil
+}
+
+func (t *icebergTable) currentSnapshot() *icebergSnapshot {
+	for _, s := range t.md.Snapshots {
+		if s.SnapshotID == t.md.CurrentSnapshotID {
+			return s
+		}
+	}
+	return nil
+}
+
+// relative converts an absolute path in the table to a path relative to the
+// table's location.
+func (t *icebergTable) relative(p string) (string, bool) {
+	if !strings.HasPrefix(p, t.e.location+"/") {
+		return "", false
+	}
+	return strings.TrimPrefix(p, t.e.location+"/"), true
+}
+
+func (t *icebergTable) absolute(p string) string {
+	if strings.Contains(p, "://") {
+		return p
+	}
+	return t.e.location + "/" + p
+}
+
+func (t *icebergTable) readAvro(ctx context.Context, p string) ([]map[string]interface{}, error) {
+	rel, ok := t.relative(p)
+	if !ok {
+		return nil, errors.Errorf("%v is outside of the table location %v", p, t.e.location)
+	}
+	data, err := t.e.get(ctx, rel)
+	if err != nil {
+		return nil, err
+	}
+	r, err := goavro.NewOCFReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	var records []map[string]interface{}
+	for r.Scan() {
+		record, err := r.Read()
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		m, ok := record.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected record in %v", p)
+		}
+		records = append(records, m)
+	}
+	return records, errors.EnsureStack(r.Err())
+}
+
+func (t *icebergTable) normalize(s Schema) (Schema, error) {
+	return s, nil
+}
+
+func (t *icebergTable) commit(ctx context.Context, prev *tableState, schema Schema, files map[string]*dataFile, commit string) (int64, error) {
+	now := time.Now()
+	nowMS := now.UnixNano() / int64(time.Millisecond)
+	md := t.md
+	var parent *int64
+	if md == nil {
+		md = &icebergMetadata{
+			FormatVersion:      1,
+			TableUUID:          uuid.New(),
+			Location:           t.e.location,
+			CurrentSnapshotID:  -1,
+			PartitionSpec:      []interface{}{},
+			PartitionSpecs:     []icebergPartitionSpec{{SpecID: 0, Fields: []interface{}{}}},
+			LastPartitionID:    999,
+			Properties:         map[string]string{},
+			SortOrders:         []icebergSortOrder{{OrderID: 0, Fields: []interface{}{}}},
+			DefaultSortOrderID: 0,
+		}
+	} else {
+		md.MetadataLog = append(md.MetadataLog, icebergMetadataLogEntry{
+			TimestampMS:  md.LastUpdatedMS,
+			MetadataFile: t.absolute(icebergMetadataPath(prev.version)),
+		})
+		if md.CurrentSnapshotID >= 0 {
+			id := md.CurrentSnapshotID
+			parent = &id
+		}
+	}
+	if md.Schema == nil || !schema.Equal(prev.schema) {
+		id := 0
+		for _, s := range md.Schemas {
+			if s.SchemaID >= id {
+				id = s.SchemaID + 1
+			}
+		}
+		md.Schema, md.LastColumnID = schema.iceberg(id, md.Schema, md.LastColumnID)
+		md.Schemas = append(md.Schemas, md.Schema)
+		md.CurrentSchemaID = id
+	}
+	snapshotID := now.UnixNano()
+	if parent != nil && snapshotID <= *parent {
+		snapshotID = *parent + 1
+	}
+	manifest, summary, err := t.writeManifest(ctx, prev, files, snapshotID, md.Schema)
+	if err != nil {
+		return 0, err
+	}
+	summary[icebergCommitKey] = commit
+	manifestList := path.Join(icebergMetadataDir, fmt.Sprintf("snap-%d-1-%s.avro", snapshotID, uuid.New()))
+	if _, err := t.writeAvro(ctx, manifestList, icebergManifestListSchema, map[string][]byte{
+		"snapshot-id":    []byte(strconv.FormatInt(snapshotID, 10)),
+		"format-version": []byte("1"),
+	}, []interface{}{manifest}); err != nil {
+		return 0, err
+	}
+	md.Snapshots = append(md.Snapshots, &icebergSnapshot{
+		SnapshotID:       snapshotID,
+		ParentSnapshotID: parent,
+		TimestampMS:      nowMS,
+		ManifestList:     t.absolute(manifestList),
+		Summary:          summary,
+		SchemaID:         md.CurrentSchemaID,
+	})
+	md.SnapshotLog = append(md.SnapshotLog, icebergSnapshotLogEntry{TimestampMS: nowMS, SnapshotID: snapshotID})
+	md.CurrentSnapshotID = snapshotID
+	md.LastUpdatedMS = nowMS
+	data, err := json.MarshalIndent(md, "", "  ")
+	if err != nil {
+		return 0, errors.EnsureStack(err)
+	}
+	version := prev.version + 1
+	if version == 0 {
+		version = 1
+	}
+	if err := t.e.putNew(ctx, icebergMetadataPath(version), data); err != nil {
+		return 0, err
+	}
+	if err := t.e.put(ctx, path.Join(icebergMetadataDir, icebergVersionHint), []byte(strconv.FormatInt(version, 10))); err != nil {
+		return 0, err
+	}
+	t.md = md
+	return version, nil
+}
+
+// writeManifest writes the manifest for a snapshot and returns its manifest
+// list entry and the snapshot summary.
+func (t *icebergTable) writeManifest(ctx context.Context, prev *tableState, files map[string]*dataFile, snapshotID int64, schema *icebergSchema) (map[string]interface{}, map[string]string, error) {
+	var entries []interface{}
+	var added, existing, deleted int32
+	var addedRows, existingRows, deletedRows int64
+	entry := func(status int32, snapshot int64, df *dataFile) map[string]interface{} {
+		return map[string]interface{}{
+			"status":      status,
+			"snapshot_id": snapshot,
+			"data_file": map[string]interface{}{
+				"file_path":           t.absolute(df.Path),
+				"file_format":         "PARQUET",
+				"partition":           map[string]interface{}{},
+				"record_count":        df.Records,
+				"file_size_in_bytes":  df.Size,
+				"block_size_in_bytes": int64(64 << 20),
+			},
+		}
+	}
+	for _, df := range sortedFiles(files) {
+		if old, ok := prev.files[df.Path]; ok {
+			entries = append(entries, entry(icebergExisting, old.Snapshot, old))
+			existing++
+			existingRows += old.Records
+			continue
+		}
+		entries = append(entries, entry(icebergAdded, snapshotID, df))
+		added++
+		addedRows += df.Records
+	}
+	for _, df := range sortedFiles(prev.files) {
+		if _, ok := files[df.Path]; !ok {
+			entries = append(entries, entry(icebergDeleted, snapshotID, df))
+			deleted++
+			deletedRows += df.Records
+		}
+	}
+	p := path.Join(icebergMetadataDir, uuid.New()+"-m0.avro")
+	schemaJSON, err := json.Marshal(schema)
+	if err != nil {
+		return nil, nil, errors.EnsureStack(err)
+	}
+	size, err := t.writeAvro(ctx, p, icebergManifestSchema, map[string][]byte{
+		"schema":            schemaJSON,
+		"partition-spec":    []byte("[]"),
+		"partition-spec-id": []byte("0"),
+		"format-version":    []byte("1"),
+	}, entries)
+	if err != nil {
+		return nil, nil, err
+	}
+	manifest := map[string]interface{}{
+		"manifest_path":             t.absolute(p),
+		"manifest_length":           size,
+		"partition_spec_id":         int32(0),
+		"added_snapshot_id":         goavro.Union("long", snapshotID),
+		"added_data_files_count":    goavro.Union("int", added),
+		"existing_data_files_count": goavro.Union("int", existing),
+		"deleted_data_files_count":  goavro.Union("int", deleted),
+		"added_rows_count":          goavro.Union("long", addedRows),
+		"existing_rows_count":       goavro.Union("long", existingRows),
+		"deleted_rows_count":        goavro.Union("long", deletedRows),
+	}
+	operation := "append"
+	if deleted > 0 {
+		operation = "overwrite"
+	}
+	summary := map[string]string{
+		"operation":          operation,
+		"added-data-files":   strconv.Itoa(int(added)),
+		"deleted-data-files": strconv.Itoa(int(deleted)),
+		"added-records":      strconv.FormatInt(addedRows, 10),
+		"deleted-records":    strconv.FormatInt(deletedRows, 10),
+		"total-data-files":   strconv.Itoa(int(added + existing)),
+		"total-records":      strconv.FormatInt(addedRows+existingRows, 10),
+	}
+	return manifest, summary, nil
+}
+
+// writeAvro writes records to an Avro file and returns its size.
+func (t *icebergTable) writeAvro(ctx context.Context, p, schema string, metadata map[string][]byte, records []interface{}) (int64, error) {
+	var buf bytes.Buffer
+	w, err := goavro.NewOCFWriter(goavro.OCFConfig{
+		W:               &buf,
+		Schema:          schema,
+		CompressionName: goavro.CompressionDeflateLabel,
+		MetaData:        metadata,
+	})
+	if err != nil {
+		return 0, errors.EnsureStack(err)
+	}
+	if len(records) > 0 {
+		if err := w.Append(records); err != nil {
+			return 0, errors.EnsureStack(err)
+		}
+	}
+	if err := t.e.put(ctx, p, buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return int64(buf.Len()), nil
+}
+
+func icebergMetadataPath(version int64) string {
+	return path.Join(icebergMetadataDir, fmt.Sprintf("v%d.metadata.json", version))
+}
@@ -0,0 +1,386 @@
+package tableexport
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+const parquetMagic = "PAR1"
+
+// Parquet physical types.
+const (
+	parquetBoolean           = 0
+	parquetInt32             = 1
+	parquetInt64             = 2
+	parquetInt96             = 3
+	parquetFloat             = 4
+	parquetDouble            = 5
+	parquetByteArray         = 6
+	parquetFixedLenByteArray = 7
+)
+
+// Parquet converted types, the predecessor of logical types.
+const (
+	convertedUTF8            = 0
+	convertedEnum            = 4
+	convertedDecimal         = 5
+	convertedDate            = 6
+	convertedTimeMillis      = 7
+	convertedTimeMicros      = 8
+	convertedTimestampMillis = 9
+	convertedTimestampMicros = 10
+	convertedUint8           = 11
+	convertedUint16          = 12
+	convertedUint32          = 13
+	convertedUint64          = 14
+	convertedJSON            = 19
+)
+
+// Parquet logical types, the field IDs of the LogicalType union.
+const (
+	logicalString    = 1
+	logicalEnum      = 4
+	logicalDecimal   = 5
+	logicalDate      = 6
+	logicalTime      = 7
+	logicalTimestamp = 8
+	logicalInteger   = 10
+	logicalJSON      = 12
+	logicalUUID      = 14
+)
+
+const (
+	repetitionRequired = 0
+	repetitionOptional = 1
+	repetitionRepeated = 2
+)
+
+// parquetMetadata is the information about a Parquet file needed to export
+// it.
+type parquetMetadata struct {
+	Schema  Schema
+	NumRows int64
+}
+
+// readParquetMetadata reads the metadata from the footer of a Parquet file,
+// the whole of which is in tail.
+func readParquetMetadata(tail []byte) (*parquetMetadata, error) {
+	fmd, err := decodeParquetFooter(tail)
+	if err != nil {
+		return nil, err
+	}
+	elements := fmd.list(2)
+	if len(elements) == 0 {
+		return nil, errors.Errorf("parquet file has no schema")
+	}
+	md := &parquetMetadata{NumRows: fmd.int(3)}
+	// The first element is the root, which is followed by its children
+	// in depth first order. Only flat schemas are supported, so every
+	// other element must be a leaf.
+	for _, e := range elements[1:] {
+		se, ok := e.(tstruct)
+		if !ok {
+			return nil, errors.Errorf("malformed parquet schema")
+		}
+		field, err := parquetField(se)
+		if err != nil {
+			return nil, err
+		}
+		md.Schema = append(md.Schema, field)
+	}
+	return md, nil
+}
+
+// decodeParquetFooter decodes the FileMetaData struct from the footer of a
+// Parquet file, the whole of which is in tail.
+func decodeParquetFooter(tail []byte) (tstruct, error) {
+	if len(tail) < 8 || string(tail[len(tail)-4:]) != parquetMagic {
+		return nil, errors.Errorf("not a parquet file")
+	}
+	n := int(binary.LittleEndian.Uint32(tail[len(tail)-8:]))
+	if n > len(tail)-8 {
+		return nil, errors.Errorf("parquet footer of %d bytes is larger than the %d bytes read", n, len(tail)-8)
+	}
+	fmd, err := decodeStruct(tail[len(tail)-8-n : len(tail)-8])
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding parquet footer")
+	}
+	return fmd, nil
+}
+
+func parquetField(se tstruct) (Field, error) {
+	name := se.str(4)
+	if se.int(5) > 0 || !se.has(1) || se.int(3) == repetitionRepeated {
+		return Field{}, errors.Errorf("column %q: nested and repeated columns are not supported", name)
+	}
+	t, err := parquetType(se)
+	if err != nil {
+		return Field{}, errors.Wrapf(err, "column %q", name)
+	}
+	return Field{Name: name, Type: t, Required: se.int(3) == repetitionRequired}, nil
+}
+
+func parquetType(se tstruct) (string, error) {
+	logical := se.strct(10)
+	converted := int64(-1)
+	if se.has(6) {
+		converted = se.int(6)
+	}
+	decimal := func() (string, bool) {
+		if d := logical.strct(logicalDecimal); d != nil {
+			return decimalType(d.int(2), d.int(1)), true
+		}
+		if converted == convertedDecimal {
+			return decimalType(se.int(8), se.int(7)), true
+		}
+		return "", false
+	}
+	switch physical := se.int(1); physical {
+	case parquetBoolean:
+		return "boolean", nil
+	case parquetInt32:
+		if t, ok := decimal(); ok {
+			return t, nil
+		}
+		switch {
+		case logical.has(logicalDate) || converted == convertedDate:
+			return "date", nil
+		case logical.has(logicalTime) || converted == convertedTimeMillis:
+			return "time", nil
+		case converted == convertedUint32 || isUnsigned(logical, 32):
+			return "long", nil
+		}
+		return "int", nil
+	case parquetInt64:
+		if t, ok := decimal(); ok {
+			return t, nil
+		}
+		if ts := logical.strct(logicalTimestamp); ts != nil {
+			if ts.has(1) && ts[1] == true {
+				return "timestamptz", nil
+			}
+			return "timestamp", nil
+		}
+		switch {
+		case converted == convertedTimestampMillis || converted == convertedTimestampMicros:
+			// Converted timestamps are always adjusted to UTC.
+			return "timestamptz", nil
+		case logical.has(logicalTime) || converted == convertedTimeMicros:
+			return "time", nil
+		case converted == convertedUint64 || isUnsigned(logical, 64):
+			return "", errors.Errorf("unsigned 64 bit integers are not supported")
+		}
+		return "long", nil
+	case parquetInt96:
+		// INT96 is the legacy timestamp encoding written by Spark and Hive.
+		return "timestamp", nil
+	case parquetFloat:
+		return "float", nil
+	case parquetDouble:
+		return "double", nil
+	case parquetByteArray:
+		if t, ok := decimal(); ok {
+			return t, nil
+		}
+		if logical.has(logicalString) || logical.has(logicalEnum) || logical.has(logicalJSON) ||
+			converted == convertedUTF8 || converted == convertedEnum || converted == convertedJSON {
+			return "string", nil
+		}
+		return "binary", nil
+	case parquetFixedLenByteArray:
+		if t, ok := decimal(); ok {
+			return t, nil
+		}
+		if logical.has(logicalUUID) {
+			return "uuid", nil
+		}
+		return "fixed[" + strconv.FormatInt(se.int(2), 10) + "]", nil
+	default:
+		return "", errors.Errorf("unknown parquet type %d", physical)
+	}
+}
+
+func isUnsigned(logical tstruct, bits int64) bool {
+	i := logical.strct(logicalInteger)
+	return i != nil && i.int(1) == bits && i[2] == false
+}
+
+// csvRowGroupSize is the number of rows per row group when converting CSV.
+const csvRowGroupSize = 64 * 1024
+
+// csvToParquet converts a CSV file with a header row to a Parquet file in
+// which every column is an optional string.
+func csvToParquet(r io.Reader, w io.Writer) (*parquetMetadata, error) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.Errorf("csv file is empty, a header row is required")
+		}
+		return nil, errors.EnsureStack(err)
+	}
+	md := &parquetMetadata{}
+	seen := make(map[string]bool)
+	for _, name := range header {
+		if name == "" || seen[name] {
+			return nil, errors.Errorf("csv header must have unique, non empty column names, got %q", header)
+		}
+		seen[name] = true
+		md.Schema = append(md.Schema, Field{Name: name, Type: "string"})
+	}
+	pw := &parquetWriter{w: w, schema: md.Schema}
+	if err := pw.write([]byte(parquetMagic)); err != nil {
+		return nil, err
+	}
+	columns := make([][]string, len(header))
+	var rows int
+	flush := func() error {
+		if rows == 0 {
+			return nil
+		}
+		if err := pw.writeRowGroup(columns, rows); err != nil {
+			return err
+		}
+		for i := range columns {
+			columns[i] = columns[i][:0]
+		}
+		rows = 0
+		return nil
+	}
+	for {
+		record, err := cr.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, errors.EnsureStack(err)
+		}
+		for i := range columns {
+			columns[i] = append(columns[i], record[i])
+		}
+		rows++
+		md.NumRows++
+		if rows == csvRowGroupSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return md, pw.close(md.NumRows)
+}
+
+// parquetWriter writes uncompressed Parquet files of optional string
+// columns, with one plain encoded data page per column chunk.
+type parquetWriter struct {
+	w         io.Writer
+	schema    Schema
+	offset    int64
+	rowGroups []fields
+}
+
+func (pw *parquetWriter) write(data []byte) error {
+	n, err := pw.w.Write(data)
+	pw.offset += int64(n)
+	return errors.EnsureStack(err)
+}
+
+func (pw *parquetWriter) writeRowGroup(columns [][]string, rows int) error {
+	var chunks []fields
+	var total int64
+	for i, values := range columns {
+		var page bytes.Buffer
+		// Definition levels, every value is defined: a length prefixed RLE
+		// run of rows ones.
+		var levels bytes.Buffer
+		writeUvarint(&levels, uint64(rows)<<1)
+		levels.WriteByte(1)
+		var n [4]byte
+		binary.LittleEndian.PutUint32(n[:], uint32(levels.Len()))
+		page.Write(n[:])
+		page.Write(levels.Bytes())
+		for _, v := range values {
+			binary.LittleEndian.PutUint32(n[:], uint32(len(v)))
+			page.Write(n[:])
+			page.WriteString(v)
+		}
+		var header bytes.Buffer
+		encodeStruct(&header, fields{
+			{1, int32(0)}, // DATA_PAGE
+			{2, int32(page.Len())},
+			{3, int32(page.Len())},
+			{5, fields{
+				{1, int32(rows)},
+				{2, int32(0)}, // PLAIN
+				{3, int32(3)}, // RLE
+				{4, int32(3)}, // RLE
+			}},
+		})
+		offset := pw.offset
+		size := int64(header.Len() + page.Len())
+		if err := pw.write(header.Bytes()); err != nil {
+			return err
+		}
+		if err := pw.write(page.Bytes()); err != nil {
+			return err
+		}
+		total += size
+		chunks = append(chunks, fields{
+			{2, offset},
+			{3, fields{
+				{1, int32(parquetByteArray)},
+				{2, []int32{0, 3}}, // PLAIN, RLE
+				{3, []string{pw.schema[i].Name}},
+				{4, int32(0)}, // UNCOMPRESSED
+				{5, int64(rows)},
+				{6, size},
+				{7, size},
+				{9, offset},
+			}},
+		})
+	}
+	pw.rowGroups = append(pw.rowGroups, fields{
+		{1, chunks},
+		{2, total},
+		{3, int64(rows)},
+	})
+	return nil
+}
+
+func (pw *parquetWriter) close(numRows int64) error {
+	elements := []fields{{
+		{4, "schema"},
+		{5, int32(len(pw.schema))},
+	}}
+	for _, f := range pw.schema {
+		elements = append(elements, fields{
+			{1, int32(parquetByteArray)},
+			{3, int32(repetitionOptional)},
+			{4, f.Name},
+			{6, int32(convertedUTF8)},
+			{10, fields{{logicalString, fields{}}}},
+		})
+	}
+	md := fields{
+		{1, int32(1)},
+		{2, elements},
+		{3, numRows},
+		{4, pw.rowGroups},
+		{6, "pachyderm"},
+	}
+	var footer bytes.Buffer
+	encodeStruct(&footer, md)
+	var n [4]byte
+	binary.LittleEndian.PutUint32(n[:], uint32(footer.Len()))
+	footer.Write(n[:])
+	footer.WriteString(parquetMagic)
+	return pw.write(footer.Bytes())
+}
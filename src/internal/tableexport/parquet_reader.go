@@ -0,0 +1,542 @@
+package tableexport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// Parquet page types.
+const (
+	pageData       = 0
+	pageDictionary = 2
+	pageDataV2     = 3
+)
+
+// Parquet encodings.
+const (
+	encodingPlain         = 0
+	encodingPlainDict     = 2
+	encodingRLE           = 3
+	encodingRLEDictionary = 8
+)
+
+// Parquet compression codecs.
+const (
+	compressionNone   = 0
+	compressionSnappy = 1
+	compressionGzip   = 2
+	compressionZstd   = 6
+)
+
+// Parquet time units, the field IDs of the TimeUnit union.
+const (
+	timeUnitMillis = 1
+	timeUnitMicros = 2
+	timeUnitNanos  = 3
+)
+
+const (
+	// julianDayOfUnixEpoch is the Julian day of 1970-01-01, which INT96
+	// timestamps count days from.
+	julianDayOfUnixEpoch = 2440588
+	// parquetMaxPageSize bounds the size of a decompressed page, so that a
+	// corrupt header can't exhaust memory.
+	parquetMaxPageSize = 1 << 30
+)
+
+// ParquetReader reads the rows of a Parquet file with a flat schema. It
+// supports data pages of either version, plain and dictionary encoded
+// values, and uncompressed, snappy, gzip and zstd compressed pages, which
+// covers the files written by the common Parquet writers.
+type ParquetReader struct {
+	data      []byte
+	columns   []parquetColumn
+	rowGroups []interface{}
+	rowGroup  int
+	// values holds the current row group's values, by column.
+	values [][]interface{}
+	row    int
+}
+
+type parquetColumn struct {
+	field      Field
+	physical   int64
+	typeLength int64
+	scale      int64
+	timeUnit   int64
+}
+
+// NewParquetReader returns a reader of the rows of the Parquet file data.
+func NewParquetReader(data []byte) (*ParquetReader, error) {
+	fmd, err := decodeParquetFooter(data)
+	if err != nil {
+		return nil, err
+	}
+	elements := fmd.list(2)
+	if len(elements) == 0 {
+		return nil, errors.Errorf("parquet file has no schema")
+	}
+	r := &ParquetReader{data: data, rowGroups: fmd.list(4)}
+	for _, e := range elements[1:] {
+		se, ok := e.(tstruct)
+		if !ok {
+			return nil, errors.Errorf("malformed parquet schema")
+		}
+		field, err := parquetField(se)
+		if err != nil {
+			return nil, err
+		}
+		c := parquetColumn{
+			field:      field,
+			physical:   se.int(1),
+			typeLength: se.int(2),
+			scale:      se.int(7),
+			timeUnit:   timeUnitMicros,
+		}
+		logical := se.strct(10)
+		if d := logical.strct(logicalDecimal); d != nil {
+			c.scale = d.int(1)
+		}
+		if ts := logical.strct(logicalTimestamp); ts != nil {
+			for unit := range ts.strct(2) {
+				c.timeUnit = int64(unit)
+			}
+		} else if se.has(6) && se.int(6) == convertedTimestampMillis {
+			c.timeUnit = timeUnitMillis
+		}
+		r.columns = append(r.columns, c)
+	}
+	return r, nil
+}
+
+// Columns returns the names of the file's columns.
+func (r *ParquetReader) Columns() []string {
+	var names []string
+	for _, c := range r.columns {
+		names = append(names, c.field.Name)
+	}
+	return names
+}
+
+// Next returns the values of the next row, in the order of Columns, or
+// io.EOF once every row has been read. Values are nil, bool, int64, float64,
+// string or time.Time. Decimals are returned as strings, so that they don't
+// lose precision.
+func (r *ParquetReader) Next() ([]interface{}, error) {
+	for r.values == nil || r.row >= len(r.values[0]) {
+		if r.rowGroup >= len(r.rowGroups) {
+			return nil, io.EOF
+		}
+		if err := r.readRowGroup(r.rowGroups[r.rowGroup]); err != nil {
+			return nil, errors.Wrapf(err, "row group %d", r.rowGroup)
+		}
+		r.rowGroup++
+		if len(r.columns) == 0 {
+			return nil, io.EOF
+		}
+	}
+	row := make([]interface{}, len(r.columns))
+	for i := range r.columns {
+		row[i] = r.values[i][r.row]
+	}
+	r.row++
+	return row, nil
+}
+
+func (r *ParquetReader) readRowGroup(rg interface{}) error {
+	rgs, ok := rg.(tstruct)
+	if !ok {
+		return errors.Errorf("malformed row group")
+	}
+	chunks := rgs.list(1)
+	if len(chunks) != len(r.columns) {
+		return errors.Errorf("row group has %d columns, the schema has %d", len(chunks), len(r.columns))
+	}
+	numRows := rgs.int(3)
+	r.values = make([][]interface{}, len(r.columns))
+	r.row = 0
+	for i, chunk := range chunks {
+		cs, ok := chunk.(tstruct)
+		if !ok {
+			return errors.Errorf("malformed column chunk")
+		}
+		values, err := r.readColumnChunk(&r.columns[i], cs.strct(3))
+		if err != nil {
+			return errors.Wrapf(err, "column %q", r.columns[i].field.Name)
+		}
+		if int64(len(values)) != numRows {
+			return errors.Errorf("column %q has %d values, the row group has %d rows", r.columns[i].field.Name, len(values), numRows)
+		}
+		r.values[i] = values
+	}
+	return nil
+}
+
+func (r *ParquetReader) readColumnChunk(c *parquetColumn, md tstruct) ([]interface{}, error) {
+	start := md.int(9)
+	if md.has(11) && md.int(11) > 0 && md.int(11) < start {
+		start = md.int(11)
+	}
+	end := start + md.int(7)
+	if start < 0 || end > int64(len(r.data)) || start > end {
+		return nil, errors.Errorf("column chunk [%d, %d) is outside the file", start, end)
+	}
+	chunk := r.data[start:end]
+	codec := md.int(4)
+	numValues := md.int(5)
+	var dict []interface{}
+	var values []interface{}
+	for int64(len(values)) < numValues && len(chunk) > 0 {
+		header, n, err := decodeStructPrefix(chunk)
+		if err != nil {
+			return nil, errors.Wrap(err, "error decoding page header")
+		}
+		chunk = chunk[n:]
+		size := header.int(3)
+		if size < 0 || size > int64(len(chunk)) {
+			return nil, errors.Errorf("page of %d bytes exceeds the remaining %d bytes", size, len(chunk))
+		}
+		body := chunk[:size]
+		chunk = chunk[size:]
+		switch header.int(1) {
+		case pageDictionary:
+			data, err := decompress(codec, body, header.int(2))
+			if err != nil {
+				return nil, err
+			}
+			if dict, _, err = c.decodePlain(data, int(header.strct(7).int(1))); err != nil {
+				return nil, errors.Wrap(err, "error decoding dictionary page")
+			}
+		case pageData:
+			dph := header.strct(5)
+			data, err := decompress(codec, body, header.int(2))
+			if err != nil {
+				return nil, err
+			}
+			var defs []int64
+			count := int(dph.int(1))
+			if !c.field.Required {
+				if len(data) < 4 {
+					return nil, errors.Errorf("truncated definition levels")
+				}
+				l := int(binary.LittleEndian.Uint32(data))
+				if l > len(data)-4 {
+					return nil, errors.Errorf("definition levels of %d bytes exceed the page", l)
+				}
+				if defs, err = decodeHybrid(data[4:4+l], 1, count); err != nil {
+					return nil, errors.Wrap(err, "error decoding definition levels")
+				}
+				data = data[4+l:]
+			}
+			page, err := c.decodePage(data, dph.int(2), dict, defs, count)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, page...)
+		case pageDataV2:
+			dph := header.strct(8)
+			count := int(dph.int(1))
+			defLen, repLen := dph.int(5), dph.int(6)
+			if defLen < 0 || repLen < 0 || defLen+repLen > int64(len(body)) {
+				return nil, errors.Errorf("levels of %d bytes exceed the page", defLen+repLen)
+			}
+			var defs []int64
+			if !c.field.Required {
+				if defs, err = decodeHybrid(body[repLen:repLen+defLen], 1, count); err != nil {
+					return nil, errors.Wrap(err, "error decoding definition levels")
+				}
+			}
+			data := body[repLen+defLen:]
+			// is_compressed defaults to true
+			if compressed, ok := dph[7].(bool); !ok || compressed {
+				if data, err = decompress(codec, data, header.int(2)-defLen-repLen); err != nil {
+					return nil, err
+				}
+			}
+			page, err := c.decodePage(data, dph.int(4), dict, defs, count)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, page...)
+		}
+	}
+	return values, nil
+}
+
+// decodePage decodes the n values of a data page, of which the ones with a
+// definition level of 0 are null.
+func (c *parquetColumn) decodePage(data []byte, encoding int64, dict []interface{}, defs []int64, n int) ([]interface{}, error) {
+	defined := n
+	if defs != nil {
+		defined = 0
+		for _, d := range defs {
+			if d > 0 {
+				defined++
+			}
+		}
+	}
+	var present []interface{}
+	switch encoding {
+	case encodingPlain:
+		var err error
+		if present, _, err = c.decodePlain(data, defined); err != nil {
+			return nil, err
+		}
+	case encodingRLE:
+		// only booleans are RLE encoded, with a length prefix
+		if c.physical != parquetBoolean || len(data) < 4 {
+			return nil, errors.Errorf("malformed RLE encoded page")
+		}
+		bits, err := decodeHybrid(data[4:], 1, defined)
+		if err != nil {
+			return nil, errors.Wrap(err, "error decoding values")
+		}
+		for _, b := range bits {
+			present = append(present, b == 1)
+		}
+	case encodingPlainDict, encodingRLEDictionary:
+		if dict == nil {
+			return nil, errors.Errorf("dictionary encoded page without a dictionary")
+		}
+		if len(data) < 1 {
+			return nil, errors.Errorf("truncated dictionary indices")
+		}
+		indices, err := decodeHybrid(data[1:], int(data[0]), defined)
+		if err != nil {
+			return nil, errors.Wrap(err, "error decoding dictionary indices")
+		}
+		for _, i := range indices {
+			if i < 0 || i >= int64(len(dict)) {
+				return nil, errors.Errorf("dictionary index %d out of range", i)
+			}
+			present = append(present, dict[i])
+		}
+	default:
+		return nil, errors.Errorf("encoding %d is not supported", encoding)
+	}
+	if defs == nil {
+		return present, nil
+	}
+	values := make([]interface{}, n)
+	for i, d := range defs {
+		if d > 0 {
+			values[i], present = present[0], present[1:]
+		}
+	}
+	return values, nil
+}
+
+// decodePlain decodes n plain encoded values from data, and returns them
+// along with the number of bytes they took up.
+func (c *parquetColumn) decodePlain(data []byte, n int) ([]interface{}, int, error) {
+	values := make([]interface{}, 0, n)
+	var off int
+	take := func(size int) ([]byte, error) {
+		if size < 0 || size > len(data)-off {
+			return nil, errors.Errorf("truncated values")
+		}
+		b := data[off : off+size]
+		off += size
+		return b, nil
+	}
+	for i := 0; i < n; i++ {
+		var v interface{}
+		switch c.physical {
+		case parquetBoolean:
+			if i/8 >= len(data) {
+				return nil, 0, errors.Errorf("truncated values")
+			}
+			v = data[i/8]&(1<<(i%8)) != 0
+			off = (i + 8) / 8
+		case parquetInt32:
+			b, err := take(4)
+			if err != nil {
+				return nil, 0, err
+			}
+			v = c.fromInt(int64(int32(binary.LittleEndian.Uint32(b))))
+		case parquetInt64:
+			b, err := take(8)
+			if err != nil {
+				return nil, 0, err
+			}
+			v = c.fromInt(int64(binary.LittleEndian.Uint64(b)))
+		case parquetInt96:
+			b, err := take(12)
+			if err != nil {
+				return nil, 0, err
+			}
+			nanos := int64(binary.LittleEndian.Uint64(b))
+			days := int64(binary.LittleEndian.Uint32(b[8:]))
+			v = time.Unix((days-julianDayOfUnixEpoch)*24*60*60, nanos).UTC()
+		case parquetFloat:
+			b, err := take(4)
+			if err != nil {
+				return nil, 0, err
+			}
+			v = float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+		case parquetDouble:
+			b, err := take(8)
+			if err != nil {
+				return nil, 0, err
+			}
+			v = math.Float64frombits(binary.LittleEndian.Uint64(b))
+		case parquetByteArray:
+			l, err := take(4)
+			if err != nil {
+				return nil, 0, err
+			}
+			b, err := take(int(binary.LittleEndian.Uint32(l)))
+			if err != nil {
+				return nil, 0, err
+			}
+			v = c.fromBytes(b)
+		case parquetFixedLenByteArray:
+			b, err := take(int(c.typeLength))
+			if err != nil {
+				return nil, 0, err
+			}
+			v = c.fromBytes(b)
+		default:
+			return nil, 0, errors.Errorf("unknown parquet type %d", c.physical)
+		}
+		values = append(values, v)
+	}
+	return values, off, nil
+}
+
+func (c *parquetColumn) fromInt(x int64) interface{} {
+	switch t := c.field.Type; {
+	case t == "date":
+		return time.Unix(x*24*60*60, 0).UTC()
+	case t == "timestamp" || t == "timestamptz":
+		switch c.timeUnit {
+		case timeUnitMillis:
+			return time.Unix(0, x*int64(time.Millisecond)).UTC()
+		case timeUnitNanos:
+			return time.Unix(0, x).UTC()
+		default:
+			return time.Unix(0, x*int64(time.Microsecond)).UTC()
+		}
+	case strings.HasPrefix(t, "decimal"):
+		return formatDecimal(big.NewInt(x), c.scale)
+	}
+	return x
+}
+
+func (c *parquetColumn) fromBytes(b []byte) interface{} {
+	if strings.HasPrefix(c.field.Type, "decimal") {
+		// decimals are big endian two's complement integers
+		x := new(big.Int).SetBytes(b)
+		if len(b) > 0 && b[0]&0x80 != 0 {
+			x.Sub(x, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+		}
+		return formatDecimal(x, c.scale)
+	}
+	return string(b)
+}
+
+func formatDecimal(x *big.Int, scale int64) string {
+	if scale <= 0 {
+		return x.String()
+	}
+	s := new(big.Int).Abs(x).String()
+	for int64(len(s)) <= scale {
+		s = "0" + s
+	}
+	s = s[:int64(len(s))-scale] + "." + s[int64(len(s))-scale:]
+	if x.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// decodeHybrid decodes n values of width bits from the RLE / bit packed
+// hybrid encoding that Parquet uses for levels and dictionary indices.
+func decodeHybrid(data []byte, width, n int) ([]int64, error) {
+	if width < 0 || width > 32 {
+		return nil, errors.Errorf("invalid bit width %d", width)
+	}
+	values := make([]int64, 0, n)
+	r := bytes.NewReader(data)
+	for len(values) < n {
+		header, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, errors.Wrap(err, "truncated run")
+		}
+		if header&1 == 0 {
+			// an RLE run of one value
+			count := int(header >> 1)
+			var buf [4]byte
+			if _, err := io.ReadFull(r, buf[:(width+7)/8]); err != nil {
+				return nil, errors.Wrap(err, "truncated run")
+			}
+			v := int64(binary.LittleEndian.Uint32(buf[:]))
+			for i := 0; i < count && len(values) < n; i++ {
+				values = append(values, v)
+			}
+			continue
+		}
+		// bit packed groups of 8 values
+		count := int(header>>1) * 8
+		packed := make([]byte, int(header>>1)*width)
+		if _, err := io.ReadFull(r, packed); err != nil {
+			// the last run may be cut short after the last value
+			if !errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, errors.Wrap(err, "truncated run")
+			}
+		}
+		for i := 0; i < count && len(values) < n; i++ {
+			var v int64
+			for b := 0; b < width; b++ {
+				bit := i*width + b
+				if packed[bit/8]&(1<<(bit%8)) != 0 {
+					v |= 1 << b
+				}
+			}
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+func decompress(codec int64, data []byte, size int64) ([]byte, error) {
+	if size < 0 || size > parquetMaxPageSize {
+		return nil, errors.Errorf("page of %d bytes is too large", size)
+	}
+	switch codec {
+	case compressionNone:
+		return data, nil
+	case compressionSnappy:
+		out, err := snappy.Decode(make([]byte, 0, size), data)
+		return out, errors.EnsureStack(err)
+	case compressionGzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		out, err := ioutil.ReadAll(r)
+		return out, errors.EnsureStack(err)
+	case compressionZstd:
+		d, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		defer d.Close()
+		out, err := d.DecodeAll(data, make([]byte, 0, size))
+		return out, errors.EnsureStack(err)
+	default:
+		return nil, errors.Errorf("compression codec %d is not supported", codec)
+	}
+}
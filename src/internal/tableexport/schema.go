@@ -0,0 +1,167 @@
+package tableexport
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// Field is a column of a table. Only flat schemas of primitive columns are
+// supported.
+type Field struct {
+	Name string
+	// Type is the Iceberg type of the column, e.g. "long" or "decimal(9,2)".
+	Type     string
+	Required bool
+}
+
+// Schema is the ordered list of columns of a table.
+type Schema []Field
+
+// Equal returns true if s and other have the same columns in the same order.
+func (s Schema) Equal(other Schema) bool {
+	if len(s) != len(other) {
+		return false
+	}
+	for i := range s {
+		if s[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Schema) String() string {
+	var cols []string
+	for _, f := range s {
+		col := f.Name + " " + f.Type
+		if f.Required {
+			col += " not null"
+		}
+		cols = append(cols, col)
+	}
+	return "(" + strings.Join(cols, ", ") + ")"
+}
+
+// deltaType converts an Iceberg type to a Delta Lake (Spark) type.
+func deltaType(t string) (string, error) {
+	switch {
+	case t == "boolean", t == "long", t == "float", t == "double", t == "string",
+		t == "binary", t == "date", t == "timestamp":
+		return t, nil
+	case t == "int":
+		return "integer", nil
+	case t == "timestamptz":
+		return "timestamp", nil
+	case t == "uuid", strings.HasPrefix(t, "fixed["):
+		return "binary", nil
+	case strings.HasPrefix(t, "decimal("):
+		return t, nil
+	default:
+		return "", errors.Errorf("column type %q is not supported by Delta Lake", t)
+	}
+}
+
+// fromDeltaType converts a Delta Lake type to an Iceberg type. Types that
+// don't round trip, e.g. "binary" written for "fixed[16]", make the schema
+// compare unequal, which only results in the schema being rewritten.
+func fromDeltaType(t string) (string, error) {
+	switch {
+	case t == "boolean", t == "long", t == "float", t == "double", t == "string",
+		t == "binary", t == "date", t == "timestamp", strings.HasPrefix(t, "decimal("):
+		return t, nil
+	case t == "integer", t == "short", t == "byte":
+		return "int", nil
+	default:
+		return "", errors.Errorf("unsupported Delta Lake column type %q", t)
+	}
+}
+
+type deltaSchema struct {
+	Type   string       `json:"type"`
+	Fields []deltaField `json:"fields"`
+}
+
+type deltaField struct {
+	Name     string                 `json:"name"`
+	Type     string                 `json:"type"`
+	Nullable bool                   `json:"nullable"`
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+func (s Schema) delta() (*deltaSchema, error) {
+	ds := &deltaSchema{Type: "struct", Fields: []deltaField{}}
+	for _, f := range s {
+		t, err := deltaType(f.Type)
+		if err != nil {
+			return nil, err
+		}
+		ds.Fields = append(ds.Fields, deltaField{
+			Name:     f.Name,
+			Type:     t,
+			Nullable: !f.Required,
+			Metadata: map[string]interface{}{},
+		})
+	}
+	return ds, nil
+}
+
+func schemaFromDelta(ds *deltaSchema) (Schema, error) {
+	var s Schema
+	for _, f := range ds.Fields {
+		t, err := fromDeltaType(f.Type)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, Field{Name: f.Name, Type: t, Required: !f.Nullable})
+	}
+	return s, nil
+}
+
+type icebergSchema struct {
+	Type     string         `json:"type"`
+	SchemaID int            `json:"schema-id"`
+	Fields   []icebergField `json:"fields"`
+}
+
+type icebergField struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+	Type     string `json:"type"`
+}
+
+// iceberg converts s to an Iceberg schema. Columns keep the IDs they have in
+// prev, new columns are assigned IDs after lastColumnID. It returns the new
+// last column ID.
+func (s Schema) iceberg(id int, prev *icebergSchema, lastColumnID int) (*icebergSchema, int) {
+	ids := make(map[string]int)
+	if prev != nil {
+		for _, f := range prev.Fields {
+			ids[f.Name] = f.ID
+		}
+	}
+	is := &icebergSchema{Type: "struct", SchemaID: id, Fields: []icebergField{}}
+	for _, f := range s {
+		fid, ok := ids[f.Name]
+		if !ok {
+			lastColumnID++
+			fid = lastColumnID
+		}
+		is.Fields = append(is.Fields, icebergField{ID: fid, Name: f.Name, Required: f.Required, Type: f.Type})
+	}
+	return is, lastColumnID
+}
+
+func schemaFromIceberg(is *icebergSchema) Schema {
+	var s Schema
+	for _, f := range is.Fields {
+		s = append(s, Field{Name: f.Name, Type: f.Type, Required: f.Required})
+	}
+	return s
+}
+
+func decimalType(precision, scale int64) string {
+	return fmt.Sprintf("decimal(%d,%d)", precision, scale)
+}
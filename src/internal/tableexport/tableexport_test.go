@@ -0,0 +1,384 @@
+package tableexport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/klauspost/compress/snappy"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+// parquetFooter returns the end of a Parquet file with the given column
+// schema elements.
+func parquetFooter(numRows int64, columns ...fields) []byte {
+	elements := []fields{{{4, "schema"}, {5, int32(len(columns))}}}
+	elements = append(elements, columns...)
+	var buf bytes.Buffer
+	encodeStruct(&buf, fields{
+		{1, int32(1)},
+		{2, elements},
+		{3, numRows},
+		{4, []fields{}},
+	})
+	n := buf.Len()
+	var size [4]byte
+	binary.LittleEndian.PutUint32(size[:], uint32(n))
+	buf.Write(size[:])
+	buf.WriteString(parquetMagic)
+	return buf.Bytes()
+}
+
+func TestParquetSchema(t *testing.T) {
+	footer := parquetFooter(42,
+		fields{{1, int32(parquetInt64)}, {3, int32(repetitionRequired)}, {4, "id"}},
+		fields{{1, int32(parquetByteArray)}, {3, int32(repetitionOptional)}, {4, "name"}, {6, int32(convertedUTF8)}},
+		fields{{1, int32(parquetInt64)}, {3, int32(repetitionOptional)}, {4, "ts"},
+			{10, fields{{logicalTimestamp, fields{{1, true}, {2, fields{{2, fields{}}}}}}}}},
+		fields{{1, int32(parquetInt32)}, {3, int32(repetitionOptional)}, {4, "price"},
+			{6, int32(convertedDecimal)}, {7, int32(2)}, {8, int32(9)}},
+		fields{{1, int32(parquetBoolean)}, {3, int32(repetitionOptional)}, {4, "ok"}},
+		fields{{1, int32(parquetFixedLenByteArray)}, {2, int32(16)}, {3, int32(repetitionOptional)}, {4, "raw"}},
+	)
+	md, err := readParquetMetadata(append([]byte("PAR1 some column data"), footer...))
+	require.NoError(t, err)
+	require.Equal(t, int64(42), md.NumRows)
+	require.Equal(t, Schema{
+		{Name: "id", Type: "long", Required: true},
+		{Name: "name", Type: "string"},
+		{Name: "ts", Type: "timestamptz"},
+		{Name: "price", Type: "decimal(9,2)"},
+		{Name: "ok", Type: "boolean"},
+		{Name: "raw", Type: "fixed[16]"},
+	}, md.Schema)
+
+	_, err = readParquetMetadata([]byte("a,b\n1,2\n"))
+	require.YesError(t, err)
+	nested := parquetFooter(1, fields{{3, int32(repetitionOptional)}, {4, "s"}, {5, int32(1)}})
+	_, err = readParquetMetadata(nested)
+	require.YesError(t, err)
+}
+
+func TestCSVToParquet(t *testing.T) {
+	var buf bytes.Buffer
+	md, err := csvToParquet(bytes.NewBufferString("a,b\n1,x\n2,y\n3,\n"), &buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), md.NumRows)
+	require.Equal(t, Schema{{Name: "a", Type: "string"}, {Name: "b", Type: "string"}}, md.Schema)
+	require.True(t, bytes.HasPrefix(buf.Bytes(), []byte(parquetMagic)))
+
+	read, err := readParquetMetadata(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, md, read)
+
+	// Check that the column chunks point at the pages.
+	data := buf.Bytes()
+	n := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	fmd, err := decodeStruct(data[len(data)-8-n : len(data)-8])
+	require.NoError(t, err)
+	rowGroups := fmd.list(4)
+	require.Equal(t, 1, len(rowGroups))
+	chunks := rowGroups[0].(tstruct).list(1)
+	require.Equal(t, 2, len(chunks))
+	for _, c := range chunks {
+		cmd := c.(tstruct).strct(3)
+		require.Equal(t, int64(3), cmd.int(5))
+		offset := cmd.int(9)
+		tr := &thriftReader{r: bytes.NewReader(data[offset:])}
+		header, err := tr.readStruct(0)
+		require.NoError(t, err)
+		require.Equal(t, int64(0), header.int(1))
+		require.Equal(t, int64(3), header.strct(5).int(1))
+		headerSize := int64(len(data[offset:]) - tr.r.Len())
+		require.Equal(t, cmd.int(6), headerSize+header.int(2))
+	}
+
+	_, err = csvToParquet(bytes.NewBufferString(""), &buf)
+	require.YesError(t, err)
+	_, err = csvToParquet(bytes.NewBufferString("a,a\n1,2\n"), &buf)
+	require.YesError(t, err)
+}
+
+type testFile struct {
+	path, content string
+	// hash defaults to a hash that's unique to the commit and path.
+	hash string
+}
+
+func testExport(t *testing.T, e *Exporter, commit string, files ...testFile) (*Result, error) {
+	return e.Export(context.Background(), commit, func(cb func(*File) error) error {
+		for _, f := range files {
+			content := f.content
+			hash := f.hash
+			if hash == "" {
+				hash = "h" + commit + f.path
+			}
+			if err := cb(&File{
+				Path: f.path,
+				Hash: hash,
+				Content: func(w io.Writer) error {
+					_, err := io.WriteString(w, content)
+					return err
+				},
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// sameHash returns a file whose hash is the same as the file at path in
+// commit, which simulates an unchanged file.
+func sameHash(commit, path string) testFile {
+	return testFile{path: path, hash: "h" + commit + path}
+}
+
+func newTestExporter(t *testing.T, format pfs.TableEgress_Format) (*Exporter, obj.Client) {
+	c, err := obj.NewLocalClient(t.TempDir())
+	require.NoError(t, err)
+	e, err := NewExporter(c, &obj.ObjectStoreURL{Scheme: "s3", Bucket: "bucket", Object: "tables/t"}, format)
+	require.NoError(t, err)
+	return e, c
+}
+
+func readDeltaLog(t *testing.T, c obj.Client, version int64) []deltaAction {
+	var buf bytes.Buffer
+	require.NoError(t, c.Get(context.Background(), "tables/t/"+deltaLogPath(version), &buf))
+	var actions []deltaAction
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var action deltaAction
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &action))
+		actions = append(actions, action)
+	}
+	return actions
+}
+
+func TestDeltaExport(t *testing.T) {
+	e, c := newTestExporter(t, pfs.TableEgress_DELTA)
+	ctx := context.Background()
+
+	// A commit without table files doesn't create the table.
+	res, err := testExport(t, e, "c0", testFile{path: "/README", content: "hi"})
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), res.Version)
+
+	res, err = testExport(t, e, "c1", testFile{path: "/a.csv", content: "x,y\n1,2\n"}, testFile{path: "/b.csv", content: "x,y\n3,4\n5,6\n"})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), res.Version)
+	require.Equal(t, int64(2), res.FilesAdded)
+	actions := readDeltaLog(t, c, 0)
+	require.Equal(t, 5, len(actions))
+	require.NotNil(t, actions[0].Protocol)
+	require.NotNil(t, actions[1].MetaData)
+	require.Equal(t, `{"type":"struct","fields":[{"name":"x","type":"string","nullable":true,"metadata":{}},{"name":"y","type":"string","nullable":true,"metadata":{}}]}`, actions[1].MetaData.SchemaString)
+	require.Equal(t, "c1", actions[4].CommitInfo.PachydermCommit)
+
+	// Exporting the same commit again is a no-op.
+	res, err = testExport(t, e, "c1", testFile{path: "/a.csv", content: "x,y\n1,2\n"})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), res.Version)
+	require.Equal(t, int64(0), res.FilesAdded)
+
+	// Unchanged files aren't uploaded again.
+	res, err = testExport(t, e, "c2", sameHash("c1", "/a.csv"), testFile{path: "/c.csv", content: "x,y\n7,8\n"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), res.Version)
+	require.Equal(t, int64(1), res.FilesAdded)
+	require.Equal(t, int64(1), res.FilesRemoved)
+	actions = readDeltaLog(t, c, 1)
+	require.Equal(t, 3, len(actions))
+	require.Equal(t, "data/hc1/b.csv.parquet", actions[0].Remove.Path)
+	require.Equal(t, "data/hc2/c.csv.parquet", actions[1].Add.Path)
+
+	state, err := e.format.load(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "c2", state.commit)
+	require.Equal(t, 2, len(state.files))
+	require.Equal(t, int64(1), state.files["data/hc2/c.csv.parquet"].Records)
+
+	// A new schema updates the table metadata.
+	res, err = testExport(t, e, "c3", testFile{path: "/d.csv", content: "z\n1\n"})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), res.Version)
+	actions = readDeltaLog(t, c, 2)
+	require.NotNil(t, actions[0].MetaData)
+	require.Equal(t, readDeltaLog(t, c, 0)[1].MetaData.ID, actions[0].MetaData.ID)
+
+	// All of a commit's files must have the same schema.
+	_, err = testExport(t, e, "c4", testFile{path: "/d.csv", content: "z\n1\n"}, testFile{path: "/e.csv", content: "x,y\n1,2\n"})
+	require.YesError(t, err)
+	_, err = testExport(t, e, "c4", sameHash("c1", "/a.csv"), testFile{path: "/e.csv", content: "z\n1\n"})
+	require.YesError(t, err)
+}
+
+func TestIcebergExport(t *testing.T) {
+	e, c := newTestExporter(t, pfs.TableEgress_ICEBERG)
+	ctx := context.Background()
+
+	res, err := testExport(t, e, "c1", testFile{path: "/a.csv", content: "x,y\n1,2\n"}, testFile{path: "/b.csv", content: "x,y\n3,4\n5,6\n"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), res.Version)
+	require.Equal(t, int64(2), res.FilesAdded)
+
+	res, err = testExport(t, e, "c2", sameHash("c1", "/a.csv"), testFile{path: "/c.csv", content: "x,y\n7,8\n"})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), res.Version)
+	require.Equal(t, int64(1), res.FilesAdded)
+	require.Equal(t, int64(1), res.FilesRemoved)
+
+	var hint bytes.Buffer
+	require.NoError(t, c.Get(ctx, "tables/t/metadata/version-hint.text", &hint))
+	require.Equal(t, "2", hint.String())
+
+	// Load the table with a new exporter, as the next export would.
+	e2, err := NewExporter(c, &obj.ObjectStoreURL{Scheme: "s3", Bucket: "bucket", Object: "tables/t"}, pfs.TableEgress_ICEBERG)
+	require.NoError(t, err)
+	state, err := e2.format.load(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), state.version)
+	require.Equal(t, "c2", state.commit)
+	require.Equal(t, Schema{{Name: "x", Type: "string"}, {Name: "y", Type: "string"}}, state.schema)
+	require.Equal(t, 2, len(state.files))
+	md := e2.format.(*icebergTable).md
+	require.Equal(t, "s3://bucket/tables/t", md.Location)
+	require.Equal(t, 2, len(md.Snapshots))
+	require.Equal(t, md.Snapshots[0].SnapshotID, *md.Snapshots[1].ParentSnapshotID)
+	require.Equal(t, "overwrite", md.Snapshots[1].Summary["operation"])
+	require.Equal(t, "2", md.Snapshots[1].Summary["total-records"])
+	// The unchanged file keeps the snapshot that added it.
+	require.Equal(t, md.Snapshots[0].SnapshotID, state.files["data/hc1/a.csv.parquet"].Snapshot)
+	require.Equal(t, md.Snapshots[1].SnapshotID, state.files["data/hc2/c.csv.parquet"].Snapshot)
+
+	// Adding a column keeps the IDs of the existing columns.
+	res, err = testExport(t, e2, "c3", testFile{path: "/d.csv", content: "y,z\n1,2\n"})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), res.Version)
+	md = e2.format.(*icebergTable).md
+	require.Equal(t, 1, md.CurrentSchemaID)
+	require.Equal(t, []icebergField{
+		{ID: 2, Name: "y", Type: "string"},
+		{ID: 3, Name: "z", Type: "string"},
+	}, md.Schema.Fields)
+	require.Equal(t, 3, md.LastColumnID)
+	require.Equal(t, 2, len(md.MetadataLog))
+}
+
+func readParquetRows(t *testing.T, data []byte) [][]interface{} {
+	r, err := NewParquetReader(data)
+	require.NoError(t, err)
+	var rows [][]interface{}
+	for {
+		row, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return rows
+		}
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+}
+
+func TestParquetReader(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := csvToParquet(bytes.NewBufferString("a,b\n1,x\n2,y\n3,\n"), &buf)
+	require.NoError(t, err)
+	r, err := NewParquetReader(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, r.Columns())
+	require.Equal(t, [][]interface{}{{"1", "x"}, {"2", "y"}, {"3", ""}}, readParquetRows(t, buf.Bytes()))
+}
+
+func TestParquetReaderDictionary(t *testing.T) {
+	// A snappy compressed column chunk of an optional int64 column, with a
+	// dictionary page and a version 2 data page of the values 7, null, 9, 7.
+	var dict bytes.Buffer
+	for _, v := range []uint64{7, 9} {
+		var n [8]byte
+		binary.LittleEndian.PutUint64(n[:], v)
+		dict.Write(n[:])
+	}
+	// definition levels 1, 0, 1, 1 as one bit packed group
+	levels := []byte{0x03, 0x0d}
+	// dictionary indices 0, 1, 0 of bit width 1, as one bit packed group
+	indices := []byte{0x01, 0x03, 0x02}
+	var chunk bytes.Buffer
+	writePage := func(header fields, body []byte) {
+		encodeStruct(&chunk, header)
+		chunk.Write(body)
+	}
+	dictBody := snappy.Encode(nil, dict.Bytes())
+	writePage(fields{
+		{1, int32(pageDictionary)},
+		{2, int32(dict.Len())},
+		{3, int32(len(dictBody))},
+		{7, fields{{1, int32(2)}, {2, int32(encodingPlain)}}},
+	}, dictBody)
+	dataBody := append(append([]byte{}, levels...), snappy.Encode(nil, indices)...)
+	writePage(fields{
+		{1, int32(pageDataV2)},
+		{2, int32(len(levels) + len(indices))},
+		{3, int32(len(dataBody))},
+		{8, fields{
+			{1, int32(4)},
+			{2, int32(1)},
+			{3, int32(4)},
+			{4, int32(encodingRLEDictionary)},
+			{5, int32(len(levels))},
+			{6, int32(0)},
+		}},
+	}, dataBody)
+	file := append([]byte(parquetMagic), chunk.Bytes()...)
+	var footer bytes.Buffer
+	encodeStruct(&footer, fields{
+		{1, int32(1)},
+		{2, []fields{
+			{{4, "schema"}, {5, int32(1)}},
+			{{1, int32(parquetInt64)}, {3, int32(repetitionOptional)}, {4, "n"}},
+		}},
+		{3, int64(4)},
+		{4, []fields{{
+			{1, []fields{{
+				{2, int64(len(parquetMagic))},
+				{3, fields{
+					{1, int32(parquetInt64)},
+					{2, []int32{encodingPlain, encodingRLEDictionary}},
+					{3, []string{"n"}},
+					{4, int32(compressionSnappy)},
+					{5, int64(4)},
+					{6, int64(chunk.Len())},
+					{7, int64(chunk.Len())},
+					{9, int64(len(parquetMagic))},
+				}},
+			}}},
+			{2, int64(chunk.Len())},
+			{3, int64(4)},
+		}}},
+	})
+	var n [4]byte
+	binary.LittleEndian.PutUint32(n[:], uint32(footer.Len()))
+	file = append(append(append(file, footer.Bytes()...), n[:]...), parquetMagic...)
+	require.Equal(t, [][]interface{}{{int64(7)}, {nil}, {int64(9)}, {int64(7)}}, readParquetRows(t, file))
+}
+
+func TestDecodeHybrid(t *testing.T) {
+	// an RLE run of three 5s followed by a bit packed group of 1, 2, 3
+	values, err := decodeHybrid([]byte{0x06, 0x05, 0x03, 0xd1, 0x00}, 3, 6)
+	require.NoError(t, err)
+	require.Equal(t, []int64{5, 5, 5, 1, 2, 3}, values)
+}
+
+func TestFormatDecimal(t *testing.T) {
+	require.Equal(t, "12.345", formatDecimal(big.NewInt(12345), 3))
+	require.Equal(t, "-0.05", formatDecimal(big.NewInt(-5), 2))
+	require.Equal(t, "42", formatDecimal(big.NewInt(42), 0))
+}
@@ -0,0 +1,308 @@
+package tableexport
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// This file implements the subset of the Thrift compact protocol needed to
+// read and write Parquet file metadata. Structs are decoded generically into
+// maps from field ID to value, which avoids generating code for the whole
+// Parquet format definition when only a few fields are needed.
+
+const (
+	tStop      = 0
+	tBoolTrue  = 1
+	tBoolFalse = 2
+	tByte      = 3
+	tI16       = 4
+	tI32       = 5
+	tI64       = 6
+	tDouble    = 7
+	tBinary    = 8
+	tList      = 9
+	tSet       = 10
+	tMap       = 11
+	tStruct    = 12
+
+	// maxThriftDepth bounds the nesting of decoded values, so that corrupt
+	// metadata can't exhaust the stack.
+	maxThriftDepth = 64
+)
+
+// tstruct is a decoded Thrift struct.
+type tstruct map[int16]interface{}
+
+func (s tstruct) int(id int16) int64 {
+	v, _ := s[id].(int64)
+	return v
+}
+
+func (s tstruct) str(id int16) string {
+	v, _ := s[id].([]byte)
+	return string(v)
+}
+
+func (s tstruct) has(id int16) bool {
+	_, ok := s[id]
+	return ok
+}
+
+func (s tstruct) strct(id int16) tstruct {
+	v, _ := s[id].(tstruct)
+	return v
+}
+
+func (s tstruct) list(id int16) []interface{} {
+	v, _ := s[id].([]interface{})
+	return v
+}
+
+type thriftReader struct {
+	r *bytes.Reader
+}
+
+func decodeStruct(data []byte) (tstruct, error) {
+	s, _, err := decodeStructPrefix(data)
+	return s, err
+}
+
+// decodeStructPrefix decodes the struct at the start of data, and returns it
+// along with the number of bytes it took up.
+func decodeStructPrefix(data []byte) (tstruct, int, error) {
+	tr := &thriftReader{r: bytes.NewReader(data)}
+	s, err := tr.readStruct(0)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s, len(data) - tr.r.Len(), nil
+}
+
+func (tr *thriftReader) readStruct(depth int) (tstruct, error) {
+	if depth > maxThriftDepth {
+		return nil, errors.Errorf("thrift value nested too deeply")
+	}
+	s := make(tstruct)
+	var id int16
+	for {
+		header, err := tr.r.ReadByte()
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		typ := header & 0x0f
+		if typ == tStop {
+			return s, nil
+		}
+		if delta := int16(header >> 4); delta != 0 {
+			id += delta
+		} else {
+			v, err := tr.readZigzag()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		switch typ {
+		case tBoolTrue:
+			s[id] = true
+		case tBoolFalse:
+			s[id] = false
+		default:
+			if s[id], err = tr.readValue(typ, depth); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (tr *thriftReader) readValue(typ byte, depth int) (interface{}, error) {
+	switch typ {
+	case tBoolTrue, tBoolFalse:
+		// Booleans outside of struct fields are encoded as a byte.
+		b, err := tr.r.ReadByte()
+		return b == tBoolTrue, errors.EnsureStack(err)
+	case tByte:
+		b, err := tr.r.ReadByte()
+		return int64(int8(b)), errors.EnsureStack(err)
+	case tI16, tI32, tI64:
+		return tr.readZigzag()
+	case tDouble:
+		var buf [8]byte
+		if _, err := io.ReadFull(tr.r, buf[:]); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(buf[:])), nil
+	case tBinary:
+		n, err := binary.ReadUvarint(tr.r)
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		if n > uint64(tr.r.Len()) {
+			return nil, errors.Errorf("thrift binary length %d exceeds the remaining %d bytes", n, tr.r.Len())
+		}
+		buf := make([]byte, n)
+		_, err = io.ReadFull(tr.r, buf)
+		return buf, errors.EnsureStack(err)
+	case tList, tSet:
+		header, err := tr.r.ReadByte()
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		n := uint64(header >> 4)
+		if n == 15 {
+			if n, err = binary.ReadUvarint(tr.r); err != nil {
+				return nil, errors.EnsureStack(err)
+			}
+		}
+		// Every element takes at least one byte.
+		if n > uint64(tr.r.Len()) {
+			return nil, errors.Errorf("thrift list length %d exceeds the remaining %d bytes", n, tr.r.Len())
+		}
+		list := make([]interface{}, n)
+		for i := range list {
+			if list[i], err = tr.readValue(header&0x0f, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	case tMap:
+		n, err := binary.ReadUvarint(tr.r)
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		if n == 0 {
+			return nil, nil
+		}
+		if n > uint64(tr.r.Len()) {
+			return nil, errors.Errorf("thrift map length %d exceeds the remaining %d bytes", n, tr.r.Len())
+		}
+		types, err := tr.r.ReadByte()
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		// Maps aren't needed, their entries are only read to skip them.
+		for i := uint64(0); i < n; i++ {
+			if _, err := tr.readValue(types>>4, depth+1); err != nil {
+				return nil, err
+			}
+			if _, err := tr.readValue(types&0x0f, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	case tStruct:
+		return tr.readStruct(depth + 1)
+	default:
+		return nil, errors.Errorf("unknown thrift type %d", typ)
+	}
+}
+
+func (tr *thriftReader) readZigzag() (int64, error) {
+	v, err := binary.ReadVarint(tr.r)
+	return v, errors.EnsureStack(err)
+}
+
+// tfield is a field of a struct to encode. Values are encoded based on their
+// Go type: bool, int32, int64, string, []byte, []int32, []string, fields
+// (a nested struct) and []fields (a list of structs).
+type tfield struct {
+	id    int16
+	value interface{}
+}
+
+type fields []tfield
+
+func encodeStruct(w *bytes.Buffer, s fields) {
+	var last int16
+	for _, f := range s {
+		typ := thriftType(f.value)
+		if b, ok := f.value.(bool); ok && !b {
+			typ = tBoolFalse
+		}
+		if delta := f.id - last; delta > 0 && delta <= 15 {
+			w.WriteByte(byte(delta)<<4 | typ)
+		} else {
+			w.WriteByte(typ)
+			writeZigzag(w, int64(f.id))
+		}
+		last = f.id
+		if typ != tBoolTrue && typ != tBoolFalse {
+			encodeValue(w, f.value)
+		}
+	}
+	w.WriteByte(tStop)
+}
+
+func thriftType(v interface{}) byte {
+	switch v.(type) {
+	case bool:
+		return tBoolTrue
+	case int32:
+		return tI32
+	case int64:
+		return tI64
+	case string, []byte:
+		return tBinary
+	case []int32, []string, []fields:
+		return tList
+	case fields:
+		return tStruct
+	default:
+		panic(errors.Errorf("unsupported thrift value %T", v))
+	}
+}
+
+func encodeValue(w *bytes.Buffer, v interface{}) {
+	switch v := v.(type) {
+	case int32:
+		writeZigzag(w, int64(v))
+	case int64:
+		writeZigzag(w, v)
+	case string:
+		writeUvarint(w, uint64(len(v)))
+		w.WriteString(v)
+	case []byte:
+		writeUvarint(w, uint64(len(v)))
+		w.Write(v)
+	case []int32:
+		writeListHeader(w, len(v), tI32)
+		for _, x := range v {
+			writeZigzag(w, int64(x))
+		}
+	case []string:
+		writeListHeader(w, len(v), tBinary)
+		for _, x := range v {
+			encodeValue(w, x)
+		}
+	case []fields:
+		writeListHeader(w, len(v), tStruct)
+		for _, x := range v {
+			encodeStruct(w, x)
+		}
+	case fields:
+		encodeStruct(w, v)
+	}
+}
+
+func writeListHeader(w *bytes.Buffer, n int, typ byte) {
+	if n < 15 {
+		w.WriteByte(byte(n)<<4 | typ)
+		return
+	}
+	w.WriteByte(0xf0 | typ)
+	writeUvarint(w, uint64(n))
+}
+
+func writeUvarint(w *bytes.Buffer, v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	w.Write(buf[:binary.PutUvarint(buf[:], v)])
+}
+
+func writeZigzag(w *bytes.Buffer, v int64) {
+	var buf [binary.MaxVarintLen64]byte
+	w.Write(buf[:binary.PutVarint(buf[:], v)])
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/pfs"
 	"github.com/pachyderm/pachyderm/v2/src/pps"
 	"github.com/pachyderm/pachyderm/v2/src/proxy"
+	"github.com/pachyderm/pachyderm/v2/src/search"
 	"github.com/pachyderm/pachyderm/v2/src/task"
 	"github.com/pachyderm/pachyderm/v2/src/transaction"
 	version "github.com/pachyderm/pachyderm/v2/src/version/versionpb"
@@ -51,10 +52,67 @@ func linkServers(mockServerPtr interface{}, realServer interface{}) {
 /* Admin Server Mocks */
 
 type inspectClusterFunc func(context.Context, *types.Empty) (*admin.ClusterInfo, error)
+type createWebhookFunc func(context.Context, *admin.CreateWebhookRequest) (*types.Empty, error)
+type inspectWebhookFunc func(context.Context, *admin.InspectWebhookRequest) (*admin.WebhookInfo, error)
+type listWebhookFunc func(*admin.ListWebhookRequest, admin.API_ListWebhookServer) error
+type deleteWebhookFunc func(context.Context, *admin.DeleteWebhookRequest) (*types.Empty, error)
+type listInflightRequestsFunc func(context.Context, *admin.ListInflightRequestsRequest) (*admin.ListInflightRequestsResponse, error)
+type cancelInflightRequestFunc func(context.Context, *admin.CancelInflightRequestRequest) (*types.Empty, error)
+type getClusterDefaultsFunc func(context.Context, *types.Empty) (*admin.ClusterDefaults, error)
+type setClusterDefaultsFunc func(context.Context, *admin.SetClusterDefaultsRequest) (*types.Empty, error)
+type getUsageReportFunc func(context.Context, *admin.GetUsageReportRequest) (*admin.UsageReport, error)
+type getCapacityReportFunc func(context.Context, *admin.GetCapacityReportRequest) (*admin.CapacityReport, error)
+type subscribeEventsFunc func(*admin.SubscribeEventsRequest, admin.API_SubscribeEventsServer) error
+type validateConfigurationFunc func(context.Context, *admin.ValidateConfigurationRequest) (*admin.ValidateConfigurationResponse, error)
+type inspectUpgradeFunc func(context.Context, *admin.InspectUpgradeRequest) (*admin.InspectUpgradeResponse, error)
+type backupFunc func(context.Context, *admin.BackupRequest) (*admin.BackupInfo, error)
+type listBackupsFunc func(*admin.ListBackupsRequest, admin.API_ListBackupsServer) error
+type verifyBackupFunc func(context.Context, *admin.VerifyBackupRequest) (*admin.VerifyBackupResponse, error)
+type restoreFunc func(context.Context, *admin.RestoreRequest) (*admin.RestoreResponse, error)
+type setReadOnlyFunc func(context.Context, *admin.SetReadOnlyRequest) (*admin.ReadOnlyState, error)
+type inspectReadOnlyFunc func(context.Context, *types.Empty) (*admin.ReadOnlyState, error)
 
 type mockInspectCluster struct{ handler inspectClusterFunc }
-
-func (mock *mockInspectCluster) Use(cb inspectClusterFunc) { mock.handler = cb }
+type mockCreateWebhook struct{ handler createWebhookFunc }
+type mockInspectWebhook struct{ handler inspectWebhookFunc }
+type mockListWebhook struct{ handler listWebhookFunc }
+type mockDeleteWebhook struct{ handler deleteWebhookFunc }
+type mockListInflightRequests struct{ handler listInflightRequestsFunc }
+type mockCancelInflightRequest struct{ handler cancelInflightRequestFunc }
+type mockGetClusterDefaults struct{ handler getClusterDefaultsFunc }
+type mockSetClusterDefaults struct{ handler setClusterDefaultsFunc }
+type mockGetUsageReport struct{ handler getUsageReportFunc }
+type mockGetCapacityReport struct{ handler getCapacityReportFunc }
+type mockSubscribeEvents struct{ handler subscribeEventsFunc }
+type mockValidateConfiguration struct{ handler validateConfigurationFunc }
+type mockInspectUpgrade struct{ handler inspectUpgradeFunc }
+type mockBackup struct{ handler backupFunc }
+type mockListBackups struct{ handler listBackupsFunc }
+type mockVerifyBackup struct{ handler verifyBackupFunc }
+type mockRestore struct{ handler restoreFunc }
+type mockSetReadOnly struct{ handler setReadOnlyFunc }
+type mockInspectReadOnly struct{ handler inspectReadOnlyFunc }
+
+func (mock *mockInspectCluster) Use(cb inspectClusterFunc)               { mock.handler = cb }
+func (mock *mockCreateWebhook) Use(cb createWebhookFunc)                 { mock.handler = cb }
+func (mock *mockInspectWebhook) Use(cb inspectWebhookFunc)               { mock.handler = cb }
+func (mock *mockListWebhook) Use(cb listWebhookFunc)                     { mock.handler = cb }
+func (mock *mockDeleteWebhook) Use(cb deleteWebhookFunc)                 { mock.handler = cb }
+func (mock *mockListInflightRequests) Use(cb listInflightRequestsFunc)   { mock.handler = cb }
+func (mock *mockCancelInflightRequest) Use(cb cancelInflightRequestFunc) { mock.handler = cb }
+func (mock *mockGetClusterDefaults) Use(cb getClusterDefaultsFunc)       { mock.handler = cb }
+func (mock *mockSetClusterDefaults) Use(cb setClusterDefaultsFunc)       { mock.handler = cb }
+func (mock *mockGetUsageReport) Use(cb getUsageReportFunc)               { mock.handler = cb }
+func (mock *mockGetCapacityReport) Use(cb getCapacityReportFunc)         { mock.handler = cb }
+func (mock *mockSubscribeEvents) Use(cb subscribeEventsFunc)             { mock.handler = cb }
+func (mock *mockValidateConfiguration) Use(cb validateConfigurationFunc) { mock.handler = cb }
+func (mock *mockInspectUpgrade) Use(cb inspectUpgradeFunc)               { mock.handler = cb }
+func (mock *mockBackup) Use(cb backupFunc)                               { mock.handler = cb }
+func (mock *mockListBackups) Use(cb listBackupsFunc)                     { mock.handler = cb }
+func (mock *mockVerifyBackup) Use(cb verifyBackupFunc)                   { mock.handler = cb }
+func (mock *mockRestore) Use(cb restoreFunc)                             { mock.handler = cb }
+func (mock *mockSetReadOnly) Use(cb setReadOnlyFunc)                     { mock.handler = cb }
+func (mock *mockInspectReadOnly) Use(cb inspectReadOnlyFunc)             { mock.handler = cb }
 
 type adminServerAPI struct {
 	mock *mockAdminServer
@@ -63,6 +121,30 @@ type adminServerAPI struct {
 type mockAdminServer struct {
 	api            adminServerAPI
 	InspectCluster mockInspectCluster
+	CreateWebhook  mockCreateWebhook
+	InspectWebhook mockInspectWebhook
+	ListWebhook    mockListWebhook
+	DeleteWebhook  mockDeleteWebhook
+
+	ListInflightRequests  mockListInflightRequests
+	CancelInflightRequest mockCancelInflightRequest
+
+	GetClusterDefaults mockGetClusterDefaults
+	SetClusterDefaults mockSetClusterDefaults
+	GetUsageReport     mockGetUsageReport
+	GetCapacityReport  mockGetCapacityReport
+	SubscribeEvents    mockSubscribeEvents
+
+	ValidateConfiguration mockValidateConfiguration
+	InspectUpgrade        mockInspectUpgrade
+
+	Backup       mockBackup
+	ListBackups  mockListBackups
+	VerifyBackup mockVerifyBackup
+	Restore      mockRestore
+
+	SetReadOnly     mockSetReadOnly
+	InspectReadOnly mockInspectReadOnly
 }
 
 func (api *adminServerAPI) InspectCluster(ctx context.Context, req *types.Empty) (*admin.ClusterInfo, error) {
@@ -71,6 +153,120 @@ func (api *adminServerAPI) InspectCluster(ctx context.Context, req *types.Empty)
 	}
 	return nil, errors.Errorf("unhandled pachd mock admin.InspectCluster")
 }
+func (api *adminServerAPI) CreateWebhook(ctx context.Context, req *admin.CreateWebhookRequest) (*types.Empty, error) {
+	if api.mock.CreateWebhook.handler != nil {
+		return api.mock.CreateWebhook.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock admin.CreateWebhook")
+}
+func (api *adminServerAPI) InspectWebhook(ctx context.Context, req *admin.InspectWebhookRequest) (*admin.WebhookInfo, error) {
+	if api.mock.InspectWebhook.handler != nil {
+		return api.mock.InspectWebhook.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock admin.InspectWebhook")
+}
+func (api *adminServerAPI) ListWebhook(req *admin.ListWebhookRequest, srv admin.API_ListWebhookServer) error {
+	if api.mock.ListWebhook.handler != nil {
+		return api.mock.ListWebhook.handler(req, srv)
+	}
+	return errors.Errorf("unhandled pachd mock admin.ListWebhook")
+}
+func (api *adminServerAPI) DeleteWebhook(ctx context.Context, req *admin.DeleteWebhookRequest) (*types.Empty, error) {
+	if api.mock.DeleteWebhook.handler != nil {
+		return api.mock.DeleteWebhook.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock admin.DeleteWebhook")
+}
+func (api *adminServerAPI) ListInflightRequests(ctx context.Context, req *admin.ListInflightRequestsRequest) (*admin.ListInflightRequestsResponse, error) {
+	if api.mock.ListInflightRequests.handler != nil {
+		return api.mock.ListInflightRequests.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock admin.ListInflightRequests")
+}
+func (api *adminServerAPI) CancelInflightRequest(ctx context.Context, req *admin.CancelInflightRequestRequest) (*types.Empty, error) {
+	if api.mock.CancelInflightRequest.handler != nil {
+		return api.mock.CancelInflightRequest.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock admin.CancelInflightRequest")
+}
+func (api *adminServerAPI) GetClusterDefaults(ctx context.Context, req *types.Empty) (*admin.ClusterDefaults, error) {
+	if api.mock.GetClusterDefaults.handler != nil {
+		return api.mock.GetClusterDefaults.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock admin.GetClusterDefaults")
+}
+func (api *adminServerAPI) SetClusterDefaults(ctx context.Context, req *admin.SetClusterDefaultsRequest) (*types.Empty, error) {
+	if api.mock.SetClusterDefaults.handler != nil {
+		return api.mock.SetClusterDefaults.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock admin.SetClusterDefaults")
+}
+func (api *adminServerAPI) GetUsageReport(ctx context.Context, req *admin.GetUsageReportRequest) (*admin.UsageReport, error) {
+	if api.mock.GetUsageReport.handler != nil {
+		return api.mock.GetUsageReport.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock admin.GetUsageReport")
+}
+func (api *adminServerAPI) GetCapacityReport(ctx context.Context, req *admin.GetCapacityReportRequest) (*admin.CapacityReport, error) {
+	if api.mock.GetCapacityReport.handler != nil {
+		return api.mock.GetCapacityReport.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock admin.GetCapacityReport")
+}
+func (api *adminServerAPI) SubscribeEvents(req *admin.SubscribeEventsRequest, srv admin.API_SubscribeEventsServer) error {
+	if api.mock.SubscribeEvents.handler != nil {
+		return api.mock.SubscribeEvents.handler(req, srv)
+	}
+	return errors.Errorf("unhandled pachd mock admin.SubscribeEvents")
+}
+func (api *adminServerAPI) ValidateConfiguration(ctx context.Context, req *admin.ValidateConfigurationRequest) (*admin.ValidateConfigurationResponse, error) {
+	if api.mock.ValidateConfiguration.handler != nil {
+		return api.mock.ValidateConfiguration.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock admin.ValidateConfiguration")
+}
+func (api *adminServerAPI) InspectUpgrade(ctx context.Context, req *admin.InspectUpgradeRequest) (*admin.InspectUpgradeResponse, error) {
+	if api.mock.InspectUpgrade.handler != nil {
+		return api.mock.InspectUpgrade.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock admin.InspectUpgrade")
+}
+func (api *adminServerAPI) Backup(ctx context.Context, req *admin.BackupRequest) (*admin.BackupInfo, error) {
+	if api.mock.Backup.handler != nil {
+		return api.mock.Backup.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock admin.Backup")
+}
+func (api *adminServerAPI) ListBackups(req *admin.ListBackupsRequest, srv admin.API_ListBackupsServer) error {
+	if api.mock.ListBackups.handler != nil {
+		return api.mock.ListBackups.handler(req, srv)
+	}
+	return errors.Errorf("unhandled pachd mock admin.ListBackups")
+}
+func (api *adminServerAPI) VerifyBackup(ctx context.Context, req *admin.VerifyBackupRequest) (*admin.VerifyBackupResponse, error) {
+	if api.mock.VerifyBackup.handler != nil {
+		return api.mock.VerifyBackup.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock admin.VerifyBackup")
+}
+func (api *adminServerAPI) Restore(ctx context.Context, req *admin.RestoreRequest) (*admin.RestoreResponse, error) {
+	if api.mock.Restore.handler != nil {
+		return api.mock.Restore.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock admin.Restore")
+}
+func (api *adminServerAPI) SetReadOnly(ctx context.Context, req *admin.SetReadOnlyRequest) (*admin.ReadOnlyState, error) {
+	if api.mock.SetReadOnly.handler != nil {
+		return api.mock.SetReadOnly.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock admin.SetReadOnly")
+}
+func (api *adminServerAPI) InspectReadOnly(ctx context.Context, req *types.Empty) (*admin.ReadOnlyState, error) {
+	if api.mock.InspectReadOnly.handler != nil {
+		return api.mock.InspectReadOnly.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock admin.InspectReadOnly")
+}
 
 /* Auth Server Mocks */
 
@@ -101,6 +297,12 @@ type extractAuthTokensFunc func(context.Context, *auth.ExtractAuthTokensRequest)
 type restoreAuthTokenFunc func(context.Context, *auth.RestoreAuthTokenRequest) (*auth.RestoreAuthTokenResponse, error)
 type deleteExpiredAuthTokensFunc func(context.Context, *auth.DeleteExpiredAuthTokensRequest) (*auth.DeleteExpiredAuthTokensResponse, error)
 type RotateRootTokenFunc func(context.Context, *auth.RotateRootTokenRequest) (*auth.RotateRootTokenResponse, error)
+type requestRoleFunc func(context.Context, *auth.RequestRoleRequest) (*auth.RequestRoleResponse, error)
+type listRoleRequestsFunc func(context.Context, *auth.ListRoleRequestsRequest) (*auth.ListRoleRequestsResponse, error)
+type reviewRoleRequestFunc func(context.Context, *auth.ReviewRoleRequestRequest) (*auth.ReviewRoleRequestResponse, error)
+type freezeACLsFunc func(context.Context, *auth.FreezeACLsRequest) (*auth.FreezeACLsResponse, error)
+type unfreezeACLsFunc func(context.Context, *auth.UnfreezeACLsRequest) (*auth.UnfreezeACLsResponse, error)
+type getACLFreezeFunc func(context.Context, *auth.GetACLFreezeRequest) (*auth.GetACLFreezeResponse, error)
 
 type mockActivateAuth struct{ handler activateAuthFunc }
 type mockDeactivateAuth struct{ handler deactivateAuthFunc }
@@ -130,6 +332,12 @@ type mockExtractAuthTokens struct{ handler extractAuthTokensFunc }
 type mockRestoreAuthToken struct{ handler restoreAuthTokenFunc }
 type mockDeleteExpiredAuthTokens struct{ handler deleteExpiredAuthTokensFunc }
 type mockRotateRootToken struct{ handler RotateRootTokenFunc }
+type mockRequestRole struct{ handler requestRoleFunc }
+type mockListRoleRequests struct{ handler listRoleRequestsFunc }
+type mockReviewRoleRequest struct{ handler reviewRoleRequestFunc }
+type mockFreezeACLs struct{ handler freezeACLsFunc }
+type mockUnfreezeACLs struct{ handler unfreezeACLsFunc }
+type mockGetACLFreeze struct{ handler getACLFreezeFunc }
 
 func (mock *mockActivateAuth) Use(cb activateAuthFunc)                             { mock.handler = cb }
 func (mock *mockDeactivateAuth) Use(cb deactivateAuthFunc)                         { mock.handler = cb }
@@ -156,6 +364,12 @@ func (mock *mockExtractAuthTokens) Use(cb extractAuthTokensFunc)
 func (mock *mockRestoreAuthToken) Use(cb restoreAuthTokenFunc)                     { mock.handler = cb }
 func (mock *mockDeleteExpiredAuthTokens) Use(cb deleteExpiredAuthTokensFunc)       { mock.handler = cb }
 func (mock *mockRotateRootToken) Use(cb RotateRootTokenFunc)                       { mock.handler = cb }
+func (mock *mockRequestRole) Use(cb requestRoleFunc)                               { mock.handler = cb }
+func (mock *mockListRoleRequests) Use(cb listRoleRequestsFunc)                     { mock.handler = cb }
+func (mock *mockReviewRoleRequest) Use(cb reviewRoleRequestFunc)                   { mock.handler = cb }
+func (mock *mockFreezeACLs) Use(cb freezeACLsFunc)                                 { mock.handler = cb }
+func (mock *mockUnfreezeACLs) Use(cb unfreezeACLsFunc)                             { mock.handler = cb }
+func (mock *mockGetACLFreeze) Use(cb getACLFreezeFunc)                             { mock.handler = cb }
 
 type authServerAPI struct {
 	mock *mockAuthServer
@@ -188,6 +402,12 @@ type mockAuthServer struct {
 	RestoreAuthToken           mockRestoreAuthToken
 	DeleteExpiredAuthTokens    mockDeleteExpiredAuthTokens
 	RotateRootToken            mockRotateRootToken
+	RequestRole                mockRequestRole
+	ListRoleRequests           mockListRoleRequests
+	ReviewRoleRequest          mockReviewRoleRequest
+	FreezeACLs                 mockFreezeACLs
+	UnfreezeACLs               mockUnfreezeACLs
+	GetACLFreeze               mockGetACLFreeze
 }
 
 func (api *authServerAPI) Activate(ctx context.Context, req *auth.ActivateRequest) (*auth.ActivateResponse, error) {
@@ -345,6 +565,45 @@ func (api *authServerAPI) RotateRootToken(ctx context.Context, req *auth.RotateR
 	return nil, errors.Errorf("unhandled pachd mock auth.RotateRootToken")
 }
 
+func (api *authServerAPI) RequestRole(ctx context.Context, req *auth.RequestRoleRequest) (*auth.RequestRoleResponse, error) {
+	if api.mock.RequestRole.handler != nil {
+		return api.mock.RequestRole.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock auth.RequestRole")
+}
+
+func (api *authServerAPI) ListRoleRequests(ctx context.Context, req *auth.ListRoleRequestsRequest) (*auth.ListRoleRequestsResponse, error) {
+	if api.mock.ListRoleRequests.handler != nil {
+		return api.mock.ListRoleRequests.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock auth.ListRoleRequests")
+}
+
+func (api *authServerAPI) ReviewRoleRequest(ctx context.Context, req *auth.ReviewRoleRequestRequest) (*auth.ReviewRoleRequestResponse, error) {
+	if api.mock.ReviewRoleRequest.handler != nil {
+		return api.mock.ReviewRoleRequest.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock auth.ReviewRoleRequest")
+}
+func (api *authServerAPI) FreezeACLs(ctx context.Context, req *auth.FreezeACLsRequest) (*auth.FreezeACLsResponse, error) {
+	if api.mock.FreezeACLs.handler != nil {
+		return api.mock.FreezeACLs.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock auth.FreezeACLs")
+}
+func (api *authServerAPI) UnfreezeACLs(ctx context.Context, req *auth.UnfreezeACLsRequest) (*auth.UnfreezeACLsResponse, error) {
+	if api.mock.UnfreezeACLs.handler != nil {
+		return api.mock.UnfreezeACLs.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock auth.UnfreezeACLs")
+}
+func (api *authServerAPI) GetACLFreeze(ctx context.Context, req *auth.GetACLFreezeRequest) (*auth.GetACLFreezeResponse, error) {
+	if api.mock.GetACLFreeze.handler != nil {
+		return api.mock.GetACLFreeze.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock auth.GetACLFreeze")
+}
+
 /* Enterprise Server Mocks */
 
 type activateEnterpriseFunc func(context.Context, *enterprise.ActivateRequest) (*enterprise.ActivateResponse, error)
@@ -425,6 +684,8 @@ type createRepoFunc func(context.Context, *pfs.CreateRepoRequest) (*types.Empty,
 type inspectRepoFunc func(context.Context, *pfs.InspectRepoRequest) (*pfs.RepoInfo, error)
 type listRepoFunc func(*pfs.ListRepoRequest, pfs.API_ListRepoServer) error
 type deleteRepoFunc func(context.Context, *pfs.DeleteRepoRequest) (*types.Empty, error)
+type cordonRepoFunc func(context.Context, *pfs.CordonRepoRequest) (*types.Empty, error)
+type uncordonRepoFunc func(context.Context, *pfs.UncordonRepoRequest) (*types.Empty, error)
 type startCommitFunc func(context.Context, *pfs.StartCommitRequest) (*pfs.Commit, error)
 type finishCommitFunc func(context.Context, *pfs.FinishCommitRequest) (*types.Empty, error)
 type inspectCommitFunc func(context.Context, *pfs.InspectCommitRequest) (*pfs.CommitInfo, error)
@@ -435,6 +696,8 @@ type inspectCommitSetFunc func(*pfs.InspectCommitSetRequest, pfs.API_InspectComm
 type listCommitSetFunc func(*pfs.ListCommitSetRequest, pfs.API_ListCommitSetServer) error
 type subscribeCommitFunc func(*pfs.SubscribeCommitRequest, pfs.API_SubscribeCommitServer) error
 type clearCommitFunc func(context.Context, *pfs.ClearCommitRequest) (*types.Empty, error)
+type checkpointCommitFunc func(context.Context, *pfs.CheckpointCommitRequest) (*pfs.CheckpointInfo, error)
+type subscribeCheckpointFunc func(*pfs.SubscribeCheckpointRequest, pfs.API_SubscribeCheckpointServer) error
 type createBranchFunc func(context.Context, *pfs.CreateBranchRequest) (*types.Empty, error)
 type inspectBranchFunc func(context.Context, *pfs.InspectBranchRequest) (*pfs.BranchInfo, error)
 type listBranchFunc func(*pfs.ListBranchRequest, pfs.API_ListBranchServer) error
@@ -458,6 +721,35 @@ type checkStorageFunc func(context.Context, *pfs.CheckStorageRequest) (*pfs.Chec
 type putCacheFunc func(context.Context, *pfs.PutCacheRequest) (*types.Empty, error)
 type getCacheFunc func(context.Context, *pfs.GetCacheRequest) (*pfs.GetCacheResponse, error)
 type clearCacheFunc func(context.Context, *pfs.ClearCacheRequest) (*types.Empty, error)
+type inspectCacheFunc func(context.Context, *pfs.InspectCacheRequest) (*pfs.InspectCacheResponse, error)
+type setSchemaFunc func(context.Context, *pfs.SetSchemaRequest) (*pfs.SchemaInfo, error)
+type listSchemaFunc func(*pfs.ListSchemaRequest, pfs.API_ListSchemaServer) error
+type deleteSchemaFunc func(context.Context, *pfs.DeleteSchemaRequest) (*types.Empty, error)
+type inspectCommitSchemaFunc func(context.Context, *pfs.InspectCommitSchemaRequest) (*pfs.CommitSchemaInfo, error)
+type setRetentionPolicyFunc func(context.Context, *pfs.SetRetentionPolicyRequest) (*pfs.RetentionPolicyInfo, error)
+type listRetentionPolicyFunc func(*pfs.ListRetentionPolicyRequest, pfs.API_ListRetentionPolicyServer) error
+type deleteRetentionPolicyFunc func(context.Context, *pfs.DeleteRetentionPolicyRequest) (*types.Empty, error)
+type applyRetentionPolicyFunc func(context.Context, *pfs.ApplyRetentionPolicyRequest) (*pfs.ApplyRetentionPolicyResponse, error)
+type eraseFileFunc func(context.Context, *pfs.EraseFileRequest) (*pfs.ErasureInfo, error)
+type listErasureFunc func(*pfs.ListErasureRequest, pfs.API_ListErasureServer) error
+type verifyErasureFunc func(context.Context, *pfs.VerifyErasureRequest) (*pfs.VerifyErasureResponse, error)
+type setValidationPolicyFunc func(context.Context, *pfs.SetValidationPolicyRequest) (*pfs.ValidationPolicyInfo, error)
+type inspectValidationPolicyFunc func(context.Context, *pfs.InspectValidationPolicyRequest) (*pfs.ValidationPolicyInfo, error)
+type deleteValidationPolicyFunc func(context.Context, *pfs.DeleteValidationPolicyRequest) (*types.Empty, error)
+type createViewFunc func(context.Context, *pfs.CreateViewRequest) (*pfs.ViewInfo, error)
+type inspectViewFunc func(context.Context, *pfs.InspectViewRequest) (*pfs.ViewInfo, error)
+type listViewFunc func(*pfs.ListViewRequest, pfs.API_ListViewServer) error
+type deleteViewFunc func(context.Context, *pfs.DeleteViewRequest) (*types.Empty, error)
+type startUploadFunc func(context.Context, *pfs.StartUploadRequest) (*pfs.UploadSession, error)
+type uploadBlockFunc func(context.Context, *pfs.UploadBlockRequest) (*types.Empty, error)
+type inspectUploadFunc func(context.Context, *pfs.InspectUploadRequest) (*pfs.UploadSession, error)
+type finishUploadFunc func(context.Context, *pfs.FinishUploadRequest) (*types.Empty, error)
+type deleteUploadFunc func(context.Context, *pfs.DeleteUploadRequest) (*types.Empty, error)
+type previewFileFunc func(context.Context, *pfs.PreviewFileRequest) (*pfs.PreviewFileResponse, error)
+type createProjectFunc func(context.Context, *pfs.CreateProjectRequest) (*types.Empty, error)
+type inspectProjectFunc func(context.Context, *pfs.InspectProjectRequest) (*pfs.ProjectInfo, error)
+type listProjectFunc func(*pfs.ListProjectRequest, pfs.API_ListProjectServer) error
+type deleteProjectFunc func(context.Context, *pfs.DeleteProjectRequest) (*types.Empty, error)
 type runLoadTestFunc func(context.Context, *pfs.RunLoadTestRequest) (*pfs.RunLoadTestResponse, error)
 type runLoadTestDefaultFunc func(context.Context, *types.Empty) (*pfs.RunLoadTestResponse, error)
 type listTaskPFSFunc func(*task.ListTaskRequest, pfs.API_ListTaskServer) error
@@ -468,6 +760,8 @@ type mockCreateRepo struct{ handler createRepoFunc }
 type mockInspectRepo struct{ handler inspectRepoFunc }
 type mockListRepo struct{ handler listRepoFunc }
 type mockDeleteRepo struct{ handler deleteRepoFunc }
+type mockCordonRepo struct{ handler cordonRepoFunc }
+type mockUncordonRepo struct{ handler uncordonRepoFunc }
 type mockStartCommit struct{ handler startCommitFunc }
 type mockFinishCommit struct{ handler finishCommitFunc }
 type mockInspectCommit struct{ handler inspectCommitFunc }
@@ -478,6 +772,8 @@ type mockInspectCommitSet struct{ handler inspectCommitSetFunc }
 type mockListCommitSet struct{ handler listCommitSetFunc }
 type mockSubscribeCommit struct{ handler subscribeCommitFunc }
 type mockClearCommit struct{ handler clearCommitFunc }
+type mockCheckpointCommit struct{ handler checkpointCommitFunc }
+type mockSubscribeCheckpoint struct{ handler subscribeCheckpointFunc }
 type mockCreateBranch struct{ handler createBranchFunc }
 type mockInspectBranch struct{ handler inspectBranchFunc }
 type mockListBranch struct{ handler listBranchFunc }
@@ -501,102 +797,197 @@ type mockCheckStorage struct{ handler checkStorageFunc }
 type mockPutCache struct{ handler putCacheFunc }
 type mockGetCache struct{ handler getCacheFunc }
 type mockClearCache struct{ handler clearCacheFunc }
+type mockInspectCache struct{ handler inspectCacheFunc }
+type mockSetSchema struct{ handler setSchemaFunc }
+type mockListSchema struct{ handler listSchemaFunc }
+type mockDeleteSchema struct{ handler deleteSchemaFunc }
+type mockInspectCommitSchema struct{ handler inspectCommitSchemaFunc }
+type mockSetRetentionPolicy struct{ handler setRetentionPolicyFunc }
+type mockListRetentionPolicy struct{ handler listRetentionPolicyFunc }
+type mockDeleteRetentionPolicy struct{ handler deleteRetentionPolicyFunc }
+type mockApplyRetentionPolicy struct{ handler applyRetentionPolicyFunc }
+type mockEraseFile struct{ handler eraseFileFunc }
+type mockListErasure struct{ handler listErasureFunc }
+type mockVerifyErasure struct{ handler verifyErasureFunc }
+type mockSetValidationPolicy struct{ handler setValidationPolicyFunc }
+type mockInspectValidationPolicy struct{ handler inspectValidationPolicyFunc }
+type mockDeleteValidationPolicy struct{ handler deleteValidationPolicyFunc }
+type mockCreateView struct{ handler createViewFunc }
+type mockInspectView struct{ handler inspectViewFunc }
+type mockListView struct{ handler listViewFunc }
+type mockDeleteView struct{ handler deleteViewFunc }
+type mockStartUpload struct{ handler startUploadFunc }
+type mockUploadBlock struct{ handler uploadBlockFunc }
+type mockInspectUpload struct{ handler inspectUploadFunc }
+type mockFinishUpload struct{ handler finishUploadFunc }
+type mockDeleteUpload struct{ handler deleteUploadFunc }
+type mockPreviewFile struct{ handler previewFileFunc }
+type mockCreateProject struct{ handler createProjectFunc }
+type mockInspectProject struct{ handler inspectProjectFunc }
+type mockListProject struct{ handler listProjectFunc }
+type mockDeleteProject struct{ handler deleteProjectFunc }
 type mockRunLoadTest struct{ handler runLoadTestFunc }
 type mockRunLoadTestDefault struct{ handler runLoadTestDefaultFunc }
 type mockListTaskPFS struct{ handler listTaskPFSFunc }
 type mockEgress struct{ handler egressFunc }
 
-func (mock *mockActivateAuthPFS) Use(cb activateAuthPFSFunc)       { mock.handler = cb }
-func (mock *mockCreateRepo) Use(cb createRepoFunc)                 { mock.handler = cb }
-func (mock *mockInspectRepo) Use(cb inspectRepoFunc)               { mock.handler = cb }
-func (mock *mockListRepo) Use(cb listRepoFunc)                     { mock.handler = cb }
-func (mock *mockDeleteRepo) Use(cb deleteRepoFunc)                 { mock.handler = cb }
-func (mock *mockStartCommit) Use(cb startCommitFunc)               { mock.handler = cb }
-func (mock *mockFinishCommit) Use(cb finishCommitFunc)             { mock.handler = cb }
-func (mock *mockInspectCommit) Use(cb inspectCommitFunc)           { mock.handler = cb }
-func (mock *mockListCommit) Use(cb listCommitFunc)                 { mock.handler = cb }
-func (mock *mockSubscribeCommit) Use(cb subscribeCommitFunc)       { mock.handler = cb }
-func (mock *mockClearCommit) Use(cb clearCommitFunc)               { mock.handler = cb }
-func (mock *mockSquashCommitSet) Use(cb squashCommitSetFunc)       { mock.handler = cb }
-func (mock *mockDropCommitSet) Use(cb dropCommitSetFunc)           { mock.handler = cb }
-func (mock *mockInspectCommitSet) Use(cb inspectCommitSetFunc)     { mock.handler = cb }
-func (mock *mockListCommitSet) Use(cb listCommitSetFunc)           { mock.handler = cb }
-func (mock *mockCreateBranch) Use(cb createBranchFunc)             { mock.handler = cb }
-func (mock *mockInspectBranch) Use(cb inspectBranchFunc)           { mock.handler = cb }
-func (mock *mockListBranch) Use(cb listBranchFunc)                 { mock.handler = cb }
-func (mock *mockDeleteBranch) Use(cb deleteBranchFunc)             { mock.handler = cb }
-func (mock *mockModifyFile) Use(cb modifyFileFunc)                 { mock.handler = cb }
-func (mock *mockGetFile) Use(cb getFileFunc)                       { mock.handler = cb }
-func (mock *mockGetFileTAR) Use(cb getFileTARFunc)                 { mock.handler = cb }
-func (mock *mockInspectFile) Use(cb inspectFileFunc)               { mock.handler = cb }
-func (mock *mockListFile) Use(cb listFileFunc)                     { mock.handler = cb }
-func (mock *mockWalkFile) Use(cb walkFileFunc)                     { mock.handler = cb }
-func (mock *mockGlobFile) Use(cb globFileFunc)                     { mock.handler = cb }
-func (mock *mockDiffFile) Use(cb diffFileFunc)                     { mock.handler = cb }
-func (mock *mockDeleteAllPFS) Use(cb deleteAllPFSFunc)             { mock.handler = cb }
-func (mock *mockFsck) Use(cb fsckFunc)                             { mock.handler = cb }
-func (mock *mockCreateFileSet) Use(cb createFileSetFunc)           { mock.handler = cb }
-func (mock *mockAddFileSet) Use(cb addFileSetFunc)                 { mock.handler = cb }
-func (mock *mockGetFileSet) Use(cb getFileSetFunc)                 { mock.handler = cb }
-func (mock *mockRenewFileSet) Use(cb renewFileSetFunc)             { mock.handler = cb }
-func (mock *mockComposeFileSet) Use(cb composeFileSetFunc)         { mock.handler = cb }
-func (mock *mockCheckStorage) Use(cb checkStorageFunc)             { mock.handler = cb }
-func (mock *mockPutCache) Use(cb putCacheFunc)                     { mock.handler = cb }
-func (mock *mockGetCache) Use(cb getCacheFunc)                     { mock.handler = cb }
-func (mock *mockClearCache) Use(cb clearCacheFunc)                 { mock.handler = cb }
-func (mock *mockRunLoadTest) Use(cb runLoadTestFunc)               { mock.handler = cb }
-func (mock *mockRunLoadTestDefault) Use(cb runLoadTestDefaultFunc) { mock.handler = cb }
-func (mock *mockListTaskPFS) Use(cb listTaskPFSFunc)               { mock.handler = cb }
-func (mock *mockEgress) Use(cb egressFunc)                         { mock.handler = cb }
+func (mock *mockActivateAuthPFS) Use(cb activateAuthPFSFunc)                 { mock.handler = cb }
+func (mock *mockCreateRepo) Use(cb createRepoFunc)                           { mock.handler = cb }
+func (mock *mockInspectRepo) Use(cb inspectRepoFunc)                         { mock.handler = cb }
+func (mock *mockListRepo) Use(cb listRepoFunc)                               { mock.handler = cb }
+func (mock *mockDeleteRepo) Use(cb deleteRepoFunc)                           { mock.handler = cb }
+func (mock *mockCordonRepo) Use(cb cordonRepoFunc)                           { mock.handler = cb }
+func (mock *mockUncordonRepo) Use(cb uncordonRepoFunc)                       { mock.handler = cb }
+func (mock *mockStartCommit) Use(cb startCommitFunc)                         { mock.handler = cb }
+func (mock *mockFinishCommit) Use(cb finishCommitFunc)                       { mock.handler = cb }
+func (mock *mockInspectCommit) Use(cb inspectCommitFunc)                     { mock.handler = cb }
+func (mock *mockListCommit) Use(cb listCommitFunc)                           { mock.handler = cb }
+func (mock *mockSubscribeCommit) Use(cb subscribeCommitFunc)                 { mock.handler = cb }
+func (mock *mockClearCommit) Use(cb clearCommitFunc)                         { mock.handler = cb }
+func (mock *mockCheckpointCommit) Use(cb checkpointCommitFunc)               { mock.handler = cb }
+func (mock *mockSubscribeCheckpoint) Use(cb subscribeCheckpointFunc)         { mock.handler = cb }
+func (mock *mockSquashCommitSet) Use(cb squashCommitSetFunc)                 { mock.handler = cb }
+func (mock *mockDropCommitSet) Use(cb dropCommitSetFunc)                     { mock.handler = cb }
+func (mock *mockInspectCommitSet) Use(cb inspectCommitSetFunc)               { mock.handler = cb }
+func (mock *mockListCommitSet) Use(cb listCommitSetFunc)                     { mock.handler = cb }
+func (mock *mockCreateBranch) Use(cb createBranchFunc)                       { mock.handler = cb }
+func (mock *mockInspectBranch) Use(cb inspectBranchFunc)                     { mock.handler = cb }
+func (mock *mockListBranch) Use(cb listBranchFunc)                           { mock.handler = cb }
+func (mock *mockDeleteBranch) Use(cb deleteBranchFunc)                       { mock.handler = cb }
+func (mock *mockModifyFile) Use(cb modifyFileFunc)                           { mock.handler = cb }
+func (mock *mockGetFile) Use(cb getFileFunc)                                 { mock.handler = cb }
+func (mock *mockGetFileTAR) Use(cb getFileTARFunc)                           { mock.handler = cb }
+func (mock *mockInspectFile) Use(cb inspectFileFunc)                         { mock.handler = cb }
+func (mock *mockListFile) Use(cb listFileFunc)                               { mock.handler = cb }
+func (mock *mockWalkFile) Use(cb walkFileFunc)                               { mock.handler = cb }
+func (mock *mockGlobFile) Use(cb globFileFunc)                               { mock.handler = cb }
+func (mock *mockDiffFile) Use(cb diffFileFunc)                               { mock.handler = cb }
+func (mock *mockDeleteAllPFS) Use(cb deleteAllPFSFunc)                       { mock.handler = cb }
+func (mock *mockFsck) Use(cb fsckFunc)                                       { mock.handler = cb }
+func (mock *mockCreateFileSet) Use(cb createFileSetFunc)                     { mock.handler = cb }
+func (mock *mockAddFileSet) Use(cb addFileSetFunc)                           { mock.handler = cb }
+func (mock *mockGetFileSet) Use(cb getFileSetFunc)                           { mock.handler = cb }
+func (mock *mockRenewFileSet) Use(cb renewFileSetFunc)                       { mock.handler = cb }
+func (mock *mockComposeFileSet) Use(cb composeFileSetFunc)                   { mock.handler = cb }
+func (mock *mockCheckStorage) Use(cb checkStorageFunc)                       { mock.handler = cb }
+func (mock *mockPutCache) Use(cb putCacheFunc)                               { mock.handler = cb }
+func (mock *mockGetCache) Use(cb getCacheFunc)                               { mock.handler = cb }
+func (mock *mockClearCache) Use(cb clearCacheFunc)                           { mock.handler = cb }
+func (mock *mockInspectCache) Use(cb inspectCacheFunc)                       { mock.handler = cb }
+func (mock *mockSetSchema) Use(cb setSchemaFunc)                             { mock.handler = cb }
+func (mock *mockListSchema) Use(cb listSchemaFunc)                           { mock.handler = cb }
+func (mock *mockDeleteSchema) Use(cb deleteSchemaFunc)                       { mock.handler = cb }
+func (mock *mockInspectCommitSchema) Use(cb inspectCommitSchemaFunc)         { mock.handler = cb }
+func (mock *mockSetRetentionPolicy) Use(cb setRetentionPolicyFunc)           { mock.handler = cb }
+func (mock *mockListRetentionPolicy) Use(cb listRetentionPolicyFunc)         { mock.handler = cb }
+func (mock *mockDeleteRetentionPolicy) Use(cb deleteRetentionPolicyFunc)     { mock.handler = cb }
+func (mock *mockApplyRetentionPolicy) Use(cb applyRetentionPolicyFunc)       { mock.handler = cb }
+func (mock *mockEraseFile) Use(cb eraseFileFunc)                             { mock.handler = cb }
+func (mock *mockListErasure) Use(cb listErasureFunc)                         { mock.handler = cb }
+func (mock *mockVerifyErasure) Use(cb verifyErasureFunc)                     { mock.handler = cb }
+func (mock *mockSetValidationPolicy) Use(cb setValidationPolicyFunc)         { mock.handler = cb }
+func (mock *mockInspectValidationPolicy) Use(cb inspectValidationPolicyFunc) { mock.handler = cb }
+func (mock *mockDeleteValidationPolicy) Use(cb deleteValidationPolicyFunc)   { mock.handler = cb }
+func (mock *mockCreateView) Use(cb createViewFunc)                           { mock.handler = cb }
+func (mock *mockInspectView) Use(cb inspectViewFunc)                         { mock.handler = cb }
+func (mock *mockListView) Use(cb listViewFunc)                               { mock.handler = cb }
+func (mock *mockDeleteView) Use(cb deleteViewFunc)                           { mock.handler = cb }
+func (mock *mockStartUpload) Use(cb startUploadFunc)                         { mock.handler = cb }
+func (mock *mockUploadBlock) Use(cb uploadBlockFunc)                         { mock.handler = cb }
+func (mock *mockInspectUpload) Use(cb inspectUploadFunc)                     { mock.handler = cb }
+func (mock *mockFinishUpload) Use(cb finishUploadFunc)                       { mock.handler = cb }
+func (mock *mockDeleteUpload) Use(cb deleteUploadFunc)                       { mock.handler = cb }
+func (mock *mockPreviewFile) Use(cb previewFileFunc)                         { mock.handler = cb }
+func (mock *mockCreateProject) Use(cb createProjectFunc)                     { mock.handler = cb }
+func (mock *mockInspectProject) Use(cb inspectProjectFunc)                   { mock.handler = cb }
+func (mock *mockListProject) Use(cb listProjectFunc)                         { mock.handler = cb }
+func (mock *mockDeleteProject) Use(cb deleteProjectFunc)                     { mock.handler = cb }
+func (mock *mockRunLoadTest) Use(cb runLoadTestFunc)                         { mock.handler = cb }
+func (mock *mockRunLoadTestDefault) Use(cb runLoadTestDefaultFunc)           { mock.handler = cb }
+func (mock *mockListTaskPFS) Use(cb listTaskPFSFunc)                         { mock.handler = cb }
+func (mock *mockEgress) Use(cb egressFunc)                                   { mock.handler = cb }
 
 type pfsServerAPI struct {
 	mock *mockPFSServer
 }
 
 type mockPFSServer struct {
-	api                pfsServerAPI
-	ActivateAuth       mockActivateAuthPFS
-	CreateRepo         mockCreateRepo
-	InspectRepo        mockInspectRepo
-	ListRepo           mockListRepo
-	DeleteRepo         mockDeleteRepo
-	StartCommit        mockStartCommit
-	FinishCommit       mockFinishCommit
-	InspectCommit      mockInspectCommit
-	ListCommit         mockListCommit
-	SubscribeCommit    mockSubscribeCommit
-	ClearCommit        mockClearCommit
-	SquashCommitSet    mockSquashCommitSet
-	DropCommitSet      mockDropCommitSet
-	InspectCommitSet   mockInspectCommitSet
-	ListCommitSet      mockListCommitSet
-	CreateBranch       mockCreateBranch
-	InspectBranch      mockInspectBranch
-	ListBranch         mockListBranch
-	DeleteBranch       mockDeleteBranch
-	ModifyFile         mockModifyFile
-	GetFile            mockGetFile
-	GetFileTAR         mockGetFileTAR
-	InspectFile        mockInspectFile
-	ListFile           mockListFile
-	WalkFile           mockWalkFile
-	GlobFile           mockGlobFile
-	DiffFile           mockDiffFile
-	DeleteAll          mockDeleteAllPFS
-	Fsck               mockFsck
-	CreateFileSet      mockCreateFileSet
-	AddFileSet         mockAddFileSet
-	GetFileSet         mockGetFileSet
-	RenewFileSet       mockRenewFileSet
-	ComposeFileSet     mockComposeFileSet
-	CheckStorage       mockCheckStorage
-	PutCache           mockPutCache
-	GetCache           mockGetCache
-	ClearCache         mockClearCache
-	RunLoadTest        mockRunLoadTest
-	RunLoadTestDefault mockRunLoadTestDefault
-	ListTask           mockListTaskPFS
-	Egress             mockEgress
+	api                     pfsServerAPI
+	ActivateAuth            mockActivateAuthPFS
+	CreateRepo              mockCreateRepo
+	InspectRepo             mockInspectRepo
+	ListRepo                mockListRepo
+	DeleteRepo              mockDeleteRepo
+	CordonRepo              mockCordonRepo
+	UncordonRepo            mockUncordonRepo
+	StartCommit             mockStartCommit
+	FinishCommit            mockFinishCommit
+	InspectCommit           mockInspectCommit
+	ListCommit              mockListCommit
+	SubscribeCommit         mockSubscribeCommit
+	ClearCommit             mockClearCommit
+	CheckpointCommit        mockCheckpointCommit
+	SubscribeCheckpoint     mockSubscribeCheckpoint
+	SquashCommitSet         mockSquashCommitSet
+	DropCommitSet           mockDropCommitSet
+	InspectCommitSet        mockInspectCommitSet
+	ListCommitSet           mockListCommitSet
+	CreateBranch            mockCreateBranch
+	InspectBranch           mockInspectBranch
+	ListBranch              mockListBranch
+	DeleteBranch            mockDeleteBranch
+	ModifyFile              mockModifyFile
+	GetFile                 mockGetFile
+	GetFileTAR              mockGetFileTAR
+	InspectFile             mockInspectFile
+	ListFile                mockListFile
+	WalkFile                mockWalkFile
+	GlobFile                mockGlobFile
+	DiffFile                mockDiffFile
+	DeleteAll               mockDeleteAllPFS
+	Fsck                    mockFsck
+	CreateFileSet           mockCreateFileSet
+	AddFileSet              mockAddFileSet
+	GetFileSet              mockGetFileSet
+	RenewFileSet            mockRenewFileSet
+	ComposeFileSet          mockComposeFileSet
+	CheckStorage            mockCheckStorage
+	PutCache                mockPutCache
+	GetCache                mockGetCache
+	ClearCache              mockClearCache
+	InspectCache            mockInspectCache
+	SetSchema               mockSetSchema
+	ListSchema              mockListSchema
+	DeleteSchema            mockDeleteSchema
+	InspectCommitSchema     mockInspectCommitSchema
+	SetRetentionPolicy      mockSetRetentionPolicy
+	ListRetentionPolicy     mockListRetentionPolicy
+	DeleteRetentionPolicy   mockDeleteRetentionPolicy
+	ApplyRetentionPolicy    mockApplyRetentionPolicy
+	EraseFile               mockEraseFile
+	ListErasure             mockListErasure
+	VerifyErasure           mockVerifyErasure
+	SetValidationPolicy     mockSetValidationPolicy
+	InspectValidationPolicy mockInspectValidationPolicy
+	DeleteValidationPolicy  mockDeleteValidationPolicy
+	CreateView              mockCreateView
+	InspectView             mockInspectView
+	ListView                mockListView
+	DeleteView              mockDeleteView
+	StartUpload             mockStartUpload
+	UploadBlock             mockUploadBlock
+	InspectUpload           mockInspectUpload
+	FinishUpload            mockFinishUpload
+	DeleteUpload            mockDeleteUpload
+	PreviewFile             mockPreviewFile
+	CreateProject           mockCreateProject
+	InspectProject          mockInspectProject
+	ListProject             mockListProject
+	DeleteProject           mockDeleteProject
+	RunLoadTest             mockRunLoadTest
+	RunLoadTestDefault      mockRunLoadTestDefault
+	ListTask                mockListTaskPFS
+	Egress                  mockEgress
 }
 
 func (api *pfsServerAPI) ActivateAuth(ctx context.Context, req *pfs.ActivateAuthRequest) (*pfs.ActivateAuthResponse, error) {
@@ -629,6 +1020,18 @@ func (api *pfsServerAPI) DeleteRepo(ctx context.Context, req *pfs.DeleteRepoRequ
 	}
 	return nil, errors.Errorf("unhandled pachd mock pfs.DeleteRepo")
 }
+func (api *pfsServerAPI) CordonRepo(ctx context.Context, req *pfs.CordonRepoRequest) (*types.Empty, error) {
+	if api.mock.CordonRepo.handler != nil {
+		return api.mock.CordonRepo.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.CordonRepo")
+}
+func (api *pfsServerAPI) UncordonRepo(ctx context.Context, req *pfs.UncordonRepoRequest) (*types.Empty, error) {
+	if api.mock.UncordonRepo.handler != nil {
+		return api.mock.UncordonRepo.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.UncordonRepo")
+}
 func (api *pfsServerAPI) StartCommit(ctx context.Context, req *pfs.StartCommitRequest) (*pfs.Commit, error) {
 	if api.mock.StartCommit.handler != nil {
 		return api.mock.StartCommit.handler(ctx, req)
@@ -689,6 +1092,18 @@ func (api *pfsServerAPI) ClearCommit(ctx context.Context, req *pfs.ClearCommitRe
 	}
 	return nil, errors.Errorf("unhandled pachd mock pfs.ClearCommit")
 }
+func (api *pfsServerAPI) CheckpointCommit(ctx context.Context, req *pfs.CheckpointCommitRequest) (*pfs.CheckpointInfo, error) {
+	if api.mock.CheckpointCommit.handler != nil {
+		return api.mock.CheckpointCommit.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.CheckpointCommit")
+}
+func (api *pfsServerAPI) SubscribeCheckpoint(req *pfs.SubscribeCheckpointRequest, serv pfs.API_SubscribeCheckpointServer) error {
+	if api.mock.SubscribeCheckpoint.handler != nil {
+		return api.mock.SubscribeCheckpoint.handler(req, serv)
+	}
+	return errors.Errorf("unhandled pachd mock pfs.SubscribeCheckpoint")
+}
 func (api *pfsServerAPI) CreateBranch(ctx context.Context, req *pfs.CreateBranchRequest) (*types.Empty, error) {
 	if api.mock.CreateBranch.handler != nil {
 		return api.mock.CreateBranch.handler(ctx, req)
@@ -827,6 +1242,180 @@ func (api *pfsServerAPI) ClearCache(ctx context.Context, req *pfs.ClearCacheRequ
 	}
 	return nil, errors.Errorf("unhandled pachd mock ClearCache")
 }
+func (api *pfsServerAPI) InspectCache(ctx context.Context, req *pfs.InspectCacheRequest) (*pfs.InspectCacheResponse, error) {
+	if api.mock.InspectCache.handler != nil {
+		return api.mock.InspectCache.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock InspectCache")
+}
+func (api *pfsServerAPI) SetSchema(ctx context.Context, req *pfs.SetSchemaRequest) (*pfs.SchemaInfo, error) {
+	if api.mock.SetSchema.handler != nil {
+		return api.mock.SetSchema.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.SetSchema")
+}
+func (api *pfsServerAPI) ListSchema(req *pfs.ListSchemaRequest, server pfs.API_ListSchemaServer) error {
+	if api.mock.ListSchema.handler != nil {
+		return api.mock.ListSchema.handler(req, server)
+	}
+	return errors.Errorf("unhandled pachd mock pfs.ListSchema")
+}
+func (api *pfsServerAPI) DeleteSchema(ctx context.Context, req *pfs.DeleteSchemaRequest) (*types.Empty, error) {
+	if api.mock.DeleteSchema.handler != nil {
+		return api.mock.DeleteSchema.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.DeleteSchema")
+}
+func (api *pfsServerAPI) InspectCommitSchema(ctx context.Context, req *pfs.InspectCommitSchemaRequest) (*pfs.CommitSchemaInfo, error) {
+	if api.mock.InspectCommitSchema.handler != nil {
+		return api.mock.InspectCommitSchema.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.InspectCommitSchema")
+}
+func (api *pfsServerAPI) SetRetentionPolicy(ctx context.Context, req *pfs.SetRetentionPolicyRequest) (*pfs.RetentionPolicyInfo, error) {
+	if api.mock.SetRetentionPolicy.handler != nil {
+		return api.mock.SetRetentionPolicy.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.SetRetentionPolicy")
+}
+func (api *pfsServerAPI) ListRetentionPolicy(req *pfs.ListRetentionPolicyRequest, server pfs.API_ListRetentionPolicyServer) error {
+	if api.mock.ListRetentionPolicy.handler != nil {
+		return api.mock.ListRetentionPolicy.handler(req, server)
+	}
+	return errors.Errorf("unhandled pachd mock pfs.ListRetentionPolicy")
+}
+func (api *pfsServerAPI) DeleteRetentionPolicy(ctx context.Context, req *pfs.DeleteRetentionPolicyRequest) (*types.Empty, error) {
+	if api.mock.DeleteRetentionPolicy.handler != nil {
+		return api.mock.DeleteRetentionPolicy.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.DeleteRetentionPolicy")
+}
+func (api *pfsServerAPI) ApplyRetentionPolicy(ctx context.Context, req *pfs.ApplyRetentionPolicyRequest) (*pfs.ApplyRetentionPolicyResponse, error) {
+	if api.mock.ApplyRetentionPolicy.handler != nil {
+		return api.mock.ApplyRetentionPolicy.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.ApplyRetentionPolicy")
+}
+func (api *pfsServerAPI) EraseFile(ctx context.Context, req *pfs.EraseFileRequest) (*pfs.ErasureInfo, error) {
+	if api.mock.EraseFile.handler != nil {
+		return api.mock.EraseFile.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.EraseFile")
+}
+func (api *pfsServerAPI) ListErasure(req *pfs.ListErasureRequest, server pfs.API_ListErasureServer) error {
+	if api.mock.ListErasure.handler != nil {
+		return api.mock.ListErasure.handler(req, server)
+	}
+	return errors.Errorf("unhandled pachd mock pfs.ListErasure")
+}
+func (api *pfsServerAPI) VerifyErasure(ctx context.Context, req *pfs.VerifyErasureRequest) (*pfs.VerifyErasureResponse, error) {
+	if api.mock.VerifyErasure.handler != nil {
+		return api.mock.VerifyErasure.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.VerifyErasure")
+}
+func (api *pfsServerAPI) SetValidationPolicy(ctx context.Context, req *pfs.SetValidationPolicyRequest) (*pfs.ValidationPolicyInfo, error) {
+	if api.mock.SetValidationPolicy.handler != nil {
+		return api.mock.SetValidationPolicy.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.SetValidationPolicy")
+}
+func (api *pfsServerAPI) InspectValidationPolicy(ctx context.Context, req *pfs.InspectValidationPolicyRequest) (*pfs.ValidationPolicyInfo, error) {
+	if api.mock.InspectValidationPolicy.handler != nil {
+		return api.mock.InspectValidationPolicy.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.InspectValidationPolicy")
+}
+func (api *pfsServerAPI) DeleteValidationPolicy(ctx context.Context, req *pfs.DeleteValidationPolicyRequest) (*types.Empty, error) {
+	if api.mock.DeleteValidationPolicy.handler != nil {
+		return api.mock.DeleteValidationPolicy.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.DeleteValidationPolicy")
+}
+func (api *pfsServerAPI) CreateView(ctx context.Context, req *pfs.CreateViewRequest) (*pfs.ViewInfo, error) {
+	if api.mock.CreateView.handler != nil {
+		return api.mock.CreateView.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.CreateView")
+}
+func (api *pfsServerAPI) InspectView(ctx context.Context, req *pfs.InspectViewRequest) (*pfs.ViewInfo, error) {
+	if api.mock.InspectView.handler != nil {
+		return api.mock.InspectView.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.InspectView")
+}
+func (api *pfsServerAPI) ListView(req *pfs.ListViewRequest, server pfs.API_ListViewServer) error {
+	if api.mock.ListView.handler != nil {
+		return api.mock.ListView.handler(req, server)
+	}
+	return errors.Errorf("unhandled pachd mock pfs.ListView")
+}
+func (api *pfsServerAPI) DeleteView(ctx context.Context, req *pfs.DeleteViewRequest) (*types.Empty, error) {
+	if api.mock.DeleteView.handler != nil {
+		return api.mock.DeleteView.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.DeleteView")
+}
+func (api *pfsServerAPI) StartUpload(ctx context.Context, req *pfs.StartUploadRequest) (*pfs.UploadSession, error) {
+	if api.mock.StartUpload.handler != nil {
+		return api.mock.StartUpload.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.StartUpload")
+}
+func (api *pfsServerAPI) UploadBlock(ctx context.Context, req *pfs.UploadBlockRequest) (*types.Empty, error) {
+	if api.mock.UploadBlock.handler != nil {
+		return api.mock.UploadBlock.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.UploadBlock")
+}
+func (api *pfsServerAPI) InspectUpload(ctx context.Context, req *pfs.InspectUploadRequest) (*pfs.UploadSession, error) {
+	if api.mock.InspectUpload.handler != nil {
+		return api.mock.InspectUpload.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.InspectUpload")
+}
+func (api *pfsServerAPI) FinishUpload(ctx context.Context, req *pfs.FinishUploadRequest) (*types.Empty, error) {
+	if api.mock.FinishUpload.handler != nil {
+		return api.mock.FinishUpload.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.FinishUpload")
+}
+func (api *pfsServerAPI) DeleteUpload(ctx context.Context, req *pfs.DeleteUploadRequest) (*types.Empty, error) {
+	if api.mock.DeleteUpload.handler != nil {
+		return api.mock.DeleteUpload.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.DeleteUpload")
+}
+func (api *pfsServerAPI) PreviewFile(ctx context.Context, req *pfs.PreviewFileRequest) (*pfs.PreviewFileResponse, error) {
+	if api.mock.PreviewFile.handler != nil {
+		return api.mock.PreviewFile.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.PreviewFile")
+}
+func (api *pfsServerAPI) CreateProject(ctx context.Context, req *pfs.CreateProjectRequest) (*types.Empty, error) {
+	if api.mock.CreateProject.handler != nil {
+		return api.mock.CreateProject.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.CreateProject")
+}
+func (api *pfsServerAPI) InspectProject(ctx context.Context, req *pfs.InspectProjectRequest) (*pfs.ProjectInfo, error) {
+	if api.mock.InspectProject.handler != nil {
+		return api.mock.InspectProject.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.InspectProject")
+}
+func (api *pfsServerAPI) ListProject(req *pfs.ListProjectRequest, server pfs.API_ListProjectServer) error {
+	if api.mock.ListProject.handler != nil {
+		return api.mock.ListProject.handler(req, server)
+	}
+	return errors.Errorf("unhandled pachd mock pfs.ListProject")
+}
+func (api *pfsServerAPI) DeleteProject(ctx context.Context, req *pfs.DeleteProjectRequest) (*types.Empty, error) {
+	if api.mock.DeleteProject.handler != nil {
+		return api.mock.DeleteProject.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.DeleteProject")
+}
 func (api *pfsServerAPI) RunLoadTest(ctx context.Context, req *pfs.RunLoadTestRequest) (*pfs.RunLoadTestResponse, error) {
 	if api.mock.RunLoadTest.handler != nil {
 		return api.mock.RunLoadTest.handler(ctx, req)
@@ -865,20 +1454,36 @@ type listJobSetFunc func(*pps.ListJobSetRequest, pps.API_ListJobSetServer) error
 type inspectDatumFunc func(context.Context, *pps.InspectDatumRequest) (*pps.DatumInfo, error)
 type listDatumFunc func(*pps.ListDatumRequest, pps.API_ListDatumServer) error
 type restartDatumFunc func(context.Context, *pps.RestartDatumRequest) (*types.Empty, error)
+type countDatumsFunc func(context.Context, *pps.CountDatumsRequest) (*pps.DatumCounts, error)
+type listQuarantinedDatumFunc func(*pps.ListQuarantinedDatumRequest, pps.API_ListQuarantinedDatumServer) error
+type requeueQuarantinedDatumsFunc func(context.Context, *pps.RequeueQuarantinedDatumsRequest) (*pps.RequeueQuarantinedDatumsResponse, error)
+type inspectDatumCacheFunc func(context.Context, *pps.InspectDatumCacheRequest) (*pps.DatumCacheInfo, error)
+type inspectJobProfileFunc func(context.Context, *pps.InspectJobProfileRequest) (*pps.JobProfile, error)
+type clearDatumCacheFunc func(context.Context, *pps.ClearDatumCacheRequest) (*types.Empty, error)
+type listDatumProvenanceFunc func(*pps.ListDatumProvenanceRequest, pps.API_ListDatumProvenanceServer) error
+type queryLineageFunc func(context.Context, *pps.QueryLineageRequest) (*pps.Lineage, error)
 type createPipelineFunc func(context.Context, *pps.CreatePipelineRequest) (*types.Empty, error)
+type planPipelineFunc func(context.Context, *pps.PlanPipelineRequest) (*pps.PipelinePlan, error)
+type lintPipelineFunc func(context.Context, *pps.LintPipelineRequest) (*pps.LintPipelineResponse, error)
+type finishCanaryFunc func(context.Context, *pps.FinishCanaryRequest) (*types.Empty, error)
+type listSLOStatusFunc func(context.Context, *pps.ListSLOStatusRequest) (*pps.ListSLOStatusResponse, error)
 type inspectPipelineFunc func(context.Context, *pps.InspectPipelineRequest) (*pps.PipelineInfo, error)
 type listPipelineFunc func(*pps.ListPipelineRequest, pps.API_ListPipelineServer) error
+type getDAGFunc func(context.Context, *pps.GetDAGRequest) (*pps.DAG, error)
 type deletePipelineFunc func(context.Context, *pps.DeletePipelineRequest) (*types.Empty, error)
 type startPipelineFunc func(context.Context, *pps.StartPipelineRequest) (*types.Empty, error)
 type stopPipelineFunc func(context.Context, *pps.StopPipelineRequest) (*types.Empty, error)
+type cordonPipelineFunc func(context.Context, *pps.CordonPipelineRequest) (*types.Empty, error)
+type uncordonPipelineFunc func(context.Context, *pps.UncordonPipelineRequest) (*types.Empty, error)
 type runPipelineFunc func(context.Context, *pps.RunPipelineRequest) (*types.Empty, error)
 type runCronFunc func(context.Context, *pps.RunCronRequest) (*types.Empty, error)
 type createSecretFunc func(context.Context, *pps.CreateSecretRequest) (*types.Empty, error)
 type deleteSecretFunc func(context.Context, *pps.DeleteSecretRequest) (*types.Empty, error)
 type inspectSecretFunc func(context.Context, *pps.InspectSecretRequest) (*pps.SecretInfo, error)
-type listSecretFunc func(context.Context, *types.Empty) (*pps.SecretInfos, error)
+type listSecretFunc func(context.Context, *pps.ListSecretRequest) (*pps.SecretInfos, error)
 type deleteAllPPSFunc func(context.Context, *types.Empty) (*types.Empty, error)
 type getLogsFunc func(*pps.GetLogsRequest, pps.API_GetLogsServer) error
+type getArchivedLogsFunc func(*pps.GetArchivedLogsRequest, pps.API_GetArchivedLogsServer) error
 type activateAuthPPSFunc func(context.Context, *pps.ActivateAuthRequest) (*pps.ActivateAuthResponse, error)
 type runLoadTestPPSFunc func(context.Context, *pfs.RunLoadTestRequest) (*pfs.RunLoadTestResponse, error)
 type runLoadTestDefaultPPSFunc func(context.Context, *types.Empty) (*pfs.RunLoadTestResponse, error)
@@ -896,12 +1501,27 @@ type mockListJobSet struct{ handler listJobSetFunc }
 type mockInspectDatum struct{ handler inspectDatumFunc }
 type mockListDatum struct{ handler listDatumFunc }
 type mockRestartDatum struct{ handler restartDatumFunc }
+type mockCountDatums struct{ handler countDatumsFunc }
+type mockListQuarantinedDatum struct{ handler listQuarantinedDatumFunc }
+type mockRequeueQuarantinedDatums struct{ handler requeueQuarantinedDatumsFunc }
+type mockInspectDatumCache struct{ handler inspectDatumCacheFunc }
+type mockInspectJobProfile struct{ handler inspectJobProfileFunc }
+type mockClearDatumCache struct{ handler clearDatumCacheFunc }
+type mockListDatumProvenance struct{ handler listDatumProvenanceFunc }
+type mockQueryLineage struct{ handler queryLineageFunc }
 type mockCreatePipeline struct{ handler createPipelineFunc }
+type mockPlanPipeline struct{ handler planPipelineFunc }
+type mockLintPipeline struct{ handler lintPipelineFunc }
+type mockFinishCanary struct{ handler finishCanaryFunc }
+type mockListSLOStatus struct{ handler listSLOStatusFunc }
 type mockInspectPipeline struct{ handler inspectPipelineFunc }
 type mockListPipeline struct{ handler listPipelineFunc }
+type mockGetDAG struct{ handler getDAGFunc }
 type mockDeletePipeline struct{ handler deletePipelineFunc }
 type mockStartPipeline struct{ handler startPipelineFunc }
 type mockStopPipeline struct{ handler stopPipelineFunc }
+type mockCordonPipeline struct{ handler cordonPipelineFunc }
+type mockUncordonPipeline struct{ handler uncordonPipelineFunc }
 type mockRunPipeline struct{ handler runPipelineFunc }
 type mockRunCron struct{ handler runCronFunc }
 type mockCreateSecret struct{ handler createSecretFunc }
@@ -910,79 +1530,112 @@ type mockInspectSecret struct{ handler inspectSecretFunc }
 type mockListSecret struct{ handler listSecretFunc }
 type mockDeleteAllPPS struct{ handler deleteAllPPSFunc }
 type mockGetLogs struct{ handler getLogsFunc }
+type mockGetArchivedLogs struct{ handler getArchivedLogsFunc }
 type mockActivateAuthPPS struct{ handler activateAuthPPSFunc }
 type mockRunLoadTestPPS struct{ handler runLoadTestPPSFunc }
 type mockRunLoadTestDefaultPPS struct{ handler runLoadTestDefaultPPSFunc }
 type mockRenderTemplate struct{ handler renderTemplateFunc }
 type mockListTaskPPS struct{ handler listTaskPPSFunc }
 
-func (mock *mockInspectJob) Use(cb inspectJobFunc)                       { mock.handler = cb }
-func (mock *mockListJob) Use(cb listJobFunc)                             { mock.handler = cb }
-func (mock *mockSubscribeJob) Use(cb subscribeJobFunc)                   { mock.handler = cb }
-func (mock *mockDeleteJob) Use(cb deleteJobFunc)                         { mock.handler = cb }
-func (mock *mockStopJob) Use(cb stopJobFunc)                             { mock.handler = cb }
-func (mock *mockUpdateJobState) Use(cb updateJobStateFunc)               { mock.handler = cb }
-func (mock *mockInspectJobSet) Use(cb inspectJobSetFunc)                 { mock.handler = cb }
-func (mock *mockListJobSet) Use(cb listJobSetFunc)                       { mock.handler = cb }
-func (mock *mockInspectDatum) Use(cb inspectDatumFunc)                   { mock.handler = cb }
-func (mock *mockListDatum) Use(cb listDatumFunc)                         { mock.handler = cb }
-func (mock *mockRestartDatum) Use(cb restartDatumFunc)                   { mock.handler = cb }
-func (mock *mockCreatePipeline) Use(cb createPipelineFunc)               { mock.handler = cb }
-func (mock *mockInspectPipeline) Use(cb inspectPipelineFunc)             { mock.handler = cb }
-func (mock *mockListPipeline) Use(cb listPipelineFunc)                   { mock.handler = cb }
-func (mock *mockDeletePipeline) Use(cb deletePipelineFunc)               { mock.handler = cb }
-func (mock *mockStartPipeline) Use(cb startPipelineFunc)                 { mock.handler = cb }
-func (mock *mockStopPipeline) Use(cb stopPipelineFunc)                   { mock.handler = cb }
-func (mock *mockRunPipeline) Use(cb runPipelineFunc)                     { mock.handler = cb }
-func (mock *mockRunCron) Use(cb runCronFunc)                             { mock.handler = cb }
-func (mock *mockCreateSecret) Use(cb createSecretFunc)                   { mock.handler = cb }
-func (mock *mockDeleteSecret) Use(cb deleteSecretFunc)                   { mock.handler = cb }
-func (mock *mockInspectSecret) Use(cb inspectSecretFunc)                 { mock.handler = cb }
-func (mock *mockListSecret) Use(cb listSecretFunc)                       { mock.handler = cb }
-func (mock *mockDeleteAllPPS) Use(cb deleteAllPPSFunc)                   { mock.handler = cb }
-func (mock *mockGetLogs) Use(cb getLogsFunc)                             { mock.handler = cb }
-func (mock *mockActivateAuthPPS) Use(cb activateAuthPPSFunc)             { mock.handler = cb }
-func (mock *mockRunLoadTestPPS) Use(cb runLoadTestPPSFunc)               { mock.handler = cb }
-func (mock *mockRunLoadTestDefaultPPS) Use(cb runLoadTestDefaultPPSFunc) { mock.handler = cb }
-func (mock *mockRenderTemplate) Use(cb renderTemplateFunc)               { mock.handler = cb }
-func (mock *mockListTaskPPS) Use(cb listTaskPPSFunc)                     { mock.handler = cb }
+func (mock *mockInspectJob) Use(cb inspectJobFunc)                             { mock.handler = cb }
+func (mock *mockListJob) Use(cb listJobFunc)                                   { mock.handler = cb }
+func (mock *mockSubscribeJob) Use(cb subscribeJobFunc)                         { mock.handler = cb }
+func (mock *mockDeleteJob) Use(cb deleteJobFunc)                               { mock.handler = cb }
+func (mock *mockStopJob) Use(cb stopJobFunc)                                   { mock.handler = cb }
+func (mock *mockUpdateJobState) Use(cb updateJobStateFunc)                     { mock.handler = cb }
+func (mock *mockInspectJobSet) Use(cb inspectJobSetFunc)                       { mock.handler = cb }
+func (mock *mockListJobSet) Use(cb listJobSetFunc)                             { mock.handler = cb }
+func (mock *mockInspectDatum) Use(cb inspectDatumFunc)                         { mock.handler = cb }
+func (mock *mockListDatum) Use(cb listDatumFunc)                               { mock.handler = cb }
+func (mock *mockRestartDatum) Use(cb restartDatumFunc)                         { mock.handler = cb }
+func (mock *mockCountDatums) Use(cb countDatumsFunc)                           { mock.handler = cb }
+func (mock *mockListQuarantinedDatum) Use(cb listQuarantinedDatumFunc)         { mock.handler = cb }
+func (mock *mockRequeueQuarantinedDatums) Use(cb requeueQuarantinedDatumsFunc) { mock.handler = cb }
+func (mock *mockInspectDatumCache) Use(cb inspectDatumCacheFunc)               { mock.handler = cb }
+func (mock *mockInspectJobProfile) Use(cb inspectJobProfileFunc)               { mock.handler = cb }
+func (mock *mockClearDatumCache) Use(cb clearDatumCacheFunc)                   { mock.handler = cb }
+func (mock *mockListDatumProvenance) Use(cb listDatumProvenanceFunc)           { mock.handler = cb }
+func (mock *mockQueryLineage) Use(cb queryLineageFunc)                         { mock.handler = cb }
+func (mock *mockCreatePipeline) Use(cb createPipelineFunc)                     { mock.handler = cb }
+func (mock *mockPlanPipeline) Use(cb planPipelineFunc)                         { mock.handler = cb }
+func (mock *mockLintPipeline) Use(cb lintPipelineFunc)                         { mock.handler = cb }
+func (mock *mockFinishCanary) Use(cb finishCanaryFunc)                         { mock.handler = cb }
+func (mock *mockListSLOStatus) Use(cb listSLOStatusFunc)                       { mock.handler = cb }
+func (mock *mockInspectPipeline) Use(cb inspectPipelineFunc)                   { mock.handler = cb }
+func (mock *mockListPipeline) Use(cb listPipelineFunc)                         { mock.handler = cb }
+func (mock *mockGetDAG) Use(cb getDAGFunc)                                     { mock.handler = cb }
+func (mock *mockDeletePipeline) Use(cb deletePipelineFunc)                     { mock.handler = cb }
+func (mock *mockStartPipeline) Use(cb startPipelineFunc)                       { mock.handler = cb }
+func (mock *mockStopPipeline) Use(cb stopPipelineFunc)                         { mock.handler = cb }
+func (mock *mockCordonPipeline) Use(cb cordonPipelineFunc)                     { mock.handler = cb }
+func (mock *mockUncordonPipeline) Use(cb uncordonPipelineFunc)                 { mock.handler = cb }
+func (mock *mockRunPipeline) Use(cb runPipelineFunc)                           { mock.handler = cb }
+func (mock *mockRunCron) Use(cb runCronFunc)                                   { mock.handler = cb }
+func (mock *mockCreateSecret) Use(cb createSecretFunc)                         { mock.handler = cb }
+func (mock *mockDeleteSecret) Use(cb deleteSecretFunc)                         { mock.handler = cb }
+func (mock *mockInspectSecret) Use(cb inspectSecretFunc)                       { mock.handler = cb }
+func (mock *mockListSecret) Use(cb listSecretFunc)                             { mock.handler = cb }
+func (mock *mockDeleteAllPPS) Use(cb deleteAllPPSFunc)                         { mock.handler = cb }
+func (mock *mockGetLogs) Use(cb getLogsFunc)                                   { mock.handler = cb }
+func (mock *mockGetArchivedLogs) Use(cb getArchivedLogsFunc)                   { mock.handler = cb }
+func (mock *mockActivateAuthPPS) Use(cb activateAuthPPSFunc)                   { mock.handler = cb }
+func (mock *mockRunLoadTestPPS) Use(cb runLoadTestPPSFunc)                     { mock.handler = cb }
+func (mock *mockRunLoadTestDefaultPPS) Use(cb runLoadTestDefaultPPSFunc)       { mock.handler = cb }
+func (mock *mockRenderTemplate) Use(cb renderTemplateFunc)                     { mock.handler = cb }
+func (mock *mockListTaskPPS) Use(cb listTaskPPSFunc)                           { mock.handler = cb }
 
 type ppsServerAPI struct {
 	mock *mockPPSServer
 }
 
 type mockPPSServer struct {
-	api                ppsServerAPI
-	InspectJob         mockInspectJob
-	ListJob            mockListJob
-	SubscribeJob       mockSubscribeJob
-	DeleteJob          mockDeleteJob
-	StopJob            mockStopJob
-	UpdateJobState     mockUpdateJobState
-	InspectJobSet      mockInspectJobSet
-	ListJobSet         mockListJobSet
-	InspectDatum       mockInspectDatum
-	ListDatum          mockListDatum
-	RestartDatum       mockRestartDatum
-	CreatePipeline     mockCreatePipeline
-	InspectPipeline    mockInspectPipeline
-	ListPipeline       mockListPipeline
-	DeletePipeline     mockDeletePipeline
-	StartPipeline      mockStartPipeline
-	StopPipeline       mockStopPipeline
-	RunPipeline        mockRunPipeline
-	RunCron            mockRunCron
-	CreateSecret       mockCreateSecret
-	DeleteSecret       mockDeleteSecret
-	InspectSecret      mockInspectSecret
-	ListSecret         mockListSecret
-	DeleteAll          mockDeleteAllPPS
-	GetLogs            mockGetLogs
-	ActivateAuth       mockActivateAuthPPS
-	RunLoadTest        mockRunLoadTestPPS
-	RunLoadTestDefault mockRunLoadTestDefaultPPS
-	RenderTemplate     mockRenderTemplate
-	ListTask           mockListTaskPPS
+	api                      ppsServerAPI
+	InspectJob               mockInspectJob
+	ListJob                  mockListJob
+	SubscribeJob             mockSubscribeJob
+	DeleteJob                mockDeleteJob
+	StopJob                  mockStopJob
+	UpdateJobState           mockUpdateJobState
+	InspectJobSet            mockInspectJobSet
+	ListJobSet               mockListJobSet
+	InspectDatum             mockInspectDatum
+	ListDatum                mockListDatum
+	RestartDatum             mockRestartDatum
+	CountDatums              mockCountDatums
+	ListQuarantinedDatum     mockListQuarantinedDatum
+	RequeueQuarantinedDatums mockRequeueQuarantinedDatums
+	InspectDatumCache        mockInspectDatumCache
+	InspectJobProfile        mockInspectJobProfile
+	ClearDatumCache          mockClearDatumCache
+	ListDatumProvenance      mockListDatumProvenance
+	QueryLineage             mockQueryLineage
+	CreatePipeline           mockCreatePipeline
+	PlanPipeline             mockPlanPipeline
+	LintPipeline             mockLintPipeline
+	FinishCanary             mockFinishCanary
+	ListSLOStatus            mockListSLOStatus
+	InspectPipeline          mockInspectPipeline
+	ListPipeline             mockListPipeline
+	GetDAG                   mockGetDAG
+	DeletePipeline           mockDeletePipeline
+	StartPipeline            mockStartPipeline
+	StopPipeline             mockStopPipeline
+	CordonPipeline           mockCordonPipeline
+	UncordonPipeline         mockUncordonPipeline
+	RunPipeline              mockRunPipeline
+	RunCron                  mockRunCron
+	CreateSecret             mockCreateSecret
+	DeleteSecret             mockDeleteSecret
+	InspectSecret            mockInspectSecret
+	ListSecret               mockListSecret
+	DeleteAll                mockDeleteAllPPS
+	GetLogs                  mockGetLogs
+	GetArchivedLogs          mockGetArchivedLogs
+	ActivateAuth             mockActivateAuthPPS
+	RunLoadTest              mockRunLoadTestPPS
+	RunLoadTestDefault       mockRunLoadTestDefaultPPS
+	RenderTemplate           mockRenderTemplate
+	ListTask                 mockListTaskPPS
 }
 
 func (api *ppsServerAPI) InspectJob(ctx context.Context, req *pps.InspectJobRequest) (*pps.JobInfo, error) {
@@ -1051,12 +1704,84 @@ func (api *ppsServerAPI) RestartDatum(ctx context.Context, req *pps.RestartDatum
 	}
 	return nil, errors.Errorf("unhandled pachd mock pps.RestartDatum")
 }
+func (api *ppsServerAPI) CountDatums(ctx context.Context, req *pps.CountDatumsRequest) (*pps.DatumCounts, error) {
+	if api.mock.CountDatums.handler != nil {
+		return api.mock.CountDatums.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.CountDatums")
+}
+func (api *ppsServerAPI) ListQuarantinedDatum(req *pps.ListQuarantinedDatumRequest, serv pps.API_ListQuarantinedDatumServer) error {
+	if api.mock.ListQuarantinedDatum.handler != nil {
+		return api.mock.ListQuarantinedDatum.handler(req, serv)
+	}
+	return errors.Errorf("unhandled pachd mock pps.ListQuarantinedDatum")
+}
+func (api *ppsServerAPI) RequeueQuarantinedDatums(ctx context.Context, req *pps.RequeueQuarantinedDatumsRequest) (*pps.RequeueQuarantinedDatumsResponse, error) {
+	if api.mock.RequeueQuarantinedDatums.handler != nil {
+		return api.mock.RequeueQuarantinedDatums.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.RequeueQuarantinedDatums")
+}
+func (api *ppsServerAPI) InspectDatumCache(ctx context.Context, req *pps.InspectDatumCacheRequest) (*pps.DatumCacheInfo, error) {
+	if api.mock.InspectDatumCache.handler != nil {
+		return api.mock.InspectDatumCache.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.InspectDatumCache")
+}
+func (api *ppsServerAPI) InspectJobProfile(ctx context.Context, req *pps.InspectJobProfileRequest) (*pps.JobProfile, error) {
+	if api.mock.InspectJobProfile.handler != nil {
+		return api.mock.InspectJobProfile.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.InspectJobProfile")
+}
+func (api *ppsServerAPI) ClearDatumCache(ctx context.Context, req *pps.ClearDatumCacheRequest) (*types.Empty, error) {
+	if api.mock.ClearDatumCache.handler != nil {
+		return api.mock.ClearDatumCache.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.ClearDatumCache")
+}
+func (api *ppsServerAPI) ListDatumProvenance(req *pps.ListDatumProvenanceRequest, serv pps.API_ListDatumProvenanceServer) error {
+	if api.mock.ListDatumProvenance.handler != nil {
+		return api.mock.ListDatumProvenance.handler(req, serv)
+	}
+	return errors.Errorf("unhandled pachd mock pps.ListDatumProvenance")
+}
+func (api *ppsServerAPI) QueryLineage(ctx context.Context, req *pps.QueryLineageRequest) (*pps.Lineage, error) {
+	if api.mock.QueryLineage.handler != nil {
+		return api.mock.QueryLineage.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.QueryLineage")
+}
 func (api *ppsServerAPI) CreatePipeline(ctx context.Context, req *pps.CreatePipelineRequest) (*types.Empty, error) {
 	if api.mock.CreatePipeline.handler != nil {
 		return api.mock.CreatePipeline.handler(ctx, req)
 	}
 	return nil, errors.Errorf("unhandled pachd mock pps.CreatePipeline")
 }
+func (api *ppsServerAPI) PlanPipeline(ctx context.Context, req *pps.PlanPipelineRequest) (*pps.PipelinePlan, error) {
+	if api.mock.PlanPipeline.handler != nil {
+		return api.mock.PlanPipeline.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.PlanPipeline")
+}
+func (api *ppsServerAPI) LintPipeline(ctx context.Context, req *pps.LintPipelineRequest) (*pps.LintPipelineResponse, error) {
+	if api.mock.LintPipeline.handler != nil {
+		return api.mock.LintPipeline.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.LintPipeline")
+}
+func (api *ppsServerAPI) FinishCanary(ctx context.Context, req *pps.FinishCanaryRequest) (*types.Empty, error) {
+	if api.mock.FinishCanary.handler != nil {
+		return api.mock.FinishCanary.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.FinishCanary")
+}
+func (api *ppsServerAPI) ListSLOStatus(ctx context.Context, req *pps.ListSLOStatusRequest) (*pps.ListSLOStatusResponse, error) {
+	if api.mock.ListSLOStatus.handler != nil {
+		return api.mock.ListSLOStatus.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.ListSLOStatus")
+}
 func (api *ppsServerAPI) InspectPipeline(ctx context.Context, req *pps.InspectPipelineRequest) (*pps.PipelineInfo, error) {
 	if api.mock.InspectPipeline.handler != nil {
 		return api.mock.InspectPipeline.handler(ctx, req)
@@ -1069,6 +1794,12 @@ func (api *ppsServerAPI) ListPipeline(req *pps.ListPipelineRequest, srv pps.API_
 	}
 	return errors.Errorf("unhandled pachd mock pps.ListPipeline")
 }
+func (api *ppsServerAPI) GetDAG(ctx context.Context, req *pps.GetDAGRequest) (*pps.DAG, error) {
+	if api.mock.GetDAG.handler != nil {
+		return api.mock.GetDAG.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.GetDAG")
+}
 func (api *ppsServerAPI) DeletePipeline(ctx context.Context, req *pps.DeletePipelineRequest) (*types.Empty, error) {
 	if api.mock.DeletePipeline.handler != nil {
 		return api.mock.DeletePipeline.handler(ctx, req)
@@ -1087,6 +1818,18 @@ func (api *ppsServerAPI) StopPipeline(ctx context.Context, req *pps.StopPipeline
 	}
 	return nil, errors.Errorf("unhandled pachd mock pps.StopPipeline")
 }
+func (api *ppsServerAPI) CordonPipeline(ctx context.Context, req *pps.CordonPipelineRequest) (*types.Empty, error) {
+	if api.mock.CordonPipeline.handler != nil {
+		return api.mock.CordonPipeline.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.CordonPipeline")
+}
+func (api *ppsServerAPI) UncordonPipeline(ctx context.Context, req *pps.UncordonPipelineRequest) (*types.Empty, error) {
+	if api.mock.UncordonPipeline.handler != nil {
+		return api.mock.UncordonPipeline.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.UncordonPipeline")
+}
 func (api *ppsServerAPI) RunPipeline(ctx context.Context, req *pps.RunPipelineRequest) (*types.Empty, error) {
 	if api.mock.RunPipeline.handler != nil {
 		return api.mock.RunPipeline.handler(ctx, req)
@@ -1117,9 +1860,9 @@ func (api *ppsServerAPI) InspectSecret(ctx context.Context, req *pps.InspectSecr
 	}
 	return nil, errors.Errorf("unhandled pachd mock pps.InspectSecret")
 }
-func (api *ppsServerAPI) ListSecret(ctx context.Context, in *types.Empty) (*pps.SecretInfos, error) {
+func (api *ppsServerAPI) ListSecret(ctx context.Context, req *pps.ListSecretRequest) (*pps.SecretInfos, error) {
 	if api.mock.ListSecret.handler != nil {
-		return api.mock.ListSecret.handler(ctx, in)
+		return api.mock.ListSecret.handler(ctx, req)
 	}
 	return nil, errors.Errorf("unhandled pachd mock pps.ListSecret")
 }
@@ -1135,6 +1878,12 @@ func (api *ppsServerAPI) GetLogs(req *pps.GetLogsRequest, serv pps.API_GetLogsSe
 	}
 	return errors.Errorf("unhandled pachd mock pps.GetLogs")
 }
+func (api *ppsServerAPI) GetArchivedLogs(req *pps.GetArchivedLogsRequest, serv pps.API_GetArchivedLogsServer) error {
+	if api.mock.GetArchivedLogs.handler != nil {
+		return api.mock.GetArchivedLogs.handler(req, serv)
+	}
+	return errors.Errorf("unhandled pachd mock pps.GetArchivedLogs")
+}
 func (api *ppsServerAPI) ActivateAuth(ctx context.Context, req *pps.ActivateAuthRequest) (*pps.ActivateAuthResponse, error) {
 	if api.mock.ActivateAuth.handler != nil {
 		return api.mock.ActivateAuth.handler(ctx, req)
@@ -1298,6 +2047,30 @@ func (api *proxyServerAPI) Listen(req *proxy.ListenRequest, srv proxy.API_Listen
 	return errors.Errorf("unhandled pachd mock proxy.Listen")
 }
 
+/* Search Server Mocks */
+
+type searchFunc func(context.Context, *search.SearchRequest) (*search.SearchResponse, error)
+
+type mockSearch struct{ handler searchFunc }
+
+func (mock *mockSearch) Use(cb searchFunc) { mock.handler = cb }
+
+type searchServerAPI struct {
+	mock *mockSearchServer
+}
+
+type mockSearchServer struct {
+	api    searchServerAPI
+	Search mockSearch
+}
+
+func (api *searchServerAPI) Search(ctx context.Context, req *search.SearchRequest) (*search.SearchResponse, error) {
+	if api.mock.Search.handler != nil {
+		return api.mock.Search.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock search.Search")
+}
+
 // MockPachd provides an interface for running the interface for a Pachd API
 // server locally without any of its dependencies. Tests may mock out specific
 // API calls by providing a handler function, and later check information about
@@ -1316,6 +2089,7 @@ type MockPachd struct {
 	Version     mockVersionServer
 	Admin       mockAdminServer
 	Proxy       mockProxyServer
+	Search      mockSearchServer
 }
 
 // NewMockPachd constructs a mock Pachd API server whose behavior can be
@@ -1336,6 +2110,7 @@ func NewMockPachd(ctx context.Context) (*MockPachd, error) {
 	mock.Version.api.mock = &mock.Version
 	mock.Admin.api.mock = &mock.Admin
 	mock.Proxy.api.mock = &mock.Proxy
+	mock.Search.api.mock = &mock.Search
 
 	loggingInterceptor := loggingmw.NewLoggingInterceptor(logrus.StandardLogger())
 	server, err := grpcutil.NewServer(ctx, false,
@@ -1360,6 +2135,7 @@ func NewMockPachd(ctx context.Context) (*MockPachd, error) {
 	transaction.RegisterAPIServer(server.Server, &mock.Transaction.api)
 	version.RegisterAPIServer(server.Server, &mock.Version.api)
 	proxy.RegisterAPIServer(server.Server, &mock.Proxy.api)
+	search.RegisterAPIServer(server.Server, &mock.Search.api)
 
 	listener, err := server.ListenTCP("localhost", 0)
 	if err != nil {
@@ -67,6 +67,16 @@ func (mock *mockCreatePipelineInTransaction) Use(cb createPipelineInTransactionF
 	mock.handler = cb
 }
 
+type deletePipelineInTransactionFunc func(*txncontext.TransactionContext, *pps.DeletePipelineRequest) error
+
+type mockDeletePipelineInTransaction struct {
+	handler deletePipelineInTransactionFunc
+}
+
+func (mock *mockDeletePipelineInTransaction) Use(cb deletePipelineInTransactionFunc) {
+	mock.handler = cb
+}
+
 type inspectPipelineInTransactionFunc func(*txncontext.TransactionContext, string) (*pps.PipelineInfo, error)
 
 type mockInspectPipelineInTransaction struct {
@@ -92,6 +102,7 @@ type MockPPSTransactionServer struct {
 	StopJobInTransaction         mockStopJobInTransaction
 	UpdateJobStateInTransaction  mockUpdateJobStateInTransaction
 	CreatePipelineInTransaction  mockCreatePipelineInTransaction
+	DeletePipelineInTransaction  mockDeletePipelineInTransaction
 	InspectPipelineInTransaction mockInspectPipelineInTransaction
 }
 
@@ -152,6 +163,13 @@ func (api *ppsTransactionAPI) CreatePipelineInTransaction(txnCtx *txncontext.Tra
 	return errors.Errorf("unhandled pachd mock: pps.CreatePipelineInTransaction")
 }
 
+func (api *ppsTransactionAPI) DeletePipelineInTransaction(txnCtx *txncontext.TransactionContext, req *pps.DeletePipelineRequest) error {
+	if api.mock.DeletePipelineInTransaction.handler != nil {
+		return api.mock.DeletePipelineInTransaction.handler(txnCtx, req)
+	}
+	return errors.Errorf("unhandled pachd mock: pps.DeletePipelineInTransaction")
+}
+
 func (api *ppsTransactionAPI) InspectPipelineInTransaction(txnCtx *txncontext.TransactionContext, pipeline string) (*pps.PipelineInfo, error) {
 	if api.mock.InspectPipelineInTransaction.handler != nil {
 		return api.mock.InspectPipelineInTransaction.handler(txnCtx, pipeline)
@@ -60,6 +60,12 @@ func (l *CertLoader) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, e
 	return cert, nil
 }
 
+// GetClientCertificate gets the currently cached certificate, to present to
+// servers that require a client certificate
+func (l *CertLoader) GetClientCertificate(_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return l.GetCertificate(nil)
+}
+
 func (l *CertLoader) reloadRoutine() {
 	t := time.NewTicker(l.refreshInterval)
 	for {
@@ -0,0 +1,81 @@
+package tls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+const (
+	// PeerVolumePath is the path at which the certificate, private key and CA
+	// that pachd and its workers authenticate each other with (if any) are
+	// mounted in the pachd and worker pods
+	PeerVolumePath = "/pachd-peer-tls"
+
+	// CAFile is the name of the mounted file containing the certificate of the
+	// CA that signs the peer certificates
+	CAFile = "ca.crt"
+
+	// PeerServerName is the name that peer certificates must be valid for.
+	// Workers are dialed by IP address, so their certificates are checked
+	// against this name instead.
+	PeerServerName = "pachd-peer"
+)
+
+// PeerTLSEnabled returns true if a peer certificate is mounted, in which case
+// pachd and its workers talk to each other over mutual TLS.
+func PeerTLSEnabled() bool {
+	_, err := os.Stat(path.Join(PeerVolumePath, CertFile))
+	return err == nil
+}
+
+// NewPeerServerConfig returns the TLS config of a server that only accepts
+// clients with a certificate signed by the peer CA.
+func NewPeerServerConfig() (*tls.Config, error) {
+	cas, loader, err := loadPeerCerts()
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		GetCertificate: loader.GetCertificate,
+		ClientCAs:      cas,
+		ClientAuth:     tls.RequireAndVerifyClientCert,
+		MinVersion:     tls.VersionTLS12,
+	}, nil
+}
+
+// NewPeerClientConfig returns the TLS config of a client that presents the
+// peer certificate, and only accepts servers with a certificate signed by the
+// peer CA.
+func NewPeerClientConfig() (*tls.Config, error) {
+	cas, loader, err := loadPeerCerts()
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		GetClientCertificate: loader.GetClientCertificate,
+		RootCAs:              cas,
+		ServerName:           PeerServerName,
+		MinVersion:           tls.VersionTLS12,
+	}, nil
+}
+
+func loadPeerCerts() (*x509.CertPool, *CertLoader, error) {
+	caPath := path.Join(PeerVolumePath, CAFile)
+	ca, err := os.ReadFile(caPath)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not read peer CA at %s", caPath)
+	}
+	cas := x509.NewCertPool()
+	if !cas.AppendCertsFromPEM(ca) {
+		return nil, nil, errors.Errorf("no certificates found in peer CA at %s", caPath)
+	}
+	loader := NewCertLoader(path.Join(PeerVolumePath, CertFile), path.Join(PeerVolumePath, KeyFile), CertCheckFrequency)
+	if err := loader.LoadAndStart(); err != nil {
+		return nil, nil, err
+	}
+	return cas, loader, nil
+}
@@ -0,0 +1,269 @@
+// Package topicsync ingests the messages of a Kafka topic or NATS subject
+// into a Pachyderm branch, so that pipelines can consume a stream without a
+// custom spout.
+//
+// Messages are committed in batches: a commit is made when a batch reaches
+// its maximum size, or when its oldest message has waited for the batch
+// interval. For Kafka topics, the offset after the last committed message of
+// each partition is kept in the description of the commit as a trailer,
+// which is also how a sync resumes: the offsets recorded on the head of the
+// PFS branch are where the next sync starts consuming, so every message is
+// committed exactly once. NATS subjects have no offsets, so a sync only
+// receives the messages published while it's running.
+package topicsync
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/eventbus"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+const (
+	// OffsetTrailer precedes "<partition>=<offset>" in the description of a
+	// synced commit, once for each partition of a Kafka topic.
+	OffsetTrailer = "kafka-offset: "
+
+	defaultBatchSize     = 1000
+	defaultBatchInterval = 10 * time.Second
+)
+
+// Spec describes a topic and the PFS branch it's synced to.
+type Spec struct {
+	// URL is the topic URL, see eventbus.ParseTopic.
+	URL string
+	// Repo and Branch are the PFS branch the topic is synced to.
+	Repo, Branch string
+	// Path is the directory messages are written to, "" means the root.
+	Path string
+	// File, if set, is a file under Path that messages are appended to, one
+	// per line. Otherwise each message is written to its own file, named
+	// "<partition>/<offset>" for Kafka messages and after the time it was
+	// received for NATS messages.
+	File string
+	// BatchSize is the maximum number of messages in a commit, 1000 if
+	// unset, and BatchInterval the longest a message waits to be
+	// committed, 10 seconds if unset.
+	BatchSize     int
+	BatchInterval time.Duration
+}
+
+// Syncer syncs a topic into a PFS branch.
+type Syncer struct {
+	c     *client.APIClient
+	spec  Spec
+	topic *eventbus.Topic
+}
+
+// NewSyncer creates a new Syncer.
+func NewSyncer(c *client.APIClient, spec Spec) (*Syncer, error) {
+	topic, err := eventbus.ParseTopic(spec.URL)
+	if err != nil {
+		return nil, err
+	}
+	if spec.Branch == "" {
+		spec.Branch = "master"
+	}
+	if spec.BatchSize <= 0 {
+		spec.BatchSize = defaultBatchSize
+	}
+	if spec.BatchInterval <= 0 {
+		spec.BatchInterval = defaultBatchInterval
+	}
+	spec.Path = path.Clean("/" + spec.Path)
+	return &Syncer{c: c, spec: spec, topic: topic}, nil
+}
+
+// Offsets returns the Kafka offsets recorded in a synced commit's
+// description, which is empty if the commit was not created by a sync.
+func Offsets(ci *pfs.CommitInfo) (map[int32]int64, error) {
+	offsets := make(map[int32]int64)
+	scanner := bufio.NewScanner(strings.NewReader(ci.Description))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, OffsetTrailer) {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimSpace(strings.TrimPrefix(line, OffsetTrailer)), "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("malformed offset trailer %q", line)
+		}
+		partition, err := strconv.ParseInt(parts[0], 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "malformed offset trailer %q", line)
+		}
+		offset, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "malformed offset trailer %q", line)
+		}
+		offsets[int32(partition)] = offset
+	}
+	return offsets, nil
+}
+
+// Description returns the PFS commit description for a batch of n messages,
+// after which the topic's partitions are at offsets.
+func Description(topic string, n int, offsets map[int32]int64) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Synced %d message(s) from %s\n", n, topic)
+	if len(offsets) > 0 {
+		b.WriteString("\n")
+		var partitions []int32
+		for partition := range offsets {
+			partitions = append(partitions, partition)
+		}
+		sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+		for _, partition := range partitions {
+			fmt.Fprintf(&b, "%s%d=%d\n", OffsetTrailer, partition, offsets[partition])
+		}
+	}
+	return b.String()
+}
+
+// Run syncs messages until ctx is done or consuming fails. onCommit, if not
+// nil, is called after each commit with the number of messages in it.
+func (s *Syncer) Run(ctx context.Context, onCommit func(commit *pfs.Commit, n int)) error {
+	if err := s.ensureRepo(); err != nil {
+		return err
+	}
+	offsets, err := s.lastOffsets()
+	if err != nil {
+		return err
+	}
+	sub, err := eventbus.NewSubscriber(ctx, s.spec.URL, offsets)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	msgs := make(chan *eventbus.Message, s.spec.BatchSize)
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := sub.Next(ctx)
+			if err != nil {
+				errCh <- err
+				return
+			}
+			select {
+			case msgs <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	var batch []*eventbus.Message
+	var timer <-chan time.Time
+	for {
+		flush := false
+		select {
+		case msg := <-msgs:
+			if len(batch) == 0 {
+				timer = time.After(s.spec.BatchInterval)
+			}
+			batch = append(batch, msg)
+			flush = len(batch) >= s.spec.BatchSize
+		case <-timer:
+			flush = true
+		case err := <-errCh:
+			return err
+		}
+		if !flush {
+			continue
+		}
+		for _, msg := range batch {
+			if s.topic.Scheme == eventbus.KafkaScheme {
+				offsets[msg.Partition] = msg.Offset + 1
+			}
+		}
+		commit, err := s.commit(batch, offsets)
+		if err != nil {
+			return err
+		}
+		if onCommit != nil {
+			onCommit(commit, len(batch))
+		}
+		batch, timer = nil, nil
+	}
+}
+
+func (s *Syncer) ensureRepo() error {
+	if _, err := s.c.InspectRepo(s.spec.Repo); err != nil {
+		if !errutil.IsNotFoundError(err) {
+			return err
+		}
+		if _, err := s.c.PfsAPIClient.CreateRepo(s.c.Ctx(), &pfs.CreateRepoRequest{
+			Repo:        client.NewRepo(s.spec.Repo),
+			Description: "Synced from " + s.topic.String(),
+		}); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	return nil
+}
+
+// lastOffsets returns the offsets recorded on the head of the PFS branch.
+func (s *Syncer) lastOffsets() (map[int32]int64, error) {
+	ci, err := s.c.InspectCommit(s.spec.Repo, s.spec.Branch, "")
+	if err != nil {
+		if errutil.IsNotFoundError(err) {
+			return make(map[int32]int64), nil
+		}
+		return nil, err
+	}
+	return Offsets(ci)
+}
+
+func (s *Syncer) commit(batch []*eventbus.Message, offsets map[int32]int64) (_ *pfs.Commit, retErr error) {
+	commit, err := s.c.StartCommit(s.spec.Repo, s.spec.Branch)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if retErr != nil {
+			return
+		}
+		_, retErr = s.c.PfsAPIClient.FinishCommit(s.c.Ctx(), &pfs.FinishCommitRequest{
+			Commit:      commit,
+			Description: Description(s.topic.String(), len(batch), offsets),
+		})
+		retErr = errors.EnsureStack(retErr)
+	}()
+	return commit, s.c.WithModifyFileClient(commit, func(mf client.ModifyFile) error {
+		if s.spec.File != "" {
+			var buf bytes.Buffer
+			for _, msg := range batch {
+				buf.Write(msg.Value)
+				buf.WriteByte('\n')
+			}
+			return errors.EnsureStack(mf.PutFile(path.Join(s.spec.Path, s.spec.File), &buf, client.WithAppendPutFile()))
+		}
+		for i, msg := range batch {
+			if err := mf.PutFile(path.Join(s.spec.Path, messageName(s.topic, msg, i)), bytes.NewReader(msg.Value)); err != nil {
+				return errors.EnsureStack(err)
+			}
+		}
+		return nil
+	})
+}
+
+// messageName returns the name of the file the i-th message of a batch is
+// written to. Names sort in the order messages were received.
+func messageName(topic *eventbus.Topic, msg *eventbus.Message, i int) string {
+	if topic.Scheme == eventbus.KafkaScheme {
+		return fmt.Sprintf("%d/%020d", msg.Partition, msg.Offset)
+	}
+	return fmt.Sprintf("%020d-%06d", msg.Time.UnixNano(), i)
+}
@@ -0,0 +1,33 @@
+package topicsync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/eventbus"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+func TestOffsets(t *testing.T) {
+	offsets := map[int32]int64{2: 7, 0: 12, 1: 0}
+	description := Description("kafka://kafka:9092/events", 3, offsets)
+	require.Equal(t, "Synced 3 message(s) from kafka://kafka:9092/events\n\nkafka-offset: 0=12\nkafka-offset: 1=0\nkafka-offset: 2=7\n", description)
+	parsed, err := Offsets(&pfs.CommitInfo{Description: description})
+	require.NoError(t, err)
+	require.Equal(t, offsets, parsed)
+
+	parsed, err = Offsets(&pfs.CommitInfo{Description: "not synced"})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(parsed))
+
+	_, err = Offsets(&pfs.CommitInfo{Description: "kafka-offset: 0"})
+	require.YesError(t, err)
+}
+
+func TestMessageName(t *testing.T) {
+	kafka := &eventbus.Topic{Scheme: eventbus.KafkaScheme}
+	require.Equal(t, "1/00000000000000000042", messageName(kafka, &eventbus.Message{Partition: 1, Offset: 42}, 0))
+	nats := &eventbus.Topic{Scheme: eventbus.NATSScheme}
+	require.Equal(t, "00000000000000000005-000003", messageName(nats, &eventbus.Message{Time: time.Unix(0, 5)}, 3))
+}
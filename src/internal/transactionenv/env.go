@@ -43,6 +43,7 @@ type PpsWrites interface {
 	StopJob(*pps.StopJobRequest) error
 	UpdateJobState(*pps.UpdateJobStateRequest) error
 	CreatePipeline(*pps.CreatePipelineRequest) error
+	DeletePipeline(*pps.DeletePipelineRequest) error
 }
 
 // AuthWrites is an interface providing a wrapper for each operation that
@@ -180,6 +181,11 @@ func (t *directTransaction) CreatePipeline(original *pps.CreatePipelineRequest)
 	return errors.EnsureStack(t.txnEnv.serviceEnv.PpsServer().CreatePipelineInTransaction(t.txnCtx, req))
 }
 
+func (t *directTransaction) DeletePipeline(original *pps.DeletePipelineRequest) error {
+	req := proto.Clone(original).(*pps.DeletePipelineRequest)
+	return errors.EnsureStack(t.txnEnv.serviceEnv.PpsServer().DeletePipelineInTransaction(t.txnCtx, req))
+}
+
 func (t *directTransaction) DeleteRoleBinding(original *auth.Resource) error {
 	req := proto.Clone(original).(*auth.Resource)
 	return errors.EnsureStack(t.txnEnv.serviceEnv.AuthServer().DeleteRoleBindingInTransaction(t.txnCtx, req))
@@ -252,6 +258,11 @@ func (t *appendTransaction) CreatePipeline(req *pps.CreatePipelineRequest) error
 	return errors.EnsureStack(err)
 }
 
+func (t *appendTransaction) DeletePipeline(req *pps.DeletePipelineRequest) error {
+	_, err := t.txnEnv.txnServer.AppendRequest(t.ctx, t.activeTxn, &transaction.TransactionRequest{DeletePipeline: req})
+	return errors.EnsureStack(err)
+}
+
 func (t *appendTransaction) ModifyRoleBinding(original *auth.ModifyRoleBindingRequest) (*auth.ModifyRoleBindingResponse, error) {
 	panic("ModifyRoleBinding not yet implemented in transactions")
 }
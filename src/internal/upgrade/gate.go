@@ -0,0 +1,60 @@
+package upgrade
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+)
+
+// exemptPrefixes are the methods that a replica handles whatever its phase,
+// so that the progress of an upgrade can be followed through any replica.
+var exemptPrefixes = []string{
+	"/admin_v2.API/InspectCluster",
+	"/admin_v2.API/InspectUpgrade",
+	"/grpc.health.v1.Health/",
+	"/versionpb_v2.API/",
+}
+
+func exempt(fullMethod string) bool {
+	for _, prefix := range exemptPrefixes {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Coordinator) check(fullMethod string) error {
+	if exempt(fullMethod) {
+		return nil
+	}
+	switch c.phase() {
+	case admin.ReplicaInfo_SERVING:
+		return nil
+	case admin.ReplicaInfo_STARTING:
+		return status.Errorf(codes.Unavailable, "pachd %s is waiting for the cluster's state to be upgraded to version %s", c.env.Pod, c.env.Version)
+	default:
+		return status.Errorf(codes.Unavailable, "pachd %s is draining, because the cluster is being upgraded to a newer version", c.env.Pod)
+	}
+}
+
+// UnaryServerInterceptor rejects requests while the replica isn't serving.
+func (c *Coordinator) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := c.check(info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor rejects requests while the replica isn't serving.
+func (c *Coordinator) StreamServerInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := c.check(info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
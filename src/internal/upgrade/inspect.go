@@ -0,0 +1,46 @@
+package upgrade
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/types"
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/migrations"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+)
+
+// Inspect returns the cluster's applied migrations, the progress of its
+// latest upgrade, and its running replicas.
+func Inspect(ctx context.Context, db *pachsql.DB, client *etcd.Client) (*admin.InspectUpgradeResponse, error) {
+	current, err := migrations.CurrentState(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	history, err := migrations.History(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	response := &admin.InspectUpgradeResponse{CurrentMigration: int64(current)}
+	for _, m := range history {
+		info := &admin.MigrationInfo{Number: int64(m.ID), Name: m.Name}
+		if info.Started, err = types.TimestampProto(m.StartTime); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		if m.EndTime.Valid {
+			if info.Finished, err = types.TimestampProto(m.EndTime.Time); err != nil {
+				return nil, errors.EnsureStack(err)
+			}
+		}
+		response.Migrations = append(response.Migrations, info)
+	}
+	if response.Status, err = getStatus(ctx, client); err != nil {
+		return nil, err
+	}
+	if response.Replicas, err = listReplicas(ctx, client); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -0,0 +1,470 @@
+// Package upgrade coordinates the rollout of pachd replicas of a new version
+// with the migration of the cluster's state. One replica of the new version
+// leads the upgrade: it waits for the replicas of older versions to drain,
+// applies the pending migrations in order, and records its progress in etcd,
+// while the other new replicas wait for the state to be migrated before they
+// serve. Replicas of older versions stop accepting requests as soon as an
+// upgrade starts, so that they never read or write state they don't
+// understand.
+package upgrade
+
+import (
+	"context"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"github.com/sirupsen/logrus"
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dlock"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/middleware/inflight"
+	"github.com/pachyderm/pachyderm/v2/src/internal/migrations"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+)
+
+const (
+	etcdPrefix = "pachyderm_upgrade"
+	// replicaTTL is how many seconds a replica stays registered after it
+	// stops renewing its lease, for example because it crashed.
+	replicaTTL = 15
+)
+
+var (
+	leaderKey      = path.Join(etcdPrefix, "leader")
+	statusKey      = path.Join(etcdPrefix, "status")
+	replicasPrefix = path.Join(etcdPrefix, "replicas") + "/"
+)
+
+// Requests are the requests a replica is handling.
+type Requests interface {
+	List() []*inflight.Request
+	Cancel(id string) bool
+}
+
+// Env is the set of dependencies required by a Coordinator.
+type Env struct {
+	DB         *pachsql.DB
+	EtcdClient *etcd.Client
+	// Migrations is the environment that migrations are applied in.
+	Migrations migrations.Env
+	// Target is the state of the cluster that this replica's version
+	// expects.
+	Target migrations.State
+	// Pod and Version identify this replica.
+	Pod     string
+	Version string
+	// Requests are the requests this replica is handling, nil if they're
+	// not tracked, in which case draining replicas wait for DrainTimeout
+	// before they're drained.
+	Requests     Requests
+	DrainTimeout time.Duration
+	Logger       *logrus.Logger
+}
+
+// Coordinator registers a replica, takes part in the upgrades of the
+// cluster's state, and drains the replica when a newer version upgrades it.
+type Coordinator struct {
+	env Env
+
+	mu      sync.Mutex
+	replica *admin.ReplicaInfo
+	lease   etcd.LeaseID
+}
+
+// NewCoordinator returns a Coordinator for the replica described by env.
+func NewCoordinator(env Env) *Coordinator {
+	if env.Logger == nil {
+		env.Logger = logrus.StandardLogger()
+	}
+	return &Coordinator{
+		env: env,
+		replica: &admin.ReplicaInfo{
+			Pod:       env.Pod,
+			Version:   env.Version,
+			Migration: int64(env.Target.Number()),
+			Phase:     admin.ReplicaInfo_STARTING,
+			Started:   types.TimestampNow(),
+		},
+	}
+}
+
+// Start registers the replica and returns once the cluster's state is
+// migrated to the replica's version, which the replica does itself if it
+// becomes the upgrade's leader. It errors if the cluster's state is newer
+// than the replica's version, or if a newer version is upgrading it.
+func (c *Coordinator) Start(ctx context.Context) error {
+	if err := c.register(ctx); err != nil {
+		return err
+	}
+	current, err := migrations.CurrentState(ctx, c.env.DB)
+	if err != nil {
+		return err
+	}
+	target := c.env.Target.Number()
+	if current > target {
+		return errors.Errorf("the cluster's state is at migration %d, which is newer than version %s's migration %d", current, c.env.Version, target)
+	}
+	if current < target {
+		if err := c.upgrade(ctx); err != nil {
+			return err
+		}
+	}
+	status, err := getStatus(ctx, c.env.EtcdClient)
+	if err != nil {
+		return err
+	}
+	if newer(status, target) {
+		return errors.Errorf("the cluster is being upgraded to version %s by %s", status.LeaderVersion, status.Leader)
+	}
+	return c.setPhase(ctx, admin.ReplicaInfo_SERVING)
+}
+
+// Run drains the replica when a newer version starts upgrading the cluster,
+// and resumes serving if that upgrade fails before the cluster's state is
+// migrated. It returns when ctx is done.
+func (c *Coordinator) Run(ctx context.Context) error {
+	watch := c.env.EtcdClient.Watch(ctx, statusKey)
+	status, err := getStatus(ctx, c.env.EtcdClient)
+	if err != nil {
+		return err
+	}
+	c.handle(ctx, status)
+	for resp := range watch {
+		if err := resp.Err(); err != nil {
+			return errors.EnsureStack(err)
+		}
+		for _, ev := range resp.Events {
+			status := &admin.UpgradeStatus{}
+			if err := proto.Unmarshal(ev.Kv.Value, status); err != nil {
+				c.env.Logger.Errorf("could not unmarshal the upgrade status: %v", err)
+				continue
+			}
+			c.handle(ctx, status)
+		}
+	}
+	return errors.EnsureStack(ctx.Err())
+}
+
+func (c *Coordinator) handle(ctx context.Context, status *admin.UpgradeStatus) {
+	target := c.env.Target.Number()
+	switch phase := c.phase(); {
+	case phase == admin.ReplicaInfo_SERVING && newer(status, target):
+		c.env.Logger.Infof("draining, because the cluster is being upgraded to version %s by %s", status.LeaderVersion, status.Leader)
+		c.drain(ctx)
+	case phase != admin.ReplicaInfo_SERVING && status.GetPhase() == admin.UpgradeStatus_FAILED:
+		current, err := migrations.CurrentState(ctx, c.env.DB)
+		if err != nil {
+			c.env.Logger.Errorf("could not read the cluster's state: %v", err)
+			return
+		}
+		if current == target {
+			c.env.Logger.Infof("serving again, because the upgrade to version %s failed: %s", status.LeaderVersion, status.Error)
+			if err := c.setPhase(ctx, admin.ReplicaInfo_SERVING); err != nil {
+				c.env.Logger.Errorf("could not update the replica's phase: %v", err)
+			}
+		}
+	}
+}
+
+// newer returns true if status is an upgrade, in progress or complete, to a
+// state newer than target.
+func newer(status *admin.UpgradeStatus, target int) bool {
+	return status != nil && status.Phase != admin.UpgradeStatus_FAILED && status.ToMigration > int64(target)
+}
+
+// drain stops the replica from accepting requests, waits for the requests
+// it's handling to finish, and cancels the ones that don't finish within the
+// drain timeout.
+func (c *Coordinator) drain(ctx context.Context) {
+	if err := c.setPhase(ctx, admin.ReplicaInfo_DRAINING); err != nil {
+		c.env.Logger.Errorf("could not update the replica's phase: %v", err)
+	}
+	deadline := time.Now().Add(c.env.DrainTimeout)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for time.Now().Before(deadline) && len(c.unfinished()) > 0 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+	if c.env.Requests == nil {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(deadline)):
+		}
+	}
+	for _, r := range c.unfinished() {
+		c.env.Logger.Warnf("cancelling %s request %s, which didn't finish within the drain timeout", r.Method, r.ID)
+		c.env.Requests.Cancel(r.ID)
+	}
+	if err := c.setPhase(ctx, admin.ReplicaInfo_DRAINED); err != nil {
+		c.env.Logger.Errorf("could not update the replica's phase: %v", err)
+	}
+}
+
+// unfinished returns the requests that the replica must finish before it's
+// drained.
+func (c *Coordinator) unfinished() []*inflight.Request {
+	if c.env.Requests == nil {
+		return nil
+	}
+	var result []*inflight.Request
+	for _, r := range c.env.Requests.List() {
+		if !exempt(r.Method) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// upgrade waits for the cluster's state to be migrated to the replica's
+// version, migrating it itself if it becomes the leader first.
+func (c *Coordinator) upgrade(ctx context.Context) error {
+	blockCtx, cancelBlock := context.WithCancel(ctx)
+	defer cancelBlock()
+	blocked := make(chan error, 1)
+	go func() {
+		blocked <- migrations.BlockUntil(blockCtx, c.env.DB, c.env.Target)
+	}()
+
+	lock := dlock.NewDLock(c.env.EtcdClient, leaderKey)
+	lockCtx, cancelLock := context.WithCancel(ctx)
+	defer cancelLock()
+	locked := make(chan error, 1)
+	var leaderCtx context.Context
+	go func() {
+		var err error
+		leaderCtx, err = lock.Lock(lockCtx)
+		locked <- err
+	}()
+
+	select {
+	case err := <-blocked:
+		// another replica migrated the state
+		cancelLock()
+		if lockErr := <-locked; lockErr == nil {
+			if err := lock.Unlock(ctx); err != nil {
+				c.env.Logger.Errorf("could not release the upgrade lock: %v", err)
+			}
+		}
+		return err
+	case err := <-locked:
+		cancelBlock()
+		<-blocked
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := lock.Unlock(ctx); err != nil {
+				c.env.Logger.Errorf("could not release the upgrade lock: %v", err)
+			}
+		}()
+		return c.lead(leaderCtx)
+	}
+}
+
+// lead upgrades the cluster's state, while holding the upgrade lock.
+func (c *Coordinator) lead(ctx context.Context) error {
+	current, err := migrations.CurrentState(ctx, c.env.DB)
+	if err != nil {
+		return err
+	}
+	target := c.env.Target.Number()
+	if current == target {
+		// the previous leader migrated the state
+		return nil
+	} else if current > target {
+		return errors.Errorf("the cluster's state is at migration %d, which is newer than version %s's migration %d", current, c.env.Version, target)
+	}
+	var states []migrations.State
+	status := &admin.UpgradeStatus{
+		Phase:         admin.UpgradeStatus_DRAINING,
+		Leader:        c.env.Pod,
+		LeaderVersion: c.env.Version,
+		FromMigration: int64(current),
+		ToMigration:   int64(target),
+		Started:       types.TimestampNow(),
+	}
+	for _, state := range migrations.States(c.env.Target) {
+		if state.Number() > current {
+			states = append(states, state)
+			status.Pending = append(status.Pending, &admin.MigrationInfo{
+				Number: int64(state.Number()),
+				Name:   state.Name(),
+			})
+		}
+	}
+	c.env.Logger.Infof("upgrading the cluster's state from migration %d to %d", current, target)
+	if err := putStatus(ctx, c.env.EtcdClient, status); err != nil {
+		return err
+	}
+	if err := c.waitForDrain(ctx); err != nil {
+		return c.fail(ctx, status, err)
+	}
+	status.Phase = admin.UpgradeStatus_MIGRATING
+	for _, state := range states {
+		status.Pending[0].Started = types.TimestampNow()
+		if err := putStatus(ctx, c.env.EtcdClient, status); err != nil {
+			return err
+		}
+		c.env.Logger.Infof("applying migration %d (%s)", state.Number(), state.Name())
+		if err := migrations.ApplyMigration(ctx, c.env.DB, c.env.Migrations, state); err != nil {
+			return c.fail(ctx, status, errors.Wrapf(err, "migration %d (%s)", state.Number(), state.Name()))
+		}
+		status.Pending = status.Pending[1:]
+	}
+	status.Phase = admin.UpgradeStatus_COMPLETE
+	status.Finished = types.TimestampNow()
+	return putStatus(ctx, c.env.EtcdClient, status)
+}
+
+func (c *Coordinator) fail(ctx context.Context, status *admin.UpgradeStatus, err error) error {
+	status.Phase = admin.UpgradeStatus_FAILED
+	status.Error = err.Error()
+	status.Finished = types.TimestampNow()
+	if putErr := putStatus(ctx, c.env.EtcdClient, status); putErr != nil {
+		c.env.Logger.Errorf("could not record the failure of the upgrade: %v", putErr)
+	}
+	return err
+}
+
+// waitForDrain waits until no replica of an older version handles requests.
+func (c *Coordinator) waitForDrain(ctx context.Context) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		replicas, err := listReplicas(ctx, c.env.EtcdClient)
+		if err != nil {
+			return err
+		}
+		serving, err := blockers(replicas, c.env.Target.Number())
+		if err != nil {
+			return err
+		}
+		if len(serving) == 0 {
+			return nil
+		}
+		c.env.Logger.Infof("waiting for %d replicas of older versions to drain: %v", len(serving), serving)
+		select {
+		case <-ctx.Done():
+			return errors.EnsureStack(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// blockers returns the replicas of older versions than target that still
+// handle requests. Replicas of older versions that are starting are waiting
+// for the cluster's state, and never serve once it's migrated. It errors if
+// a replica is of a newer version.
+func blockers(replicas []*admin.ReplicaInfo, target int) ([]string, error) {
+	var result []string
+	for _, r := range replicas {
+		if r.Migration > int64(target) {
+			return nil, errors.Errorf("replica %s is of version %s, which is newer than this upgrade's", r.Pod, r.Version)
+		}
+		if r.Migration < int64(target) && (r.Phase == admin.ReplicaInfo_SERVING || r.Phase == admin.ReplicaInfo_DRAINING) {
+			result = append(result, r.Pod)
+		}
+	}
+	return result, nil
+}
+
+// register records the replica in etcd, under a lease that the replica
+// keeps alive as long as it runs.
+func (c *Coordinator) register(ctx context.Context) error {
+	resp, err := c.env.EtcdClient.Grant(ctx, replicaTTL)
+	if err != nil {
+		return errors.Wrapf(err, "error granting replica lease")
+	}
+	keepAliveChan, err := c.env.EtcdClient.KeepAlive(context.Background(), resp.ID)
+	if err != nil {
+		return errors.Wrapf(err, "error with KeepAlive")
+	}
+	go func() {
+		for {
+			_, more := <-keepAliveChan
+			if !more {
+				c.env.Logger.Errorf("failed to renew the replica's etcd lease")
+				return
+			}
+		}
+	}()
+	c.mu.Lock()
+	c.lease = resp.ID
+	c.mu.Unlock()
+	return c.put(ctx)
+}
+
+func (c *Coordinator) phase() admin.ReplicaInfo_Phase {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.replica.Phase
+}
+
+func (c *Coordinator) setPhase(ctx context.Context, phase admin.ReplicaInfo_Phase) error {
+	c.mu.Lock()
+	c.replica.Phase = phase
+	c.mu.Unlock()
+	return c.put(ctx)
+}
+
+func (c *Coordinator) put(ctx context.Context) error {
+	c.mu.Lock()
+	data, err := proto.Marshal(c.replica)
+	lease := c.lease
+	c.mu.Unlock()
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	_, err = c.env.EtcdClient.Put(ctx, replicasPrefix+c.env.Pod, string(data), etcd.WithLease(lease))
+	return errors.EnsureStack(err)
+}
+
+func getStatus(ctx context.Context, client *etcd.Client) (*admin.UpgradeStatus, error) {
+	resp, err := client.Get(ctx, statusKey)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	status := &admin.UpgradeStatus{}
+	if err := proto.Unmarshal(resp.Kvs[0].Value, status); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return status, nil
+}
+
+func putStatus(ctx context.Context, client *etcd.Client, status *admin.UpgradeStatus) error {
+	data, err := proto.Marshal(status)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	_, err = client.Put(ctx, statusKey, string(data))
+	return errors.EnsureStack(err)
+}
+
+func listReplicas(ctx context.Context, client *etcd.Client) ([]*admin.ReplicaInfo, error) {
+	resp, err := client.Get(ctx, replicasPrefix, etcd.WithPrefix(), etcd.WithSort(etcd.SortByKey, etcd.SortAscend))
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	var result []*admin.ReplicaInfo
+	for _, kv := range resp.Kvs {
+		replica := &admin.ReplicaInfo{}
+		if err := proto.Unmarshal(kv.Value, replica); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		result = append(result, replica)
+	}
+	return result, nil
+}
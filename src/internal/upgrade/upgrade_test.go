@@ -0,0 +1,51 @@
+package upgrade
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestBlockers(t *testing.T) {
+	replicas := []*admin.ReplicaInfo{
+		{Pod: "old-serving", Migration: 1, Phase: admin.ReplicaInfo_SERVING},
+		{Pod: "old-draining", Migration: 1, Phase: admin.ReplicaInfo_DRAINING},
+		{Pod: "old-drained", Migration: 1, Phase: admin.ReplicaInfo_DRAINED},
+		{Pod: "old-starting", Migration: 1, Phase: admin.ReplicaInfo_STARTING},
+		{Pod: "new-starting", Migration: 2, Phase: admin.ReplicaInfo_STARTING},
+	}
+	serving, err := blockers(replicas, 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"old-serving", "old-draining"}, serving)
+
+	_, err = blockers(replicas, 1)
+	require.YesError(t, err)
+	require.Matches(t, "replica new-starting .* newer than this upgrade's", err.Error())
+}
+
+func TestNewer(t *testing.T) {
+	require.False(t, newer(nil, 2))
+	require.False(t, newer(&admin.UpgradeStatus{Phase: admin.UpgradeStatus_COMPLETE, ToMigration: 2}, 2))
+	require.False(t, newer(&admin.UpgradeStatus{Phase: admin.UpgradeStatus_FAILED, ToMigration: 3}, 2))
+	for _, phase := range []admin.UpgradeStatus_Phase{admin.UpgradeStatus_DRAINING, admin.UpgradeStatus_MIGRATING, admin.UpgradeStatus_COMPLETE} {
+		require.True(t, newer(&admin.UpgradeStatus{Phase: phase, ToMigration: 3}, 2))
+	}
+}
+
+func TestGate(t *testing.T) {
+	c := NewCoordinator(Env{Pod: "pachd-0", Version: "2.1.0"})
+	for _, phase := range []admin.ReplicaInfo_Phase{admin.ReplicaInfo_STARTING, admin.ReplicaInfo_DRAINING, admin.ReplicaInfo_DRAINED} {
+		c.replica.Phase = phase
+		err := c.check("/pfs_v2.API/InspectRepo")
+		require.YesError(t, err)
+		require.Equal(t, codes.Unavailable, status.Code(err))
+		require.NoError(t, c.check("/admin_v2.API/InspectUpgrade"))
+		require.NoError(t, c.check("/grpc.health.v1.Health/Check"))
+	}
+	c.replica.Phase = admin.ReplicaInfo_SERVING
+	require.NoError(t, c.check("/pfs_v2.API/InspectRepo"))
+}
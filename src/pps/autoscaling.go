@@ -0,0 +1,52 @@
+package pps
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// DefaultTargetCompletion is the target_completion of a DatumAutoscaling that
+// doesn't set one.
+const DefaultTargetCompletion = 10 * time.Minute
+
+// ValidateDatumAutoscaling validates a pipeline's datum autoscaling.
+func ValidateDatumAutoscaling(autoscaling *DatumAutoscaling) error {
+	if autoscaling == nil {
+		return nil
+	}
+	if autoscaling.MinWorkers < 0 {
+		return errors.New("min_workers must be non-negative")
+	}
+	if autoscaling.MaxWorkers <= 0 {
+		return errors.New("max_workers must be positive")
+	}
+	if autoscaling.MinWorkers > autoscaling.MaxWorkers {
+		return errors.New("min_workers can't be more than max_workers")
+	}
+	if autoscaling.TargetCompletion != nil {
+		target, err := types.DurationFromProto(autoscaling.TargetCompletion)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		if target <= 0 {
+			return errors.New("target_completion must be positive")
+		}
+	}
+	return nil
+}
+
+// TargetCompletionDuration returns how quickly the pipeline should process
+// its pending datums.
+func (a *DatumAutoscaling) TargetCompletionDuration() time.Duration {
+	if a == nil || a.TargetCompletion == nil {
+		return DefaultTargetCompletion
+	}
+	target, err := types.DurationFromProto(a.TargetCompletion)
+	if err != nil || target <= 0 {
+		return DefaultTargetCompletion
+	}
+	return target
+}
@@ -0,0 +1,99 @@
+package pps
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// JobUsage is the resources a job has consumed, in the units of a JobBudget.
+type JobUsage struct {
+	WorkerHours float64
+	CPUHours    float64
+	GPUHours    float64
+	// Cost is only set if the job's budget has a worker hour cost.
+	Cost float64
+}
+
+// GetJobUsage returns the resources consumed by the datums a job has
+// finished so far.
+func GetJobUsage(jobInfo *JobInfo) JobUsage {
+	var workerTime time.Duration
+	if stats := jobInfo.Stats; stats != nil {
+		for _, d := range []*types.Duration{stats.DownloadTime, stats.ProcessTime, stats.UploadTime} {
+			if d != nil {
+				t, err := types.DurationFromProto(d)
+				if err == nil {
+					workerTime += t
+				}
+			}
+		}
+	}
+	usage := JobUsage{WorkerHours: workerTime.Hours()}
+	if details := jobInfo.Details; details != nil {
+		usage.CPUHours = usage.WorkerHours * float64(details.ResourceRequests.GetCpu())
+		usage.GPUHours = usage.WorkerHours * workerGPUs(details.ResourceRequests, details.ResourceLimits)
+		usage.Cost = usage.WorkerHours * details.Budget.GetWorkerHourCost()
+	}
+	return usage
+}
+
+// workerGPUs returns the number of GPUs a worker uses, which may be set in
+// either its requests or its limits. A fractional GPU counts as its
+// fraction, and a MIG instance as a whole GPU.
+func workerGPUs(requests, limits *ResourceSpec) float64 {
+	for _, gpu := range []*GPUSpec{requests.GetGpu(), limits.GetGpu()} {
+		switch {
+		case gpu == nil:
+		case gpu.Fraction > 0:
+			return float64(gpu.Fraction)
+		case gpu.MigProfile != "" && gpu.Number == 0:
+			return 1
+		case gpu.Number > 0:
+			return float64(gpu.Number)
+		}
+	}
+	return 0
+}
+
+// Exceeded returns a description of the limit of b that usage exceeds, or ""
+// if usage is within b. A nil budget is never exceeded.
+func (b *JobBudget) Exceeded(usage JobUsage) string {
+	switch {
+	case b == nil:
+		return ""
+	case b.MaxWorkerHours > 0 && usage.WorkerHours > b.MaxWorkerHours:
+		return fmt.Sprintf("job used %.2f worker hours, exceeding its budget of %g", usage.WorkerHours, b.MaxWorkerHours)
+	case b.MaxCpuHours > 0 && usage.CPUHours > b.MaxCpuHours:
+		return fmt.Sprintf("job used %.2f CPU hours, exceeding its budget of %g", usage.CPUHours, b.MaxCpuHours)
+	case b.MaxGpuHours > 0 && usage.GPUHours > b.MaxGpuHours:
+		return fmt.Sprintf("job used %.2f GPU hours, exceeding its budget of %g", usage.GPUHours, b.MaxGpuHours)
+	case b.MaxCost > 0 && usage.Cost > b.MaxCost:
+		return fmt.Sprintf("job cost %.2f, exceeding its budget of %g", usage.Cost, b.MaxCost)
+	}
+	return ""
+}
+
+// ValidateJobBudget validates the budget of a pipeline with the given
+// resource requests and limits.
+func ValidateJobBudget(budget *JobBudget, requests, limits *ResourceSpec) error {
+	if budget == nil {
+		return nil
+	}
+	if budget.MaxWorkerHours < 0 || budget.MaxCpuHours < 0 || budget.MaxGpuHours < 0 || budget.MaxCost < 0 || budget.WorkerHourCost < 0 {
+		return errors.New("budget limits must be non-negative")
+	}
+	if budget.MaxCpuHours > 0 && requests.GetCpu() <= 0 {
+		return errors.New("a budget of CPU hours requires a CPU resource request")
+	}
+	if budget.MaxGpuHours > 0 && workerGPUs(requests, limits) <= 0 {
+		return errors.New("a budget of GPU hours requires a GPU resource request or limit")
+	}
+	if budget.MaxCost > 0 && budget.WorkerHourCost <= 0 {
+		return errors.New("a budget with a max cost requires a worker hour cost")
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package pps
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+const (
+	// DefaultBuilderImage is the Kaniko executor image used by build pipelines
+	// that don't set one.
+	DefaultBuilderImage = "gcr.io/kaniko-project/executor:v1.9.1-debug"
+	// BuiltImageFile is the file in a build pipeline's output commits that
+	// holds the reference, by digest, of the image that the job built.
+	BuiltImageFile = "/image"
+
+	builderDockerConfigPath = "/kaniko/.docker"
+)
+
+// ValidateBuild validates a pipeline's build, and the input it builds from.
+func ValidateBuild(build *Build, input *Input) error {
+	if build == nil {
+		return nil
+	}
+	if build.Destination == "" {
+		return errors.New("destination must be set")
+	}
+	if strings.Contains(build.Destination, "@") || strings.Contains(path.Base(build.Destination), ":") {
+		return errors.Errorf("destination %q must be an image repository without a tag or digest (images are tagged with the job ID)", build.Destination)
+	}
+	if clean := path.Clean(build.Dockerfile); path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return errors.Errorf("dockerfile %q must be a path relative to the root of the input", build.Dockerfile)
+	}
+	if input.GetPfs() == nil {
+		return errors.New("a build pipeline's input must be a single pfs input")
+	}
+	if input.Pfs.Glob != "/" {
+		return errors.Errorf("a build pipeline's input must have the glob pattern \"/\", not %q, so that each job builds from the whole input", input.Pfs.Glob)
+	}
+	return nil
+}
+
+// BuildTransform returns the transform that runs a pipeline's build on its
+// input. The env, secrets and image pull secrets of transform, the transform
+// in the pipeline's spec, are kept, and the rest of it is replaced.
+func BuildTransform(build *Build, input *PFSInput, transform *Transform) *Transform {
+	result := &Transform{
+		Image: build.BuilderImage,
+		Cmd:   []string{"/busybox/sh"},
+	}
+	if result.Image == "" {
+		result.Image = DefaultBuilderImage
+	}
+	if transform != nil {
+		result.Env = transform.Env
+		result.Secrets = transform.Secrets
+		result.ImagePullSecrets = transform.ImagePullSecrets
+	}
+	if build.Secret != "" {
+		result.Secrets = append(result.Secrets, &SecretMount{Name: build.Secret, MountPath: builderDockerConfigPath})
+	}
+	dockerfile := build.Dockerfile
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+	context := path.Join("/pfs", input.Name)
+	args := []string{
+		"/kaniko/executor",
+		shellQuote("--context=dir://" + context),
+		shellQuote("--dockerfile=" + path.Join(context, dockerfile)),
+		// the job ID is expanded by the shell
+		shellQuote("--destination="+build.Destination) + `:"$PACH_JOB_ID"`,
+		shellQuote("--image-name-with-digest-file=" + path.Join("/pfs/out", BuiltImageFile)),
+	}
+	var keys []string
+	for k := range build.BuildArgs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, shellQuote(fmt.Sprintf("--build-arg=%s=%s", k, build.BuildArgs[k])))
+	}
+	result.Stdin = []string{strings.Join(args, " ")}
+	return result
+}
+
+// shellQuote quotes s as a single word for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
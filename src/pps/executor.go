@@ -0,0 +1,49 @@
+package pps
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// DefaultArgoPollInterval is the poll_interval of an ArgoExecutor that
+// doesn't set one.
+const DefaultArgoPollInterval = 5 * time.Second
+
+// ValidateExecutor validates a pipeline's executor.
+func ValidateExecutor(executor *Executor) error {
+	if executor == nil {
+		return nil
+	}
+	if executor.Argo == nil {
+		return errors.New("executor must specify an execution backend")
+	}
+	if executor.Argo.WorkflowTemplate == "" {
+		return errors.New("argo executor must specify a workflow_template")
+	}
+	if executor.Argo.PollInterval != nil {
+		interval, err := types.DurationFromProto(executor.Argo.PollInterval)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		if interval <= 0 {
+			return errors.New("poll_interval must be positive")
+		}
+	}
+	return nil
+}
+
+// ArgoPollInterval returns how often the status of an ArgoExecutor's
+// workflows is checked.
+func ArgoPollInterval(argo *ArgoExecutor) time.Duration {
+	if argo.PollInterval == nil {
+		return DefaultArgoPollInterval
+	}
+	interval, err := types.DurationFromProto(argo.PollInterval)
+	if err != nil {
+		return DefaultArgoPollInterval
+	}
+	return interval
+}
@@ -0,0 +1,45 @@
+package pps
+
+import (
+	"regexp"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// NvidiaGPU is the resource type of NVIDIA GPUs, which MIG profiles and
+// time-sliced GPUs are requested from.
+const NvidiaGPU = "nvidia.com/gpu"
+
+var migProfileRegex = regexp.MustCompile(`^[0-9]+g\.[0-9]+gb$`)
+
+// ValidateGPUSpec validates the GPUs a pipeline requests.
+func ValidateGPUSpec(gpu *GPUSpec) error {
+	if gpu == nil {
+		return nil
+	}
+	if gpu.Number < 0 {
+		return errors.Errorf("number must be non-negative, got %d", gpu.Number)
+	}
+	if gpu.MigProfile == "" && gpu.Fraction == 0 {
+		return nil
+	}
+	if gpu.Type != "" && gpu.Type != NvidiaGPU {
+		return errors.Errorf("mig_profile and fraction are only supported for %s GPUs, not %s", NvidiaGPU, gpu.Type)
+	}
+	if gpu.MigProfile != "" {
+		if gpu.Fraction != 0 {
+			return errors.New("cannot set both mig_profile and fraction")
+		}
+		if !migProfileRegex.MatchString(gpu.MigProfile) {
+			return errors.Errorf("invalid mig_profile %q, must be of the form <compute>g.<memory>gb, such as 1g.5gb", gpu.MigProfile)
+		}
+		return nil
+	}
+	if gpu.Number != 0 {
+		return errors.New("cannot set both number and fraction")
+	}
+	if gpu.Fraction < 0 || gpu.Fraction > 1 {
+		return errors.Errorf("fraction must be between 0 and 1, got %v", gpu.Fraction)
+	}
+	return nil
+}
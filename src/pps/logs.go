@@ -0,0 +1,58 @@
+package pps
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+var (
+	// levelFieldRE matches the level field of structured log lines, e.g.
+	// `level=info` (logrus) or `"level":"info"` (JSON).
+	levelFieldRE = regexp.MustCompile(`(?i)\blevel"?\s*[=:]\s*"?([a-z]+)`)
+	// levelPrefixRE matches a level at the start of a log line, e.g.
+	// "ERROR: ...", "[warn] ..." or "WARNING:root:..." (Python).
+	levelPrefixRE = regexp.MustCompile(`^\s*[\[(]?([A-Za-z]+)(?:[\]):\s]|$)`)
+)
+
+// ParseLogLevel returns the level of a log line, or LOG_LEVEL_UNKNOWN if it
+// doesn't have one.
+func ParseLogLevel(line string) LogLevel {
+	if m := levelFieldRE.FindStringSubmatch(line); m != nil {
+		if level, err := ParseLogLevelName(m[1]); err == nil {
+			return level
+		}
+	}
+	if m := levelPrefixRE.FindStringSubmatch(line); m != nil {
+		if level, err := ParseLogLevelName(m[1]); err == nil {
+			return level
+		}
+	}
+	return LogLevel_LOG_LEVEL_UNKNOWN
+}
+
+// ParseLogLevelName parses the name of a log level, e.g. "warn" or "ERROR".
+func ParseLogLevelName(name string) (LogLevel, error) {
+	switch strings.ToLower(name) {
+	case "debug", "trace":
+		return LogLevel_LOG_DEBUG, nil
+	case "info":
+		return LogLevel_LOG_INFO, nil
+	case "warn", "warning":
+		return LogLevel_LOG_WARNING, nil
+	case "error", "err", "fatal", "critical", "panic":
+		return LogLevel_LOG_ERROR, nil
+	default:
+		return LogLevel_LOG_LEVEL_UNKNOWN, errors.Errorf("unknown log level %q", name)
+	}
+}
+
+// LogLevelOf returns the level of msg, treating messages without a level as
+// LOG_INFO.
+func LogLevelOf(msg *LogMessage) LogLevel {
+	if msg.Level == LogLevel_LOG_LEVEL_UNKNOWN {
+		return LogLevel_LOG_INFO
+	}
+	return msg.Level
+}
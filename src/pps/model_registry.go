@@ -0,0 +1,42 @@
+package pps
+
+import (
+	"net/url"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// ModelRegistryTokenEnv is the environment variable that holds the bearer
+// token used to authenticate to a pipeline's model registry, and the key of
+// the secret that it's read from.
+const ModelRegistryTokenEnv = "MLFLOW_TRACKING_TOKEN"
+
+// ValidateModelRegistry validates a pipeline's model_registry.
+func ValidateModelRegistry(registry *ModelRegistry) error {
+	if registry == nil {
+		return nil
+	}
+	u, err := url.Parse(registry.Url)
+	if err != nil {
+		return errors.Wrapf(err, "invalid url %q", registry.Url)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.Errorf("url %q must be an http or https URL", registry.Url)
+	}
+	if u.Host == "" {
+		return errors.Errorf("url %q must have a host", registry.Url)
+	}
+	if registry.Model == "" {
+		return errors.New("model must be set")
+	}
+	return nil
+}
+
+// ExperimentOrDefault returns the experiment that a pipeline's runs are
+// logged to.
+func (r *ModelRegistry) ExperimentOrDefault(pipeline string) string {
+	if r.Experiment == "" {
+		return pipeline
+	}
+	return r.Experiment
+}
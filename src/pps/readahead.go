@@ -0,0 +1,34 @@
+package pps
+
+import (
+	units "github.com/docker/go-units"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// DefaultReadaheadSizeBytes is the size_bytes of a Readahead that doesn't set
+// one.
+const DefaultReadaheadSizeBytes = 64 * units.MiB
+
+// ValidateReadahead validates a pipeline's readahead.
+func ValidateReadahead(readahead *Readahead) error {
+	if readahead == nil {
+		return nil
+	}
+	if readahead.Files <= 0 {
+		return errors.New("files must be positive")
+	}
+	if readahead.SizeBytes < 0 {
+		return errors.New("size_bytes must be non-negative")
+	}
+	return nil
+}
+
+// SizeBytesOrDefault returns the most file content that may be buffered ahead
+// of the file being read.
+func (r *Readahead) SizeBytesOrDefault() int64 {
+	if r == nil || r.SizeBytes <= 0 {
+		return DefaultReadaheadSizeBytes
+	}
+	return r.SizeBytes
+}
@@ -0,0 +1,30 @@
+package pps
+
+import (
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// ValidateDatumRetryPolicy validates a pipeline's datum retry policy.
+func ValidateDatumRetryPolicy(policy *DatumRetryPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	for _, d := range []*types.Duration{policy.Backoff, policy.MaxBackoff} {
+		if d == nil {
+			continue
+		}
+		duration, err := types.DurationFromProto(d)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		if duration < 0 {
+			return errors.New("retry backoffs must be non-negative")
+		}
+	}
+	if policy.MaxBackoff != nil && policy.Backoff == nil {
+		return errors.New("max_backoff requires a backoff")
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ var (
 	// format strings for state name parsing errors
 	errInvalidJobStateName      string
 	errInvalidPipelineStateName string
+	errInvalidDatumStateName    string
 )
 
 func init() {
@@ -29,6 +30,11 @@ func init() {
 		states = append(states, strings.ToLower(strings.TrimPrefix(PipelineState_name[i], "PIPELINE_")))
 	}
 	errInvalidPipelineStateName = fmt.Sprintf("state %%s must be one of %s, or %s, etc", strings.Join(states, ", "), PipelineState_name[0])
+	states = states[:0]
+	for i := int32(0); DatumState_name[i] != ""; i++ {
+		states = append(states, strings.ToLower(DatumState_name[i]))
+	}
+	errInvalidDatumStateName = fmt.Sprintf("state %%s must be one of %s", strings.Join(states, ", "))
 }
 
 func (j *Job) String() string {
@@ -161,12 +167,21 @@ func PipelineStateFromName(name string) (PipelineState, error) {
 	return 0, errors.Errorf(errInvalidPipelineStateName, name)
 }
 
+// DatumStateFromName attempts to interpret a string as a DatumState, in any
+// case.
+func DatumStateFromName(name string) (DatumState, error) {
+	if value, ok := DatumState_value[strings.ToUpper(name)]; ok {
+		return DatumState(value), nil
+	}
+	return 0, errors.Errorf(errInvalidDatumStateName, name)
+}
+
 // IsTerminal returns 'true' if 'state' indicates that the job is done (i.e.
 // the state will not change later: SUCCESS, FAILURE, KILLED) and 'false'
 // otherwise.
 func IsTerminal(state JobState) bool {
 	switch state {
-	case JobState_JOB_SUCCESS, JobState_JOB_FAILURE, JobState_JOB_KILLED, JobState_JOB_UNRUNNABLE:
+	case JobState_JOB_SUCCESS, JobState_JOB_FAILURE, JobState_JOB_KILLED, JobState_JOB_UNRUNNABLE, JobState_JOB_BUDGET_EXCEEDED:
 		return true
 	case JobState_JOB_CREATED, JobState_JOB_STARTING, JobState_JOB_RUNNING, JobState_JOB_EGRESSING, JobState_JOB_FINISHING:
 		return false
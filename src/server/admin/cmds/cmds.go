@@ -2,17 +2,32 @@ package cmds
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/gogo/protobuf/types"
+	"github.com/pachyderm/pachyderm/v2/src/admin"
 	"github.com/pachyderm/pachyderm/v2/src/client"
 	"github.com/pachyderm/pachyderm/v2/src/internal/cmdutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/serde"
+	"github.com/pachyderm/pachyderm/v2/src/internal/tabwriter"
+	"github.com/pachyderm/pachyderm/v2/src/server/admin/pretty"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // Cmds returns a slice containing admin commands.
 func Cmds() []*cobra.Command {
 	var commands []*cobra.Command
 
+	var raw bool
+	var output string
+	outputFlags := cmdutil.OutputFlags(&raw, &output)
+
 	inspectCluster := &cobra.Command{
 		Short: "Returns info about the pachyderm cluster",
 		Long:  "Returns info about the pachyderm cluster",
@@ -32,5 +47,733 @@ func Cmds() []*cobra.Command {
 	}
 	commands = append(commands, cmdutil.CreateAlias(inspectCluster, "inspect cluster"))
 
+	var url, secret string
+	var events, repos, pipelines []string
+	var maxAttempts int64
+	webhookFlags := pflag.NewFlagSet("", pflag.ContinueOnError)
+	webhookFlags.StringVar(&url, "url", "", "The http or https URL that events are POSTed to, or the kafka:// or nats:// topic URL they're published to.")
+	webhookFlags.StringVar(&secret, "secret", "", "If set, each request has an X-Pachyderm-Signature header with \"sha256=\" followed by the HMAC SHA-256 of its body, keyed with this secret.")
+	webhookFlags.StringSliceVar(&events, "event", nil, fmt.Sprintf("An event to send, all events are sent if unset. One of %s.", strings.Join(webhookEventTypes(), ", ")))
+	webhookFlags.StringSliceVar(&repos, "repo", nil, "Only send events about this repo.")
+	webhookFlags.StringSliceVar(&pipelines, "pipeline", nil, "Only send events about this pipeline.")
+	webhookFlags.Int64Var(&maxAttempts, "max-attempts", 0, "The number of times a delivery is attempted before it fails, 5 if unset.")
+	createWebhookFunc := func(name string, update bool) error {
+		webhook := &admin.Webhook{
+			Name:        name,
+			URL:         url,
+			Secret:      secret,
+			Repos:       repos,
+			Pipelines:   pipelines,
+			MaxAttempts: maxAttempts,
+		}
+		for _, event := range events {
+			t, ok := admin.WebhookEventType_value[strings.ToUpper(event)]
+			if !ok || t == int32(admin.WebhookEventType_WEBHOOK_EVENT_UNKNOWN) {
+				return errors.Errorf("unknown event %q, must be one of %s", event, strings.Join(webhookEventTypes(), ", "))
+			}
+			webhook.Events = append(webhook.Events, admin.WebhookEventType(t))
+		}
+		c, err := client.NewOnUserMachine("user")
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		return c.CreateWebhook(webhook, update)
+	}
+
+	createWebhook := &cobra.Command{
+		Use:   "{{alias}} <name>",
+		Short: "Register a webhook for cluster events.",
+		Long: `Register a webhook for cluster events.
+
+Each event is POSTed to the webhook's URL as a JSON object, and retried with
+exponential backoff until the webhook responds with a 2xx status or the
+webhook's max attempts are exhausted. If the URL is a kafka:// or nats://
+topic URL, each event is instead published to the topic as a JSON message,
+which can be ingested into a repo with 'pachctl sync topic'.`,
+		Example: `
+# send an event to http://example.com/hook when a job of pipeline "edges" fails
+$ {{alias}} edges-failures --url http://example.com/hook --event JOB_FAILED --pipeline edges
+
+# send all events, signed with a secret
+$ {{alias}} everything --url https://example.com/hook --secret my-secret
+
+# publish finished commits of repo "images" to a Kafka topic
+$ {{alias}} images-commits --url kafka://kafka:9092/images --event COMMIT_FINISHED --repo images`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			return createWebhookFunc(args[0], false)
+		}),
+	}
+	createWebhook.Flags().AddFlagSet(webhookFlags)
+	commands = append(commands, cmdutil.CreateAlias(createWebhook, "create webhook"))
+
+	updateWebhook := &cobra.Command{
+		Use:   "{{alias}} <name>",
+		Short: "Update a webhook.",
+		Long:  "Update a webhook, replacing all of its settings with the flags' values.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			return createWebhookFunc(args[0], true)
+		}),
+	}
+	updateWebhook.Flags().AddFlagSet(webhookFlags)
+	commands = append(commands, cmdutil.CreateAlias(updateWebhook, "update webhook"))
+
+	inspectWebhook := &cobra.Command{
+		Use:   "{{alias}} <name>",
+		Short: "Return info about a webhook, including its recent deliveries.",
+		Long:  "Return info about a webhook, including its recent deliveries.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			webhookInfo, err := c.InspectWebhook(args[0])
+			if err != nil {
+				return err
+			}
+			if raw {
+				return errors.EnsureStack(cmdutil.Encoder(output, os.Stdout).EncodeProto(webhookInfo))
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			return pretty.PrintDetailedWebhookInfo(webhookInfo)
+		}),
+	}
+	inspectWebhook.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(inspectWebhook, "inspect webhook"))
+
+	listWebhook := &cobra.Command{
+		Short: "Return a list of webhooks.",
+		Long:  "Return a list of webhooks.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			webhookInfos, err := c.ListWebhook()
+			if err != nil {
+				return err
+			}
+			if raw {
+				encoder := cmdutil.Encoder(output, os.Stdout)
+				for _, webhookInfo := range webhookInfos {
+					if err := encoder.EncodeProto(webhookInfo); err != nil {
+						return errors.EnsureStack(err)
+					}
+				}
+				return nil
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.WebhookHeader)
+			for _, webhookInfo := range webhookInfos {
+				pretty.PrintWebhookInfo(writer, webhookInfo)
+			}
+			return writer.Flush()
+		}),
+	}
+	listWebhook.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(listWebhook, "list webhook"))
+
+	deleteWebhook := &cobra.Command{
+		Use:   "{{alias}} <name>",
+		Short: "Delete a webhook.",
+		Long:  "Delete a webhook.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			return c.DeleteWebhook(args[0])
+		}),
+	}
+	commands = append(commands, cmdutil.CreateAlias(deleteWebhook, "delete webhook"))
+
+	var minAge time.Duration
+	var method string
+	listRequest := &cobra.Command{
+		Short: "Return the requests pachd is handling.",
+		Long: `Return the requests pachd is handling, oldest first, with their caller, age and the messages streamed so far.
+
+Each pachd only lists the requests it's handling itself, so when pachd has
+several replicas this only covers the one the client is connected to.`,
+		Example: `
+# list the requests that have been running for over ten minutes
+$ {{alias}} --min-age 10m
+
+# list the in-flight GetFile requests
+$ {{alias}} --method GetFile`,
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			resp, err := c.ListInflightRequests(minAge, method)
+			if err != nil {
+				return err
+			}
+			if raw {
+				encoder := cmdutil.Encoder(output, os.Stdout)
+				for _, request := range resp.Requests {
+					if err := encoder.EncodeProto(request); err != nil {
+						return errors.EnsureStack(err)
+					}
+				}
+				return nil
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.InflightRequestHeader)
+			for _, request := range resp.Requests {
+				pretty.PrintInflightRequest(writer, request)
+			}
+			return writer.Flush()
+		}),
+	}
+	listRequest.Flags().DurationVar(&minAge, "min-age", 0, "Only list requests that have been running for at least this long.")
+	listRequest.Flags().StringVar(&method, "method", "", "Only list requests whose method contains this string.")
+	listRequest.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(listRequest, "list request"))
+
+	stopRequest := &cobra.Command{
+		Use:   "{{alias}} <id>",
+		Short: "Cancel a request pachd is handling.",
+		Long:  "Cancel a request pachd is handling. The request fails with a cancellation error.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			return c.CancelInflightRequest(args[0])
+		}),
+	}
+	commands = append(commands, cmdutil.CreateAlias(stopRequest, "stop request"))
+
+	var defaultsFormat string
+	inspectDefaults := &cobra.Command{
+		Short: "Return the cluster's defaults for pipeline specs.",
+		Long:  "Return the cluster's defaults for pipeline specs.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			defaults, err := c.GetClusterDefaults()
+			if err != nil {
+				return err
+			}
+			return errors.EnsureStack(cmdutil.Encoder(defaultsFormat, os.Stdout).EncodeProto(defaults))
+		}),
+	}
+	inspectDefaults.Flags().StringVarP(&defaultsFormat, "output", "o", "json", "Output format: \"json\" or \"yaml\".")
+	commands = append(commands, cmdutil.CreateAlias(inspectDefaults, "inspect defaults"))
+
+	var defaultsFile string
+	updateDefaults := &cobra.Command{
+		Short: "Replace the cluster's defaults for pipeline specs.",
+		Long: `Replace the cluster's defaults for pipeline specs.
+
+The defaults are merged into each pipeline spec that's created or updated
+afterwards, under the values the spec sets itself. Existing pipelines keep
+their settings until they're updated.`,
+		Example: `
+# give pipelines that don't set their resources 1 cpu and 1G of memory, and
+# time out their jobs after a day
+$ echo '{"resource_requests": {"cpu": 1, "memory": "1G"}, "job_timeout": "24h"}' | {{alias}}
+
+# set the defaults from a file
+$ {{alias}} -f defaults.yaml`,
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			var defaultsBytes []byte
+			var err error
+			if defaultsFile == "-" {
+				cmdutil.PrintStdinReminder()
+				defaultsBytes, err = ioutil.ReadAll(os.Stdin)
+			} else {
+				defaultsBytes, err = ioutil.ReadFile(defaultsFile)
+			}
+			if err != nil {
+				return errors.Wrapf(err, "could not read defaults")
+			}
+			var defaults admin.ClusterDefaults
+			if err := serde.Decode(defaultsBytes, &defaults); err != nil {
+				return errors.Wrapf(err, "could not parse defaults")
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			return c.SetClusterDefaults(&defaults)
+		}),
+	}
+	updateDefaults.Flags().StringVarP(&defaultsFile, "file", "f", "-", "The JSON or YAML file containing the defaults, \"-\" reads from stdin.")
+	commands = append(commands, cmdutil.CreateAlias(updateDefaults, "update defaults"))
+
+	deleteDefaults := &cobra.Command{
+		Short: "Clear the cluster's defaults for pipeline specs.",
+		Long:  "Clear the cluster's defaults for pipeline specs. Existing pipelines keep their settings.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			return c.SetClusterDefaults(nil)
+		}),
+	}
+	commands = append(commands, cmdutil.CreateAlias(deleteDefaults, "delete defaults"))
+
+	var usageStart, usageEnd, usageFormat string
+	inspectUsage := &cobra.Command{
+		Short: "Return the resources used by each repo, pipeline and user.",
+		Long: `Return the storage used by each repo, and the compute and egress used by each pipeline's jobs that finished in a time window, and attribute them to the repos' owners, for chargeback.
+
+Repo sizes are their current sizes. The usage of a repo or pipeline with several owners is split evenly between them.`,
+		Example: `
+# report the usage of the last 30 days
+$ {{alias}}
+
+# export the usage of September as CSV
+$ {{alias}} --start 2021-09-01 --end 2021-10-01 --format csv > usage.csv
+
+# export the usage of the last week for node_exporter's textfile collector
+$ {{alias}} --start $(date -d '-7 days' --iso-8601=seconds) --format prometheus > pachyderm_usage.prom`,
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			start, err := parseUsageTime(usageStart)
+			if err != nil {
+				return errors.Wrapf(err, "invalid --start")
+			}
+			end, err := parseUsageTime(usageEnd)
+			if err != nil {
+				return errors.Wrapf(err, "invalid --end")
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			report, err := c.GetUsageReport(start, end)
+			if err != nil {
+				return err
+			}
+			if raw {
+				return errors.EnsureStack(cmdutil.Encoder(output, os.Stdout).EncodeProto(report))
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			switch usageFormat {
+			case "csv":
+				return pretty.WriteUsageReportCSV(os.Stdout, report)
+			case "prometheus":
+				return pretty.WriteUsageReportMetrics(os.Stdout, report)
+			case "table":
+			default:
+				return errors.Errorf("unknown format %q, must be one of table, csv or prometheus", usageFormat)
+			}
+			fmt.Printf("Usage from %s to %s\n\n", prettyTimestamp(report.Start), prettyTimestamp(report.End))
+			writer := tabwriter.NewWriter(os.Stdout, pretty.RepoUsageHeader)
+			for _, r := range report.Repos {
+				pretty.PrintRepoUsage(writer, r)
+			}
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			fmt.Println()
+			writer = tabwriter.NewWriter(os.Stdout, pretty.PipelineUsageHeader)
+			for _, p := range report.Pipelines {
+				pretty.PrintPipelineUsage(writer, p)
+			}
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			fmt.Println()
+			writer = tabwriter.NewWriter(os.Stdout, pretty.UserUsageHeader)
+			for _, u := range report.Users {
+				pretty.PrintUserUsage(writer, u)
+			}
+			return writer.Flush()
+		}),
+	}
+	inspectUsage.Flags().StringVar(&usageStart, "start", "", "The start of the window, as an RFC 3339 timestamp or a date. Defaults to 30 days before the end.")
+	inspectUsage.Flags().StringVar(&usageEnd, "end", "", "The end of the window, as an RFC 3339 timestamp or a date. Defaults to now.")
+	inspectUsage.Flags().StringVar(&usageFormat, "format", "table", "The format of the report: \"table\", \"csv\" or \"prometheus\".")
+	inspectUsage.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(inspectUsage, "inspect usage"))
+
+	var capacityWindow time.Duration
+	inspectCapacity := &cobra.Command{
+		Short: "Return the cluster's storage usage and when it will run out.",
+		Long: `Return the storage postgres, object storage and etcd use, how fast it's growing, and when each is projected to be full, with a recommendation for each that's running out.
+
+pachd samples the usage every hour, and the growth is the trend of the samples in the window. The capacities are configured in pachd's CAPACITY_POSTGRES_SIZE, CAPACITY_OBJECT_STORAGE_SIZE and CAPACITY_ETCD_QUOTA.`,
+		Example: `
+# project the growth of the last week
+$ {{alias}}
+
+# project the growth of the last 30 days
+$ {{alias}} --window 720h`,
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			report, err := c.GetCapacityReport(capacityWindow)
+			if err != nil {
+				return err
+			}
+			if raw {
+				return errors.EnsureStack(cmdutil.Encoder(output, os.Stdout).EncodeProto(report))
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			fmt.Printf("Growth from %s to %s (%d samples)\n\n", prettyTimestamp(report.WindowStart), prettyTimestamp(report.Time), report.Samples)
+			writer := tabwriter.NewWriter(os.Stdout, pretty.CapacityHeader)
+			for _, rc := range report.Resources {
+				pretty.PrintResourceCapacity(writer, rc)
+			}
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			var printed bool
+			for _, rc := range report.Resources {
+				if rc.Recommendation == "" {
+					continue
+				}
+				if !printed {
+					fmt.Println("\nRecommendations:")
+					printed = true
+				}
+				fmt.Printf("  %s\n", rc.Recommendation)
+			}
+			return nil
+		}),
+	}
+	inspectCapacity.Flags().DurationVar(&capacityWindow, "window", 0, "How far back the samples the growth is computed from go. Defaults to 7 days.")
+	inspectCapacity.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(inspectCapacity, "inspect capacity"))
+
+	inspectUpgrade := &cobra.Command{
+		Short: "Return the progress of the cluster's latest upgrade.",
+		Long: `Return the progress of the cluster's latest upgrade.
+
+When pachd replicas of a newer version are rolled out, one of them leads the
+upgrade: the replicas of older versions stop accepting requests and finish the
+ones they're handling, then the leader migrates the cluster's state, and the
+replicas of the newer version start serving. This shows the migrations that
+have been applied, the phase of the latest upgrade and the migrations it has
+yet to apply, and the phase of each running replica.`,
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			response, err := c.InspectUpgrade()
+			if err != nil {
+				return err
+			}
+			if raw {
+				return errors.EnsureStack(cmdutil.Encoder(output, os.Stdout).EncodeProto(response))
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			current := fmt.Sprint(response.CurrentMigration)
+			for _, m := range response.Migrations {
+				if m.Number == response.CurrentMigration {
+					current = fmt.Sprintf("%d (%s)", m.Number, m.Name)
+				}
+			}
+			fmt.Printf("Current migration: %s\n", current)
+			if response.Status == nil {
+				fmt.Println("The cluster hasn't been upgraded since it was deployed.")
+			} else {
+				pretty.PrintUpgradeStatus(os.Stdout, response.Status)
+			}
+			fmt.Println()
+			writer := tabwriter.NewWriter(os.Stdout, pretty.ReplicaHeader)
+			for _, r := range response.Replicas {
+				pretty.PrintReplicaInfo(writer, r)
+			}
+			return writer.Flush()
+		}),
+	}
+	inspectUpgrade.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(inspectUpgrade, "inspect upgrade"))
+
+	var backupLocation string
+	backupFlags := pflag.NewFlagSet("", pflag.ContinueOnError)
+	backupFlags.StringVar(&backupLocation, "location", "", "The object storage URL of the backups, such as s3://bucket/backups. Defaults to pachd's BACKUP_LOCATION.")
+
+	createBackup := &cobra.Command{
+		Short: "Back up the cluster's metadata.",
+		Long: `Back up the cluster's metadata.
+
+This takes a point-in-time snapshot of the cluster's postgres tables and etcd
+keys, and copies the chunks in object storage that they reference, to a backup
+location. Chunks are shared by the backups at a location, so each backup only
+copies the chunks that are new since the backups before it. The cluster keeps
+running during the backup.`,
+		Example: `
+# back up the cluster to pachd's default backup location
+$ {{alias}}
+
+# back up the cluster to a bucket
+$ {{alias}} --location s3://my-backups/pachyderm`,
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			info, err := c.Backup(backupLocation)
+			if err != nil {
+				return err
+			}
+			if raw {
+				return errors.EnsureStack(cmdutil.Encoder(output, os.Stdout).EncodeProto(info))
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			pretty.PrintDetailedBackupInfo(os.Stdout, info)
+			return nil
+		}),
+	}
+	createBackup.Flags().AddFlagSet(backupFlags)
+	createBackup.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(createBackup, "create backup"))
+
+	listBackup := &cobra.Command{
+		Short: "Return the backups at a backup location.",
+		Long:  "Return the complete backups at a backup location, newest first.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			backupInfos, err := c.ListBackups(backupLocation)
+			if err != nil {
+				return err
+			}
+			if raw {
+				encoder := cmdutil.Encoder(output, os.Stdout)
+				for _, backupInfo := range backupInfos {
+					if err := encoder.EncodeProto(backupInfo); err != nil {
+						return errors.EnsureStack(err)
+					}
+				}
+				return nil
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.BackupHeader)
+			for _, backupInfo := range backupInfos {
+				pretty.PrintBackupInfo(writer, backupInfo)
+			}
+			return writer.Flush()
+		}),
+	}
+	listBackup.Flags().AddFlagSet(backupFlags)
+	listBackup.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(listBackup, "list backup"))
+
+	var deep bool
+	verifyBackup := &cobra.Command{
+		Use:   "{{alias}} [<id>]",
+		Short: "Check that a backup is complete.",
+		Long: `Check that a backup, or the latest one if no ID is given, is complete and
+can be restored.
+
+By default, this checks that each object of the backup exists. With --deep, it
+reads every object, and checks the hash of each chunk and the number of rows
+and keys, which takes as long as downloading the backup.`,
+		Run: cmdutil.RunBoundedArgs(0, 1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			var id string
+			if len(args) > 0 {
+				id = args[0]
+			}
+			response, err := c.VerifyBackup(backupLocation, id, deep)
+			if err != nil {
+				return err
+			}
+			if raw {
+				return errors.EnsureStack(cmdutil.Encoder(output, os.Stdout).EncodeProto(response))
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			fmt.Printf("Checked backup %s and its %d chunks.\n", response.Backup.ID, response.ChunksChecked)
+			if len(response.Problems) == 0 {
+				fmt.Println("No problems found.")
+				return nil
+			}
+			fmt.Println("Problems:")
+			for _, problem := range response.Problems {
+				fmt.Printf("  %s\n", problem)
+			}
+			return errors.Errorf("backup %s can't be restored", response.Backup.ID)
+		}),
+	}
+	verifyBackup.Flags().BoolVar(&deep, "deep", false, "Read every object of the backup, and check its contents.")
+	verifyBackup.Flags().AddFlagSet(backupFlags)
+	verifyBackup.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(verifyBackup, "verify backup"))
+
+	var force bool
+	restoreBackup := &cobra.Command{
+		Use:   "{{alias}} [<id>]",
+		Short: "Restore the cluster's metadata from a backup.",
+		Long: `Restore the cluster's metadata from a backup, or the latest one if no ID is
+given.
+
+This replaces the cluster's postgres tables and etcd keys with the backup's,
+and copies back the backup's chunks that are missing from the cluster's object
+storage. The backup must have been taken by a cluster of the same version, and
+unless --force is set, the cluster must not have any repos. Restart pachd once
+the backup is restored.`,
+		Run: cmdutil.RunBoundedArgs(0, 1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			var id string
+			if len(args) > 0 {
+				id = args[0]
+			}
+			response, err := c.Restore(backupLocation, id, force)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Restored backup %s, and %d chunks missing from object storage.\n", response.Backup.ID, response.ChunksRestored)
+			fmt.Println("Restart pachd to load it, e.g. with: kubectl rollout restart deployment pachd")
+			return nil
+		}),
+	}
+	restoreBackup.Flags().BoolVar(&force, "force", false, "Restore the backup even if the cluster has repos, replacing them.")
+	restoreBackup.Flags().AddFlagSet(backupFlags)
+	commands = append(commands, cmdutil.CreateAlias(restoreBackup, "restore backup"))
+
+	var reason string
+	startMaintenance := &cobra.Command{
+		Short: "Make the cluster read-only for maintenance.",
+		Long: `Make the cluster read-only for maintenance, such as a backup or a migration.
+
+While the cluster is read-only, the requests that would change it, such as
+creating a repo or commit, putting a file or creating a pipeline, fail with an
+error that includes --reason. Reads, such as listing repos and getting files,
+keep working. Pipelines that are already running keep processing their jobs,
+so stop the pipelines that create their own commits, such as cron pipelines
+and spouts, for a quiescent cluster.`,
+		Example: `
+# make the cluster read-only while it's backed up
+$ {{alias}} --reason "nightly backup"`,
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			state, err := c.SetReadOnly(true, reason)
+			if err != nil {
+				return err
+			}
+			pretty.PrintReadOnlyState(os.Stdout, state)
+			return nil
+		}),
+	}
+	startMaintenance.Flags().StringVar(&reason, "reason", "", "Why the cluster is read-only, which is included in the errors of rejected requests.")
+	commands = append(commands, cmdutil.CreateAlias(startMaintenance, "start maintenance"))
+
+	finishMaintenance := &cobra.Command{
+		Short: "Make the cluster writable again after maintenance.",
+		Long:  "Make the cluster writable again after maintenance.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			state, err := c.SetReadOnly(false, "")
+			if err != nil {
+				return err
+			}
+			pretty.PrintReadOnlyState(os.Stdout, state)
+			return nil
+		}),
+	}
+	commands = append(commands, cmdutil.CreateAlias(finishMaintenance, "finish maintenance"))
+
+	inspectMaintenance := &cobra.Command{
+		Short: "Return whether the cluster is read-only for maintenance.",
+		Long:  "Return whether the cluster is read-only for maintenance, why, and since when.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			state, err := c.InspectReadOnly()
+			if err != nil {
+				return err
+			}
+			if raw {
+				return errors.EnsureStack(cmdutil.Encoder(output, os.Stdout).EncodeProto(state))
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			pretty.PrintReadOnlyState(os.Stdout, state)
+			return nil
+		}),
+	}
+	inspectMaintenance.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(inspectMaintenance, "inspect maintenance"))
+	commands = append(commands, InitCmd())
+	commands = append(commands, DeployCmds()...)
+
 	return commands
 }
+
+// parseUsageTime parses an RFC 3339 timestamp or a date, or returns the zero
+// time if s is empty.
+func parseUsageTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	return t, errors.EnsureStack(err)
+}
+
+func prettyTimestamp(ts *types.Timestamp) string {
+	t, err := types.TimestampFromProto(ts)
+	if err != nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
+
+func webhookEventTypes() []string {
+	var names []string
+	for t := admin.WebhookEventType_COMMIT_FINISHED; ; t++ {
+		name, ok := admin.WebhookEventType_name[int32(t)]
+		if !ok {
+			return names
+		}
+		names = append(names, name)
+	}
+}
@@ -0,0 +1,232 @@
+package cmds
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/cmdutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/deployconfig"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/tabwriter"
+	"github.com/pachyderm/pachyderm/v2/src/server/admin/pretty"
+
+	"github.com/spf13/cobra"
+)
+
+// DeployCmds returns the commands that check a cluster's configuration
+// before it's deployed, and that convert the flags of 1.x's 'pachctl deploy'
+// to Helm values.
+func DeployCmds() []*cobra.Command {
+	var commands []*cobra.Command
+
+	deployDocs := &cobra.Command{
+		Short: "Check the configuration of a Pachyderm cluster before it's deployed.",
+		Long: "Check the configuration of a Pachyderm cluster before it's deployed. " +
+			"Clusters are deployed with Helm, see 'pachctl init' to write their Helm values, " +
+			"or 'pachctl deploy values' to convert the flags of 1.x's 'pachctl deploy' to them.",
+	}
+	commands = append(commands, cmdutil.CreateAlias(deployDocs, "deploy"))
+
+	var raw bool
+	var output string
+	var remote, offline bool
+	validate := &cobra.Command{
+		Use:   "{{alias}} <values.yaml>",
+		Short: "Validate the Helm values of a cluster.",
+		Long: `Validate the Helm values that a cluster would be deployed with, before they're applied. If the file is '-', the values are read from stdin.
+
+The values are checked against the chart's schema, and for values that are inconsistent with each other:
+  - the storage backend has the values it requires, such as a bucket and credentials
+  - activating auth has an enterprise license
+  - the redirect URI of each identity provider is the identity server's callback, on the right host and port
+  - a TLS certificate matches its key and hasn't expired
+  - pachd's service ports are valid and distinct
+  - an external postgres has a host and password
+
+Unless --offline is set, the object storage bucket is read with the configured credentials, and the discovery document of each OIDC identity provider is fetched. These checks run from this machine, or from the current cluster's pachd with --remote, which reaches them through the cluster's network.
+
+Errors stop the cluster from deploying or working, and make the command fail. Warnings are likely mistakes, such as values that aren't in the chart.`,
+		Example: `
+# check values before installing them
+$ {{alias}} values.yaml && helm install pachd pach/pachyderm -f values.yaml
+
+# check values from the current cluster's network
+$ {{alias}} --remote values.yaml`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			if output != "" && !raw {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			data, err := readValues(args[0])
+			if err != nil {
+				return err
+			}
+			var problems []*admin.ConfigurationProblem
+			if remote {
+				c, err := client.NewOnUserMachine("user")
+				if err != nil {
+					return err
+				}
+				defer c.Close()
+				if problems, err = c.ValidateConfiguration(data, offline); err != nil {
+					return err
+				}
+			} else if problems, err = deployconfig.Validate(context.Background(), data, offline); err != nil {
+				return err
+			}
+			if raw {
+				response := &admin.ValidateConfigurationResponse{Problems: problems}
+				if err := cmdutil.Encoder(output, os.Stdout).EncodeProto(response); err != nil {
+					return errors.EnsureStack(err)
+				}
+			} else if err := printProblems(os.Stdout, problems); err != nil {
+				return err
+			}
+			var numErrors int
+			for _, p := range problems {
+				if p.Severity == admin.ConfigurationProblem_ERROR {
+					numErrors++
+				}
+			}
+			if numErrors > 0 {
+				return errors.Errorf("the configuration has %d error(s)", numErrors)
+			}
+			return nil
+		}),
+	}
+	validate.Flags().BoolVar(&remote, "remote", false, "Validate the values on the current cluster's pachd, rather than on this machine.")
+	validate.Flags().BoolVar(&offline, "offline", false, "Don't check that the object storage and identity providers can be reached.")
+	validate.Flags().AddFlagSet(cmdutil.OutputFlags(&raw, &output))
+	commands = append(commands, cmdutil.CreateAlias(validate, "deploy validate"))
+
+	valuesDocs := &cobra.Command{
+		Short: "Convert the flags of 1.x's 'pachctl deploy' to Helm values.",
+		Long: `Convert the arguments and flags of 1.x's 'pachctl deploy <target>' to the Helm values that deploy an equivalent cluster with the chart, and write them to stdout. Run the subcommand of the target with the arguments and flags that the cluster was deployed with.
+
+The values are converted back to flags to check that they're equivalent. Flags that have no equivalent in the chart are warnings, and flags such as --dry-run, which didn't change the cluster, are ignored.`,
+	}
+	commands = append(commands, cmdutil.CreateAlias(valuesDocs, "deploy values"))
+	for _, target := range deployconfig.LegacyTargets {
+		commands = append(commands, legacyValuesCmd(target))
+	}
+
+	var writeFiles bool
+	flags := &cobra.Command{
+		Use:   "{{alias}} <values.yaml>",
+		Short: "Convert Helm values to the flags of 1.x's 'pachctl deploy'.",
+		Long: `Convert Helm values to the invocation of 1.x's 'pachctl deploy' that deploys an equivalent cluster, to compare a cluster deployed with the chart to one deployed by pachctl 1.x. If the file is '-', the values are read from stdin.
+
+Values that have no equivalent flag are warnings. Some flags name files, such as the TLS certificate of --tls, whose contents are in the values. Pass --write-files to write them to the current directory.`,
+		Example: `
+# convert the values of an existing release
+$ helm get values pachd | {{alias}} -`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			data, err := readValues(args[0])
+			if err != nil {
+				return err
+			}
+			c, problems, err := deployconfig.ValuesToLegacy(data)
+			if err != nil {
+				return err
+			}
+			for _, path := range sortedFiles(c.Files) {
+				if !writeFiles {
+					problems = append(problems, &admin.ConfigurationProblem{
+						Severity: admin.ConfigurationProblem_WARNING,
+						Path:     path,
+						Message:  "isn't written, pass --write-files to write it",
+					})
+					continue
+				}
+				if err := ioutil.WriteFile(path, c.Files[path], 0600); err != nil {
+					return errors.Wrapf(err, "could not write %s", path)
+				}
+			}
+			fmt.Println(c.String())
+			if len(problems) > 0 {
+				return printProblems(os.Stderr, problems)
+			}
+			return nil
+		}),
+	}
+	flags.Flags().BoolVar(&writeFiles, "write-files", false, "Write the files that the flags name to the current directory.")
+	commands = append(commands, cmdutil.CreateAlias(flags, "deploy flags"))
+
+	return commands
+}
+
+func legacyValuesCmd(target string) *cobra.Command {
+	flags := deployconfig.NewLegacyFlags(target)
+	cmd := &cobra.Command{
+		Use:   strings.TrimSpace("{{alias}} " + deployconfig.LegacyUsage(target)),
+		Short: fmt.Sprintf("Convert 'pachctl deploy %s' to Helm values.", target),
+		Long:  fmt.Sprintf("Convert the arguments and flags of 1.x's 'pachctl deploy %s' to Helm values, and write them to stdout. The values are also validated, like 'pachctl deploy validate --offline'.", target),
+		Run: cmdutil.Run(func(args []string) error {
+			data, problems, err := deployconfig.LegacyToValues(deployconfig.NewLegacyCommand(target, args, flags))
+			if err != nil {
+				return err
+			}
+			validation, err := deployconfig.Validate(context.Background(), data, true)
+			if err != nil {
+				return err
+			}
+			problems = append(problems, validation...)
+			if _, err := os.Stdout.Write(data); err != nil {
+				return errors.EnsureStack(err)
+			}
+			if len(problems) == 0 {
+				return nil
+			}
+			if err := printProblems(os.Stderr, problems); err != nil {
+				return err
+			}
+			for _, p := range problems {
+				if p.Severity == admin.ConfigurationProblem_ERROR {
+					return errors.Errorf("the values aren't equivalent or valid")
+				}
+			}
+			return nil
+		}),
+	}
+	cmd.Flags().AddFlagSet(flags)
+	return cmdutil.CreateAlias(cmd, "deploy values "+target)
+}
+
+func sortedFiles(files map[string][]byte) []string {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func readValues(file string) ([]byte, error) {
+	if file == "-" {
+		data, err := ioutil.ReadAll(os.Stdin)
+		return data, errors.EnsureStack(err)
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read values")
+	}
+	return data, nil
+}
+
+func printProblems(w io.Writer, problems []*admin.ConfigurationProblem) error {
+	if len(problems) == 0 {
+		fmt.Fprintln(w, "The configuration is valid.")
+		return nil
+	}
+	writer := tabwriter.NewWriter(w, pretty.ConfigurationProblemHeader)
+	for _, p := range problems {
+		pretty.PrintConfigurationProblem(writer, p)
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,480 @@
+package cmds
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/identity"
+	"github.com/pachyderm/pachyderm/v2/src/internal/cmdutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// storageBackends are the storage backends the init wizard can configure, in
+// the order they're offered.
+var storageBackends = []string{"AMAZON", "GOOGLE", "MICROSOFT", "MINIO", "LOCAL"}
+
+// initConfig is the cluster configuration collected by the init wizard.
+type initConfig struct {
+	Backend  string
+	Bucket   string
+	Region   string
+	Endpoint string
+	ID       string
+	Secret   string
+	Cred     string
+	HostPath string
+
+	ActivateAuth bool
+	LicenseKey   string
+	Connector    *identity.IDPConnector
+
+	TLS         bool
+	TLSSecret   string
+	TLSCertFile string
+	TLSKeyFile  string
+	TLSCert     string
+	TLSKey      string
+}
+
+// values returns the Helm values that deploy a cluster with this
+// configuration.
+func (c *initConfig) values() map[string]interface{} {
+	storage := map[string]interface{}{"backend": c.Backend}
+	switch c.Backend {
+	case "AMAZON":
+		storage["amazon"] = map[string]interface{}{
+			"bucket": c.Bucket,
+			"region": c.Region,
+			"id":     c.ID,
+			"secret": c.Secret,
+		}
+	case "GOOGLE":
+		storage["google"] = map[string]interface{}{
+			"bucket": c.Bucket,
+			"cred":   c.Cred,
+		}
+	case "MICROSOFT":
+		storage["microsoft"] = map[string]interface{}{
+			"container": c.Bucket,
+			"id":        c.ID,
+			"secret":    c.Secret,
+		}
+	case "MINIO":
+		storage["minio"] = map[string]interface{}{
+			"bucket":   c.Bucket,
+			"endpoint": c.Endpoint,
+			"id":       c.ID,
+			"secret":   c.Secret,
+		}
+	case "LOCAL":
+		storage["local"] = map[string]interface{}{
+			"hostPath": c.HostPath,
+		}
+	}
+	pachd := map[string]interface{}{
+		"storage":      storage,
+		"activateAuth": c.ActivateAuth,
+	}
+	if c.LicenseKey != "" {
+		pachd["enterpriseLicenseKey"] = c.LicenseKey
+	}
+	if c.TLS {
+		tls := map[string]interface{}{"enabled": true}
+		if c.TLSSecret != "" {
+			tls["secretName"] = c.TLSSecret
+		} else {
+			tls["newSecret"] = map[string]interface{}{
+				"create": true,
+				"crt":    c.TLSCert,
+				"key":    c.TLSKey,
+			}
+		}
+		pachd["tls"] = tls
+	}
+	values := map[string]interface{}{
+		"pachd": pachd,
+	}
+	if c.Backend != "MINIO" {
+		// deployTarget has no MINIO value, the backend alone selects it
+		values["deployTarget"] = c.Backend
+	}
+	if c.Connector != nil {
+		values["oidc"] = map[string]interface{}{
+			"mockIDP": false,
+			"upstreamIDPs": []interface{}{
+				map[string]interface{}{
+					"id":         c.Connector.Id,
+					"name":       c.Connector.Name,
+					"type":       c.Connector.Type,
+					"jsonConfig": c.Connector.JsonConfig,
+				},
+			},
+		}
+	}
+	return values
+}
+
+// initWizard asks an operator how a new cluster should be configured.
+type initWizard struct {
+	in  *bufio.Reader
+	out io.Writer
+	// check validates an identity provider connector against a running
+	// cluster, returning any warnings. It's nil if there's no cluster to
+	// check against.
+	check func(*identity.IDPConnector) ([]string, error)
+	// readFile reads the TLS certificate and key.
+	readFile func(string) ([]byte, error)
+}
+
+func newInitWizard(in io.Reader, out io.Writer) *initWizard {
+	return &initWizard{
+		in:       bufio.NewReader(in),
+		out:      out,
+		readFile: ioutil.ReadFile,
+	}
+}
+
+// ask prints a question and returns the answer, or def if the answer is
+// empty.
+func (w *initWizard) ask(question, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(w.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(w.out, "%s: ", question)
+	}
+	answer, err := w.in.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && answer != "") {
+		return "", errors.Wrapf(err, "could not read answer to %q", question)
+	}
+	if answer = strings.TrimSpace(answer); answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
+
+// askRequired asks a question until it's given a non-empty answer.
+func (w *initWizard) askRequired(question, def string) (string, error) {
+	for {
+		answer, err := w.ask(question, def)
+		if err != nil || answer != "" {
+			return answer, err
+		}
+		fmt.Fprintln(w.out, "A value is required.")
+	}
+}
+
+// confirm asks a yes or no question.
+func (w *initWizard) confirm(question string, def bool) (bool, error) {
+	defAnswer := "y/N"
+	if def {
+		defAnswer = "Y/n"
+	}
+	for {
+		answer, err := w.ask(question+" ("+defAnswer+")", "")
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(w.out, "Please answer 'y' or 'n'.")
+	}
+}
+
+// choose asks the operator to pick one of options.
+func (w *initWizard) choose(question string, options []string, def string) (string, error) {
+	for {
+		answer, err := w.ask(fmt.Sprintf("%s (%s)", question, strings.Join(options, ", ")), def)
+		if err != nil {
+			return "", err
+		}
+		for _, option := range options {
+			if strings.EqualFold(answer, option) {
+				return option, nil
+			}
+		}
+		fmt.Fprintf(w.out, "%q is not one of the options.\n", answer)
+	}
+}
+
+// run walks the operator through storage, auth, identity provider and TLS
+// configuration.
+func (w *initWizard) run() (*initConfig, error) {
+	var config initConfig
+	if err := w.storage(&config); err != nil {
+		return nil, err
+	}
+	if err := w.auth(&config); err != nil {
+		return nil, err
+	}
+	if err := w.tls(&config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func (w *initWizard) storage(config *initConfig) error {
+	fmt.Fprintln(w.out, "== Storage ==")
+	var err error
+	if config.Backend, err = w.choose("Storage backend", storageBackends, "AMAZON"); err != nil {
+		return err
+	}
+	switch config.Backend {
+	case "AMAZON":
+		if config.Bucket, err = w.askRequired("S3 bucket", ""); err != nil {
+			return err
+		}
+		if config.Region, err = w.askRequired("AWS region", "us-east-1"); err != nil {
+			return err
+		}
+		if config.ID, err = w.ask("AWS access key ID (empty to use the node's IAM role)", ""); err != nil {
+			return err
+		}
+		if config.ID != "" {
+			if config.Secret, err = w.askRequired("AWS secret access key", ""); err != nil {
+				return err
+			}
+		}
+	case "GOOGLE":
+		if config.Bucket, err = w.askRequired("GCS bucket", ""); err != nil {
+			return err
+		}
+		if config.Cred, err = w.ask("Service account credentials JSON (empty to use workload identity)", ""); err != nil {
+			return err
+		}
+	case "MICROSOFT":
+		if config.Bucket, err = w.askRequired("Azure blob container", ""); err != nil {
+			return err
+		}
+		if config.ID, err = w.askRequired("Azure storage account name", ""); err != nil {
+			return err
+		}
+		if config.Secret, err = w.askRequired("Azure storage account key", ""); err != nil {
+			return err
+		}
+	case "MINIO":
+		if config.Endpoint, err = w.askRequired("MinIO endpoint (host:port)", ""); err != nil {
+			return err
+		}
+		if config.Bucket, err = w.askRequired("MinIO bucket", ""); err != nil {
+			return err
+		}
+		if config.ID, err = w.askRequired("MinIO access key ID", ""); err != nil {
+			return err
+		}
+		if config.Secret, err = w.askRequired("MinIO secret access key", ""); err != nil {
+			return err
+		}
+	case "LOCAL":
+		if config.HostPath, err = w.ask("Host path for PFS data", "/var/pachyderm/"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *initWizard) auth(config *initConfig) error {
+	fmt.Fprintln(w.out, "== Authentication ==")
+	var err error
+	if config.ActivateAuth, err = w.confirm("Activate authentication (requires an enterprise license)", true); err != nil {
+		return err
+	}
+	if !config.ActivateAuth {
+		return nil
+	}
+	if config.LicenseKey, err = w.askRequired("Enterprise license key", ""); err != nil {
+		return err
+	}
+	configure, err := w.confirm("Configure an identity provider", true)
+	if err != nil || !configure {
+		return err
+	}
+	for {
+		connector, err := w.connector()
+		if err != nil {
+			return err
+		}
+		if w.check == nil {
+			fmt.Fprintln(w.out, "Not connected to a cluster, skipping identity provider validation.")
+			config.Connector = connector
+			return nil
+		}
+		warnings, err := w.check(connector)
+		if err == nil {
+			for _, warning := range warnings {
+				fmt.Fprintf(w.out, "WARNING: %s\n", warning)
+			}
+			fmt.Fprintln(w.out, "Identity provider configuration is valid.")
+			config.Connector = connector
+			return nil
+		}
+		fmt.Fprintf(w.out, "Identity provider configuration is invalid: %v\n", err)
+		retry, err := w.confirm("Re-enter the identity provider configuration", true)
+		if err != nil {
+			return err
+		}
+		if !retry {
+			// keep the configuration, the operator can fix it in the
+			// declaration file
+			config.Connector = connector
+			return nil
+		}
+	}
+}
+
+func (w *initWizard) connector() (*identity.IDPConnector, error) {
+	connType, err := w.choose("Identity provider type", []string{"oidc", "github", "gitlab", "google", "microsoft"}, "oidc")
+	if err != nil {
+		return nil, err
+	}
+	id, err := w.askRequired("Connector ID", connType)
+	if err != nil {
+		return nil, err
+	}
+	jsonConfig := make(map[string]interface{})
+	if connType == "oidc" {
+		if jsonConfig["issuer"], err = w.askRequired("Issuer URL", ""); err != nil {
+			return nil, err
+		}
+	}
+	if jsonConfig["clientID"], err = w.askRequired("Client ID", ""); err != nil {
+		return nil, err
+	}
+	if jsonConfig["clientSecret"], err = w.askRequired("Client secret", ""); err != nil {
+		return nil, err
+	}
+	if jsonConfig["redirectURI"], err = w.askRequired("Redirect URI", "http://localhost:30658/callback"); err != nil {
+		return nil, err
+	}
+	if connType == "oidc" {
+		jsonConfig["insecureEnableGroups"] = true
+	}
+	data, err := json.Marshal(jsonConfig)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return &identity.IDPConnector{
+		Id:         id,
+		Name:       id,
+		Type:       connType,
+		JsonConfig: string(data),
+	}, nil
+}
+
+func (w *initWizard) tls(config *initConfig) error {
+	fmt.Fprintln(w.out, "== TLS ==")
+	var err error
+	if config.TLS, err = w.confirm("Enable TLS for pachd", false); err != nil {
+		return err
+	}
+	if !config.TLS {
+		return nil
+	}
+	if config.TLSSecret, err = w.ask("Existing Kubernetes TLS secret (empty to create one)", ""); err != nil {
+		return err
+	}
+	if config.TLSSecret != "" {
+		return nil
+	}
+	if config.TLSCertFile, err = w.askRequired("Certificate file", ""); err != nil {
+		return err
+	}
+	if config.TLSKeyFile, err = w.askRequired("Key file", ""); err != nil {
+		return err
+	}
+	cert, err := w.readFile(config.TLSCertFile)
+	if err != nil {
+		return errors.Wrapf(err, "could not read certificate")
+	}
+	key, err := w.readFile(config.TLSKeyFile)
+	if err != nil {
+		return errors.Wrapf(err, "could not read key")
+	}
+	config.TLSCert, config.TLSKey = string(cert), string(key)
+	return nil
+}
+
+// writeDeclaration writes the Helm values for config to path.
+func writeDeclaration(path string, config *initConfig) error {
+	data, err := yaml.Marshal(config.values())
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	return errors.EnsureStack(ioutil.WriteFile(path, data, 0600))
+}
+
+// InitCmd returns a cobra.Command that walks an operator through configuring a
+// new cluster.
+func InitCmd() *cobra.Command {
+	var output string
+	var apply, noCheck bool
+	initCmd := &cobra.Command{
+		Short: "Interactively configure a new Pachyderm cluster.",
+		Long: "Interactively configure a new Pachyderm cluster. This asks for the storage backend, " +
+			"whether to activate authentication, which identity provider to use and how to set up TLS, " +
+			"then writes a Helm values file that deploys a cluster configured that way. " +
+			"Identity provider configurations are validated against the current cluster, if there is one.",
+		Example: "\t- {{alias}}\n" +
+			"\t- {{alias}} --output my-values.yaml\n" +
+			"\t- {{alias}} --apply",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			wizard := newInitWizard(os.Stdin, os.Stdout)
+			var c *client.APIClient
+			if !noCheck || apply {
+				var err error
+				c, err = client.NewOnUserMachine("user")
+				if err != nil {
+					if apply {
+						return err
+					}
+					fmt.Fprintf(os.Stderr, "could not connect to a cluster, identity providers won't be validated: %v\n", err)
+				} else {
+					defer c.Close()
+				}
+			}
+			if c != nil && !noCheck {
+				wizard.check = func(connector *identity.IDPConnector) ([]string, error) {
+					resp, err := c.CheckIdentityProvider(c.Ctx(), &identity.CheckIdentityProviderRequest{Connector: connector})
+					if err != nil {
+						return nil, grpcutil.ScrubGRPC(err)
+					}
+					return resp.Warnings, nil
+				}
+			}
+			config, err := wizard.run()
+			if err != nil {
+				return err
+			}
+			if err := writeDeclaration(output, config); err != nil {
+				return err
+			}
+			fmt.Printf("Wrote cluster configuration to %s, deploy it with:\n  helm install pachd pach/pachyderm -f %s\n", output, output)
+			if apply && config.Connector != nil {
+				if _, err := c.CreateIDPConnector(c.Ctx(), &identity.CreateIDPConnectorRequest{Connector: config.Connector}); err != nil {
+					return grpcutil.ScrubGRPC(err)
+				}
+				fmt.Printf("Created identity provider connector %q\n", config.Connector.Id)
+			}
+			return nil
+		}),
+	}
+	initCmd.Flags().StringVarP(&output, "output", "o", "values.yaml", "The file to write the cluster's Helm values to.")
+	initCmd.Flags().BoolVar(&apply, "apply", false, "Also create the identity provider connector on the current cluster.")
+	initCmd.Flags().BoolVar(&noCheck, "no-check", false, "Don't validate the identity provider configuration against the current cluster.")
+	return cmdutil.CreateAlias(initCmd, "init")
+}
@@ -0,0 +1,130 @@
+package cmds
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/identity"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestInitWizard(t *testing.T) {
+	answers := []string{
+		"minio",             // storage backend
+		"minio:9000",        // endpoint
+		"",                  // bucket is required
+		"pachyderm",         // bucket
+		"id",                // access key ID
+		"secret",            // secret access key
+		"",                  // activate auth
+		"license",           // license key
+		"y",                 // configure an IdP
+		"",                  // IdP type
+		"okta",              // connector ID
+		"https://bad",       // issuer
+		"client",            // client ID
+		"shh",               // client secret
+		"",                  // redirect URI
+		"yes",               // re-enter after a failed check
+		"oidc",              // IdP type
+		"okta",              // connector ID
+		"https://okta.test", // issuer
+		"client",            // client ID
+		"shh",               // client secret
+		"",                  // redirect URI
+		"y",                 // enable TLS
+		"",                  // no existing secret
+		"tls.crt",           // certificate
+		"tls.key",           // key
+	}
+	var out bytes.Buffer
+	w := newInitWizard(strings.NewReader(strings.Join(answers, "\n")+"\n"), &out)
+	var checked int
+	w.check = func(connector *identity.IDPConnector) ([]string, error) {
+		checked++
+		var config map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(connector.JsonConfig), &config))
+		if config["issuer"] == "https://bad" {
+			return nil, errors.New("issuer unreachable")
+		}
+		return []string{"redirect URI looks wrong"}, nil
+	}
+	w.readFile = func(path string) ([]byte, error) {
+		return []byte("contents of " + path), nil
+	}
+
+	config, err := w.run()
+	require.NoError(t, err)
+	require.Equal(t, 2, checked)
+	require.True(t, strings.Contains(out.String(), "A value is required."))
+	require.True(t, strings.Contains(out.String(), "issuer unreachable"))
+	require.True(t, strings.Contains(out.String(), "WARNING: redirect URI looks wrong"))
+
+	values := config.values()
+	_, ok := values["deployTarget"]
+	require.False(t, ok)
+	pachd := values["pachd"].(map[string]interface{})
+	require.Equal(t, true, pachd["activateAuth"])
+	require.Equal(t, "license", pachd["enterpriseLicenseKey"])
+	storage := pachd["storage"].(map[string]interface{})
+	require.Equal(t, "MINIO", storage["backend"])
+	require.Equal(t, map[string]interface{}{
+		"bucket":   "pachyderm",
+		"endpoint": "minio:9000",
+		"id":       "id",
+		"secret":   "secret",
+	}, storage["minio"])
+	require.Equal(t, map[string]interface{}{
+		"enabled": true,
+		"newSecret": map[string]interface{}{
+			"create": true,
+			"crt":    "contents of tls.crt",
+			"key":    "contents of tls.key",
+		},
+	}, pachd["tls"])
+
+	oidc := values["oidc"].(map[string]interface{})
+	require.Equal(t, false, oidc["mockIDP"])
+	idp := oidc["upstreamIDPs"].([]interface{})[0].(map[string]interface{})
+	require.Equal(t, "okta", idp["id"])
+	require.Equal(t, "oidc", idp["type"])
+	var jsonConfig map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(idp["jsonConfig"].(string)), &jsonConfig))
+	require.Equal(t, "https://okta.test", jsonConfig["issuer"])
+	require.Equal(t, "http://localhost:30658/callback", jsonConfig["redirectURI"])
+}
+
+func TestInitWizardDefaults(t *testing.T) {
+	answers := []string{
+		"",       // storage backend
+		"bucket", // bucket
+		"",       // region
+		"",       // access key ID
+		"n",      // activate auth
+		"",       // enable TLS
+	}
+	w := newInitWizard(strings.NewReader(strings.Join(answers, "\n")+"\n"), &bytes.Buffer{})
+	config, err := w.run()
+	require.NoError(t, err)
+	values := config.values()
+	require.Equal(t, "AMAZON", values["deployTarget"])
+	_, ok := values["oidc"]
+	require.False(t, ok)
+	pachd := values["pachd"].(map[string]interface{})
+	require.Equal(t, false, pachd["activateAuth"])
+	_, ok = pachd["tls"]
+	require.False(t, ok)
+	require.Equal(t, map[string]interface{}{
+		"bucket": "bucket",
+		"region": "us-east-1",
+		"id":     "",
+		"secret": "",
+	}, pachd["storage"].(map[string]interface{})["amazon"])
+
+	// running out of answers is an error rather than a loop
+	_, err = newInitWizard(strings.NewReader("AMAZON\n"), &bytes.Buffer{}).run()
+	require.YesError(t, err)
+}
@@ -0,0 +1,42 @@
+package pretty
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pretty"
+)
+
+// BackupHeader is the header for backups.
+const BackupHeader = "ID\tSTARTED\tDURATION\tVERSION\tMIGRATION\tMETADATA\tCHUNKS\tNEW CHUNKS\t\n"
+
+// PrintBackupInfo pretty-prints a backup.
+func PrintBackupInfo(w io.Writer, b *admin.BackupInfo) {
+	fmt.Fprintf(w, "%s\t", b.ID)
+	fmt.Fprintf(w, "%s\t", pretty.Ago(b.Started))
+	fmt.Fprintf(w, "%s\t", pretty.TimeDifference(b.Started, b.Finished))
+	fmt.Fprintf(w, "%s\t", b.Version)
+	fmt.Fprintf(w, "%d\t", b.Migration)
+	fmt.Fprintf(w, "%s\t", pretty.Size(b.MetadataBytes))
+	fmt.Fprintf(w, "%d (%s)\t", b.Chunks, pretty.Size(b.ChunkBytes))
+	fmt.Fprintf(w, "%d (%s)\t\n", b.NewChunks, pretty.Size(b.NewChunkBytes))
+}
+
+// PrintDetailedBackupInfo pretty-prints a backup, with its contents.
+func PrintDetailedBackupInfo(w io.Writer, b *admin.BackupInfo) {
+	fmt.Fprintf(w, "Backup: %s\n", b.ID)
+	fmt.Fprintf(w, "Location: %s\n", b.Location)
+	fmt.Fprintf(w, "Started: %s\n", pretty.Ago(b.Started))
+	fmt.Fprintf(w, "Duration: %s\n", pretty.TimeDifference(b.Started, b.Finished))
+	fmt.Fprintf(w, "Version: %s\n", b.Version)
+	fmt.Fprintf(w, "Migration: %d\n", b.Migration)
+	if b.Parent != "" {
+		fmt.Fprintf(w, "Parent: %s\n", b.Parent)
+	}
+	fmt.Fprintf(w, "Tables: %d (%d rows)\n", b.Tables, b.Rows)
+	fmt.Fprintf(w, "Etcd keys: %d, at revision %d\n", b.EtcdKeys, b.EtcdRevision)
+	fmt.Fprintf(w, "Metadata: %s\n", pretty.Size(b.MetadataBytes))
+	fmt.Fprintf(w, "Chunks: %d (%s)\n", b.Chunks, pretty.Size(b.ChunkBytes))
+	fmt.Fprintf(w, "New chunks: %d (%s)\n", b.NewChunks, pretty.Size(b.NewChunkBytes))
+}
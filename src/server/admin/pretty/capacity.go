@@ -0,0 +1,50 @@
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pretty"
+)
+
+// CapacityHeader is the header for the resources of a capacity report.
+const CapacityHeader = "RESOURCE\tUSED\tCAPACITY\tGROWTH/DAY\tFULL IN\t\n"
+
+// PrintResourceCapacity pretty-prints a resource of a capacity report.
+func PrintResourceCapacity(w io.Writer, rc *admin.ResourceCapacity) {
+	fmt.Fprintf(w, "%s\t", rc.Resource)
+	fmt.Fprintf(w, "%s\t", pretty.Size(rc.UsedBytes))
+	if rc.CapacityBytes > 0 {
+		fmt.Fprintf(w, "%s (%.0f%%)\t", pretty.Size(rc.CapacityBytes), 100*float64(rc.UsedBytes)/float64(rc.CapacityBytes))
+	} else {
+		fmt.Fprintf(w, "-\t")
+	}
+	if rc.GrowthBytesPerDay < 0 {
+		fmt.Fprintf(w, "-%s\t", pretty.Size(int64(-rc.GrowthBytesPerDay)))
+	} else {
+		fmt.Fprintf(w, "%s\t", pretty.Size(int64(rc.GrowthBytesPerDay)))
+	}
+	fmt.Fprintf(w, "%s\t", fullIn(rc.FullIn))
+	fmt.Fprintln(w)
+}
+
+func fullIn(d *types.Duration) string {
+	if d == nil {
+		return "-"
+	}
+	fullIn, err := types.DurationFromProto(d)
+	if err != nil {
+		return "-"
+	}
+	if fullIn == 0 {
+		return "full"
+	}
+	if fullIn < 48*time.Hour {
+		return fmt.Sprintf("%.0f hours", fullIn.Hours())
+	}
+	return fmt.Sprintf("%.0f days", fullIn.Hours()/24)
+}
@@ -0,0 +1,110 @@
+package pretty
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pretty"
+)
+
+// WebhookHeader is the header for webhooks.
+const WebhookHeader = "NAME\tURL\tEVENTS\tSUCCEEDED\tFAILED\tLAST DELIVERY\t\n"
+
+// PrintWebhookInfo pretty-prints webhook info.
+func PrintWebhookInfo(w io.Writer, webhookInfo *admin.WebhookInfo) {
+	fmt.Fprintf(w, "%s\t", webhookInfo.Webhook.Name)
+	fmt.Fprintf(w, "%s\t", webhookInfo.Webhook.URL)
+	fmt.Fprintf(w, "%s\t", events(webhookInfo.Webhook.Events))
+	fmt.Fprintf(w, "%d\t", webhookInfo.Succeeded)
+	fmt.Fprintf(w, "%d\t", webhookInfo.Failed)
+	if len(webhookInfo.Deliveries) > 0 {
+		fmt.Fprintf(w, "%s\t", deliveryStatus(webhookInfo.Deliveries[0]))
+	} else {
+		fmt.Fprintf(w, "-\t")
+	}
+	fmt.Fprintln(w)
+}
+
+// PrintDetailedWebhookInfo pretty-prints detailed webhook info, including
+// its recent deliveries.
+func PrintDetailedWebhookInfo(webhookInfo *admin.WebhookInfo) error {
+	template, err := template.New("WebhookInfo").Funcs(funcMap).Parse(
+		`Name: {{.Webhook.Name}}
+URL: {{.Webhook.URL}}
+Created: {{prettyAgo .Created}}
+Events: {{events .Webhook.Events}}{{if .Webhook.Repos}}
+Repos: {{commafy .Webhook.Repos}}{{end}}{{if .Webhook.Pipelines}}
+Pipelines: {{commafy .Webhook.Pipelines}}{{end}}
+Succeeded: {{.Succeeded}}
+Failed: {{.Failed}}{{if .Deliveries}}
+Recent Deliveries:{{range .Deliveries}}
+  {{.Event.ID}} {{.Event.Type}} {{prettyAgo .Event.Time}}: {{deliveryStatus .}}{{end}}{{end}}
+`)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	return errors.EnsureStack(template.Execute(os.Stdout, webhookInfo))
+}
+
+// InflightRequestHeader is the header for in-flight requests.
+const InflightRequestHeader = "ID\tMETHOD\tCALLER\tPEER\tAGE\tRECEIVED\tSENT\t\n"
+
+// PrintInflightRequest pretty-prints an in-flight request.
+func PrintInflightRequest(w io.Writer, request *admin.InflightRequest) {
+	fmt.Fprintf(w, "%s\t", request.ID)
+	fmt.Fprintf(w, "%s\t", request.Method)
+	if request.Caller != "" {
+		fmt.Fprintf(w, "%s\t", request.Caller)
+	} else {
+		fmt.Fprintf(w, "-\t")
+	}
+	fmt.Fprintf(w, "%s\t", request.Peer)
+	fmt.Fprintf(w, "%s\t", pretty.Duration(request.Age))
+	fmt.Fprintf(w, "%s\t", messages(request.MessagesReceived, request.BytesReceived))
+	fmt.Fprintf(w, "%s\t", messages(request.MessagesSent, request.BytesSent))
+	fmt.Fprintln(w)
+}
+
+func messages(n, size int64) string {
+	return fmt.Sprintf("%d (%s)", n, pretty.Size(size))
+}
+
+func events(events []admin.WebhookEventType) string {
+	if len(events) == 0 {
+		return "all"
+	}
+	var names []string
+	for _, e := range events {
+		names = append(names, e.String())
+	}
+	return strings.Join(names, ", ")
+}
+
+func deliveryStatus(delivery *admin.WebhookDelivery) string {
+	var status string
+	switch {
+	case delivery.Succeeded:
+		status = "succeeded"
+	case delivery.Finished != nil:
+		status = "failed"
+	default:
+		status = "retrying"
+	}
+	status = fmt.Sprintf("%s after %d attempt(s)", status, delivery.Attempts)
+	if delivery.Error != "" && !delivery.Succeeded {
+		status += ": " + delivery.Error
+	}
+	return status
+}
+
+var funcMap = template.FuncMap{
+	"prettyAgo":      pretty.Ago,
+	"commafy":        func(s []string) string { return strings.Join(s, ", ") },
+	"events":         events,
+	"deliveryStatus": deliveryStatus,
+}
@@ -0,0 +1,25 @@
+package pretty
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pretty"
+)
+
+// PrintReadOnlyState pretty-prints whether the cluster is read-only.
+func PrintReadOnlyState(w io.Writer, s *admin.ReadOnlyState) {
+	if !s.ReadOnly {
+		fmt.Fprintln(w, "The cluster is writable.")
+		return
+	}
+	fmt.Fprintln(w, "The cluster is read-only for maintenance.")
+	if s.Reason != "" {
+		fmt.Fprintf(w, "  Reason: %s\n", s.Reason)
+	}
+	if s.SetBy != "" {
+		fmt.Fprintf(w, "  Started by: %s\n", s.SetBy)
+	}
+	fmt.Fprintf(w, "  Started: %s\n", pretty.Ago(s.Since))
+}
@@ -0,0 +1,39 @@
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pretty"
+)
+
+// ReplicaHeader is the header for pachd replicas.
+const ReplicaHeader = "POD\tVERSION\tMIGRATION\tPHASE\tSTARTED\t\n"
+
+// PrintReplicaInfo pretty-prints a pachd replica.
+func PrintReplicaInfo(w io.Writer, r *admin.ReplicaInfo) {
+	fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t\n", r.Pod, r.Version, r.Migration, strings.ToLower(r.Phase.String()), pretty.Ago(r.Started))
+}
+
+// PrintUpgradeStatus pretty-prints the progress of an upgrade.
+func PrintUpgradeStatus(w io.Writer, s *admin.UpgradeStatus) {
+	fmt.Fprintf(w, "Upgrade to %s: %s\n", s.LeaderVersion, strings.ToLower(s.Phase.String()))
+	fmt.Fprintf(w, "  Leader: %s\n", s.Leader)
+	fmt.Fprintf(w, "  Migrations: %d to %d\n", s.FromMigration, s.ToMigration)
+	fmt.Fprintf(w, "  Started: %s\n", pretty.Ago(s.Started))
+	if s.Finished != nil {
+		fmt.Fprintf(w, "  Duration: %s\n", pretty.TimeDifference(s.Started, s.Finished))
+	}
+	for i, m := range s.Pending {
+		if i == 0 && s.Phase == admin.UpgradeStatus_MIGRATING && m.Started != nil {
+			fmt.Fprintf(w, "  Applying: %d (%s), for %s\n", m.Number, m.Name, pretty.Since(m.Started))
+		} else {
+			fmt.Fprintf(w, "  Pending: %d (%s)\n", m.Number, m.Name)
+		}
+	}
+	if s.Error != "" {
+		fmt.Fprintf(w, "  Error: %s\n", s.Error)
+	}
+}
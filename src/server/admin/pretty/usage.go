@@ -0,0 +1,149 @@
+package pretty
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pretty"
+)
+
+const (
+	// RepoUsageHeader is the header for the repos of a usage report.
+	RepoUsageHeader = "REPO\tSIZE\tOWNERS\t\n"
+	// PipelineUsageHeader is the header for the pipelines of a usage report.
+	PipelineUsageHeader = "PIPELINE\tJOBS\tCOMPUTE HOURS\tCPU HOURS\tEGRESS\tCOST\tOWNERS\t\n"
+	// UserUsageHeader is the header for the users of a usage report.
+	UserUsageHeader = "PRINCIPAL\tSTORAGE\tCOMPUTE HOURS\tCPU HOURS\tEGRESS\tCOST\t\n"
+)
+
+// PrintRepoUsage pretty-prints a repo's usage.
+func PrintRepoUsage(w io.Writer, usage *admin.RepoUsage) {
+	fmt.Fprintf(w, "%s\t", usage.Repo)
+	fmt.Fprintf(w, "%s\t", pretty.Size(usage.SizeBytes))
+	fmt.Fprintf(w, "%s\t", owners(usage.Owners))
+	fmt.Fprintln(w)
+}
+
+// PrintPipelineUsage pretty-prints a pipeline's usage.
+func PrintPipelineUsage(w io.Writer, usage *admin.PipelineUsage) {
+	fmt.Fprintf(w, "%s\t", usage.Pipeline)
+	fmt.Fprintf(w, "%d\t", usage.Jobs)
+	fmt.Fprintf(w, "%.2f\t", usage.ComputeSeconds/3600)
+	fmt.Fprintf(w, "%.2f\t", usage.CpuSeconds/3600)
+	fmt.Fprintf(w, "%s\t", pretty.Size(usage.EgressBytes))
+	fmt.Fprintf(w, "%.2f\t", usage.Cost)
+	fmt.Fprintf(w, "%s\t", owners(usage.Owners))
+	fmt.Fprintln(w)
+}
+
+// PrintUserUsage pretty-prints the usage attributed to a principal.
+func PrintUserUsage(w io.Writer, usage *admin.UserUsage) {
+	if usage.Principal != "" {
+		fmt.Fprintf(w, "%s\t", usage.Principal)
+	} else {
+		fmt.Fprintf(w, "(unowned)\t")
+	}
+	fmt.Fprintf(w, "%s\t", pretty.Size(usage.StorageBytes))
+	fmt.Fprintf(w, "%.2f\t", usage.ComputeSeconds/3600)
+	fmt.Fprintf(w, "%.2f\t", usage.CpuSeconds/3600)
+	fmt.Fprintf(w, "%s\t", pretty.Size(usage.EgressBytes))
+	fmt.Fprintf(w, "%.2f\t", usage.Cost)
+	fmt.Fprintln(w)
+}
+
+func owners(owners []string) string {
+	if len(owners) == 0 {
+		return "-"
+	}
+	return strings.Join(owners, ", ")
+}
+
+// WriteUsageReportCSV writes a usage report as CSV, with a row for each
+// repo, pipeline and user. Columns that don't apply to a row are empty.
+func WriteUsageReportCSV(w io.Writer, report *admin.UsageReport) error {
+	cw := csv.NewWriter(w)
+	rows := [][]string{{"kind", "name", "owners", "storage_bytes", "jobs", "compute_seconds", "cpu_seconds", "egress_bytes", "cost"}}
+	for _, r := range report.Repos {
+		rows = append(rows, []string{"repo", r.Repo, strings.Join(r.Owners, " "), formatInt(r.SizeBytes), "", "", "", "", ""})
+	}
+	for _, p := range report.Pipelines {
+		rows = append(rows, []string{"pipeline", p.Pipeline, strings.Join(p.Owners, " "), "", formatInt(p.Jobs),
+			formatFloat(p.ComputeSeconds), formatFloat(p.CpuSeconds), formatInt(p.EgressBytes), formatFloat(p.Cost)})
+	}
+	for _, u := range report.Users {
+		rows = append(rows, []string{"user", u.Principal, "", formatInt(u.StorageBytes), "",
+			formatFloat(u.ComputeSeconds), formatFloat(u.CpuSeconds), formatInt(u.EgressBytes), formatFloat(u.Cost)})
+	}
+	return errors.EnsureStack(cw.WriteAll(rows))
+}
+
+func formatInt(i int64) string     { return strconv.FormatInt(i, 10) }
+func formatFloat(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+
+// WriteUsageReportMetrics writes a usage report in the Prometheus text
+// format, for example for node_exporter's textfile collector. Each value is
+// a gauge of the total over the report's window.
+func WriteUsageReportMetrics(w io.Writer, report *admin.UsageReport) error {
+	var families []*dto.MetricFamily
+	family := func(name, help string) *dto.MetricFamily {
+		f := &dto.MetricFamily{
+			Name: proto.String("pachyderm_usage_" + name),
+			Help: proto.String(help),
+			Type: dto.MetricType_GAUGE.Enum(),
+		}
+		families = append(families, f)
+		return f
+	}
+	add := func(f *dto.MetricFamily, label, value string, v float64) {
+		f.Metric = append(f.Metric, &dto.Metric{
+			Label: []*dto.LabelPair{{Name: proto.String(label), Value: proto.String(value)}},
+			Gauge: &dto.Gauge{Value: proto.Float64(v)},
+		})
+	}
+	repoSize := family("repo_size_bytes", "The size of the repo.")
+	for _, r := range report.Repos {
+		add(repoSize, "repo", r.Repo, float64(r.SizeBytes))
+	}
+	pipelineJobs := family("pipeline_jobs", "The number of the pipeline's jobs that finished in the window.")
+	pipelineCompute := family("pipeline_compute_seconds", "The worker time the pipeline's jobs used.")
+	pipelineCPU := family("pipeline_cpu_seconds", "The CPU time the pipeline's user code used.")
+	pipelineEgress := family("pipeline_egress_bytes", "The bytes the pipeline's jobs egressed.")
+	pipelineCost := family("pipeline_cost", "The cost of the pipeline's jobs at their budget's worker hour cost.")
+	for _, p := range report.Pipelines {
+		add(pipelineJobs, "pipeline", p.Pipeline, float64(p.Jobs))
+		add(pipelineCompute, "pipeline", p.Pipeline, p.ComputeSeconds)
+		add(pipelineCPU, "pipeline", p.Pipeline, p.CpuSeconds)
+		add(pipelineEgress, "pipeline", p.Pipeline, float64(p.EgressBytes))
+		add(pipelineCost, "pipeline", p.Pipeline, p.Cost)
+	}
+	userStorage := family("user_storage_bytes", "The size of the repos the principal owns.")
+	userCompute := family("user_compute_seconds", "The worker time the principal's pipelines used.")
+	userCPU := family("user_cpu_seconds", "The CPU time the principal's pipelines used.")
+	userEgress := family("user_egress_bytes", "The bytes the principal's pipelines egressed.")
+	userCost := family("user_cost", "The cost of the principal's pipelines.")
+	for _, u := range report.Users {
+		add(userStorage, "principal", u.Principal, float64(u.StorageBytes))
+		add(userCompute, "principal", u.Principal, u.ComputeSeconds)
+		add(userCPU, "principal", u.Principal, u.CpuSeconds)
+		add(userEgress, "principal", u.Principal, float64(u.EgressBytes))
+		add(userCost, "principal", u.Principal, u.Cost)
+	}
+	for _, f := range families {
+		if len(f.Metric) == 0 {
+			continue
+		}
+		if _, err := expfmt.MetricFamilyToText(w, f); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+)
+
+// ConfigurationProblemHeader is the header for configuration problems.
+const ConfigurationProblemHeader = "SEVERITY\tVALUE\tPROBLEM\t\n"
+
+// PrintConfigurationProblem pretty-prints a configuration problem.
+func PrintConfigurationProblem(w io.Writer, p *admin.ConfigurationProblem) {
+	path := p.Path
+	if path == "" {
+		path = "-"
+	}
+	fmt.Fprintf(w, "%s\t%s\t%s\t\n", strings.ToLower(p.Severity.String()), path, p.Message)
+}
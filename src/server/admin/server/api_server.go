@@ -3,24 +3,47 @@ package server
 import (
 	"github.com/gogo/protobuf/types"
 	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/middleware/inflight"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
 	"github.com/pachyderm/pachyderm/v2/src/internal/serviceenv"
 	"github.com/sirupsen/logrus"
+	etcd "go.etcd.io/etcd/client/v3"
 
 	"golang.org/x/net/context"
 )
 
 // Env is the set of dependencies required by an APIServer
 type Env struct {
-	ClusterID string
-	Config    *serviceenv.Configuration
-	Logger    *logrus.Logger
+	ClusterID  string
+	Config     *serviceenv.Configuration
+	Logger     *logrus.Logger
+	DB         *pachsql.DB
+	Listener   col.PostgresListener
+	EtcdClient *etcd.Client
+	// GetPachClient returns a client that makes requests on behalf of the
+	// caller in ctx.
+	GetPachClient func(context.Context) *client.APIClient
+	// Requests tracks the requests this pachd is handling, nil if they're
+	// not tracked.
+	Requests *inflight.Tracker
+
+	BackgroundContext context.Context
 }
 
 func EnvFromServiceEnv(senv serviceenv.ServiceEnv) Env {
 	return Env{
-		ClusterID: senv.ClusterID(),
-		Config:    senv.Config(),
-		Logger:    senv.Logger(),
+		ClusterID:  senv.ClusterID(),
+		Config:     senv.Config(),
+		Logger:     senv.Logger(),
+		DB:         senv.GetDBClient(),
+		Listener:   senv.GetPostgresListener(),
+		EtcdClient: senv.GetEtcdClient(),
+
+		GetPachClient:     senv.GetPachClient,
+		BackgroundContext: senv.Context(),
 	}
 }
 
@@ -31,16 +54,36 @@ type APIServer interface {
 
 // NewAPIServer returns a new admin.APIServer
 func NewAPIServer(env Env) APIServer {
+	return newAPIServer(env)
+}
+
+func newAPIServer(env Env) *apiServer {
+	clusterInfo := &admin.ClusterInfo{
+		ID:           env.ClusterID,
+		DeploymentID: env.Config.DeploymentID,
+	}
+	if env.Config.PachdSpecificConfiguration != nil {
+		// an invalid policy stops PFS from starting, so it's only reported
+		// when it's valid
+		if compressors, err := grpcutil.ParseCompressionPolicy(env.Config.StorageStreamCompression); err == nil {
+			clusterInfo.StreamCompressors = compressors
+		}
+	}
 	return &apiServer{
-		clusterInfo: &admin.ClusterInfo{
-			ID:           env.ClusterID,
-			DeploymentID: env.Config.DeploymentID,
-		},
+		env:         env,
+		clusterInfo: clusterInfo,
+		webhooks:    webhooksCollection(env.DB, env.Listener),
+
+		clusterDefaults: ClusterDefaultsCollection(env.DB, env.Listener),
 	}
 }
 
 type apiServer struct {
+	env         Env
 	clusterInfo *admin.ClusterInfo
+	webhooks    col.PostgresCollection
+
+	clusterDefaults col.PostgresCollection
 }
 
 func (a *apiServer) InspectCluster(ctx context.Context, request *types.Empty) (*admin.ClusterInfo, error) {
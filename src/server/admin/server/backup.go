@@ -0,0 +1,95 @@
+package server
+
+import (
+	"path"
+
+	"golang.org/x/net/context"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/backup"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dlock"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
+)
+
+// backupLockPath is the lock that backups and restores hold, so that only one
+// runs in the cluster at a time.
+const backupLockPath = "admin-backup-lock"
+
+// backupLocation returns the location of a backup request, or pachd's
+// default location if it doesn't set one.
+func (a *apiServer) backupLocation(location string) string {
+	if location == "" && a.env.Config.PachdSpecificConfiguration != nil {
+		return a.env.Config.BackupLocation
+	}
+	return location
+}
+
+func (a *apiServer) backupEnv() (backup.Env, error) {
+	if a.env.Config.PachdSpecificConfiguration == nil {
+		return backup.Env{}, errors.New("the enterprise server can't be backed up with the backup API")
+	}
+	objClient, err := obj.NewClient(a.env.Config.StorageBackend, a.env.Config.StorageRoot)
+	if err != nil {
+		return backup.Env{}, err
+	}
+	return backup.Env{
+		DB:           a.env.DB,
+		EtcdClient:   a.env.EtcdClient,
+		ObjectClient: objClient,
+	}, nil
+}
+
+// withBackupLock calls cb while holding the backup lock.
+func (a *apiServer) withBackupLock(ctx context.Context, cb func(context.Context) error) error {
+	lock := dlock.NewDLock(a.env.EtcdClient, path.Join(a.env.Config.EtcdPrefix, backupLockPath))
+	lockCtx, err := lock.Lock(ctx)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer lock.Unlock(lockCtx)
+	return cb(lockCtx)
+}
+
+func (a *apiServer) Backup(ctx context.Context, request *admin.BackupRequest) (*admin.BackupInfo, error) {
+	env, err := a.backupEnv()
+	if err != nil {
+		return nil, err
+	}
+	var info *admin.BackupInfo
+	if err := a.withBackupLock(ctx, func(ctx context.Context) error {
+		var err error
+		info, err = backup.Backup(ctx, env, a.backupLocation(request.Location))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (a *apiServer) ListBackups(request *admin.ListBackupsRequest, server admin.API_ListBackupsServer) error {
+	return backup.List(server.Context(), a.backupLocation(request.Location), func(info *admin.BackupInfo) error {
+		return errors.EnsureStack(server.Send(info))
+	})
+}
+
+func (a *apiServer) VerifyBackup(ctx context.Context, request *admin.VerifyBackupRequest) (*admin.VerifyBackupResponse, error) {
+	return backup.Verify(ctx, a.backupLocation(request.Location), request.ID, request.Deep)
+}
+
+func (a *apiServer) Restore(ctx context.Context, request *admin.RestoreRequest) (*admin.RestoreResponse, error) {
+	env, err := a.backupEnv()
+	if err != nil {
+		return nil, err
+	}
+	var response *admin.RestoreResponse
+	if err := a.withBackupLock(ctx, func(ctx context.Context) error {
+		var err error
+		response, err = backup.Restore(ctx, env, a.backupLocation(request.Location), request.ID, request.Force)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	a.env.Logger.Infof("restored backup %s from %s; pachd must be restarted", response.Backup.ID, response.Backup.Location)
+	return response, nil
+}
@@ -0,0 +1,315 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dlock"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/serviceenv"
+)
+
+const (
+	capacityAnalyzerLockPath = "admin-capacity-analyzer-lock"
+	capacitySampleInterval   = time.Hour
+	capacitySampleRetention  = 30 * 24 * time.Hour
+	// defaultCapacityWindow is how far back the samples a capacity report's
+	// growth is computed from go, if its request doesn't set a window.
+	defaultCapacityWindow = 7 * 24 * time.Hour
+	// A resource gets a recommendation if it's projected to be full within
+	// capacityWarningPeriod, or if it's at least capacityWarningFraction full.
+	capacityWarningPeriod   = 90 * 24 * time.Hour
+	capacityWarningFraction = 0.85
+)
+
+// capacitySample is the usage of each resource at a point in time.
+type capacitySample struct {
+	Time               time.Time `db:"time"`
+	PostgresBytes      int64     `db:"postgres_bytes"`
+	MetadataBytes      int64     `db:"metadata_bytes"`
+	ObjectStorageBytes int64     `db:"object_storage_bytes"`
+	EtcdBytes          int64     `db:"etcd_bytes"`
+}
+
+type capacityResource struct {
+	name string
+	// label names the resource in recommendations.
+	label string
+	used  func(*capacitySample) int64
+	// capacity returns the resource's capacity from the configuration, 0
+	// if it isn't known.
+	capacity func(*serviceenv.PachdSpecificConfiguration) (int64, error)
+	// advice is what a recommendation suggests doing about the resource
+	// running out.
+	advice string
+}
+
+// capacityResources are the resources a capacity report covers, in the order
+// they're reported.
+var capacityResources = []capacityResource{
+	{
+		name:  "postgres",
+		label: "postgres disk",
+		used:  func(s *capacitySample) int64 { return s.PostgresBytes },
+		capacity: func(c *serviceenv.PachdSpecificConfiguration) (int64, error) {
+			return parseCapacity("CAPACITY_POSTGRES_SIZE", c.CapacityPostgresSize)
+		},
+		advice: "grow postgres's volume, or delete unused repos and pipelines",
+	},
+	{
+		name:     "metadata",
+		label:    "metadata",
+		used:     func(s *capacitySample) int64 { return s.MetadataBytes },
+		capacity: func(*serviceenv.PachdSpecificConfiguration) (int64, error) { return 0, nil },
+	},
+	{
+		name:  "object-storage",
+		label: "object storage",
+		used:  func(s *capacitySample) int64 { return s.ObjectStorageBytes },
+		capacity: func(c *serviceenv.PachdSpecificConfiguration) (int64, error) {
+			return parseCapacity("CAPACITY_OBJECT_STORAGE_SIZE", c.CapacityObjectStorageSize)
+		},
+		advice: "raise the bucket's quota, or delete unused repos and commits so their chunks are garbage collected",
+	},
+	{
+		name:  "etcd",
+		label: "etcd database",
+		used:  func(s *capacitySample) int64 { return s.EtcdBytes },
+		capacity: func(c *serviceenv.PachdSpecificConfiguration) (int64, error) {
+			return parseCapacity("CAPACITY_ETCD_QUOTA", c.CapacityEtcdQuota)
+		},
+		advice: "raise etcd's --quota-backend-bytes and CAPACITY_ETCD_QUOTA, or compact and defragment etcd",
+	},
+}
+
+func parseCapacity(name, quantity string) (int64, error) {
+	if quantity == "" {
+		return 0, nil
+	}
+	q, err := resource.ParseQuantity(quantity)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid %s %q", name, quantity)
+	}
+	return q.Value(), nil
+}
+
+// SetupPostgresCapacitySamplesV0 runs SQL to create the table capacity
+// samples are stored in.
+// DO NOT MODIFY THIS FUNCTION
+// IT HAS BEEN USED IN A RELEASED MIGRATION
+func SetupPostgresCapacitySamplesV0(ctx context.Context, tx *pachsql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		CREATE SCHEMA IF NOT EXISTS admin;
+		CREATE TABLE admin.capacity_samples (
+			time TIMESTAMPTZ NOT NULL PRIMARY KEY,
+			postgres_bytes BIGINT NOT NULL,
+			metadata_bytes BIGINT NOT NULL,
+			object_storage_bytes BIGINT NOT NULL,
+			etcd_bytes BIGINT NOT NULL
+		);
+	`)
+	return errors.EnsureStack(err)
+}
+
+// RunCapacityAnalyzer samples the cluster's storage usage every hour, for
+// capacity reports to compute its growth from, until env.BackgroundContext
+// is done. Only one analyzer in the cluster samples at a time.
+func RunCapacityAnalyzer(env Env) {
+	a := newAPIServer(env)
+	ctx := env.BackgroundContext
+	lock := dlock.NewDLock(env.EtcdClient, path.Join(env.Config.EtcdPrefix, capacityAnalyzerLockPath))
+	backoff.RetryUntilCancel(ctx, func() error {
+		lockCtx, err := lock.Lock(ctx)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		defer lock.Unlock(lockCtx)
+		return a.sampleCapacity(lockCtx)
+	}, backoff.NewInfiniteBackOff(), func(err error, t time.Duration) error {
+		env.Logger.Errorf("error sampling capacity: %v; retrying in %v", err, t)
+		return nil
+	})
+}
+
+// sampleCapacity records a sample every capacitySampleInterval, and deletes
+// the samples older than capacitySampleRetention.
+func (a *apiServer) sampleCapacity(ctx context.Context) error {
+	ticker := time.NewTicker(capacitySampleInterval)
+	defer ticker.Stop()
+	for {
+		sample, err := a.takeCapacitySample(ctx)
+		if err != nil {
+			return err
+		}
+		if _, err := a.env.DB.NamedExecContext(ctx, `
+			INSERT INTO admin.capacity_samples (time, postgres_bytes, metadata_bytes, object_storage_bytes, etcd_bytes)
+			VALUES (:time, :postgres_bytes, :metadata_bytes, :object_storage_bytes, :etcd_bytes)
+			ON CONFLICT (time) DO NOTHING
+		`, sample); err != nil {
+			return errors.EnsureStack(err)
+		}
+		if _, err := a.env.DB.ExecContext(ctx, `DELETE FROM admin.capacity_samples WHERE time < $1`,
+			sample.Time.Add(-capacitySampleRetention)); err != nil {
+			return errors.EnsureStack(err)
+		}
+		select {
+		case <-ctx.Done():
+			return errors.EnsureStack(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *apiServer) takeCapacitySample(ctx context.Context) (*capacitySample, error) {
+	s := &capacitySample{Time: time.Now()}
+	if err := a.env.DB.GetContext(ctx, &s.PostgresBytes, `SELECT pg_database_size(current_database())`); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	// everything but file sets and the chunks they reference is metadata
+	if err := a.env.DB.GetContext(ctx, &s.MetadataBytes, `
+		SELECT COALESCE(SUM(pg_total_relation_size(format('%I.%I', schemaname, tablename)::regclass)), 0)::BIGINT
+		FROM pg_tables
+		WHERE schemaname NOT IN ('pg_catalog', 'information_schema', 'storage')
+	`); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := a.env.DB.GetContext(ctx, &s.ObjectStorageBytes, `
+		SELECT COALESCE(SUM(size), 0)::BIGINT FROM storage.chunk_objects WHERE uploaded AND NOT tombstone
+	`); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if a.env.EtcdClient != nil && len(a.env.EtcdClient.Endpoints()) > 0 {
+		status, err := a.env.EtcdClient.Status(ctx, a.env.EtcdClient.Endpoints()[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get etcd's status")
+		}
+		s.EtcdBytes = status.DbSize
+	}
+	return s, nil
+}
+
+func (a *apiServer) GetCapacityReport(ctx context.Context, request *admin.GetCapacityReportRequest) (*admin.CapacityReport, error) {
+	window := defaultCapacityWindow
+	if request.Window != nil {
+		var err error
+		if window, err = types.DurationFromProto(request.Window); err != nil {
+			return nil, errors.Wrapf(err, "invalid window")
+		}
+		if window <= 0 {
+			return nil, errors.Errorf("window (%v) must be positive", window)
+		}
+	}
+	config := &serviceenv.PachdSpecificConfiguration{}
+	if a.env.Config.PachdSpecificConfiguration != nil {
+		config = a.env.Config.PachdSpecificConfiguration
+	}
+	capacities := make(map[string]int64)
+	for _, r := range capacityResources {
+		c, err := r.capacity(config)
+		if err != nil {
+			return nil, err
+		}
+		capacities[r.name] = c
+	}
+	current, err := a.takeCapacitySample(ctx)
+	if err != nil {
+		return nil, err
+	}
+	windowStart := current.Time.Add(-window)
+	var samples []*capacitySample
+	if err := a.env.DB.SelectContext(ctx, &samples, `
+		SELECT time, postgres_bytes, metadata_bytes, object_storage_bytes, etcd_bytes
+		FROM admin.capacity_samples
+		WHERE time >= $1
+		ORDER BY time
+	`, windowStart); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return capacityReport(append(samples, current), windowStart, capacities)
+}
+
+// capacityReport projects the growth of each resource from samples, which
+// are in time order and end with the current usage.
+func capacityReport(samples []*capacitySample, windowStart time.Time, capacities map[string]int64) (*admin.CapacityReport, error) {
+	current := samples[len(samples)-1]
+	report := &admin.CapacityReport{Samples: int64(len(samples))}
+	var err error
+	if report.Time, err = types.TimestampProto(current.Time); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if report.WindowStart, err = types.TimestampProto(windowStart); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	for _, r := range capacityResources {
+		rc := &admin.ResourceCapacity{
+			Resource:          r.name,
+			UsedBytes:         r.used(current),
+			CapacityBytes:     capacities[r.name],
+			GrowthBytesPerDay: growthPerDay(samples, r.used),
+		}
+		if rc.CapacityBytes > 0 {
+			var fullIn time.Duration
+			full := rc.UsedBytes >= rc.CapacityBytes
+			if !full && rc.GrowthBytesPerDay > 0 {
+				// capped at a century, which time.Duration can hold
+				days := math.Min(float64(rc.CapacityBytes-rc.UsedBytes)/rc.GrowthBytesPerDay, 100*365)
+				fullIn = time.Duration(days * 24 * float64(time.Hour))
+			}
+			if full || fullIn > 0 {
+				rc.FullIn = types.DurationProto(fullIn)
+			}
+			fraction := float64(rc.UsedBytes) / float64(rc.CapacityBytes)
+			switch {
+			case full:
+				rc.Recommendation = fmt.Sprintf("%s is full; %s", r.label, r.advice)
+			case fullIn > 0 && fullIn < capacityWarningPeriod:
+				rc.Recommendation = fmt.Sprintf("%s full in %s; %s", r.label, approxDuration(fullIn), r.advice)
+			case fraction >= capacityWarningFraction:
+				rc.Recommendation = fmt.Sprintf("%s is %.0f%% full; %s", r.label, 100*fraction, r.advice)
+			}
+		}
+		report.Resources = append(report.Resources, rc)
+	}
+	return report, nil
+}
+
+// growthPerDay returns the slope, in bytes per day, of the least squares
+// line through the samples' usage of a resource.
+func growthPerDay(samples []*capacitySample, used func(*capacitySample) int64) float64 {
+	if len(samples) < 2 {
+		return 0
+	}
+	var n, sx, sy, sxx, sxy float64
+	for _, s := range samples {
+		x := s.Time.Sub(samples[0].Time).Hours() / 24
+		y := float64(used(s))
+		n++
+		sx += x
+		sy += y
+		sxx += x * x
+		sxy += x * y
+	}
+	d := n*sxx - sx*sx
+	if d == 0 {
+		return 0
+	}
+	return (n*sxy - sx*sy) / d
+}
+
+// approxDuration formats d as a rough number of days, or hours if it's less
+// than two days.
+func approxDuration(d time.Duration) string {
+	if d < 48*time.Hour {
+		return fmt.Sprintf("~%d hours", int64(math.Ceil(d.Hours())))
+	}
+	return fmt.Sprintf("~%d days", int64(d.Hours()/24))
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestCapacityReport(t *testing.T) {
+	start := time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)
+	var samples []*capacitySample
+	for day := 0; day <= 7; day++ {
+		samples = append(samples, &capacitySample{
+			Time:               start.Add(time.Duration(day) * 24 * time.Hour),
+			PostgresBytes:      1000 + int64(day)*10,
+			MetadataBytes:      100 + int64(day),
+			ObjectStorageBytes: 5000,
+			EtcdBytes:          900 - int64(day),
+		})
+	}
+	report, err := capacityReport(samples, start, map[string]int64{
+		"postgres":       1400,
+		"object-storage": 5500,
+		"etcd":           2000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(8), report.Samples)
+	require.Equal(t, 4, len(report.Resources))
+
+	postgres := report.Resources[0]
+	require.Equal(t, "postgres", postgres.Resource)
+	require.Equal(t, int64(1070), postgres.UsedBytes)
+	require.True(t, postgres.GrowthBytesPerDay > 9.99 && postgres.GrowthBytesPerDay < 10.01)
+	fullIn, err := types.DurationFromProto(postgres.FullIn)
+	require.NoError(t, err)
+	require.Equal(t, 33, int(fullIn.Hours()/24))
+	require.Matches(t, "^postgres disk full in ~33 days; ", postgres.Recommendation)
+
+	metadata := report.Resources[1]
+	require.Equal(t, "metadata", metadata.Resource)
+	require.Nil(t, metadata.FullIn)
+	require.Equal(t, "", metadata.Recommendation)
+
+	// object storage isn't growing, but is almost full
+	objectStorage := report.Resources[2]
+	require.Equal(t, 0.0, objectStorage.GrowthBytesPerDay)
+	require.Nil(t, objectStorage.FullIn)
+	require.Matches(t, "^object storage is 91% full; ", objectStorage.Recommendation)
+
+	// etcd is shrinking
+	etcd := report.Resources[3]
+	require.True(t, etcd.GrowthBytesPerDay < 0)
+	require.Nil(t, etcd.FullIn)
+	require.Equal(t, "", etcd.Recommendation)
+}
+
+func TestCapacityReportOneSample(t *testing.T) {
+	report, err := capacityReport([]*capacitySample{{Time: time.Now(), PostgresBytes: 10}}, time.Now(), map[string]int64{"postgres": 10})
+	require.NoError(t, err)
+	require.Equal(t, 0.0, report.Resources[0].GrowthBytesPerDay)
+	fullIn, err := types.DurationFromProto(report.Resources[0].FullIn)
+	require.NoError(t, err)
+	require.Equal(t, time.Duration(0), fullIn)
+	require.Matches(t, "^postgres disk is full; ", report.Resources[0].Recommendation)
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dbutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+const (
+	clusterDefaultsCollectionName = "cluster_defaults"
+	// clusterDefaultsKey is the key of the only record in the cluster
+	// defaults collection.
+	clusterDefaultsKey = "defaults"
+)
+
+var clusterDefaultsIndexes = []*col.Index{}
+
+// ClusterDefaultsCollection returns the collection holding the cluster's
+// defaults for pipeline specs.
+func ClusterDefaultsCollection(db *pachsql.DB, listener col.PostgresListener) col.PostgresCollection {
+	return col.NewPostgresCollection(
+		clusterDefaultsCollectionName,
+		db,
+		listener,
+		&admin.ClusterDefaults{},
+		clusterDefaultsIndexes,
+	)
+}
+
+// ClusterDefaultsCollectionsV0 returns the cluster defaults collection for
+// postgres-initialization purposes. This collection is not usable for
+// querying.
+// DO NOT MODIFY THIS FUNCTION
+// IT HAS BEEN USED IN A RELEASED MIGRATION
+func ClusterDefaultsCollectionsV0() []col.PostgresCollection {
+	return []col.PostgresCollection{
+		col.NewPostgresCollection(clusterDefaultsCollectionName, nil, nil, nil, clusterDefaultsIndexes),
+	}
+}
+
+// GetClusterDefaults reads the cluster's defaults from defaults, a
+// collection returned by ClusterDefaultsCollection. The defaults are empty
+// if none have been set.
+func GetClusterDefaults(ctx context.Context, defaults col.PostgresCollection) (*admin.ClusterDefaults, error) {
+	result := &admin.ClusterDefaults{}
+	if err := defaults.ReadOnly(ctx).Get(clusterDefaultsKey, result); err != nil && !col.IsErrNotFound(err) {
+		return nil, errors.EnsureStack(err)
+	}
+	return result, nil
+}
+
+func validateClusterDefaults(defaults *admin.ClusterDefaults) error {
+	for name, d := range map[string]*types.Duration{
+		"datum_timeout": defaults.DatumTimeout,
+		"job_timeout":   defaults.JobTimeout,
+	} {
+		if d == nil {
+			continue
+		}
+		duration, err := types.DurationFromProto(d)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %s", name)
+		}
+		if duration <= 0 {
+			return errors.Errorf("%s must be positive", name)
+		}
+	}
+	if defaults.DatumTries < 0 {
+		return errors.Errorf("datum_tries must be non-negative, got %d", defaults.DatumTries)
+	}
+	return pps.ValidateDatumRetryPolicy(defaults.DatumRetryPolicy)
+}
+
+func (a *apiServer) GetClusterDefaults(ctx context.Context, request *types.Empty) (*admin.ClusterDefaults, error) {
+	return GetClusterDefaults(ctx, a.clusterDefaults)
+}
+
+func (a *apiServer) SetClusterDefaults(ctx context.Context, request *admin.SetClusterDefaultsRequest) (*types.Empty, error) {
+	if request.Defaults != nil {
+		if err := validateClusterDefaults(request.Defaults); err != nil {
+			return nil, err
+		}
+	}
+	if err := dbutil.WithTx(ctx, a.env.DB, func(sqlTx *pachsql.Tx) error {
+		defaults := a.clusterDefaults.ReadWrite(sqlTx)
+		if request.Defaults == nil {
+			if err := defaults.Delete(clusterDefaultsKey); err != nil && !col.IsErrNotFound(err) {
+				return errors.EnsureStack(err)
+			}
+			return nil
+		}
+		return errors.EnsureStack(defaults.Put(clusterDefaultsKey, request.Defaults))
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
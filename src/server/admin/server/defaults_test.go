@@ -0,0 +1,18 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestValidateClusterDefaults(t *testing.T) {
+	require.NoError(t, validateClusterDefaults(&admin.ClusterDefaults{}))
+	require.NoError(t, validateClusterDefaults(&admin.ClusterDefaults{JobTimeout: types.DurationProto(3600e9), DatumTries: 5}))
+	require.YesError(t, validateClusterDefaults(&admin.ClusterDefaults{JobTimeout: types.DurationProto(0)}))
+	require.YesError(t, validateClusterDefaults(&admin.ClusterDefaults{DatumTimeout: types.DurationProto(-1)}))
+	require.YesError(t, validateClusterDefaults(&admin.ClusterDefaults{DatumTries: -1}))
+}
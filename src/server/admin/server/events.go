@@ -0,0 +1,262 @@
+package server
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/watch"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// eventPermissionTTL is how long a subscription remembers whether its caller
+// can read a repo.
+const eventPermissionTTL = time.Minute
+
+// eventKinds are the kinds of events, in the order of their revisions in a
+// cursor.
+var eventKinds = []admin.EventKind{admin.EventKind_COMMIT, admin.EventKind_JOB, admin.EventKind_PIPELINE}
+
+// An eventCursor holds, for each kind of event, the revision (the unix time,
+// in seconds, at which an item was last updated) of the last event of that
+// kind that a subscription sent. The collections of each kind are watched
+// separately, so a subscription resumes from each revision separately.
+type eventCursor map[admin.EventKind]int64
+
+func parseEventCursor(cursor string) (eventCursor, error) {
+	parts := strings.Split(cursor, ".")
+	if len(parts) != len(eventKinds) {
+		return nil, errors.Errorf("invalid event cursor %q", cursor)
+	}
+	result := make(eventCursor)
+	for i, part := range parts {
+		rev, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || rev < 0 {
+			return nil, errors.Errorf("invalid event cursor %q", cursor)
+		}
+		result[eventKinds[i]] = rev
+	}
+	return result, nil
+}
+
+func (c eventCursor) String() string {
+	parts := make([]string, len(eventKinds))
+	for i, kind := range eventKinds {
+		parts[i] = strconv.FormatInt(c[kind], 10)
+	}
+	return strings.Join(parts, ".")
+}
+
+// eventFilter decides which events a subscription sends.
+type eventFilter struct {
+	kinds     map[admin.EventKind]bool
+	repos     map[string]bool
+	pipelines map[string]bool
+	// since holds the revisions of the oldest changes that are sent.
+	since eventCursor
+}
+
+func newEventFilter(request *admin.SubscribeEventsRequest, now time.Time) (*eventFilter, error) {
+	f := &eventFilter{
+		kinds:     make(map[admin.EventKind]bool),
+		repos:     make(map[string]bool),
+		pipelines: make(map[string]bool),
+		since:     make(eventCursor),
+	}
+	for _, kind := range eventKinds {
+		f.since[kind] = now.Unix()
+	}
+	for _, kind := range request.Kinds {
+		if _, ok := admin.EventKind_name[int32(kind)]; !ok || kind == admin.EventKind_EVENT_KIND_UNKNOWN {
+			return nil, errors.Errorf("invalid event kind %v", kind)
+		}
+		f.kinds[kind] = true
+	}
+	for _, repo := range request.Repos {
+		f.repos[repo] = true
+	}
+	for _, pipeline := range request.Pipelines {
+		f.pipelines[pipeline] = true
+	}
+	if request.Cursor != "" {
+		var err error
+		if f.since, err = parseEventCursor(request.Cursor); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (f *eventFilter) wantsKind(kind admin.EventKind) bool {
+	return len(f.kinds) == 0 || f.kinds[kind]
+}
+
+// matches returns true if an event about repo, and pipeline if it's about a
+// job or pipeline, passes the filter.
+func (f *eventFilter) matches(repo, pipeline string) bool {
+	if len(f.repos) == 0 && len(f.pipelines) == 0 {
+		return true
+	}
+	return f.repos[repo] || (pipeline != "" && f.pipelines[pipeline])
+}
+
+// newEvent returns the event for the item that changed in e, or nil if it
+// isn't sent.
+func (f *eventFilter) newEvent(kind admin.EventKind, e *watch.Event, val proto.Message) (*admin.Event, error) {
+	if e.Type != watch.EventPut || e.Rev < f.since[kind] {
+		return nil, nil
+	}
+	var key string
+	if err := e.Unmarshal(&key, val); err != nil {
+		return nil, err
+	}
+	event := &admin.Event{
+		Kind: kind,
+		Time: &types.Timestamp{Seconds: e.Rev},
+	}
+	var repo, pipeline string
+	switch val := val.(type) {
+	case *pfs.CommitInfo:
+		if val.Commit.Branch.Repo.Type != pfs.UserRepoType {
+			return nil, nil
+		}
+		repo = val.Commit.Branch.Repo.Name
+		event.Commit = proto.Clone(val).(*pfs.CommitInfo)
+	case *pps.JobInfo:
+		repo, pipeline = val.Job.Pipeline.Name, val.Job.Pipeline.Name
+		event.Job = proto.Clone(val).(*pps.JobInfo)
+	case *pps.PipelineInfo:
+		repo, pipeline = val.Pipeline.Name, val.Pipeline.Name
+		event.Pipeline = proto.Clone(val).(*pps.PipelineInfo)
+	}
+	if !f.matches(repo, pipeline) {
+		return nil, nil
+	}
+	return event, nil
+}
+
+// eventRepo returns the repo whose readers may receive event.
+func eventRepo(event *admin.Event) string {
+	switch {
+	case event.Commit != nil:
+		return event.Commit.Commit.Branch.Repo.Name
+	case event.Job != nil:
+		return event.Job.Job.Pipeline.Name
+	case event.Pipeline != nil:
+		return event.Pipeline.Pipeline.Name
+	}
+	return ""
+}
+
+// repoReader remembers, for a while, whether a subscription's caller can read
+// each repo.
+type repoReader struct {
+	authorize func(repo string) (bool, error)
+	mu        sync.Mutex
+	checked   map[string]time.Time
+	readable  map[string]bool
+}
+
+func newRepoReader(authorize func(repo string) (bool, error)) *repoReader {
+	return &repoReader{
+		authorize: authorize,
+		checked:   make(map[string]time.Time),
+		readable:  make(map[string]bool),
+	}
+}
+
+func (r *repoReader) canRead(repo string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t, ok := r.checked[repo]; ok && time.Since(t) < eventPermissionTTL {
+		return r.readable[repo], nil
+	}
+	ok, err := r.authorize(repo)
+	if err != nil {
+		return false, err
+	}
+	r.checked[repo], r.readable[repo] = time.Now(), ok
+	return ok, nil
+}
+
+// repoAuthorizer returns a function that returns true if the caller in ctx
+// can read a repo.
+func (a *apiServer) repoAuthorizer(ctx context.Context) (func(repo string) (bool, error), error) {
+	pachClient := a.env.GetPachClient(ctx)
+	if _, err := pachClient.WhoAmI(pachClient.Ctx(), &auth.WhoAmIRequest{}); err != nil {
+		if auth.IsErrNotActivated(err) {
+			return func(string) (bool, error) { return true, nil }, nil
+		}
+		return nil, errors.EnsureStack(grpcutil.ScrubGRPC(err))
+	}
+	return func(repo string) (bool, error) {
+		resp, err := pachClient.Authorize(pachClient.Ctx(), &auth.AuthorizeRequest{
+			Resource:    &auth.Resource{Type: auth.ResourceType_REPO, Name: repo},
+			Permissions: []auth.Permission{auth.Permission_REPO_READ},
+		})
+		if err != nil {
+			return false, errors.EnsureStack(grpcutil.ScrubGRPC(err))
+		}
+		return resp.Authorized, nil
+	}, nil
+}
+
+func (a *apiServer) SubscribeEvents(request *admin.SubscribeEventsRequest, server admin.API_SubscribeEventsServer) error {
+	filter, err := newEventFilter(request, time.Now())
+	if err != nil {
+		return err
+	}
+	authorize, err := a.repoAuthorizer(server.Context())
+	if err != nil {
+		return err
+	}
+	reader := newRepoReader(authorize)
+	// progress is the cursor of the next event, guarded by sendMu
+	progress := make(eventCursor)
+	for kind, rev := range filter.since {
+		progress[kind] = rev
+	}
+	var sendMu sync.Mutex
+	eg, ctx := errgroup.WithContext(server.Context())
+	watchKind := func(kind admin.EventKind, c col.PostgresCollection, val proto.Message) {
+		if !filter.wantsKind(kind) {
+			return
+		}
+		eg.Go(func() error {
+			// the initial state of the collection is sent in the order it
+			// was last updated, so that a cursor doesn't skip any changes
+			return errors.EnsureStack(c.ReadOnly(ctx).WatchF(func(e *watch.Event) error {
+				event, err := filter.newEvent(kind, e, val)
+				if err != nil || event == nil {
+					return err
+				}
+				if ok, err := reader.canRead(eventRepo(event)); err != nil || !ok {
+					return err
+				}
+				sendMu.Lock()
+				defer sendMu.Unlock()
+				progress[kind] = e.Rev
+				event.Cursor = progress.String()
+				return errors.EnsureStack(server.Send(event))
+			}, watch.WithSort(col.SortByModRevision, col.SortAscend), watch.IgnoreDelete))
+		})
+	}
+	watchKind(admin.EventKind_COMMIT, pfsdb.Commits(a.env.DB, a.env.Listener), &pfs.CommitInfo{})
+	watchKind(admin.EventKind_JOB, ppsdb.Jobs(a.env.DB, a.env.Listener), &pps.JobInfo{})
+	watchKind(admin.EventKind_PIPELINE, ppsdb.Pipelines(a.env.DB, a.env.Listener), &pps.PipelineInfo{})
+	return errors.EnsureStack(eg.Wait())
+}
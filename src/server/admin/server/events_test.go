@@ -0,0 +1,97 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/internal/watch"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestEventCursor(t *testing.T) {
+	cursor, err := parseEventCursor("10.20.30")
+	require.NoError(t, err)
+	require.Equal(t, eventCursor{admin.EventKind_COMMIT: 10, admin.EventKind_JOB: 20, admin.EventKind_PIPELINE: 30}, cursor)
+	require.Equal(t, "10.20.30", cursor.String())
+	for _, bad := range []string{"", "10", "10.20", "10.20.30.40", "10.x.30", "10.-1.30"} {
+		_, err := parseEventCursor(bad)
+		require.YesError(t, err, bad)
+	}
+}
+
+func putEvent(t *testing.T, key string, rev int64, val proto.Message) *watch.Event {
+	data, err := proto.Marshal(val)
+	require.NoError(t, err)
+	return &watch.Event{Key: []byte(key), Value: data, Type: watch.EventPut, Rev: rev, Template: val}
+}
+
+func TestEventFilter(t *testing.T) {
+	now := time.Unix(100, 0)
+	commit := &pfs.CommitInfo{Commit: client.NewRepo("images").NewCommit("master", "abc")}
+	specCommit := &pfs.CommitInfo{Commit: client.NewSystemRepo("edges", pfs.SpecRepoType).NewCommit("master", "abc")}
+	job := &pps.JobInfo{Job: client.NewJob("edges", "def")}
+
+	f, err := newEventFilter(&admin.SubscribeEventsRequest{}, now)
+	require.NoError(t, err)
+	require.True(t, f.wantsKind(admin.EventKind_PIPELINE))
+	// changes from before the subscription aren't sent
+	event, err := f.newEvent(admin.EventKind_COMMIT, putEvent(t, "images@abc", 99, commit), &pfs.CommitInfo{})
+	require.NoError(t, err)
+	require.Nil(t, event)
+	event, err = f.newEvent(admin.EventKind_COMMIT, putEvent(t, "images@abc", 100, commit), &pfs.CommitInfo{})
+	require.NoError(t, err)
+	require.Equal(t, "abc", event.Commit.Commit.ID)
+	require.Equal(t, "images", eventRepo(event))
+	// nor are changes to system repos, or deletions
+	event, err = f.newEvent(admin.EventKind_COMMIT, putEvent(t, "edges.spec@abc", 100, specCommit), &pfs.CommitInfo{})
+	require.NoError(t, err)
+	require.Nil(t, event)
+	event, err = f.newEvent(admin.EventKind_COMMIT, &watch.Event{Key: []byte("images@abc"), Type: watch.EventDelete, Rev: 100}, &pfs.CommitInfo{})
+	require.NoError(t, err)
+	require.Nil(t, event)
+
+	f, err = newEventFilter(&admin.SubscribeEventsRequest{
+		Kinds:     []admin.EventKind{admin.EventKind_JOB},
+		Pipelines: []string{"edges"},
+		Cursor:    "0.50.0",
+	}, now)
+	require.NoError(t, err)
+	require.False(t, f.wantsKind(admin.EventKind_COMMIT))
+	require.True(t, f.wantsKind(admin.EventKind_JOB))
+	event, err = f.newEvent(admin.EventKind_JOB, putEvent(t, "edges@def", 50, job), &pps.JobInfo{})
+	require.NoError(t, err)
+	require.Equal(t, "def", event.Job.Job.ID)
+	require.Equal(t, "edges", eventRepo(event))
+	event, err = f.newEvent(admin.EventKind_JOB, putEvent(t, "edges@def", 49, job), &pps.JobInfo{})
+	require.NoError(t, err)
+	require.Nil(t, event)
+	require.False(t, f.matches("images", ""))
+
+	_, err = newEventFilter(&admin.SubscribeEventsRequest{Kinds: []admin.EventKind{admin.EventKind_EVENT_KIND_UNKNOWN}}, now)
+	require.YesError(t, err)
+	_, err = newEventFilter(&admin.SubscribeEventsRequest{Cursor: "50"}, now)
+	require.YesError(t, err)
+}
+
+func TestRepoReader(t *testing.T) {
+	var checks int
+	r := newRepoReader(func(repo string) (bool, error) {
+		checks++
+		return repo == "images", nil
+	})
+	for i := 0; i < 2; i++ {
+		ok, err := r.canRead("images")
+		require.NoError(t, err)
+		require.True(t, ok)
+		ok, err = r.canRead("secrets")
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+	require.Equal(t, 2, checks)
+}
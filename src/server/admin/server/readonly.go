@@ -0,0 +1,35 @@
+package server
+
+import (
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	authmw "github.com/pachyderm/pachyderm/v2/src/internal/middleware/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/middleware/readonly"
+)
+
+func (a *apiServer) SetReadOnly(ctx context.Context, request *admin.SetReadOnlyRequest) (*admin.ReadOnlyState, error) {
+	state := &admin.ReadOnlyState{}
+	if request.ReadOnly {
+		state = &admin.ReadOnlyState{
+			ReadOnly: true,
+			Reason:   request.Reason,
+			Since:    types.TimestampNow(),
+			SetBy:    authmw.GetWhoAmI(ctx),
+		}
+	}
+	if err := readonly.Set(ctx, a.env.EtcdClient, state); err != nil {
+		return nil, err
+	}
+	if state.ReadOnly {
+		a.env.Logger.Infof("%s made the cluster read-only: %s", state.SetBy, state.Reason)
+	} else {
+		a.env.Logger.Infof("%s made the cluster writable", authmw.GetWhoAmI(ctx))
+	}
+	return state, nil
+}
+
+func (a *apiServer) InspectReadOnly(ctx context.Context, _ *types.Empty) (*admin.ReadOnlyState, error) {
+	return readonly.Get(ctx, a.env.EtcdClient)
+}
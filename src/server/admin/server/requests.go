@@ -0,0 +1,64 @@
+package server
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+var errRequestsNotTracked = errors.New("this pachd does not track its requests")
+
+func (a *apiServer) ListInflightRequests(ctx context.Context, request *admin.ListInflightRequestsRequest) (*admin.ListInflightRequestsResponse, error) {
+	if a.env.Requests == nil {
+		return nil, errRequestsNotTracked
+	}
+	var minAge time.Duration
+	if request.MinAge != nil {
+		var err error
+		if minAge, err = types.DurationFromProto(request.MinAge); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+	}
+	now := time.Now()
+	response := &admin.ListInflightRequestsResponse{Pachd: a.env.Config.PachdPodName}
+	for _, r := range a.env.Requests.List() {
+		age := now.Sub(r.Started)
+		if age < minAge || !strings.Contains(r.Method, request.Method) {
+			continue
+		}
+		started, err := types.TimestampProto(r.Started)
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		response.Requests = append(response.Requests, &admin.InflightRequest{
+			ID:               r.ID,
+			Method:           r.Method,
+			Caller:           r.Caller,
+			Peer:             r.Peer,
+			Started:          started,
+			Age:              types.DurationProto(age),
+			Streaming:        r.Streaming,
+			MessagesReceived: r.MessagesReceived,
+			MessagesSent:     r.MessagesSent,
+			BytesReceived:    r.BytesReceived,
+			BytesSent:        r.BytesSent,
+		})
+	}
+	return response, nil
+}
+
+func (a *apiServer) CancelInflightRequest(ctx context.Context, request *admin.CancelInflightRequestRequest) (*types.Empty, error) {
+	if a.env.Requests == nil {
+		return nil, errRequestsNotTracked
+	}
+	if !a.env.Requests.Cancel(request.ID) {
+		return nil, errors.Errorf("request %q not found on pachd %q, it may have finished or be handled by another pachd", request.ID, a.env.Config.PachdPodName)
+	}
+	a.env.Logger.Infof("cancelled in-flight request %s", request.ID)
+	return &types.Empty{}, nil
+}
@@ -0,0 +1,12 @@
+package server
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/upgrade"
+)
+
+func (a *apiServer) InspectUpgrade(ctx context.Context, request *admin.InspectUpgradeRequest) (*admin.InspectUpgradeResponse, error) {
+	return upgrade.Inspect(ctx, a.env.DB, a.env.EtcdClient)
+}
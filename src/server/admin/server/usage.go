@@ -0,0 +1,184 @@
+package server
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsdb"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	authserver "github.com/pachyderm/pachyderm/v2/src/server/auth/server"
+)
+
+// defaultUsageReportWindow is how far back a usage report goes if its
+// request doesn't set a start.
+const defaultUsageReportWindow = 30 * 24 * time.Hour
+
+func (a *apiServer) GetUsageReport(ctx context.Context, request *admin.GetUsageReportRequest) (*admin.UsageReport, error) {
+	end := time.Now()
+	if request.End != nil {
+		var err error
+		if end, err = types.TimestampFromProto(request.End); err != nil {
+			return nil, errors.Wrapf(err, "invalid end")
+		}
+	}
+	start := end.Add(-defaultUsageReportWindow)
+	if request.Start != nil {
+		var err error
+		if start, err = types.TimestampFromProto(request.Start); err != nil {
+			return nil, errors.Wrapf(err, "invalid start")
+		}
+	}
+	if !start.Before(end) {
+		return nil, errors.Errorf("start (%v) must be before end (%v)", start, end)
+	}
+	owners, err := authserver.RepoOwners(ctx, a.env.DB, a.env.Listener)
+	if err != nil {
+		return nil, err
+	}
+	report := &admin.UsageReport{}
+	if report.Start, err = types.TimestampProto(start); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if report.End, err = types.TimestampProto(end); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	repoInfos, err := a.env.GetPachClient(ctx).ListRepo()
+	if err != nil {
+		return nil, err
+	}
+	for _, ri := range repoInfos {
+		report.Repos = append(report.Repos, &admin.RepoUsage{
+			Repo:      ri.Repo.Name,
+			SizeBytes: ri.SizeBytesUpperBound,
+			Owners:    owners[ri.Repo.Name],
+		})
+	}
+	sort.Slice(report.Repos, func(i, j int) bool { return report.Repos[i].Repo < report.Repos[j].Repo })
+	if report.Pipelines, err = a.pipelineUsage(ctx, start, end); err != nil {
+		return nil, err
+	}
+	for _, p := range report.Pipelines {
+		p.Owners = owners[p.Pipeline]
+	}
+	report.Users = userUsage(report.Repos, report.Pipelines)
+	return report, nil
+}
+
+// pipelineUsage returns the usage of the jobs that finished in [start, end),
+// by pipeline.
+func (a *apiServer) pipelineUsage(ctx context.Context, start, end time.Time) ([]*admin.PipelineUsage, error) {
+	pipelines := ppsdb.Pipelines(a.env.DB, a.env.Listener).ReadOnly(ctx)
+	// The job's budget is the one its pipeline version had, so the pipeline
+	// versions are looked up as they're seen.
+	versions := make(map[string]*pps.PipelineInfo)
+	usage := make(map[string]*admin.PipelineUsage)
+	jobInfo := &pps.JobInfo{}
+	if err := ppsdb.Jobs(a.env.DB, a.env.Listener).ReadOnly(ctx).List(jobInfo, col.DefaultOptions(), func(string) error {
+		if jobInfo.Finished == nil {
+			return nil
+		}
+		finished, err := types.TimestampFromProto(jobInfo.Finished)
+		if err != nil || finished.Before(start) || !finished.Before(end) {
+			return nil
+		}
+		name := jobInfo.Job.Pipeline.Name
+		key := ppsdb.VersionKey(name, jobInfo.PipelineVersion)
+		pipelineInfo, ok := versions[key]
+		if !ok {
+			pipelineInfo = &pps.PipelineInfo{}
+			if err := pipelines.GetUniqueByIndex(ppsdb.PipelinesVersionIndex, key, pipelineInfo); err != nil {
+				if !col.IsErrNotFound(err) {
+					return errors.EnsureStack(err)
+				}
+				// the pipeline was deleted, so its jobs have no cost
+				pipelineInfo = nil
+			}
+			versions[key] = pipelineInfo
+		}
+		if pipelineInfo.GetDetails() != nil {
+			jobInfo.Details = &pps.JobInfo_Details{
+				ResourceRequests: pipelineInfo.Details.ResourceRequests,
+				ResourceLimits:   pipelineInfo.Details.ResourceLimits,
+				Budget:           pipelineInfo.Details.Budget,
+			}
+		}
+		p, ok := usage[name]
+		if !ok {
+			p = &admin.PipelineUsage{Pipeline: name}
+			usage[name] = p
+		}
+		addJobUsage(p, jobInfo)
+		return nil
+	}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	var result []*admin.PipelineUsage
+	for _, p := range usage {
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Pipeline < result[j].Pipeline })
+	return result, nil
+}
+
+func addJobUsage(p *admin.PipelineUsage, jobInfo *pps.JobInfo) {
+	usage := pps.GetJobUsage(jobInfo)
+	p.Jobs++
+	p.ComputeSeconds += usage.WorkerHours * time.Hour.Seconds()
+	p.Cost += usage.Cost
+	p.EgressBytes += jobInfo.EgressBytes
+	for _, d := range []*types.Duration{jobInfo.Stats.GetUserCpuTime(), jobInfo.Stats.GetSystemCpuTime()} {
+		if cpu, err := types.DurationFromProto(d); d != nil && err == nil {
+			p.CpuSeconds += cpu.Seconds()
+		}
+	}
+}
+
+// userUsage attributes the usage of repos and pipelines to their owners,
+// splitting it evenly between them. Usage with no owners is attributed to
+// the empty principal.
+func userUsage(repos []*admin.RepoUsage, pipelines []*admin.PipelineUsage) []*admin.UserUsage {
+	users := make(map[string]*admin.UserUsage)
+	user := func(principal string) *admin.UserUsage {
+		u, ok := users[principal]
+		if !ok {
+			u = &admin.UserUsage{Principal: principal}
+			users[principal] = u
+		}
+		return u
+	}
+	owned := func(owners []string) []string {
+		if len(owners) == 0 {
+			return []string{""}
+		}
+		return owners
+	}
+	for _, r := range repos {
+		owners := owned(r.Owners)
+		for _, o := range owners {
+			user(o).StorageBytes += r.SizeBytes / int64(len(owners))
+		}
+	}
+	for _, p := range pipelines {
+		owners := owned(p.Owners)
+		n := float64(len(owners))
+		for _, o := range owners {
+			u := user(o)
+			u.ComputeSeconds += p.ComputeSeconds / n
+			u.CpuSeconds += p.CpuSeconds / n
+			u.EgressBytes += p.EgressBytes / int64(len(owners))
+			u.Cost += p.Cost / n
+		}
+	}
+	var result []*admin.UserUsage
+	for _, u := range users {
+		result = append(result, u)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Principal < result[j].Principal })
+	return result
+}
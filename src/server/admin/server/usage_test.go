@@ -0,0 +1,50 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestAddJobUsage(t *testing.T) {
+	p := &admin.PipelineUsage{Pipeline: "edges"}
+	jobInfo := &pps.JobInfo{
+		Stats: &pps.ProcessStats{
+			ProcessTime:   types.DurationProto(time.Hour),
+			UserCpuTime:   types.DurationProto(40 * time.Minute),
+			SystemCpuTime: types.DurationProto(5 * time.Minute),
+		},
+		EgressBytes: 100,
+		Details:     &pps.JobInfo_Details{Budget: &pps.JobBudget{WorkerHourCost: 2}},
+	}
+	addJobUsage(p, jobInfo)
+	addJobUsage(p, &pps.JobInfo{})
+	require.Equal(t, int64(2), p.Jobs)
+	require.Equal(t, 3600.0, p.ComputeSeconds)
+	require.Equal(t, 2700.0, p.CpuSeconds)
+	require.Equal(t, int64(100), p.EgressBytes)
+	require.Equal(t, 2.0, p.Cost)
+}
+
+func TestUserUsage(t *testing.T) {
+	users := userUsage(
+		[]*admin.RepoUsage{
+			{Repo: "images", SizeBytes: 100, Owners: []string{"user:alice", "user:bob"}},
+			{Repo: "edges", SizeBytes: 30, Owners: []string{"user:bob"}},
+			{Repo: "scratch", SizeBytes: 7},
+		},
+		[]*admin.PipelineUsage{
+			{Pipeline: "edges", ComputeSeconds: 60, EgressBytes: 10, Cost: 1, Owners: []string{"user:bob"}},
+		},
+	)
+	require.Equal(t, []*admin.UserUsage{
+		{Principal: "", StorageBytes: 7},
+		{Principal: "user:alice", StorageBytes: 50},
+		{Principal: "user:bob", StorageBytes: 80, ComputeSeconds: 60, EgressBytes: 10, Cost: 1},
+	}, users)
+}
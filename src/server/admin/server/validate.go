@@ -0,0 +1,19 @@
+package server
+
+import (
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/deployconfig"
+
+	"golang.org/x/net/context"
+)
+
+// ValidateConfiguration checks a cluster's Helm values. The checks that
+// connect to the object storage and identity providers run from pachd, so
+// they reach them through the cluster's network.
+func (a *apiServer) ValidateConfiguration(ctx context.Context, request *admin.ValidateConfigurationRequest) (*admin.ValidateConfigurationResponse, error) {
+	problems, err := deployconfig.Validate(ctx, request.Values, request.Offline)
+	if err != nil {
+		return nil, err
+	}
+	return &admin.ValidateConfigurationResponse{Problems: problems}, nil
+}
@@ -0,0 +1,278 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dlock"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
+	"github.com/pachyderm/pachyderm/v2/src/internal/watch"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	authserver "github.com/pachyderm/pachyderm/v2/src/server/auth/server"
+)
+
+const (
+	webhookDispatcherLockPath = "admin-webhook-dispatcher-lock"
+	// maxConcurrentWebhookDeliveries is the number of deliveries, including
+	// their retries, that are in flight at once.
+	maxConcurrentWebhookDeliveries = 16
+)
+
+// RunWebhookDispatcher watches for the events webhooks can subscribe to and
+// delivers them, until env.BackgroundContext is done. Only one dispatcher in
+// the cluster delivers events at a time.
+func RunWebhookDispatcher(env Env) {
+	a := newAPIServer(env)
+	d := &webhookDispatcher{
+		apiServer: a,
+		client:    &http.Client{Timeout: webhookTimeout},
+		sem:       semaphore.NewWeighted(maxConcurrentWebhookDeliveries),
+	}
+	ctx := env.BackgroundContext
+	lock := dlock.NewDLock(env.EtcdClient, path.Join(env.Config.EtcdPrefix, webhookDispatcherLockPath))
+	backoff.RetryUntilCancel(ctx, func() error {
+		lockCtx, err := lock.Lock(ctx)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		defer lock.Unlock(lockCtx)
+		return d.run(lockCtx)
+	}, backoff.NewInfiniteBackOff(), func(err error, t time.Duration) error {
+		env.Logger.Errorf("error dispatching webhook events: %v; retrying in %v", err, t)
+		return nil
+	})
+}
+
+type webhookDispatcher struct {
+	*apiServer
+	client *http.Client
+	sem    *semaphore.Weighted
+}
+
+// run watches the collections whose changes produce events. Changes that
+// happened before run was called don't produce events, except for commits
+// and jobs that were already in progress.
+func (d *webhookDispatcher) run(ctx context.Context) error {
+	start := time.Now().Unix()
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		// unfinished commits, a commit finished event is sent when one of
+		// them finishes
+		unfinished := make(map[string]bool)
+		commitInfo := &pfs.CommitInfo{}
+		return d.watch(ctx, pfsdb.Commits(d.env.DB, d.env.Listener), commitInfo, func(key string, deleted bool) *admin.WebhookEvent {
+			if deleted {
+				delete(unfinished, key)
+				return nil
+			}
+			if commitInfo.Finished == nil {
+				if commitInfo.Commit.Branch.Repo.Type == pfs.UserRepoType {
+					unfinished[key] = true
+				}
+				return nil
+			}
+			if !unfinished[key] {
+				return nil
+			}
+			delete(unfinished, key)
+			return &admin.WebhookEvent{
+				Type:   admin.WebhookEventType_COMMIT_FINISHED,
+				Repo:   commitInfo.Commit.Branch.Repo.Name,
+				Branch: commitInfo.Commit.Branch.Name,
+				Commit: commitInfo.Commit.ID,
+				Reason: commitInfo.Error,
+			}
+		})
+	})
+	eg.Go(func() error {
+		// jobs that aren't in a terminal state, a job failed or succeeded
+		// event is sent when one of them finishes
+		running := make(map[string]bool)
+		jobInfo := &pps.JobInfo{}
+		return d.watch(ctx, ppsdb.Jobs(d.env.DB, d.env.Listener), jobInfo, func(key string, deleted bool) *admin.WebhookEvent {
+			if deleted {
+				delete(running, key)
+				return nil
+			}
+			if !pps.IsTerminal(jobInfo.State) {
+				running[key] = true
+				return nil
+			}
+			if !running[key] {
+				return nil
+			}
+			delete(running, key)
+			var eventType admin.WebhookEventType
+			switch jobInfo.State {
+			case pps.JobState_JOB_FAILURE:
+				eventType = admin.WebhookEventType_JOB_FAILED
+			case pps.JobState_JOB_SUCCESS:
+				eventType = admin.WebhookEventType_JOB_SUCCEEDED
+			default:
+				return nil
+			}
+			return &admin.WebhookEvent{
+				Type:     eventType,
+				Pipeline: jobInfo.Job.Pipeline.Name,
+				Job:      jobInfo.Job.ID,
+				Reason:   jobInfo.Reason,
+			}
+		})
+	})
+	eg.Go(func() error {
+		// the last seen state of each pipeline version
+		states := make(map[string]pps.PipelineState)
+		pipelineInfo := &pps.PipelineInfo{}
+		return d.watch(ctx, ppsdb.Pipelines(d.env.DB, d.env.Listener), pipelineInfo, func(key string, deleted bool) *admin.WebhookEvent {
+			if deleted {
+				delete(states, key)
+				return nil
+			}
+			prev, ok := states[key]
+			states[key] = pipelineInfo.State
+			if rollback := pipelineInfo.Rollback; !ok && rollback != nil && pipelineInfo.Details.GetCreatedAt().GetSeconds() >= start {
+				// versions created by rollbacks are created with rollback set
+				return &admin.WebhookEvent{
+					Type:     admin.WebhookEventType_PIPELINE_ROLLED_BACK,
+					Pipeline: pipelineInfo.Pipeline.Name,
+					Job:      rollback.Job.GetID(),
+					Reason:   rollback.Reason,
+				}
+			}
+			if !ok || prev == pps.PipelineState_PIPELINE_CRASHING || pipelineInfo.State != pps.PipelineState_PIPELINE_CRASHING {
+				return nil
+			}
+			return &admin.WebhookEvent{
+				Type:     admin.WebhookEventType_PIPELINE_CRASHING,
+				Pipeline: pipelineInfo.Pipeline.Name,
+				Reason:   pipelineInfo.Reason,
+			}
+		})
+	})
+	eg.Go(func() error {
+		// the SLOs each pipeline version was last seen violating
+		violations := make(map[string]map[string]bool)
+		pipelineInfo := &pps.PipelineInfo{}
+		return errors.EnsureStack(ppsdb.Pipelines(d.env.DB, d.env.Listener).ReadOnly(ctx).WatchF(func(e *watch.Event) error {
+			if e.Type == watch.EventDelete {
+				delete(violations, string(e.Key))
+				return nil
+			}
+			var key string
+			if err := e.Unmarshal(&key, pipelineInfo); err != nil {
+				d.env.Logger.Errorf("error unmarshalling %v for webhook events: %v", string(e.Key), err)
+				return nil
+			}
+			prev := violations[key]
+			violations[key] = make(map[string]bool)
+			for _, violation := range pipelineInfo.SLOViolations {
+				violations[key][violation.SLO] = true
+				if prev[violation.SLO] || violation.Since.GetSeconds() < start {
+					continue
+				}
+				if err := d.dispatch(ctx, &admin.WebhookEvent{
+					Type:     admin.WebhookEventType_PIPELINE_SLO_VIOLATED,
+					Pipeline: pipelineInfo.Pipeline.Name,
+					SLO:      violation.SLO,
+					Reason:   violation.Reason,
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}))
+	})
+	eg.Go(func() error {
+		config := &auth.OIDCConfig{}
+		return d.watchEvents(ctx, authserver.AuthConfigCollection(d.env.DB, d.env.Listener), config, func(e *watch.Event) *admin.WebhookEvent {
+			// the initial state of the collection is sent first, which
+			// doesn't need to be reported
+			if e.Rev < start {
+				return nil
+			}
+			return &admin.WebhookEvent{Type: admin.WebhookEventType_AUTH_CONFIG_CHANGED}
+		})
+	})
+	return errors.EnsureStack(eg.Wait())
+}
+
+// watch calls f with the key of each item of c as it changes, after
+// unmarshalling it into val unless it was deleted, and dispatches the event
+// f returns, if any.
+func (d *webhookDispatcher) watch(ctx context.Context, c col.PostgresCollection, val proto.Message, f func(key string, deleted bool) *admin.WebhookEvent) error {
+	return d.watchEvents(ctx, c, val, func(e *watch.Event) *admin.WebhookEvent {
+		if e.Type == watch.EventDelete {
+			return f(string(e.Key), true)
+		}
+		var key string
+		if err := e.Unmarshal(&key, val); err != nil {
+			d.env.Logger.Errorf("error unmarshalling %v for webhook events: %v", string(e.Key), err)
+			return nil
+		}
+		return f(key, false)
+	})
+}
+
+func (d *webhookDispatcher) watchEvents(ctx context.Context, c col.PostgresCollection, val proto.Message, f func(e *watch.Event) *admin.WebhookEvent) error {
+	return errors.EnsureStack(c.ReadOnly(ctx).WatchF(func(e *watch.Event) error {
+		if event := f(e); event != nil {
+			return d.dispatch(ctx, event)
+		}
+		return nil
+	}))
+}
+
+// dispatch delivers event to the webhooks subscribed to it, in the
+// background.
+func (d *webhookDispatcher) dispatch(ctx context.Context, event *admin.WebhookEvent) error {
+	event.ID = uuid.NewWithoutDashes()
+	event.Time = types.TimestampNow()
+	event.ClusterID = d.env.ClusterID
+	var webhooks []*admin.Webhook
+	info := &admin.WebhookInfo{}
+	if err := d.webhooks.ReadOnly(ctx).List(info, col.DefaultOptions(), func(string) error {
+		if webhookMatches(info.Webhook, event) {
+			webhooks = append(webhooks, proto.Clone(info.Webhook).(*admin.Webhook))
+		}
+		return nil
+	}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	for _, webhook := range webhooks {
+		webhook := webhook
+		go func() {
+			// Deliveries outlive the dispatcher's lock, so that they aren't
+			// interrupted if another dispatcher takes over.
+			ctx := d.env.BackgroundContext
+			if err := d.sem.Acquire(ctx, 1); err != nil {
+				return
+			}
+			defer d.sem.Release(1)
+			b := backoff.NewExponentialBackOff()
+			b.InitialInterval = time.Second
+			b.MaxInterval = time.Minute
+			b.MaxElapsedTime = 0
+			deliverWebhook(ctx, d.client, webhook, event, b, func(delivery *admin.WebhookDelivery) {
+				if err := d.recordWebhookDelivery(ctx, webhook.Name, delivery); err != nil {
+					d.env.Logger.Errorf("error recording delivery %v of webhook %v: %v", event.ID, webhook.Name, err)
+				}
+			})
+		}()
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+)
+
+const webhooksCollectionName = "webhooks"
+
+var webhooksIndexes = []*col.Index{}
+
+func webhooksCollection(db *pachsql.DB, listener col.PostgresListener) col.PostgresCollection {
+	return col.NewPostgresCollection(
+		webhooksCollectionName,
+		db,
+		listener,
+		&admin.WebhookInfo{},
+		webhooksIndexes,
+	)
+}
+
+// WebhooksCollectionsV0 returns the webhooks collection for
+// postgres-initialization purposes. This collection is not usable for
+// querying.
+// DO NOT MODIFY THIS FUNCTION
+// IT HAS BEEN USED IN A RELEASED MIGRATION
+func WebhooksCollectionsV0() []col.PostgresCollection {
+	return []col.PostgresCollection{
+		col.NewPostgresCollection(webhooksCollectionName, nil, nil, nil, webhooksIndexes),
+	}
+}
@@ -0,0 +1,327 @@
+package server
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ancestry"
+	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dbutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/eventbus"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+)
+
+const (
+	// defaultWebhookMaxAttempts is the number of times a delivery is
+	// attempted if the webhook doesn't set it.
+	defaultWebhookMaxAttempts = 5
+	// maxWebhookDeliveries is the number of recent deliveries kept for each
+	// webhook.
+	maxWebhookDeliveries = 20
+
+	webhookTimeout = 10 * time.Second
+
+	// WebhookSignatureHeader is the header of webhook requests containing
+	// "sha256=" followed by the hex encoded HMAC SHA-256 of the request
+	// body, keyed with the webhook's secret.
+	WebhookSignatureHeader = "X-Pachyderm-Signature"
+	// WebhookEventHeader is the header of webhook requests containing the
+	// type of the event.
+	WebhookEventHeader = "X-Pachyderm-Event"
+	// WebhookDeliveryHeader is the header of webhook requests containing the
+	// ID of the event, which is the same for every attempt to deliver it.
+	WebhookDeliveryHeader = "X-Pachyderm-Delivery"
+)
+
+func validateWebhook(webhook *admin.Webhook) error {
+	if webhook == nil {
+		return errors.New("webhook must be set")
+	}
+	if err := ancestry.ValidateName(webhook.Name); err != nil {
+		return err
+	}
+	u, err := url.Parse(webhook.URL)
+	if err != nil {
+		return errors.Wrapf(err, "invalid webhook URL %q", webhook.URL)
+	}
+	switch u.Scheme {
+	case "http", "https":
+		if u.Host == "" {
+			return errors.Errorf("invalid webhook URL %q, it has no host", webhook.URL)
+		}
+	case eventbus.KafkaScheme, eventbus.NATSScheme:
+		if _, err := eventbus.ParseTopic(webhook.URL); err != nil {
+			return errors.Wrapf(err, "invalid webhook URL %q", webhook.URL)
+		}
+	default:
+		return errors.Errorf("invalid webhook URL %q, it must be an http, https, kafka or nats URL", webhook.URL)
+	}
+	for _, e := range webhook.Events {
+		if _, ok := admin.WebhookEventType_name[int32(e)]; !ok || e == admin.WebhookEventType_WEBHOOK_EVENT_UNKNOWN {
+			return errors.Errorf("invalid webhook event type %v", e)
+		}
+	}
+	if webhook.MaxAttempts < 0 {
+		return errors.Errorf("max attempts must be non-negative, got %d", webhook.MaxAttempts)
+	}
+	return nil
+}
+
+// redactWebhookInfo returns a copy of info without the webhook's secret.
+func redactWebhookInfo(info *admin.WebhookInfo) *admin.WebhookInfo {
+	info = proto.Clone(info).(*admin.WebhookInfo)
+	if info.Webhook != nil {
+		info.Webhook.Secret = ""
+	}
+	return info
+}
+
+func (a *apiServer) CreateWebhook(ctx context.Context, request *admin.CreateWebhookRequest) (*types.Empty, error) {
+	if err := validateWebhook(request.Webhook); err != nil {
+		return nil, err
+	}
+	if err := dbutil.WithTx(ctx, a.env.DB, func(sqlTx *pachsql.Tx) error {
+		webhooks := a.webhooks.ReadWrite(sqlTx)
+		info := &admin.WebhookInfo{}
+		if err := webhooks.Get(request.Webhook.Name, info); err != nil {
+			if !col.IsErrNotFound(err) {
+				return errors.EnsureStack(err)
+			}
+			return errors.EnsureStack(webhooks.Create(request.Webhook.Name, &admin.WebhookInfo{
+				Webhook: request.Webhook,
+				Created: types.TimestampNow(),
+			}))
+		}
+		if !request.Update {
+			return errors.Errorf("webhook %q already exists", request.Webhook.Name)
+		}
+		info.Webhook = request.Webhook
+		return errors.EnsureStack(webhooks.Put(request.Webhook.Name, info))
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+func (a *apiServer) InspectWebhook(ctx context.Context, request *admin.InspectWebhookRequest) (*admin.WebhookInfo, error) {
+	info := &admin.WebhookInfo{}
+	if err := a.webhooks.ReadOnly(ctx).Get(request.Name, info); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil, errors.Errorf("webhook %q not found", request.Name)
+		}
+		return nil, errors.EnsureStack(err)
+	}
+	return redactWebhookInfo(info), nil
+}
+
+func (a *apiServer) ListWebhook(request *admin.ListWebhookRequest, server admin.API_ListWebhookServer) error {
+	info := &admin.WebhookInfo{}
+	return errors.EnsureStack(a.webhooks.ReadOnly(server.Context()).List(info, col.DefaultOptions(), func(string) error {
+		return errors.EnsureStack(server.Send(redactWebhookInfo(info)))
+	}))
+}
+
+func (a *apiServer) DeleteWebhook(ctx context.Context, request *admin.DeleteWebhookRequest) (*types.Empty, error) {
+	if err := dbutil.WithTx(ctx, a.env.DB, func(sqlTx *pachsql.Tx) error {
+		if err := a.webhooks.ReadWrite(sqlTx).Delete(request.Name); err != nil {
+			if col.IsErrNotFound(err) {
+				return errors.Errorf("webhook %q not found", request.Name)
+			}
+			return errors.EnsureStack(err)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// webhookMatches returns true if webhook is subscribed to event.
+func webhookMatches(webhook *admin.Webhook, event *admin.WebhookEvent) bool {
+	if len(webhook.Events) > 0 {
+		var subscribed bool
+		for _, t := range webhook.Events {
+			if t == event.Type {
+				subscribed = true
+				break
+			}
+		}
+		if !subscribed {
+			return false
+		}
+	}
+	if len(webhook.Repos) == 0 && len(webhook.Pipelines) == 0 {
+		return true
+	}
+	if event.Repo != "" {
+		for _, repo := range webhook.Repos {
+			if repo == event.Repo {
+				return true
+			}
+		}
+	}
+	if event.Pipeline != "" {
+		for _, pipeline := range webhook.Pipelines {
+			if pipeline == event.Pipeline {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// signWebhook returns the value of the signature header of a request with
+// body, sent to a webhook with secret.
+func signWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliverWebhook sends event to webhook, retrying with b until it succeeds
+// or the webhook's max attempts are exhausted. record is called with the
+// state of the delivery after each attempt.
+func deliverWebhook(ctx context.Context, client *http.Client, webhook *admin.Webhook, event *admin.WebhookEvent, b backoff.BackOff, record func(*admin.WebhookDelivery)) *admin.WebhookDelivery {
+	delivery := &admin.WebhookDelivery{Event: event}
+	maxAttempts := webhook.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = defaultWebhookMaxAttempts
+	}
+	body, err := (&jsonpb.Marshaler{}).MarshalToString(event)
+	if err != nil {
+		delivery.Error = err.Error()
+		delivery.Finished = types.TimestampNow()
+		record(delivery)
+		return delivery
+	}
+	b.Reset()
+	for {
+		delivery.Attempts++
+		if isEventBusURL(webhook.URL) {
+			delivery.StatusCode, err = 0, publishWebhook(ctx, webhook, event, []byte(body))
+		} else {
+			delivery.StatusCode, err = postWebhook(ctx, client, webhook, event, []byte(body))
+		}
+		delivery.Error = ""
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+		delivery.Succeeded = err == nil
+		next := b.NextBackOff()
+		if delivery.Succeeded || delivery.Attempts >= maxAttempts || next == backoff.Stop {
+			delivery.Finished = types.TimestampNow()
+			record(delivery)
+			return delivery
+		}
+		record(delivery)
+		select {
+		case <-ctx.Done():
+			delivery.Error = ctx.Err().Error()
+			delivery.Finished = types.TimestampNow()
+			record(delivery)
+			return delivery
+		case <-time.After(next):
+		}
+	}
+}
+
+// postWebhook makes a single attempt to send event to webhook, returning
+// the status code of the response, if any.
+func postWebhook(ctx context.Context, client *http.Client, webhook *admin.Webhook, event *admin.WebhookEvent, body []byte) (int32, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, errors.EnsureStack(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, event.Type.String())
+	req.Header.Set(WebhookDeliveryHeader, event.ID)
+	if webhook.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, signWebhook(webhook.Secret, body))
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, errors.EnsureStack(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return int32(resp.StatusCode), errors.Errorf("webhook returned status %v", resp.Status)
+	}
+	return int32(resp.StatusCode), nil
+}
+
+// isEventBusURL returns true if rawURL is a Kafka topic or NATS subject
+// rather than an HTTP endpoint.
+func isEventBusURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && (u.Scheme == eventbus.KafkaScheme || u.Scheme == eventbus.NATSScheme)
+}
+
+// publishWebhook makes a single attempt to publish event to the topic of
+// webhook. Kafka messages are keyed by the event's repo or pipeline, so that
+// the events of each are kept in order.
+func publishWebhook(ctx context.Context, webhook *admin.Webhook, event *admin.WebhookEvent, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+	pub, err := eventbus.NewPublisher(ctx, webhook.URL)
+	if err != nil {
+		return err
+	}
+	defer pub.Close()
+	var key []byte
+	switch {
+	case event.Pipeline != "":
+		key = []byte(event.Pipeline)
+	case event.Repo != "":
+		key = []byte(event.Repo)
+	}
+	return pub.Publish(ctx, key, body)
+}
+
+// recordWebhookDelivery stores the state of delivery in the recent
+// deliveries of the webhook named name. Deliveries to deleted webhooks are
+// dropped.
+func (a *apiServer) recordWebhookDelivery(ctx context.Context, name string, delivery *admin.WebhookDelivery) error {
+	return dbutil.WithTx(ctx, a.env.DB, func(sqlTx *pachsql.Tx) error {
+		info := &admin.WebhookInfo{}
+		if err := a.webhooks.ReadWrite(sqlTx).Update(name, info, func() error {
+			var found bool
+			for i, d := range info.Deliveries {
+				if d.Event.ID == delivery.Event.ID {
+					info.Deliveries[i] = delivery
+					found = true
+					break
+				}
+			}
+			if !found {
+				info.Deliveries = append([]*admin.WebhookDelivery{delivery}, info.Deliveries...)
+				if len(info.Deliveries) > maxWebhookDeliveries {
+					info.Deliveries = info.Deliveries[:maxWebhookDeliveries]
+				}
+			}
+			if delivery.Finished != nil {
+				if delivery.Succeeded {
+					info.Succeeded++
+				} else {
+					info.Failed++
+				}
+			}
+			return nil
+		}); err != nil && !col.IsErrNotFound(err) {
+			return errors.EnsureStack(err)
+		}
+		return nil
+	})
+}
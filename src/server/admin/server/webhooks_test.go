@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gogo/protobuf/jsonpb"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestWebhookMatches(t *testing.T) {
+	commit := &admin.WebhookEvent{Type: admin.WebhookEventType_COMMIT_FINISHED, Repo: "images"}
+	job := &admin.WebhookEvent{Type: admin.WebhookEventType_JOB_FAILED, Pipeline: "edges"}
+	authConfig := &admin.WebhookEvent{Type: admin.WebhookEventType_AUTH_CONFIG_CHANGED}
+
+	all := &admin.Webhook{}
+	require.True(t, webhookMatches(all, commit))
+	require.True(t, webhookMatches(all, job))
+	require.True(t, webhookMatches(all, authConfig))
+
+	jobs := &admin.Webhook{Events: []admin.WebhookEventType{admin.WebhookEventType_JOB_FAILED}}
+	require.False(t, webhookMatches(jobs, commit))
+	require.True(t, webhookMatches(jobs, job))
+
+	filtered := &admin.Webhook{Repos: []string{"images"}, Pipelines: []string{"montage"}}
+	require.True(t, webhookMatches(filtered, commit))
+	require.False(t, webhookMatches(filtered, job))
+	require.False(t, webhookMatches(filtered, authConfig))
+}
+
+func TestValidateWebhook(t *testing.T) {
+	require.NoError(t, validateWebhook(&admin.Webhook{Name: "hook", URL: "https://example.com/hook"}))
+	require.YesError(t, validateWebhook(&admin.Webhook{Name: "bad name", URL: "https://example.com/hook"}))
+	require.YesError(t, validateWebhook(&admin.Webhook{Name: "hook", URL: "ftp://example.com/hook"}))
+	require.NoError(t, validateWebhook(&admin.Webhook{Name: "hook", URL: "kafka://kafka:9092/events"}))
+	require.NoError(t, validateWebhook(&admin.Webhook{Name: "hook", URL: "nats://token@nats/pachyderm.events"}))
+	require.YesError(t, validateWebhook(&admin.Webhook{Name: "hook", URL: "kafka://kafka:9092"}))
+	require.YesError(t, validateWebhook(&admin.Webhook{Name: "hook", URL: "https://example.com/hook", Events: []admin.WebhookEventType{0}}))
+	require.YesError(t, validateWebhook(&admin.Webhook{Name: "hook", URL: "https://example.com/hook", MaxAttempts: -1}))
+}
+
+func TestDeliverWebhook(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, signWebhook("secret", body), r.Header.Get(WebhookSignatureHeader))
+		require.Equal(t, "JOB_FAILED", r.Header.Get(WebhookEventHeader))
+		require.Equal(t, "1", r.Header.Get(WebhookDeliveryHeader))
+		event := &admin.WebhookEvent{}
+		require.NoError(t, jsonpb.UnmarshalString(string(body), event))
+		require.Equal(t, "edges", event.Pipeline)
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	event := &admin.WebhookEvent{ID: "1", Type: admin.WebhookEventType_JOB_FAILED, Pipeline: "edges"}
+	var recorded []int64
+	record := func(d *admin.WebhookDelivery) { recorded = append(recorded, d.Attempts) }
+	webhook := &admin.Webhook{Name: "hook", URL: server.URL, Secret: "secret"}
+	delivery := deliverWebhook(context.Background(), server.Client(), webhook, event, &backoff.ZeroBackOff{}, record)
+	require.True(t, delivery.Succeeded)
+	require.Equal(t, int64(3), delivery.Attempts)
+	require.Equal(t, int32(http.StatusOK), delivery.StatusCode)
+	require.NotNil(t, delivery.Finished)
+	require.Equal(t, []int64{1, 2, 3}, recorded)
+
+	// The delivery fails once the max attempts are exhausted.
+	requests = 0
+	webhook.MaxAttempts = 2
+	delivery = deliverWebhook(context.Background(), server.Client(), webhook, event, &backoff.ZeroBackOff{}, record)
+	require.False(t, delivery.Succeeded)
+	require.Equal(t, int64(2), delivery.Attempts)
+	require.Equal(t, int32(http.StatusServiceUnavailable), delivery.StatusCode)
+	require.NotNil(t, delivery.Finished)
+}
@@ -0,0 +1,100 @@
+package cmds
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/cmdutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+)
+
+func printACLFreeze(freeze *auth.ACLFreeze) {
+	since := "unknown"
+	if freeze.Created != nil {
+		since = freeze.Created.Format(time.RFC3339)
+	}
+	fmt.Printf("ACLs frozen by %s since %s: %s\n", freeze.Principal, since, freeze.Reason)
+}
+
+// FreezeACLsCmd returns a cobra command that freezes the cluster's ACLs
+func FreezeACLsCmd() *cobra.Command {
+	var reason string
+	freeze := &cobra.Command{
+		Use:   "{{alias}}",
+		Short: "Freeze the cluster's ACLs",
+		Long: "Freeze the cluster's ACLs, e.g. while responding to a compromised admin token. " +
+			"Until they're unfrozen, role bindings, group memberships, robot tokens and the auth " +
+			"configuration can't be changed, and auth can't be deactivated. Existing role bindings " +
+			"are still used to authorize requests.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return errors.Wrapf(err, "could not connect")
+			}
+			defer c.Close()
+			resp, err := c.FreezeACLs(c.Ctx(), &auth.FreezeACLsRequest{Reason: reason})
+			if err != nil {
+				return grpcutil.ScrubGRPC(err)
+			}
+			printACLFreeze(resp.Freeze)
+			return nil
+		}),
+	}
+	freeze.Flags().StringVar(&reason, "reason", "", "Why the ACLs are frozen, recorded with the freeze (required).")
+	return cmdutil.CreateAlias(freeze, "auth freeze")
+}
+
+// UnfreezeACLsCmd returns a cobra command that unfreezes the cluster's ACLs
+func UnfreezeACLsCmd() *cobra.Command {
+	unfreeze := &cobra.Command{
+		Use:   "{{alias}}",
+		Short: "Unfreeze the cluster's ACLs",
+		Long:  "Unfreeze the cluster's ACLs, allowing them to be changed again.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return errors.Wrapf(err, "could not connect")
+			}
+			defer c.Close()
+			if _, err := c.UnfreezeACLs(c.Ctx(), &auth.UnfreezeACLsRequest{}); err != nil {
+				return grpcutil.ScrubGRPC(err)
+			}
+			fmt.Println("ACLs unfrozen")
+			return nil
+		}),
+	}
+	return cmdutil.CreateAlias(unfreeze, "auth unfreeze")
+}
+
+// GetACLFreezeCmd returns a cobra command that prints whether the cluster's
+// ACLs are frozen
+func GetACLFreezeCmd() *cobra.Command {
+	get := &cobra.Command{
+		Use:   "{{alias}}",
+		Short: "Print whether the cluster's ACLs are frozen",
+		Long:  "Print whether the cluster's ACLs are frozen, and if so by whom and why.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return errors.Wrapf(err, "could not connect")
+			}
+			defer c.Close()
+			resp, err := c.GetACLFreeze(c.Ctx(), &auth.GetACLFreezeRequest{})
+			if err != nil {
+				return grpcutil.ScrubGRPC(err)
+			}
+			if resp.Freeze == nil {
+				fmt.Println("ACLs are not frozen")
+				return nil
+			}
+			printACLFreeze(resp.Freeze)
+			return nil
+		}),
+	}
+	return cmdutil.CreateAlias(get, "auth get-freeze")
+}
@@ -3,6 +3,7 @@ package cmds
 import (
 	"bufio"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"sort"
 	"strings"
@@ -285,6 +286,7 @@ func DeactivateCmd() *cobra.Command {
 // registered with your GitHub account will subsequently be accessible.
 func LoginCmd() *cobra.Command {
 	var noBrowser, enterprise, idToken bool
+	var kubernetesTokenFile string
 	login := &cobra.Command{
 		Short: "Log in to Pachyderm",
 		Long: "Login to Pachyderm. Any resources that have been restricted to " +
@@ -300,7 +302,19 @@ func LoginCmd() *cobra.Command {
 			// Issue authentication request to Pachyderm and get response
 			var resp *auth.AuthenticateResponse
 			var authErr error
-			if idToken {
+			if kubernetesTokenFile != "" {
+				token, err := ioutil.ReadFile(kubernetesTokenFile)
+				if err != nil {
+					return errors.Wrapf(err, "could not read Kubernetes token")
+				}
+				resp, authErr = c.Authenticate(
+					c.Ctx(),
+					&auth.AuthenticateRequest{KubernetesToken: strings.TrimSpace(string(token))})
+				if authErr != nil {
+					return errors.Wrapf(grpcutil.ScrubGRPC(authErr),
+						"authorization failed (Pachyderm logs may contain more information)")
+				}
+			} else if idToken {
 				token, err := cmdutil.ReadPassword("ID token: ")
 				if err != nil {
 					return errors.Wrapf(err, "could not read id token")
@@ -340,6 +354,8 @@ func LoginCmd() *cobra.Command {
 		"If set, don't try to open a web browser")
 	login.PersistentFlags().BoolVarP(&idToken, "id-token", "t", false,
 		"If set, read an ID token on stdin to authenticate the user")
+	login.PersistentFlags().StringVar(&kubernetesTokenFile, "kubernetes-token-file", "",
+		"If set, authenticate as the Kubernetes service account whose projected token is in this file")
 	login.PersistentFlags().BoolVar(&enterprise, "enterprise", false, "Login for the active enterprise context")
 	return cmdutil.CreateAlias(login, "auth login")
 }
@@ -600,6 +616,56 @@ func GetRepoRoleBindingCmd() *cobra.Command {
 	return cmdutil.CreateAlias(get, "auth get repo")
 }
 
+// SetProjectRoleBindingCmd returns a cobra command that sets the roles for a user on a project
+func SetProjectRoleBindingCmd() *cobra.Command {
+	setScope := &cobra.Command{
+		Use:   "{{alias}} <project> [role1,role2 | none ] <subject>",
+		Short: "Set the roles that 'username' has on 'project'",
+		Long:  "Set the roles that 'username' has on 'project', which apply to every repo in it",
+		Run: cmdutil.RunFixedArgs(3, func(args []string) error {
+			var roles []string
+			if args[1] == "none" {
+				roles = []string{}
+			} else {
+				roles = strings.Split(args[1], ",")
+			}
+
+			subject, project := args[2], args[0]
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return errors.Wrapf(err, "could not connect")
+			}
+			defer c.Close()
+			err = c.ModifyProjectRoleBinding(project, subject, roles)
+			return grpcutil.ScrubGRPC(err)
+		}),
+	}
+	return cmdutil.CreateAlias(setScope, "auth set project")
+}
+
+// GetProjectRoleBindingCmd returns a cobra command that gets the role bindings for a project
+func GetProjectRoleBindingCmd() *cobra.Command {
+	get := &cobra.Command{
+		Use:   "{{alias}} <project>",
+		Short: "Get the role bindings for 'project'",
+		Long:  "Get the role bindings for 'project'",
+		Run: cmdutil.RunBoundedArgs(1, 1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return errors.Wrapf(err, "could not connect")
+			}
+			defer c.Close()
+			resp, err := c.GetProjectRoleBinding(args[0])
+			if err != nil {
+				return grpcutil.ScrubGRPC(err)
+			}
+			printRoleBinding(resp)
+			return nil
+		}),
+	}
+	return cmdutil.CreateAlias(get, "auth get project")
+}
+
 // SetClusterRoleBindingCmd returns a cobra command that sets the roles for a user on a resource
 func SetClusterRoleBindingCmd() *cobra.Command {
 	setScope := &cobra.Command{
@@ -800,6 +866,12 @@ func Cmds() []*cobra.Command {
 	commands = append(commands, cmdutil.CreateAlias(get, "auth get"))
 	commands = append(commands, cmdutil.CreateAlias(set, "auth set"))
 	commands = append(commands, cmdutil.CreateAlias(check, "auth check"))
+
+	request := &cobra.Command{
+		Short: "Request a role on a resource",
+		Long:  "Request a role on a resource",
+	}
+	commands = append(commands, cmdutil.CreateAlias(request, "auth request"))
 	commands = append(commands, ActivateCmd())
 	commands = append(commands, DeactivateCmd())
 	commands = append(commands, LoginCmd())
@@ -813,11 +885,21 @@ func Cmds() []*cobra.Command {
 	commands = append(commands, GetGroupsCmd())
 	commands = append(commands, GetRepoRoleBindingCmd())
 	commands = append(commands, SetRepoRoleBindingCmd())
+	commands = append(commands, GetProjectRoleBindingCmd())
+	commands = append(commands, SetProjectRoleBindingCmd())
 	commands = append(commands, GetClusterRoleBindingCmd())
 	commands = append(commands, SetClusterRoleBindingCmd())
 	commands = append(commands, GetEnterpriseRoleBindingCmd())
 	commands = append(commands, SetEnterpriseRoleBindingCmd())
 	commands = append(commands, RotateRootToken())
 	commands = append(commands, RolesForPermissionCmd())
+	commands = append(commands, RequestRepoRoleCmd())
+	commands = append(commands, RequestClusterRoleCmd())
+	commands = append(commands, ListRoleRequestsCmd())
+	commands = append(commands, ApproveRoleRequestCmd())
+	commands = append(commands, DenyRoleRequestCmd())
+	commands = append(commands, FreezeACLsCmd())
+	commands = append(commands, UnfreezeACLsCmd())
+	commands = append(commands, GetACLFreezeCmd())
 	return commands
 }
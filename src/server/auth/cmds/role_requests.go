@@ -0,0 +1,209 @@
+package cmds
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/spf13/cobra"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/cmdutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/tabwriter"
+)
+
+const roleRequestHeader = "ID\tPRINCIPAL\tRESOURCE\tROLE\tSTATE\tCREATED\tEXPIRES\tREASON\t\n"
+
+func parseTTL(ttl string) (int64, error) {
+	if ttl == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(ttl)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not parse duration %q", ttl)
+	}
+	return int64(d.Seconds()), nil
+}
+
+func printRoleRequest(w *tabwriter.Writer, r *auth.RoleRequest) {
+	resource := r.Resource.Type.String()
+	if r.Resource.Name != "" {
+		resource = fmt.Sprintf("%v:%v", r.Resource.Type, r.Resource.Name)
+	}
+	created := "-"
+	if r.Created != nil {
+		created = units.HumanDuration(time.Since(*r.Created)) + " ago"
+	}
+	expires := "-"
+	if r.Expiration != nil {
+		expires = r.Expiration.Format(time.RFC3339)
+	}
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n", r.ID, r.Principal, resource, r.Role, r.State, created, expires, r.Reason)
+}
+
+// requestRole returns a cobra command's run function that requests a role on
+// the resource returned by resource.
+func requestRole(reason, ttl *string, resource func(args []string) *auth.Resource) func(args []string) error {
+	return func(args []string) error {
+		req := &auth.RequestRoleRequest{
+			Resource: resource(args),
+			Role:     args[len(args)-1],
+			Reason:   *reason,
+		}
+		var err error
+		if req.TTL, err = parseTTL(*ttl); err != nil {
+			return err
+		}
+		c, err := client.NewOnUserMachine("user")
+		if err != nil {
+			return errors.Wrapf(err, "could not connect")
+		}
+		defer c.Close()
+		resp, err := c.RequestRole(c.Ctx(), req)
+		if err != nil {
+			return grpcutil.ScrubGRPC(err)
+		}
+		fmt.Printf("Requested %q, request ID: %s\n", req.Role, resp.Request.ID)
+		return nil
+	}
+}
+
+func requestRoleFlags(cmd *cobra.Command, reason, ttl *string) {
+	cmd.Flags().StringVar(reason, "reason", "", "Why the role is needed, shown to the reviewers.")
+	cmd.Flags().StringVar(ttl, "ttl", "", "If set, the role is removed this long after the "+
+		"request is approved. This flag should be a golang duration (e.g. \"30s\" or \"1h2m3s\").")
+}
+
+// RequestRepoRoleCmd returns a cobra command that requests a role on a repo
+func RequestRepoRoleCmd() *cobra.Command {
+	var reason, ttl string
+	request := &cobra.Command{
+		Use:   "{{alias}} <repo> <role>",
+		Short: "Request a role on 'repo'",
+		Long: "Request a role on 'repo'. The users that can modify the role bindings on " +
+			"'repo' are notified, and the role is granted once one of them approves the request.",
+		Run: cmdutil.RunFixedArgs(2, requestRole(&reason, &ttl, func(args []string) *auth.Resource {
+			return &auth.Resource{Type: auth.ResourceType_REPO, Name: args[0]}
+		})),
+	}
+	requestRoleFlags(request, &reason, &ttl)
+	return cmdutil.CreateAlias(request, "auth request repo")
+}
+
+// RequestClusterRoleCmd returns a cobra command that requests a role on the cluster
+func RequestClusterRoleCmd() *cobra.Command {
+	var reason, ttl string
+	request := &cobra.Command{
+		Use:   "{{alias}} <role>",
+		Short: "Request a role on the cluster",
+		Long: "Request a role on the cluster. The cluster admins are notified, and the " +
+			"role is granted once one of them approves the request.",
+		Run: cmdutil.RunFixedArgs(1, requestRole(&reason, &ttl, func(args []string) *auth.Resource {
+			return &auth.Resource{Type: auth.ResourceType_CLUSTER}
+		})),
+	}
+	requestRoleFlags(request, &reason, &ttl)
+	return cmdutil.CreateAlias(request, "auth request cluster")
+}
+
+// ListRoleRequestsCmd returns a cobra command that lists role requests
+func ListRoleRequestsCmd() *cobra.Command {
+	var all bool
+	var repo string
+	list := &cobra.Command{
+		Use:   "{{alias}}",
+		Short: "List role requests",
+		Long:  "List your role requests and the role requests you can review. By default only pending requests are listed.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return errors.Wrapf(err, "could not connect")
+			}
+			defer c.Close()
+			req := &auth.ListRoleRequestsRequest{All: all}
+			if repo != "" {
+				req.Resource = &auth.Resource{Type: auth.ResourceType_REPO, Name: repo}
+			}
+			resp, err := c.ListRoleRequests(c.Ctx(), req)
+			if err != nil {
+				return grpcutil.ScrubGRPC(err)
+			}
+			w := tabwriter.NewWriter(os.Stdout, roleRequestHeader)
+			for _, r := range resp.Requests {
+				printRoleRequest(w, r)
+			}
+			return w.Flush()
+		}),
+	}
+	list.Flags().BoolVarP(&all, "all", "a", false, "Include requests that have already been reviewed.")
+	list.Flags().StringVar(&repo, "repo", "", "Only list requests for roles on this repo.")
+	return cmdutil.CreateAlias(list, "auth list-requests")
+}
+
+// ApproveRoleRequestCmd returns a cobra command that approves a role request
+func ApproveRoleRequestCmd() *cobra.Command {
+	var comment, ttl string
+	approve := &cobra.Command{
+		Use:   "{{alias}} <request-id>",
+		Short: "Approve a role request",
+		Long:  "Approve a role request, granting the requested role. Requires permission to modify the role bindings on the requested resource.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			req := &auth.ReviewRoleRequestRequest{
+				ID:      args[0],
+				Approve: true,
+				Comment: comment,
+			}
+			var err error
+			if req.TTL, err = parseTTL(ttl); err != nil {
+				return err
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return errors.Wrapf(err, "could not connect")
+			}
+			defer c.Close()
+			resp, err := c.ReviewRoleRequest(c.Ctx(), req)
+			if err != nil {
+				return grpcutil.ScrubGRPC(err)
+			}
+			if resp.Request.Expiration != nil {
+				fmt.Printf("Granted %q to %s until %s\n", resp.Request.Role, resp.Request.Principal, resp.Request.Expiration.Format(time.RFC3339))
+			} else {
+				fmt.Printf("Granted %q to %s\n", resp.Request.Role, resp.Request.Principal)
+			}
+			return nil
+		}),
+	}
+	approve.Flags().StringVar(&comment, "comment", "", "A comment recorded with the approval.")
+	approve.Flags().StringVar(&ttl, "ttl", "", "If set, overrides the lifetime requested by the requester. "+
+		"This flag should be a golang duration (e.g. \"30s\" or \"1h2m3s\").")
+	return cmdutil.CreateAlias(approve, "auth approve")
+}
+
+// DenyRoleRequestCmd returns a cobra command that denies a role request
+func DenyRoleRequestCmd() *cobra.Command {
+	var comment string
+	deny := &cobra.Command{
+		Use:   "{{alias}} <request-id>",
+		Short: "Deny a role request",
+		Long:  "Deny a role request. Requires permission to modify the role bindings on the requested resource.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return errors.Wrapf(err, "could not connect")
+			}
+			defer c.Close()
+			_, err = c.ReviewRoleRequest(c.Ctx(), &auth.ReviewRoleRequestRequest{
+				ID:      args[0],
+				Comment: comment,
+			})
+			return grpcutil.ScrubGRPC(err)
+		}),
+	}
+	deny.Flags().StringVar(&comment, "comment", "", "A comment recorded with the denial, e.g. the reason for it.")
+	return cmdutil.CreateAlias(deny, "auth deny")
+}
@@ -18,6 +18,7 @@ type APIServer interface {
 	CheckClusterIsAuthorized(ctx context.Context, p ...auth_client.Permission) error
 	CheckClusterIsAuthorizedInTransaction(*txncontext.TransactionContext, ...auth_client.Permission) error
 	CheckRepoIsAuthorizedInTransaction(*txncontext.TransactionContext, *pfs_client.Repo, ...auth_client.Permission) error
+	CheckProjectIsAuthorizedInTransaction(*txncontext.TransactionContext, string, ...auth_client.Permission) error
 
 	AuthorizeInTransaction(*txncontext.TransactionContext, *auth_client.AuthorizeRequest) (*auth_client.AuthorizeResponse, error)
 	ModifyRoleBindingInTransaction(*txncontext.TransactionContext, *auth_client.ModifyRoleBindingRequest) (*auth_client.ModifyRoleBindingResponse, error)
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dbutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	internalauth "github.com/pachyderm/pachyderm/v2/src/internal/middleware/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
+)
+
+// aclFreezeKey is the only key in the aclFreeze collection, which maps to the
+// ACL freeze while the ACLs are frozen.
+const aclFreezeKey = "freeze"
+
+// getACLFreeze returns the ACL freeze, or nil if the ACLs aren't frozen.
+// aclFreeze may be a read-only or read-write view of the collection.
+func getACLFreeze(aclFreeze interface {
+	Get(key interface{}, val proto.Message) error
+}) (*auth.ACLFreeze, error) {
+	freeze := &auth.ACLFreeze{}
+	if err := aclFreeze.Get(aclFreezeKey, freeze); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.EnsureStack(err)
+	}
+	return freeze, nil
+}
+
+// checkACLsNotFrozen returns ErrACLsFrozen if the ACLs are frozen. op names
+// the rejected change in the audit log.
+func (a *apiServer) checkACLsNotFrozen(ctx context.Context, op string) error {
+	freeze, err := getACLFreeze(a.aclFreeze.ReadOnly(ctx))
+	if err != nil {
+		return err
+	}
+	return rejectIfFrozen(freeze, op, internalauth.GetWhoAmI(ctx))
+}
+
+// checkACLsNotFrozenInTransaction is identical to checkACLsNotFrozen except
+// that it runs inside an existing postgres transaction.
+func (a *apiServer) checkACLsNotFrozenInTransaction(txnCtx *txncontext.TransactionContext, op string) error {
+	freeze, err := getACLFreeze(a.aclFreeze.ReadWrite(txnCtx.SqlTx))
+	if err != nil {
+		return err
+	}
+	principal, _ := txnCtx.WhoAmI()
+	return rejectIfFrozen(freeze, op, principal.GetUsername())
+}
+
+func rejectIfFrozen(freeze *auth.ACLFreeze, op, principal string) error {
+	if freeze == nil {
+		return nil
+	}
+	logrus.WithFields(logrus.Fields{
+		"principal": principal,
+		"op":        op,
+	}).Warn("auth: rejected ACL change while the ACLs are frozen")
+	return auth.ErrACLsFrozen
+}
+
+// FreezeACLs implements the protobuf auth.FreezeACLs RPC
+func (a *apiServer) FreezeACLs(ctx context.Context, req *auth.FreezeACLsRequest) (resp *auth.FreezeACLsResponse, retErr error) {
+	if req.Reason == "" {
+		return nil, errors.New("a reason must be given for freezing the ACLs")
+	}
+	callerInfo, err := a.getAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	freeze := &auth.ACLFreeze{
+		Principal: callerInfo.Subject,
+		Reason:    req.Reason,
+		Created:   &now,
+	}
+	if err := dbutil.WithTx(ctx, a.env.DB, func(sqlTx *pachsql.Tx) error {
+		existing, err := getACLFreeze(a.aclFreeze.ReadWrite(sqlTx))
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return errors.Errorf("the ACLs were already frozen by %v: %v", existing.Principal, existing.Reason)
+		}
+		return errors.EnsureStack(a.aclFreeze.ReadWrite(sqlTx).Put(aclFreezeKey, freeze))
+	}); err != nil {
+		return nil, err
+	}
+	logrus.WithFields(logrus.Fields{
+		"principal": freeze.Principal,
+		"reason":    freeze.Reason,
+	}).Warn("auth: ACLs frozen")
+	return &auth.FreezeACLsResponse{Freeze: freeze}, nil
+}
+
+// UnfreezeACLs implements the protobuf auth.UnfreezeACLs RPC
+func (a *apiServer) UnfreezeACLs(ctx context.Context, req *auth.UnfreezeACLsRequest) (resp *auth.UnfreezeACLsResponse, retErr error) {
+	callerInfo, err := a.getAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var freeze *auth.ACLFreeze
+	if err := dbutil.WithTx(ctx, a.env.DB, func(sqlTx *pachsql.Tx) error {
+		var err error
+		if freeze, err = getACLFreeze(a.aclFreeze.ReadWrite(sqlTx)); err != nil || freeze == nil {
+			return err
+		}
+		return errors.EnsureStack(a.aclFreeze.ReadWrite(sqlTx).Delete(aclFreezeKey))
+	}); err != nil {
+		return nil, err
+	}
+	if freeze != nil {
+		logrus.WithFields(logrus.Fields{
+			"principal":   callerInfo.Subject,
+			"frozen_by":   freeze.Principal,
+			"freeze_time": freeze.Created,
+		}).Warn("auth: ACLs unfrozen")
+	}
+	return &auth.UnfreezeACLsResponse{}, nil
+}
+
+// GetACLFreeze implements the protobuf auth.GetACLFreeze RPC
+func (a *apiServer) GetACLFreeze(ctx context.Context, req *auth.GetACLFreezeRequest) (resp *auth.GetACLFreezeResponse, retErr error) {
+	if err := a.isActive(ctx); err != nil {
+		return nil, err
+	}
+	freeze, err := getACLFreeze(a.aclFreeze.ReadOnly(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &auth.GetACLFreezeResponse{Freeze: freeze}, nil
+}
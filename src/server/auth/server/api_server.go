@@ -19,6 +19,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/keycache"
 	internalauth "github.com/pachyderm/pachyderm/v2/src/internal/middleware/auth"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
 	txnenv "github.com/pachyderm/pachyderm/v2/src/internal/transactionenv"
 	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
 	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
@@ -57,6 +58,10 @@ type apiServer struct {
 
 	configCache             *keycache.Cache
 	clusterRoleBindingCache *keycache.Cache
+	// roleBindingCache and repoCache cache the role bindings and repos read
+	// to authorize requests outside of a transaction.
+	roleBindingCache *keycache.CollectionCache
+	repoCache        *keycache.CollectionCache
 
 	// roleBindings is a collection of resource name -> role binding mappings.
 	roleBindings col.PostgresCollection
@@ -64,6 +69,13 @@ type apiServer struct {
 	members col.PostgresCollection
 	// groups is a collection of group -> usernames mappings.
 	groups col.PostgresCollection
+	// roleRequests is a collection of role request ID -> role request mappings.
+	roleRequests col.PostgresCollection
+	// repos is the pfs repos collection, which is read to find the project
+	// a repo is in.
+	repos col.PostgresCollection
+	// aclFreeze contains the ACL freeze, if any (under the key aclFreezeKey)
+	aclFreeze col.PostgresCollection
 	// collection containing the auth config (under the key configKey)
 	authConfig col.PostgresCollection
 	// oidcStates  contains the set of OIDC nonces for requests that are in progress
@@ -99,6 +111,9 @@ func NewAuthServer(env Env, public, requireNoncriticalServers, watchesEnabled bo
 		roleBindings:   roleBindingsCollection(env.DB, env.Listener),
 		members:        membersCollection(env.DB, env.Listener),
 		groups:         groupsCollection(env.DB, env.Listener),
+		roleRequests:   roleRequestsCollection(env.DB, env.Listener),
+		aclFreeze:      aclFreezeCollection(env.DB, env.Listener),
+		repos:          pfsdb.Repos(env.DB, env.Listener),
 		oidcStates:     oidcStates,
 		public:         public,
 		watchesEnabled: watchesEnabled,
@@ -120,10 +135,19 @@ func NewAuthServer(env Env, public, requireNoncriticalServers, watchesEnabled bo
 
 		// Watch for changes to the cluster role binding
 		go s.clusterRoleBindingCache.Watch()
+
+		s.roleBindingCache = keycache.NewCollectionCache(env.BackgroundContext, s.roleBindings, &auth.RoleBinding{}, env.Config.MetadataCacheSize)
+		s.repoCache = keycache.NewCollectionCache(env.BackgroundContext, s.repos, &pfs.RepoInfo{}, env.Config.MetadataCacheSize)
+		go s.roleBindingCache.Watch()
+		go s.repoCache.Watch()
 	}
 
 	s.deleteExpiredTokensRoutine()
 
+	if public {
+		s.expireRoleRequestsRoutine()
+	}
+
 	return s, nil
 }
 
@@ -385,6 +409,9 @@ func (a *apiServer) rotateRootToken(ctx context.Context, req *auth.RotateRootTok
 
 // Deactivate implements the protobuf auth.Deactivate RPC
 func (a *apiServer) Deactivate(ctx context.Context, req *auth.DeactivateRequest) (resp *auth.DeactivateResponse, retErr error) {
+	if err := a.checkACLsNotFrozen(ctx, "Deactivate"); err != nil {
+		return nil, err
+	}
 	if err := dbutil.WithTx(ctx, a.env.DB, func(sqlTx *pachsql.Tx) error {
 		a.roleBindings.ReadWrite(sqlTx).DeleteAll()
 		a.deleteAllAuthTokens(ctx, sqlTx)
@@ -473,6 +500,29 @@ func (a *apiServer) Authenticate(ctx context.Context, req *auth.AuthenticateRequ
 		}
 		pachToken = t
 
+	case req.KubernetesToken != "":
+		subject, expiry, err := a.validateKubernetesToken(ctx, req.KubernetesToken)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := a.expiredEnterpriseCheck(ctx, subject); err != nil {
+			return nil, err
+		}
+
+		// As with ID tokens, the pach token expires with the service account
+		// token, or after the default TTL if that's sooner.
+		expirationSecs := int64(60 * a.env.Config.SessionDurationMinutes)
+		if !expiry.IsZero() && time.Until(expiry) < time.Duration(expirationSecs)*time.Second {
+			expirationSecs = int64(time.Until(expiry).Seconds())
+		}
+
+		t, err := a.generateAndInsertAuthToken(ctx, subject, expirationSecs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error storing auth token for %q", subject)
+		}
+		pachToken = t
+
 	default:
 		return nil, errors.Errorf("unrecognized authentication mechanism (old pachd?)")
 	}
@@ -492,7 +542,11 @@ func resourceKey(r *auth.Resource) string {
 	return fmt.Sprintf("%s:%s", r.Type, r.Name)
 }
 
-func (a *apiServer) evaluateRoleBindingInTransaction(txnCtx *txncontext.TransactionContext, principal string, resource *auth.Resource, permissions map[auth.Permission]bool) (*authorizeRequest, error) {
+// evaluateRoleBindingInTransaction evaluates the role bindings that apply to
+// principal on resource. If cacheCtx isn't nil, the resource's role bindings
+// are read with it from the caches, which is only safe if txnCtx's
+// transaction can't have modified them.
+func (a *apiServer) evaluateRoleBindingInTransaction(txnCtx *txncontext.TransactionContext, principal string, resource *auth.Resource, permissions map[auth.Permission]bool, cacheCtx context.Context) (*authorizeRequest, error) {
 	request := newAuthorizeRequest(principal, permissions, a.getGroupsInTransaction)
 
 	// Special-case making spec repos world-readable, because the alternative breaks reading pipelines.
@@ -535,9 +589,29 @@ func (a *apiServer) evaluateRoleBindingInTransaction(txnCtx *txncontext.Transact
 		return request, nil
 	}
 
+	// Roles bound on a project apply to the repos in it
+	if resource.Type == auth.ResourceType_REPO {
+		project, err := a.repoProjectInTransaction(txnCtx, resource.Name, cacheCtx)
+		if err != nil {
+			return nil, err
+		}
+		if project != "" {
+			var projectBinding auth.RoleBinding
+			if err := a.getRoleBindingInTransaction(txnCtx, resourceKey(&auth.Resource{Type: auth.ResourceType_PROJECT, Name: project}), &projectBinding, cacheCtx); err != nil && !col.IsErrNotFound(err) {
+				return nil, errors.Wrapf(err, "error getting role bindings for project %q", project)
+			}
+			if err := request.evaluateRoleBinding(txnCtx, &projectBinding); err != nil {
+				return nil, err
+			}
+			if request.isSatisfied() {
+				return request, nil
+			}
+		}
+	}
+
 	// Get the role bindings for the resource to check
 	var roleBinding auth.RoleBinding
-	if err := a.roleBindings.ReadWrite(txnCtx.SqlTx).Get(resourceKey(resource), &roleBinding); err != nil {
+	if err := a.getRoleBindingInTransaction(txnCtx, resourceKey(resource), &roleBinding, cacheCtx); err != nil {
 		if col.IsErrNotFound(err) {
 			return nil, &auth.ErrNoRoleBinding{
 				Resource: *resource,
@@ -551,12 +625,45 @@ func (a *apiServer) evaluateRoleBindingInTransaction(txnCtx *txncontext.Transact
 	return request, nil
 }
 
+// getRoleBindingInTransaction reads the role binding under key, from the
+// cache if cacheCtx isn't nil and it's enabled.
+func (a *apiServer) getRoleBindingInTransaction(txnCtx *txncontext.TransactionContext, key string, binding *auth.RoleBinding, cacheCtx context.Context) error {
+	if cacheCtx != nil && a.roleBindingCache != nil {
+		return a.roleBindingCache.Get(cacheCtx, key, binding)
+	}
+	return errors.EnsureStack(a.roleBindings.ReadWrite(txnCtx.SqlTx).Get(key, binding))
+}
+
+// repoProjectInTransaction returns the name of the project the user repo
+// named repo is in, or "" if it's in the default project or doesn't exist.
+// The repo is read from the cache if cacheCtx isn't nil and it's enabled.
+func (a *apiServer) repoProjectInTransaction(txnCtx *txncontext.TransactionContext, repo string, cacheCtx context.Context) (string, error) {
+	var repoInfo pfs.RepoInfo
+	var err error
+	if cacheCtx != nil && a.repoCache != nil {
+		err = a.repoCache.Get(cacheCtx, pfsdb.RepoKey(&pfs.Repo{Name: repo, Type: pfs.UserRepoType}), &repoInfo)
+	} else {
+		err = a.repos.ReadWrite(txnCtx.SqlTx).Get(&pfs.Repo{Name: repo, Type: pfs.UserRepoType}, &repoInfo)
+	}
+	if err != nil {
+		if col.IsErrNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "error getting project of repo %q", repo)
+	}
+	return repoInfo.Project.GetName(), nil
+}
+
 // AuthorizeInTransaction is identical to Authorize except that it can run
 // inside an existing etcd STM transaction.  This is not an RPC.
 func (a *apiServer) AuthorizeInTransaction(
 	txnCtx *txncontext.TransactionContext,
 	req *auth.AuthorizeRequest,
 ) (resp *auth.AuthorizeResponse, retErr error) {
+	return a.authorizeInTransaction(txnCtx, req, nil)
+}
+
+func (a *apiServer) authorizeInTransaction(txnCtx *txncontext.TransactionContext, req *auth.AuthorizeRequest, cacheCtx context.Context) (*auth.AuthorizeResponse, error) {
 	me, err := txnCtx.WhoAmI()
 	if err != nil {
 		return nil, err
@@ -567,7 +674,7 @@ func (a *apiServer) AuthorizeInTransaction(
 		permissions[p] = true
 	}
 
-	request, err := a.evaluateRoleBindingInTransaction(txnCtx, me.Username, req.Resource, permissions)
+	request, err := a.evaluateRoleBindingInTransaction(txnCtx, me.Username, req.Resource, permissions, cacheCtx)
 	if err != nil {
 		return nil, err
 	}
@@ -588,7 +695,9 @@ func (a *apiServer) Authorize(
 	var response *auth.AuthorizeResponse
 	if err := a.env.TxnEnv.WithReadContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
 		var err error
-		response, err = a.AuthorizeInTransaction(txnCtx, req)
+		// the read transaction can't have modified any role bindings, so
+		// they're read from the caches
+		response, err = a.authorizeInTransaction(txnCtx, req, ctx)
 		return err
 	}); err != nil {
 		return nil, err
@@ -605,7 +714,7 @@ func (a *apiServer) GetPermissionsForPrincipal(ctx context.Context, req *auth.Ge
 	var request *authorizeRequest
 	if err := a.env.TxnEnv.WithReadContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
 		var err error
-		request, err = a.evaluateRoleBindingInTransaction(txnCtx, req.Principal, req.Resource, permissions)
+		request, err = a.evaluateRoleBindingInTransaction(txnCtx, req.Principal, req.Resource, permissions, ctx)
 		return err
 	}); err != nil {
 		return nil, err
@@ -644,7 +753,7 @@ func (a *apiServer) getPermissionsForPrincipalInTransaction(txnCtx *txncontext.T
 		permissions[auth.Permission(p)] = true
 	}
 
-	request, err := a.evaluateRoleBindingInTransaction(txnCtx, req.Principal, req.Resource, permissions)
+	request, err := a.evaluateRoleBindingInTransaction(txnCtx, req.Principal, req.Resource, permissions, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -808,6 +917,9 @@ func (a *apiServer) ModifyRoleBindingInTransaction(
 	if err := a.isActiveInTransaction(txnCtx); err != nil {
 		return nil, err
 	}
+	if err := a.checkACLsNotFrozenInTransaction(txnCtx, "ModifyRoleBinding"); err != nil {
+		return nil, err
+	}
 
 	if err := a.checkCanonicalSubject(req.Principal); err != nil {
 		return nil, err
@@ -828,10 +940,30 @@ func (a *apiServer) ModifyRoleBindingInTransaction(
 		if err := a.CheckRepoIsAuthorizedInTransaction(txnCtx, &pfs.Repo{Type: pfs.UserRepoType, Name: req.Resource.Name}, auth.Permission_REPO_MODIFY_BINDINGS); err != nil {
 			return nil, err
 		}
+	case auth.ResourceType_PROJECT:
+		if err := a.CheckProjectIsAuthorizedInTransaction(txnCtx, req.Resource.Name, auth.Permission_PROJECT_MODIFY_BINDINGS); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, errors.Errorf("unknown resource type %v", req.Resource.Type)
 	}
 
+	for _, name := range req.Roles {
+		r, err := getRole(name)
+		if err != nil {
+			return nil, err
+		}
+		if !roleAppliesToResource(r.role, req.Resource.Type) {
+			return nil, errors.Errorf("role %q cannot be bound to a %v", name, req.Resource.Type)
+		}
+	}
+
+	if req.Resource.Type != auth.ResourceType_CLUSTER {
+		if err := a.checkRoleBoundaryInTransaction(txnCtx, req.Resource, req.Principal, req.Roles); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := a.setUserRoleBindingInTransaction(txnCtx, req.Resource, req.Principal, req.Roles); err != nil {
 		return nil, err
 	}
@@ -839,6 +971,68 @@ func (a *apiServer) ModifyRoleBindingInTransaction(
 	return &auth.ModifyRoleBindingResponse{}, nil
 }
 
+// checkRoleBoundaryInTransaction returns an error if the caller grants or
+// revokes a role on resource that has permissions the caller doesn't have on
+// resource itself. This lets users who can modify a resource's role bindings,
+// such as delegated admins, manage access to it without being able to grant
+// more access than they have. Users with the CLUSTER_MODIFY_BINDINGS
+// permission are exempt.
+func (a *apiServer) checkRoleBoundaryInTransaction(txnCtx *txncontext.TransactionContext, resource *auth.Resource, principal string, roleSlice []string) error {
+	me, err := txnCtx.WhoAmI()
+	if err != nil {
+		return err
+	}
+
+	// evaluateRoleBindingInTransaction may modify the resource, so pass a copy
+	resp, err := a.getPermissionsForPrincipalInTransaction(txnCtx, &auth.GetPermissionsForPrincipalRequest{
+		Principal: me.Username,
+		Resource:  &auth.Resource{Type: resource.Type, Name: resource.Name},
+	})
+	if err != nil {
+		return err
+	}
+	boundary := make(map[auth.Permission]bool)
+	for _, p := range resp.Permissions {
+		boundary[p] = true
+	}
+	if boundary[auth.Permission_CLUSTER_MODIFY_BINDINGS] {
+		return nil
+	}
+
+	// Only the roles being granted or revoked need to be within the boundary,
+	// so that the principal's other roles can be left in place.
+	var bindings auth.RoleBinding
+	if err := a.roleBindings.ReadWrite(txnCtx.SqlTx).Get(resourceKey(resource), &bindings); err != nil && !col.IsErrNotFound(err) {
+		return errors.EnsureStack(err)
+	}
+	current := make(map[string]bool)
+	if entry, ok := bindings.Entries[principal]; ok && entry != nil {
+		current = entry.Roles
+	}
+	requested := make(map[string]bool)
+	var changed []string
+	for _, r := range roleSlice {
+		requested[r] = true
+		if !current[r] {
+			changed = append(changed, r)
+		}
+	}
+	for r := range current {
+		if !requested[r] {
+			changed = append(changed, r)
+		}
+	}
+
+	missing, err := permissionsOutsideBoundary(changed, boundary)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return &auth.ErrNotAuthorized{Subject: me.Username, Resource: *resource, Required: missing}
+	}
+	return nil
+}
+
 func (a *apiServer) setUserRoleBindingInTransaction(txnCtx *txncontext.TransactionContext, resource *auth.Resource, principal string, roleSlice []string) error {
 	roles, err := rolesFromRoleSlice(roleSlice)
 	if err != nil {
@@ -949,6 +1143,10 @@ func (a *apiServer) GetRobotToken(ctx context.Context, req *auth.GetRobotTokenRe
 
 	subject = auth.RobotPrefix + subject
 
+	if err := a.checkACLsNotFrozen(ctx, "GetRobotToken"); err != nil {
+		return nil, err
+	}
+
 	// generate new token, and write to postgres
 	var token string
 	var err error
@@ -1070,6 +1268,9 @@ func (a *apiServer) SetGroupsForUser(ctx context.Context, req *auth.SetGroupsFor
 	if err := a.checkCanonicalSubject(req.Username); err != nil {
 		return nil, err
 	}
+	if err := a.checkACLsNotFrozen(ctx, "SetGroupsForUser"); err != nil {
+		return nil, err
+	}
 	// TODO(msteffen): canonicalize group names
 	if err := a.setGroupsForUserInternal(ctx, req.Username, req.Groups); err != nil {
 		return nil, err
@@ -1086,6 +1287,9 @@ func (a *apiServer) ModifyMembers(ctx context.Context, req *auth.ModifyMembersRe
 	if err := a.checkCanonicalSubjects(req.Remove); err != nil {
 		return nil, err
 	}
+	if err := a.checkACLsNotFrozen(ctx, "ModifyMembers"); err != nil {
+		return nil, err
+	}
 
 	if err := dbutil.WithTx(ctx, a.env.DB, func(sqlTx *pachsql.Tx) error {
 		members := a.members.ReadWrite(sqlTx)
@@ -1340,6 +1544,9 @@ func (a *apiServer) SetConfiguration(ctx context.Context, req *auth.SetConfigura
 	if !a.watchesEnabled {
 		return nil, errors.New("watches are not enabled, unable to set config")
 	}
+	if err := a.checkACLsNotFrozen(ctx, "SetConfiguration"); err != nil {
+		return nil, err
+	}
 
 	var configToStore *auth.OIDCConfig
 	if req.Configuration != nil {
@@ -1389,6 +1596,9 @@ func (a *apiServer) ExtractAuthTokens(ctx context.Context, req *auth.ExtractAuth
 }
 
 func (a *apiServer) RestoreAuthToken(ctx context.Context, req *auth.RestoreAuthTokenRequest) (resp *auth.RestoreAuthTokenResponse, retErr error) {
+	if err := a.checkACLsNotFrozen(ctx, "RestoreAuthToken"); err != nil {
+		return nil, err
+	}
 	var ttl int64
 	if req.Token.Expiration != nil {
 		ttl = int64(time.Until(*req.Token.Expiration).Seconds())
@@ -1,8 +1,13 @@
 package server
 
 import (
+	"context"
+	"sort"
+	"strings"
+
 	"github.com/pachyderm/pachyderm/v2/src/auth"
 	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
 )
 
@@ -11,6 +16,8 @@ const (
 	roleBindingsCollectionName = "role_bindings"
 	membersCollectionName      = "members"
 	groupsCollectionName       = "groups"
+	roleRequestsCollectionName = "role_requests"
+	aclFreezeCollectionName    = "acl_freeze"
 )
 
 var authConfigIndexes = []*col.Index{}
@@ -25,6 +32,12 @@ func authConfigCollection(db *pachsql.DB, listener col.PostgresListener) col.Pos
 	)
 }
 
+// AuthConfigCollection returns the collection that stores the auth config,
+// for watching changes to it.
+func AuthConfigCollection(db *pachsql.DB, listener col.PostgresListener) col.PostgresCollection {
+	return authConfigCollection(db, listener)
+}
+
 var roleBindingsIndexes = []*col.Index{}
 
 func roleBindingsCollection(db *pachsql.DB, listener col.PostgresListener) col.PostgresCollection {
@@ -37,6 +50,31 @@ func roleBindingsCollection(db *pachsql.DB, listener col.PostgresListener) col.P
 	)
 }
 
+// RepoOwners returns the principals with the repoOwner role on each repo,
+// keyed by repo name, for attributing repos and pipelines to their owners.
+// Pipelines are never repo owners.
+func RepoOwners(ctx context.Context, db *pachsql.DB, listener col.PostgresListener) (map[string][]string, error) {
+	owners := make(map[string][]string)
+	prefix := auth.ResourceType_REPO.String() + ":"
+	binding := &auth.RoleBinding{}
+	if err := roleBindingsCollection(db, listener).ReadOnly(ctx).List(binding, col.DefaultOptions(), func(key string) error {
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		repo := strings.TrimPrefix(key, prefix)
+		for principal, roles := range binding.Entries {
+			if roles.Roles[auth.RepoOwnerRole] && !strings.HasPrefix(principal, auth.PipelinePrefix) {
+				owners[repo] = append(owners[repo], principal)
+			}
+		}
+		sort.Strings(owners[repo])
+		return nil
+	}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return owners, nil
+}
+
 var membersIndexes = []*col.Index{}
 
 func membersCollection(db *pachsql.DB, listener col.PostgresListener) col.PostgresCollection {
@@ -61,6 +99,18 @@ func groupsCollection(db *pachsql.DB, listener col.PostgresListener) col.Postgre
 	)
 }
 
+var roleRequestsIndexes = []*col.Index{}
+
+func roleRequestsCollection(db *pachsql.DB, listener col.PostgresListener) col.PostgresCollection {
+	return col.NewPostgresCollection(
+		roleRequestsCollectionName,
+		db,
+		listener,
+		&auth.RoleRequest{},
+		roleRequestsIndexes,
+	)
+}
+
 // CollectionsV0 returns a list of all the PPS API collections for
 // postgres-initialization purposes. These collections are not usable for
 // querying.
@@ -74,3 +124,37 @@ func CollectionsV0() []col.PostgresCollection {
 		col.NewPostgresCollection(groupsCollectionName, nil, nil, nil, groupsIndexes),
 	}
 }
+
+var aclFreezeIndexes = []*col.Index{}
+
+func aclFreezeCollection(db *pachsql.DB, listener col.PostgresListener) col.PostgresCollection {
+	return col.NewPostgresCollection(
+		aclFreezeCollectionName,
+		db,
+		listener,
+		&auth.ACLFreeze{},
+		aclFreezeIndexes,
+	)
+}
+
+// RoleRequestsCollectionsV0 returns the role requests collection for
+// postgres-initialization purposes. This collection is not usable for
+// querying.
+// DO NOT MODIFY THIS FUNCTION
+// IT HAS BEEN USED IN A RELEASED MIGRATION
+func RoleRequestsCollectionsV0() []col.PostgresCollection {
+	return []col.PostgresCollection{
+		col.NewPostgresCollection(roleRequestsCollectionName, nil, nil, nil, roleRequestsIndexes),
+	}
+}
+
+// ACLFreezeCollectionsV0 returns the ACL freeze collection for
+// postgres-initialization purposes. This collection is not usable for
+// querying.
+// DO NOT MODIFY THIS FUNCTION
+// IT HAS BEEN USED IN A RELEASED MIGRATION
+func ACLFreezeCollectionsV0() []col.PostgresCollection {
+	return []col.PostgresCollection{
+		col.NewPostgresCollection(aclFreezeCollectionName, nil, nil, nil, aclFreezeIndexes),
+	}
+}
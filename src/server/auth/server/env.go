@@ -11,6 +11,7 @@ import (
 	logrus "github.com/sirupsen/logrus"
 	etcd "go.etcd.io/etcd/client/v3"
 	"golang.org/x/net/context"
+	kube "k8s.io/client-go/kubernetes"
 )
 
 // Env is the environment required for an apiServer
@@ -25,6 +26,10 @@ type Env struct {
 	GetPfsServer        func() pfs.APIServer
 	GetPpsServer        func() pps.APIServer
 
+	// GetKubeClient is only called to review Kubernetes service account
+	// tokens, so it may be nil if AuthKubernetesAudiences isn't set.
+	GetKubeClient func() kube.Interface
+
 	BackgroundContext context.Context
 	Logger            *logrus.Logger
 	Config            serviceenv.Configuration
@@ -41,6 +46,8 @@ func EnvFromServiceEnv(senv serviceenv.ServiceEnv, txnEnv *txnenv.TransactionEnv
 		GetPfsServer:        senv.PfsServer,
 		GetPpsServer:        senv.PpsServer,
 
+		GetKubeClient: func() kube.Interface { return senv.GetKubeClient() },
+
 		BackgroundContext: senv.Context(),
 		Logger:            senv.Logger(),
 		Config:            *senv.Config(),
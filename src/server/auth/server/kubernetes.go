@@ -0,0 +1,113 @@
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	authnv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+const (
+	// kubernetesRobotPrefix is the prefix of the robot names that Kubernetes
+	// service accounts are authenticated as.
+	kubernetesRobotPrefix = "k8s/"
+	// serviceAccountPrefix is the prefix of the usernames Kubernetes gives
+	// service accounts.
+	serviceAccountPrefix = "system:serviceaccount:"
+)
+
+// kubernetesSubject returns the subject that the given Kubernetes service
+// account is authenticated as.
+func kubernetesSubject(namespace, serviceAccount string) string {
+	return auth.RobotPrefix + kubernetesRobotPrefix + namespace + "/" + serviceAccount
+}
+
+// splitList splits a comma-separated configuration value, dropping empty
+// entries.
+func splitList(s string) []string {
+	var result []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+// validateKubernetesToken verifies a Kubernetes service account token with a
+// TokenReview, and returns the subject of its service account and when the
+// token expires. The expiration is zero if the token doesn't expire.
+func (a *apiServer) validateKubernetesToken(ctx context.Context, token string) (string, time.Time, error) {
+	audiences := splitList(a.env.Config.AuthKubernetesAudiences)
+	if len(audiences) == 0 || a.env.GetKubeClient == nil {
+		return "", time.Time{}, errors.New("this cluster doesn't accept Kubernetes service account tokens, set AUTH_KUBERNETES_AUDIENCES to enable them")
+	}
+	review, err := a.env.GetKubeClient().AuthenticationV1().TokenReviews().Create(ctx, &authnv1.TokenReview{
+		Spec: authnv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: audiences,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", time.Time{}, errors.Wrapf(err, "could not review Kubernetes token")
+	}
+	if !review.Status.Authenticated {
+		return "", time.Time{}, errors.Errorf("Kubernetes token was rejected: %s", review.Status.Error)
+	}
+	username := review.Status.User.Username
+	if !strings.HasPrefix(username, serviceAccountPrefix) {
+		return "", time.Time{}, errors.Errorf("Kubernetes token belongs to %q, which is not a service account", username)
+	}
+	parts := strings.SplitN(strings.TrimPrefix(username, serviceAccountPrefix), ":", 2)
+	if len(parts) != 2 {
+		return "", time.Time{}, errors.Errorf("could not parse service account name %q", username)
+	}
+	namespace, serviceAccount := parts[0], parts[1]
+	if namespaces := splitList(a.env.Config.AuthKubernetesNamespaces); len(namespaces) > 0 {
+		var allowed bool
+		for _, ns := range namespaces {
+			if ns == namespace {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return "", time.Time{}, errors.Errorf("service accounts in namespace %q cannot log in to this cluster", namespace)
+		}
+	}
+	expiry, err := tokenExpiry(token)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return kubernetesSubject(namespace, serviceAccount), expiry, nil
+}
+
+// tokenExpiry returns the value of the 'exp' claim of a JWT that has already
+// been verified, or zero if it's not set.
+func tokenExpiry(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, errors.New("Kubernetes token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "could not decode Kubernetes token")
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, errors.Wrapf(err, "could not parse Kubernetes token claims")
+	}
+	if claims.Exp == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(claims.Exp, 0), nil
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	authnv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kube "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/internal/serviceenv"
+)
+
+func TestValidateKubernetesToken(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authnv1.TokenReview)
+		require.Equal(t, []string{"pachyderm", "pachyderm.example.com"}, review.Spec.Audiences)
+		switch review.Spec.Token {
+		case "bad":
+			review.Status.Error = "invalid token"
+		case "user":
+			review.Status.Authenticated = true
+			review.Status.User.Username = "alice"
+		default:
+			review.Status.Authenticated = true
+			review.Status.User.Username = "system:serviceaccount:" + review.Spec.Token[:len(review.Spec.Token)-len(".e30.sig")] + ":etl"
+		}
+		return true, review, nil
+	})
+	config := &serviceenv.EnterpriseSpecifcConfiguration{
+		AuthKubernetesAudiences:  "pachyderm, pachyderm.example.com",
+		AuthKubernetesNamespaces: "jobs,ingest",
+	}
+	a := &apiServer{env: Env{
+		GetKubeClient: func() kube.Interface { return client },
+		Config:        serviceenv.Configuration{EnterpriseSpecifcConfiguration: config},
+	}}
+	ctx := context.Background()
+
+	// the namespace is the JWT header, so that the payload is a valid claim set
+	subject, expiry, err := a.validateKubernetesToken(ctx, "jobs.e30.sig")
+	require.NoError(t, err)
+	require.Equal(t, "robot:k8s/jobs/etl", subject)
+	require.True(t, expiry.IsZero())
+
+	exp := base64.RawURLEncoding.EncodeToString([]byte(`{"exp":1700000000}`))
+	expiry, err = tokenExpiry("header." + exp + ".sig")
+	require.NoError(t, err)
+	require.True(t, expiry.Equal(time.Unix(1700000000, 0)))
+	_, err = tokenExpiry("not a jwt")
+	require.YesError(t, err)
+
+	_, _, err = a.validateKubernetesToken(ctx, "other.e30.sig")
+	require.YesError(t, err)
+	_, _, err = a.validateKubernetesToken(ctx, "bad")
+	require.YesError(t, err)
+	_, _, err = a.validateKubernetesToken(ctx, "user")
+	require.YesError(t, err)
+
+	config.AuthKubernetesAudiences = ""
+	_, _, err = a.validateKubernetesToken(ctx, "jobs.e30.sig")
+	require.YesError(t, err)
+}
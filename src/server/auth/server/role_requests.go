@@ -0,0 +1,413 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	internalauth "github.com/pachyderm/pachyderm/v2/src/internal/middleware/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
+	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
+)
+
+const (
+	// roleRequestExpiryInterval is how often approved role requests are
+	// checked for role bindings whose TTL has elapsed.
+	roleRequestExpiryInterval = time.Minute
+
+	roleRequestWebhookTimeout = 10 * time.Second
+)
+
+// roleRequestEvent is the body of the webhook sent when a role request
+// changes state.
+type roleRequestEvent struct {
+	// Event is one of "created", "approved", "denied" or "expired".
+	Event   string          `json:"event"`
+	Request json.RawMessage `json:"request"`
+	// Reviewers are the principals that can review the request.
+	Reviewers []string `json:"reviewers"`
+}
+
+// modifyBindingsPermission returns the permission required to modify the role
+// bindings on resources of type rt, which is also the permission required to
+// review role requests for them.
+func modifyBindingsPermission(rt auth.ResourceType) (auth.Permission, error) {
+	switch rt {
+	case auth.ResourceType_CLUSTER:
+		return auth.Permission_CLUSTER_MODIFY_BINDINGS, nil
+	case auth.ResourceType_REPO:
+		return auth.Permission_REPO_MODIFY_BINDINGS, nil
+	case auth.ResourceType_PROJECT:
+		return auth.Permission_PROJECT_MODIFY_BINDINGS, nil
+	default:
+		return 0, errors.Errorf("role requests are not supported for resource type %v", rt)
+	}
+}
+
+// canReviewInTransaction returns true if principal can review role requests
+// for resource.
+func (a *apiServer) canReviewInTransaction(txnCtx *txncontext.TransactionContext, principal string, resource *auth.Resource) (bool, error) {
+	permission, err := modifyBindingsPermission(resource.Type)
+	if err != nil {
+		return false, err
+	}
+	request, err := a.evaluateRoleBindingInTransaction(txnCtx, principal, proto.Clone(resource).(*auth.Resource), map[auth.Permission]bool{permission: true}, nil)
+	if err != nil {
+		if auth.IsErrNoRoleBinding(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return request.isSatisfied(), nil
+}
+
+// RequestRole implements the protobuf auth.RequestRole RPC
+func (a *apiServer) RequestRole(ctx context.Context, req *auth.RequestRoleRequest) (resp *auth.RequestRoleResponse, retErr error) {
+	if req.Resource == nil {
+		return nil, errors.New("a resource must be specified")
+	}
+	if _, err := modifyBindingsPermission(req.Resource.Type); err != nil {
+		return nil, err
+	}
+	role, err := getRole(req.Role)
+	if err != nil {
+		return nil, err
+	}
+	if !roleAppliesToResource(role.role, req.Resource.Type) {
+		return nil, errors.Errorf("role %q cannot be granted on resources of type %v", req.Role, req.Resource.Type)
+	}
+	if req.TTL < 0 {
+		return nil, errors.Errorf("invalid TTL %d", req.TTL)
+	}
+	callerInfo, err := a.getAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	roleRequest := &auth.RoleRequest{
+		ID:        uuid.NewWithoutDashes(),
+		Principal: callerInfo.Subject,
+		Resource:  req.Resource,
+		Role:      req.Role,
+		Reason:    req.Reason,
+		TTL:       req.TTL,
+		State:     auth.RoleRequestState_PENDING,
+		Created:   &now,
+	}
+	var reviewers []string
+	if err := a.env.TxnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		var binding auth.RoleBinding
+		if err := a.roleBindings.ReadWrite(txnCtx.SqlTx).Get(resourceKey(req.Resource), &binding); err != nil {
+			if col.IsErrNotFound(err) {
+				return &auth.ErrNoRoleBinding{Resource: *req.Resource}
+			}
+			return errors.EnsureStack(err)
+		}
+		if roles, ok := binding.Entries[callerInfo.Subject]; ok && roles.Roles[req.Role] {
+			return errors.Errorf("%v already has role %q on %v %q", callerInfo.Subject, req.Role, req.Resource.Type, req.Resource.Name)
+		}
+		var err error
+		reviewers, err = a.reviewersInTransaction(txnCtx, req.Resource)
+		if err != nil {
+			return err
+		}
+		return errors.EnsureStack(a.roleRequests.ReadWrite(txnCtx.SqlTx).Create(roleRequest.ID, roleRequest))
+	}); err != nil {
+		return nil, err
+	}
+	a.notifyRoleRequest("created", roleRequest, reviewers)
+	return &auth.RequestRoleResponse{Request: roleRequest}, nil
+}
+
+// ListRoleRequests implements the protobuf auth.ListRoleRequests RPC. Callers
+// see their own requests and the requests they can review.
+func (a *apiServer) ListRoleRequests(ctx context.Context, req *auth.ListRoleRequestsRequest) (resp *auth.ListRoleRequestsResponse, retErr error) {
+	callerInfo, err := a.getAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var roleRequests []*auth.RoleRequest
+	roleRequest := &auth.RoleRequest{}
+	if err := a.roleRequests.ReadOnly(ctx).List(roleRequest, col.DefaultOptions(), func(string) error {
+		if !req.All && roleRequest.State != auth.RoleRequestState_PENDING {
+			return nil
+		}
+		if req.Resource != nil && resourceKey(req.Resource) != resourceKey(roleRequest.Resource) {
+			return nil
+		}
+		roleRequests = append(roleRequests, proto.Clone(roleRequest).(*auth.RoleRequest))
+		return nil
+	}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	resp = &auth.ListRoleRequestsResponse{}
+	if err := a.env.TxnEnv.WithReadContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		resp.Requests = nil
+		// canReview caches review permissions by resource, as most requests
+		// are for a handful of resources.
+		canReview := make(map[string]bool)
+		for _, roleRequest := range roleRequests {
+			if roleRequest.Principal != callerInfo.Subject {
+				key := resourceKey(roleRequest.Resource)
+				ok, cached := canReview[key]
+				if !cached {
+					var err error
+					if ok, err = a.canReviewInTransaction(txnCtx, callerInfo.Subject, roleRequest.Resource); err != nil {
+						return err
+					}
+					canReview[key] = ok
+				}
+				if !ok {
+					continue
+				}
+			}
+			resp.Requests = append(resp.Requests, roleRequest)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(resp.Requests, func(i, j int) bool {
+		return resp.Requests[i].Created.Before(*resp.Requests[j].Created)
+	})
+	return resp, nil
+}
+
+// ReviewRoleRequest implements the protobuf auth.ReviewRoleRequest RPC.
+// Approving a request adds the requested role to the requester's role binding
+// on the resource, and the role is removed again once its TTL elapses.
+func (a *apiServer) ReviewRoleRequest(ctx context.Context, req *auth.ReviewRoleRequestRequest) (resp *auth.ReviewRoleRequestResponse, retErr error) {
+	if req.TTL < 0 {
+		return nil, errors.Errorf("invalid TTL %d", req.TTL)
+	}
+	callerInfo, err := a.getAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	roleRequest := &auth.RoleRequest{}
+	var reviewers []string
+	if err := a.env.TxnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		roleRequests := a.roleRequests.ReadWrite(txnCtx.SqlTx)
+		if err := roleRequests.Get(req.ID, roleRequest); err != nil {
+			if col.IsErrNotFound(err) {
+				return errors.Errorf("role request %q not found", req.ID)
+			}
+			return errors.EnsureStack(err)
+		}
+		if roleRequest.State != auth.RoleRequestState_PENDING {
+			return errors.Errorf("role request %q has already been reviewed", req.ID)
+		}
+		if roleRequest.Principal == callerInfo.Subject {
+			return errors.New("cannot review your own role request")
+		}
+		ok, err := a.canReviewInTransaction(txnCtx, callerInfo.Subject, roleRequest.Resource)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			permission, _ := modifyBindingsPermission(roleRequest.Resource.Type)
+			return &auth.ErrNotAuthorized{
+				Subject:  callerInfo.Subject,
+				Resource: *roleRequest.Resource,
+				Required: []auth.Permission{permission},
+			}
+		}
+		now := time.Now()
+		roleRequest.Reviewer = callerInfo.Subject
+		roleRequest.ReviewComment = req.Comment
+		roleRequest.Reviewed = &now
+		roleRequest.State = auth.RoleRequestState_DENIED
+		if req.Approve {
+			if err := a.checkACLsNotFrozenInTransaction(txnCtx, "ReviewRoleRequest"); err != nil {
+				return err
+			}
+			if err := a.addRoleInTransaction(txnCtx, roleRequest.Resource, roleRequest.Principal, roleRequest.Role); err != nil {
+				return err
+			}
+			if req.TTL > 0 {
+				roleRequest.TTL = req.TTL
+			}
+			if roleRequest.TTL > 0 {
+				expiration := now.Add(time.Duration(roleRequest.TTL) * time.Second)
+				roleRequest.Expiration = &expiration
+			}
+			roleRequest.State = auth.RoleRequestState_APPROVED
+		}
+		if reviewers, err = a.reviewersInTransaction(txnCtx, roleRequest.Resource); err != nil {
+			return err
+		}
+		return errors.EnsureStack(roleRequests.Put(roleRequest.ID, roleRequest))
+	}); err != nil {
+		return nil, err
+	}
+	event := "denied"
+	if req.Approve {
+		event = "approved"
+	}
+	a.notifyRoleRequest(event, roleRequest, reviewers)
+	return &auth.ReviewRoleRequestResponse{Request: roleRequest}, nil
+}
+
+// addRoleInTransaction adds role to the roles principal already has on
+// resource. It returns an error if principal already has the role, so that
+// expiring the role request doesn't remove a role granted by other means.
+func (a *apiServer) addRoleInTransaction(txnCtx *txncontext.TransactionContext, resource *auth.Resource, principal, role string) error {
+	var binding auth.RoleBinding
+	if err := a.roleBindings.ReadWrite(txnCtx.SqlTx).Get(resourceKey(resource), &binding); err != nil {
+		if col.IsErrNotFound(err) {
+			return &auth.ErrNoRoleBinding{Resource: *resource}
+		}
+		return errors.EnsureStack(err)
+	}
+	roleSlice := []string{role}
+	if roles, ok := binding.Entries[principal]; ok {
+		if roles.Roles[role] {
+			return errors.Errorf("%v already has role %q on %v %q", principal, role, resource.Type, resource.Name)
+		}
+		roleSlice = append(roleSlice, setToList(roles.Roles)...)
+	}
+	return a.setUserRoleBindingInTransaction(txnCtx, resource, principal, roleSlice)
+}
+
+// removeRoleInTransaction removes role from the roles principal has on
+// resource, leaving the others in place.
+func (a *apiServer) removeRoleInTransaction(txnCtx *txncontext.TransactionContext, resource *auth.Resource, principal, role string) error {
+	var binding auth.RoleBinding
+	if err := a.roleBindings.ReadWrite(txnCtx.SqlTx).Get(resourceKey(resource), &binding); err != nil {
+		if col.IsErrNotFound(err) {
+			// The resource has been deleted along with its role bindings.
+			return nil
+		}
+		return errors.EnsureStack(err)
+	}
+	roles, ok := binding.Entries[principal]
+	if !ok || !roles.Roles[role] {
+		return nil
+	}
+	return a.setUserRoleBindingInTransaction(txnCtx, resource, principal, setToList(removeFromSet(roles.Roles, role)))
+}
+
+// reviewersInTransaction returns the principals bound to a role that allows
+// them to review role requests for resource.
+func (a *apiServer) reviewersInTransaction(txnCtx *txncontext.TransactionContext, resource *auth.Resource) ([]string, error) {
+	permission, err := modifyBindingsPermission(resource.Type)
+	if err != nil {
+		return nil, err
+	}
+	reviewerRoles := make(map[string]bool)
+	for _, r := range rolesForPermission(permission) {
+		reviewerRoles[r.Name] = true
+	}
+	keys := []string{clusterRoleBindingKey}
+	if resource.Type != auth.ResourceType_CLUSTER {
+		keys = append(keys, resourceKey(resource))
+	}
+	reviewers := make(map[string]bool)
+	for _, key := range keys {
+		var binding auth.RoleBinding
+		if err := a.roleBindings.ReadWrite(txnCtx.SqlTx).Get(key, &binding); err != nil {
+			if col.IsErrNotFound(err) {
+				continue
+			}
+			return nil, errors.EnsureStack(err)
+		}
+		for principal, roles := range binding.Entries {
+			for role := range roles.Roles {
+				if reviewerRoles[role] {
+					reviewers[principal] = true
+				}
+			}
+		}
+	}
+	return setToList(reviewers), nil
+}
+
+// notifyRoleRequest sends a role request event to the configured webhook, if
+// any. Delivery is best effort and doesn't block the caller.
+func (a *apiServer) notifyRoleRequest(event string, roleRequest *auth.RoleRequest, reviewers []string) {
+	url := a.env.Config.AuthRoleRequestWebhookURL
+	if url == "" {
+		return
+	}
+	go func() {
+		if err := func() error {
+			marshaler := &jsonpb.Marshaler{}
+			request, err := marshaler.MarshalToString(roleRequest)
+			if err != nil {
+				return errors.EnsureStack(err)
+			}
+			body, err := json.Marshal(&roleRequestEvent{
+				Event:     event,
+				Request:   json.RawMessage(request),
+				Reviewers: reviewers,
+			})
+			if err != nil {
+				return errors.EnsureStack(err)
+			}
+			client := &http.Client{Timeout: roleRequestWebhookTimeout}
+			resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+			if err != nil {
+				return errors.EnsureStack(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+				return errors.Errorf("webhook returned status %v", resp.Status)
+			}
+			return nil
+		}(); err != nil {
+			a.env.Logger.Errorf("error sending %v notification for role request %v: %v", event, roleRequest.ID, err)
+		}
+	}()
+}
+
+// expireRoleRequestsRoutine periodically removes the role bindings created
+// by approved role requests whose TTL has elapsed.
+func (a *apiServer) expireRoleRequestsRoutine() {
+	go func(ctx context.Context) {
+		ctx = internalauth.AsInternalUser(ctx, "auth-server")
+		for {
+			time.Sleep(roleRequestExpiryInterval)
+			if err := a.expireRoleRequests(ctx, time.Now()); err != nil {
+				a.env.Logger.Errorf("error expiring role requests: %v", err)
+			}
+		}
+	}(a.env.BackgroundContext)
+}
+
+func (a *apiServer) expireRoleRequests(ctx context.Context, now time.Time) error {
+	var ids []string
+	roleRequest := &auth.RoleRequest{}
+	if err := a.roleRequests.ReadOnly(ctx).List(roleRequest, col.DefaultOptions(), func(string) error {
+		if roleRequest.State == auth.RoleRequestState_APPROVED && roleRequest.Expiration != nil && now.After(*roleRequest.Expiration) {
+			ids = append(ids, roleRequest.ID)
+		}
+		return nil
+	}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	for _, id := range ids {
+		roleRequest := &auth.RoleRequest{}
+		if err := a.env.TxnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+			return errors.EnsureStack(a.roleRequests.ReadWrite(txnCtx.SqlTx).Update(id, roleRequest, func() error {
+				if roleRequest.State != auth.RoleRequestState_APPROVED {
+					return nil
+				}
+				roleRequest.State = auth.RoleRequestState_EXPIRED
+				return a.removeRoleInTransaction(txnCtx, roleRequest.Resource, roleRequest.Principal, roleRequest.Role)
+			}))
+		}); err != nil {
+			return err
+		}
+		a.notifyRoleRequest("expired", roleRequest, nil)
+	}
+	return nil
+}
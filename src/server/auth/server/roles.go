@@ -1,6 +1,8 @@
 package server
 
 import (
+	"sort"
+
 	"github.com/pachyderm/pachyderm/v2/src/auth"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 )
@@ -39,7 +41,7 @@ func init() {
 	// and create pipelines that read from a repo.
 	repoReaderRole := registerRole(&auth.Role{
 		Name:          auth.RepoReaderRole,
-		ResourceTypes: []auth.ResourceType{auth.ResourceType_CLUSTER, auth.ResourceType_REPO},
+		ResourceTypes: []auth.ResourceType{auth.ResourceType_CLUSTER, auth.ResourceType_PROJECT, auth.ResourceType_REPO},
 		Permissions: []auth.Permission{
 			auth.Permission_REPO_READ,
 			auth.Permission_REPO_INSPECT_COMMIT,
@@ -58,7 +60,7 @@ func init() {
 	// plus all the permissions of repoReader.
 	repoWriterRole := registerRole(&auth.Role{
 		Name:          auth.RepoWriterRole,
-		ResourceTypes: []auth.ResourceType{auth.ResourceType_CLUSTER, auth.ResourceType_REPO},
+		ResourceTypes: []auth.ResourceType{auth.ResourceType_CLUSTER, auth.ResourceType_PROJECT, auth.ResourceType_REPO},
 		Permissions: combinePermissions(repoReaderRole.Permissions, []auth.Permission{
 			auth.Permission_REPO_WRITE,
 			auth.Permission_REPO_DELETE_COMMIT,
@@ -72,13 +74,40 @@ func init() {
 	// a repo and delete it, plus all the permissions of repoWriter.
 	repoOwnerRole := registerRole(&auth.Role{
 		Name:          auth.RepoOwnerRole,
-		ResourceTypes: []auth.ResourceType{auth.ResourceType_CLUSTER, auth.ResourceType_REPO},
+		ResourceTypes: []auth.ResourceType{auth.ResourceType_CLUSTER, auth.ResourceType_PROJECT, auth.ResourceType_REPO},
 		Permissions: combinePermissions(repoWriterRole.Permissions, []auth.Permission{
 			auth.Permission_REPO_MODIFY_BINDINGS,
 			auth.Permission_REPO_DELETE,
 		}),
 	})
 
+	// projectOwner has the ability to create repos in a project, modify
+	// its role bindings and delete it, plus all the permissions of
+	// repoOwner on the repos in it.
+	projectOwnerRole := registerRole(&auth.Role{
+		Name:          auth.ProjectOwnerRole,
+		ResourceTypes: []auth.ResourceType{auth.ResourceType_CLUSTER, auth.ResourceType_PROJECT},
+		Permissions: combinePermissions(repoOwnerRole.Permissions, []auth.Permission{
+			auth.Permission_PROJECT_CREATE_REPO,
+			auth.Permission_PROJECT_MODIFY_BINDINGS,
+			auth.Permission_PROJECT_DELETE,
+		}),
+	})
+
+	// delegatedAdmin has the ability to modify the role bindings for
+	// a repo, plus all the permissions of repoReader. Like any user without
+	// the CLUSTER_MODIFY_BINDINGS permission, it can only grant and revoke
+	// roles within its own permissions, so binding it at the cluster level
+	// delegates access management for every repo without giving away
+	// write access or any cluster-level settings.
+	registerRole(&auth.Role{
+		Name:          auth.DelegatedAdminRole,
+		ResourceTypes: []auth.ResourceType{auth.ResourceType_CLUSTER, auth.ResourceType_PROJECT, auth.ResourceType_REPO},
+		Permissions: combinePermissions(repoReaderRole.Permissions, []auth.Permission{
+			auth.Permission_REPO_MODIFY_BINDINGS,
+		}),
+	})
+
 	// oidcAppAdmin has the ability to create, update and
 	// delete OIDC apps.
 	oidcAppAdminRole := registerRole(&auth.Role{
@@ -174,9 +203,9 @@ func init() {
 	// clusterAdmin is a catch-all role that has every permission
 	registerRole(&auth.Role{
 		Name:          auth.ClusterAdminRole,
-		ResourceTypes: []auth.ResourceType{auth.ResourceType_CLUSTER, auth.ResourceType_REPO},
+		ResourceTypes: []auth.ResourceType{auth.ResourceType_CLUSTER, auth.ResourceType_PROJECT, auth.ResourceType_REPO},
 		Permissions: combinePermissions(
-			repoOwnerRole.Permissions,
+			projectOwnerRole.Permissions,
 			oidcAppAdminRole.Permissions,
 			idpAdminRole.Permissions,
 			identityAdminRole.Permissions,
@@ -198,6 +227,7 @@ func init() {
 				auth.Permission_CLUSTER_AUTH_EXTRACT_TOKENS,
 				auth.Permission_CLUSTER_AUTH_RESTORE_TOKEN,
 				auth.Permission_CLUSTER_AUTH_ROTATE_ROOT_TOKEN,
+				auth.Permission_CLUSTER_AUTH_FREEZE_ACLS,
 				auth.Permission_CLUSTER_AUTH_DELETE_EXPIRED_TOKENS,
 				auth.Permission_CLUSTER_AUTH_GET_PERMISSIONS_FOR_PRINCIPAL,
 				auth.Permission_CLUSTER_AUTH_REVOKE_USER_TOKENS,
@@ -207,6 +237,17 @@ func init() {
 				auth.Permission_CLUSTER_ENTERPRISE_DEACTIVATE,
 				auth.Permission_CLUSTER_DELETE_ALL,
 				auth.Permission_CLUSTER_ENTERPRISE_PAUSE,
+				auth.Permission_CLUSTER_MODIFY_WEBHOOKS,
+				auth.Permission_CLUSTER_LIST_WEBHOOKS,
+				auth.Permission_CLUSTER_LIST_REQUESTS,
+				auth.Permission_CLUSTER_CANCEL_REQUESTS,
+				auth.Permission_CLUSTER_SET_DEFAULTS,
+				auth.Permission_CLUSTER_GET_USAGE_REPORT,
+				auth.Permission_CLUSTER_GET_CAPACITY_REPORT,
+				auth.Permission_CLUSTER_VALIDATE_CONFIGURATION,
+				auth.Permission_CLUSTER_BACKUP,
+				auth.Permission_CLUSTER_RESTORE,
+				auth.Permission_CLUSTER_SET_READ_ONLY,
 			}),
 	})
 }
@@ -237,3 +278,26 @@ func rolesForPermission(permission auth.Permission) []*auth.Role {
 	}
 	return resp
 }
+
+// permissionsOutsideBoundary returns the permissions granted by any of the
+// roles in roleSlice that aren't in boundary, sorted.
+func permissionsOutsideBoundary(roleSlice []string, boundary map[auth.Permission]bool) ([]auth.Permission, error) {
+	missing := make(map[auth.Permission]bool)
+	for _, name := range roleSlice {
+		r, err := getRole(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range r.role.Permissions {
+			if !boundary[p] {
+				missing[p] = true
+			}
+		}
+	}
+	result := make([]auth.Permission, 0, len(missing))
+	for p := range missing {
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result, nil
+}
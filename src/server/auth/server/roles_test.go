@@ -0,0 +1,58 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func permissionSet(roleName string) map[auth.Permission]bool {
+	return roles[roleName].permissionsIndex
+}
+
+func TestPermissionsOutsideBoundary(t *testing.T) {
+	// a delegated admin can grant repoReader and delegatedAdmin, but not repoWriter
+	boundary := permissionSet(auth.DelegatedAdminRole)
+	missing, err := permissionsOutsideBoundary([]string{auth.RepoReaderRole, auth.DelegatedAdminRole}, boundary)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(missing))
+
+	missing, err = permissionsOutsideBoundary([]string{auth.RepoReaderRole, auth.RepoWriterRole}, boundary)
+	require.NoError(t, err)
+	require.ElementsEqual(t, []auth.Permission{
+		auth.Permission_REPO_WRITE,
+		auth.Permission_REPO_DELETE_COMMIT,
+		auth.Permission_REPO_CREATE_BRANCH,
+		auth.Permission_REPO_DELETE_BRANCH,
+		auth.Permission_REPO_ADD_PIPELINE_WRITER,
+	}, missing)
+
+	// a repo owner can grant any repo role
+	missing, err = permissionsOutsideBoundary([]string{auth.RepoOwnerRole, auth.DelegatedAdminRole}, permissionSet(auth.RepoOwnerRole))
+	require.NoError(t, err)
+	require.Equal(t, 0, len(missing))
+
+	_, err = permissionsOutsideBoundary([]string{"notARole"}, boundary)
+	require.YesError(t, err)
+}
+
+func TestProjectOwnerRole(t *testing.T) {
+	// a project owner can grant any repo role on its project
+	missing, err := permissionsOutsideBoundary([]string{auth.RepoOwnerRole, auth.RepoReaderRole, auth.ProjectOwnerRole}, permissionSet(auth.ProjectOwnerRole))
+	require.NoError(t, err)
+	require.Equal(t, 0, len(missing))
+
+	// but a repo owner can't grant the project permissions
+	missing, err = permissionsOutsideBoundary([]string{auth.ProjectOwnerRole}, permissionSet(auth.RepoOwnerRole))
+	require.NoError(t, err)
+	require.ElementsEqual(t, []auth.Permission{
+		auth.Permission_PROJECT_CREATE_REPO,
+		auth.Permission_PROJECT_MODIFY_BINDINGS,
+		auth.Permission_PROJECT_DELETE,
+	}, missing)
+
+	require.True(t, roleAppliesToResource(roles[auth.RepoReaderRole].role, auth.ResourceType_PROJECT))
+	require.False(t, roleAppliesToResource(roles[auth.ProjectOwnerRole].role, auth.ResourceType_REPO))
+	require.True(t, permissionSet(auth.ClusterAdminRole)[auth.Permission_PROJECT_DELETE])
+}
@@ -1313,3 +1313,63 @@ func TestRotateRootToken(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, len(listResp))
 }
+
+// TestACLFreeze tests that while the ACLs are frozen, role bindings can't be
+// changed but are still used to authorize requests, and that only admins can
+// freeze and unfreeze them.
+func TestACLFreeze(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	c, _ := minikubetestenv.AcquireCluster(t)
+	tu.ActivateAuthClient(t, c)
+	rootClient := tu.AuthenticateClient(t, c, auth.RootUser)
+	alice, bob := robot(tu.UniqueString("alice")), robot(tu.UniqueString("bob"))
+	aliceClient := tu.AuthenticateClient(t, c, alice)
+
+	repo := tu.UniqueString(t.Name())
+	require.NoError(t, aliceClient.CreateRepo(repo))
+
+	// alice isn't an admin, so she can't freeze the ACLs
+	_, err := aliceClient.FreezeACLs(aliceClient.Ctx(), &auth.FreezeACLsRequest{Reason: "testing"})
+	require.YesError(t, err)
+	require.True(t, auth.IsErrNotAuthorized(err), err.Error())
+
+	_, err = rootClient.FreezeACLs(rootClient.Ctx(), &auth.FreezeACLsRequest{})
+	require.YesError(t, err)
+	resp, err := rootClient.FreezeACLs(rootClient.Ctx(), &auth.FreezeACLsRequest{Reason: "testing"})
+	require.NoError(t, err)
+	defer func() {
+		_, err := rootClient.UnfreezeACLs(rootClient.Ctx(), &auth.UnfreezeACLsRequest{})
+		require.NoError(t, err)
+	}()
+	require.Equal(t, auth.RootUser, resp.Freeze.Principal)
+
+	// anyone can see the freeze
+	getResp, err := aliceClient.GetACLFreeze(aliceClient.Ctx(), &auth.GetACLFreezeRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "testing", getResp.Freeze.Reason)
+
+	// role bindings can't be changed, even by root, but still apply
+	err = aliceClient.ModifyRepoRoleBinding(repo, bob, []string{auth.RepoReaderRole})
+	require.YesError(t, err)
+	require.True(t, auth.IsErrACLsFrozen(err), err.Error())
+	err = rootClient.ModifyClusterRoleBinding(bob, []string{auth.ClusterAdminRole})
+	require.YesError(t, err)
+	require.True(t, auth.IsErrACLsFrozen(err), err.Error())
+	_, err = rootClient.GetRobotToken(rootClient.Ctx(), &auth.GetRobotTokenRequest{Robot: bob})
+	require.YesError(t, err)
+	require.True(t, auth.IsErrACLsFrozen(err), err.Error())
+	_, err = aliceClient.InspectRepo(repo)
+	require.NoError(t, err)
+
+	// once unfrozen, role bindings can be changed again
+	_, err = aliceClient.UnfreezeACLs(aliceClient.Ctx(), &auth.UnfreezeACLsRequest{})
+	require.YesError(t, err)
+	_, err = rootClient.UnfreezeACLs(rootClient.Ctx(), &auth.UnfreezeACLsRequest{})
+	require.NoError(t, err)
+	getResp, err = rootClient.GetACLFreeze(rootClient.Ctx(), &auth.GetACLFreezeRequest{})
+	require.NoError(t, err)
+	require.Nil(t, getResp.Freeze)
+	require.NoError(t, aliceClient.ModifyRepoRoleBinding(repo, bob, []string{auth.RepoReaderRole}))
+}
@@ -1264,6 +1264,119 @@ func TestUnprivilegedUserCannotMakeSelfOwner(t *testing.T) {
 	require.Equal(t, buildBindings(alice, auth.RepoOwnerRole), getRepoRoleBinding(t, aliceClient, repo))
 }
 
+// TestDelegatedAdmin tests that a delegatedAdmin can manage the role bindings
+// of every repo, but can't grant or revoke roles with permissions it doesn't
+// have, or modify the cluster's role bindings
+func TestDelegatedAdmin(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	t.Parallel()
+	c, _ := minikubetestenv.AcquireCluster(t)
+	tu.ActivateAuthClient(t, c)
+	alice, bob, carol := robot(tu.UniqueString("alice")), robot(tu.UniqueString("bob")), robot(tu.UniqueString("carol"))
+	aliceClient, bobClient, rootClient := tu.AuthenticateClient(t, c, alice), tu.AuthenticateClient(t, c, bob), tu.AuthenticateClient(t, c, auth.RootUser)
+
+	// alice creates a repo, and the root user makes bob a delegated admin
+	repo := tu.UniqueString(t.Name())
+	require.NoError(t, aliceClient.CreateRepo(repo))
+	require.NoError(t, rootClient.ModifyClusterRoleBinding(bob, []string{auth.DelegatedAdminRole}))
+
+	// bob can make carol a reader of alice's repo
+	require.NoError(t, bobClient.ModifyRepoRoleBinding(repo, carol, []string{auth.RepoReaderRole}))
+	require.Equal(t,
+		buildBindings(alice, auth.RepoOwnerRole, carol, auth.RepoReaderRole), getRepoRoleBinding(t, aliceClient, repo))
+
+	// bob can't make carol, or himself, a writer or an owner
+	for _, principal := range []string{carol, bob} {
+		for _, role := range []string{auth.RepoWriterRole, auth.RepoOwnerRole} {
+			err := bobClient.ModifyRepoRoleBinding(repo, principal, []string{role})
+			require.YesError(t, err)
+			require.True(t, auth.IsErrNotAuthorized(err), err.Error())
+		}
+	}
+
+	// bob can't revoke alice's ownership either
+	err := bobClient.ModifyRepoRoleBinding(repo, alice, []string{})
+	require.YesError(t, err)
+	require.True(t, auth.IsErrNotAuthorized(err), err.Error())
+	require.Equal(t,
+		buildBindings(alice, auth.RepoOwnerRole, carol, auth.RepoReaderRole), getRepoRoleBinding(t, aliceClient, repo))
+
+	// bob can't modify the cluster's role bindings
+	err = bobClient.ModifyClusterRoleBinding(carol, []string{auth.DelegatedAdminRole})
+	require.YesError(t, err)
+	require.True(t, auth.IsErrNotAuthorized(err), err.Error())
+
+	// alice, who owns the repo, can still make carol a writer
+	require.NoError(t, aliceClient.ModifyRepoRoleBinding(repo, carol, []string{auth.RepoWriterRole}))
+}
+
+// TestRoleRequest tests that a user can request a role on a repo, that only
+// the repo's owner can approve it, and that approving it grants the role.
+func TestRoleRequest(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	t.Parallel()
+	c, _ := minikubetestenv.AcquireCluster(t)
+	tu.ActivateAuthClient(t, c)
+	alice, bob, carol := robot(tu.UniqueString("alice")), robot(tu.UniqueString("bob")), robot(tu.UniqueString("carol"))
+	aliceClient, bobClient, carolClient := tu.AuthenticateClient(t, c, alice), tu.AuthenticateClient(t, c, bob), tu.AuthenticateClient(t, c, carol)
+
+	// alice creates a repo and bob requests write access to it
+	repo := tu.UniqueString(t.Name())
+	require.NoError(t, aliceClient.CreateRepo(repo))
+	resource := &auth.Resource{Type: auth.ResourceType_REPO, Name: repo}
+	resp, err := bobClient.RequestRole(bobClient.Ctx(), &auth.RequestRoleRequest{
+		Resource: resource,
+		Role:     auth.RepoWriterRole,
+		Reason:   "testing",
+	})
+	require.NoError(t, err)
+	id := resp.Request.ID
+
+	// alice can see the request, carol can't
+	requests, err := aliceClient.ListRoleRequests(aliceClient.Ctx(), &auth.ListRoleRequestsRequest{Resource: resource})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(requests.Requests))
+	require.Equal(t, bob, requests.Requests[0].Principal)
+	requests, err = carolClient.ListRoleRequests(carolClient.Ctx(), &auth.ListRoleRequestsRequest{Resource: resource})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(requests.Requests))
+
+	// bob and carol can't approve the request
+	_, err = bobClient.ReviewRoleRequest(bobClient.Ctx(), &auth.ReviewRoleRequestRequest{ID: id, Approve: true})
+	require.YesError(t, err)
+	_, err = carolClient.ReviewRoleRequest(carolClient.Ctx(), &auth.ReviewRoleRequestRequest{ID: id, Approve: true})
+	require.YesError(t, err)
+	require.True(t, auth.IsErrNotAuthorized(err), err.Error())
+	require.Equal(t, buildBindings(alice, auth.RepoOwnerRole), getRepoRoleBinding(t, aliceClient, repo))
+
+	// alice approves the request for an hour, which grants bob the role
+	review, err := aliceClient.ReviewRoleRequest(aliceClient.Ctx(), &auth.ReviewRoleRequestRequest{ID: id, Approve: true, TTL: 3600})
+	require.NoError(t, err)
+	require.Equal(t, auth.RoleRequestState_APPROVED, review.Request.State)
+	require.Equal(t, alice, review.Request.Reviewer)
+	require.NotNil(t, review.Request.Expiration)
+	require.Equal(t,
+		buildBindings(alice, auth.RepoOwnerRole, bob, auth.RepoWriterRole), getRepoRoleBinding(t, aliceClient, repo))
+
+	// the request can't be reviewed again, and is no longer pending
+	_, err = aliceClient.ReviewRoleRequest(aliceClient.Ctx(), &auth.ReviewRoleRequestRequest{ID: id})
+	require.YesError(t, err)
+	requests, err = bobClient.ListRoleRequests(bobClient.Ctx(), &auth.ListRoleRequestsRequest{Resource: resource})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(requests.Requests))
+	requests, err = bobClient.ListRoleRequests(bobClient.Ctx(), &auth.ListRoleRequestsRequest{Resource: resource, All: true})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(requests.Requests))
+
+	// bob can't request a role he already has
+	_, err = bobClient.RequestRole(bobClient.Ctx(), &auth.RequestRoleRequest{Resource: resource, Role: auth.RepoWriterRole})
+	require.YesError(t, err)
+}
+
 // TestListRepoNotLoggedInError makes sure that if a user isn't logged in, and
 // they call ListRepo(), they get an error.
 func TestListRepoNotLoggedInError(t *testing.T) {
@@ -52,6 +52,26 @@ func (a *apiServer) CheckRepoIsAuthorizedInTransaction(txnCtx *txncontext.Transa
 	return nil
 }
 
+// CheckProjectIsAuthorizedInTransaction returns an error if the current user
+// doesn't have the permissions in `p` on the project named `project`
+func (a *apiServer) CheckProjectIsAuthorizedInTransaction(txnCtx *txncontext.TransactionContext, project string, p ...auth.Permission) error {
+	me, err := txnCtx.WhoAmI()
+	if auth.IsErrNotActivated(err) {
+		return nil
+	}
+
+	resource := auth.Resource{Type: auth.ResourceType_PROJECT, Name: project}
+	req := &auth.AuthorizeRequest{Resource: &auth.Resource{Type: resource.Type, Name: resource.Name}, Permissions: p}
+	resp, err := a.AuthorizeInTransaction(txnCtx, req)
+	if err != nil {
+		return err
+	}
+	if !resp.Authorized {
+		return &auth.ErrNotAuthorized{Subject: me.Username, Resource: resource, Required: p}
+	}
+	return nil
+}
+
 // CheckRepoIsAuthorized returns an error if the current user doesn't have
 // the permissions in `p` on the repo `r`
 func (a *apiServer) CheckRepoIsAuthorized(ctx context.Context, r *pfs.Repo, p ...auth.Permission) error {
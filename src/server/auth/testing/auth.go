@@ -200,6 +200,36 @@ func (a *InactiveAPIServer) RotateRootToken(context.Context, *auth.RotateRootTok
 	return nil, auth.ErrNotActivated
 }
 
+// RequestRole implements the RequestRole RPC, but just returns NotActivatedError
+func (a *InactiveAPIServer) RequestRole(context.Context, *auth.RequestRoleRequest) (*auth.RequestRoleResponse, error) {
+	return nil, auth.ErrNotActivated
+}
+
+// ListRoleRequests implements the ListRoleRequests RPC, but just returns NotActivatedError
+func (a *InactiveAPIServer) ListRoleRequests(context.Context, *auth.ListRoleRequestsRequest) (*auth.ListRoleRequestsResponse, error) {
+	return nil, auth.ErrNotActivated
+}
+
+// ReviewRoleRequest implements the ReviewRoleRequest RPC, but just returns NotActivatedError
+func (a *InactiveAPIServer) ReviewRoleRequest(context.Context, *auth.ReviewRoleRequestRequest) (*auth.ReviewRoleRequestResponse, error) {
+	return nil, auth.ErrNotActivated
+}
+
+// FreezeACLs implements the FreezeACLs RPC, but just returns NotActivatedError
+func (a *InactiveAPIServer) FreezeACLs(context.Context, *auth.FreezeACLsRequest) (*auth.FreezeACLsResponse, error) {
+	return nil, auth.ErrNotActivated
+}
+
+// UnfreezeACLs implements the UnfreezeACLs RPC, but just returns NotActivatedError
+func (a *InactiveAPIServer) UnfreezeACLs(context.Context, *auth.UnfreezeACLsRequest) (*auth.UnfreezeACLsResponse, error) {
+	return nil, auth.ErrNotActivated
+}
+
+// GetACLFreeze implements the GetACLFreeze RPC, but just returns NotActivatedError
+func (a *InactiveAPIServer) GetACLFreeze(context.Context, *auth.GetACLFreezeRequest) (*auth.GetACLFreezeResponse, error) {
+	return nil, auth.ErrNotActivated
+}
+
 // CheckRepoIsAuthorized returns nil when auth is not activated
 func (a *InactiveAPIServer) CheckRepoIsAuthorized(context.Context, *pfs.Repo, ...auth.Permission) error {
 	return nil
@@ -219,3 +249,8 @@ func (a *InactiveAPIServer) CheckClusterIsAuthorizedInTransaction(*txncontext.Tr
 func (a *InactiveAPIServer) CheckRepoIsAuthorizedInTransaction(*txncontext.TransactionContext, *pfs.Repo, ...auth.Permission) error {
 	return nil
 }
+
+// CheckProjectIsAuthorizedInTransaction returns nil when auth is not activated
+func (a *InactiveAPIServer) CheckProjectIsAuthorizedInTransaction(*txncontext.TransactionContext, string, ...auth.Permission) error {
+	return nil
+}
@@ -6,10 +6,8 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
-	"os/signal"
 	"sort"
 	"strings"
-	"syscall"
 	"text/template"
 	"time"
 	"unicode"
@@ -33,6 +31,7 @@ import (
 	licensecmds "github.com/pachyderm/pachyderm/v2/src/server/license/cmds"
 	pfscmds "github.com/pachyderm/pachyderm/v2/src/server/pfs/cmds"
 	ppscmds "github.com/pachyderm/pachyderm/v2/src/server/pps/cmds"
+	searchcmds "github.com/pachyderm/pachyderm/v2/src/server/search/cmds"
 	txncmds "github.com/pachyderm/pachyderm/v2/src/server/transaction/cmds"
 	"github.com/pachyderm/pachyderm/v2/src/version"
 	"github.com/pachyderm/pachyderm/v2/src/version/versionpb"
@@ -335,6 +334,11 @@ Environment variables:
     trace to any outgoing RPCs.
   PACH_TRACE_DURATION=<duration>, the amount of time for which PPS should trace
     a pipeline after 'pachctl create-pipeline' (PACH_TRACE must also be set).
+  PACHCTL_NOTIFY_CMD=<command>, the shell command that commands run with --notify
+    when they finish. Its environment has PACHCTL_NOTIFY_STATUS (succeeded or
+    failed), PACHCTL_NOTIFY_EXIT_CODE, PACHCTL_NOTIFY_ERROR,
+    PACHCTL_NOTIFY_COMMAND, PACHCTL_NOTIFY_DURATION (in seconds) and
+    PACHCTL_NOTIFY_MESSAGE. A desktop notification is shown if it's unset.
 `,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			log.SetFormatter(new(prefixed.TextFormatter))
@@ -532,149 +536,7 @@ This resets the cluster to its initial state.`,
 	}
 	subcommands = append(subcommands, cmdutil.CreateAlias(deleteAll, "delete all"))
 
-	var port uint16
-	var remotePort uint16
-	var oidcPort uint16
-	var remoteOidcPort uint16
-	var s3gatewayPort uint16
-	var remoteS3gatewayPort uint16
-	var dexPort uint16
-	var remoteDexPort uint16
-	var consolePort uint16
-	var remoteConsolePort uint16
-	var namespace string
-	portForward := &cobra.Command{
-		Short: "Forward a port on the local machine to pachd. This command blocks.",
-		Long:  "Forward a port on the local machine to pachd. This command blocks.",
-		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
-			// TODO(ys): remove the `--namespace` flag here eventually
-			if namespace != "" {
-				fmt.Printf("WARNING: The `--namespace` flag is deprecated and will be removed in a future version. Please set the namespace in the pachyderm context instead: pachctl config update context `pachctl config get active-context` --namespace '%s'\n", namespace)
-			}
-
-			cfg, err := config.Read(false, false)
-			if err != nil {
-				return err
-			}
-			contextName, context, err := cfg.ActiveContext(true)
-			if err != nil {
-				return err
-			}
-			if context.PortForwarders != nil && len(context.PortForwarders) > 0 {
-				fmt.Println("Port forwarding appears to already be running for this context. Running multiple forwarders may not work correctly.")
-				if ok, err := cmdutil.InteractiveConfirm(); err != nil {
-					return err
-				} else if !ok {
-					return nil
-				}
-			}
-
-			fw, err := client.NewPortForwarder(context, namespace)
-			if err != nil {
-				return err
-			}
-			defer fw.Close()
-
-			context.PortForwarders = map[string]uint32{}
-			successCount := 0
-
-			fmt.Println("Forwarding the pachd (Pachyderm daemon) port...")
-			port, err := fw.RunForPachd(port, remotePort)
-			if err != nil {
-				fmt.Printf("port forwarding failed: %v\n", err)
-			} else {
-				fmt.Printf("listening on port %d\n", port)
-				context.PortForwarders["pachd"] = uint32(port)
-				successCount++
-			}
-
-			fmt.Println("Forwarding the OIDC callback port...")
-			port, err = fw.RunForPachd(oidcPort, remoteOidcPort)
-			if err != nil {
-				fmt.Printf("port forwarding failed: %v\n", err)
-			} else {
-				fmt.Printf("listening on port %d\n", port)
-				context.PortForwarders["oidc-acs"] = uint32(port)
-				successCount++
-			}
-
-			fmt.Println("Forwarding the s3gateway port...")
-			port, err = fw.RunForPachd(s3gatewayPort, remoteS3gatewayPort)
-			if err != nil {
-				fmt.Printf("port forwarding failed: %v\n", err)
-			} else {
-				fmt.Printf("listening on port %d\n", port)
-				context.PortForwarders["s3g"] = uint32(port)
-				successCount++
-			}
-
-			fmt.Println("Forwarding the identity service port...")
-			port, err = fw.RunForPachd(dexPort, remoteDexPort)
-			if err != nil {
-				fmt.Printf("port forwarding failed: %v\n", err)
-			} else {
-				fmt.Printf("listening on port %d\n", port)
-				context.PortForwarders["dex"] = uint32(port)
-				successCount++
-			}
-
-			fmt.Println("Forwarding the console service port...")
-			port, err = fw.RunForConsole(consolePort, remoteConsolePort)
-			if err != nil {
-				fmt.Printf("port forwarding failed: %v\n", err)
-			} else {
-				fmt.Printf("listening on port %d\n", port)
-				context.PortForwarders["console"] = uint32(port)
-				successCount++
-			}
-
-			if successCount == 0 {
-				return errors.New("failed to start port forwarders")
-			}
-
-			if err = cfg.Write(); err != nil {
-				return err
-			}
-
-			defer func() {
-				// reload config in case changes have happened since the
-				// config was last read
-				cfg, err := config.Read(true, false)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "failed to read config file: %v\n", err)
-					return
-				}
-				context, ok := cfg.V2.Contexts[contextName]
-				if ok {
-					context.PortForwarders = nil
-					if err := cfg.Write(); err != nil {
-						fmt.Fprintf(os.Stderr, "failed to write config file: %v\n", err)
-					}
-				}
-			}()
-
-			fmt.Println("CTRL-C to exit")
-			ch := make(chan os.Signal, 1)
-			// Handle Control-C, closing the terminal window, and pkill (and friends)
-			// cleanly.
-			signal.Notify(ch, os.Interrupt, syscall.SIGHUP, syscall.SIGTERM)
-			<-ch
-
-			return nil
-		}),
-	}
-	portForward.Flags().Uint16VarP(&port, "port", "p", 30650, "The local port to bind pachd to.")
-	portForward.Flags().Uint16Var(&remotePort, "remote-port", 1650, "The remote port that pachd is bound to in the cluster.")
-	portForward.Flags().Uint16Var(&oidcPort, "oidc-port", 30657, "The local port to bind pachd's OIDC callback to.")
-	portForward.Flags().Uint16Var(&remoteOidcPort, "remote-oidc-port", 1657, "The remote port that OIDC callback is bound to in the cluster.")
-	portForward.Flags().Uint16VarP(&s3gatewayPort, "s3gateway-port", "s", 30600, "The local port to bind the s3gateway to.")
-	portForward.Flags().Uint16Var(&remoteS3gatewayPort, "remote-s3gateway-port", 1600, "The remote port that the s3 gateway is bound to.")
-	portForward.Flags().Uint16Var(&dexPort, "dex-port", 30658, "The local port to bind the identity service to.")
-	portForward.Flags().Uint16Var(&remoteDexPort, "remote-dex-port", 1658, "The local port to bind the identity service to.")
-	portForward.Flags().Uint16Var(&consolePort, "console-port", 4000, "The local port to bind the console service to.")
-	portForward.Flags().Uint16Var(&remoteConsolePort, "remote-console-port", 4000, "The remote port to bind the console  service to.")
-	portForward.Flags().StringVar(&namespace, "namespace", "", "Kubernetes namespace Pachyderm is deployed in.")
-	subcommands = append(subcommands, cmdutil.CreateAlias(portForward, "port-forward"))
+	subcommands = append(subcommands, portForwardCmds()...)
 
 	var install bool
 	var installPathBash string
@@ -768,6 +630,12 @@ This resets the cluster to its initial state.`,
 	}
 	subcommands = append(subcommands, cmdutil.CreateAlias(subscribeDocs, "subscribe"))
 
+	checkpointDocs := &cobra.Command{
+		Short: "Mark the progress of an open Pachyderm resource.",
+		Long:  "Mark the progress of an open Pachyderm resource.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(checkpointDocs, "checkpoint"))
+
 	putDocs := &cobra.Command{
 		Short: "Insert data into Pachyderm.",
 		Long:  "Insert data into Pachyderm.",
@@ -786,6 +654,30 @@ This resets the cluster to its initial state.`,
 	}
 	subcommands = append(subcommands, cmdutil.CreateAlias(getDocs, "get"))
 
+	headDocs := &cobra.Command{
+		Short: "Print the beginning of the data represented by a Pachyderm resource.",
+		Long:  "Print the beginning of the data represented by a Pachyderm resource.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(headDocs, "head"))
+
+	tailDocs := &cobra.Command{
+		Short: "Print the end of the data represented by a Pachyderm resource.",
+		Long:  "Print the end of the data represented by a Pachyderm resource.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(tailDocs, "tail"))
+
+	sampleDocs := &cobra.Command{
+		Short: "Print a random sample of the data represented by a Pachyderm resource.",
+		Long:  "Print a random sample of the data represented by a Pachyderm resource.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(sampleDocs, "sample"))
+
+	previewDocs := &cobra.Command{
+		Short: "Print the structure and first records of a Pachyderm resource.",
+		Long:  "Print the structure and first records of a Pachyderm resource.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(previewDocs, "preview"))
+
 	globDocs := &cobra.Command{
 		Short: "Print a list of Pachyderm resources matching a glob pattern.",
 		Long:  "Print a list of Pachyderm resources matching a glob pattern.",
@@ -810,6 +702,24 @@ This resets the cluster to its initial state.`,
 	}
 	subcommands = append(subcommands, cmdutil.CreateAlias(restartDocs, "restart"))
 
+	requeueDocs := &cobra.Command{
+		Short: "Process quarantined work again.",
+		Long:  "Process quarantined work again.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(requeueDocs, "requeue"))
+
+	promoteDocs := &cobra.Command{
+		Short: "Promote a canary.",
+		Long:  "Promote a canary.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(promoteDocs, "promote"))
+
+	rollbackDocs := &cobra.Command{
+		Short: "Roll back a canary.",
+		Long:  "Roll back a canary.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(rollbackDocs, "rollback"))
+
 	resumeDocs := &cobra.Command{
 		Short: "Resume a stopped task.",
 		Long:  "Resume a stopped task.",
@@ -828,6 +738,66 @@ This resets the cluster to its initial state.`,
 	}
 	subcommands = append(subcommands, cmdutil.CreateAlias(editDocs, "edit"))
 
+	eraseDocs := &cobra.Command{
+		Short: "Permanently erase data from a Pachyderm resource.",
+		Long:  "Permanently erase data from a Pachyderm resource.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(eraseDocs, "erase"))
+
+	exportDocs := &cobra.Command{
+		Short: "Export a Pachyderm resource to a portable archive.",
+		Long:  "Export a Pachyderm resource to a portable archive.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(exportDocs, "export"))
+
+	importDocs := &cobra.Command{
+		Short: "Import a Pachyderm resource from a portable archive.",
+		Long:  "Import a Pachyderm resource from a portable archive.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(importDocs, "import"))
+
+	replicateDocs := &cobra.Command{
+		Short: "Replicate a Pachyderm resource to or from another cluster.",
+		Long:  "Replicate a Pachyderm resource to or from another cluster.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(replicateDocs, "replicate"))
+
+	syncDocs := &cobra.Command{
+		Short: "Sync a Pachyderm resource from an external source.",
+		Long:  "Sync a Pachyderm resource from an external source.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(syncDocs, "sync"))
+
+	verifyDocs := &cobra.Command{
+		Short: "Check the integrity of a Pachyderm resource.",
+		Long:  "Check the integrity of a Pachyderm resource.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(verifyDocs, "verify"))
+
+	restoreDocs := &cobra.Command{
+		Short: "Restore a Pachyderm resource from a backup.",
+		Long:  "Restore a Pachyderm resource from a backup.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(restoreDocs, "restore"))
+
+	cordonDocs := &cobra.Command{
+		Short: "Stop new work from starting on a Pachyderm resource.",
+		Long:  "Stop new work from starting on a Pachyderm resource, without deleting it.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(cordonDocs, "cordon"))
+
+	uncordonDocs := &cobra.Command{
+		Short: "Let new work start on a cordoned Pachyderm resource again.",
+		Long:  "Let new work start on a cordoned Pachyderm resource again.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(uncordonDocs, "uncordon"))
+
+	drawDocs := &cobra.Command{
+		Short: "Draw a graph of Pachyderm resources.",
+		Long:  "Draw a graph of Pachyderm resources.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(drawDocs, "draw"))
+
 	subcommands = append(subcommands, pfscmds.Cmds()...)
 	subcommands = append(subcommands, ppscmds.Cmds()...)
 	subcommands = append(subcommands, authcmds.Cmds()...)
@@ -839,6 +809,7 @@ This resets the cluster to its initial state.`,
 	subcommands = append(subcommands, txncmds.Cmds()...)
 	subcommands = append(subcommands, configcmds.Cmds()...)
 	subcommands = append(subcommands, taskcmds.Cmds()...)
+	subcommands = append(subcommands, searchcmds.Cmds()...)
 
 	cmdutil.MergeCommands(rootCmd, subcommands)
 
@@ -875,28 +846,42 @@ func applyRootUsageFunc(rootCmd *cobra.Command) {
 			"tag":
 			// These are ignored - they will show up in the help topics section
 		case
+			"checkpoint",
 			"copy",
 			"create",
 			"delete",
 			"diff",
+			"draw",
 			"edit",
+			"erase",
+			"export",
 			"finish",
 			"wait",
 			"get",
 			"glob",
+			"import",
 			"inspect",
 			"list",
+			"promote",
 			"put",
+			"replicate",
+			"requeue",
 			"restart",
+			"rollback",
+			"search",
 			"squash",
 			"start",
 			"stop",
 			"subscribe",
-			"update":
+			"sync",
+			"update",
+			"verify":
 			actions = append(actions, subcmd)
 		case
 			"extract",
 			"restore",
+			"cordon",
+			"uncordon",
 			"garbage-collect",
 			"auth",
 			"enterprise",
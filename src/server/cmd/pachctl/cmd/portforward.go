@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/cmdutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/config"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/tabwriter"
+)
+
+// tunnelProbeTimeout is how long 'pachctl port-forward status' waits to
+// connect to each tunnel's local port.
+const tunnelProbeTimeout = time.Second
+
+// portForwardService is a service that 'pachctl port-forward' forwards a
+// local port to.
+type portForwardService struct {
+	tunnel      client.Tunnel
+	description string
+}
+
+// selectServices returns the services named in names, a comma-separated list,
+// or all services if it's empty.
+func selectServices(services []portForwardService, names string) ([]portForwardService, error) {
+	if names == "" {
+		return services, nil
+	}
+	byName := make(map[string]portForwardService)
+	var all []string
+	for _, s := range services {
+		byName[s.tunnel.Name] = s
+		all = append(all, s.tunnel.Name)
+	}
+	var result []portForwardService
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		s, ok := byName[name]
+		if !ok {
+			return nil, errors.Errorf("unknown service %q, must be one of %s", name, strings.Join(all, ", "))
+		}
+		if !seen[name] {
+			seen[name] = true
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+// probeTunnel returns "up" if a connection can be made to the local port of
+// a tunnel, and "down" otherwise.
+func probeTunnel(port uint32) string {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("localhost:%d", port), tunnelProbeTimeout)
+	if err != nil {
+		return "down"
+	}
+	conn.Close()
+	return "up"
+}
+
+// printTunnelStatus prints the state of each of a context's port forwarders.
+func printTunnelStatus(w io.Writer, forwarders map[string]uint32, probe func(uint32) string) error {
+	var names []string
+	for name := range forwarders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	writer := tabwriter.NewWriter(w, "NAME\tLOCAL PORT\tSTATE\n")
+	for _, name := range names {
+		port := forwarders[name]
+		fmt.Fprintf(writer, "%s\t%d\t%s\n", name, port, probe(port))
+	}
+	return writer.Flush()
+}
+
+func portForwardCmds() []*cobra.Command {
+	var commands []*cobra.Command
+
+	var port uint16
+	var remotePort uint16
+	var oidcPort uint16
+	var remoteOidcPort uint16
+	var s3gatewayPort uint16
+	var remoteS3gatewayPort uint16
+	var dexPort uint16
+	var remoteDexPort uint16
+	var restGatewayPort uint16
+	var remoteRestGatewayPort uint16
+	var consolePort uint16
+	var remoteConsolePort uint16
+	var services string
+	var namespace string
+	portForward := &cobra.Command{
+		Short: "Forward ports on the local machine to pachd and console. This command blocks.",
+		Long: "Forward ports on the local machine to pachd's API, OIDC callback, S3 gateway, identity service and REST gateway, and to console. " +
+			"Tunnels whose connection to their pod is lost, e.g. because the pod was restarted, are re-established automatically. " +
+			"This command blocks; 'pachctl port-forward status' shows its tunnels.",
+		Example: `
+# forward all services
+$ {{alias}}
+
+# only forward pachd's API and the S3 gateway
+$ {{alias}} --services pachd,s3g`,
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// TODO(ys): remove the `--namespace` flag here eventually
+			if namespace != "" {
+				fmt.Printf("WARNING: The `--namespace` flag is deprecated and will be removed in a future version. Please set the namespace in the pachyderm context instead: pachctl config update context `pachctl config get active-context` --namespace '%s'\n", namespace)
+			}
+
+			selected, err := selectServices([]portForwardService{
+				{client.Tunnel{Name: "pachd", App: "pachd", LocalPort: port, RemotePort: remotePort}, "the pachd (Pachyderm daemon) port"},
+				{client.Tunnel{Name: "oidc-acs", App: "pachd", LocalPort: oidcPort, RemotePort: remoteOidcPort}, "the OIDC callback port"},
+				{client.Tunnel{Name: "s3g", App: "pachd", LocalPort: s3gatewayPort, RemotePort: remoteS3gatewayPort}, "the s3gateway port"},
+				{client.Tunnel{Name: "dex", App: "pachd", LocalPort: dexPort, RemotePort: remoteDexPort}, "the identity service port"},
+				{client.Tunnel{Name: "rest", App: "pachd", LocalPort: restGatewayPort, RemotePort: remoteRestGatewayPort}, "the REST gateway port"},
+				{client.Tunnel{Name: "console", App: "console", LocalPort: consolePort, RemotePort: remoteConsolePort}, "the console service port"},
+			}, services)
+			if err != nil {
+				return err
+			}
+
+			cfg, err := config.Read(false, false)
+			if err != nil {
+				return err
+			}
+			contextName, context, err := cfg.ActiveContext(true)
+			if err != nil {
+				return err
+			}
+			if context.PortForwarders != nil && len(context.PortForwarders) > 0 {
+				fmt.Println("Port forwarding appears to already be running for this context. Running multiple forwarders may not work correctly.")
+				if ok, err := cmdutil.InteractiveConfirm(); err != nil {
+					return err
+				} else if !ok {
+					return nil
+				}
+			}
+
+			fw, err := client.NewPortForwarder(context, namespace)
+			if err != nil {
+				return err
+			}
+			defer fw.Close()
+
+			context.PortForwarders = map[string]uint32{}
+			successCount := 0
+
+			notify := func(e client.TunnelEvent) {
+				switch {
+				case e.Up:
+					fmt.Printf("%s: reconnected to pod %s on port %d\n", e.Tunnel.Name, e.Pod, e.Tunnel.LocalPort)
+				case e.Err != nil:
+					fmt.Printf("%s: %v, reconnecting...\n", e.Tunnel.Name, e.Err)
+				}
+			}
+			for _, s := range selected {
+				fmt.Printf("Forwarding %s...\n", s.description)
+				port, err := fw.KeepTunnel(ctx, s.tunnel, notify)
+				if err != nil {
+					fmt.Printf("port forwarding failed: %v\n", err)
+					continue
+				}
+				fmt.Printf("listening on port %d\n", port)
+				context.PortForwarders[s.tunnel.Name] = uint32(port)
+				successCount++
+			}
+
+			if successCount == 0 {
+				return errors.New("failed to start port forwarders")
+			}
+
+			if err = cfg.Write(); err != nil {
+				return err
+			}
+
+			defer func() {
+				// reload config in case changes have happened since the
+				// config was last read
+				cfg, err := config.Read(true, false)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "failed to read config file: %v\n", err)
+					return
+				}
+				context, ok := cfg.V2.Contexts[contextName]
+				if ok {
+					context.PortForwarders = nil
+					if err := cfg.Write(); err != nil {
+						fmt.Fprintf(os.Stderr, "failed to write config file: %v\n", err)
+					}
+				}
+			}()
+
+			fmt.Println("CTRL-C to exit")
+			ch := make(chan os.Signal, 1)
+			// Handle Control-C, closing the terminal window, and pkill (and friends)
+			// cleanly.
+			signal.Notify(ch, os.Interrupt, syscall.SIGHUP, syscall.SIGTERM)
+			<-ch
+
+			return nil
+		}),
+	}
+	portForward.Flags().Uint16VarP(&port, "port", "p", 30650, "The local port to bind pachd to.")
+	portForward.Flags().Uint16Var(&remotePort, "remote-port", 1650, "The remote port that pachd is bound to in the cluster.")
+	portForward.Flags().Uint16Var(&oidcPort, "oidc-port", 30657, "The local port to bind pachd's OIDC callback to.")
+	portForward.Flags().Uint16Var(&remoteOidcPort, "remote-oidc-port", 1657, "The remote port that OIDC callback is bound to in the cluster.")
+	portForward.Flags().Uint16VarP(&s3gatewayPort, "s3gateway-port", "s", 30600, "The local port to bind the s3gateway to.")
+	portForward.Flags().Uint16Var(&remoteS3gatewayPort, "remote-s3gateway-port", 1600, "The remote port that the s3 gateway is bound to.")
+	portForward.Flags().Uint16Var(&dexPort, "dex-port", 30658, "The local port to bind the identity service to.")
+	portForward.Flags().Uint16Var(&remoteDexPort, "remote-dex-port", 1658, "The local port to bind the identity service to.")
+	portForward.Flags().Uint16Var(&restGatewayPort, "rest-gateway-port", 30659, "The local port to bind the REST gateway to.")
+	portForward.Flags().Uint16Var(&remoteRestGatewayPort, "remote-rest-gateway-port", 1659, "The remote port that the REST gateway is bound to.")
+	portForward.Flags().Uint16Var(&consolePort, "console-port", 4000, "The local port to bind the console service to.")
+	portForward.Flags().Uint16Var(&remoteConsolePort, "remote-console-port", 4000, "The remote port to bind the console  service to.")
+	portForward.Flags().StringVar(&services, "services", "", "A comma-separated list of the services to forward, of pachd, oidc-acs, s3g, dex, rest and console. All services are forwarded if unset.")
+	portForward.Flags().StringVar(&namespace, "namespace", "", "Kubernetes namespace Pachyderm is deployed in.")
+	commands = append(commands, cmdutil.CreateAlias(portForward, "port-forward"))
+
+	portForwardStatus := &cobra.Command{
+		Short: "Show the tunnels of the running port forwarder.",
+		Long:  "Show the tunnels of the 'pachctl port-forward' running for the active context, and whether each of them is up.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			cfg, err := config.Read(false, true)
+			if err != nil {
+				return err
+			}
+			contextName, context, err := cfg.ActiveContext(true)
+			if err != nil {
+				return err
+			}
+			if len(context.PortForwarders) == 0 {
+				fmt.Printf("No port forwarder is running for context %q.\n", contextName)
+				return nil
+			}
+			return printTunnelStatus(os.Stdout, context.PortForwarders, probeTunnel)
+		}),
+	}
+	commands = append(commands, cmdutil.CreateAlias(portForwardStatus, "port-forward status"))
+
+	return commands
+}
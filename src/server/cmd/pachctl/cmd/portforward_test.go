@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestSelectServices(t *testing.T) {
+	services := []portForwardService{
+		{tunnel: client.Tunnel{Name: "pachd"}},
+		{tunnel: client.Tunnel{Name: "s3g"}},
+		{tunnel: client.Tunnel{Name: "console"}},
+	}
+	selected, err := selectServices(services, "")
+	require.NoError(t, err)
+	require.Equal(t, services, selected)
+
+	selected, err = selectServices(services, "console, pachd,console")
+	require.NoError(t, err)
+	require.Equal(t, []portForwardService{services[2], services[0]}, selected)
+
+	_, err = selectServices(services, "pachd,dex")
+	require.YesError(t, err)
+	require.Matches(t, `unknown service "dex"`, err.Error())
+}
+
+func TestTunnelStatus(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer l.Close()
+	up := uint32(l.Addr().(*net.TCPAddr).Port)
+	require.Equal(t, "up", probeTunnel(up))
+
+	// find a port that nothing listens on
+	l2, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	down := uint32(l2.Addr().(*net.TCPAddr).Port)
+	require.NoError(t, l2.Close())
+	require.Equal(t, "down", probeTunnel(down))
+
+	var buf bytes.Buffer
+	require.NoError(t, printTunnelStatus(&buf, map[string]uint32{"s3g": down, "pachd": up}, probeTunnel))
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Equal(t, 3, len(lines))
+	require.Matches(t, `^NAME\s+LOCAL PORT\s+STATE$`, string(lines[0]))
+	require.Matches(t, `^pachd\s+\d+\s+up$`, string(lines[1]))
+	require.Matches(t, `^s3g\s+\d+\s+down$`, string(lines[2]))
+}
@@ -10,6 +10,7 @@ import (
 	"runtime/debug"
 	"runtime/pprof"
 	"syscall"
+	"time"
 
 	adminclient "github.com/pachyderm/pachyderm/v2/src/admin"
 	authclient "github.com/pachyderm/pachyderm/v2/src/auth"
@@ -22,27 +23,35 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/dbutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/healthcheck"
 	logutil "github.com/pachyderm/pachyderm/v2/src/internal/log"
 	"github.com/pachyderm/pachyderm/v2/src/internal/metrics"
 	authmw "github.com/pachyderm/pachyderm/v2/src/internal/middleware/auth"
 	errorsmw "github.com/pachyderm/pachyderm/v2/src/internal/middleware/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/middleware/inflight"
+	"github.com/pachyderm/pachyderm/v2/src/internal/middleware/limits"
 	loggingmw "github.com/pachyderm/pachyderm/v2/src/internal/middleware/logging"
+	"github.com/pachyderm/pachyderm/v2/src/internal/middleware/readonly"
 	version_middleware "github.com/pachyderm/pachyderm/v2/src/internal/middleware/version"
 	"github.com/pachyderm/pachyderm/v2/src/internal/migrations"
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
 	"github.com/pachyderm/pachyderm/v2/src/internal/profileutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/serviceenv"
 	"github.com/pachyderm/pachyderm/v2/src/internal/tls"
 	"github.com/pachyderm/pachyderm/v2/src/internal/tracing"
 	txnenv "github.com/pachyderm/pachyderm/v2/src/internal/transactionenv"
+	"github.com/pachyderm/pachyderm/v2/src/internal/upgrade"
 	licenseclient "github.com/pachyderm/pachyderm/v2/src/license"
 	pfsclient "github.com/pachyderm/pachyderm/v2/src/pfs"
 	ppsclient "github.com/pachyderm/pachyderm/v2/src/pps"
 	proxyclient "github.com/pachyderm/pachyderm/v2/src/proxy"
+	searchclient "github.com/pachyderm/pachyderm/v2/src/search"
 	adminserver "github.com/pachyderm/pachyderm/v2/src/server/admin/server"
 	authserver "github.com/pachyderm/pachyderm/v2/src/server/auth/server"
 	debugserver "github.com/pachyderm/pachyderm/v2/src/server/debug/server"
 	eprsserver "github.com/pachyderm/pachyderm/v2/src/server/enterprise/server"
 	proxyserver "github.com/pachyderm/pachyderm/v2/src/server/proxy/server"
+	searchserver "github.com/pachyderm/pachyderm/v2/src/server/search/server"
 	"google.golang.org/grpc/health"
 
 	identity_server "github.com/pachyderm/pachyderm/v2/src/server/identity/server"
@@ -50,6 +59,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/server/pfs/s3"
 	pfs_server "github.com/pachyderm/pachyderm/v2/src/server/pfs/server"
 	pps_server "github.com/pachyderm/pachyderm/v2/src/server/pps/server"
+	"github.com/pachyderm/pachyderm/v2/src/server/restgateway"
 	txnserver "github.com/pachyderm/pachyderm/v2/src/server/transaction/server"
 	transactionclient "github.com/pachyderm/pachyderm/v2/src/transaction"
 	"github.com/pachyderm/pachyderm/v2/src/version"
@@ -71,7 +81,7 @@ var mode string
 var readiness bool
 
 func init() {
-	flag.StringVar(&mode, "mode", "full", "Pachd currently supports four modes: full, enterprise, sidecar and paused. Full includes everything you need in a full pachd node. Enterprise runs the Enterprise Server. Sidecar runs only PFS, the Auth service, and a stripped-down version of PPS.  Paused runs all APIs other than PFS and PPS; it is intended to enable taking database backups.")
+	flag.StringVar(&mode, "mode", "full", "Pachd currently supports five modes: full, enterprise, sidecar, paused and chunkcache. Full includes everything you need in a full pachd node. Enterprise runs the Enterprise Server. Sidecar runs only PFS, the Auth service, and a stripped-down version of PPS.  Paused runs all APIs other than PFS and PPS; it is intended to enable taking database backups. Chunkcache runs on each node to evict chunks from the cache that the node's pods share.")
 	flag.BoolVar(&readiness, "readiness", false, "Run readiness check.")
 	flag.Parse()
 }
@@ -96,11 +106,49 @@ func main() {
 		cmdutil.Main(doSidecarMode, &serviceenv.PachdFullConfiguration{})
 	case mode == "paused":
 		cmdutil.Main(doPausedMode, &serviceenv.PachdFullConfiguration{})
+	case mode == "chunkcache":
+		cmdutil.Main(doChunkCacheMode, &serviceenv.PachdFullConfiguration{})
 	default:
 		fmt.Printf("unrecognized mode: %s\n", mode)
 	}
 }
 
+// nodeCacheEvictionPeriod is how often the chunk cache daemon evicts chunks
+// from the node cache.
+const nodeCacheEvictionPeriod = 30 * time.Second
+
+// doChunkCacheMode runs the daemon that manages the chunk cache that the pods
+// on a node share. The pods only add chunks to the cache, so the daemon
+// evicts the least recently read ones when it grows past its size, and
+// exports the cache's size.
+func doChunkCacheMode(config interface{}) error {
+	env := serviceenv.NewConfiguration(config)
+	dir := env.StorageNodeCachePath
+	if dir == "" {
+		return errors.New("STORAGE_NODE_CACHE_PATH must be set in chunkcache mode")
+	}
+	if err := obj.InitNodeCache(dir); err != nil {
+		return err
+	}
+	log.Printf("managing the node cache in %s, up to %d bytes", dir, env.StorageNodeCacheSizeBytes)
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		log.Errorf("metrics server: %v", http.ListenAndServe(fmt.Sprintf(":%v", env.PrometheusPort), nil))
+	}()
+	ctx := context.Background()
+	ticker := time.NewTicker(nodeCacheEvictionPeriod)
+	defer ticker.Stop()
+	for {
+		stats, err := obj.EvictNodeCache(ctx, dir, env.StorageNodeCacheSizeBytes)
+		if err != nil {
+			log.Errorf("could not evict chunks from the node cache: %v", err)
+		} else if stats.Evicted > 0 {
+			log.Infof("evicted %d chunks from the node cache, %d chunks (%d bytes) remain", stats.Evicted, stats.Objects, stats.SizeBytes)
+		}
+		<-ticker.C
+	}
+}
+
 func doReadinessCheck(config interface{}) error {
 	env := serviceenv.InitPachOnlyEnv(serviceenv.NewConfiguration(config))
 	return env.GetPachClient(context.Background()).Health()
@@ -136,14 +184,11 @@ func doEnterpriseMode(config interface{}) (retErr error) {
 	profileutil.StartCloudProfiler("pachyderm-pachd-enterprise", env.Config())
 	debug.SetGCPercent(env.Config().GCPercent)
 
-	// TODO: currently all pachds attempt to apply migrations, we should coordinate this
 	if err := dbutil.WaitUntilReady(context.Background(), log.StandardLogger(), env.GetDBClient()); err != nil {
 		return err
 	}
-	if err := migrations.ApplyMigrations(context.Background(), env.GetDBClient(), migrations.MakeEnv(nil, env.GetEtcdClient()), clusterstate.DesiredClusterState); err != nil {
-		return err
-	}
-	if err := migrations.BlockUntil(context.Background(), env.GetDBClient(), clusterstate.DesiredClusterState); err != nil {
+	upgrader := newUpgradeCoordinator(env, nil)
+	if err := upgrader.Start(context.Background()); err != nil {
 		return err
 	}
 	env.InitDexDB()
@@ -155,6 +200,15 @@ func doEnterpriseMode(config interface{}) (retErr error) {
 	// Setup External Pachd GRPC Server.
 	authInterceptor := authmw.NewInterceptor(env.AuthServer)
 	loggingInterceptor := loggingmw.NewLoggingInterceptor(env.Logger())
+	limiter, err := newLimiter(env.Config())
+	if err != nil {
+		return err
+	}
+	principalLimiter, err := newPrincipalLimiter(env.Config())
+	if err != nil {
+		return err
+	}
+	readOnly := readonly.NewGate(env.GetEtcdClient())
 	externalServer, err := grpcutil.NewServer(
 		context.Background(),
 		true,
@@ -168,15 +222,23 @@ func doEnterpriseMode(config interface{}) (retErr error) {
 		grpc.ChainUnaryInterceptor(
 			errorsmw.UnaryServerInterceptor,
 			version_middleware.UnaryServerInterceptor,
+			upgrader.UnaryServerInterceptor,
+			limiter.UnaryServerInterceptor,
 			tracing.UnaryServerInterceptor(),
 			authInterceptor.InterceptUnary,
+			principalLimiter.UnaryServerInterceptor,
+			readOnly.UnaryServerInterceptor,
 			loggingInterceptor.UnaryServerInterceptor,
 		),
 		grpc.ChainStreamInterceptor(
 			errorsmw.StreamServerInterceptor,
 			version_middleware.StreamServerInterceptor,
+			upgrader.StreamServerInterceptor,
+			limiter.StreamServerInterceptor,
 			tracing.StreamServerInterceptor(),
 			authInterceptor.InterceptStream,
+			principalLimiter.StreamServerInterceptor,
+			readOnly.StreamServerInterceptor,
 			loggingInterceptor.StreamServerInterceptor,
 		),
 	)
@@ -392,6 +454,12 @@ func doEnterpriseMode(config interface{}) (retErr error) {
 	go waitForError("Internal Enterprise GRPC Server", errChan, true, func() error {
 		return internalServer.Wait()
 	})
+	go waitForError("Upgrade Coordinator", errChan, true, func() error {
+		return upgrader.Run(context.Background())
+	})
+	go waitForError("Read-only Gate", errChan, true, func() error {
+		return readOnly.Run(context.Background())
+	})
 	return <-errChan
 }
 
@@ -422,6 +490,7 @@ func doSidecarMode(config interface{}) (retErr error) {
 	}
 	env := serviceenv.InitWithKube(serviceenv.NewConfiguration(config))
 	profileutil.StartCloudProfiler("pachyderm-pachd-sidecar", env.Config())
+	profileutil.StartContinuousProfiler(context.Background(), profileutil.WorkerProfileSource(env.Config().PPSPipelineName, env.Config().PachdPodName, "storage"), env.Config())
 	debug.SetGCPercent(env.Config().GCPercent)
 	if env.Config().EtcdPrefix == "" {
 		env.Config().EtcdPrefix = col.DefaultPrefix
@@ -580,19 +649,18 @@ func doFullMode(config interface{}) (retErr error) {
 	}
 	env := serviceenv.InitWithKube(serviceenv.NewConfiguration(config))
 	profileutil.StartCloudProfiler("pachyderm-pachd-full", env.Config())
+	profileutil.StartContinuousProfiler(context.Background(), profileutil.PachdProfileSource(env.Config().PachdPodName), env.Config())
 	debug.SetGCPercent(env.Config().GCPercent)
 	if env.Config().EtcdPrefix == "" {
 		env.Config().EtcdPrefix = col.DefaultPrefix
 	}
 
-	// TODO: currently all pachds attempt to apply migrations, we should coordinate this
 	if err := dbutil.WaitUntilReady(ctx, log.StandardLogger(), env.GetDBClient()); err != nil {
 		return err
 	}
-	if err := migrations.ApplyMigrations(ctx, env.GetDBClient(), migrations.MakeEnv(nil, env.GetEtcdClient()), clusterstate.DesiredClusterState); err != nil {
-		return err
-	}
-	if err := migrations.BlockUntil(ctx, env.GetDBClient(), clusterstate.DesiredClusterState); err != nil {
+	requests := inflight.NewTracker()
+	upgrader := newUpgradeCoordinator(env, requests)
+	if err := upgrader.Start(ctx); err != nil {
 		return err
 	}
 	env.InitDexDB()
@@ -602,10 +670,20 @@ func doFullMode(config interface{}) (retErr error) {
 		reporter = metrics.NewReporter(env)
 	}
 	requireNoncriticalServers := !env.Config().RequireCriticalServersOnly
+	healthChecker := newHealthChecker(env)
 
 	// Setup External Pachd GRPC Server.
 	authInterceptor := authmw.NewInterceptor(env.AuthServer)
 	loggingInterceptor := loggingmw.NewLoggingInterceptor(env.Logger())
+	limiter, err := newLimiter(env.Config())
+	if err != nil {
+		return err
+	}
+	principalLimiter, err := newPrincipalLimiter(env.Config())
+	if err != nil {
+		return err
+	}
+	readOnly := readonly.NewGate(env.GetEtcdClient())
 	externalServer, err := grpcutil.NewServer(
 		ctx,
 		true,
@@ -619,15 +697,25 @@ func doFullMode(config interface{}) (retErr error) {
 		grpc.ChainUnaryInterceptor(
 			errorsmw.UnaryServerInterceptor,
 			version_middleware.UnaryServerInterceptor,
+			upgrader.UnaryServerInterceptor,
+			limiter.UnaryServerInterceptor,
 			tracing.UnaryServerInterceptor(),
 			authInterceptor.InterceptUnary,
+			principalLimiter.UnaryServerInterceptor,
+			readOnly.UnaryServerInterceptor,
+			requests.UnaryServerInterceptor,
 			loggingInterceptor.UnaryServerInterceptor,
 		),
 		grpc.ChainStreamInterceptor(
 			errorsmw.StreamServerInterceptor,
 			version_middleware.StreamServerInterceptor,
+			upgrader.StreamServerInterceptor,
+			limiter.StreamServerInterceptor,
 			tracing.StreamServerInterceptor(),
 			authInterceptor.InterceptStream,
+			principalLimiter.StreamServerInterceptor,
+			readOnly.StreamServerInterceptor,
+			requests.StreamServerInterceptor,
 			loggingInterceptor.StreamServerInterceptor,
 		),
 	)
@@ -733,7 +821,11 @@ func doFullMode(config interface{}) (retErr error) {
 			return err
 		}
 		if err := logGRPCServerSetup("Admin API", func() error {
-			adminclient.RegisterAPIServer(externalServer.Server, adminserver.NewAPIServer(adminserver.EnvFromServiceEnv(env)))
+			adminEnv := adminserver.EnvFromServiceEnv(env)
+			adminEnv.Requests = requests
+			adminclient.RegisterAPIServer(externalServer.Server, adminserver.NewAPIServer(adminEnv))
+			go adminserver.RunWebhookDispatcher(adminEnv)
+			go adminserver.RunCapacityAnalyzer(adminEnv)
 			return nil
 		}); err != nil {
 			return err
@@ -770,11 +862,19 @@ func doFullMode(config interface{}) (retErr error) {
 		}); err != nil {
 			return err
 		}
+		if err := logGRPCServerSetup("Search API", func() error {
+			searchEnv := searchserver.EnvFromServiceEnv(env)
+			searchclient.RegisterAPIServer(externalServer.Server, searchserver.NewAPIServer(searchEnv))
+			go searchserver.RunIndexer(searchEnv)
+			return nil
+		}); err != nil {
+			return err
+		}
 		txnEnv.Initialize(env, transactionAPIServer)
 		if _, err := externalServer.ListenTCP("", env.Config().Port); err != nil {
 			return err
 		}
-		healthServer.Resume()
+		healthChecker.AddServer(healthServer)
 		return nil
 	}); err != nil {
 		return err
@@ -787,11 +887,13 @@ func doFullMode(config interface{}) (retErr error) {
 			errorsmw.UnaryServerInterceptor,
 			tracing.UnaryServerInterceptor(),
 			authInterceptor.InterceptUnary,
+			requests.UnaryServerInterceptor,
 			loggingInterceptor.UnaryServerInterceptor,
 		),
 		grpc.ChainStreamInterceptor(
 			errorsmw.StreamServerInterceptor,
 			authInterceptor.InterceptStream,
+			requests.StreamServerInterceptor,
 			loggingInterceptor.StreamServerInterceptor,
 		),
 	)
@@ -917,7 +1019,9 @@ func doFullMode(config interface{}) (retErr error) {
 			return err
 		}
 		if err := logGRPCServerSetup("Admin API", func() error {
-			adminclient.RegisterAPIServer(internalServer.Server, adminserver.NewAPIServer(adminserver.EnvFromServiceEnv(env)))
+			adminEnv := adminserver.EnvFromServiceEnv(env)
+			adminEnv.Requests = requests
+			adminclient.RegisterAPIServer(internalServer.Server, adminserver.NewAPIServer(adminEnv))
 			return nil
 		}); err != nil {
 			return err
@@ -930,11 +1034,17 @@ func doFullMode(config interface{}) (retErr error) {
 		}); err != nil {
 			return err
 		}
+		if err := logGRPCServerSetup("Search API", func() error {
+			searchclient.RegisterAPIServer(internalServer.Server, searchserver.NewAPIServer(searchserver.EnvFromServiceEnv(env)))
+			return nil
+		}); err != nil {
+			return err
+		}
 		txnEnv.Initialize(env, transactionAPIServer)
 		if _, err := internalServer.ListenTCP("", env.Config().PeerPort); err != nil {
 			return err
 		}
-		healthServer.Resume()
+		healthChecker.AddServer(healthServer)
 		return nil
 	}); err != nil {
 		return err
@@ -949,9 +1059,16 @@ func doFullMode(config interface{}) (retErr error) {
 	go waitForError("Internal Pachd GRPC Server", errChan, true, func() error {
 		return internalServer.Wait()
 	})
+	go waitForError("Upgrade Coordinator", errChan, true, func() error {
+		return upgrader.Run(ctx)
+	})
+	go waitForError("Read-only Gate", errChan, true, func() error {
+		return readOnly.Run(ctx)
+	})
 	go waitForError("S3 Server", errChan, requireNoncriticalServers, func() error {
 		router := s3.Router(s3.NewMasterDriver(), env.GetPachClient)
 		server := s3.Server(env.Config().S3GatewayPort, router)
+		server.Handler = s3.ReadOnly(server.Handler, readOnly.CheckWrite)
 		certPath, keyPath, err := tls.GetCertPaths()
 		if err != nil {
 			log.Warnf("s3gateway TLS disabled: %v", err)
@@ -966,10 +1083,33 @@ func doFullMode(config interface{}) (retErr error) {
 		server.TLSConfig = &gotls.Config{GetCertificate: cLoader.GetCertificate}
 		return errors.EnsureStack(server.ListenAndServeTLS(certPath, keyPath))
 	})
+	go healthChecker.Run(ctx)
 	go waitForError("Prometheus Server", errChan, requireNoncriticalServers, func() error {
 		http.Handle("/metrics", promhttp.Handler())
+		http.Handle("/readyz", healthChecker)
 		return errors.EnsureStack(http.ListenAndServe(fmt.Sprintf(":%v", env.Config().PrometheusPort), nil))
 	})
+	if env.Config().RESTGatewayPort != 0 {
+		go waitForError("REST Gateway", errChan, requireNoncriticalServers, func() error {
+			pachClient := env.GetPachClient(ctx)
+			handler, err := restgateway.NewHandler(ctx, pachClient.PfsAPIClient, pachClient.PpsAPIClient, pachClient.AuthAPIClient, pachClient.AdminAPIClient)
+			if err != nil {
+				return err
+			}
+			server := restgateway.Server(env.Config().RESTGatewayPort, handler)
+			certPath, keyPath, err := tls.GetCertPaths()
+			if err != nil {
+				log.Warnf("REST gateway TLS disabled: %v", err)
+				return errors.EnsureStack(server.ListenAndServe())
+			}
+			cLoader := tls.NewCertLoader(certPath, keyPath, tls.CertCheckFrequency)
+			if err := cLoader.LoadAndStart(); err != nil {
+				return errors.Wrapf(err, "couldn't load TLS cert for REST gateway")
+			}
+			server.TLSConfig = &gotls.Config{GetCertificate: cLoader.GetCertificate}
+			return errors.EnsureStack(server.ListenAndServeTLS(certPath, keyPath))
+		})
+	}
 	go func(c chan os.Signal) {
 		<-c
 		log.Println("terminating; waiting for pachd server to gracefully stop")
@@ -1018,14 +1158,11 @@ func doPausedMode(config interface{}) (retErr error) {
 		env.Config().EtcdPrefix = col.DefaultPrefix
 	}
 
-	// TODO: currently all pachds attempt to apply migrations, we should coordinate this
 	if err := dbutil.WaitUntilReady(ctx, log.StandardLogger(), env.GetDBClient()); err != nil {
 		return err
 	}
-	if err := migrations.ApplyMigrations(ctx, env.GetDBClient(), migrations.MakeEnv(nil, env.GetEtcdClient()), clusterstate.DesiredClusterState); err != nil {
-		return err
-	}
-	if err := migrations.BlockUntil(ctx, env.GetDBClient(), clusterstate.DesiredClusterState); err != nil {
+	upgrader := newUpgradeCoordinator(env, nil)
+	if err := upgrader.Start(ctx); err != nil {
 		return err
 	}
 	env.InitDexDB()
@@ -1035,6 +1172,15 @@ func doPausedMode(config interface{}) (retErr error) {
 	// Setup External Pachd GRPC Server.
 	authInterceptor := authmw.NewInterceptor(env.AuthServer)
 	loggingInterceptor := loggingmw.NewLoggingInterceptor(env.Logger())
+	limiter, err := newLimiter(env.Config())
+	if err != nil {
+		return err
+	}
+	principalLimiter, err := newPrincipalLimiter(env.Config())
+	if err != nil {
+		return err
+	}
+	readOnly := readonly.NewGate(env.GetEtcdClient())
 	externalServer, err := grpcutil.NewServer(
 		ctx,
 		true,
@@ -1048,15 +1194,23 @@ func doPausedMode(config interface{}) (retErr error) {
 		grpc.ChainUnaryInterceptor(
 			errorsmw.UnaryServerInterceptor,
 			version_middleware.UnaryServerInterceptor,
+			upgrader.UnaryServerInterceptor,
+			limiter.UnaryServerInterceptor,
 			tracing.UnaryServerInterceptor(),
 			authInterceptor.InterceptUnary,
+			principalLimiter.UnaryServerInterceptor,
+			readOnly.UnaryServerInterceptor,
 			loggingInterceptor.UnaryServerInterceptor,
 		),
 		grpc.ChainStreamInterceptor(
 			errorsmw.StreamServerInterceptor,
 			version_middleware.StreamServerInterceptor,
+			upgrader.StreamServerInterceptor,
+			limiter.StreamServerInterceptor,
 			tracing.StreamServerInterceptor(),
 			authInterceptor.InterceptStream,
+			principalLimiter.StreamServerInterceptor,
+			readOnly.StreamServerInterceptor,
 			loggingInterceptor.StreamServerInterceptor,
 		),
 	)
@@ -1281,9 +1435,16 @@ func doPausedMode(config interface{}) (retErr error) {
 	go waitForError("Internal Pachd GRPC Server", errChan, true, func() error {
 		return internalServer.Wait()
 	})
+	go waitForError("Upgrade Coordinator", errChan, true, func() error {
+		return upgrader.Run(ctx)
+	})
+	go waitForError("Read-only Gate", errChan, true, func() error {
+		return readOnly.Run(ctx)
+	})
 	go waitForError("S3 Server", errChan, requireNoncriticalServers, func() error {
 		router := s3.Router(s3.NewMasterDriver(), env.GetPachClient)
 		server := s3.Server(env.Config().S3GatewayPort, router)
+		server.Handler = s3.ReadOnly(server.Handler, readOnly.CheckWrite)
 		certPath, keyPath, err := tls.GetCertPaths()
 		if err != nil {
 			log.Warnf("s3gateway TLS disabled: %v", err)
@@ -1314,6 +1475,65 @@ func doPausedMode(config interface{}) (retErr error) {
 	return <-errChan
 }
 
+// newLimiter returns the limiter of the RPCs handled by the external gRPC
+// server, as configured in config.
+func newLimiter(config *serviceenv.Configuration) (*limits.Limiter, error) {
+	limitsConfig, err := limits.ParseConfig(config.GRPCMaxRequestBytes, config.GRPCMaxResponseBytes, config.GRPCDefaultDeadlines, config.GRPCConcurrencyLimits)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid gRPC limits")
+	}
+	return limits.NewLimiter(limitsConfig), nil
+}
+
+// newPrincipalLimiter returns the limiter of each principal's RPCs to the
+// external gRPC server, as configured in config.
+func newPrincipalLimiter(config *serviceenv.Configuration) (*limits.PrincipalLimiter, error) {
+	principalConfig, err := limits.ParsePrincipalConfig(config.GRPCPrincipalLimits, config.GRPCPrincipalLimitOverrides)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid per-principal gRPC limits")
+	}
+	return limits.NewPrincipalLimiter(principalConfig), nil
+}
+
+// newHealthChecker returns the checker of the dependencies of a full mode
+// pachd, whose results are reported by its health servers and at /readyz.
+// newUpgradeCoordinator returns the coordinator that takes part in the
+// cluster's upgrades on behalf of this pachd.
+func newUpgradeCoordinator(env serviceenv.ServiceEnv, requests upgrade.Requests) *upgrade.Coordinator {
+	var pod string
+	if env.Config().PachdSpecificConfiguration != nil {
+		pod = env.Config().PachdPodName
+	} else {
+		pod, _ = os.Hostname()
+	}
+	return upgrade.NewCoordinator(upgrade.Env{
+		DB:           env.GetDBClient(),
+		EtcdClient:   env.GetEtcdClient(),
+		Migrations:   migrations.MakeEnv(nil, env.GetEtcdClient()),
+		Target:       clusterstate.DesiredClusterState,
+		Pod:          pod,
+		Version:      version.PrettyVersion(),
+		Requests:     requests,
+		DrainTimeout: time.Duration(env.Config().UpgradeDrainTimeoutSeconds) * time.Second,
+		Logger:       log.StandardLogger(),
+	})
+}
+
+func newHealthChecker(env serviceenv.ServiceEnv) *healthcheck.Checker {
+	config := env.Config()
+	return healthcheck.NewChecker(
+		time.Duration(config.HealthCheckIntervalSeconds)*time.Second,
+		time.Duration(config.HealthCheckTimeoutSeconds)*time.Second,
+		healthcheck.Etcd(env.GetEtcdClient()),
+		healthcheck.Postgres("postgres", env.GetDirectDBClient()),
+		healthcheck.Postgres("pg-bouncer", env.GetDBClient()),
+		healthcheck.ObjectStorage(func() (obj.Client, error) {
+			return obj.NewClient(config.StorageBackend, config.StorageRoot)
+		}),
+		healthcheck.Auth(func() authclient.APIServer { return env.AuthServer() }),
+	)
+}
+
 func logGRPCServerSetup(name string, f func() error) (retErr error) {
 	log.Printf("started setting up %v GRPC Server", name)
 	defer func() {
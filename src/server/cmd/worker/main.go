@@ -38,9 +38,15 @@ func do(config interface{}) error {
 	// must run InstallJaegerTracer before InitWithKube/pach client initialization
 	tracing.InstallJaegerTracerFromEnv()
 	env := serviceenv.InitWithKube(serviceenv.NewConfiguration(config))
+	// Workers started by a warm worker pool don't know their pipeline until
+	// one claims them.
+	if err := worker.WaitForPoolAssignment(context.Background(), env); err != nil {
+		return err
+	}
 
 	// Enable cloud profilers if the configuration allows.
 	profileutil.StartCloudProfiler("pachyderm-worker", env.Config())
+	profileutil.StartContinuousProfiler(context.Background(), profileutil.WorkerProfileSource(env.Config().PPSPipelineName, env.Config().PodName, "user"), env.Config())
 
 	// Construct a client that connects to the sidecar.
 	pachClient := env.GetPachClient(context.Background())
@@ -63,7 +69,11 @@ func do(config interface{}) error {
 	}
 
 	// Start worker api server
-	server, err := grpcutil.NewServer(context.Background(), false)
+	serverOptions, err := workerserver.ServerOptions()
+	if err != nil {
+		return err
+	}
+	server, err := grpcutil.NewServer(context.Background(), false, serverOptions...)
 	if err != nil {
 		return err
 	}
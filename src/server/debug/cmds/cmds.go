@@ -1,7 +1,9 @@
 package cmds
 
 import (
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gogo/protobuf/types"
@@ -79,11 +81,25 @@ func Cmds() []*cobra.Command {
 	commands = append(commands, cmdutil.CreateAlias(binary, "debug binary"))
 
 	var limit int64
+	var collectorNames []string
+	var notify bool
 	dump := &cobra.Command{
 		Use:   "{{alias}} <file>",
 		Short: "Collect a standard set of debugging information.",
-		Long:  "Collect a standard set of debugging information.",
-		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+		Long: "Collect a standard set of debugging information, as a gzipped tar file that's written as it's collected. " +
+			"If <file> is '-', it's written to stdout. Passwords, tokens and other secrets are redacted from the collected information.\n\n" +
+			"The --collectors flag selects the information that's collected, from:\n" + collectorsHelp(),
+		Example: `
+# collect everything
+$ {{alias}} dump.tgz
+
+# only collect the logs and Kubernetes state of the pipeline edges
+$ {{alias}} --pipeline edges --collectors logs,kubernetes dump.tgz`,
+		Run: cmdutil.RunFixedArgs(1, cmdutil.Notify(&notify, func(args []string) error {
+			collectors, err := parseCollectors(collectorNames)
+			if err != nil {
+				return err
+			}
 			client, err := client.NewOnUserMachine("debug-dump")
 			if err != nil {
 				return err
@@ -93,17 +109,67 @@ func Cmds() []*cobra.Command {
 			if err != nil {
 				return err
 			}
+			if args[0] == "-" {
+				return client.Dump(filter, limit, os.Stdout, collectors...)
+			}
 			return withFile(args[0], func(f *os.File) error {
-				return client.Dump(filter, limit, f)
+				return client.Dump(filter, limit, f, collectors...)
 			})
-		}),
+		})),
 	}
 	dump.Flags().BoolVar(&pachd, "pachd", false, "Only collect the dump from pachd.")
 	dump.Flags().StringVarP(&pipeline, "pipeline", "p", "", "Only collect the dump from the worker pods for the given pipeline.")
 	dump.Flags().StringVarP(&worker, "worker", "w", "", "Only collect the dump from the given worker pod.")
 	dump.Flags().Int64VarP(&limit, "limit", "l", 0, "Limit sets the limit for the number of commits / jobs that are returned for each repo / pipeline in the dump.")
+	dump.Flags().StringSliceVarP(&collectorNames, "collectors", "c", nil, "A comma-separated list of the collectors to run. All collectors run if unset.")
+	dump.Flags().AddFlagSet(cmdutil.NotifyFlags(&notify))
 	commands = append(commands, cmdutil.CreateAlias(dump, "debug dump"))
 
+	var since time.Duration
+	var from, to string
+	var profiles []string
+	profileHistory := &cobra.Command{
+		Use:   "{{alias}} <file>",
+		Short: "Collect the profiles that pachd and the workers captured continuously.",
+		Long: "Collect the CPU and heap profiles that pachd and the workers captured continuously, and that are still kept in object storage, " +
+			"as a gzipped tar file. The profiles are named <source>/<time>-<profile>.pprof. If <file> is '-', it's written to stdout.",
+		Example: `
+# collect the profiles captured in the last hour
+$ {{alias}} --since 1h profiles.tgz
+
+# collect pachd's CPU profiles captured during an incident
+$ {{alias}} --pachd --profile cpu --from 2021-06-01T10:00:00Z --to 2021-06-01T11:00:00Z profiles.tgz`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			start, end, err := parseTimeRange(since, from, to, time.Now())
+			if err != nil {
+				return err
+			}
+			client, err := client.NewOnUserMachine("debug-profile-history")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			filter, err := createFilter(pachd, pipeline, worker)
+			if err != nil {
+				return err
+			}
+			if args[0] == "-" {
+				return client.GetProfileHistory(filter, profiles, start, end, os.Stdout)
+			}
+			return withFile(args[0], func(f *os.File) error {
+				return client.GetProfileHistory(filter, profiles, start, end, f)
+			})
+		}),
+	}
+	profileHistory.Flags().BoolVar(&pachd, "pachd", false, "Only collect the profiles of pachd.")
+	profileHistory.Flags().StringVarP(&pipeline, "pipeline", "p", "", "Only collect the profiles of the worker pods for the given pipeline.")
+	profileHistory.Flags().StringVarP(&worker, "worker", "w", "", "Only collect the profiles of the given worker pod.")
+	profileHistory.Flags().StringSliceVar(&profiles, "profile", nil, "A comma-separated list of the profiles, 'cpu' or 'heap', to collect. Both are collected if unset.")
+	profileHistory.Flags().DurationVar(&since, "since", 0, "Only collect the profiles captured within this duration of now.")
+	profileHistory.Flags().StringVar(&from, "from", "", "Only collect the profiles captured at or after this RFC 3339 time.")
+	profileHistory.Flags().StringVar(&to, "to", "", "Only collect the profiles captured at or before this RFC 3339 time.")
+	commands = append(commands, cmdutil.CreateAlias(profileHistory, "debug profile-history"))
+
 	debug := &cobra.Command{
 		Short: "Debug commands for analyzing a running cluster.",
 		Long:  "Debug commands for analyzing a running cluster.",
@@ -133,6 +199,68 @@ func createFilter(pachd bool, pipeline, worker string) (*debug.Filter, error) {
 	return f, nil
 }
 
+// collectorDescriptions describe the collectors of a dump.
+var collectorDescriptions = map[debug.Collector]string{
+	debug.Collector_VERSION:    "the version of pachd",
+	debug.Collector_KUBERNETES: "the output of 'kubectl describe' for each pod",
+	debug.Collector_LOGS:       "the logs of each container, from Kubernetes and Loki",
+	debug.Collector_PROFILES:   "goroutine and heap profiles of pachd and the workers",
+	debug.Collector_SPECS:      "the specs of each version of each pipeline",
+	debug.Collector_COMMITS:    "the commits of each repo",
+	debug.Collector_JOBS:       "the jobs of each pipeline",
+}
+
+func collectorsHelp() string {
+	var lines []string
+	for value := int32(1); value < int32(len(debug.Collector_name)); value++ {
+		collector := debug.Collector(value)
+		lines = append(lines, fmt.Sprintf("  %-10s  %s", strings.ToLower(collector.String()), collectorDescriptions[collector]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func parseCollectors(names []string) ([]debug.Collector, error) {
+	var result []debug.Collector
+	for _, name := range names {
+		value, ok := debug.Collector_value[strings.ToUpper(strings.TrimSpace(name))]
+		if !ok || value == int32(debug.Collector_COLLECTOR_UNKNOWN) {
+			return nil, errors.Errorf("unknown collector %q", name)
+		}
+		result = append(result, debug.Collector(value))
+	}
+	return result, nil
+}
+
+// parseTimeRange returns the start and end of the range of times set by
+// the --since, --from and --to flags. A zero time leaves that end of the
+// range open.
+func parseTimeRange(since time.Duration, from, to string, now time.Time) (start, end time.Time, retErr error) {
+	if since != 0 && from != "" {
+		return time.Time{}, time.Time{}, errors.Errorf("only one of --since and --from may be set")
+	}
+	if since != 0 {
+		start = now.Add(-since)
+	}
+	if from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.Wrapf(err, "could not parse --from")
+		}
+		start = t
+	}
+	if to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.Wrapf(err, "could not parse --to")
+		}
+		end = t
+	}
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return time.Time{}, time.Time{}, errors.Errorf("the end of the range, %v, is before its start, %v", end, start)
+	}
+	return start, end, nil
+}
+
 func withFile(file string, cb func(*os.File) error) (retErr error) {
 	f, err := os.Create(file)
 	if err != nil {
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"sort"
+
+	"github.com/pachyderm/pachyderm/v2/src/debug"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// collectors is the set of collectors that a dump runs.
+type collectors map[debug.Collector]bool
+
+// newCollectors returns the set of the selected collectors, or of every
+// collector if none are selected.
+func newCollectors(selected []debug.Collector) (collectors, error) {
+	c := make(collectors)
+	for _, collector := range selected {
+		if _, ok := debug.Collector_name[int32(collector)]; !ok || collector == debug.Collector_COLLECTOR_UNKNOWN {
+			return nil, errors.Errorf("invalid collector %v", collector)
+		}
+		c[collector] = true
+	}
+	if len(c) == 0 {
+		return allCollectors(), nil
+	}
+	return c, nil
+}
+
+func allCollectors() collectors {
+	c := make(collectors)
+	for value := range debug.Collector_name {
+		if collector := debug.Collector(value); collector != debug.Collector_COLLECTOR_UNKNOWN {
+			c[collector] = true
+		}
+	}
+	return c
+}
+
+func (c collectors) has(collector debug.Collector) bool {
+	return c[collector]
+}
+
+// list returns the collectors in the order of their values, so that they can
+// be passed on to the workers.
+func (c collectors) list() []debug.Collector {
+	var result []debug.Collector
+	for collector := range c {
+		result = append(result, collector)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
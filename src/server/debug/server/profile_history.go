@@ -0,0 +1,116 @@
+package server
+
+import (
+	"archive/tar"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/debug"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
+	"github.com/pachyderm/pachyderm/v2/src/internal/profileutil"
+)
+
+type profileMatcher func(source, kind string, t time.Time) bool
+
+// newProfileMatcher returns a profileMatcher for the profiles selected by
+// request.
+func newProfileMatcher(request *debug.ProfileHistoryRequest) (profileMatcher, error) {
+	kinds := make(map[string]bool)
+	for _, kind := range request.Profiles {
+		if kind != profileutil.ProfileCPU && kind != profileutil.ProfileHeap {
+			return nil, errors.Errorf("unknown profile %q, must be %q or %q", kind, profileutil.ProfileCPU, profileutil.ProfileHeap)
+		}
+		kinds[kind] = true
+	}
+	var start, end time.Time
+	if request.Start != nil {
+		var err error
+		if start, err = types.TimestampFromProto(request.Start); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+	}
+	if request.End != nil {
+		var err error
+		if end, err = types.TimestampFromProto(request.End); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+	}
+	matchSource := func(string) bool { return true }
+	if request.Filter != nil {
+		switch f := request.Filter.Filter.(type) {
+		case *debug.Filter_Pachd:
+			matchSource = func(source string) bool {
+				return strings.HasPrefix(source, pachdPrefix+"/")
+			}
+		case *debug.Filter_Pipeline:
+			matchSource = func(source string) bool {
+				return strings.HasPrefix(source, join(pipelinePrefix, f.Pipeline.Name)+"/")
+			}
+		case *debug.Filter_Worker:
+			// worker sources are pipelines/<pipeline>/<pod>/<container>
+			matchSource = func(source string) bool {
+				return strings.HasPrefix(source, pipelinePrefix+"/") && path.Base(path.Dir(source)) == f.Worker.Pod
+			}
+		}
+	}
+	return func(source, kind string, t time.Time) bool {
+		if len(kinds) > 0 && !kinds[kind] {
+			return false
+		}
+		if !start.IsZero() && t.Before(start) {
+			return false
+		}
+		if !end.IsZero() && t.After(end) {
+			return false
+		}
+		return matchSource(source)
+	}, nil
+}
+
+// GetProfileHistory returns the profiles that pachd and the workers captured
+// continuously, and that are still kept in object storage, as a tar.gz. The
+// profiles are named <source>/<time>-<profile>.pprof.
+func (s *debugServer) GetProfileHistory(request *debug.ProfileHistoryRequest, server debug.Debug_GetProfileHistoryServer) error {
+	match, err := newProfileMatcher(request)
+	if err != nil {
+		return err
+	}
+	objClient, err := obj.NewClientFromSecret(s.env.Config().StorageRoot)
+	if err != nil {
+		return err
+	}
+	ctx := server.Context()
+	var names []string
+	if err := objClient.Walk(ctx, profileutil.ProfilesPrefix+"/", func(name string) error {
+		source, kind, t, err := profileutil.ParseProfileObject(name)
+		if err != nil {
+			// not a profile, skip it
+			return nil
+		}
+		if match(source, kind, t) {
+			names = append(names, name)
+		}
+		return nil
+	}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	return grpcutil.WithStreamingBytesWriter(server, func(w io.Writer) error {
+		return withDebugWriter(w, func(tw *tar.Writer) error {
+			for _, name := range names {
+				name := name
+				if err := collectDebugFile(tw, strings.TrimPrefix(name, profileutil.ProfilesPrefix+"/"), "", func(w io.Writer) error {
+					return errors.EnsureStack(objClient.Get(ctx, name, w))
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/debug"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestProfileMatcher(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2021, 6, 1, hour, 0, 0, 0, time.UTC) }
+	ts := func(hour int) *types.Timestamp {
+		result, err := types.TimestampProto(at(hour))
+		require.NoError(t, err)
+		return result
+	}
+	profiles := []struct {
+		source, kind string
+		t            time.Time
+	}{
+		{"pachd/pachd-0", "cpu", at(9)},
+		{"pachd/pachd-0", "heap", at(10)},
+		{"pipelines/edges/pipeline-edges-v1-abcde/user", "cpu", at(10)},
+		{"pipelines/edges/pipeline-edges-v1-abcde/storage", "heap", at(11)},
+		{"pipelines/edges-2/pipeline-edges-2-v1-fghij/user", "cpu", at(12)},
+	}
+	for _, test := range []struct {
+		request *debug.ProfileHistoryRequest
+		matches []int
+	}{
+		{&debug.ProfileHistoryRequest{}, []int{0, 1, 2, 3, 4}},
+		{&debug.ProfileHistoryRequest{Profiles: []string{"cpu"}}, []int{0, 2, 4}},
+		{&debug.ProfileHistoryRequest{Start: ts(10), End: ts(11)}, []int{1, 2, 3}},
+		{&debug.ProfileHistoryRequest{Filter: &debug.Filter{Filter: &debug.Filter_Pachd{Pachd: true}}}, []int{0, 1}},
+		{&debug.ProfileHistoryRequest{Filter: &debug.Filter{Filter: &debug.Filter_Pipeline{Pipeline: &pps.Pipeline{Name: "edges"}}}}, []int{2, 3}},
+		{&debug.ProfileHistoryRequest{Filter: &debug.Filter{Filter: &debug.Filter_Worker{Worker: &debug.Worker{Pod: "pipeline-edges-2-v1-fghij"}}}}, []int{4}},
+	} {
+		match, err := newProfileMatcher(test.request)
+		require.NoError(t, err)
+		var matches []int
+		for i, p := range profiles {
+			if match(p.source, p.kind, p.t) {
+				matches = append(matches, i)
+			}
+		}
+		require.Equal(t, test.matches, matches, test.request.String())
+	}
+
+	_, err := newProfileMatcher(&debug.ProfileHistoryRequest{Profiles: []string{"goroutine"}})
+	require.YesError(t, err)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
 	loki "github.com/pachyderm/pachyderm/v2/src/internal/lokiutil/client"
 	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/profileutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/serviceenv"
 	"github.com/pachyderm/pachyderm/v2/src/pfs"
 	"github.com/pachyderm/pachyderm/v2/src/pps"
@@ -70,6 +71,7 @@ func (s *debugServer) handleRedirect(
 	collectWorker collectWorkerFunc,
 	redirect redirectFunc,
 	collect collectFunc,
+	collectApps collectFunc,
 ) error {
 	return grpcutil.WithStreamingBytesWriter(server, func(w io.Writer) error {
 		return withDebugWriter(w, func(tw *tar.Writer) error {
@@ -124,12 +126,21 @@ func (s *debugServer) handleRedirect(
 				}
 			}
 			// All other pachyderm apps (console, pg-bouncer, etcd, etc.).
-			return s.appLogs(tw)
+			return collectApps(tw)
 		})
 	})
 }
 
-func (s *debugServer) appLogs(tw *tar.Writer) error {
+func (s *debugServer) appLogsFunc(cs collectors) collectFunc {
+	return func(tw *tar.Writer, _ ...string) error {
+		return s.appLogs(tw, cs)
+	}
+}
+
+func (s *debugServer) appLogs(tw *tar.Writer, cs collectors) error {
+	if !cs.has(debug.Collector_KUBERNETES) && !cs.has(debug.Collector_LOGS) {
+		return nil
+	}
 	pods, err := s.env.GetKubeClient().CoreV1().Pods(s.env.Config().Namespace).List(s.env.Context(), metav1.ListOptions{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "ListOptions",
@@ -155,8 +166,13 @@ func (s *debugServer) appLogs(tw *tar.Writer) error {
 	}
 	for _, pod := range pods.Items {
 		podPrefix := join(pod.Labels["app"], pod.Name)
-		if err := s.collectDescribe(tw, pod.Name, podPrefix); err != nil {
-			return err
+		if cs.has(debug.Collector_KUBERNETES) {
+			if err := s.collectDescribe(tw, pod.Name, podPrefix); err != nil {
+				return err
+			}
+		}
+		if !cs.has(debug.Collector_LOGS) {
+			continue
 		}
 		for _, container := range pod.Spec.Containers {
 			prefix := join(podPrefix, container.Name)
@@ -285,6 +301,7 @@ func (s *debugServer) Profile(request *debug.ProfileRequest, server debug.Debug_
 		nil,
 		redirectProfileFunc(pachClient.Ctx(), request.Profile),
 		collectProfileFunc(request.Profile),
+		s.appLogsFunc(allCollectors()),
 	)
 }
 
@@ -302,9 +319,6 @@ func collectProfile(tw *tar.Writer, profile *debug.Profile, prefix ...string) er
 
 func writeProfile(w io.Writer, profile *debug.Profile) error {
 	if profile.Name == "cpu" {
-		if err := pprof.StartCPUProfile(w); err != nil {
-			return errors.EnsureStack(err)
-		}
 		duration := defaultDuration
 		if profile.Duration != nil {
 			var err error
@@ -313,9 +327,7 @@ func writeProfile(w io.Writer, profile *debug.Profile) error {
 				return errors.EnsureStack(err)
 			}
 		}
-		time.Sleep(duration)
-		pprof.StopCPUProfile()
-		return nil
+		return profileutil.WriteCPUProfile(context.Background(), w, duration)
 	}
 	p := pprof.Lookup(profile.Name)
 	if p == nil {
@@ -348,6 +360,7 @@ func (s *debugServer) Binary(request *debug.BinaryRequest, server debug.Debug_Bi
 		nil,
 		redirectBinaryFunc(pachClient.Ctx()),
 		collectBinary,
+		s.appLogsFunc(allCollectors()),
 	)
 }
 
@@ -381,43 +394,56 @@ func (s *debugServer) Dump(request *debug.DumpRequest, server debug.Debug_DumpSe
 	if request.Limit == 0 {
 		request.Limit = math.MaxInt64
 	}
+	cs, err := newCollectors(request.Collectors)
+	if err != nil {
+		return err
+	}
 	pachClient := s.env.GetPachClient(server.Context())
 	return s.handleRedirect(
 		pachClient,
 		server,
 		request.Filter,
-		s.collectPachdDumpFunc(pachClient, request.Limit),
-		s.collectPipelineDumpFunc(pachClient, request.Limit),
-		s.collectWorkerDump,
-		redirectDumpFunc(pachClient.Ctx()),
-		collectDump,
+		s.collectPachdDumpFunc(pachClient, request.Limit, cs),
+		s.collectPipelineDumpFunc(pachClient, request.Limit, cs),
+		s.collectWorkerDumpFunc(cs),
+		redirectDumpFunc(pachClient.Ctx(), cs),
+		collectDumpFunc(cs),
+		s.appLogsFunc(cs),
 	)
 }
 
-func (s *debugServer) collectPachdDumpFunc(pachClient *client.APIClient, limit int64) collectFunc {
+func (s *debugServer) collectPachdDumpFunc(pachClient *client.APIClient, limit int64, cs collectors) collectFunc {
 	return func(tw *tar.Writer, prefix ...string) error {
 		// Collect input repos.
-		if err := s.collectInputRepos(tw, pachClient, limit); err != nil {
-			return err
+		if cs.has(debug.Collector_COMMITS) {
+			if err := s.collectInputRepos(tw, pachClient, limit); err != nil {
+				return err
+			}
 		}
 		// Collect the pachd version.
-		if err := s.collectPachdVersion(tw, pachClient, prefix...); err != nil {
-			return err
+		if cs.has(debug.Collector_VERSION) {
+			if err := s.collectPachdVersion(tw, pachClient, prefix...); err != nil {
+				return err
+			}
 		}
 		// Collect the pachd describe output.
-		if err := s.collectDescribe(tw, s.name, prefix...); err != nil {
-			return err
-		}
-		// Collect the pachd container logs.
-		if err := s.collectLogs(tw, s.name, "pachd", prefix...); err != nil {
-			return err
+		if cs.has(debug.Collector_KUBERNETES) {
+			if err := s.collectDescribe(tw, s.name, prefix...); err != nil {
+				return err
+			}
 		}
-		// Collect the pachd container logs from loki.
-		if err := s.collectLogsLoki(tw, s.name, "pachd", prefix...); err != nil {
-			return err
+		if cs.has(debug.Collector_LOGS) {
+			// Collect the pachd container logs.
+			if err := s.collectLogs(tw, s.name, "pachd", prefix...); err != nil {
+				return err
+			}
+			// Collect the pachd container logs from loki.
+			if err := s.collectLogsLoki(tw, s.name, "pachd", prefix...); err != nil {
+				return err
+			}
 		}
 		// Collect the pachd container dump.
-		return collectDump(tw, prefix...)
+		return collectDumpFunc(cs)(tw, prefix...)
 	}
 }
 
@@ -631,36 +657,47 @@ func (s *debugServer) collectLogsLoki(tw *tar.Writer, pod, container string, pre
 	}, prefix...)
 }
 
-func collectDump(tw *tar.Writer, prefix ...string) error {
-	if err := collectProfile(tw, &debug.Profile{Name: "goroutine"}, prefix...); err != nil {
-		return err
+func collectDumpFunc(cs collectors) collectFunc {
+	return func(tw *tar.Writer, prefix ...string) error {
+		if !cs.has(debug.Collector_PROFILES) {
+			return nil
+		}
+		if err := collectProfile(tw, &debug.Profile{Name: "goroutine"}, prefix...); err != nil {
+			return err
+		}
+		return collectProfile(tw, &debug.Profile{Name: "heap"}, prefix...)
 	}
-	return collectProfile(tw, &debug.Profile{Name: "heap"}, prefix...)
 }
 
-func (s *debugServer) collectPipelineDumpFunc(pachClient *client.APIClient, limit int64) collectPipelineFunc {
+func (s *debugServer) collectPipelineDumpFunc(pachClient *client.APIClient, limit int64, cs collectors) collectPipelineFunc {
 	return func(tw *tar.Writer, pipelineInfo *pps.PipelineInfo, prefix ...string) error {
-		if err := collectDebugFile(tw, "spec", "json", func(w io.Writer) error {
-			fullPipelineInfos, err := pachClient.ListPipelineHistory(pipelineInfo.Pipeline.Name, -1, true)
-			if err != nil {
-				return err
-			}
-			for _, fullPipelineInfo := range fullPipelineInfos {
-				if err := s.marshaller.Marshal(w, fullPipelineInfo); err != nil {
-					return errors.EnsureStack(err)
+		if cs.has(debug.Collector_SPECS) {
+			if err := collectDebugFile(tw, "spec", "json", func(w io.Writer) error {
+				fullPipelineInfos, err := pachClient.ListPipelineHistory(pipelineInfo.Pipeline.Name, -1, true)
+				if err != nil {
+					return err
 				}
+				for _, fullPipelineInfo := range fullPipelineInfos {
+					if err := s.marshaller.Marshal(w, fullPipelineInfo); err != nil {
+						return errors.EnsureStack(err)
+					}
+				}
+				return nil
+			}, prefix...); err != nil {
+				return err
 			}
-			return nil
-		}, prefix...); err != nil {
-			return err
 		}
-		if err := s.collectCommits(tw, pachClient, client.NewRepo(pipelineInfo.Pipeline.Name), limit, prefix...); err != nil {
-			return err
+		if cs.has(debug.Collector_COMMITS) {
+			if err := s.collectCommits(tw, pachClient, client.NewRepo(pipelineInfo.Pipeline.Name), limit, prefix...); err != nil {
+				return err
+			}
 		}
-		if err := s.collectJobs(tw, pachClient, pipelineInfo.Pipeline.Name, limit, prefix...); err != nil {
-			return err
+		if cs.has(debug.Collector_JOBS) {
+			if err := s.collectJobs(tw, pachClient, pipelineInfo.Pipeline.Name, limit, prefix...); err != nil {
+				return err
+			}
 		}
-		if os.Getenv(s.env.Config().LokiHostVar) != "" {
+		if cs.has(debug.Collector_LOGS) && os.Getenv(s.env.Config().LokiHostVar) != "" {
 			if err := s.forEachWorkerLoki(pipelineInfo, func(pod string) error {
 				workerPrefix := join(podPrefix, pod)
 				if len(prefix) > 0 {
@@ -813,27 +850,34 @@ func (s *debugServer) collectJobs(tw *tar.Writer, pachClient *client.APIClient,
 	return collectGraph(tw, "jobs-chart.png", "number of jobs", []chart.Series{download, process, upload}, prefix...)
 }
 
-func (s *debugServer) collectWorkerDump(tw *tar.Writer, pod *v1.Pod, prefix ...string) error {
-	// Collect the worker describe output.
-	if err := s.collectDescribe(tw, pod.Name, prefix...); err != nil {
-		return err
-	}
-	// Collect the worker user and storage container logs.
-	userPrefix := client.PPSWorkerUserContainerName
-	sidecarPrefix := client.PPSWorkerSidecarContainerName
-	if len(prefix) > 0 {
-		userPrefix = join(prefix[0], userPrefix)
-		sidecarPrefix = join(prefix[0], sidecarPrefix)
-	}
-	if err := s.collectLogs(tw, pod.Name, client.PPSWorkerUserContainerName, userPrefix); err != nil {
-		return err
+func (s *debugServer) collectWorkerDumpFunc(cs collectors) collectWorkerFunc {
+	return func(tw *tar.Writer, pod *v1.Pod, prefix ...string) error {
+		// Collect the worker describe output.
+		if cs.has(debug.Collector_KUBERNETES) {
+			if err := s.collectDescribe(tw, pod.Name, prefix...); err != nil {
+				return err
+			}
+		}
+		if !cs.has(debug.Collector_LOGS) {
+			return nil
+		}
+		// Collect the worker user and storage container logs.
+		userPrefix := client.PPSWorkerUserContainerName
+		sidecarPrefix := client.PPSWorkerSidecarContainerName
+		if len(prefix) > 0 {
+			userPrefix = join(prefix[0], userPrefix)
+			sidecarPrefix = join(prefix[0], sidecarPrefix)
+		}
+		if err := s.collectLogs(tw, pod.Name, client.PPSWorkerUserContainerName, userPrefix); err != nil {
+			return err
+		}
+		return s.collectLogs(tw, pod.Name, client.PPSWorkerSidecarContainerName, sidecarPrefix)
 	}
-	return s.collectLogs(tw, pod.Name, client.PPSWorkerSidecarContainerName, sidecarPrefix)
 }
 
-func redirectDumpFunc(ctx context.Context) redirectFunc {
+func redirectDumpFunc(ctx context.Context, cs collectors) redirectFunc {
 	return func(c debug.DebugClient, filter *debug.Filter) (io.Reader, error) {
-		dumpC, err := c.Dump(ctx, &debug.DumpRequest{Filter: filter})
+		dumpC, err := c.Dump(ctx, &debug.DumpRequest{Filter: filter, Collectors: cs.list()})
 		if err != nil {
 			return nil, errors.EnsureStack(err)
 		}
@@ -42,7 +42,15 @@ func collectDebugFile(tw *tar.Writer, name, ext string, cb func(io.Writer) error
 		}
 	}()
 	return fsutil.WithTmpFile("pachyderm_debug", func(f *os.File) error {
-		if err := cb(f); err != nil {
+		if ext == "txt" || ext == "json" {
+			rw := newRedactWriter(f)
+			if err := cb(rw); err != nil {
+				return err
+			}
+			if err := rw.Close(); err != nil {
+				return err
+			}
+		} else if err := cb(f); err != nil {
 			return err
 		}
 		fullName := name
@@ -59,9 +67,14 @@ func writeErrorFile(tw *tar.Writer, err error, prefix ...string) error {
 		file = join(prefix[0], file)
 	}
 	return fsutil.WithTmpFile("pachyderm_debug", func(f *os.File) error {
-		if _, err := io.Copy(f, strings.NewReader(err.Error()+"\n")); err != nil {
+		// errors may hold secrets such as the credentials in URLs
+		rw := newRedactWriter(f)
+		if _, err := io.Copy(rw, strings.NewReader(err.Error()+"\n")); err != nil {
 			return errors.EnsureStack(err)
 		}
+		if err := rw.Close(); err != nil {
+			return err
+		}
 		return writeTarFile(tw, file, f)
 	})
 }
@@ -113,22 +126,12 @@ func copyTar(tw *tar.Writer, tr *tar.Reader, prefix ...string) error {
 		if len(prefix) > 0 {
 			hdr.Name = join(prefix[0], hdr.Name)
 		}
-		if err := fsutil.WithTmpFile("pachyderm_debug_copy_tar", func(f *os.File) error {
-			_, err = io.Copy(f, tr)
-			if err != nil {
-				return errors.EnsureStack(err)
-			}
-			_, err = f.Seek(0, 0)
-			if err != nil {
-				return errors.EnsureStack(err)
-			}
-			if err := tw.WriteHeader(hdr); err != nil {
-				return errors.EnsureStack(err)
-			}
-			_, err = io.Copy(tw, f)
+		// the entry's size is in its header, so it can be streamed as is
+		if err := tw.WriteHeader(hdr); err != nil {
+			return errors.EnsureStack(err)
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
 			return errors.EnsureStack(err)
-		}); err != nil {
-			return err
 		}
 	}
 }
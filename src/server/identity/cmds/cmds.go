@@ -167,6 +167,45 @@ func CreateIDPConnectorCmd() *cobra.Command {
 	return cmdutil.CreateAlias(createConnector, "idp create-connector")
 }
 
+// CheckIDPConnectorCmd returns a cobra.Command to check an IDP configuration
+// without saving it
+func CheckIDPConnectorCmd() *cobra.Command {
+	var file string
+	checkConnector := &cobra.Command{
+		Short: "Check an identity provider connector configuration without creating it.",
+		Long:  `Check an identity provider connector configuration without creating it. The connector is opened with the given configuration, which for OIDC connectors contacts the issuer, and any problems are reported.`,
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := newClient()
+			if err != nil {
+				return errors.Wrapf(err, "could not connect")
+			}
+			defer c.Close()
+
+			var connector connectorConfig
+			if err := deserializeYAML(file, &connector); err != nil {
+				return errors.Wrapf(err, "unable to parse config")
+			}
+
+			config, err := connector.toIDPConnector()
+			if err != nil {
+				return err
+			}
+
+			resp, err := c.CheckIdentityProvider(c.Ctx(), &identity.CheckIdentityProviderRequest{Connector: config})
+			if err != nil {
+				return grpcutil.ScrubGRPC(err)
+			}
+			for _, warning := range resp.Warnings {
+				fmt.Fprintf(os.Stderr, "WARNING: %s\n", warning)
+			}
+			fmt.Println("Connector configuration is valid")
+			return nil
+		}),
+	}
+	checkConnector.PersistentFlags().StringVar(&file, "config", "-", `The file to read the YAML-encoded connector configuration from, or '-' for stdin.`)
+	return cmdutil.CreateAlias(checkConnector, "idp check-connector")
+}
+
 // UpdateIDPConnectorCmd returns a cobra.Command to create a new IDP integration
 func UpdateIDPConnectorCmd() *cobra.Command {
 	var file string
@@ -425,6 +464,7 @@ func Cmds() []*cobra.Command {
 	commands = append(commands, GetIdentityServerConfigCmd())
 	commands = append(commands, SetIdentityServerConfigCmd())
 	commands = append(commands, CreateIDPConnectorCmd())
+	commands = append(commands, CheckIDPConnectorCmd())
 	commands = append(commands, GetIDPConnectorCmd())
 	commands = append(commands, UpdateIDPConnectorCmd())
 	commands = append(commands, DeleteIDPConnectorCmd())
@@ -2,7 +2,10 @@ package server
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 
 	logrus "github.com/sirupsen/logrus"
 
@@ -68,6 +71,38 @@ func (a *apiServer) CreateIDPConnector(ctx context.Context, req *identity.Create
 	return &identity.CreateIDPConnectorResponse{}, nil
 }
 
+func (a *apiServer) CheckIdentityProvider(ctx context.Context, req *identity.CheckIdentityProviderRequest) (resp *identity.CheckIdentityProviderResponse, retErr error) {
+	if req.Connector == nil || req.Connector.Type == "" {
+		return nil, errors.New("no type specified")
+	}
+	id := req.Connector.Id
+	if id == "" {
+		id = "check"
+	}
+	// opening the connector validates its configuration, and for some types,
+	// such as oidc, fetches the provider's discovery document
+	if err := a.api.validateConnector(id, req.Connector.Type, []byte(req.Connector.JsonConfig)); err != nil {
+		return nil, err
+	}
+	resp = &identity.CheckIdentityProviderResponse{}
+	var config struct {
+		RedirectURI string `json:"redirectURI"`
+	}
+	if err := json.Unmarshal([]byte(req.Connector.JsonConfig), &config); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	serverConfig, err := a.GetIdentityServerConfig(ctx, &identity.GetIdentityServerConfigRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if issuer := serverConfig.Config.Issuer; issuer == "" {
+		resp.Warnings = append(resp.Warnings, "the identity server's issuer isn't set, so the redirect URI can't be checked")
+	} else if callback := strings.TrimSuffix(issuer, "/") + "/callback"; config.RedirectURI != "" && config.RedirectURI != callback {
+		resp.Warnings = append(resp.Warnings, fmt.Sprintf("the redirect URI %q should be %q, the identity server's callback", config.RedirectURI, callback))
+	}
+	return resp, nil
+}
+
 func (a *apiServer) GetIDPConnector(ctx context.Context, req *identity.GetIDPConnectorRequest) (resp *identity.GetIDPConnectorResponse, retErr error) {
 	c, err := a.api.getConnector(req.Id)
 	if err != nil {
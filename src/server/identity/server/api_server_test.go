@@ -299,3 +299,47 @@ func TestShortenIDTokenExpiry(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, time.Until(*whoAmIResp.Expiration) < time.Hour)
 }
+
+// TestCheckIdentityProvider tests that connectors are validated without being created
+func TestCheckIdentityProvider(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	c, _ := minikubetestenv.AcquireCluster(t)
+	adminClient := tu.AuthenticatedPachClient(t, c, auth.RootUser)
+
+	_, err := adminClient.SetIdentityServerConfig(adminClient.Ctx(), &identity.SetIdentityServerConfigRequest{
+		Config: &identity.IdentityServerConfig{
+			Issuer: "http://localhost:30658/dex",
+		},
+	})
+	require.NoError(t, err)
+
+	// an invalid config is an error
+	_, err = adminClient.CheckIdentityProvider(adminClient.Ctx(), &identity.CheckIdentityProviderRequest{
+		Connector: &identity.IDPConnector{Type: "mockPassword", JsonConfig: `{"username": "test"`},
+	})
+	require.YesError(t, err)
+
+	// an unreachable issuer is an error
+	_, err = adminClient.CheckIdentityProvider(adminClient.Ctx(), &identity.CheckIdentityProviderRequest{
+		Connector: &identity.IDPConnector{Type: "oidc", JsonConfig: `{"issuer": "http://unreachable.invalid", "clientID": "pachyderm"}`},
+	})
+	require.YesError(t, err)
+
+	// a mismatched redirect URI is a warning
+	resp, err := adminClient.CheckIdentityProvider(adminClient.Ctx(), &identity.CheckIdentityProviderRequest{
+		Connector: &identity.IDPConnector{
+			Type:       "github",
+			JsonConfig: `{"clientID": "pachyderm", "clientSecret": "secret", "redirectURI": "http://localhost:30658/callback"}`,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(resp.Warnings))
+	require.Matches(t, "http://localhost:30658/dex/callback", resp.Warnings[0])
+
+	// nothing was created
+	connectors, err := adminClient.ListIDPConnectors(adminClient.Ctx(), &identity.ListIDPConnectorsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(connectors.Connectors))
+}
@@ -8157,6 +8157,70 @@ func TestDatumTries(t *testing.T) {
 	require.Equal(t, tries, observedTries)
 }
 
+func TestDatumCache(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	t.Parallel()
+	c, _ := minikubetestenv.AcquireCluster(t)
+
+	dataRepo := tu.UniqueString("TestDatumCache_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	commit, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	require.NoError(t, c.PutFile(commit, "a", strings.NewReader("a")))
+	require.NoError(t, c.PutFile(commit, "b", strings.NewReader("b")))
+	require.NoError(t, c.FinishCommit(dataRepo, "master", ""))
+
+	// The pipeline's output differs each time its code runs, so the output
+	// only stays the same if it's taken from the cache.
+	pipeline := tu.UniqueString("TestDatumCache")
+	createPipeline := func(update bool) {
+		_, err := c.PpsAPIClient.CreatePipeline(
+			context.Background(),
+			&pps.CreatePipelineRequest{
+				Pipeline: client.NewPipeline(pipeline),
+				Transform: &pps.Transform{
+					Cmd:   []string{"bash"},
+					Stdin: []string{"for f in /pfs/*/*; do date +%s%N >/pfs/out/$(basename $f); done"},
+				},
+				Input:      client.NewPFSInput(dataRepo, "/*"),
+				DatumCache: true,
+				Update:     update,
+				Reprocess:  update,
+			})
+		require.NoError(t, err)
+	}
+	getOutput := func() string {
+		commitInfo, err := c.InspectCommit(pipeline, "master", "")
+		require.NoError(t, err)
+		_, err = c.WaitCommitSetAll(commitInfo.Commit.ID)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, c.GetFile(commitInfo.Commit, "a", &buf))
+		return buf.String()
+	}
+
+	createPipeline(false)
+	output := getOutput()
+	info, err := c.InspectDatumCache(pipeline)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), info.Entries)
+
+	// Reprocessing the datums reuses their cached output.
+	createPipeline(true)
+	require.Equal(t, output, getOutput())
+
+	// Once the cache is cleared, the pipeline's code runs again.
+	require.NoError(t, c.ClearDatumCache(pipeline))
+	info, err = c.InspectDatumCache(pipeline)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), info.Entries)
+	createPipeline(true)
+	require.NotEqual(t, output, getOutput())
+}
+
 func TestInspectJob(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
@@ -9012,6 +9076,54 @@ func TestSecrets(t *testing.T) {
 	require.YesError(t, err)
 }
 
+func TestPipelineSecrets(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	t.Parallel()
+	c, _ := minikubetestenv.AcquireCluster(t)
+	repo := tu.UniqueString(t.Name() + "-data")
+	pipeline := tu.UniqueString(t.Name())
+	other := tu.UniqueString(t.Name() + "-other")
+	secret := tu.UniqueString(strings.ToLower(t.Name() + "-secret"))
+	require.NoError(t, c.CreateRepo(repo))
+	_, err := c.PpsAPIClient.CreatePipeline(c.Ctx(), basicPipelineReq(pipeline, repo))
+	require.NoError(t, err)
+
+	file := func(value string) []byte {
+		return []byte(fmt.Sprintf(`{"kind": "Secret", "apiVersion": "v1", "metadata": {"name": %q}, "stringData": {"mykey": %q}}`, secret, value))
+	}
+	require.NoError(t, c.CreatePipelineSecret(pipeline, file("v1"), false))
+	require.YesError(t, c.CreatePipelineSecret(pipeline, file("v2"), false))
+	require.NoError(t, c.CreatePipelineSecret(pipeline, file("v2"), true))
+	// the secret can't be replaced by another scope
+	require.YesError(t, c.CreateSecret(file("v3")))
+
+	secretInfos, err := c.ListPipelineSecret(pipeline)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(secretInfos))
+	require.Equal(t, secret, secretInfos[0].Secret.Name)
+	require.Equal(t, pipeline, secretInfos[0].Pipeline.Name)
+
+	// only the pipeline the secret is scoped to can use it
+	req := basicPipelineReq(other, repo)
+	req.Transform.Secrets = []*pps.SecretMount{{Name: secret, Key: "mykey", EnvVar: "MY_SECRET"}}
+	_, err = c.PpsAPIClient.CreatePipeline(c.Ctx(), req)
+	require.YesError(t, err)
+	require.Matches(t, "scoped to pipeline", err.Error())
+	req = basicPipelineReq(pipeline, repo)
+	req.Transform.Secrets = []*pps.SecretMount{{Name: secret, Key: "mykey", EnvVar: "MY_SECRET"}}
+	req.Update = true
+	_, err = c.PpsAPIClient.CreatePipeline(c.Ctx(), req)
+	require.NoError(t, err)
+
+	require.NoError(t, c.DeleteSecret(secret))
+	secretInfos, err = c.ListPipelineSecret(pipeline)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(secretInfos))
+}
+
 // Test that an unauthenticated user can't call secrets APIS
 func TestSecretsUnauthenticated(t *testing.T) {
 	if testing.Short() {
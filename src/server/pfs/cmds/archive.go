@@ -0,0 +1,117 @@
+package cmds
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/cmdutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/repoarchive"
+	"github.com/pachyderm/pachyderm/v2/src/server/cmd/pachctl/shell"
+)
+
+func archiveCmds() []*cobra.Command {
+	var commands []*cobra.Command
+
+	var outputPath string
+	exportRepo := &cobra.Command{
+		Use:   "{{alias}} <repo>",
+		Short: "Export a repo as a portable archive.",
+		Long: `Export a repo as a portable archive.
+
+The archive is a tar stream containing a manifest of the repo's commits and
+branches followed by the files changed in each commit. It can be restored on
+any cluster with 'pachctl import repo'. Open commits are not exported.`,
+		Example: `
+# export the repo "images" to a file
+$ {{alias}} images -o images.tar`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) (retErr error) {
+			c, err := newClient("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			var w io.Writer = os.Stdout
+			if outputPath != "" {
+				f, err := os.Create(outputPath)
+				if err != nil {
+					return errors.EnsureStack(err)
+				}
+				defer func() {
+					if err := f.Close(); err != nil && retErr == nil {
+						retErr = errors.EnsureStack(err)
+					}
+				}()
+				w = f
+			}
+			bw := bufio.NewWriter(w)
+			if err := repoarchive.Export(c, args[0], bw); err != nil {
+				return grpcutil.ScrubGRPC(err)
+			}
+			return errors.EnsureStack(bw.Flush())
+		}),
+	}
+	exportRepo.Flags().StringVarP(&outputPath, "output", "o", "", "The path to write the archive to, defaults to stdout.")
+	shell.RegisterCompletionFunc(exportRepo, shell.RepoCompletion)
+	commands = append(commands, cmdutil.CreateAlias(exportRepo, "export repo"))
+
+	var inputPath string
+	var repoName string
+	importRepo := &cobra.Command{
+		Use:   "{{alias}}",
+		Short: "Import a repo from a portable archive.",
+		Long: `Import a repo from an archive created with 'pachctl export repo'.
+
+The repo is created if it doesn't exist. Commit IDs are assigned by the
+cluster, so a mapping from the archived commit IDs to the imported commit IDs
+is printed. Branch provenance is only restored if the upstream branches exist.`,
+		Example: `
+# import the archive in images.tar as the repo "images-copy"
+$ {{alias}} -f images.tar --repo images-copy`,
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := newClient("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			var r io.Reader = os.Stdin
+			if inputPath != "" && inputPath != "-" {
+				f, err := os.Open(inputPath)
+				if err != nil {
+					return errors.EnsureStack(err)
+				}
+				defer f.Close()
+				r = f
+			} else {
+				cmdutil.PrintStdinReminder()
+			}
+			res, err := repoarchive.Import(c, repoName, bufio.NewReader(r))
+			if err != nil {
+				return grpcutil.ScrubGRPC(err)
+			}
+			oldIDs := make([]string, 0, len(res.Commits))
+			for oldID := range res.Commits {
+				oldIDs = append(oldIDs, oldID)
+			}
+			sort.Strings(oldIDs)
+			for _, oldID := range oldIDs {
+				fmt.Printf("%s -> %s\n", oldID, res.Commits[oldID])
+			}
+			for _, p := range res.SkippedProvenance {
+				fmt.Fprintf(os.Stderr, "skipped provenance on missing branch %s\n", p)
+			}
+			return nil
+		}),
+	}
+	importRepo.Flags().StringVarP(&inputPath, "file", "f", "-", "The archive to import, defaults to stdin.")
+	importRepo.Flags().StringVar(&repoName, "repo", "", "The name of the repo to import into, defaults to the archived repo's name.")
+	commands = append(commands, cmdutil.CreateAlias(importRepo, "import repo"))
+
+	return commands
+}
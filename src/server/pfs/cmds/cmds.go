@@ -3,6 +3,7 @@ package cmds
 import (
 	"archive/tar"
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -13,8 +14,10 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	prompt "github.com/c-bata/go-prompt"
+	units "github.com/docker/go-units"
 	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
 	"github.com/mattn/go-isatty"
@@ -57,6 +60,9 @@ func Cmds() []*cobra.Command {
 	var noPager bool
 	pagerFlags := cmdutil.PagerFlags(&noPager)
 
+	var notify bool
+	notifyFlags := cmdutil.NotifyFlags(&notify)
+
 	repoDocs := &cobra.Command{
 		Short: "Docs for repos.",
 		Long: `Repos, short for repository, are the top level data objects in Pachyderm.
@@ -67,6 +73,7 @@ or type (e.g. csv, binary, images, etc).`,
 	commands = append(commands, cmdutil.CreateDocsAlias(repoDocs, "repo", " repo$"))
 
 	var description string
+	var project string
 	createRepo := &cobra.Command{
 		Use:   "{{alias}} <repo>",
 		Short: "Create a new repo.",
@@ -84,6 +91,7 @@ or type (e.g. csv, binary, images, etc).`,
 					&pfs.CreateRepoRequest{
 						Repo:        client.NewRepo(args[0]),
 						Description: description,
+						Project:     projectFlag(project),
 					},
 				)
 				return errors.EnsureStack(err)
@@ -92,6 +100,7 @@ or type (e.g. csv, binary, images, etc).`,
 		}),
 	}
 	createRepo.Flags().StringVarP(&description, "description", "d", "", "A description of the repo.")
+	createRepo.Flags().StringVar(&project, "project", "", "The project to create the repo in, instead of the default project.")
 	commands = append(commands, cmdutil.CreateAlias(createRepo, "create repo"))
 
 	updateRepo := &cobra.Command{
@@ -159,9 +168,22 @@ or type (e.g. csv, binary, images, etc).`,
 
 	var all bool
 	var repoType string
+	var number int64
+	var reverse bool
+	var after string
+	var timeAfter, timeBefore string
 	listRepo := &cobra.Command{
 		Short: "Return a list of repos.",
 		Long:  "Return a list of repos. By default, hide system repos like pipeline metadata",
+		Example: `
+# return the 20 newest repos
+$ {{alias}} -n 20
+
+# return the next 20 repos, after the last repo of the previous page
+$ {{alias}} -n 20 --after <repo>
+
+# return the repos created in the last day
+$ {{alias}} --created-after 24h`,
 		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
 			if all && repoType != "" {
 				return errors.Errorf("cannot set a repo type with --all")
@@ -175,7 +197,24 @@ or type (e.g. csv, binary, images, etc).`,
 			if repoType == "" && !all {
 				repoType = pfs.UserRepoType // default to user
 			}
-			repoInfos, err := c.ListRepoByType(repoType)
+			request := &pfs.ListRepoRequest{
+				Type:    repoType,
+				Number:  number,
+				Reverse: reverse,
+			}
+			if project != "" {
+				request.Project = client.NewProject(project)
+			}
+			if after != "" {
+				request.Cursor = cmdutil.ParseRepo(after)
+			}
+			if request.CreatedAfter, err = cmdutil.ParseTimestamp(timeAfter); err != nil {
+				return err
+			}
+			if request.CreatedBefore, err = cmdutil.ParseTimestamp(timeBefore); err != nil {
+				return err
+			}
+			repoInfos, err := listRepoInfos(c, request)
 			if err != nil {
 				return err
 			}
@@ -206,6 +245,11 @@ or type (e.g. csv, binary, images, etc).`,
 	listRepo.Flags().AddFlagSet(timestampFlags)
 	listRepo.Flags().BoolVar(&all, "all", false, "include system repos of all types")
 	listRepo.Flags().StringVar(&repoType, "type", "", "only include repos of the given type")
+	listRepo.Flags().StringVar(&project, "project", "", "only include repos in the given project")
+	listRepo.Flags().Int64VarP(&number, "number", "n", 0, "list only this many repos; if set to zero, list all repos")
+	listRepo.Flags().BoolVar(&reverse, "reverse", false, "list repos from oldest to newest")
+	listRepo.Flags().StringVar(&after, "after", "", "list the repos after this one, which is the last repo of the previous page")
+	listRepo.Flags().AddFlagSet(cmdutil.TimeRangeFlags("created", &timeAfter, &timeBefore))
 	commands = append(commands, cmdutil.CreateAlias(listRepo, "list repo"))
 
 	var force bool
@@ -248,6 +292,172 @@ or type (e.g. csv, binary, images, etc).`,
 	shell.RegisterCompletionFunc(deleteRepo, shell.RepoCompletion)
 	commands = append(commands, cmdutil.CreateAlias(deleteRepo, "delete repo"))
 
+	var cordonReason string
+	cordonRepo := &cobra.Command{
+		Use:   "{{alias}} <repo>",
+		Short: "Stop new commits from being started in a repo.",
+		Long: "Stop new commits from being started in a repo, without deleting it, until it's uncordoned. " +
+			"Commits that are open can still be finished. The reason is included in the errors of the rejected commits.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			return c.CordonRepo(args[0], cordonReason)
+		}),
+	}
+	cordonRepo.Flags().StringVar(&cordonReason, "reason", "", "Why the repo is cordoned.")
+	shell.RegisterCompletionFunc(cordonRepo, shell.RepoCompletion)
+	commands = append(commands, cmdutil.CreateAlias(cordonRepo, "cordon repo"))
+
+	uncordonRepo := &cobra.Command{
+		Use:   "{{alias}} <repo>",
+		Short: "Let new commits be started in a cordoned repo again.",
+		Long:  "Let new commits be started in a cordoned repo again.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			return c.UncordonRepo(args[0])
+		}),
+	}
+	shell.RegisterCompletionFunc(uncordonRepo, shell.RepoCompletion)
+	commands = append(commands, cmdutil.CreateAlias(uncordonRepo, "uncordon repo"))
+
+	projectDocs := &cobra.Command{
+		Short: "Docs for projects.",
+		Long: `Projects group repos and pipelines, so that teams sharing a cluster can
+list just their own and be granted access to all of them at once.
+
+Roles bound on a project apply to every repo in it, and the projectOwner role
+allows creating repos and pipelines in it. Repos and pipelines that aren't
+created in a project are in the default project.`,
+	}
+	commands = append(commands, cmdutil.CreateDocsAlias(projectDocs, "project", " project$"))
+
+	createProject := &cobra.Command{
+		Use:   "{{alias}} <project>",
+		Short: "Create a new project.",
+		Long:  "Create a new project. The caller becomes the project's owner.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			return c.CreateProject(args[0], description)
+		}),
+	}
+	createProject.Flags().StringVarP(&description, "description", "d", "", "A description of the project.")
+	commands = append(commands, cmdutil.CreateAlias(createProject, "create project"))
+
+	updateProject := &cobra.Command{
+		Use:   "{{alias}} <project>",
+		Short: "Update a project.",
+		Long:  "Update a project's description.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			_, err = c.PfsAPIClient.CreateProject(
+				c.Ctx(),
+				&pfs.CreateProjectRequest{
+					Project:     client.NewProject(args[0]),
+					Description: description,
+					Update:      true,
+				},
+			)
+			return grpcutil.ScrubGRPC(err)
+		}),
+	}
+	updateProject.Flags().StringVarP(&description, "description", "d", "", "A description of the project.")
+	commands = append(commands, cmdutil.CreateAlias(updateProject, "update project"))
+
+	inspectProject := &cobra.Command{
+		Use:   "{{alias}} <project>",
+		Short: "Return info about a project.",
+		Long:  "Return info about a project, and the repos in it.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			projectInfo, err := c.InspectProject(args[0])
+			if err != nil {
+				return err
+			}
+			if raw {
+				return errors.EnsureStack(cmdutil.Encoder(output, os.Stdout).EncodeProto(projectInfo))
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			repoInfos, err := c.ListProjectRepo(args[0])
+			if err != nil {
+				return err
+			}
+			return pretty.PrintDetailedProjectInfo(projectInfo, repoInfos, fullTimestamps)
+		}),
+	}
+	inspectProject.Flags().AddFlagSet(outputFlags)
+	inspectProject.Flags().AddFlagSet(timestampFlags)
+	commands = append(commands, cmdutil.CreateAlias(inspectProject, "inspect project"))
+
+	listProject := &cobra.Command{
+		Short: "Return a list of projects.",
+		Long:  "Return a list of projects, including the default project.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			projectInfos, err := c.ListProject()
+			if err != nil {
+				return err
+			}
+			if raw {
+				encoder := cmdutil.Encoder(output, os.Stdout)
+				for _, projectInfo := range projectInfos {
+					if err := encoder.EncodeProto(projectInfo); err != nil {
+						return errors.EnsureStack(err)
+					}
+				}
+				return nil
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.ProjectHeader)
+			for _, projectInfo := range projectInfos {
+				pretty.PrintProjectInfo(writer, projectInfo, fullTimestamps)
+			}
+			return writer.Flush()
+		}),
+	}
+	listProject.Flags().AddFlagSet(outputFlags)
+	listProject.Flags().AddFlagSet(timestampFlags)
+	commands = append(commands, cmdutil.CreateAlias(listProject, "list project"))
+
+	deleteProject := &cobra.Command{
+		Use:   "{{alias}} <project>",
+		Short: "Delete a project.",
+		Long:  "Delete a project. Its repos and pipelines must be deleted first.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			return c.DeleteProject(args[0])
+		}),
+	}
+	commands = append(commands, cmdutil.CreateAlias(deleteProject, "delete project"))
+
 	commitDocs := &cobra.Command{
 		Short: "Docs for commits.",
 		Long: `Commits are atomic transactions on the content of a repo.
@@ -407,8 +617,8 @@ $ {{alias}} test@fork -p XXX`,
 	commands = append(commands, cmdutil.CreateAlias(inspectCommit, "inspect commit"))
 
 	var from string
-	var number int64
 	var originStr string
+	var commitState string
 	var expand bool
 	listCommit := &cobra.Command{
 		Use:   "{{alias}} [<commit-id>|<repo>[@<branch-or-commit>]]",
@@ -431,7 +641,13 @@ $ {{alias}} foo@master
 $ {{alias}} foo@master -n 20
 
 # return commits in repo "foo" on branch "master" since commit XXX
-$ {{alias}} foo@master --from XXX`,
+$ {{alias}} foo@master --from XXX
+
+# return the next 20 commits in repo "foo" after commit XXX
+$ {{alias}} foo -n 20 --after XXX
+
+# return the finished commits in repo "foo" started in the last hour
+$ {{alias}} foo --state finished --started-after 1h`,
 		Run: cmdutil.RunBoundedArgs(0, 1, func(args []string) (retErr error) {
 			c, err := client.NewOnUserMachine("user")
 			if err != nil {
@@ -444,11 +660,14 @@ $ {{alias}} foo@master --from XXX`,
 			} else if all && originStr != "" {
 				return errors.New("cannot specify both --all and --origin")
 			}
+			filtered := reverse || after != "" || commitState != "" || timeAfter != "" || timeBefore != ""
 
 			if len(args) == 0 {
 				// Outputting all commitsets
 				if originStr != "" {
 					return errors.Errorf("cannot specify --origin when listing all commits")
+				} else if filtered {
+					return errors.Errorf("cannot specify --reverse, --after, --state, --started-after or --started-before when listing all commits")
 				} else if from != "" {
 					return errors.Errorf("cannot specify --from when listing all commits")
 				}
@@ -530,6 +749,8 @@ $ {{alias}} foo@master --from XXX`,
 					return errors.Errorf("cannot specify --all when listing subcommits")
 				} else if originStr != "" {
 					return errors.Errorf("cannot specify --origin when listing subcommits")
+				} else if filtered {
+					return errors.Errorf("cannot specify --reverse, --after, --state, --started-after or --started-before when listing subcommits")
 				}
 
 				commitInfos, err := c.InspectCommitSet(args[0])
@@ -582,14 +803,31 @@ $ {{alias}} foo@master --from XXX`,
 					return err
 				}
 
-				listClient, err := c.PfsAPIClient.ListCommit(c.Ctx(), &pfs.ListCommitRequest{
+				state, err := parseCommitState(commitState)
+				if err != nil {
+					return err
+				}
+
+				request := &pfs.ListCommitRequest{
 					Repo:       repo,
 					From:       fromCommit,
 					To:         toCommit,
 					Number:     number,
+					Reverse:    reverse,
 					All:        all,
 					OriginKind: origin,
-				})
+					State:      state,
+				}
+				if after != "" {
+					request.Cursor = repo.NewCommit("", after)
+				}
+				if request.StartedAfter, err = cmdutil.ParseTimestamp(timeAfter); err != nil {
+					return err
+				}
+				if request.StartedBefore, err = cmdutil.ParseTimestamp(timeBefore); err != nil {
+					return err
+				}
+				listClient, err := c.PfsAPIClient.ListCommit(c.Ctx(), request)
 				if err != nil {
 					return grpcutil.ScrubGRPC(err)
 				}
@@ -617,6 +855,10 @@ $ {{alias}} foo@master --from XXX`,
 	listCommit.Flags().BoolVar(&all, "all", false, "return all types of commits, including aliases")
 	listCommit.Flags().BoolVarP(&expand, "expand", "x", false, "show one line for each sub-commmit and include more columns")
 	listCommit.Flags().StringVar(&originStr, "origin", "", "only return commits of a specific type")
+	listCommit.Flags().BoolVar(&reverse, "reverse", false, "list commits from oldest to newest")
+	listCommit.Flags().StringVar(&after, "after", "", "list the commits after this commit ID, which is the last commit of the previous page")
+	listCommit.Flags().StringVar(&commitState, "state", "", "only return commits that have reached this state: \"finishing\" or \"finished\"")
+	listCommit.Flags().AddFlagSet(cmdutil.TimeRangeFlags("started", &timeAfter, &timeBefore))
 	listCommit.Flags().AddFlagSet(outputFlags)
 	listCommit.Flags().AddFlagSet(timestampFlags)
 	shell.RegisterCompletionFunc(listCommit, shell.RepoCompletion)
@@ -629,7 +871,7 @@ $ {{alias}} foo@master --from XXX`,
 		Example: `
 # wait for the commit foo@XXX to finish and return it
 $ {{alias}} foo@XXX -b bar@baz`,
-		Run: cmdutil.RunFixedArgs(1, func(args []string) (retErr error) {
+		Run: cmdutil.RunFixedArgs(1, cmdutil.Notify(&notify, func(args []string) (retErr error) {
 			commit, err := cmdutil.ParseCommit(args[0])
 			if err != nil {
 				return err
@@ -657,10 +899,11 @@ $ {{alias}} foo@XXX -b bar@baz`,
 				FullTimestamps: fullTimestamps,
 			}
 			return pretty.PrintDetailedCommitInfo(os.Stdout, ci)
-		}),
+		})),
 	}
 	waitCommit.Flags().AddFlagSet(outputFlags)
 	waitCommit.Flags().AddFlagSet(timestampFlags)
+	waitCommit.Flags().AddFlagSet(notifyFlags)
 	commands = append(commands, cmdutil.CreateAlias(waitCommit, "wait commit"))
 
 	var newCommits bool
@@ -750,6 +993,91 @@ $ {{alias}} test@master --new`,
 	shell.RegisterCompletionFunc(subscribeCommit, shell.BranchCompletion)
 	commands = append(commands, cmdutil.CreateAlias(subscribeCommit, "subscribe commit"))
 
+	checkpointCommit := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch-or-commit>",
+		Short: "Mark the files written to an open commit so far.",
+		Long: `Mark the files written to an open commit so far. The files as of a checkpoint never change, so
+consumers subscribed to the commit's checkpoints can read them before the commit is finished, by
+reading the checkpoint's fileset from the __filesets__ repo.`,
+		Example: `
+# checkpoint the open commit on branch "master" of repo "test"
+$ {{alias}} test@master`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) (retErr error) {
+			commit, err := cmdutil.ParseCommit(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			checkpointInfo, err := c.CheckpointCommit(commit.Branch.Repo.Name, commit.Branch.Name, commit.ID)
+			if err != nil {
+				return err
+			}
+			if raw {
+				return errors.EnsureStack(cmdutil.Encoder(output, os.Stdout).EncodeProto(checkpointInfo))
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			w := tabwriter.NewWriter(os.Stdout, pretty.CheckpointHeader)
+			pretty.PrintCheckpointInfo(w, checkpointInfo, fullTimestamps)
+			return w.Flush()
+		}),
+	}
+	checkpointCommit.Flags().AddFlagSet(outputFlags)
+	checkpointCommit.Flags().AddFlagSet(timestampFlags)
+	shell.RegisterCompletionFunc(checkpointCommit, shell.BranchCompletion)
+	commands = append(commands, cmdutil.CreateAlias(checkpointCommit, "checkpoint commit"))
+
+	var fromCheckpoint int64
+	subscribeCheckpoint := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch-or-commit>",
+		Short: "Print the checkpoints of a commit as they are made.",
+		Long:  "Print the checkpoints of a commit as they are made, until the commit is finished. By default, the commit's existing checkpoints are returned first.",
+		Example: `
+# subscribe to the checkpoints of the open commit on branch "master" of repo "test"
+$ {{alias}} test@master
+
+# subscribe to the checkpoints of commit XXX in repo "test" made after checkpoint 3
+$ {{alias}} test@XXX --from 3`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) (retErr error) {
+			commit, err := cmdutil.ParseCommit(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			if raw {
+				encoder := cmdutil.Encoder(output, os.Stdout)
+				return c.SubscribeCheckpoint(commit, fromCheckpoint, func(checkpointInfo *pfs.CheckpointInfo) error {
+					return errors.EnsureStack(encoder.EncodeProto(checkpointInfo))
+				})
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			w := tabwriter.NewWriter(os.Stdout, pretty.CheckpointHeader)
+			defer func() {
+				if err := w.Flush(); retErr == nil {
+					retErr = err
+				}
+			}()
+			return c.SubscribeCheckpoint(commit, fromCheckpoint, func(checkpointInfo *pfs.CheckpointInfo) error {
+				pretty.PrintCheckpointInfo(w, checkpointInfo, fullTimestamps)
+				return nil
+			})
+		}),
+	}
+	subscribeCheckpoint.Flags().Int64Var(&fromCheckpoint, "from", 0, "subscribe to the checkpoints made after this checkpoint number")
+	subscribeCheckpoint.Flags().AddFlagSet(outputFlags)
+	subscribeCheckpoint.Flags().AddFlagSet(timestampFlags)
+	shell.RegisterCompletionFunc(subscribeCheckpoint, shell.BranchCompletion)
+	commands = append(commands, cmdutil.CreateAlias(subscribeCheckpoint, "subscribe checkpoint"))
+
 	squashCommit := &cobra.Command{
 		Use:   "{{alias}} <commit-id>",
 		Short: "Squash the sub-commits of a commit.",
@@ -972,87 +1300,844 @@ Any pachctl command that can take a commit, can take a branch name instead.`,
 	shell.RegisterCompletionFunc(deleteBranch, shell.BranchCompletion)
 	commands = append(commands, cmdutil.CreateAlias(deleteBranch, "delete branch"))
 
-	fileDocs := &cobra.Command{
-		Short: "Docs for files.",
-		Long: `Files are the lowest level data objects in Pachyderm.
+	schemaDocs := &cobra.Command{
+		Short: "Docs for schemas.",
+		Long: `Schemas validate the structured files in a repo.
 
-Files can be of any type (e.g. csv, binary, images, etc) or size and can be
-written to started (but not finished) commits with 'put file'. Files can be read
-from commits with 'get file'.`,
+A schema applies to the files in a repo that match a glob pattern. When a
+commit to the repo is finished, the files that match each of the repo's
+schemas are validated against it, and the commit finishes with an error if
+any of them don't conform. JSON Schema, Avro and protobuf schemas are
+supported.`,
 	}
-	commands = append(commands, cmdutil.CreateDocsAlias(fileDocs, "file", " file$"))
-
-	var filePaths []string
-	var inputFile string
-	var recursive bool
-	var parallelism int
-	var appendFile bool
-	var compress bool
-	var enableProgress bool
-	var fullPath bool
-	putFile := &cobra.Command{
-		Use:   "{{alias}} <repo>@<branch-or-commit>[:<path/to/file>]",
-		Short: "Put a file into the filesystem.",
-		Long:  "Put a file into the filesystem.  This command supports a number of ways to insert data into PFS.",
+	commands = append(commands, cmdutil.CreateDocsAlias(schemaDocs, "schema", " schema$"))
+
+	var schemaType string
+	var schemaFile string
+	var schemaMessage string
+	setSchema := &cobra.Command{
+		Use:   "{{alias}} <repo> <path-pattern>",
+		Short: "Set the schema for the files in a repo that match a pattern.",
+		Long: `Set the schema for the files in a repo that match a pattern, which they are
+validated against when commits to the repo are finished. Setting the schema
+for a pattern that already has one replaces it, and increments its version.
+
+JSON Schema files hold JSON values, or newline-delimited JSON values. Avro
+files are Avro object container files. Protobuf files each hold a single
+serialized message, and their schema is a FileDescriptorSet, as written by
+'protoc --include_imports --descriptor_set_out'.`,
 		Example: `
-# Put data from stdin at repo@branch:/path
-$ echo "data" | {{alias}} repo@branch:/path
-
-# Put a file from the local filesystem at repo@branch:/file
-$ {{alias}} repo@branch -f file
-
-# Put a file from the local filesystem at repo@branch:/path
-$ {{alias}} repo@branch:/path -f file
-
-# Put the contents of a directory at repo@branch:/dir/file
-$ {{alias}} -r repo@branch -f dir
-
-# Put the contents of a directory at repo@branch:/path/file (without /dir)
-$ {{alias}} -r repo@branch:/path -f dir
-
-# Put the data from a URL at repo@branch:/example.png
-$ {{alias}} repo@branch -f http://host/example.png
-
-# Put the data from a URL at repo@branch:/dir/example.png
-$ {{alias}} repo@branch:/dir -f http://host/example.png
-
-# Put the data from an S3 bucket at repo@branch:/s3_object
-$ {{alias}} repo@branch -r -f s3://my_bucket
-
-# Put several files or URLs that are listed in file.
-# Files and URLs should be newline delimited.
-$ {{alias}} repo@branch -i file
-
-# Put several files or URLs that are listed at URL.
-# NOTE this URL can reference local files, so it could cause you to put sensitive
-# files into your Pachyderm cluster.
-$ {{alias}} repo@branch -i http://host/path`,
-		Run: cmdutil.RunFixedArgs(1, func(args []string) (retErr error) {
-			if !enableProgress {
-				progress.Disable()
+# Validate the JSON files in repo "events" against a JSON Schema:
+$ {{alias}} events "/**.json" --type json -f event.schema.json
+
+# Validate the protobuf files in repo "events" as example.Event messages:
+$ {{alias}} events "/**.pb" --type protobuf -f event.desc --message example.Event`,
+		Run: cmdutil.RunFixedArgs(2, func(args []string) error {
+			schema := &pfs.Schema{Message: schemaMessage}
+			switch strings.ToLower(schemaType) {
+			case "json", "json_schema":
+				schema.Type = pfs.SchemaType_JSON_SCHEMA
+			case "avro":
+				schema.Type = pfs.SchemaType_AVRO
+			case "protobuf", "proto":
+				schema.Type = pfs.SchemaType_PROTOBUF
+			default:
+				return errors.Errorf("--type must be one of json, avro or protobuf, got %q", schemaType)
+			}
+			if schemaFile == "" {
+				return errors.New("--file (-f) must be set")
+			}
+			var err error
+			if schemaFile == "-" {
+				schema.Definition, err = ioutil.ReadAll(os.Stdin)
+			} else {
+				schema.Definition, err = ioutil.ReadFile(schemaFile)
 			}
-			file, err := cmdutil.ParseFile(args[0])
+			if err != nil {
+				return errors.EnsureStack(err)
+			}
+			c, err := client.NewOnUserMachine("user")
 			if err != nil {
 				return err
 			}
-			opts := []client.Option{client.WithMaxConcurrentStreams(parallelism)}
-			if compress {
-				opts = append(opts, client.WithGZIPCompression())
+			defer c.Close()
+			schemaInfo, err := c.SetSchema(args[0], args[1], schema)
+			if err != nil {
+				return err
 			}
-			c, err := newClient("user", opts...)
+			fmt.Printf("Set version %d of the schema for %s\n", schemaInfo.Version, schemaInfo.PathPattern)
+			return nil
+		}),
+	}
+	setSchema.Flags().StringVar(&schemaType, "type", "", "The type of the schema: json, avro or protobuf.")
+	setSchema.Flags().StringVarP(&schemaFile, "file", "f", "", "The file holding the schema, or - to read it from stdin.")
+	setSchema.Flags().StringVar(&schemaMessage, "message", "", "The fully-qualified name of the message protobuf files hold.")
+	shell.RegisterCompletionFunc(setSchema, shell.RepoCompletion)
+	commands = append(commands, cmdutil.CreateAlias(setSchema, "create schema"))
+	commands = append(commands, cmdutil.CreateAlias(setSchema, "update schema"))
+
+	listSchema := &cobra.Command{
+		Use:   "{{alias}} <repo>",
+		Short: "Return the schemas of a repo.",
+		Long:  "Return the schemas of a repo.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
 			if err != nil {
 				return err
 			}
 			defer c.Close()
-			defer progress.Wait()
-
-			// check whether or not the repo exists before attempting to upload
-			if _, err = c.InspectRepo(file.Commit.Branch.Repo.Name); err != nil {
-				if errutil.IsNotFoundError(err) {
-					return err
-				}
-				return errors.Wrapf(err, "could not inspect repo %s", err, file.Commit.Branch.Repo.Name)
-			}
+			schemaInfos, err := c.ListSchema(args[0])
+			if err != nil {
+				return err
+			}
+			if raw {
+				encoder := cmdutil.Encoder(output, os.Stdout)
+				for _, schemaInfo := range schemaInfos {
+					if err := encoder.EncodeProto(schemaInfo); err != nil {
+						return errors.EnsureStack(err)
+					}
+				}
+				return nil
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.SchemaHeader)
+			for _, schemaInfo := range schemaInfos {
+				pretty.PrintSchemaInfo(writer, schemaInfo, fullTimestamps)
+			}
+			return writer.Flush()
+		}),
+	}
+	listSchema.Flags().AddFlagSet(outputFlags)
+	listSchema.Flags().AddFlagSet(timestampFlags)
+	shell.RegisterCompletionFunc(listSchema, shell.RepoCompletion)
+	commands = append(commands, cmdutil.CreateAlias(listSchema, "list schema"))
+
+	deleteSchema := &cobra.Command{
+		Use:   "{{alias}} <repo> <path-pattern>",
+		Short: "Delete the schema for a pattern in a repo.",
+		Long:  "Delete the schema for a pattern in a repo. Commits that have already been validated against it are unaffected.",
+		Run: cmdutil.RunFixedArgs(2, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			return c.DeleteSchema(args[0], args[1])
+		}),
+	}
+	shell.RegisterCompletionFunc(deleteSchema, shell.RepoCompletion)
+	commands = append(commands, cmdutil.CreateAlias(deleteSchema, "delete schema"))
+
+	inspectCommitSchema := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch-or-commit>",
+		Short: "Return the schemas a commit was validated against.",
+		Long:  "Return the versions of its repo's schemas a commit was validated against, and whether its files conform to them. Waits for the commit to finish.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			commit, err := cmdutil.ParseCommit(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			commitSchemaInfo, err := c.InspectCommitSchema(commit.Branch.Repo.Name, commit.Branch.Name, commit.ID)
+			if err != nil {
+				return err
+			}
+			if raw {
+				return errors.EnsureStack(cmdutil.Encoder(output, os.Stdout).EncodeProto(commitSchemaInfo))
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.SchemaConformanceHeader)
+			for _, conformance := range commitSchemaInfo.Schemas {
+				pretty.PrintSchemaConformance(writer, conformance)
+			}
+			return writer.Flush()
+		}),
+	}
+	inspectCommitSchema.Flags().AddFlagSet(outputFlags)
+	shell.RegisterCompletionFunc(inspectCommitSchema, shell.BranchCompletion)
+	commands = append(commands, cmdutil.CreateAlias(inspectCommitSchema, "inspect commit-schema"))
+
+	retentionDocs := &cobra.Command{
+		Short: "Docs for retention policies.",
+		Long: `Retention policies limit how many commits are kept on the branches of a repo.
+
+A policy keeps the newest commits on a branch, the commits that finished
+recently, or both, and a commit is kept if either rule keeps it. The head of a
+branch is always kept. Commits that aren't kept are squashed: their data
+remains in the commits that follow them, but they no longer appear in the
+branch's history, and data that no commit references is garbage collected.
+
+A repo's policy applies to its branches that don't have a policy of their own.
+Policies are enforced periodically, and can be previewed or applied
+immediately with 'pachctl run retention'.`,
+	}
+	commands = append(commands, cmdutil.CreateDocsAlias(retentionDocs, "retention", " retention$"))
+
+	var keepCommits int64
+	var keepDuration time.Duration
+	setRetention := &cobra.Command{
+		Use:   "{{alias}} <repo>[@<branch>]",
+		Short: "Set the retention policy of a repo or branch.",
+		Long: `Set the retention policy of a branch, or of the branches of a repo that don't
+have a policy of their own. Setting a policy replaces the existing one.`,
+		Example: `
+# Keep the newest 100 commits on each branch of repo "logs":
+$ {{alias}} logs --keep-commits 100
+
+# Keep the commits on branch "master" of repo "logs" from the last 30 days,
+# and at least the newest 10:
+$ {{alias}} logs@master --keep-duration 720h --keep-commits 10`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			branch, err := cmdutil.ParseBranch(args[0])
+			if err != nil {
+				return err
+			}
+			policy := &pfs.RetentionPolicy{KeepCommits: keepCommits}
+			if keepDuration != 0 {
+				policy.KeepDuration = types.DurationProto(keepDuration)
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			_, err = c.SetRetentionPolicy(branch.Repo.Name, branch.Name, policy)
+			return err
+		}),
+	}
+	setRetention.Flags().Int64Var(&keepCommits, "keep-commits", 0, "Keep the newest commits on each branch, up to this number.")
+	setRetention.Flags().DurationVar(&keepDuration, "keep-duration", 0, "Keep the commits that finished less than this long ago, e.g. 720h for 30 days.")
+	shell.RegisterCompletionFunc(setRetention, shell.BranchCompletion)
+	commands = append(commands, cmdutil.CreateAlias(setRetention, "create retention"))
+	commands = append(commands, cmdutil.CreateAlias(setRetention, "update retention"))
+
+	listRetention := &cobra.Command{
+		Use:   "{{alias}} <repo>",
+		Short: "Return the retention policies of a repo.",
+		Long:  "Return the retention policies of a repo and its branches. The policy of the repo itself is shown with branch '*'.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			policyInfos, err := c.ListRetentionPolicy(args[0])
+			if err != nil {
+				return err
+			}
+			if raw {
+				encoder := cmdutil.Encoder(output, os.Stdout)
+				for _, policyInfo := range policyInfos {
+					if err := encoder.EncodeProto(policyInfo); err != nil {
+						return errors.EnsureStack(err)
+					}
+				}
+				return nil
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.RetentionPolicyHeader)
+			for _, policyInfo := range policyInfos {
+				pretty.PrintRetentionPolicyInfo(writer, policyInfo, fullTimestamps)
+			}
+			return writer.Flush()
+		}),
+	}
+	listRetention.Flags().AddFlagSet(outputFlags)
+	listRetention.Flags().AddFlagSet(timestampFlags)
+	shell.RegisterCompletionFunc(listRetention, shell.RepoCompletion)
+	commands = append(commands, cmdutil.CreateAlias(listRetention, "list retention"))
+
+	deleteRetention := &cobra.Command{
+		Use:   "{{alias}} <repo>[@<branch>]",
+		Short: "Delete the retention policy of a repo or branch.",
+		Long:  "Delete the retention policy of a repo or branch. A branch whose policy is deleted falls back to its repo's policy, if there is one.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			branch, err := cmdutil.ParseBranch(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			return c.DeleteRetentionPolicy(branch.Repo.Name, branch.Name)
+		}),
+	}
+	shell.RegisterCompletionFunc(deleteRetention, shell.BranchCompletion)
+	commands = append(commands, cmdutil.CreateAlias(deleteRetention, "delete retention"))
+
+	var dryRun bool
+	runRetention := &cobra.Command{
+		Use:   "{{alias}} <repo>",
+		Short: "Apply the retention policies of a repo.",
+		Long: `Squash the commits of a repo that aren't kept by its retention policies, without
+waiting for the policies to be enforced, and print them. Squashing a commit
+squashes the other commits in its commit set, such as the output commits of
+pipelines that processed it.`,
+		Example: `
+# Preview the commits of repo "logs" that would be squashed:
+$ {{alias}} logs --dry-run`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			expired, err := c.ApplyRetentionPolicy(args[0], dryRun)
+			if err != nil {
+				return err
+			}
+			for _, commit := range expired {
+				fmt.Println(commit)
+			}
+			return nil
+		}),
+	}
+	runRetention.Flags().BoolVar(&dryRun, "dry-run", false, "Print the commits that would be squashed, without squashing them.")
+	shell.RegisterCompletionFunc(runRetention, shell.RepoCompletion)
+	commands = append(commands, cmdutil.CreateAlias(runRetention, "run retention"))
+
+	erasureDocs := &cobra.Command{
+		Short: "Docs for erasures.",
+		Long: `An erasure removes a file from every commit of a repo, along with its stored
+content, for example to comply with a request to delete someone's data.
+
+Erasing a file rewrites the commits that contained it, and any other files
+that shared storage with it, and leaves an audit record with the reason for
+the erasure. Reading an erased file returns an error that names the erasure.
+The erased content is deleted by garbage collection, which can be checked with
+'pachctl verify erasure'.
+
+Erasures don't change the repos downstream of the repo, so erase the file from
+the output repos of pipelines that processed it too.`,
+	}
+	commands = append(commands, cmdutil.CreateDocsAlias(erasureDocs, "erasure", " erasure$"))
+
+	var reason string
+	eraseFile := &cobra.Command{
+		Use:   "{{alias}} <repo> <path>",
+		Short: "Erase a file from every commit of a repo.",
+		Long: `Erase a file, or a directory and everything under it, from every commit of a
+repo, along with its stored content. This can't be undone. All the commits of
+the repo must be finished.`,
+		Example: `
+# Erase the file "/users/1234.json" from repo "profiles":
+$ {{alias}} profiles /users/1234.json --reason "deletion request 1234"`,
+		Run: cmdutil.RunFixedArgs(2, func(args []string) error {
+			if reason == "" {
+				return errors.New("--reason must be specified")
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			erasure, err := c.EraseFile(args[0], args[1], reason)
+			if err != nil {
+				return err
+			}
+			fmt.Println(erasure.Id)
+			return nil
+		}),
+	}
+	eraseFile.Flags().StringVar(&reason, "reason", "", "The reason for the erasure, which is recorded in its audit record.")
+	shell.RegisterCompletionFunc(eraseFile, shell.RepoCompletion)
+	commands = append(commands, cmdutil.CreateAlias(eraseFile, "erase file"))
+
+	listErasure := &cobra.Command{
+		Use:   "{{alias}} <repo>",
+		Short: "Return the erasures of a repo.",
+		Long:  "Return the audit records of the files erased from a repo.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			erasures, err := c.ListErasure(args[0])
+			if err != nil {
+				return err
+			}
+			if raw {
+				encoder := cmdutil.Encoder(output, os.Stdout)
+				for _, erasure := range erasures {
+					if err := encoder.EncodeProto(erasure); err != nil {
+						return errors.EnsureStack(err)
+					}
+				}
+				return nil
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.ErasureHeader)
+			for _, erasure := range erasures {
+				pretty.PrintErasureInfo(writer, erasure, fullTimestamps)
+			}
+			return writer.Flush()
+		}),
+	}
+	listErasure.Flags().AddFlagSet(outputFlags)
+	listErasure.Flags().AddFlagSet(timestampFlags)
+	shell.RegisterCompletionFunc(listErasure, shell.RepoCompletion)
+	commands = append(commands, cmdutil.CreateAlias(listErasure, "list erasure"))
+
+	verifyErasure := &cobra.Command{
+		Use:   "{{alias}} <repo> <id>",
+		Short: "Check that the content of erased files is no longer stored.",
+		Long: `Check that no commit of a repo contains the path of an erasure, and that the
+chunks that held the erased content have been garbage collected. Chunks that
+are still referenced are shared with files that weren't erased, for example
+files with identical content in other repos, or with temporary file sets that
+haven't expired yet.`,
+		Run: cmdutil.RunFixedArgs(2, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			resp, err := c.VerifyErasure(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			if raw {
+				return errors.EnsureStack(cmdutil.Encoder(output, os.Stdout).EncodeProto(resp))
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			pretty.PrintVerifyErasureResponse(os.Stdout, resp)
+			return nil
+		}),
+	}
+	verifyErasure.Flags().AddFlagSet(outputFlags)
+	shell.RegisterCompletionFunc(verifyErasure, shell.RepoCompletion)
+	commands = append(commands, cmdutil.CreateAlias(verifyErasure, "verify erasure"))
+
+	validationDocs := &cobra.Command{
+		Short: "Docs for validation policies.",
+		Long: `Validation policies describe the files a repo accepts. When a commit is
+finished, the files it adds or changes are checked against its repo's policy:
+their extensions, their size, the format of CSV, JSON and Parquet files, and
+optionally a validator image, which runs as a Kubernetes job and passes if it
+exits with status 0.
+
+A policy either rejects commits with files that violate it, so they finish
+with an error and pipelines don't process them, or flags them, so they finish
+normally and their violations are shown by 'pachctl inspect commit'.`,
+	}
+	commands = append(commands, cmdutil.CreateDocsAlias(validationDocs, "validation", " validation$"))
+
+	var flagViolations bool
+	var allowedExtensions []string
+	var maxFileSize string
+	var formats []string
+	var validatorImage string
+	var validatorCmd []string
+	var validatorSecret string
+	var validatorTimeout time.Duration
+	setValidation := &cobra.Command{
+		Use:   "{{alias}} <repo>",
+		Short: "Set the validation policy of a repo.",
+		Long: `Set the policy that the files of a repo's commits are validated against when
+they're finished. Setting a policy replaces the existing one.
+
+A validator image's job can read the commit's files from the file sets named
+by its PACH_VALIDATION_FILESET and PACH_VALIDATION_DIFF_FILESET environment
+variables, e.g. with 'pachctl get file __filesets__@<id>:/path'.`,
+		Example: `
+# Reject commits to repo "sales" with files that aren't valid CSV files of up
+# to 100MB:
+$ {{alias}} sales --allowed-extension .csv --format csv --max-file-size 100MB
+
+# Flag commits to repo "events" whose files aren't accepted by an image:
+$ {{alias}} events --flag --image myorg/validator:1.0 --secret pach-token`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			policy := &pfs.ValidationPolicy{AllowedExtensions: allowedExtensions}
+			if flagViolations {
+				policy.Action = pfs.ValidationAction_FLAG
+			}
+			if maxFileSize != "" {
+				size, err := units.FromHumanSize(maxFileSize)
+				if err != nil {
+					return errors.Wrapf(err, "invalid --max-file-size")
+				}
+				policy.MaxFileSizeBytes = size
+			}
+			for _, format := range formats {
+				value, ok := pfs.FileFormat_value["FORMAT_"+strings.ToUpper(format)]
+				if !ok {
+					return errors.Errorf("unknown format %q, must be one of csv, json or parquet", format)
+				}
+				policy.Formats = append(policy.Formats, pfs.FileFormat(value))
+			}
+			if validatorImage != "" {
+				policy.Image = &pfs.ValidatorImage{
+					Image:  validatorImage,
+					Cmd:    validatorCmd,
+					Secret: validatorSecret,
+				}
+				if validatorTimeout != 0 {
+					policy.Image.Timeout = types.DurationProto(validatorTimeout)
+				}
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			_, err = c.SetValidationPolicy(args[0], policy)
+			return err
+		}),
+	}
+	setValidation.Flags().BoolVar(&flagViolations, "flag", false, "Flag commits with files that violate the policy, rather than rejecting them.")
+	setValidation.Flags().StringSliceVar(&allowedExtensions, "allowed-extension", nil, "An extension that files can have, e.g. .csv. If none are set, files can have any extension.")
+	setValidation.Flags().StringVar(&maxFileSize, "max-file-size", "", "The maximum size of a file, e.g. 100MB.")
+	setValidation.Flags().StringSliceVar(&formats, "format", nil, "A format that files with its extension must have: csv, json or parquet.")
+	setValidation.Flags().StringVar(&validatorImage, "image", "", "An image that validates the files of each commit.")
+	setValidation.Flags().StringSliceVar(&validatorCmd, "cmd", nil, "The command the validator image runs, if it's not the image's entrypoint.")
+	setValidation.Flags().StringVar(&validatorSecret, "secret", "", "A Kubernetes secret whose keys are set as environment variables of the validator image.")
+	setValidation.Flags().DurationVar(&validatorTimeout, "timeout", 0, "How long the validator image can run before the files are considered invalid (default 10m).")
+	shell.RegisterCompletionFunc(setValidation, shell.RepoCompletion)
+	commands = append(commands, cmdutil.CreateAlias(setValidation, "create validation"))
+	commands = append(commands, cmdutil.CreateAlias(setValidation, "update validation"))
+
+	inspectValidation := &cobra.Command{
+		Use:   "{{alias}} <repo>",
+		Short: "Return the validation policy of a repo.",
+		Long:  "Return the validation policy of a repo.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			policyInfo, err := c.InspectValidationPolicy(args[0])
+			if err != nil {
+				return err
+			}
+			if raw {
+				return errors.EnsureStack(cmdutil.Encoder(output, os.Stdout).EncodeProto(policyInfo))
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			pretty.PrintValidationPolicyInfo(os.Stdout, policyInfo, fullTimestamps)
+			return nil
+		}),
+	}
+	inspectValidation.Flags().AddFlagSet(outputFlags)
+	inspectValidation.Flags().AddFlagSet(timestampFlags)
+	shell.RegisterCompletionFunc(inspectValidation, shell.RepoCompletion)
+	commands = append(commands, cmdutil.CreateAlias(inspectValidation, "inspect validation"))
+
+	deleteValidation := &cobra.Command{
+		Use:   "{{alias}} <repo>",
+		Short: "Delete the validation policy of a repo.",
+		Long:  "Delete the validation policy of a repo. Commits that are already finished aren't changed.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			return c.DeleteValidationPolicy(args[0])
+		}),
+	}
+	shell.RegisterCompletionFunc(deleteValidation, shell.RepoCompletion)
+	commands = append(commands, cmdutil.CreateAlias(deleteValidation, "delete validation"))
+
+	viewDocs := &cobra.Command{
+		Short: "Docs for views.",
+		Long: `Views combine branches of several repos into a single read-only repo, named
+after the view. Each source of a view maps a path of the view to a path of a
+branch, e.g. the view "training" can hold the files of images@master under
+/images and those of labels@master under /labels.
+
+When a source branch gets a new commit, the view's master branch gets one with
+the current files of its sources. A view's repo can be listed, mounted, read
+through the S3 gateway as master.<view>, and used as a pipeline input, like any
+other repo.`,
+	}
+	commands = append(commands, cmdutil.CreateDocsAlias(viewDocs, "view", " view$"))
+
+	var viewSources []string
+	parseView := func(name string) (*pfs.View, error) {
+		view := &pfs.View{
+			Name:        name,
+			Description: description,
+		}
+		for _, arg := range viewSources {
+			parts := strings.SplitN(arg, "=", 2)
+			if len(parts) != 2 {
+				return nil, errors.Errorf("invalid source %q, expected <path>=<repo>@<branch>[:<path>]", arg)
+			}
+			file, err := cmdutil.ParseFile(parts[1])
+			if err != nil {
+				return nil, err
+			}
+			if file.Commit.ID != "" {
+				return nil, errors.Errorf("invalid source %q: the source must be a branch", arg)
+			}
+			if file.Commit.Branch.Name == "" {
+				file.Commit.Branch.Name = "master"
+			}
+			view.Sources = append(view.Sources, &pfs.ViewSource{
+				Path:       parts[0],
+				Branch:     file.Commit.Branch,
+				SourcePath: file.Path,
+			})
+		}
+		return view, nil
+	}
+
+	createView := &cobra.Command{
+		Use:   "{{alias}} <view>",
+		Short: "Create a new view.",
+		Long:  "Create a new view, and the read-only repo that holds its files.",
+		Example: `
+# Create a view "training" with the files of images@master under /images, and
+# the files under /labels of labels@master under /labels:
+$ {{alias}} training --source /images=images@master --source /labels=labels@master:/labels`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			view, err := parseView(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			_, err = c.CreateView(view)
+			return err
+		}),
+	}
+	createView.Flags().StringArrayVarP(&viewSources, "source", "s", nil, "A source of the view, in the form <path>=<repo>@<branch>[:<path>].")
+	createView.Flags().StringVarP(&description, "description", "d", "", "A description of the view.")
+	commands = append(commands, cmdutil.CreateAlias(createView, "create view"))
+
+	updateView := &cobra.Command{
+		Use:   "{{alias}} <view>",
+		Short: "Update a view.",
+		Long: `Replace the sources and description of a view. If the view's source branches
+change, its master branch gets a new commit. Otherwise, changes to the paths of
+its sources take effect with the next commit to a source.`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			view, err := parseView(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			_, err = c.UpdateView(view)
+			return err
+		}),
+	}
+	updateView.Flags().StringArrayVarP(&viewSources, "source", "s", nil, "A source of the view, in the form <path>=<repo>@<branch>[:<path>].")
+	updateView.Flags().StringVarP(&description, "description", "d", "", "A description of the view.")
+	shell.RegisterCompletionFunc(updateView, shell.RepoCompletion)
+	commands = append(commands, cmdutil.CreateAlias(updateView, "update view"))
+
+	inspectView := &cobra.Command{
+		Use:   "{{alias}} <view>",
+		Short: "Return info about a view.",
+		Long:  "Return info about a view.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			viewInfo, err := c.InspectView(args[0])
+			if err != nil {
+				return err
+			}
+			if raw {
+				return errors.EnsureStack(cmdutil.Encoder(output, os.Stdout).EncodeProto(viewInfo))
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			pretty.PrintDetailedViewInfo(os.Stdout, viewInfo, fullTimestamps)
+			return nil
+		}),
+	}
+	inspectView.Flags().AddFlagSet(outputFlags)
+	inspectView.Flags().AddFlagSet(timestampFlags)
+	shell.RegisterCompletionFunc(inspectView, shell.RepoCompletion)
+	commands = append(commands, cmdutil.CreateAlias(inspectView, "inspect view"))
+
+	listView := &cobra.Command{
+		Short: "Return all views.",
+		Long:  "Return all views.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			viewInfos, err := c.ListView()
+			if err != nil {
+				return err
+			}
+			if raw {
+				encoder := cmdutil.Encoder(output, os.Stdout)
+				for _, viewInfo := range viewInfos {
+					if err := encoder.EncodeProto(viewInfo); err != nil {
+						return errors.EnsureStack(err)
+					}
+				}
+				return nil
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.ViewHeader)
+			for _, viewInfo := range viewInfos {
+				pretty.PrintViewInfo(writer, viewInfo, fullTimestamps)
+			}
+			return writer.Flush()
+		}),
+	}
+	listView.Flags().AddFlagSet(outputFlags)
+	listView.Flags().AddFlagSet(timestampFlags)
+	commands = append(commands, cmdutil.CreateAlias(listView, "list view"))
+
+	var forceView bool
+	deleteView := &cobra.Command{
+		Use:   "{{alias}} <view>",
+		Short: "Delete a view.",
+		Long:  "Delete a view and its repo.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			return c.DeleteView(args[0], forceView)
+		}),
+	}
+	deleteView.Flags().BoolVarP(&forceView, "force", "f", false, "Delete the view's repo even if pipelines take it as input.")
+	shell.RegisterCompletionFunc(deleteView, shell.RepoCompletion)
+	commands = append(commands, cmdutil.CreateAlias(deleteView, "delete view"))
+
+	fileDocs := &cobra.Command{
+		Short: "Docs for files.",
+		Long: `Files are the lowest level data objects in Pachyderm.
+
+Files can be of any type (e.g. csv, binary, images, etc) or size and can be
+written to started (but not finished) commits with 'put file'. Files can be read
+from commits with 'get file'.`,
+	}
+	commands = append(commands, cmdutil.CreateDocsAlias(fileDocs, "file", " file$"))
+
+	var filePaths []string
+	var inputFile string
+	var recursive bool
+	var parallelism int
+	var appendFile bool
+	var compress bool
+	var compression string
+	var enableProgress bool
+	var fullPath bool
+	var contentType string
+	var resumable bool
+	var uploadSession string
+	putFile := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch-or-commit>[:<path/to/file>]",
+		Short: "Put a file into the filesystem.",
+		Long:  "Put a file into the filesystem.  This command supports a number of ways to insert data into PFS.",
+		Example: `
+# Put data from stdin at repo@branch:/path
+$ echo "data" | {{alias}} repo@branch:/path
+
+# Put a file from the local filesystem at repo@branch:/file
+$ {{alias}} repo@branch -f file
+
+# Put a file from the local filesystem at repo@branch:/path
+$ {{alias}} repo@branch:/path -f file
+
+# Put the contents of a directory at repo@branch:/dir/file
+$ {{alias}} -r repo@branch -f dir
+
+# Put the contents of a directory at repo@branch:/path/file (without /dir)
+$ {{alias}} -r repo@branch:/path -f dir
+
+# Put the data from a URL at repo@branch:/example.png
+$ {{alias}} repo@branch -f http://host/example.png
+
+# Put the data from a URL at repo@branch:/dir/example.png
+$ {{alias}} repo@branch:/dir -f http://host/example.png
+
+# Put the data from an S3 bucket at repo@branch:/s3_object
+$ {{alias}} repo@branch -r -f s3://my_bucket
+
+# Put several files or URLs that are listed in file.
+# Files and URLs should be newline delimited.
+$ {{alias}} repo@branch -i file
+
+# Put several files or URLs that are listed at URL.
+# NOTE this URL can reference local files, so it could cause you to put sensitive
+# files into your Pachyderm cluster.
+$ {{alias}} repo@branch -i http://host/path
+
+# Put a large file at repo@branch:/file with a resumable upload, and resume it
+# if it's interrupted
+$ {{alias}} repo@branch -f file --resumable
+$ {{alias}} repo@branch -f file --upload-session <id>`,
+		Run: cmdutil.RunFixedArgs(1, cmdutil.Notify(&notify, func(args []string) (retErr error) {
+			if !enableProgress {
+				progress.Disable()
+			}
+			file, err := cmdutil.ParseFile(args[0])
+			if err != nil {
+				return err
+			}
+			opts := []client.Option{client.WithMaxConcurrentStreams(parallelism)}
+			if compress {
+				opts = append(opts, client.WithGZIPCompression())
+			}
+			compressionOpts, err := streamCompressionOptions(compression)
+			if err != nil {
+				return err
+			}
+			opts = append(opts, compressionOpts...)
+			c, err := newClient("user", opts...)
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			defer progress.Wait()
+
+			// check whether or not the repo exists before attempting to upload
+			if _, err = c.InspectRepo(file.Commit.Branch.Repo.Name); err != nil {
+				if errutil.IsNotFoundError(err) {
+					return err
+				}
+				return errors.Wrapf(err, "could not inspect repo %s", err, file.Commit.Branch.Repo.Name)
+			}
+
+			if resumable || uploadSession != "" {
+				if inputFile != "" || recursive || len(filePaths) != 1 || filePaths[0] == "-" {
+					return errors.New("resumable uploads only support a single local file, passed with -f")
+				}
+				source := filePaths[0]
+				target := file.Path
+				if target == "" {
+					target = source
+					if !fullPath {
+						target = filepath.Base(source)
+					}
+				}
+				return putFileResumable(c, file.Commit, target, source, uploadSession, parallelism, appendFile)
+			}
 
 			// TODO: Rethink put file parallelism for 2.0.
 			// Doing parallel uploads at the file level for small files will be bad, but we still want a clear way to parallelize large file uploads.
@@ -1110,13 +2195,13 @@ $ {{alias}} repo@branch -i http://host/path`,
 						if !fullPath {
 							target = filepath.Base(source)
 						}
-						if err := putFileHelper(mf, joinPaths("", target), source, recursive, appendFile); err != nil {
+						if err := putFileHelper(mf, joinPaths("", target), source, recursive, appendFile, contentType); err != nil {
 							return err
 						}
 					} else if len(sources) == 1 {
 						// We have a single source and the user has specified a path,
 						// we use the path and ignore source (in terms of naming the file).
-						if err := putFileHelper(mf, file.Path, source, recursive, appendFile); err != nil {
+						if err := putFileHelper(mf, file.Path, source, recursive, appendFile, contentType); err != nil {
 							return err
 						}
 					} else {
@@ -1126,23 +2211,28 @@ $ {{alias}} repo@branch -i http://host/path`,
 						if !fullPath {
 							target = filepath.Base(source)
 						}
-						if err := putFileHelper(mf, joinPaths(file.Path, target), source, recursive, appendFile); err != nil {
+						if err := putFileHelper(mf, joinPaths(file.Path, target), source, recursive, appendFile, contentType); err != nil {
 							return err
 						}
 					}
 				}
 				return nil
 			})
-		}),
+		})),
 	}
 	putFile.Flags().StringSliceVarP(&filePaths, "file", "f", []string{"-"}, "The file to be put, it can be a local file or a URL.")
 	putFile.Flags().StringVarP(&inputFile, "input-file", "i", "", "Read filepaths or URLs from a file.  If - is used, paths are read from the standard input.")
 	putFile.Flags().BoolVarP(&recursive, "recursive", "r", false, "Recursively put the files in a directory.")
 	putFile.Flags().BoolVarP(&compress, "compress", "", false, "Compress data during upload. This parameter might help you upload your uncompressed data, such as CSV files, to Pachyderm faster. Use 'compress' with caution, because if your data is already compressed, this parameter might slow down the upload speed instead of increasing.")
+	putFile.Flags().StringVar(&compression, "compression", "none", streamCompressionUsage)
 	putFile.Flags().IntVarP(&parallelism, "parallelism", "p", DefaultParallelism, "The maximum number of files that can be uploaded in parallel.")
 	putFile.Flags().BoolVarP(&appendFile, "append", "a", false, "Append to the existing content of the file, either from previous commits or previous calls to 'put file' within this commit.")
 	putFile.Flags().BoolVar(&enableProgress, "progress", isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd()), "Print progress bars.")
 	putFile.Flags().BoolVar(&fullPath, "full-path", false, "If true, use the entire path provided to -f as the target filename in PFS. By default only the base of the path is used.")
+	putFile.Flags().StringVar(&contentType, "content-type", "", "The content type of the files, e.g. 'text/csv'. By default it's detected from the content of each file.")
+	putFile.Flags().BoolVar(&resumable, "resumable", false, "Upload a single local file in checksummed blocks, with --parallelism blocks at once. If the upload fails, it can be resumed with --upload-session.")
+	putFile.Flags().StringVar(&uploadSession, "upload-session", "", "Resume the resumable upload with this ID, only uploading the blocks it's missing.")
+	putFile.Flags().AddFlagSet(notifyFlags)
 	shell.RegisterCompletionFunc(putFile,
 		func(flag, text string, maxCompletions int64) ([]prompt.Suggest, shell.CacheFunc) {
 			if flag == "-f" || flag == "--file" || flag == "-i" || flag == "input-file" {
@@ -1193,6 +2283,7 @@ $ {{alias}} repo@branch -i http://host/path`,
 	var outputPath string
 	var offsetBytes int64
 	var retry bool
+	var manifestPath string
 	getFile := &cobra.Command{
 		Use:   "{{alias}} <repo>@<branch-or-commit>:<path/in/pfs>",
 		Short: "Return the contents of a file.",
@@ -1211,7 +2302,14 @@ $ {{alias}} foo@master^2:XXX
 
 # get file "test[].txt" on branch "master" in repo "foo"
 # the path is interpreted as a glob pattern: quote and protect regex characters
-$ {{alias}} 'foo@master:/test\[\].txt'`,
+$ {{alias}} 'foo@master:/test\[\].txt'
+
+# download only the files under directory "data" on branch "master" in repo
+# "foo" that are selected by the patterns in "manifest.txt", e.g.
+#   /images/2022/
+#   !/images/**/*.tmp
+#   /labels/*.json
+$ {{alias}} -r foo@master:/data -o data --manifest manifest.txt`,
 		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
 			if !enableProgress {
 				progress.Disable()
@@ -1220,7 +2318,14 @@ $ {{alias}} 'foo@master:/test\[\].txt'`,
 			if err != nil {
 				return err
 			}
-			c, err := newClient("user")
+			opts, err := streamCompressionOptions(compression)
+			if err != nil {
+				return err
+			}
+			if manifestPath != "" {
+				opts = append(opts, client.WithMaxConcurrentStreams(parallelism))
+			}
+			c, err := newClient("user", opts...)
 			if err != nil {
 				return err
 			}
@@ -1231,6 +2336,18 @@ $ {{alias}} 'foo@master:/test\[\].txt'`,
 				if outputPath == "" {
 					return errors.Errorf("an output path needs to be specified when using the --recursive flag")
 				}
+				if manifestPath != "" {
+					f, err := os.Open(manifestPath)
+					if err != nil {
+						return errors.EnsureStack(err)
+					}
+					defer f.Close()
+					m, err := parseManifest(f)
+					if err != nil {
+						return err
+					}
+					return sparseGetFile(c, file, outputPath, m, parallelism)
+				}
 				// Check that the path matches one directory / file.
 				fi, err := c.InspectFile(file.Commit, file.Path)
 				if err != nil {
@@ -1286,10 +2403,199 @@ $ {{alias}} 'foo@master:/test\[\].txt'`,
 	getFile.Flags().StringVarP(&outputPath, "output", "o", "", "The path where data will be downloaded.")
 	getFile.Flags().BoolVar(&enableProgress, "progress", isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd()), "{true|false} Whether or not to print the progress bars.")
 	getFile.Flags().Int64Var(&offsetBytes, "offset", 0, "The number of bytes in the file to skip ahead when reading.")
+	getFile.Flags().StringVar(&compression, "compression", "none", streamCompressionUsage)
 	getFile.Flags().BoolVar(&retry, "retry", false, "{true|false} Whether to append the missing bytes to an existing file. No-op if the file doesn't exist.")
+	getFile.Flags().StringVar(&manifestPath, "manifest", "", "With --recursive, a file of include and exclude patterns that selects the files to download. An interrupted download resumes where it stopped when rerun.")
+	getFile.Flags().IntVarP(&parallelism, "parallelism", "p", DefaultParallelism, "With --manifest, the maximum number of files that can be downloaded in parallel.")
 	shell.RegisterCompletionFunc(getFile, shell.FileCompletion)
 	commands = append(commands, cmdutil.CreateAlias(getFile, "get file"))
 
+	var headLines int
+	var headBytes int64
+	headFileCmd := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch-or-commit>:<path/in/pfs>",
+		Short: "Return the first lines of a file.",
+		Long:  "Return the first lines of a file. Only the beginning of the file is downloaded, so this can be used to preview large files.",
+		Example: `
+# print the first 10 lines of file "XXX" on branch "master" in repo "foo"
+$ {{alias}} foo@master:XXX
+
+# print the first 100 lines of the file
+$ {{alias}} foo@master:XXX -n 100
+
+# print the first kilobyte of the file
+$ {{alias}} foo@master:XXX -c 1024`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			file, err := cmdutil.ParseFile(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := newClient("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			return headFile(c, file, headLines, headBytes, os.Stdout)
+		}),
+	}
+	headFileCmd.Flags().IntVarP(&headLines, "lines", "n", 10, "The number of lines to print.")
+	headFileCmd.Flags().Int64VarP(&headBytes, "bytes", "c", 0, "The number of bytes to print, instead of lines.")
+	shell.RegisterCompletionFunc(headFileCmd, shell.FileCompletion)
+	commands = append(commands, cmdutil.CreateAlias(headFileCmd, "head file"))
+
+	var tailLines int
+	var tailBytes int64
+	tailFileCmd := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch-or-commit>:<path/in/pfs>",
+		Short: "Return the last lines of a file.",
+		Long:  "Return the last lines of a file. Only the end of the file is downloaded, so this can be used to preview large files.",
+		Example: `
+# print the last 10 lines of file "XXX" on branch "master" in repo "foo"
+$ {{alias}} foo@master:XXX
+
+# print the last 100 lines of the file
+$ {{alias}} foo@master:XXX -n 100
+
+# print the last kilobyte of the file
+$ {{alias}} foo@master:XXX -c 1024`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			file, err := cmdutil.ParseFile(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := newClient("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			return tailFile(c, file, tailLines, tailBytes, os.Stdout)
+		}),
+	}
+	tailFileCmd.Flags().IntVarP(&tailLines, "lines", "n", 10, "The number of lines to print.")
+	tailFileCmd.Flags().Int64VarP(&tailBytes, "bytes", "c", 0, "The number of bytes to print, instead of lines.")
+	shell.RegisterCompletionFunc(tailFileCmd, shell.FileCompletion)
+	commands = append(commands, cmdutil.CreateAlias(tailFileCmd, "tail file"))
+
+	var sampleFraction float64
+	var sampleSeed int64
+	sampleFileCmd := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch-or-commit>:<path/in/pfs>",
+		Short: "Return a random sample of a file's lines.",
+		Long: `Return a random sample of a file's lines, each of which is kept with the given probability. The sampling happens in pachd, so only the sampled lines are downloaded.
+
+If the path is a directory, a random sample of the paths of the files under it is returned instead.`,
+		Example: `
+# print about 1% of the lines of file "XXX" on branch "master" in repo "foo"
+$ {{alias}} foo@master:XXX
+
+# print about 10% of the lines of the file, the same 10% every time
+$ {{alias}} foo@master:XXX --fraction 0.1 --seed 42
+
+# print about 1% of the paths of the files under directory "dir"
+$ {{alias}} foo@master:dir`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			file, err := cmdutil.ParseFile(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := newClient("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			seed := sampleSeed
+			if seed == 0 {
+				seed = time.Now().UnixNano()
+			}
+			return sampleFile(c, file, sampleFraction, seed, os.Stdout)
+		}),
+	}
+	sampleFileCmd.Flags().Float64Var(&sampleFraction, "fraction", 0.01, "The probability each line is kept with, between 0 and 1.")
+	sampleFileCmd.Flags().Int64Var(&sampleSeed, "seed", 0, "The random seed, so that the same sample can be taken again. If 0, a random seed is used.")
+	shell.RegisterCompletionFunc(sampleFileCmd, shell.FileCompletion)
+	commands = append(commands, cmdutil.CreateAlias(sampleFileCmd, "sample file"))
+
+	var previewRows int64
+	var previewFormat string
+	var previewNoHeader, previewSchema bool
+	previewFileCmd := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch-or-commit>:<path/in/pfs>",
+		Short: "Return the columns and first rows of a CSV, JSON or Parquet file.",
+		Long: `Return the columns and first rows of a CSV, JSON or Parquet file.
+
+The file is parsed in pachd, which only reads as much of it as the preview
+needs, so this can be used to check large datasets. The format is inferred
+from the file's extension: ".csv" for CSV, ".json", ".jsonl" and ".ndjson"
+for JSON, and ".parquet" for Parquet. The first record of a CSV file holds
+the names of its columns, unless --no-header is set. The types of CSV and
+JSON columns are inferred from the previewed rows, and the types of Parquet
+columns are read from the file's schema.`,
+		Example: `
+# print the first 10 rows of file "data.csv" on branch "master" in repo "foo"
+$ {{alias}} foo@master:data.csv
+
+# print the first 100 rows of a Parquet file
+$ {{alias}} foo@master:data.parquet -n 100
+
+# print the columns of a file without an extension, and their types
+$ {{alias}} foo@master:data --format json --schema`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			file, err := cmdutil.ParseFile(args[0])
+			if err != nil {
+				return err
+			}
+			opts := []client.PreviewFileOption{client.WithPreviewRows(previewRows)}
+			if previewFormat != "" {
+				value, ok := pfs.FileFormat_value["FORMAT_"+strings.ToUpper(previewFormat)]
+				if !ok {
+					return errors.Errorf("unknown format %q, must be one of csv, json or parquet", previewFormat)
+				}
+				opts = append(opts, client.WithPreviewFormat(pfs.FileFormat(value)))
+			}
+			if previewNoHeader {
+				opts = append(opts, client.WithPreviewNoHeader())
+			}
+			c, err := newClient("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			preview, err := c.PreviewFile(file.Commit, file.Path, opts...)
+			if err != nil {
+				return err
+			}
+			if raw {
+				return errors.EnsureStack(cmdutil.Encoder(output, os.Stdout).EncodeProto(preview))
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			if previewSchema {
+				writer := tabwriter.NewWriter(os.Stdout, pretty.PreviewSchemaHeader)
+				pretty.PrintPreviewSchema(writer, preview)
+				return writer.Flush()
+			}
+			if len(preview.Columns) == 0 {
+				return nil
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.PreviewRowsHeader(preview))
+			pretty.PrintPreviewRows(writer, preview)
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			if preview.Truncated {
+				fmt.Fprintf(os.Stderr, "showing the first %d rows\n", len(preview.Rows))
+			}
+			return nil
+		}),
+	}
+	previewFileCmd.Flags().Int64VarP(&previewRows, "rows", "n", 10, "The number of rows to print.")
+	previewFileCmd.Flags().StringVar(&previewFormat, "format", "", "The format of the file (csv, json or parquet), if it can't be inferred from its extension.")
+	previewFileCmd.Flags().BoolVar(&previewNoHeader, "no-header", false, "Treat the first record of a CSV file as a row, rather than the names of its columns.")
+	previewFileCmd.Flags().BoolVar(&previewSchema, "schema", false, "Print the columns of the file and their types, instead of its rows.")
+	previewFileCmd.Flags().AddFlagSet(outputFlags)
+	shell.RegisterCompletionFunc(previewFileCmd, shell.FileCompletion)
+	commands = append(commands, cmdutil.CreateAlias(previewFileCmd, "preview file"))
+
 	inspectFile := &cobra.Command{
 		Use:   "{{alias}} <repo>@<branch-or-commit>:<path/in/pfs>",
 		Short: "Return info about a file.",
@@ -1355,11 +2661,15 @@ $ {{alias}} 'foo@master:dir\[1\]'`,
 				return err
 			}
 			defer c.Close()
+			var opts []client.ListFileOption
+			if contentType != "" {
+				opts = append(opts, client.WithContentTypeListFile(contentType))
+			}
 			if raw {
 				encoder := cmdutil.Encoder(output, os.Stdout)
 				return c.ListFile(file.Commit, file.Path, func(fi *pfs.FileInfo) error {
 					return errors.EnsureStack(encoder.EncodeProto(fi))
-				})
+				}, opts...)
 			} else if output != "" {
 				return errors.New("cannot set --output (-o) without --raw")
 			}
@@ -1368,7 +2678,7 @@ $ {{alias}} 'foo@master:dir\[1\]'`,
 			if err := c.ListFile(file.Commit, file.Path, func(fi *pfs.FileInfo) error {
 				pretty.PrintFileInfo(writer, fi, fullTimestamps, false)
 				return nil
-			}); err != nil {
+			}, opts...); err != nil {
 				return err
 			}
 			return writer.Flush()
@@ -1376,6 +2686,7 @@ $ {{alias}} 'foo@master:dir\[1\]'`,
 	}
 	listFile.Flags().AddFlagSet(outputFlags)
 	listFile.Flags().AddFlagSet(timestampFlags)
+	listFile.Flags().StringVar(&contentType, "content-type", "", "Only list regular files of this content type, e.g. 'text/csv' or 'image/*'.")
 	shell.RegisterCompletionFunc(listFile, shell.FileCompletion)
 	commands = append(commands, cmdutil.CreateAlias(listFile, "list file"))
 
@@ -1390,7 +2701,11 @@ $ {{alias}} 'foo@master:dir\[1\]'`,
 $ {{alias}} "foo@master:A*"
 
 # Return files in repo "foo" on branch "master" under directory "data".
-$ {{alias}} "foo@master:data/*"`,
+$ {{alias}} "foo@master:data/*"
+
+# Return the Parquet files anywhere in repo "foo" on branch "master",
+# regardless of their names.
+$ {{alias}} "foo@master:**" --content-type application/vnd.apache.parquet`,
 		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
 			file, err := cmdutil.ParseFile(args[0])
 			if err != nil {
@@ -1401,7 +2716,11 @@ $ {{alias}} "foo@master:data/*"`,
 				return err
 			}
 			defer c.Close()
-			fileInfos, err := c.GlobFileAll(file.Commit, file.Path)
+			var opts []client.GlobFileOption
+			if contentType != "" {
+				opts = append(opts, client.WithContentTypeGlobFile(contentType))
+			}
+			fileInfos, err := c.GlobFileAll(file.Commit, file.Path, opts...)
 			if err != nil {
 				return err
 			}
@@ -1425,6 +2744,7 @@ $ {{alias}} "foo@master:data/*"`,
 	}
 	globFile.Flags().AddFlagSet(outputFlags)
 	globFile.Flags().AddFlagSet(timestampFlags)
+	globFile.Flags().StringVar(&contentType, "content-type", "", "Only return regular files of this content type, e.g. 'text/csv' or 'image/*'.")
 	shell.RegisterCompletionFunc(globFile, shell.FileCompletion)
 	commands = append(commands, cmdutil.CreateAlias(globFile, "glob file"))
 
@@ -1670,17 +2990,52 @@ Objects are a low-level resource and should not be accessed directly by most use
 	// Add the mount commands (which aren't available on Windows, so they're in
 	// their own file)
 	commands = append(commands, mountCmds()...)
+	commands = append(commands, replicationCmds()...)
+	commands = append(commands, archiveCmds()...)
+	commands = append(commands, gitSyncCmds()...)
+	commands = append(commands, topicSyncCmds()...)
+	commands = append(commands, dirSyncCmds()...)
 
 	return commands
 }
 
-func putFileHelper(mf client.ModifyFile, path, source string, recursive, appendFile bool) (retErr error) {
+// putFileResumable puts the local file source at path with a resumable
+// upload, resuming the upload with ID session if it's set.
+func putFileResumable(c *client.APIClient, commit *pfs.Commit, path, source, session string, parallelism int, appendFile bool) (retErr error) {
+	f, err := os.Open(source)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil && retErr == nil {
+			retErr = errors.EnsureStack(err)
+		}
+	}()
+	fi, err := f.Stat()
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	var opts []client.PutFileOption
+	if appendFile {
+		opts = append(opts, client.WithAppendPutFile())
+	}
+	id, err := c.PutFileResumable(commit, path, f, fi.Size(), session, parallelism, opts...)
+	if err != nil && id != "" {
+		return errors.Wrapf(err, "upload %s failed, rerun with --upload-session %s to resume it", id, id)
+	}
+	return err
+}
+
+func putFileHelper(mf client.ModifyFile, path, source string, recursive, appendFile bool, contentType string) (retErr error) {
 	// Resolve the path and convert to unix path in case we're on windows.
 	path = filepath.ToSlash(filepath.Clean(path))
 	var opts []client.PutFileOption
 	if appendFile {
 		opts = append(opts, client.WithAppendPutFile())
 	}
+	if contentType != "" {
+		opts = append(opts, client.WithContentTypePutFile(contentType))
+	}
 	// try parsing the filename as a url, if it is one do a PutFileURL
 	if url, err := url.Parse(source); err == nil && url.Scheme != "" {
 		return errors.EnsureStack(mf.PutFileURL(path, url.String(), recursive, opts...))
@@ -1708,7 +3063,7 @@ func putFileHelper(mf client.ModifyFile, path, source string, recursive, appendF
 			// don't do a second recursive 'put file', just put the one file at
 			// filePath into childDest, and then this walk loop will go on to the
 			// next one
-			return putFileHelper(mf, childDest, filePath, false, appendFile)
+			return putFileHelper(mf, childDest, filePath, false, appendFile, contentType)
 		})
 		return errors.EnsureStack(err)
 	}
@@ -1801,6 +3156,25 @@ func forEachDiffFile(newFiles, oldFiles []*pfs.FileInfo, f func(newFile, oldFile
 	}
 }
 
+const streamCompressionUsage = "How to compress the transfer: 'auto' uses the best compressor the cluster allows, 'zstd' or 'gzip' use that compressor if the cluster allows it, and 'none' doesn't compress. Compression speeds up transfers of compressible data over slow links."
+
+// streamCompressionOptions returns the client options that compress file
+// transfers as the --compression flag asks.
+func streamCompressionOptions(compression string) ([]client.Option, error) {
+	switch compression {
+	case "", "none":
+		return nil, nil
+	case "auto":
+		return []client.Option{client.WithStreamCompression(grpcutil.StreamCompressors...)}, nil
+	}
+	for _, name := range grpcutil.StreamCompressors {
+		if name == compression {
+			return []client.Option{client.WithStreamCompression(name)}, nil
+		}
+	}
+	return nil, errors.Errorf("unknown compression %q, must be one of auto, %s or none", compression, strings.Join(grpcutil.StreamCompressors, ", "))
+}
+
 func newClient(name string, options ...client.Option) (*client.APIClient, error) {
 	if inWorkerStr, ok := os.LookupEnv("PACH_IN_WORKER"); ok {
 		inWorker, err := strconv.ParseBool(inWorkerStr)
@@ -1814,6 +3188,18 @@ func newClient(name string, options ...client.Option) (*client.APIClient, error)
 	return client.NewOnUserMachine(name, options...)
 }
 
+func parseCommitState(input string) (pfs.CommitState, error) {
+	switch strings.ToLower(input) {
+	case "":
+		return pfs.CommitState_COMMIT_STATE_UNKNOWN, nil
+	case "finishing":
+		return pfs.CommitState_FINISHING, nil
+	case "finished":
+		return pfs.CommitState_FINISHED, nil
+	}
+	return pfs.CommitState_COMMIT_STATE_UNKNOWN, errors.Errorf("unknown commit state '%s', must be one of: finishing, finished", input)
+}
+
 func parseOriginKind(input string) (pfs.OriginKind, error) {
 	if input == "" {
 		return pfs.OriginKind_ORIGIN_KIND_UNKNOWN, nil
@@ -1832,3 +3218,24 @@ func parseOriginKind(input string) (pfs.OriginKind, error) {
 
 	return result, nil
 }
+
+// projectFlag returns the project named by a --project flag, which is nil
+// if the flag isn't set.
+func projectFlag(project string) *pfs.Project {
+	if project == "" {
+		return nil
+	}
+	return client.NewProject(project)
+}
+
+// listRepoInfos returns the repos that match request.
+func listRepoInfos(c *client.APIClient, request *pfs.ListRepoRequest) ([]*pfs.RepoInfo, error) {
+	ctx, cf := context.WithCancel(c.Ctx())
+	defer cf()
+	listClient, err := c.PfsAPIClient.ListRepo(ctx, request)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	repoInfos, err := clientsdk.ListRepoInfo(listClient)
+	return repoInfos, grpcutil.ScrubGRPC(err)
+}
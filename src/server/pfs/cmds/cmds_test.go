@@ -149,3 +149,249 @@ func TestDiffFile(t *testing.T) {
 		"repo", tu.UniqueString("TestDiffFile-repo"),
 	).Run())
 }
+
+func TestHeadTailSampleFile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	c, _ := minikubetestenv.AcquireCluster(t)
+	require.NoError(t, tu.PachctlBashCmd(t, c, `
+		pachctl create repo {{.repo}}
+
+		seq 1 1000 | pachctl put file {{.repo}}@master:/data
+
+		pachctl head file {{.repo}}@master:/data -n 3 \
+			| match '^1$' \
+			| match '^3$' \
+			| match -v '^4$'
+
+		pachctl tail file {{.repo}}@master:/data -n 2 \
+			| match '^999$' \
+			| match '^1000$' \
+			| match -v '^998$'
+
+		pachctl head file {{.repo}}@master:/data -c 4 \
+			| match '^2$'
+
+		test "$(pachctl sample file {{.repo}}@master:/data --fraction 0.1 --seed 7)" \
+			= "$(pachctl sample file {{.repo}}@master:/data --fraction 0.1 --seed 7)"
+		`,
+		"repo", tu.UniqueString("TestHeadTailSampleFile-repo"),
+	).Run())
+}
+
+func TestPreviewFileCommands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	c, _ := minikubetestenv.AcquireCluster(t)
+	require.NoError(t, tu.PachctlBashCmd(t, c, `
+		pachctl create repo {{.repo}}
+		(echo id,name; seq 1 100 | sed 's/.*/&,name-&/') \
+			| pachctl put file {{.repo}}@master:/data.csv
+
+		pachctl preview file {{.repo}}@master:/data.csv -n 2 2>/dev/null \
+			| match '^id +name' \
+			| match '^2 +name-2' \
+			| match -v '^3 '
+		pachctl preview file {{.repo}}@master:/data.csv --schema \
+			| match '^id +integer' \
+			| match '^name +string'
+
+		echo '{"a": 1}' | pachctl put file {{.repo}}@master:/data
+		(pachctl preview file {{.repo}}@master:/data 2>&1 || true) \
+			| match "format must be specified"
+		pachctl preview file {{.repo}}@master:/data --format json \
+			| match '^1'
+		`,
+		"repo", tu.UniqueString("TestPreviewFileCommands-repo"),
+	).Run())
+}
+
+func TestSchemaCommands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	c, _ := minikubetestenv.AcquireCluster(t)
+	require.NoError(t, tu.PachctlBashCmd(t, c, `
+		pachctl create repo {{.repo}}
+		echo '{"type": "object", "required": ["id"]}' \
+			| pachctl create schema {{.repo}} "/*.json" --type json -f -
+		pachctl list schema {{.repo}} \
+			| match "/\*.json" \
+			| match JSON_SCHEMA
+
+		echo '{"id": 1}' | pachctl put file {{.repo}}@master:/a.json
+		pachctl inspect commit-schema {{.repo}}@master \
+			| match "/\*.json"
+
+		echo '{"name": "x"}' | pachctl put file {{.repo}}@master:/b.json
+		pachctl inspect commit-schema {{.repo}}@master \
+			| match "/b.json"
+
+		pachctl delete schema {{.repo}} "/*.json"
+		pachctl list schema {{.repo}} \
+			| match -v "/\*.json"
+		`,
+		"repo", tu.UniqueString("TestSchemaCommands-repo"),
+	).Run())
+}
+
+func TestRetentionCommands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	c, _ := minikubetestenv.AcquireCluster(t)
+	require.NoError(t, tu.PachctlBashCmd(t, c, `
+		pachctl create repo {{.repo}}
+		for i in 1 2 3; do
+			echo "$i" | pachctl put file {{.repo}}@master:/file$i
+		done
+		pachctl create retention {{.repo}} --keep-commits 2
+		pachctl list retention {{.repo}} \
+			| match "\*" \
+			| match 2
+
+		test "$(pachctl run retention {{.repo}} --dry-run | wc -l)" -eq 1
+		test "$(pachctl list commit {{.repo}}@master | wc -l)" -eq 4
+		pachctl run retention {{.repo}}
+		test "$(pachctl list commit {{.repo}}@master | wc -l)" -eq 3
+
+		pachctl delete retention {{.repo}}
+		pachctl list retention {{.repo}} \
+			| match -v "\*"
+		`,
+		"repo", tu.UniqueString("TestRetentionCommands-repo"),
+	).Run())
+}
+
+func TestErasureCommands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	c, _ := minikubetestenv.AcquireCluster(t)
+	require.NoError(t, tu.PachctlBashCmd(t, c, `
+		pachctl create repo {{.repo}}
+		echo "alice" | pachctl put file {{.repo}}@master:/users/alice
+		echo "bob" | pachctl put file {{.repo}}@master:/users/bob
+
+		pachctl erase file {{.repo}} /users/alice && exit 1
+		id="$(pachctl erase file {{.repo}} /users/alice --reason "deletion request")"
+		(pachctl get file {{.repo}}@master:/users/alice 2>&1 || true) \
+			| match "erased by erasure ${id}"
+		pachctl get file {{.repo}}@master:/users/bob \
+			| match bob
+		pachctl list erasure {{.repo}} \
+			| match "${id}" \
+			| match "deletion request"
+		pachctl verify erasure {{.repo}} "${id}" \
+			| match "Commits containing the path: 0"
+		`,
+		"repo", tu.UniqueString("TestErasureCommands-repo"),
+	).Run())
+}
+
+func TestValidationCommands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	c, _ := minikubetestenv.AcquireCluster(t)
+	require.NoError(t, tu.PachctlBashCmd(t, c, `
+		pachctl create repo {{.repo}}
+		pachctl create validation {{.repo}} --allowed-extension .csv --format csv
+		pachctl inspect validation {{.repo}} \
+			| match "Action: REJECT" \
+			| match "Formats: CSV"
+
+		echo "a,b" | pachctl put file {{.repo}}@master:/a.csv
+		pachctl wait commit {{.repo}}@master \
+			| match "0 violations in 1 files"
+
+		echo "data" | pachctl put file {{.repo}}@master:/b.txt
+		pachctl wait commit {{.repo}}@master \
+			| match "/b.txt (extension)"
+
+		pachctl delete validation {{.repo}}
+		(pachctl inspect validation {{.repo}} 2>&1 || true) \
+			| match "has no validation policy"
+		`,
+		"repo", tu.UniqueString("TestValidationCommands-repo"),
+	).Run())
+}
+
+func TestViewCommands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	c, _ := minikubetestenv.AcquireCluster(t)
+	require.NoError(t, tu.PachctlBashCmd(t, c, `
+		pachctl create repo {{.images}}
+		pachctl create repo {{.labels}}
+		echo "image" | pachctl put file {{.images}}@master:/1.png
+		echo "label" | pachctl put file {{.labels}}@master:/labels/1.txt
+
+		pachctl create view {{.view}} \
+			--source /images={{.images}}@master \
+			--source /labels={{.labels}}@master:/labels
+		pachctl inspect view {{.view}} \
+			| match "/images <- {{.images}}@master:/" \
+			| match "/labels <- {{.labels}}@master:/labels"
+		pachctl list view \
+			| match {{.view}}
+
+		pachctl wait commit {{.view}}@master
+		pachctl get file {{.view}}@master:/images/1.png \
+			| match image
+		pachctl get file {{.view}}@master:/labels/1.txt \
+			| match label
+
+		(pachctl delete repo {{.view}} 2>&1 || true) \
+			| match "delete the view instead"
+		pachctl delete view {{.view}}
+		pachctl list repo \
+			| match -v {{.view}}
+		`,
+		"images", tu.UniqueString("TestViewCommands-images"),
+		"labels", tu.UniqueString("TestViewCommands-labels"),
+		"view", tu.UniqueString("TestViewCommands-view"),
+	).Run())
+}
+
+func TestSyncDirCommands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	c, _ := minikubetestenv.AcquireCluster(t)
+	require.NoError(t, tu.PachctlBashCmd(t, c, `
+		pachctl create repo {{.repo}}
+		dir=$(mktemp -d)
+		mkdir -p $dir/sub
+		echo foo > $dir/a
+		echo bar > $dir/sub/b
+
+		pachctl sync dir $dir {{.repo}}@master:/data \
+			| match "upload /data/a" \
+			| match "upload /data/sub/b"
+		pachctl get file {{.repo}}@master:/data/sub/b \
+			| match bar
+
+		pachctl sync dir $dir {{.repo}}@master:/data \
+			| match "up to date"
+
+		echo baz > $dir/a
+		rm $dir/sub/b
+		pachctl sync dir $dir {{.repo}}@master:/data --dry-run \
+			| match "upload /data/a" \
+			| match "delete /data/sub/b"
+		pachctl get file {{.repo}}@master:/data/a \
+			| match foo
+		pachctl sync dir $dir {{.repo}}@master:/data \
+			| match "uploaded 1, deleted 1"
+		pachctl get file {{.repo}}@master:/data/a \
+			| match baz
+		pachctl list file {{.repo}}@master:/data/sub \
+			| match -v /data/sub/b
+		`,
+		"repo", tu.UniqueString("TestSyncDirCommands"),
+	).Run())
+}
@@ -0,0 +1,249 @@
+package cmds
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/cmdutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+// syncFile is a file on one side of a directory sync, keyed by its path
+// relative to the synced directory.
+type syncFile struct {
+	sizeBytes int64
+	// hash is only set for remote files. Local files are hashed lazily,
+	// since only the ones with the same size as their remote file need it.
+	hash []byte
+}
+
+// syncDiff is the set of changes that make a remote directory match a local
+// one. Paths are relative to the synced directory.
+type syncDiff struct {
+	uploads []string
+	deletes []string
+}
+
+func (d *syncDiff) empty() bool {
+	return len(d.uploads) == 0 && len(d.deletes) == 0
+}
+
+// diffDir compares local files against remote ones. A local file is uploaded
+// if it's missing remotely, has a different size, or has the same size but a
+// different hash, which is computed by hashLocal. Remote files that don't
+// exist locally are deleted if del is set.
+func diffDir(local, remote map[string]*syncFile, del bool, hashLocal func(string) ([]byte, error)) (*syncDiff, error) {
+	d := &syncDiff{}
+	for p, lf := range local {
+		rf, ok := remote[p]
+		if !ok || rf.sizeBytes != lf.sizeBytes {
+			d.uploads = append(d.uploads, p)
+			continue
+		}
+		hash, err := hashLocal(p)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(hash, rf.hash) {
+			d.uploads = append(d.uploads, p)
+		}
+	}
+	if del {
+		for p := range remote {
+			if _, ok := local[p]; !ok {
+				d.deletes = append(d.deletes, p)
+			}
+		}
+	}
+	sort.Strings(d.uploads)
+	sort.Strings(d.deletes)
+	return d, nil
+}
+
+// localDirFiles returns the regular files under dir, keyed by their
+// slash-separated path relative to dir.
+func localDirFiles(dir string) (map[string]*syncFile, error) {
+	files := make(map[string]*syncFile)
+	if err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		files["/"+filepath.ToSlash(rel)] = &syncFile{sizeBytes: info.Size()}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// remoteDirFiles returns the files under dir in the head of branch, keyed by
+// their path relative to dir. A branch or directory that doesn't exist yet
+// has no files.
+func remoteDirFiles(c *client.APIClient, branch *pfs.Branch, dir string) (map[string]*syncFile, error) {
+	files := make(map[string]*syncFile)
+	commitInfo, err := c.InspectCommit(branch.Repo.Name, branch.Name, "")
+	if err != nil {
+		if errutil.IsNotFoundError(err) {
+			return files, nil
+		}
+		return nil, err
+	}
+	if err := c.WalkFile(commitInfo.Commit, dir, func(fi *pfs.FileInfo) error {
+		if fi.FileType != pfs.FileType_FILE {
+			return nil
+		}
+		rel := "/" + strings.TrimPrefix(strings.TrimPrefix(fi.File.Path, dir), "/")
+		files[rel] = &syncFile{sizeBytes: fi.SizeBytes, hash: fi.Hash}
+		return nil
+	}); err != nil && !errutil.IsNotFoundError(err) {
+		return nil, err
+	}
+	return files, nil
+}
+
+// syncDir makes dst, a directory of the head of branch, match the local
+// directory src, by uploading the files that differ and deleting the ones
+// that no longer exist locally in a single commit.
+func syncDir(c *client.APIClient, src string, branch *pfs.Branch, dst string, del, dryRun bool) (*syncDiff, error) {
+	local, err := localDirFiles(src)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := remoteDirFiles(c, branch, dst)
+	if err != nil {
+		return nil, err
+	}
+	diff, err := diffDir(local, remote, del, func(p string) ([]byte, error) {
+		f, err := os.Open(filepath.Join(src, filepath.FromSlash(p)))
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		defer f.Close()
+		return fileset.ComputeHash(f)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if dryRun || diff.empty() {
+		return diff, nil
+	}
+	commit := client.NewCommit(branch.Repo.Name, branch.Name, "")
+	if err := c.WithModifyFileClient(commit, func(mf client.ModifyFile) error {
+		for _, p := range diff.deletes {
+			if err := mf.DeleteFile(path.Join(dst, p)); err != nil {
+				return err
+			}
+		}
+		for _, p := range diff.uploads {
+			if err := func() error {
+				f, err := os.Open(filepath.Join(src, filepath.FromSlash(p)))
+				if err != nil {
+					return errors.EnsureStack(err)
+				}
+				defer f.Close()
+				return mf.PutFile(path.Join(dst, p), f)
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+func dirSyncCmds() []*cobra.Command {
+	var commands []*cobra.Command
+
+	var del, dryRun bool
+	syncDirCmd := &cobra.Command{
+		Use:   "{{alias}} <local-dir> <repo>@<branch>[:<path>]",
+		Short: "Sync a local directory to a directory of a branch.",
+		Long: `Sync a local directory to a directory of a branch.
+
+Local files are compared against the files in the head of the branch by size,
+and by content hash if their sizes match, so files that haven't changed
+aren't uploaded again. Files that differ are uploaded, and files that no
+longer exist locally are deleted, in a single commit. No commit is made if
+nothing changed. The branch is created if it doesn't exist.`,
+		Example: `
+# sync the data/ directory into the root of foo@master
+$ {{alias}} ./data foo@master
+
+# sync the data/ directory into /data of foo@dev, keeping files deleted locally
+$ {{alias}} ./data foo@dev:/data --delete=false
+
+# show what would be uploaded and deleted, without committing
+$ {{alias}} ./data foo@master --dry-run`,
+		Run: cmdutil.RunFixedArgs(2, func(args []string) error {
+			info, err := os.Stat(args[0])
+			if err != nil {
+				return errors.EnsureStack(err)
+			}
+			if !info.IsDir() {
+				return errors.Errorf("%s is not a directory", args[0])
+			}
+			file, err := cmdutil.ParseFile(args[1])
+			if err != nil {
+				return err
+			}
+			branch := file.Commit.Branch
+			if file.Commit.ID != "" {
+				return errors.Errorf("can only sync to a branch, not commit %s", file.Commit.ID)
+			}
+			if branch.Name == "" {
+				branch.Name = "master"
+			}
+			c, err := newClient("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			dst := path.Join("/", file.Path)
+			diff, err := syncDir(c, args[0], branch, dst, del, dryRun)
+			if err != nil {
+				return grpcutil.ScrubGRPC(err)
+			}
+			for _, p := range diff.uploads {
+				fmt.Printf("upload %s\n", path.Join(dst, p))
+			}
+			for _, p := range diff.deletes {
+				fmt.Printf("delete %s\n", path.Join(dst, p))
+			}
+			switch {
+			case diff.empty():
+				fmt.Printf("%s is up to date with %s\n", args[1], args[0])
+			case dryRun:
+				fmt.Printf("dry run: %d to upload, %d to delete\n", len(diff.uploads), len(diff.deletes))
+			default:
+				fmt.Printf("uploaded %d, deleted %d\n", len(diff.uploads), len(diff.deletes))
+			}
+			return nil
+		}),
+	}
+	syncDirCmd.Flags().BoolVar(&del, "delete", true, "Delete files from the branch that don't exist in the local directory.")
+	syncDirCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the changes that would be made, without committing them.")
+	commands = append(commands, cmdutil.CreateAlias(syncDirCmd, "sync dir"))
+
+	return commands
+}
@@ -0,0 +1,69 @@
+package cmds
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
+)
+
+func TestDiffDir(t *testing.T) {
+	hash := func(s string) []byte {
+		h, err := fileset.ComputeHash(strings.NewReader(s))
+		require.NoError(t, err)
+		return h
+	}
+	content := map[string]string{
+		"/same":      "foo",
+		"/changed":   "bar",
+		"/resized":   "bazbaz",
+		"/new":       "new",
+		"/dir/same":  "foo",
+		"/dir/added": "added",
+	}
+	local := make(map[string]*syncFile)
+	for p, s := range content {
+		local[p] = &syncFile{sizeBytes: int64(len(s))}
+	}
+	remote := map[string]*syncFile{
+		"/same":     {sizeBytes: 3, hash: hash("foo")},
+		"/changed":  {sizeBytes: 3, hash: hash("baz")},
+		"/resized":  {sizeBytes: 3, hash: hash("baz")},
+		"/dir/same": {sizeBytes: 3, hash: hash("foo")},
+		"/removed":  {sizeBytes: 3, hash: hash("old")},
+	}
+	var hashed []string
+	hashLocal := func(p string) ([]byte, error) {
+		hashed = append(hashed, p)
+		return hash(content[p]), nil
+	}
+	diff, err := diffDir(local, remote, true, hashLocal)
+	require.NoError(t, err)
+	require.Equal(t, []string{"/changed", "/dir/added", "/new", "/resized"}, diff.uploads)
+	require.Equal(t, []string{"/removed"}, diff.deletes)
+	// Only files with the same size as their remote file are hashed.
+	require.ElementsEqual(t, []string{"/same", "/changed", "/dir/same"}, hashed)
+
+	diff, err = diffDir(local, remote, false, hashLocal)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(diff.deletes))
+
+	diff, err = diffDir(remote, remote, true, func(p string) ([]byte, error) { return remote[p].hash, nil })
+	require.NoError(t, err)
+	require.True(t, diff.empty())
+}
+
+func TestLocalDirFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "a", "b"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "top"), []byte("12345"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a", "b", "nested"), []byte("1"), 0644))
+	files, err := localDirFiles(dir)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(files))
+	require.Equal(t, int64(5), files["/top"].sizeBytes)
+	require.Equal(t, int64(1), files["/a/b/nested"].sizeBytes)
+}
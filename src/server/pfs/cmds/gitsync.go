@@ -0,0 +1,107 @@
+package cmds
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/cmdutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/gitsync"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+)
+
+func gitSyncCmds() []*cobra.Command {
+	var commands []*cobra.Command
+
+	var ref, subdir, username, listen string
+	syncGit := &cobra.Command{
+		Use:   "{{alias}} <git-url> <repo>@<branch>",
+		Short: "Sync a branch from a Git repository.",
+		Long: `Sync a branch from a Git repository.
+
+Each Git commit on the first-parent history of the Git branch becomes a commit
+on the Pachyderm branch containing the files it changed. The Git commit
+message is kept as the commit description, followed by 'git-commit',
+'git-author' and 'git-date' trailers. The trailers on the head of the branch
+record where the next sync resumes; the first sync only imports the tip of the
+Git branch. The repo is created if it doesn't exist.
+
+For private repositories, the password or access token is read from the
+GIT_PASSWORD environment variable.
+
+With --listen, a sync is run and then a webhook is served that syncs on every
+push to the Git branch. Set GIT_WEBHOOK_SECRET to the secret configured on the
+Git server to reject unsigned requests.`,
+		Example: `
+# import the deploy/ directory of a GitHub repository into config@master
+$ {{alias}} https://github.com/example/app.git config@master --ref main --path deploy
+
+# keep config@master in sync, triggered by push webhooks on port 8080
+$ GIT_WEBHOOK_SECRET=... {{alias}} https://github.com/example/app.git config@master --ref main --listen :8080`,
+		Run: cmdutil.RunFixedArgs(2, func(args []string) error {
+			branch, err := cmdutil.ParseBranch(args[1])
+			if err != nil {
+				return err
+			}
+			if branch.Name == "" {
+				branch.Name = "master"
+			}
+			c, err := newClient("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			syncer := gitsync.NewSyncer(c, gitsync.Spec{
+				URL:      args[0],
+				Ref:      ref,
+				Path:     subdir,
+				Username: username,
+				Password: os.Getenv("GIT_PASSWORD"),
+				Repo:     branch.Repo.Name,
+				Branch:   branch.Name,
+			})
+			printResult := func(res *gitsync.Result) {
+				for _, commit := range res.Commits {
+					fmt.Printf("synced %s\n", commit)
+				}
+				if len(res.Commits) == 0 {
+					fmt.Printf("%s is up to date with %s\n", args[1], res.Head)
+				}
+			}
+			if listen == "" {
+				res, err := syncer.SyncOnce(c.Ctx())
+				if err != nil {
+					return grpcutil.ScrubGRPC(err)
+				}
+				printResult(res)
+				return nil
+			}
+			hook := gitsync.NewWebhook(syncer, os.Getenv("GIT_WEBHOOK_SECRET"))
+			hook.Sync = func(res *gitsync.Result, err error) {
+				if err == nil {
+					printResult(res)
+				}
+			}
+			ctx, cancel := context.WithCancel(c.Ctx())
+			defer cancel()
+			go func() {
+				if err := hook.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+					fmt.Fprintf(os.Stderr, "%v\n", err)
+				}
+			}()
+			fmt.Printf("listening for push events on %s\n", listen)
+			return errors.EnsureStack(http.ListenAndServe(listen, hook))
+		}),
+	}
+	syncGit.Flags().StringVar(&ref, "ref", "", "The Git branch to sync, defaults to the remote's default branch.")
+	syncGit.Flags().StringVar(&subdir, "path", "", "The subdirectory of the Git repository to sync, defaults to the whole repository.")
+	syncGit.Flags().StringVar(&username, "username", "", "The username for HTTP authentication to the Git server.")
+	syncGit.Flags().StringVar(&listen, "listen", "", "If set, serve a push webhook on this address (e.g. \":8080\") and sync on every push.")
+	commands = append(commands, cmdutil.CreateAlias(syncGit, "sync git"))
+
+	return commands
+}
@@ -0,0 +1,134 @@
+package cmds
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+// errHeadDone is returned by a headWriter once it has written all the lines
+// it may, to stop the file it's writing from being downloaded any further.
+var errHeadDone = errors.New("head done")
+
+// headWriter writes the first n lines written to it to w.
+type headWriter struct {
+	w io.Writer
+	n int
+}
+
+func (hw *headWriter) Write(data []byte) (int, error) {
+	written := 0
+	for hw.n > 0 && written < len(data) {
+		end := len(data)
+		if i := bytes.IndexByte(data[written:], '\n'); i >= 0 {
+			end = written + i + 1
+			hw.n--
+		}
+		if _, err := hw.w.Write(data[written:end]); err != nil {
+			return written, errors.EnsureStack(err)
+		}
+		written = end
+	}
+	if hw.n <= 0 {
+		return written, errHeadDone
+	}
+	return written, nil
+}
+
+// headFile writes the first lines lines of file to w, or the first
+// numBytes bytes if numBytes is positive, without downloading the rest of it.
+func headFile(c *client.APIClient, file *pfs.File, lines int, numBytes int64, w io.Writer) error {
+	if numBytes > 0 {
+		return c.GetFile(file.Commit, file.Path, w, client.WithSizeBytes(numBytes))
+	}
+	if lines <= 0 {
+		return nil
+	}
+	if err := c.GetFile(file.Commit, file.Path, &headWriter{w: w, n: lines}); err != nil && !errors.Is(err, errHeadDone) {
+		return err
+	}
+	return nil
+}
+
+// tailFile writes the last lines lines of file to w, or the last numBytes
+// bytes if numBytes is positive. It only downloads the end of the file, reading
+// further back until it has found enough lines.
+func tailFile(c *client.APIClient, file *pfs.File, lines int, numBytes int64, w io.Writer) error {
+	fi, err := c.InspectFile(file.Commit, file.Path)
+	if err != nil {
+		return err
+	}
+	if fi.FileType != pfs.FileType_FILE {
+		return errors.Errorf("%s is not a file", file.Path)
+	}
+	size := fi.SizeBytes
+	if numBytes > 0 {
+		offset := size - numBytes
+		if offset < 0 {
+			offset = 0
+		}
+		return c.GetFile(file.Commit, file.Path, w, client.WithOffset(offset))
+	}
+	if lines <= 0 || size == 0 {
+		return nil
+	}
+	for window := int64(64 * 1024); ; window *= 2 {
+		offset := size - window
+		if offset < 0 {
+			offset = 0
+		}
+		buf := &bytes.Buffer{}
+		if err := c.GetFile(file.Commit, file.Path, buf, client.WithOffset(offset)); err != nil {
+			return err
+		}
+		data := buf.Bytes()
+		// A trailing newline ends the last line rather than starting another.
+		start, found := len(bytes.TrimSuffix(data, []byte("\n"))), 0
+		for found < lines {
+			i := bytes.LastIndexByte(data[:start], '\n')
+			if i < 0 {
+				break
+			}
+			start = i
+			found++
+		}
+		if found == lines {
+			_, err := w.Write(data[start+1:])
+			return errors.EnsureStack(err)
+		}
+		if offset == 0 {
+			_, err := w.Write(data)
+			return errors.EnsureStack(err)
+		}
+	}
+}
+
+// sampleFile writes a random sample of the lines of file to w, each of which
+// is kept with probability fraction. The sampling happens in pachd, so only
+// the sampled lines are downloaded. If file is a directory, it writes a
+// random sample of the paths of the files under it instead.
+func sampleFile(c *client.APIClient, file *pfs.File, fraction float64, seed int64, w io.Writer) error {
+	if fraction < 0 || fraction > 1 {
+		return errors.Errorf("fraction must be between 0 and 1, got %v", fraction)
+	}
+	fi, err := c.InspectFile(file.Commit, file.Path)
+	if err != nil {
+		return err
+	}
+	if fi.FileType == pfs.FileType_FILE {
+		return c.GetFile(file.Commit, file.Path, w, client.WithSample(fraction, seed))
+	}
+	r := rand.New(rand.NewSource(seed))
+	return c.WalkFile(file.Commit, file.Path, func(fi *pfs.FileInfo) error {
+		if fi.FileType != pfs.FileType_FILE || r.Float64() >= fraction {
+			return nil
+		}
+		_, err := fmt.Fprintln(w, fi.File.Path)
+		return errors.EnsureStack(err)
+	})
+}
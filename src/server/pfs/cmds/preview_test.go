@@ -0,0 +1,21 @@
+package cmds
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestHeadWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &headWriter{w: &buf, n: 2}
+	n, err := w.Write([]byte("a\nb"))
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	n, err = w.Write([]byte("c\nd\ne\n"))
+	require.True(t, errors.Is(err, errHeadDone))
+	require.Equal(t, 2, n)
+	require.Equal(t, "a\nbc\n", buf.String())
+}
@@ -0,0 +1,168 @@
+package cmds
+
+import (
+	"fmt"
+	"os"
+
+	units "github.com/docker/go-units"
+	"github.com/spf13/cobra"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/cmdutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/replication"
+	"github.com/pachyderm/pachyderm/v2/src/internal/tabwriter"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/server/cmd/pachctl/shell"
+)
+
+const (
+	// replicationHeader is the header for replication status.
+	replicationHeader = "BRANCH\tLAST REPLICATED\tPENDING\tLAG\t\n"
+)
+
+func replicationCmds() []*cobra.Command {
+	var commands []*cobra.Command
+
+	var to, from, remoteToken string
+	remoteFlags := func(cmd *cobra.Command) {
+		cmd.Flags().StringVar(&to, "to", "", "The address of the cluster to push commits to.")
+		cmd.Flags().StringVar(&from, "from", "", "The address of the cluster to pull commits from.")
+		cmd.Flags().StringVar(&remoteToken, "remote-token", "", "The auth token to use for the remote cluster.")
+	}
+	// newReplicator connects to the remote cluster and returns a replicator
+	// in the direction specified by --to or --from.
+	newReplicator := func(c *client.APIClient) (*replication.Replicator, func(), error) {
+		if (to == "") == (from == "") {
+			return nil, nil, errors.New("exactly one of --to or --from must be set")
+		}
+		addr := to
+		if from != "" {
+			addr = from
+		}
+		remote, err := client.NewFromURI(addr)
+		if err != nil {
+			return nil, nil, err
+		}
+		if remoteToken != "" {
+			remote.SetAuthToken(remoteToken)
+		}
+		closeRemote := func() { remote.Close() }
+		if to != "" {
+			return replication.NewReplicator(c, remote), closeRemote, nil
+		}
+		return replication.NewReplicator(remote, c), closeRemote, nil
+	}
+	parseSpecs := func(args []string) ([]replication.Spec, error) {
+		branches, err := cmdutil.ParseBranches(args)
+		if err != nil {
+			return nil, err
+		}
+		var specs []replication.Spec
+		for _, b := range branches {
+			if b.Name == "" {
+				b.Name = "master"
+			}
+			specs = append(specs, replication.Spec{Repo: b.Repo.Name, Branch: b.Name})
+		}
+		return specs, nil
+	}
+
+	var follow bool
+	replicateBranch := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch> ...",
+		Short: "Replicate branches to or from another cluster.",
+		Long: `Replicate branches to or from another cluster.
+
+Finished commits on each branch that are missing from the other cluster are
+copied over, oldest first. Only files that changed between commits are
+transferred. The destination repo is created if it doesn't exist.`,
+		Example: `
+# push master of "images" to another cluster
+$ {{alias}} images@master --to grpc://dr.example.com:30650
+
+# continuously pull master of "images" from another cluster
+$ {{alias}} images@master --from grpc://prod.example.com:30650 --follow`,
+		Run: cmdutil.RunMinimumArgs(1, func(args []string) error {
+			specs, err := parseSpecs(args)
+			if err != nil {
+				return err
+			}
+			if follow && len(specs) != 1 {
+				return errors.New("--follow can only be used with a single branch")
+			}
+			c, err := newClient("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			r, closeRemote, err := newReplicator(c)
+			if err != nil {
+				return err
+			}
+			defer closeRemote()
+			if follow {
+				return r.Follow(specs[0], func(ci *pfs.CommitInfo) error {
+					fmt.Printf("replicated %s\n", ci.Commit.ID)
+					return nil
+				})
+			}
+			for _, spec := range specs {
+				n, err := r.ReplicateOnce(spec)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("%s: replicated %d commit(s)\n", spec, n)
+			}
+			return nil
+		}),
+	}
+	remoteFlags(replicateBranch)
+	replicateBranch.Flags().BoolVarP(&follow, "follow", "f", false, "Keep replicating new commits as they are finished.")
+	shell.RegisterCompletionFunc(replicateBranch, shell.BranchCompletion)
+	commands = append(commands, cmdutil.CreateAlias(replicateBranch, "replicate branch"))
+
+	inspectReplication := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch> ...",
+		Short: "Return the replication status of branches.",
+		Long:  "Return the replication status of branches, including the number of commits not yet replicated and how long the oldest of them has been waiting.",
+		Run: cmdutil.RunMinimumArgs(1, func(args []string) error {
+			specs, err := parseSpecs(args)
+			if err != nil {
+				return err
+			}
+			c, err := newClient("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			r, closeRemote, err := newReplicator(c)
+			if err != nil {
+				return err
+			}
+			defer closeRemote()
+			writer := tabwriter.NewWriter(os.Stdout, replicationHeader)
+			for _, spec := range specs {
+				s, err := r.Status(spec)
+				if err != nil {
+					return err
+				}
+				last := s.LastReplicated
+				if last == "" {
+					last = "-"
+				}
+				lag := "-"
+				if s.Pending > 0 {
+					lag = units.HumanDuration(s.Lag)
+				}
+				fmt.Fprintf(writer, "%s\t%s\t%d\t%s\t\n", spec, last, s.Pending, lag)
+			}
+			return writer.Flush()
+		}),
+	}
+	remoteFlags(inspectReplication)
+	shell.RegisterCompletionFunc(inspectReplication, shell.BranchCompletion)
+	commands = append(commands, cmdutil.CreateAlias(inspectReplication, "inspect replication"))
+
+	return commands
+}
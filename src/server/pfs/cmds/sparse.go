@@ -0,0 +1,247 @@
+package cmds
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	globlib "github.com/pachyderm/ohmyglob"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+// sparseStateFile is the file in the output directory of a sparse download
+// that records the files that have been downloaded, so that an interrupted
+// download can be resumed. It's removed once the download completes.
+const sparseStateFile = ".pachctl-get-file.state"
+
+type manifestRule struct {
+	exclude bool
+	match   func(string) bool
+}
+
+// manifest selects the paths downloaded by a sparse 'get file'. Each line of
+// a manifest is a glob pattern that includes the paths it matches, or
+// excludes them if it starts with '!'. A pattern that ends in '/' matches
+// every path under that prefix. Blank lines and lines starting with '#' are
+// ignored. The last pattern that matches a path decides whether it's
+// included, and paths that no pattern matches are only included if the
+// manifest has no include patterns.
+type manifest struct {
+	rules      []manifestRule
+	hasInclude bool
+}
+
+func parseManifest(r io.Reader) (*manifest, error) {
+	m := &manifest{}
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := manifestRule{}
+		if strings.HasPrefix(line, "!") {
+			rule.exclude = true
+			line = strings.TrimSpace(line[1:])
+		}
+		pattern := "/" + strings.TrimPrefix(line, "/")
+		if strings.HasSuffix(pattern, "/") {
+			pattern += "**"
+		}
+		g, err := globlib.Compile(pattern, '/')
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern on line %d of manifest", lineNum)
+		}
+		rule.match = g.Match
+		m.rules = append(m.rules, rule)
+		m.hasInclude = m.hasInclude || !rule.exclude
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return m, nil
+}
+
+// includes returns whether the manifest selects p, a path relative to the
+// directory being downloaded.
+func (m *manifest) includes(p string) bool {
+	included := !m.hasInclude
+	for _, rule := range m.rules {
+		if rule.match(p) {
+			included = !rule.exclude
+		}
+	}
+	return included
+}
+
+// sparseState is the set of files a sparse download has already written,
+// keyed by their path relative to the output directory.
+type sparseState struct {
+	mu     sync.Mutex
+	hashes map[string]string
+	f      *os.File
+}
+
+// openSparseState reads the state left in dir by an earlier, interrupted
+// download, and opens it to record the files that are downloaded next.
+func openSparseState(dir string) (*sparseState, error) {
+	s := &sparseState{hashes: make(map[string]string)}
+	statePath := filepath.Join(dir, sparseStateFile)
+	if f, err := os.Open(statePath); err == nil {
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			// A line may be truncated if the download was killed while
+			// writing it, in which case its file is downloaded again.
+			parts := strings.SplitN(scanner.Text(), " ", 2)
+			if len(parts) == 2 {
+				s.hashes[parts[1]] = parts[0]
+			}
+		}
+		err := scanner.Err()
+		f.Close()
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, errors.EnsureStack(err)
+	}
+	f, err := os.OpenFile(statePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	s.f = f
+	return s, nil
+}
+
+// done returns whether the file at p, with hash, was already downloaded and
+// hasn't been changed or removed since.
+func (s *sparseState) done(dir, p, hash string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.hashes[p] != hash {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(dir, filepath.FromSlash(p)))
+	return err == nil
+}
+
+func (s *sparseState) record(p, hash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hashes[p] = hash
+	_, err := fmt.Fprintf(s.f, "%s %s\n", hash, p)
+	return errors.EnsureStack(err)
+}
+
+// sparseGetFile downloads the files under file that m includes to
+// outputPath, with parallelism files downloaded at once. Files already
+// downloaded by an earlier, interrupted call with the same outputPath are
+// skipped if they haven't changed.
+func sparseGetFile(c *client.APIClient, file *pfs.File, outputPath string, m *manifest, parallelism int) (retErr error) {
+	// Resolve the commit first, so that every file comes from the same one
+	// even if its branch moves during the download.
+	commitInfo, err := c.InspectCommit(file.Commit.Branch.Repo.Name, file.Commit.Branch.Name, file.Commit.ID)
+	if err != nil {
+		return err
+	}
+	commit := commitInfo.Commit
+	root, err := c.InspectFile(commit, file.Path)
+	if err != nil {
+		return err
+	}
+	relPath := func(p string) string {
+		if root.FileType == pfs.FileType_FILE {
+			return "/" + path.Base(p)
+		}
+		return "/" + strings.TrimPrefix(strings.TrimPrefix(p, root.File.Path), "/")
+	}
+	if err := os.MkdirAll(outputPath, 0755); err != nil {
+		return errors.EnsureStack(err)
+	}
+	state, err := openSparseState(outputPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := state.f.Close(); retErr == nil {
+			retErr = errors.EnsureStack(err)
+		}
+		if retErr == nil {
+			retErr = errors.EnsureStack(os.Remove(filepath.Join(outputPath, sparseStateFile)))
+		}
+	}()
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	eg, ctx := errgroup.WithContext(c.Ctx())
+	fileInfos := make(chan *pfs.FileInfo)
+	for i := 0; i < parallelism; i++ {
+		eg.Go(func() error {
+			for fi := range fileInfos {
+				if err := sparseGetOneFile(c.WithCtx(ctx), fi, outputPath, relPath(fi.File.Path), state); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	eg.Go(func() error {
+		defer close(fileInfos)
+		return c.WithCtx(ctx).WalkFile(commit, root.File.Path, func(fi *pfs.FileInfo) error {
+			if fi.FileType != pfs.FileType_FILE {
+				return nil
+			}
+			p := relPath(fi.File.Path)
+			if !m.includes(p) || state.done(outputPath, p, hex.EncodeToString(fi.Hash)) {
+				return nil
+			}
+			select {
+			case fileInfos <- fi:
+				return nil
+			case <-ctx.Done():
+				return errors.EnsureStack(ctx.Err())
+			}
+		})
+	})
+	return errors.EnsureStack(eg.Wait())
+}
+
+// sparseGetOneFile downloads a file to a temporary path, and moves it into
+// place once it's complete, so an interrupted download never leaves a
+// partial file behind under the file's name.
+func sparseGetOneFile(c *client.APIClient, fi *pfs.FileInfo, outputPath, p string, state *sparseState) (retErr error) {
+	dst := filepath.Join(outputPath, filepath.FromSlash(p))
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return errors.EnsureStack(err)
+	}
+	tmp := dst + ".part"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil && retErr == nil && !errors.Is(err, os.ErrClosed) {
+			retErr = errors.EnsureStack(err)
+		}
+	}()
+	if err := c.GetFile(fi.File.Commit, fi.File.Path, f); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		return errors.EnsureStack(err)
+	}
+	return state.record(p, hex.EncodeToString(fi.Hash))
+}
@@ -0,0 +1,61 @@
+package cmds
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestManifest(t *testing.T) {
+	m, err := parseManifest(strings.NewReader(`
+# images from 2022, except temporary files
+images/2022/
+!/images/**/*.tmp
+/labels/*.json
+`))
+	require.NoError(t, err)
+	for p, expected := range map[string]bool{
+		"/images/2022/a.png":       true,
+		"/images/2022/dir/b.png":   true,
+		"/images/2022/dir/c.tmp":   false,
+		"/images/2021/a.png":       false,
+		"/labels/a.json":           true,
+		"/labels/dir/a.json":       false,
+		"/labels/a.csv":            false,
+		"/images/2022.png":         false,
+		"/unrelated/images/2022/a": false,
+	} {
+		require.Equal(t, expected, m.includes(p), p)
+	}
+
+	// Without include patterns, everything that isn't excluded is included.
+	m, err = parseManifest(strings.NewReader("!*.tmp\n"))
+	require.NoError(t, err)
+	require.True(t, m.includes("/a.png"))
+	require.False(t, m.includes("/a.tmp"))
+
+	_, err = parseManifest(strings.NewReader("/images/[\n"))
+	require.YesError(t, err)
+}
+
+func TestSparseState(t *testing.T) {
+	dir := t.TempDir()
+	s, err := openSparseState(dir)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a"), []byte("a"), 0644))
+	require.NoError(t, s.record("/a", "hash-a"))
+	require.NoError(t, s.record("/b", "hash-b"))
+	require.NoError(t, s.f.Close())
+
+	// A resumed download skips files that are unchanged and still present.
+	s, err = openSparseState(dir)
+	require.NoError(t, err)
+	defer s.f.Close()
+	require.True(t, s.done(dir, "/a", "hash-a"))
+	require.False(t, s.done(dir, "/a", "hash-a2"))
+	require.False(t, s.done(dir, "/b", "hash-b"))
+	require.False(t, s.done(dir, "/c", ""))
+}
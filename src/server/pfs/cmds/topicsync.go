@@ -0,0 +1,101 @@
+package cmds
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/cmdutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/topicsync"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+func topicSyncCmds() []*cobra.Command {
+	var commands []*cobra.Command
+
+	var subdir, file string
+	var batchSize int
+	var batchInterval time.Duration
+	syncTopic := &cobra.Command{
+		Use:   "{{alias}} <topic-url> <repo>@<branch>",
+		Short: "Sync a branch from a Kafka topic or NATS subject.",
+		Long: `Sync a branch from a Kafka topic or NATS subject.
+
+Messages are committed to the branch in batches, each message in its own file
+or, with --file, appended to a single file one per line. A commit is made when
+a batch reaches --batch-size messages, or --batch-interval after its first
+message. The repo is created if it doesn't exist. The sync runs until it's
+interrupted.
+
+Topic URLs are of the form kafka://broker-1:9092,broker-2:9092/topic or
+nats://[user:password@|token@]host:4222/subject. Kafka messages are named
+'<partition>/<offset>', and the offsets after the last synced message of each
+partition are recorded in the commit description as 'kafka-offset' trailers,
+which is where the next sync resumes. NATS messages are only received while
+the sync is running.
+
+This command can also be the transform of a spout pipeline, committing to the
+pipeline's output repo.`,
+		Example: `
+# ingest a Kafka topic into events@master, one file per message
+$ {{alias}} kafka://kafka:9092/events events@master
+
+# append the messages of a NATS subject to readings@master:/readings.jsonl every minute
+$ {{alias}} nats://nats:4222/sensors.readings readings@master --file readings.jsonl --batch-interval 1m`,
+		Run: cmdutil.RunFixedArgs(2, func(args []string) error {
+			branch, err := cmdutil.ParseBranch(args[1])
+			if err != nil {
+				return err
+			}
+			c, err := newClient("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			syncer, err := topicsync.NewSyncer(c, topicsync.Spec{
+				URL:           args[0],
+				Repo:          branch.Repo.Name,
+				Branch:        branch.Name,
+				Path:          subdir,
+				File:          file,
+				BatchSize:     batchSize,
+				BatchInterval: batchInterval,
+			})
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithCancel(c.Ctx())
+			defer cancel()
+			sigChan := make(chan os.Signal, 1)
+			signal.Notify(sigChan, os.Interrupt)
+			defer signal.Stop(sigChan)
+			go func() {
+				select {
+				case <-sigChan:
+					cancel()
+				case <-ctx.Done():
+				}
+			}()
+			err = syncer.Run(ctx, func(commit *pfs.Commit, n int) {
+				fmt.Printf("synced %d message(s) in %s\n", n, commit.ID)
+			})
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return grpcutil.ScrubGRPC(err)
+		}),
+	}
+	syncTopic.Flags().StringVar(&subdir, "path", "", "The directory messages are written to, defaults to the root.")
+	syncTopic.Flags().StringVar(&file, "file", "", "If set, append messages to this file under --path, one per line, instead of writing each to its own file.")
+	syncTopic.Flags().IntVar(&batchSize, "batch-size", 1000, "The maximum number of messages in a commit.")
+	syncTopic.Flags().DurationVar(&batchInterval, "batch-interval", 10*time.Second, "The longest a message waits before it's committed.")
+	commands = append(commands, cmdutil.CreateAlias(syncTopic, "sync topic"))
+
+	return commands
+}
@@ -14,6 +14,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/hanwen/go-fuse/v2/fs"
@@ -167,12 +168,13 @@ type MountManager struct {
 	// it. i.e. when we try to mount it for the first time.
 	States map[string]*MountStateMachine
 	// map from mount name onto mfc for that mount
-	mfcs   map[string]*client.ModifyFileClient
-	root   *loopbackRoot
-	opts   *Options
-	tmpDir string
-	target string
-	mu     sync.Mutex
+	mfcs     map[string]*client.ModifyFileClient
+	sessions *sessionManager
+	root     *loopbackRoot
+	opts     *Options
+	tmpDir   string
+	target   string
+	mu       sync.Mutex
 }
 
 func (mm *MountManager) ListByRepos() (ListRepoResponse, error) {
@@ -367,14 +369,15 @@ func NewMountManager(c *client.APIClient, target string, opts *Options) (ret *Mo
 	}
 	logrus.Infof("Loopback root at %s", rootDir)
 	return &MountManager{
-		Client: c,
-		States: map[string]*MountStateMachine{},
-		mfcs:   map[string]*client.ModifyFileClient{},
-		root:   root,
-		opts:   opts,
-		target: target,
-		tmpDir: rootDir,
-		mu:     sync.Mutex{},
+		Client:   c,
+		States:   map[string]*MountStateMachine{},
+		mfcs:     map[string]*client.ModifyFileClient{},
+		sessions: newSessionManager(),
+		root:     root,
+		opts:     opts,
+		target:   target,
+		tmpDir:   rootDir,
+		mu:       sync.Mutex{},
 	}, nil
 }
 
@@ -737,14 +740,29 @@ func Server(c *client.APIClient, sopts *ServerOptions) error {
 		cfg.Write()
 		mm.Client.SetAuthToken("")
 	})
+	mm.sessionRoutes(router)
 
 	// TODO: switch http server for gRPC server and bind to a unix socket not a
 	// TCP port (just for convenient manual testing with curl for now...)
 	// TODO: make port and bind ip parameterizable
 	srv := &http.Server{Addr: ":9002", Handler: router}
 
+	stopReaping := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(sessionReapInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				mm.reapSessions()
+			case <-stopReaping:
+				return
+			}
+		}
+	}()
 	go func() {
 		err := mm.Start()
+		close(stopReaping)
 		if err != nil {
 			logrus.Infof("Error running mount manager: %s", err)
 			os.Exit(1)
@@ -826,6 +844,10 @@ func (mm *MountManager) updateClientEndpoint(reqPachdAddress string) (map[string
 				return nil, err
 			}
 			if clusterStatus["cluster_address"] != "INVALID" {
+				// sessions' clients point at the old cluster
+				if err := mm.EndAllSessions(); err != nil {
+					return nil, err
+				}
 				err := mm.UnmountAll()
 				if err != nil {
 					return nil, err
@@ -1271,7 +1293,12 @@ func (mm *MountManager) mfc(name string) (*client.ModifyFileClient, error) {
 	} else {
 		repoName = opts.Repo
 	}
-	mfc, err := mm.Client.NewModifyFileClient(client.NewCommit(repoName, mm.root.branch(name), ""))
+	// mounts created by a session upload with the session's token
+	c := mm.sessions.sessionClient(name)
+	if c == nil {
+		c = mm.Client
+	}
+	mfc, err := c.NewModifyFileClient(client.NewCommit(repoName, mm.root.branch(name), ""))
 	if err != nil {
 		return nil, err
 	}
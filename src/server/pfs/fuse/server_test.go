@@ -44,6 +44,26 @@ func get(path string) (*http.Response, error) {
 	return x, errors.EnsureStack(err)
 }
 
+func post(path string, body io.Reader) (*http.Response, error) {
+	client := &http.Client{}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:9002/%s", path), body)
+	if err != nil {
+		panic(err)
+	}
+	x, err := client.Do(req)
+	return x, errors.EnsureStack(err)
+}
+
+func del(path string) (*http.Response, error) {
+	client := &http.Client{}
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("http://localhost:9002/%s", path), nil)
+	if err != nil {
+		panic(err)
+	}
+	x, err := client.Do(req)
+	return x, errors.EnsureStack(err)
+}
+
 /*
 
 Tests to write:
@@ -592,3 +612,90 @@ func TestRwCommitUnmountCreatesTwoCommits(t *testing.T) {
 		require.Equal(t, len(commits), 2)
 	})
 }
+
+func TestSessionMountCommit(t *testing.T) {
+	// A session lists branches, mounts and commits, and records each action
+	// in its audit log.
+	env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+	require.NoError(t, env.PachClient.CreateRepo("repo"))
+	withServerMount(t, env.PachClient, nil, func(mountPoint string) {
+		resp, err := post("sessions", strings.NewReader(`{"ttl_seconds": 3600}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, 200, resp.StatusCode)
+		session := &Session{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(session))
+		require.True(t, session.Expires.After(time.Now()))
+
+		require.NoError(t, env.PachClient.CreateBranch("repo", "master", "", "", nil))
+		resp, err = get("sessions/" + session.ID + "/branches")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var branches []SessionBranch
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&branches))
+		require.Equal(t, []SessionBranch{{Repo: "repo", Branch: "master", Writable: true}}, branches)
+
+		resp, err = put("sessions/"+session.ID+"/repos/repo/master/_mount?name=repo&mode=rw", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, 200, resp.StatusCode)
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(session))
+		require.Equal(t, []string{"repo"}, session.Mounts)
+
+		before, err := env.PachClient.ListCommitByRepo(client.NewRepo("repo"))
+		require.NoError(t, err)
+		err = ioutil.WriteFile(
+			filepath.Join(mountPoint, "repo", "file1"), []byte("hello"), 0644,
+		)
+		require.NoError(t, err)
+		resp, err = put("sessions/"+session.ID+"/repos/repo/master/_commit?name=repo", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, 200, resp.StatusCode)
+		after, err := env.PachClient.ListCommitByRepo(client.NewRepo("repo"))
+		require.NoError(t, err)
+		require.Equal(t, len(before)+1, len(after))
+
+		// ending the session unmounts its mounts
+		resp, err = del("sessions/" + session.ID)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(session))
+		require.NotNil(t, session.Ended)
+		require.Equal(t, 0, len(session.Mounts))
+		resp, err = get("sessions/" + session.ID + "/branches")
+		require.NoError(t, err)
+		require.Equal(t, 401, resp.StatusCode)
+
+		resp, err = get("sessions/" + session.ID + "/audit")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var audit []AuditRecord
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&audit))
+		var actions []string
+		for _, rec := range audit {
+			require.Equal(t, "", rec.Error)
+			actions = append(actions, rec.Action)
+		}
+		require.Equal(t, []string{"create", "mount", "commit", "end"}, actions)
+	})
+}
+
+func TestSessionRequiresValidToken(t *testing.T) {
+	c, _ := minikubetestenv.AcquireCluster(t)
+	tu.ActivateAuthClient(t, c)
+	withServerMount(t, c, nil, func(mountPoint string) {
+		resp, err := post("sessions", strings.NewReader(`{"token": "not a token"}`))
+		require.NoError(t, err)
+		require.Equal(t, 401, resp.StatusCode)
+
+		alice := tu.AuthenticateClient(t, c, "alice")
+		resp, err = post("sessions", strings.NewReader(fmt.Sprintf(`{"token": %q}`, alice.AuthToken())))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, 200, resp.StatusCode)
+		session := &Session{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(session))
+		require.Equal(t, "robot:alice", session.User)
+	})
+}
@@ -0,0 +1,559 @@
+package fuse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+/*
+
+Sessions
+========
+
+Sessions let a frontend such as the JupyterLab extension act on behalf of a
+particular user, rather than as whoever the mount server itself is logged in
+as. A session is bound to a user's token when it's created, and:
+
+- lists only the branches of repos the user can read,
+- only mounts repos the user can read, and only mounts them "rw" if the user
+  can write to them,
+- uploads the changes to the mounts it created with the user's token, so that
+  commits are attributed to the user and checked against their permissions,
+- expires after its TTL (or when the token expires, if that's sooner), at which
+  point its mounts are unmounted, committing any pending changes.
+
+Every action taken through a session is recorded in the session's audit log,
+which is kept for sessionRetention after the session ends.
+
+POST   /sessions                                  {"token": ..., "ttl_seconds": ...}
+GET    /sessions/{id}
+DELETE /sessions/{id}
+GET    /sessions/{id}/branches
+PUT    /sessions/{id}/repos/{repo}/{branch}/_mount?name=...&mode=...
+PUT    /sessions/{id}/repos/{repo}/{branch}/_commit?name=...
+PUT    /sessions/{id}/repos/{repo}/{branch}/_unmount?name=...
+GET    /sessions/{id}/audit
+
+*/
+
+const (
+	// defaultSessionTTL is how long a session lasts if its creator doesn't
+	// say otherwise.
+	defaultSessionTTL = 8 * time.Hour
+	// sessionRetention is how long a session's audit log is kept after the
+	// session ends.
+	sessionRetention = time.Hour
+	// sessionReapInterval is how often expired sessions are ended.
+	sessionReapInterval = time.Minute
+	// maxAuditRecords is the number of audit records kept per session, after
+	// which the oldest are dropped.
+	maxAuditRecords = 1000
+)
+
+type Session struct {
+	ID      string    `json:"id"`
+	User    string    `json:"user"`    // "" if auth isn't active
+	Created time.Time `json:"created"` // written by the server
+	Expires time.Time `json:"expires"` // written by the server
+	// Ended is when the session was deleted or expired, or nil if it's live
+	Ended  *time.Time `json:"ended,omitempty"`
+	Mounts []string   `json:"mounts"` // names of the mounts the session created
+
+	client *client.APIClient // authenticated as User
+	audit  []AuditRecord
+}
+
+type AuditRecord struct {
+	Time   time.Time `json:"time"`
+	Action string    `json:"action"` // "create", "mount", "commit", "unmount", "end", "expire"
+	Repo   string    `json:"repo,omitempty"`
+	Branch string    `json:"branch,omitempty"`
+	Name   string    `json:"name,omitempty"`
+	Error  string    `json:"error,omitempty"`
+}
+
+type SessionBranch struct {
+	Repo     string `json:"repo"`
+	Branch   string `json:"branch"`
+	Writable bool   `json:"writable"`
+}
+
+type sessionManager struct {
+	mu       sync.Mutex
+	sessions map[string]*Session
+	// map from mount name onto the ID of the session that created it
+	owners map[string]string
+}
+
+func newSessionManager() *sessionManager {
+	return &sessionManager{
+		sessions: map[string]*Session{},
+		owners:   map[string]string{},
+	}
+}
+
+// sessionClient returns the client of the live session that created the mount
+// with the given name, or nil if it wasn't created by a session.
+func (sm *sessionManager) sessionClient(name string) *client.APIClient {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	s, ok := sm.sessions[sm.owners[name]]
+	if !ok || s.Ended != nil {
+		return nil
+	}
+	return s.client
+}
+
+func (sm *sessionManager) record(s *Session, rec AuditRecord, err error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	rec.Time = time.Now()
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	s.audit = append(s.audit, rec)
+	if len(s.audit) > maxAuditRecords {
+		s.audit = s.audit[len(s.audit)-maxAuditRecords:]
+	}
+	logrus.WithFields(logrus.Fields{
+		"session": s.ID,
+		"user":    s.User,
+		"action":  rec.Action,
+		"repo":    rec.Repo,
+		"branch":  rec.Branch,
+		"name":    rec.Name,
+		"error":   rec.Error,
+	}).Info("mount server session")
+}
+
+// get returns a copy of the session with the given ID, along with the session
+// itself, which must only be read with sm.mu held.
+func (sm *sessionManager) get(id string) (Session, *Session, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	s, ok := sm.sessions[id]
+	if !ok {
+		return Session{}, nil, errors.Errorf("session %q not found", id)
+	}
+	view := *s
+	view.Mounts = append([]string{}, s.Mounts...)
+	return view, s, nil
+}
+
+// CreateSession starts a session bound to token, which lasts for ttl, or
+// defaultSessionTTL if ttl is 0.
+func (mm *MountManager) CreateSession(token string, ttl time.Duration) (Session, error) {
+	if ttl <= 0 {
+		ttl = defaultSessionTTL
+	}
+	c := mm.Client.WithCtx(context.Background())
+	c.SetAuthToken(token)
+	now := time.Now()
+	s := &Session{
+		ID:      uuid.NewWithoutDashes(),
+		Created: now,
+		Expires: now.Add(ttl),
+		client:  c,
+	}
+	active, err := c.IsAuthActive()
+	if err != nil {
+		return Session{}, err
+	}
+	if active {
+		who, err := c.WhoAmI(c.Ctx(), &auth.WhoAmIRequest{})
+		if err != nil {
+			return Session{}, errors.Wrap(err, "invalid session token")
+		}
+		s.User = who.Username
+		if who.Expiration != nil && who.Expiration.Before(s.Expires) {
+			s.Expires = *who.Expiration
+		}
+	}
+	func() {
+		mm.sessions.mu.Lock()
+		defer mm.sessions.mu.Unlock()
+		mm.sessions.sessions[s.ID] = s
+	}()
+	mm.sessions.record(s, AuditRecord{Action: "create"}, nil)
+	view, _, err := mm.sessions.get(s.ID)
+	return view, err
+}
+
+// liveSession returns the session with the given ID, if it hasn't ended.
+func (mm *MountManager) liveSession(id string) (*Session, error) {
+	view, s, err := mm.sessions.get(id)
+	if err != nil {
+		return nil, err
+	}
+	if view.Ended != nil {
+		return nil, errors.Errorf("session %q has ended", id)
+	}
+	if time.Now().After(view.Expires) {
+		mm.EndSession(id, "expire")
+		return nil, errors.Errorf("session %q has expired", id)
+	}
+	return s, nil
+}
+
+// EndSession unmounts the mounts created by the session with the given ID,
+// committing any changes to them, and marks the session as ended. action is
+// recorded in the session's audit log.
+func (mm *MountManager) EndSession(id, action string) error {
+	view, s, err := mm.sessions.get(id)
+	if err != nil {
+		return err
+	}
+	if view.Ended != nil {
+		return nil
+	}
+	var retErr error
+	for _, name := range view.Mounts {
+		if err := mm.sessionUnmount(s, name); err != nil && retErr == nil {
+			retErr = err
+		}
+	}
+	func() {
+		mm.sessions.mu.Lock()
+		defer mm.sessions.mu.Unlock()
+		now := time.Now()
+		s.Ended = &now
+	}()
+	mm.sessions.record(s, AuditRecord{Action: action}, retErr)
+	return retErr
+}
+
+// EndAllSessions ends every live session, e.g. because the cluster the mount
+// server points at has changed.
+func (mm *MountManager) EndAllSessions() error {
+	var ids []string
+	func() {
+		mm.sessions.mu.Lock()
+		defer mm.sessions.mu.Unlock()
+		for id, s := range mm.sessions.sessions {
+			if s.Ended == nil {
+				ids = append(ids, id)
+			}
+		}
+	}()
+	var retErr error
+	for _, id := range ids {
+		if err := mm.EndSession(id, "end"); err != nil && retErr == nil {
+			retErr = err
+		}
+	}
+	return retErr
+}
+
+// reapSessions ends expired sessions, and forgets sessions that ended more
+// than sessionRetention ago.
+func (mm *MountManager) reapSessions() {
+	now := time.Now()
+	var expired []string
+	func() {
+		mm.sessions.mu.Lock()
+		defer mm.sessions.mu.Unlock()
+		for id, s := range mm.sessions.sessions {
+			switch {
+			case s.Ended != nil && now.Sub(*s.Ended) > sessionRetention:
+				delete(mm.sessions.sessions, id)
+			case s.Ended == nil && now.After(s.Expires):
+				expired = append(expired, id)
+			}
+		}
+	}()
+	for _, id := range expired {
+		if err := mm.EndSession(id, "expire"); err != nil {
+			logrus.Infof("Error ending expired session %s: %s", id, err)
+		}
+	}
+}
+
+// SessionBranches lists the branches of the repos that the session's user can
+// read.
+func (mm *MountManager) SessionBranches(s *Session) ([]SessionBranch, error) {
+	repos, err := s.client.ListRepo()
+	if err != nil {
+		return nil, err
+	}
+	result := []SessionBranch{}
+	for _, repo := range repos {
+		read, write := repoAccess(repo)
+		if !read {
+			continue
+		}
+		bs, err := s.client.ListBranch(repo.Repo.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, branch := range bs {
+			result = append(result, SessionBranch{
+				Repo:     repo.Repo.Name,
+				Branch:   branch.Branch.Name,
+				Writable: write,
+			})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Repo != result[j].Repo {
+			return result[i].Repo < result[j].Repo
+		}
+		return result[i].Branch < result[j].Branch
+	})
+	return result, nil
+}
+
+// repoAccess returns whether the caller that listed or inspected repo can read
+// and write it. Repos have no AuthInfo when auth isn't active, in which case
+// everyone can do both.
+func repoAccess(repo *pfs.RepoInfo) (read, write bool) {
+	if repo.AuthInfo == nil {
+		return true, true
+	}
+	for _, p := range repo.AuthInfo.Permissions {
+		switch p {
+		case auth.Permission_REPO_READ:
+			read = true
+		case auth.Permission_REPO_WRITE:
+			write = true
+		}
+	}
+	return read, write
+}
+
+// checkOwner returns an error if the mount with the given name was created by
+// a session other than s.
+func (mm *MountManager) checkOwner(s *Session, name string) error {
+	mm.sessions.mu.Lock()
+	defer mm.sessions.mu.Unlock()
+	if owner, ok := mm.sessions.owners[name]; ok && owner != s.ID {
+		return errors.Errorf("mount %q belongs to another session", name)
+	}
+	return nil
+}
+
+func (mm *MountManager) SessionMount(s *Session, key MountKey, name, mode string) (retErr error) {
+	defer func() {
+		mm.sessions.record(s, AuditRecord{Action: "mount", Repo: key.Repo, Branch: key.Branch, Name: name}, retErr)
+	}()
+	if err := mm.checkOwner(s, name); err != nil {
+		return err
+	}
+	repo, err := s.client.InspectRepo(key.Repo)
+	if err != nil {
+		return err
+	}
+	read, write := repoAccess(repo)
+	if !read || (mode == "rw" && !write) {
+		return errors.Errorf("user %q can't mount repo %q in mode %q", s.User, key.Repo, mode)
+	}
+	// claim the mount before mounting it, so that uploads to it use the
+	// session's client from the start
+	claimed := func() bool {
+		mm.sessions.mu.Lock()
+		defer mm.sessions.mu.Unlock()
+		if _, ok := mm.sessions.owners[name]; ok {
+			return false
+		}
+		mm.sessions.owners[name] = s.ID
+		s.Mounts = append(s.Mounts, name)
+		return true
+	}()
+	if _, err := mm.MountBranch(key, name, mode); err != nil {
+		if claimed {
+			mm.release(s, name)
+		}
+		return err
+	}
+	return nil
+}
+
+func (mm *MountManager) SessionCommit(s *Session, key MountKey, name string) (retErr error) {
+	defer func() {
+		mm.sessions.record(s, AuditRecord{Action: "commit", Repo: key.Repo, Branch: key.Branch, Name: name}, retErr)
+	}()
+	if err := mm.checkOwner(s, name); err != nil {
+		return err
+	}
+	_, err := mm.CommitBranch(key, name)
+	return err
+}
+
+func (mm *MountManager) SessionUnmount(s *Session, key MountKey, name string) (retErr error) {
+	defer func() {
+		mm.sessions.record(s, AuditRecord{Action: "unmount", Repo: key.Repo, Branch: key.Branch, Name: name}, retErr)
+	}()
+	if err := mm.checkOwner(s, name); err != nil {
+		return err
+	}
+	return mm.sessionUnmount(s, name)
+}
+
+// sessionUnmount unmounts the mount with the given name, which was created by
+// s, and releases it.
+func (mm *MountManager) sessionUnmount(s *Session, name string) error {
+	var key MountKey
+	func() {
+		mm.mu.Lock()
+		defer mm.mu.Unlock()
+		if msm, ok := mm.States[name]; ok {
+			key = msm.MountKey
+		}
+	}()
+	_, err := mm.UnmountBranch(key, name)
+	mm.release(s, name)
+	return err
+}
+
+// release forgets that the mount with the given name was created by s.
+func (mm *MountManager) release(s *Session, name string) {
+	mm.sessions.mu.Lock()
+	defer mm.sessions.mu.Unlock()
+	delete(mm.sessions.owners, name)
+	for i, n := range s.Mounts {
+		if n == name {
+			s.Mounts = append(s.Mounts[:i], s.Mounts[i+1:]...)
+			break
+		}
+	}
+}
+
+func (mm *MountManager) sessionRoutes(router *mux.Router) {
+	router.Methods("POST").Path("/sessions").HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		type SessionRequest struct {
+			Token      string `json:"token"`
+			TTLSeconds int64  `json:"ttl_seconds"`
+		}
+
+		var sReq SessionRequest
+		if err := json.NewDecoder(req.Body).Decode(&sReq); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s, err := mm.CreateSession(sReq.Token, time.Duration(sReq.TTLSeconds)*time.Second)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, s)
+	})
+	router.Methods("GET").Path("/sessions/{id}").HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s, _, err := mm.sessions.get(mux.Vars(req)["id"])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		writeJSON(w, s)
+	})
+	router.Methods("DELETE").Path("/sessions/{id}").HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		id := mux.Vars(req)["id"]
+		if _, _, err := mm.sessions.get(id); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err := mm.EndSession(id, "end"); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		s, _, err := mm.sessions.get(id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		writeJSON(w, s)
+	})
+	router.Methods("GET").Path("/sessions/{id}/audit").HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, s, err := mm.sessions.get(mux.Vars(req)["id"])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		mm.sessions.mu.Lock()
+		audit := append([]AuditRecord{}, s.audit...)
+		mm.sessions.mu.Unlock()
+		writeJSON(w, audit)
+	})
+	router.Methods("GET").Path("/sessions/{id}/branches").HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s, err := mm.liveSession(mux.Vars(req)["id"])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		bs, err := mm.SessionBranches(s)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, bs)
+	})
+	sessionMountAction := func(action string, f func(s *Session, key MountKey, name string, vs map[string]string) error) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			vs := mux.Vars(req)
+			s, err := mm.liveSession(vs["id"])
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			key, err := mountKeyFromString(vs["key"])
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if key.Commit != "" {
+				http.Error(w, fmt.Sprintf("don't support %s on commits yet", action), http.StatusBadRequest)
+				return
+			}
+			if err := f(s, key, vs["name"], vs); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			view, _, err := mm.sessions.get(s.ID)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			writeJSON(w, view)
+		}
+	}
+	router.Methods("PUT").
+		Path("/sessions/{id}/repos/{key:.+}/_mount").
+		Queries("mode", "{mode}").
+		Queries("name", "{name}").
+		HandlerFunc(sessionMountAction("mount", func(s *Session, key MountKey, name string, vs map[string]string) error {
+			return mm.SessionMount(s, key, name, vs["mode"])
+		}))
+	router.Methods("PUT").
+		Queries("name", "{name}").
+		Path("/sessions/{id}/repos/{key:.+}/_commit").
+		HandlerFunc(sessionMountAction("commit", func(s *Session, key MountKey, name string, _ map[string]string) error {
+			return mm.SessionCommit(s, key, name)
+		}))
+	router.Methods("PUT").
+		Queries("name", "{name}").
+		Path("/sessions/{id}/repos/{key:.+}/_unmount").
+		HandlerFunc(sessionMountAction("unmount", func(s *Session, key MountKey, name string, _ map[string]string) error {
+			return mm.SessionUnmount(s, key, name)
+		}))
+}
+
+func writeJSON(w http.ResponseWriter, t interface{}) {
+	marshalled, err := jsonMarshal(t)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Write(marshalled)
+}
@@ -1,6 +1,8 @@
 package pfs
 
 import (
+	"context"
+
 	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
 	pfs_client "github.com/pachyderm/pachyderm/v2/src/pfs"
 )
@@ -29,4 +31,9 @@ type APIServer interface {
 	DeleteBranchInTransaction(*txncontext.TransactionContext, *pfs_client.DeleteBranchRequest) error
 
 	AddFileSetInTransaction(*txncontext.TransactionContext, *pfs_client.AddFileSetRequest) error
+
+	// WalkCommitFiles calls the callback with each file and directory in a
+	// finished commit. It doesn't check that the caller can read the commit's
+	// repo, even if the server does for the public RPCs.
+	WalkCommitFiles(context.Context, *pfs_client.CommitInfo, func(*pfs_client.FileInfo) error) error
 }
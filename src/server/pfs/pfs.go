@@ -17,6 +17,13 @@ type ErrFileNotFound struct {
 	File *pfs.File
 }
 
+// ErrFileErased represents a file-not-found error for a file that was
+// erased from its repo.
+type ErrFileErased struct {
+	File      *pfs.File
+	ErasureID string
+}
+
 // ErrRepoNotFound represents a repo-not-found error.
 type ErrRepoNotFound struct {
 	Repo *pfs.Repo
@@ -118,6 +125,13 @@ type ErrCommitOnOutputBranch struct {
 	Branch *pfs.Branch
 }
 
+// ErrRepoCordoned represents an error where an attempt was made to start a
+// commit in a cordoned repo.
+type ErrRepoCordoned struct {
+	Repo   *pfs.Repo
+	Cordon *pfs.Cordon
+}
+
 // ErrSquashWithoutChildren represents an error when attempting to squash a
 // commit that has no children.  Since squash works by removing a commit and
 // leaving its data in any child commits, a squash would result in data loss in
@@ -144,6 +158,14 @@ func (e ErrFileNotFound) GRPCStatus() *status.Status {
 	return status.New(codes.NotFound, e.Error())
 }
 
+func (e ErrFileErased) Error() string {
+	return fmt.Sprintf("file %v not found in repo %v at commit %v: it was erased by erasure %v", e.File.Path, e.File.Commit.Branch.Repo, e.File.Commit.ID, e.ErasureID)
+}
+
+func (e ErrFileErased) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, e.Error())
+}
+
 func (e ErrRepoNotFound) Error() string {
 	return fmt.Sprintf("repo %v not found", e.Repo)
 }
@@ -244,6 +266,14 @@ func (e ErrCommitOnOutputBranch) Error() string {
 	return fmt.Sprintf("cannot start a commit on an output branch: %s", e.Branch)
 }
 
+func (e ErrRepoCordoned) Error() string {
+	msg := fmt.Sprintf("repo %s is cordoned, new commits can't be started in it", e.Repo)
+	if e.Cordon.GetReason() != "" {
+		msg += fmt.Sprintf(" (%s)", e.Cordon.Reason)
+	}
+	return msg
+}
+
 func (e ErrSquashWithoutChildren) Error() string {
 	return fmt.Sprintf("cannot squash a commit that has no children as that would cause data loss, use the drop operation instead: %s", e.Commit)
 }
@@ -268,6 +298,7 @@ var (
 	ambiguousCommitRe         = regexp.MustCompile("commit .+ is ambiguous")
 	inconsistentCommitRe      = regexp.MustCompile("branch already has a commit in this transaction")
 	commitOnOutputBranchRe    = regexp.MustCompile("cannot start a commit on an output branch")
+	repoCordonedRe            = regexp.MustCompile("repo [^ ]+ is cordoned")
 	squashWithoutChildrenRe   = regexp.MustCompile("cannot squash a commit that has no children")
 	dropWithChildrenRe        = regexp.MustCompile("cannot drop a commit that has children")
 )
@@ -412,6 +443,15 @@ func IsCommitOnOutputBranchErr(err error) bool {
 	return commitOnOutputBranchRe.MatchString(err.Error())
 }
 
+// IsRepoCordonedErr returns true if the err is due to an attempt to start a
+// commit in a cordoned repo.
+func IsRepoCordonedErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	return repoCordonedRe.MatchString(err.Error())
+}
+
 func IsSquashWithoutChildrenErr(err error) bool {
 	if err == nil {
 		return false
@@ -437,5 +477,42 @@ func ValidateSQLDatabaseEgress(sql *pfs.SQLDatabaseEgress) error {
 	if secret.Name == "" || secret.Key == "" {
 		return errors.Errorf("egress.sql_database.secret.name and egress.sql_database.secret.key are required")
 	}
+	if sql.BatchSize < 0 {
+		return errors.Errorf("egress.sql_database.batch_size can't be negative")
+	}
+	if sql.MarkerTable != "" && !sqlTableRe.MatchString(sql.MarkerTable) {
+		return errors.Errorf("egress.sql_database.marker_table %q is not a valid table name", sql.MarkerTable)
+	}
+	if sql.GetFileFormat().GetHeader() && sql.GetFileFormat().GetType() != pfs.SQLDatabaseEgress_FileFormat_CSV {
+		return errors.Errorf("egress.sql_database.file_format.header can only be set for CSV files")
+	}
+	for i, table := range sql.Tables {
+		if table.Glob == "" {
+			return errors.Errorf("egress.sql_database.tables[%d].glob is required", i)
+		}
+		if !sqlTableRe.MatchString(table.Table) {
+			return errors.Errorf("egress.sql_database.tables[%d].table %q is not a valid table name", i, table.Table)
+		}
+		if len(table.Columns) > 0 && sql.GetFileFormat().GetType() == pfs.SQLDatabaseEgress_FileFormat_CSV && !sql.GetFileFormat().GetHeader() {
+			return errors.Errorf("egress.sql_database.tables[%d].columns requires CSV files with a header", i)
+		}
+	}
+	return nil
+}
+
+// sqlTableRe matches table names, optionally qualified with their schema,
+// that are safe to use in SQL statements unquoted.
+var sqlTableRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*(\.[A-Za-z_][A-Za-z0-9_$]*)?$`)
+
+func ValidateTableEgress(table *pfs.TableEgress) error {
+	if table == nil {
+		return nil
+	}
+	if table.Url == "" {
+		return errors.Errorf("egress.table.url is required")
+	}
+	if table.Format == pfs.TableEgress_UNKNOWN {
+		return errors.Errorf("egress.table.format must be DELTA or ICEBERG")
+	}
 	return nil
 }
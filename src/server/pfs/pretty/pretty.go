@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	units "github.com/docker/go-units"
 	"github.com/fatih/color"
@@ -22,6 +23,8 @@ const (
 	RepoAuthHeader = "NAME\tCREATED\tSIZE (MASTER)\tACCESS LEVEL\t\n"
 	// CommitHeader is the header for commits.
 	CommitHeader = "REPO\tBRANCH\tCOMMIT\tFINISHED\tSIZE\tORIGIN\tDESCRIPTION\n"
+	// CheckpointHeader is the header for checkpoints.
+	CheckpointHeader = "NUMBER\tCREATED\tFILESET\t\n"
 	// CommitSetHeader is the header for commitsets.
 	CommitSetHeader = "ID\tSUBCOMMITS\tPROGRESS\tCREATED\tMODIFIED\n"
 	// BranchHeader is the header for branches.
@@ -32,6 +35,19 @@ const (
 	FileHeaderWithCommit = "COMMIT\tNAME\tTYPE\tCOMMITTED\tSIZE\t\n"
 	// DiffFileHeader is the header for files produced by diff file.
 	DiffFileHeader = "OP\t" + FileHeader
+	// ProjectHeader is the header for projects.
+	ProjectHeader = "PROJECT\tCREATED\tDESCRIPTION\t\n"
+	// SchemaHeader is the header for schemas.
+	SchemaHeader = "PATTERN\tTYPE\tVERSION\tCREATED\t\n"
+	// SchemaConformanceHeader is the header for the schemas a commit was
+	// validated against.
+	SchemaConformanceHeader = "PATTERN\tVERSION\tFILES\tERROR\t\n"
+	// RetentionPolicyHeader is the header for retention policies.
+	RetentionPolicyHeader = "BRANCH\tKEEP COMMITS\tKEEP DURATION\tCREATED\t\n"
+	// ErasureHeader is the header for erasures.
+	ErasureHeader = "ID\tPATH\tCOMMITS\tSIZE\tREQUESTED BY\tCREATED\tREASON\t\n"
+	// ViewHeader is the header for views.
+	ViewHeader = "NAME\tSOURCES\tCREATED\tDESCRIPTION\t\n"
 )
 
 // PrintRepoInfo pretty-prints repo info.
@@ -74,7 +90,9 @@ func PrintDetailedRepoInfo(repoInfo *PrintableRepoInfo) error {
 		`Name: {{.Repo.Name}}{{if .Description}}
 Description: {{.Description}}{{end}}{{if .FullTimestamps}}
 Created: {{.Created}}{{else}}
-Created: {{prettyAgo .Created}}{{end}}{{if .Details}}
+Created: {{prettyAgo .Created}}{{end}}{{if .Project}}
+Project: {{.Project.Name}}{{end}}{{if .Cordon}}
+Cordoned: {{printCordon .Cordon}}{{end}}{{if .Details}}
 Size of HEAD on master: {{prettySize .Details.SizeBytes}}{{end}}{{if .AuthInfo}}
 Roles: {{ .AuthInfo.Roles | commafy }}
 Permissions: {{ .AuthInfo.Permissions | commafy }}{{end}}
@@ -105,6 +123,33 @@ func printTrigger(trigger *pfs.Trigger) string {
 	return fmt.Sprintf("%s on %s", trigger.Branch, cond)
 }
 
+func printTriggerStatus(status *pfs.TriggerStatus) string {
+	var met []string
+	if status.CronMet {
+		met = append(met, "Cron")
+	}
+	if status.SizeMet {
+		met = append(met, "Size")
+	}
+	if status.CommitsMet {
+		met = append(met, "Commits")
+	}
+	result := fmt.Sprintf("%d commits, %s pending", status.PendingCommits, pretty.Size(status.PendingBytes))
+	if status.NextCron != nil {
+		next, err := types.TimestampFromProto(status.NextCron)
+		if err == nil {
+			result += fmt.Sprintf(", next cron window %s", next.Local().Format(time.RFC3339))
+		}
+	}
+	if len(met) > 0 {
+		result += fmt.Sprintf(", met %s", strings.Join(met, ", "))
+	}
+	if status.Triggered {
+		result += " (triggered)"
+	}
+	return result
+}
+
 // PrintBranch pretty-prints a Branch.
 func PrintBranch(w io.Writer, branchInfo *pfs.BranchInfo) {
 	fmt.Fprintf(w, "%s\t", branchInfo.Branch.Name)
@@ -127,7 +172,8 @@ func PrintDetailedBranchInfo(branchInfo *pfs.BranchInfo) error {
 		`Name: {{.Branch.Repo.Name}}@{{.Branch.Name}}{{if .Head}}
 Head Commit: {{ .Head.Branch.Repo.Name}}@{{.Head.ID}} {{end}}{{if .Provenance}}
 Provenance: {{range .Provenance}} {{.Repo.Name}}@{{.Name}} {{end}} {{end}}{{if .Trigger}}
-Trigger: {{printTrigger .Trigger}} {{end}}
+Trigger: {{printTrigger .Trigger}} {{end}}{{if .TriggerStatus}}
+Trigger Status: {{printTriggerStatus .TriggerStatus}} {{end}}
 `)
 	if err != nil {
 		return errors.EnsureStack(err)
@@ -237,7 +283,11 @@ Started: {{.Started}}{{else}}
 Started: {{prettyAgo .Started}}{{end}}{{if .Finished}}{{if .FullTimestamps}}
 Finished: {{.Finished}}{{else}}
 Finished: {{prettyAgo .Finished}}{{end}}{{end}}{{if .Details}}
-Size: {{prettySize .Details.SizeBytes}}{{end}}
+Size: {{prettySize .Details.SizeBytes}}{{range .Details.Schemas}}
+Schema {{.PathPattern}} (version {{.Version}}): {{if .Error}}{{.Error}}{{else}}conforms{{end}}{{end}}{{with .Details.Validation}}
+Validation ({{.Action}}): {{.ViolationCount}} violations in {{.FilesChecked}} files{{range .Violations}}
+  {{printValidationViolation .}}{{end}}{{end}}{{end}}{{if .Checkpoints}}
+Checkpoints: {{len .Checkpoints}}{{end}}
 `)
 	if err != nil {
 		return errors.EnsureStack(err)
@@ -245,6 +295,197 @@ Size: {{prettySize .Details.SizeBytes}}{{end}}
 	return errors.EnsureStack(template.Execute(w, commitInfo))
 }
 
+// PrintCheckpointInfo pretty-prints checkpoint info.
+func PrintCheckpointInfo(w io.Writer, checkpointInfo *pfs.CheckpointInfo, fullTimestamps bool) {
+	fmt.Fprintf(w, "%d\t", checkpointInfo.Checkpoint.Number)
+	if fullTimestamps {
+		fmt.Fprintf(w, "%s\t", checkpointInfo.Checkpoint.Created.String())
+	} else {
+		fmt.Fprintf(w, "%s\t", pretty.Ago(checkpointInfo.Checkpoint.Created))
+	}
+	fmt.Fprintf(w, "%s\t", checkpointInfo.FileSetId)
+	fmt.Fprintln(w)
+}
+
+// PrintSchemaInfo pretty-prints schema info.
+func PrintSchemaInfo(w io.Writer, schemaInfo *pfs.SchemaInfo, fullTimestamps bool) {
+	fmt.Fprintf(w, "%s\t", schemaInfo.PathPattern)
+	fmt.Fprintf(w, "%v\t", schemaInfo.Schema.GetType())
+	fmt.Fprintf(w, "%d\t", schemaInfo.Version)
+	if fullTimestamps {
+		fmt.Fprintf(w, "%s\t", schemaInfo.Created.String())
+	} else {
+		fmt.Fprintf(w, "%s\t", pretty.Ago(schemaInfo.Created))
+	}
+	fmt.Fprintln(w)
+}
+
+// PrintSchemaConformance pretty-prints whether a commit conforms to a
+// schema.
+func PrintSchemaConformance(w io.Writer, conformance *pfs.SchemaConformance) {
+	fmt.Fprintf(w, "%s\t", conformance.PathPattern)
+	fmt.Fprintf(w, "%d\t", conformance.Version)
+	fmt.Fprintf(w, "%d\t", conformance.Files)
+	if conformance.Error != "" {
+		fmt.Fprintf(w, "%s\t", conformance.Error)
+	} else {
+		fmt.Fprintf(w, "-\t")
+	}
+	fmt.Fprintln(w)
+}
+
+// PrintRetentionPolicyInfo pretty-prints a retention policy.
+func PrintRetentionPolicyInfo(w io.Writer, policyInfo *pfs.RetentionPolicyInfo, fullTimestamps bool) {
+	if policyInfo.Branch != "" {
+		fmt.Fprintf(w, "%s\t", policyInfo.Branch)
+	} else {
+		fmt.Fprintf(w, "*\t")
+	}
+	if policyInfo.Policy.GetKeepCommits() != 0 {
+		fmt.Fprintf(w, "%d\t", policyInfo.Policy.KeepCommits)
+	} else {
+		fmt.Fprintf(w, "-\t")
+	}
+	if policyInfo.Policy.GetKeepDuration() != nil {
+		fmt.Fprintf(w, "%s\t", pretty.Duration(policyInfo.Policy.KeepDuration))
+	} else {
+		fmt.Fprintf(w, "-\t")
+	}
+	if fullTimestamps {
+		fmt.Fprintf(w, "%s\t", policyInfo.Created.String())
+	} else {
+		fmt.Fprintf(w, "%s\t", pretty.Ago(policyInfo.Created))
+	}
+	fmt.Fprintln(w)
+}
+
+// PrintErasureInfo pretty-prints the audit record of an erasure.
+func PrintErasureInfo(w io.Writer, erasure *pfs.ErasureInfo, fullTimestamps bool) {
+	fmt.Fprintf(w, "%s\t", erasure.Id)
+	fmt.Fprintf(w, "%s\t", erasure.Path)
+	fmt.Fprintf(w, "%d\t", len(erasure.Commits))
+	fmt.Fprintf(w, "%s\t", units.BytesSize(float64(erasure.ChunkBytes)))
+	if erasure.RequestedBy != "" {
+		fmt.Fprintf(w, "%s\t", erasure.RequestedBy)
+	} else {
+		fmt.Fprintf(w, "-\t")
+	}
+	if fullTimestamps {
+		fmt.Fprintf(w, "%s\t", erasure.Created.String())
+	} else {
+		fmt.Fprintf(w, "%s\t", pretty.Ago(erasure.Created))
+	}
+	fmt.Fprintf(w, "%s\t", erasure.Reason)
+	fmt.Fprintln(w)
+}
+
+// PrintVerifyErasureResponse pretty-prints the verification report of an
+// erasure.
+func PrintVerifyErasureResponse(w io.Writer, resp *pfs.VerifyErasureResponse) {
+	fmt.Fprintf(w, "Erasure: %s\n", resp.Erasure.Id)
+	fmt.Fprintf(w, "Path: %s\n", resp.Erasure.Path)
+	fmt.Fprintf(w, "Commits checked: %d\n", resp.CommitsChecked)
+	fmt.Fprintf(w, "Commits containing the path: %d\n", len(resp.Commits))
+	for _, commit := range resp.Commits {
+		fmt.Fprintf(w, "  %s\n", commit)
+	}
+	fmt.Fprintf(w, "Erased chunks: %d\n", len(resp.Erasure.Chunks))
+	fmt.Fprintf(w, "Erased chunks still stored: %d\n", len(resp.StoredChunks))
+	fmt.Fprintf(w, "Erased chunks still referenced: %d\n", len(resp.ReferencedChunks))
+	fmt.Fprintf(w, "Verified: %t\n", resp.Verified)
+}
+
+// PrintValidationPolicyInfo pretty-prints a validation policy.
+func PrintValidationPolicyInfo(w io.Writer, policyInfo *pfs.ValidationPolicyInfo, fullTimestamps bool) {
+	policy := policyInfo.Policy
+	fmt.Fprintf(w, "Repo: %s\n", policyInfo.Repo.Name)
+	fmt.Fprintf(w, "Action: %s\n", policy.Action)
+	if len(policy.AllowedExtensions) > 0 {
+		fmt.Fprintf(w, "Allowed extensions: %s\n", strings.Join(policy.AllowedExtensions, ", "))
+	}
+	if policy.MaxFileSizeBytes > 0 {
+		fmt.Fprintf(w, "Max file size: %s\n", units.BytesSize(float64(policy.MaxFileSizeBytes)))
+	}
+	if len(policy.Formats) > 0 {
+		var formats []string
+		for _, format := range policy.Formats {
+			formats = append(formats, FileFormat(format))
+		}
+		fmt.Fprintf(w, "Formats: %s\n", strings.Join(formats, ", "))
+	}
+	if image := policy.Image; image != nil {
+		fmt.Fprintf(w, "Image: %s\n", image.Image)
+		if len(image.Cmd) > 0 {
+			fmt.Fprintf(w, "Command: %s\n", strings.Join(image.Cmd, " "))
+		}
+		if image.Secret != "" {
+			fmt.Fprintf(w, "Secret: %s\n", image.Secret)
+		}
+		if image.Timeout != nil {
+			fmt.Fprintf(w, "Timeout: %s\n", pretty.Duration(image.Timeout))
+		}
+	}
+	if fullTimestamps {
+		fmt.Fprintf(w, "Created: %s\n", policyInfo.Created.String())
+	} else {
+		fmt.Fprintf(w, "Created: %s\n", pretty.Ago(policyInfo.Created))
+	}
+}
+
+// FileFormat returns the name of a file format checked by validation
+// policies, e.g. "CSV".
+func FileFormat(format pfs.FileFormat) string {
+	return strings.TrimPrefix(format.String(), "FORMAT_")
+}
+
+func printValidationViolation(v *pfs.ValidationViolation) string {
+	if v.Path == "" {
+		return fmt.Sprintf("%s: %s", v.Rule, v.Message)
+	}
+	return fmt.Sprintf("%s (%s): %s", v.Path, v.Rule, v.Message)
+}
+
+// PrintViewInfo pretty-prints view info.
+func PrintViewInfo(w io.Writer, viewInfo *pfs.ViewInfo, fullTimestamps bool) {
+	fmt.Fprintf(w, "%s\t", viewInfo.View.Name)
+	var sources []string
+	for _, source := range viewInfo.View.Sources {
+		sources = append(sources, ViewSource(source))
+	}
+	fmt.Fprintf(w, "%s\t", strings.Join(sources, ", "))
+	if fullTimestamps {
+		fmt.Fprintf(w, "%s\t", viewInfo.Created.String())
+	} else {
+		fmt.Fprintf(w, "%s\t", pretty.Ago(viewInfo.Created))
+	}
+	fmt.Fprintf(w, "%s\t", viewInfo.View.Description)
+	fmt.Fprintln(w)
+}
+
+// PrintDetailedViewInfo pretty-prints detailed view info.
+func PrintDetailedViewInfo(w io.Writer, viewInfo *pfs.ViewInfo, fullTimestamps bool) {
+	fmt.Fprintf(w, "Name: %s\n", viewInfo.View.Name)
+	fmt.Fprintf(w, "Repo: %s\n", viewInfo.Repo)
+	if viewInfo.View.Description != "" {
+		fmt.Fprintf(w, "Description: %s\n", viewInfo.View.Description)
+	}
+	if fullTimestamps {
+		fmt.Fprintf(w, "Created: %s\n", viewInfo.Created.String())
+	} else {
+		fmt.Fprintf(w, "Created: %s\n", pretty.Ago(viewInfo.Created))
+	}
+	fmt.Fprintf(w, "Sources:\n")
+	for _, source := range viewInfo.View.Sources {
+		fmt.Fprintf(w, "  %s\n", ViewSource(source))
+	}
+}
+
+// ViewSource returns a description of a view source, e.g.
+// "/images <- images@master:/".
+func ViewSource(source *pfs.ViewSource) string {
+	return fmt.Sprintf("%s <- %s@%s:%s", source.Path, source.Branch.Repo, source.Branch.Name, source.SourcePath)
+}
+
 // PrintFileInfo pretty-prints file info.
 // If recurse is false and directory size is 0, display "-" instead
 // If fast is true and file size is 0, display "-" instead
@@ -281,12 +522,45 @@ func PrintDiffFileInfo(w io.Writer, added bool, fileInfo *pfs.FileInfo, fullTime
 	PrintFileInfo(w, fileInfo, fullTimestamps, false)
 }
 
+// PreviewSchemaHeader is the header for the columns of a previewed file.
+const PreviewSchemaHeader = "COLUMN\tTYPE\t\n"
+
+// PrintPreviewSchema pretty-prints the columns of a previewed file.
+func PrintPreviewSchema(w io.Writer, preview *pfs.PreviewFileResponse) {
+	for _, column := range preview.Columns {
+		fmt.Fprintf(w, "%s\t%s\t\n", column.Name, column.Type)
+	}
+}
+
+// PreviewRowsHeader returns the header for the rows of a previewed file,
+// which are its column names.
+func PreviewRowsHeader(preview *pfs.PreviewFileResponse) string {
+	var names []string
+	for _, column := range preview.Columns {
+		names = append(names, column.Name)
+	}
+	return strings.Join(names, "\t") + "\t\n"
+}
+
+// PrintPreviewRows pretty-prints the rows of a previewed file. Tabs and
+// newlines in values are replaced with spaces, to keep each row on a line.
+func PrintPreviewRows(w io.Writer, preview *pfs.PreviewFileResponse) {
+	r := strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+	for _, row := range preview.Rows {
+		for _, value := range row.Values {
+			fmt.Fprintf(w, "%s\t", r.Replace(value))
+		}
+		fmt.Fprintln(w)
+	}
+}
+
 // PrintDetailedFileInfo pretty-prints detailed file info.
 func PrintDetailedFileInfo(fileInfo *pfs.FileInfo) error {
 	template, err := template.New("FileInfo").Funcs(funcMap).Parse(
 		`Path: {{.File.Path}}
 Datum: {{.File.Datum}}
-Type: {{fileType .FileType}}
+Type: {{fileType .FileType}}{{if .ContentType}}
+Content Type: {{.ContentType}}{{end}}
 Size: {{prettySize .SizeBytes}}
 `)
 	if err != nil {
@@ -303,11 +577,27 @@ func fileType(fileType pfs.FileType) string {
 }
 
 var funcMap = template.FuncMap{
-	"prettyAgo":    pretty.Ago,
-	"prettySize":   pretty.Size,
-	"fileType":     fileType,
-	"printTrigger": printTrigger,
-	"commafy":      pretty.Commafy,
+	"prettyAgo":                pretty.Ago,
+	"prettySize":               pretty.Size,
+	"fileType":                 fileType,
+	"printTrigger":             printTrigger,
+	"printTriggerStatus":       printTriggerStatus,
+	"commafy":                  pretty.Commafy,
+	"printValidationViolation": printValidationViolation,
+	"printCordon":              PrintCordon,
+}
+
+// PrintCordon renders why and since when a repo or pipeline is cordoned.
+func PrintCordon(cordon *pfs.Cordon) string {
+	var parts []string
+	if cordon.Reason != "" {
+		parts = append(parts, cordon.Reason)
+	}
+	if cordon.CordonedBy != "" {
+		parts = append(parts, "by "+cordon.CordonedBy)
+	}
+	parts = append(parts, pretty.Ago(cordon.Since))
+	return strings.Join(parts, ", ")
 }
 
 // CompactPrintCommit renders 'c' as a compact string, e.g.
@@ -330,3 +620,37 @@ func CompactPrintCommitSafe(c *pfs.Commit) string {
 func CompactPrintFile(f *pfs.File) string {
 	return fmt.Sprintf("%s@%s:%s", f.Commit.Branch.Repo, f.Commit.ID, f.Path)
 }
+
+// PrintProjectInfo pretty-prints project info.
+func PrintProjectInfo(w io.Writer, projectInfo *pfs.ProjectInfo, fullTimestamps bool) {
+	fmt.Fprintf(w, "%s\t", projectInfo.Project.Name)
+	if projectInfo.Created == nil {
+		fmt.Fprintf(w, "-\t")
+	} else if fullTimestamps {
+		fmt.Fprintf(w, "%s\t", projectInfo.Created.String())
+	} else {
+		fmt.Fprintf(w, "%s\t", pretty.Ago(projectInfo.Created))
+	}
+	fmt.Fprintf(w, "%s\t", projectInfo.Description)
+	fmt.Fprintln(w)
+}
+
+// PrintDetailedProjectInfo pretty-prints detailed project info, and the
+// repos in the project.
+func PrintDetailedProjectInfo(projectInfo *pfs.ProjectInfo, repoInfos []*pfs.RepoInfo, fullTimestamps bool) error {
+	template, err := template.New("ProjectInfo").Funcs(funcMap).Parse(
+		`Name: {{.ProjectInfo.Project.Name}}{{if .ProjectInfo.Description}}
+Description: {{.ProjectInfo.Description}}{{end}}{{if .ProjectInfo.Created}}{{if .FullTimestamps}}
+Created: {{.ProjectInfo.Created}}{{else}}
+Created: {{prettyAgo .ProjectInfo.Created}}{{end}}{{end}}
+Repos:{{range .RepoInfos}} {{.Repo.Name}}{{else}} none{{end}}
+`)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	return errors.EnsureStack(template.Execute(os.Stdout, struct {
+		ProjectInfo    *pfs.ProjectInfo
+		RepoInfos      []*pfs.RepoInfo
+		FullTimestamps bool
+	}{projectInfo, repoInfos, fullTimestamps}))
+}
@@ -3,6 +3,7 @@ package s3
 import (
 	"net/http"
 
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
 	"github.com/pachyderm/pachyderm/v2/src/server/pfs"
 	"github.com/pachyderm/s2"
 )
@@ -31,3 +32,7 @@ func maybeNotFoundError(r *http.Request, err error) *s2.Error {
 	}
 	return s2.InternalError(r, err)
 }
+
+func readOnlyError(r *http.Request, err error) *s2.Error {
+	return s2.NewError(r, http.StatusServiceUnavailable, "ServiceUnavailable", grpcutil.ScrubGRPC(err).Error())
+}
@@ -183,3 +183,21 @@ func Server(port uint16, defaultRouter *mux.Router) *S3Server {
 	}
 	return &s3Server
 }
+
+// ReadOnly wraps handler so that the requests that write, whose methods
+// aren't GET or HEAD, are rejected while checkWrite returns an error, such
+// as when the cluster is read-only for maintenance.
+func ReadOnly(handler http.Handler, checkWrite func() error) http.Handler {
+	logger := logrus.WithFields(logrus.Fields{
+		"source": "s3gateway",
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			if err := checkWrite(); err != nil {
+				s2.WriteError(logger, w, r, readOnlyError(r, err))
+				return
+			}
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
@@ -22,7 +22,6 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pfsload"
-	"github.com/pachyderm/pachyderm/v2/src/internal/storage/chunk"
 	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
 	"github.com/pachyderm/pachyderm/v2/src/internal/storage/metrics"
 	"github.com/pachyderm/pachyderm/v2/src/internal/task"
@@ -43,16 +42,23 @@ import (
 type apiServer struct {
 	env    Env
 	driver *driver
+	// compressors are the compressors file transfer streams may use.
+	compressors []string
 }
 
 func newAPIServer(env Env) (*apiServer, error) {
+	compressors, err := grpcutil.ParseCompressionPolicy(env.StorageConfig.StorageStreamCompression)
+	if err != nil {
+		return nil, err
+	}
 	d, err := newDriver(env)
 	if err != nil {
 		return nil, err
 	}
 	s := &apiServer{
-		env:    env,
-		driver: d,
+		env:         env,
+		driver:      d,
+		compressors: compressors,
 	}
 	return s, nil
 }
@@ -84,7 +90,7 @@ func (a *apiServer) CreateRepoInTransaction(txnCtx *txncontext.TransactionContex
 	if repo := request.GetRepo(); repo != nil && repo.Name == fileSetsRepo {
 		return errors.Errorf("%s is a reserved name", fileSetsRepo)
 	}
-	return a.driver.createRepo(txnCtx, request.Repo, request.Description, request.Update)
+	return a.driver.createRepo(txnCtx, request.Repo, request.Description, request.Update, request.Project)
 }
 
 // CreateRepo implements the protobuf pfs.CreateRepo RPC
@@ -128,7 +134,7 @@ func (a *apiServer) InspectRepo(ctx context.Context, request *pfs.InspectRepoReq
 
 // ListRepo implements the protobuf pfs.ListRepo RPC
 func (a *apiServer) ListRepo(request *pfs.ListRepoRequest, srv pfs.API_ListRepoServer) (retErr error) {
-	return a.driver.listRepo(srv.Context(), true, request.Type, srv.Send)
+	return a.driver.listRepo(srv.Context(), true, request, srv.Send)
 }
 
 // DeleteRepoInTransaction is identical to DeleteRepo except that it can run
@@ -198,7 +204,7 @@ func (a *apiServer) InspectCommit(ctx context.Context, request *pfs.InspectCommi
 
 // ListCommit implements the protobuf pfs.ListCommit RPC
 func (a *apiServer) ListCommit(request *pfs.ListCommitRequest, respServer pfs.API_ListCommitServer) (retErr error) {
-	return a.driver.listCommit(respServer.Context(), request.Repo, request.To, request.From, request.Number, request.Reverse, request.All, request.OriginKind, func(ci *pfs.CommitInfo) error {
+	return a.driver.listCommit(respServer.Context(), request, func(ci *pfs.CommitInfo) error {
 		return errors.EnsureStack(respServer.Send(ci))
 	})
 }
@@ -258,6 +264,16 @@ func (a *apiServer) ClearCommit(ctx context.Context, request *pfs.ClearCommitReq
 	return &types.Empty{}, a.driver.clearCommit(ctx, request.Commit)
 }
 
+// CheckpointCommit implements the protobuf pfs.CheckpointCommit RPC
+func (a *apiServer) CheckpointCommit(ctx context.Context, request *pfs.CheckpointCommitRequest) (response *pfs.CheckpointInfo, retErr error) {
+	return a.driver.checkpointCommit(ctx, request.Commit)
+}
+
+// SubscribeCheckpoint implements the protobuf pfs.SubscribeCheckpoint RPC
+func (a *apiServer) SubscribeCheckpoint(request *pfs.SubscribeCheckpointRequest, stream pfs.API_SubscribeCheckpointServer) (retErr error) {
+	return a.driver.subscribeCheckpoint(stream.Context(), request.Commit, request.From, stream.Send)
+}
+
 // CreateBranchInTransaction is identical to CreateBranch except that it can run
 // inside an existing postgres transaction.  This is not an RPC.
 func (a *apiServer) CreateBranchInTransaction(txnCtx *txncontext.TransactionContext, request *pfs.CreateBranchRequest) error {
@@ -299,6 +315,10 @@ func (a *apiServer) InspectBranch(ctx context.Context, request *pfs.InspectBranc
 	if err := a.env.TxnEnv.WithReadContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
 		var err error
 		branchInfo, err = a.driver.inspectBranch(txnCtx, request.Branch)
+		if err != nil || branchInfo.Trigger == nil {
+			return err
+		}
+		branchInfo.TriggerStatus, _, err = a.driver.branchTriggerStatus(txnCtx, branchInfo, time.Now())
 		return err
 	}); err != nil {
 		return nil, err
@@ -337,6 +357,9 @@ func (a *apiServer) DeleteBranch(ctx context.Context, request *pfs.DeleteBranchR
 }
 
 func (a *apiServer) ModifyFile(server pfs.API_ModifyFileServer) (retErr error) {
+	if err := grpcutil.CheckCompression(server.Context(), a.compressors); err != nil {
+		return err
+	}
 	commit, err := readCommit(server)
 	if err != nil {
 		return err
@@ -379,14 +402,18 @@ func (a *apiServer) modifyFile(ctx context.Context, uw *fileset.UnorderedWriter,
 			var n int64
 			p := mod.AddFile.Path
 			t := mod.AddFile.Datum
+			var opts []fileset.FileOption
+			if mod.AddFile.ContentType != "" {
+				opts = append(opts, fileset.WithContentType(mod.AddFile.ContentType))
+			}
 			switch src := mod.AddFile.Source.(type) {
 			case *pfs.AddFile_Raw:
-				n, err = putFileRaw(uw, p, t, src.Raw)
+				n, err = putFileRaw(uw, p, t, src.Raw, opts...)
 			case *pfs.AddFile_Url:
-				n, err = putFileURL(ctx, uw, p, t, src.Url)
+				n, err = putFileURL(ctx, uw, p, t, src.Url, opts...)
 			default:
 				// need to write empty data to path
-				n, err = putFileRaw(uw, p, t, &types.BytesValue{})
+				n, err = putFileRaw(uw, p, t, &types.BytesValue{}, opts...)
 			}
 			if err != nil {
 				return bytesRead, err
@@ -410,14 +437,14 @@ func (a *apiServer) modifyFile(ctx context.Context, uw *fileset.UnorderedWriter,
 	return bytesRead, nil
 }
 
-func putFileRaw(uw *fileset.UnorderedWriter, path, tag string, src *types.BytesValue) (int64, error) {
-	if err := uw.Put(path, tag, true, bytes.NewReader(src.Value)); err != nil {
+func putFileRaw(uw *fileset.UnorderedWriter, path, tag string, src *types.BytesValue, opts ...fileset.FileOption) (int64, error) {
+	if err := uw.Put(path, tag, true, bytes.NewReader(src.Value), opts...); err != nil {
 		return 0, err
 	}
 	return int64(len(src.Value)), nil
 }
 
-func putFileURL(ctx context.Context, uw *fileset.UnorderedWriter, dstPath, tag string, src *pfs.AddFile_URLSource) (n int64, retErr error) {
+func putFileURL(ctx context.Context, uw *fileset.UnorderedWriter, dstPath, tag string, src *pfs.AddFile_URLSource, opts ...fileset.FileOption) (n int64, retErr error) {
 	url, err := url.Parse(src.URL)
 	if err != nil {
 		return 0, errors.EnsureStack(err)
@@ -437,7 +464,7 @@ func putFileURL(ctx context.Context, uw *fileset.UnorderedWriter, dstPath, tag s
 				retErr = err
 			}
 		}()
-		return 0, uw.Put(dstPath, tag, true, resp.Body)
+		return 0, uw.Put(dstPath, tag, true, resp.Body, opts...)
 	default:
 		url, err := obj.ParseURL(src.URL)
 		if err != nil {
@@ -453,7 +480,7 @@ func putFileURL(ctx context.Context, uw *fileset.UnorderedWriter, dstPath, tag s
 				return miscutil.WithPipe(func(w io.Writer) error {
 					return errors.EnsureStack(objClient.Get(ctx, name, w))
 				}, func(r io.Reader) error {
-					return uw.Put(filepath.Join(dstPath, strings.TrimPrefix(name, path)), tag, true, r)
+					return uw.Put(filepath.Join(dstPath, strings.TrimPrefix(name, path)), tag, true, r, opts...)
 				})
 			})
 			return 0, errors.EnsureStack(err)
@@ -461,7 +488,7 @@ func putFileURL(ctx context.Context, uw *fileset.UnorderedWriter, dstPath, tag s
 		return 0, miscutil.WithPipe(func(w io.Writer) error {
 			return errors.EnsureStack(objClient.Get(ctx, url.Object, w))
 		}, func(r io.Reader) error {
-			return uw.Put(dstPath, tag, true, r)
+			return uw.Put(dstPath, tag, true, r, opts...)
 		})
 	}
 }
@@ -473,6 +500,9 @@ func deleteFile(uw *fileset.UnorderedWriter, request *pfs.DeleteFile) error {
 
 // GetFileTAR implements the protobuf pfs.GetFileTAR RPC
 func (a *apiServer) GetFileTAR(request *pfs.GetFileRequest, server pfs.API_GetFileTARServer) (retErr error) {
+	if err := grpcutil.CheckCompression(server.Context(), a.compressors); err != nil {
+		return err
+	}
 	return metrics.ReportRequestWithThroughput(func() (int64, error) {
 		ctx := server.Context()
 		src, err := a.driver.getFile(ctx, request.File)
@@ -496,6 +526,12 @@ func (a *apiServer) GetFileTAR(request *pfs.GetFileRequest, server pfs.API_GetFi
 
 // GetFile implements the protobuf pfs.GetFile RPC
 func (a *apiServer) GetFile(request *pfs.GetFileRequest, server pfs.API_GetFileServer) (retErr error) {
+	if err := grpcutil.CheckCompression(server.Context(), a.compressors); err != nil {
+		return err
+	}
+	if err := validateGetFileRequest(request); err != nil {
+		return err
+	}
 	return metrics.ReportRequestWithThroughput(func() (int64, error) {
 		ctx := server.Context()
 		src, err := a.driver.getFile(ctx, request.File)
@@ -512,7 +548,7 @@ func (a *apiServer) GetFile(request *pfs.GetFileRequest, server pfs.API_GetFileS
 		if err := src.Iterate(ctx, func(fi *pfs.FileInfo, file fileset.File) error {
 			n = fileset.SizeFromIndex(file.Index())
 			return grpcutil.WithStreamingBytesWriter(server, func(w io.Writer) error {
-				return errors.EnsureStack(file.Content(ctx, w, chunk.WithOffsetBytes(request.Offset)))
+				return getFileContent(ctx, w, file, request)
 			})
 		}); err != nil {
 			return 0, errors.EnsureStack(err)
@@ -590,7 +626,7 @@ func (a *apiServer) InspectFile(ctx context.Context, request *pfs.InspectFileReq
 
 // ListFile implements the protobuf pfs.ListFile RPC
 func (a *apiServer) ListFile(request *pfs.ListFileRequest, server pfs.API_ListFileServer) (retErr error) {
-	return a.driver.listFile(server.Context(), request.File, func(fi *pfs.FileInfo) error {
+	return a.driver.listFile(server.Context(), request.File, request.ContentType, func(fi *pfs.FileInfo) error {
 		return errors.EnsureStack(server.Send(fi))
 	})
 }
@@ -602,9 +638,14 @@ func (a *apiServer) WalkFile(request *pfs.WalkFileRequest, server pfs.API_WalkFi
 	})
 }
 
+// WalkCommitFiles implements the pfsserver.APIServer interface
+func (a *apiServer) WalkCommitFiles(ctx context.Context, commitInfo *pfs.CommitInfo, cb func(*pfs.FileInfo) error) error {
+	return a.driver.walkCommitFiles(ctx, commitInfo, cb)
+}
+
 // GlobFile implements the protobuf pfs.GlobFile RPC
 func (a *apiServer) GlobFile(request *pfs.GlobFileRequest, respServer pfs.API_GlobFileServer) (retErr error) {
-	return a.driver.globFile(respServer.Context(), request.Commit, request.Pattern, func(fi *pfs.FileInfo) error {
+	return a.driver.globFile(respServer.Context(), request.Commit, request.Pattern, request.ContentType, func(fi *pfs.FileInfo) error {
 		return errors.EnsureStack(respServer.Send(fi))
 	})
 }
@@ -753,6 +794,235 @@ func (a *apiServer) ClearCache(ctx context.Context, req *pfs.ClearCacheRequest)
 	return &types.Empty{}, nil
 }
 
+func (a *apiServer) InspectCache(ctx context.Context, req *pfs.InspectCacheRequest) (resp *pfs.InspectCacheResponse, retErr error) {
+	entries, err := a.driver.countCache(ctx, req.TagPrefix)
+	if err != nil {
+		return nil, err
+	}
+	return &pfs.InspectCacheResponse{Entries: entries}, nil
+}
+
+// SetSchema implements the protobuf pfs.SetSchema RPC
+func (a *apiServer) SetSchema(ctx context.Context, request *pfs.SetSchemaRequest) (response *pfs.SchemaInfo, retErr error) {
+	if err := a.env.TxnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		var err error
+		response, err = a.driver.setSchema(txnCtx, request.Repo, request.PathPattern, request.Schema)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ListSchema implements the protobuf pfs.ListSchema RPC
+func (a *apiServer) ListSchema(request *pfs.ListSchemaRequest, srv pfs.API_ListSchemaServer) (retErr error) {
+	return a.driver.listSchema(srv.Context(), request.Repo, srv.Send)
+}
+
+// DeleteSchema implements the protobuf pfs.DeleteSchema RPC
+func (a *apiServer) DeleteSchema(ctx context.Context, request *pfs.DeleteSchemaRequest) (response *types.Empty, retErr error) {
+	if err := a.env.TxnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		return a.driver.deleteSchema(txnCtx, request.Repo, request.PathPattern)
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// InspectCommitSchema implements the protobuf pfs.InspectCommitSchema RPC
+func (a *apiServer) InspectCommitSchema(ctx context.Context, request *pfs.InspectCommitSchemaRequest) (response *pfs.CommitSchemaInfo, retErr error) {
+	if request.Commit == nil {
+		return nil, errors.New("commit must be specified")
+	}
+	return a.driver.inspectCommitSchema(ctx, request.Commit)
+}
+
+// CreateProject implements the protobuf pfs.CreateProject RPC
+func (a *apiServer) CreateProject(ctx context.Context, request *pfs.CreateProjectRequest) (response *types.Empty, retErr error) {
+	if err := a.env.TxnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		return a.driver.createProject(txnCtx, request.Project, request.Description, request.Update)
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// InspectProject implements the protobuf pfs.InspectProject RPC
+func (a *apiServer) InspectProject(ctx context.Context, request *pfs.InspectProjectRequest) (response *pfs.ProjectInfo, retErr error) {
+	if err := a.env.TxnEnv.WithReadContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		var err error
+		response, err = a.driver.inspectProject(txnCtx, request.Project)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ListProject implements the protobuf pfs.ListProject RPC
+func (a *apiServer) ListProject(request *pfs.ListProjectRequest, srv pfs.API_ListProjectServer) (retErr error) {
+	return a.driver.listProject(srv.Context(), srv.Send)
+}
+
+// DeleteProject implements the protobuf pfs.DeleteProject RPC
+func (a *apiServer) DeleteProject(ctx context.Context, request *pfs.DeleteProjectRequest) (response *types.Empty, retErr error) {
+	if err := a.env.TxnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		return a.driver.deleteProject(txnCtx, request.Project)
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// SetRetentionPolicy implements the protobuf pfs.SetRetentionPolicy RPC
+func (a *apiServer) SetRetentionPolicy(ctx context.Context, request *pfs.SetRetentionPolicyRequest) (response *pfs.RetentionPolicyInfo, retErr error) {
+	if err := a.env.TxnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		var err error
+		response, err = a.driver.setRetentionPolicy(txnCtx, request.Repo, request.Branch, request.Policy)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ListRetentionPolicy implements the protobuf pfs.ListRetentionPolicy RPC
+func (a *apiServer) ListRetentionPolicy(request *pfs.ListRetentionPolicyRequest, srv pfs.API_ListRetentionPolicyServer) (retErr error) {
+	return a.driver.listRetentionPolicy(srv.Context(), request.Repo, srv.Send)
+}
+
+// DeleteRetentionPolicy implements the protobuf pfs.DeleteRetentionPolicy RPC
+func (a *apiServer) DeleteRetentionPolicy(ctx context.Context, request *pfs.DeleteRetentionPolicyRequest) (response *types.Empty, retErr error) {
+	if err := a.env.TxnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		return a.driver.deleteRetentionPolicy(txnCtx, request.Repo, request.Branch)
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// ApplyRetentionPolicy implements the protobuf pfs.ApplyRetentionPolicy RPC
+func (a *apiServer) ApplyRetentionPolicy(ctx context.Context, request *pfs.ApplyRetentionPolicyRequest) (response *pfs.ApplyRetentionPolicyResponse, retErr error) {
+	expired, err := a.driver.applyRetentionPolicy(ctx, request.Repo, request.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	return &pfs.ApplyRetentionPolicyResponse{Expired: expired}, nil
+}
+
+// EraseFile implements the protobuf pfs.EraseFile RPC
+func (a *apiServer) EraseFile(ctx context.Context, request *pfs.EraseFileRequest) (response *pfs.ErasureInfo, retErr error) {
+	return a.driver.eraseFile(ctx, request.Repo, request.Path, request.Reason)
+}
+
+// ListErasure implements the protobuf pfs.ListErasure RPC
+func (a *apiServer) ListErasure(request *pfs.ListErasureRequest, srv pfs.API_ListErasureServer) (retErr error) {
+	return a.driver.listErasure(srv.Context(), request.Repo, srv.Send)
+}
+
+// VerifyErasure implements the protobuf pfs.VerifyErasure RPC
+func (a *apiServer) VerifyErasure(ctx context.Context, request *pfs.VerifyErasureRequest) (response *pfs.VerifyErasureResponse, retErr error) {
+	return a.driver.verifyErasure(ctx, request.Repo, request.Id)
+}
+
+// SetValidationPolicy implements the protobuf pfs.SetValidationPolicy RPC
+func (a *apiServer) SetValidationPolicy(ctx context.Context, request *pfs.SetValidationPolicyRequest) (response *pfs.ValidationPolicyInfo, retErr error) {
+	if err := a.env.TxnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		var err error
+		response, err = a.driver.setValidationPolicy(txnCtx, request.Repo, request.Policy)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// InspectValidationPolicy implements the protobuf pfs.InspectValidationPolicy RPC
+func (a *apiServer) InspectValidationPolicy(ctx context.Context, request *pfs.InspectValidationPolicyRequest) (response *pfs.ValidationPolicyInfo, retErr error) {
+	return a.driver.inspectValidationPolicy(ctx, request.Repo)
+}
+
+// DeleteValidationPolicy implements the protobuf pfs.DeleteValidationPolicy RPC
+func (a *apiServer) DeleteValidationPolicy(ctx context.Context, request *pfs.DeleteValidationPolicyRequest) (response *types.Empty, retErr error) {
+	if err := a.env.TxnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		return a.driver.deleteValidationPolicy(txnCtx, request.Repo)
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// CreateView implements the protobuf pfs.CreateView RPC
+func (a *apiServer) CreateView(ctx context.Context, request *pfs.CreateViewRequest) (response *pfs.ViewInfo, retErr error) {
+	if err := a.env.TxnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		var err error
+		response, err = a.driver.createView(txnCtx, request.View, request.Update)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// InspectView implements the protobuf pfs.InspectView RPC
+func (a *apiServer) InspectView(ctx context.Context, request *pfs.InspectViewRequest) (response *pfs.ViewInfo, retErr error) {
+	return a.driver.inspectView(ctx, request.Name)
+}
+
+// ListView implements the protobuf pfs.ListView RPC
+func (a *apiServer) ListView(request *pfs.ListViewRequest, srv pfs.API_ListViewServer) (retErr error) {
+	return a.driver.listView(srv.Context(), srv.Send)
+}
+
+// DeleteView implements the protobuf pfs.DeleteView RPC
+func (a *apiServer) DeleteView(ctx context.Context, request *pfs.DeleteViewRequest) (response *types.Empty, retErr error) {
+	if err := a.env.TxnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		return a.driver.deleteView(txnCtx, request.Name, request.Force)
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// StartUpload implements the protobuf pfs.StartUpload RPC
+func (a *apiServer) StartUpload(ctx context.Context, request *pfs.StartUploadRequest) (response *pfs.UploadSession, retErr error) {
+	return a.driver.startUpload(ctx, request)
+}
+
+// UploadBlock implements the protobuf pfs.UploadBlock RPC
+func (a *apiServer) UploadBlock(ctx context.Context, request *pfs.UploadBlockRequest) (response *types.Empty, retErr error) {
+	if err := a.driver.uploadBlock(ctx, request); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// InspectUpload implements the protobuf pfs.InspectUpload RPC
+func (a *apiServer) InspectUpload(ctx context.Context, request *pfs.InspectUploadRequest) (response *pfs.UploadSession, retErr error) {
+	return a.driver.inspectUpload(ctx, request.Session)
+}
+
+// FinishUpload implements the protobuf pfs.FinishUpload RPC
+func (a *apiServer) FinishUpload(ctx context.Context, request *pfs.FinishUploadRequest) (response *types.Empty, retErr error) {
+	if err := a.driver.finishUpload(ctx, request.Session); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// DeleteUpload implements the protobuf pfs.DeleteUpload RPC
+func (a *apiServer) DeleteUpload(ctx context.Context, request *pfs.DeleteUploadRequest) (response *types.Empty, retErr error) {
+	if err := a.driver.deleteUpload(ctx, request.Session); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// PreviewFile implements the protobuf pfs.PreviewFile RPC
+func (a *apiServer) PreviewFile(ctx context.Context, request *pfs.PreviewFileRequest) (response *pfs.PreviewFileResponse, retErr error) {
+	return a.driver.previewFile(ctx, request)
+}
+
 // RunLoadTest implements the pfs.RunLoadTest RPC
 func (a *apiServer) RunLoadTest(ctx context.Context, req *pfs.RunLoadTestRequest) (_ *pfs.RunLoadTestResponse, retErr error) {
 	pachClient := a.env.GetPachClient(ctx)
@@ -895,24 +1165,19 @@ func readCommit(srv pfs.API_ModifyFileServer) (*pfs.Commit, error) {
 }
 
 func (a *apiServer) Egress(ctx context.Context, req *pfs.EgressRequest) (*pfs.EgressResponse, error) {
-	src, err := a.driver.getFile(ctx, req.Commit.NewFile("/"))
+	driver, err := newEgressDriver(req)
 	if err != nil {
 		return nil, err
 	}
-	switch target := req.Target.(type) {
-	case *pfs.EgressRequest_ObjectStorage:
-		result, err := copyToObjectStorage(ctx, src, target.ObjectStorage.Url)
-		if err != nil {
-			return nil, errors.EnsureStack(err)
-		}
-		return &pfs.EgressResponse{Result: &pfs.EgressResponse_ObjectStorage{ObjectStorage: result}}, nil
-
-	case *pfs.EgressRequest_SqlDatabase:
-		result, err := copyToSQLDB(ctx, src, target.SqlDatabase.Url, target.SqlDatabase.FileFormat)
-		if err != nil {
-			return nil, errors.EnsureStack(err)
-		}
-		return &pfs.EgressResponse{Result: &pfs.EgressResponse_SqlDatabase{SqlDatabase: result}}, nil
+	// resolve the commit, since drivers may record its ID
+	commitInfo, err := a.driver.inspectCommit(ctx, req.Commit, pfs.CommitState_STARTED)
+	if err != nil {
+		return nil, err
+	}
+	src, err := a.driver.getFile(ctx, req.Commit.NewFile("/"))
+	if err != nil {
+		return nil, err
 	}
-	return nil, errors.Errorf("egress failed")
+	resp, err := driver.egress(ctx, src, commitInfo.Commit)
+	return resp, errors.EnsureStack(err)
 }
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+var (
+	failedCommitsCleanedMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pfs_cleanup",
+		Name:      "failed_commits_total",
+		Help:      "Count of failed commits whose data was dropped by the failed commit cleanup, by repo type",
+	}, []string{"repo_type"})
+	failedCommitsReclaimedBytesMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pfs_cleanup",
+		Name:      "reclaimed_bytes_total",
+		Help:      "Upper bound on the bytes reclaimed by the failed commit cleanup, by repo type",
+	}, []string{"repo_type"})
+)
+
+// failedCommitRetention describes which failed commits are kept by the failed
+// commit cleanup. A zero value in a field disables that part of the policy.
+type failedCommitRetention struct {
+	// maxAge is how long a failed commit is kept after it finished.
+	maxAge time.Duration
+	// keepCount is the number of most recent failed commits kept per repo.
+	keepCount int
+}
+
+// expired returns the failed commits in cis that are not retained by the
+// policy. Commits that are not finished, or that finished successfully, are
+// never returned.
+func (r failedCommitRetention) expired(cis []*pfs.CommitInfo, now time.Time) []*pfs.CommitInfo {
+	type failedCommit struct {
+		ci       *pfs.CommitInfo
+		finished time.Time
+	}
+	var failed []failedCommit
+	for _, ci := range cis {
+		if ci.Finished == nil || ci.Error == "" {
+			continue
+		}
+		finished, err := types.TimestampFromProto(ci.Finished)
+		if err != nil {
+			continue
+		}
+		failed = append(failed, failedCommit{ci: ci, finished: finished})
+	}
+	// Newest first, so that the first keepCount commits are the ones retained.
+	sort.SliceStable(failed, func(i, j int) bool {
+		return failed[i].finished.After(failed[j].finished)
+	})
+	var result []*pfs.CommitInfo
+	for i, fc := range failed {
+		if r.keepCount > 0 && i >= r.keepCount {
+			result = append(result, fc.ci)
+			continue
+		}
+		if r.maxAge > 0 && now.Sub(fc.finished) > r.maxAge {
+			result = append(result, fc.ci)
+		}
+	}
+	return result
+}
+
+// cleanupFailedCommits periodically drops the data of failed commits in
+// pipeline output and meta repos that are no longer retained. Failed commits
+// are never used as the base of their descendants, so dropping their file
+// sets only affects reads of the failed commits themselves.
+func (d *driver) cleanupFailedCommits(ctx context.Context, period time.Duration, retention failedCommitRetention) error {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		if err := d.cleanupFailedCommitsOnce(ctx, retention); err != nil {
+			log.Errorf("error cleaning up failed commits: %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return errors.EnsureStack(ctx.Err())
+		}
+	}
+}
+
+func (d *driver) cleanupFailedCommitsOnce(ctx context.Context, retention failedCommitRetention) error {
+	var repos []*pfs.Repo
+	repoInfo := &pfs.RepoInfo{}
+	if err := d.repos.ReadOnly(ctx).List(repoInfo, col.DefaultOptions(), func(string) error {
+		if repoInfo.Repo.Type == pfs.UserRepoType || repoInfo.Repo.Type == pfs.MetaRepoType {
+			repos = append(repos, proto.Clone(repoInfo.Repo).(*pfs.Repo))
+		}
+		return nil
+	}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	now := time.Now()
+	for _, repo := range repos {
+		var cis []*pfs.CommitInfo
+		commitInfo := &pfs.CommitInfo{}
+		if err := d.commits.ReadOnly(ctx).GetByIndex(pfsdb.CommitsRepoIndex, pfsdb.RepoKey(repo), commitInfo, col.DefaultOptions(), func(string) error {
+			// Only commits created by pipelines are cleaned up, user commits
+			// that failed validation are left for the user to inspect.
+			if commitInfo.Origin.Kind == pfs.OriginKind_AUTO && commitInfo.Error != "" {
+				cis = append(cis, proto.Clone(commitInfo).(*pfs.CommitInfo))
+			}
+			return nil
+		}); err != nil {
+			return errors.EnsureStack(err)
+		}
+		for _, ci := range retention.expired(cis, now) {
+			if err := d.dropFailedCommit(ctx, ci); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dropFailedCommit drops the file sets of a failed commit, recording the
+// reclaimed size. Commits that were already dropped are skipped.
+func (d *driver) dropFailedCommit(ctx context.Context, ci *pfs.CommitInfo) error {
+	id, err := d.commitStore.GetDiffFileSet(ctx, ci.Commit)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	size, err := d.storage.SizeUpperBound(ctx, *id)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if size == 0 {
+		return nil
+	}
+	if err := d.commitStore.DropFileSets(ctx, ci.Commit); err != nil {
+		return errors.EnsureStack(err)
+	}
+	repoType := ci.Commit.Branch.Repo.Type
+	failedCommitsCleanedMetric.WithLabelValues(repoType).Inc()
+	failedCommitsReclaimedBytesMetric.WithLabelValues(repoType).Add(float64(size))
+	d.log.Infof("dropped data of failed commit %v (%d bytes)", ci.Commit, size)
+	return nil
+}
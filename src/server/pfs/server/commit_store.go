@@ -32,7 +32,14 @@ type commitStore interface {
 	GetTotalFileSetTx(tx *pachsql.Tx, commit *pfs.Commit) (*fileset.ID, error)
 	// GetDiffFileSet returns the diff fileset for a commit
 	GetDiffFileSet(ctx context.Context, commit *pfs.Commit) (*fileset.ID, error)
-	// DropFileSets clears the diff and total filesets for the commit.
+	// AddCheckpointTx records id as the fileset of the commit as of a checkpoint.
+	AddCheckpointTx(tx *pachsql.Tx, commit *pfs.Commit, number int64, id fileset.ID) error
+	// GetCheckpointFileSet returns the fileset of the commit as of a checkpoint.
+	GetCheckpointFileSet(ctx context.Context, commit *pfs.Commit, number int64) (*fileset.ID, error)
+	// ReplaceFileSetsTx replaces the diff and total filesets of the commit, and
+	// drops its checkpoints. The total is left unset if total is nil.
+	ReplaceFileSetsTx(tx *pachsql.Tx, commit *pfs.Commit, diff fileset.ID, total *fileset.ID) error
+	// DropFileSets clears the diff, total and checkpoint filesets for the commit.
 	DropFileSets(ctx context.Context, commit *pfs.Commit) error
 	// DropFileSetsTx is identical to DropFileSets except it runs in the provided transaction.
 	DropFileSetsTx(tx *pachsql.Tx, commit *pfs.Commit) error
@@ -136,6 +143,58 @@ func (cs *postgresCommitStore) SetTotalFileSetTx(tx *pachsql.Tx, commit *pfs.Com
 	return setTotal(tx, cs.tr, commit, id)
 }
 
+func (cs *postgresCommitStore) AddCheckpointTx(tx *pachsql.Tx, commit *pfs.Commit, number int64, id fileset.ID) error {
+	id2, err := cs.s.CloneTx(tx, id, defaultTTL)
+	if err != nil {
+		return err
+	}
+	id = *id2
+	if _, err := tx.Exec(
+		`INSERT INTO pfs.commit_checkpoints (commit_id, num, fileset_id)
+	VALUES ($1, $2, $3)
+`, pfsdb.CommitKey(commit), number, id); err != nil {
+		return errors.EnsureStack(err)
+	}
+	oid := commitCheckpointTrackerID(commit, id)
+	pointsTo := []string{id.TrackerID()}
+	return errors.EnsureStack(cs.tr.CreateTx(tx, oid, pointsTo, track.NoTTL))
+}
+
+func (cs *postgresCommitStore) GetCheckpointFileSet(ctx context.Context, commit *pfs.Commit, number int64) (*fileset.ID, error) {
+	var id *fileset.ID
+	if err := dbutil.WithTx(ctx, cs.db, func(tx *pachsql.Tx) error {
+		var checkpointID fileset.ID
+		if err := tx.Get(&checkpointID,
+			`SELECT fileset_id FROM pfs.commit_checkpoints
+			WHERE commit_id = $1 AND num = $2
+		`, pfsdb.CommitKey(commit), number); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.Errorf("checkpoint %d of commit %v has no data", number, commit)
+			}
+			return errors.EnsureStack(err)
+		}
+		var err error
+		id, err = cs.s.CloneTx(tx, checkpointID, defaultTTL)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+func (cs *postgresCommitStore) ReplaceFileSetsTx(tx *pachsql.Tx, commit *pfs.Commit, diff fileset.ID, total *fileset.ID) error {
+	if err := cs.DropFileSetsTx(tx, commit); err != nil {
+		return err
+	}
+	if err := cs.AddFileSetTx(tx, commit, diff); err != nil {
+		return err
+	}
+	if total == nil {
+		return nil
+	}
+	return setTotal(tx, cs.tr, commit, *total)
+}
+
 func (cs *postgresCommitStore) DropFileSets(ctx context.Context, commit *pfs.Commit) error {
 	return dbutil.WithTx(ctx, cs.db, func(tx *pachsql.Tx) error {
 		return cs.DropFileSetsTx(tx, commit)
@@ -146,9 +205,31 @@ func (cs *postgresCommitStore) DropFileSetsTx(tx *pachsql.Tx, commit *pfs.Commit
 	if err := dropTotal(tx, cs.tr, commit); err != nil {
 		return errors.EnsureStack(err)
 	}
+	if err := cs.dropCheckpoints(tx, commit); err != nil {
+		return err
+	}
 	return cs.dropDiff(tx, commit)
 }
 
+func (cs *postgresCommitStore) dropCheckpoints(tx *pachsql.Tx, commit *pfs.Commit) error {
+	var ids []fileset.ID
+	if err := tx.Select(&ids,
+		`SELECT fileset_id FROM pfs.commit_checkpoints
+		WHERE commit_id = $1
+		`, pfsdb.CommitKey(commit)); err != nil {
+		return errors.EnsureStack(err)
+	}
+	for _, id := range ids {
+		if err := cs.tr.DeleteTx(tx, commitCheckpointTrackerID(commit, id)); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	if _, err := tx.Exec(`DELETE FROM pfs.commit_checkpoints WHERE commit_id = $1`, pfsdb.CommitKey(commit)); err != nil {
+		return errors.EnsureStack(err)
+	}
+	return nil
+}
+
 func (cs *postgresCommitStore) dropDiff(tx *pachsql.Tx, commit *pfs.Commit) error {
 	diffIDs, err := getDiff(tx, commit)
 	if err != nil {
@@ -228,6 +309,10 @@ func commitTotalTrackerID(commit *pfs.Commit, fs fileset.ID) string {
 	return commitTrackerPrefix + pfsdb.CommitKey(commit) + "/total/" + fs.HexString()
 }
 
+func commitCheckpointTrackerID(commit *pfs.Commit, fs fileset.ID) string {
+	return commitTrackerPrefix + pfsdb.CommitKey(commit) + "/checkpoint/" + fs.HexString()
+}
+
 // SetupPostgresCommitStoreV0 runs SQL to setup the commit store.
 // DO NOT MODIFY THIS FUNCTION
 // IT HAS BEEN USED IN A RELEASED MIGRATION
@@ -248,3 +333,19 @@ func SetupPostgresCommitStoreV0(ctx context.Context, tx *pachsql.Tx) error {
 	`)
 	return errors.EnsureStack(err)
 }
+
+// SetupPostgresCommitCheckpointsV0 runs SQL to add checkpoints to the commit
+// store.
+// DO NOT MODIFY THIS FUNCTION
+// IT HAS BEEN USED IN A RELEASED MIGRATION
+func SetupPostgresCommitCheckpointsV0(ctx context.Context, tx *pachsql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		CREATE TABLE pfs.commit_checkpoints (
+			commit_id TEXT NOT NULL,
+			num BIGINT NOT NULL,
+			fileset_id UUID NOT NULL,
+			PRIMARY KEY(commit_id, num)
+		);
+	`)
+	return errors.EnsureStack(err)
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	pfsserver "github.com/pachyderm/pachyderm/v2/src/server/pfs"
+)
+
+// setRepoCordon cordons repo with cordon, or uncordons it if cordon is nil.
+func (d *driver) setRepoCordon(txnCtx *txncontext.TransactionContext, repo *pfs.Repo, cordon *pfs.Cordon) error {
+	if repo == nil {
+		return errors.New("repo must be specified")
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorizedInTransaction(txnCtx, repo, auth.Permission_REPO_WRITE); err != nil {
+		return errors.EnsureStack(err)
+	}
+	repoInfo := &pfs.RepoInfo{}
+	if err := d.repos.ReadWrite(txnCtx.SqlTx).Update(repo, repoInfo, func() error {
+		repoInfo.Cordon = cordon
+		return nil
+	}); err != nil {
+		if col.IsErrNotFound(err) {
+			return pfsserver.ErrRepoNotFound{Repo: repo}
+		}
+		return errors.EnsureStack(err)
+	}
+	return nil
+}
+
+// CordonRepo implements the protobuf pfs.CordonRepo RPC
+func (a *apiServer) CordonRepo(ctx context.Context, request *pfs.CordonRepoRequest) (response *types.Empty, retErr error) {
+	if err := a.env.TxnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		cordon := &pfs.Cordon{Reason: request.Reason, Since: txnCtx.Timestamp}
+		if whoAmI, err := txnCtx.WhoAmI(); err == nil {
+			cordon.CordonedBy = whoAmI.Username
+		}
+		return a.driver.setRepoCordon(txnCtx, request.Repo, cordon)
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// UncordonRepo implements the protobuf pfs.UncordonRepo RPC
+func (a *apiServer) UncordonRepo(ctx context.Context, request *pfs.UncordonRepoRequest) (response *types.Empty, retErr error) {
+	if err := a.env.TxnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		return a.driver.setRepoCordon(txnCtx, request.Repo, nil)
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
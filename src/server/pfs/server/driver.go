@@ -17,8 +17,10 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/keycache"
 	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pbutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
 	"github.com/pachyderm/pachyderm/v2/src/internal/storage/chunk"
 	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
@@ -79,6 +81,19 @@ type driver struct {
 	repos    col.PostgresCollection
 	commits  col.PostgresCollection
 	branches col.PostgresCollection
+	schemas  col.PostgresCollection
+	projects col.PostgresCollection
+
+	retentionPolicies  col.PostgresCollection
+	erasures           col.PostgresCollection
+	validationPolicies col.PostgresCollection
+	views              col.PostgresCollection
+	uploads            col.PostgresCollection
+
+	// repoCache and branchCache cache the repos and branches read outside of
+	// transactions. Their watches only run in pachd, not in the sidecar.
+	repoCache   *keycache.CollectionCache
+	branchCache *keycache.CollectionCache
 
 	storage     *fileset.Storage
 	commitStore commitStore
@@ -100,6 +115,13 @@ func newDriver(env Env) (*driver, error) {
 	repos := pfsdb.Repos(env.DB, env.Listener)
 	commits := pfsdb.Commits(env.DB, env.Listener)
 	branches := pfsdb.Branches(env.DB, env.Listener)
+	schemas := pfsdb.Schemas(env.DB, env.Listener)
+	projects := pfsdb.Projects(env.DB, env.Listener)
+	retentionPolicies := pfsdb.RetentionPolicies(env.DB, env.Listener)
+	erasures := pfsdb.Erasures(env.DB, env.Listener)
+	validationPolicies := pfsdb.ValidationPolicies(env.DB, env.Listener)
+	views := pfsdb.Views(env.DB, env.Listener)
+	uploads := pfsdb.Uploads(env.DB, env.Listener)
 
 	// Setup driver struct.
 	d := &driver{
@@ -110,7 +132,18 @@ func newDriver(env Env) (*driver, error) {
 		repos:      repos,
 		commits:    commits,
 		branches:   branches,
+		schemas:    schemas,
+		projects:   projects,
 		log:        env.Logger,
+
+		retentionPolicies:  retentionPolicies,
+		erasures:           erasures,
+		validationPolicies: validationPolicies,
+		views:              views,
+		uploads:            uploads,
+
+		repoCache:   keycache.NewCollectionCache(env.BackgroundContext, repos, &pfs.RepoInfo{}, env.MetadataCacheSize),
+		branchCache: keycache.NewCollectionCache(env.BackgroundContext, branches, &pfs.BranchInfo{}, env.MetadataCacheSize),
 	}
 	// Setup tracker and chunk / fileset storage.
 	tracker := track.NewPostgresTracker(env.DB)
@@ -136,7 +169,7 @@ func newDriver(env Env) (*driver, error) {
 	return d, nil
 }
 
-func (d *driver) createRepo(txnCtx *txncontext.TransactionContext, repo *pfs.Repo, description string, update bool) error {
+func (d *driver) createRepo(txnCtx *txncontext.TransactionContext, repo *pfs.Repo, description string, update bool, project *pfs.Project) error {
 	// Validate arguments
 	if repo == nil {
 		return errors.New("repo cannot be nil")
@@ -174,6 +207,9 @@ func (d *driver) createRepo(txnCtx *txncontext.TransactionContext, repo *pfs.Rep
 				Repo: repo,
 			}
 		}
+		if project != nil && pfsdb.ProjectName(project) != pfsdb.ProjectName(existingRepoInfo.Project) {
+			return errors.Errorf("repo %s is in project %s, and can't be moved to project %s", repo, pfsdb.ProjectName(existingRepoInfo.Project), pfsdb.ProjectName(project))
+		}
 
 		if existingRepoInfo.Description == description {
 			// Don't overwrite the stored proto with an identical value. This
@@ -203,6 +239,10 @@ func (d *driver) createRepo(txnCtx *txncontext.TransactionContext, repo *pfs.Rep
 			} else if err != nil {
 				return errors.Wrapf(err, "error checking whether user repo for %q exists", repo.Name)
 			}
+			// system repos are in the same project as their user repo
+			project = existingRepoInfo.Project
+		} else if project, err = d.repoProject(txnCtx, project); err != nil {
+			return err
 		}
 
 		// New repo case
@@ -224,6 +264,7 @@ func (d *driver) createRepo(txnCtx *txncontext.TransactionContext, repo *pfs.Rep
 			Repo:        repo,
 			Created:     txnCtx.Timestamp,
 			Description: description,
+			Project:     project,
 		}))
 	}
 }
@@ -266,11 +307,33 @@ func (d *driver) getPermissions(ctx context.Context, repo *pfs.Repo) ([]auth.Per
 	return resp.Permissions, resp.Roles, nil
 }
 
-func (d *driver) listRepo(ctx context.Context, includeAuth bool, repoType string, cb func(*pfs.RepoInfo) error) error {
+// listRepo calls cb with each repo that matches the request, newest first
+// unless request.Reverse is set.
+func (d *driver) listRepo(ctx context.Context, includeAuth bool, request *pfs.ListRepoRequest, cb func(*pfs.RepoInfo) error) error {
 	authSeemsActive := true
 	repoInfo := &pfs.RepoInfo{}
+	number := request.Number
+	if number == 0 {
+		number = math.MaxInt64
+	}
+	// pastCursor is true once the repos before the cursor have been skipped
+	pastCursor := request.Cursor == nil
 
 	processFunc := func(string) error {
+		if request.Project != nil && pfsdb.ProjectName(repoInfo.Project) != pfsdb.ProjectName(request.Project) {
+			return nil
+		}
+		if !pbutil.InTimeRange(repoInfo.Created, request.CreatedAfter, request.CreatedBefore) {
+			return nil
+		}
+		if !pastCursor {
+			pastCursor = pfsdb.RepoKey(repoInfo.Repo) == pfsdb.RepoKey(request.Cursor)
+			return nil
+		}
+		if number == 0 {
+			return errutil.ErrBreak
+		}
+		number--
 		size, err := d.repoSize(ctx, repoInfo.Repo)
 		if err != nil {
 			return err
@@ -289,13 +352,24 @@ func (d *driver) listRepo(ctx context.Context, includeAuth bool, repoType string
 		return cb(proto.Clone(repoInfo).(*pfs.RepoInfo))
 	}
 
-	if repoType == "" {
+	opts := col.DefaultOptions()
+	if request.Reverse {
+		opts.Order = col.SortAscend
+	}
+	var err error
+	if request.Type == "" {
 		// blank type means return all
-		return errors.EnsureStack(d.repos.ReadOnly(ctx).List(repoInfo, col.DefaultOptions(), processFunc))
+		err = d.repos.ReadOnly(ctx).List(repoInfo, opts, processFunc)
 	} else {
-		err := d.repos.ReadOnly(ctx).GetByIndex(pfsdb.ReposTypeIndex, repoType, repoInfo, col.DefaultOptions(), processFunc)
+		err = d.repos.ReadOnly(ctx).GetByIndex(pfsdb.ReposTypeIndex, request.Type, repoInfo, opts, processFunc)
+	}
+	if err != nil {
 		return errors.EnsureStack(err)
 	}
+	if !pastCursor {
+		return errors.Errorf("cursor repo %s was not found in the listed repos", request.Cursor)
+	}
+	return nil
 }
 
 func (d *driver) deleteAllBranchesFromRepos(txnCtx *txncontext.TransactionContext, repos []pfs.RepoInfo, force bool) error {
@@ -348,6 +422,13 @@ func (d *driver) deleteRepo(txnCtx *txncontext.TransactionContext, repo *pfs.Rep
 		} else if err != nil && !errutil.IsNotFoundError(err) {
 			return errors.EnsureStack(err)
 		}
+		if repo.Type == pfs.UserRepoType {
+			if err := d.views.ReadWrite(txnCtx.SqlTx).Get(repo.Name, &pfs.ViewInfo{}); err == nil {
+				return errors.Errorf("cannot delete the repo of a view - delete the view instead")
+			} else if !col.IsErrNotFound(err) {
+				return errors.EnsureStack(err)
+			}
+		}
 	}
 	// if this is a user repo, delete any dependent repos
 	if repo.Type == pfs.UserRepoType {
@@ -408,6 +489,26 @@ func (d *driver) deleteRepo(txnCtx *txncontext.TransactionContext, repo *pfs.Rep
 	if err := d.commits.ReadWrite(txnCtx.SqlTx).DeleteByIndex(pfsdb.CommitsRepoIndex, pfsdb.RepoKey(repo)); err != nil {
 		return errors.EnsureStack(err)
 	}
+	if err := d.schemas.ReadWrite(txnCtx.SqlTx).DeleteByIndex(pfsdb.SchemasRepoIndex, pfsdb.RepoKey(repo)); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if err := d.retentionPolicies.ReadWrite(txnCtx.SqlTx).DeleteByIndex(pfsdb.RetentionPoliciesRepoIndex, pfsdb.RepoKey(repo)); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if err := d.erasures.ReadWrite(txnCtx.SqlTx).DeleteByIndex(pfsdb.ErasuresRepoIndex, pfsdb.RepoKey(repo)); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if err := d.validationPolicies.ReadWrite(txnCtx.SqlTx).Delete(pfsdb.RepoKey(repo)); err != nil && !col.IsErrNotFound(err) {
+		return errors.EnsureStack(err)
+	}
+	if err := d.uploads.ReadWrite(txnCtx.SqlTx).DeleteByIndex(pfsdb.UploadsRepoIndex, pfsdb.RepoKey(repo)); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if repo.Type == pfs.UserRepoType {
+		if err := d.views.ReadWrite(txnCtx.SqlTx).Delete(repo.Name); err != nil && !col.IsErrNotFound(err) {
+			return errors.EnsureStack(err)
+		}
+	}
 	if err := repos.Delete(repo); err != nil && !col.IsErrNotFound(err) {
 		return errors.Wrapf(err, "repos.Delete")
 	}
@@ -464,6 +565,9 @@ func (d *driver) startCommit(
 		}
 		return nil, errors.EnsureStack(err)
 	}
+	if repoInfo.Cordon != nil {
+		return nil, pfsserver.ErrRepoCordoned{Repo: branch.Repo, Cordon: repoInfo.Cordon}
+	}
 
 	// update 'branch' (which must always be set) and set parent.ID (if 'parent'
 	// was not set)
@@ -690,13 +794,13 @@ func (d *driver) aliasCommit(txnCtx *txncontext.TransactionContext, parent *pfs.
 
 func (d *driver) repoSize(ctx context.Context, repo *pfs.Repo) (int64, error) {
 	repoInfo := new(pfs.RepoInfo)
-	if err := d.repos.ReadOnly(ctx).Get(repo, repoInfo); err != nil {
+	if err := d.repoCache.Get(ctx, pfsdb.RepoKey(repo), repoInfo); err != nil {
 		return 0, errors.EnsureStack(err)
 	}
 	for _, branch := range repoInfo.Branches {
 		if branch.Name == "master" {
 			branchInfo := &pfs.BranchInfo{}
-			if err := d.branches.ReadOnly(ctx).Get(branch, branchInfo); err != nil {
+			if err := d.branchCache.Get(ctx, pfsdb.BranchKey(branch), branchInfo); err != nil {
 				return 0, errors.EnsureStack(err)
 			}
 			commit := branchInfo.Head
@@ -1108,21 +1212,49 @@ func passesCommitOriginFilter(commitInfo *pfs.CommitInfo, all bool, originKind p
 	return commitInfo.Origin.Kind != pfs.OriginKind_ALIAS
 }
 
-func (d *driver) listCommit(
-	ctx context.Context,
-	repo *pfs.Repo,
-	to *pfs.Commit,
-	from *pfs.Commit,
-	number int64,
-	reverse bool,
-	all bool,
-	originKind pfs.OriginKind,
-	cb func(*pfs.CommitInfo) error,
-) error {
+// passesCommitFilter returns true if commitInfo matches the filters of a
+// ListCommit request: its origin, state, and start time.
+func passesCommitFilter(commitInfo *pfs.CommitInfo, request *pfs.ListCommitRequest) bool {
+	if !passesCommitOriginFilter(commitInfo, request.All, request.OriginKind) {
+		return false
+	}
+	switch request.State {
+	case pfs.CommitState_FINISHING:
+		if commitInfo.Finishing == nil {
+			return false
+		}
+	case pfs.CommitState_FINISHED:
+		if commitInfo.Finished == nil {
+			return false
+		}
+	}
+	return pbutil.InTimeRange(commitInfo.Started, request.StartedAfter, request.StartedBefore)
+}
+
+// isCommitCursor returns true if commit is the cursor of a ListCommit request.
+// The cursor's repo and branch are only compared if they're set, so that a
+// cursor can be just a commit ID.
+func isCommitCursor(commit, cursor *pfs.Commit) bool {
+	if commit.ID != cursor.ID {
+		return false
+	}
+	if cursor.Branch.GetRepo().GetName() != "" && pfsdb.RepoKey(commit.Branch.Repo) != pfsdb.RepoKey(cursor.Branch.Repo) {
+		return false
+	}
+	return cursor.Branch.GetName() == "" || commit.Branch.Name == cursor.Branch.Name
+}
+
+func (d *driver) listCommit(ctx context.Context, request *pfs.ListCommitRequest, cb func(*pfs.CommitInfo) error) error {
+	repo, to, from, number, reverse := request.Repo, request.To, request.From, request.Number, request.Reverse
 	// Validate arguments
 	if repo == nil {
 		return errors.New("repo cannot be nil")
 	}
+	switch request.State {
+	case pfs.CommitState_COMMIT_STATE_UNKNOWN, pfs.CommitState_STARTED, pfs.CommitState_FINISHING, pfs.CommitState_FINISHED:
+	default:
+		return errors.Errorf("cannot filter commits by state %v", request.State)
+	}
 
 	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, repo, auth.Permission_REPO_LIST_COMMIT); err != nil {
 		return errors.EnsureStack(err)
@@ -1133,7 +1265,7 @@ func (d *driver) listCommit(
 
 	// Make sure that the repo exists
 	if repo.Name != "" {
-		if err := d.repos.ReadOnly(ctx).Get(repo, &pfs.RepoInfo{}); err != nil {
+		if err := d.repoCache.Get(ctx, pfsdb.RepoKey(repo), &pfs.RepoInfo{}); err != nil {
 			if col.IsErrNotFound(err) {
 				return pfsserver.ErrRepoNotFound{Repo: repo}
 			}
@@ -1157,6 +1289,15 @@ func (d *driver) listCommit(
 	if number == 0 {
 		number = math.MaxInt64
 	}
+	// pastCursor is true once the commits before the cursor have been skipped
+	pastCursor := request.Cursor == nil
+	skip := func(commitInfo *pfs.CommitInfo) bool {
+		if !pastCursor {
+			pastCursor = isCommitCursor(commitInfo.Commit, request.Cursor)
+			return true
+		}
+		return false
+	}
 
 	if from != nil && to == nil {
 		return errors.Errorf("cannot use `from` commit without `to` commit")
@@ -1168,14 +1309,17 @@ func (d *driver) listCommit(
 			// We don't sort these because there is no provenance between commits
 			// within a repo, so there is no topological sort necessary.
 			for i, ci := range cis {
+				if reverse {
+					ci = cis[len(cis)-1-i]
+				}
+				if skip(ci) {
+					continue
+				}
 				if number == 0 {
 					return errutil.ErrBreak
 				}
 				number--
 
-				if reverse {
-					ci = cis[len(cis)-1-i]
-				}
 				var err error
 				ci.SizeBytesUpperBound, err = d.commitSizeUpperBound(ctx, ci.Commit)
 				if err != nil && !pfsserver.IsBaseCommitNotFinishedErr(err) {
@@ -1200,7 +1344,7 @@ func (d *driver) listCommit(
 				}
 				lastRev = createRev
 			}
-			if passesCommitOriginFilter(ci, all, originKind) {
+			if passesCommitFilter(ci, request) {
 				cis = append(cis, proto.Clone(ci).(*pfs.CommitInfo))
 			}
 			return nil
@@ -1237,7 +1381,7 @@ func (d *driver) listCommit(
 			if err := d.commits.ReadOnly(ctx).Get(cursor, commitInfo); err != nil {
 				return errors.EnsureStack(err)
 			}
-			if passesCommitOriginFilter(commitInfo, all, originKind) {
+			if passesCommitFilter(commitInfo, request) && !skip(commitInfo) {
 				if err := cb(commitInfo); err != nil {
 					if errors.Is(err, errutil.ErrBreak) {
 						return nil
@@ -1249,6 +1393,9 @@ func (d *driver) listCommit(
 			cursor = commitInfo.ParentCommit
 		}
 	}
+	if !pastCursor {
+		return errors.Errorf("cursor commit %s was not found in the listed commits", request.Cursor)
+	}
 	return nil
 }
 
@@ -1649,6 +1796,90 @@ func (d *driver) clearCommit(ctx context.Context, commit *pfs.Commit) error {
 	return errors.EnsureStack(d.commitStore.DropFileSets(ctx, commit))
 }
 
+// checkpointCommit marks the files written to an open commit so far, so that
+// they can be read before the commit is finished.
+func (d *driver) checkpointCommit(ctx context.Context, commit *pfs.Commit) (*pfs.CheckpointInfo, error) {
+	commitInfo, err := d.inspectCommit(ctx, commit, pfs.CommitState_STARTED)
+	if err != nil {
+		return nil, err
+	}
+	if commitInfo.Finishing != nil {
+		return nil, pfsserver.ErrCommitFinished{Commit: commitInfo.Commit}
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, commitInfo.Commit.Branch.Repo, auth.Permission_REPO_WRITE); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	// Files written after the fileset is read go in the next checkpoint.
+	id, err := d.getFileSet(ctx, commitInfo.Commit)
+	if err != nil {
+		return nil, err
+	}
+	checkpointInfo := &pfs.CheckpointInfo{Commit: commitInfo.Commit, FileSetId: id.HexString()}
+	if err := d.txnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		checkpoint := &pfs.Checkpoint{Created: txnCtx.Timestamp}
+		if err := d.commits.ReadWrite(txnCtx.SqlTx).Update(commitInfo.Commit, commitInfo, func() error {
+			if commitInfo.Finishing != nil {
+				return pfsserver.ErrCommitFinished{Commit: commitInfo.Commit}
+			}
+			checkpoint.Number = int64(len(commitInfo.Checkpoints)) + 1
+			commitInfo.Checkpoints = append(commitInfo.Checkpoints, checkpoint)
+			return nil
+		}); err != nil {
+			return errors.EnsureStack(err)
+		}
+		checkpointInfo.Checkpoint = checkpoint
+		return errors.EnsureStack(d.commitStore.AddCheckpointTx(txnCtx.SqlTx, commitInfo.Commit, checkpoint.Number, *id))
+	}); err != nil {
+		return nil, err
+	}
+	return checkpointInfo, nil
+}
+
+// subscribeCheckpoint calls cb with the checkpoints of a commit made after
+// checkpoint number from, as they're made, until the commit is finished.
+func (d *driver) subscribeCheckpoint(ctx context.Context, commit *pfs.Commit, from int64, cb func(*pfs.CheckpointInfo) error) error {
+	commitInfo, err := d.inspectCommit(ctx, commit, pfs.CommitState_STARTED)
+	if err != nil {
+		return err
+	}
+	commit = commitInfo.Commit
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, commit.Branch.Repo, auth.Permission_REPO_READ); err != nil {
+		return errors.EnsureStack(err)
+	}
+	err = d.commits.ReadOnly(ctx).WatchOneF(commit, func(ev *watch.Event) error {
+		if ev.Type == watch.EventDelete {
+			return pfsserver.ErrCommitDeleted{Commit: commit}
+		}
+		var key string
+		commitInfo := &pfs.CommitInfo{}
+		if err := ev.Unmarshal(&key, commitInfo); err != nil {
+			return errors.Wrapf(err, "unmarshal")
+		}
+		for _, checkpoint := range commitInfo.Checkpoints {
+			if checkpoint.Number <= from {
+				continue
+			}
+			id, err := d.commitStore.GetCheckpointFileSet(ctx, commit, checkpoint.Number)
+			if err != nil {
+				return errors.EnsureStack(err)
+			}
+			if err := cb(&pfs.CheckpointInfo{
+				Commit:     commit,
+				Checkpoint: checkpoint,
+				FileSetId:  id.HexString(),
+			}); err != nil {
+				return err
+			}
+			from = checkpoint.Number
+		}
+		if commitInfo.Finishing != nil {
+			return errutil.ErrBreak
+		}
+		return nil
+	})
+	return errors.EnsureStack(err)
+}
+
 // createBranch creates a new branch or updates an existing branch (must be one
 // or the other). Most importantly, it sets 'branch.DirectProvenance' to
 // 'provenance' and then for all (downstream) branches, restores the invariant:
@@ -1975,6 +2206,9 @@ func (d *driver) deleteBranch(txnCtx *txncontext.TransactionContext, branch *pfs
 		if err := d.branches.ReadWrite(txnCtx.SqlTx).Delete(branch); err != nil {
 			return errors.Wrapf(err, "branches.Delete")
 		}
+		if err := d.retentionPolicies.ReadWrite(txnCtx.SqlTx).Delete(pfsdb.RetentionPolicyKey(branch.Repo, branch.Name)); err != nil && !col.IsErrNotFound(err) {
+			return errors.EnsureStack(err)
+		}
 	}
 	repoInfo := &pfs.RepoInfo{}
 	if err := d.repos.ReadWrite(txnCtx.SqlTx).Update(branch.Repo, repoInfo, func() error {
@@ -2021,16 +2255,38 @@ func (d *driver) addBranchProvenance(txnCtx *txncontext.TransactionContext, bran
 
 func (d *driver) deleteAll(ctx context.Context) error {
 	var repoInfos []*pfs.RepoInfo
-	if err := d.listRepo(ctx, !includeAuth, "", func(repoInfo *pfs.RepoInfo) error {
+	if err := d.listRepo(ctx, !includeAuth, &pfs.ListRepoRequest{}, func(repoInfo *pfs.RepoInfo) error {
 		repoInfos = append(repoInfos, repoInfo)
 		return nil
 	}); err != nil {
 		return err
 	}
+	var projectInfos []*pfs.ProjectInfo
+	if err := d.listProject(ctx, func(projectInfo *pfs.ProjectInfo) error {
+		if projectInfo.Project.Name != pfsdb.DefaultProjectName {
+			projectInfos = append(projectInfos, proto.Clone(projectInfo).(*pfs.ProjectInfo))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
 	return d.txnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
-		// the list does not use the transaction
+		// the list does not use the transaction. Projects that still have
+		// repos the caller can't delete are kept.
+		nonEmpty := make(map[string]bool)
 		for _, repoInfo := range repoInfos {
-			if err := d.deleteRepo(txnCtx, repoInfo.Repo, true); err != nil && !auth.IsErrNotAuthorized(err) {
+			if err := d.deleteRepo(txnCtx, repoInfo.Repo, true); err != nil {
+				if !auth.IsErrNotAuthorized(err) {
+					return err
+				}
+				nonEmpty[pfsdb.ProjectName(repoInfo.Project)] = true
+			}
+		}
+		for _, projectInfo := range projectInfos {
+			if nonEmpty[projectInfo.Project.Name] {
+				continue
+			}
+			if err := d.deleteProject(txnCtx, projectInfo.Project); err != nil && !auth.IsErrNotAuthorized(err) {
 				return err
 			}
 		}
@@ -2087,6 +2343,10 @@ func (d *driver) clearCache(ctx context.Context, tagPrefix string) error {
 	return d.cache.Clear(ctx, tagPrefix)
 }
 
+func (d *driver) countCache(ctx context.Context, tagPrefix string) (int64, error) {
+	return d.cache.Count(ctx, tagPrefix)
+}
+
 // TODO: Is this really necessary?
 type branchSet []*pfs.Branch
 
@@ -196,7 +196,7 @@ func (d *driver) getFile(ctx context.Context, file *pfs.File) (Source, error) {
 		}),
 	}
 	s := NewSource(commitInfo, fs, opts...)
-	return NewErrOnEmpty(s, &pfsserver.ErrFileNotFound{File: file}), nil
+	return newTombstoneSource(d, NewErrOnEmpty(s, &pfsserver.ErrFileNotFound{File: file}), file), nil
 }
 
 func (d *driver) inspectFile(ctx context.Context, file *pfs.File) (*pfs.FileInfo, error) {
@@ -217,7 +217,7 @@ func (d *driver) inspectFile(ctx context.Context, file *pfs.File) (*pfs.FileInfo
 	}
 	s := NewSource(commitInfo, fs, opts...)
 	var ret *pfs.FileInfo
-	s = NewErrOnEmpty(s, &pfsserver.ErrFileNotFound{File: file})
+	s = newTombstoneSource(d, NewErrOnEmpty(s, &pfsserver.ErrFileNotFound{File: file}), file)
 	if err := s.Iterate(ctx, func(fi *pfs.FileInfo, f fileset.File) error {
 		p2 := fi.File.Path
 		if p2 == p || p2 == p+"/" {
@@ -230,7 +230,7 @@ func (d *driver) inspectFile(ctx context.Context, file *pfs.File) (*pfs.FileInfo
 	return ret, nil
 }
 
-func (d *driver) listFile(ctx context.Context, file *pfs.File, cb func(*pfs.FileInfo) error) error {
+func (d *driver) listFile(ctx context.Context, file *pfs.File, contentType string, cb func(*pfs.FileInfo) error) error {
 	name := cleanPath(file.Path)
 	commitInfo, fs, err := d.openCommit(ctx, file.Commit, index.WithPrefix(name), index.WithDatum(file.Datum))
 	if err != nil {
@@ -254,7 +254,7 @@ func (d *driver) listFile(ctx context.Context, file *pfs.File, cb func(*pfs.File
 	}
 	s := NewSource(commitInfo, fs, opts...)
 	err = s.Iterate(ctx, func(fi *pfs.FileInfo, _ fileset.File) error {
-		if pathIsChild(name, cleanPath(fi.File.Path)) {
+		if pathIsChild(name, cleanPath(fi.File.Path)) && matchContentType(contentType, fi) {
 			return cb(fi)
 		}
 		return nil
@@ -289,7 +289,23 @@ func (d *driver) walkFile(ctx context.Context, file *pfs.File, cb func(*pfs.File
 	return err
 }
 
-func (d *driver) globFile(ctx context.Context, commit *pfs.Commit, glob string, cb func(*pfs.FileInfo) error) error {
+// walkCommitFiles is walkFile over all of a finished commit, without the
+// authorization checks of openCommit.
+func (d *driver) walkCommitFiles(ctx context.Context, commitInfo *pfs.CommitInfo, cb func(*pfs.FileInfo) error) error {
+	id, err := d.getFileSet(ctx, commitInfo.Commit)
+	if err != nil {
+		return err
+	}
+	fs, err := d.storage.Open(ctx, []fileset.ID{*id})
+	if err != nil {
+		return err
+	}
+	return NewSource(commitInfo, fs).Iterate(ctx, func(fi *pfs.FileInfo, _ fileset.File) error {
+		return cb(fi)
+	})
+}
+
+func (d *driver) globFile(ctx context.Context, commit *pfs.Commit, glob string, contentType string, cb func(*pfs.FileInfo) error) error {
 	glob = cleanPath(glob)
 	commitInfo, fs, err := d.openCommit(ctx, commit, index.WithPrefix(globLiteralPrefix(glob)))
 	if err != nil {
@@ -308,7 +324,7 @@ func (d *driver) globFile(ctx context.Context, commit *pfs.Commit, glob string,
 	}
 	s := NewSource(commitInfo, fs, opts...)
 	err = s.Iterate(ctx, func(fi *pfs.FileInfo, _ fileset.File) error {
-		if mf(fi.File.Path) {
+		if mf(fi.File.Path) && matchContentType(contentType, fi) {
 			return cb(fi)
 		}
 		return nil
@@ -316,6 +332,15 @@ func (d *driver) globFile(ctx context.Context, commit *pfs.Commit, glob string,
 	return errors.EnsureStack(err)
 }
 
+// matchContentType returns true if contentType is empty, or fi is a regular
+// file of that content type.
+func matchContentType(contentType string, fi *pfs.FileInfo) bool {
+	if contentType == "" {
+		return true
+	}
+	return fi.FileType == pfs.FileType_FILE && fileset.MatchContentType(contentType, fi.ContentType)
+}
+
 func (d *driver) diffFile(ctx context.Context, oldFile, newFile *pfs.File, cb func(oldFi, newFi *pfs.FileInfo) error) error {
 	// TODO: move validation to the Validating API Server
 	// Validation
@@ -1,16 +1,20 @@
 package server
 
 import (
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
 	"github.com/pachyderm/pachyderm/v2/src/internal/sdata"
 	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
+	"github.com/pachyderm/pachyderm/v2/src/internal/tableexport"
 	"github.com/pachyderm/pachyderm/v2/src/pfs"
 	"golang.org/x/net/context"
 )
@@ -24,6 +28,60 @@ func getEgressPassword() (string, error) {
 	return password, nil
 }
 
+// egressDriver writes the files in a commit to an external system. Each
+// target of an EgressRequest is written by its own driver.
+type egressDriver interface {
+	egress(ctx context.Context, src Source, commit *pfs.Commit) (*pfs.EgressResponse, error)
+}
+
+func newEgressDriver(req *pfs.EgressRequest) (egressDriver, error) {
+	switch target := req.Target.(type) {
+	case *pfs.EgressRequest_ObjectStorage:
+		return &objectStorageEgress{target.ObjectStorage}, nil
+	case *pfs.EgressRequest_SqlDatabase:
+		return &sqlDatabaseEgress{target.SqlDatabase}, nil
+	case *pfs.EgressRequest_Table:
+		return &tableEgress{target.Table}, nil
+	}
+	return nil, errors.Errorf("egress target %T is not supported", req.Target)
+}
+
+type objectStorageEgress struct {
+	*pfs.ObjectStorageEgress
+}
+
+func (e *objectStorageEgress) egress(ctx context.Context, src Source, _ *pfs.Commit) (*pfs.EgressResponse, error) {
+	result, err := copyToObjectStorage(ctx, src, e.Url)
+	if err != nil {
+		return nil, err
+	}
+	return &pfs.EgressResponse{Result: &pfs.EgressResponse_ObjectStorage{ObjectStorage: result}}, nil
+}
+
+type sqlDatabaseEgress struct {
+	*pfs.SQLDatabaseEgress
+}
+
+func (e *sqlDatabaseEgress) egress(ctx context.Context, src Source, commit *pfs.Commit) (*pfs.EgressResponse, error) {
+	result, err := copyToSQLDB(ctx, src, commit, e.SQLDatabaseEgress)
+	if err != nil {
+		return nil, err
+	}
+	return &pfs.EgressResponse{Result: &pfs.EgressResponse_SqlDatabase{SqlDatabase: result}}, nil
+}
+
+type tableEgress struct {
+	*pfs.TableEgress
+}
+
+func (e *tableEgress) egress(ctx context.Context, src Source, commit *pfs.Commit) (*pfs.EgressResponse, error) {
+	result, err := copyToTable(ctx, src, commit, e.TableEgress)
+	if err != nil {
+		return nil, err
+	}
+	return &pfs.EgressResponse{Result: &pfs.EgressResponse_Table{Table: result}}, nil
+}
+
 func copyToObjectStorage(ctx context.Context, src Source, destURL string) (*pfs.EgressResponse_ObjectStorageResult, error) {
 	bytesWritten, err := getFileURL(ctx, destURL, src)
 	if err != nil {
@@ -34,8 +92,12 @@ func copyToObjectStorage(ctx context.Context, src Source, destURL string) (*pfs.
 	return result, nil
 }
 
-func copyToSQLDB(ctx context.Context, src Source, destURL string, fileFormat *pfs.SQLDatabaseEgress_FileFormat) (*pfs.EgressResponse_SQLDatabaseResult, error) {
-	url, err := pachsql.ParseURL(destURL)
+// defaultEgressMarkerTable is the table in which the commits egressed to a
+// SQL database are recorded, if the egress doesn't name one.
+const defaultEgressMarkerTable = "pachyderm_egress_commits"
+
+func copyToSQLDB(ctx context.Context, src Source, commit *pfs.Commit, egress *pfs.SQLDatabaseEgress) (*pfs.EgressResponse_SQLDatabaseResult, error) {
+	url, err := pachsql.ParseURL(egress.Url)
 	if err != nil {
 		return nil, errors.EnsureStack(err)
 	}
@@ -51,6 +113,25 @@ func copyToSQLDB(ctx context.Context, src Source, destURL string, fileFormat *pf
 	}
 	defer db.Close()
 
+	markerTable := egress.MarkerTable
+	if markerTable == "" {
+		markerTable = defaultEgressMarkerTable
+	}
+	// the marker table is created outside of the transaction, because MySQL
+	// and Snowflake implicitly commit the transaction a table is created in
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		repo VARCHAR(255) NOT NULL,
+		commit_id VARCHAR(64) NOT NULL,
+		egressed_at TIMESTAMP NOT NULL,
+		PRIMARY KEY (repo, commit_id)
+	)`, markerTable)); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	mappings, err := newSQLTableMappings(egress.Tables)
+	if err != nil {
+		return nil, err
+	}
+
 	// all table are written through a single transaction
 	tx, err := db.BeginTxx(ctx, nil)
 	if err != nil {
@@ -58,16 +139,29 @@ func copyToSQLDB(ctx context.Context, src Source, destURL string, fileFormat *pf
 	}
 	defer tx.Rollback()
 
-	// cache tableInfos because multiple files can belong to the same table
-	tableInfos := make(map[string]*pachsql.TableInfo)
 	result := new(pfs.EgressResponse_SQLDatabaseResult)
 	result.RowsWritten = make(map[string]int64)
+	// a commit is only egressed once, which makes retrying a failed egress
+	// safe, even if it failed after its transaction was committed
+	var marked int
+	if err := tx.GetContext(ctx, &marked, fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE repo = %s AND commit_id = %s",
+		markerTable, pachsql.Placeholder(tx.DriverName(), 0), pachsql.Placeholder(tx.DriverName(), 1)),
+		commit.Branch.Repo.Name, commit.ID); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if marked > 0 {
+		result.AlreadyEgressed = true
+		return result, nil
+	}
+
+	// cache tableInfos because multiple files can belong to the same table
+	tableInfos := make(map[string]*pachsql.TableInfo)
 	err = src.Iterate(ctx, func(fi *pfs.FileInfo, file fileset.File) error {
 		if fi.FileType != pfs.FileType_FILE {
 			return nil
 		}
 
-		tableName := strings.Split(fi.File.Path, "/")[1]
+		tableName, columns := mappings.table(fi.File.Path)
 		tableInfo, ok := tableInfos[tableName]
 		if !ok {
 			// first time interacting with table, so do a full drop first
@@ -88,21 +182,27 @@ func copyToSQLDB(ctx context.Context, src Source, destURL string, fileFormat *pf
 				return errors.EnsureStack(file.Content(ctx, w))
 			},
 			func(r io.Reader) error {
-				var tr sdata.TupleReader
-				switch fileFormat.Type {
-				case pfs.SQLDatabaseEgress_FileFormat_CSV:
-					tr = sdata.NewCSVParser(r)
-				case pfs.SQLDatabaseEgress_FileFormat_JSON:
-					tr = sdata.NewJSONParser(r, fileFormat.Columns)
+				tr, fields, err := newSQLTupleReader(r, egress.FileFormat)
+				if err != nil {
+					return err
 				}
-				tw := sdata.NewSQLTupleWriter(tx, tableInfo)
-				tuple, err := sdata.NewTupleFromTableInfo(tableInfo)
+				if fields == nil {
+					// the fields are the table's columns, in order
+					fields = tableInfo.ColumnNames()
+					columns = nil
+				}
+				fileTableInfo, err := selectColumns(tableInfo, fields, columns)
+				if err != nil {
+					return errors.Wrapf(err, "writing %s to table %s", fi.File.Path, tableName)
+				}
+				tw := sdata.NewBatchedSQLTupleWriter(tx, fileTableInfo, int(egress.BatchSize))
+				tuple, err := sdata.NewTupleFromTableInfo(fileTableInfo)
 				if err != nil {
 					return errors.EnsureStack(err)
 				}
 				n, err := sdata.Copy(tw, tr, tuple)
 				result.RowsWritten[tableName] += int64(n)
-				return errors.EnsureStack(err)
+				return errors.Wrapf(err, "writing %s to table %s", fi.File.Path, tableName)
 			}); err != nil {
 			return errors.EnsureStack(err)
 		}
@@ -111,5 +211,134 @@ func copyToSQLDB(ctx context.Context, src Source, destURL string, fileFormat *pf
 	if err != nil {
 		return nil, errors.EnsureStack(err)
 	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (repo, commit_id, egressed_at) VALUES (%s, %s, %s)",
+		markerTable, pachsql.Placeholder(tx.DriverName(), 0), pachsql.Placeholder(tx.DriverName(), 1), pachsql.Placeholder(tx.DriverName(), 2)),
+		commit.Branch.Repo.Name, commit.ID, time.Now().UTC()); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
 	return result, errors.EnsureStack(tx.Commit())
 }
+
+// newSQLTupleReader returns a reader of the tuples in r, along with the names
+// of their fields, which are nil if the tuples' fields are the table's
+// columns in order.
+func newSQLTupleReader(r io.Reader, fileFormat *pfs.SQLDatabaseEgress_FileFormat) (sdata.TupleReader, []string, error) {
+	switch fileFormat.GetType() {
+	case pfs.SQLDatabaseEgress_FileFormat_CSV:
+		if fileFormat.Header {
+			tr, header, err := sdata.NewCSVParserWithHeader(r)
+			return tr, header, errors.EnsureStack(err)
+		}
+		return sdata.NewCSVParser(r), nil, nil
+	case pfs.SQLDatabaseEgress_FileFormat_JSON:
+		if len(fileFormat.Columns) == 0 {
+			return nil, nil, errors.Errorf("JSON files require file_format.columns to be set")
+		}
+		return sdata.NewJSONParser(r, fileFormat.Columns), fileFormat.Columns, nil
+	case pfs.SQLDatabaseEgress_FileFormat_PARQUET:
+		// a Parquet file's metadata is at its end, so the whole file is read
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, nil, errors.EnsureStack(err)
+		}
+		tr, columns, err := sdata.NewParquetParser(data)
+		return tr, columns, errors.EnsureStack(err)
+	}
+	return nil, nil, errors.Errorf("file format %v is not supported", fileFormat.GetType())
+}
+
+// selectColumns returns the columns of tableInfo that fields are written to,
+// in the order of fields. A field is written to the column columns maps it
+// to, or otherwise to the column of the same name.
+func selectColumns(tableInfo *pachsql.TableInfo, fields []string, columns map[string]string) (*pachsql.TableInfo, error) {
+	selected := *tableInfo
+	selected.Columns = nil
+	for _, field := range fields {
+		name := field
+		if column, ok := columns[field]; ok {
+			name = column
+		}
+		var found bool
+		for _, c := range tableInfo.Columns {
+			// databases differ in how they fold the case of identifiers
+			if strings.EqualFold(c.Name, name) {
+				selected.Columns = append(selected.Columns, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.Errorf("field %q has no column %q to be written to", field, name)
+		}
+	}
+	return &selected, nil
+}
+
+type sqlTableMapping struct {
+	match   func(string) bool
+	table   string
+	columns map[string]string
+}
+
+type sqlTableMappings []sqlTableMapping
+
+func newSQLTableMappings(tables []*pfs.SQLDatabaseEgress_TableMapping) (sqlTableMappings, error) {
+	var mappings sqlTableMappings
+	for _, t := range tables {
+		match, err := globMatchFunction(t.Glob)
+		if err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, sqlTableMapping{match: match, table: t.Table, columns: t.Columns})
+	}
+	return mappings, nil
+}
+
+// table returns the table the file at p is written to, and how its fields map
+// to the table's columns.
+func (ms sqlTableMappings) table(p string) (string, map[string]string) {
+	for _, m := range ms {
+		if m.match(p) {
+			return m.table, m.columns
+		}
+	}
+	return strings.Split(p, "/")[1], nil
+}
+
+func copyToTable(ctx context.Context, src Source, commit *pfs.Commit, table *pfs.TableEgress) (*pfs.EgressResponse_TableResult, error) {
+	url, err := obj.ParseURL(table.Url)
+	if err != nil {
+		return nil, err
+	}
+	objClient, err := obj.NewClientFromURLAndSecret(url, false)
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := tableexport.NewExporter(objClient, url, table.Format)
+	if err != nil {
+		return nil, err
+	}
+	res, err := exporter.Export(ctx, commit.ID, func(cb func(*tableexport.File) error) error {
+		return errors.EnsureStack(src.Iterate(ctx, func(fi *pfs.FileInfo, file fileset.File) error {
+			if fi.FileType != pfs.FileType_FILE {
+				return nil
+			}
+			return cb(&tableexport.File{
+				Path: fi.File.Path,
+				Hash: hex.EncodeToString(fi.Hash),
+				Content: func(w io.Writer) error {
+					return errors.EnsureStack(file.Content(ctx, w))
+				},
+			})
+		}))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pfs.EgressResponse_TableResult{
+		Version:      res.Version,
+		FilesAdded:   res.FilesAdded,
+		FilesRemoved: res.FilesRemoved,
+		BytesWritten: res.BytesWritten,
+	}, nil
+}
@@ -3,8 +3,10 @@ package server
 import (
 	"path"
 
+	"github.com/pachyderm/pachyderm/v2/src/auth"
 	"github.com/pachyderm/pachyderm/v2/src/client"
 	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	authmw "github.com/pachyderm/pachyderm/v2/src/internal/middleware/auth"
 	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
 	"github.com/pachyderm/pachyderm/v2/src/internal/serviceenv"
@@ -15,6 +17,7 @@ import (
 	"github.com/sirupsen/logrus"
 	etcd "go.etcd.io/etcd/client/v3"
 	"golang.org/x/net/context"
+	kube "k8s.io/client-go/kubernetes"
 )
 
 // Env is the dependencies needed to run the PFS API server
@@ -33,10 +36,17 @@ type Env struct {
 	GetPPSServer func() ppsserver.APIServer
 	// TODO: remove this, the load tests need a pachClient
 	GetPachClient func(ctx context.Context) *client.APIClient
+	// GetKubeClient returns the client that validation jobs are run with, so
+	// it may be nil if validator images aren't supported.
+	GetKubeClient func() kube.Interface
+	Namespace     string
 
 	BackgroundContext context.Context
 	StorageConfig     serviceenv.StorageConfiguration
 	Logger            *logrus.Logger
+	// MetadataCacheSize is the number of repos and branches each cached by
+	// the driver. If it's 0, they aren't cached.
+	MetadataCacheSize int
 }
 
 func EnvFromServiceEnv(env serviceenv.ServiceEnv, txnEnv *txnenv.TransactionEnv) (*Env, error) {
@@ -45,6 +55,18 @@ func EnvFromServiceEnv(env serviceenv.ServiceEnv, txnEnv *txnenv.TransactionEnv)
 	if err != nil {
 		return nil, err
 	}
+	if env.Config().StorageSecondaryURL != "" {
+		url, err := obj.ParseURL(env.Config().StorageSecondaryURL)
+		if err != nil {
+			return nil, err
+		}
+		secondary, err := obj.NewClientFromURLAndSecret(url, false)
+		if err != nil {
+			return nil, err
+		}
+		objClient = obj.NewMirrorClient(objClient, secondary, env.Config().StorageSecondaryAsync)
+	}
+	objClient = obj.AuditObjClient(objClient, storagePrincipal(env.Config().PPSPipelineName), env.Config().StorageAccessLog)
 	etcdPrefix := path.Join(env.Config().EtcdPrefix, env.Config().PFSEtcdPrefix)
 	if env.AuthServer() == nil {
 		panic("auth server cannot be nil")
@@ -61,9 +83,29 @@ func EnvFromServiceEnv(env serviceenv.ServiceEnv, txnEnv *txnenv.TransactionEnv)
 		AuthServer:    env.AuthServer(),
 		GetPPSServer:  env.PpsServer,
 		GetPachClient: env.GetPachClient,
+		GetKubeClient: func() kube.Interface { return env.GetKubeClient() },
+		Namespace:     env.Config().Namespace,
 
 		BackgroundContext: env.Context(),
 		StorageConfig:     env.Config().StorageConfiguration,
 		Logger:            env.Logger(),
+		MetadataCacheSize: env.Config().MetadataCacheSize,
 	}, nil
 }
+
+// storagePrincipal returns the function that object storage operations get
+// the principal they're made on behalf of from, which is the caller of the
+// request they're part of. The sidecar of a pipeline's workers only accesses
+// object storage for the pipeline's jobs, so the rest of its operations are
+// made on behalf of the pipeline.
+func storagePrincipal(pipeline string) func(context.Context) string {
+	if pipeline == "" {
+		return authmw.GetWhoAmI
+	}
+	return func(ctx context.Context) string {
+		if principal := authmw.GetWhoAmI(ctx); principal != "" {
+			return principal
+		}
+		return auth.PipelinePrefix + pipeline
+	}
+}
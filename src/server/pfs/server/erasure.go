@@ -0,0 +1,419 @@
+package server
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pacherr"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/chunk"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset/index"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/track"
+	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
+	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	pfsserver "github.com/pachyderm/pachyderm/v2/src/server/pfs"
+)
+
+// erasedPath returns true if p is the erased path, or is under it.
+func erasedPath(erased, p string) bool {
+	return p == erased || strings.HasPrefix(p, erased+"/")
+}
+
+// commitFileSets are the file sets of a commit that an erasure rewrites.
+type commitFileSets struct {
+	commit *pfs.Commit
+	diff   *fileset.ID
+	total  *fileset.ID
+}
+
+func (d *driver) eraseFile(ctx context.Context, repo *pfs.Repo, p, reason string) (*pfs.ErasureInfo, error) {
+	if repo == nil {
+		return nil, errors.New("repo must be specified")
+	}
+	p = cleanPath(p)
+	if p == "/" {
+		return nil, errors.New("cannot erase the root directory, delete the repo instead")
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, repo, auth.Permission_REPO_DELETE); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := d.repos.ReadOnly(ctx).Get(repo, &pfs.RepoInfo{}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	// The file sets of open commits are still changing, so they can't be
+	// rewritten.
+	var commits []*pfs.Commit
+	commitInfo := &pfs.CommitInfo{}
+	if err := d.commits.ReadOnly(ctx).GetByIndex(pfsdb.CommitsRepoIndex, pfsdb.RepoKey(repo), commitInfo, col.DefaultOptions(), func(string) error {
+		if commitInfo.Finished == nil {
+			return errors.Errorf("commit %v is not finished, finish it before erasing files from repo %v", commitInfo.Commit, repo)
+		}
+		commits = append(commits, proto.Clone(commitInfo.Commit).(*pfs.Commit))
+		return nil
+	}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	erasure := &pfs.ErasureInfo{
+		Id:     uuid.NewWithoutDashes(),
+		Repo:   repo,
+		Path:   p,
+		Reason: reason,
+	}
+	if err := d.storage.WithRenewer(ctx, defaultTTL, func(ctx context.Context, renewer *fileset.Renewer) error {
+		var fileSets []*commitFileSets
+		for _, commit := range commits {
+			cfs, err := d.getCommitFileSets(ctx, renewer, commit)
+			if err != nil {
+				return err
+			}
+			fileSets = append(fileSets, cfs)
+		}
+		// Find the chunks that hold the file's content in any commit first,
+		// because files in other commits may share them.
+		chunks := make(map[string]int64)
+		for _, cfs := range fileSets {
+			for _, id := range []*fileset.ID{cfs.diff, cfs.total} {
+				if id == nil {
+					continue
+				}
+				if err := d.erasedChunks(ctx, *id, p, chunks); err != nil {
+					return err
+				}
+			}
+		}
+		var rewritten []*commitFileSets
+		for _, cfs := range fileSets {
+			diff, diffChanged, err := d.rewriteFileSet(ctx, renewer, *cfs.diff, p, chunks)
+			if err != nil {
+				return err
+			}
+			total, totalChanged := cfs.total, false
+			if cfs.total != nil {
+				total, totalChanged, err = d.rewriteFileSet(ctx, renewer, *cfs.total, p, chunks)
+				if err != nil {
+					return err
+				}
+			}
+			if diffChanged || totalChanged {
+				rewritten = append(rewritten, &commitFileSets{commit: cfs.commit, diff: diff, total: total})
+			}
+		}
+		if len(rewritten) == 0 {
+			return errors.Errorf("file %v not found in any commit of repo %v", p, repo)
+		}
+		for id, size := range chunks {
+			erasure.Chunks = append(erasure.Chunks, id)
+			erasure.ChunkBytes += size
+		}
+		sort.Strings(erasure.Chunks)
+		return d.txnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+			erasure.Commits = nil
+			for _, cfs := range rewritten {
+				// Commits squashed since they were read no longer have file
+				// sets to replace.
+				if err := d.commits.ReadWrite(txnCtx.SqlTx).Get(pfsdb.CommitKey(cfs.commit), &pfs.CommitInfo{}); err != nil {
+					if col.IsErrNotFound(err) {
+						continue
+					}
+					return errors.EnsureStack(err)
+				}
+				if err := d.commitStore.ReplaceFileSetsTx(txnCtx.SqlTx, cfs.commit, *cfs.diff, cfs.total); err != nil {
+					return err
+				}
+				erasure.Commits = append(erasure.Commits, cfs.commit)
+			}
+			if whoAmI, err := txnCtx.WhoAmI(); err == nil {
+				erasure.RequestedBy = whoAmI.Username
+			}
+			erasure.Created = txnCtx.Timestamp
+			return errors.EnsureStack(d.erasures.ReadWrite(txnCtx.SqlTx).Put(pfsdb.ErasureKey(repo, erasure.Id), erasure))
+		})
+	}); err != nil {
+		return nil, err
+	}
+	d.log.Infof("erased %v from %d commits of repo %v (erasure %v)", p, len(erasure.Commits), repo, erasure.Id)
+	d.collectGarbage(ctx)
+	return erasure, nil
+}
+
+// getCommitFileSets returns the diff and total file sets of a commit. The
+// total is nil if the commit doesn't have one.
+func (d *driver) getCommitFileSets(ctx context.Context, renewer *fileset.Renewer, commit *pfs.Commit) (*commitFileSets, error) {
+	diff, err := d.commitStore.GetDiffFileSet(ctx, commit)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := renewer.Add(ctx, *diff); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	total, err := d.commitStore.GetTotalFileSet(ctx, commit)
+	if err != nil {
+		if !errors.Is(err, errNoTotalFileSet) {
+			return nil, errors.EnsureStack(err)
+		}
+		total = nil
+	} else if err := renewer.Add(ctx, *total); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return &commitFileSets{commit: commit, diff: diff, total: total}, nil
+}
+
+// erasedChunks adds the chunks that hold the content of the files at or
+// under p in the file set to chunks, along with their size.
+func (d *driver) erasedChunks(ctx context.Context, id fileset.ID, p string, chunks map[string]int64) error {
+	fs, err := d.storage.Open(ctx, []fileset.ID{id}, index.WithPrefix(p))
+	if err != nil {
+		return err
+	}
+	return errors.EnsureStack(fs.Iterate(ctx, func(f fileset.File) error {
+		idx := f.Index()
+		if !erasedPath(p, idx.Path) {
+			return nil
+		}
+		for _, dataRef := range idx.File.DataRefs {
+			chunks[chunk.ID(dataRef.Ref.Id).HexString()] = dataRef.Ref.SizeBytes
+		}
+		return nil
+	}))
+}
+
+// rewriteFileSet returns a file set that is identical to the file set at id,
+// except that the files at or under p are removed, and the files that share
+// one of chunks are written again, so that their content is stored in new
+// chunks. The layers of the file set are rewritten separately, so the
+// deletions of each layer still apply to the same files. It also returns
+// whether any layer needed to be rewritten.
+func (d *driver) rewriteFileSet(ctx context.Context, renewer *fileset.Renewer, id fileset.ID, p string, chunks map[string]int64) (*fileset.ID, bool, error) {
+	layers, err := d.storage.Flatten(ctx, []fileset.ID{id})
+	if err != nil {
+		return nil, false, err
+	}
+	var changed bool
+	var ids []fileset.ID
+	for _, layer := range layers {
+		fs, err := d.storage.Open(ctx, []fileset.ID{layer})
+		if err != nil {
+			return nil, false, err
+		}
+		erased := func(idx *index.Index) bool {
+			if erasedPath(p, idx.Path) {
+				return true
+			}
+			for _, dataRef := range idx.File.DataRefs {
+				if _, ok := chunks[chunk.ID(dataRef.Ref.Id).HexString()]; ok {
+					return true
+				}
+			}
+			return false
+		}
+		var needsRewrite bool
+		for _, deletive := range []bool{false, true} {
+			if err := fs.Iterate(ctx, func(f fileset.File) error {
+				if erased(f.Index()) {
+					needsRewrite = true
+					return errutil.ErrBreak
+				}
+				return nil
+			}, deletive); err != nil && !errors.Is(err, errutil.ErrBreak) {
+				return nil, false, errors.EnsureStack(err)
+			}
+		}
+		if !needsRewrite {
+			ids = append(ids, layer)
+			continue
+		}
+		changed = true
+		newLayer, err := d.rewriteLayer(ctx, fs, p, erased)
+		if err != nil {
+			return nil, false, err
+		}
+		if err := renewer.Add(ctx, *newLayer); err != nil {
+			return nil, false, errors.EnsureStack(err)
+		}
+		ids = append(ids, *newLayer)
+	}
+	if !changed {
+		return &id, false, nil
+	}
+	newID, err := d.storage.Compose(ctx, ids, defaultTTL)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := renewer.Add(ctx, *newID); err != nil {
+		return nil, false, errors.EnsureStack(err)
+	}
+	return newID, true, nil
+}
+
+// rewriteLayer writes a primitive file set without the files at or under p.
+// Files that erased returns true for, and that aren't under p, are written
+// again from their content rather than copied.
+func (d *driver) rewriteLayer(ctx context.Context, fs fileset.FileSet, p string, erased func(*index.Index) bool) (*fileset.ID, error) {
+	w := d.storage.NewWriter(ctx, fileset.WithTTL(defaultTTL))
+	if err := fs.Iterate(ctx, func(f fileset.File) error {
+		idx := f.Index()
+		if erasedPath(p, idx.Path) {
+			return nil
+		}
+		return w.Delete(idx.Path, idx.File.Datum)
+	}, true); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := fs.Iterate(ctx, func(f fileset.File) error {
+		idx := f.Index()
+		if erasedPath(p, idx.Path) {
+			return nil
+		}
+		if !erased(idx) {
+			return w.Copy(f, idx.File.Datum)
+		}
+		return miscutil.WithPipe(func(w2 io.Writer) error {
+			return f.Content(ctx, w2)
+		}, func(r io.Reader) error {
+			return w.Add(idx.Path, idx.File.Datum, r, fileset.WithContentType(idx.File.ContentType))
+		})
+	}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return w.Close()
+}
+
+func (d *driver) listErasure(ctx context.Context, repo *pfs.Repo, cb func(*pfs.ErasureInfo) error) error {
+	if repo == nil {
+		return errors.New("repo must be specified")
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, repo, auth.Permission_REPO_READ); err != nil {
+		return errors.EnsureStack(err)
+	}
+	erasure := &pfs.ErasureInfo{}
+	return errors.EnsureStack(d.erasures.ReadOnly(ctx).GetByIndex(pfsdb.ErasuresRepoIndex, pfsdb.RepoKey(repo), erasure, col.DefaultOptions(), func(string) error {
+		return cb(erasure)
+	}))
+}
+
+func (d *driver) verifyErasure(ctx context.Context, repo *pfs.Repo, id string) (*pfs.VerifyErasureResponse, error) {
+	if repo == nil {
+		return nil, errors.New("repo must be specified")
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, repo, auth.Permission_REPO_READ); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	erasure := &pfs.ErasureInfo{}
+	if err := d.erasures.ReadOnly(ctx).Get(pfsdb.ErasureKey(repo, id), erasure); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil, errors.Errorf("erasure %v not found in repo %v", id, repo)
+		}
+		return nil, errors.EnsureStack(err)
+	}
+	resp := &pfs.VerifyErasureResponse{Erasure: erasure}
+	var commits []*pfs.Commit
+	commitInfo := &pfs.CommitInfo{}
+	if err := d.commits.ReadOnly(ctx).GetByIndex(pfsdb.CommitsRepoIndex, pfsdb.RepoKey(repo), commitInfo, col.DefaultOptions(), func(string) error {
+		commits = append(commits, proto.Clone(commitInfo.Commit).(*pfs.Commit))
+		return nil
+	}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := d.storage.WithRenewer(ctx, defaultTTL, func(ctx context.Context, renewer *fileset.Renewer) error {
+		for _, commit := range commits {
+			cfs, err := d.getCommitFileSets(ctx, renewer, commit)
+			if err != nil {
+				return err
+			}
+			found := make(map[string]int64)
+			for _, id := range []*fileset.ID{cfs.diff, cfs.total} {
+				if id == nil {
+					continue
+				}
+				if err := d.erasedChunks(ctx, *id, erasure.Path, found); err != nil {
+					return err
+				}
+			}
+			if len(found) > 0 {
+				resp.Commits = append(resp.Commits, commit)
+			}
+			resp.CommitsChecked++
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	tracker := track.NewPostgresTracker(d.env.DB)
+	for _, chunkID := range erasure.Chunks {
+		id, err := chunk.IDFromHex(chunkID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tracker.GetExpiresAt(ctx, id.TrackerID()); err != nil {
+			if pacherr.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.EnsureStack(err)
+		}
+		resp.StoredChunks = append(resp.StoredChunks, chunkID)
+		upstream, err := tracker.GetUpstream(ctx, id.TrackerID())
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		if len(upstream) > 0 {
+			resp.ReferencedChunks = append(resp.ReferencedChunks, chunkID)
+		}
+	}
+	resp.Verified = len(resp.Commits) == 0 && len(resp.StoredChunks) == 0
+	return resp, nil
+}
+
+// fileErasure returns the erasure that erased file, or nil if it wasn't
+// erased.
+func (d *driver) fileErasure(ctx context.Context, file *pfs.File) (*pfs.ErasureInfo, error) {
+	p := cleanPath(file.Path)
+	var result *pfs.ErasureInfo
+	erasure := &pfs.ErasureInfo{}
+	if err := d.erasures.ReadOnly(ctx).GetByIndex(pfsdb.ErasuresRepoIndex, pfsdb.RepoKey(file.Commit.Branch.Repo), erasure, col.DefaultOptions(), func(string) error {
+		if erasedPath(erasure.Path, p) {
+			result = proto.Clone(erasure).(*pfs.ErasureInfo)
+			return errutil.ErrBreak
+		}
+		return nil
+	}); err != nil && !errors.Is(err, errutil.ErrBreak) {
+		return nil, errors.EnsureStack(err)
+	}
+	return result, nil
+}
+
+// tombstoneSource replaces the not found error of a source with an error
+// that names the erasure, if the file it was looking for was erased.
+type tombstoneSource struct {
+	d      *driver
+	source Source
+	file   *pfs.File
+}
+
+func newTombstoneSource(d *driver, s Source, file *pfs.File) Source {
+	return &tombstoneSource{d: d, source: s, file: file}
+}
+
+// Iterate calls cb for each File in the underlying source.
+func (s *tombstoneSource) Iterate(ctx context.Context, cb func(*pfs.FileInfo, fileset.File) error) error {
+	err := s.source.Iterate(ctx, cb)
+	notFound := &pfsserver.ErrFileNotFound{}
+	if !errors.As(err, &notFound) {
+		return err
+	}
+	erasure, lookupErr := s.d.fileErasure(ctx, s.file)
+	if lookupErr != nil || erasure == nil {
+		return err
+	}
+	return pfsserver.ErrFileErased{File: s.file, ErasureID: erasure.Id}
+}
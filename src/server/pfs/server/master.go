@@ -13,6 +13,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/middleware/auth"
 	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
 	"github.com/pachyderm/pachyderm/v2/src/internal/storage/chunk"
 	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
@@ -27,6 +28,9 @@ import (
 
 const (
 	masterLockPath = "pfs-master-lock"
+	// cronTriggerPeriod is how often triggers with a cron spec are checked,
+	// which matches the resolution of cron specs.
+	cronTriggerPeriod = time.Minute
 )
 
 func (d *driver) master(ctx context.Context) {
@@ -59,9 +63,48 @@ func (d *driver) master(ctx context.Context) {
 				return gc.RunForever(ctx)
 			})
 		}
+		cleanupPeriod := time.Second * time.Duration(d.env.StorageConfig.StorageFailedCommitCleanupPeriod)
+		if cleanupPeriod <= 0 {
+			d.log.Info("Skipping Failed Commit Cleanup")
+		} else {
+			retention := failedCommitRetention{
+				maxAge:    time.Second * time.Duration(d.env.StorageConfig.StorageFailedCommitMaxAge),
+				keepCount: d.env.StorageConfig.StorageFailedCommitKeepCount,
+			}
+			d.log.Infof("Starting Failed Commit Cleanup with period=%v, max age=%v, keep count=%v", cleanupPeriod, retention.maxAge, retention.keepCount)
+			eg.Go(func() error {
+				return d.cleanupFailedCommits(ctx, cleanupPeriod, retention)
+			})
+		}
+		retentionPeriod := time.Second * time.Duration(d.env.StorageConfig.StorageRetentionPeriod)
+		if retentionPeriod <= 0 {
+			d.log.Info("Skipping Retention Policy Enforcement")
+		} else {
+			d.log.Infof("Starting Retention Policy Enforcement with period=%v", retentionPeriod)
+			eg.Go(func() error {
+				return d.enforceRetentionPolicies(ctx, retentionPeriod)
+			})
+		}
+		if mc, ok := d.env.ObjectClient.(*obj.MirrorClient); ok {
+			checkPeriod := time.Second * time.Duration(d.env.StorageConfig.StorageMirrorCheckPeriod)
+			if checkPeriod <= 0 {
+				d.log.Info("Skipping Storage Mirror Check")
+			} else {
+				d.log.Infof("Starting Storage Mirror Check with period=%v", checkPeriod)
+				eg.Go(func() error {
+					return d.checkMirror(ctx, mc, checkPeriod)
+				})
+			}
+		}
 		eg.Go(func() error {
 			return d.finishCommits(ctx)
 		})
+		eg.Go(func() error {
+			return d.checkCronTriggers(ctx, cronTriggerPeriod)
+		})
+		eg.Go(func() error {
+			return d.fillViews(ctx)
+		})
 		return errors.EnsureStack(eg.Wait())
 	}, backoff.NewInfiniteBackOff(), func(err error, _ time.Duration) error {
 		log.Errorf("error in pfs master: %v", err)
@@ -167,6 +210,27 @@ func (d *driver) finishRepoCommits(ctx context.Context, compactor *compactor, re
 				}); err != nil {
 					return err
 				}
+				// Validate the commit's files against the repo's schemas.
+				if validationError == "" {
+					if err := miscutil.LogStep(fmt.Sprintf("checking schemas of commit %v", commit), func() error {
+						var err error
+						details.Schemas, validationError, err = d.checkSchemas(ctx, commit.Branch.Repo, *totalId)
+						return err
+					}); err != nil {
+						return err
+					}
+				}
+				// Validate the files the commit changed against the repo's
+				// validation policy.
+				if validationError == "" {
+					if err := miscutil.LogStep(fmt.Sprintf("checking validation policy of commit %v", commit), func() error {
+						var err error
+						details.Validation, validationError, err = d.checkValidationPolicy(ctx, commit, *totalId)
+						return err
+					}); err != nil {
+						return err
+					}
+				}
 				details.ValidatingTime = types.DurationProto(time.Since(start))
 				// Finish the commit.
 				return d.finalizeCommit(ctx, commit, validationError, details, totalId)
@@ -247,3 +311,23 @@ func (d *driver) finishAliasDescendents(txnCtx *txncontext.TransactionContext, p
 	}
 	return nil
 }
+
+// checkMirror periodically copies objects that are missing from the secondary
+// bucket of a mirrored object store.
+func (d *driver) checkMirror(ctx context.Context, mc *obj.MirrorClient, period time.Duration) error {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		report, err := mc.Check(ctx, "", true)
+		if err != nil {
+			log.Errorf("error checking storage mirror: %v", err)
+		} else if report.Repaired > 0 || len(report.MissingPrimary) > 0 {
+			log.Infof("storage mirror check: repaired %d object(s), %d object(s) only in secondary", report.Repaired, len(report.MissingPrimary))
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return errors.EnsureStack(ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,567 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	parquetsource "github.com/xitongsys/parquet-go/source"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/chunk"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+const (
+	defaultPreviewRows = 10
+	maxPreviewRows     = 1000
+)
+
+// preview accumulates the columns and rows of a previewed file.
+type preview struct {
+	columns []*pfs.PreviewColumn
+	index   map[string]int
+	rows    []*pfs.PreviewRow
+}
+
+func newPreview() *preview {
+	return &preview{index: make(map[string]int)}
+}
+
+// column returns the index of the column named name, adding it if it's new.
+func (p *preview) column(name string) int {
+	i, ok := p.index[name]
+	if !ok {
+		i = len(p.columns)
+		p.index[name] = i
+		p.columns = append(p.columns, &pfs.PreviewColumn{Name: name})
+	}
+	return i
+}
+
+// set sets the value of column i of row, and merges typ into its type.
+func (p *preview) set(row *pfs.PreviewRow, i int, value, typ string) {
+	for len(row.Values) <= i {
+		row.Values = append(row.Values, "")
+	}
+	row.Values[i] = value
+	p.columns[i].Type = mergeTypes(p.columns[i].Type, typ)
+}
+
+// response pads every row to the number of columns, since JSON rows can add
+// columns that earlier rows don't have.
+func (p *preview) response(truncated bool) *pfs.PreviewFileResponse {
+	for _, row := range p.rows {
+		for len(row.Values) < len(p.columns) {
+			row.Values = append(row.Values, "")
+		}
+	}
+	for _, c := range p.columns {
+		if c.Type == "" {
+			c.Type = "string"
+		}
+	}
+	return &pfs.PreviewFileResponse{Columns: p.columns, Rows: p.rows, Truncated: truncated}
+}
+
+// mergeTypes returns the type of a column that has values of types a and b.
+// An empty type is a column with no values yet.
+func mergeTypes(a, b string) string {
+	switch {
+	case a == "" || a == b:
+		return b
+	case b == "":
+		return a
+	case (a == "integer" && b == "float") || (a == "float" && b == "integer"):
+		return "float"
+	default:
+		return "mixed"
+	}
+}
+
+// csvType infers the type of a CSV field.
+func csvType(field string) string {
+	if field == "" {
+		return ""
+	}
+	if _, err := strconv.ParseInt(field, 10, 64); err == nil {
+		return "integer"
+	}
+	if _, err := strconv.ParseFloat(field, 64); err == nil {
+		return "float"
+	}
+	if _, err := strconv.ParseBool(field); err == nil {
+		return "boolean"
+	}
+	return "string"
+}
+
+// previewCSV reads the first rows records of r, after its header unless
+// noHeader is set.
+func previewCSV(r io.Reader, rows int, noHeader bool) (*pfs.PreviewFileResponse, error) {
+	p := newPreview()
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	if !noHeader {
+		header, err := cr.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return p.response(false), nil
+			}
+			return nil, errors.EnsureStack(err)
+		}
+		for _, name := range header {
+			p.column(name)
+		}
+	}
+	for {
+		record, err := cr.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return p.response(false), nil
+			}
+			return nil, errors.EnsureStack(err)
+		}
+		if len(p.rows) == rows {
+			return p.response(true), nil
+		}
+		row := &pfs.PreviewRow{}
+		for i, field := range record {
+			if i >= len(p.columns) {
+				p.column(fmt.Sprintf("column%d", i+1))
+			}
+			p.set(row, i, field, csvType(field))
+		}
+		p.rows = append(p.rows, row)
+	}
+}
+
+// jsonValue returns a JSON value as a preview value, which is unquoted if
+// it's a string, and its type.
+func jsonValue(raw json.RawMessage) (string, string, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return "", "", nil
+	}
+	switch raw[0] {
+	case '"':
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return "", "", errors.EnsureStack(err)
+		}
+		return s, "string", nil
+	case '{':
+		return string(raw), "object", nil
+	case '[':
+		return string(raw), "array", nil
+	case 't', 'f':
+		return string(raw), "boolean", nil
+	case 'n':
+		return "", "", nil
+	}
+	if bytes.ContainsAny(raw, ".eE") {
+		return string(raw), "float", nil
+	}
+	return string(raw), "integer", nil
+}
+
+// addJSONRow adds a row for a JSON value. The fields of objects become
+// columns, in the order they first appear, and other values go in a single
+// column named "value".
+func (p *preview) addJSONRow(raw json.RawMessage) error {
+	row := &pfs.PreviewRow{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	tok, err := dec.Token()
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if tok != json.Delim('{') {
+		value, typ, err := jsonValue(raw)
+		if err != nil {
+			return err
+		}
+		p.set(row, p.column("value"), value, typ)
+		p.rows = append(p.rows, row)
+		return nil
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		var field json.RawMessage
+		if err := dec.Decode(&field); err != nil {
+			return errors.EnsureStack(err)
+		}
+		value, typ, err := jsonValue(field)
+		if err != nil {
+			return err
+		}
+		p.set(row, p.column(fmt.Sprint(tok)), value, typ)
+	}
+	p.rows = append(p.rows, row)
+	return nil
+}
+
+// previewJSON reads the first rows values of r, which holds a sequence of
+// JSON values, such as newline-delimited JSON, or a single JSON array whose
+// elements are the rows.
+func previewJSON(r io.Reader, rows int) (*pfs.PreviewFileResponse, error) {
+	p := newPreview()
+	br := &bytesReader{r: r}
+	dec := json.NewDecoder(br)
+	first, err := br.peekNonSpace()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return p.response(false), nil
+		}
+		return nil, err
+	}
+	more := func() bool { return true }
+	if first == '[' {
+		if _, err := dec.Token(); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		more = dec.More
+	}
+	for more() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, errors.EnsureStack(err)
+		}
+		if len(p.rows) == rows {
+			return p.response(true), nil
+		}
+		if err := p.addJSONRow(raw); err != nil {
+			return nil, err
+		}
+	}
+	return p.response(false), nil
+}
+
+// bytesReader is a reader that can peek at the first non-space byte of r,
+// without consuming it.
+type bytesReader struct {
+	r   io.Reader
+	buf []byte
+}
+
+func (br *bytesReader) peekNonSpace() (byte, error) {
+	for {
+		trimmed := bytes.TrimLeft(br.buf, " \t\r\n")
+		if len(trimmed) > 0 {
+			return trimmed[0], nil
+		}
+		data := make([]byte, 4096)
+		n, err := br.r.Read(data)
+		br.buf = append(br.buf, data[:n]...)
+		if err != nil && n == 0 {
+			return 0, errors.EnsureStack(err)
+		}
+	}
+}
+
+func (br *bytesReader) Read(data []byte) (int, error) {
+	if len(br.buf) > 0 {
+		n := copy(data, br.buf)
+		br.buf = br.buf[n:]
+		return n, nil
+	}
+	// The error isn't wrapped, since the JSON decoder compares it with io.EOF.
+	return br.r.Read(data) //nolint:wrapcheck
+}
+
+// parquetFile is a read-only parquetsource.ParquetFile backed by an io.ReaderAt, so
+// that only the footer and the pages of the previewed rows are read.
+type parquetFile struct {
+	r      io.ReaderAt
+	size   int64
+	offset int64
+}
+
+var _ parquetsource.ParquetFile = &parquetFile{}
+
+func (f *parquetFile) Read(data []byte) (int, error) {
+	if f.offset >= f.size {
+		return 0, io.EOF
+	}
+	if remaining := f.size - f.offset; int64(len(data)) > remaining {
+		data = data[:remaining]
+	}
+	n, err := f.r.ReadAt(data, f.offset)
+	f.offset += int64(n)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return n, errors.EnsureStack(err)
+	}
+	return n, nil
+}
+
+func (f *parquetFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.size
+	default:
+		return 0, errors.Errorf("invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, errors.Errorf("invalid offset %d", offset)
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+func (f *parquetFile) Open(string) (parquetsource.ParquetFile, error) {
+	return &parquetFile{r: f.r, size: f.size}, nil
+}
+
+func (f *parquetFile) Create(string) (parquetsource.ParquetFile, error) {
+	return nil, errors.New("parquet file is read-only")
+}
+
+func (f *parquetFile) Write([]byte) (int, error) {
+	return 0, errors.New("parquet file is read-only")
+}
+
+func (f *parquetFile) Close() error { return nil }
+
+// parquetType returns the type of a Parquet column.
+func parquetType(se *parquet.SchemaElement) string {
+	var typ string
+	switch se.GetType() {
+	case parquet.Type_BOOLEAN:
+		typ = "boolean"
+	case parquet.Type_INT32, parquet.Type_INT64:
+		typ = "integer"
+	case parquet.Type_FLOAT, parquet.Type_DOUBLE:
+		typ = "float"
+	case parquet.Type_BYTE_ARRAY:
+		typ = "byte_array"
+		if se.IsSetConvertedType() && se.GetConvertedType() == parquet.ConvertedType_UTF8 {
+			typ = "string"
+		}
+	default:
+		typ = strings.ToLower(se.GetType().String())
+	}
+	return typ
+}
+
+// previewParquet reads the first rows rows of a Parquet file of size bytes.
+// Each leaf column of its schema is a column of the preview, and the values
+// of repeated columns are JSON arrays.
+func previewParquet(r io.ReaderAt, size int64, rows int) (retResp *pfs.PreviewFileResponse, retErr error) {
+	// The Parquet reader panics on some malformed files.
+	defer func() {
+		if rec := recover(); rec != nil {
+			retErr = errors.Errorf("invalid Parquet file: %v", rec)
+		}
+	}()
+	tail := make([]byte, len(parquetMagic))
+	if size < int64(2*len(parquetMagic)) {
+		return nil, errors.New("not a Parquet file: too small")
+	}
+	if _, err := r.ReadAt(tail, size-int64(len(tail))); err != nil && !errors.Is(err, io.EOF) {
+		return nil, errors.EnsureStack(err)
+	}
+	if !bytes.Equal(tail, parquetMagic) {
+		return nil, errors.New("not a Parquet file: missing magic number at the end of the file")
+	}
+	pr, err := reader.NewParquetColumnReader(&parquetFile{r: r, size: size}, 1)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid Parquet file")
+	}
+	defer pr.ReadStop()
+	numRows := pr.GetNumRows()
+	n := int64(rows)
+	if numRows < n {
+		n = numRows
+	}
+	p := newPreview()
+	for i := int64(0); i < n; i++ {
+		p.rows = append(p.rows, &pfs.PreviewRow{})
+	}
+	sh := pr.SchemaHandler
+	for i, inPath := range sh.ValueColumns {
+		// Paths start with the name of the schema's root.
+		exPath := strings.Split(sh.InPathToExPath[inPath], common.PAR_GO_PATH_DELIMITER)
+		name := strings.Join(exPath[1:], ".")
+		col := p.column(name)
+		inPathParts := strings.Split(inPath, common.PAR_GO_PATH_DELIMITER)
+		maxDL, err := sh.MaxDefinitionLevel(inPathParts)
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		maxRL, err := sh.MaxRepetitionLevel(inPathParts)
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		repeated := maxRL > 0
+		p.columns[col].Type = parquetType(sh.SchemaElements[sh.MapIndex[inPath]])
+		if repeated {
+			p.columns[col].Type = "array<" + p.columns[col].Type + ">"
+		}
+		if n == 0 {
+			continue
+		}
+		values, rls, dls, err := pr.ReadColumnByIndex(int64(i), n)
+		if err != nil {
+			return nil, errors.Wrapf(err, "read column %s", name)
+		}
+		row := -1
+		var list []interface{}
+		flush := func() error {
+			if row < 0 || !repeated {
+				return nil
+			}
+			if list == nil {
+				list = []interface{}{}
+			}
+			data, err := json.Marshal(list)
+			if err != nil {
+				return errors.EnsureStack(err)
+			}
+			p.rows[row].Values[col] = string(data)
+			list = nil
+			return nil
+		}
+		for j, v := range values {
+			if rls[j] == 0 {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+				row++
+				if row >= len(p.rows) {
+					break
+				}
+				for len(p.rows[row].Values) <= col {
+					p.rows[row].Values = append(p.rows[row].Values, "")
+				}
+			}
+			if dls[j] < maxDL || v == nil {
+				continue
+			}
+			if repeated {
+				list = append(list, v)
+			} else {
+				p.rows[row].Values[col] = fmt.Sprint(v)
+			}
+		}
+		if row < len(p.rows) {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return p.response(numRows > n), nil
+}
+
+// fileReaderAt reads ranges of a file from storage.
+type fileReaderAt struct {
+	ctx  context.Context
+	file fileset.File
+}
+
+func (f *fileReaderAt) ReadAt(data []byte, offset int64) (int, error) {
+	buf := &bytes.Buffer{}
+	w := &limitWriter{w: buf, n: int64(len(data))}
+	if err := f.file.Content(f.ctx, w, chunk.WithOffsetBytes(offset)); err != nil && !errors.Is(err, errSizeLimit) {
+		return 0, errors.EnsureStack(err)
+	}
+	n := copy(data, buf.Bytes())
+	if n < len(data) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// previewFormat returns the format of p, from its extension.
+func previewFormat(p string) pfs.FileFormat {
+	ext := strings.ToLower(path.Ext(p))
+	for format, exts := range formatExtensions {
+		for _, e := range exts {
+			if e == ext {
+				return format
+			}
+		}
+	}
+	return pfs.FileFormat_FILE_FORMAT_UNKNOWN
+}
+
+func (d *driver) previewFile(ctx context.Context, req *pfs.PreviewFileRequest) (*pfs.PreviewFileResponse, error) {
+	if req.File == nil {
+		return nil, errors.New("file must be specified")
+	}
+	rows := int(req.Rows)
+	switch {
+	case rows < 0:
+		return nil, errors.Errorf("rows must be non-negative, got %d", req.Rows)
+	case rows == 0:
+		rows = defaultPreviewRows
+	case rows > maxPreviewRows:
+		return nil, errors.Errorf("at most %d rows can be previewed", maxPreviewRows)
+	}
+	src, err := d.getFile(ctx, req.File)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSingleFile(ctx, src); err != nil {
+		return nil, err
+	}
+	var resp *pfs.PreviewFileResponse
+	if err := src.Iterate(ctx, func(fi *pfs.FileInfo, file fileset.File) error {
+		format := req.Format
+		if format == pfs.FileFormat_FILE_FORMAT_UNKNOWN {
+			format = previewFormat(fi.File.Path)
+		}
+		var err error
+		switch format {
+		case pfs.FileFormat_FORMAT_CSV, pfs.FileFormat_FORMAT_JSON:
+			// Only the beginning of the file is read: once the rows have been
+			// parsed, the pipe is closed, which stops the file's content from
+			// being read any further.
+			err = miscutil.WithPipe(func(w io.Writer) error {
+				if err := file.Content(ctx, w); err != nil && !errors.Is(err, io.ErrClosedPipe) {
+					return errors.EnsureStack(err)
+				}
+				return nil
+			}, func(r io.Reader) error {
+				var err error
+				if format == pfs.FileFormat_FORMAT_CSV {
+					resp, err = previewCSV(r, rows, req.NoHeader)
+				} else {
+					resp, err = previewJSON(r, rows)
+				}
+				return err
+			})
+		case pfs.FileFormat_FORMAT_PARQUET:
+			resp, err = previewParquet(&fileReaderAt{ctx: ctx, file: file}, fi.SizeBytes, rows)
+		default:
+			return errors.Errorf("can't infer the format of %s from its extension, the format must be specified", fi.File.Path)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "preview %s", fi.File.Path)
+		}
+		resp.FileInfo = fi
+		resp.Format = format
+		return nil
+	}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ancestry"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+// defaultProjectInfo is the info of the default project, which isn't stored.
+func defaultProjectInfo() *pfs.ProjectInfo {
+	return &pfs.ProjectInfo{
+		Project:     &pfs.Project{Name: pfsdb.DefaultProjectName},
+		Description: "Repos and pipelines that aren't created in a project.",
+	}
+}
+
+func (d *driver) createProject(txnCtx *txncontext.TransactionContext, project *pfs.Project, description string, update bool) error {
+	if project == nil {
+		return errors.New("project cannot be nil")
+	}
+	if project.Name == pfsdb.DefaultProjectName {
+		return errors.Errorf("the %s project cannot be created or updated", pfsdb.DefaultProjectName)
+	}
+	// Check that the user is logged in (user doesn't need any access level to
+	// create a project, but they must be authenticated if auth is active)
+	whoAmI, err := txnCtx.WhoAmI()
+	authIsActivated := !auth.IsErrNotActivated(err)
+	if authIsActivated && err != nil {
+		return errors.Wrapf(grpcutil.ScrubGRPC(err), "error authenticating (must log in to create a project)")
+	}
+	if err := ancestry.ValidateName(project.Name); err != nil {
+		return err
+	}
+	projects := d.projects.ReadWrite(txnCtx.SqlTx)
+	var projectInfo pfs.ProjectInfo
+	if err := projects.Get(project.Name, &projectInfo); err != nil && !col.IsErrNotFound(err) {
+		return errors.Wrapf(err, "error checking whether project %q exists", project.Name)
+	} else if err == nil {
+		if !update {
+			return errors.Errorf("project %s already exists", project.Name)
+		}
+		if projectInfo.Description == description {
+			return nil
+		}
+		// Updating a project's description requires owning it, like
+		// modifying its role bindings.
+		if err := d.env.AuthServer.CheckProjectIsAuthorizedInTransaction(txnCtx, project.Name, auth.Permission_PROJECT_MODIFY_BINDINGS); err != nil {
+			return errors.Wrapf(err, "could not update description of project %q", project.Name)
+		}
+		projectInfo.Description = description
+		return errors.EnsureStack(projects.Put(project.Name, &projectInfo))
+	}
+	if authIsActivated {
+		// Make the caller the sole owner of the new project.
+		if err := d.env.AuthServer.CreateRoleBindingInTransaction(
+			txnCtx,
+			whoAmI.Username,
+			[]string{auth.ProjectOwnerRole},
+			&auth.Resource{Type: auth.ResourceType_PROJECT, Name: project.Name},
+		); err != nil {
+			return errors.Wrapf(grpcutil.ScrubGRPC(err), "could not create role binding for new project %q", project.Name)
+		}
+	}
+	return errors.EnsureStack(projects.Create(project.Name, &pfs.ProjectInfo{
+		Project:     project,
+		Description: description,
+		Created:     txnCtx.Timestamp,
+	}))
+}
+
+func (d *driver) inspectProject(txnCtx *txncontext.TransactionContext, project *pfs.Project) (*pfs.ProjectInfo, error) {
+	if project == nil {
+		return nil, errors.New("project cannot be nil")
+	}
+	if project.Name == pfsdb.DefaultProjectName {
+		return defaultProjectInfo(), nil
+	}
+	projectInfo := &pfs.ProjectInfo{}
+	if err := d.projects.ReadWrite(txnCtx.SqlTx).Get(project.Name, projectInfo); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return projectInfo, nil
+}
+
+func (d *driver) listProject(ctx context.Context, cb func(*pfs.ProjectInfo) error) error {
+	if err := cb(defaultProjectInfo()); err != nil {
+		return err
+	}
+	projectInfo := &pfs.ProjectInfo{}
+	return errors.EnsureStack(d.projects.ReadOnly(ctx).List(projectInfo, col.DefaultOptions(), func(string) error {
+		return cb(projectInfo)
+	}))
+}
+
+func (d *driver) deleteProject(txnCtx *txncontext.TransactionContext, project *pfs.Project) error {
+	if project == nil {
+		return errors.New("project cannot be nil")
+	}
+	if project.Name == pfsdb.DefaultProjectName {
+		return errors.Errorf("the %s project cannot be deleted", pfsdb.DefaultProjectName)
+	}
+	projects := d.projects.ReadWrite(txnCtx.SqlTx)
+	if err := projects.Get(project.Name, &pfs.ProjectInfo{}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if err := d.env.AuthServer.CheckProjectIsAuthorizedInTransaction(txnCtx, project.Name, auth.Permission_PROJECT_DELETE); err != nil {
+		return errors.EnsureStack(err)
+	}
+	// Repos are deleted with their project's role bindings in place, so a
+	// project can only be deleted once it's empty.
+	var repoInfo pfs.RepoInfo
+	if err := d.repos.ReadWrite(txnCtx.SqlTx).GetByIndex(pfsdb.ReposTypeIndex, pfs.UserRepoType, &repoInfo, col.DefaultOptions(), func(string) error {
+		if repoInfo.Project.GetName() == project.Name {
+			return errors.Errorf("project %s still has repo %s, which must be deleted first", project.Name, repoInfo.Repo.Name)
+		}
+		return nil
+	}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if err := d.env.AuthServer.DeleteRoleBindingInTransaction(txnCtx, &auth.Resource{Type: auth.ResourceType_PROJECT, Name: project.Name}); err != nil && !auth.IsErrNotActivated(err) && !col.IsErrNotFound(err) {
+		return grpcutil.ScrubGRPC(err)
+	}
+	return errors.EnsureStack(projects.Delete(project.Name))
+}
+
+// repoProject returns the project a new repo is created in. nil is returned
+// for the default project, so that repos created before there were projects
+// and repos created in the default project look the same.
+func (d *driver) repoProject(txnCtx *txncontext.TransactionContext, project *pfs.Project) (*pfs.Project, error) {
+	if pfsdb.ProjectName(project) == pfsdb.DefaultProjectName {
+		return nil, nil
+	}
+	if err := d.projects.ReadWrite(txnCtx.SqlTx).Get(project.Name, &pfs.ProjectInfo{}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := d.env.AuthServer.CheckProjectIsAuthorizedInTransaction(txnCtx, project.Name, auth.Permission_PROJECT_CREATE_REPO); err != nil {
+		return nil, errors.Wrapf(err, "could not create a repo in project %q", project.Name)
+	}
+	return &pfs.Project{Name: project.Name}, nil
+}
@@ -0,0 +1,396 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	pfsserver "github.com/pachyderm/pachyderm/v2/src/server/pfs"
+)
+
+var retentionSquashedMetric = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "pachyderm",
+	Subsystem: "pfs_retention",
+	Name:      "squashed_commit_sets_total",
+	Help:      "Count of commit sets squashed because they weren't kept by a retention policy",
+})
+
+// validateRetentionPolicy returns an error if a retention policy is invalid.
+func validateRetentionPolicy(policy *pfs.RetentionPolicy) error {
+	if policy == nil {
+		return errors.New("policy must be specified")
+	}
+	if policy.KeepCommits < 0 {
+		return errors.New("keep_commits can't be negative")
+	}
+	var keepDuration time.Duration
+	if policy.KeepDuration != nil {
+		var err error
+		keepDuration, err = types.DurationFromProto(policy.KeepDuration)
+		if err != nil {
+			return errors.Wrap(err, "invalid keep_duration")
+		}
+		if keepDuration < 0 {
+			return errors.New("keep_duration can't be negative")
+		}
+	}
+	if policy.KeepCommits == 0 && keepDuration == 0 {
+		return errors.New("policy must set keep_commits or keep_duration")
+	}
+	return nil
+}
+
+// expiredCommits returns the commits of a branch's history that aren't kept
+// by policy at now. cis is the branch's history, newest first, starting at
+// its head, which is always kept. Commits that aren't finished are also
+// always kept.
+func expiredCommits(policy *pfs.RetentionPolicy, cis []*pfs.CommitInfo, now time.Time) []*pfs.CommitInfo {
+	var keepDuration time.Duration
+	if policy.KeepDuration != nil {
+		keepDuration, _ = types.DurationFromProto(policy.KeepDuration)
+	}
+	var result []*pfs.CommitInfo
+	for i, ci := range cis {
+		if i == 0 || ci.Finished == nil {
+			continue
+		}
+		if policy.KeepCommits > 0 && int64(i) < policy.KeepCommits {
+			continue
+		}
+		if keepDuration > 0 {
+			finished, err := types.TimestampFromProto(ci.Finished)
+			if err != nil || now.Sub(finished) < keepDuration {
+				continue
+			}
+		}
+		result = append(result, ci)
+	}
+	return result
+}
+
+func (d *driver) setRetentionPolicy(txnCtx *txncontext.TransactionContext, repo *pfs.Repo, branch string, policy *pfs.RetentionPolicy) (*pfs.RetentionPolicyInfo, error) {
+	if repo == nil {
+		return nil, errors.New("repo must be specified")
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorizedInTransaction(txnCtx, repo, auth.Permission_REPO_WRITE); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := d.repos.ReadWrite(txnCtx.SqlTx).Get(repo, &pfs.RepoInfo{}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if branch != "" {
+		if err := d.branches.ReadWrite(txnCtx.SqlTx).Get(repo.NewBranch(branch), &pfs.BranchInfo{}); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+	}
+	if err := validateRetentionPolicy(policy); err != nil {
+		return nil, err
+	}
+	policyInfo := &pfs.RetentionPolicyInfo{}
+	if err := d.retentionPolicies.ReadWrite(txnCtx.SqlTx).Upsert(pfsdb.RetentionPolicyKey(repo, branch), policyInfo, func() error {
+		policyInfo.Repo = repo
+		policyInfo.Branch = branch
+		policyInfo.Policy = policy
+		policyInfo.Created = txnCtx.Timestamp
+		return nil
+	}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return policyInfo, nil
+}
+
+func (d *driver) listRetentionPolicy(ctx context.Context, repo *pfs.Repo, cb func(*pfs.RetentionPolicyInfo) error) error {
+	if repo == nil {
+		return errors.New("repo must be specified")
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, repo, auth.Permission_REPO_READ); err != nil {
+		return errors.EnsureStack(err)
+	}
+	policyInfo := &pfs.RetentionPolicyInfo{}
+	return errors.EnsureStack(d.retentionPolicies.ReadOnly(ctx).GetByIndex(pfsdb.RetentionPoliciesRepoIndex, pfsdb.RepoKey(repo), policyInfo, col.DefaultOptions(), func(string) error {
+		return cb(policyInfo)
+	}))
+}
+
+func (d *driver) deleteRetentionPolicy(txnCtx *txncontext.TransactionContext, repo *pfs.Repo, branch string) error {
+	if repo == nil {
+		return errors.New("repo must be specified")
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorizedInTransaction(txnCtx, repo, auth.Permission_REPO_WRITE); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if err := d.retentionPolicies.ReadWrite(txnCtx.SqlTx).Delete(pfsdb.RetentionPolicyKey(repo, branch)); err != nil {
+		if col.IsErrNotFound(err) {
+			if branch != "" {
+				return errors.Errorf("branch %s has no retention policy", repo.NewBranch(branch))
+			}
+			return errors.Errorf("repo %s has no retention policy", repo)
+		}
+		return errors.EnsureStack(err)
+	}
+	return nil
+}
+
+// retentionEvaluator decides which commits are kept by their branch's
+// retention policy. It caches the policies and expired commits of the repos
+// and branches it has evaluated, so it should only be used for a single
+// transaction.
+type retentionEvaluator struct {
+	d     *driver
+	txn   *txncontext.TransactionContext
+	now   time.Time
+	repos map[string]map[string]*pfs.RetentionPolicy
+	// expired maps branch keys to the set of IDs of the branch's expired
+	// commits.
+	expired map[string]map[string]*pfs.CommitInfo
+}
+
+func newRetentionEvaluator(d *driver, txnCtx *txncontext.TransactionContext, now time.Time) *retentionEvaluator {
+	return &retentionEvaluator{
+		d:       d,
+		txn:     txnCtx,
+		now:     now,
+		repos:   make(map[string]map[string]*pfs.RetentionPolicy),
+		expired: make(map[string]map[string]*pfs.CommitInfo),
+	}
+}
+
+// policy returns the retention policy that applies to branch: its own, or
+// its repo's, or nil if neither has one.
+func (r *retentionEvaluator) policy(branch *pfs.Branch) (*pfs.RetentionPolicy, error) {
+	repoKey := pfsdb.RepoKey(branch.Repo)
+	policies, ok := r.repos[repoKey]
+	if !ok {
+		policies = make(map[string]*pfs.RetentionPolicy)
+		policyInfo := &pfs.RetentionPolicyInfo{}
+		if err := r.d.retentionPolicies.ReadWrite(r.txn.SqlTx).GetByIndex(pfsdb.RetentionPoliciesRepoIndex, repoKey, policyInfo, col.DefaultOptions(), func(string) error {
+			policies[policyInfo.Branch] = proto.Clone(policyInfo.Policy).(*pfs.RetentionPolicy)
+			return nil
+		}); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		r.repos[repoKey] = policies
+	}
+	if policy, ok := policies[branch.Name]; ok {
+		return policy, nil
+	}
+	return policies[""], nil
+}
+
+// expiredCommits returns the commits of branch that aren't kept by its
+// policy, keyed by commit ID.
+func (r *retentionEvaluator) expiredCommits(branch *pfs.Branch) (map[string]*pfs.CommitInfo, error) {
+	branchKey := pfsdb.BranchKey(branch)
+	if expired, ok := r.expired[branchKey]; ok {
+		return expired, nil
+	}
+	expired := make(map[string]*pfs.CommitInfo)
+	r.expired[branchKey] = expired
+	policy, err := r.policy(branch)
+	if err != nil || policy == nil {
+		return expired, err
+	}
+	branchInfo := &pfs.BranchInfo{}
+	if err := r.d.branches.ReadWrite(r.txn.SqlTx).Get(branch, branchInfo); err != nil {
+		if col.IsErrNotFound(err) {
+			return expired, nil
+		}
+		return nil, errors.EnsureStack(err)
+	}
+	// Walk the branch's history, which ends where it was branched from
+	// another branch.
+	var cis []*pfs.CommitInfo
+	for commit := branchInfo.Head; commit != nil && pfsdb.BranchKey(commit.Branch) == branchKey; {
+		ci := &pfs.CommitInfo{}
+		if err := r.d.commits.ReadWrite(r.txn.SqlTx).Get(commit, ci); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		cis = append(cis, ci)
+		commit = ci.ParentCommit
+	}
+	for _, ci := range expiredCommits(policy, cis, r.now) {
+		expired[ci.Commit.ID] = ci
+	}
+	return expired, nil
+}
+
+// squashable returns true if the commit set can be squashed: none of its
+// commits are kept by their branch's policy, and all of them have children
+// that their data can be squashed into.
+func (r *retentionEvaluator) squashable(commitSet *pfs.CommitSet) (bool, error) {
+	cis, err := r.d.inspectCommitSetImmediate(r.txn, commitSet)
+	if err != nil {
+		return false, err
+	}
+	for _, ci := range cis {
+		if len(ci.ChildCommits) == 0 || ci.Finished == nil {
+			return false, nil
+		}
+		if ci.Commit.Branch.Repo.Type == pfs.SpecRepoType && ci.Origin.Kind == pfs.OriginKind_USER {
+			return false, nil
+		}
+		policy, err := r.policy(ci.Commit.Branch)
+		if err != nil {
+			return false, err
+		}
+		if policy == nil {
+			continue
+		}
+		expired, err := r.expiredCommits(ci.Commit.Branch)
+		if err != nil {
+			return false, err
+		}
+		if _, ok := expired[ci.Commit.ID]; !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// expiredCommitSets returns the commits of repo that aren't kept by its
+// retention policies, and whose commit sets can be squashed, oldest first.
+func (d *driver) expiredCommitSets(txnCtx *txncontext.TransactionContext, repo *pfs.Repo, now time.Time) ([]*pfs.CommitInfo, error) {
+	repoInfo := &pfs.RepoInfo{}
+	if err := d.repos.ReadWrite(txnCtx.SqlTx).Get(repo, repoInfo); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	r := newRetentionEvaluator(d, txnCtx, now)
+	var result []*pfs.CommitInfo
+	seen := make(map[string]bool)
+	for _, branch := range repoInfo.Branches {
+		expired, err := r.expiredCommits(branch)
+		if err != nil {
+			return nil, err
+		}
+		for id, ci := range expired {
+			squashable, ok := seen[id]
+			if !ok {
+				squashable, err = r.squashable(client.NewCommitSet(id))
+				if err != nil {
+					return nil, err
+				}
+				seen[id] = squashable
+			}
+			if squashable {
+				result = append(result, ci)
+			}
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Finished.Compare(result[j].Finished) < 0
+	})
+	return result, nil
+}
+
+// applyRetentionPolicy squashes the commit sets of repo's commits that aren't
+// kept by its retention policies, and returns those commits. If dryRun is
+// set, the commits are only returned.
+func (d *driver) applyRetentionPolicy(ctx context.Context, repo *pfs.Repo, dryRun bool) ([]*pfs.Commit, error) {
+	if repo == nil {
+		return nil, errors.New("repo must be specified")
+	}
+	perm := auth.Permission_REPO_WRITE
+	if dryRun {
+		perm = auth.Permission_REPO_READ
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, repo, perm); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	var cis []*pfs.CommitInfo
+	if err := d.txnEnv.WithReadContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		var err error
+		cis, err = d.expiredCommitSets(txnCtx, repo, time.Now())
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	var result []*pfs.Commit
+	squashed := make(map[string]struct{})
+	for _, ci := range cis {
+		if dryRun {
+			result = append(result, ci.Commit)
+			continue
+		}
+		if _, ok := squashed[ci.Commit.ID]; !ok {
+			if err := d.txnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+				return d.squashCommitSet(txnCtx, client.NewCommitSet(ci.Commit.ID))
+			}); err != nil {
+				// Commit sets that can't be squashed yet, for example
+				// because a child commit isn't finished, are retried the
+				// next time the policy is applied.
+				if !pfsserver.IsCommitSetNotFoundErr(err) {
+					log.Errorf("error squashing commit set %v: %v", ci.Commit.ID, err)
+				}
+				continue
+			}
+			squashed[ci.Commit.ID] = struct{}{}
+			retentionSquashedMetric.Inc()
+		}
+		result = append(result, ci.Commit)
+	}
+	if len(squashed) > 0 {
+		d.log.Infof("squashed %d commit sets of repo %v that weren't kept by its retention policy", len(squashed), repo)
+		d.collectGarbage(ctx)
+	}
+	return result, nil
+}
+
+// collectGarbage runs a cycle of the storage garbage collection, so that
+// the data of squashed commits, or of erased files, is deleted without
+// waiting for the next cycle.
+func (d *driver) collectGarbage(ctx context.Context) {
+	period := time.Second * time.Duration(d.env.StorageConfig.StorageGCPeriod)
+	if period <= 0 {
+		return
+	}
+	if err := d.storage.NewGC(period).RunUntilEmpty(ctx); err != nil {
+		log.Errorf("error collecting garbage: %v", err)
+	}
+}
+
+// enforceRetentionPolicies periodically applies the retention policies of
+// all repos that have one.
+func (d *driver) enforceRetentionPolicies(ctx context.Context, period time.Duration) error {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		if err := d.enforceRetentionPoliciesOnce(ctx); err != nil {
+			log.Errorf("error enforcing retention policies: %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return errors.EnsureStack(ctx.Err())
+		}
+	}
+}
+
+func (d *driver) enforceRetentionPoliciesOnce(ctx context.Context) error {
+	repos := make(map[string]*pfs.Repo)
+	policyInfo := &pfs.RetentionPolicyInfo{}
+	if err := d.retentionPolicies.ReadOnly(ctx).List(policyInfo, col.DefaultOptions(), func(string) error {
+		repos[pfsdb.RepoKey(policyInfo.Repo)] = proto.Clone(policyInfo.Repo).(*pfs.Repo)
+		return nil
+	}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	for _, repo := range repos {
+		if _, err := d.applyRetentionPolicy(ctx, repo, false); err != nil {
+			log.Errorf("error applying the retention policy of repo %v: %v", repo, err)
+		}
+	}
+	return nil
+}
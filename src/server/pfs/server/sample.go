@@ -0,0 +1,110 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/chunk"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+// errSizeLimit is returned by a limitWriter once it has written as many bytes
+// as it may, to stop the file it's writing from being read any further.
+var errSizeLimit = errors.New("size limit reached")
+
+func validateGetFileRequest(request *pfs.GetFileRequest) error {
+	if request.Offset < 0 {
+		return errors.Errorf("offset must be non-negative, got %d", request.Offset)
+	}
+	if request.SizeBytes < 0 {
+		return errors.Errorf("size_bytes must be non-negative, got %d", request.SizeBytes)
+	}
+	if request.SampleFraction < 0 || request.SampleFraction > 1 {
+		return errors.Errorf("sample_fraction must be between 0 and 1, got %v", request.SampleFraction)
+	}
+	return nil
+}
+
+// getFileContent writes the content of file to w, from the request's offset,
+// and sampled and limited as the request asks.
+func getFileContent(ctx context.Context, w io.Writer, file fileset.File, request *pfs.GetFileRequest) error {
+	if request.SizeBytes > 0 {
+		w = &limitWriter{w: w, n: request.SizeBytes}
+	}
+	if request.SampleFraction > 0 && request.SampleFraction < 1 {
+		w = newLineSampler(w, request.SampleFraction, request.SampleSeed)
+	}
+	if err := file.Content(ctx, w, chunk.WithOffsetBytes(request.Offset)); err != nil && !errors.Is(err, errSizeLimit) {
+		return errors.EnsureStack(err)
+	}
+	return nil
+}
+
+// limitWriter writes at most n bytes to w, and then returns errSizeLimit.
+type limitWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (lw *limitWriter) Write(data []byte) (int, error) {
+	if lw.n <= 0 {
+		return 0, errSizeLimit
+	}
+	limited := int64(len(data)) > lw.n
+	if limited {
+		data = data[:lw.n]
+	}
+	n, err := lw.w.Write(data)
+	lw.n -= int64(n)
+	if err != nil {
+		return n, errors.EnsureStack(err)
+	}
+	if limited {
+		return n, errSizeLimit
+	}
+	return n, nil
+}
+
+// lineSampler writes each line written to it to w with probability fraction.
+type lineSampler struct {
+	w         io.Writer
+	fraction  float64
+	rand      *rand.Rand
+	lineStart bool
+	keep      bool
+}
+
+func newLineSampler(w io.Writer, fraction float64, seed int64) *lineSampler {
+	return &lineSampler{
+		w:         w,
+		fraction:  fraction,
+		rand:      rand.New(rand.NewSource(seed)),
+		lineStart: true,
+	}
+}
+
+func (s *lineSampler) Write(data []byte) (int, error) {
+	n := len(data)
+	for len(data) > 0 {
+		if s.lineStart {
+			s.keep = s.rand.Float64() < s.fraction
+			s.lineStart = false
+		}
+		end := len(data)
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			end = i + 1
+			s.lineStart = true
+		}
+		if s.keep {
+			if _, err := s.w.Write(data[:end]); err != nil {
+				return 0, errors.EnsureStack(err)
+			}
+		}
+		data = data[end:]
+	}
+	return n, nil
+}
@@ -0,0 +1,287 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/linkedin/goavro/v2"
+	"google.golang.org/protobuf/encoding/protowire"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	openapivalidate "k8s.io/kube-openapi/pkg/validation/validate"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
+	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+// schemaValidator validates the content of a file against a schema.
+type schemaValidator func(r io.Reader) error
+
+// newSchemaValidator returns a validator for schema, or an error if schema
+// isn't valid.
+func newSchemaValidator(schema *pfs.Schema) (schemaValidator, error) {
+	if schema == nil {
+		return nil, errors.New("schema must be set")
+	}
+	switch schema.Type {
+	case pfs.SchemaType_JSON_SCHEMA:
+		return newJSONSchemaValidator(schema.Definition)
+	case pfs.SchemaType_AVRO:
+		return newAvroValidator(schema.Definition)
+	case pfs.SchemaType_PROTOBUF:
+		return newProtobufValidator(schema.Definition, schema.Message)
+	default:
+		return nil, errors.Errorf("unsupported schema type %v", schema.Type)
+	}
+}
+
+// newJSONSchemaValidator returns a validator for files holding one or more
+// JSON values, each of which must conform to the JSON Schema definition.
+func newJSONSchemaValidator(definition []byte) (schemaValidator, error) {
+	s := &spec.Schema{}
+	if err := json.Unmarshal(definition, s); err != nil {
+		return nil, errors.Wrap(err, "invalid JSON Schema")
+	}
+	return func(r io.Reader) error {
+		decoder := json.NewDecoder(r)
+		for i := 0; ; i++ {
+			var value interface{}
+			if err := decoder.Decode(&value); err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				return errors.Wrapf(err, "invalid JSON value %d", i)
+			}
+			if err := openapivalidate.AgainstSchema(s, value, strfmt.Default); err != nil {
+				return errors.Wrapf(err, "JSON value %d", i)
+			}
+		}
+	}, nil
+}
+
+// newAvroValidator returns a validator for Avro object container files,
+// whose records must be encodable with the Avro schema definition.
+func newAvroValidator(definition []byte) (schemaValidator, error) {
+	codec, err := goavro.NewCodec(string(definition))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid Avro schema")
+	}
+	return func(r io.Reader) error {
+		ocfr, err := goavro.NewOCFReader(r)
+		if err != nil {
+			return errors.Wrap(err, "not an Avro object container file")
+		}
+		for i := 0; ocfr.Scan(); i++ {
+			record, err := ocfr.Read()
+			if err != nil {
+				return errors.Wrapf(err, "invalid record %d", i)
+			}
+			if _, err := codec.BinaryFromNative(nil, record); err != nil {
+				return errors.Wrapf(err, "record %d", i)
+			}
+		}
+		return errors.EnsureStack(ocfr.Err())
+	}, nil
+}
+
+// newProtobufValidator returns a validator for files holding a serialized
+// message, whose type is the message in the FileDescriptorSet definition.
+func newProtobufValidator(definition []byte, message string) (schemaValidator, error) {
+	fds := &descriptorpb.FileDescriptorSet{}
+	if err := protov2.Unmarshal(definition, fds); err != nil {
+		return nil, errors.Wrap(err, "invalid FileDescriptorSet")
+	}
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid FileDescriptorSet")
+	}
+	if message == "" {
+		return nil, errors.New("message must be set for protobuf schemas")
+	}
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(message))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not find message %q", message)
+	}
+	md, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, errors.Errorf("%q is not a message", message)
+	}
+	return func(r io.Reader) error {
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		msg := dynamicpb.NewMessage(md)
+		if err := protov2.Unmarshal(data, msg); err != nil {
+			return errors.Wrapf(err, "not a serialized %s", message)
+		}
+		// Any data parses as unknown fields, so files with fields the message
+		// doesn't have don't conform to it.
+		if unknown := msg.GetUnknown(); len(unknown) > 0 {
+			num, _, _ := protowire.ConsumeTag(unknown)
+			return errors.Errorf("field %d is not a field of %s", num, message)
+		}
+		return nil
+	}, nil
+}
+
+func (d *driver) setSchema(txnCtx *txncontext.TransactionContext, repo *pfs.Repo, pathPattern string, schema *pfs.Schema) (*pfs.SchemaInfo, error) {
+	if repo == nil {
+		return nil, errors.New("repo must be specified")
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorizedInTransaction(txnCtx, repo, auth.Permission_REPO_WRITE); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := d.repos.ReadWrite(txnCtx.SqlTx).Get(repo, &pfs.RepoInfo{}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	pathPattern = cleanPath(pathPattern)
+	if _, err := globMatchFunction(pathPattern); err != nil {
+		return nil, errors.Wrapf(err, "invalid path pattern %q", pathPattern)
+	}
+	if _, err := newSchemaValidator(schema); err != nil {
+		return nil, err
+	}
+	schemaInfo := &pfs.SchemaInfo{}
+	if err := d.schemas.ReadWrite(txnCtx.SqlTx).Upsert(pfsdb.SchemaKey(repo, pathPattern), schemaInfo, func() error {
+		schemaInfo.Repo = repo
+		schemaInfo.PathPattern = pathPattern
+		schemaInfo.Schema = schema
+		schemaInfo.Version++
+		schemaInfo.Created = txnCtx.Timestamp
+		return nil
+	}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return schemaInfo, nil
+}
+
+func (d *driver) listSchema(ctx context.Context, repo *pfs.Repo, cb func(*pfs.SchemaInfo) error) error {
+	if repo == nil {
+		return errors.New("repo must be specified")
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, repo, auth.Permission_REPO_READ); err != nil {
+		return errors.EnsureStack(err)
+	}
+	schemaInfo := &pfs.SchemaInfo{}
+	return errors.EnsureStack(d.schemas.ReadOnly(ctx).GetByIndex(pfsdb.SchemasRepoIndex, pfsdb.RepoKey(repo), schemaInfo, col.DefaultOptions(), func(string) error {
+		return cb(schemaInfo)
+	}))
+}
+
+func (d *driver) deleteSchema(txnCtx *txncontext.TransactionContext, repo *pfs.Repo, pathPattern string) error {
+	if repo == nil {
+		return errors.New("repo must be specified")
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorizedInTransaction(txnCtx, repo, auth.Permission_REPO_WRITE); err != nil {
+		return errors.EnsureStack(err)
+	}
+	pathPattern = cleanPath(pathPattern)
+	if err := d.schemas.ReadWrite(txnCtx.SqlTx).Delete(pfsdb.SchemaKey(repo, pathPattern)); err != nil {
+		if col.IsErrNotFound(err) {
+			return errors.Errorf("repo %s has no schema for %q", repo, pathPattern)
+		}
+		return errors.EnsureStack(err)
+	}
+	return nil
+}
+
+func (d *driver) inspectCommitSchema(ctx context.Context, commit *pfs.Commit) (*pfs.CommitSchemaInfo, error) {
+	commitInfo, err := d.inspectCommit(ctx, commit, pfs.CommitState_FINISHED)
+	if err != nil {
+		return nil, err
+	}
+	return &pfs.CommitSchemaInfo{
+		Commit:  commitInfo.Commit,
+		Schemas: commitInfo.Details.GetSchemas(),
+	}, nil
+}
+
+// checkSchemas validates the files in the file set id of a commit to repo
+// against the repo's schemas. It returns the conformance of the files to
+// each schema, and a validation error describing the first schema they
+// don't conform to.
+func (d *driver) checkSchemas(ctx context.Context, repo *pfs.Repo, id fileset.ID) ([]*pfs.SchemaConformance, string, error) {
+	type check struct {
+		match       func(string) bool
+		validate    schemaValidator
+		conformance *pfs.SchemaConformance
+	}
+	var checks []*check
+	schemaInfo := &pfs.SchemaInfo{}
+	if err := d.schemas.ReadOnly(ctx).GetByIndex(pfsdb.SchemasRepoIndex, pfsdb.RepoKey(repo), schemaInfo, col.DefaultOptions(), func(string) error {
+		match, err := globMatchFunction(schemaInfo.PathPattern)
+		if err != nil {
+			return err
+		}
+		validate, err := newSchemaValidator(schemaInfo.Schema)
+		if err != nil {
+			return err
+		}
+		checks = append(checks, &check{
+			match:    match,
+			validate: validate,
+			conformance: &pfs.SchemaConformance{
+				PathPattern: schemaInfo.PathPattern,
+				Version:     schemaInfo.Version,
+			},
+		})
+		return nil
+	}); err != nil {
+		return nil, "", errors.EnsureStack(err)
+	}
+	if len(checks) == 0 {
+		return nil, "", nil
+	}
+	fs, err := d.storage.Open(ctx, []fileset.ID{id})
+	if err != nil {
+		return nil, "", err
+	}
+	if err := fs.Iterate(ctx, func(f fileset.File) error {
+		p := f.Index().Path
+		for _, c := range checks {
+			if c.conformance.Error != "" || !c.match(p) {
+				continue
+			}
+			c.conformance.Files++
+			var validationErr error
+			if err := miscutil.WithPipe(func(w io.Writer) error {
+				return f.Content(ctx, w)
+			}, func(r io.Reader) error {
+				validationErr = c.validate(r)
+				return validationErr
+			}); err != nil && validationErr == nil {
+				return err
+			}
+			if validationErr != nil {
+				c.conformance.Error = fmt.Sprintf("%v: %v", p, validationErr)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, "", errors.EnsureStack(err)
+	}
+	var result []*pfs.SchemaConformance
+	var validationError string
+	for _, c := range checks {
+		result = append(result, c.conformance)
+		if validationError == "" && c.conformance.Error != "" {
+			validationError = fmt.Sprintf("files do not conform to the schema for %v (version %d): %v", c.conformance.PathPattern, c.conformance.Version, c.conformance.Error)
+		}
+	}
+	return result, validationError, nil
+}
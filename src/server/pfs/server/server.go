@@ -12,6 +12,8 @@ func NewAPIServer(env Env) (pfsserver.APIServer, error) {
 		return nil, err
 	}
 	go a.driver.master(env.BackgroundContext)
+	go a.driver.repoCache.Watch()
+	go a.driver.branchCache.Watch()
 	go func() { pfsload.Worker(env.GetPachClient(env.BackgroundContext), env.TaskService) }()
 	return newValidatedAPIServer(a, env.AuthServer), nil
 }
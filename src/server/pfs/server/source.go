@@ -58,6 +58,8 @@ func (s *source) Iterate(ctx context.Context, cb func(*pfs.FileInfo, fileset.Fil
 		}
 		if fileset.IsDir(idx.Path) {
 			fi.FileType = pfs.FileType_DIR
+		} else {
+			fi.ContentType = idx.File.ContentType
 		}
 		cachedFi, ok, err := s.checkFileInfoCache(ctx, cache, f)
 		if err != nil {
@@ -4,6 +4,8 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -44,9 +46,15 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/pfs"
 	pfsserver "github.com/pachyderm/pachyderm/v2/src/server/pfs"
 	"github.com/stretchr/testify/assert"
+	parquetsource "github.com/xitongsys/parquet-go/source"
+	"github.com/xitongsys/parquet-go/writer"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func CommitToID(commit interface{}) interface{} {
@@ -532,6 +540,37 @@ func TestPFS(suite *testing.T) {
 		require.ElementsEqualUnderFn(t, repoNames, repoInfos, RepoInfoToName)
 	})
 
+	suite.Run("ListRepoPagination", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+
+		for i := 0; i < 5; i++ {
+			require.NoError(t, env.PachClient.CreateRepo(fmt.Sprintf("repo%d", i)))
+		}
+		listRepo := func(request *pfs.ListRepoRequest) []string {
+			listClient, err := env.PachClient.PfsAPIClient.ListRepo(env.PachClient.Ctx(), request)
+			require.NoError(t, err)
+			repoInfos, err := clientsdk.ListRepoInfo(listClient)
+			require.NoError(t, err)
+			var names []string
+			for _, repoInfo := range repoInfos {
+				names = append(names, repoInfo.Repo.Name)
+			}
+			return names
+		}
+
+		require.Equal(t, []string{"repo4", "repo3"}, listRepo(&pfs.ListRepoRequest{Number: 2}))
+		require.Equal(t, []string{"repo2", "repo1"}, listRepo(&pfs.ListRepoRequest{Number: 2, Cursor: client.NewRepo("repo3")}))
+		require.Equal(t, []string{"repo0"}, listRepo(&pfs.ListRepoRequest{Number: 2, Cursor: client.NewRepo("repo1")}))
+		require.Equal(t, []string{"repo0", "repo1"}, listRepo(&pfs.ListRepoRequest{Number: 2, Reverse: true}))
+		require.Equal(t, 0, len(listRepo(&pfs.ListRepoRequest{CreatedBefore: &types.Timestamp{}})))
+
+		listClient, err := env.PachClient.PfsAPIClient.ListRepo(env.PachClient.Ctx(), &pfs.ListRepoRequest{Cursor: client.NewRepo("missing")})
+		require.NoError(t, err)
+		_, err = clientsdk.ListRepoInfo(listClient)
+		require.YesError(t, err)
+	})
+
 	// Make sure that artifacts of deleted repos do not resurface
 	suite.Run("CreateDeletedRepo", func(t *testing.T) {
 		t.Parallel()
@@ -597,6 +636,52 @@ func TestPFS(suite *testing.T) {
 		require.Equal(t, 1, len(commitInfos))
 	})
 
+	suite.Run("ListCommitPagination", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+
+		repo := "repo"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		var ids []string
+		for i := 0; i < 5; i++ {
+			commit, err := env.PachClient.StartCommit(repo, "master")
+			require.NoError(t, err)
+			ids = append(ids, commit.ID)
+			if i < 4 {
+				require.NoError(t, finishCommit(env.PachClient, repo, "master", commit.ID))
+			}
+		}
+		listCommit := func(request *pfs.ListCommitRequest) []string {
+			request.Repo = client.NewRepo(repo)
+			listClient, err := env.PachClient.PfsAPIClient.ListCommit(env.PachClient.Ctx(), request)
+			require.NoError(t, err)
+			commitInfos, err := clientsdk.ListCommit(listClient)
+			require.NoError(t, err)
+			var result []string
+			for _, commitInfo := range commitInfos {
+				result = append(result, commitInfo.Commit.ID)
+			}
+			return result
+		}
+
+		require.Equal(t, []string{ids[4], ids[3]}, listCommit(&pfs.ListCommitRequest{Number: 2}))
+		require.Equal(t, []string{ids[2], ids[1]}, listCommit(&pfs.ListCommitRequest{Number: 2, Cursor: &pfs.Commit{ID: ids[3]}}))
+		require.Equal(t, []string{ids[1], ids[2]}, listCommit(&pfs.ListCommitRequest{Number: 2, Reverse: true, Cursor: &pfs.Commit{ID: ids[0]}}))
+		// the open commit isn't finished
+		require.Equal(t, []string{ids[3], ids[2], ids[1], ids[0]}, listCommit(&pfs.ListCommitRequest{State: pfs.CommitState_FINISHED}))
+		require.Equal(t, 0, len(listCommit(&pfs.ListCommitRequest{StartedBefore: &types.Timestamp{}})))
+		// the cursor and the filters also apply when following a branch
+		require.Equal(t, []string{ids[3], ids[2]}, listCommit(&pfs.ListCommitRequest{
+			To:     client.NewCommit(repo, "master", ""),
+			Number: 2,
+			State:  pfs.CommitState_FINISHED,
+		}))
+		require.Equal(t, []string{ids[1], ids[0]}, listCommit(&pfs.ListCommitRequest{
+			To:     client.NewCommit(repo, "master", ""),
+			Cursor: &pfs.Commit{ID: ids[2]},
+		}))
+	})
+
 	// The DAG looks like this before the update:
 	// prov1 prov2
 	//   \    /
@@ -1612,6 +1697,56 @@ func TestPFS(suite *testing.T) {
 		require.Equal(t, len(fis), 2)
 	})
 
+	suite.Run("ContentType", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+
+		repo := "test"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		commit1, err := env.PachClient.StartCommit(repo, "master")
+		require.NoError(t, err)
+		require.NoError(t, env.PachClient.PutFile(commit1, "data/a.txt", strings.NewReader("PAR1 not really parquet")))
+		require.NoError(t, env.PachClient.PutFile(commit1, "data/b", strings.NewReader("{\"a\": 1}")))
+		require.NoError(t, env.PachClient.PutFile(commit1, "data/c.csv", strings.NewReader("a,b\n1,2\n")))
+		require.NoError(t, env.PachClient.PutFile(commit1, "data/d", strings.NewReader("a,b\n1,2\n"), client.WithContentTypePutFile("text/tab-separated-values")))
+		require.NoError(t, finishCommit(env.PachClient, repo, commit1.Branch.Name, commit1.ID))
+
+		fi, err := env.PachClient.InspectFile(commit1, "data/a.txt")
+		require.NoError(t, err)
+		require.Equal(t, "application/vnd.apache.parquet", fi.ContentType)
+		fi, err = env.PachClient.InspectFile(commit1, "data/b")
+		require.NoError(t, err)
+		require.Equal(t, "application/json", fi.ContentType)
+
+		// Appending keeps the content type of the start of the file.
+		commit2, err := env.PachClient.StartCommit(repo, "master")
+		require.NoError(t, err)
+		require.NoError(t, env.PachClient.PutFile(commit2, "data/b", strings.NewReader("garbage"), client.WithAppendPutFile()))
+		require.NoError(t, finishCommit(env.PachClient, repo, commit2.Branch.Name, commit2.ID))
+		fi, err = env.PachClient.InspectFile(commit2, "data/b")
+		require.NoError(t, err)
+		require.Equal(t, "application/json", fi.ContentType)
+
+		paths := func(fis []*pfs.FileInfo) []string {
+			var result []string
+			for _, fi := range fis {
+				result = append(result, fi.File.Path)
+			}
+			return result
+		}
+		fis, err := env.PachClient.GlobFileAll(commit2, "/data/*", client.WithContentTypeGlobFile("application/vnd.apache.parquet"))
+		require.NoError(t, err)
+		require.ElementsEqual(t, []string{"/data/a.txt"}, paths(fis))
+		fis, err = env.PachClient.GlobFileAll(commit2, "/*", client.WithContentTypeGlobFile("text/*"))
+		require.NoError(t, err)
+		require.Equal(t, 0, len(fis))
+		fis, err = env.PachClient.ListFileAll(commit2, "data", client.WithContentTypeListFile("text/*"))
+		require.NoError(t, err)
+		require.ElementsEqual(t, []string{"/data/c.csv", "/data/d"}, paths(fis))
+		_, err = env.PachClient.GlobFileAll(commit2, "/*", client.WithContentTypeGlobFile("not a type"))
+		require.YesError(t, err)
+	})
+
 	suite.Run("InspectFile2", func(t *testing.T) {
 		t.Parallel()
 		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
@@ -2564,6 +2699,41 @@ func TestPFS(suite *testing.T) {
 				}
 			}
 		})
+		t.Run("WithSizeBytesAndSample", func(t *testing.T) {
+			repo := "sample"
+			require.NoError(t, env.PachClient.CreateRepo(repo))
+
+			commit, err := env.PachClient.StartCommit(repo, "master")
+			require.NoError(t, err)
+
+			var lines []string
+			for i := 0; i < 1000; i++ {
+				lines = append(lines, fmt.Sprintf("line %d\n", i))
+			}
+			data := strings.Join(lines, "")
+			require.NoError(t, env.PachClient.PutFile(commit, "file", strings.NewReader(data)))
+			require.NoError(t, finishCommit(env.PachClient, repo, commit.Branch.Name, commit.ID))
+
+			var b bytes.Buffer
+			require.NoError(t, env.PachClient.GetFile(commit, "file", &b, client.WithOffset(5), client.WithSizeBytes(10)))
+			require.Equal(t, data[5:15], b.String())
+
+			sample := func(seed int64) string {
+				var b bytes.Buffer
+				require.NoError(t, env.PachClient.GetFile(commit, "file", &b, client.WithSample(0.1, seed)))
+				return b.String()
+			}
+			sampled := sample(1)
+			require.Equal(t, sampled, sample(1))
+			sampledLines := strings.SplitAfter(sampled, "\n")
+			sampledLines = sampledLines[:len(sampledLines)-1]
+			require.True(t, len(sampledLines) > 0 && len(sampledLines) < len(lines))
+			for _, line := range sampledLines {
+				require.OneOfEquals(t, line, lines)
+			}
+
+			require.YesError(t, env.PachClient.GetFile(commit, "file", &b, client.WithSample(2, 0)))
+		})
 	})
 
 	suite.Run("ManyPutsSingleFileSingleCommit", func(t *testing.T) {
@@ -5458,6 +5628,67 @@ func TestPFS(suite *testing.T) {
 			require.NotEqual(t, head, bi.Head.ID)
 		})
 
+		t.Run("CronWithoutCommit", func(t *testing.T) {
+			require.NoError(t, c.CreateRepo("cron-idle"))
+			require.NoError(t, c.CreateBranchTrigger("cron-idle", "trigger", "", "", &pfs.Trigger{
+				Branch:   "master",
+				CronSpec: "* * * * *", // every minute
+			}))
+			bi, err := c.InspectBranch("cron-idle", "trigger")
+			require.NoError(t, err)
+			head := bi.Head.ID
+			require.NoError(t, c.PutFile(client.NewCommit("cron-idle", "master", ""), "file1", strings.NewReader("foo")))
+			ci, err := c.WaitCommit("cron-idle", "master", "")
+			require.NoError(t, err)
+			// The trigger should fire once the cron window elapses, without
+			// another commit.
+			require.NoErrorWithinTRetry(t, 3*time.Minute, func() error {
+				bi, err := c.InspectBranch("cron-idle", "trigger")
+				if err != nil {
+					return err
+				}
+				if bi.Head.ID == head {
+					return errors.Errorf("trigger hasn't fired")
+				}
+				return nil
+			})
+			bi, err = c.InspectBranch("cron-idle", "trigger")
+			require.NoError(t, err)
+			require.Equal(t, ci.Commit.ID, bi.Head.ID)
+			require.Equal(t, int64(0), bi.TriggerStatus.PendingCommits)
+		})
+
+		t.Run("Status", func(t *testing.T) {
+			require.NoError(t, c.CreateRepo("status"))
+			require.NoError(t, c.CreateBranchTrigger("status", "trigger", "", "", &pfs.Trigger{
+				Branch:  "master",
+				Size_:   "1K",
+				Commits: 3,
+			}))
+			masterHead := client.NewCommit("status", "master", "")
+			require.NoError(t, c.PutFile(masterHead, "file1", strings.NewReader("foo")))
+			_, err := c.WaitCommit("status", "master", "")
+			require.NoError(t, err)
+			require.NoError(t, c.PutFile(masterHead, "file2", strings.NewReader("bar")))
+			_, err = c.WaitCommit("status", "master", "")
+			require.NoError(t, err)
+
+			bi, err := c.InspectBranch("status", "trigger")
+			require.NoError(t, err)
+			require.NotNil(t, bi.TriggerStatus)
+			require.Equal(t, int64(2), bi.TriggerStatus.PendingCommits)
+			require.Equal(t, int64(6), bi.TriggerStatus.PendingBytes)
+			require.False(t, bi.TriggerStatus.SizeMet)
+			require.False(t, bi.TriggerStatus.CommitsMet)
+			require.False(t, bi.TriggerStatus.Triggered)
+			require.Nil(t, bi.TriggerStatus.NextCron)
+
+			// Branches without a trigger have no status
+			bi, err = c.InspectBranch("status", "master")
+			require.NoError(t, err)
+			require.Nil(t, bi.TriggerStatus)
+		})
+
 		t.Run("Count", func(t *testing.T) {
 			require.NoError(t, c.CreateRepo("count"))
 			require.NoError(t, c.CreateBranchTrigger("count", "trigger", "", "", &pfs.Trigger{
@@ -6147,6 +6378,617 @@ func TestPFS(suite *testing.T) {
 		require.False(t, strings.Contains(err.Error(), pfs.UserRepoType))
 	})
 
+	suite.Run("Projects", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		c := env.PachClient
+
+		require.YesError(t, c.CreateProject("default", ""))
+		require.YesError(t, c.CreateProject("bad.name", ""))
+		require.NoError(t, c.CreateProject("vision", "image processing"))
+		require.YesError(t, c.CreateProject("vision", ""))
+		projectInfos, err := c.ListProject()
+		require.NoError(t, err)
+		require.Equal(t, 2, len(projectInfos))
+		require.Equal(t, "default", projectInfos[0].Project.Name)
+		require.Equal(t, "vision", projectInfos[1].Project.Name)
+		projectInfo, err := c.InspectProject("vision")
+		require.NoError(t, err)
+		require.Equal(t, "image processing", projectInfo.Description)
+		_, err = c.InspectProject("nlp")
+		require.YesError(t, err)
+		require.True(t, errutil.IsNotFoundError(err))
+
+		// repos are created in the default project unless one is given
+		require.NoError(t, c.CreateRepo("text"))
+		require.NoError(t, c.CreateProjectRepo("vision", "images"))
+		require.YesError(t, c.CreateProjectRepo("nlp", "words"))
+		repoInfo, err := c.InspectRepo("images")
+		require.NoError(t, err)
+		require.Equal(t, "vision", repoInfo.Project.Name)
+		repoInfos, err := c.ListProjectRepo("vision")
+		require.NoError(t, err)
+		require.Equal(t, 1, len(repoInfos))
+		require.Equal(t, "images", repoInfos[0].Repo.Name)
+		repoInfos, err = c.ListProjectRepo("default")
+		require.NoError(t, err)
+		require.Equal(t, 1, len(repoInfos))
+		require.Equal(t, "text", repoInfos[0].Repo.Name)
+
+		// a repo can't be moved to another project
+		_, err = c.PfsAPIClient.CreateRepo(c.Ctx(), &pfs.CreateRepoRequest{
+			Repo:    client.NewRepo("images"),
+			Project: client.NewProject("default"),
+			Update:  true,
+		})
+		require.YesError(t, err)
+
+		// a project can only be deleted once it's empty
+		require.YesError(t, c.DeleteProject("default"))
+		require.YesError(t, c.DeleteProject("vision"))
+		require.NoError(t, c.DeleteRepo("images", false))
+		require.NoError(t, c.DeleteProject("vision"))
+		projectInfos, err = c.ListProject()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(projectInfos))
+	})
+
+	suite.Run("Checkpoints", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		c := env.PachClient
+		repo := "test"
+		require.NoError(t, c.CreateRepo(repo))
+		commit, err := c.StartCommit(repo, "master")
+		require.NoError(t, err)
+
+		require.NoError(t, c.PutFile(commit, "a", strings.NewReader("foo")))
+		first, err := c.CheckpointCommit(repo, "master", commit.ID)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), first.Checkpoint.Number)
+		require.NoError(t, c.DeleteFile(commit, "a"))
+		require.NoError(t, c.PutFile(commit, "b", strings.NewReader("bar")))
+		second, err := c.CheckpointCommit(repo, "master", commit.ID)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), second.Checkpoint.Number)
+
+		// the files as of a checkpoint don't change
+		var buf bytes.Buffer
+		require.NoError(t, c.GetFile(client.NewCheckpointCommit(first), "a", &buf))
+		require.Equal(t, "foo", buf.String())
+		fileInfos, err := c.ListFileAll(client.NewCheckpointCommit(second), "/")
+		require.NoError(t, err)
+		require.Equal(t, 1, len(fileInfos))
+		require.Equal(t, "/b", fileInfos[0].File.Path)
+
+		require.NoError(t, c.FinishCommit(repo, "master", commit.ID))
+		_, err = c.CheckpointCommit(repo, "master", commit.ID)
+		require.YesError(t, err)
+		commitInfo, err := c.InspectCommit(repo, "master", commit.ID)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(commitInfo.Checkpoints))
+
+		// subscribing to a finished commit returns its checkpoints and ends
+		var numbers []int64
+		require.NoError(t, c.SubscribeCheckpoint(commit, 1, func(checkpointInfo *pfs.CheckpointInfo) error {
+			numbers = append(numbers, checkpointInfo.Checkpoint.Number)
+			return nil
+		}))
+		require.ElementsEqual(t, []int64{2}, numbers)
+	})
+
+	suite.Run("Schemas", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		repo := "test"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+
+		// Invalid schemas are rejected.
+		_, err := env.PachClient.SetSchema(repo, "/*.json", &pfs.Schema{
+			Type:       pfs.SchemaType_JSON_SCHEMA,
+			Definition: []byte("not json"),
+		})
+		require.YesError(t, err)
+		_, err = env.PachClient.SetSchema(repo, "/*.avro", &pfs.Schema{
+			Type:       pfs.SchemaType_AVRO,
+			Definition: []byte(`{"type": "nope"}`),
+		})
+		require.YesError(t, err)
+
+		jsonSchema := &pfs.Schema{
+			Type:       pfs.SchemaType_JSON_SCHEMA,
+			Definition: []byte(`{"type": "object", "required": ["id"], "properties": {"id": {"type": "integer"}}}`),
+		}
+		schemaInfo, err := env.PachClient.SetSchema(repo, "/*.json", jsonSchema)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), schemaInfo.Version)
+		descriptors := &descriptorpb.FileDescriptorSet{
+			File: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(durationpb.File_google_protobuf_duration_proto)},
+		}
+		definition, err := protov2.Marshal(descriptors)
+		require.NoError(t, err)
+		_, err = env.PachClient.SetSchema(repo, "/*.pb", &pfs.Schema{
+			Type:       pfs.SchemaType_PROTOBUF,
+			Definition: definition,
+			Message:    "google.protobuf.Duration",
+		})
+		require.NoError(t, err)
+		schemaInfos, err := env.PachClient.ListSchema(repo)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(schemaInfos))
+
+		// Conforming files.
+		commit := client.NewCommit(repo, "master", "")
+		require.NoError(t, env.PachClient.PutFile(commit, "a.json", strings.NewReader("{\"id\": 1}\n{\"id\": 2}\n")))
+		duration, err := protov2.Marshal(durationpb.New(time.Second))
+		require.NoError(t, err)
+		require.NoError(t, env.PachClient.PutFile(commit, "a.pb", bytes.NewReader(duration)))
+		require.NoError(t, env.PachClient.PutFile(commit, "a.txt", strings.NewReader("not validated")))
+		commitSchemaInfo, err := env.PachClient.InspectCommitSchema(repo, "master", "")
+		require.NoError(t, err)
+		require.Equal(t, 2, len(commitSchemaInfo.Schemas))
+		for _, conformance := range commitSchemaInfo.Schemas {
+			require.Equal(t, "", conformance.Error)
+			require.Equal(t, int64(1), conformance.Files)
+		}
+		commitInfo, err := env.PachClient.InspectCommit(repo, "master", "")
+		require.NoError(t, err)
+		require.Equal(t, "", commitInfo.Error)
+
+		// Nonconforming files error the commit.
+		require.NoError(t, env.PachClient.PutFile(commit, "b.json", strings.NewReader(`{"id": "one"}`)))
+		commitSchemaInfo, err = env.PachClient.InspectCommitSchema(repo, "master", "")
+		require.NoError(t, err)
+		commitInfo, err = env.PachClient.InspectCommit(repo, "master", "")
+		require.NoError(t, err)
+		require.True(t, strings.Contains(commitInfo.Error, "/b.json"))
+		for _, conformance := range commitSchemaInfo.Schemas {
+			if conformance.PathPattern == "/*.json" {
+				require.True(t, strings.Contains(conformance.Error, "/b.json"))
+			}
+		}
+
+		// Updating the schema increments its version.
+		require.NoError(t, env.PachClient.DeleteFile(commit, "b.json"))
+		schemaInfo, err = env.PachClient.SetSchema(repo, "/*.json", jsonSchema)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), schemaInfo.Version)
+		require.NoError(t, env.PachClient.PutFile(commit, "c.pb", strings.NewReader("\xff\xff")))
+		commitInfo, err = env.PachClient.WaitCommit(repo, "master", "")
+		require.NoError(t, err)
+		require.True(t, strings.Contains(commitInfo.Error, "/c.pb"))
+
+		require.NoError(t, env.PachClient.DeleteSchema(repo, "/*.pb"))
+		require.YesError(t, env.PachClient.DeleteSchema(repo, "/*.pb"))
+		schemaInfos, err = env.PachClient.ListSchema(repo)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(schemaInfos))
+		require.Equal(t, int64(2), schemaInfos[0].Version)
+	})
+
+	suite.Run("RetentionPolicies", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		repo := "test"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		commit := client.NewCommit(repo, "master", "")
+		var ids []string
+		for i := 0; i < 5; i++ {
+			require.NoError(t, env.PachClient.PutFile(commit, fmt.Sprintf("file%d", i), strings.NewReader("foo")))
+			commitInfo, err := env.PachClient.WaitCommit(repo, "master", "")
+			require.NoError(t, err)
+			ids = append(ids, commitInfo.Commit.ID)
+		}
+		require.NoError(t, env.PachClient.CreateBranch(repo, "staging", "master", "", nil))
+
+		// Invalid policies are rejected.
+		_, err := env.PachClient.SetRetentionPolicy(repo, "", &pfs.RetentionPolicy{})
+		require.YesError(t, err)
+		_, err = env.PachClient.SetRetentionPolicy(repo, "", &pfs.RetentionPolicy{KeepCommits: -1})
+		require.YesError(t, err)
+		_, err = env.PachClient.SetRetentionPolicy(repo, "missing", &pfs.RetentionPolicy{KeepCommits: 1})
+		require.YesError(t, err)
+
+		// A repo's policy applies to branches without their own policy.
+		_, err = env.PachClient.SetRetentionPolicy(repo, "", &pfs.RetentionPolicy{KeepCommits: 3})
+		require.NoError(t, err)
+		_, err = env.PachClient.SetRetentionPolicy(repo, "staging", &pfs.RetentionPolicy{KeepDuration: types.DurationProto(time.Hour)})
+		require.NoError(t, err)
+		policyInfos, err := env.PachClient.ListRetentionPolicy(repo)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(policyInfos))
+
+		// A dry run returns the expired commits without squashing them.
+		expired, err := env.PachClient.ApplyRetentionPolicy(repo, true)
+		require.NoError(t, err)
+		var expiredIDs []string
+		for _, c := range expired {
+			expiredIDs = append(expiredIDs, c.ID)
+		}
+		require.ElementsEqual(t, ids[:2], expiredIDs)
+		commitInfos, err := env.PachClient.ListCommit(client.NewRepo(repo), client.NewCommit(repo, "master", ""), nil, 0)
+		require.NoError(t, err)
+		require.Equal(t, 5, len(commitInfos))
+
+		expired, err = env.PachClient.ApplyRetentionPolicy(repo, false)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(expired))
+		commitInfos, err = env.PachClient.ListCommit(client.NewRepo(repo), client.NewCommit(repo, "master", ""), nil, 0)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(commitInfos))
+		_, err = env.PachClient.InspectCommit(repo, "master", ids[0])
+		require.YesError(t, err)
+		// The squashed commits' data remains in their descendants.
+		var files []string
+		require.NoError(t, env.PachClient.ListFile(commit, "/", func(fi *pfs.FileInfo) error {
+			files = append(files, fi.File.Path)
+			return nil
+		}))
+		require.Equal(t, 5, len(files))
+
+		// The policy is already satisfied.
+		expired, err = env.PachClient.ApplyRetentionPolicy(repo, true)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(expired))
+
+		require.NoError(t, env.PachClient.DeleteRetentionPolicy(repo, "staging"))
+		require.YesError(t, env.PachClient.DeleteRetentionPolicy(repo, "staging"))
+		policyInfos, err = env.PachClient.ListRetentionPolicy(repo)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(policyInfos))
+		require.Equal(t, "", policyInfos[0].Branch)
+	})
+
+	suite.Run("EraseFile", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		repo := "test"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		commit := client.NewCommit(repo, "master", "")
+		require.NoError(t, env.PachClient.PutFile(commit, "users/alice", strings.NewReader("alice's data")))
+		require.NoError(t, env.PachClient.PutFile(commit, "users/bob", strings.NewReader("bob's data")))
+		first, err := env.PachClient.WaitCommit(repo, "master", "")
+		require.NoError(t, err)
+		require.NoError(t, env.PachClient.PutFile(commit, "users/alice", strings.NewReader(" and more"), client.WithAppendPutFile()))
+		second, err := env.PachClient.WaitCommit(repo, "master", "")
+		require.NoError(t, err)
+
+		_, err = env.PachClient.EraseFile(repo, "/", "everything")
+		require.YesError(t, err)
+		_, err = env.PachClient.EraseFile(repo, "users/carol", "no such file")
+		require.YesError(t, err)
+
+		erasure, err := env.PachClient.EraseFile(repo, "users/alice", "deletion request")
+		require.NoError(t, err)
+		require.Equal(t, "/users/alice", erasure.Path)
+		require.Equal(t, "deletion request", erasure.Reason)
+		require.Equal(t, 2, len(erasure.Commits))
+		require.True(t, len(erasure.Chunks) > 0)
+
+		// The file is gone from every commit, and reading it names the
+		// erasure, while the other files are unchanged.
+		for _, ci := range []*pfs.CommitInfo{first, second} {
+			_, err := env.PachClient.InspectFile(ci.Commit, "users/alice")
+			require.YesError(t, err)
+			require.True(t, pfsserver.IsFileNotFoundErr(err))
+			require.True(t, strings.Contains(err.Error(), erasure.Id))
+			buf := &bytes.Buffer{}
+			require.NoError(t, env.PachClient.GetFile(ci.Commit, "users/bob", buf))
+			require.Equal(t, "bob's data", buf.String())
+		}
+
+		erasures, err := env.PachClient.ListErasure(repo)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(erasures))
+		require.Equal(t, erasure.Id, erasures[0].Id)
+
+		resp, err := env.PachClient.VerifyErasure(repo, erasure.Id)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), resp.CommitsChecked)
+		require.Equal(t, 0, len(resp.Commits))
+		_, err = env.PachClient.VerifyErasure(repo, "missing")
+		require.YesError(t, err)
+	})
+
+	suite.Run("ValidationPolicy", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		repo := "test"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+
+		// Invalid policies are rejected.
+		_, err := env.PachClient.SetValidationPolicy(repo, &pfs.ValidationPolicy{AllowedExtensions: []string{"csv"}})
+		require.YesError(t, err)
+		_, err = env.PachClient.InspectValidationPolicy(repo)
+		require.YesError(t, err)
+
+		_, err = env.PachClient.SetValidationPolicy(repo, &pfs.ValidationPolicy{
+			AllowedExtensions: []string{".csv", ".json"},
+			MaxFileSizeBytes:  100,
+			Formats:           []pfs.FileFormat{pfs.FileFormat_FORMAT_CSV, pfs.FileFormat_FORMAT_JSON},
+		})
+		require.NoError(t, err)
+		policyInfo, err := env.PachClient.InspectValidationPolicy(repo)
+		require.NoError(t, err)
+		require.Equal(t, pfs.ValidationAction_REJECT, policyInfo.Policy.Action)
+
+		// Conforming files.
+		commit := client.NewCommit(repo, "master", "")
+		require.NoError(t, env.PachClient.PutFile(commit, "a.csv", strings.NewReader("a,b\n1,2\n")))
+		require.NoError(t, env.PachClient.PutFile(commit, "a.json", strings.NewReader("{\"id\": 1}\n{\"id\": 2}\n")))
+		commitInfo, err := env.PachClient.WaitCommit(repo, "master", "")
+		require.NoError(t, err)
+		require.Equal(t, "", commitInfo.Error)
+		require.Equal(t, int64(1), commitInfo.Details.Validation.FilesChecked)
+		require.Equal(t, int64(0), commitInfo.Details.Validation.ViolationCount)
+
+		// Nonconforming files error the commit.
+		require.NoError(t, env.PachClient.PutFile(commit, "b.csv", strings.NewReader("a,b\n1\n")))
+		commitInfo, err = env.PachClient.WaitCommit(repo, "master", "")
+		require.NoError(t, err)
+		require.True(t, strings.Contains(commitInfo.Error, "/b.csv"))
+		require.Equal(t, "format", commitInfo.Details.Validation.Violations[0].Rule)
+
+		// Flagged files don't error the commit, and only the files a commit
+		// changes are validated.
+		_, err = env.PachClient.SetValidationPolicy(repo, &pfs.ValidationPolicy{
+			Action:            pfs.ValidationAction_FLAG,
+			AllowedExtensions: []string{".csv"},
+			MaxFileSizeBytes:  10,
+		})
+		require.NoError(t, err)
+		require.NoError(t, env.PachClient.PutFile(commit, "c.txt", strings.NewReader("more than ten bytes")))
+		commitInfo, err = env.PachClient.WaitCommit(repo, "master", "")
+		require.NoError(t, err)
+		require.Equal(t, "", commitInfo.Error)
+		validation := commitInfo.Details.Validation
+		require.Equal(t, pfs.ValidationAction_FLAG, validation.Action)
+		require.Equal(t, int64(1), validation.FilesChecked)
+		require.Equal(t, int64(2), validation.ViolationCount)
+		for _, violation := range validation.Violations {
+			require.Equal(t, "/c.txt", violation.Path)
+		}
+
+		require.NoError(t, env.PachClient.DeleteValidationPolicy(repo))
+		require.YesError(t, env.PachClient.DeleteValidationPolicy(repo))
+		require.NoError(t, env.PachClient.PutFile(commit, "d.txt", strings.NewReader("not validated")))
+		commitInfo, err = env.PachClient.WaitCommit(repo, "master", "")
+		require.NoError(t, err)
+		require.Equal(t, "", commitInfo.Error)
+		require.Nil(t, commitInfo.Details.Validation)
+	})
+
+	suite.Run("Views", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		images, labels, view := "images", "labels", "training"
+		require.NoError(t, env.PachClient.CreateRepo(images))
+		require.NoError(t, env.PachClient.CreateRepo(labels))
+		imagesCommit := client.NewCommit(images, "master", "")
+		labelsCommit := client.NewCommit(labels, "master", "")
+		require.NoError(t, env.PachClient.PutFile(imagesCommit, "1.png", strings.NewReader("image 1")))
+		require.NoError(t, env.PachClient.PutFile(labelsCommit, "labels/1.txt", strings.NewReader("label 1")))
+		require.NoError(t, env.PachClient.PutFile(labelsCommit, "other/1.txt", strings.NewReader("other")))
+
+		// Sources must be branches.
+		_, err := env.PachClient.CreateView(&pfs.View{
+			Name:    view,
+			Sources: []*pfs.ViewSource{{Path: "/images", Branch: client.NewRepo(images).NewBranch("")}},
+		})
+		require.YesError(t, err)
+
+		sources := []*pfs.ViewSource{
+			{Path: "/images", Branch: client.NewRepo(images).NewBranch("master")},
+			{Path: "/labels", Branch: client.NewRepo(labels).NewBranch("master"), SourcePath: "/labels"},
+		}
+		_, err = env.PachClient.CreateView(&pfs.View{Name: view, Sources: sources})
+		require.NoError(t, err)
+		_, err = env.PachClient.CreateView(&pfs.View{Name: view, Sources: sources})
+		require.YesError(t, err)
+		viewInfo, err := env.PachClient.InspectView(view)
+		require.NoError(t, err)
+		require.Equal(t, view, viewInfo.Repo.Name)
+		viewInfos, err := env.PachClient.ListView()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(viewInfos))
+
+		checkFiles := func(expected ...string) {
+			commitInfo, err := env.PachClient.WaitCommit(view, "master", "")
+			require.NoError(t, err)
+			require.Equal(t, "", commitInfo.Error)
+			fileInfos, err := env.PachClient.GlobFileAll(commitInfo.Commit, "**")
+			require.NoError(t, err)
+			var paths []string
+			for _, fileInfo := range fileInfos {
+				if fileInfo.FileType == pfs.FileType_FILE {
+					paths = append(paths, fileInfo.File.Path)
+				}
+			}
+			require.ElementsEqual(t, expected, paths)
+		}
+		checkFiles("/images/1.png", "/labels/1.txt")
+		var buf bytes.Buffer
+		require.NoError(t, env.PachClient.GetFile(client.NewCommit(view, "master", ""), "/labels/1.txt", &buf))
+		require.Equal(t, "label 1", buf.String())
+
+		// Commits to a source propagate to the view.
+		require.NoError(t, env.PachClient.PutFile(imagesCommit, "2.png", strings.NewReader("image 2")))
+		checkFiles("/images/1.png", "/images/2.png", "/labels/1.txt")
+
+		// The view's repo is read-only.
+		_, err = env.PachClient.StartCommit(view, "master")
+		require.YesError(t, err)
+		require.YesError(t, env.PachClient.DeleteRepo(view, false))
+
+		// Removing a source removes its files.
+		_, err = env.PachClient.UpdateView(&pfs.View{Name: view, Sources: sources[:1]})
+		require.NoError(t, err)
+		checkFiles("/images/1.png", "/images/2.png")
+
+		require.NoError(t, env.PachClient.DeleteView(view, false))
+		_, err = env.PachClient.InspectView(view)
+		require.YesError(t, err)
+		_, err = env.PachClient.InspectRepo(view)
+		require.YesError(t, err)
+	})
+
+	suite.Run("ResumableUpload", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		repo := "test"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		commit := client.NewCommit(repo, "master", "")
+		checksum := func(data string) string {
+			sum := sha256.Sum256([]byte(data))
+			return hex.EncodeToString(sum[:])
+		}
+		blocks := []string{"abcd", "efgh", "ij"}
+		var hashes []string
+		for _, block := range blocks {
+			hashes = append(hashes, checksum(block))
+		}
+
+		// The checksums must match the size of the file.
+		_, err := env.PachClient.StartUpload(commit, "file", 10, 4, hashes[:2])
+		require.YesError(t, err)
+		session, err := env.PachClient.StartUpload(commit, "file", 10, 4, hashes)
+		require.NoError(t, err)
+		require.Equal(t, []int64{0, 1, 2}, session.MissingBlocks)
+
+		// Blocks that don't match their checksum or size are rejected.
+		require.YesError(t, env.PachClient.UploadBlock(session.Id, 0, []byte("abce")))
+		require.YesError(t, env.PachClient.UploadBlock(session.Id, 2, []byte("ijk")))
+		require.YesError(t, env.PachClient.UploadBlock(session.Id, 3, []byte("ij")))
+
+		require.NoError(t, env.PachClient.UploadBlock(session.Id, 2, []byte(blocks[2])))
+		require.NoError(t, env.PachClient.UploadBlock(session.Id, 0, []byte(blocks[0])))
+		session, err = env.PachClient.InspectUpload(session.Id)
+		require.NoError(t, err)
+		require.Equal(t, []int64{1}, session.MissingBlocks)
+		require.YesError(t, env.PachClient.FinishUpload(session.Id))
+
+		require.NoError(t, env.PachClient.UploadBlock(session.Id, 1, []byte(blocks[1])))
+		require.NoError(t, env.PachClient.FinishUpload(session.Id))
+		_, err = env.PachClient.InspectUpload(session.Id)
+		require.YesError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, env.PachClient.GetFile(commit, "file", &buf))
+		require.Equal(t, "abcdefghij", buf.String())
+
+		// An upload that's resumed only uploads its missing blocks, and can
+		// append to the file.
+		data := strings.Repeat("x", client.DefaultUploadBlockSize+10)
+		session, err = env.PachClient.StartUpload(commit, "file", int64(len(data)), client.DefaultUploadBlockSize, []string{
+			checksum(data[:client.DefaultUploadBlockSize]),
+			checksum(data[client.DefaultUploadBlockSize:]),
+		}, client.WithAppendPutFile())
+		require.NoError(t, err)
+		require.NoError(t, env.PachClient.UploadBlock(session.Id, 1, []byte(data[client.DefaultUploadBlockSize:])))
+		id, err := env.PachClient.PutFileResumable(commit, "file", strings.NewReader(data), int64(len(data)), session.Id, 2)
+		require.NoError(t, err)
+		require.Equal(t, session.Id, id)
+		buf.Reset()
+		require.NoError(t, env.PachClient.GetFile(commit, "file", &buf))
+		require.Equal(t, "abcdefghij"+data, buf.String())
+
+		// A resumed upload must be of the same file.
+		session, err = env.PachClient.StartUpload(commit, "other", 4, client.DefaultUploadBlockSize, []string{checksum("data")})
+		require.NoError(t, err)
+		_, err = env.PachClient.PutFileResumable(commit, "other", strings.NewReader("date"), 4, session.Id, 1)
+		require.YesError(t, err)
+
+		session, err = env.PachClient.StartUpload(commit, "abandoned", 0, 4, nil)
+		require.NoError(t, err)
+		require.NoError(t, env.PachClient.DeleteUpload(session.Id))
+		require.YesError(t, env.PachClient.FinishUpload(session.Id))
+	})
+
+	suite.Run("PreviewFile", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		repo := "test"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		commit := client.NewCommit(repo, "master", "")
+		var csvData strings.Builder
+		csvData.WriteString("id,name,score\n")
+		for i := 0; i < 100; i++ {
+			fmt.Fprintf(&csvData, "%d,name-%d,%d.5\n", i, i, i)
+		}
+		type record struct {
+			Name string   `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+			Age  int64    `parquet:"name=age, type=INT64"`
+			Tags []string `parquet:"name=tags, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=REPEATED"`
+		}
+		parquetData := &bytes.Buffer{}
+		pw, err := writer.NewParquetWriter(memParquetFile{parquetData}, new(record), 1)
+		require.NoError(t, err)
+		require.NoError(t, pw.Write(record{Name: "a", Age: 1, Tags: []string{"x", "y"}}))
+		require.NoError(t, pw.Write(record{Name: "b", Age: 2}))
+		require.NoError(t, pw.WriteStop())
+		require.NoError(t, env.PachClient.WithModifyFileClient(commit, func(mf client.ModifyFile) error {
+			require.NoError(t, mf.PutFile("data.csv", strings.NewReader(csvData.String())))
+			require.NoError(t, mf.PutFile("data.ndjson", strings.NewReader(`{"a": 1, "b": "x"}`+"\n"+`{"b": "y", "c": [1, 2], "a": 2.5}`+"\n")))
+			require.NoError(t, mf.PutFile("array", strings.NewReader(`[{"a": true}, {"a": false}]`)))
+			require.NoError(t, mf.PutFile("data.parquet", parquetData))
+			require.NoError(t, mf.PutFile("fake.parquet", strings.NewReader("not parquet")))
+			return nil
+		}))
+
+		preview, err := env.PachClient.PreviewFile(commit, "data.csv", client.WithPreviewRows(2))
+		require.NoError(t, err)
+		require.Equal(t, pfs.FileFormat_FORMAT_CSV, preview.Format)
+		require.Equal(t, "/data.csv", preview.FileInfo.File.Path)
+		require.Equal(t, []*pfs.PreviewColumn{
+			{Name: "id", Type: "integer"},
+			{Name: "name", Type: "string"},
+			{Name: "score", Type: "float"},
+		}, preview.Columns)
+		require.Equal(t, 2, len(preview.Rows))
+		require.Equal(t, []string{"1", "name-1", "1.5"}, preview.Rows[1].Values)
+		require.True(t, preview.Truncated)
+
+		// Without a header, the first record is a row.
+		preview, err = env.PachClient.PreviewFile(commit, "data.csv", client.WithPreviewRows(1), client.WithPreviewNoHeader())
+		require.NoError(t, err)
+		require.Equal(t, "column1", preview.Columns[0].Name)
+		require.Equal(t, []string{"id", "name", "score"}, preview.Rows[0].Values)
+
+		preview, err = env.PachClient.PreviewFile(commit, "data.ndjson")
+		require.NoError(t, err)
+		require.Equal(t, []*pfs.PreviewColumn{
+			{Name: "a", Type: "float"},
+			{Name: "b", Type: "string"},
+			{Name: "c", Type: "array"},
+		}, preview.Columns)
+		require.Equal(t, []string{"1", "x", ""}, preview.Rows[0].Values)
+		require.Equal(t, []string{"2.5", "y", "[1, 2]"}, preview.Rows[1].Values)
+		require.False(t, preview.Truncated)
+
+		// Files without a known extension need their format.
+		_, err = env.PachClient.PreviewFile(commit, "array")
+		require.YesError(t, err)
+		preview, err = env.PachClient.PreviewFile(commit, "array", client.WithPreviewFormat(pfs.FileFormat_FORMAT_JSON))
+		require.NoError(t, err)
+		require.Equal(t, []*pfs.PreviewColumn{{Name: "a", Type: "boolean"}}, preview.Columns)
+		require.Equal(t, 2, len(preview.Rows))
+
+		preview, err = env.PachClient.PreviewFile(commit, "data.parquet", client.WithPreviewRows(1))
+		require.NoError(t, err)
+		require.Equal(t, []*pfs.PreviewColumn{
+			{Name: "name", Type: "string"},
+			{Name: "age", Type: "integer"},
+			{Name: "tags", Type: "array<string>"},
+		}, preview.Columns)
+		require.Equal(t, []string{"a", "1", `["x","y"]`}, preview.Rows[0].Values)
+		require.True(t, preview.Truncated)
+
+		_, err = env.PachClient.PreviewFile(commit, "fake.parquet")
+		require.YesError(t, err)
+		require.Matches(t, "not a Parquet file", err.Error())
+		_, err = env.PachClient.PreviewFile(commit, "data.csv", client.WithPreviewRows(100000))
+		require.YesError(t, err)
+	})
+
 	suite.Run("EgressToPostgres", func(_suite *testing.T) {
 		os.Setenv("PACHYDERM_SQL_PASSWORD", tu.DefaultPostgresPassword)
 
@@ -6196,6 +7038,27 @@ func TestPFS(suite *testing.T) {
 				tables:         []string{"test_table", "test_table2", "empty_table"},
 				expectedCounts: map[string]int64{"test_table": 4, "test_table2": 1, "empty_table": 0},
 			},
+			{
+				name: "CSVWithHeaderAndTableMappings",
+				files: []File{
+					{"name,identifier\nFoo,1\nBar,2", "/events/0000.csv"},
+					{"A,ID\nHello,3", "/test_table2/0000"},
+				},
+				options: &pfs.SQLDatabaseEgress{
+					FileFormat: &pfs.SQLDatabaseEgress_FileFormat{
+						Type:   pfs.SQLDatabaseEgress_FileFormat_CSV,
+						Header: true,
+					},
+					Tables: []*pfs.SQLDatabaseEgress_TableMapping{{
+						Glob:    "/events/*.csv",
+						Table:   "test_table",
+						Columns: map[string]string{"name": "A", "identifier": "ID"},
+					}},
+					BatchSize: 1,
+				},
+				tables:         []string{"test_table", "test_table2"},
+				expectedCounts: map[string]int64{"test_table": 2, "test_table2": 1},
+			},
 		}
 		for _, test := range tests {
 			_suite.Run(test.name, func(t *testing.T) {
@@ -6242,6 +7105,20 @@ func TestPFS(suite *testing.T) {
 					require.NoError(t, db.QueryRow(fmt.Sprintf("select count(*) from %s", table)).Scan(&count))
 					require.Equal(t, expected, count)
 				}
+
+				// egressing the commit again doesn't write anything
+				resp, err = env.PachClient.Egress(env.PachClient.Ctx(),
+					&pfs.EgressRequest{
+						Commit: commit,
+						Target: &pfs.EgressRequest_SqlDatabase{
+							SqlDatabase: test.options,
+						},
+					})
+				require.NoError(t, err)
+				require.True(t, resp.GetSqlDatabase().GetAlreadyEgressed())
+				require.Equal(t, 0, len(resp.GetSqlDatabase().GetRowsWritten()))
+				require.NoError(t, db.QueryRow("select count(*) from pachyderm_egress_commits").Scan(&count))
+				require.Equal(t, int64(1), count)
 			})
 		}
 	})
@@ -6283,3 +7160,19 @@ func getRand() *rand.Rand {
 func randomReader(n int) io.Reader {
 	return io.LimitReader(getRand(), int64(n))
 }
+
+// memParquetFile is a write-only Parquet file that's written to a buffer.
+type memParquetFile struct {
+	buf *bytes.Buffer
+}
+
+func (f memParquetFile) Write(data []byte) (int, error) { return f.buf.Write(data) }
+func (f memParquetFile) Read([]byte) (int, error)       { return 0, io.EOF }
+func (f memParquetFile) Seek(int64, int) (int64, error) { return 0, nil }
+func (f memParquetFile) Close() error                   { return nil }
+func (f memParquetFile) Open(string) (parquetsource.ParquetFile, error) {
+	return f, nil
+}
+func (f memParquetFile) Create(string) (parquetsource.ParquetFile, error) {
+	return f, nil
+}
@@ -1,11 +1,14 @@
 package server
 
 import (
+	"context"
 	"time"
 
 	units "github.com/docker/go-units"
+	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
 	"github.com/robfig/cron"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/pachyderm/pachyderm/v2/src/internal/ancestry"
 	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
@@ -84,69 +87,179 @@ func (d *driver) triggerCommit(
 	return nil
 }
 
+// branchTriggerStatus returns the status of the trigger of the branch
+// described by bi, and the head of the trigger's branch, which is nil if the
+// trigger's branch doesn't exist.
+func (d *driver) branchTriggerStatus(txnCtx *txncontext.TransactionContext, bi *pfs.BranchInfo, now time.Time) (*pfs.TriggerStatus, *pfs.CommitInfo, error) {
+	triggerBI := &pfs.BranchInfo{}
+	if err := d.branches.ReadWrite(txnCtx.SqlTx).Get(bi.Branch.Repo.NewBranch(bi.Trigger.Branch), triggerBI); err != nil {
+		if col.IsErrNotFound(err) {
+			return &pfs.TriggerStatus{}, nil, nil
+		}
+		return nil, nil, errors.EnsureStack(err)
+	}
+	oldHead := &pfs.CommitInfo{}
+	if err := d.commits.ReadWrite(txnCtx.SqlTx).Get(bi.Head, oldHead); err != nil {
+		return nil, nil, errors.EnsureStack(err)
+	}
+	newHead := &pfs.CommitInfo{}
+	if err := d.commits.ReadWrite(txnCtx.SqlTx).Get(triggerBI.Head, newHead); err != nil {
+		return nil, nil, errors.EnsureStack(err)
+	}
+	status, err := d.triggerStatus(txnCtx, bi.Trigger, oldHead, newHead, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	return status, newHead, nil
+}
+
+// checkCronTriggers periodically fires the triggers with a cron spec whose
+// window has elapsed since their branch last moved. Triggers are otherwise only
+// evaluated when a commit finishes, which would leave commits waiting on a
+// cron window until the next commit arrives.
+func (d *driver) checkCronTriggers(ctx context.Context, period time.Duration) error {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		if err := d.checkCronTriggersOnce(ctx); err != nil {
+			log.Errorf("error checking cron triggers: %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return errors.EnsureStack(ctx.Err())
+		}
+	}
+}
+
+func (d *driver) checkCronTriggersOnce(ctx context.Context) error {
+	var branches []*pfs.Branch
+	branchInfo := &pfs.BranchInfo{}
+	if err := d.branches.ReadOnly(ctx).List(branchInfo, col.DefaultOptions(), func(string) error {
+		if branchInfo.Trigger != nil && branchInfo.Trigger.CronSpec != "" {
+			branches = append(branches, proto.Clone(branchInfo.Branch).(*pfs.Branch))
+		}
+		return nil
+	}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	for _, branch := range branches {
+		if err := d.txnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+			bi := &pfs.BranchInfo{}
+			if err := d.branches.ReadWrite(txnCtx.SqlTx).Get(branch, bi); err != nil {
+				return errors.EnsureStack(err)
+			}
+			if bi.Trigger == nil || bi.Trigger.CronSpec == "" {
+				return nil
+			}
+			status, newHead, err := d.branchTriggerStatus(txnCtx, bi, time.Now())
+			if err != nil {
+				return err
+			}
+			// Open commits fire the trigger when they finish.
+			if !status.Triggered || newHead == nil || newHead.Finished == nil || newHead.Commit.ID == bi.Head.ID {
+				return nil
+			}
+			txnCtx.CommitSetID = newHead.Commit.ID
+			return d.triggerCommit(txnCtx, newHead.Commit)
+		}); err != nil && !col.IsErrNotFound(err) {
+			log.Errorf("error checking the trigger of branch %v: %v", branch, err)
+		}
+	}
+	return nil
+}
+
+// maxPendingCommits bounds how far back triggerStatus walks the trigger's
+// branch when counting the commits since the last trigger.
+const maxPendingCommits = 1000
+
 // isTriggered checks to see if a branch should be updated from oldHead to
 // newHead based on a trigger.
 func (d *driver) isTriggered(txnCtx *txncontext.TransactionContext, t *pfs.Trigger, oldHead, newHead *pfs.CommitInfo) (bool, error) {
-	result := t.All
-	merge := func(cond bool) {
-		if t.All {
-			result = result && cond
-		} else {
-			result = result || cond
-		}
+	status, err := d.triggerStatus(txnCtx, t, oldHead, newHead, time.Now())
+	if err != nil {
+		return false, err
+	}
+	return status.Triggered, nil
+}
+
+// triggerStatus evaluates each of a trigger's conditions for moving a branch
+// from oldHead to newHead at time now.
+func (d *driver) triggerStatus(txnCtx *txncontext.TransactionContext, t *pfs.Trigger, oldHead, newHead *pfs.CommitInfo, now time.Time) (*pfs.TriggerStatus, error) {
+	status := &pfs.TriggerStatus{}
+	var conds []bool
+	if oldHead != nil && oldHead.Details != nil && newHead.Details != nil {
+		status.PendingBytes = newHead.Details.SizeBytes - oldHead.Details.SizeBytes
+	} else if newHead.Details != nil {
+		status.PendingBytes = newHead.Details.SizeBytes
 	}
 	if t.Size_ != "" {
 		size, err := units.FromHumanSize(t.Size_)
 		if err != nil {
 			// Shouldn't be possible to error here since we validate on ingress
-			return false, errors.EnsureStack(err)
-		}
-		var oldSize int64
-		if oldHead != nil {
-			oldSize = oldHead.Details.SizeBytes
+			return nil, errors.EnsureStack(err)
 		}
-		merge(newHead.Details.SizeBytes-oldSize >= size)
+		status.SizeMet = status.PendingBytes >= size
+		conds = append(conds, status.SizeMet)
 	}
 	if t.CronSpec != "" {
-		// Shouldn't be possible to error here since we validate on ingress
 		schedule, err := cron.ParseStandard(t.CronSpec)
 		if err != nil {
 			// Shouldn't be possible to error here since we validate on ingress
-			return false, errors.EnsureStack(err)
+			return nil, errors.EnsureStack(err)
 		}
-		var oldTime, newTime time.Time
+		var oldTime time.Time
 		if oldHead != nil && oldHead.Finishing != nil {
 			oldTime, err = types.TimestampFromProto(oldHead.Finishing)
 			if err != nil {
-				return false, errors.EnsureStack(err)
+				return nil, errors.EnsureStack(err)
 			}
 		}
-		if newHead.Finishing != nil {
-			newTime, err = types.TimestampFromProto(newHead.Finishing)
-			if err != nil {
-				return false, errors.EnsureStack(err)
-			}
+		next := schedule.Next(oldTime)
+		status.NextCron, err = types.TimestampProto(next)
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		// The window is measured against the current time rather than the
+		// new commit, so that a trigger fires when its window elapses even if
+		// no further commits arrive.
+		status.CronMet = next.Before(now)
+		conds = append(conds, status.CronMet)
+	}
+	// Count the commits since the last trigger, up to the number the trigger
+	// needs, or maxPendingCommits if it doesn't trigger on commits.
+	limit := int64(maxPendingCommits)
+	if t.Commits > limit {
+		limit = t.Commits
+	}
+	ci := newHead
+	for oldHead == nil || ci.Commit.ID != oldHead.Commit.ID {
+		status.PendingCommits++
+		if ci.ParentCommit == nil || status.PendingCommits >= limit {
+			break
+		}
+		if oldHead != nil && ci.ParentCommit.ID == oldHead.Commit.ID {
+			break
+		}
+		var err error
+		ci, err = d.resolveCommit(txnCtx.SqlTx, ci.ParentCommit)
+		if err != nil {
+			return nil, err
 		}
-		merge(schedule.Next(oldTime).Before(newTime))
 	}
 	if t.Commits != 0 {
-		ci := newHead
-		var commits int64
-		for commits < t.Commits {
-			commits++
-			if ci.ParentCommit != nil && oldHead.Commit.ID != ci.ParentCommit.ID {
-				var err error
-				ci, err = d.resolveCommit(txnCtx.SqlTx, ci.ParentCommit)
-				if err != nil {
-					return false, err
-				}
-			} else {
-				break
-			}
+		status.CommitsMet = status.PendingCommits >= t.Commits
+		conds = append(conds, status.CommitsMet)
+	}
+	status.Triggered = t.All
+	for _, cond := range conds {
+		if t.All {
+			status.Triggered = status.Triggered && cond
+		} else {
+			status.Triggered = status.Triggered || cond
 		}
-		merge(commits == t.Commits)
 	}
-	return result, nil
+	return status, nil
 }
 
 // validateTrigger returns an error if a trigger is invalid
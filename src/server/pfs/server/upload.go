@@ -0,0 +1,256 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
+	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
+	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+const (
+	// maxUploadBlockSize is the largest block of a resumable upload, which
+	// has to fit in a single gRPC message.
+	maxUploadBlockSize = 16 * 1024 * 1024
+	// uploadSessionTTL is how long a resumable upload can take, after which
+	// its session and uploaded blocks are deleted.
+	uploadSessionTTL = 24 * time.Hour
+)
+
+// numBlocks returns the number of blocks of a resumable upload.
+func numBlocks(sizeBytes, blockSizeBytes int64) int64 {
+	return (sizeBytes + blockSizeBytes - 1) / blockSizeBytes
+}
+
+// blockSize returns the size of the block of session at index, which is the
+// session's block size for every block but the last.
+func blockSize(session *pfs.UploadSession, index int64) int64 {
+	if index == int64(len(session.BlockHashes))-1 {
+		return session.SizeBytes - index*session.BlockSizeBytes
+	}
+	return session.BlockSizeBytes
+}
+
+func withMissingBlocks(session *pfs.UploadSession) *pfs.UploadSession {
+	session.MissingBlocks = nil
+	for i, id := range session.BlockFileSets {
+		if id == "" {
+			session.MissingBlocks = append(session.MissingBlocks, int64(i))
+		}
+	}
+	return session
+}
+
+func (d *driver) startUpload(ctx context.Context, req *pfs.StartUploadRequest) (*pfs.UploadSession, error) {
+	if req.File == nil || req.File.Commit == nil || req.File.Commit.Branch == nil || req.File.Commit.Branch.Repo == nil {
+		return nil, errors.New("file must be specified")
+	}
+	if req.BlockSizeBytes <= 0 || req.BlockSizeBytes > maxUploadBlockSize {
+		return nil, errors.Errorf("block size must be between 1 and %d bytes", maxUploadBlockSize)
+	}
+	if req.SizeBytes < 0 {
+		return nil, errors.New("size must not be negative")
+	}
+	if n := numBlocks(req.SizeBytes, req.BlockSizeBytes); int64(len(req.BlockHashes)) != n {
+		return nil, errors.Errorf("a file of %d bytes has %d blocks, but %d checksums were given", req.SizeBytes, n, len(req.BlockHashes))
+	}
+	for i, hash := range req.BlockHashes {
+		if sum, err := hex.DecodeString(hash); err != nil || len(sum) != sha256.Size {
+			return nil, errors.Errorf("checksum of block %d is not a hex-encoded SHA-256 checksum", i)
+		}
+	}
+	p := cleanPath(req.File.Path)
+	if err := validate(p); err != nil {
+		return nil, err
+	}
+	repo := req.File.Commit.Branch.Repo
+	if repo.Type == "" {
+		repo.Type = pfs.UserRepoType
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, repo, auth.Permission_REPO_WRITE); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := d.repos.ReadOnly(ctx).Get(repo, &pfs.RepoInfo{}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	now := time.Now()
+	created, err := types.TimestampProto(now)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	expires, err := types.TimestampProto(now.Add(uploadSessionTTL))
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	session := &pfs.UploadSession{
+		Id:             uuid.NewWithoutDashes(),
+		File:           &pfs.File{Commit: req.File.Commit, Path: p},
+		Datum:          req.Datum,
+		Append:         req.Append,
+		SizeBytes:      req.SizeBytes,
+		BlockSizeBytes: req.BlockSizeBytes,
+		BlockHashes:    req.BlockHashes,
+		BlockFileSets:  make([]string, len(req.BlockHashes)),
+		Created:        created,
+		Expires:        expires,
+	}
+	if err := d.txnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		uploads := d.uploads.ReadWrite(txnCtx.SqlTx)
+		// Sessions that were never finished are cleaned up when another
+		// upload to their repo starts. Their blocks expire on their own.
+		var expired []string
+		existing := &pfs.UploadSession{}
+		if err := uploads.GetByIndex(pfsdb.UploadsRepoIndex, pfsdb.RepoKey(repo), existing, col.DefaultOptions(), func(key string) error {
+			if expires, err := types.TimestampFromProto(existing.Expires); err == nil && expires.Before(now) {
+				expired = append(expired, key)
+			}
+			return nil
+		}); err != nil {
+			return errors.EnsureStack(err)
+		}
+		for _, key := range expired {
+			if err := uploads.Delete(key); err != nil && !col.IsErrNotFound(err) {
+				return errors.EnsureStack(err)
+			}
+		}
+		return errors.EnsureStack(uploads.Put(session.Id, session))
+	}); err != nil {
+		return nil, err
+	}
+	return withMissingBlocks(session), nil
+}
+
+// getUpload returns the session with id, after checking that it hasn't
+// expired and that the caller can write to its repo.
+func (d *driver) getUpload(ctx context.Context, id string) (*pfs.UploadSession, error) {
+	session := &pfs.UploadSession{}
+	if err := d.uploads.ReadOnly(ctx).Get(id, session); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil, errors.Errorf("upload %s not found", id)
+		}
+		return nil, errors.EnsureStack(err)
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, session.File.Commit.Branch.Repo, auth.Permission_REPO_WRITE); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	expires, err := types.TimestampFromProto(session.Expires)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if expires.Before(time.Now()) {
+		return nil, errors.Errorf("upload %s expired at %v", id, expires)
+	}
+	return session, nil
+}
+
+func (d *driver) uploadBlock(ctx context.Context, req *pfs.UploadBlockRequest) error {
+	session, err := d.getUpload(ctx, req.Session)
+	if err != nil {
+		return err
+	}
+	if req.Index < 0 || req.Index >= int64(len(session.BlockHashes)) {
+		return errors.Errorf("upload %s has no block %d", session.Id, req.Index)
+	}
+	if size := blockSize(session, req.Index); int64(len(req.Data)) != size {
+		return errors.Errorf("block %d of upload %s must be %d bytes, but is %d bytes", req.Index, session.Id, size, len(req.Data))
+	}
+	sum := sha256.Sum256(req.Data)
+	if hex.EncodeToString(sum[:]) != session.BlockHashes[req.Index] {
+		return errors.Errorf("block %d of upload %s doesn't match its checksum", req.Index, session.Id)
+	}
+	// Each block is stored in its own file set, at the path of the file, so
+	// that composing them in order gives the content of the file.
+	var id *fileset.ID
+	if err := d.storage.WithRenewer(ctx, defaultTTL, func(ctx context.Context, renewer *fileset.Renewer) error {
+		id, err = d.withUnorderedWriter(ctx, renewer, func(uw *fileset.UnorderedWriter) error {
+			return errors.EnsureStack(uw.Put(session.File.Path, session.Datum, true, bytes.NewReader(req.Data)))
+		})
+		return err
+	}); err != nil {
+		return err
+	}
+	expires, err := types.TimestampFromProto(session.Expires)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if _, err := d.storage.SetTTL(ctx, *id, time.Until(expires)); err != nil {
+		return errors.EnsureStack(err)
+	}
+	return d.txnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		return errors.EnsureStack(d.uploads.ReadWrite(txnCtx.SqlTx).Update(session.Id, session, func() error {
+			session.BlockFileSets[req.Index] = id.HexString()
+			return nil
+		}))
+	})
+}
+
+func (d *driver) inspectUpload(ctx context.Context, id string) (*pfs.UploadSession, error) {
+	session, err := d.getUpload(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return withMissingBlocks(session), nil
+}
+
+func (d *driver) finishUpload(ctx context.Context, id string) error {
+	session, err := d.getUpload(ctx, id)
+	if err != nil {
+		return err
+	}
+	if missing := withMissingBlocks(session).MissingBlocks; len(missing) > 0 {
+		return errors.Errorf("upload %s is missing %d of %d blocks", id, len(missing), len(session.BlockHashes))
+	}
+	var ids []fileset.ID
+	for _, block := range session.BlockFileSets {
+		fsid, err := fileset.ParseID(block)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, *fsid)
+	}
+	if err := d.storage.WithRenewer(ctx, defaultTTL, func(ctx context.Context, renewer *fileset.Renewer) error {
+		composed, err := d.storage.Compose(ctx, ids, defaultTTL)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		if err := renewer.Add(ctx, *composed); err != nil {
+			return errors.EnsureStack(err)
+		}
+		return d.modifyFile(ctx, session.File.Commit, func(uw *fileset.UnorderedWriter) error {
+			if len(ids) == 0 {
+				return errors.EnsureStack(uw.Put(session.File.Path, session.Datum, session.Append, &bytes.Buffer{}))
+			}
+			src := client.NewCommit(fileSetsRepo, "", composed.HexString()).NewFile(session.File.Path)
+			return d.copyFile(ctx, uw, session.File.Path, src, session.Append, session.Datum)
+		})
+	}); err != nil {
+		return err
+	}
+	return d.txnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		if err := d.uploads.ReadWrite(txnCtx.SqlTx).Delete(id); err != nil && !col.IsErrNotFound(err) {
+			return errors.EnsureStack(err)
+		}
+		return nil
+	})
+}
+
+func (d *driver) deleteUpload(ctx context.Context, id string) error {
+	if _, err := d.getUpload(ctx, id); err != nil {
+		return err
+	}
+	return d.txnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		return errors.EnsureStack(d.uploads.ReadWrite(txnCtx.SqlTx).Delete(id))
+	})
+}
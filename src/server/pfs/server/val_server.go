@@ -1,6 +1,8 @@
 package server
 
 import (
+	"mime"
+
 	"github.com/gogo/protobuf/types"
 	"github.com/pachyderm/pachyderm/v2/src/auth"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
@@ -70,6 +72,9 @@ func (a *validatedAPIServer) ListFile(request *pfs.ListFileRequest, server pfs.A
 	if err := validateFile(request.File); err != nil {
 		return err
 	}
+	if err := validateContentType(request.ContentType); err != nil {
+		return err
+	}
 	if err := a.auth.CheckRepoIsAuthorized(server.Context(), request.File.Commit.Branch.Repo, auth.Permission_REPO_LIST_FILE); err != nil {
 		return errors.EnsureStack(err)
 	}
@@ -111,6 +116,9 @@ func (a *validatedAPIServer) GlobFile(request *pfs.GlobFileRequest, server pfs.A
 	if commit.Branch.Repo == nil {
 		return errors.New("commit repo cannot be nil")
 	}
+	if err := validateContentType(request.ContentType); err != nil {
+		return err
+	}
 	if err := a.auth.CheckRepoIsAuthorized(server.Context(), commit.Branch.Repo, auth.Permission_REPO_READ, auth.Permission_REPO_LIST_FILE); err != nil {
 		return errors.EnsureStack(err)
 	}
@@ -192,6 +200,9 @@ func (a *validatedAPIServer) Egress(ctx context.Context, request *pfs.EgressRequ
 	if err != nil {
 		return nil, err
 	}
+	if err := pfsserver.ValidateTableEgress(request.GetTable()); err != nil {
+		return nil, err
+	}
 	return a.apiServer.Egress(ctx, request)
 }
 
@@ -210,3 +221,14 @@ func validateFile(file *pfs.File) error {
 	}
 	return nil
 }
+
+// validateContentType validates a content type filter, which may be empty.
+func validateContentType(contentType string) error {
+	if contentType == "" {
+		return nil
+	}
+	if _, _, err := mime.ParseMediaType(contentType); err != nil {
+		return errors.Wrapf(err, "invalid content type %q", contentType)
+	}
+	return nil
+}
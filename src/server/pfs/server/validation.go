@@ -0,0 +1,455 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset/index"
+	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
+	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+const (
+	// maxValidationViolations is the number of violations recorded in the
+	// details of a commit.
+	maxValidationViolations = 100
+	defaultValidatorTimeout = 10 * time.Minute
+	validatorPollInterval   = 2 * time.Second
+	validatorCommitLabel    = "pach-validation-commit"
+)
+
+// formatExtensions are the extensions of the files checked by each of the
+// built-in format validators.
+var formatExtensions = map[pfs.FileFormat][]string{
+	pfs.FileFormat_FORMAT_CSV:     {".csv"},
+	pfs.FileFormat_FORMAT_JSON:    {".json", ".jsonl", ".ndjson"},
+	pfs.FileFormat_FORMAT_PARQUET: {".parquet"},
+}
+
+var formatValidators = map[pfs.FileFormat]schemaValidator{
+	pfs.FileFormat_FORMAT_CSV:     validateCSV,
+	pfs.FileFormat_FORMAT_JSON:    validateJSON,
+	pfs.FileFormat_FORMAT_PARQUET: validateParquet,
+}
+
+// validateCSV checks that r holds CSV records that all have the same number of
+// fields.
+func validateCSV(r io.Reader) error {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	for {
+		if _, err := cr.Read(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return errors.Wrap(err, "invalid CSV")
+		}
+	}
+}
+
+// validateJSON checks that r holds one or more JSON values.
+func validateJSON(r io.Reader) error {
+	decoder := json.NewDecoder(r)
+	for i := 0; ; i++ {
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return errors.Wrapf(err, "invalid JSON value %d", i)
+		}
+	}
+}
+
+var parquetMagic = []byte("PAR1")
+
+// validateParquet checks that r holds a Parquet file, which starts with the
+// Parquet magic number and ends with the length of its footer and the magic
+// number. Only the end of the file is kept while it's read.
+func validateParquet(r io.Reader) error {
+	header := make([]byte, len(parquetMagic))
+	if _, err := io.ReadFull(r, header); err != nil || !bytes.Equal(header, parquetMagic) {
+		return errors.New("not a Parquet file: missing magic number at the start of the file")
+	}
+	size := int64(len(header))
+	var tail []byte
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := r.Read(buf)
+		size += int64(n)
+		tail = append(tail, buf[:n]...)
+		if len(tail) > 8 {
+			tail = tail[len(tail)-8:]
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return errors.EnsureStack(err)
+		}
+	}
+	if len(tail) < 8 || !bytes.Equal(tail[4:], parquetMagic) {
+		return errors.New("not a Parquet file: missing magic number at the end of the file")
+	}
+	footerLength := int64(tail[0]) | int64(tail[1])<<8 | int64(tail[2])<<16 | int64(tail[3])<<24
+	if footerLength > size-12 {
+		return errors.Errorf("not a Parquet file: footer length %d exceeds the size of the file", footerLength)
+	}
+	return nil
+}
+
+func validateValidationPolicy(policy *pfs.ValidationPolicy) error {
+	if policy == nil {
+		return errors.New("policy must be set")
+	}
+	if _, ok := pfs.ValidationAction_name[int32(policy.Action)]; !ok {
+		return errors.Errorf("unknown validation action %v", policy.Action)
+	}
+	for _, ext := range policy.AllowedExtensions {
+		if !strings.HasPrefix(ext, ".") || len(ext) < 2 {
+			return errors.Errorf("invalid extension %q, extensions must start with a \".\"", ext)
+		}
+	}
+	if policy.MaxFileSizeBytes < 0 {
+		return errors.New("max_file_size_bytes cannot be negative")
+	}
+	for _, format := range policy.Formats {
+		if _, ok := formatValidators[format]; !ok {
+			return errors.Errorf("unsupported file format %v", format)
+		}
+	}
+	if policy.Image != nil {
+		if policy.Image.Image == "" {
+			return errors.New("image must be set for validator images")
+		}
+		if policy.Image.Timeout != nil {
+			timeout, err := types.DurationFromProto(policy.Image.Timeout)
+			if err != nil {
+				return errors.EnsureStack(err)
+			}
+			if timeout <= 0 {
+				return errors.New("validator image timeout must be positive")
+			}
+		}
+	}
+	return nil
+}
+
+func (d *driver) setValidationPolicy(txnCtx *txncontext.TransactionContext, repo *pfs.Repo, policy *pfs.ValidationPolicy) (*pfs.ValidationPolicyInfo, error) {
+	if repo == nil {
+		return nil, errors.New("repo must be specified")
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorizedInTransaction(txnCtx, repo, auth.Permission_REPO_WRITE); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := d.repos.ReadWrite(txnCtx.SqlTx).Get(repo, &pfs.RepoInfo{}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := validateValidationPolicy(policy); err != nil {
+		return nil, err
+	}
+	policyInfo := &pfs.ValidationPolicyInfo{}
+	if err := d.validationPolicies.ReadWrite(txnCtx.SqlTx).Upsert(pfsdb.RepoKey(repo), policyInfo, func() error {
+		policyInfo.Repo = repo
+		policyInfo.Policy = policy
+		policyInfo.Created = txnCtx.Timestamp
+		return nil
+	}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return policyInfo, nil
+}
+
+func (d *driver) inspectValidationPolicy(ctx context.Context, repo *pfs.Repo) (*pfs.ValidationPolicyInfo, error) {
+	if repo == nil {
+		return nil, errors.New("repo must be specified")
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, repo, auth.Permission_REPO_READ); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	policyInfo := &pfs.ValidationPolicyInfo{}
+	if err := d.validationPolicies.ReadOnly(ctx).Get(pfsdb.RepoKey(repo), policyInfo); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil, errors.Errorf("repo %s has no validation policy", repo)
+		}
+		return nil, errors.EnsureStack(err)
+	}
+	return policyInfo, nil
+}
+
+func (d *driver) deleteValidationPolicy(txnCtx *txncontext.TransactionContext, repo *pfs.Repo) error {
+	if repo == nil {
+		return errors.New("repo must be specified")
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorizedInTransaction(txnCtx, repo, auth.Permission_REPO_WRITE); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if err := d.validationPolicies.ReadWrite(txnCtx.SqlTx).Delete(pfsdb.RepoKey(repo)); err != nil {
+		if col.IsErrNotFound(err) {
+			return errors.Errorf("repo %s has no validation policy", repo)
+		}
+		return errors.EnsureStack(err)
+	}
+	return nil
+}
+
+// checkValidationPolicy validates the files that commit adds or changes
+// against the validation policy of its repo, reading their content from the
+// commit's total file set id. It returns nil if the repo has no policy, and a
+// validation error if the policy rejects the commit.
+func (d *driver) checkValidationPolicy(ctx context.Context, commit *pfs.Commit, id fileset.ID) (*pfs.ValidationResult, string, error) {
+	policyInfo := &pfs.ValidationPolicyInfo{}
+	if err := d.validationPolicies.ReadOnly(ctx).Get(pfsdb.RepoKey(commit.Branch.Repo), policyInfo); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil, "", nil
+		}
+		return nil, "", errors.EnsureStack(err)
+	}
+	policy := policyInfo.Policy
+	result := &pfs.ValidationResult{Action: policy.Action}
+	addViolation := func(p, rule, message string) {
+		result.ViolationCount++
+		if len(result.Violations) < maxValidationViolations {
+			result.Violations = append(result.Violations, &pfs.ValidationViolation{
+				Path:    p,
+				Rule:    rule,
+				Message: message,
+			})
+		}
+	}
+	allowed := make(map[string]bool)
+	for _, ext := range policy.AllowedExtensions {
+		allowed[strings.ToLower(ext)] = true
+	}
+	validators := make(map[string]schemaValidator)
+	for _, format := range policy.Formats {
+		for _, ext := range formatExtensions[format] {
+			validators[ext] = formatValidators[format]
+		}
+	}
+	if err := d.storage.WithRenewer(ctx, defaultTTL, func(ctx context.Context, renewer *fileset.Renewer) error {
+		diffId, err := d.commitStore.GetDiffFileSet(ctx, commit)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		if err := renewer.Add(ctx, *diffId); err != nil {
+			return errors.EnsureStack(err)
+		}
+		// The diff file set only holds the content a commit appended to a
+		// file, so the files it changed are read from the total file set.
+		changed := make(map[string]bool)
+		diff, err := d.storage.Open(ctx, []fileset.ID{*diffId})
+		if err != nil {
+			return err
+		}
+		if err := diff.Iterate(ctx, func(f fileset.File) error {
+			changed[f.Index().Path] = true
+			return nil
+		}); err != nil {
+			return errors.EnsureStack(err)
+		}
+		if len(changed) == 0 {
+			return nil
+		}
+		fs, err := d.storage.Open(ctx, []fileset.ID{id})
+		if err != nil {
+			return err
+		}
+		fs = fileset.NewIndexFilter(fs, func(idx *index.Index) bool {
+			return changed[idx.Path]
+		})
+		if err := fs.Iterate(ctx, func(f fileset.File) error {
+			p := f.Index().Path
+			result.FilesChecked++
+			ext := strings.ToLower(path.Ext(p))
+			if len(allowed) > 0 && !allowed[ext] {
+				addViolation(p, "extension", fmt.Sprintf("extension %q is not allowed", path.Ext(p)))
+			}
+			if size := index.SizeBytes(f.Index()); policy.MaxFileSizeBytes > 0 && size > policy.MaxFileSizeBytes {
+				addViolation(p, "size", fmt.Sprintf("size of %d bytes exceeds the maximum of %d bytes", size, policy.MaxFileSizeBytes))
+			}
+			if validate, ok := validators[ext]; ok {
+				var validationErr error
+				if err := miscutil.WithPipe(func(w io.Writer) error {
+					return f.Content(ctx, w)
+				}, func(r io.Reader) error {
+					validationErr = validate(r)
+					return validationErr
+				}); err != nil && validationErr == nil {
+					return err
+				}
+				if validationErr != nil {
+					addViolation(p, "format", validationErr.Error())
+				}
+			}
+			return nil
+		}); err != nil {
+			return errors.EnsureStack(err)
+		}
+		if policy.Image != nil {
+			message, err := d.runValidatorImage(ctx, commit, id, *diffId, policy.Image)
+			if err != nil {
+				return err
+			}
+			if message != "" {
+				addViolation("", "image", message)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, "", err
+	}
+	var validationError string
+	if result.ViolationCount > 0 && policy.Action == pfs.ValidationAction_REJECT {
+		v := result.Violations[0]
+		validationError = fmt.Sprintf("commit violates the validation policy of its repo (%d violations), the first of which is %v", result.ViolationCount, formatViolation(v))
+	}
+	return result, validationError, nil
+}
+
+func formatViolation(v *pfs.ValidationViolation) string {
+	if v.Path == "" {
+		return fmt.Sprintf("%s: %s", v.Rule, v.Message)
+	}
+	return fmt.Sprintf("%s %s: %s", v.Path, v.Rule, v.Message)
+}
+
+// runValidatorImage runs image as a Kubernetes job that validates the files
+// of commit, whose total and diff file sets are given. It returns a message
+// describing why the files are invalid, or an empty string if they're valid.
+func (d *driver) runValidatorImage(ctx context.Context, commit *pfs.Commit, total, diff fileset.ID, image *pfs.ValidatorImage) (string, error) {
+	if d.env.GetKubeClient == nil {
+		return "validator images can't be run, as pachd isn't connected to Kubernetes", nil
+	}
+	timeout := defaultValidatorTimeout
+	if image.Timeout != nil {
+		var err error
+		if timeout, err = types.DurationFromProto(image.Timeout); err != nil {
+			return "", errors.EnsureStack(err)
+		}
+	}
+	jobs := d.env.GetKubeClient().BatchV1().Jobs(d.env.Namespace)
+	// Jobs left over from earlier attempts to finish the commit are deleted
+	// before it's validated again.
+	sum := sha256.Sum256([]byte(pfsdb.CommitKey(commit)))
+	commitLabel := hex.EncodeToString(sum[:20])
+	selector := fmt.Sprintf("%s=%s", validatorCommitLabel, commitLabel)
+	propagation := metav1.DeletePropagationBackground
+	deleteOptions := metav1.DeleteOptions{PropagationPolicy: &propagation}
+	if err := jobs.DeleteCollection(ctx, deleteOptions, metav1.ListOptions{LabelSelector: selector}); err != nil {
+		return "", errors.Wrap(err, "error deleting old validation jobs")
+	}
+	job := validatorJob(commit, total, diff, image, timeout)
+	job.Name = "pach-validate-" + uuid.NewWithoutDashes()[:16]
+	job.Labels[validatorCommitLabel] = commitLabel
+	if _, err := jobs.Create(ctx, job, metav1.CreateOptions{}); err != nil {
+		return "", errors.Wrap(err, "error creating validation job")
+	}
+	defer func() {
+		if err := jobs.Delete(ctx, job.Name, deleteOptions); err != nil && !errutil.IsNotFoundError(err) {
+			d.log.Errorf("error deleting validation job %v: %v", job.Name, err)
+		}
+	}()
+	deadline := time.Now().Add(timeout)
+	for {
+		job, err := jobs.Get(ctx, job.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", errors.Wrap(err, "error reading validation job")
+		}
+		if job.Status.Succeeded > 0 {
+			return "", nil
+		}
+		if job.Status.Failed > 0 {
+			message := fmt.Sprintf("validator image %v failed", image.Image)
+			if logs := d.validatorLogs(ctx, job.Name); logs != "" {
+				message += ": " + logs
+			}
+			return message, nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Sprintf("validator image %v timed out after %v", image.Image, timeout), nil
+		}
+		select {
+		case <-ctx.Done():
+			return "", errors.EnsureStack(ctx.Err())
+		case <-time.After(validatorPollInterval):
+		}
+	}
+}
+
+// validatorJob returns the Kubernetes job that runs image to validate commit.
+func validatorJob(commit *pfs.Commit, total, diff fileset.ID, image *pfs.ValidatorImage, timeout time.Duration) *batchv1.Job {
+	backoffLimit := int32(0)
+	deadlineSeconds := int64(timeout.Seconds())
+	container := v1.Container{
+		Name:    "validator",
+		Image:   image.Image,
+		Command: image.Cmd,
+		Env: []v1.EnvVar{
+			{Name: "PACH_VALIDATION_REPO", Value: commit.Branch.Repo.Name},
+			{Name: "PACH_VALIDATION_COMMIT", Value: commit.ID},
+			{Name: "PACH_VALIDATION_FILESET", Value: total.HexString()},
+			{Name: "PACH_VALIDATION_DIFF_FILESET", Value: diff.HexString()},
+		},
+	}
+	if image.Secret != "" {
+		container.EnvFrom = []v1.EnvFromSource{{
+			SecretRef: &v1.SecretEnvSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: image.Secret},
+			},
+		}}
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"app": "pach-validation"},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: &deadlineSeconds,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					RestartPolicy: v1.RestartPolicyNever,
+					Containers:    []v1.Container{container},
+				},
+			},
+		},
+	}
+}
+
+// validatorLogs returns the last line logged by the pod of the validation job
+// name, or an empty string if it can't be read.
+func (d *driver) validatorLogs(ctx context.Context, name string) string {
+	pods := d.env.GetKubeClient().CoreV1().Pods(d.env.Namespace)
+	podList, err := pods.List(ctx, metav1.ListOptions{LabelSelector: "job-name=" + name})
+	if err != nil || len(podList.Items) == 0 {
+		return ""
+	}
+	tailLines := int64(1)
+	logs, err := pods.GetLogs(podList.Items[0].Name, &v1.PodLogOptions{TailLines: &tailLines}).Do(ctx).Raw()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(logs))
+}
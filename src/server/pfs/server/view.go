@@ -0,0 +1,259 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
+	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
+	"github.com/pachyderm/pachyderm/v2/src/internal/watch"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	pfsserver "github.com/pachyderm/pachyderm/v2/src/server/pfs"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// viewBranch is the branch of a view's repo that holds its commits.
+const viewBranch = "master"
+
+func validateView(view *pfs.View) error {
+	if view == nil {
+		return errors.New("view must be specified")
+	}
+	if view.Name == "" {
+		return errors.New("view name must be specified")
+	}
+	if len(view.Sources) == 0 {
+		return errors.New("a view must have at least one source")
+	}
+	for _, source := range view.Sources {
+		if source.Branch == nil || source.Branch.Repo == nil || source.Branch.Name == "" {
+			return errors.New("the branch of each source must be specified")
+		}
+		if source.Branch.Repo.Type == "" {
+			source.Branch.Repo.Type = pfs.UserRepoType
+		}
+		source.Path = cleanPath(source.Path)
+		if err := validate(source.Path); err != nil {
+			return err
+		}
+		source.SourcePath = cleanPath(source.SourcePath)
+		if err := validate(source.SourcePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *driver) createView(txnCtx *txncontext.TransactionContext, view *pfs.View, update bool) (*pfs.ViewInfo, error) {
+	if err := validateView(view); err != nil {
+		return nil, err
+	}
+	repo := client.NewRepo(view.Name)
+	views := d.views.ReadWrite(txnCtx.SqlTx)
+	viewInfo := &pfs.ViewInfo{}
+	exists := true
+	if err := views.Get(view.Name, viewInfo); err != nil {
+		if !col.IsErrNotFound(err) {
+			return nil, errors.EnsureStack(err)
+		}
+		exists = false
+	}
+	if exists && !update {
+		return nil, errors.Errorf("view %s already exists", view.Name)
+	}
+	// The caller must be able to read the sources, as the view exposes their
+	// files to anyone who can read its repo.
+	var provenance []*pfs.Branch
+	for _, source := range view.Sources {
+		if err := d.env.AuthServer.CheckRepoIsAuthorizedInTransaction(txnCtx, source.Branch.Repo, auth.Permission_REPO_READ); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		provenance = append(provenance, source.Branch)
+	}
+	if err := d.createRepo(txnCtx, repo, view.Description, exists, nil); err != nil {
+		return nil, err
+	}
+	if !exists {
+		viewInfo.Created = txnCtx.Timestamp
+	}
+	viewInfo.View = view
+	viewInfo.Repo = repo
+	if err := views.Put(view.Name, viewInfo); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	// Each commit to a source propagates to the view's branch, which is
+	// filled by the PFS master.
+	if err := d.createBranch(txnCtx, repo.NewBranch(viewBranch), nil, provenance, nil); err != nil {
+		return nil, err
+	}
+	return viewInfo, nil
+}
+
+func (d *driver) inspectView(ctx context.Context, name string) (*pfs.ViewInfo, error) {
+	viewInfo := &pfs.ViewInfo{}
+	if err := d.views.ReadOnly(ctx).Get(name, viewInfo); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil, errors.Errorf("view %s not found", name)
+		}
+		return nil, errors.EnsureStack(err)
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, viewInfo.Repo, auth.Permission_REPO_READ); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return viewInfo, nil
+}
+
+// listView calls cb with the views whose repos the caller can read.
+func (d *driver) listView(ctx context.Context, cb func(*pfs.ViewInfo) error) error {
+	viewInfo := &pfs.ViewInfo{}
+	return errors.EnsureStack(d.views.ReadOnly(ctx).List(viewInfo, col.DefaultOptions(), func(string) error {
+		if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, viewInfo.Repo, auth.Permission_REPO_READ); err != nil {
+			if auth.IsErrNotAuthorized(err) {
+				return nil
+			}
+			return errors.EnsureStack(err)
+		}
+		return cb(viewInfo)
+	}))
+}
+
+func (d *driver) deleteView(txnCtx *txncontext.TransactionContext, name string, force bool) error {
+	views := d.views.ReadWrite(txnCtx.SqlTx)
+	viewInfo := &pfs.ViewInfo{}
+	if err := views.Get(name, viewInfo); err != nil {
+		if col.IsErrNotFound(err) {
+			return errors.Errorf("view %s not found", name)
+		}
+		return errors.EnsureStack(err)
+	}
+	if err := views.Delete(name); err != nil {
+		return errors.EnsureStack(err)
+	}
+	return d.deleteRepo(txnCtx, viewInfo.Repo, force)
+}
+
+// fillViews fills the commits of each view's repo with the files of its
+// sources.
+func (d *driver) fillViews(ctx context.Context) error {
+	views := make(map[string]context.CancelFunc)
+	defer func() {
+		for _, cancel := range views {
+			cancel()
+		}
+	}()
+	err := d.views.ReadOnly(ctx).WatchF(func(ev *watch.Event) error {
+		if ev.Type == watch.EventError {
+			return ev.Err
+		}
+		key := string(ev.Key)
+		if ev.Type == watch.EventDelete {
+			if cancel, ok := views[key]; ok {
+				cancel()
+			}
+			delete(views, key)
+			return nil
+		}
+		if _, ok := views[key]; ok {
+			return nil
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		views[key] = cancel
+		go func() {
+			backoff.RetryUntilCancel(ctx, func() error {
+				return d.fillViewCommits(ctx, key)
+			}, backoff.NewInfiniteBackOff(), func(err error, d time.Duration) error {
+				log.Errorf("error filling commits of view %v: %v, retrying in %v", key, err, d)
+				return nil
+			})
+		}()
+		return nil
+	})
+	return errors.EnsureStack(err)
+}
+
+func (d *driver) fillViewCommits(ctx context.Context, name string) error {
+	repo := client.NewRepo(name)
+	err := d.commits.ReadOnly(ctx).WatchByIndexF(pfsdb.CommitsRepoIndex, pfsdb.RepoKey(repo), func(ev *watch.Event) error {
+		if ev.Type == watch.EventError {
+			return ev.Err
+		}
+		var key string
+		commitInfo := &pfs.CommitInfo{}
+		if err := ev.Unmarshal(&key, commitInfo); err != nil {
+			return err
+		}
+		if commitInfo.Finishing != nil || commitInfo.Origin.Kind == pfs.OriginKind_ALIAS {
+			return nil
+		}
+		return miscutil.LogStep(fmt.Sprintf("filling view commit %v", commitInfo.Commit), func() error {
+			return d.fillViewCommit(ctx, name, commitInfo)
+		})
+	}, watch.WithSort(col.SortByCreateRevision, col.SortAscend), watch.IgnoreDelete)
+	return errors.EnsureStack(err)
+}
+
+// fillViewCommit writes the files of a view's sources to commitInfo, and
+// finishes it. The sources are read from the commits in the same commit set,
+// once they're finished.
+func (d *driver) fillViewCommit(ctx context.Context, name string, commitInfo *pfs.CommitInfo) error {
+	viewInfo := &pfs.ViewInfo{}
+	if err := d.views.ReadOnly(ctx).Get(name, viewInfo); err != nil {
+		return errors.EnsureStack(err)
+	}
+	commit := commitInfo.Commit
+	var sources []*pfs.ViewSource
+	var commitError string
+	for _, source := range viewInfo.View.Sources {
+		// Sources added after the commit was started appear in later commits.
+		if !has(&commitInfo.DirectProvenance, source.Branch) {
+			continue
+		}
+		sourceCommitInfo, err := d.inspectCommit(ctx, source.Branch.NewCommit(commit.ID), pfs.CommitState_FINISHED)
+		if err != nil {
+			return err
+		}
+		if sourceCommitInfo.Error != "" {
+			commitError = fmt.Sprintf("source commit %v failed: %v", sourceCommitInfo.Commit, sourceCommitInfo.Error)
+			break
+		}
+		sources = append(sources, source)
+	}
+	if commitError == "" {
+		if err := d.storage.WithRenewer(ctx, defaultTTL, func(ctx context.Context, renewer *fileset.Renewer) error {
+			return d.withCommitUnorderedWriter(ctx, renewer, commit, func(uw *fileset.UnorderedWriter) error {
+				// The commit starts with the files of its parent, which are
+				// replaced by the current files of the sources.
+				if err := uw.Delete("/", ""); err != nil {
+					return errors.EnsureStack(err)
+				}
+				for _, source := range sources {
+					src := source.Branch.NewCommit(commit.ID).NewFile(source.SourcePath)
+					if err := d.copyFile(ctx, uw, source.Path, src, false, ""); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}); err != nil {
+			return err
+		}
+	}
+	return d.txnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		if err := d.finishCommit(txnCtx, commit, "", commitError, true); err != nil {
+			if pfsserver.IsCommitFinishedErr(err) {
+				return nil
+			}
+			return err
+		}
+		return nil
+	})
+}
@@ -108,6 +108,38 @@ If the job fails, the output commit will not be populated with data.`,
 	shell.RegisterCompletionFunc(inspectJob, shell.JobCompletion)
 	commands = append(commands, cmdutil.CreateAlias(inspectJob, "inspect job"))
 
+	var slowest int64
+	inspectJobProfile := &cobra.Command{
+		Use:   "{{alias}} <pipeline>@<job>",
+		Short: "Return the resource usage of a job's datums.",
+		Long:  "Return the CPU, memory, time and I/O used by the datums a job processed, and its slowest datums. Use it to find slow datums and to right-size a pipeline's resource requests.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			job, err := cmdutil.ParseJob(args[0])
+			if err != nil {
+				return err
+			}
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			profile, err := client.InspectJobProfile(job.Pipeline.Name, job.ID, slowest)
+			if err != nil {
+				return errors.Wrap(err, "error from InspectJobProfile")
+			}
+			if raw {
+				return errors.EnsureStack(cmdutil.Encoder(output, os.Stdout).EncodeProto(profile))
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			return pretty.PrintJobProfile(os.Stdout, profile)
+		}),
+	}
+	inspectJobProfile.Flags().Int64Var(&slowest, "slowest", 10, "The number of slowest datums to show.")
+	inspectJobProfile.Flags().AddFlagSet(outputFlags)
+	shell.RegisterCompletionFunc(inspectJobProfile, shell.JobCompletion)
+	commands = append(commands, cmdutil.CreateAlias(inspectJobProfile, "inspect job-profile"))
+
 	writeJobInfos := func(out io.Writer, jobInfos []*pps.JobInfo) error {
 		if raw {
 			e := cmdutil.Encoder(output, out)
@@ -172,6 +204,10 @@ If the job fails, the output commit will not be populated with data.`,
 	var history string
 	var stateStrs []string
 	var expand bool
+	var number int64
+	var reverse bool
+	var after string
+	var createdAfter, createdBefore string
 	listJob := &cobra.Command{
 		Use:   "{{alias}} [<job-id>]",
 		Short: "Return info about jobs.",
@@ -196,7 +232,14 @@ $ {{alias}} -p foo --history all
 $ {{alias}} -i foo@XXX -i bar@YYY
 
 # Return all sub-jobs in pipeline foo and whose input commits include bar@YYY
-$ {{alias}} -p foo -i bar@YYY`,
+$ {{alias}} -p foo -i bar@YYY
+
+# Return the 20 most recent failed sub-jobs of pipeline "foo", then the next 20
+$ {{alias}} -p foo --state failure -n 20
+$ {{alias}} -p foo --state failure -n 20 --after <job-id>
+
+# Return the sub-jobs of pipeline "foo" created in the last day
+$ {{alias}} -p foo --created-after 24h`,
 		Run: cmdutil.RunBoundedArgs(0, 1, func(args []string) error {
 			commits, err := cmdutil.ParseCommits(inputCommitStrs)
 			if err != nil {
@@ -206,13 +249,34 @@ $ {{alias}} -p foo -i bar@YYY`,
 			if err != nil {
 				return errors.Wrapf(err, "error parsing history flag")
 			}
-			var filter string
-			if len(stateStrs) > 0 {
-				filter, err = ParseJobStates(stateStrs)
+			var states []pps.JobState
+			for _, stateStr := range stateStrs {
+				state, err := ppsclient.JobStateFromName(stateStr)
 				if err != nil {
 					return errors.Wrap(err, "error parsing state")
 				}
+				states = append(states, state)
+			}
+			request := &pps.ListJobRequest{
+				InputCommit: commits,
+				History:     historyCount,
+				State:       states,
+				Number:      number,
+				Reverse:     reverse,
+			}
+			if pipelineName != "" {
+				request.Pipeline = pachdclient.NewPipeline(pipelineName)
+			}
+			if after != "" {
+				request.Cursor = pachdclient.NewJob(pipelineName, after)
 			}
+			if request.CreatedAfter, err = cmdutil.ParseTimestamp(createdAfter); err != nil {
+				return err
+			}
+			if request.CreatedBefore, err = cmdutil.ParseTimestamp(createdBefore); err != nil {
+				return err
+			}
+			paged := number != 0 || reverse || after != "" || createdAfter != "" || createdBefore != ""
 
 			client, err := pachdclient.NewOnUserMachine("user")
 			if err != nil {
@@ -233,6 +297,8 @@ $ {{alias}} -p foo -i bar@YYY`,
 						return errors.Errorf("cannot specify '--input' when listing all jobs")
 					} else if history != "none" {
 						return errors.Errorf("cannot specify '--history' when listing all jobs")
+					} else if paged {
+						return errors.Errorf("cannot specify '--number', '--reverse', '--after', '--created-after' or '--created-before' when listing all jobs")
 					}
 
 					listJobSetClient, err := client.PpsAPIClient.ListJobSet(client.Ctx(), &pps.ListJobSetRequest{})
@@ -259,20 +325,28 @@ $ {{alias}} -p foo -i bar@YYY`,
 					})
 				} else {
 					// We are listing all sub-jobs, possibly restricted to a single pipeline
+					if after != "" && pipelineName == "" {
+						return errors.Errorf("must specify '--pipeline' with '--after'")
+					}
+					request.Details = raw
+					listJobClient, err := client.PpsAPIClient.ListJob(client.Ctx(), request)
+					if err != nil {
+						return grpcutil.ScrubGRPC(err)
+					}
 					if raw {
 						e := cmdutil.Encoder(output, os.Stdout)
-						return client.ListJobFilterF(pipelineName, commits, historyCount, true, filter, func(ji *ppsclient.JobInfo) error {
+						return grpcutil.ScrubGRPC(clientsdk.ForEachJobInfo(listJobClient, func(ji *ppsclient.JobInfo) error {
 							return errors.EnsureStack(e.EncodeProto(ji))
-						})
+						}))
 					}
 
 					return pager.Page(noPager, os.Stdout, func(w io.Writer) error {
 						writer := tabwriter.NewWriter(w, pretty.JobHeader)
-						if err := client.ListJobFilterF(pipelineName, commits, historyCount, false, filter, func(ji *ppsclient.JobInfo) error {
+						if err := clientsdk.ForEachJobInfo(listJobClient, func(ji *ppsclient.JobInfo) error {
 							pretty.PrintJobInfo(writer, ji, fullTimestamps)
 							return nil
 						}); err != nil {
-							return err
+							return grpcutil.ScrubGRPC(err)
 						}
 						return writer.Flush()
 					})
@@ -287,6 +361,8 @@ $ {{alias}} -p foo -i bar@YYY`,
 					return errors.Errorf("cannot specify '--history' when listing sub-jobs")
 				} else if pipelineName != "" {
 					return errors.Errorf("cannot specify '--pipeline' when listing sub-jobs")
+				} else if paged {
+					return errors.Errorf("cannot specify '--number', '--reverse', '--after', '--created-after' or '--created-before' when listing sub-jobs")
 				}
 
 				var jobInfos []*pps.JobInfo
@@ -309,6 +385,10 @@ $ {{alias}} -p foo -i bar@YYY`,
 	listJob.Flags().AddFlagSet(pagerFlags)
 	listJob.Flags().StringVar(&history, "history", "none", "Return jobs from historical versions of pipelines.")
 	listJob.Flags().StringArrayVar(&stateStrs, "state", []string{}, "Return only sub-jobs with the specified state. Can be repeated to include multiple states")
+	listJob.Flags().Int64VarP(&number, "number", "n", 0, "Return only this many sub-jobs; if set to zero, return all sub-jobs.")
+	listJob.Flags().BoolVar(&reverse, "reverse", false, "Return sub-jobs from oldest to newest.")
+	listJob.Flags().StringVar(&after, "after", "", "Return the sub-jobs after this job ID, which is the last sub-job of the previous page. Requires --pipeline.")
+	listJob.Flags().AddFlagSet(cmdutil.TimeRangeFlags("created", &createdAfter, &createdBefore))
 	shell.RegisterCompletionFunc(listJob,
 		func(flag, text string, maxCompletions int64) ([]prompt.Suggest, shell.CacheFunc) {
 			if flag == "-p" || flag == "--pipeline" {
@@ -428,16 +508,62 @@ each datum.`,
 	commands = append(commands, cmdutil.CreateAlias(restartDatum, "restart datum"))
 
 	var pipelineInputPath string
+	var quarantined bool
+	var produced string
+	var datumStateStrs []string
+	var datumNumber int64
+	var datumAfter string
+	var counts bool
 	listDatum := &cobra.Command{
 		Use:   "{{alias}} <pipeline>@<job>",
 		Short: "Return the datums in a job.",
-		Long:  "Return the datums in a job.",
+		Long:  "Return the datums in a job. With --quarantined, return the datums that were quarantined by the most recent successful job of a pipeline. With --produced, return the datums that produced a file in a pipeline's output commit, along with their input files. With --counts, return the number of datums of a job in each state, without listing them.",
+		Example: `
+# Return the number of datums of job foo@XXX in each state
+$ {{alias}} foo@XXX --counts
+
+# Return the first 100 failed datums of job foo@XXX, then the next 100
+$ {{alias}} foo@XXX --state failed -n 100
+$ {{alias}} foo@XXX --state failed -n 100 --after <datum-id>`,
 		Run: cmdutil.RunBoundedArgs(0, 1, func(args []string) (retErr error) {
+			var states []ppsclient.DatumState
+			for _, stateStr := range datumStateStrs {
+				state, err := ppsclient.DatumStateFromName(stateStr)
+				if err != nil {
+					return errors.Wrap(err, "error parsing state")
+				}
+				states = append(states, state)
+			}
+			paged := len(states) != 0 || datumNumber != 0 || datumAfter != ""
+			if (produced != "" || quarantined || counts) && paged {
+				return errors.Errorf("cannot specify '--state', '--number' or '--after' with '--produced', '--quarantined' or '--counts'")
+			}
+			if counts && (len(args) != 1 || produced != "" || quarantined || pipelineInputPath != "") {
+				return errors.Errorf("--counts requires a job, and can't be combined with a pipeline spec, --produced or --quarantined")
+			}
 			client, err := pachdclient.NewOnUserMachine("user")
 			if err != nil {
 				return err
 			}
 			defer client.Close()
+			if counts {
+				job, err := cmdutil.ParseJob(args[0])
+				if err != nil {
+					return err
+				}
+				datumCounts, err := client.CountDatums(job.Pipeline.Name, job.ID)
+				if err != nil {
+					return err
+				}
+				if raw {
+					return errors.EnsureStack(cmdutil.Encoder(output, os.Stdout).EncodeProto(datumCounts))
+				} else if output != "" {
+					return errors.New("cannot set --output (-o) without --raw")
+				}
+				writer := tabwriter.NewWriter(os.Stdout, pretty.DatumCountsHeader)
+				pretty.PrintDatumCounts(writer, datumCounts)
+				return writer.Flush()
+			}
 			var printF func(*ppsclient.DatumInfo) error
 			if !raw {
 				if output != "" {
@@ -459,7 +585,21 @@ each datum.`,
 					return errors.EnsureStack(e.EncodeProto(di))
 				}
 			}
-			if pipelineInputPath != "" && len(args) == 1 {
+			if produced != "" {
+				if len(args) != 0 || pipelineInputPath != "" || quarantined {
+					return errors.Errorf("--produced can't be combined with a job, a pipeline spec or --quarantined")
+				}
+				file, err := cmdutil.ParseFile(produced)
+				if err != nil {
+					return err
+				}
+				return client.ListDatumProvenance(file.Commit, file.Path, printF)
+			} else if quarantined {
+				if len(args) != 1 || pipelineInputPath != "" {
+					return errors.Errorf("--quarantined requires a pipeline name")
+				}
+				return client.ListQuarantinedDatum(args[0], printF)
+			} else if pipelineInputPath != "" && len(args) == 1 {
 				return errors.Errorf("can't specify both a job and a pipeline spec")
 			} else if pipelineInputPath != "" {
 				pipelineBytes, err := readPipelineBytes(pipelineInputPath)
@@ -474,23 +614,104 @@ each datum.`,
 				if err != nil {
 					return err
 				}
-				return client.ListDatumInput(request.Input, printF)
+				return listDatumPage(client, &ppsclient.ListDatumRequest{
+					Input:  request.Input,
+					State:  states,
+					Number: datumNumber,
+					Cursor: datumCursor(nil, datumAfter),
+				}, printF)
 			} else if len(args) == 1 {
 				job, err := cmdutil.ParseJob(args[0])
 				if err != nil {
 					return err
 				}
-				return client.ListDatum(job.Pipeline.Name, job.ID, printF)
+				return listDatumPage(client, &ppsclient.ListDatumRequest{
+					Job:    job,
+					State:  states,
+					Number: datumNumber,
+					Cursor: datumCursor(job, datumAfter),
+				}, printF)
 			} else {
 				return errors.Errorf("must specify either a job or a pipeline spec")
 			}
 		}),
 	}
 	listDatum.Flags().StringVarP(&pipelineInputPath, "file", "f", "", "The JSON file containing the pipeline to list datums from, the pipeline need not exist")
+	listDatum.Flags().StringVar(&produced, "produced", "", "List the datums that produced a file, given as <repo>@<branch-or-commit>:<path>.")
+	listDatum.Flags().BoolVar(&quarantined, "quarantined", false, "List the quarantined datums of the pipeline given as the argument.")
+	listDatum.Flags().StringArrayVar(&datumStateStrs, "state", []string{}, "Return only datums with the specified state. Can be repeated to include multiple states.")
+	listDatum.Flags().Int64VarP(&datumNumber, "number", "n", 0, "Return only this many datums; if set to zero, return all datums.")
+	listDatum.Flags().StringVar(&datumAfter, "after", "", "Return the datums after this datum ID, which is the last datum of the previous page.")
+	listDatum.Flags().BoolVar(&counts, "counts", false, "Return the number of datums of the job in each state, without listing them.")
 	listDatum.Flags().AddFlagSet(outputFlags)
 	shell.RegisterCompletionFunc(listDatum, shell.JobCompletion)
 	commands = append(commands, cmdutil.CreateAlias(listDatum, "list datum"))
 
+	requeueDatum := &cobra.Command{
+		Use:   "{{alias}} <pipeline>",
+		Short: "Process a pipeline's quarantined datums again.",
+		Long:  "Process a pipeline's quarantined datums again, by starting a new job for the pipeline.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			resp, err := client.RequeueQuarantinedDatums(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Requeued %d datum(s) in job %s@%s\n", resp.Count, resp.Job.Pipeline.Name, resp.Job.ID)
+			return nil
+		}),
+	}
+	shell.RegisterCompletionFunc(requeueDatum, shell.PipelineCompletion)
+	commands = append(commands, cmdutil.CreateAlias(requeueDatum, "requeue datum"))
+
+	inspectDatumCache := &cobra.Command{
+		Use:   "{{alias}} <pipeline>",
+		Short: "Display info about a pipeline's datum cache.",
+		Long:  "Display info about a pipeline's datum cache, which holds the output of the datums it processed if its spec sets datum_cache.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			info, err := client.InspectDatumCache(args[0])
+			if err != nil {
+				return err
+			}
+			if raw {
+				return errors.EnsureStack(cmdutil.Encoder(output, os.Stdout).EncodeProto(info))
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			fmt.Printf("Pipeline: %s\n", info.Pipeline.Name)
+			fmt.Printf("Cached Datums: %d\n", info.Entries)
+			return nil
+		}),
+	}
+	inspectDatumCache.Flags().AddFlagSet(outputFlags)
+	shell.RegisterCompletionFunc(inspectDatumCache, shell.PipelineCompletion)
+	commands = append(commands, cmdutil.CreateAlias(inspectDatumCache, "inspect datum-cache"))
+
+	deleteDatumCache := &cobra.Command{
+		Use:   "{{alias}} <pipeline>",
+		Short: "Clear a pipeline's datum cache.",
+		Long:  "Clear a pipeline's datum cache, so that the pipeline's code runs again for each of its datums the next time they're processed.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			return client.ClearDatumCache(args[0])
+		}),
+	}
+	shell.RegisterCompletionFunc(deleteDatumCache, shell.PipelineCompletion)
+	commands = append(commands, cmdutil.CreateAlias(deleteDatumCache, "delete datum-cache"))
+
 	inspectDatum := &cobra.Command{
 		Use:   "{{alias}} <pipeline>@<job> <datum>",
 		Short: "Display detailed info about a single datum.",
@@ -530,6 +751,9 @@ each datum.`,
 		follow      bool
 		tail        int64
 		since       string
+		logFilter   string
+		logLevel    string
+		archived    bool
 	)
 
 	// prettyLogsPrinter helps to print the logs recieved in different colours
@@ -569,7 +793,13 @@ each datum.`,
 	$ {{alias}} --job=aedfa12aedf
 
 	# Return logs emitted by the pipeline \"filter\" while processing /apple.txt and a file with the hash 123aef
-	$ {{alias}} --pipeline=filter --inputs=/apple.txt,123aef`,
+	$ {{alias}} --pipeline=filter --inputs=/apple.txt,123aef
+
+	# Follow the warnings and errors logged by the "filter" pipeline that mention "timeout"
+	$ {{alias}} -f --pipeline=filter --level=warning --filter=timeout
+
+	# Return the archived logs of the finished job aedfa12aedf in the "filter" pipeline
+	$ {{alias}} --job=filter@aedfa12aedf --archived`,
 		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
 			client, err := pachdclient.NewOnUserMachine("user")
 			if err != nil {
@@ -611,8 +841,54 @@ each datum.`,
 				jobID = job.ID
 			}
 
+			request := &pps.GetLogsRequest{
+				Master:      master,
+				Follow:      follow,
+				Since:       types.DurationProto(since),
+				DataFilters: data,
+				Filter:      logFilter,
+			}
+			if logLevel != "" {
+				if request.Level, err = pps.ParseLogLevelName(logLevel); err != nil {
+					return err
+				}
+			}
+			if follow {
+				request.HeartbeatInterval = types.DurationProto(logsHeartbeatInterval)
+			}
+			if pipelineName != "" {
+				request.Pipeline = pachdclient.NewPipeline(pipelineName)
+			}
+			if jobID != "" {
+				request.Job = pachdclient.NewJob(pipelineName, jobID)
+			}
+			if datumID != "" {
+				request.Datum = &pps.Datum{
+					Job: pachdclient.NewJob(pipelineName, jobID),
+					ID:  datumID,
+				}
+			}
+
 			// Issue RPC
-			iter := client.GetLogs(pipelineName, jobID, data, datumID, master, follow, since)
+			var iter *pachdclient.LogsIter
+			if archived {
+				if request.Job == nil {
+					return errors.Errorf("--archived requires --job")
+				}
+				if follow {
+					return errors.Errorf("--archived can't be used with --follow, as archived logs are from finished jobs")
+				}
+				iter = client.GetArchivedLogs(&pps.GetArchivedLogsRequest{
+					Job:         request.Job,
+					DataFilters: request.DataFilters,
+					Datum:       request.Datum,
+					Master:      request.Master,
+					Filter:      request.Filter,
+					Level:       request.Level,
+				})
+			} else {
+				iter = client.GetLogsForRequest(request)
+			}
 			var buf bytes.Buffer
 			encoder := json.NewEncoder(&buf)
 			for iter.Next() {
@@ -650,6 +926,9 @@ each datum.`,
 	getLogs.Flags().BoolVarP(&follow, "follow", "f", false, "Follow logs as more are created.")
 	getLogs.Flags().Int64VarP(&tail, "tail", "t", 0, "Lines of recent logs to display.")
 	getLogs.Flags().StringVar(&since, "since", "24h", "Return log messages more recent than \"since\".")
+	getLogs.Flags().StringVar(&logFilter, "filter", "", "Return log messages that match this regular expression (filtered by pachd).")
+	getLogs.Flags().StringVar(&logLevel, "level", "", "Return log messages at or above this level (debug, info, warning or error); messages without a level count as info.")
+	getLogs.Flags().BoolVar(&archived, "archived", false, "Return the logs of a finished job from the log archive, after its pods are gone (job must be set).")
 	shell.RegisterCompletionFunc(getLogs,
 		func(flag, text string, maxCompletions int64) ([]prompt.Suggest, shell.CacheFunc) {
 			if flag == "--pipeline" || flag == "-p" {
@@ -681,39 +960,81 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 	var username string
 	var pipelinePath string
 	var jsonnetPath string
-	var jsonnetArgs []string
+	var jsonnetArgs, setArgs []string
+	var dryRun bool
+	var lint bool
 	createPipeline := &cobra.Command{
 		Short: "Create a new pipeline.",
 		Long:  "Create a new pipeline from a pipeline specification. For details on the format, see https://docs.pachyderm.com/latest/reference/pipeline_spec/.",
 		Run: cmdutil.RunFixedArgs(0, func(args []string) (retErr error) {
-			return pipelineHelper(false, pushImages, registry, username, pipelinePath, jsonnetPath, jsonnetArgs, false)
+			return pipelineHelper(false, pushImages, registry, username, pipelinePath, jsonnetPath, append(jsonnetArgs, setArgs...), false, dryRun, lint, 0)
 		}),
 	}
 	createPipeline.Flags().StringVarP(&pipelinePath, "file", "f", "", "A JSON file (url or filepath) containing one or more pipelines. \"-\" reads from stdin (the default behavior). Exactly one of --file and --jsonnet must be set.")
 	createPipeline.Flags().StringVar(&jsonnetPath, "jsonnet", "", "BETA: A Jsonnet template file (url or filepath) for one or more pipelines. \"-\" reads from stdin. Exactly one of --file and --jsonnet must be set. Jsonnet templates must contain a top-level function; strings can be passed to this function with --arg (below)")
 	createPipeline.Flags().StringArrayVar(&jsonnetArgs, "arg", nil, "Top-level argument passed to the Jsonnet template in --jsonnet (which must be set if any --arg arguments are passed). Value must be of the form 'param=value'. For multiple args, --arg may be set more than once.")
+	createPipeline.Flags().StringArrayVar(&setArgs, "set", nil, "Set a parameter of the Jsonnet template in --jsonnet, of the form 'param=value'. Parameters not set keep their defaults, and the values of all parameters are recorded in the pipeline. Equivalent to --arg.")
 	createPipeline.Flags().BoolVarP(&pushImages, "push-images", "p", false, "If true, push local docker images into the docker registry.")
 	createPipeline.Flags().StringVarP(&registry, "registry", "r", "index.docker.io", "The registry to push images to.")
 	createPipeline.Flags().StringVarP(&username, "username", "u", "", "The username to push images as.")
+	createPipeline.Flags().BoolVar(&dryRun, "dry-run", false, "If true, validate the pipelines and print what creating them would do, including their number of datums at the current heads of their inputs, without creating anything.")
+	createPipeline.Flags().BoolVar(&lint, "lint", false, "If true, check the pipelines for common mistakes and print the problems found, with suggested fixes, before creating them. Pipelines with errors aren't created.")
 	commands = append(commands, cmdutil.CreateAlias(createPipeline, "create pipeline"))
 
 	var reprocess bool
+	var canary float64
 	updatePipeline := &cobra.Command{
 		Short: "Update an existing Pachyderm pipeline.",
 		Long:  "Update a Pachyderm pipeline with a new pipeline specification. For details on the format, see https://docs.pachyderm.com/latest/reference/pipeline-spec/.",
 		Run: cmdutil.RunFixedArgs(0, func(args []string) (retErr error) {
-			return pipelineHelper(reprocess, pushImages, registry, username, pipelinePath, jsonnetPath, jsonnetArgs, true)
+			return pipelineHelper(reprocess, pushImages, registry, username, pipelinePath, jsonnetPath, append(jsonnetArgs, setArgs...), true, dryRun, lint, canary)
 		}),
 	}
 	updatePipeline.Flags().StringVarP(&pipelinePath, "file", "f", "", "A JSON file (url or filepath) containing one or more pipelines. \"-\" reads from stdin (the default behavior). Exactly one of --file and --jsonnet must be set.")
 	updatePipeline.Flags().StringVar(&jsonnetPath, "jsonnet", "", "BETA: A Jsonnet template file (url or filepath) for one or more pipelines. \"-\" reads from stdin. Exactly one of --file and --jsonnet must be set. Jsonnet templates must contain a top-level function; strings can be passed to this function with --arg (below)")
 	updatePipeline.Flags().StringArrayVar(&jsonnetArgs, "arg", nil, "Top-level argument passed to the Jsonnet template in --jsonnet (which must be set if any --arg arguments are passed). Value must be of the form 'param=value'. For multiple args, --arg may be set more than once.")
+	updatePipeline.Flags().StringArrayVar(&setArgs, "set", nil, "Set a parameter of the Jsonnet template in --jsonnet, of the form 'param=value'. Parameters not set keep their defaults, and the values of all parameters are recorded in the pipeline. Equivalent to --arg.")
 	updatePipeline.Flags().BoolVarP(&pushImages, "push-images", "p", false, "If true, push local docker images into the docker registry.")
 	updatePipeline.Flags().StringVarP(&registry, "registry", "r", "index.docker.io", "The registry to push images to.")
 	updatePipeline.Flags().StringVarP(&username, "username", "u", "", "The username to push images as.")
+	updatePipeline.Flags().BoolVar(&dryRun, "dry-run", false, "If true, validate the pipelines and print what updating them would do, including their number of datums at the current heads of their inputs, without updating anything.")
 	updatePipeline.Flags().BoolVar(&reprocess, "reprocess", false, "If true, reprocess datums that were already processed by previous version of the pipeline.")
+	updatePipeline.Flags().BoolVar(&lint, "lint", false, "If true, check the pipelines for common mistakes and print the problems found, with suggested fixes, before updating them. Pipelines with errors aren't updated.")
+	updatePipeline.Flags().Float64Var(&canary, "canary", 0, "If set, run the new spec as a canary beside the pipeline, on this fraction of its datums, rather than updating the pipeline. The canary's output is committed to the canary branch of the <pipeline>-canary repo. Promote the canary with 'pachctl promote canary', or roll it back with 'pachctl rollback canary'.")
 	commands = append(commands, cmdutil.CreateAlias(updatePipeline, "update pipeline"))
 
+	promoteCanary := &cobra.Command{
+		Use:   "{{alias}} <pipeline>",
+		Short: "Update a pipeline to its canary's spec.",
+		Long:  "Update a pipeline to the spec of its canary, created with 'pachctl update pipeline --canary', and delete the canary and its output repo.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			return client.FinishCanary(args[0], true)
+		}),
+	}
+	shell.RegisterCompletionFunc(promoteCanary, shell.PipelineCompletion)
+	commands = append(commands, cmdutil.CreateAlias(promoteCanary, "promote canary"))
+
+	rollbackCanary := &cobra.Command{
+		Use:   "{{alias}} <pipeline>",
+		Short: "Delete a pipeline's canary, leaving the pipeline unchanged.",
+		Long:  "Delete a pipeline's canary, created with 'pachctl update pipeline --canary', and its output repo, leaving the pipeline unchanged.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			return client.FinishCanary(args[0], false)
+		}),
+	}
+	shell.RegisterCompletionFunc(rollbackCanary, shell.PipelineCompletion)
+	commands = append(commands, cmdutil.CreateAlias(rollbackCanary, "rollback canary"))
+
 	runCron := &cobra.Command{
 		Use:   "{{alias}} <pipeline>",
 		Short: "Run an existing Pachyderm cron pipeline now",
@@ -845,6 +1166,7 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 	commands = append(commands, cmdutil.CreateAlias(editPipeline, "edit pipeline"))
 
 	var spec bool
+	var project string
 	listPipeline := &cobra.Command{
 		Use:   "{{alias}} [<pipeline>]",
 		Short: "Return info about all pipelines.",
@@ -881,6 +1203,9 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 			if pipeline != "" {
 				request.Pipeline = pachdclient.NewPipeline(pipeline)
 			}
+			if project != "" {
+				request.Project = pachdclient.NewProject(project)
+			}
 			lpClient, err := client.PpsAPIClient.ListPipeline(client.Ctx(), request)
 			if err != nil {
 				return grpcutil.ScrubGRPC(err)
@@ -924,8 +1249,100 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 	listPipeline.Flags().AddFlagSet(timestampFlags)
 	listPipeline.Flags().StringVar(&history, "history", "none", "Return revision history for pipelines.")
 	listPipeline.Flags().StringArrayVar(&stateStrs, "state", []string{}, "Return only pipelines with the specified state. Can be repeated to include multiple states")
+	listPipeline.Flags().StringVar(&project, "project", "", "Return only pipelines in the specified project.")
 	commands = append(commands, cmdutil.CreateAlias(listPipeline, "list pipeline"))
 
+	var downstream bool
+	var lineageDepth, lineageLimit int64
+	inspectLineage := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch-or-commit>",
+		Short: "Return the lineage of a commit.",
+		Long:  "Return the commits, and pipeline versions, that a commit was produced from, or with --downstream the commits that were produced from it, which deleting it would affect. The lineage of a file is the lineage of its commit.",
+		Example: `
+# return the input commits and pipeline versions that produced the head of edges@master
+$ {{alias}} edges@master
+
+# return the commits that would be affected by deleting a commit to images
+$ {{alias}} images@9d5f0ba1b5b644e0a84f2ab9f1b1a1a7 --downstream
+
+# return only the direct inputs of a commit
+$ {{alias}} montage@master --depth 1`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) (retErr error) {
+			commit, err := cmdutil.ParseCommit(args[0])
+			if err != nil {
+				return err
+			}
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			direction := ppsclient.QueryLineageRequest_UPSTREAM
+			if downstream {
+				direction = ppsclient.QueryLineageRequest_DOWNSTREAM
+			}
+			lineage, err := client.QueryLineage(commit, direction, lineageDepth, lineageLimit)
+			if err != nil {
+				return err
+			}
+			if raw {
+				return errors.EnsureStack(cmdutil.Encoder(output, os.Stdout).EncodeProto(lineage))
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.LineageHeader)
+			pretty.PrintLineage(writer, lineage)
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			if lineage.Truncated {
+				fmt.Fprintf(os.Stderr, "lineage truncated to %d commits, use --limit to return more\n", len(lineage.Nodes))
+			}
+			return nil
+		}),
+	}
+	inspectLineage.Flags().BoolVar(&downstream, "downstream", false, "Return the commits produced from the commit, rather than the ones it was produced from.")
+	inspectLineage.Flags().Int64Var(&lineageDepth, "depth", 0, "The number of provenance hops from the commit to follow, 0 follows them all.")
+	inspectLineage.Flags().Int64Var(&lineageLimit, "limit", 0, "The number of commits to return, the server's default (1000) if it's 0.")
+	inspectLineage.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(inspectLineage, "inspect lineage"))
+
+	var dagFormat, dagProject string
+	drawDAG := &cobra.Command{
+		Short: "Draw the DAG of repos and pipelines.",
+		Long:  "Draw the DAG of repos and pipelines, with the state of each pipeline and its last job, as a Graphviz DOT graph, a Mermaid flowchart, or JSON. Edges are labeled with the branch they read or write, unless it's master.",
+		Example: `
+# render the DAG as an SVG with Graphviz
+$ {{alias}} | dot -Tsvg > dag.svg
+
+# embed the DAG of a project in Markdown
+$ {{alias}} --project research --format mermaid`,
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			dag, err := client.GetDAG(dagProject)
+			if err != nil {
+				return err
+			}
+			switch dagFormat {
+			case "dot":
+				return pretty.WriteDAGDot(os.Stdout, dag)
+			case "mermaid":
+				return pretty.WriteDAGMermaid(os.Stdout, dag)
+			case "json":
+				return errors.EnsureStack(cmdutil.Encoder("json", os.Stdout).EncodeProto(dag))
+			default:
+				return errors.Errorf("unknown format %q, must be one of dot, mermaid or json", dagFormat)
+			}
+		}),
+	}
+	drawDAG.Flags().StringVar(&dagFormat, "format", "dot", "The format of the DAG: \"dot\", \"mermaid\" or \"json\".")
+	drawDAG.Flags().StringVar(&dagProject, "project", "", "Draw only the repos and pipelines in the specified project, and the repos its pipelines read.")
+	commands = append(commands, cmdutil.CreateAlias(drawDAG, "draw dag"))
+
 	var (
 		all      bool
 		force    bool
@@ -955,10 +1372,10 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 			if len(args) > 0 {
 				req.Pipeline = pachdclient.NewPipeline(args[0])
 			}
-			if _, err = client.PpsAPIClient.DeletePipeline(client.Ctx(), req); err != nil {
+			return txncmds.WithActiveTransaction(client, func(txClient *pachdclient.APIClient) error {
+				_, err := txClient.PpsAPIClient.DeletePipeline(txClient.Ctx(), req)
 				return grpcutil.ScrubGRPC(err)
-			}
-			return nil
+			})
 		}),
 	}
 	deletePipeline.Flags().BoolVar(&all, "all", false, "delete all pipelines")
@@ -984,44 +1401,115 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 	}
 	commands = append(commands, cmdutil.CreateAlias(startPipeline, "start pipeline"))
 
+	var drain bool
 	stopPipeline := &cobra.Command{
 		Use:   "{{alias}} <pipeline>",
 		Short: "Stop a running pipeline.",
-		Long:  "Stop a running pipeline.",
+		Long: "Stop a running pipeline. Its running jobs are killed, unless --drain is set, " +
+			"in which case they finish the datums they're processing and continue from there when the pipeline is started.",
 		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
 			client, err := pachdclient.NewOnUserMachine("user")
 			if err != nil {
 				return err
 			}
 			defer client.Close()
+			if drain {
+				if err := client.DrainPipeline(args[0]); err != nil {
+					return errors.Wrap(err, "error from StopPipeline")
+				}
+				return nil
+			}
 			if err := client.StopPipeline(args[0]); err != nil {
 				return errors.Wrap(err, "error from StopPipeline")
 			}
 			return nil
 		}),
 	}
+	stopPipeline.Flags().BoolVar(&drain, "drain", false, "Let running jobs finish the datums they're processing, and continue from there when the pipeline is started, rather than killing them.")
 	commands = append(commands, cmdutil.CreateAlias(stopPipeline, "stop pipeline"))
 
+	var cordonReason string
+	cordonPipeline := &cobra.Command{
+		Use:   "{{alias}} <pipeline>",
+		Short: "Stop a pipeline's new jobs from starting.",
+		Long: "Stop a pipeline's new jobs from starting, without stopping or deleting it, until it's uncordoned. " +
+			"Unlike stop pipeline, its running jobs keep running, and the jobs of new input commits are created and wait to start.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			return client.CordonPipeline(args[0], cordonReason)
+		}),
+	}
+	cordonPipeline.Flags().StringVar(&cordonReason, "reason", "", "Why the pipeline is cordoned.")
+	commands = append(commands, cmdutil.CreateAlias(cordonPipeline, "cordon pipeline"))
+
+	uncordonPipeline := &cobra.Command{
+		Use:   "{{alias}} <pipeline>",
+		Short: "Let a cordoned pipeline's jobs start again.",
+		Long:  "Let a cordoned pipeline's jobs start again, beginning with the jobs that waited while it was cordoned.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			return client.UncordonPipeline(args[0])
+		}),
+	}
+	commands = append(commands, cmdutil.CreateAlias(uncordonPipeline, "uncordon pipeline"))
+
 	var file string
+	var secretPipeline, secretName string
+	var literals []string
+	var updateSecret bool
 	createSecret := &cobra.Command{
 		Short: "Create a secret on the cluster.",
-		Long:  "Create a secret on the cluster.",
+		Long: "Create a secret on the cluster, from a file containing a Kubernetes secret or from literal values. " +
+			"Secrets scoped to a pipeline with --pipeline can only be used by that pipeline, and can be managed by anyone who can update it.",
+		Example: `
+# create a secret from a Kubernetes secret manifest
+$ {{alias}} -f secret.json
+
+# create a secret for the pipeline "edges", with a key for each literal
+$ {{alias}} --pipeline edges --name edges-db --from-literal user=edges --from-literal password=hunter2
+
+# replace the data of the secret if it exists
+$ {{alias}} --pipeline edges --name edges-db --from-literal password=hunter3 --update`,
 		Run: cmdutil.RunFixedArgs(0, func(args []string) (retErr error) {
+			var fileBytes []byte
+			switch {
+			case file != "" && (secretName != "" || len(literals) > 0):
+				return errors.New("--file can't be used with --name or --from-literal")
+			case file != "":
+				var err error
+				if fileBytes, err = ioutil.ReadFile(file); err != nil {
+					return errors.EnsureStack(err)
+				}
+			case secretName != "":
+				var err error
+				if fileBytes, err = literalSecret(secretName, literals); err != nil {
+					return err
+				}
+			default:
+				return errors.New("either --file or --name must be set")
+			}
 			client, err := pachdclient.NewOnUserMachine("user")
 			if err != nil {
 				return err
 			}
 			defer client.Close()
-			fileBytes, err := ioutil.ReadFile(file)
-			if err != nil {
-				return errors.EnsureStack(err)
-			}
 
-			_, err = client.PpsAPIClient.CreateSecret(
-				client.Ctx(),
-				&ppsclient.CreateSecretRequest{
-					File: fileBytes,
-				})
+			request := &ppsclient.CreateSecretRequest{
+				File:   fileBytes,
+				Update: updateSecret,
+			}
+			if secretPipeline != "" {
+				request.Pipeline = pachdclient.NewPipeline(secretPipeline)
+			}
+			_, err = client.PpsAPIClient.CreateSecret(client.Ctx(), request)
 
 			if err != nil {
 				return grpcutil.ScrubGRPC(err)
@@ -1030,6 +1518,10 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 		}),
 	}
 	createSecret.Flags().StringVarP(&file, "file", "f", "", "File containing Kubernetes secret.")
+	createSecret.Flags().StringVar(&secretName, "name", "", "The name of a secret created from --from-literal values.")
+	createSecret.Flags().StringArrayVar(&literals, "from-literal", nil, "A key and value of the secret, as key=value. May be repeated.")
+	createSecret.Flags().StringVar(&secretPipeline, "pipeline", "", "Scope the secret to this pipeline.")
+	createSecret.Flags().BoolVar(&updateSecret, "update", false, "Replace the data of the secret if it already exists.")
 	commands = append(commands, cmdutil.CreateAlias(createSecret, "create secret"))
 
 	deleteSecret := &cobra.Command{
@@ -1096,10 +1588,11 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 			}
 			defer client.Close()
 
-			secretInfos, err := client.PpsAPIClient.ListSecret(
-				client.Ctx(),
-				&types.Empty{},
-			)
+			request := &ppsclient.ListSecretRequest{}
+			if secretPipeline != "" {
+				request.Pipeline = pachdclient.NewPipeline(secretPipeline)
+			}
+			secretInfos, err := client.PpsAPIClient.ListSecret(client.Ctx(), request)
 
 			if err != nil {
 				return grpcutil.ScrubGRPC(err)
@@ -1111,8 +1604,36 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 			return writer.Flush()
 		}),
 	}
+	listSecret.Flags().StringVar(&secretPipeline, "pipeline", "", "List only the secrets scoped to this pipeline.")
 	commands = append(commands, cmdutil.CreateAlias(listSecret, "list secret"))
 
+	listSLO := &cobra.Command{
+		Use:   "{{alias}} [<pipeline>]",
+		Short: "Return the status of pipelines' SLOs.",
+		Long:  "Return the current value of each of a pipeline's SLOs, or every pipeline's SLOs, and whether it's violated.",
+		Run: cmdutil.RunBoundedArgs(0, 1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			var pipelineName string
+			if len(args) > 0 {
+				pipelineName = args[0]
+			}
+			statuses, err := client.ListSLOStatus(pipelineName)
+			if err != nil {
+				return err
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.SLOStatusHeader)
+			for _, status := range statuses {
+				pretty.PrintSLOStatus(writer, status)
+			}
+			return writer.Flush()
+		}),
+	}
+	commands = append(commands, cmdutil.CreateAlias(listSLO, "list slo"))
+
 	var seed int64
 	runLoadTest := &cobra.Command{
 		Use:   "{{alias}} <spec-file> ",
@@ -1181,6 +1702,24 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 // TODO(msteffen) This is very similar to readConfigBytes in
 // s/s/identity/cmds/cmds.go (which differs only in not supporting URLs),
 // so the two could perhaps be refactored.
+// listDatumPage calls cb with each datum returned by a ListDatum request.
+func listDatumPage(client *pachdclient.APIClient, request *ppsclient.ListDatumRequest, cb func(*ppsclient.DatumInfo) error) error {
+	listDatumClient, err := client.PpsAPIClient.ListDatum(client.Ctx(), request)
+	if err != nil {
+		return grpcutil.ScrubGRPC(err)
+	}
+	return grpcutil.ScrubGRPC(clientsdk.ForEachDatumInfo(listDatumClient, cb))
+}
+
+// datumCursor returns the cursor of a ListDatum request listing the datums
+// after the datum with ID after, if it's set.
+func datumCursor(job *ppsclient.Job, after string) *ppsclient.Datum {
+	if after == "" {
+		return nil
+	}
+	return &ppsclient.Datum{Job: job, ID: after}
+}
+
 func readPipelineBytes(pipelinePath string) (pipelineBytes []byte, retErr error) {
 	if pipelinePath == "-" {
 		cmdutil.PrintStdinReminder()
@@ -1213,26 +1752,59 @@ func readPipelineBytes(pipelinePath string) (pipelineBytes []byte, retErr error)
 	return pipelineBytes, nil
 }
 
-func evaluateJsonnetTemplate(client *client.APIClient, jsonnetPath string, jsonnetArgs []string) ([]byte, error) {
+// evaluateJsonnetTemplate renders and validates a template, and returns the
+// rendered specs and the values of the template's parameters.
+func evaluateJsonnetTemplate(client *client.APIClient, jsonnetPath string, jsonnetArgs []string) ([]byte, map[string]string, error) {
 	templateBytes, err := readPipelineBytes(jsonnetPath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	args, err := pachtmpl.ParseArgs(jsonnetArgs)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	res, err := client.RenderTemplate(client.Ctx(), &ppsclient.RenderTemplateRequest{
 		Template: string(templateBytes),
 		Args:     args,
+		Validate: true,
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, grpcutil.ScrubGRPC(err)
 	}
-	return []byte(res.Json), nil
+	return []byte(res.Json), res.Parameters, nil
 }
 
-func pipelineHelper(reprocess bool, pushImages bool, registry, username, pipelinePath, jsonnetPath string, jsonnetArgs []string, update bool) error {
+// datumPreviewLimit is the number of datums 'create pipeline --dry-run' lists.
+const datumPreviewLimit = 10
+
+// logsHeartbeatInterval is how often 'logs -f' asks pachd for a heartbeat when
+// there are no new logs, so idle connections aren't closed by proxies.
+const logsHeartbeatInterval = 30 * time.Second
+
+// literalSecret returns the JSON encoding of a Kubernetes secret with the
+// key=value pairs in literals.
+func literalSecret(name string, literals []string) ([]byte, error) {
+	data := make(map[string]string)
+	for _, l := range literals {
+		kv := strings.SplitN(l, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, errors.Errorf("invalid literal %q, must be key=value", l)
+		}
+		data[kv[0]] = kv[1]
+	}
+	if len(data) == 0 {
+		return nil, errors.New("--name requires at least one --from-literal")
+	}
+	secret, err := json.Marshal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]string{"name": name},
+		"stringData": data,
+	})
+	return secret, errors.EnsureStack(err)
+}
+
+func pipelineHelper(reprocess bool, pushImages bool, registry, username, pipelinePath, jsonnetPath string, jsonnetArgs []string, update, dryRun, lint bool, canary float64) error {
 	// validate arguments
 	if pipelinePath != "" && jsonnetPath != "" {
 		return errors.New("cannot set both --file and --jsonnet; exactly one must be set")
@@ -1240,6 +1812,12 @@ func pipelineHelper(reprocess bool, pushImages bool, registry, username, pipelin
 	if pipelinePath == "" && jsonnetPath == "" {
 		pipelinePath = "-" // default input
 	}
+	if jsonnetPath == "" && len(jsonnetArgs) > 0 {
+		return errors.New("--arg and --set can only be used with --jsonnet")
+	}
+	if dryRun && pushImages {
+		return errors.New("cannot set both --dry-run and --push-images")
+	}
 	pc, err := pachdclient.NewOnUserMachine("user")
 	if err != nil {
 		return errors.Wrapf(err, "error connecting to pachd")
@@ -1247,10 +1825,11 @@ func pipelineHelper(reprocess bool, pushImages bool, registry, username, pipelin
 	defer pc.Close()
 	// read/compute pipeline spec(s) (file, stdin, url, or via template)
 	var pipelineBytes []byte
+	var templateParameters map[string]string
 	if pipelinePath != "" {
 		pipelineBytes, err = readPipelineBytes(pipelinePath)
 	} else if jsonnetPath != "" {
-		pipelineBytes, err = evaluateJsonnetTemplate(pc, jsonnetPath, jsonnetArgs)
+		pipelineBytes, templateParameters, err = evaluateJsonnetTemplate(pc, jsonnetPath, jsonnetArgs)
 	}
 	if err != nil {
 		return err
@@ -1284,6 +1863,12 @@ func pipelineHelper(reprocess bool, pushImages bool, registry, username, pipelin
 		if update {
 			request.Update = true
 			request.Reprocess = reprocess
+			if canary > 0 {
+				request.Canary = &ppsclient.CanarySpec{Fraction: canary}
+			}
+		}
+		if len(templateParameters) > 0 {
+			request.TemplateParameters = templateParameters
 		}
 
 		if pushImages {
@@ -1308,6 +1893,33 @@ func pipelineHelper(reprocess bool, pushImages bool, registry, username, pipelin
 						"'bash:latest' to 'bash:5'. This improves reproducibility of your pipelines.\n\n")
 			}
 		}
+		if lint {
+			findings, err := pc.PpsAPIClient.LintPipeline(pc.Ctx(), &ppsclient.LintPipelineRequest{Spec: request})
+			if err != nil {
+				return grpcutil.ScrubGRPC(err)
+			}
+			if err := pretty.PrintLintFindings(os.Stdout, request.Pipeline.Name, findings); err != nil {
+				return err
+			}
+			for _, finding := range findings.Findings {
+				if finding.Severity == ppsclient.LintSeverity_LINT_ERROR {
+					return errors.Errorf("pipeline %q has errors, fix them before creating it", request.Pipeline.Name)
+				}
+			}
+		}
+		if dryRun {
+			plan, err := pc.PpsAPIClient.PlanPipeline(pc.Ctx(), &ppsclient.PlanPipelineRequest{
+				Spec:       request,
+				DatumLimit: datumPreviewLimit,
+			})
+			if err != nil {
+				return grpcutil.ScrubGRPC(err)
+			}
+			if err := pretty.PrintPipelinePlan(os.Stdout, plan); err != nil {
+				return err
+			}
+			continue
+		}
 		if err = txncmds.WithActiveTransaction(pc, func(txClient *pachdclient.APIClient) error {
 			_, err := txClient.PpsAPIClient.CreatePipeline(
 				txClient.Ctx(),
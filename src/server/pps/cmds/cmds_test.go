@@ -892,6 +892,54 @@ func TestJsonnetPipelineTemplateError(t *testing.T) {
 		`).Run())
 }
 
+func TestJsonnetPipelineTemplateParameters(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	c, _ := minikubetestenv.AcquireCluster(t)
+	require.NoError(t, tu.PachctlBashCmd(t, c, `
+		yes | pachctl delete all
+	`).Run())
+	require.NoError(t, tu.PachctlBashCmd(t, c, `
+		pachctl create repo data
+		pachctl create pipeline --jsonnet - --set name=foo --set parallelism=2 <<EOF
+		function(name, output="out", parallelism=1) {
+		  pipeline: { name: name+"-pipeline" },
+		  input: {
+		    pfs: {
+		      name: "input",
+		      glob: "/*",
+		      repo: "data"
+		    }
+		  },
+		  parallelism_spec: {
+		    constant: std.parseInt(parallelism)
+		  },
+		  transform: {
+		    cmd: [ "/bin/bash" ],
+		    stdin: [ "cp /pfs/input/* /pfs/out/"+output ]
+		  }
+		}
+		EOF
+		pachctl inspect pipeline foo-pipeline \
+		  | match 'Template Parameters: name=foo, output=out, parallelism=2'
+
+		# an invalid spec creates no pipeline
+		set +e +o pipefail
+		createpipeline_stderr="$(
+		  pachctl create pipeline --jsonnet - --set name=bar 2>&1 >/dev/null <<EOF
+		function(name) {
+		  pipeline: { name: name+"-pipeline" },
+		  input: { pfs: { glob: "/*", repo: "data" } },
+		}
+		EOF
+		  )"
+		set -e -o pipefail
+		echo "${createpipeline_stderr}" | match 'pipeline must specify a transform'
+		pachctl list pipeline | match -v bar-pipeline
+		`).Run())
+}
+
 func TestJobDatumCount(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
@@ -0,0 +1,85 @@
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// dagNodeLabel is the label of a node in a drawing of a DAG: a repo's name,
+// or a pipeline's name and state.
+func dagNodeLabel(node *pps.DAGNode) string {
+	if node.Type != pps.DAGNode_PIPELINE {
+		return node.Name
+	}
+	state := strings.ToLower(strings.TrimPrefix(node.State.String(), "PIPELINE_"))
+	if node.LastJobState == pps.JobState_JOB_STATE_UNKNOWN {
+		return fmt.Sprintf("%s\\n%s", node.Name, state)
+	}
+	job := strings.ReplaceAll(strings.ToLower(strings.TrimPrefix(node.LastJobState.String(), "JOB_")), "_", " ")
+	return fmt.Sprintf("%s\\n%s / %s", node.Name, state, job)
+}
+
+// dagEdgeLabel is the label of an edge in a drawing of a DAG, which is only
+// set if the edge isn't on the master branch.
+func dagEdgeLabel(edge *pps.DAGEdge) string {
+	if edge.Branch == "master" {
+		return ""
+	}
+	return edge.Branch
+}
+
+// WriteDAGDot writes a DAG in Graphviz's DOT language, with repos drawn as
+// cylinders and pipelines as boxes.
+func WriteDAGDot(w io.Writer, dag *pps.DAG) error {
+	var b strings.Builder
+	b.WriteString("digraph pachyderm {\n")
+	b.WriteString("  rankdir=LR;\n")
+	for _, node := range dag.Nodes {
+		shape := "cylinder"
+		if node.Type == pps.DAGNode_PIPELINE {
+			shape = "box"
+		}
+		fmt.Fprintf(&b, "  %q [label=\"%s\", shape=%s];\n", node.ID, dagNodeLabel(node), shape)
+	}
+	for _, edge := range dag.Edges {
+		if label := dagEdgeLabel(edge); label != "" {
+			fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", edge.From, edge.To, label)
+		} else {
+			fmt.Fprintf(&b, "  %q -> %q;\n", edge.From, edge.To)
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return errors.EnsureStack(err)
+}
+
+// WriteDAGMermaid writes a DAG as a Mermaid flowchart, with repos drawn as
+// cylinders and pipelines as boxes.
+func WriteDAGMermaid(w io.Writer, dag *pps.DAG) error {
+	// node ids contain characters Mermaid doesn't allow in its ids
+	ids := make(map[string]string)
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	for i, node := range dag.Nodes {
+		ids[node.ID] = fmt.Sprintf("n%d", i)
+		label := strings.ReplaceAll(dagNodeLabel(node), "\\n", "<br>")
+		if node.Type == pps.DAGNode_PIPELINE {
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[node.ID], label)
+		} else {
+			fmt.Fprintf(&b, "  %s[(\"%s\")]\n", ids[node.ID], label)
+		}
+	}
+	for _, edge := range dag.Edges {
+		if label := dagEdgeLabel(edge); label != "" {
+			fmt.Fprintf(&b, "  %s -->|%s| %s\n", ids[edge.From], label, ids[edge.To])
+		} else {
+			fmt.Fprintf(&b, "  %s --> %s\n", ids[edge.From], ids[edge.To])
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return errors.EnsureStack(err)
+}
@@ -5,8 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	units "github.com/docker/go-units"
 	"github.com/fatih/color"
@@ -30,8 +33,16 @@ const (
 	JobSetHeader = "ID\tSUBJOBS\tPROGRESS\tCREATED\tMODIFIED\n"
 	// DatumHeader is the header for datums
 	DatumHeader = "ID\tFILES\tSTATUS\tTIME\t\n"
+	// DatumCountsHeader is the header for the datum counts of a job
+	DatumCountsHeader = "TOTAL\tSUCCESS\tSKIPPED\tFAILED\tRECOVERED\tQUARANTINED\t\n"
+	// DatumProfileHeader is the header for the slowest datums of a job profile
+	DatumProfileHeader = "ID\tSTATE\tPROCESS TIME\tUSER CPU\tSYSTEM CPU\tMAX MEMORY\tDL\tUL\t\n"
 	// SecretHeader is the header for secrets
-	SecretHeader = "NAME\tTYPE\tCREATED\t\n"
+	SecretHeader = "NAME\tTYPE\tCREATED\tPIPELINE\t\n"
+	// SLOStatusHeader is the header for SLO statuses
+	SLOStatusHeader = "PIPELINE\tSLO\tOBJECTIVE\tVALUE\tJOBS\tSTATUS\t\n"
+	// LineageHeader is the header for the commits of a lineage
+	LineageHeader = "DEPTH\tCOMMIT\tORIGIN\tPIPELINE VERSION\tPRODUCED FROM\t\n"
 	// jobReasonLen is the amount of the job reason that we print
 	jobReasonLen = 25
 )
@@ -131,7 +142,7 @@ func PrintPipelineInfo(w io.Writer, pipelineInfo *ppsclient.PipelineInfo, fullTi
 		fmt.Fprint(w, "-\t")
 		fmt.Fprint(w, "-\t")
 		fmt.Fprint(w, "-\t")
-		fmt.Fprintf(w, "%s / %s\t", pipelineState(pipelineInfo.State), JobState(pipelineInfo.LastJobState))
+		fmt.Fprintf(w, "%s / %s\t", cordonedPipelineState(pipelineInfo), JobState(pipelineInfo.LastJobState))
 		fmt.Fprint(w, "pipeline details unavailable\t")
 	} else {
 		fmt.Fprintf(w, "%s\t", pipelineInfo.Pipeline.Name)
@@ -142,7 +153,7 @@ func PrintPipelineInfo(w io.Writer, pipelineInfo *ppsclient.PipelineInfo, fullTi
 		} else {
 			fmt.Fprintf(w, "%s\t", pretty.Ago(pipelineInfo.Details.CreatedAt))
 		}
-		fmt.Fprintf(w, "%s / %s\t", pipelineState(pipelineInfo.State), JobState(pipelineInfo.LastJobState))
+		fmt.Fprintf(w, "%s / %s\t", cordonedPipelineState(pipelineInfo), JobState(pipelineInfo.LastJobState))
 		fmt.Fprintf(w, "%s\t", pipelineInfo.Details.Description)
 	}
 	fmt.Fprintln(w)
@@ -199,15 +210,24 @@ Reason: {{.Reason}}
 Processed: {{.DataProcessed}}
 Failed: {{.DataFailed}}
 Skipped: {{.DataSkipped}}
-Recovered: {{.DataRecovered}}
+Recovered: {{.DataRecovered}}{{if .DataQuarantined}}
+Quarantined: {{.DataQuarantined}}{{end}}{{if or .DataRestored .DataRecomputed}}
+Restored From Checkpoints: {{.DataRestored}}
+Recomputed: {{.DataRecomputed}}{{end}}
 Total: {{.DataTotal}}
 Data Downloaded: {{prettySize .Stats.DownloadBytes}}
-Data Uploaded: {{prettySize .Stats.UploadBytes}}
+Data Uploaded: {{prettySize .Stats.UploadBytes}}{{if .EgressBytes}}
+Data Egressed: {{prettySize .EgressBytes}}{{end}}
 Download Time: {{prettyDuration .Stats.DownloadTime}}
 Process Time: {{prettyDuration .Stats.ProcessTime}}
-Upload Time: {{prettyDuration .Stats.UploadTime}}
+Upload Time: {{prettyDuration .Stats.UploadTime}}{{if .Stats.GpuTime}}
+GPU Utilization: {{gpuUtilization .Stats}}{{end}}
 Datum Timeout: {{.Details.DatumTimeout}}
-Job Timeout: {{.Details.JobTimeout}}
+Job Timeout: {{.Details.JobTimeout}}{{if .Details.Priority}}
+Priority: {{.Details.Priority}}{{end}}{{if .Details.DatumRetryPolicy}}
+Datum Retry Policy: {{datumRetryPolicy .Details.DatumRetryPolicy}}{{end}}{{if .Details.Budget}}
+Budget: {{jobBudget .Details.Budget}}
+Usage: {{jobUsage .JobInfo}}{{end}}
 Worker Status:
 {{workerStatus .}}Restarts: {{.Restart}}
 ParallelismSpec: {{.Details.ParallelismSpec}}
@@ -219,7 +239,9 @@ ParallelismSpec: {{.Details.ParallelismSpec}}
   Memory: {{ .Details.ResourceLimits.Memory }}
   {{ if .Details.ResourceLimits.Gpu }}GPU:
     Type: {{ .Details.ResourceLimits.Gpu.Type }}
-    Number: {{ .Details.ResourceLimits.Gpu.Number }} {{end}} {{end}}
+    Number: {{ .Details.ResourceLimits.Gpu.Number }}{{ if .Details.ResourceLimits.Gpu.MigProfile }}
+    MIG Profile: {{ .Details.ResourceLimits.Gpu.MigProfile }}{{end}}{{ if .Details.ResourceLimits.Gpu.Fraction }}
+    Fraction: {{ .Details.ResourceLimits.Gpu.Fraction }}{{end}} {{end}} {{end}}
 {{ if .Details.SidecarResourceLimits }}SidecarResourceLimits:
   CPU: {{ .Details.SidecarResourceLimits.Cpu }}
   Memory: {{ .Details.SidecarResourceLimits.Memory }} {{end}}
@@ -256,14 +278,25 @@ func NewPrintablePipelineInfo(pi *ppsclient.PipelineInfo) *PrintablePipelineInfo
 func PrintDetailedPipelineInfo(w io.Writer, pipelineInfo *PrintablePipelineInfo) error {
 	template, err := template.New("PipelineInfo").Funcs(funcMap).Parse(
 		`Name: {{.Pipeline.Name}}{{if .Details.Description}}
-Description: {{.Details.Description}}{{end}}{{if .FullTimestamps }}
+Description: {{.Details.Description}}{{end}}{{if .Details.Project}}
+Project: {{.Details.Project.Name}}{{end}}{{if .FullTimestamps }}
 Created: {{.Details.CreatedAt}}{{ else }}
 Created: {{prettyAgo .Details.CreatedAt}} {{end}}
 State: {{pipelineState .State}}
 Reason: {{.Reason}}
 Workers Available: {{.Details.WorkersAvailable}}/{{.Details.WorkersRequested}}
-Stopped: {{ .Stopped }}
-Parallelism Spec: {{.Details.ParallelismSpec}}
+Stopped: {{ .Stopped }}{{if .Drain}}
+Drain: {{drain .Drain}}{{end}}{{if .Cordon}}
+Cordoned: {{cordon .Cordon}}{{end}}{{if .Details.SchedulingSpec.GetPreemptible}}
+Scheduling: {{preemptibleScheduling .Details.SchedulingSpec.Preemptible .SchedulingStatus}}{{end}}
+Parallelism Spec: {{.Details.ParallelismSpec}}{{if .Details.DatumAutoscaling}}
+Datum Autoscaling: {{datumAutoscaling .Details.DatumAutoscaling}}{{end}}{{if .Details.KubernetesJobs}}
+Kubernetes Jobs: true{{end}}{{if .Details.DatumCache}}
+Datum Cache: true{{end}}{{if .Details.Readahead}}
+Readahead: {{readahead .Details.Readahead}}{{end}}{{if .Details.ModelRegistry}}
+Model Registry: {{modelRegistry .Pipeline.Name .Details.ModelRegistry}}{{end}}{{if .Details.Build}}
+Build: {{build .Details.Build}}{{end}}{{if .Details.Executor}}{{if .Details.Executor.Argo}}
+Executor: argo ({{.Details.Executor.Argo.WorkflowTemplate}}){{end}}{{end}}
 {{ if .Details.ResourceRequests }}ResourceRequests:
   CPU: {{ .Details.ResourceRequests.Cpu }}
   Memory: {{ .Details.ResourceRequests.Memory }} {{end}}
@@ -272,15 +305,29 @@ Parallelism Spec: {{.Details.ParallelismSpec}}
   Memory: {{ .Details.ResourceLimits.Memory }}
   {{ if .Details.ResourceLimits.Gpu }}GPU:
     Type: {{ .Details.ResourceLimits.Gpu.Type }} 
-    Number: {{ .Details.ResourceLimits.Gpu.Number }} {{end}} {{end}}
+    Number: {{ .Details.ResourceLimits.Gpu.Number }}{{ if .Details.ResourceLimits.Gpu.MigProfile }}
+    MIG Profile: {{ .Details.ResourceLimits.Gpu.MigProfile }}{{end}}{{ if .Details.ResourceLimits.Gpu.Fraction }}
+    Fraction: {{ .Details.ResourceLimits.Gpu.Fraction }}{{end}} {{end}} {{end}}
 Datum Timeout: {{.Details.DatumTimeout}}
-Job Timeout: {{.Details.JobTimeout}}
+Job Timeout: {{.Details.JobTimeout}}{{if .Details.Priority}}
+Priority: {{.Details.Priority}}{{end}}{{if .Details.DatumRetryPolicy}}
+Datum Retry Policy: {{datumRetryPolicy .Details.DatumRetryPolicy}}{{end}}{{if .Details.Budget}}
+Budget: {{jobBudget .Details.Budget}}{{end}}{{if .Details.NetworkPolicy}}
+Network Policy: {{networkPolicy .Details.NetworkPolicy}}{{end}}{{if .Details.Canary}}
+Canary: {{canary .Details.Canary}}{{end}}{{if .Details.RollbackPolicy}}
+Rollback Policy: {{rollbackPolicy .Details.RollbackPolicy}}{{end}}{{if .Rollback}}
+Rolled Back: {{rollback .Rollback}}{{end}}{{if .Details.SLOs}}
+SLOs: {{slos .Details.SLOs}}{{end}}{{if .SLOViolations}}
+SLO Violations: {{sloViolations .SLOViolations}}{{end}}{{if .Details.TemplateParameters}}
+Template Parameters: {{templateParameters .Details.TemplateParameters}}{{end}}
 Input:
 {{pipelineInput .PipelineInfo}}
 Output Branch: {{.Details.OutputBranch}}
 Transform:
 {{prettyTransform .Details.Transform}}
-{{ if .Details.Egress }}Egress: {{egress .Details.Egress}} {{end}}
+{{ if .Details.InitContainers }}Init Containers: {{containers .Details.InitContainers}}
+{{end}}{{ if .Details.Sidecars }}Sidecars: {{containers .Details.Sidecars}}
+{{end}}{{ if .Details.Egress }}Egress: {{egress .Details.Egress}} {{end}}
 {{if .Details.RecentError}} Recent Error: {{.Details.RecentError}} {{end}}
 `)
 	if err != nil {
@@ -289,6 +336,160 @@ Transform:
 	return errors.EnsureStack(template.Execute(w, pipelineInfo))
 }
 
+// PrintPipelinePlan pretty-prints what creating or updating a pipeline would
+// do.
+func PrintPipelinePlan(w io.Writer, plan *ppsclient.PipelinePlan) error {
+	template, err := template.New("PipelinePlan").Funcs(funcMap).Parse(
+		`Pipeline: {{.Pipeline.Name}} ({{if .Exists}}update{{else}}create{{end}})
+Workers: {{.Workers}}{{if .ResourceRequests}}
+Resource Requests: {{resources .ResourceRequests}}{{end}}{{if .ResourceLimits}}
+Resource Limits: {{resources .ResourceLimits}}{{end}}{{if .SidecarResourceLimits}}
+Sidecar Resource Limits: {{resources .SidecarResourceLimits}}{{end}}
+Datums: {{.Datums}}{{if .DatumPreview}}
+Datum Preview:{{range .DatumPreview}}
+  {{datumFiles .}}{{end}}{{if lt (len .DatumPreview) .Datums}}
+  ...{{end}}{{end}}{{if .Warnings}}
+Warnings:{{range .Warnings}}
+  {{.}}{{end}}{{end}}
+`)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	return errors.EnsureStack(template.Execute(w, plan))
+}
+
+// PrintLintFindings pretty-prints the findings of linting a pipeline's spec.
+func PrintLintFindings(w io.Writer, pipeline string, lint *ppsclient.LintPipelineResponse) error {
+	if len(lint.Findings) == 0 {
+		_, err := fmt.Fprintf(w, "Pipeline %s: no problems found\n", pipeline)
+		return errors.EnsureStack(err)
+	}
+	template, err := template.New("LintFindings").Funcs(funcMap).Parse(
+		`Pipeline {{.Pipeline}}:{{range .Findings}}
+  {{lintSeverity .Severity}} [{{.Rule}}]{{if .Field}} {{.Field}}:{{end}} {{.Message}}{{if .Suggestion}}
+    Suggestion: {{.Suggestion}}{{end}}{{end}}
+`)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	return errors.EnsureStack(template.Execute(w, struct {
+		Pipeline string
+		Findings []*ppsclient.LintFinding
+	}{pipeline, lint.Findings}))
+}
+
+func lintSeverity(severity ppsclient.LintSeverity) string {
+	switch severity {
+	case ppsclient.LintSeverity_LINT_ERROR:
+		return color.New(color.FgRed).SprintFunc()("ERROR")
+	case ppsclient.LintSeverity_LINT_WARNING:
+		return color.New(color.FgYellow).SprintFunc()("WARNING")
+	default:
+		return "INFO"
+	}
+}
+
+// PrintJobProfile pretty-prints the resource usage of a job's datums.
+func PrintJobProfile(w io.Writer, profile *ppsclient.JobProfile) error {
+	template, err := template.New("JobProfile").Funcs(funcMap).Parse(
+		`Job: {{.Job.Pipeline.Name}}@{{.Job.ID}}
+Datums: {{.Aggregate.ProcessTime.Count}}
+Total Process Time: {{exactDuration .Stats.ProcessTime}}
+Total User CPU Time: {{exactDuration .Stats.UserCpuTime}}
+Total System CPU Time: {{exactDuration .Stats.SystemCpuTime}}
+Max Memory: {{prettySize .Stats.MaxRSS}}
+Per Datum:
+  Process Time: {{aggregateSeconds .Aggregate.ProcessTime}}
+  User CPU Time: {{aggregateSeconds .Aggregate.UserCpuTime}}
+  System CPU Time: {{aggregateSeconds .Aggregate.SystemCpuTime}}
+  Download Time: {{aggregateSeconds .Aggregate.DownloadTime}}
+  Upload Time: {{aggregateSeconds .Aggregate.UploadTime}}
+  Max Memory: {{aggregateBytes .Aggregate.MaxRSS}}
+  Downloaded: {{aggregateBytes .Aggregate.DownloadBytes}}
+  Uploaded: {{aggregateBytes .Aggregate.UploadBytes}}
+`)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if err := template.Execute(w, profile); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if len(profile.Slowest) == 0 {
+		return nil
+	}
+	fmt.Fprintln(w, "Slowest Datums:")
+	tw := ansiterm.NewTabWriter(w, 0, 1, 2, ' ', 0)
+	fmt.Fprint(tw, DatumProfileHeader)
+	for _, d := range profile.Slowest {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n",
+			d.Datum.ID,
+			datumState(d.State),
+			exactDuration(d.Stats.ProcessTime),
+			exactDuration(d.Stats.UserCpuTime),
+			exactDuration(d.Stats.SystemCpuTime),
+			pretty.Size(d.Stats.MaxRSS),
+			pretty.Size(d.Stats.DownloadBytes),
+			pretty.Size(d.Stats.UploadBytes),
+		)
+	}
+	return errors.EnsureStack(tw.Flush())
+}
+
+// exactDuration prints a duration to the millisecond, unlike
+// pretty.Duration, which rounds to a human readable approximation.
+func exactDuration(d *types.Duration) string {
+	duration, err := types.DurationFromProto(d)
+	if d == nil || err != nil {
+		return "-"
+	}
+	return duration.Round(time.Millisecond).String()
+}
+
+// aggregateSeconds prints the distribution of a stat measured in seconds.
+func aggregateSeconds(agg *ppsclient.Aggregate) string {
+	seconds := func(s float64) string {
+		return time.Duration(s * float64(time.Second)).Round(time.Millisecond).String()
+	}
+	return fmt.Sprintf("mean %s, stddev %s, p5 %s, p95 %s", seconds(agg.Mean), seconds(agg.Stddev), seconds(agg.FifthPercentile), seconds(agg.NinetyFifthPercentile))
+}
+
+// aggregateBytes prints the distribution of a stat measured in bytes.
+func aggregateBytes(agg *ppsclient.Aggregate) string {
+	bytes := func(b float64) string { return pretty.Size(int64(b)) }
+	return fmt.Sprintf("mean %s, stddev %s, p5 %s, p95 %s", bytes(agg.Mean), bytes(agg.Stddev), bytes(agg.FifthPercentile), bytes(agg.NinetyFifthPercentile))
+}
+
+func resources(spec *ppsclient.ResourceSpec) string {
+	var resources []string
+	if spec.Cpu > 0 {
+		resources = append(resources, fmt.Sprintf("%g CPU", spec.Cpu))
+	}
+	if spec.Memory != "" {
+		resources = append(resources, fmt.Sprintf("%s memory", spec.Memory))
+	}
+	if spec.Disk != "" {
+		resources = append(resources, fmt.Sprintf("%s disk", spec.Disk))
+	}
+	if gpu := spec.Gpu; gpu != nil {
+		switch {
+		case gpu.MigProfile != "":
+			number := gpu.Number
+			if number == 0 {
+				number = 1
+			}
+			resources = append(resources, fmt.Sprintf("%d MIG %s", number, gpu.MigProfile))
+		case gpu.Fraction > 0:
+			resources = append(resources, fmt.Sprintf("%g of a time-sliced GPU", gpu.Fraction))
+		case gpu.Number > 0:
+			resources = append(resources, fmt.Sprintf("%d %s", gpu.Number, gpu.Type))
+		}
+	}
+	if len(resources) == 0 {
+		return "none"
+	}
+	return strings.Join(resources, ", ")
+}
+
 // PrintDatumInfo pretty-prints file info.
 // If recurse is false and directory size is 0, display "-" instead
 // If fast is true and file size is 0, display "-" instead
@@ -304,6 +505,11 @@ func PrintDatumInfo(w io.Writer, datumInfo *ppsclient.DatumInfo) {
 	fmt.Fprintln(w)
 }
 
+// PrintDatumCounts pretty-prints the datum counts of a job.
+func PrintDatumCounts(w io.Writer, counts *ppsclient.DatumCounts) {
+	fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%d\t%d\t\n", counts.Total, counts.Success, counts.Skipped, counts.Failed, counts.Recovered, counts.Quarantined)
+}
+
 func datumFiles(datumInfo *ppsclient.DatumInfo) string {
 	builder := &strings.Builder{}
 	for i, fi := range datumInfo.Data {
@@ -315,6 +521,28 @@ func datumFiles(datumInfo *ppsclient.DatumInfo) string {
 	return builder.String()
 }
 
+// PrintLineage pretty-prints the commits of a lineage, along with the commits
+// each of them was produced from.
+func PrintLineage(w io.Writer, lineage *ppsclient.Lineage) {
+	producedFrom := make(map[string][]string)
+	for _, edge := range lineage.Edges {
+		to := pfspretty.CompactPrintCommit(edge.To)
+		producedFrom[to] = append(producedFrom[to], pfspretty.CompactPrintCommit(edge.From))
+	}
+	for _, node := range lineage.Nodes {
+		commit := pfspretty.CompactPrintCommit(node.Commit)
+		version := "-"
+		if node.Pipeline != nil {
+			version = fmt.Sprintf("%s v%d", node.Pipeline.Name, node.PipelineVersion)
+		}
+		from := "-"
+		if len(producedFrom[commit]) > 0 {
+			from = strings.Join(producedFrom[commit], ", ")
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t\n", node.Depth, commit, strings.ToLower(node.Origin.String()), version, from)
+	}
+}
+
 // PrintDetailedDatumInfo pretty-prints detailed info about a datum
 func PrintDetailedDatumInfo(w io.Writer, datumInfo *ppsclient.DatumInfo) {
 	fmt.Fprintf(w, "ID\t%s\n", datumInfo.Datum.ID)
@@ -370,7 +598,26 @@ func PrintDetailedDatumInfo(w io.Writer, datumInfo *ppsclient.DatumInfo) {
 
 // PrintSecretInfo pretty-prints secret info.
 func PrintSecretInfo(w io.Writer, secretInfo *ppsclient.SecretInfo) {
-	fmt.Fprintf(w, "%s\t%s\t%s\t\n", secretInfo.Secret.Name, secretInfo.Type, pretty.Ago(secretInfo.CreationTimestamp))
+	pipeline := "-"
+	if secretInfo.Pipeline != nil {
+		pipeline = secretInfo.Pipeline.Name
+	}
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n", secretInfo.Secret.Name, secretInfo.Type, pretty.Ago(secretInfo.CreationTimestamp), pipeline)
+}
+
+// PrintSLOStatus pretty-prints the status of an SLO.
+func PrintSLOStatus(w io.Writer, status *ppsclient.SLOStatus) {
+	fmt.Fprintf(w, "%s\t", status.Pipeline.Name)
+	fmt.Fprintf(w, "%s\t", status.SLO.Name)
+	fmt.Fprintf(w, "%s\t", sloObjective(status.SLO))
+	fmt.Fprintf(w, "%s\t", sloValue(status))
+	fmt.Fprintf(w, "%d\t", status.Jobs)
+	if status.Violated {
+		fmt.Fprintf(w, "violated since %s\t", pretty.Ago(status.Since))
+	} else {
+		fmt.Fprint(w, "ok\t")
+	}
+	fmt.Fprintln(w)
 }
 
 // PrintFileHeader prints the header for a pfs file.
@@ -391,6 +638,8 @@ func datumState(datumState ppsclient.DatumState) string {
 		return color.New(color.FgRed).SprintFunc()("failed")
 	case ppsclient.DatumState_RECOVERED:
 		return color.New(color.FgYellow).SprintFunc()("recovered")
+	case ppsclient.DatumState_QUARANTINED:
+		return color.New(color.FgRed).SprintFunc()("quarantined")
 	case ppsclient.DatumState_SUCCESS:
 		return color.New(color.FgGreen).SprintFunc()("success")
 	case ppsclient.DatumState_UNKNOWN:
@@ -418,6 +667,8 @@ func JobState(jobState ppsclient.JobState) string {
 		return color.New(color.FgYellow).SprintFunc()("finishing")
 	case ppsclient.JobState_JOB_UNRUNNABLE:
 		return color.New(color.FgRed).SprintFunc()("unrunnable")
+	case ppsclient.JobState_JOB_BUDGET_EXCEEDED:
+		return color.New(color.FgRed).SprintFunc()("budget exceeded")
 
 	}
 	return "-"
@@ -451,6 +702,15 @@ func pipelineState(pipelineState ppsclient.PipelineState) string {
 	return "-"
 }
 
+// cordonedPipelineState renders the pipeline's state, noting whether it's
+// cordoned.
+func cordonedPipelineState(pipelineInfo *ppsclient.PipelineInfo) string {
+	if pipelineInfo.Cordon != nil {
+		return pipelineState(pipelineInfo.State) + color.New(color.FgYellow).SprintFunc()(" (cordoned)")
+	}
+	return pipelineState(pipelineInfo.State)
+}
+
 func jobInput(pji PrintableJobInfo) string {
 	if pji.Details.Input == nil {
 		return ""
@@ -542,17 +802,264 @@ func egress(e *ppsclient.Egress) string {
 	return string(s)
 }
 
+func jobBudget(budget *ppsclient.JobBudget) string {
+	var limits []string
+	if budget.MaxWorkerHours > 0 {
+		limits = append(limits, fmt.Sprintf("%g worker hours", budget.MaxWorkerHours))
+	}
+	if budget.MaxCpuHours > 0 {
+		limits = append(limits, fmt.Sprintf("%g CPU hours", budget.MaxCpuHours))
+	}
+	if budget.MaxGpuHours > 0 {
+		limits = append(limits, fmt.Sprintf("%g GPU hours", budget.MaxGpuHours))
+	}
+	if budget.MaxCost > 0 {
+		limits = append(limits, fmt.Sprintf("cost %g at %g per worker hour", budget.MaxCost, budget.WorkerHourCost))
+	}
+	if len(limits) == 0 {
+		return "none"
+	}
+	return strings.Join(limits, ", ")
+}
+
+func datumRetryPolicy(policy *ppsclient.DatumRetryPolicy) string {
+	var parts []string
+	if policy.Backoff != nil {
+		backoff := pretty.Duration(policy.Backoff)
+		if policy.MaxBackoff != nil {
+			backoff += " up to " + pretty.Duration(policy.MaxBackoff)
+		}
+		parts = append(parts, "backoff "+backoff)
+	}
+	if policy.NoRetryOnTimeout {
+		parts = append(parts, "no retry on timeout")
+	}
+	if policy.Quarantine {
+		parts = append(parts, "quarantine")
+	}
+	if len(parts) == 0 {
+		return "default"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func datumAutoscaling(autoscaling *ppsclient.DatumAutoscaling) string {
+	return fmt.Sprintf("%d-%d workers, target completion %v", autoscaling.MinWorkers, autoscaling.MaxWorkers, autoscaling.TargetCompletionDuration())
+}
+
+func readahead(readahead *ppsclient.Readahead) string {
+	return fmt.Sprintf("%d files, up to %s", readahead.Files, pretty.Size(readahead.SizeBytesOrDefault()))
+}
+
+func modelRegistry(pipeline string, registry *ppsclient.ModelRegistry) string {
+	return fmt.Sprintf("%s in %s (experiment %s)", registry.Model, registry.Url, registry.ExperimentOrDefault(pipeline))
+}
+
+func build(build *ppsclient.Build) string {
+	dockerfile := build.Dockerfile
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+	return fmt.Sprintf("%s, pushed to %s", dockerfile, build.Destination)
+}
+
+func drain(drain *ppsclient.Drain) string {
+	state := "in progress"
+	if drain.State == ppsclient.DrainState_DRAIN_COMPLETE {
+		state = "complete"
+	}
+	return fmt.Sprintf("%s, %d jobs checkpointed", state, len(drain.Jobs))
+}
+
+func preemptibleScheduling(spec *ppsclient.PreemptibleScheduling, status *ppsclient.SchedulingStatus) string {
+	if status.GetOnDemand() {
+		return fmt.Sprintf("on-demand, after %d preemptions", status.Preemptions)
+	}
+	result := fmt.Sprintf("preemptible, %d preemptions", status.GetPreemptions())
+	if spec.FallbackAfterPreemptions > 0 {
+		result += fmt.Sprintf(" (on-demand after %d)", spec.FallbackAfterPreemptions)
+	}
+	return result
+}
+
+func canary(spec *ppsclient.CanarySpec) string {
+	return fmt.Sprintf("%g%% of datums", spec.Fraction*100)
+}
+
+func rollbackPolicy(policy *ppsclient.RollbackPolicy) string {
+	var conditions []string
+	if policy.OnJobFailure {
+		conditions = append(conditions, "a job fails")
+	}
+	if policy.MaxDatumFailureRate > 0 {
+		conditions = append(conditions, fmt.Sprintf("more than %g%% of a job's datums fail", policy.MaxDatumFailureRate*100))
+	}
+	jobs := policy.Jobs
+	if jobs == 0 {
+		jobs = 1
+	}
+	return fmt.Sprintf("roll back if %s in the first %d jobs of an update", strings.Join(conditions, " or "), jobs)
+}
+
+func rollback(rollback *ppsclient.PipelineRollback) string {
+	return fmt.Sprintf("from version %d to the spec of version %d: %s", rollback.FromVersion, rollback.ToVersion, rollback.Reason)
+}
+
+func sloWindow(slo *ppsclient.SLO) time.Duration {
+	if slo.Window == nil {
+		return 24 * time.Hour
+	}
+	window, _ := types.DurationFromProto(slo.Window)
+	return window
+}
+
+func sloObjective(slo *ppsclient.SLO) string {
+	switch {
+	case slo.MaxJobDuration != nil:
+		percentile := slo.JobDurationPercentile
+		if percentile == 0 {
+			percentile = 95
+		}
+		max, _ := types.DurationFromProto(slo.MaxJobDuration)
+		return fmt.Sprintf("p%g job duration <= %v over %v", percentile, max, sloWindow(slo))
+	case slo.MaxCommitInterval != nil:
+		max, _ := types.DurationFromProto(slo.MaxCommitInterval)
+		return fmt.Sprintf("a job succeeds every %v", max)
+	default:
+		return fmt.Sprintf("failure rate <= %g%% over %v", slo.MaxFailureRate*100, sloWindow(slo))
+	}
+}
+
+func sloValue(status *ppsclient.SLOStatus) string {
+	if status.SLO.MaxFailureRate != 0 {
+		if status.Jobs == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.1f%%", status.Value*100)
+	}
+	if status.Value == 0 {
+		return "-"
+	}
+	return time.Duration(status.Value * float64(time.Second)).Round(time.Second).String()
+}
+
+func slos(slos []*ppsclient.SLO) string {
+	var parts []string
+	for _, slo := range slos {
+		parts = append(parts, fmt.Sprintf("%s (%s)", slo.Name, sloObjective(slo)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func sloViolations(violations []*ppsclient.SLOViolation) string {
+	var parts []string
+	for _, violation := range violations {
+		parts = append(parts, fmt.Sprintf("%s since %s: %s", violation.SLO, pretty.Ago(violation.Since), violation.Reason))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func networkPolicy(spec *ppsclient.NetworkPolicySpec) string {
+	if len(spec.Egress) == 0 {
+		return "no egress"
+	}
+	var endpoints []string
+	for _, endpoint := range spec.Egress {
+		name := endpoint.Name
+		if name == "" {
+			name = endpoint.Cidr
+		}
+		if name == "" {
+			name = "pods"
+		}
+		if len(endpoint.Ports) > 0 {
+			var ports []string
+			for _, port := range endpoint.Ports {
+				ports = append(ports, strconv.Itoa(int(port)))
+			}
+			name += ":" + strings.Join(ports, ",")
+		}
+		endpoints = append(endpoints, name)
+	}
+	return "egress to " + strings.Join(endpoints, ", ")
+}
+
+func containers(specs []*ppsclient.ContainerSpec) string {
+	var parts []string
+	for _, spec := range specs {
+		parts = append(parts, fmt.Sprintf("%s (%s)", spec.Name, spec.Image))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func jobUsage(jobInfo *ppsclient.JobInfo) string {
+	usage := ppsclient.GetJobUsage(jobInfo)
+	s := fmt.Sprintf("%.2f worker hours, %.2f CPU hours, %.2f GPU hours", usage.WorkerHours, usage.CPUHours, usage.GPUHours)
+	if jobInfo.Details.Budget.GetWorkerHourCost() > 0 {
+		s += fmt.Sprintf(", cost %.2f", usage.Cost)
+	}
+	return s
+}
+
+// gpuUtilization returns the utilization of a job's GPUs while it processed
+// datums.
+func gpuUtilization(stats *ppsclient.ProcessStats) string {
+	gpuTime, err := types.DurationFromProto(stats.GpuTime)
+	if err != nil {
+		return "-"
+	}
+	processTime, err := types.DurationFromProto(stats.ProcessTime)
+	if err != nil || processTime <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", 100*gpuTime.Seconds()/processTime.Seconds())
+}
+
+func templateParameters(parameters map[string]string) string {
+	var params []string
+	for name, value := range parameters {
+		params = append(params, fmt.Sprintf("%s=%s", name, value))
+	}
+	sort.Strings(params)
+	return strings.Join(params, ", ")
+}
+
 var funcMap = template.FuncMap{
-	"pipelineState":        pipelineState,
-	"jobState":             JobState,
-	"datumState":           datumState,
-	"workerStatus":         workerStatus,
-	"pipelineInput":        pipelineInput,
-	"jobInput":             jobInput,
-	"prettyAgo":            pretty.Ago,
-	"prettyTimeDifference": pretty.TimeDifference,
-	"prettyDuration":       pretty.Duration,
-	"prettySize":           pretty.Size,
-	"prettyTransform":      prettyTransform,
-	"egress":               egress,
+	"pipelineState":         pipelineState,
+	"jobState":              JobState,
+	"datumState":            datumState,
+	"workerStatus":          workerStatus,
+	"pipelineInput":         pipelineInput,
+	"jobInput":              jobInput,
+	"prettyAgo":             pretty.Ago,
+	"prettyTimeDifference":  pretty.TimeDifference,
+	"prettyDuration":        pretty.Duration,
+	"prettySize":            pretty.Size,
+	"prettyTransform":       prettyTransform,
+	"egress":                egress,
+	"jobBudget":             jobBudget,
+	"jobUsage":              jobUsage,
+	"gpuUtilization":        gpuUtilization,
+	"exactDuration":         exactDuration,
+	"aggregateSeconds":      aggregateSeconds,
+	"aggregateBytes":        aggregateBytes,
+	"datumRetryPolicy":      datumRetryPolicy,
+	"datumAutoscaling":      datumAutoscaling,
+	"readahead":             readahead,
+	"modelRegistry":         modelRegistry,
+	"build":                 build,
+	"containers":            containers,
+	"preemptibleScheduling": preemptibleScheduling,
+	"drain":                 drain,
+	"cordon":                pfspretty.PrintCordon,
+	"networkPolicy":         networkPolicy,
+	"canary":                canary,
+	"rollbackPolicy":        rollbackPolicy,
+	"rollback":              rollback,
+	"slos":                  slos,
+	"sloViolations":         sloViolations,
+	"lintSeverity":          lintSeverity,
+	"templateParameters":    templateParameters,
+	"resources":             resources,
+	"datumFiles":            datumFiles,
 }
@@ -151,3 +151,81 @@ func TestPipelineEgressURL(t *testing.T) {
 		}
 	}
 }
+
+func TestPipelinePlan(t *testing.T) {
+	buf := new(bytes.Buffer)
+	plan := &ppsclient.PipelinePlan{
+		Pipeline:         &ppsclient.Pipeline{Name: "edges"},
+		Workers:          4,
+		ResourceRequests: &ppsclient.ResourceSpec{Cpu: 0.5, Memory: "1G"},
+		Datums:           2,
+		DatumPreview: []*ppsclient.DatumInfo{{
+			Data: []*pfsclient.FileInfo{{
+				File: &pfsclient.File{
+					Commit: &pfsclient.Commit{ID: "abc", Branch: &pfsclient.Branch{Name: "master", Repo: &pfsclient.Repo{Name: "images", Type: pfsclient.UserRepoType}}},
+					Path:   "/1.png",
+				},
+			}},
+		}},
+		Warnings: []string{"there are fewer datums (2) than workers (4), so some workers will be idle"},
+	}
+	if err := pretty.PrintPipelinePlan(buf, plan); err != nil {
+		t.Fatal(err)
+	}
+	s := buf.String()
+	for _, expected := range []string{
+		"Pipeline: edges (create)",
+		"Workers: 4",
+		"Resource Requests: 0.5 CPU, 1G memory",
+		"Datums: 2",
+		"images@abc:/1.png",
+		"  ...",
+		"some workers will be idle",
+	} {
+		if !strings.Contains(s, expected) {
+			t.Errorf("could not find %q in pipeline plan:\n%s", expected, s)
+		}
+	}
+}
+
+func TestWriteDAG(t *testing.T) {
+	dag := &ppsclient.DAG{
+		Nodes: []*ppsclient.DAGNode{
+			{ID: "pipeline:edges", Type: ppsclient.DAGNode_PIPELINE, Name: "edges", State: ppsclient.PipelineState_PIPELINE_RUNNING, LastJobState: ppsclient.JobState_JOB_SUCCESS},
+			{ID: "repo:edges", Type: ppsclient.DAGNode_REPO, Name: "edges"},
+			{ID: "repo:images", Type: ppsclient.DAGNode_REPO, Name: "images"},
+		},
+		Edges: []*ppsclient.DAGEdge{
+			{From: "pipeline:edges", To: "repo:edges", Branch: "master"},
+			{From: "repo:images", To: "pipeline:edges", Branch: "staging"},
+		},
+	}
+	buf := new(bytes.Buffer)
+	if err := pretty.WriteDAGDot(buf, dag); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"pipeline:edges" [label="edges\nrunning / success", shape=box];`,
+		`"repo:images" [label="images", shape=cylinder];`,
+		`"pipeline:edges" -> "repo:edges";`,
+		`"repo:images" -> "pipeline:edges" [label="staging"];`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, buf.String())
+		}
+	}
+	buf.Reset()
+	if err := pretty.WriteDAGMermaid(buf, dag); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`n0["edges<br>running / success"]`,
+		`n2[("images")]`,
+		`n0 --> n1`,
+		`n2 -->|staging| n0`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, buf.String())
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
 	"path"
 	"sort"
 	"strings"
@@ -34,9 +35,12 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/imagepolicy"
 	"github.com/pachyderm/pachyderm/v2/src/internal/lokiutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/metrics"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pachtmpl"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pbutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
 	"github.com/pachyderm/pachyderm/v2/src/internal/ppsdb"
 	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/serde"
@@ -100,9 +104,12 @@ type apiServer struct {
 	port                  uint16
 	peerPort              uint16
 	gcPercent             int
+	priorityClasses       map[string]string
+	imagePolicy           *imagepolicy.Policy
 	// collections
-	pipelines col.PostgresCollection
-	jobs      col.PostgresCollection
+	pipelines       col.PostgresCollection
+	jobs            col.PostgresCollection
+	clusterDefaults col.PostgresCollection
 }
 
 func merge(from, to map[string]bool) {
@@ -197,6 +204,16 @@ func (a *apiServer) validateInput(pipelineName string, input *pps.Input) error {
 					"'empty_files', as 's3' requires input data to be accessed via " +
 					"Pachyderm's S3 gateway rather than the file system")
 			}
+			if input.Pfs.Remote != nil {
+				if err := validateRemoteRepo(pipelineName, input.Pfs); err != nil {
+					return err
+				}
+			}
+			if input.Pfs.Sql != nil {
+				if err := validateSQLSource(pipelineName, input.Pfs); err != nil {
+					return err
+				}
+			}
 		}
 		if input.Cross != nil {
 			if set {
@@ -249,6 +266,21 @@ func (a *apiServer) validateInput(pipelineName string, input *pps.Input) error {
 			if _, err := cron.ParseStandard(input.Cron.Spec); err != nil {
 				return errors.Wrapf(err, "error parsing cron-spec")
 			}
+			if _, err := cronLocation(input.Cron); err != nil {
+				return err
+			}
+			for name, d := range map[string]*types.Duration{"jitter": input.Cron.Jitter, "backfill": input.Cron.Backfill} {
+				if d == nil {
+					continue
+				}
+				duration, err := types.DurationFromProto(d)
+				if err != nil {
+					return errors.Wrapf(err, "invalid cron %s", name)
+				}
+				if duration < 0 {
+					return errors.Errorf("cron %s must be non-negative", name)
+				}
+			}
 		}
 		if !set {
 			return errors.Errorf("no input set")
@@ -454,8 +486,12 @@ func (a *apiServer) UpdateJobStateInTransaction(txnCtx *txncontext.TransactionCo
 	jobInfo.DataSkipped = request.DataSkipped
 	jobInfo.DataFailed = request.DataFailed
 	jobInfo.DataRecovered = request.DataRecovered
+	jobInfo.DataQuarantined = request.DataQuarantined
 	jobInfo.DataTotal = request.DataTotal
 	jobInfo.Stats = request.Stats
+	jobInfo.EgressBytes = request.EgressBytes
+	jobInfo.DataRestored = request.DataRestored
+	jobInfo.DataRecomputed = request.DataRecomputed
 
 	return ppsutil.UpdateJobState(a.pipelines.ReadWrite(txnCtx.SqlTx), jobs, jobInfo, request.State, request.Reason)
 }
@@ -675,15 +711,8 @@ func (a *apiServer) intersectCommitSets(ctx context.Context, commits []*pfs.Comm
 // listJob is the internal implementation of ListJob shared between ListJob and
 // ListJobStream. When ListJob is removed, this should be inlined into
 // ListJobStream.
-func (a *apiServer) listJob(
-	ctx context.Context,
-	pipeline *pps.Pipeline,
-	inputCommits []*pfs.Commit,
-	history int64,
-	details bool,
-	jqFilter string,
-	f func(*pps.JobInfo) error,
-) error {
+func (a *apiServer) listJob(ctx context.Context, request *pps.ListJobRequest, f func(*pps.JobInfo) error) error {
+	pipeline, inputCommits, history, details, jqFilter := request.Pipeline, request.InputCommit, request.History, request.Details, request.JqFilter
 	if pipeline != nil {
 		// If 'pipeline is set, check that caller has access to the pipeline's
 		// output repo; currently, that's all that's required for ListJob.
@@ -733,9 +762,30 @@ func (a *apiServer) listJob(
 		return err
 	}
 
+	states := make(map[pps.JobState]bool)
+	for _, state := range request.State {
+		states[state] = true
+	}
+	number := request.Number
+	if number == 0 {
+		number = math.MaxInt64
+	}
+	// pastCursor is true once the jobs before the cursor have been skipped
+	pastCursor := request.Cursor == nil
+
 	jobs := a.jobs.ReadOnly(ctx)
 	jobInfo := &pps.JobInfo{}
 	_f := func(string) error {
+		if !pastCursor {
+			pastCursor = ppsdb.JobKey(jobInfo.Job) == ppsdb.JobKey(request.Cursor)
+			return nil
+		}
+		if len(states) > 0 && !states[jobInfo.State] {
+			return nil
+		}
+		if !pbutil.InTimeRange(jobInfo.Created, request.CreatedAfter, request.CreatedBefore) {
+			return nil
+		}
 		if details {
 			if err := a.getJobDetails(ctx, jobInfo); err != nil {
 				if auth.IsErrNotAuthorized(err) {
@@ -769,15 +819,28 @@ func (a *apiServer) listJob(
 			}
 		}
 
+		if number == 0 {
+			return errutil.ErrBreak
+		}
+		number--
 		return f(jobInfo)
 	}
+	opts := col.DefaultOptions()
+	if request.Reverse {
+		opts.Order = col.SortAscend
+	}
 	if pipeline != nil {
-		err := jobs.GetByIndex(ppsdb.JobsPipelineIndex, pipeline.Name, jobInfo, col.DefaultOptions(), _f)
-		return errors.EnsureStack(err)
+		err = jobs.GetByIndex(ppsdb.JobsPipelineIndex, pipeline.Name, jobInfo, opts, _f)
 	} else {
-		err := jobs.List(jobInfo, col.DefaultOptions(), _f)
+		err = jobs.List(jobInfo, opts, _f)
+	}
+	if err != nil {
 		return errors.EnsureStack(err)
 	}
+	if !pastCursor {
+		return errors.Errorf("cursor job %s was not found in the listed jobs", request.Cursor)
+	}
+	return nil
 }
 
 func (a *apiServer) getJobDetails(ctx context.Context, jobInfo *pps.JobInfo) error {
@@ -816,6 +879,10 @@ func (a *apiServer) getJobDetails(ctx context.Context, jobInfo *pps.JobInfo) err
 	details.SchedulingSpec = pipelineInfo.Details.SchedulingSpec
 	details.PodSpec = pipelineInfo.Details.PodSpec
 	details.PodPatch = pipelineInfo.Details.PodPatch
+	details.Budget = pipelineInfo.Details.Budget
+	details.Priority = pipelineInfo.Details.Priority
+	details.DatumRetryPolicy = pipelineInfo.Details.DatumRetryPolicy
+	details.Canary = pipelineInfo.Details.Canary
 
 	// If the job is running, we fill in WorkerStatus field, otherwise
 	// we just return the jobInfo.
@@ -842,7 +909,7 @@ func (a *apiServer) getJobDetails(ctx context.Context, jobInfo *pps.JobInfo) err
 
 // ListJob implements the protobuf pps.ListJob RPC
 func (a *apiServer) ListJob(request *pps.ListJobRequest, resp pps.API_ListJobServer) (retErr error) {
-	return a.listJob(resp.Context(), request.Pipeline, request.InputCommit, request.History, request.Details, request.JqFilter, func(ji *pps.JobInfo) error {
+	return a.listJob(resp.Context(), request, func(ji *pps.JobInfo) error {
 		return errors.EnsureStack(resp.Send(ji))
 	})
 }
@@ -997,6 +1064,139 @@ func (a *apiServer) RestartDatum(ctx context.Context, request *pps.RestartDatumR
 	return &types.Empty{}, nil
 }
 
+// ListQuarantinedDatum implements the protobuf pps.ListQuarantinedDatum RPC
+func (a *apiServer) ListQuarantinedDatum(request *pps.ListQuarantinedDatumRequest, server pps.API_ListQuarantinedDatumServer) (retErr error) {
+	_, err := a.collectQuarantinedDatums(server.Context(), request.Pipeline, func(di *pps.DatumInfo) error {
+		return errors.EnsureStack(server.Send(di))
+	})
+	return err
+}
+
+// RequeueQuarantinedDatums implements the protobuf
+// pps.RequeueQuarantinedDatums RPC
+func (a *apiServer) RequeueQuarantinedDatums(ctx context.Context, request *pps.RequeueQuarantinedDatumsRequest) (response *pps.RequeueQuarantinedDatumsResponse, retErr error) {
+	var count int64
+	if _, err := a.collectQuarantinedDatums(ctx, request.Pipeline, func(*pps.DatumInfo) error {
+		count++
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.Errorf("pipeline %q has no quarantined datums", request.Pipeline.Name)
+	}
+	// Quarantined datums are never skipped, so starting a new job for the
+	// pipeline is enough to process them again.
+	pipelineInfo, err := a.inspectPipeline(ctx, request.Pipeline.Name, true)
+	if err != nil {
+		return nil, err
+	}
+	req := ppsutil.PipelineReqFromInfo(pipelineInfo)
+	req.Update = true
+	if _, err := a.CreatePipeline(ctx, req); err != nil {
+		return nil, err
+	}
+	pipelineInfo, err = a.inspectPipeline(ctx, request.Pipeline.Name, false)
+	if err != nil {
+		return nil, err
+	}
+	return &pps.RequeueQuarantinedDatumsResponse{
+		Job:   client.NewJob(request.Pipeline.Name, pipelineInfo.SpecCommit.ID),
+		Count: count,
+	}, nil
+}
+
+// collectQuarantinedDatums calls cb with each datum that was quarantined by
+// the most recent successful job of pipeline, and returns that job.
+func (a *apiServer) collectQuarantinedDatums(ctx context.Context, pipeline *pps.Pipeline, cb func(*pps.DatumInfo) error) (*pps.Job, error) {
+	if pipeline == nil || pipeline.Name == "" {
+		return nil, errors.New("must specify a pipeline")
+	}
+	var job *pps.Job
+	if err := a.listJob(ctx, &pps.ListJobRequest{
+		Pipeline: pipeline,
+		State:    []pps.JobState{pps.JobState_JOB_SUCCESS},
+		Number:   1,
+	}, func(ji *pps.JobInfo) error {
+		job = ji.Job
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, nil
+	}
+	if err := a.collectDatums(ctx, job, func(meta *datum.Meta, _ *pfs.File) error {
+		if meta.State != datum.State_QUARANTINED {
+			return nil
+		}
+		return cb(convertDatumMetaToInfo(meta, job))
+	}); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// ListDatumProvenance implements the protobuf pps.ListDatumProvenance RPC
+func (a *apiServer) ListDatumProvenance(request *pps.ListDatumProvenanceRequest, server pps.API_ListDatumProvenanceServer) (retErr error) {
+	ctx := server.Context()
+	file := request.File
+	if file.GetCommit().GetBranch().GetRepo() == nil {
+		return errors.New("must specify a file")
+	}
+	repo := file.Commit.Branch.Repo
+	if repo.Type != pfs.UserRepoType {
+		return errors.Errorf("%s is not a pipeline output repo", repo)
+	}
+	if err := a.env.AuthServer.CheckRepoIsAuthorized(ctx, repo, auth.Permission_REPO_READ); err != nil && !auth.IsErrNotActivated(err) {
+		return errors.EnsureStack(err)
+	}
+	pachClient := a.env.GetPachClient(ctx)
+	commitInfo, err := pachClient.PfsAPIClient.InspectCommit(ctx, &pfs.InspectCommitRequest{Commit: file.Commit})
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	// Each datum's output is also written to /pfs/<datum>/out in the meta
+	// commit, so the datums that produced the file are the ones whose output
+	// contains it.
+	glob := "/" + path.Join(datum.PFSPrefix, "*", datum.OutputPrefix, escapeGlob(path.Clean("/"+file.Path)))
+	ids := make(map[string]bool)
+	metaCommit := ppsutil.MetaCommit(commitInfo.Commit)
+	if err := pachClient.GlobFile(metaCommit, glob, func(fi *pfs.FileInfo) error {
+		if parts := strings.Split(strings.TrimPrefix(fi.File.Path, "/"), "/"); len(parts) > 1 {
+			ids[parts[1]] = true
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return errors.Errorf("no datums produced %s in commit %s", file.Path, commitInfo.Commit.ID)
+	}
+	job := client.NewJob(repo.Name, commitInfo.Commit.ID)
+	return a.collectDatums(ctx, job, func(meta *datum.Meta, pfsState *pfs.File) error {
+		if !ids[common.DatumID(meta.Inputs)] {
+			return nil
+		}
+		di := convertDatumMetaToInfo(meta, job)
+		di.PfsState = pfsState
+		return errors.EnsureStack(server.Send(di))
+	})
+}
+
+// escapeGlob escapes the glob metacharacters in p, so that it only matches
+// itself.
+func escapeGlob(p string) string {
+	var b strings.Builder
+	for _, r := range p {
+		if strings.ContainsRune(`*?[]{}!()@+^\`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
 func (a *apiServer) InspectDatum(ctx context.Context, request *pps.InspectDatumRequest) (response *pps.DatumInfo, retErr error) {
 	if request.Datum == nil || request.Datum.ID == "" {
 		return nil, errors.New("must specify a datum")
@@ -1022,21 +1222,34 @@ func (a *apiServer) InspectDatum(ctx context.Context, request *pps.InspectDatumR
 
 func (a *apiServer) ListDatum(request *pps.ListDatumRequest, server pps.API_ListDatumServer) (retErr error) {
 	// TODO: Auth?
+	page, err := newDatumPage(request)
+	if err != nil {
+		return err
+	}
+	send := func(di *pps.DatumInfo) error {
+		return errors.EnsureStack(server.Send(di))
+	}
 	if request.Input != nil {
-		return a.listDatumInput(server.Context(), request.Input, func(meta *datum.Meta) error {
+		return page.finish(a.listDatumInput(server.Context(), request.Input, func(meta *datum.Meta) error {
 			di := convertDatumMetaToInfo(meta, nil)
 			di.State = pps.DatumState_UNKNOWN
-			return errors.EnsureStack(server.Send(di))
-		})
+			return page.add(di, send)
+		}))
 	}
-	return a.collectDatums(server.Context(), request.Job, func(meta *datum.Meta, _ *pfs.File) error {
-		return errors.EnsureStack(server.Send(convertDatumMetaToInfo(meta, request.Job)))
-	})
+	return page.finish(a.collectDatums(server.Context(), request.Job, func(meta *datum.Meta, _ *pfs.File) error {
+		return page.add(convertDatumMetaToInfo(meta, request.Job), send)
+	}))
 }
 
 func (a *apiServer) listDatumInput(ctx context.Context, input *pps.Input, cb func(*datum.Meta) error) error {
 	setInputDefaults("", input)
 	if visitErr := pps.VisitInput(input, func(input *pps.Input) error {
+		if input.Pfs != nil && input.Pfs.Remote != nil {
+			return errors.Errorf("can't list datums with a remote input, there will be no datums until the pipeline is created")
+		}
+		if input.Pfs != nil && input.Pfs.Sql != nil {
+			return errors.Errorf("can't list datums with a sql input, there will be no datums until the pipeline is created")
+		}
 		if input.Pfs != nil {
 			pachClient := a.env.GetPachClient(ctx)
 			ci, err := pachClient.InspectCommit(input.Pfs.Repo, input.Pfs.Branch, "")
@@ -1088,6 +1301,8 @@ func convertDatumState(state datum.State) pps.DatumState {
 		return pps.DatumState_FAILED
 	case datum.State_RECOVERED:
 		return pps.DatumState_RECOVERED
+	case datum.State_QUARANTINED:
+		return pps.DatumState_QUARANTINED
 	default:
 		return pps.DatumState_SUCCESS
 	}
@@ -1119,6 +1334,17 @@ func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.AP
 	if request.Since == nil || (request.Since.Seconds == 0 && request.Since.Nanos == 0) {
 		request.Since = types.DurationProto(DefaultLogsFrom)
 	}
+	filter, err := newLogFilter(request.Filter, request.Level)
+	if err != nil {
+		return err
+	}
+	var heartbeatInterval time.Duration
+	if request.Follow && request.HeartbeatInterval != nil {
+		heartbeatInterval, err = types.DurationFromProto(request.HeartbeatInterval)
+		if err != nil {
+			return errors.Wrapf(err, "invalid heartbeat interval")
+		}
+	}
 	if a.env.Config.LokiLogging || request.UseLokiBackend {
 		pachClient := a.env.GetPachClient(apiGetLogsServer.Context())
 		resp, err := pachClient.Enterprise.GetState(pachClient.Ctx(),
@@ -1127,13 +1353,20 @@ func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.AP
 			return errors.Wrapf(grpcutil.ScrubGRPC(err), "could not get enterprise status")
 		}
 		if resp.State == enterpriseclient.State_ACTIVE {
-			return a.getLogsLoki(request, apiGetLogsServer)
+			return withHeartbeats(apiGetLogsServer.Context(), apiGetLogsServer, heartbeatInterval, func(send func(*pps.LogMessage) error) error {
+				return a.getLogsLoki(request, apiGetLogsServer, filter, send)
+			})
 		}
 		enterprisemetrics.IncEnterpriseFailures()
 		return errors.Errorf("%s requires an activation key to use Loki for logs. %s\n\n%s",
 			enterprisetext.OpenSourceProduct, enterprisetext.ActivateCTA, enterprisetext.RegisterCTA)
 	}
+	return withHeartbeats(apiGetLogsServer.Context(), apiGetLogsServer, heartbeatInterval, func(send func(*pps.LogMessage) error) error {
+		return a.getLogsKube(request, apiGetLogsServer, filter, send)
+	})
+}
 
+func (a *apiServer) getLogsKube(request *pps.GetLogsRequest, apiGetLogsServer pps.API_GetLogsServer, filter *logFilter, send func(*pps.LogMessage) error) error {
 	// Authorize request and get list of pods containing logs we're interested in
 	// (based on pipeline and job filters)
 	var rcName, containerName string
@@ -1246,6 +1479,7 @@ func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.AP
 					msg := new(pps.LogMessage)
 					if containerName == "pachd" {
 						msg.Message = scanner.Text()
+						msg.Level = pps.ParseLogLevel(msg.Message)
 					} else {
 						logBytes := scanner.Bytes()
 						if err := jsonpb.Unmarshal(bytes.NewReader(logBytes), msg); err != nil {
@@ -1270,6 +1504,9 @@ func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.AP
 						}
 					}
 					msg.Message = strings.TrimSuffix(msg.Message, "\n")
+					if !filter.match(msg) {
+						continue
+					}
 
 					// Log message passes all filters -- return it
 					select {
@@ -1290,14 +1527,14 @@ func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.AP
 	}()
 
 	for msg := range logCh {
-		if err := apiGetLogsServer.Send(msg); err != nil {
-			return errors.EnsureStack(err)
+		if err := send(msg); err != nil {
+			return err
 		}
 	}
 	return errors.EnsureStack(egErr)
 }
 
-func (a *apiServer) getLogsLoki(request *pps.GetLogsRequest, apiGetLogsServer pps.API_GetLogsServer) (retErr error) {
+func (a *apiServer) getLogsLoki(request *pps.GetLogsRequest, apiGetLogsServer pps.API_GetLogsServer, filter *logFilter, send func(*pps.LogMessage) error) (retErr error) {
 	// Authorize request and get list of pods containing logs we're interested in
 	// (based on pipeline and job filters)
 	loki, err := a.env.GetLokiClient()
@@ -1316,9 +1553,14 @@ func (a *apiServer) getLogsLoki(request *pps.GetLogsRequest, apiGetLogsServer pp
 			return errors.EnsureStack(err)
 		}
 		return lokiutil.QueryRange(apiGetLogsServer.Context(), loki, `{app="pachd"}`, time.Now().Add(-since), time.Now(), request.Follow, func(t time.Time, line string) error {
-			return errors.EnsureStack(apiGetLogsServer.Send(&pps.LogMessage{
+			msg := &pps.LogMessage{
 				Message: strings.TrimSuffix(line, "\n"),
-			}))
+			}
+			msg.Level = pps.ParseLogLevel(msg.Message)
+			if !filter.match(msg) {
+				return nil
+			}
+			return send(msg)
 		})
 	} else if request.Job != nil && request.Pipeline != nil && !proto.Equal(request.Job.Pipeline, request.Pipeline) {
 		return errors.Errorf("job is from the wrong pipeline")
@@ -1391,7 +1633,10 @@ func (a *apiServer) getLogsLoki(request *pps.GetLogsRequest, apiGetLogsServer pp
 			return nil
 		}
 		msg.Message = strings.TrimSuffix(msg.Message, "\n")
-		return errors.EnsureStack(apiGetLogsServer.Send(msg))
+		if !filter.match(msg) {
+			return nil
+		}
+		return send(msg)
 	})
 }
 
@@ -1441,7 +1686,7 @@ func (a *apiServer) validatePipelineRequest(request *pps.CreatePipelineRequest)
 	if request.S3Out && ((request.Service != nil) || (request.Spout != nil)) {
 		return errors.New("s3 output is not supported in spouts or services")
 	}
-	if request.Transform == nil {
+	if request.Transform == nil && request.Build == nil {
 		return errors.Errorf("pipeline must specify a transform")
 	}
 	if request.ReprocessSpec != "" &&
@@ -1453,6 +1698,118 @@ func (a *apiServer) validatePipelineRequest(request *pps.CreatePipelineRequest)
 	if request.Spout != nil && request.Autoscaling {
 		return errors.Errorf("autoscaling can't be used with spouts (spouts aren't triggered externally)")
 	}
+	if request.KubernetesJobs && (request.Spout != nil || request.Service != nil) {
+		return errors.Errorf("kubernetes_jobs can't be used with spouts or services (they run continuously)")
+	}
+	if request.DatumCache && request.Spout != nil {
+		return errors.Errorf("datum_cache can't be used with spouts (spouts don't process datums)")
+	}
+	if request.DatumCheckpoints && request.Spout != nil {
+		return errors.Errorf("datum_checkpoints can't be used with spouts (spouts don't process datums)")
+	}
+	if request.Executor != nil {
+		if request.Spout != nil || request.Service != nil {
+			return errors.Errorf("an executor can't be used with spouts or services (they don't process datums)")
+		}
+		if request.S3Out || ppsutil.ContainsS3Inputs(request.Input) {
+			return errors.Errorf("an executor can't be used with s3 inputs or outputs (the s3 gateway is only reachable from the pipeline's workers)")
+		}
+		if err := pps.ValidateExecutor(request.Executor); err != nil {
+			return errors.Wrapf(err, "invalid executor")
+		}
+	}
+	if request.Transform.GetProtocol() == pps.TransformProtocol_TRANSFORM_GRPC {
+		if request.Spout != nil || request.Service != nil || request.Executor != nil || request.Build != nil {
+			return errors.Errorf("the TRANSFORM_GRPC protocol can't be used with spouts, services, executors or builds (the worker doesn't process their datums)")
+		}
+		if len(request.Transform.Cmd) == 0 {
+			return errors.Errorf("a transform with the TRANSFORM_GRPC protocol must set cmd, which serves the UserCode service")
+		}
+	}
+	if request.Readahead != nil {
+		if request.Spout != nil || request.Service != nil {
+			return errors.Errorf("readahead can't be used with spouts or services (they don't process datums)")
+		}
+		if err := pps.ValidateReadahead(request.Readahead); err != nil {
+			return errors.Wrapf(err, "invalid readahead")
+		}
+	}
+	if request.DatumAutoscaling != nil {
+		if request.ParallelismSpec != nil {
+			return errors.Errorf("datum_autoscaling can't be used with a parallelism_spec")
+		}
+		if request.Spout != nil || request.Service != nil {
+			return errors.Errorf("datum_autoscaling can't be used with spouts or services")
+		}
+		if err := pps.ValidateDatumAutoscaling(request.DatumAutoscaling); err != nil {
+			return errors.Wrapf(err, "invalid datum_autoscaling")
+		}
+	}
+	if request.Build != nil {
+		if request.Spout != nil || request.Service != nil || request.Executor != nil || request.S3Out {
+			return errors.Errorf("build can't be used with spouts, services, executors or s3 outputs")
+		}
+		if request.Transform.GetImageFrom() != "" {
+			return errors.Errorf("a build pipeline can't set transform.image_from (its transform runs the builder image)")
+		}
+		if err := pps.ValidateBuild(request.Build, request.Input); err != nil {
+			return errors.Wrapf(err, "invalid build")
+		}
+	}
+	if from := request.Transform.GetImageFrom(); from != "" && from == request.Pipeline.GetName() {
+		return errors.Errorf("transform.image_from can't refer to the pipeline itself")
+	}
+	if request.ModelRegistry != nil {
+		if request.Spout != nil || request.Service != nil {
+			return errors.Errorf("model_registry can't be used with spouts or services (they don't run jobs)")
+		}
+		if err := pps.ValidateModelRegistry(request.ModelRegistry); err != nil {
+			return errors.Wrapf(err, "invalid model_registry")
+		}
+	}
+	if request.WorkerPool != nil {
+		if request.Spout != nil || request.Service != nil || request.KubernetesJobs {
+			return errors.Errorf("worker_pool can't be used with spouts, services or kubernetes_jobs (their workers aren't scaled with jobs)")
+		}
+		if request.S3Out || ppsutil.ContainsS3Inputs(request.Input) {
+			return errors.Errorf("worker_pool can't be used with s3 inputs or outputs (their workers serve an s3 gateway for the pipeline)")
+		}
+		if request.WorkerPool.Warm < 0 {
+			return errors.Errorf("worker_pool.warm must be non-negative, not %d", request.WorkerPool.Warm)
+		}
+	}
+	if err := validateSchedulingSpec(request.SchedulingSpec); err != nil {
+		return errors.Wrapf(err, "invalid scheduling_spec")
+	}
+	if request.NetworkPolicy != nil {
+		if request.WorkerPool != nil {
+			return errors.Errorf("network_policy can't be used with worker_pool (pool workers are shared with other pipelines)")
+		}
+		if err := validateNetworkPolicy(request.NetworkPolicy); err != nil {
+			return errors.Wrapf(err, "invalid network_policy")
+		}
+	}
+	if request.Canary != nil {
+		if err := validateCanary(request); err != nil {
+			return errors.Wrapf(err, "invalid canary")
+		}
+	}
+	if request.RollbackPolicy != nil {
+		if request.Spout != nil || request.Service != nil {
+			return errors.Errorf("rollback_policy can't be used with spouts or services (they don't run jobs)")
+		}
+		if err := validateRollbackPolicy(request.RollbackPolicy); err != nil {
+			return errors.Wrapf(err, "invalid rollback_policy")
+		}
+	}
+	if len(request.SLOs) > 0 {
+		if request.Spout != nil || request.Service != nil {
+			return errors.Errorf("slos can't be used with spouts or services (they don't run jobs)")
+		}
+		if err := validateSLOs(request.SLOs); err != nil {
+			return errors.Wrapf(err, "invalid slos")
+		}
+	}
 	return nil
 }
 
@@ -1487,7 +1844,8 @@ func (a *apiServer) validateEnterpriseChecks(ctx context.Context, req *pps.Creat
 		return errors.Errorf("%s requires an activation key to create more than %d total pipelines (you have %d). %s\n\n%s",
 			enterprisetext.OpenSourceProduct, enterpriselimits.Pipelines, len(seen), enterprisetext.ActivateCTA, enterprisetext.RegisterCTA)
 	}
-	if req.ParallelismSpec != nil && req.ParallelismSpec.Constant > enterpriselimits.Parallelism {
+	if (req.ParallelismSpec != nil && req.ParallelismSpec.Constant > enterpriselimits.Parallelism) ||
+		(req.DatumAutoscaling != nil && req.DatumAutoscaling.MaxWorkers > enterpriselimits.Parallelism) {
 		enterprisemetrics.IncEnterpriseFailures()
 		return errors.Errorf("%s requires an activation key to create pipelines with parallelism more than %d. %s\n\n%s",
 			enterprisetext.OpenSourceProduct, enterpriselimits.Parallelism, enterprisetext.ActivateCTA, enterprisetext.RegisterCTA)
@@ -1507,6 +1865,9 @@ func (a *apiServer) validateSecret(ctx context.Context, req *pps.CreatePipelineR
 			}
 			return errors.Wrapf(err, "could not get Kubernetes secret %s", s.Name)
 		}
+		if pipeline := ss.Labels[secretPipelineLabel]; pipeline != "" && pipeline != req.Pipeline.GetName() {
+			return errors.Errorf("secret %s is scoped to pipeline %s", s.Name, pipeline)
+		}
 		if s.EnvVar == "" {
 			continue
 		}
@@ -1522,6 +1883,9 @@ func (a *apiServer) validateEgress(pipelineName string, egress *pps.Egress) erro
 	if egress == nil {
 		return nil
 	}
+	if err := pfsServer.ValidateTableEgress(egress.GetTable()); err != nil {
+		return err
+	}
 	return pfsServer.ValidateSQLDatabaseEgress(egress.GetSqlDatabase())
 }
 
@@ -1572,6 +1936,21 @@ func (a *apiServer) validatePipeline(pipelineInfo *pps.PipelineInfo) error {
 			return errors.EnsureStack(err)
 		}
 	}
+	if err := pps.ValidateJobBudget(pipelineInfo.Details.Budget, pipelineInfo.Details.ResourceRequests, pipelineInfo.Details.ResourceLimits); err != nil {
+		return errors.Wrapf(err, "invalid budget")
+	}
+	if err := pps.ValidateDatumRetryPolicy(pipelineInfo.Details.DatumRetryPolicy); err != nil {
+		return errors.Wrapf(err, "invalid datum retry policy")
+	}
+	if err := validatePriority(pipelineInfo.Details, a.priorityClasses); err != nil {
+		return err
+	}
+	if err := validateGPUs(pipelineInfo.Details, gpuSharing(a.env.Config)); err != nil {
+		return err
+	}
+	if err := validateContainers(pipelineInfo.Details, gpuSharing(a.env.Config)); err != nil {
+		return err
+	}
 	if pipelineInfo.Details.PodSpec != "" && !json.Valid([]byte(pipelineInfo.Details.PodSpec)) {
 		return errors.Errorf("malformed PodSpec")
 	}
@@ -1638,6 +2017,9 @@ func (a *apiServer) fixPipelineInputRepoACLsInTransaction(txnCtx *txncontext.Tra
 			remove[repo] = struct{}{}
 			return nil
 		})
+		if from := prevPipelineInfo.Details.Transform.GetImageFrom(); from != "" {
+			remove[from] = struct{}{}
+		}
 	}
 
 	// Figure out which repos 'pipeline' is using
@@ -1667,12 +2049,20 @@ func (a *apiServer) fixPipelineInputRepoACLsInTransaction(txnCtx *txncontext.Tra
 				delete(remove, repo)
 			} else {
 				addRead[repo] = struct{}{}
-				if input.Cron != nil {
+				if input.Cron != nil || input.Pfs.GetRemote() != nil || input.Pfs.GetSql() != nil {
 					addWrite[repo] = struct{}{}
 				}
 			}
 			return nil
 		})
+		// pipelines read the images built by their image_from pipeline
+		if from := pipelineInfo.Details.Transform.GetImageFrom(); from != "" {
+			if _, ok := remove[from]; ok {
+				delete(remove, from)
+			} else {
+				addRead[from] = struct{}{}
+			}
+		}
 	}
 	if pipelineName == "" {
 		return errors.Errorf("fixPipelineInputRepoACLs called with both current and " +
@@ -1723,6 +2113,9 @@ func (a *apiServer) fixPipelineInputRepoACLsInTransaction(txnCtx *txncontext.Tra
 // the parallelism spec in CreatePipelineRequest.Parallelism into a constant
 // that can be stored in PipelineInfo.Parallelism
 func getExpectedNumWorkers(pipelineInfo *pps.PipelineInfo) (int, error) {
+	if autoscaling := pipelineInfo.Details.DatumAutoscaling; autoscaling != nil {
+		return int(autoscaling.MaxWorkers), nil
+	}
 	switch pspec := pipelineInfo.Details.ParallelismSpec; {
 	case pspec == nil, pspec.Constant == 0:
 		return 1, nil
@@ -1778,6 +2171,18 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 		return nil, err
 	}
 
+	if err := a.applyClusterDefaults(ctx, request); err != nil {
+		return nil, err
+	}
+
+	if err := a.resolveBuiltImage(ctx, request); err != nil {
+		return nil, err
+	}
+
+	if err := a.checkImagePolicy(ctx, request); err != nil {
+		return nil, err
+	}
+
 	if err := a.txnEnv.WithTransaction(ctx, func(txn txnenv.Transaction) error {
 		return errors.EnsureStack(txn.CreatePipeline(request))
 	}, nil); err != nil {
@@ -1786,6 +2191,89 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 	return &types.Empty{}, nil
 }
 
+// PlanPipeline implements the protobuf pps.PlanPipeline RPC
+func (a *apiServer) PlanPipeline(ctx context.Context, request *pps.PlanPipelineRequest) (*pps.PipelinePlan, error) {
+	if request.Spec == nil || request.Spec.Pipeline == nil {
+		return nil, errors.New("request.Spec.Pipeline cannot be nil")
+	}
+	// initializePipelineInfo sets defaults in the request, so plan a copy
+	spec := proto.Clone(request.Spec).(*pps.CreatePipelineRequest)
+	if err := a.validateSecret(ctx, spec); err != nil {
+		return nil, err
+	}
+	if err := a.applyClusterDefaults(ctx, spec); err != nil {
+		return nil, err
+	}
+	pipelineInfo, err := a.initializePipelineInfo(spec, nil)
+	if err != nil {
+		return nil, err
+	}
+	workers, err := getExpectedNumWorkers(pipelineInfo)
+	if err != nil {
+		return nil, err
+	}
+	plan := &pps.PipelinePlan{
+		Pipeline:              pipelineInfo.Pipeline,
+		Workers:               int64(workers),
+		ResourceRequests:      pipelineInfo.Details.ResourceRequests,
+		ResourceLimits:        pipelineInfo.Details.ResourceLimits,
+		SidecarResourceLimits: pipelineInfo.Details.SidecarResourceLimits,
+	}
+	if _, err := a.inspectPipeline(ctx, spec.Pipeline.Name, false); err == nil {
+		plan.Exists = true
+		if !spec.Update {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("pipeline %q already exists, it can only be updated", spec.Pipeline.Name))
+		}
+	} else if !errutil.IsNotFoundError(err) {
+		return nil, err
+	} else if spec.Update {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("pipeline %q doesn't exist yet, it will be created", spec.Pipeline.Name))
+	}
+	if err := a.checkImagePolicy(ctx, spec); err != nil {
+		if !imagepolicy.IsViolation(err) {
+			return nil, err
+		}
+		plan.Warnings = append(plan.Warnings, err.Error())
+	}
+
+	input := pipelineInfo.Details.Input
+	var hasCron bool
+	pps.VisitInput(input, func(input *pps.Input) error {
+		hasCron = hasCron || input.Cron != nil
+		return nil
+	})
+	switch {
+	case input == nil:
+		// spouts have no input
+		return plan, nil
+	case hasCron:
+		plan.Warnings = append(plan.Warnings, "datums can't be previewed for cron inputs, which have none until the pipeline is created")
+		return plan, nil
+	}
+	if err := a.listDatumInput(ctx, proto.Clone(input).(*pps.Input), func(meta *datum.Meta) error {
+		if plan.Datums < request.DatumLimit {
+			di := convertDatumMetaToInfo(meta, nil)
+			di.State = pps.DatumState_UNKNOWN
+			plan.DatumPreview = append(plan.DatumPreview, di)
+		}
+		plan.Datums++
+		return nil
+	}); err != nil {
+		if !errutil.IsNotFoundError(err) {
+			return nil, err
+		}
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("datums can't be listed yet: %v", grpcutil.ScrubGRPC(err)))
+		return plan, nil
+	}
+	switch {
+	case plan.Datums == 0:
+		plan.Warnings = append(plan.Warnings, "the input has no datums, check its glob patterns and that its branches have data")
+	case plan.Datums < plan.Workers:
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("there are fewer datums (%d) than workers (%d), so some workers will be idle", plan.Datums, plan.Workers))
+	}
+	return plan, nil
+}
+
 func (a *apiServer) initializePipelineInfo(request *pps.CreatePipelineRequest, oldPipelineInfo *pps.PipelineInfo) (*pps.PipelineInfo, error) {
 	if err := a.validatePipelineRequest(request); err != nil {
 		return nil, err
@@ -1824,6 +2312,27 @@ func (a *apiServer) initializePipelineInfo(request *pps.CreatePipelineRequest, o
 			Metadata:              request.Metadata,
 			ReprocessSpec:         request.ReprocessSpec,
 			Autoscaling:           request.Autoscaling,
+			Budget:                request.Budget,
+			DatumRetryPolicy:      request.DatumRetryPolicy,
+			TemplateParameters:    request.TemplateParameters,
+			Priority:              request.Priority,
+			DatumAutoscaling:      request.DatumAutoscaling,
+			Sidecars:              request.Sidecars,
+			InitContainers:        request.InitContainers,
+			SharedVolumes:         request.SharedVolumes,
+			KubernetesJobs:        request.KubernetesJobs,
+			DatumCache:            request.DatumCache,
+			DatumCheckpoints:      request.DatumCheckpoints,
+			NetworkPolicy:         request.NetworkPolicy,
+			Canary:                request.Canary,
+			RollbackPolicy:        request.RollbackPolicy,
+			SLOs:                  request.SLOs,
+			Project:               request.Project,
+			Executor:              request.Executor,
+			Readahead:             request.Readahead,
+			ModelRegistry:         request.ModelRegistry,
+			Build:                 request.Build,
+			WorkerPool:            request.WorkerPool,
 		},
 	}
 
@@ -1844,6 +2353,8 @@ func (a *apiServer) initializePipelineInfo(request *pps.CreatePipelineRequest, o
 		if oldPipelineInfo.Stopped {
 			pipelineInfo.Stopped = true
 		}
+		// an update doesn't uncordon the pipeline either
+		pipelineInfo.Cordon = oldPipelineInfo.Cordon
 		if !request.Reprocess {
 			pipelineInfo.Details.Salt = oldPipelineInfo.Details.Salt
 		}
@@ -1855,6 +2366,16 @@ func (a *apiServer) initializePipelineInfo(request *pps.CreatePipelineRequest, o
 func (a *apiServer) CreatePipelineInTransaction(
 	txnCtx *txncontext.TransactionContext,
 	request *pps.CreatePipelineRequest,
+) error {
+	if request.Canary != nil {
+		return a.createCanaryInTransaction(txnCtx, request)
+	}
+	return a.createPipelineInTransaction(txnCtx, request)
+}
+
+func (a *apiServer) createPipelineInTransaction(
+	txnCtx *txncontext.TransactionContext,
+	request *pps.CreatePipelineRequest,
 ) error {
 	pipelineName := request.Pipeline.Name
 	oldPipelineInfo, err := a.InspectPipelineInTransaction(txnCtx, pipelineName)
@@ -1871,9 +2392,35 @@ func (a *apiServer) CreatePipelineInTransaction(
 	if err != nil {
 		return err
 	}
-	// Verify that all input repos exist (create cron and git repos if necessary)
+	if oldPipelineInfo != nil {
+		if oldProject, newProject := pfsdb.ProjectName(oldPipelineInfo.Details.GetProject()), pfsdb.ProjectName(request.Project); oldProject != newProject {
+			return errors.Errorf("pipeline %q is in project %s, and can't be moved to project %s", pipelineName, oldProject, newProject)
+		}
+	}
+	// Verify that all input repos exist (create cron, mirror and SQL ingest repos if necessary)
 	if visitErr := pps.VisitInput(newPipelineInfo.Details.Input, func(input *pps.Input) error {
-		if input.Pfs != nil {
+		if input.Pfs != nil && input.Pfs.Remote != nil {
+			remote := input.Pfs.Remote
+			if err := a.env.PFSServer.CreateRepoInTransaction(txnCtx,
+				&pfs.CreateRepoRequest{
+					Repo:        client.NewRepo(input.Pfs.Repo),
+					Description: fmt.Sprintf("Mirror of %s@%s on %s for pipeline %s.", remote.Repo, remote.Branch, remote.PachdAddress, request.Pipeline.Name),
+					Project:     request.Project,
+				},
+			); err != nil && !errutil.IsAlreadyExistError(err) {
+				return errors.EnsureStack(err)
+			}
+		} else if input.Pfs != nil && input.Pfs.Sql != nil {
+			if err := a.env.PFSServer.CreateRepoInTransaction(txnCtx,
+				&pfs.CreateRepoRequest{
+					Repo:        client.NewRepo(input.Pfs.Repo),
+					Description: fmt.Sprintf("SQL ingest repo for pipeline %s.", request.Pipeline.Name),
+					Project:     request.Project,
+				},
+			); err != nil && !errutil.IsAlreadyExistError(err) {
+				return errors.EnsureStack(err)
+			}
+		} else if input.Pfs != nil {
 			if _, err := a.env.PFSServer.InspectRepoInTransaction(txnCtx,
 				&pfs.InspectRepoRequest{
 					Repo: client.NewSystemRepo(input.Pfs.Repo, input.Pfs.RepoType),
@@ -1887,6 +2434,7 @@ func (a *apiServer) CreatePipelineInTransaction(
 				&pfs.CreateRepoRequest{
 					Repo:        client.NewRepo(input.Cron.Repo),
 					Description: fmt.Sprintf("Cron tick repo for pipeline %s.", request.Pipeline.Name),
+					Project:     request.Project,
 				},
 			); err != nil && !errutil.IsAlreadyExistError(err) {
 				return errors.EnsureStack(err)
@@ -1931,6 +2479,7 @@ func (a *apiServer) CreatePipelineInTransaction(
 			&pfs.CreateRepoRequest{
 				Repo:        client.NewRepo(pipelineName),
 				Description: fmt.Sprintf("Output repo for pipeline %s.", request.Pipeline.Name),
+				Project:     request.Project,
 			}); err != nil && !errutil.IsAlreadyExistError(err) {
 			return errors.Wrapf(err, "error creating output repo for %s", pipelineName)
 		} else if errutil.IsAlreadyExistError(err) {
@@ -2092,10 +2641,15 @@ func pipelineTypeFromInfo(pipelineInfo *pps.PipelineInfo) pps.PipelineInfo_Pipel
 
 // setPipelineDefaults sets the default values for a pipeline info
 func setPipelineDefaults(pipelineInfo *pps.PipelineInfo) error {
+	setInputDefaults(pipelineInfo.Pipeline.Name, pipelineInfo.Details.Input)
+	if build := pipelineInfo.Details.Build; build != nil {
+		// build pipelines always run the generated transform, so that updating
+		// the spec returned by InspectPipeline doesn't keep a stale one
+		pipelineInfo.Details.Transform = pps.BuildTransform(build, pipelineInfo.Details.Input.Pfs, pipelineInfo.Details.Transform)
+	}
 	if pipelineInfo.Details.Transform.Image == "" {
 		pipelineInfo.Details.Transform.Image = DefaultUserImage
 	}
-	setInputDefaults(pipelineInfo.Pipeline.Name, pipelineInfo.Details.Input)
 	if pipelineInfo.Details.OutputBranch == "" {
 		// Output branches default to master
 		pipelineInfo.Details.OutputBranch = "master"
@@ -2114,6 +2668,11 @@ func setPipelineDefaults(pipelineInfo *pps.PipelineInfo) error {
 	if pipelineInfo.Details.ReprocessSpec == "" {
 		pipelineInfo.Details.ReprocessSpec = client.ReprocessSpecUntilSuccess
 	}
+	if pipelineInfo.Details.KubernetesJobs {
+		// Kubernetes Jobs only exist while the pipeline has work to do, which
+		// is when an autoscaling pipeline leaves standby
+		pipelineInfo.Details.Autoscaling = true
+	}
 	return nil
 }
 
@@ -2122,6 +2681,20 @@ func setInputDefaults(pipelineName string, input *pps.Input) {
 	nCreatedBranches := make(map[string]int)
 	pps.VisitInput(input, func(input *pps.Input) error {
 		if input.Pfs != nil {
+			if remote := input.Pfs.Remote; remote != nil {
+				if remote.Branch == "" {
+					remote.Branch = "master"
+				}
+				if input.Pfs.Name == "" {
+					input.Pfs.Name = remote.Repo
+				}
+				if input.Pfs.Repo == "" {
+					input.Pfs.Repo = fmt.Sprintf("%s_%s", pipelineName, input.Pfs.Name)
+				}
+			}
+			if input.Pfs.Sql != nil && input.Pfs.Repo == "" && input.Pfs.Name != "" {
+				input.Pfs.Repo = fmt.Sprintf("%s_%s", pipelineName, input.Pfs.Name)
+			}
 			if input.Pfs.Branch == "" {
 				if input.Pfs.Trigger != nil {
 					// We start counting trigger branches at 1
@@ -2143,8 +2716,12 @@ func setInputDefaults(pipelineName string, input *pps.Input) {
 		}
 		if input.Cron != nil {
 			if input.Cron.Start == nil {
-				start, _ := types.TimestampProto(now)
-				input.Cron.Start = start
+				start := now
+				// an invalid backfill is rejected by validateInput
+				if backfill, err := types.DurationFromProto(input.Cron.Backfill); err == nil && input.Cron.Backfill != nil {
+					start = start.Add(-backfill)
+				}
+				input.Cron.Start, _ = types.TimestampProto(start)
 			}
 			if input.Cron.Repo == "" {
 				input.Cron.Repo = fmt.Sprintf("%s_%s", pipelineName, input.Cron.Name)
@@ -2312,10 +2889,25 @@ func (a *apiServer) listPipeline(ctx context.Context, request *pps.ListPipelineR
 		// ensure field names and enum values match with --raw output
 		enc = serde.NewJSONEncoder(&jsonBuffer, serde.WithOrigName(true))
 	}
+	// A pipeline is in the same project as its output repo
+	var inProject map[string]bool
+	if request.Project != nil {
+		repoInfos, err := a.env.GetPachClient(ctx).ListProjectRepo(pfsdb.ProjectName(request.Project))
+		if err != nil {
+			return err
+		}
+		inProject = make(map[string]bool)
+		for _, ri := range repoInfos {
+			inProject[ri.Repo.Name] = true
+		}
+	}
 	// get all pipelines at once to avoid holding the list query open
 	// this should be fine with numbers of pipelines pachyderm can actually run
 	var infos []*pps.PipelineInfo
 	if err := ppsutil.ListPipelineInfo(ctx, a.pipelines, request.Pipeline, request.History, func(ptr *pps.PipelineInfo) error {
+		if inProject != nil && !inProject[ptr.Pipeline.Name] {
+			return nil
+		}
 		infos = append(infos, proto.Clone(ptr).(*pps.PipelineInfo))
 		return nil
 	}); err != nil {
@@ -2382,6 +2974,20 @@ func (a *apiServer) DeletePipeline(ctx context.Context, request *pps.DeletePipel
 func (a *apiServer) deletePipeline(ctx context.Context, request *pps.DeletePipelineRequest) error {
 	pipelineName := request.Pipeline.Name
 
+	// if there's an active transaction, the pipeline is deleted along with the
+	// rest of its requests when it's finished
+	if activeTxn, err := client.GetTransaction(ctx); err != nil {
+		return err
+	} else if activeTxn != nil {
+		return a.txnEnv.WithTransaction(ctx, func(txn txnenv.Transaction) error {
+			return errors.EnsureStack(txn.DeletePipeline(&pps.DeletePipelineRequest{
+				Pipeline: request.Pipeline,
+				Force:    request.Force,
+				KeepRepo: request.KeepRepo,
+			}))
+		}, nil)
+	}
+
 	// stop the pipeline to avoid interference from new jobs
 	if _, err := a.StopPipeline(ctx,
 		&pps.StopPipelineRequest{Pipeline: request.Pipeline}); err != nil && errutil.IsNotFoundError(err) {
@@ -2402,9 +3008,33 @@ func (a *apiServer) deletePipeline(ctx context.Context, request *pps.DeletePipel
 		return err
 	}
 	clearJobCache(a.env.GetPachClient(ctx), pipelineName)
+	clearJobCache(a.env.GetPachClient(ctx), ppsutil.DatumCacheTag(pipelineName))
 	return deleteErr
 }
 
+// DeletePipelineInTransaction is identical to DeletePipeline except that it
+// can run inside an existing postgres transaction, so that a pipeline can be
+// deleted atomically with other operations.  It doesn't support deleting all
+// pipelines.  This is not an RPC.
+func (a *apiServer) DeletePipelineInTransaction(txnCtx *txncontext.TransactionContext, request *pps.DeletePipelineRequest) error {
+	if request.All {
+		return errors.New("cannot delete all pipelines in a transaction")
+	}
+	if request.Pipeline == nil {
+		return errors.New("request.Pipeline cannot be nil")
+	}
+	if err := a.stopPipelineInTransaction(txnCtx, &pps.StopPipelineRequest{Pipeline: request.Pipeline}); err != nil && !errutil.IsNotFoundError(err) {
+		return errors.Wrapf(err, "error stopping pipeline %s", request.Pipeline.Name)
+	}
+	err := a.deletePipelineInTransaction(txnCtx, request)
+	// as outside of a transaction, an incomplete deletion still succeeds
+	if errors.Is(err, errIncompleteDeletion) {
+		logrus.Warnf("pipeline %s: %v", request.Pipeline.Name, err)
+		return nil
+	}
+	return err
+}
+
 func (a *apiServer) deletePipelineInTransaction(txnCtx *txncontext.TransactionContext, request *pps.DeletePipelineRequest) error {
 	pipelineName := request.Pipeline.Name
 
@@ -2522,18 +3152,26 @@ func (a *apiServer) deletePipelineInTransaction(txnCtx *txncontext.TransactionCo
 			}
 		}
 	}
-	// delete cron after main repo is deleted or has provenance removed
-	// cron repos are only used to trigger jobs, so don't keep them even with KeepRepo
+	// delete cron, mirror and SQL ingest repos after main repo is deleted or
+	// has provenance removed. cron repos are only used to trigger jobs, and
+	// mirror and SQL ingest repos only hold copies of data from elsewhere, so
+	// don't keep them even with KeepRepo
 	if pipelineInfo.Details != nil {
 		if err := pps.VisitInput(pipelineInfo.Details.Input, func(input *pps.Input) error {
-			if input.Cron != nil {
-				err := a.env.PFSServer.DeleteRepoInTransaction(txnCtx, &pfs.DeleteRepoRequest{
-					Repo:  client.NewRepo(input.Cron.Repo),
-					Force: request.Force,
-				})
-				return errors.EnsureStack(err)
+			var repo string
+			switch {
+			case input.Cron != nil:
+				repo = input.Cron.Repo
+			case input.Pfs != nil && (input.Pfs.Remote != nil || input.Pfs.Sql != nil):
+				repo = input.Pfs.Repo
+			default:
+				return nil
 			}
-			return nil
+			err := a.env.PFSServer.DeleteRepoInTransaction(txnCtx, &pfs.DeleteRepoRequest{
+				Repo:  client.NewRepo(repo),
+				Force: request.Force,
+			})
+			return errors.EnsureStack(err)
 		}); err != nil {
 			return err
 		}
@@ -2584,6 +3222,8 @@ func (a *apiServer) StartPipeline(ctx context.Context, request *pps.StartPipelin
 		newPipelineInfo := &pps.PipelineInfo{}
 		return a.updatePipeline(txnCtx, pipelineInfo.Pipeline.Name, newPipelineInfo, func() error {
 			newPipelineInfo.Stopped = false
+			// drained jobs continue from their checkpoints
+			newPipelineInfo.Drain = nil
 			return nil
 		})
 	}); err != nil {
@@ -2599,50 +3239,94 @@ func (a *apiServer) StopPipeline(ctx context.Context, request *pps.StopPipelineR
 	}
 
 	if err := a.txnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
-		pipelineInfo, err := a.InspectPipelineInTransaction(txnCtx, request.Pipeline.Name)
-		if err == nil {
-			// check if the caller is authorized to update this pipeline
-			// don't pass in the input - stopping the pipeline means they won't be read anymore,
-			// so we don't need to check any permissions
-			if err := a.authorizePipelineOpInTransaction(txnCtx, pipelineOpStartStop, pipelineInfo.Details.Input, pipelineInfo.Pipeline.Name); err != nil {
-				return err
-			}
+		return a.stopPipelineInTransaction(txnCtx, request)
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
 
-			// Remove branch provenance to prevent new output and meta commits from being created
+func (a *apiServer) stopPipelineInTransaction(txnCtx *txncontext.TransactionContext, request *pps.StopPipelineRequest) error {
+	pipelineInfo, err := a.InspectPipelineInTransaction(txnCtx, request.Pipeline.Name)
+	if err == nil {
+		// check if the caller is authorized to update this pipeline
+		// don't pass in the input - stopping the pipeline means they won't be read anymore,
+		// so we don't need to check any permissions
+		if err := a.authorizePipelineOpInTransaction(txnCtx, pipelineOpStartStop, pipelineInfo.Details.Input, pipelineInfo.Pipeline.Name); err != nil {
+			return err
+		}
+		if request.Drain && (pipelineInfo.Details.Spout != nil || pipelineInfo.Details.Service != nil) {
+			return errors.Errorf("pipeline %q can't be drained, as spouts and services don't process datums", pipelineInfo.Pipeline.Name)
+		}
+
+		// Remove branch provenance to prevent new output and meta commits from being created
+		if err := a.env.PFSServer.CreateBranchInTransaction(txnCtx, &pfs.CreateBranchRequest{
+			Branch:     client.NewBranch(pipelineInfo.Pipeline.Name, pipelineInfo.Details.OutputBranch),
+			Provenance: nil,
+		}); err != nil {
+			return errors.EnsureStack(err)
+		}
+		if pipelineInfo.Details.Spout == nil && pipelineInfo.Details.Service == nil {
 			if err := a.env.PFSServer.CreateBranchInTransaction(txnCtx, &pfs.CreateBranchRequest{
-				Branch:     client.NewBranch(pipelineInfo.Pipeline.Name, pipelineInfo.Details.OutputBranch),
+				Branch:     client.NewSystemRepo(pipelineInfo.Pipeline.Name, pfs.MetaRepoType).NewBranch(pipelineInfo.Details.OutputBranch),
 				Provenance: nil,
 			}); err != nil {
 				return errors.EnsureStack(err)
 			}
-			if pipelineInfo.Details.Spout == nil && pipelineInfo.Details.Service == nil {
-				if err := a.env.PFSServer.CreateBranchInTransaction(txnCtx, &pfs.CreateBranchRequest{
-					Branch:     client.NewSystemRepo(pipelineInfo.Pipeline.Name, pfs.MetaRepoType).NewBranch(pipelineInfo.Details.OutputBranch),
-					Provenance: nil,
-				}); err != nil {
-					return errors.EnsureStack(err)
-				}
-			}
+		}
 
-			newPipelineInfo := &pps.PipelineInfo{}
-			if err := a.updatePipeline(txnCtx, pipelineInfo.Pipeline.Name, newPipelineInfo, func() error {
-				newPipelineInfo.Stopped = true
-				return nil
-			}); err != nil {
+		drain := pipelineInfo.Drain
+		if request.Drain && drain == nil {
+			if drain, err = a.startDrainInTransaction(txnCtx, pipelineInfo.Pipeline); err != nil {
 				return err
 			}
-		} else if !errutil.IsNotFoundError(err) {
+		} else if !request.Drain {
+			drain = nil
+		}
+		newPipelineInfo := &pps.PipelineInfo{}
+		if err := a.updatePipeline(txnCtx, pipelineInfo.Pipeline.Name, newPipelineInfo, func() error {
+			newPipelineInfo.Stopped = true
+			newPipelineInfo.Drain = drain
+			return nil
+		}); err != nil {
 			return err
 		}
+		if request.Drain {
+			// the pipeline's workers finish the jobs' in-flight datums
+			return nil
+		}
+	} else if !errutil.IsNotFoundError(err) || request.Drain {
+		return err
+	}
+
+	// Kill any remaining jobs
+	// if the pipeline output repo doesn't exist, we technically run this without authorization,
+	// but it's not clear what authorization means in that case, and those jobs are doomed, anyway
+	return a.stopAllJobsInPipeline(txnCtx, request.Pipeline)
+}
 
-		// Kill any remaining jobs
-		// if the pipeline output repo doesn't exist, we technically run this without authorization,
-		// but it's not clear what authorization means in that case, and those jobs are doomed, anyway
-		return a.stopAllJobsInPipeline(txnCtx, request.Pipeline)
+// startDrainInTransaction returns the drain of pipeline, which is being
+// stopped with StopPipelineRequest.drain. It lists the pipeline's running
+// jobs, which its workers checkpoint, and is already complete if there are
+// none.
+func (a *apiServer) startDrainInTransaction(txnCtx *txncontext.TransactionContext, pipeline *pps.Pipeline) (*pps.Drain, error) {
+	drain := &pps.Drain{
+		State:   pps.DrainState_DRAIN_IN_PROGRESS,
+		Started: types.TimestampNow(),
+	}
+	jobInfo := &pps.JobInfo{}
+	sort := &col.Options{Target: col.SortByCreateRevision, Order: col.SortAscend}
+	if err := a.jobs.ReadWrite(txnCtx.SqlTx).GetByIndex(ppsdb.JobsTerminalIndex, ppsdb.JobTerminalKey(pipeline, false), jobInfo, sort, func(string) error {
+		drain.Jobs = append(drain.Jobs, client.NewJob(pipeline.Name, jobInfo.Job.ID))
+		return nil
 	}); err != nil {
-		return nil, err
+		return nil, errors.EnsureStack(err)
 	}
-	return &types.Empty{}, nil
+	if len(drain.Jobs) == 0 {
+		drain.State = pps.DrainState_DRAIN_COMPLETE
+		drain.Finished = drain.Started
+	}
+	return drain, nil
 }
 
 func (a *apiServer) RunPipeline(ctx context.Context, request *pps.RunPipelineRequest) (response *types.Empty, retErr error) {
@@ -2741,6 +3425,53 @@ func (a *apiServer) propagateJobs(txnCtx *txncontext.TransactionContext) error {
 	return nil
 }
 
+// secretPipelineLabel is the label of secrets scoped to a pipeline, whose
+// value is the pipeline's name.
+const secretPipelineLabel = "secret-pipeline"
+
+// authorizeSecret checks that the caller has repoPermission on the output repo
+// of pipeline, if a secret is scoped to one, or clusterPermission otherwise.
+func (a *apiServer) authorizeSecret(ctx context.Context, pipeline string, repoPermission, clusterPermission auth.Permission) error {
+	if pipeline == "" {
+		return errors.EnsureStack(a.env.AuthServer.CheckClusterIsAuthorized(ctx, clusterPermission))
+	}
+	if _, err := a.inspectPipeline(ctx, pipeline, false); err != nil {
+		return err
+	}
+	return errors.EnsureStack(a.env.AuthServer.CheckRepoIsAuthorized(ctx, client.NewRepo(pipeline), repoPermission))
+}
+
+// getSecret returns a secret, and the pipeline it's scoped to, if any.
+func (a *apiServer) getSecret(ctx context.Context, name string) (*v1.Secret, string, error) {
+	secret, err := a.env.KubeClient.CoreV1().Secrets(a.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to get secret")
+	}
+	if secret.Labels["secret-source"] != "pachyderm-user" {
+		// only secrets created with CreateSecret can be scoped to pipelines
+		return secret, "", nil
+	}
+	return secret, secret.Labels[secretPipelineLabel], nil
+}
+
+func newSecretInfo(s *v1.Secret) *pps.SecretInfo {
+	creationTimestamp := timestamppb.New(s.GetCreationTimestamp().Time)
+	info := &pps.SecretInfo{
+		Secret: &pps.Secret{
+			Name: s.Name,
+		},
+		Type: string(s.Type),
+		CreationTimestamp: &types.Timestamp{
+			Seconds: creationTimestamp.GetSeconds(),
+			Nanos:   creationTimestamp.GetNanos(),
+		},
+	}
+	if pipeline := s.Labels[secretPipelineLabel]; pipeline != "" {
+		info.Pipeline = client.NewPipeline(pipeline)
+	}
+	return info
+}
+
 // CreateSecret implements the protobuf pps.CreateSecret RPC
 func (a *apiServer) CreateSecret(ctx context.Context, request *pps.CreateSecretRequest) (response *types.Empty, retErr error) {
 	metricsFn := metrics.ReportUserAction(ctx, a.reporter, "CreateSecret")
@@ -2750,6 +3481,10 @@ func (a *apiServer) CreateSecret(ctx context.Context, request *pps.CreateSecretR
 	if err := json.Unmarshal(request.GetFile(), &s); err != nil {
 		return nil, errors.Wrapf(err, "failed to unmarshal secret")
 	}
+	pipeline := request.Pipeline.GetName()
+	if err := a.authorizeSecret(ctx, pipeline, auth.Permission_REPO_WRITE, auth.Permission_CLUSTER_CREATE_SECRET); err != nil {
+		return nil, err
+	}
 
 	labels := s.GetLabels()
 	if labels["suite"] != "" && labels["suite"] != "pachyderm" {
@@ -2760,10 +3495,33 @@ func (a *apiServer) CreateSecret(ctx context.Context, request *pps.CreateSecretR
 	}
 	labels["suite"] = "pachyderm"
 	labels["secret-source"] = "pachyderm-user"
+	delete(labels, secretPipelineLabel)
+	if pipeline != "" {
+		labels[secretPipelineLabel] = pipeline
+	}
 	s.SetLabels(labels)
 
-	if _, err := a.env.KubeClient.CoreV1().Secrets(a.namespace).Create(ctx, &s, metav1.CreateOptions{}); err != nil {
-		return nil, errors.Wrapf(err, "failed to create secret")
+	secrets := a.env.KubeClient.CoreV1().Secrets(a.namespace)
+	if _, err := secrets.Create(ctx, &s, metav1.CreateOptions{}); err != nil {
+		if !request.Update || !k8serrors.IsAlreadyExists(err) {
+			return nil, errors.Wrapf(err, "failed to create secret")
+		}
+		// Only replace secrets with the same scope, so that a pipeline's
+		// owners can't overwrite cluster-wide secrets or other pipelines'.
+		existing, existingPipeline, err := a.getSecret(ctx, s.Name)
+		if err != nil {
+			return nil, err
+		}
+		if existing.Labels["secret-source"] != "pachyderm-user" {
+			return nil, errors.Errorf("secret %q exists and wasn't created by Pachyderm", s.Name)
+		}
+		if existingPipeline != pipeline {
+			return nil, errors.Errorf("secret %q exists and isn't scoped to the same pipeline", s.Name)
+		}
+		s.ResourceVersion = existing.ResourceVersion
+		if _, err := secrets.Update(ctx, &s, metav1.UpdateOptions{}); err != nil {
+			return nil, errors.Wrapf(err, "failed to update secret")
+		}
 	}
 	return &types.Empty{}, nil
 }
@@ -2773,6 +3531,13 @@ func (a *apiServer) DeleteSecret(ctx context.Context, request *pps.DeleteSecretR
 	metricsFn := metrics.ReportUserAction(ctx, a.reporter, "DeleteSecret")
 	defer func(start time.Time) { metricsFn(start, retErr) }(time.Now())
 
+	_, pipeline, err := a.getSecret(ctx, request.Secret.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.authorizeSecret(ctx, pipeline, auth.Permission_REPO_WRITE, auth.Permission_SECRET_DELETE); err != nil {
+		return nil, err
+	}
 	if err := a.env.KubeClient.CoreV1().Secrets(a.namespace).Delete(ctx, request.Secret.Name, metav1.DeleteOptions{}); err != nil {
 		return nil, errors.Wrapf(err, "failed to delete secret")
 	}
@@ -2784,48 +3549,38 @@ func (a *apiServer) InspectSecret(ctx context.Context, request *pps.InspectSecre
 	metricsFn := metrics.ReportUserAction(ctx, a.reporter, "InspectSecret")
 	defer func(start time.Time) { metricsFn(start, retErr) }(time.Now())
 
-	secret, err := a.env.KubeClient.CoreV1().Secrets(a.namespace).Get(ctx, request.Secret.Name, metav1.GetOptions{})
+	secret, pipeline, err := a.getSecret(ctx, request.Secret.Name)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get secret")
+		return nil, err
 	}
-	creationTimestamp := timestamppb.New(secret.GetCreationTimestamp().Time)
-	return &pps.SecretInfo{
-		Secret: &pps.Secret{
-			Name: secret.Name,
-		},
-		Type: string(secret.Type),
-		CreationTimestamp: &types.Timestamp{
-			Seconds: creationTimestamp.GetSeconds(),
-			Nanos:   creationTimestamp.GetNanos(),
-		},
-	}, nil
+	if err := a.authorizeSecret(ctx, pipeline, auth.Permission_REPO_READ, auth.Permission_SECRET_INSPECT); err != nil {
+		return nil, err
+	}
+	return newSecretInfo(secret), nil
 }
 
 // ListSecret implements the protobuf pps.ListSecret RPC
-func (a *apiServer) ListSecret(ctx context.Context, in *types.Empty) (response *pps.SecretInfos, retErr error) {
+func (a *apiServer) ListSecret(ctx context.Context, request *pps.ListSecretRequest) (response *pps.SecretInfos, retErr error) {
 	metricsFn := metrics.ReportUserAction(ctx, a.reporter, "ListSecret")
 	defer func(start time.Time) { metricsFn(start, retErr) }(time.Now())
 
+	pipeline := request.Pipeline.GetName()
+	if err := a.authorizeSecret(ctx, pipeline, auth.Permission_REPO_READ, auth.Permission_CLUSTER_LIST_SECRETS); err != nil {
+		return nil, err
+	}
+	selector := "secret-source=pachyderm-user"
+	if pipeline != "" {
+		selector += fmt.Sprintf(",%s=%s", secretPipelineLabel, pipeline)
+	}
 	secrets, err := a.env.KubeClient.CoreV1().Secrets(a.namespace).List(ctx, metav1.ListOptions{
-		LabelSelector: "secret-source=pachyderm-user",
+		LabelSelector: selector,
 	})
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to list secrets")
 	}
 	secretInfos := []*pps.SecretInfo{}
-	for _, s := range secrets.Items {
-		creationTimestamp := timestamppb.New(s.GetCreationTimestamp().Time)
-
-		secretInfos = append(secretInfos, &pps.SecretInfo{
-			Secret: &pps.Secret{
-				Name: s.Name,
-			},
-			Type: string(s.Type),
-			CreationTimestamp: &types.Timestamp{
-				Seconds: creationTimestamp.GetSeconds(),
-				Nanos:   creationTimestamp.GetNanos(),
-			},
-		})
+	for i := range secrets.Items {
+		secretInfos = append(secretInfos, newSecretInfo(&secrets.Items[i]))
 	}
 
 	return &pps.SecretInfos{
@@ -3068,9 +3823,25 @@ func (a *apiServer) RenderTemplate(ctx context.Context, req *pps.RenderTemplateR
 	default:
 		return nil, errors.Errorf("not a json object or list: %v", jsonResult)
 	}
+	parameters, err := pachtmpl.Parameters(req.Template, req.Args)
+	if err != nil {
+		return nil, err
+	}
+	for _, spec := range specs {
+		if len(parameters) > 0 {
+			spec.TemplateParameters = parameters
+		}
+		if req.Validate {
+			// initializePipelineInfo sets defaults in the request, so validate a copy
+			if _, err := a.initializePipelineInfo(proto.Clone(spec).(*pps.CreatePipelineRequest), nil); err != nil {
+				return nil, errors.Wrapf(err, "invalid spec for pipeline %q", spec.Pipeline.GetName())
+			}
+		}
+	}
 	return &pps.RenderTemplateResponse{
-		Json:  jsonResult,
-		Specs: specs,
+		Json:       jsonResult,
+		Specs:      specs,
+		Parameters: parameters,
 	}, nil
 }
 
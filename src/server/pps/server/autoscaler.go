@@ -0,0 +1,75 @@
+package server
+
+import (
+	"math"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// datumBacklog is the work a pipeline's unfinished jobs have left.
+type datumBacklog struct {
+	// pending is the number of datums that haven't been processed yet.
+	pending int64
+	// datumTime is the average time a worker spends on a datum, or 0 if it
+	// isn't known yet.
+	datumTime time.Duration
+}
+
+// getDatumBacklog returns the backlog of a pipeline's unfinished jobs. The
+// average datum time is measured on the most recent job that has processed
+// any datums, which may be a finished one.
+func getDatumBacklog(pachClient *client.APIClient, pipeline string) (datumBacklog, error) {
+	var b datumBacklog
+	err := pachClient.ListJobF(pipeline, nil, 0, false, func(ji *pps.JobInfo) error {
+		done := ji.DataProcessed + ji.DataFailed + ji.DataRecovered + ji.DataQuarantined
+		if !pps.IsTerminal(ji.State) {
+			if pending := ji.DataTotal - done - ji.DataSkipped; pending > 0 {
+				b.pending += pending
+			}
+		}
+		if b.datumTime == 0 && done > 0 {
+			workerTime := time.Duration(pps.GetJobUsage(ji).WorkerHours * float64(time.Hour))
+			b.datumTime = workerTime / time.Duration(done)
+		}
+		if pps.IsTerminal(ji.State) {
+			// jobs run in order, so older jobs are finished too
+			return errutil.ErrBreak
+		}
+		return nil
+	})
+	return b, err
+}
+
+// datumAutoscaleTarget returns how many workers a pipeline should run to
+// process backlog within its target completion time.
+func datumAutoscaleTarget(autoscaling *pps.DatumAutoscaling, backlog datumBacklog) int32 {
+	var target int64
+	switch {
+	case backlog.pending == 0:
+	case backlog.datumTime == 0:
+		// nothing is known about how long datums take, so process them all at
+		// once
+		target = backlog.pending
+	default:
+		work := float64(backlog.pending) * backlog.datumTime.Seconds()
+		target = int64(math.Ceil(work / autoscaling.TargetCompletionDuration().Seconds()))
+		if target > backlog.pending {
+			target = backlog.pending
+		}
+	}
+	// one worker always runs while the pipeline has jobs, as it computes them
+	minWorkers := autoscaling.MinWorkers
+	if minWorkers < 1 {
+		minWorkers = 1
+	}
+	if target < minWorkers {
+		target = minWorkers
+	}
+	if target > autoscaling.MaxWorkers {
+		target = autoscaling.MaxWorkers
+	}
+	return int32(target)
+}
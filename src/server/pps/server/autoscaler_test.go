@@ -0,0 +1,46 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestDatumAutoscaleTarget(t *testing.T) {
+	autoscaling := &pps.DatumAutoscaling{
+		MinWorkers:       2,
+		MaxWorkers:       10,
+		TargetCompletion: types.DurationProto(time.Minute),
+	}
+	// no pending datums: the minimum
+	require.Equal(t, int32(2), datumAutoscaleTarget(autoscaling, datumBacklog{}))
+	// unknown datum time: a worker per datum, up to the maximum
+	require.Equal(t, int32(5), datumAutoscaleTarget(autoscaling, datumBacklog{pending: 5}))
+	require.Equal(t, int32(10), datumAutoscaleTarget(autoscaling, datumBacklog{pending: 50}))
+	// 30 datums taking 10s each is 5 minutes of work, so 5 workers finish it
+	// in a minute
+	require.Equal(t, int32(5), datumAutoscaleTarget(autoscaling, datumBacklog{pending: 30, datumTime: 10 * time.Second}))
+	require.Equal(t, int32(6), datumAutoscaleTarget(autoscaling, datumBacklog{pending: 31, datumTime: 10 * time.Second}))
+	// quick datums: the minimum
+	require.Equal(t, int32(2), datumAutoscaleTarget(autoscaling, datumBacklog{pending: 30, datumTime: time.Second}))
+	// slow datums: the maximum
+	require.Equal(t, int32(10), datumAutoscaleTarget(autoscaling, datumBacklog{pending: 30, datumTime: time.Hour}))
+	// never more workers than datums
+	autoscaling.MinWorkers = 0
+	require.Equal(t, int32(3), datumAutoscaleTarget(autoscaling, datumBacklog{pending: 3, datumTime: time.Hour}))
+	// but always a worker to compute the jobs
+	require.Equal(t, int32(1), datumAutoscaleTarget(autoscaling, datumBacklog{}))
+}
+
+func TestValidateDatumAutoscaling(t *testing.T) {
+	require.NoError(t, pps.ValidateDatumAutoscaling(nil))
+	require.NoError(t, pps.ValidateDatumAutoscaling(&pps.DatumAutoscaling{MaxWorkers: 1}))
+	require.YesError(t, pps.ValidateDatumAutoscaling(&pps.DatumAutoscaling{}))
+	require.YesError(t, pps.ValidateDatumAutoscaling(&pps.DatumAutoscaling{MinWorkers: 3, MaxWorkers: 2}))
+	require.YesError(t, pps.ValidateDatumAutoscaling(&pps.DatumAutoscaling{MinWorkers: -1, MaxWorkers: 2}))
+	require.YesError(t, pps.ValidateDatumAutoscaling(&pps.DatumAutoscaling{MaxWorkers: 2, TargetCompletion: types.DurationProto(0)}))
+}
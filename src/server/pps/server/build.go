@@ -0,0 +1,109 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// resolveBuiltImage sets the image of a transform that sets image_from, but
+// not image, to the image most recently built by the build pipeline.
+func (a *apiServer) resolveBuiltImage(ctx context.Context, request *pps.CreatePipelineRequest) error {
+	from := request.Transform.GetImageFrom()
+	if from == "" || request.Transform.Image != "" {
+		return nil
+	}
+	image, err := latestBuiltImage(a.env.GetPachClient(ctx), from)
+	if err != nil {
+		return err
+	}
+	if image == "" {
+		return errors.Errorf("build pipeline %q hasn't built an image yet, wait for its first job or set transform.image", from)
+	}
+	request.Transform.Image = image
+	return nil
+}
+
+// latestBuiltImage returns the image built by the head of a build pipeline's
+// output branch, or "" if the head hasn't built one.
+func latestBuiltImage(pachClient *client.APIClient, pipeline string) (string, error) {
+	pipelineInfo, err := pachClient.InspectPipeline(pipeline, true)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not inspect image_from pipeline %q", pipeline)
+	}
+	if pipelineInfo.Details.Build == nil {
+		return "", errors.Errorf("image_from pipeline %q isn't a build pipeline", pipeline)
+	}
+	head, err := pachClient.InspectCommit(pipeline, pipelineInfo.Details.OutputBranch, "")
+	if err != nil {
+		return "", err
+	}
+	return builtImage(pachClient, head)
+}
+
+// builtImage returns the image built by the job of a build pipeline's output
+// commit, or "" if it didn't build one.
+func builtImage(pachClient *client.APIClient, commitInfo *pfs.CommitInfo) (string, error) {
+	if commitInfo.Finished == nil || commitInfo.Error != "" {
+		return "", nil
+	}
+	var buf bytes.Buffer
+	if err := pachClient.GetFile(commitInfo.Commit, pps.BuiltImageFile, &buf); err != nil {
+		if errutil.IsNotFoundError(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// followBuiltImages updates the image of a pipeline whose transform sets
+// image_from whenever the build pipeline builds a new image. Jobs record the
+// transform they ran, so each job's image can be traced back to the build
+// that produced it.
+func followBuiltImages(ctx context.Context, env Env, pipelineInfo *pps.PipelineInfo) error {
+	pachClient := env.GetPachClient(ctx)
+	from := pipelineInfo.Details.Transform.ImageFrom
+	buildInfo, err := pachClient.InspectPipeline(from, true)
+	if err != nil {
+		return err
+	}
+	branch := buildInfo.Details.OutputBranch
+	return errors.EnsureStack(pachClient.SubscribeCommit(client.NewRepo(from), branch, "", pfs.CommitState_FINISHED, func(ci *pfs.CommitInfo) error {
+		// Only the head of the branch is followed, so replaying the branch's
+		// history when the subscription restarts doesn't roll the image back.
+		head, err := pachClient.InspectCommit(from, branch, "")
+		if err != nil {
+			return err
+		}
+		if head.Commit.ID != ci.Commit.ID {
+			return nil
+		}
+		image, err := builtImage(pachClient, ci)
+		if err != nil || image == "" {
+			return err
+		}
+		current, err := pachClient.InspectPipeline(pipelineInfo.Pipeline.Name, true)
+		if err != nil {
+			return err
+		}
+		if current.Details.Transform.Image == image {
+			return nil
+		}
+		log.Infof("PPS master: updating pipeline %q to image %s built by %s", pipelineInfo.Pipeline.Name, image, ci.Commit)
+		request := ppsutil.PipelineReqFromInfo(current)
+		request.Transform.Image = image
+		request.Update = true
+		_, err = pachClient.PpsAPIClient.CreatePipeline(pachClient.Ctx(), request)
+		return errors.EnsureStack(err)
+	}))
+}
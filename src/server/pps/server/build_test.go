@@ -0,0 +1,58 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestBuildPipelineDefaults(t *testing.T) {
+	pipelineInfo := &pps.PipelineInfo{
+		Pipeline: &pps.Pipeline{Name: "edges-build"},
+		Details: &pps.PipelineInfo_Details{
+			Input: &pps.Input{Pfs: &pps.PFSInput{Repo: "edges-src", Glob: "/"}},
+			Build: &pps.Build{
+				Destination: "registry.example.com/team/edges",
+				Dockerfile:  "docker/Dockerfile",
+				Secret:      "registry-creds",
+				BuildArgs:   map[string]string{"VERSION": "it's 1"},
+			},
+			Transform: &pps.Transform{
+				Image: "ignored",
+				Cmd:   []string{"ignored"},
+				Env:   map[string]string{"HTTP_PROXY": "proxy:3128"},
+			},
+		},
+	}
+	require.NoError(t, pps.ValidateBuild(pipelineInfo.Details.Build, pipelineInfo.Details.Input))
+	require.NoError(t, setPipelineDefaults(pipelineInfo))
+	transform := pipelineInfo.Details.Transform
+	require.Equal(t, pps.DefaultBuilderImage, transform.Image)
+	require.Equal(t, []string{"/busybox/sh"}, transform.Cmd)
+	require.Equal(t, "proxy:3128", transform.Env["HTTP_PROXY"])
+	require.Equal(t, 1, len(transform.Secrets))
+	require.Equal(t, "registry-creds", transform.Secrets[0].Name)
+	script := strings.Join(transform.Stdin, "\n")
+	for _, arg := range []string{
+		"'--context=dir:///pfs/edges-src'",
+		"'--dockerfile=/pfs/edges-src/docker/Dockerfile'",
+		`'--destination=registry.example.com/team/edges':"$PACH_JOB_ID"`,
+		"'--image-name-with-digest-file=/pfs/out/image'",
+		`'--build-arg=VERSION=it'\''s 1'`,
+	} {
+		require.True(t, strings.Contains(script, arg), "%q isn't in %q", arg, script)
+	}
+}
+
+func TestValidateBuild(t *testing.T) {
+	input := &pps.Input{Pfs: &pps.PFSInput{Repo: "src", Glob: "/"}}
+	require.NoError(t, pps.ValidateBuild(&pps.Build{Destination: "example.com/app"}, input))
+	require.YesError(t, pps.ValidateBuild(&pps.Build{}, input))
+	require.YesError(t, pps.ValidateBuild(&pps.Build{Destination: "example.com/app:v1"}, input))
+	require.YesError(t, pps.ValidateBuild(&pps.Build{Destination: "example.com/app@sha256:abc"}, input))
+	require.YesError(t, pps.ValidateBuild(&pps.Build{Destination: "example.com/app", Dockerfile: "../Dockerfile"}, input))
+	require.YesError(t, pps.ValidateBuild(&pps.Build{Destination: "example.com/app"}, &pps.Input{Pfs: &pps.PFSInput{Repo: "src", Glob: "/*"}}))
+	require.YesError(t, pps.ValidateBuild(&pps.Build{Destination: "example.com/app"}, &pps.Input{Cross: []*pps.Input{input, input}}))
+}
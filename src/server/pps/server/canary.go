@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// canaryBranch is the branch of a canary's output repo that its output is
+// committed to.
+const canaryBranch = "canary"
+
+// canaryPipelineName returns the name of the canary of the named pipeline.
+func canaryPipelineName(pipelineName string) string {
+	return pipelineName + "-canary"
+}
+
+// validateCanary validates the canary spec of request. Canaries process a
+// sample of the pipeline's datums, so pipelines without datums can't have
+// them. They also can't have inputs whose repos belong to the pipeline, which
+// are deleted with the canary, or send their output anywhere but their
+// output repo.
+func validateCanary(request *pps.CreatePipelineRequest) error {
+	if fraction := request.Canary.Fraction; !(fraction > 0 && fraction <= 1) {
+		return errors.Errorf("fraction must be greater than 0 and at most 1, not %v", fraction)
+	}
+	if request.Spout != nil || request.Service != nil {
+		return errors.Errorf("spouts and services can't have canaries (they don't process datums)")
+	}
+	if request.Egress != nil || request.ModelRegistry != nil {
+		return errors.Errorf("canaries can't use egress or model_registry (their output must stay in their output repo)")
+	}
+	return pps.VisitInput(request.Input, func(input *pps.Input) error {
+		if input.Cron != nil || input.Pfs != nil && (input.Pfs.Remote != nil || input.Pfs.Sql != nil) {
+			return errors.Errorf("canaries can't have cron, mirror or SQL ingest inputs (their repos belong to the pipeline)")
+		}
+		return nil
+	})
+}
+
+// createCanaryInTransaction creates or updates the canary of the pipeline
+// named in request, which must exist, from the rest of request.
+func (a *apiServer) createCanaryInTransaction(txnCtx *txncontext.TransactionContext, request *pps.CreatePipelineRequest) error {
+	pipelineName := request.Pipeline.Name
+	pipelineInfo, err := a.InspectPipelineInTransaction(txnCtx, pipelineName)
+	if err != nil {
+		if errutil.IsNotFoundError(err) {
+			return errors.Errorf("pipeline %q must exist before it can have a canary", pipelineName)
+		}
+		return err
+	}
+	if pipelineInfo.Details.Canary != nil {
+		return errors.Errorf("pipeline %q is a canary, and can't have a canary of its own", pipelineName)
+	}
+	canaryName := canaryPipelineName(pipelineName)
+	if canaryInfo, err := a.InspectPipelineInTransaction(txnCtx, canaryName); err == nil && canaryInfo.Details.Canary == nil {
+		return errors.Errorf("pipeline %q already exists, and isn't the canary of %q", canaryName, pipelineName)
+	} else if err != nil && !errutil.IsNotFoundError(err) {
+		return err
+	}
+	request = proto.Clone(request).(*pps.CreatePipelineRequest)
+	request.Pipeline = client.NewPipeline(canaryName)
+	request.OutputBranch = canaryBranch
+	request.Update = true
+	return a.createPipelineInTransaction(txnCtx, request)
+}
+
+// FinishCanary implements the protobuf pps.FinishCanary RPC
+func (a *apiServer) FinishCanary(ctx context.Context, request *pps.FinishCanaryRequest) (*types.Empty, error) {
+	if request.Pipeline == nil {
+		return nil, errors.New("request.Pipeline cannot be nil")
+	}
+	canary := client.NewPipeline(canaryPipelineName(request.Pipeline.Name))
+	if err := a.txnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		canaryInfo, err := a.InspectPipelineInTransaction(txnCtx, canary.Name)
+		if err != nil {
+			if errutil.IsNotFoundError(err) {
+				return errors.Errorf("pipeline %q has no canary", request.Pipeline.Name)
+			}
+			return err
+		}
+		if canaryInfo.Details.Canary == nil {
+			return errors.Errorf("pipeline %q isn't the canary of %q", canary.Name, request.Pipeline.Name)
+		}
+		if request.Promote {
+			pipelineInfo, err := a.InspectPipelineInTransaction(txnCtx, request.Pipeline.Name)
+			if err != nil {
+				return err
+			}
+			spec := ppsutil.PipelineReqFromInfo(canaryInfo)
+			spec.Pipeline = request.Pipeline
+			spec.OutputBranch = pipelineInfo.Details.OutputBranch
+			spec.Canary = nil
+			spec.Update = true
+			if err := a.createPipelineInTransaction(txnCtx, spec); err != nil {
+				return errors.Wrapf(err, "could not update pipeline %q to its canary's spec", request.Pipeline.Name)
+			}
+		}
+		return a.DeletePipelineInTransaction(txnCtx, &pps.DeletePipelineRequest{Pipeline: canary})
+	}); err != nil {
+		return nil, err
+	}
+	clearJobCache(a.env.GetPachClient(ctx), canary.Name)
+	return &types.Empty{}, nil
+}
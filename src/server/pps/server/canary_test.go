@@ -0,0 +1,27 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestValidateCanary(t *testing.T) {
+	valid := func(fraction float64, input *pps.Input) error {
+		return validateCanary(&pps.CreatePipelineRequest{
+			Canary: &pps.CanarySpec{Fraction: fraction},
+			Input:  input,
+		})
+	}
+	require.NoError(t, valid(0.1, client.NewPFSInput("images", "/*")))
+	require.NoError(t, valid(1, client.NewCrossInput(client.NewPFSInput("a", "/*"), client.NewPFSInput("b", "/*"))))
+	require.YesError(t, valid(0, client.NewPFSInput("images", "/*")))
+	require.YesError(t, valid(1.5, client.NewPFSInput("images", "/*")))
+	require.YesError(t, valid(0.1, client.NewCronInput("tick", "@every 1m")))
+	require.YesError(t, validateCanary(&pps.CreatePipelineRequest{
+		Canary:  &pps.CanarySpec{Fraction: 0.1},
+		Service: &pps.Service{},
+	}))
+}
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+const (
+	// pachInitContainerName is the name of the init container that copies
+	// Pachyderm's binaries into worker pods.
+	pachInitContainerName = "init"
+	// sharedVolumePrefix prefixes the names of pipelines' shared volumes in
+	// their worker pods, so they can't collide with Pachyderm's own volumes.
+	sharedVolumePrefix = "shared-"
+)
+
+// reservedMountPaths are the paths Pachyderm mounts volumes at in the user
+// container, which shared volumes can't be mounted at or under.
+var reservedMountPaths = []string{client.PPSInputPrefix, "/pach-bin"}
+
+// validateContainers checks that a pipeline's sidecars, init containers and
+// shared volumes can be added to its worker pods.
+func validateContainers(details *pps.PipelineInfo_Details, sharing ppsutil.GPUSharing) error {
+	names := map[string]bool{
+		client.PPSWorkerUserContainerName:    true,
+		client.PPSWorkerSidecarContainerName: true,
+		pachInitContainerName:                true,
+	}
+	for field, containers := range map[string][]*pps.ContainerSpec{
+		"sidecars":        details.Sidecars,
+		"init_containers": details.InitContainers,
+	} {
+		for i, c := range containers {
+			if err := validateContainerSpec(c, sharing); err != nil {
+				return errors.Wrapf(err, "invalid %s[%d]", field, i)
+			}
+			if names[c.Name] {
+				return errors.Errorf("invalid %s[%d]: the container name %q is already in use", field, i, c.Name)
+			}
+			names[c.Name] = true
+		}
+	}
+
+	volumeNames := make(map[string]bool)
+	mountPaths := make(map[string]bool)
+	for i, v := range details.SharedVolumes {
+		if errs := validation.IsDNS1123Label(sharedVolumePrefix + v.Name); v.Name == "" || len(errs) > 0 {
+			return errors.Errorf("invalid shared_volumes[%d]: invalid name %q: %s", i, v.Name, strings.Join(errs, ", "))
+		}
+		if volumeNames[v.Name] {
+			return errors.Errorf("invalid shared_volumes[%d]: the name %q is already in use", i, v.Name)
+		}
+		volumeNames[v.Name] = true
+		if !path.IsAbs(v.MountPath) || path.Clean(v.MountPath) != v.MountPath || v.MountPath == "/" {
+			return errors.Errorf("invalid shared_volumes[%d]: mount_path must be a clean, absolute path, got %q", i, v.MountPath)
+		}
+		for _, reserved := range reservedMountPaths {
+			if v.MountPath == reserved || strings.HasPrefix(v.MountPath, reserved+"/") {
+				return errors.Errorf("invalid shared_volumes[%d]: mount_path %q is reserved by Pachyderm", i, v.MountPath)
+			}
+		}
+		if mountPaths[v.MountPath] {
+			return errors.Errorf("invalid shared_volumes[%d]: mount_path %q is already in use", i, v.MountPath)
+		}
+		mountPaths[v.MountPath] = true
+	}
+	return nil
+}
+
+func validateContainerSpec(c *pps.ContainerSpec, sharing ppsutil.GPUSharing) error {
+	if c == nil {
+		return errors.New("container can't be empty")
+	}
+	if errs := validation.IsDNS1123Label(c.Name); len(errs) > 0 {
+		return errors.Errorf("invalid name %q: %s", c.Name, strings.Join(errs, ", "))
+	}
+	if c.Image == "" {
+		return errors.New("image must be set")
+	}
+	for _, port := range c.Ports {
+		if port < 1 || port > 65535 {
+			return errors.Errorf("invalid port %d", port)
+		}
+	}
+	for name, spec := range map[string]*pps.ResourceSpec{
+		"resource_requests": c.ResourceRequests,
+		"resource_limits":   c.ResourceLimits,
+	} {
+		if gpu := spec.GetGpu(); gpu != nil {
+			if err := pps.ValidateGPUSpec(gpu); err != nil {
+				return errors.Wrapf(err, "invalid %s.gpu", name)
+			}
+			if _, _, err := ppsutil.GPUResource(gpu, sharing); err != nil {
+				return errors.Wrapf(err, "invalid %s.gpu", name)
+			}
+		}
+	}
+	return nil
+}
+
+// sharedVolumes returns the volumes for a pipeline's shared volumes, and the
+// mounts every container in its worker pods needs for them.
+func sharedVolumes(details *pps.PipelineInfo_Details) ([]v1.Volume, []v1.VolumeMount) {
+	var volumes []v1.Volume
+	var mounts []v1.VolumeMount
+	for _, v := range details.SharedVolumes {
+		volumes = append(volumes, v1.Volume{
+			Name: sharedVolumePrefix + v.Name,
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{},
+			},
+		})
+		mounts = append(mounts, v1.VolumeMount{
+			Name:      sharedVolumePrefix + v.Name,
+			MountPath: v.MountPath,
+		})
+	}
+	return volumes, mounts
+}
+
+// extraContainers converts a pipeline's sidecars or init containers into
+// containers for its worker pods, each of which mounts mounts.
+func extraContainers(specs []*pps.ContainerSpec, mounts []v1.VolumeMount, pullPolicy v1.PullPolicy, sharing ppsutil.GPUSharing) ([]v1.Container, error) {
+	var result []v1.Container
+	for _, spec := range specs {
+		container := v1.Container{
+			Name:            spec.Name,
+			Image:           spec.Image,
+			Command:         spec.Cmd,
+			ImagePullPolicy: pullPolicy,
+			VolumeMounts:    mounts,
+		}
+		for name, value := range spec.Env {
+			container.Env = append(container.Env, v1.EnvVar{Name: name, Value: value})
+		}
+		sort.Slice(container.Env, func(i, j int) bool { return container.Env[i].Name < container.Env[j].Name })
+		for _, port := range spec.Ports {
+			container.Ports = append(container.Ports, v1.ContainerPort{ContainerPort: port})
+		}
+		if spec.ResourceRequests != nil {
+			requests, err := ppsutil.GetRequestsResourceList(spec.ResourceRequests, sharing)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not parse resource requests of container %q", spec.Name)
+			}
+			container.Resources.Requests = *requests
+		}
+		if spec.ResourceLimits != nil {
+			limits, err := ppsutil.GetLimitsResourceList(spec.ResourceLimits, sharing)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not parse resource limits of container %q", spec.Name)
+			}
+			container.Resources.Limits = *limits
+		}
+		result = append(result, container)
+	}
+	return result, nil
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestValidateContainers(t *testing.T) {
+	sharing := ppsutil.GPUSharing{}
+	exporter := &pps.ContainerSpec{Name: "exporter", Image: "prom/exporter", Ports: []int32{9100}}
+	fetch := &pps.ContainerSpec{Name: "fetch-model", Image: "busybox", Cmd: []string{"wget", "model"}}
+	models := &pps.SharedVolume{Name: "models", MountPath: "/models"}
+	require.NoError(t, validateContainers(&pps.PipelineInfo_Details{}, sharing))
+	require.NoError(t, validateContainers(&pps.PipelineInfo_Details{
+		Sidecars:       []*pps.ContainerSpec{exporter},
+		InitContainers: []*pps.ContainerSpec{fetch},
+		SharedVolumes:  []*pps.SharedVolume{models},
+	}, sharing))
+
+	for _, details := range []*pps.PipelineInfo_Details{
+		// names that Pachyderm uses, or that are used twice
+		{Sidecars: []*pps.ContainerSpec{{Name: "storage", Image: "busybox"}}},
+		{InitContainers: []*pps.ContainerSpec{{Name: "init", Image: "busybox"}}},
+		{Sidecars: []*pps.ContainerSpec{exporter}, InitContainers: []*pps.ContainerSpec{exporter}},
+		// invalid containers
+		{Sidecars: []*pps.ContainerSpec{{Name: "Exporter", Image: "busybox"}}},
+		{Sidecars: []*pps.ContainerSpec{{Name: "exporter"}}},
+		{Sidecars: []*pps.ContainerSpec{{Name: "exporter", Image: "busybox", Ports: []int32{70000}}}},
+		{Sidecars: []*pps.ContainerSpec{{Name: "exporter", Image: "busybox", ResourceLimits: &pps.ResourceSpec{Gpu: &pps.GPUSpec{Fraction: 0.5}}}}},
+		// invalid shared volumes
+		{SharedVolumes: []*pps.SharedVolume{models, models}},
+		{SharedVolumes: []*pps.SharedVolume{models, {Name: "other", MountPath: "/models"}}},
+		{SharedVolumes: []*pps.SharedVolume{{Name: "models", MountPath: "models"}}},
+		{SharedVolumes: []*pps.SharedVolume{{Name: "models", MountPath: "/pfs/models"}}},
+		{SharedVolumes: []*pps.SharedVolume{{Name: "Models", MountPath: "/models"}}},
+	} {
+		require.YesError(t, validateContainers(details, sharing))
+	}
+}
+
+func TestExtraContainers(t *testing.T) {
+	details := &pps.PipelineInfo_Details{
+		Sidecars: []*pps.ContainerSpec{{
+			Name:           "exporter",
+			Image:          "prom/exporter",
+			Env:            map[string]string{"B": "2", "A": "1"},
+			Ports:          []int32{9100},
+			ResourceLimits: &pps.ResourceSpec{Memory: "1G"},
+		}},
+		SharedVolumes: []*pps.SharedVolume{{Name: "models", MountPath: "/models"}},
+	}
+	volumes, mounts := sharedVolumes(details)
+	require.Equal(t, 1, len(volumes))
+	require.Equal(t, "shared-models", volumes[0].Name)
+	require.NotNil(t, volumes[0].EmptyDir)
+	require.Equal(t, []v1.VolumeMount{{Name: "shared-models", MountPath: "/models"}}, mounts)
+
+	containers, err := extraContainers(details.Sidecars, mounts, v1.PullIfNotPresent, ppsutil.GPUSharing{})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(containers))
+	c := containers[0]
+	require.Equal(t, "exporter", c.Name)
+	require.Equal(t, "prom/exporter", c.Image)
+	require.Equal(t, []v1.EnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "2"}}, c.Env)
+	require.Equal(t, []v1.ContainerPort{{ContainerPort: 9100}}, c.Ports)
+	require.Equal(t, mounts, c.VolumeMounts)
+	require.Equal(t, "1G", c.Resources.Limits.Memory().String())
+	require.Nil(t, c.Resources.Requests)
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// CordonPipeline implements the protobuf pps.CordonPipeline RPC
+func (a *apiServer) CordonPipeline(ctx context.Context, request *pps.CordonPipelineRequest) (response *types.Empty, retErr error) {
+	if err := a.txnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		cordon := &pfs.Cordon{Reason: request.Reason, Since: txnCtx.Timestamp}
+		if whoAmI, err := txnCtx.WhoAmI(); err == nil {
+			cordon.CordonedBy = whoAmI.Username
+		}
+		return a.setPipelineCordonInTransaction(txnCtx, request.Pipeline, cordon)
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// UncordonPipeline implements the protobuf pps.UncordonPipeline RPC
+func (a *apiServer) UncordonPipeline(ctx context.Context, request *pps.UncordonPipelineRequest) (response *types.Empty, retErr error) {
+	if err := a.txnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		return a.setPipelineCordonInTransaction(txnCtx, request.Pipeline, nil)
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// setPipelineCordonInTransaction cordons pipeline with cordon, or uncordons it
+// if cordon is nil. The pipeline's workers watch its PipelineInfo, and don't
+// start new jobs while it's cordoned.
+func (a *apiServer) setPipelineCordonInTransaction(txnCtx *txncontext.TransactionContext, pipeline *pps.Pipeline, cordon *pfs.Cordon) error {
+	if pipeline == nil {
+		return errors.New("request.Pipeline cannot be nil")
+	}
+	pipelineInfo, err := a.InspectPipelineInTransaction(txnCtx, pipeline.Name)
+	if err != nil {
+		return err
+	}
+	if err := a.authorizePipelineOpInTransaction(txnCtx, pipelineOpStartStop, pipelineInfo.Details.Input, pipelineInfo.Pipeline.Name); err != nil {
+		return err
+	}
+	if pipelineInfo.Details.Service != nil {
+		return errors.Errorf("pipeline %q can't be cordoned, as services don't run jobs", pipelineInfo.Pipeline.Name)
+	}
+	if pipelineInfo.Details.Spout != nil {
+		return errors.Errorf("pipeline %q can't be cordoned, as spouts don't run jobs; cordon its output repo to stop its commits", pipelineInfo.Pipeline.Name)
+	}
+	newPipelineInfo := &pps.PipelineInfo{}
+	return a.updatePipeline(txnCtx, pipelineInfo.Pipeline.Name, newPipelineInfo, func() error {
+		newPipelineInfo.Cordon = cordon
+		return nil
+	})
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"sort"
+
+	"golang.org/x/net/context"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// GetDAG implements the protobuf pps.GetDAG RPC
+func (a *apiServer) GetDAG(ctx context.Context, request *pps.GetDAGRequest) (*pps.DAG, error) {
+	pachClient := a.env.GetPachClient(ctx)
+	var repoInfos []*pfs.RepoInfo
+	var err error
+	if request.Project != nil {
+		repoInfos, err = pachClient.ListProjectRepo(pfsdb.ProjectName(request.Project))
+	} else {
+		repoInfos, err = pachClient.ListRepo()
+	}
+	if err != nil {
+		return nil, err
+	}
+	var pipelineInfos []*pps.PipelineInfo
+	if err := a.listPipeline(ctx, &pps.ListPipelineRequest{Project: request.Project}, func(pipelineInfo *pps.PipelineInfo) error {
+		pipelineInfos = append(pipelineInfos, pipelineInfo)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return buildDAG(repoInfos, pipelineInfos), nil
+}
+
+// buildDAG returns the DAG of repos and pipelines. The repos pipelines read
+// that aren't in repoInfos, such as those in other projects, are added
+// without their size.
+func buildDAG(repoInfos []*pfs.RepoInfo, pipelineInfos []*pps.PipelineInfo) *pps.DAG {
+	nodes := make(map[string]*pps.DAGNode)
+	repoNode := func(name string) *pps.DAGNode {
+		id := "repo:" + name
+		node, ok := nodes[id]
+		if !ok {
+			node = &pps.DAGNode{ID: id, Type: pps.DAGNode_REPO, Name: name}
+			nodes[id] = node
+		}
+		return node
+	}
+	for _, repoInfo := range repoInfos {
+		repoNode(repoInfo.Repo.Name).SizeBytes = repoInfo.SizeBytesUpperBound
+	}
+	dag := &pps.DAG{}
+	// a pipeline can read the same branch more than once, e.g. to join it
+	// with itself, but it's one edge
+	seen := make(map[[3]string]bool)
+	addEdge := func(from, to, branch string) {
+		key := [3]string{from, to, branch}
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		dag.Edges = append(dag.Edges, &pps.DAGEdge{From: from, To: to, Branch: branch})
+	}
+	for _, pipelineInfo := range pipelineInfos {
+		node := &pps.DAGNode{
+			ID:           "pipeline:" + pipelineInfo.Pipeline.Name,
+			Type:         pps.DAGNode_PIPELINE,
+			Name:         pipelineInfo.Pipeline.Name,
+			State:        pipelineInfo.State,
+			LastJobState: pipelineInfo.LastJobState,
+			PipelineType: pipelineInfo.Type,
+		}
+		nodes[node.ID] = node
+		pps.VisitInput(pipelineInfo.GetDetails().GetInput(), func(input *pps.Input) error {
+			switch {
+			case input.Pfs != nil:
+				addEdge(repoNode(input.Pfs.Repo).ID, node.ID, input.Pfs.Branch)
+			case input.Cron != nil:
+				// cron ticks are committed to the master branch of the
+				// cron's repo
+				addEdge(repoNode(input.Cron.Repo).ID, node.ID, "master")
+			}
+			return nil
+		})
+		addEdge(node.ID, repoNode(pipelineInfo.Pipeline.Name).ID, pipelineInfo.GetDetails().GetOutputBranch())
+	}
+	for _, node := range nodes {
+		dag.Nodes = append(dag.Nodes, node)
+	}
+	sort.Slice(dag.Nodes, func(i, j int) bool { return dag.Nodes[i].ID < dag.Nodes[j].ID })
+	sort.Slice(dag.Edges, func(i, j int) bool {
+		if dag.Edges[i].From != dag.Edges[j].From {
+			return dag.Edges[i].From < dag.Edges[j].From
+		}
+		if dag.Edges[i].To != dag.Edges[j].To {
+			return dag.Edges[i].To < dag.Edges[j].To
+		}
+		return dag.Edges[i].Branch < dag.Edges[j].Branch
+	})
+	return dag
+}
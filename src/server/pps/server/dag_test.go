@@ -0,0 +1,55 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestBuildDAG(t *testing.T) {
+	repoInfos := []*pfs.RepoInfo{
+		{Repo: client.NewRepo("images"), SizeBytesUpperBound: 100},
+		{Repo: client.NewRepo("edges"), SizeBytesUpperBound: 10},
+	}
+	pipelineInfos := []*pps.PipelineInfo{{
+		Pipeline:     client.NewPipeline("edges"),
+		State:        pps.PipelineState_PIPELINE_RUNNING,
+		LastJobState: pps.JobState_JOB_SUCCESS,
+		Type:         pps.PipelineInfo_PIPELINE_TYPE_TRANSFORM,
+		Details: &pps.PipelineInfo_Details{
+			Input: client.NewCrossInput(
+				client.NewPFSInputOpts("", "images", "master", "/*", "", "", false, false, nil),
+				client.NewPFSInputOpts("", "images", "master", "/", "", "", false, false, nil),
+				client.NewPFSInputOpts("labels", "images", "staging", "/*", "", "", false, false, nil),
+				&pps.Input{Cron: &pps.CronInput{Name: "tick", Repo: "edges_tick"}},
+			),
+			OutputBranch: "master",
+		},
+	}}
+	dag := buildDAG(repoInfos, pipelineInfos)
+
+	var ids []string
+	for _, node := range dag.Nodes {
+		ids = append(ids, node.ID)
+	}
+	require.Equal(t, []string{"pipeline:edges", "repo:edges", "repo:edges_tick", "repo:images"}, ids)
+	require.Equal(t, pps.DAGNode_PIPELINE, dag.Nodes[0].Type)
+	require.Equal(t, pps.JobState_JOB_SUCCESS, dag.Nodes[0].LastJobState)
+	require.Equal(t, int64(100), dag.Nodes[3].SizeBytes)
+	// the cron repo isn't in repoInfos, so it has no size
+	require.Equal(t, int64(0), dag.Nodes[2].SizeBytes)
+
+	var edges []string
+	for _, edge := range dag.Edges {
+		edges = append(edges, edge.From+" -> "+edge.To+" @ "+edge.Branch)
+	}
+	require.Equal(t, []string{
+		"pipeline:edges -> repo:edges @ master",
+		"repo:edges_tick -> pipeline:edges @ master",
+		"repo:images -> pipeline:edges @ master",
+		"repo:images -> pipeline:edges @ staging",
+	}, edges)
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// InspectDatumCache implements the protobuf pps.InspectDatumCache RPC
+func (a *apiServer) InspectDatumCache(ctx context.Context, request *pps.InspectDatumCacheRequest) (response *pps.DatumCacheInfo, retErr error) {
+	if request.Pipeline == nil || request.Pipeline.Name == "" {
+		return nil, errors.New("must specify a pipeline")
+	}
+	if err := a.authorizePipelineOp(ctx, pipelineOpListDatum, nil, request.Pipeline.Name); err != nil {
+		return nil, err
+	}
+	if _, err := a.inspectPipeline(ctx, request.Pipeline.Name, false); err != nil {
+		return nil, err
+	}
+	pachClient := a.env.GetPachClient(ctx)
+	resp, err := pachClient.PfsAPIClient.InspectCache(pachClient.Ctx(), &pfs.InspectCacheRequest{
+		TagPrefix: ppsutil.DatumCacheTag(request.Pipeline.Name),
+	})
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return &pps.DatumCacheInfo{
+		Pipeline: request.Pipeline,
+		Entries:  resp.Entries,
+	}, nil
+}
+
+// ClearDatumCache implements the protobuf pps.ClearDatumCache RPC
+func (a *apiServer) ClearDatumCache(ctx context.Context, request *pps.ClearDatumCacheRequest) (response *types.Empty, retErr error) {
+	if request.Pipeline == nil || request.Pipeline.Name == "" {
+		return nil, errors.New("must specify a pipeline")
+	}
+	if err := a.authorizePipelineOp(ctx, pipelineOpStartStop, nil, request.Pipeline.Name); err != nil {
+		return nil, err
+	}
+	if _, err := a.inspectPipeline(ctx, request.Pipeline.Name, false); err != nil {
+		return nil, err
+	}
+	pachClient := a.env.GetPachClient(ctx)
+	if _, err := pachClient.PfsAPIClient.ClearCache(pachClient.Ctx(), &pfs.ClearCacheRequest{
+		TagPrefix: ppsutil.DatumCacheTag(request.Pipeline.Name),
+	}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return &types.Empty{}, nil
+}
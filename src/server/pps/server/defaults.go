@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	adminserver "github.com/pachyderm/pachyderm/v2/src/server/admin/server"
+)
+
+// applyClusterDefaults merges the cluster's defaults into request.
+func (a *apiServer) applyClusterDefaults(ctx context.Context, request *pps.CreatePipelineRequest) error {
+	defaults, err := adminserver.GetClusterDefaults(ctx, a.clusterDefaults)
+	if err != nil {
+		return err
+	}
+	mergeClusterDefaults(request, defaults)
+	return nil
+}
+
+// mergeClusterDefaults sets the fields of request that it doesn't set itself
+// to their values in defaults.
+func mergeClusterDefaults(request *pps.CreatePipelineRequest, defaults *admin.ClusterDefaults) {
+	request.ResourceRequests = mergeResourceSpec(request.ResourceRequests, defaults.ResourceRequests)
+	request.ResourceLimits = mergeResourceSpec(request.ResourceLimits, defaults.ResourceLimits)
+	request.SidecarResourceLimits = mergeResourceSpec(request.SidecarResourceLimits, defaults.SidecarResourceLimits)
+	if request.DatumTimeout == nil {
+		request.DatumTimeout = defaults.DatumTimeout
+	}
+	if request.JobTimeout == nil {
+		request.JobTimeout = defaults.JobTimeout
+	}
+	if request.DatumTries == 0 {
+		request.DatumTries = defaults.DatumTries
+	}
+	if request.DatumRetryPolicy == nil && defaults.DatumRetryPolicy != nil {
+		request.DatumRetryPolicy = proto.Clone(defaults.DatumRetryPolicy).(*pps.DatumRetryPolicy)
+	}
+}
+
+// mergeResourceSpec returns spec with each of its unset fields set to the
+// field's value in defaults.
+func mergeResourceSpec(spec, defaults *pps.ResourceSpec) *pps.ResourceSpec {
+	if defaults == nil {
+		return spec
+	}
+	if spec == nil {
+		return proto.Clone(defaults).(*pps.ResourceSpec)
+	}
+	if spec.Cpu == 0 {
+		spec.Cpu = defaults.Cpu
+	}
+	if spec.Memory == "" {
+		spec.Memory = defaults.Memory
+	}
+	if spec.Gpu == nil && defaults.Gpu != nil {
+		spec.Gpu = proto.Clone(defaults.Gpu).(*pps.GPUSpec)
+	}
+	if spec.Disk == "" {
+		spec.Disk = defaults.Disk
+	}
+	return spec
+}
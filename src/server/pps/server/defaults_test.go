@@ -0,0 +1,35 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestMergeClusterDefaults(t *testing.T) {
+	defaults := &admin.ClusterDefaults{
+		ResourceRequests: &pps.ResourceSpec{Cpu: 1, Memory: "1G"},
+		ResourceLimits:   &pps.ResourceSpec{Memory: "2G"},
+		JobTimeout:       types.DurationProto(3600e9),
+		DatumTries:       5,
+	}
+	request := &pps.CreatePipelineRequest{
+		ResourceRequests: &pps.ResourceSpec{Memory: "4G"},
+		DatumTries:       2,
+	}
+	mergeClusterDefaults(request, defaults)
+	require.Equal(t, &pps.ResourceSpec{Cpu: 1, Memory: "4G"}, request.ResourceRequests)
+	require.Equal(t, &pps.ResourceSpec{Memory: "2G"}, request.ResourceLimits)
+	require.Nil(t, request.SidecarResourceLimits)
+	require.Equal(t, defaults.JobTimeout, request.JobTimeout)
+	require.Nil(t, request.DatumTimeout)
+	require.Equal(t, int64(2), request.DatumTries)
+
+	// the defaults are copied, not shared with the request
+	request.ResourceLimits.Cpu = 2
+	require.Equal(t, float32(0), defaults.ResourceLimits.Cpu)
+}
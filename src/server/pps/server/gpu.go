@@ -0,0 +1,41 @@
+package server
+
+import (
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/serviceenv"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// gpuSharing returns how the cluster's GPUs are time-sliced, according to
+// pachd's config.
+func gpuSharing(config serviceenv.Configuration) ppsutil.GPUSharing {
+	if config.PachdSpecificConfiguration == nil {
+		return ppsutil.GPUSharing{}
+	}
+	return ppsutil.GPUSharing{
+		Replicas: config.GPUSharedReplicas,
+		Resource: config.GPUSharedResource,
+	}
+}
+
+// validateGPUs checks that the GPUs a pipeline requests are valid, and can
+// be requested from the cluster.
+func validateGPUs(details *pps.PipelineInfo_Details, sharing ppsutil.GPUSharing) error {
+	for name, spec := range map[string]*pps.ResourceSpec{
+		"resource_requests": details.ResourceRequests,
+		"resource_limits":   details.ResourceLimits,
+	} {
+		gpu := spec.GetGpu()
+		if gpu == nil {
+			continue
+		}
+		if err := pps.ValidateGPUSpec(gpu); err != nil {
+			return errors.Wrapf(err, "invalid %s.gpu", name)
+		}
+		if _, _, err := ppsutil.GPUResource(gpu, sharing); err != nil {
+			return errors.Wrapf(err, "invalid %s.gpu", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestValidateGPUs(t *testing.T) {
+	sharing := ppsutil.GPUSharing{Replicas: 4, Resource: "nvidia.com/gpu.shared"}
+	withLimits := func(gpu *pps.GPUSpec) *pps.PipelineInfo_Details {
+		return &pps.PipelineInfo_Details{ResourceLimits: &pps.ResourceSpec{Gpu: gpu}}
+	}
+	require.NoError(t, validateGPUs(&pps.PipelineInfo_Details{}, sharing))
+	require.NoError(t, validateGPUs(withLimits(&pps.GPUSpec{Type: "nvidia.com/gpu", Number: 2}), sharing))
+	require.NoError(t, validateGPUs(withLimits(&pps.GPUSpec{MigProfile: "1g.5gb"}), sharing))
+	require.NoError(t, validateGPUs(withLimits(&pps.GPUSpec{Fraction: 0.25}), sharing))
+
+	require.YesError(t, validateGPUs(withLimits(&pps.GPUSpec{Fraction: 0.25}), ppsutil.GPUSharing{}))
+	require.YesError(t, validateGPUs(withLimits(&pps.GPUSpec{Fraction: 1.5}), sharing))
+	require.YesError(t, validateGPUs(withLimits(&pps.GPUSpec{Number: 1, Fraction: 0.5}), sharing))
+	require.YesError(t, validateGPUs(withLimits(&pps.GPUSpec{MigProfile: "1g.5gb", Fraction: 0.5}), sharing))
+	require.YesError(t, validateGPUs(withLimits(&pps.GPUSpec{MigProfile: "big"}), sharing))
+	require.YesError(t, validateGPUs(withLimits(&pps.GPUSpec{Type: "amd.com/gpu", Fraction: 0.5}), sharing))
+}
+
+func TestGPUResource(t *testing.T) {
+	sharing := ppsutil.GPUSharing{Replicas: 4, Resource: "nvidia.com/gpu.shared"}
+	name, number, err := ppsutil.GPUResource(&pps.GPUSpec{Type: "nvidia.com/gpu", Number: 2}, sharing)
+	require.NoError(t, err)
+	require.Equal(t, "nvidia.com/gpu", string(name))
+	require.Equal(t, int64(2), number)
+
+	name, number, err = ppsutil.GPUResource(&pps.GPUSpec{MigProfile: "1g.5gb"}, sharing)
+	require.NoError(t, err)
+	require.Equal(t, "nvidia.com/mig-1g.5gb", string(name))
+	require.Equal(t, int64(1), number)
+
+	name, number, err = ppsutil.GPUResource(&pps.GPUSpec{Fraction: 0.3}, sharing)
+	require.NoError(t, err)
+	require.Equal(t, "nvidia.com/gpu.shared", string(name))
+	require.Equal(t, int64(2), number)
+}
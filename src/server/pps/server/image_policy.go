@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/imagepolicy"
+	"github.com/pachyderm/pachyderm/v2/src/internal/serviceenv"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// newImagePolicy returns the image policy pachd is configured with.
+func newImagePolicy(config serviceenv.Configuration) *imagepolicy.Policy {
+	policy := &imagepolicy.Policy{
+		RequireDigest:  config.PipelineImageRequireDigest,
+		ScanWebhookURL: config.PipelineImageScanWebhookURL,
+		ScanTimeout:    time.Duration(config.PipelineImageScanTimeoutSeconds) * time.Second,
+	}
+	for _, registry := range strings.Split(config.PipelineImageAllowedRegistries, ",") {
+		if registry = strings.TrimSpace(registry); registry != "" {
+			policy.AllowedRegistries = append(policy.AllowedRegistries, registry)
+		}
+	}
+	return policy
+}
+
+// pipelineImages returns the images that the pipeline created by request
+// runs: its transform's image, which is the builder image of build
+// pipelines, and the images of its sidecars and init containers. Pachyderm's
+// own worker images aren't subject to the image policy.
+func pipelineImages(request *pps.CreatePipelineRequest) []string {
+	image := request.Transform.GetImage()
+	if build := request.Build; build != nil {
+		image = build.BuilderImage
+		if image == "" {
+			image = pps.DefaultBuilderImage
+		}
+	}
+	if image == "" {
+		image = DefaultUserImage
+	}
+	images := []string{image}
+	for _, containers := range [][]*pps.ContainerSpec{request.InitContainers, request.Sidecars} {
+		for _, container := range containers {
+			images = append(images, container.Image)
+		}
+	}
+	return images
+}
+
+// checkImagePolicy checks that the images of the pipeline created by request
+// satisfy the cluster's image policy.
+func (a *apiServer) checkImagePolicy(ctx context.Context, request *pps.CreatePipelineRequest) error {
+	if a.imagePolicy == nil || !a.imagePolicy.Enabled() {
+		return nil
+	}
+	if err := a.imagePolicy.Check(ctx, request.Pipeline.Name, pipelineImages(request)); err != nil {
+		return errors.Wrapf(err, "pipeline %q can't be created", request.Pipeline.Name)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/internal/serviceenv"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestPipelineImages(t *testing.T) {
+	require.Equal(t, []string{DefaultUserImage}, pipelineImages(&pps.CreatePipelineRequest{}))
+	require.Equal(t, []string{pps.DefaultBuilderImage}, pipelineImages(&pps.CreatePipelineRequest{
+		Transform: &pps.Transform{Image: "ignored"},
+		Build:     &pps.Build{},
+	}))
+	require.Equal(t, []string{"user", "init", "sidecar"}, pipelineImages(&pps.CreatePipelineRequest{
+		Transform:      &pps.Transform{Image: "user"},
+		InitContainers: []*pps.ContainerSpec{{Image: "init"}},
+		Sidecars:       []*pps.ContainerSpec{{Image: "sidecar"}},
+	}))
+}
+
+func TestNewImagePolicy(t *testing.T) {
+	require.False(t, newImagePolicy(serviceenv.Configuration{
+		PachdSpecificConfiguration: &serviceenv.PachdSpecificConfiguration{},
+	}).Enabled())
+	policy := newImagePolicy(serviceenv.Configuration{
+		PachdSpecificConfiguration: &serviceenv.PachdSpecificConfiguration{
+			PipelineImageAllowedRegistries: "gcr.io/project, docker.io",
+		},
+	})
+	require.Equal(t, []string{"gcr.io/project", "docker.io"}, policy.AllowedRegistries)
+}
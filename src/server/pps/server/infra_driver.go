@@ -8,6 +8,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/pps"
 	"github.com/pachyderm/pachyderm/v2/src/version"
 	"golang.org/x/net/context"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
@@ -29,6 +30,25 @@ type InfraDriver interface {
 	UpdateReplicationController(ctx context.Context, old *v1.ReplicationController, update func(rc *v1.ReplicationController) bool) error
 	ListReplicationControllers(ctx context.Context) (*v1.ReplicationControllerList, error)
 	WatchPipelinePods(ctx context.Context) (<-chan watch.Event, func(), error)
+	// ReadWorkerJob returns the Kubernetes Job running the workers of a
+	// pipeline with kubernetes_jobs set, whose RC is rc, or nil if there isn't
+	// one.
+	ReadWorkerJob(ctx context.Context, rc *v1.ReplicationController) (*batchv1.Job, error)
+	// ScaleWorkerJob creates the Kubernetes Job running rc's workers from rc's
+	// pod template, or sets its parallelism if it exists.
+	ScaleWorkerJob(ctx context.Context, rc *v1.ReplicationController, parallelism int32) error
+	// DeleteWorkerJob deletes the Kubernetes Job running rc's workers, and its
+	// pods, if it exists.
+	DeleteWorkerJob(ctx context.Context, rc *v1.ReplicationController) error
+	// ReadPoolWorkers returns the number of workers that the pipeline with
+	// worker_pool set, whose RC is rc, has claimed from its worker pool.
+	ReadPoolWorkers(ctx context.Context, rc *v1.ReplicationController) (int32, error)
+	// ClaimPoolWorkers claims up to n idle workers from the worker pool of
+	// rc's pipeline, and returns the number it claimed.
+	ClaimPoolWorkers(ctx context.Context, rc *v1.ReplicationController, n int32) (int32, error)
+	// ReleasePoolWorkers deletes the workers that rc's pipeline claimed from
+	// its worker pool.
+	ReleasePoolWorkers(ctx context.Context, rc *v1.ReplicationController) error
 }
 
 type mockInfraOp int32
@@ -40,16 +60,23 @@ const (
 )
 
 type mockInfraDriver struct {
-	rcs          map[string]v1.ReplicationController // indexed by pipeline name
-	calls        map[string]map[mockInfraOp]int      // indexed by pipeline name
-	scaleHistory map[string][]int32                  // indexed by pipeline name
+	rcs             map[string]v1.ReplicationController // indexed by pipeline name
+	jobs            map[string]batchv1.Job              // indexed by pipeline name
+	calls           map[string]map[mockInfraOp]int      // indexed by pipeline name
+	scaleHistory    map[string][]int32                  // indexed by pipeline name
+	jobScaleHistory map[string][]int32                  // indexed by pipeline name, 0 when the job is deleted
+	idlePoolWorkers int32                               // the idle workers of every worker pool
+	poolWorkers     map[string]int32                    // indexed by pipeline name
 }
 
 func newMockInfraDriver() *mockInfraDriver {
 	d := &mockInfraDriver{
-		rcs:          make(map[string]v1.ReplicationController),
-		calls:        make(map[string]map[mockInfraOp]int),
-		scaleHistory: make(map[string][]int32),
+		rcs:             make(map[string]v1.ReplicationController),
+		jobs:            make(map[string]batchv1.Job),
+		calls:           make(map[string]map[mockInfraOp]int),
+		scaleHistory:    make(map[string][]int32),
+		jobScaleHistory: make(map[string][]int32),
+		poolWorkers:     make(map[string]int32),
 	}
 	return d
 }
@@ -110,6 +137,51 @@ func (d *mockInfraDriver) WatchPipelinePods(ctx context.Context) (<-chan watch.E
 	return ch, func() {}, nil
 }
 
+func (d *mockInfraDriver) ReadWorkerJob(ctx context.Context, rc *v1.ReplicationController) (*batchv1.Job, error) {
+	job, ok := d.jobs[rc.ObjectMeta.Labels[pipelineNameLabel]]
+	if !ok {
+		return nil, nil
+	}
+	return &job, nil
+}
+
+func (d *mockInfraDriver) ScaleWorkerJob(ctx context.Context, rc *v1.ReplicationController, parallelism int32) error {
+	name := rc.ObjectMeta.Labels[pipelineNameLabel]
+	d.jobs[name] = batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: rc.ObjectMeta.Name},
+		Spec:       batchv1.JobSpec{Parallelism: &parallelism},
+	}
+	d.jobScaleHistory[name] = append(d.jobScaleHistory[name], parallelism)
+	return nil
+}
+
+func (d *mockInfraDriver) DeleteWorkerJob(ctx context.Context, rc *v1.ReplicationController) error {
+	name := rc.ObjectMeta.Labels[pipelineNameLabel]
+	if _, ok := d.jobs[name]; ok {
+		delete(d.jobs, name)
+		d.jobScaleHistory[name] = append(d.jobScaleHistory[name], 0)
+	}
+	return nil
+}
+
+func (d *mockInfraDriver) ReadPoolWorkers(ctx context.Context, rc *v1.ReplicationController) (int32, error) {
+	return d.poolWorkers[rc.ObjectMeta.Labels[pipelineNameLabel]], nil
+}
+
+func (d *mockInfraDriver) ClaimPoolWorkers(ctx context.Context, rc *v1.ReplicationController, n int32) (int32, error) {
+	if n > d.idlePoolWorkers {
+		n = d.idlePoolWorkers
+	}
+	d.idlePoolWorkers -= n
+	d.poolWorkers[rc.ObjectMeta.Labels[pipelineNameLabel]] += n
+	return n, nil
+}
+
+func (d *mockInfraDriver) ReleasePoolWorkers(ctx context.Context, rc *v1.ReplicationController) error {
+	delete(d.poolWorkers, rc.ObjectMeta.Labels[pipelineNameLabel])
+	return nil
+}
+
 ////////////////////////////////////
 // -------- Mock Helpers -------- //
 ////////////////////////////////////
@@ -119,7 +191,7 @@ func (d *mockInfraDriver) resetRCs() {
 }
 
 func (d *mockInfraDriver) makeRC(pi *pps.PipelineInfo) *v1.ReplicationController {
-	return &v1.ReplicationController{
+	rc := &v1.ReplicationController{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: ppsutil.PipelineRcName(pi.Pipeline.Name, pi.Version),
 			Annotations: map[string]string{
@@ -133,6 +205,10 @@ func (d *mockInfraDriver) makeRC(pi *pps.PipelineInfo) *v1.ReplicationController
 			},
 		},
 	}
+	if pi.SchedulingStatus.GetOnDemand() {
+		rc.ObjectMeta.Annotations[onDemandAnnotation] = "true"
+	}
+	return rc
 }
 
 func (d *mockInfraDriver) writeRC(rc *v1.ReplicationController) {
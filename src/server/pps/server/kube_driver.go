@@ -2,6 +2,7 @@ package server
 
 import (
 	"fmt"
+	"math"
 	"path"
 
 	"github.com/pachyderm/pachyderm/v2/src/client/limit"
@@ -13,6 +14,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	logrus "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
@@ -99,19 +101,38 @@ func (kd *kubeDriver) DeletePipelineResources(ctx context.Context, pipeline stri
 			}
 		}
 	}
+	// Delete any network policies associated with pc.pipeline
+	if err := kd.deleteWorkerNetworkPolicies(ctx, selector, opts); err != nil {
+		return err
+	}
 	// Finally, delete pc.pipeline's RC, which will cause pollPipelines to stop
 	// polling it.
 	rcs, err := kd.kubeClient.CoreV1().ReplicationControllers(kd.namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
 	if err != nil {
 		return errors.Wrapf(err, "could not list RCs")
 	}
+	poolKeys := make(map[string]bool)
 	for _, rc := range rcs.Items {
+		if key, ok := rc.Annotations[workerPoolAnnotation]; ok {
+			// the workers claimed from a pool aren't owned by the RC
+			if err := kd.kubeClient.CoreV1().Pods(kd.namespace).DeleteCollection(ctx, opts, metav1.ListOptions{
+				LabelSelector: claimedPoolWorkersSelector(&rc),
+			}); err != nil {
+				return errors.Wrapf(err, "could not delete pool workers of RC %q", rc.Name)
+			}
+			poolKeys[key] = true
+		}
 		if err := kd.kubeClient.CoreV1().ReplicationControllers(kd.namespace).Delete(ctx, rc.Name, opts); err != nil {
 			if !errutil.IsNotFoundError(err) {
 				return errors.Wrapf(err, "could not delete RC %q", rc.Name)
 			}
 		}
 	}
+	for key := range poolKeys {
+		if err := kd.deleteUnusedWorkerPool(ctx, key); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -173,3 +194,80 @@ func (kd *kubeDriver) WatchPipelinePods(ctx context.Context) (<-chan watch.Event
 	}
 	return kubePipelineWatch.ResultChan(), kubePipelineWatch.Stop, nil
 }
+
+func (kd *kubeDriver) ReadWorkerJob(ctx context.Context, rc *v1.ReplicationController) (*batchv1.Job, error) {
+	kd.limiter.Acquire()
+	defer kd.limiter.Release()
+	job, err := kd.kubeClient.BatchV1().Jobs(kd.namespace).Get(ctx, rc.Name, metav1.GetOptions{})
+	if err != nil {
+		if errutil.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read job %q", rc.Name)
+	}
+	return job, nil
+}
+
+func (kd *kubeDriver) ScaleWorkerJob(ctx context.Context, rc *v1.ReplicationController, parallelism int32) error {
+	kd.limiter.Acquire()
+	defer kd.limiter.Release()
+	jobs := kd.kubeClient.BatchV1().Jobs(kd.namespace)
+	job, err := jobs.Get(ctx, rc.Name, metav1.GetOptions{})
+	if err != nil && !errutil.IsNotFoundError(err) {
+		return newRetriableError(err, "error reading worker job")
+	}
+	if err == nil {
+		job.Spec.Parallelism = &parallelism
+		if _, err := jobs.Update(ctx, job, metav1.UpdateOptions{}); err != nil {
+			return newRetriableError(err, "error updating worker job")
+		}
+		return nil
+	}
+	if _, err := jobs.Create(ctx, workerJob(rc, parallelism), metav1.CreateOptions{}); err != nil {
+		return newRetriableError(err, "error creating worker job")
+	}
+	return nil
+}
+
+func (kd *kubeDriver) DeleteWorkerJob(ctx context.Context, rc *v1.ReplicationController) error {
+	kd.limiter.Acquire()
+	defer kd.limiter.Release()
+	propagation := metav1.DeletePropagationBackground
+	if err := kd.kubeClient.BatchV1().Jobs(kd.namespace).Delete(ctx, rc.Name, metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+	}); err != nil && !errutil.IsNotFoundError(err) {
+		return newRetriableError(err, "error deleting worker job")
+	}
+	return nil
+}
+
+// workerJob returns a Kubernetes Job that runs parallelism of rc's workers.
+// The job is owned by rc, so it's deleted along with the pipeline's other
+// resources.
+func workerJob(rc *v1.ReplicationController, parallelism int32) *batchv1.Job {
+	template := rc.Spec.Template.DeepCopy()
+	// Jobs don't allow pods to always restart. Workers don't exit on their
+	// own, so the job runs until it's deleted.
+	template.Spec.RestartPolicy = v1.RestartPolicyOnFailure
+	// Crashing workers are handled by the pipeline's crashing monitor, rather
+	// than by failing the job.
+	backoffLimit := int32(math.MaxInt32)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        rc.Name,
+			Labels:      rc.Labels,
+			Annotations: rc.Annotations,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "v1",
+				Kind:       "ReplicationController",
+				Name:       rc.Name,
+				UID:        rc.UID,
+			}},
+		},
+		Spec: batchv1.JobSpec{
+			Parallelism:  &parallelism,
+			BackoffLimit: &backoffLimit,
+			Template:     *template,
+		},
+	}
+}
@@ -0,0 +1,219 @@
+package server
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	pfsServer "github.com/pachyderm/pachyderm/v2/src/server/pfs"
+)
+
+// defaultLineageMaxCommits is the number of commits a lineage is truncated to
+// when the request doesn't set max_commits.
+const defaultLineageMaxCommits = 1000
+
+// QueryLineage implements the protobuf pps.QueryLineage RPC
+func (a *apiServer) QueryLineage(ctx context.Context, request *pps.QueryLineageRequest) (response *pps.Lineage, retErr error) {
+	if request.Commit.GetBranch().GetRepo() == nil {
+		return nil, errors.New("must specify a commit")
+	}
+	if request.MaxDepth < 0 || request.MaxCommits < 0 {
+		return nil, errors.New("max_depth and max_commits can't be negative")
+	}
+	pachClient := a.env.GetPachClient(ctx)
+	q := &lineageQuery{
+		inspectCommit: func(commit *pfs.Commit) (*pfs.CommitInfo, error) {
+			commitInfo, err := pachClient.PfsAPIClient.InspectCommit(pachClient.Ctx(), &pfs.InspectCommitRequest{Commit: commit})
+			return commitInfo, errors.EnsureStack(err)
+		},
+		inspectBranch: func(branch *pfs.Branch) (*pfs.BranchInfo, error) {
+			branchInfo, err := pachClient.PfsAPIClient.InspectBranch(pachClient.Ctx(), &pfs.InspectBranchRequest{Branch: branch})
+			return branchInfo, errors.EnsureStack(err)
+		},
+		lookup: func(specCommit *pfs.Commit) (*pps.PipelineInfo, error) {
+			pipelineInfo := &pps.PipelineInfo{}
+			if err := a.pipelines.ReadOnly(ctx).Get(specCommit, pipelineInfo); err != nil {
+				return nil, errors.EnsureStack(err)
+			}
+			return pipelineInfo, nil
+		},
+		maxDepth:   request.MaxDepth,
+		maxCommits: request.MaxCommits,
+	}
+	if q.maxCommits == 0 {
+		q.maxCommits = defaultLineageMaxCommits
+	}
+	return q.run(request.Commit, request.Direction)
+}
+
+// A lineageQuery walks the provenance of a commit breadth first, so that a
+// truncated lineage holds the commits closest to the queried one.
+type lineageQuery struct {
+	inspectCommit func(*pfs.Commit) (*pfs.CommitInfo, error)
+	inspectBranch func(*pfs.Branch) (*pfs.BranchInfo, error)
+	// lookup returns the pipeline version of a spec commit
+	lookup     func(specCommit *pfs.Commit) (*pps.PipelineInfo, error)
+	maxDepth   int64
+	maxCommits int64
+
+	lineage *pps.Lineage
+	seen    map[string]bool
+}
+
+func commitKey(commit *pfs.Commit) string {
+	return commit.Branch.Repo.String() + "@" + commit.ID
+}
+
+func (q *lineageQuery) run(commit *pfs.Commit, direction pps.QueryLineageRequest_Direction) (*pps.Lineage, error) {
+	q.lineage = &pps.Lineage{}
+	q.seen = make(map[string]bool)
+	start, err := q.resolve(commit)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.add(start, 0); err != nil {
+		return nil, err
+	}
+	next := q.upstream
+	if direction == pps.QueryLineageRequest_DOWNSTREAM {
+		next = q.downstream
+	}
+	frontier := []*pfs.CommitInfo{start}
+	for depth := int64(1); len(frontier) > 0 && (q.maxDepth == 0 || depth <= q.maxDepth); depth++ {
+		var nextFrontier []*pfs.CommitInfo
+		for _, commitInfo := range frontier {
+			commitInfos, err := next(commitInfo)
+			if err != nil {
+				return nil, err
+			}
+			for _, ci := range commitInfos {
+				from, to := ci.Commit, commitInfo.Commit
+				if direction == pps.QueryLineageRequest_DOWNSTREAM {
+					from, to = to, from
+				}
+				q.lineage.Edges = append(q.lineage.Edges, &pps.LineageEdge{From: from, To: to})
+				if q.seen[commitKey(ci.Commit)] {
+					continue
+				}
+				if int64(len(q.lineage.Nodes)) >= q.maxCommits {
+					// the edge's commit isn't in the lineage
+					q.lineage.Edges = q.lineage.Edges[:len(q.lineage.Edges)-1]
+					q.lineage.Truncated = true
+					return q.lineage, nil
+				}
+				if err := q.add(ci, depth); err != nil {
+					return nil, err
+				}
+				nextFrontier = append(nextFrontier, ci)
+			}
+		}
+		frontier = nextFrontier
+	}
+	return q.lineage, nil
+}
+
+func (q *lineageQuery) add(commitInfo *pfs.CommitInfo, depth int64) error {
+	q.seen[commitKey(commitInfo.Commit)] = true
+	node := &pps.LineageNode{
+		Commit: commitInfo.Commit,
+		Origin: commitInfo.Origin.GetKind(),
+		Depth:  depth,
+	}
+	if commitInfo.Commit.Branch.Repo.Type == pfs.SpecRepoType {
+		pipelineInfo, err := q.lookup(commitInfo.Commit)
+		if err != nil {
+			return err
+		}
+		node.Pipeline = pipelineInfo.Pipeline
+		node.PipelineVersion = pipelineInfo.Version
+	}
+	q.lineage.Nodes = append(q.lineage.Nodes, node)
+	return nil
+}
+
+// resolve returns the commit that commit is, or is an alias of.
+func (q *lineageQuery) resolve(commit *pfs.Commit) (*pfs.CommitInfo, error) {
+	commitInfo, err := q.inspectCommit(commit)
+	if err != nil {
+		return nil, err
+	}
+	for commitInfo.Origin.GetKind() == pfs.OriginKind_ALIAS && commitInfo.ParentCommit != nil {
+		if commitInfo, err = q.inspectCommit(commitInfo.ParentCommit); err != nil {
+			return nil, err
+		}
+	}
+	return commitInfo, nil
+}
+
+// upstream returns the commits that commitInfo was produced from, which are
+// the commits, or aliases of them, on its provenance in its commit set.
+func (q *lineageQuery) upstream(commitInfo *pfs.CommitInfo) ([]*pfs.CommitInfo, error) {
+	var result []*pfs.CommitInfo
+	for _, branch := range commitInfo.DirectProvenance {
+		ci, err := q.resolve(&pfs.Commit{Branch: branch, ID: commitInfo.Commit.ID})
+		if err != nil {
+			if pfsServer.IsCommitNotFoundErr(err) {
+				// the provenance was added after the commit
+				continue
+			}
+			return nil, err
+		}
+		result = append(result, ci)
+	}
+	return result, nil
+}
+
+// downstream returns the commits that were produced from commitInfo, which are
+// the commits on its direct subvenance in its commit set, and in the commit
+// sets that its aliases are in.
+func (q *lineageQuery) downstream(commitInfo *pfs.CommitInfo) ([]*pfs.CommitInfo, error) {
+	ids := []string{commitInfo.Commit.ID}
+	aliases := commitInfo.ChildCommits
+	for len(aliases) > 0 {
+		ci, err := q.inspectCommit(aliases[0])
+		aliases = aliases[1:]
+		if err != nil {
+			return nil, err
+		}
+		if ci.Origin.GetKind() == pfs.OriginKind_ALIAS {
+			ids = append(ids, ci.Commit.ID)
+			aliases = append(aliases, ci.ChildCommits...)
+		}
+	}
+	branchInfo, err := q.inspectBranch(commitInfo.Commit.Branch)
+	if err != nil {
+		return nil, err
+	}
+	var subvenance []*pfs.Branch
+	for _, branch := range branchInfo.Subvenance {
+		bi, err := q.inspectBranch(branch)
+		if err != nil {
+			return nil, err
+		}
+		for _, prov := range bi.DirectProvenance {
+			if proto.Equal(prov, commitInfo.Commit.Branch) {
+				subvenance = append(subvenance, branch)
+				break
+			}
+		}
+	}
+	var result []*pfs.CommitInfo
+	for _, id := range ids {
+		for _, branch := range subvenance {
+			ci, err := q.inspectCommit(&pfs.Commit{Branch: branch, ID: id})
+			if err != nil {
+				if pfsServer.IsCommitNotFoundErr(err) {
+					continue
+				}
+				return nil, err
+			}
+			// an alias downstream was produced from an earlier commit set
+			if ci.Origin.GetKind() != pfs.OriginKind_ALIAS {
+				result = append(result, ci)
+			}
+		}
+	}
+	return result, nil
+}
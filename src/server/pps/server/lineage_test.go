@@ -0,0 +1,106 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	pfsServer "github.com/pachyderm/pachyderm/v2/src/server/pfs"
+)
+
+// newTestLineageQuery returns a query over three commit sets: "a" commits to
+// images, "s" creates the edges pipeline, and "b" commits to images again.
+func newTestLineageQuery(maxDepth, maxCommits int64) *lineageQuery {
+	images := client.NewRepo("images").NewBranch("master")
+	spec := client.NewSystemRepo("edges", pfs.SpecRepoType).NewBranch("master")
+	edges := client.NewRepo("edges").NewBranch("master")
+	commit := func(branch *pfs.Branch, id string) *pfs.Commit { return branch.NewCommit(id) }
+	commitInfos := make(map[string]*pfs.CommitInfo)
+	add := func(ci *pfs.CommitInfo) { commitInfos[commitKey(ci.Commit)] = ci }
+	origin := func(kind pfs.OriginKind) *pfs.CommitOrigin { return &pfs.CommitOrigin{Kind: kind} }
+	add(&pfs.CommitInfo{Commit: commit(images, "a"), Origin: origin(pfs.OriginKind_USER), ChildCommits: []*pfs.Commit{commit(images, "s")}})
+	add(&pfs.CommitInfo{Commit: commit(images, "s"), Origin: origin(pfs.OriginKind_ALIAS), ParentCommit: commit(images, "a"), ChildCommits: []*pfs.Commit{commit(images, "b")}})
+	add(&pfs.CommitInfo{Commit: commit(images, "b"), Origin: origin(pfs.OriginKind_USER), ParentCommit: commit(images, "s")})
+	add(&pfs.CommitInfo{Commit: commit(spec, "s"), Origin: origin(pfs.OriginKind_USER), ChildCommits: []*pfs.Commit{commit(spec, "b")}})
+	add(&pfs.CommitInfo{Commit: commit(spec, "b"), Origin: origin(pfs.OriginKind_ALIAS), ParentCommit: commit(spec, "s")})
+	provenance := []*pfs.Branch{images, spec}
+	add(&pfs.CommitInfo{Commit: commit(edges, "s"), Origin: origin(pfs.OriginKind_AUTO), DirectProvenance: provenance, ChildCommits: []*pfs.Commit{commit(edges, "b")}})
+	add(&pfs.CommitInfo{Commit: commit(edges, "b"), Origin: origin(pfs.OriginKind_AUTO), DirectProvenance: provenance, ParentCommit: commit(edges, "s")})
+	branchInfos := map[string]*pfs.BranchInfo{
+		images.String(): {Branch: images, Subvenance: []*pfs.Branch{edges}},
+		spec.String():   {Branch: spec, Subvenance: []*pfs.Branch{edges}},
+		edges.String():  {Branch: edges, DirectProvenance: provenance},
+	}
+	return &lineageQuery{
+		inspectCommit: func(commit *pfs.Commit) (*pfs.CommitInfo, error) {
+			if ci, ok := commitInfos[commitKey(commit)]; ok {
+				return ci, nil
+			}
+			return nil, pfsServer.ErrCommitNotFound{Commit: commit}
+		},
+		inspectBranch: func(branch *pfs.Branch) (*pfs.BranchInfo, error) {
+			return branchInfos[branch.String()], nil
+		},
+		lookup: func(specCommit *pfs.Commit) (*pps.PipelineInfo, error) {
+			return &pps.PipelineInfo{Pipeline: client.NewPipeline("edges"), Version: 1}, nil
+		},
+		maxDepth:   maxDepth,
+		maxCommits: maxCommits,
+	}
+}
+
+func lineageStrings(lineage *pps.Lineage) (nodes, edges []string) {
+	for _, node := range lineage.Nodes {
+		nodes = append(nodes, commitKey(node.Commit))
+	}
+	for _, edge := range lineage.Edges {
+		edges = append(edges, commitKey(edge.From)+" -> "+commitKey(edge.To))
+	}
+	return nodes, edges
+}
+
+func TestQueryLineageUpstream(t *testing.T) {
+	q := newTestLineageQuery(0, 100)
+	lineage, err := q.run(client.NewRepo("edges").NewCommit("master", "b"), pps.QueryLineageRequest_UPSTREAM)
+	require.NoError(t, err)
+	nodes, edges := lineageStrings(lineage)
+	// the spec commit in "b" is an alias of the pipeline's version in "s"
+	require.Equal(t, []string{"edges@b", "images@b", "edges.spec@s"}, nodes)
+	require.Equal(t, []string{"images@b -> edges@b", "edges.spec@s -> edges@b"}, edges)
+	require.Equal(t, "edges", lineage.Nodes[2].Pipeline.Name)
+	require.Equal(t, uint64(1), lineage.Nodes[2].PipelineVersion)
+	require.False(t, lineage.Truncated)
+
+	q = newTestLineageQuery(0, 2)
+	lineage, err = q.run(client.NewRepo("edges").NewCommit("master", "b"), pps.QueryLineageRequest_UPSTREAM)
+	require.NoError(t, err)
+	nodes, edges = lineageStrings(lineage)
+	require.Equal(t, []string{"edges@b", "images@b"}, nodes)
+	require.Equal(t, []string{"images@b -> edges@b"}, edges)
+	require.True(t, lineage.Truncated)
+}
+
+func TestQueryLineageDownstream(t *testing.T) {
+	// images@a is used by edges@s, through its alias in "s", but not by
+	// edges@b
+	q := newTestLineageQuery(0, 100)
+	lineage, err := q.run(client.NewRepo("images").NewCommit("master", "a"), pps.QueryLineageRequest_DOWNSTREAM)
+	require.NoError(t, err)
+	nodes, edges := lineageStrings(lineage)
+	require.Equal(t, []string{"images@a", "edges@s"}, nodes)
+	require.Equal(t, []string{"images@a -> edges@s"}, edges)
+
+	// every commit to edges was made by the pipeline's first version
+	lineage, err = q.run(client.NewSystemRepo("edges", pfs.SpecRepoType).NewCommit("master", "s"), pps.QueryLineageRequest_DOWNSTREAM)
+	require.NoError(t, err)
+	nodes, _ = lineageStrings(lineage)
+	require.Equal(t, []string{"edges.spec@s", "edges@s", "edges@b"}, nodes)
+
+	q = newTestLineageQuery(1, 100)
+	lineage, err = q.run(client.NewRepo("images").NewCommit("master", "b"), pps.QueryLineageRequest_DOWNSTREAM)
+	require.NoError(t, err)
+	nodes, _ = lineageStrings(lineage)
+	require.Equal(t, []string{"images@b", "edges@b"}, nodes)
+}
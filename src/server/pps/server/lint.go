@@ -0,0 +1,209 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/imagepolicy"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/datum"
+)
+
+// lintCrossDatumLimit is the number of datums a cross input may produce
+// before it's flagged. Each of the crossed inputs' datums is only counted up
+// to this limit, so linting large inputs stays cheap.
+const lintCrossDatumLimit = 100000
+
+type linter struct {
+	findings []*pps.LintFinding
+}
+
+func (l *linter) add(rule string, severity pps.LintSeverity, field, message, suggestion string) {
+	l.findings = append(l.findings, &pps.LintFinding{
+		Rule:       rule,
+		Severity:   severity,
+		Field:      field,
+		Message:    message,
+		Suggestion: suggestion,
+	})
+}
+
+// LintPipeline implements the protobuf pps.LintPipeline RPC
+func (a *apiServer) LintPipeline(ctx context.Context, request *pps.LintPipelineRequest) (*pps.LintPipelineResponse, error) {
+	if request.Spec == nil || request.Spec.Pipeline == nil {
+		return nil, errors.New("request.Spec.Pipeline cannot be nil")
+	}
+	l := &linter{}
+	// initializePipelineInfo sets defaults in the request, so lint a copy
+	spec := proto.Clone(request.Spec).(*pps.CreatePipelineRequest)
+	lintDeprecatedFields(l, spec)
+	if err := a.validateSecret(ctx, spec); err != nil {
+		l.add("invalid-spec", pps.LintSeverity_LINT_ERROR, "", grpcutil.ScrubGRPC(err).Error(),
+			"create the secret, or fix its name in the spec")
+	}
+	if err := a.applyClusterDefaults(ctx, spec); err != nil {
+		return nil, err
+	}
+	lintImages(l, spec)
+	if err := a.checkImagePolicy(ctx, spec); err != nil {
+		if !imagepolicy.IsViolation(err) {
+			return nil, err
+		}
+		l.add("image-policy", pps.LintSeverity_LINT_ERROR, "transform.image", err.Error(),
+			"use an image that satisfies the cluster's image policy")
+	}
+	pipelineInfo, err := a.initializePipelineInfo(spec, nil)
+	if err != nil {
+		l.add("invalid-spec", pps.LintSeverity_LINT_ERROR, "", grpcutil.ScrubGRPC(err).Error(),
+			"fix the spec, creating the pipeline fails until it's valid")
+		return &pps.LintPipelineResponse{Findings: l.findings}, nil
+	}
+	lintResources(l, pipelineInfo)
+	a.lintParallelism(ctx, l, pipelineInfo)
+	a.lintInputs(ctx, l, pipelineInfo.Details.Input)
+	return &pps.LintPipelineResponse{Findings: l.findings}, nil
+}
+
+func lintDeprecatedFields(l *linter, spec *pps.CreatePipelineRequest) {
+	if spec.PodSpec != "" {
+		l.add("deprecated-field", pps.LintSeverity_LINT_WARNING, "pod_spec", "pod_spec is deprecated",
+			"use pod_patch, a JSON patch that's applied to the workers' pod spec")
+	}
+}
+
+func lintImages(l *linter, spec *pps.CreatePipelineRequest) {
+	image := spec.Transform.GetImage()
+	if image == "" || spec.Build != nil || strings.Contains(image, "@") {
+		return
+	}
+	tagged := strings.LastIndex(image, ":") > strings.LastIndex(image, "/")
+	switch {
+	case !tagged:
+		l.add("unpinned-image", pps.LintSeverity_LINT_WARNING, "transform.image",
+			fmt.Sprintf("image %q has no tag, so it runs whatever latest is when the workers start", image),
+			fmt.Sprintf("pin the image with a tag, such as %s:1.0, or a digest", image))
+	case strings.HasSuffix(image, ":latest"):
+		l.add("unpinned-image", pps.LintSeverity_LINT_WARNING, "transform.image",
+			fmt.Sprintf("image %q uses the latest tag, which changes as new images are pushed", image),
+			"pin the image with a versioned tag or a digest, which improves the reproducibility of jobs")
+	}
+}
+
+func lintResources(l *linter, pipelineInfo *pps.PipelineInfo) {
+	details := pipelineInfo.Details
+	if details.ResourceRequests.GetMemory() == "" && details.ResourceRequests.GetCpu() == 0 {
+		l.add("missing-resource-requests", pps.LintSeverity_LINT_WARNING, "resource_requests",
+			"the workers don't request any memory or CPU, so they may be scheduled on nodes without room for them",
+			"set resource_requests.memory and resource_requests.cpu to what a datum needs")
+	}
+	if details.ResourceLimits.GetMemory() == "" {
+		l.add("missing-resource-limits", pps.LintSeverity_LINT_INFO, "resource_limits",
+			"the workers' memory isn't limited, so a datum that uses too much memory can starve the other pods on its node",
+			"set resource_limits.memory")
+	}
+}
+
+// lintParallelism flags pipelines whose workers request more CPU or memory,
+// together, than the cluster's nodes have. It's skipped if pachd can't list
+// the nodes.
+func (a *apiServer) lintParallelism(ctx context.Context, l *linter, pipelineInfo *pps.PipelineInfo) {
+	requests := pipelineInfo.Details.ResourceRequests
+	if requests == nil || a.env.KubeClient == nil {
+		return
+	}
+	workers, err := getExpectedNumWorkers(pipelineInfo)
+	if err != nil || workers <= 1 {
+		return
+	}
+	nodes, err := a.env.KubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return
+	}
+	var cpu, memory resource.Quantity
+	for _, node := range nodes.Items {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		cpu.Add(node.Status.Allocatable[v1.ResourceCPU])
+		memory.Add(node.Status.Allocatable[v1.ResourceMemory])
+	}
+	exceeds := func(name string, request, allocatable resource.Quantity) {
+		total := resource.NewMilliQuantity(request.MilliValue()*int64(workers), request.Format)
+		if total.Cmp(allocatable) > 0 {
+			l.add("parallelism-exceeds-cluster", pps.LintSeverity_LINT_WARNING, "parallelism_spec",
+				fmt.Sprintf("%d workers request %s %s, but the cluster's nodes only have %s, so some workers won't be scheduled", workers, total.String(), name, allocatable.String()),
+				"lower parallelism_spec, or the workers' resource requests")
+		}
+	}
+	if requests.Cpu > 0 {
+		exceeds("CPU", *resource.NewMilliQuantity(int64(requests.Cpu*1000), resource.DecimalSI), cpu)
+	}
+	if requests.Memory != "" {
+		if request, err := resource.ParseQuantity(requests.Memory); err == nil {
+			exceeds("memory", request, memory)
+		}
+	}
+}
+
+// lintInputs flags PFS inputs whose globs match nothing, and cross inputs
+// that produce too many datums.
+func (a *apiServer) lintInputs(ctx context.Context, l *linter, input *pps.Input) {
+	pps.VisitInput(input, func(input *pps.Input) error {
+		switch {
+		case input.Pfs != nil:
+			if input.Pfs.Remote != nil || input.Pfs.Sql != nil {
+				return nil
+			}
+			n, err := a.countDatums(ctx, &pps.Input{Pfs: input.Pfs}, 1)
+			switch {
+			case errutil.IsNotFoundError(err):
+				l.add("missing-input", pps.LintSeverity_LINT_INFO, "input.pfs.branch",
+					fmt.Sprintf("input %q can't be read yet: %v", input.Pfs.Name, grpcutil.ScrubGRPC(err)),
+					"create the input's repo and branch, or check their names")
+			case err != nil:
+			case n == 0:
+				l.add("glob-matches-nothing", pps.LintSeverity_LINT_WARNING, "input.pfs.glob",
+					fmt.Sprintf("the glob %q of input %q matches nothing at the head of %s@%s, so the pipeline has no datums to process", input.Pfs.Glob, input.Pfs.Name, input.Pfs.Repo, input.Pfs.Branch),
+					`check that the glob matches the paths in the repo, such as "/*" for a datum per top-level file or directory, and that the branch has data`)
+			}
+		case input.Cross != nil:
+			total := int64(1)
+			for _, child := range input.Cross {
+				n, err := a.countDatums(ctx, child, lintCrossDatumLimit+1)
+				if err != nil {
+					return nil
+				}
+				if total *= n; total > lintCrossDatumLimit {
+					l.add("large-cross", pps.LintSeverity_LINT_WARNING, "input.cross",
+						fmt.Sprintf("the cross of %d inputs produces more than %d datums, every combination of the inputs' datums", len(input.Cross), lintCrossDatumLimit),
+						"use a join or group input to pair up related datums, or make the inputs' globs less granular")
+					return nil
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// countDatums counts the datums of input, up to limit.
+func (a *apiServer) countDatums(ctx context.Context, input *pps.Input, limit int64) (int64, error) {
+	var n int64
+	if err := a.listDatumInput(ctx, proto.Clone(input).(*pps.Input), func(*datum.Meta) error {
+		if n++; n >= limit {
+			return errutil.ErrBreak
+		}
+		return nil
+	}); err != nil && !errors.Is(err, errutil.ErrBreak) {
+		return 0, err
+	}
+	return n, nil
+}
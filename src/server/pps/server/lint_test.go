@@ -0,0 +1,46 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func lintRules(l *linter) []string {
+	var rules []string
+	for _, finding := range l.findings {
+		rules = append(rules, finding.Rule)
+	}
+	return rules
+}
+
+func TestLintImages(t *testing.T) {
+	for image, flagged := range map[string]bool{
+		"python":                   true,
+		"python:latest":            true,
+		"localhost:5000/python":    true,
+		"python:3.10":              false,
+		"localhost:5000/python:3":  false,
+		"python@sha256:0123456789": false,
+	} {
+		l := &linter{}
+		lintImages(l, &pps.CreatePipelineRequest{Transform: &pps.Transform{Image: image}})
+		require.Equal(t, flagged, len(l.findings) > 0, image)
+	}
+}
+
+func TestLintResourcesAndDeprecatedFields(t *testing.T) {
+	l := &linter{}
+	lintDeprecatedFields(l, &pps.CreatePipelineRequest{PodSpec: `{"hostNetwork": true}`})
+	lintResources(l, &pps.PipelineInfo{Details: &pps.PipelineInfo_Details{}})
+	require.Equal(t, []string{"deprecated-field", "missing-resource-requests", "missing-resource-limits"}, lintRules(l))
+	require.Equal(t, pps.LintSeverity_LINT_INFO, l.findings[2].Severity)
+
+	l = &linter{}
+	lintResources(l, &pps.PipelineInfo{Details: &pps.PipelineInfo_Details{
+		ResourceRequests: &pps.ResourceSpec{Memory: "1G"},
+		ResourceLimits:   &pps.ResourceSpec{Memory: "2G"},
+	}})
+	require.Equal(t, 0, len(l.findings))
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"math"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// datumPage restricts the datums returned by ListDatum to those in the
+// request's states, after its cursor, and at most its number of them.
+type datumPage struct {
+	states map[pps.DatumState]bool
+	number int64
+	cursor string
+	// pastCursor is true once the datums before the cursor have been skipped
+	pastCursor bool
+}
+
+func newDatumPage(request *pps.ListDatumRequest) (*datumPage, error) {
+	if request.Input != nil && len(request.State) > 0 {
+		return nil, errors.Errorf("can't list datums by state with an input, the datums aren't part of a job")
+	}
+	p := &datumPage{
+		states:     make(map[pps.DatumState]bool),
+		number:     request.Number,
+		pastCursor: request.Cursor == nil,
+	}
+	for _, state := range request.State {
+		p.states[state] = true
+	}
+	if p.number == 0 {
+		p.number = math.MaxInt64
+	}
+	if request.Cursor != nil {
+		p.cursor = request.Cursor.ID
+	}
+	return p, nil
+}
+
+// add calls cb with di if it's part of the page, and returns errutil.ErrBreak
+// once the page is full.
+func (p *datumPage) add(di *pps.DatumInfo, cb func(*pps.DatumInfo) error) error {
+	if !p.pastCursor {
+		p.pastCursor = di.Datum.ID == p.cursor
+		return nil
+	}
+	if len(p.states) > 0 && !p.states[di.State] {
+		return nil
+	}
+	if p.number == 0 {
+		return errutil.ErrBreak
+	}
+	p.number--
+	return cb(di)
+}
+
+// finish returns the error the datums were listed with, once the page is
+// done.
+func (p *datumPage) finish(err error) error {
+	if errors.Is(err, errutil.ErrBreak) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !p.pastCursor {
+		return errors.Errorf("cursor datum %s was not found in the listed datums", p.cursor)
+	}
+	return nil
+}
+
+// CountDatums implements the protobuf pps.CountDatums RPC. The counts are the
+// ones the workers keep in the job's info, so the datums aren't read.
+func (a *apiServer) CountDatums(ctx context.Context, request *pps.CountDatumsRequest) (*pps.DatumCounts, error) {
+	jobInfo, err := a.InspectJob(ctx, &pps.InspectJobRequest{Job: request.Job})
+	if err != nil {
+		return nil, err
+	}
+	return &pps.DatumCounts{
+		Total:       jobInfo.DataTotal,
+		Success:     jobInfo.DataProcessed,
+		Skipped:     jobInfo.DataSkipped,
+		Failed:      jobInfo.DataFailed,
+		Recovered:   jobInfo.DataRecovered,
+		Quarantined: jobInfo.DataQuarantined,
+	}, nil
+}
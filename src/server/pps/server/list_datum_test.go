@@ -0,0 +1,57 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// listTestDatums returns the IDs of the datums a page of a ListDatum request
+// contains, out of datums a to f, where c and e failed.
+func listTestDatums(t *testing.T, request *pps.ListDatumRequest) ([]string, error) {
+	page, err := newDatumPage(request)
+	require.NoError(t, err)
+	var ids []string
+	var listErr error
+	for _, id := range []string{"a", "b", "c", "d", "e", "f"} {
+		di := &pps.DatumInfo{Datum: &pps.Datum{ID: id}, State: pps.DatumState_SUCCESS}
+		if id == "c" || id == "e" {
+			di.State = pps.DatumState_FAILED
+		}
+		if listErr = page.add(di, func(di *pps.DatumInfo) error {
+			ids = append(ids, di.Datum.ID)
+			return nil
+		}); listErr != nil {
+			break
+		}
+	}
+	return ids, page.finish(listErr)
+}
+
+func TestDatumPage(t *testing.T) {
+	job := client.NewJob("edges", "job")
+	ids, err := listTestDatums(t, &pps.ListDatumRequest{Job: job})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c", "d", "e", "f"}, ids)
+
+	ids, err = listTestDatums(t, &pps.ListDatumRequest{Job: job, Number: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, ids)
+	ids, err = listTestDatums(t, &pps.ListDatumRequest{Job: job, Number: 2, Cursor: &pps.Datum{Job: job, ID: "b"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"c", "d"}, ids)
+
+	ids, err = listTestDatums(t, &pps.ListDatumRequest{Job: job, State: []pps.DatumState{pps.DatumState_FAILED}, Number: 1})
+	require.NoError(t, err)
+	require.Equal(t, []string{"c"}, ids)
+	ids, err = listTestDatums(t, &pps.ListDatumRequest{Job: job, State: []pps.DatumState{pps.DatumState_FAILED}, Number: 1, Cursor: &pps.Datum{Job: job, ID: "c"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"e"}, ids)
+
+	_, err = listTestDatums(t, &pps.ListDatumRequest{Job: job, Cursor: &pps.Datum{Job: job, ID: "z"}})
+	require.YesError(t, err)
+	_, err = newDatumPage(&pps.ListDatumRequest{Input: &pps.Input{}, State: []pps.DatumState{pps.DatumState_FAILED}})
+	require.YesError(t, err)
+}
@@ -0,0 +1,265 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/types"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/watch"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/common"
+)
+
+const (
+	// logArchivePrefix prefixes the names of the objects that the logs of
+	// finished jobs are archived to, which are named
+	// <prefix>/<pipeline>/<job ID>.
+	logArchivePrefix = "pps-logs"
+	// logArchiveSweepInterval is how often archived logs older than their
+	// retention period are deleted.
+	logArchiveSweepInterval = time.Hour
+)
+
+func logArchiveName(job *pps.Job) string {
+	return path.Join(logArchivePrefix, job.Pipeline.Name, job.ID)
+}
+
+func parseLogArchiveName(name string) (*pps.Job, error) {
+	parts := strings.Split(strings.TrimPrefix(name, logArchivePrefix+"/"), "/")
+	if !strings.HasPrefix(name, logArchivePrefix+"/") || len(parts) != 2 {
+		return nil, errors.Errorf("invalid log archive name %q", name)
+	}
+	return client.NewJob(parts[0], parts[1]), nil
+}
+
+// logArchiveRetention returns how long archived logs are kept, or 0 if logs
+// aren't archived.
+func logArchiveRetention(env Env) time.Duration {
+	return time.Duration(env.Config.LogArchiveRetentionDays) * 24 * time.Hour
+}
+
+// startLogArchiver starts a new goroutine running archiveLogs, if logs are
+// archived.
+func (m *ppsMaster) startLogArchiver() {
+	if logArchiveRetention(m.env) <= 0 || m.env.GetObjectClient == nil {
+		return
+	}
+	m.pollPipelinesMu.Lock()
+	defer m.pollPipelinesMu.Unlock()
+	m.archiveCancel = startMonitorThread(m.masterCtx, "archiveLogs", m.archiveLogs)
+}
+
+func (m *ppsMaster) cancelLogArchiver() {
+	m.pollPipelinesMu.Lock()
+	defer m.pollPipelinesMu.Unlock()
+	if m.archiveCancel != nil {
+		m.archiveCancel()
+		m.archiveCancel = nil
+	}
+}
+
+// archiveLogs archives the logs of each job to object storage when it
+// finishes, and deletes them once they're older than the retention period or
+// their job has been deleted.
+func (m *ppsMaster) archiveLogs(ctx context.Context) {
+	if err := backoff.RetryUntilCancel(ctx, func() error {
+		objC, err := m.env.GetObjectClient()
+		if err != nil {
+			return err
+		}
+		jobs := ppsdb.Jobs(m.env.DB, m.env.Listener)
+		eg, ctx := errgroup.WithContext(ctx)
+		eg.Go(func() error {
+			return m.archiveFinishedJobLogs(ctx, objC, jobs)
+		})
+		eg.Go(func() error {
+			for {
+				if err := m.sweepLogArchive(ctx, objC, jobs); err != nil {
+					return err
+				}
+				select {
+				case <-time.After(logArchiveSweepInterval):
+				case <-ctx.Done():
+					return errors.EnsureStack(ctx.Err())
+				}
+			}
+		})
+		return errors.EnsureStack(eg.Wait())
+	}, backoff.NewInfiniteBackOff(), backoff.NotifyContinue("archiveLogs")); err != nil && ctx.Err() == nil {
+		log.Errorf("PPS master: archiveLogs exited unexpectedly: %v", err)
+	}
+}
+
+func (m *ppsMaster) archiveFinishedJobLogs(ctx context.Context, objC obj.Client, jobs col.PostgresCollection) error {
+	jobInfo := &pps.JobInfo{}
+	return errors.EnsureStack(jobs.ReadOnly(ctx).WatchF(func(ev *watch.Event) error {
+		if ev.Type == watch.EventError {
+			return ev.Err
+		}
+		if ev.Type == watch.EventDelete {
+			return nil // the sweeper deletes the logs of deleted jobs
+		}
+		var key string
+		if err := ev.Unmarshal(&key, jobInfo); err != nil {
+			return err
+		}
+		if !pps.IsTerminal(jobInfo.State) || jobInfo.Finished == nil {
+			return nil
+		}
+		if finished, err := types.TimestampFromProto(jobInfo.Finished); err != nil || time.Since(finished) > logArchiveRetention(m.env) {
+			return nil
+		}
+		if err := m.archiveJobLogs(ctx, objC, jobInfo); err != nil {
+			log.Errorf("PPS master: could not archive the logs of job %v: %v", jobInfo.Job, err)
+		}
+		return nil
+	}))
+}
+
+// archiveJobLogs writes the logs of a finished job, from its worker master
+// and its workers, to object storage as newline-delimited JSON, unless
+// they've already been archived.
+func (m *ppsMaster) archiveJobLogs(ctx context.Context, objC obj.Client, jobInfo *pps.JobInfo) error {
+	name := logArchiveName(jobInfo.Job)
+	if exists, err := objC.Exists(ctx, name); err != nil || exists {
+		return errors.EnsureStack(err)
+	}
+	since := time.Hour
+	if created, err := types.TimestampFromProto(jobInfo.Created); err == nil {
+		since += time.Since(created)
+	}
+	pachClient := m.env.GetPachClient(ctx)
+	return miscutil.WithPipe(func(w io.Writer) error {
+		marshaler := &jsonpb.Marshaler{}
+		for _, master := range []bool{true, false} {
+			iter := pachClient.GetLogsForRequest(&pps.GetLogsRequest{
+				Job:    jobInfo.Job,
+				Master: master,
+				Since:  types.DurationProto(since),
+			})
+			for iter.Next() {
+				if err := marshaler.Marshal(w, iter.Message()); err != nil {
+					return errors.EnsureStack(err)
+				}
+				if _, err := w.Write([]byte("\n")); err != nil {
+					return errors.EnsureStack(err)
+				}
+			}
+			if err := iter.Err(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, func(r io.Reader) error {
+		return errors.EnsureStack(objC.Put(ctx, name, r))
+	})
+}
+
+// sweepLogArchive deletes the archived logs of jobs that finished before the
+// retention period, or that have been deleted.
+func (m *ppsMaster) sweepLogArchive(ctx context.Context, objC obj.Client, jobs col.PostgresCollection) error {
+	var expired []string
+	if err := objC.Walk(ctx, logArchivePrefix+"/", func(name string) error {
+		job, err := parseLogArchiveName(name)
+		if err != nil {
+			log.Errorf("PPS master: %v", err)
+			return nil
+		}
+		jobInfo := &pps.JobInfo{}
+		if err := jobs.ReadOnly(ctx).Get(ppsdb.JobKey(job), jobInfo); err != nil {
+			if !col.IsErrNotFound(err) {
+				return errors.EnsureStack(err)
+			}
+			expired = append(expired, name)
+			return nil
+		}
+		if finished, err := types.TimestampFromProto(jobInfo.Finished); err == nil && time.Since(finished) > logArchiveRetention(m.env) {
+			expired = append(expired, name)
+		}
+		return nil
+	}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	for _, name := range expired {
+		if err := objC.Delete(ctx, name); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	return nil
+}
+
+// GetArchivedLogs implements the protobuf pps.GetArchivedLogs RPC
+func (a *apiServer) GetArchivedLogs(request *pps.GetArchivedLogsRequest, server pps.API_GetArchivedLogsServer) (retErr error) {
+	ctx := server.Context()
+	if request.Job == nil || request.Job.GetPipeline().GetName() == "" || request.Job.ID == "" {
+		return errors.New("must specify a job and its pipeline")
+	}
+	if logArchiveRetention(a.env) <= 0 || a.env.GetObjectClient == nil {
+		return errors.New("job logs aren't archived in this cluster, as LOG_ARCHIVE_RETENTION_DAYS isn't set")
+	}
+	filter, err := newLogFilter(request.Filter, request.Level)
+	if err != nil {
+		return err
+	}
+	jobInfo := &pps.JobInfo{}
+	if err := a.jobs.ReadOnly(ctx).Get(ppsdb.JobKey(request.Job), jobInfo); err != nil {
+		return errors.Wrapf(err, "could not get job information for %q", request.Job.ID)
+	}
+	pipelineInfo, err := a.inspectPipeline(ctx, jobInfo.Job.Pipeline.Name, true)
+	if err != nil {
+		return errors.Wrapf(err, "could not get pipeline information for %s", jobInfo.Job.Pipeline.Name)
+	}
+	if err := a.authorizePipelineOp(ctx, pipelineOpGetLogs, pipelineInfo.Details.Input, pipelineInfo.Pipeline.Name); err != nil {
+		return err
+	}
+	objC, err := a.env.GetObjectClient()
+	if err != nil {
+		return err
+	}
+	name := logArchiveName(jobInfo.Job)
+	if exists, err := objC.Exists(ctx, name); err != nil {
+		return errors.EnsureStack(err)
+	} else if !exists {
+		return errors.Errorf("job %v has no archived logs, they are archived when it finishes", jobInfo.Job.ID)
+	}
+	return miscutil.WithPipe(func(w io.Writer) error {
+		return errors.EnsureStack(objC.Get(ctx, name, w))
+	}, func(r io.Reader) error {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(nil, 16*1024*1024)
+		for scanner.Scan() {
+			msg := &pps.LogMessage{}
+			if err := jsonpb.UnmarshalString(scanner.Text(), msg); err != nil {
+				return errors.EnsureStack(err)
+			}
+			if request.Datum != nil && request.Datum.ID != msg.DatumID {
+				continue
+			}
+			if request.Master != msg.Master {
+				continue
+			}
+			if !common.MatchDatum(request.DataFilters, msg.Data) || !filter.match(msg) {
+				continue
+			}
+			if err := server.Send(msg); err != nil {
+				return errors.EnsureStack(err)
+			}
+		}
+		return errors.EnsureStack(scanner.Err())
+	})
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestLogArchiveName(t *testing.T) {
+	job := client.NewJob("edges", "0123456789abcdef0123456789abcdef")
+	name := logArchiveName(job)
+	require.Equal(t, "pps-logs/edges/0123456789abcdef0123456789abcdef", name)
+	parsed, err := parseLogArchiveName(name)
+	require.NoError(t, err)
+	require.Equal(t, job.Pipeline.Name, parsed.Pipeline.Name)
+	require.Equal(t, job.ID, parsed.ID)
+
+	for _, name := range []string{
+		"pps-logs/edges",
+		"pps-logs/edges/job/extra",
+		"other/edges/0123456789abcdef0123456789abcdef",
+	} {
+		_, err := parseLogArchiveName(name)
+		require.YesError(t, err, name)
+	}
+}
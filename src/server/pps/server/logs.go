@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// logFilter holds the filters of a logs request that apply to the content of
+// log lines, rather than to where they were logged.
+type logFilter struct {
+	re    *regexp.Regexp
+	level pps.LogLevel
+}
+
+func newLogFilter(filter string, level pps.LogLevel) (*logFilter, error) {
+	f := &logFilter{level: level}
+	if filter != "" {
+		re, err := regexp.Compile(filter)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid filter %q", filter)
+		}
+		f.re = re
+	}
+	return f, nil
+}
+
+// match returns whether msg passes the filter.
+func (f *logFilter) match(msg *pps.LogMessage) bool {
+	if f.level != pps.LogLevel_LOG_LEVEL_UNKNOWN && pps.LogLevelOf(msg) < f.level {
+		return false
+	}
+	return f.re == nil || f.re.MatchString(msg.Message)
+}
+
+// withHeartbeats calls f with a function that sends log messages to server.
+// If interval is positive, a heartbeat message is sent whenever no message
+// has been sent for interval, until f returns.
+func withHeartbeats(ctx context.Context, server pps.API_GetLogsServer, interval time.Duration, f func(send func(*pps.LogMessage) error) error) error {
+	var mu sync.Mutex
+	lastSent := time.Now()
+	send := func(msg *pps.LogMessage) error {
+		mu.Lock()
+		defer mu.Unlock()
+		lastSent = time.Now()
+		return errors.EnsureStack(server.Send(msg))
+	}
+	if interval <= 0 {
+		return f(send)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	defer func() {
+		cancel()
+		<-done
+	}()
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+			mu.Lock()
+			if time.Since(lastSent) >= interval {
+				// A failed heartbeat means the stream is broken, which f will
+				// find out when it next sends a message.
+				server.Send(&pps.LogMessage{Heartbeat: true, Ts: types.TimestampNow()})
+				lastSent = time.Now()
+			}
+			mu.Unlock()
+		}
+	}()
+	return f(send)
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	for line, level := range map[string]pps.LogLevel{
+		"ERROR: could not open file":                    pps.LogLevel_LOG_ERROR,
+		"[warn] disk is almost full":                    pps.LogLevel_LOG_WARNING,
+		"WARNING:root:deprecated option":                pps.LogLevel_LOG_WARNING,
+		"debug":                                         pps.LogLevel_LOG_DEBUG,
+		`time="2021-01-01T00:00:00Z" level=info msg=hi`: pps.LogLevel_LOG_INFO,
+		`{"level":"error","msg":"failed"}`:              pps.LogLevel_LOG_ERROR,
+		"errors are counted below":                      pps.LogLevel_LOG_LEVEL_UNKNOWN,
+		"processed 10 files":                            pps.LogLevel_LOG_LEVEL_UNKNOWN,
+	} {
+		require.Equal(t, level, pps.ParseLogLevel(line), line)
+	}
+}
+
+func TestLogFilter(t *testing.T) {
+	filter, err := newLogFilter("time(out)?", pps.LogLevel_LOG_WARNING)
+	require.NoError(t, err)
+	require.True(t, filter.match(&pps.LogMessage{Message: "request timeout", Level: pps.LogLevel_LOG_ERROR}))
+	require.False(t, filter.match(&pps.LogMessage{Message: "request timeout", Level: pps.LogLevel_LOG_INFO}))
+	require.False(t, filter.match(&pps.LogMessage{Message: "request timeout"}))
+	require.False(t, filter.match(&pps.LogMessage{Message: "request failed", Level: pps.LogLevel_LOG_ERROR}))
+
+	filter, err = newLogFilter("", pps.LogLevel_LOG_LEVEL_UNKNOWN)
+	require.NoError(t, err)
+	require.True(t, filter.match(&pps.LogMessage{Message: "anything", Level: pps.LogLevel_LOG_DEBUG}))
+
+	_, err = newLogFilter("(", pps.LogLevel_LOG_LEVEL_UNKNOWN)
+	require.YesError(t, err)
+}
+
+type testLogsServer struct {
+	pps.API_GetLogsServer
+	mu   sync.Mutex
+	msgs []*pps.LogMessage
+}
+
+func (s *testLogsServer) Send(msg *pps.LogMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.msgs = append(s.msgs, msg)
+	return nil
+}
+
+func TestWithHeartbeats(t *testing.T) {
+	server := &testLogsServer{}
+	require.NoError(t, withHeartbeats(context.Background(), server, 10*time.Millisecond, func(send func(*pps.LogMessage) error) error {
+		if err := send(&pps.LogMessage{Message: "first"}); err != nil {
+			return err
+		}
+		time.Sleep(100 * time.Millisecond)
+		return send(&pps.LogMessage{Message: "last"})
+	}))
+	require.True(t, len(server.msgs) > 2)
+	require.Equal(t, "first", server.msgs[0].Message)
+	require.Equal(t, "last", server.msgs[len(server.msgs)-1].Message)
+	for _, msg := range server.msgs[1 : len(server.msgs)-1] {
+		require.True(t, msg.Heartbeat)
+	}
+
+	server = &testLogsServer{}
+	require.NoError(t, withHeartbeats(context.Background(), server, 0, func(send func(*pps.LogMessage) error) error {
+		time.Sleep(20 * time.Millisecond)
+		return send(&pps.LogMessage{Message: "only"})
+	}))
+	require.Equal(t, 1, len(server.msgs))
+}
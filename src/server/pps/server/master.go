@@ -77,6 +77,7 @@ type ppsMaster struct {
 	pollCancel      func() // protected by pollPipelinesMu
 	pollPodsCancel  func() // protected by pollPipelinesMu
 	watchCancel     func() // protected by pollPipelinesMu
+	archiveCancel   func() // protected by pollPipelinesMu
 	pcMgr           *pcManager
 	kd              InfraDriver
 	sd              PipelineStateDriver
@@ -117,6 +118,10 @@ func (a *apiServer) master() {
 		kd := newKubeDriver(a.env.KubeClient, a.env.Config, a.env.Logger)
 		sd := newPipelineStateDriver(a.env.DB, a.pipelines, a.txnEnv, a.env.PFSServer)
 		m := newMaster(ctx, a.env, a.etcdPrefix, kd, sd)
+		cancelRollbacks := startMonitorThread(ctx, "rollBackFailedUpdates", a.rollBackFailedUpdates)
+		defer cancelRollbacks()
+		cancelSLOs := startMonitorThread(ctx, "checkSLOs", a.checkSLOs)
+		defer cancelSLOs()
 		m.run()
 		return errors.Wrapf(ctx.Err(), "ppsMaster.Run() exited unexpectedly")
 	}, backoff.NewInfiniteBackOff(), func(err error, d time.Duration) error {
@@ -161,6 +166,8 @@ func (m *ppsMaster) run() {
 	defer m.cancelPipelinePodsPoller()
 	m.startPipelineWatcher()
 	defer m.cancelPipelineWatcher()
+	m.startLogArchiver()
+	defer m.cancelLogArchiver()
 
 eventLoop:
 	for {
@@ -3,8 +3,11 @@ package server
 import (
 	"bytes"
 	"context"
+	"math/rand"
 	"path"
 	"time"
+	// pachd's image has no zoneinfo, which cron input time zones need
+	_ "time/tzdata"
 
 	"github.com/gogo/protobuf/types"
 	opentracing "github.com/opentracing/opentracing-go"
@@ -16,6 +19,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	logutil "github.com/pachyderm/pachyderm/v2/src/internal/log"
 	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/tracing"
 	"github.com/pachyderm/pachyderm/v2/src/internal/tracing/extended"
@@ -68,8 +72,9 @@ func startMonitorThread(ctx context.Context, name string, f func(ctx context.Con
 	ctx, cancel := context.WithCancel(ctx)
 	done := make(chan struct{})
 	go func() {
+		defer close(done)
+		defer logutil.Recover(name, nil)
 		f(ctx)
-		close(done)
 	}()
 	return func() {
 		cancel()
@@ -97,8 +102,32 @@ func (pc *pipelineController) monitorPipeline(ctx context.Context, pipelineInfo
 					backoff.NotifyCtx(ctx, "cron for "+in.Cron.Name))
 			})
 		}
+		if in.Pfs != nil && in.Pfs.Remote != nil {
+			eg.Go(func() error {
+				return backoff.RetryNotify(func() error {
+					return mirrorRemoteCommits(ctx, pc.env, in)
+				}, backoff.NewInfiniteBackOff(),
+					backoff.NotifyCtx(ctx, "mirror for "+in.Pfs.Name))
+			})
+		}
+		if in.Pfs != nil && in.Pfs.Sql != nil {
+			eg.Go(func() error {
+				return backoff.RetryNotify(func() error {
+					return ingestSQLCommits(ctx, pc.env, in)
+				}, backoff.NewInfiniteBackOff(),
+					backoff.NotifyCtx(ctx, "sql ingest for "+in.Pfs.Name))
+			})
+		}
 		return nil
 	})
+	if pipelineInfo.Details.Transform.GetImageFrom() != "" {
+		eg.Go(func() error {
+			return backoff.RetryNotify(func() error {
+				return followBuiltImages(ctx, pc.env, pipelineInfo)
+			}, backoff.NewInfiniteBackOff(),
+				backoff.NotifyCtx(ctx, "image_from for "+pipelineInfo.Pipeline.Name))
+		})
+	}
 	if pipelineInfo.Details.Autoscaling {
 		// Capacity 1 gives us a bit of buffer so we don't needlessly go into
 		// standby when SubscribeCommit takes too long to return.
@@ -291,10 +320,33 @@ func cronTick(pachClient *client.APIClient, now time.Time, cron *pps.CronInput)
 					return errors.EnsureStack(err)
 				}
 			}
-			return errors.EnsureStack(m.PutFile(now.Format(time.RFC3339), bytes.NewReader(nil)))
+			// ticks are named in UTC so that they sort by time, see getLatestCronTime
+			return errors.EnsureStack(m.PutFile(now.UTC().Format(time.RFC3339), bytes.NewReader(nil)))
 		})
 }
 
+// cronLocation returns the time zone a cron input's spec is evaluated in.
+func cronLocation(in *pps.CronInput) (*time.Location, error) {
+	if in.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(in.Timezone)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cron timezone %q", in.Timezone)
+	}
+	return loc, nil
+}
+
+// cronJitter returns a random delay for a cron tick, up to the input's
+// jitter.
+func cronJitter(in *pps.CronInput) time.Duration {
+	jitter, err := types.DurationFromProto(in.Jitter)
+	if err != nil || in.Jitter == nil || jitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(jitter)))
+}
+
 // makeCronCommits makes commits to a single cron input's repo. It's
 // a helper function called by monitorPipeline.
 func makeCronCommits(ctx context.Context, env Env, in *pps.Input) error {
@@ -302,6 +354,10 @@ func makeCronCommits(ctx context.Context, env Env, in *pps.Input) error {
 	if err != nil {
 		return errors.EnsureStack(err) // Shouldn't happen, as the input is validated in CreatePipeline
 	}
+	loc, err := cronLocation(in.Cron)
+	if err != nil {
+		return err
+	}
 	pachClient := env.GetPachClient(ctx)
 	latestTime, err := getLatestCronTime(ctx, env, in)
 	if err != nil {
@@ -309,16 +365,20 @@ func makeCronCommits(ctx context.Context, env Env, in *pps.Input) error {
 	}
 
 	for {
-		// get the time of the next time from the latest time using the cron schedule
-		next := schedule.Next(latestTime)
+		// get the time of the next time from the latest time using the cron
+		// schedule, in the input's time zone
+		next := schedule.Next(latestTime.In(loc))
 		if next.IsZero() {
 			return nil // zero time indicates there will never be another tick
 		}
-		// and wait until then to make the next commit
-		select {
-		case <-time.After(time.Until(next)):
-		case <-ctx.Done():
-			return errors.EnsureStack(ctx.Err())
+		// and wait until then to make the next commit. Ticks that are already
+		// due, such as backfilled ones, are made immediately.
+		if wait := time.Until(next); wait > 0 {
+			select {
+			case <-time.After(wait + cronJitter(in.Cron)):
+			case <-ctx.Done():
+				return errors.EnsureStack(ctx.Err())
+			}
 		}
 		if err := cronTick(pachClient, next, in.Cron); err != nil {
 			return err
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// workerNetworkPolicy returns the NetworkPolicy, called name, that restricts
+// the egress of the worker pods selected by podSelector to the endpoints in
+// spec. The workers may also always resolve DNS, connect to pachd and
+// pg-bouncer, and connect to egressCIDRs, which pachd is configured with.
+func workerNetworkPolicy(name string, labels, podSelector map[string]string, spec *pps.NetworkPolicySpec, egressCIDRs []string) *networkingv1.NetworkPolicy {
+	udp, tcp := v1.ProtocolUDP, v1.ProtocolTCP
+	dnsPort := intstr.FromInt(53)
+	rules := []networkingv1.NetworkPolicyEgressRule{
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Protocol: &udp, Port: &dnsPort},
+				{Protocol: &tcp, Port: &dnsPort},
+			},
+		},
+		{
+			To: []networkingv1.NetworkPolicyPeer{
+				{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "pachd"}}},
+				{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "pg-bouncer"}}},
+			},
+		},
+	}
+	for _, cidr := range egressCIDRs {
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: cidr}}},
+		})
+	}
+	for _, endpoint := range spec.Egress {
+		var rule networkingv1.NetworkPolicyEgressRule
+		if endpoint.Cidr != "" {
+			rule.To = []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{
+				CIDR:   endpoint.Cidr,
+				Except: endpoint.Except,
+			}}}
+		} else {
+			peer := networkingv1.NetworkPolicyPeer{}
+			if endpoint.NamespaceSelector != nil {
+				peer.NamespaceSelector = &metav1.LabelSelector{MatchLabels: endpoint.NamespaceSelector}
+			}
+			if endpoint.PodSelector != nil {
+				peer.PodSelector = &metav1.LabelSelector{MatchLabels: endpoint.PodSelector}
+			}
+			rule.To = []networkingv1.NetworkPolicyPeer{peer}
+		}
+		for _, port := range endpoint.Ports {
+			protocol := v1.Protocol(endpoint.Protocol)
+			if protocol == "" {
+				protocol = v1.ProtocolTCP
+			}
+			port := intstr.FromInt(int(port))
+			rule.Ports = append(rule.Ports, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &port})
+		}
+		rules = append(rules, rule)
+	}
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NetworkPolicy",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: podSelector},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      rules,
+		},
+	}
+}
+
+// workerEgressCIDRs returns the CIDRs that the workers of pipelines with
+// network policies may always connect to: the Kubernetes API server, which
+// the workers use, and the CIDRs pachd is configured with.
+func (kd *kubeDriver) workerEgressCIDRs() []string {
+	var cidrs []string
+	if kd.config.KubeAddress != "" {
+		cidrs = append(cidrs, kd.config.KubeAddress+"/32")
+	}
+	for _, cidr := range strings.Split(kd.config.WorkerEgressCIDRs, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr != "" {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	return cidrs
+}
+
+// createWorkerNetworkPolicy creates the NetworkPolicy of the workers of the
+// pipeline, if it has a network policy.
+func (kd *kubeDriver) createWorkerNetworkPolicy(ctx context.Context, pipelineInfo *pps.PipelineInfo, options *workerOptions) error {
+	spec := pipelineInfo.Details.NetworkPolicy
+	if spec == nil {
+		return nil
+	}
+	policy := workerNetworkPolicy(options.rcName, options.labels, options.labels, spec, kd.workerEgressCIDRs())
+	if _, err := kd.kubeClient.NetworkingV1().NetworkPolicies(kd.namespace).Create(ctx, policy, metav1.CreateOptions{}); err != nil {
+		if !errutil.IsAlreadyExistError(err) {
+			return errors.EnsureStack(err)
+		}
+	}
+	return nil
+}
+
+// deleteWorkerNetworkPolicies deletes the NetworkPolicies of the workers
+// matching selector.
+func (kd *kubeDriver) deleteWorkerNetworkPolicies(ctx context.Context, selector string, opts metav1.DeleteOptions) error {
+	policies, err := kd.kubeClient.NetworkingV1().NetworkPolicies(kd.namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return errors.Wrapf(err, "could not list network policies")
+	}
+	for _, policy := range policies.Items {
+		if err := kd.kubeClient.NetworkingV1().NetworkPolicies(kd.namespace).Delete(ctx, policy.Name, opts); err != nil {
+			if !errutil.IsNotFoundError(err) {
+				return errors.Wrapf(err, "could not delete network policy %q", policy.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateNetworkPolicy validates the endpoints of a pipeline's network
+// policy.
+func validateNetworkPolicy(spec *pps.NetworkPolicySpec) error {
+	for _, endpoint := range spec.GetEgress() {
+		name := endpoint.Name
+		if name == "" {
+			name = endpoint.Cidr
+		}
+		hasSelector := endpoint.NamespaceSelector != nil || endpoint.PodSelector != nil
+		switch {
+		case endpoint.Cidr == "" && !hasSelector:
+			return errors.Errorf("endpoint %q must have a cidr, or a namespace_selector or pod_selector", name)
+		case endpoint.Cidr != "" && hasSelector:
+			return errors.Errorf("endpoint %q can't have both a cidr and selectors", name)
+		case endpoint.Cidr == "" && len(endpoint.Except) > 0:
+			return errors.Errorf("endpoint %q can only have except with a cidr", name)
+		}
+		if endpoint.Cidr != "" {
+			_, ipNet, err := net.ParseCIDR(endpoint.Cidr)
+			if err != nil {
+				return errors.Wrapf(err, "endpoint %q has an invalid cidr", name)
+			}
+			for _, except := range endpoint.Except {
+				exceptIP, _, err := net.ParseCIDR(except)
+				if err != nil {
+					return errors.Wrapf(err, "endpoint %q has an invalid except", name)
+				}
+				if !ipNet.Contains(exceptIP) {
+					return errors.Errorf("endpoint %q has except %q, which isn't in its cidr", name, except)
+				}
+			}
+		}
+		for _, port := range endpoint.Ports {
+			if port < 1 || port > 65535 {
+				return errors.Errorf("endpoint %q has invalid port %d", name, port)
+			}
+		}
+		switch v1.Protocol(endpoint.Protocol) {
+		case "", v1.ProtocolTCP, v1.ProtocolUDP, v1.ProtocolSCTP:
+		default:
+			return errors.Errorf("endpoint %q has invalid protocol %q (must be TCP, UDP or SCTP)", name, endpoint.Protocol)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestWorkerNetworkPolicy(t *testing.T) {
+	selector := map[string]string{"app": "pipeline-a-v1"}
+	spec := &pps.NetworkPolicySpec{Egress: []*pps.NetworkEndpoint{
+		{Name: "pypi", Cidr: "151.101.0.0/16", Ports: []int32{443}},
+		{Name: "feature-store", NamespaceSelector: map[string]string{"name": "features"}},
+	}}
+	policy := workerNetworkPolicy("pipeline-a-v1", selector, selector, spec, []string{"10.0.0.0/8"})
+	require.Equal(t, selector, policy.Spec.PodSelector.MatchLabels)
+	require.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}, policy.Spec.PolicyTypes)
+	// DNS, pachd and pg-bouncer, the configured CIDR and the two endpoints
+	rules := policy.Spec.Egress
+	require.Equal(t, 5, len(rules))
+	require.Equal(t, "10.0.0.0/8", rules[2].To[0].IPBlock.CIDR)
+	require.Equal(t, "151.101.0.0/16", rules[3].To[0].IPBlock.CIDR)
+	require.Equal(t, 443, rules[3].Ports[0].Port.IntValue())
+	require.Equal(t, map[string]string{"name": "features"}, rules[4].To[0].NamespaceSelector.MatchLabels)
+	require.Nil(t, rules[4].To[0].PodSelector)
+	require.Equal(t, 0, len(rules[4].Ports))
+}
+
+func TestValidateNetworkPolicy(t *testing.T) {
+	valid := func(endpoint *pps.NetworkEndpoint) error {
+		return validateNetworkPolicy(&pps.NetworkPolicySpec{Egress: []*pps.NetworkEndpoint{endpoint}})
+	}
+	require.NoError(t, validateNetworkPolicy(nil))
+	require.NoError(t, valid(&pps.NetworkEndpoint{Cidr: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}, Ports: []int32{443}}))
+	require.NoError(t, valid(&pps.NetworkEndpoint{PodSelector: map[string]string{"app": "feast"}, Protocol: "UDP"}))
+	require.YesError(t, valid(&pps.NetworkEndpoint{Name: "nothing"}))
+	require.YesError(t, valid(&pps.NetworkEndpoint{Cidr: "10.0.0.0/8", PodSelector: map[string]string{"app": "feast"}}))
+	require.YesError(t, valid(&pps.NetworkEndpoint{Cidr: "pypi.org"}))
+	require.YesError(t, valid(&pps.NetworkEndpoint{Cidr: "10.0.0.0/8", Except: []string{"192.168.0.0/16"}}))
+	require.YesError(t, valid(&pps.NetworkEndpoint{Cidr: "10.0.0.0/8", Ports: []int32{70000}}))
+	require.YesError(t, valid(&pps.NetworkEndpoint{Cidr: "10.0.0.0/8", Protocol: "ICMP"}))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	logutil "github.com/pachyderm/pachyderm/v2/src/internal/log"
 	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/tracing"
 	"github.com/pachyderm/pachyderm/v2/src/internal/tracing/extended"
@@ -268,6 +269,7 @@ func (pc *pipelineController) tryFinish() {
 //
 // returns true if the pipeline is deleted, and the pipelineController can try to shutdown
 func (pc *pipelineController) step(timestamp time.Time) (isDelete bool, retErr error) {
+	defer logutil.Recover("PPS master step for "+pc.pipeline, &retErr)
 	log.Debugf("PPS master: processing event for %q", pc.pipeline)
 	// Handle tracing
 	span, _ := extended.AddSpanToAnyPipelineTrace(pc.ctx, pc.env.EtcdClient, pc.pipeline, "/pps.Master/ProcessPipelineUpdate")
@@ -372,6 +374,15 @@ func evaluate(pi *pps.PipelineInfo, rc *v1.ReplicationController) (pps.PipelineS
 			}
 			return pps.PipelineState_PIPELINE_RUNNING, nil, "", nil
 		}
+		if pi.Drain != nil && pi.Drain.State == pps.DrainState_DRAIN_IN_PROGRESS {
+			return pi.State, []sideEffect{
+				PipelineMonitorSideEffect(sideEffectToggle_DOWN),
+				CrashingMonitorSideEffect(sideEffectToggle_DOWN),
+				// keep the workers until they've finished their in-flight
+				// datums, as the worker master completes the drain
+				ScaleWorkersSideEffect(sideEffectToggle_UP),
+			}, "", nil
+		}
 		return pi.State, []sideEffect{
 			// don't want cron commits or STANDBY state changes while pipeline is
 			// stopped
@@ -468,6 +479,10 @@ func rcIsFresh(pi *pps.PipelineInfo, rc *v1.ReplicationController) bool {
 		log.Infof("PPS master: auth token in %q is stale %s != %s",
 			pi.Pipeline.Name, rcAuthTokenHash, hashAuthToken(pi.AuthToken))
 		return false
+	case (rc.ObjectMeta.Annotations[onDemandAnnotation] == "true") != pi.SchedulingStatus.GetOnDemand():
+		log.Infof("PPS master: workers of %q must be rescheduled onto on-demand nodes",
+			pi.Pipeline.Name)
+		return false
 	}
 	return true
 }
@@ -555,58 +570,35 @@ func (pc *pipelineController) scaleUpPipeline(ctx context.Context, pi *pps.Pipel
 	if pi.Details.ParallelismSpec != nil && pi.Details.ParallelismSpec.Constant > 0 {
 		maxScale = int32(pi.Details.ParallelismSpec.Constant)
 	}
+	if pi.Details.DatumAutoscaling != nil {
+		maxScale = int32(pi.Details.DatumAutoscaling.MaxWorkers)
+	}
+	if pi.Details.KubernetesJobs {
+		// scale the pipeline's Kubernetes Job, rather than its RC
+		job, err := pc.iDriver.ReadWorkerJob(ctx, oldRC)
+		if err != nil {
+			return newRetriableError(err, "error reading worker job")
+		}
+		var curScale int32
+		if job != nil && job.Spec.Parallelism != nil {
+			curScale = *job.Spec.Parallelism
+		}
+		targetScale := pc.targetScale(ctx, pi, curScale, maxScale)
+		if curScale == targetScale {
+			return nil // no changes necessary
+		}
+		return errors.EnsureStack(pc.iDriver.ScaleWorkerJob(ctx, oldRC, targetScale))
+	}
+	if pi.Details.WorkerPool != nil {
+		return pc.scaleUpPooledPipeline(ctx, pi, oldRC, maxScale)
+	}
 	// update pipeline RC
 	return errors.EnsureStack(pc.iDriver.UpdateReplicationController(ctx, oldRC, func(rc *v1.ReplicationController) bool {
 		var curScale int32
 		if rc.Spec.Replicas != nil && *rc.Spec.Replicas > 0 {
 			curScale = *rc.Spec.Replicas
 		}
-		targetScale := func() int32 {
-			if !pi.Details.Autoscaling {
-				return maxScale // don't bother if Autoscaling is off
-			}
-			if curScale == 0 {
-				return 1 // make one pod to be the worker master & calculate tasks
-			}
-			// Master is scheduled; see if tasks have been calculated
-			var nTasks int32
-			// TODO: should this run through internal PPS service?
-			err := pc.env.GetPachClient(ctx).ListTask("pps", driver.TaskNamespace(pi), "", func(*task.TaskInfo) error {
-				nTasks++
-				return nil
-			})
-			// Set parallelism
-			log.Debugf("Beginning scale-up check for %q, which has %d tasks",
-				pi.Pipeline.Name, nTasks)
-			switch {
-			case err != nil || nTasks == 0:
-				log.Errorf("tasks remaining for %q not known (possibly still being calculated): %v",
-					pi.Pipeline.Name, err)
-				return curScale // leave pipeline alone until until nTasks is available
-			case nTasks <= curScale:
-				return curScale // can't scale down w/o dropping work
-			case nTasks <= maxScale:
-				return nTasks
-			default:
-				return maxScale
-			}
-		}()
-		if targetScale < maxScale {
-			// schedule another step in scaleUpInterval, to check the tasks again
-			go func() {
-				time.Sleep(pc.scaleUpInterval)
-				// Normally, it's necessary to acquire the mutex in step.pc.pcMgr and
-				// then read the latest pipelineController from pcMgr before calling
-				// Bump(), in order to avoid Bumping a dead pipelineController and
-				// dropping a Bump event. But in this case, we'd rather drop the Bump
-				// event. If this pipeline was recently updated, the new pipeline may
-				// not have autoscaling, or it may simply not make sense to trigger an
-				// update anymore.
-				if pc.ctx.Err() == nil {
-					pc.Bump(time.Time{}) // no ts, as it's not a new event
-				}
-			}()
-		}
+		targetScale := pc.targetScale(ctx, pi, curScale, maxScale)
 		if curScale == targetScale {
 			return false // no changes necessary
 		}
@@ -616,6 +608,108 @@ func (pc *pipelineController) scaleUpPipeline(ctx context.Context, pi *pps.Pipel
 	}))
 }
 
+// scaleUpPooledPipeline scales up a pipeline with worker_pool set, by
+// claiming idle workers from its worker pool. Workers that the pool can't
+// provide are started by the pipeline's RC, as they would be without a pool.
+func (pc *pipelineController) scaleUpPooledPipeline(ctx context.Context, pi *pps.PipelineInfo, rc *v1.ReplicationController, maxScale int32) error {
+	claimed, err := pc.iDriver.ReadPoolWorkers(ctx, rc)
+	if err != nil {
+		return newRetriableError(err, "error reading pool workers")
+	}
+	var cold int32
+	if rc.Spec.Replicas != nil {
+		cold = *rc.Spec.Replicas
+	}
+	curScale := claimed + cold
+	targetScale := pc.targetScale(ctx, pi, curScale, maxScale)
+	if targetScale <= curScale {
+		return nil // pooled pipelines are only scaled down by scaleDownPipeline
+	}
+	n, err := pc.iDriver.ClaimPoolWorkers(ctx, rc, targetScale-curScale)
+	if err != nil {
+		return newRetriableError(err, "error claiming pool workers")
+	}
+	if n == targetScale-curScale {
+		return nil
+	}
+	log.Infof("PPS master: worker pool of %q had %d of %d workers, starting the rest", pi.Pipeline.Name, n, targetScale-curScale)
+	return errors.EnsureStack(pc.iDriver.UpdateReplicationController(ctx, rc, func(rc *v1.ReplicationController) bool {
+		replicas := targetScale - claimed - n
+		rc.Spec.Replicas = &replicas
+		return true
+	}))
+}
+
+// targetScale returns the number of workers pc's pipeline should have, given
+// that it has curScale workers and may have at most maxScale. If the pipeline
+// may need to scale again, it also schedules another step.
+func (pc *pipelineController) targetScale(ctx context.Context, pi *pps.PipelineInfo, curScale, maxScale int32) int32 {
+	datumAutoscaling := pi.Details.DatumAutoscaling
+	targetScale := func() int32 {
+		if datumAutoscaling != nil {
+			pachClient := pc.env.GetPachClient(ctx)
+			pachClient.SetAuthToken(pi.AuthToken)
+			backlog, err := getDatumBacklog(pachClient, pi.Pipeline.Name)
+			if err != nil {
+				log.Errorf("datum backlog for %q not known: %v", pi.Pipeline.Name, err)
+				if curScale == 0 {
+					return 1
+				}
+				return curScale
+			}
+			log.Debugf("Autoscaling %q, which has %d pending datums taking %v each",
+				pi.Pipeline.Name, backlog.pending, backlog.datumTime)
+			return datumAutoscaleTarget(datumAutoscaling, backlog)
+		}
+		if !pi.Details.Autoscaling {
+			return maxScale // don't bother if Autoscaling is off
+		}
+		if curScale == 0 {
+			return 1 // make one pod to be the worker master & calculate tasks
+		}
+		// Master is scheduled; see if tasks have been calculated
+		var nTasks int32
+		// TODO: should this run through internal PPS service?
+		err := pc.env.GetPachClient(ctx).ListTask("pps", driver.TaskNamespace(pi), "", func(*task.TaskInfo) error {
+			nTasks++
+			return nil
+		})
+		// Set parallelism
+		log.Debugf("Beginning scale-up check for %q, which has %d tasks",
+			pi.Pipeline.Name, nTasks)
+		switch {
+		case err != nil || nTasks == 0:
+			log.Errorf("tasks remaining for %q not known (possibly still being calculated): %v",
+				pi.Pipeline.Name, err)
+			return curScale // leave pipeline alone until until nTasks is available
+		case nTasks <= curScale:
+			return curScale // can't scale down w/o dropping work
+		case nTasks <= maxScale:
+			return nTasks
+		default:
+			return maxScale
+		}
+	}()
+	if targetScale < maxScale || datumAutoscaling != nil {
+		// schedule another step in scaleUpInterval, to check the tasks (or,
+		// with datum autoscaling, the pending datums) again
+		go func() {
+			time.Sleep(pc.scaleUpInterval)
+			// Normally, it's necessary to acquire the mutex in step.pc.pcMgr and
+			// then read the latest pipelineController from pcMgr before calling
+			// Bump(), in order to avoid Bumping a dead pipelineController and
+			// dropping a Bump event. But in this case, we'd rather drop the Bump
+			// event. If this pipeline was recently updated, the new pipeline may
+			// not have autoscaling, or it may simply not make sense to trigger an
+			// update anymore.
+			if pc.ctx.Err() == nil {
+				pc.Bump(time.Time{}) // no ts, as it's not a new event
+			}
+		}()
+	}
+	return targetScale
+}
+
 // scaleDownPipeline edits the RC associated with pc's pipeline & spins down the
 // configured number of workers.
 func (pc *pipelineController) scaleDownPipeline(ctx context.Context, pi *pps.PipelineInfo, rc *v1.ReplicationController) (retErr error) {
@@ -629,6 +723,15 @@ func (pc *pipelineController) scaleDownPipeline(ctx context.Context, pi *pps.Pip
 		tracing.TagAnySpan(span, "err", retErr)
 		tracing.FinishAnySpan(span)
 	}()
+	if pi.Details.KubernetesJobs {
+		// the pipeline's work is done, so delete its Kubernetes Job
+		return errors.EnsureStack(pc.iDriver.DeleteWorkerJob(ctx, rc))
+	}
+	if pi.Details.WorkerPool != nil {
+		if err := pc.iDriver.ReleasePoolWorkers(ctx, rc); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
 	return errors.EnsureStack(pc.iDriver.UpdateReplicationController(ctx, rc, func(rc *v1.ReplicationController) bool {
 		if rc.Spec.Replicas != nil && *rc.Spec.Replicas == 0 {
 			return false // prior attempt succeeded
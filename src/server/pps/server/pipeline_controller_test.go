@@ -303,6 +303,109 @@ func TestAutoscalingManyTasks(t *testing.T) {
 	require.ElementsEqual(t, []int32{0, 1, 100, 0}, infraDriver.scaleHistory[pi.Pipeline.Name])
 }
 
+func TestKubernetesJobs(t *testing.T) {
+	stateDriver, infraDriver, mockPachd := ppsMasterHandles(t)
+	pipeline := tu.UniqueString(t.Name())
+	done := mockJobRunning(mockPachd, 3, 1)
+	defer close(done)
+	pi := &pps.PipelineInfo{
+		Pipeline: client.NewPipeline(pipeline),
+		State:    pps.PipelineState_PIPELINE_STARTING,
+		Details: &pps.PipelineInfo_Details{
+			Autoscaling:    true,
+			KubernetesJobs: true,
+			ParallelismSpec: &pps.ParallelismSpec{
+				Constant: 2,
+			},
+		},
+		Version: 1,
+	}
+	stateDriver.upsertPipeline(pi)
+	validate(t, stateDriver, infraDriver, []pipelineTest{
+		{
+			pipeline: pipeline,
+			expectedStates: []pps.PipelineState{
+				pps.PipelineState_PIPELINE_STARTING,
+				pps.PipelineState_PIPELINE_STANDBY,
+				pps.PipelineState_PIPELINE_RUNNING,
+				pps.PipelineState_PIPELINE_STANDBY,
+			},
+		},
+	})
+	// the workers ran in a Kubernetes Job, which was deleted once the job
+	// was done, and the RC was never scaled up
+	require.NoErrorWithinT(t, 10*time.Second, func() error {
+		return backoff.Retry(func() error {
+			if len(infraDriver.jobs) == 0 {
+				return nil
+			}
+			return errors.New("worker job hasn't been deleted")
+		}, backoff.NewTestingBackOff())
+	})
+	history := infraDriver.jobScaleHistory[pipeline]
+	require.True(t, len(history) >= 2, "job scale history: %v", history)
+	require.Equal(t, int32(1), history[0])
+	require.Equal(t, int32(0), history[len(history)-1])
+	for _, replicas := range infraDriver.scaleHistory[pipeline] {
+		require.Equal(t, int32(0), replicas)
+	}
+}
+
+func TestWorkerPool(t *testing.T) {
+	stateDriver, infraDriver, mockPachd := ppsMasterHandles(t)
+	infraDriver.idlePoolWorkers = 1
+	pipeline := tu.UniqueString(t.Name())
+	done := mockJobRunning(mockPachd, 3, 1)
+	defer close(done)
+	mockPachd.PFS.InspectCommit.Use(func(context.Context, *pfs.InspectCommitRequest) (*pfs.CommitInfo, error) {
+		// wait for the RC to start the worker the pool didn't have before
+		// closing the commit
+		require.NoError(t, backoff.Retry(func() error {
+			if len(infraDriver.scaleHistory[pipeline]) > 1 {
+				return nil
+			}
+			return errors.New("waiting for scaleHistory to update")
+		}, backoff.NewTestingBackOff()))
+		return &pfs.CommitInfo{}, nil
+	})
+	pi := &pps.PipelineInfo{
+		Pipeline: client.NewPipeline(pipeline),
+		State:    pps.PipelineState_PIPELINE_STARTING,
+		Details: &pps.PipelineInfo_Details{
+			Autoscaling: true,
+			WorkerPool:  &pps.WorkerPool{Warm: 1},
+			ParallelismSpec: &pps.ParallelismSpec{
+				Constant: 2,
+			},
+		},
+		Version: 1,
+	}
+	stateDriver.upsertPipeline(pi)
+	validate(t, stateDriver, infraDriver, []pipelineTest{
+		{
+			pipeline: pipeline,
+			expectedStates: []pps.PipelineState{
+				pps.PipelineState_PIPELINE_STARTING,
+				pps.PipelineState_PIPELINE_STANDBY,
+				pps.PipelineState_PIPELINE_RUNNING,
+				pps.PipelineState_PIPELINE_STANDBY,
+			},
+		},
+	})
+	// the pool's idle worker was claimed, the RC started the other worker,
+	// and the claimed worker was released once the job was done
+	require.Equal(t, int32(0), infraDriver.idlePoolWorkers)
+	require.NoErrorWithinT(t, 10*time.Second, func() error {
+		return backoff.Retry(func() error {
+			if len(infraDriver.poolWorkers) == 0 {
+				return nil
+			}
+			return errors.New("pool workers haven't been released")
+		}, backoff.NewTestingBackOff())
+	})
+	require.ElementsEqual(t, []int32{0, 1, 0}, infraDriver.scaleHistory[pipeline])
+}
+
 func TestAutoscalingNoCommits(t *testing.T) {
 	stateDriver, infraDriver, mockPachd := ppsMasterHandles(t)
 	pipeline := tu.UniqueString(t.Name())
@@ -609,6 +712,18 @@ func TestEvaluate(t *testing.T) {
 		test(initState, expectedResult.state, expectedResult.sideEffects)
 	}
 
+	// Stopped == true with a drain in progress, RC != nil: the workers are
+	// kept until the drain is complete
+	pi.Drain = &pps.Drain{State: pps.DrainState_DRAIN_IN_PROGRESS}
+	test(pps.PipelineState_PIPELINE_PAUSED, pps.PipelineState_PIPELINE_PAUSED, []sideEffect{
+		PipelineMonitorSideEffect(sideEffectToggle_DOWN),
+		CrashingMonitorSideEffect(sideEffectToggle_DOWN),
+		ScaleWorkersSideEffect(sideEffectToggle_UP),
+	})
+	pi.Drain.State = pps.DrainState_DRAIN_COMPLETE
+	test(pps.PipelineState_PIPELINE_PAUSED, pps.PipelineState_PIPELINE_PAUSED, testsStop[pps.PipelineState_PIPELINE_PAUSED].sideEffects)
+	pi.Drain = nil
+
 	// Stopped == false, Autoscaling == true, RC == nil
 	rc = nil
 	pi.Stopped = false
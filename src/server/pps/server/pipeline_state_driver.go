@@ -9,6 +9,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/client"
 	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
 	"github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dbutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
 	"github.com/pachyderm/pachyderm/v2/src/internal/ppsdb"
@@ -41,6 +42,10 @@ type PipelineStateDriver interface {
 	// list all PipelineInfos
 	ListPipelineInfo(ctx context.Context, f func(*pps.PipelineInfo) error) error
 	GetPipelineInfo(ctx context.Context, name string, version int) (*pps.PipelineInfo, error)
+	// RecordPreemption records that a worker of the pipeline @ specCommit was
+	// preempted, and falls back to on-demand nodes if the pipeline's
+	// preemptible scheduling says to
+	RecordPreemption(ctx context.Context, specCommit *pfs.Commit) error
 }
 
 type stateDriver struct {
@@ -131,6 +136,25 @@ func (sd *stateDriver) GetPipelineInfo(ctx context.Context, name string, version
 	return &pipelineInfo, nil
 }
 
+func (sd *stateDriver) RecordPreemption(ctx context.Context, specCommit *pfs.Commit) error {
+	var preemptions int64
+	var fellBack bool
+	if err := dbutil.WithTx(ctx, sd.db, func(sqlTx *pachsql.Tx) error {
+		pipelineInfo := &pps.PipelineInfo{}
+		return errors.EnsureStack(sd.pipelines.ReadWrite(sqlTx).Update(specCommit, pipelineInfo, func() error {
+			preemptions, fellBack = recordPreemption(pipelineInfo)
+			return nil
+		}))
+	}); err != nil {
+		return errors.Wrapf(err, "could not record preemption of %q", specCommit.Branch.Repo.Name)
+	}
+	log.Infof("PPS master: worker of %q was preempted (%d preemptions)", specCommit.Branch.Repo.Name, preemptions)
+	if fellBack {
+		log.Infof("PPS master: %q is falling back to on-demand nodes after %d preemptions", specCommit.Branch.Repo.Name, preemptions)
+	}
+	return nil
+}
+
 func (sd *stateDriver) tryLoadLatestPipelineInfo(ctx context.Context, pipeline string) (*pps.PipelineInfo, error) {
 	pi := &pps.PipelineInfo{}
 	errCnt := 0
@@ -263,6 +287,17 @@ func (d *mockStateDriver) GetPipelineInfo(ctx context.Context, name string, vers
 	return nil, errors.New("not found")
 }
 
+func (d *mockStateDriver) RecordPreemption(ctx context.Context, specCommit *pfs.Commit) error {
+	if pi, ok := d.specCommits[specCommit.ID]; ok {
+		pi = proto.Clone(pi).(*pps.PipelineInfo)
+		recordPreemption(pi)
+		d.specCommits[specCommit.ID] = pi
+		d.pushWatchEvent(pi, watch.EventPut)
+		return nil
+	}
+	return errors.New("pipeline does not exist")
+}
+
 func (d *mockStateDriver) upsertPipeline(pi *pps.PipelineInfo) *pfs.Commit {
 	mockSpecCommit := client.NewCommit(pi.Pipeline.Name, "master", uuid.NewWithoutDashes())
 	pi.SpecCommit = mockSpecCommit
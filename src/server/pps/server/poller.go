@@ -8,6 +8,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
 	kube_err "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	kube_watch "k8s.io/apimachinery/pkg/watch"
 
 	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
@@ -169,8 +170,12 @@ func (m *ppsMaster) pollPipelines(ctx context.Context) {
 //   2) Checks if the Pod belongs to a pipeline (pipelineName annotation is set)
 //   3) Checks if the Pod is failing
 // If all three conditions are met, then the pipline (in 'pipelineName') is set
-// to CRASHING
+// to CRASHING. Pods whose node was preempted are recorded as preemptions of
+// their pipeline instead, if it's scheduled on preemptible nodes.
 func (m *ppsMaster) pollPipelinePods(ctx context.Context) {
+	// preempted tracks the pods whose preemption has been recorded, so that
+	// each preemption is only recorded once, even across watches
+	preempted := make(map[types.UID]bool)
 	if err := backoff.RetryUntilCancel(ctx, backoff.MustLoop(func() error {
 		watch, cancel, err := m.kd.WatchPipelinePods(ctx)
 		if err != nil {
@@ -210,6 +215,26 @@ func (m *ppsMaster) pollPipelinePods(ctx context.Context) {
 					}
 					return m.setPipelineCrashing(ctx, pipelineInfo.SpecCommit, reason)
 				}
+				if podPreempted(pod) && !preempted[pod.UID] {
+					pipelineName := pod.ObjectMeta.Annotations["pipelineName"]
+					pipelineVersion, versionErr := strconv.Atoi(pod.ObjectMeta.Annotations["pipelineVersion"])
+					if versionErr != nil {
+						return errors.Wrapf(versionErr, "couldn't find pipeline rc version")
+					}
+					pipelineInfo, err := m.sd.GetPipelineInfo(ctx, pipelineName, pipelineVersion)
+					if err != nil {
+						return errors.EnsureStack(err)
+					}
+					if pipelineInfo.Details.GetSchedulingSpec().GetPreemptible() != nil {
+						if err := m.sd.RecordPreemption(ctx, pipelineInfo.SpecCommit); err != nil {
+							return err
+						}
+					}
+					preempted[pod.UID] = true
+				}
+				if event.Type == kube_watch.Deleted {
+					delete(preempted, pod.UID)
+				}
 				for _, status := range pod.Status.ContainerStatuses {
 					if status.State.Waiting != nil && failures[status.State.Waiting.Reason] {
 						if err := crashPipeline(status.State.Waiting.Message); err != nil {
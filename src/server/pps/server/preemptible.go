@@ -0,0 +1,148 @@
+package server
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// onDemandAnnotation is set on the RCs of pipelines whose workers have fallen
+// back from preemptible nodes to on-demand nodes.
+const onDemandAnnotation = "onDemand"
+
+// podDisruptionTarget is the condition Kubernetes sets on pods that are about
+// to be stopped by a disruption, such as their node being shut down.
+const podDisruptionTarget v1.PodConditionType = "DisruptionTarget"
+
+// preemptionReasons are the reasons that Kubernetes, and cloud providers,
+// give for stopping pods because their node was reclaimed or shut down.
+var preemptionReasons = map[string]bool{
+	"Shutdown":               true,
+	"NodeShutdown":           true,
+	"Terminated":             true,
+	"NodeLost":               true,
+	"DeletionByTaintManager": true,
+	"TerminationByKubelet":   true,
+}
+
+// podPreempted returns whether pod was stopped because its node was
+// preempted.
+func podPreempted(pod *v1.Pod) bool {
+	if preemptionReasons[pod.Status.Reason] {
+		return true
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == podDisruptionTarget && condition.Status == v1.ConditionTrue && preemptionReasons[condition.Reason] {
+			return true
+		}
+	}
+	return false
+}
+
+// recordPreemption counts a preemption of one of pipelineInfo's workers, and
+// falls back to on-demand nodes once the pipeline has been preempted as many
+// times as its preemptible scheduling allows. It returns the number of
+// preemptions, and whether the pipeline just fell back.
+func recordPreemption(pipelineInfo *pps.PipelineInfo) (int64, bool) {
+	if pipelineInfo.SchedulingStatus == nil {
+		pipelineInfo.SchedulingStatus = &pps.SchedulingStatus{}
+	}
+	status := pipelineInfo.SchedulingStatus
+	status.Preemptions++
+	fallbackAfter := pipelineInfo.Details.GetSchedulingSpec().GetPreemptible().GetFallbackAfterPreemptions()
+	if !status.OnDemand && fallbackAfter > 0 && status.Preemptions >= fallbackAfter {
+		status.OnDemand = true
+		return status.Preemptions, true
+	}
+	return status.Preemptions, false
+}
+
+// applySchedulingSpec schedules the worker pods with podSpec as spec says.
+// onDemand is whether the workers have fallen back from preemptible nodes to
+// on-demand nodes.
+func applySchedulingSpec(podSpec *v1.PodSpec, spec *pps.SchedulingSpec, onDemand bool) {
+	if spec == nil {
+		return
+	}
+	podSpec.PriorityClassName = spec.PriorityClassName
+	nodeSelector := make(map[string]string)
+	for k, v := range spec.NodeSelector {
+		nodeSelector[k] = v
+	}
+	if preemptible := spec.Preemptible; preemptible != nil {
+		if onDemand {
+			for k, v := range preemptible.OnDemandNodeSelector {
+				nodeSelector[k] = v
+			}
+		} else {
+			for k, v := range preemptible.NodeSelector {
+				nodeSelector[k] = v
+			}
+			for _, t := range preemptible.Tolerations {
+				podSpec.Tolerations = append(podSpec.Tolerations, v1.Toleration{
+					Key:      t.Key,
+					Operator: v1.TolerationOperator(t.Operator),
+					Value:    t.Value,
+					Effect:   v1.TaintEffect(t.Effect),
+				})
+			}
+		}
+	}
+	if len(nodeSelector) > 0 {
+		podSpec.NodeSelector = nodeSelector
+	}
+	if len(spec.PreferredNodes) > 0 {
+		var terms []v1.PreferredSchedulingTerm
+		for _, preference := range spec.PreferredNodes {
+			terms = append(terms, v1.PreferredSchedulingTerm{
+				Weight: preference.Weight,
+				Preference: v1.NodeSelectorTerm{
+					MatchExpressions: []v1.NodeSelectorRequirement{{
+						Key:      preference.Key,
+						Operator: v1.NodeSelectorOpIn,
+						Values:   preference.Values,
+					}},
+				},
+			})
+		}
+		podSpec.Affinity = &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: terms,
+		}}
+	}
+}
+
+// validateSchedulingSpec validates the preemptible scheduling and node
+// preferences of a pipeline's scheduling spec.
+func validateSchedulingSpec(spec *pps.SchedulingSpec) error {
+	if preemptible := spec.GetPreemptible(); preemptible != nil {
+		for _, t := range preemptible.Tolerations {
+			switch v1.TolerationOperator(t.Operator) {
+			case "", v1.TolerationOpEqual:
+			case v1.TolerationOpExists:
+				if t.Value != "" {
+					return errors.Errorf("toleration of %q can't have a value with the Exists operator", t.Key)
+				}
+			default:
+				return errors.Errorf("toleration of %q has invalid operator %q (must be Equal or Exists)", t.Key, t.Operator)
+			}
+			switch v1.TaintEffect(t.Effect) {
+			case "", v1.TaintEffectNoSchedule, v1.TaintEffectPreferNoSchedule, v1.TaintEffectNoExecute:
+			default:
+				return errors.Errorf("toleration of %q has invalid effect %q (must be NoSchedule, PreferNoSchedule or NoExecute)", t.Key, t.Effect)
+			}
+		}
+		if preemptible.FallbackAfterPreemptions < 0 {
+			return errors.Errorf("scheduling_spec.preemptible.fallback_after_preemptions must be non-negative, not %d", preemptible.FallbackAfterPreemptions)
+		}
+	}
+	for _, preference := range spec.GetPreferredNodes() {
+		if preference.Key == "" || len(preference.Values) == 0 {
+			return errors.New("scheduling_spec.preferred_nodes must have a key and values")
+		}
+		if preference.Weight < 1 || preference.Weight > 100 {
+			return errors.Errorf("the weight of the preferred nodes with %q must be from 1 to 100, not %d", preference.Key, preference.Weight)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func preemptibleTestSchedulingSpec() *pps.SchedulingSpec {
+	return &pps.SchedulingSpec{
+		NodeSelector: map[string]string{"disk": "ssd"},
+		Preemptible: &pps.PreemptibleScheduling{
+			NodeSelector:             map[string]string{"spot": "true"},
+			Tolerations:              []*pps.Toleration{{Key: "spot", Operator: "Exists", Effect: "NoSchedule"}},
+			FallbackAfterPreemptions: 2,
+			OnDemandNodeSelector:     map[string]string{"spot": "false"},
+		},
+		PreferredNodes: []*pps.NodePreference{{Key: "zone", Values: []string{"a"}, Weight: 10}},
+	}
+}
+
+func TestApplySchedulingSpec(t *testing.T) {
+	spec := preemptibleTestSchedulingSpec()
+	podSpec := &v1.PodSpec{}
+	applySchedulingSpec(podSpec, spec, false)
+	require.Equal(t, map[string]string{"disk": "ssd", "spot": "true"}, podSpec.NodeSelector)
+	require.Equal(t, 1, len(podSpec.Tolerations))
+	require.Equal(t, v1.TolerationOpExists, podSpec.Tolerations[0].Operator)
+	terms := podSpec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	require.Equal(t, 1, len(terms))
+	require.Equal(t, int32(10), terms[0].Weight)
+	// the spec itself isn't modified
+	require.Equal(t, map[string]string{"disk": "ssd"}, spec.NodeSelector)
+
+	podSpec = &v1.PodSpec{}
+	applySchedulingSpec(podSpec, spec, true)
+	require.Equal(t, map[string]string{"disk": "ssd", "spot": "false"}, podSpec.NodeSelector)
+	require.Equal(t, 0, len(podSpec.Tolerations))
+}
+
+func TestRecordPreemption(t *testing.T) {
+	pi := &pps.PipelineInfo{Details: &pps.PipelineInfo_Details{SchedulingSpec: preemptibleTestSchedulingSpec()}}
+	preemptions, fellBack := recordPreemption(pi)
+	require.Equal(t, int64(1), preemptions)
+	require.False(t, fellBack)
+	preemptions, fellBack = recordPreemption(pi)
+	require.Equal(t, int64(2), preemptions)
+	require.True(t, fellBack)
+	require.True(t, pi.SchedulingStatus.OnDemand)
+	_, fellBack = recordPreemption(pi)
+	require.False(t, fellBack)
+}
+
+func TestPodPreempted(t *testing.T) {
+	require.True(t, podPreempted(&v1.Pod{Status: v1.PodStatus{Reason: "NodeShutdown"}}))
+	require.True(t, podPreempted(&v1.Pod{Status: v1.PodStatus{Conditions: []v1.PodCondition{{
+		Type:   podDisruptionTarget,
+		Status: v1.ConditionTrue,
+		Reason: "DeletionByTaintManager",
+	}}}}))
+	require.False(t, podPreempted(&v1.Pod{Status: v1.PodStatus{Reason: "Evicted"}}))
+}
+
+func TestValidateSchedulingSpec(t *testing.T) {
+	require.NoError(t, validateSchedulingSpec(nil))
+	require.NoError(t, validateSchedulingSpec(preemptibleTestSchedulingSpec()))
+	spec := preemptibleTestSchedulingSpec()
+	spec.Preemptible.Tolerations[0].Operator = "Maybe"
+	require.YesError(t, validateSchedulingSpec(spec))
+	spec = preemptibleTestSchedulingSpec()
+	spec.PreferredNodes[0].Weight = 0
+	require.YesError(t, validateSchedulingSpec(spec))
+}
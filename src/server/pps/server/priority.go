@@ -0,0 +1,53 @@
+package server
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// parsePriorityClasses parses a comma-separated list of name=class pairs,
+// which map the priorities pipelines may set to Kubernetes PriorityClasses.
+func parsePriorityClasses(classes string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(classes, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.Errorf("malformed pipeline priority class %q, must be name=class", pair)
+		}
+		if _, ok := result[parts[0]]; ok {
+			return nil, errors.Errorf("pipeline priority %q is defined more than once", parts[0])
+		}
+		result[parts[0]] = parts[1]
+	}
+	return result, nil
+}
+
+// validatePriority checks that a pipeline's priority is one of classes, and
+// that it doesn't also set a PriorityClass directly.
+func validatePriority(details *pps.PipelineInfo_Details, classes map[string]string) error {
+	if details.Priority == "" {
+		return nil
+	}
+	if details.SchedulingSpec.GetPriorityClassName() != "" {
+		return errors.New("cannot set both a priority and scheduling_spec.priority_class_name")
+	}
+	if _, ok := classes[details.Priority]; !ok {
+		var names []string
+		for name := range classes {
+			names = append(names, name)
+		}
+		if len(names) == 0 {
+			return errors.Errorf("unknown priority %q, this cluster has no pipeline priority classes", details.Priority)
+		}
+		sort.Strings(names)
+		return errors.Errorf("unknown priority %q, must be one of %s", details.Priority, strings.Join(names, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestParsePriorityClasses(t *testing.T) {
+	classes, err := parsePriorityClasses("")
+	require.NoError(t, err)
+	require.Equal(t, 0, len(classes))
+
+	classes, err = parsePriorityClasses("urgent=ns-pipeline-urgent, backfill=ns-pipeline-backfill")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"urgent":   "ns-pipeline-urgent",
+		"backfill": "ns-pipeline-backfill",
+	}, classes)
+
+	_, err = parsePriorityClasses("urgent")
+	require.YesError(t, err)
+	_, err = parsePriorityClasses("urgent=a,urgent=b")
+	require.YesError(t, err)
+}
+
+func TestValidatePriority(t *testing.T) {
+	classes := map[string]string{"urgent": "ns-pipeline-urgent"}
+	require.NoError(t, validatePriority(&pps.PipelineInfo_Details{}, nil))
+	require.NoError(t, validatePriority(&pps.PipelineInfo_Details{Priority: "urgent"}, classes))
+	require.YesError(t, validatePriority(&pps.PipelineInfo_Details{Priority: "backfill"}, classes))
+	require.YesError(t, validatePriority(&pps.PipelineInfo_Details{Priority: "urgent"}, nil))
+	require.YesError(t, validatePriority(&pps.PipelineInfo_Details{
+		Priority:       "urgent",
+		SchedulingSpec: &pps.SchedulingSpec{PriorityClassName: "other"},
+	}, classes))
+}
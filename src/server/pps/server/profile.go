@@ -0,0 +1,200 @@
+package server
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/common"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/datum"
+)
+
+const defaultProfileSlowest = 10
+
+// InspectJobProfile implements the protobuf pps.InspectJobProfile RPC
+func (a *apiServer) InspectJobProfile(ctx context.Context, request *pps.InspectJobProfileRequest) (response *pps.JobProfile, retErr error) {
+	job := request.Job
+	if job.GetPipeline().GetName() == "" || job.ID == "" {
+		return nil, errors.New("must specify a job")
+	}
+	if request.Slowest < 0 {
+		return nil, errors.Errorf("slowest must be non-negative, got %d", request.Slowest)
+	}
+	if err := a.env.AuthServer.CheckRepoIsAuthorized(ctx, client.NewRepo(job.Pipeline.Name), auth.Permission_REPO_READ); err != nil && !auth.IsErrNotActivated(err) {
+		return nil, errors.EnsureStack(err)
+	}
+	slowest := int(request.Slowest)
+	if slowest == 0 {
+		slowest = defaultProfileSlowest
+	}
+	p := newJobProfiler(slowest)
+	if err := a.collectDatums(ctx, job, func(meta *datum.Meta, _ *pfs.File) error {
+		// Datums the job skipped were processed, and profiled, by an earlier
+		// job.
+		if meta.Job != nil && !proto.Equal(meta.Job, job) {
+			return nil
+		}
+		return p.add(&pps.DatumProfile{
+			Datum: &pps.Datum{Job: job, ID: common.DatumID(meta.Inputs)},
+			State: convertDatumState(meta.State),
+			Stats: meta.Stats,
+		})
+	}); err != nil {
+		return nil, err
+	}
+	response = p.profile()
+	response.Job = job
+	return response, nil
+}
+
+// jobProfiler accumulates the stats of a job's datums into a JobProfile.
+type jobProfiler struct {
+	total   *pps.ProcessStats
+	samples map[string][]float64
+	slowest datumHeap
+	n       int
+}
+
+func newJobProfiler(slowest int) *jobProfiler {
+	return &jobProfiler{
+		total:   &pps.ProcessStats{},
+		samples: make(map[string][]float64),
+		n:       slowest,
+	}
+}
+
+func (p *jobProfiler) add(d *pps.DatumProfile) error {
+	stats := d.Stats
+	if stats == nil {
+		stats = &pps.ProcessStats{}
+		d.Stats = stats
+	}
+	if err := datum.MergeProcessStats(p.total, stats); err != nil {
+		return err
+	}
+	for name, dur := range map[string]*types.Duration{
+		"download_time":   stats.DownloadTime,
+		"process_time":    stats.ProcessTime,
+		"upload_time":     stats.UploadTime,
+		"user_cpu_time":   stats.UserCpuTime,
+		"system_cpu_time": stats.SystemCpuTime,
+	} {
+		seconds, err := durationSeconds(dur)
+		if err != nil {
+			return err
+		}
+		p.samples[name] = append(p.samples[name], seconds)
+	}
+	p.samples["download_bytes"] = append(p.samples["download_bytes"], float64(stats.DownloadBytes))
+	p.samples["upload_bytes"] = append(p.samples["upload_bytes"], float64(stats.UploadBytes))
+	p.samples["max_rss"] = append(p.samples["max_rss"], float64(stats.MaxRSS))
+
+	processTime, err := durationSeconds(stats.ProcessTime)
+	if err != nil {
+		return err
+	}
+	heap.Push(&p.slowest, slowDatum{profile: d, seconds: processTime})
+	if p.slowest.Len() > p.n {
+		heap.Pop(&p.slowest)
+	}
+	return nil
+}
+
+func (p *jobProfiler) profile() *pps.JobProfile {
+	result := &pps.JobProfile{
+		Stats: p.total,
+		Aggregate: &pps.AggregateProcessStats{
+			DownloadTime:  aggregate(p.samples["download_time"]),
+			ProcessTime:   aggregate(p.samples["process_time"]),
+			UploadTime:    aggregate(p.samples["upload_time"]),
+			DownloadBytes: aggregate(p.samples["download_bytes"]),
+			UploadBytes:   aggregate(p.samples["upload_bytes"]),
+			UserCpuTime:   aggregate(p.samples["user_cpu_time"]),
+			SystemCpuTime: aggregate(p.samples["system_cpu_time"]),
+			MaxRSS:        aggregate(p.samples["max_rss"]),
+		},
+	}
+	slowest := make([]slowDatum, len(p.slowest))
+	copy(slowest, p.slowest)
+	sort.SliceStable(slowest, func(i, j int) bool { return slowest[i].seconds > slowest[j].seconds })
+	for _, d := range slowest {
+		result.Slowest = append(result.Slowest, d.profile)
+	}
+	return result
+}
+
+// aggregate describes the distribution of samples.
+func aggregate(samples []float64) *pps.Aggregate {
+	if len(samples) == 0 {
+		return &pps.Aggregate{}
+	}
+	sorted := make([]float64, len(samples))
+	copy(sorted, samples)
+	sort.Float64s(sorted)
+	var sum float64
+	for _, s := range sorted {
+		sum += s
+	}
+	mean := sum / float64(len(sorted))
+	var variance float64
+	for _, s := range sorted {
+		variance += (s - mean) * (s - mean)
+	}
+	variance /= float64(len(sorted))
+	return &pps.Aggregate{
+		Count:                 int64(len(sorted)),
+		Mean:                  mean,
+		Stddev:                math.Sqrt(variance),
+		FifthPercentile:       percentile(sorted, 0.05),
+		NinetyFifthPercentile: percentile(sorted, 0.95),
+	}
+}
+
+// percentile returns the p-th percentile of sorted, using the nearest rank.
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+func durationSeconds(d *types.Duration) (float64, error) {
+	if d == nil {
+		return 0, nil
+	}
+	dur, err := types.DurationFromProto(d)
+	if err != nil {
+		return 0, errors.EnsureStack(err)
+	}
+	return dur.Seconds(), nil
+}
+
+type slowDatum struct {
+	profile *pps.DatumProfile
+	seconds float64
+}
+
+// datumHeap is a min-heap of datums by process time, so the fastest of the
+// slowest datums seen so far is the one evicted.
+type datumHeap []slowDatum
+
+func (h datumHeap) Len() int            { return len(h) }
+func (h datumHeap) Less(i, j int) bool  { return h[i].seconds < h[j].seconds }
+func (h datumHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *datumHeap) Push(x interface{}) { *h = append(*h, x.(slowDatum)) }
+func (h *datumHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
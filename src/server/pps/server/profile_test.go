@@ -0,0 +1,58 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestAggregate(t *testing.T) {
+	require.Equal(t, &pps.Aggregate{}, aggregate(nil))
+	var samples []float64
+	for i := 20; i > 0; i-- {
+		samples = append(samples, float64(i))
+	}
+	agg := aggregate(samples)
+	require.Equal(t, int64(20), agg.Count)
+	require.Equal(t, 10.5, agg.Mean)
+	require.True(t, agg.Stddev > 5.7 && agg.Stddev < 5.8)
+	require.Equal(t, 1.0, agg.FifthPercentile)
+	require.Equal(t, 19.0, agg.NinetyFifthPercentile)
+	// aggregate shouldn't reorder the caller's samples
+	require.Equal(t, 20.0, samples[0])
+}
+
+func TestJobProfiler(t *testing.T) {
+	p := newJobProfiler(3)
+	for _, seconds := range []int{4, 9, 1, 7, 3} {
+		require.NoError(t, p.add(&pps.DatumProfile{
+			Datum: &pps.Datum{ID: fmt.Sprint(seconds)},
+			Stats: &pps.ProcessStats{
+				ProcessTime:   types.DurationProto(time.Duration(seconds) * time.Second),
+				UserCpuTime:   types.DurationProto(time.Second),
+				DownloadBytes: 10,
+				MaxRSS:        int64(seconds) << 20,
+			},
+		}))
+	}
+	require.NoError(t, p.add(&pps.DatumProfile{Datum: &pps.Datum{ID: "unprofiled"}}))
+	profile := p.profile()
+
+	var slowest []string
+	for _, d := range profile.Slowest {
+		slowest = append(slowest, d.Datum.ID)
+	}
+	require.Equal(t, []string{"9", "7", "4"}, slowest)
+	require.Equal(t, int64(6), profile.Aggregate.ProcessTime.Count)
+	require.Equal(t, 4.0, profile.Aggregate.ProcessTime.Mean)
+	require.Equal(t, int64(50), profile.Stats.DownloadBytes)
+	require.Equal(t, int64(9<<20), profile.Stats.MaxRSS)
+	userCPU, err := types.DurationFromProto(profile.Stats.UserCpuTime)
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Second, userCPU)
+}
@@ -0,0 +1,21 @@
+package server
+
+import (
+	"testing"
+
+	globlib "github.com/pachyderm/ohmyglob"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestEscapeGlob(t *testing.T) {
+	for _, p := range []string{"/a/b.txt", "/a/*.txt", "/[x]/{y}?", "/!(z)@+^", `/back\slash`} {
+		g, err := globlib.Compile("/pfs/*/out"+escapeGlob(p), '/')
+		require.NoError(t, err)
+		require.True(t, g.Match("/pfs/abc/out"+p), p)
+		require.False(t, g.Match("/pfs/abc/out"+p+"x"), p)
+	}
+	g, err := globlib.Compile("/pfs/*/out"+escapeGlob("/a/*.txt"), '/')
+	require.NoError(t, err)
+	require.False(t, g.Match("/pfs/abc/out/a/b.txt"))
+}
@@ -0,0 +1,185 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+const (
+	// remoteTokenKey is the key of the auth token in a remote input's secret.
+	remoteTokenKey = "auth_token"
+	// mirroredCommitPrefix prefixes the description of commits to a mirror
+	// repo, and is followed by the ID of the remote commit they mirror.
+	mirroredCommitPrefix = "Mirror of remote commit "
+)
+
+// validateRemoteRepo checks the remote repo of a PFS input, whose defaults
+// have been set.
+func validateRemoteRepo(pipelineName string, input *pps.PFSInput) error {
+	remote := input.Remote
+	if _, err := grpcutil.ParsePachdAddress(remote.PachdAddress); err != nil {
+		return errors.Wrapf(err, "invalid remote pachd_address %q", remote.PachdAddress)
+	}
+	switch {
+	case remote.Repo == "":
+		return errors.Errorf("remote input must specify a remote repo")
+	case input.Repo != fmt.Sprintf("%s_%s", pipelineName, input.Name):
+		return errors.Errorf("remote input %q can't specify a repo, as pachyderm "+
+			"creates the repo it mirrors the remote repo into", input.Name)
+	case input.Commit != "":
+		return errors.Errorf("remote input %q can't specify a commit", input.Name)
+	case input.Trigger != nil:
+		return errors.Errorf("remote input %q can't specify a trigger", input.Name)
+	}
+	return nil
+}
+
+// newRemoteClient returns a client for the cluster of a remote input,
+// authenticated with the token in the input's secret, if it has one.
+func newRemoteClient(ctx context.Context, env Env, remote *pps.RemoteRepo) (*client.APIClient, error) {
+	c, err := client.NewFromURI(remote.PachdAddress)
+	if err != nil {
+		return nil, err
+	}
+	if remote.Secret != "" {
+		secret, err := env.KubeClient.CoreV1().Secrets(env.Config.Namespace).Get(ctx, remote.Secret, metav1.GetOptions{})
+		if err != nil {
+			c.Close()
+			return nil, errors.Wrapf(err, "could not get secret %q", remote.Secret)
+		}
+		token, ok := secret.Data[remoteTokenKey]
+		if !ok {
+			c.Close()
+			return nil, errors.Errorf("secret %q has no %q key", remote.Secret, remoteTokenKey)
+		}
+		c.SetAuthToken(strings.TrimSpace(string(token)))
+	}
+	return c.WithCtx(ctx), nil
+}
+
+// mirrorRemoteCommits mirrors the commits to a remote input's branch into
+// its repo, one commit per remote commit. Commits made to the remote branch
+// while a commit is being mirrored are skipped in favor of the latest one.
+// It's a helper function called by monitorPipeline.
+func mirrorRemoteCommits(ctx context.Context, env Env, in *pps.Input) (retErr error) {
+	remote := in.Pfs.Remote
+	remoteClient, err := newRemoteClient(ctx, env, remote)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := remoteClient.Close(); retErr == nil {
+			retErr = err
+		}
+	}()
+	pachClient := env.GetPachClient(ctx)
+	mirrored, err := latestMirroredCommit(pachClient, in.Pfs.Repo)
+	if err != nil {
+		return err
+	}
+	return remoteClient.SubscribeCommit(client.NewRepo(remote.Repo), remote.Branch, "", pfs.CommitState_FINISHED, func(*pfs.CommitInfo) error {
+		head, err := remoteClient.InspectCommit(remote.Repo, remote.Branch, "")
+		if err != nil {
+			return err
+		}
+		if head.Finished == nil || head.Error != "" || head.Commit.ID == mirrored {
+			return nil
+		}
+		if err := mirrorRemoteCommit(pachClient, remoteClient, in.Pfs.Repo, head.Commit, mirrored); err != nil {
+			return err
+		}
+		mirrored = head.Commit.ID
+		return nil
+	})
+}
+
+// latestMirroredCommit returns the ID of the remote commit that the head of
+// repo mirrors, or "" if it doesn't mirror one. If the head was left open by
+// an interrupted mirror, it's finished with an error.
+func latestMirroredCommit(pachClient *client.APIClient, repo string) (string, error) {
+	ci, err := pachClient.InspectCommit(repo, "master", "")
+	if err != nil {
+		if errutil.IsNotFoundError(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	if ci.Finished == nil {
+		if _, err := pachClient.PfsAPIClient.FinishCommit(pachClient.Ctx(), &pfs.FinishCommitRequest{
+			Commit: ci.Commit,
+			Error:  "mirroring the remote commit was interrupted",
+			Force:  true,
+		}); err != nil {
+			return "", grpcutil.ScrubGRPC(err)
+		}
+		return "", nil
+	}
+	if ci.Error != "" || !strings.HasPrefix(ci.Description, mirroredCommitPrefix) {
+		return "", nil
+	}
+	return strings.TrimPrefix(ci.Description, mirroredCommitPrefix), nil
+}
+
+// mirrorRemoteCommit makes a commit to repo holding the files in the remote
+// commit. If the head of repo mirrors the remote commit with ID from, only
+// the files that changed since it are copied, otherwise they all are.
+func mirrorRemoteCommit(pachClient, remoteClient *client.APIClient, repo string, commit *pfs.Commit, from string) (retErr error) {
+	localCommit, err := pachClient.StartCommit(repo, "master")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		req := &pfs.FinishCommitRequest{
+			Commit:      localCommit,
+			Description: mirroredCommitPrefix + commit.ID,
+		}
+		if retErr != nil {
+			req.Description = ""
+			req.Error = fmt.Sprintf("could not mirror remote commit %s: %v", commit.ID, retErr)
+		}
+		if _, err := pachClient.PfsAPIClient.FinishCommit(pachClient.Ctx(), req); retErr == nil {
+			retErr = grpcutil.ScrubGRPC(err)
+		}
+	}()
+	return pachClient.WithModifyFileClient(localCommit, func(mf client.ModifyFile) error {
+		copyFile := func(path string) error {
+			r, err := remoteClient.GetFileTAR(commit, path)
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+			return errors.EnsureStack(mf.PutFileTAR(r))
+		}
+		if from != "" {
+			if _, err := remoteClient.InspectCommit(commit.Branch.Repo.Name, "", from); err == nil {
+				return remoteClient.DiffFile(commit, "/", commit.Branch.Repo.NewCommit("", from), "/", false, func(newFile, oldFile *pfs.FileInfo) error {
+					if newFile != nil && newFile.FileType == pfs.FileType_FILE {
+						return copyFile(newFile.File.Path)
+					}
+					if newFile == nil && oldFile.FileType == pfs.FileType_FILE {
+						return errors.EnsureStack(mf.DeleteFile(oldFile.File.Path))
+					}
+					return nil
+				})
+			} else if !errutil.IsNotFoundError(err) {
+				return err
+			}
+		}
+		// There's no mirrored commit to diff against, so replace the mirror
+		// repo's files with all of the remote commit's.
+		if err := mf.DeleteFile("/"); err != nil {
+			return errors.EnsureStack(err)
+		}
+		return copyFile("/")
+	})
+}
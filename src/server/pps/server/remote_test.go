@@ -0,0 +1,56 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestRemoteInputDefaults(t *testing.T) {
+	input := &pps.Input{Pfs: &pps.PFSInput{
+		Glob: "/*",
+		Remote: &pps.RemoteRepo{
+			PachdAddress: "grpcs://pachd.example.com:30650",
+			Repo:         "images",
+		},
+	}}
+	setInputDefaults("edges", input)
+	require.Equal(t, "images", input.Pfs.Name)
+	require.Equal(t, "edges_images", input.Pfs.Repo)
+	require.Equal(t, "master", input.Pfs.Branch)
+	require.Equal(t, "master", input.Pfs.Remote.Branch)
+	require.NoError(t, validateRemoteRepo("edges", input.Pfs))
+}
+
+func TestValidateRemoteRepo(t *testing.T) {
+	valid := func() *pps.PFSInput {
+		return &pps.PFSInput{
+			Name: "images",
+			Repo: "edges_images",
+			Remote: &pps.RemoteRepo{
+				PachdAddress: "pachd.example.com:30650",
+				Repo:         "images",
+				Branch:       "master",
+			},
+		}
+	}
+	require.NoError(t, validateRemoteRepo("edges", valid()))
+
+	input := valid()
+	input.Remote.PachdAddress = ""
+	require.YesError(t, validateRemoteRepo("edges", input))
+	input = valid()
+	input.Remote.Repo = ""
+	require.YesError(t, validateRemoteRepo("edges", input))
+	input = valid()
+	input.Repo = "images"
+	require.YesError(t, validateRemoteRepo("edges", input))
+	input = valid()
+	input.Commit = "0123456789abcdef0123456789abcdef"
+	require.YesError(t, validateRemoteRepo("edges", input))
+	input = valid()
+	input.Trigger = &pfs.Trigger{Branch: "staging"}
+	require.YesError(t, validateRemoteRepo("edges", input))
+}
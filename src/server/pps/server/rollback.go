@@ -0,0 +1,173 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	middleware_auth "github.com/pachyderm/pachyderm/v2/src/internal/middleware/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
+	"github.com/pachyderm/pachyderm/v2/src/internal/watch"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func validateRollbackPolicy(policy *pps.RollbackPolicy) error {
+	if !policy.OnJobFailure && policy.MaxDatumFailureRate == 0 {
+		return errors.Errorf("one of on_job_failure and max_datum_failure_rate must be set")
+	}
+	if rate := policy.MaxDatumFailureRate; !(rate >= 0 && rate < 1) {
+		return errors.Errorf("max_datum_failure_rate must be at least 0 and less than 1, not %v", rate)
+	}
+	if policy.Jobs < 0 {
+		return errors.Errorf("jobs must be non-negative, not %d", policy.Jobs)
+	}
+	return nil
+}
+
+// rollbackReason returns why policy rolls back the update whose job is
+// jobInfo, or "" if it doesn't.
+func rollbackReason(policy *pps.RollbackPolicy, jobInfo *pps.JobInfo) string {
+	if policy.OnJobFailure && jobInfo.State == pps.JobState_JOB_FAILURE {
+		return fmt.Sprintf("job %s failed: %s", jobInfo.Job.ID, jobInfo.Reason)
+	}
+	if policy.MaxDatumFailureRate > 0 && jobInfo.DataTotal > 0 &&
+		float64(jobInfo.DataFailed)/float64(jobInfo.DataTotal) > policy.MaxDatumFailureRate {
+		return fmt.Sprintf("%d of the %d datums of job %s failed, more than the maximum failure rate of %v",
+			jobInfo.DataFailed, jobInfo.DataTotal, jobInfo.Job.ID, policy.MaxDatumFailureRate)
+	}
+	return ""
+}
+
+func createdBefore(a, b *pps.JobInfo) bool {
+	if a.Created == nil || b.Created == nil {
+		return false
+	}
+	return a.Created.Seconds < b.Created.Seconds ||
+		a.Created.Seconds == b.Created.Seconds && a.Created.Nanos < b.Created.Nanos
+}
+
+// rollBackFailedUpdates watches for jobs finishing, and rolls back the
+// updates of pipelines whose rollback policies their jobs fail.
+func (a *apiServer) rollBackFailedUpdates(ctx context.Context) {
+	if err := backoff.RetryUntilCancel(ctx, func() error {
+		jobInfo := &pps.JobInfo{}
+		return errors.EnsureStack(a.jobs.ReadOnly(ctx).WatchF(func(ev *watch.Event) error {
+			if ev.Type == watch.EventError {
+				return ev.Err
+			}
+			if ev.Type != watch.EventPut {
+				return nil
+			}
+			var key string
+			if err := ev.Unmarshal(&key, jobInfo); err != nil {
+				return errors.EnsureStack(err)
+			}
+			if jobInfo.State != pps.JobState_JOB_FAILURE && jobInfo.State != pps.JobState_JOB_SUCCESS {
+				return nil
+			}
+			if err := a.checkRollbackPolicy(ctx, jobInfo); err != nil {
+				log.Errorf("PPS master: could not check the rollback policy of %q after job %s: %v", jobInfo.Job.Pipeline.Name, jobInfo.Job.ID, err)
+			}
+			return nil
+		}))
+	}, backoff.NewInfiniteBackOff(), backoff.NotifyContinue("rollBackFailedUpdates")); err != nil && ctx.Err() == nil {
+		log.Errorf("PPS master: rollBackFailedUpdates exited unexpectedly: %v", err)
+	}
+}
+
+// checkRollbackPolicy rolls back the version of the pipeline that ran
+// jobInfo, if it's the pipeline's current version, and jobInfo is one of its
+// first jobs and fails its rollback policy.
+func (a *apiServer) checkRollbackPolicy(ctx context.Context, jobInfo *pps.JobInfo) error {
+	pipelineName := jobInfo.Job.Pipeline.Name
+	pipelineInfo := &pps.PipelineInfo{}
+	if err := a.pipelines.ReadOnly(ctx).GetUniqueByIndex(ppsdb.PipelinesVersionIndex,
+		ppsdb.VersionKey(pipelineName, jobInfo.PipelineVersion), pipelineInfo); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil // the pipeline was deleted
+		}
+		return errors.EnsureStack(err)
+	}
+	policy := pipelineInfo.Details.RollbackPolicy
+	if policy == nil || pipelineInfo.Version <= 1 || pipelineInfo.Rollback != nil {
+		return nil
+	}
+	reason := rollbackReason(policy, jobInfo)
+	if reason == "" {
+		return nil
+	}
+	jobs := policy.Jobs
+	if jobs == 0 {
+		jobs = 1
+	}
+	// jobInfo only counts if it's one of the version's first jobs
+	var earlier int64
+	otherJob := &pps.JobInfo{}
+	if err := a.jobs.ReadOnly(ctx).GetByIndex(ppsdb.JobsPipelineIndex, pipelineName, otherJob, col.DefaultOptions(), func(string) error {
+		if otherJob.PipelineVersion == jobInfo.PipelineVersion && createdBefore(otherJob, jobInfo) {
+			earlier++
+		}
+		return nil
+	}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if earlier >= jobs {
+		return nil
+	}
+	// roll back as the pipeline, which can update itself
+	ctx = metadata.NewIncomingContext(middleware_auth.ClearWhoAmI(ctx), metadata.Pairs(auth.ContextTokenKey, pipelineInfo.AuthToken))
+	return a.txnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		return a.rollbackPipelineInTransaction(txnCtx, pipelineInfo, jobInfo.Job, reason)
+	})
+}
+
+// rollbackPipelineInTransaction updates a pipeline to the spec of the version
+// before pipelineInfo, and records the rollback in the new version, unless
+// pipelineInfo is no longer the pipeline's current version.
+func (a *apiServer) rollbackPipelineInTransaction(txnCtx *txncontext.TransactionContext, pipelineInfo *pps.PipelineInfo, job *pps.Job, reason string) error {
+	pipelineName := pipelineInfo.Pipeline.Name
+	currentInfo, err := a.InspectPipelineInTransaction(txnCtx, pipelineName)
+	if err != nil {
+		return err
+	}
+	if currentInfo.Version != pipelineInfo.Version {
+		return nil // the pipeline was updated, or already rolled back
+	}
+	previousInfo := &pps.PipelineInfo{}
+	if err := a.pipelines.ReadWrite(txnCtx.SqlTx).GetUniqueByIndex(ppsdb.PipelinesVersionIndex,
+		ppsdb.VersionKey(pipelineName, pipelineInfo.Version-1), previousInfo); err != nil {
+		return errors.Wrapf(err, "could not find the previous version of pipeline %q", pipelineName)
+	}
+	request := ppsutil.PipelineReqFromInfo(previousInfo)
+	request.Update = true
+	if err := a.createPipelineInTransaction(txnCtx, request); err != nil {
+		return errors.Wrapf(err, "could not restore version %d of pipeline %q", previousInfo.Version, pipelineName)
+	}
+	specCommit, err := ppsutil.FindPipelineSpecCommitInTransaction(txnCtx, a.env.PFSServer, pipelineName, "")
+	if err != nil {
+		return err
+	}
+	rolledBackInfo := &pps.PipelineInfo{}
+	if err := a.pipelines.ReadWrite(txnCtx.SqlTx).Update(specCommit, rolledBackInfo, func() error {
+		rolledBackInfo.Rollback = &pps.PipelineRollback{
+			FromVersion: pipelineInfo.Version,
+			ToVersion:   previousInfo.Version,
+			Job:         job,
+			Reason:      reason,
+		}
+		return nil
+	}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	log.Infof("PPS master: rolled back pipeline %q from version %d to the spec of version %d: %s",
+		pipelineName, pipelineInfo.Version, previousInfo.Version, reason)
+	return nil
+}
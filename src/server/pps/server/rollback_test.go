@@ -0,0 +1,26 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestValidateRollbackPolicy(t *testing.T) {
+	require.NoError(t, validateRollbackPolicy(&pps.RollbackPolicy{OnJobFailure: true}))
+	require.NoError(t, validateRollbackPolicy(&pps.RollbackPolicy{MaxDatumFailureRate: 0.1, Jobs: 3}))
+	require.YesError(t, validateRollbackPolicy(&pps.RollbackPolicy{}))
+	require.YesError(t, validateRollbackPolicy(&pps.RollbackPolicy{MaxDatumFailureRate: 1}))
+	require.YesError(t, validateRollbackPolicy(&pps.RollbackPolicy{OnJobFailure: true, Jobs: -1}))
+}
+
+func TestRollbackReason(t *testing.T) {
+	job := &pps.Job{ID: "abc"}
+	failed := &pps.JobInfo{Job: job, State: pps.JobState_JOB_FAILURE, Reason: "oops"}
+	flaky := &pps.JobInfo{Job: job, State: pps.JobState_JOB_SUCCESS, DataTotal: 10, DataFailed: 2}
+	require.NotEqual(t, "", rollbackReason(&pps.RollbackPolicy{OnJobFailure: true}, failed))
+	require.Equal(t, "", rollbackReason(&pps.RollbackPolicy{OnJobFailure: true}, flaky))
+	require.NotEqual(t, "", rollbackReason(&pps.RollbackPolicy{MaxDatumFailureRate: 0.1}, flaky))
+	require.Equal(t, "", rollbackReason(&pps.RollbackPolicy{MaxDatumFailureRate: 0.5}, flaky))
+}
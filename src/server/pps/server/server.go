@@ -8,11 +8,13 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/collection"
 	loki "github.com/pachyderm/pachyderm/v2/src/internal/lokiutil/client"
 	"github.com/pachyderm/pachyderm/v2/src/internal/metrics"
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
 	"github.com/pachyderm/pachyderm/v2/src/internal/ppsdb"
 	"github.com/pachyderm/pachyderm/v2/src/internal/serviceenv"
 	"github.com/pachyderm/pachyderm/v2/src/internal/task"
 	txnenv "github.com/pachyderm/pachyderm/v2/src/internal/transactionenv"
+	adminserver "github.com/pachyderm/pachyderm/v2/src/server/admin/server"
 	authserver "github.com/pachyderm/pachyderm/v2/src/server/auth"
 	pfsserver "github.com/pachyderm/pachyderm/v2/src/server/pfs"
 	ppsiface "github.com/pachyderm/pachyderm/v2/src/server/pps"
@@ -33,6 +35,9 @@ type Env struct {
 	// TODO: make this just a *loki.Client
 	// This is not a circular dependency
 	GetLokiClient func() (*loki.Client, error)
+	// GetObjectClient returns a client for the object storage that job logs
+	// are archived to.
+	GetObjectClient func() (obj.Client, error)
 
 	PFSServer  pfsserver.APIServer
 	AuthServer authserver.APIServer
@@ -57,6 +62,9 @@ func EnvFromServiceEnv(senv serviceenv.ServiceEnv, txnEnv *txnenv.TransactionEnv
 		EtcdPrefix:    etcdPrefix,
 		TaskService:   senv.GetTaskService(etcdPrefix),
 		GetLokiClient: senv.GetLokiClient,
+		GetObjectClient: func() (obj.Client, error) {
+			return obj.NewClient(senv.Config().StorageBackend, senv.Config().StorageRoot)
+		},
 
 		PFSServer:     senv.PfsServer(),
 		AuthServer:    senv.AuthServer(),
@@ -85,6 +93,10 @@ func NewAPIServer(env Env) (ppsiface.APIServer, error) {
 // loop in the background.
 func NewAPIServerNoMaster(env Env) (ppsiface.APIServer, error) {
 	config := env.Config
+	priorityClasses, err := parsePriorityClasses(config.PipelinePriorityClasses)
+	if err != nil {
+		return nil, err
+	}
 	apiServer := &apiServer{
 		env:                   env,
 		txnEnv:                env.TxnEnv,
@@ -101,10 +113,13 @@ func NewAPIServerNoMaster(env Env) (ppsiface.APIServer, error) {
 		workerUsesRoot:        config.WorkerUsesRoot,
 		pipelines:             ppsdb.Pipelines(env.DB, env.Listener),
 		jobs:                  ppsdb.Jobs(env.DB, env.Listener),
+		clusterDefaults:       adminserver.ClusterDefaultsCollection(env.DB, env.Listener),
 		workerGrpcPort:        config.PPSWorkerPort,
 		port:                  config.Port,
 		peerPort:              config.PeerPort,
 		gcPercent:             config.GCPercent,
+		priorityClasses:       priorityClasses,
+		imagePolicy:           newImagePolicy(config),
 	}
 	return apiServer, nil
 }
@@ -0,0 +1,312 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dbutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+const (
+	defaultSLOWindow             = 24 * time.Hour
+	defaultJobDurationPercentile = 95
+	// sloCheckInterval is how often the PPS master checks pipelines' SLOs.
+	sloCheckInterval = time.Minute
+)
+
+var (
+	sloViolatedMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pps_slo",
+		Name:      "violated",
+		Help:      "Whether a pipeline is violating an SLO, 1 if it is and 0 if it isn't",
+	}, []string{"pipeline", "slo"})
+	sloValueMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pps_slo",
+		Name:      "value",
+		Help:      "The measured value of a pipeline's SLO: a job duration percentile or time since a job succeeded in seconds, or a failure rate",
+	}, []string{"pipeline", "slo"})
+)
+
+func validateSLOs(slos []*pps.SLO) error {
+	names := make(map[string]bool)
+	for _, slo := range slos {
+		if slo.Name == "" {
+			return errors.New("every SLO must have a name")
+		}
+		if names[slo.Name] {
+			return errors.Errorf("there's more than one SLO named %q", slo.Name)
+		}
+		names[slo.Name] = true
+		var objectives int
+		for _, d := range []*types.Duration{slo.Window, slo.MaxJobDuration, slo.MaxCommitInterval} {
+			if d == nil {
+				continue
+			}
+			if duration, err := types.DurationFromProto(d); err != nil || duration <= 0 {
+				return errors.Errorf("the durations of SLO %q must be positive", slo.Name)
+			}
+		}
+		if slo.MaxJobDuration != nil {
+			objectives++
+		}
+		if slo.MaxCommitInterval != nil {
+			objectives++
+		}
+		if slo.MaxFailureRate != 0 {
+			objectives++
+		}
+		if objectives != 1 {
+			return errors.Errorf("SLO %q must set exactly one of max_job_duration, max_commit_interval and max_failure_rate", slo.Name)
+		}
+		if p := slo.JobDurationPercentile; !(p >= 0 && p <= 100) {
+			return errors.Errorf("the job_duration_percentile of SLO %q must be between 0 and 100, not %v", slo.Name, p)
+		}
+		if rate := slo.MaxFailureRate; !(rate >= 0 && rate < 1) {
+			return errors.Errorf("the max_failure_rate of SLO %q must be at least 0 and less than 1, not %v", slo.Name, rate)
+		}
+	}
+	return nil
+}
+
+// checkSLO measures slo against the jobs of the pipeline described by
+// pipelineInfo, as of now. The status's since is left unset.
+func checkSLO(slo *pps.SLO, pipelineInfo *pps.PipelineInfo, jobInfos []*pps.JobInfo, now time.Time) *pps.SLOStatus {
+	status := &pps.SLOStatus{Pipeline: pipelineInfo.Pipeline, SLO: slo}
+	window := defaultSLOWindow
+	if slo.Window != nil {
+		window, _ = types.DurationFromProto(slo.Window)
+	}
+	var durations []time.Duration
+	var failed int64
+	var lastSuccess time.Time
+	for _, jobInfo := range jobInfos {
+		finished, err := types.TimestampFromProto(jobInfo.Finished)
+		if err != nil {
+			continue
+		}
+		switch jobInfo.State {
+		case pps.JobState_JOB_SUCCESS:
+			if finished.After(lastSuccess) {
+				lastSuccess = finished
+			}
+		case pps.JobState_JOB_FAILURE, pps.JobState_JOB_UNRUNNABLE:
+		default:
+			// killed jobs were stopped by users, rather than failing
+			continue
+		}
+		if now.Sub(finished) > window {
+			continue
+		}
+		status.Jobs++
+		if jobInfo.State != pps.JobState_JOB_SUCCESS {
+			failed++
+		}
+		started := jobInfo.Started
+		if started == nil {
+			started = jobInfo.Created
+		}
+		if started, err := types.TimestampFromProto(started); err == nil {
+			durations = append(durations, finished.Sub(started))
+		}
+	}
+	switch {
+	case slo.MaxJobDuration != nil:
+		if len(durations) == 0 {
+			return status
+		}
+		percentile := slo.JobDurationPercentile
+		if percentile == 0 {
+			percentile = defaultJobDurationPercentile
+		}
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		i := int(math.Ceil(percentile/100*float64(len(durations)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		duration := durations[i]
+		status.Value = duration.Seconds()
+		if max, _ := types.DurationFromProto(slo.MaxJobDuration); duration > max {
+			status.Violated = true
+			status.Reason = fmt.Sprintf("the p%g duration of the jobs that finished in the last %v is %v, more than %v",
+				percentile, window, duration.Round(time.Second), max)
+		}
+	case slo.MaxCommitInterval != nil:
+		if pipelineInfo.Stopped {
+			return status
+		}
+		since := lastSuccess
+		if since.IsZero() {
+			// the pipeline hasn't had a job succeed, so it's measured from
+			// when its current version was created
+			created, err := types.TimestampFromProto(pipelineInfo.Details.GetCreatedAt())
+			if err != nil {
+				return status
+			}
+			since = created
+		}
+		interval := now.Sub(since)
+		status.Value = interval.Seconds()
+		if max, _ := types.DurationFromProto(slo.MaxCommitInterval); interval > max {
+			status.Violated = true
+			status.Reason = fmt.Sprintf("no job has succeeded in %v, more than %v", interval.Round(time.Second), max)
+		}
+	case slo.MaxFailureRate != 0:
+		if status.Jobs == 0 {
+			return status
+		}
+		status.Value = float64(failed) / float64(status.Jobs)
+		if status.Value > slo.MaxFailureRate {
+			status.Violated = true
+			status.Reason = fmt.Sprintf("%d of the %d jobs that finished in the last %v failed, more than the maximum failure rate of %v",
+				failed, status.Jobs, window, slo.MaxFailureRate)
+		}
+	}
+	return status
+}
+
+// ListSLOStatus implements the protobuf pps.ListSLOStatus RPC
+func (a *apiServer) ListSLOStatus(ctx context.Context, request *pps.ListSLOStatusRequest) (*pps.ListSLOStatusResponse, error) {
+	response := &pps.ListSLOStatusResponse{}
+	if err := a.listSLOStatus(ctx, request.Pipeline, func(_ *pps.PipelineInfo, statuses []*pps.SLOStatus) error {
+		response.Statuses = append(response.Statuses, statuses...)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// listSLOStatus checks the SLOs of pipeline, or of every pipeline if it's
+// nil, and calls f with the current version of each pipeline that has SLOs
+// and their statuses.
+func (a *apiServer) listSLOStatus(ctx context.Context, pipeline *pps.Pipeline, f func(*pps.PipelineInfo, []*pps.SLOStatus) error) error {
+	var pipelineInfos []*pps.PipelineInfo
+	if err := ppsutil.ListPipelineInfo(ctx, a.pipelines, pipeline, 0, func(pipelineInfo *pps.PipelineInfo) error {
+		if len(pipelineInfo.Details.GetSLOs()) > 0 {
+			pipelineInfos = append(pipelineInfos, proto.Clone(pipelineInfo).(*pps.PipelineInfo))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, pipelineInfo := range pipelineInfos {
+		var jobInfos []*pps.JobInfo
+		jobInfo := &pps.JobInfo{}
+		if err := a.jobs.ReadOnly(ctx).GetByIndex(ppsdb.JobsPipelineIndex, pipelineInfo.Pipeline.Name, jobInfo, col.DefaultOptions(), func(string) error {
+			jobInfos = append(jobInfos, proto.Clone(jobInfo).(*pps.JobInfo))
+			return nil
+		}); err != nil {
+			return errors.EnsureStack(err)
+		}
+		since := make(map[string]*types.Timestamp)
+		for _, violation := range pipelineInfo.SLOViolations {
+			since[violation.SLO] = violation.Since
+		}
+		var statuses []*pps.SLOStatus
+		for _, slo := range pipelineInfo.Details.SLOs {
+			status := checkSLO(slo, pipelineInfo, jobInfos, now)
+			if status.Violated {
+				status.Since = since[slo.Name]
+				if status.Since == nil {
+					status.Since, _ = types.TimestampProto(now)
+				}
+			}
+			statuses = append(statuses, status)
+		}
+		if err := f(pipelineInfo, statuses); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSLOs periodically checks every pipeline's SLOs, exports their values
+// as metrics, and records the SLOs pipelines are violating in their
+// PipelineInfos.
+func (a *apiServer) checkSLOs(ctx context.Context) {
+	if err := backoff.RetryUntilCancel(ctx, func() error {
+		for {
+			if err := a.recordSLOViolations(ctx); err != nil {
+				return err
+			}
+			select {
+			case <-time.After(sloCheckInterval):
+			case <-ctx.Done():
+				return errors.EnsureStack(ctx.Err())
+			}
+		}
+	}, backoff.NewInfiniteBackOff(), backoff.NotifyContinue("checkSLOs")); err != nil && ctx.Err() == nil {
+		log.Errorf("PPS master: checkSLOs exited unexpectedly: %v", err)
+	}
+}
+
+func (a *apiServer) recordSLOViolations(ctx context.Context) error {
+	// deleted pipelines and SLOs shouldn't be reported
+	sloViolatedMetric.Reset()
+	sloValueMetric.Reset()
+	return a.listSLOStatus(ctx, nil, func(pipelineInfo *pps.PipelineInfo, statuses []*pps.SLOStatus) error {
+		var violations []*pps.SLOViolation
+		for _, status := range statuses {
+			var violated float64
+			if status.Violated {
+				violated = 1
+				violations = append(violations, &pps.SLOViolation{
+					SLO:    status.SLO.Name,
+					Reason: status.Reason,
+					Since:  status.Since,
+				})
+			}
+			sloViolatedMetric.WithLabelValues(pipelineInfo.Pipeline.Name, status.SLO.Name).Set(violated)
+			sloValueMetric.WithLabelValues(pipelineInfo.Pipeline.Name, status.SLO.Name).Set(status.Value)
+		}
+		if sameSLOViolations(pipelineInfo.SLOViolations, violations) {
+			return nil
+		}
+		for _, violation := range violations {
+			log.Infof("PPS master: pipeline %q is violating SLO %q: %s", pipelineInfo.Pipeline.Name, violation.SLO, violation.Reason)
+		}
+		return dbutil.WithTx(ctx, a.env.DB, func(sqlTx *pachsql.Tx) error {
+			updatedInfo := &pps.PipelineInfo{}
+			if err := a.pipelines.ReadWrite(sqlTx).Update(pipelineInfo.SpecCommit, updatedInfo, func() error {
+				updatedInfo.SLOViolations = violations
+				return nil
+			}); err != nil && !col.IsErrNotFound(err) {
+				return errors.EnsureStack(err)
+			}
+			return nil
+		})
+	})
+}
+
+// sameSLOViolations returns whether a and b are violations of the same SLOs.
+// Their reasons change as the SLOs are measured, and aren't compared.
+func sameSLOViolations(a, b []*pps.SLOViolation) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].SLO != b[i].SLO {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestValidateSLOs(t *testing.T) {
+	hour := types.DurationProto(time.Hour)
+	require.NoError(t, validateSLOs([]*pps.SLO{
+		{Name: "fast", MaxJobDuration: hour, JobDurationPercentile: 99},
+		{Name: "fresh", MaxCommitInterval: hour},
+		{Name: "reliable", MaxFailureRate: 0.05, Window: hour},
+	}))
+	require.YesError(t, validateSLOs([]*pps.SLO{{MaxCommitInterval: hour}}))
+	require.YesError(t, validateSLOs([]*pps.SLO{{Name: "a", MaxCommitInterval: hour}, {Name: "a", MaxFailureRate: 0.1}}))
+	require.YesError(t, validateSLOs([]*pps.SLO{{Name: "none"}}))
+	require.YesError(t, validateSLOs([]*pps.SLO{{Name: "both", MaxCommitInterval: hour, MaxFailureRate: 0.1}}))
+	require.YesError(t, validateSLOs([]*pps.SLO{{Name: "rate", MaxFailureRate: 1}}))
+	require.YesError(t, validateSLOs([]*pps.SLO{{Name: "negative", MaxJobDuration: types.DurationProto(-time.Hour)}}))
+}
+
+func TestCheckSLO(t *testing.T) {
+	now := time.Now()
+	job := func(state pps.JobState, duration, ago time.Duration) *pps.JobInfo {
+		started, _ := types.TimestampProto(now.Add(-ago - duration))
+		finished, _ := types.TimestampProto(now.Add(-ago))
+		return &pps.JobInfo{State: state, Started: started, Finished: finished}
+	}
+	created, _ := types.TimestampProto(now.Add(-72 * time.Hour))
+	pipelineInfo := &pps.PipelineInfo{Pipeline: &pps.Pipeline{Name: "p"}, Details: &pps.PipelineInfo_Details{CreatedAt: created}}
+	jobInfos := []*pps.JobInfo{
+		job(pps.JobState_JOB_SUCCESS, 10*time.Minute, 3*time.Hour),
+		job(pps.JobState_JOB_SUCCESS, 20*time.Minute, 2*time.Hour),
+		job(pps.JobState_JOB_FAILURE, time.Hour, time.Hour),
+		job(pps.JobState_JOB_KILLED, 5*time.Hour, time.Minute),
+		// outside of the default window
+		job(pps.JobState_JOB_FAILURE, 10*time.Hour, 48*time.Hour),
+	}
+
+	status := checkSLO(&pps.SLO{Name: "fast", MaxJobDuration: types.DurationProto(30 * time.Minute)}, pipelineInfo, jobInfos, now)
+	require.True(t, status.Violated)
+	require.Equal(t, int64(3), status.Jobs)
+	require.Equal(t, time.Hour.Seconds(), status.Value)
+	status = checkSLO(&pps.SLO{Name: "median", MaxJobDuration: types.DurationProto(30 * time.Minute), JobDurationPercentile: 50}, pipelineInfo, jobInfos, now)
+	require.False(t, status.Violated)
+
+	status = checkSLO(&pps.SLO{Name: "fresh", MaxCommitInterval: types.DurationProto(time.Hour)}, pipelineInfo, jobInfos, now)
+	require.True(t, status.Violated)
+	status = checkSLO(&pps.SLO{Name: "fresh", MaxCommitInterval: types.DurationProto(3 * time.Hour)}, pipelineInfo, jobInfos, now)
+	require.False(t, status.Violated)
+	status = checkSLO(&pps.SLO{Name: "fresh", MaxCommitInterval: types.DurationProto(time.Hour)}, pipelineInfo, nil, now)
+	require.True(t, status.Violated)
+
+	status = checkSLO(&pps.SLO{Name: "reliable", MaxFailureRate: 0.5}, pipelineInfo, jobInfos, now)
+	require.False(t, status.Violated)
+	status = checkSLO(&pps.SLO{Name: "reliable", MaxFailureRate: 0.25}, pipelineInfo, jobInfos, now)
+	require.True(t, status.Violated)
+	status = checkSLO(&pps.SLO{Name: "reliable", MaxFailureRate: 0.25, Window: types.DurationProto(90 * time.Minute)}, pipelineInfo, jobInfos, now)
+	require.Equal(t, 1.0, status.Value)
+}
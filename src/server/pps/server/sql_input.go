@@ -0,0 +1,342 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron"
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/sdata"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+const (
+	// sqlPasswordKey is the key of the password in a SQL input's secret.
+	sqlPasswordKey = "PACHYDERM_SQL_PASSWORD"
+	// sqlPositionPrefix prefixes the description of commits to a SQL input's
+	// repo, and is followed by the input's position as JSON.
+	sqlPositionPrefix = "SQL ingest position "
+	// sqlChangesExt is the extension of the files that a replication slot's
+	// changes are written to, which hold a JSON object per line.
+	sqlChangesExt = ".jsonl"
+)
+
+// sqlPosition is how far a SQL input has read its database.
+type sqlPosition struct {
+	// Time is when the database was last read.
+	Time time.Time `json:"time"`
+	// LSN is the log sequence number of the last change read from the
+	// input's replication slot.
+	LSN string `json:"lsn,omitempty"`
+}
+
+// sqlChange is a change read from a replication slot.
+type sqlChange struct {
+	LSN  string          `json:"lsn"`
+	XID  string          `json:"xid"`
+	Data json.RawMessage `json:"data"`
+}
+
+// validateSQLSource checks the SQL source of a PFS input, whose defaults have
+// been set.
+func validateSQLSource(pipelineName string, input *pps.PFSInput) error {
+	source := input.Sql
+	url, err := pachsql.ParseURL(source.Url)
+	if err != nil {
+		return errors.Wrapf(err, "invalid sql url %q", source.Url)
+	}
+	switch url.Protocol {
+	case pachsql.ProtocolPostgres, "postgresql", pachsql.ProtocolMySQL, pachsql.ProtocolSnowflake:
+	default:
+		return errors.Errorf("sql input %q: database protocol %q is not supported", input.Name, url.Protocol)
+	}
+	if _, err := cron.ParseStandard(source.Spec); err != nil {
+		return errors.Wrapf(err, "error parsing sql input %q spec", input.Name)
+	}
+	switch {
+	case input.Remote != nil:
+		return errors.Errorf("input %q can't read from both a remote repo and a SQL database", input.Name)
+	case source.Secret == "":
+		return errors.Errorf("sql input %q must specify a secret", input.Name)
+	case (source.Query == "") == (source.ReplicationSlot == ""):
+		return errors.Errorf("sql input %q must specify exactly one of query and replication_slot", input.Name)
+	case source.ReplicationSlot != "" && url.Protocol != pachsql.ProtocolPostgres && url.Protocol != "postgresql":
+		return errors.Errorf("sql input %q can only read a replication slot from postgres", input.Name)
+	case source.Format != "" && source.Format != "json" && source.Format != "csv":
+		return errors.Errorf("sql input %q has unknown format %q, it must be json or csv", input.Name, source.Format)
+	case input.Repo != fmt.Sprintf("%s_%s", pipelineName, input.Name):
+		return errors.Errorf("sql input %q can't specify a repo, as pachyderm "+
+			"creates the repo it ingests the database into", input.Name)
+	case input.Commit != "":
+		return errors.Errorf("sql input %q can't specify a commit", input.Name)
+	case input.Trigger != nil:
+		return errors.Errorf("sql input %q can't specify a trigger", input.Name)
+	}
+	return nil
+}
+
+// openSQLSource connects to the database of a SQL input, with the password in
+// the input's secret.
+func openSQLSource(ctx context.Context, env Env, source *pps.SQLSource) (*pachsql.DB, error) {
+	url, err := pachsql.ParseURL(source.Url)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := env.KubeClient.CoreV1().Secrets(env.Config.Namespace).Get(ctx, source.Secret, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get secret %q", source.Secret)
+	}
+	password, ok := secret.Data[sqlPasswordKey]
+	if !ok {
+		return nil, errors.Errorf("secret %q has no %q key", source.Secret, sqlPasswordKey)
+	}
+	return pachsql.OpenURL(*url, strings.TrimSpace(string(password)))
+}
+
+// ingestSQLCommits reads a SQL input's database into its repo each time its
+// spec is due, one commit per read. A new input's database is read
+// immediately. It's a helper function called by monitorPipeline.
+func ingestSQLCommits(ctx context.Context, env Env, in *pps.Input) (retErr error) {
+	source := in.Pfs.Sql
+	schedule, err := cron.ParseStandard(source.Spec)
+	if err != nil {
+		return errors.EnsureStack(err) // Shouldn't happen, as the input is validated in CreatePipeline
+	}
+	db, err := openSQLSource(ctx, env, source)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := db.Close(); retErr == nil {
+			retErr = errors.EnsureStack(err)
+		}
+	}()
+	pachClient := env.GetPachClient(ctx)
+	pos, err := latestSQLPosition(pachClient, in.Pfs.Repo)
+	if err != nil {
+		return err
+	}
+	for {
+		if pos != nil {
+			next := schedule.Next(pos.Time)
+			if next.IsZero() {
+				return nil // zero time indicates the database will never be read again
+			}
+			if wait := time.Until(next); wait > 0 {
+				select {
+				case <-time.After(wait):
+				case <-ctx.Done():
+					return errors.EnsureStack(ctx.Err())
+				}
+			}
+		}
+		if pos, err = ingestSQL(ctx, pachClient, db, in.Pfs, pos); err != nil {
+			return err
+		}
+	}
+}
+
+// latestSQLPosition returns the position recorded by the head of a SQL
+// input's repo, or nil if the database hasn't been read yet. If the head was
+// left open by an interrupted read, it's finished with an error.
+func latestSQLPosition(pachClient *client.APIClient, repo string) (*sqlPosition, error) {
+	var pos *sqlPosition
+	if err := pachClient.ListCommitF(client.NewRepo(repo), client.NewCommit(repo, "master", ""), nil, 0, false, func(ci *pfs.CommitInfo) error {
+		if ci.Finished == nil {
+			if _, err := pachClient.PfsAPIClient.FinishCommit(pachClient.Ctx(), &pfs.FinishCommitRequest{
+				Commit: ci.Commit,
+				Error:  "reading the SQL database was interrupted",
+				Force:  true,
+			}); err != nil {
+				return grpcutil.ScrubGRPC(err)
+			}
+			return nil
+		}
+		if ci.Error != "" || !strings.HasPrefix(ci.Description, sqlPositionPrefix) {
+			return nil
+		}
+		pos = &sqlPosition{}
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(ci.Description, sqlPositionPrefix)), pos); err != nil {
+			return errors.Wrapf(err, "could not parse the position of commit %s", ci.Commit.ID)
+		}
+		return errutil.ErrBreak
+	}); err != nil && !errutil.IsNotFoundError(err) {
+		return nil, err
+	}
+	return pos, nil
+}
+
+// ingestSQL reads a SQL input's database once, and returns the input's new
+// position.
+func ingestSQL(ctx context.Context, pachClient *client.APIClient, db *pachsql.DB, input *pps.PFSInput, pos *sqlPosition) (*sqlPosition, error) {
+	next := &sqlPosition{Time: time.Now().UTC()}
+	if pos != nil {
+		next.LSN = pos.LSN
+	}
+	if input.Sql.Query != "" {
+		return next, snapshotSQL(ctx, pachClient, db, input, next)
+	}
+	return next, readSQLChanges(ctx, pachClient, db, input, next)
+}
+
+// snapshotSQL replaces the files in a SQL input's repo with the result of its
+// query.
+func snapshotSQL(ctx context.Context, pachClient *client.APIClient, db *pachsql.DB, input *pps.PFSInput, next *sqlPosition) error {
+	rows, err := db.QueryContext(ctx, input.Sql.Query)
+	if err != nil {
+		return errors.Wrapf(err, "could not run the query of sql input %q", input.Name)
+	}
+	defer rows.Close()
+	format := input.Sql.Format
+	if format == "" {
+		format = "json"
+	}
+	return commitSQL(pachClient, input.Repo, next, func(mf client.ModifyFile) error {
+		if err := mf.DeleteFile("/"); err != nil {
+			return errors.EnsureStack(err)
+		}
+		return miscutil.WithPipe(func(w io.Writer) error {
+			columns, err := rows.Columns()
+			if err != nil {
+				return errors.EnsureStack(err)
+			}
+			var tw sdata.TupleWriter
+			if format == "csv" {
+				tw = sdata.NewCSVWriter(w, columns)
+			} else {
+				tw = sdata.NewJSONWriter(w, columns)
+			}
+			_, err = sdata.MaterializeSQL(tw, rows)
+			return err
+		}, func(r io.Reader) error {
+			return errors.EnsureStack(mf.PutFile("/snapshot."+format, r))
+		})
+	})
+}
+
+// readSQLChanges commits the changes in a SQL input's replication slot since
+// its position to a new file in its repo, if there are any, and then advances
+// the slot past them. Changes are peeked rather than consumed, so that
+// changes that fail to be committed are read again. If the slot fails to
+// advance, the changes that were already committed are skipped by their LSN.
+func readSQLChanges(ctx context.Context, pachClient *client.APIClient, db *pachsql.DB, input *pps.PFSInput, next *sqlPosition) error {
+	slot := input.Sql.ReplicationSlot
+	query := "SELECT lsn::text, xid::text, data FROM pg_logical_slot_peek_changes($1, NULL, NULL)"
+	args := []interface{}{slot}
+	if next.LSN != "" {
+		query += " WHERE lsn > $2::pg_lsn"
+		args = append(args, next.LSN)
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return errors.Wrapf(err, "could not read replication slot %q", slot)
+	}
+	defer rows.Close()
+	readChange := func() (*sqlChange, error) {
+		if !rows.Next() {
+			return nil, errors.EnsureStack(rows.Err())
+		}
+		var c sqlChange
+		var data sql.NullString
+		if err := rows.Scan(&c.LSN, &c.XID, &data); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		// output plugins such as wal2json write JSON, which is kept as is
+		if json.Valid([]byte(data.String)) {
+			c.Data = json.RawMessage(data.String)
+		} else {
+			c.Data, _ = json.Marshal(data.String)
+		}
+		return &c, nil
+	}
+	first, err := readChange()
+	if err != nil {
+		return err
+	}
+	if first == nil {
+		return nil // nothing has changed
+	}
+	// the file is named after the first change in it, so that a read that's
+	// retried after failing part way through replaces the partial file
+	path, err := sqlChangesPath(first.LSN)
+	if err != nil {
+		return err
+	}
+	if err := commitSQL(pachClient, input.Repo, next, func(mf client.ModifyFile) error {
+		return miscutil.WithPipe(func(w io.Writer) error {
+			enc := json.NewEncoder(w)
+			for c := first; c != nil; {
+				if err := enc.Encode(c); err != nil {
+					return errors.EnsureStack(err)
+				}
+				// next is recorded in the commit's description, which is
+				// written when the commit is finished, after the last change
+				next.LSN = c.LSN
+				var err error
+				if c, err = readChange(); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, func(r io.Reader) error {
+			return errors.EnsureStack(mf.PutFile(path, r))
+		})
+	}); err != nil {
+		return err
+	}
+	if _, err := db.ExecContext(ctx, "SELECT pg_replication_slot_advance($1, $2::pg_lsn)", slot, next.LSN); err != nil {
+		log.Errorf("could not advance replication slot %q to %s: %v", slot, next.LSN, err)
+	}
+	return nil
+}
+
+// sqlChangesPath returns the path of the file of changes starting at lsn,
+// which is zero padded so that the files sort in the order of their changes.
+func sqlChangesPath(lsn string) (string, error) {
+	var hi, lo uint32
+	if _, err := fmt.Sscanf(lsn, "%X/%X", &hi, &lo); err != nil {
+		return "", errors.Wrapf(err, "invalid LSN %q", lsn)
+	}
+	return fmt.Sprintf("/%08X%08X%s", hi, lo, sqlChangesExt), nil
+}
+
+// commitSQL makes a commit to repo with the files written by cb, which
+// records the input's position once cb has returned.
+func commitSQL(pachClient *client.APIClient, repo string, pos *sqlPosition, cb func(client.ModifyFile) error) (retErr error) {
+	commit, err := pachClient.StartCommit(repo, "master")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		req := &pfs.FinishCommitRequest{Commit: commit}
+		if retErr == nil {
+			data, err := json.Marshal(pos)
+			if err != nil {
+				retErr = errors.EnsureStack(err)
+			}
+			req.Description = sqlPositionPrefix + string(data)
+		}
+		if retErr != nil {
+			req.Description = ""
+			req.Error = fmt.Sprintf("could not read the SQL database: %v", retErr)
+		}
+		if _, err := pachClient.PfsAPIClient.FinishCommit(pachClient.Ctx(), req); retErr == nil {
+			retErr = grpcutil.ScrubGRPC(err)
+		}
+	}()
+	return pachClient.WithModifyFileClient(commit, cb)
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestSQLInputDefaults(t *testing.T) {
+	input := &pps.Input{Pfs: &pps.PFSInput{
+		Name: "orders",
+		Glob: "/",
+		Sql: &pps.SQLSource{
+			Url:    "postgres://pachyderm@db.example.com:5432/shop",
+			Secret: "sql-password",
+			Spec:   "@every 1h",
+			Query:  "SELECT * FROM orders",
+		},
+	}}
+	setInputDefaults("report", input)
+	require.Equal(t, "report_orders", input.Pfs.Repo)
+	require.Equal(t, "master", input.Pfs.Branch)
+	require.NoError(t, validateSQLSource("report", input.Pfs))
+}
+
+func TestValidateSQLSource(t *testing.T) {
+	valid := func() *pps.PFSInput {
+		return &pps.PFSInput{
+			Name: "orders",
+			Repo: "report_orders",
+			Sql: &pps.SQLSource{
+				Url:             "postgres://pachyderm@db.example.com:5432/shop",
+				Secret:          "sql-password",
+				Spec:            "@every 1h",
+				ReplicationSlot: "pachyderm",
+			},
+		}
+	}
+	require.NoError(t, validateSQLSource("report", valid()))
+
+	input := valid()
+	input.Sql.Spec = "not a spec"
+	require.YesError(t, validateSQLSource("report", input))
+	input = valid()
+	input.Sql.Secret = ""
+	require.YesError(t, validateSQLSource("report", input))
+	input = valid()
+	input.Sql.Query = "SELECT * FROM orders"
+	require.YesError(t, validateSQLSource("report", input))
+	input = valid()
+	input.Sql.Url = "mysql://pachyderm@db.example.com:3306/shop"
+	require.YesError(t, validateSQLSource("report", input))
+	input = valid()
+	input.Sql.Format = "parquet"
+	require.YesError(t, validateSQLSource("report", input))
+	input = valid()
+	input.Repo = "orders"
+	require.YesError(t, validateSQLSource("report", input))
+}
+
+func TestSQLChangesPath(t *testing.T) {
+	path, err := sqlChangesPath("16/B374D848")
+	require.NoError(t, err)
+	require.Equal(t, "/00000016B374D848.jsonl", path)
+	_, err = sqlChangesPath("not an lsn")
+	require.YesError(t, err)
+}
@@ -0,0 +1,261 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/net/context"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubelabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+const (
+	// workerPoolLabel holds the key of the worker pool that started a worker.
+	// The workers that a pooled pipeline's RC starts itself, because its pool
+	// had no idle workers, have coldWorkerPool instead, so that the RC doesn't
+	// adopt the workers claimed from the pool.
+	workerPoolLabel = "workerPool"
+	coldWorkerPool  = "cold"
+	// workerPoolAnnotation holds the key of the worker pool of a pooled
+	// pipeline's RC.
+	workerPoolAnnotation = "workerPool"
+)
+
+// workerPoolKey returns the key of the worker pool shared by the pipelines
+// whose worker pods have podSpec. Pods that only differ in the pipeline they
+// run have the same key.
+func workerPoolKey(podSpec *v1.PodSpec) (string, error) {
+	spec := podSpec.DeepCopy()
+	setPipelineEnv(spec, "", "")
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", errors.EnsureStack(err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:16], nil
+}
+
+// setPipelineEnv sets the environment variables that name the pipeline, and
+// its spec commit, in all of podSpec's containers.
+func setPipelineEnv(podSpec *v1.PodSpec, pipelineName, specCommitID string) {
+	for _, containers := range [][]v1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			for j := range containers[i].Env {
+				switch containers[i].Env[j].Name {
+				case client.PPSPipelineNameEnv:
+					containers[i].Env[j].Value = pipelineName
+				case client.PPSSpecCommitEnv:
+					containers[i].Env[j].Value = specCommitID
+				}
+			}
+		}
+	}
+}
+
+// workerPoolPodSpec returns the pod spec of the workers of the pool with key,
+// which serves pipelines whose worker pods have podSpec. Until they're
+// claimed, pool workers act as the workers of a pipeline named after the
+// pool's RC.
+func workerPoolPodSpec(podSpec *v1.PodSpec, key string) *v1.PodSpec {
+	spec := podSpec.DeepCopy()
+	setPipelineEnv(spec, ppsutil.WorkerPoolRcName(key), "")
+	for i := range spec.Containers {
+		if spec.Containers[i].Name == client.PPSWorkerUserContainerName {
+			spec.Containers[i].Env = append(spec.Containers[i].Env, v1.EnvVar{Name: client.PPSWorkerPoolEnv, Value: key})
+		}
+	}
+	return spec
+}
+
+func workerPoolLabels(key string) map[string]string {
+	return map[string]string{
+		"app":           ppsutil.WorkerPoolRcName(key),
+		"suite":         suite,
+		"component":     "worker-pool",
+		workerPoolLabel: key,
+	}
+}
+
+// claimedPoolWorkersSelector selects the workers that the pipeline of rc
+// claimed from its worker pool.
+func claimedPoolWorkersSelector(rc *v1.ReplicationController) string {
+	return fmt.Sprintf("%s=%s,%s=%s", pipelineNameLabel, rc.Labels[pipelineNameLabel], workerPoolLabel, rc.Annotations[workerPoolAnnotation])
+}
+
+func podIsReady(pod *v1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != v1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// ensureWorkerPool creates the worker pool that serves pipelines whose
+// worker pods have podSpec, if it doesn't exist, and makes sure it keeps at
+// least pool.Warm idle workers. It returns the pool's key.
+func (kd *kubeDriver) ensureWorkerPool(ctx context.Context, pool *pps.WorkerPool, podSpec *v1.PodSpec) (string, error) {
+	key, err := workerPoolKey(podSpec)
+	if err != nil {
+		return "", err
+	}
+	name := ppsutil.WorkerPoolRcName(key)
+	labels := workerPoolLabels(key)
+	warm := int32(pool.Warm)
+	rc := &v1.ReplicationController{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ReplicationController",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Spec: v1.ReplicationControllerSpec{
+			Selector: labels,
+			Replicas: &warm,
+			Template: &v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   name,
+					Labels: labels,
+				},
+				Spec: *workerPoolPodSpec(podSpec, key),
+			},
+		},
+	}
+	rcs := kd.kubeClient.CoreV1().ReplicationControllers(kd.namespace)
+	if _, err := rcs.Create(ctx, rc, metav1.CreateOptions{}); err != nil {
+		if !errutil.IsAlreadyExistError(err) {
+			return "", errors.EnsureStack(err)
+		}
+		// the pool is shared, so it keeps the largest warm of its pipelines
+		existing, err := rcs.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", errors.EnsureStack(err)
+		}
+		if existing.Spec.Replicas == nil || *existing.Spec.Replicas < warm {
+			existing.Spec.Replicas = &warm
+			if _, err := rcs.Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+				return "", errors.EnsureStack(err)
+			}
+		}
+	}
+	return key, nil
+}
+
+// deleteUnusedWorkerPool deletes the worker pool with key, and its workers,
+// if no pipeline's RC uses it.
+func (kd *kubeDriver) deleteUnusedWorkerPool(ctx context.Context, key string) error {
+	rcs, err := kd.ListReplicationControllers(ctx)
+	if err != nil {
+		return err
+	}
+	for _, rc := range rcs.Items {
+		if rc.Annotations[workerPoolAnnotation] == key {
+			return nil
+		}
+	}
+	name := ppsutil.WorkerPoolRcName(key)
+	if err := kd.kubeClient.CoreV1().ReplicationControllers(kd.namespace).Delete(ctx, name, metav1.DeleteOptions{
+		OrphanDependents: &falseVal,
+	}); err != nil && !errutil.IsNotFoundError(err) {
+		return errors.Wrapf(err, "could not delete worker pool %q", name)
+	}
+	return nil
+}
+
+func (kd *kubeDriver) ReadPoolWorkers(ctx context.Context, rc *v1.ReplicationController) (int32, error) {
+	kd.limiter.Acquire()
+	defer kd.limiter.Release()
+	pods, err := kd.kubeClient.CoreV1().Pods(kd.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: claimedPoolWorkersSelector(rc),
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to list pool workers of %q", rc.Name)
+	}
+	var n int32
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp == nil {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (kd *kubeDriver) ClaimPoolWorkers(ctx context.Context, rc *v1.ReplicationController, n int32) (int32, error) {
+	kd.limiter.Acquire()
+	defer kd.limiter.Release()
+	key := rc.Annotations[workerPoolAnnotation]
+	pods := kd.kubeClient.CoreV1().Pods(kd.namespace)
+	idle, err := pods.List(ctx, metav1.ListOptions{
+		LabelSelector: kubelabels.SelectorFromSet(workerPoolLabels(key)).String(),
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to list idle workers of worker pool %q", key)
+	}
+	// Relabeling a worker takes it out of the pool, which starts another
+	// worker to replace it, and gives it the labels of the pipeline's
+	// workers. The annotation tells the worker which pipeline claimed it.
+	labels := make(map[string]string)
+	for k, v := range rc.Spec.Template.Labels {
+		labels[k] = v
+	}
+	labels[workerPoolLabel] = key
+	assignment := ppsutil.WorkerPoolAssignment(rc.Labels[pipelineNameLabel], rc.Annotations[pipelineSpecCommitAnnotation])
+	var claimed int32
+	for i := range idle.Items {
+		if claimed == n {
+			break
+		}
+		pod := &idle.Items[i]
+		if !podIsReady(pod) {
+			continue
+		}
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				// the patch fails if another claim relabeled the worker first
+				"resourceVersion": pod.ResourceVersion,
+				"labels":          labels,
+				"annotations":     map[string]string{ppsutil.WorkerPoolAssignmentAnnotation: assignment},
+			},
+		})
+		if err != nil {
+			return claimed, errors.EnsureStack(err)
+		}
+		if _, err := pods.Patch(ctx, pod.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			if errutil.IsNotFoundError(err) || k8serrors.IsConflict(err) {
+				continue
+			}
+			return claimed, errors.Wrapf(err, "failed to claim pool worker %q", pod.Name)
+		}
+		claimed++
+	}
+	return claimed, nil
+}
+
+func (kd *kubeDriver) ReleasePoolWorkers(ctx context.Context, rc *v1.ReplicationController) error {
+	kd.limiter.Acquire()
+	defer kd.limiter.Release()
+	// Claimed workers run the pipeline until they exit, so they're deleted
+	// rather than returned to the pool, which has already replaced them.
+	if err := kd.kubeClient.CoreV1().Pods(kd.namespace).DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{
+		LabelSelector: claimedPoolWorkersSelector(rc),
+	}); err != nil {
+		return newRetriableError(err, "error deleting pool workers")
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func workerPoolTestPodSpec(pipelineName, image string) *v1.PodSpec {
+	return &v1.PodSpec{
+		Containers: []v1.Container{{
+			Name:  client.PPSWorkerUserContainerName,
+			Image: image,
+			Env: []v1.EnvVar{
+				{Name: client.PPSPipelineNameEnv, Value: pipelineName},
+				{Name: client.PPSSpecCommitEnv, Value: pipelineName + "-commit"},
+			},
+		}},
+	}
+}
+
+func TestWorkerPoolKey(t *testing.T) {
+	a, err := workerPoolKey(workerPoolTestPodSpec("a", "image:1"))
+	require.NoError(t, err)
+	b, err := workerPoolKey(workerPoolTestPodSpec("b", "image:1"))
+	require.NoError(t, err)
+	c, err := workerPoolKey(workerPoolTestPodSpec("a", "image:2"))
+	require.NoError(t, err)
+	// pipelines that only differ in their names share a pool
+	require.Equal(t, a, b)
+	require.NotEqual(t, a, c)
+}
+
+func TestWorkerPoolPodSpec(t *testing.T) {
+	podSpec := workerPoolTestPodSpec("a", "image:1")
+	key, err := workerPoolKey(podSpec)
+	require.NoError(t, err)
+	spec := workerPoolPodSpec(podSpec, key)
+	env := make(map[string]string)
+	for _, e := range spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	require.Equal(t, key, env[client.PPSWorkerPoolEnv])
+	require.Equal(t, "worker-pool-"+key, env[client.PPSPipelineNameEnv])
+	require.Equal(t, "", env[client.PPSSpecCommitEnv])
+	// the pipeline's pod spec is unchanged
+	require.Equal(t, "a", podSpec.Containers[0].Env[0].Value)
+	require.Equal(t, 2, len(podSpec.Containers[0].Env))
+}
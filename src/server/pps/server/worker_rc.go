@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/tls"
 	"github.com/pachyderm/pachyderm/v2/src/internal/tracing"
 	"github.com/pachyderm/pachyderm/v2/src/pps"
 	workerstats "github.com/pachyderm/pachyderm/v2/src/server/worker/stats"
@@ -42,6 +44,8 @@ const (
 	// UploadConcurrencyLimitEnvVar is the environment variable for the upload concurrency limit.
 	// EnvVar defined in src/internal/serviceenv/config.go
 	UploadConcurrencyLimitEnvVar = "STORAGE_UPLOAD_CONCURRENCY_LIMIT"
+
+	nodeCacheVolumeName = "pach-node-cache"
 )
 
 // Parameters used when creating the kubernetes replication controller in charge
@@ -63,8 +67,12 @@ type workerOptions struct {
 	volumeMounts          []v1.VolumeMount      // Paths where we mount each volume in 'volumes'
 	postgresSecret        *v1.SecretKeySelector // the reference to the postgres password
 	schedulingSpec        *pps.SchedulingSpec   // the SchedulingSpec for the pipeline
+	onDemand              bool                  // whether the workers have fallen back from preemptible to on-demand nodes
+	priorityClassName     string                // the PriorityClass of the pipeline's priority, if it has one
 	podSpec               string
 	podPatch              string
+	sidecars              []v1.Container // The pipeline's own sidecars, run alongside the user and storage containers
+	initContainers        []v1.Container // The pipeline's own init containers, run after Pachyderm's init container
 
 	// Secrets that we mount in the worker container (e.g. for reading/writing to
 	// s3)
@@ -76,32 +84,32 @@ type workerOptions struct {
 // VolumeMount object configured for the pachctl secret (currently used in spout pipelines).
 func getPachctlSecretVolumeAndMount(secret string) (v1.Volume, v1.VolumeMount) {
 	return v1.Volume{
-			Name: client.PachctlSecretName,
-			VolumeSource: v1.VolumeSource{
-				Secret: &v1.SecretVolumeSource{
-					SecretName: secret,
-				},
+		Name: client.PachctlSecretName,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: secret,
 			},
-		}, v1.VolumeMount{
-			Name:      client.PachctlSecretName,
-			MountPath: "/pachctl",
-		}
+		},
+	}, v1.VolumeMount{
+		Name:      client.PachctlSecretName,
+		MountPath: "/pachctl",
+	}
 }
 
 // getTLSCertSecretVolumeAndMount returns a Volume and VolumeMount object
 // configured for the pach-tls secret to be stored in pipeline side-cars.
 func getTLSCertSecretVolumeAndMount(secret, mountPath string) (v1.Volume, v1.VolumeMount) {
 	return v1.Volume{
-			Name: secret,
-			VolumeSource: v1.VolumeSource{
-				Secret: &v1.SecretVolumeSource{
-					SecretName: secret,
-				},
+		Name: secret,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: secret,
 			},
-		}, v1.VolumeMount{
-			Name:      secret,
-			MountPath: mountPath,
-		}
+		},
+	}, v1.VolumeMount{
+		Name:      secret,
+		MountPath: mountPath,
+	}
 }
 
 func (kd *kubeDriver) workerPodSpec(options *workerOptions, pipelineInfo *pps.PipelineInfo) (v1.PodSpec, error) {
@@ -223,6 +231,7 @@ func (kd *kubeDriver) workerPodSpec(options *workerOptions, pipelineInfo *pps.Pi
 		},
 	}...)
 	workerEnv = append(workerEnv, commonEnv...)
+	workerEnv = append(workerEnv, kd.getModelRegistrySecretEnvVars(pipelineInfo)...)
 
 	// Set S3GatewayPort in the worker (for user code) and sidecar (for serving)
 	if options.s3GatewayPort != 0 {
@@ -248,6 +257,15 @@ func (kd *kubeDriver) workerPodSpec(options *workerOptions, pipelineInfo *pps.Pi
 		sidecarEnv = append(sidecarEnv, v1.EnvVar{Name: "GOOGLE_CLOUD_PROFILER_PROJECT", Value: p})
 		workerEnv = append(workerEnv, v1.EnvVar{Name: "GOOGLE_CLOUD_PROFILER_PROJECT", Value: p})
 	}
+	for _, e := range []v1.EnvVar{
+		{Name: "CONTINUOUS_PROFILING_INTERVAL_SECONDS", Value: strconv.Itoa(kd.config.ContinuousProfilingIntervalSeconds)},
+		{Name: "CONTINUOUS_PROFILING_CPU_SECONDS", Value: strconv.Itoa(kd.config.ContinuousProfilingCPUSeconds)},
+		{Name: "CONTINUOUS_PROFILING_RETENTION_HOURS", Value: strconv.Itoa(kd.config.ContinuousProfilingRetentionHours)},
+		{Name: "METADATA_BACKEND", Value: kd.config.MetadataBackend},
+	} {
+		sidecarEnv = append(sidecarEnv, e)
+		workerEnv = append(workerEnv, e)
+	}
 
 	// This only happens in local deployment.  We want the workers to be
 	// able to read from/write to the hostpath volume as well.
@@ -291,6 +309,26 @@ func (kd *kubeDriver) workerPodSpec(options *workerOptions, pipelineInfo *pps.Pi
 	sidecarVolumeMounts = append(sidecarVolumeMounts, secretMount)
 	userVolumeMounts = append(userVolumeMounts, secretMount)
 
+	// the sidecar reads chunks through the cache shared by the pods on its
+	// node, if pachd uses one
+	if kd.config.StorageNodeCacheHostPath != "" && kd.config.StorageNodeCachePath != "" {
+		hostPathType := v1.HostPathDirectoryOrCreate
+		options.volumes = append(options.volumes, v1.Volume{
+			Name: nodeCacheVolumeName,
+			VolumeSource: v1.VolumeSource{
+				HostPath: &v1.HostPathVolumeSource{
+					Path: kd.config.StorageNodeCacheHostPath,
+					Type: &hostPathType,
+				},
+			},
+		})
+		sidecarVolumeMounts = append(sidecarVolumeMounts, v1.VolumeMount{
+			Name:      nodeCacheVolumeName,
+			MountPath: kd.config.StorageNodeCachePath,
+		})
+		sidecarEnv = append(sidecarEnv, v1.EnvVar{Name: "STORAGE_NODE_CACHE_PATH", Value: kd.config.StorageNodeCachePath})
+	}
+
 	// in the case the pachd is deployed with custom root certs, propagate them to the side-cars
 	if path, ok := os.LookupEnv("SSL_CERT_DIR"); ok {
 		sidecarEnv = append(sidecarEnv, v1.EnvVar{Name: "SSL_CERT_DIR", Value: path})
@@ -299,6 +337,14 @@ func (kd *kubeDriver) workerPodSpec(options *workerOptions, pipelineInfo *pps.Pi
 		sidecarVolumeMounts = append(sidecarVolumeMounts, certSecretMount)
 	}
 
+	// the worker serves its API over mutual TLS with the peer certificate, if
+	// pachd has one
+	if kd.config.PeerTLSSecretName != "" {
+		peerVolume, peerMount := getTLSCertSecretVolumeAndMount(kd.config.PeerTLSSecretName, tls.PeerVolumePath)
+		options.volumes = append(options.volumes, peerVolume)
+		userVolumeMounts = append(userVolumeMounts, peerMount)
+	}
+
 	// mount secret for spouts using pachctl
 	if pipelineInfo.Details.Spout != nil {
 		pachctlSecretVolume, pachctlSecretMount := getPachctlSecretVolumeAndMount("spout-pachctl-secret-" + pipelineInfo.Pipeline.Name)
@@ -417,9 +463,9 @@ func (kd *kubeDriver) workerPodSpec(options *workerOptions, pipelineInfo *pps.Pi
 		ImagePullSecrets:              options.imagePullSecrets,
 		TerminationGracePeriodSeconds: int64Ptr(0),
 	}
-	if options.schedulingSpec != nil {
-		podSpec.NodeSelector = options.schedulingSpec.NodeSelector
-		podSpec.PriorityClassName = options.schedulingSpec.PriorityClassName
+	applySchedulingSpec(&podSpec, options.schedulingSpec, options.onDemand)
+	if options.priorityClassName != "" {
+		podSpec.PriorityClassName = options.priorityClassName
 	}
 
 	if options.resourceRequests != nil {
@@ -453,6 +499,9 @@ func (kd *kubeDriver) workerPodSpec(options *workerOptions, pipelineInfo *pps.Pi
 		}
 	}
 
+	podSpec.InitContainers = append(podSpec.InitContainers, options.initContainers...)
+	podSpec.Containers = append(podSpec.Containers, options.sidecars...)
+
 	if options.podSpec != "" || options.podPatch != "" {
 		jsonPodSpec, err := json.Marshal(&podSpec)
 		if err != nil {
@@ -490,6 +539,7 @@ func (kd *kubeDriver) getStorageEnvVars(pipelineInfo *pps.PipelineInfo) []v1.Env
 	vars := []v1.EnvVar{
 		{Name: UploadConcurrencyLimitEnvVar, Value: strconv.Itoa(kd.config.StorageUploadConcurrencyLimit)},
 		{Name: client.PPSPipelineNameEnv, Value: pipelineInfo.Pipeline.Name},
+		{Name: "STORAGE_ACCESS_LOG", Value: strconv.FormatBool(kd.config.StorageAccessLog)},
 	}
 	return vars
 }
@@ -512,6 +562,24 @@ func (kd *kubeDriver) getEgressSecretEnvVars(pipelineInfo *pps.PipelineInfo) []v
 	return result
 }
 
+// getModelRegistrySecretEnvVars returns the env var that holds the token used
+// by the worker to authenticate to the pipeline's model registry.
+func (kd *kubeDriver) getModelRegistrySecretEnvVars(pipelineInfo *pps.PipelineInfo) []v1.EnvVar {
+	secret := pipelineInfo.Details.ModelRegistry.GetSecret()
+	if secret == "" {
+		return nil
+	}
+	return []v1.EnvVar{{
+		Name: pps.ModelRegistryTokenEnv,
+		ValueFrom: &v1.EnvVarSource{
+			SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: secret},
+				Key:                  pps.ModelRegistryTokenEnv,
+			},
+		},
+	}}
+}
+
 // We don't want to expose pipeline auth tokens, so we hash it. This will be
 // visible to any user with k8s cluster access
 // Note: This hash shouldn't be used for authentication in any way. We just use
@@ -531,26 +599,38 @@ func (kd *kubeDriver) getWorkerOptions(ctx context.Context, pipelineInfo *pps.Pi
 	var sidecarResourceLimits *v1.ResourceList
 	if pipelineInfo.Details.ResourceRequests != nil {
 		var err error
-		resourceRequests, err = ppsutil.GetRequestsResourceListFromPipeline(pipelineInfo)
+		resourceRequests, err = ppsutil.GetRequestsResourceListFromPipeline(pipelineInfo, gpuSharing(kd.config))
 		if err != nil {
 			return nil, errors.Wrapf(err, "could not determine resource request")
 		}
 	}
 	if pipelineInfo.Details.ResourceLimits != nil {
 		var err error
-		resourceLimits, err = ppsutil.GetLimitsResourceList(pipelineInfo.Details.ResourceLimits)
+		resourceLimits, err = ppsutil.GetLimitsResourceList(pipelineInfo.Details.ResourceLimits, gpuSharing(kd.config))
 		if err != nil {
 			return nil, errors.Wrapf(err, "could not determine resource limit")
 		}
 	}
 	if pipelineInfo.Details.SidecarResourceLimits != nil {
 		var err error
-		sidecarResourceLimits, err = ppsutil.GetLimitsResourceList(pipelineInfo.Details.SidecarResourceLimits)
+		sidecarResourceLimits, err = ppsutil.GetLimitsResourceList(pipelineInfo.Details.SidecarResourceLimits, gpuSharing(kd.config))
 		if err != nil {
 			return nil, errors.Wrapf(err, "could not determine sidecar resource limit")
 		}
 	}
 
+	var priorityClassName string
+	if priority := pipelineInfo.Details.Priority; priority != "" {
+		classes, err := parsePriorityClasses(kd.config.PipelinePriorityClasses)
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		if priorityClassName, ok = classes[priority]; !ok {
+			return nil, errors.Errorf("unknown pipeline priority %q", priority)
+		}
+	}
+
 	transform := pipelineInfo.Details.Transform
 	rcName := ppsutil.PipelineRcName(pipelineName, pipelineVersion)
 	labels := labels(rcName)
@@ -627,6 +707,22 @@ func (kd *kubeDriver) getWorkerOptions(ctx context.Context, pipelineInfo *pps.Pi
 		Name:      client.PPSWorkerVolume,
 		MountPath: client.PPSInputPrefix,
 	})
+	shared, sharedMounts := sharedVolumes(pipelineInfo.Details)
+	volumes = append(volumes, shared...)
+	volumeMounts = append(volumeMounts, sharedMounts...)
+	pullPolicy := v1.PullPolicy(kd.config.WorkerImagePullPolicy)
+	if pullPolicy == "" {
+		pullPolicy = v1.PullIfNotPresent
+	}
+	sidecars, err := extraContainers(pipelineInfo.Details.Sidecars, sharedMounts, pullPolicy, gpuSharing(kd.config))
+	if err != nil {
+		return nil, err
+	}
+	initContainers, err := extraContainers(pipelineInfo.Details.InitContainers, sharedMounts, pullPolicy, gpuSharing(kd.config))
+	if err != nil {
+		return nil, err
+	}
+
 	var imagePullSecrets []v1.LocalObjectReference
 	for _, secret := range transform.ImagePullSecrets {
 		imagePullSecrets = append(imagePullSecrets, v1.LocalObjectReference{Name: secret})
@@ -645,6 +741,9 @@ func (kd *kubeDriver) getWorkerOptions(ctx context.Context, pipelineInfo *pps.Pi
 		pipelineSpecCommitAnnotation: pipelineInfo.SpecCommit.ID,
 		hashedAuthTokenAnnotation:    hashAuthToken(pipelineInfo.AuthToken),
 	}
+	if pipelineInfo.SchedulingStatus.GetOnDemand() {
+		annotations[onDemandAnnotation] = "true"
+	}
 
 	// add the user's custom metadata (annotations and labels).
 	metadata := pipelineInfo.Details.GetMetadata()
@@ -714,12 +813,18 @@ func (kd *kubeDriver) getWorkerOptions(ctx context.Context, pipelineInfo *pps.Pi
 		imagePullSecrets:      imagePullSecrets,
 		service:               service,
 		schedulingSpec:        pipelineInfo.Details.SchedulingSpec,
+		onDemand:              pipelineInfo.SchedulingStatus.GetOnDemand(),
+		priorityClassName:     priorityClassName,
 		podSpec:               pipelineInfo.Details.PodSpec,
 		podPatch:              pipelineInfo.Details.PodPatch,
+		sidecars:              sidecars,
+		initContainers:        initContainers,
 	}, nil
 }
 
-func (kd *kubeDriver) createWorkerPachctlSecret(ctx context.Context, pipelineInfo *pps.PipelineInfo) error {
+// createWorkerPachctlSecret creates a secret named secretName with a pachctl
+// config that accesses pachd at pachdAddress as the pipeline.
+func (kd *kubeDriver) createWorkerPachctlSecret(ctx context.Context, pipelineInfo *pps.PipelineInfo, secretName, pachdAddress string) error {
 	var cfg config.Config
 	err := cfg.InitV2()
 	if err != nil {
@@ -730,7 +835,7 @@ func (kd *kubeDriver) createWorkerPachctlSecret(ctx context.Context, pipelineInf
 		return errors.Wrapf(err, "error getting the active context")
 	}
 	context.SessionToken = pipelineInfo.AuthToken
-	context.PachdAddress = "localhost:1653"
+	context.PachdAddress = pachdAddress
 
 	rawConfig, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
@@ -742,7 +847,7 @@ func (kd *kubeDriver) createWorkerPachctlSecret(ctx context.Context, pipelineInf
 			APIVersion: "v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   "spout-pachctl-secret-" + pipelineInfo.Pipeline.Name,
+			Name:   secretName,
 			Labels: labels(pipelineInfo.Pipeline.Name),
 		},
 		Data: map[string][]byte{
@@ -781,7 +886,15 @@ func (kd *kubeDriver) createWorkerSvcAndRc(ctx context.Context, pipelineInfo *pp
 
 	// create pachctl secret used in spouts
 	if pipelineInfo.Details.Spout != nil {
-		if err := kd.createWorkerPachctlSecret(ctx, pipelineInfo); err != nil {
+		if err := kd.createWorkerPachctlSecret(ctx, pipelineInfo, "spout-pachctl-secret-"+pipelineInfo.Pipeline.Name, "localhost:1653"); err != nil {
+			return err
+		}
+	}
+	// create pachctl secret used by executors, which run outside of the
+	// worker pods, so they can't use the sidecar
+	if pipelineInfo.Details.Executor != nil {
+		pachdAddress := fmt.Sprintf("pachd.%s:%d", kd.namespace, kd.config.Port)
+		if err := kd.createWorkerPachctlSecret(ctx, pipelineInfo, ppsutil.ExecutorSecretName(pipelineInfo.Pipeline.Name), pachdAddress); err != nil {
 			return err
 		}
 	}
@@ -794,6 +907,28 @@ func (kd *kubeDriver) createWorkerSvcAndRc(ctx context.Context, pipelineInfo *pp
 	if err != nil {
 		return err
 	}
+	// create the network policy before the RC, so that the workers never run
+	// without it
+	if err := kd.createWorkerNetworkPolicy(ctx, pipelineInfo, options); err != nil {
+		return err
+	}
+	rcAnnotations, podLabels := options.annotations, options.labels
+	if pipelineInfo.Details.WorkerPool != nil {
+		key, err := kd.ensureWorkerPool(ctx, pipelineInfo.Details.WorkerPool, &podSpec)
+		if err != nil {
+			return err
+		}
+		rcAnnotations = make(map[string]string)
+		for k, v := range options.annotations {
+			rcAnnotations[k] = v
+		}
+		rcAnnotations[workerPoolAnnotation] = key
+		podLabels = make(map[string]string)
+		for k, v := range options.labels {
+			podLabels[k] = v
+		}
+		podLabels[workerPoolLabel] = coldWorkerPool
+	}
 	rc := &v1.ReplicationController{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "ReplicationController",
@@ -802,15 +937,15 @@ func (kd *kubeDriver) createWorkerSvcAndRc(ctx context.Context, pipelineInfo *pp
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        options.rcName,
 			Labels:      options.labels,
-			Annotations: options.annotations,
+			Annotations: rcAnnotations,
 		},
 		Spec: v1.ReplicationControllerSpec{
-			Selector: options.labels,
+			Selector: podLabels,
 			Replicas: &options.parallelism,
 			Template: &v1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        options.rcName,
-					Labels:      options.labels,
+					Labels:      podLabels,
 					Annotations: options.annotations,
 				},
 				Spec: podSpec,
@@ -899,16 +1034,16 @@ func (kd *kubeDriver) createWorkerSvcAndRc(ctx context.Context, pipelineInfo *pp
 // VolumeMount object
 func GetBackendSecretVolumeAndMount() (v1.Volume, v1.VolumeMount) {
 	return v1.Volume{
-			Name: client.StorageSecretName,
-			VolumeSource: v1.VolumeSource{
-				Secret: &v1.SecretVolumeSource{
-					SecretName: client.StorageSecretName,
-				},
+		Name: client.StorageSecretName,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: client.StorageSecretName,
 			},
-		}, v1.VolumeMount{
-			Name:      client.StorageSecretName,
-			MountPath: "/" + client.StorageSecretName,
-		}
+		},
+	}, v1.VolumeMount{
+		Name:      client.StorageSecretName,
+		MountPath: "/" + client.StorageSecretName,
+	}
 }
 
 func int64Ptr(x int64) *int64 {
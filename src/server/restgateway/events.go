@@ -0,0 +1,154 @@
+package restgateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// eventsHeartbeat is how often a comment is sent to event stream clients
+// while there are no events, so that proxies don't close idle streams.
+const eventsHeartbeat = 30 * time.Second
+
+// parseEventsRequest returns the SubscribeEvents request of an event stream
+// request, which sets its fields with the query parameters kind, repo and
+// pipeline, which may be repeated, and cursor. The Last-Event-ID header,
+// which browsers send when they reconnect, takes precedence over cursor.
+func parseEventsRequest(r *http.Request) (*admin.SubscribeEventsRequest, error) {
+	query := r.URL.Query()
+	request := &admin.SubscribeEventsRequest{
+		Repos:     query["repo"],
+		Pipelines: query["pipeline"],
+		Cursor:    query.Get("cursor"),
+	}
+	for _, kind := range query["kind"] {
+		k, ok := admin.EventKind_value[strings.ToUpper(kind)]
+		if !ok || k == int32(admin.EventKind_EVENT_KIND_UNKNOWN) {
+			return nil, errors.Errorf("invalid event kind %q, must be commit, job or pipeline", kind)
+		}
+		request.Kinds = append(request.Kinds, admin.EventKind(k))
+	}
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		request.Cursor = id
+	}
+	return request, nil
+}
+
+// requestContext returns the context of the RPCs made to serve r, for the
+// handlers that aren't served by the gateway itself. Browsers' EventSource
+// can't set headers, so the auth token may be passed in the token query
+// parameter as well as in the headers that the gateway accepts.
+func requestContext(r *http.Request) context.Context {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		token = r.Header.Get(tokenHeader)
+	}
+	if token == "" {
+		if md := bearerToken(r.Context(), r); md != nil {
+			token = md.Get(auth.ContextTokenKey)[0]
+		}
+	}
+	if token == "" {
+		return r.Context()
+	}
+	return metadata.AppendToOutgoingContext(r.Context(), auth.ContextTokenKey, token)
+}
+
+// eventsHandler serves GET /api/events, which streams the events of
+// SubscribeEvents as server-sent events. Each event's ID is its cursor, and
+// its type is its kind in lowercase. If the subscription fails, an "error"
+// event with the JSON encoding of the error's status is sent and the stream
+// ends.
+func eventsHandler(adminClient admin.APIClient, marshaler *jsonMarshaler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+			return
+		}
+		request, err := parseEventsRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ctx, cancel := context.WithCancel(requestContext(r))
+		defer cancel()
+		events := make(chan *admin.Event)
+		errc := make(chan error, 1)
+		go func() {
+			client, err := adminClient.SubscribeEvents(ctx, request)
+			if err != nil {
+				errc <- err
+				return
+			}
+			for {
+				event, err := client.Recv()
+				if err != nil {
+					errc <- err
+					return
+				}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					errc <- ctx.Err()
+					return
+				}
+			}
+		}()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+		heartbeat := time.NewTicker(eventsHeartbeat)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case event := <-events:
+				data, err := marshaler.Marshal(event)
+				if err != nil {
+					writeEventError(w, marshaler, err)
+					return
+				}
+				fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.Cursor, strings.ToLower(event.Kind.String()), data)
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+			case err := <-errc:
+				if r.Context().Err() == nil {
+					writeEventError(w, marshaler, err)
+				}
+				return
+			case <-r.Context().Done():
+				return
+			}
+			flusher.Flush()
+		}
+	})
+}
+
+func writeEventError(w http.ResponseWriter, marshaler *jsonMarshaler, err error) {
+	s := status.Convert(err)
+	data, merr := marshaler.Marshal(s.Proto())
+	if merr != nil {
+		data = []byte(fmt.Sprintf(`{"code":%d}`, s.Code()))
+	}
+	fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,179 @@
+package restgateway
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+// FilesPrefix is the path under which the files of commits are served, at
+//
+//	GET /api/files/<repo>/<branch-or-commit>/<path>[?download=true]
+//
+// Responses have the file's detected content type, its hash as their ETag,
+// and support range and conditional requests, so that files can be embedded
+// directly in web pages. Files of commits are cached by browsers for good,
+// since they can't change, while files of branches are revalidated on each
+// request. With download=true, browsers save the file rather than display
+// it.
+const FilesPrefix = Prefix + "/files"
+
+// immutableCacheControl is the Cache-Control of files requested by commit ID.
+const immutableCacheControl = "private, max-age=31536000, immutable"
+
+// filesHandler serves the files of commits.
+type filesHandler struct {
+	pfsClient pfs.APIClient
+	marshaler *jsonMarshaler
+}
+
+// parseFilePath returns the file at a files path, which is of the form
+// <repo>/<branch-or-commit>/<path>.
+func parseFilePath(p string) (*pfs.File, error) {
+	parts := strings.SplitN(strings.TrimPrefix(p, FilesPrefix+"/"), "/", 3)
+	if len(parts) < 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, errors.Errorf("path must be %s/<repo>/<branch-or-commit>/<path>", FilesPrefix)
+	}
+	if uuid.IsUUIDWithoutDashes(parts[1]) {
+		return client.NewCommit(parts[0], "", parts[1]).NewFile(parts[2]), nil
+	}
+	return client.NewCommit(parts[0], parts[1], "").NewFile(parts[2]), nil
+}
+
+func (h *filesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	file, err := parseFilePath(r.URL.Path)
+	if err != nil {
+		h.writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+		return
+	}
+	ctx, cancel := context.WithCancel(requestContext(r))
+	defer cancel()
+	fileInfo, err := h.pfsClient.InspectFile(ctx, &pfs.InspectFileRequest{File: file})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	if fileInfo.FileType != pfs.FileType_FILE {
+		h.writeError(w, status.Errorf(codes.InvalidArgument, "%s is a directory", file.Path))
+		return
+	}
+	header := w.Header()
+	contentType := fileInfo.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(path.Ext(fileInfo.File.Path))
+	}
+	// If the content type still isn't known, ServeContent sniffs it.
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	header.Set("ETag", fmt.Sprintf("%q", hex.EncodeToString(fileInfo.Hash)))
+	if file.Commit.ID != "" {
+		header.Set("Cache-Control", immutableCacheControl)
+	} else {
+		header.Set("Cache-Control", "private, no-cache")
+	}
+	// The files are user content, so they can't run scripts in the
+	// gateway's origin, and browsers must trust their content type.
+	header.Set("Content-Security-Policy", "sandbox")
+	header.Set("X-Content-Type-Options", "nosniff")
+	if r.URL.Query().Get("download") == "true" {
+		header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(fileInfo.File.Path)}))
+	}
+	var modified time.Time
+	if t := timestamp(fileInfo.Committed); t != nil {
+		modified = *t
+	}
+	content := &fileContent{ctx: ctx, pfsClient: h.pfsClient, file: fileInfo.File, size: fileInfo.SizeBytes}
+	defer content.stop()
+	http.ServeContent(w, r, path.Base(fileInfo.File.Path), modified, content)
+}
+
+func (h *filesHandler) writeError(w http.ResponseWriter, err error) {
+	code, body := errorResponse(h.marshaler, err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body) //nolint:errcheck
+}
+
+// fileContent is an io.ReadSeeker over a file in PFS. Seeking is free, and
+// reading streams the file from the current offset, so serving a range only
+// downloads that range.
+type fileContent struct {
+	ctx       context.Context
+	pfsClient pfs.APIClient
+	file      *pfs.File
+	size      int64
+	offset    int64
+	r         io.Reader
+	cancel    context.CancelFunc
+}
+
+func (c *fileContent) Read(data []byte) (int, error) {
+	if c.offset >= c.size {
+		return 0, io.EOF
+	}
+	if c.r == nil {
+		ctx, cancel := context.WithCancel(c.ctx)
+		getFileClient, err := c.pfsClient.GetFile(ctx, &pfs.GetFileRequest{File: c.file, Offset: c.offset})
+		if err != nil {
+			cancel()
+			return 0, errors.EnsureStack(err)
+		}
+		c.r, c.cancel = grpcutil.NewStreamingBytesReader(getFileClient, nil), cancel
+	}
+	n, err := c.r.Read(data)
+	c.offset += int64(n)
+	if errors.Is(err, io.EOF) {
+		return n, io.EOF
+	}
+	return n, errors.EnsureStack(err)
+}
+
+func (c *fileContent) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += c.offset
+	case io.SeekEnd:
+		offset += c.size
+	default:
+		return 0, errors.Errorf("invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, errors.Errorf("invalid offset %d", offset)
+	}
+	if offset != c.offset {
+		c.stop()
+		c.offset = offset
+	}
+	return offset, nil
+}
+
+// stop stops streaming the file, which is streamed again from the current
+// offset by the next read.
+func (c *fileContent) stop() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.r, c.cancel = nil, nil
+}
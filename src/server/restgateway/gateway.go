@@ -0,0 +1,125 @@
+// Package restgateway serves the PFS, PPS and auth APIs as JSON over HTTP, for
+// clients that can't use gRPC. Each RPC is served at
+// POST /api/<package>.<service>/<method>, e.g. /api/pfs_v2.API/InspectRepo,
+// with the JSON encoding of its request as the body. The responses of
+// streaming RPCs are sequences of newline-delimited JSON objects. The OpenAPI
+// specs of the APIs are served at /api/openapi/<package>.swagger.json,
+// changes to commits, jobs and pipelines are streamed as server-sent events
+// from /api/events, a stable API for orchestration tools is served under
+// /api/orchestration/v1, and the files of commits are served under
+// /api/files.
+package restgateway
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// openAPISpecs are generated from the protos along with the *.pb.gw.go files,
+// by etc/proto/run.sh.
+//go:embed openapi/*/*.swagger.json
+var openAPISpecs embed.FS
+
+// Prefix is the path under which the gateway serves the APIs.
+const Prefix = "/api"
+
+// tokenHeader is the HTTP header that holds a Pachyderm auth token, as an
+// alternative to "Authorization: Bearer <token>".
+var tokenHeader = textproto.CanonicalMIMEHeaderKey(auth.ContextTokenKey)
+
+// headerMatcher forwards the auth token header to the RPCs, along with the
+// headers that the gateway forwards by default.
+func headerMatcher(key string) (string, bool) {
+	if textproto.CanonicalMIMEHeaderKey(key) == tokenHeader {
+		return auth.ContextTokenKey, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// bearerToken forwards the token in a request's Authorization header, if it
+// holds one, to the RPC as its auth token.
+func bearerToken(_ context.Context, r *http.Request) metadata.MD {
+	const prefix = "bearer "
+	authorization := r.Header.Get("Authorization")
+	if len(authorization) <= len(prefix) || !strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return nil
+	}
+	if r.Header.Get(tokenHeader) != "" {
+		// don't send the RPC two tokens
+		return nil
+	}
+	return metadata.Pairs(auth.ContextTokenKey, strings.TrimSpace(authorization[len(prefix):]))
+}
+
+// NewHandler returns an http.Handler that serves the gateway by forwarding
+// requests to the given API clients.
+func NewHandler(ctx context.Context, pfsClient pfs.APIClient, ppsClient pps.APIClient, authClient auth.APIClient, adminClient admin.APIClient) (http.Handler, error) {
+	marshaler := newJSONMarshaler()
+	gateway := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, marshaler),
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+		runtime.WithMetadata(bearerToken),
+	)
+	if err := pfs.RegisterAPIHandlerClient(ctx, gateway, pfsClient); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := pps.RegisterAPIHandlerClient(ctx, gateway, ppsClient); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := auth.RegisterAPIHandlerClient(ctx, gateway, authClient); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	specs, err := fs.Sub(openAPISpecs, "openapi")
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	// the specs are flattened from openapi/<package>/<package>.swagger.json to
+	// /api/openapi/<package>.swagger.json
+	specServer := http.FileServer(http.FS(specs))
+	mux := http.NewServeMux()
+	mux.Handle(Prefix+"/openapi/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, Prefix+"/openapi/")
+		if !strings.HasSuffix(name, ".swagger.json") || strings.Contains(name, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		r2 := r.Clone(r.Context())
+		r2.URL.Path = "/" + strings.TrimSuffix(name, ".swagger.json") + "/" + name
+		specServer.ServeHTTP(w, r2)
+	}))
+	mux.Handle(Prefix+"/events", eventsHandler(adminClient, marshaler))
+	mux.Handle(OrchestrationPrefix+"/", newOrchestrationHandler(pfsClient, ppsClient, marshaler))
+	mux.Handle(FilesPrefix+"/", &filesHandler{pfsClient: pfsClient, marshaler: marshaler})
+	mux.Handle(Prefix+"/", http.StripPrefix(Prefix, gateway))
+	return mux, nil
+}
+
+// Server returns an HTTP server on port that serves handler, logging each
+// request.
+func Server(port uint16, handler http.Handler) *http.Server {
+	logger := logrus.WithFields(logrus.Fields{
+		"source": "restgateway",
+	})
+	return &http.Server{
+		Addr: fmt.Sprintf(":%d", port),
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger.Debugf("http request: %s %s", r.Method, r.RequestURI)
+			handler.ServeHTTP(w, r)
+		}),
+	}
+}
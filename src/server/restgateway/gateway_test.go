@@ -0,0 +1,418 @@
+package restgateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/pachyderm/pachyderm/v2/src/admin"
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+type testPFSClient struct {
+	pfs.APIClient
+	created time.Time
+}
+
+func (c *testPFSClient) InspectRepo(ctx context.Context, req *pfs.InspectRepoRequest, _ ...grpc.CallOption) (*pfs.RepoInfo, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if tokens := md.Get(auth.ContextTokenKey); len(tokens) != 1 || tokens[0] != "token" {
+		return nil, status.Errorf(codes.Unauthenticated, "no token, got %v", tokens)
+	}
+	if req.Repo.Name != "images" {
+		return nil, status.Errorf(codes.NotFound, "repo %s not found", req.Repo.Name)
+	}
+	created, _ := types.TimestampProto(c.created)
+	return &pfs.RepoInfo{Repo: req.Repo, Created: created, SizeBytesUpperBound: 10}, nil
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	created := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	h, err := NewHandler(context.Background(), &testPFSClient{created: created}, pps.NewAPIClient(nil), auth.NewAPIClient(nil), admin.NewAPIClient(nil))
+	require.NoError(t, err)
+	s := httptest.NewServer(h)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func post(t *testing.T, url, header, value, body string) (int, map[string]interface{}) {
+	req, err := http.NewRequest("POST", url, strings.NewReader(body))
+	require.NoError(t, err)
+	if header != "" {
+		req.Header.Set(header, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	result := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(data, &result), string(data))
+	return resp.StatusCode, result
+}
+
+func TestInspectRepo(t *testing.T) {
+	s := newTestServer(t)
+	url := s.URL + "/api/pfs_v2.API/InspectRepo"
+	body := `{"repo": {"name": "images", "type": "user"}}`
+
+	for _, header := range []string{"Authorization", "Authn-Token"} {
+		value := "token"
+		if header == "Authorization" {
+			value = "Bearer token"
+		}
+		code, result := post(t, url, header, value, body)
+		require.Equal(t, http.StatusOK, code, result)
+		require.Equal(t, "images", result["repo"].(map[string]interface{})["name"])
+		// gogo's timestamps are encoded as RFC 3339 strings
+		require.Equal(t, "2022-01-02T03:04:05Z", result["created"])
+		require.Equal(t, "10", result["size_bytes_upper_bound"])
+	}
+
+	code, _ := post(t, url, "", "", body)
+	require.Equal(t, http.StatusUnauthorized, code)
+	code, result := post(t, url, "Authorization", "Bearer token", `{"repo": {"name": "videos"}}`)
+	require.Equal(t, http.StatusNotFound, code)
+	require.Equal(t, "repo videos not found", result["message"])
+}
+
+func TestOpenAPISpecs(t *testing.T) {
+	s := newTestServer(t)
+	for _, pkg := range []string{"pfs", "pps", "auth"} {
+		resp, err := http.Get(s.URL + "/api/openapi/" + pkg + ".swagger.json")
+		require.NoError(t, err)
+		spec := make(map[string]interface{})
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&spec))
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "2.0", spec["swagger"])
+	}
+	resp, err := http.Get(s.URL + "/api/openapi/pfs/pfs.swagger.json")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+type testAdminClient struct {
+	admin.APIClient
+	requests chan *admin.SubscribeEventsRequest
+	tokens   chan string
+}
+
+type testEventsClient struct {
+	grpc.ClientStream
+	events []*admin.Event
+}
+
+func (c *testEventsClient) Recv() (*admin.Event, error) {
+	if len(c.events) == 0 {
+		return nil, status.Errorf(codes.Unavailable, "the subscription ended")
+	}
+	event := c.events[0]
+	c.events = c.events[1:]
+	return event, nil
+}
+
+func (c *testAdminClient) SubscribeEvents(ctx context.Context, req *admin.SubscribeEventsRequest, _ ...grpc.CallOption) (admin.API_SubscribeEventsClient, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	c.requests <- req
+	c.tokens <- strings.Join(md.Get(auth.ContextTokenKey), ",")
+	return &testEventsClient{events: []*admin.Event{
+		{Cursor: "1.2.3", Kind: admin.EventKind_COMMIT, Commit: &pfs.CommitInfo{Commit: client.NewRepo("images").NewCommit("master", "abc")}},
+		{Cursor: "1.4.3", Kind: admin.EventKind_JOB, Job: &pps.JobInfo{Job: client.NewJob("edges", "def"), State: pps.JobState_JOB_SUCCESS}},
+	}}, nil
+}
+
+func TestEvents(t *testing.T) {
+	adminClient := &testAdminClient{requests: make(chan *admin.SubscribeEventsRequest, 1), tokens: make(chan string, 1)}
+	h, err := NewHandler(context.Background(), &testPFSClient{}, pps.NewAPIClient(nil), auth.NewAPIClient(nil), adminClient)
+	require.NoError(t, err)
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	req, err := http.NewRequest("GET", s.URL+"/api/events?kind=commit&kind=JOB&repo=images&pipeline=edges&cursor=0.0.0&token=token", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", "1.1.1")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	require.Equal(t, &admin.SubscribeEventsRequest{
+		Kinds:     []admin.EventKind{admin.EventKind_COMMIT, admin.EventKind_JOB},
+		Repos:     []string{"images"},
+		Pipelines: []string{"edges"},
+		Cursor:    "1.1.1",
+	}, <-adminClient.requests)
+	require.Equal(t, "token", <-adminClient.tokens)
+
+	frames := strings.Split(strings.TrimSpace(string(body)), "\n\n")
+	require.Equal(t, 3, len(frames), string(body))
+	require.True(t, strings.HasPrefix(frames[0], "id: 1.2.3\nevent: commit\ndata: {"), frames[0])
+	require.True(t, strings.Contains(frames[0], `"id":"abc"`), frames[0])
+	require.True(t, strings.HasPrefix(frames[1], "id: 1.4.3\nevent: job\ndata: {"), frames[1])
+	require.True(t, strings.Contains(frames[1], `"state":"JOB_SUCCESS"`), frames[1])
+	require.Equal(t, `event: error`+"\n"+`data: {"code":14,"message":"the subscription ended"}`, frames[2])
+
+	resp, err = http.Get(s.URL + "/api/events?kind=branch")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+type testPPSClient struct {
+	pps.APIClient
+	mu      sync.Mutex
+	updates int
+}
+
+func (c *testPPSClient) InspectPipeline(ctx context.Context, req *pps.InspectPipelineRequest, _ ...grpc.CallOption) (*pps.PipelineInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if req.Pipeline.Name != "edges" {
+		return nil, status.Errorf(codes.NotFound, "pipeline %s not found", req.Pipeline.Name)
+	}
+	return &pps.PipelineInfo{
+		Pipeline:   req.Pipeline,
+		SpecCommit: client.NewSystemRepo("edges", pfs.SpecRepoType).NewCommit("master", fmt.Sprintf("job%d", c.updates)),
+		Details: &pps.PipelineInfo_Details{
+			Input: &pps.Input{Pfs: &pps.PFSInput{Repo: "images", Glob: "/*"}},
+		},
+	}, nil
+}
+
+func (c *testPPSClient) CreatePipeline(ctx context.Context, req *pps.CreatePipelineRequest, _ ...grpc.CallOption) (*types.Empty, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !req.Update || req.Input.Pfs.Repo != "images" {
+		return nil, status.Errorf(codes.InvalidArgument, "unexpected request %v", req)
+	}
+	c.updates++
+	return &types.Empty{}, nil
+}
+
+func (c *testPPSClient) InspectJob(ctx context.Context, req *pps.InspectJobRequest, _ ...grpc.CallOption) (*pps.JobInfo, error) {
+	switch {
+	case strings.HasPrefix(req.Job.ID, "job"):
+		return &pps.JobInfo{Job: req.Job, State: pps.JobState_JOB_SUCCESS, DataTotal: 2, DataProcessed: 2}, nil
+	case req.Job.ID == "running":
+		if req.Wait {
+			<-ctx.Done()
+			return nil, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+		}
+		return &pps.JobInfo{Job: req.Job, State: pps.JobState_JOB_RUNNING}, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "job %s not found", req.Job.ID)
+}
+
+func (c *testPFSClient) InspectCommit(ctx context.Context, req *pfs.InspectCommitRequest, _ ...grpc.CallOption) (*pfs.CommitInfo, error) {
+	commit := req.Commit.Branch.Repo.NewCommit(req.Commit.Branch.Name, "abc")
+	finished := types.TimestampNow()
+	return &pfs.CommitInfo{Commit: commit, Finished: finished, Error: "the upload failed"}, nil
+}
+
+func TestOrchestration(t *testing.T) {
+	ppsClient := &testPPSClient{}
+	h, err := NewHandler(context.Background(), &testPFSClient{}, ppsClient, auth.NewAPIClient(nil), admin.NewAPIClient(nil))
+	require.NoError(t, err)
+	s := httptest.NewServer(h)
+	defer s.Close()
+	url := s.URL + OrchestrationPrefix
+
+	// retrying a run with the same idempotency key doesn't start another job
+	for i := 0; i < 2; i++ {
+		code, result := post(t, url+"/pipelines/edges/run", idempotencyKeyHeader, "run-1", "")
+		require.Equal(t, http.StatusOK, code, result)
+		require.Equal(t, "job1", result["job"])
+		require.Equal(t, "JOB_SUCCESS", result["state"])
+		require.Equal(t, true, result["terminal"])
+		require.Equal(t, "success", result["outcome"])
+		require.Equal(t, float64(2), result["data_processed"])
+	}
+	require.Equal(t, 1, ppsClient.updates)
+	req, err := http.NewRequest("POST", url+"/pipelines/edges/run", nil)
+	require.NoError(t, err)
+	req.Header.Set(idempotencyKeyHeader, "run-1")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, "true", resp.Header.Get("Idempotent-Replayed"))
+	code, result := post(t, url+"/pipelines/edges/run", idempotencyKeyHeader, "run-2", "")
+	require.Equal(t, http.StatusOK, code, result)
+	require.Equal(t, "job2", result["job"])
+	require.Equal(t, 2, ppsClient.updates)
+	code, result = post(t, url+"/pipelines/faces/run", "", "", "")
+	require.Equal(t, http.StatusNotFound, code)
+	require.Equal(t, "pipeline faces not found", result["message"])
+
+	get := func(path string) (int, map[string]interface{}) {
+		resp, err := http.Get(url + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		result := make(map[string]interface{})
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return resp.StatusCode, result
+	}
+	// a wait that times out returns the job's current state
+	code, result = get("/pipelines/edges/jobs/running/wait?timeout=100ms")
+	require.Equal(t, http.StatusOK, code, result)
+	require.Equal(t, "JOB_RUNNING", result["state"])
+	require.Equal(t, false, result["terminal"])
+	_, ok := result["outcome"]
+	require.False(t, ok)
+	code, _ = get("/pipelines/edges/jobs/running/wait?timeout=1h")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, result = get("/repos/images/branches/master/wait?timeout=5")
+	require.Equal(t, http.StatusOK, code, result)
+	require.Equal(t, "abc", result["commit"])
+	require.Equal(t, true, result["finished"])
+	require.Equal(t, "failure", result["outcome"])
+	require.Equal(t, "the upload failed", result["error"])
+}
+
+type testFilesClient struct {
+	pfs.APIClient
+	content string
+	offsets []int64
+}
+
+func (c *testFilesClient) InspectFile(ctx context.Context, req *pfs.InspectFileRequest, _ ...grpc.CallOption) (*pfs.FileInfo, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if tokens := md.Get(auth.ContextTokenKey); len(tokens) != 1 || tokens[0] != "token" {
+		return nil, status.Errorf(codes.Unauthenticated, "no token, got %v", tokens)
+	}
+	commit := client.NewCommit("reports", "master", "abc")
+	committed, _ := types.TimestampProto(time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC))
+	switch req.File.Path {
+	case "plots/loss.png":
+		return &pfs.FileInfo{
+			File:        commit.NewFile("/plots/loss.png"),
+			FileType:    pfs.FileType_FILE,
+			Committed:   committed,
+			SizeBytes:   int64(len(c.content)),
+			Hash:        []byte{0xab, 0xcd},
+			ContentType: "image/png",
+		}, nil
+	case "plots":
+		return &pfs.FileInfo{File: commit.NewFile("/plots/"), FileType: pfs.FileType_DIR}, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "file %s not found", req.File.Path)
+}
+
+type testGetFileClient struct {
+	grpc.ClientStream
+	data []byte
+}
+
+func (c *testGetFileClient) Recv() (*types.BytesValue, error) {
+	if len(c.data) == 0 {
+		return nil, io.EOF
+	}
+	// send the content in small messages, so that ranges end mid-stream
+	n := 4
+	if len(c.data) < n {
+		n = len(c.data)
+	}
+	value := &types.BytesValue{Value: c.data[:n]}
+	c.data = c.data[n:]
+	return value, nil
+}
+
+func (c *testFilesClient) GetFile(ctx context.Context, req *pfs.GetFileRequest, _ ...grpc.CallOption) (pfs.API_GetFileClient, error) {
+	if req.File.Commit.ID != "abc" {
+		return nil, status.Errorf(codes.InvalidArgument, "the file must be read from the inspected commit")
+	}
+	c.offsets = append(c.offsets, req.Offset)
+	return &testGetFileClient{data: []byte(c.content[req.Offset:])}, nil
+}
+
+func TestFiles(t *testing.T) {
+	pfsClient := &testFilesClient{content: "0123456789abcdefghij"}
+	h, err := NewHandler(context.Background(), pfsClient, pps.NewAPIClient(nil), auth.NewAPIClient(nil), admin.NewAPIClient(nil))
+	require.NoError(t, err)
+	s := httptest.NewServer(h)
+	defer s.Close()
+	url := s.URL + FilesPrefix
+
+	get := func(path string, headers ...string) (*http.Response, string) {
+		req, err := http.NewRequest("GET", url+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer token")
+		for i := 0; i < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(body)
+	}
+
+	resp, body := get("/reports/master/plots/loss.png")
+	require.Equal(t, http.StatusOK, resp.StatusCode, body)
+	require.Equal(t, pfsClient.content, body)
+	require.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+	require.Equal(t, `"abcd"`, resp.Header.Get("ETag"))
+	require.Equal(t, "private, no-cache", resp.Header.Get("Cache-Control"))
+	require.Equal(t, "bytes", resp.Header.Get("Accept-Ranges"))
+	require.Equal(t, "Sun, 02 Jan 2022 03:04:05 GMT", resp.Header.Get("Last-Modified"))
+
+	// a range is streamed from its offset
+	pfsClient.offsets = nil
+	resp, body = get("/reports/master/plots/loss.png", "Range", "bytes=10-14")
+	require.Equal(t, http.StatusPartialContent, resp.StatusCode, body)
+	require.Equal(t, "abcde", body)
+	require.Equal(t, "bytes 10-14/20", resp.Header.Get("Content-Range"))
+	require.Equal(t, []int64{10}, pfsClient.offsets)
+
+	// the file isn't read if the client's copy is current
+	pfsClient.offsets = nil
+	resp, _ = get("/reports/master/plots/loss.png", "If-None-Match", `"abcd"`)
+	require.Equal(t, http.StatusNotModified, resp.StatusCode)
+	require.Equal(t, 0, len(pfsClient.offsets))
+
+	// files of commits are immutable
+	resp, _ = get("/reports/" + uuid.NewWithoutDashes() + "/plots/loss.png?download=true")
+	require.Equal(t, immutableCacheControl, resp.Header.Get("Cache-Control"))
+	require.Equal(t, "attachment; filename=loss.png", resp.Header.Get("Content-Disposition"))
+
+	resp, _ = get("/reports/master/plots")
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, _ = get("/reports/master/missing.png")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp, _ = get("/reports/master")
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// the token can be passed as a query parameter, for <img> tags
+	resp, err = http.Get(url + "/reports/master/plots/loss.png?token=token")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp, err = http.Get(url + "/reports/master/plots/loss.png")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,119 @@
+package restgateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// jsonMarshaler is the runtime.Marshaler of the gateway. The gateway's own
+// JSONPb marshaler uses golang/protobuf's jsonpb, which doesn't know how to
+// encode gogo's well-known types (e.g. a types.Timestamp is encoded as an
+// object rather than an RFC 3339 string), so Pachyderm's messages are encoded
+// with gogo's jsonpb instead. The messages that the gateway itself creates,
+// such as errors, are golang/protobuf messages and are left to JSONPb.
+type jsonMarshaler struct {
+	marshaler   *jsonpb.Marshaler
+	unmarshaler *jsonpb.Unmarshaler
+	fallback    *runtime.JSONPb
+}
+
+func newJSONMarshaler() *jsonMarshaler {
+	return &jsonMarshaler{
+		// OrigName matches the field names in the OpenAPI specs and in the
+		// output of 'pachctl ... --raw'
+		marshaler:   &jsonpb.Marshaler{OrigName: true},
+		unmarshaler: &jsonpb.Unmarshaler{AllowUnknownFields: true},
+		fallback:    &runtime.JSONPb{OrigName: true},
+	}
+}
+
+// isGolangMessage returns true if v is generated by golang/protobuf (i.e.
+// implements its v2 API), rather than by gogo.
+func isGolangMessage(v interface{}) bool {
+	_, ok := v.(interface{ ProtoReflect() protoreflect.Message })
+	return ok
+}
+
+func (m *jsonMarshaler) ContentType() string {
+	return "application/json"
+}
+
+func (m *jsonMarshaler) Marshal(v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case proto.Message:
+		if isGolangMessage(v) {
+			return m.fallback.Marshal(v) //nolint:wrapcheck
+		}
+		var buf bytes.Buffer
+		if err := m.marshaler.Marshal(&buf, v); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		return buf.Bytes(), nil
+	case map[string]interface{}:
+		// streaming responses are wrapped in {"result": <message>}
+		result := make(map[string]json.RawMessage)
+		for k, x := range v {
+			data, err := m.Marshal(x)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = data
+		}
+		data, err := json.Marshal(result)
+		return data, errors.EnsureStack(err)
+	case map[string]proto.Message:
+		// streaming errors are wrapped in {"error": <message>}
+		result := make(map[string]interface{})
+		for k, x := range v {
+			result[k] = x
+		}
+		return m.Marshal(result)
+	default:
+		return m.fallback.Marshal(v) //nolint:wrapcheck
+	}
+}
+
+func (m *jsonMarshaler) Unmarshal(data []byte, v interface{}) error {
+	if msg, ok := v.(proto.Message); ok && !isGolangMessage(v) {
+		return errors.EnsureStack(m.unmarshaler.Unmarshal(bytes.NewReader(data), msg))
+	}
+	return m.fallback.Unmarshal(data, v) //nolint:wrapcheck
+}
+
+// NewDecoder returns a Decoder for the bodies of requests to client-streaming
+// RPCs, which hold a sequence of JSON messages.
+func (m *jsonMarshaler) NewDecoder(r io.Reader) runtime.Decoder {
+	d := json.NewDecoder(r)
+	return runtime.DecoderFunc(func(v interface{}) error {
+		var data json.RawMessage
+		if err := d.Decode(&data); err != nil {
+			return err //nolint:wrapcheck
+		}
+		return m.Unmarshal(data, v)
+	})
+}
+
+func (m *jsonMarshaler) NewEncoder(w io.Writer) runtime.Encoder {
+	return runtime.EncoderFunc(func(v interface{}) error {
+		data, err := m.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(append(data, '\n')); err != nil {
+			return errors.EnsureStack(err)
+		}
+		return nil
+	})
+}
+
+func (m *jsonMarshaler) Delimiter() []byte {
+	return []byte("\n")
+}
@@ -0,0 +1,445 @@
+package restgateway
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// OrchestrationPrefix is the path under which the orchestration API is
+// served. Unlike the gateway, whose paths and messages follow the protos, the
+// orchestration API's paths and responses are kept stable across releases, so
+// that operators for orchestration tools (e.g. Airflow or Dagster) can rely on
+// them:
+//
+//	POST /api/orchestration/v1/pipelines/<pipeline>/run[?reprocess=true]
+//	GET  /api/orchestration/v1/pipelines/<pipeline>/jobs/<job>
+//	GET  /api/orchestration/v1/pipelines/<pipeline>/jobs/<job>/wait[?timeout=<duration>]
+//	GET  /api/orchestration/v1/repos/<repo>/branches/<branch>/wait[?commit=<id>&timeout=<duration>]
+//
+// Runs may carry an Idempotency-Key header, and a retried run with the same
+// key gets the response of the first rather than starting another job. Waits
+// are long polls, which return when the job or commit finishes or the timeout
+// passes, whichever is first.
+const OrchestrationPrefix = Prefix + "/orchestration/v1"
+
+const (
+	// defaultWaitTimeout is how long a wait lasts if it doesn't set a timeout.
+	defaultWaitTimeout = 30 * time.Second
+	// maxWaitTimeout is the longest a wait can last, as proxies tend to close
+	// requests that take much longer.
+	maxWaitTimeout = 5 * time.Minute
+	// idempotencyKeyHeader is the header that holds a run's idempotency key.
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotencyTTL is how long the response to a run with an idempotency
+	// key is kept.
+	idempotencyTTL = 24 * time.Hour
+)
+
+// jobStatus is the response to runs and job requests.
+type jobStatus struct {
+	Pipeline string `json:"pipeline"`
+	Job      string `json:"job"`
+	// State is the job's pps.JobState, e.g. "JOB_RUNNING".
+	State string `json:"state"`
+	// Terminal is true once the job can't change state again.
+	Terminal bool `json:"terminal"`
+	// Outcome is set once the job is terminal, to one of "success",
+	// "failure", "killed", "unrunnable" or "budget_exceeded".
+	Outcome       string     `json:"outcome,omitempty"`
+	Reason        string     `json:"reason,omitempty"`
+	Started       *time.Time `json:"started,omitempty"`
+	Finished      *time.Time `json:"finished,omitempty"`
+	DataTotal     int64      `json:"data_total"`
+	DataProcessed int64      `json:"data_processed"`
+	DataSkipped   int64      `json:"data_skipped"`
+	DataFailed    int64      `json:"data_failed"`
+}
+
+// jobOutcomes are the outcomes of the terminal job states.
+var jobOutcomes = map[pps.JobState]string{
+	pps.JobState_JOB_SUCCESS:         "success",
+	pps.JobState_JOB_FAILURE:         "failure",
+	pps.JobState_JOB_KILLED:          "killed",
+	pps.JobState_JOB_UNRUNNABLE:      "unrunnable",
+	pps.JobState_JOB_BUDGET_EXCEEDED: "budget_exceeded",
+}
+
+func newJobStatus(jobInfo *pps.JobInfo) *jobStatus {
+	s := &jobStatus{
+		Pipeline:      jobInfo.Job.Pipeline.Name,
+		Job:           jobInfo.Job.ID,
+		State:         jobInfo.State.String(),
+		Outcome:       jobOutcomes[jobInfo.State],
+		Reason:        jobInfo.Reason,
+		Started:       timestamp(jobInfo.Started),
+		Finished:      timestamp(jobInfo.Finished),
+		DataTotal:     jobInfo.DataTotal,
+		DataProcessed: jobInfo.DataProcessed,
+		DataSkipped:   jobInfo.DataSkipped,
+		DataFailed:    jobInfo.DataFailed,
+	}
+	s.Terminal = s.Outcome != ""
+	return s
+}
+
+// commitStatus is the response to commit waits.
+type commitStatus struct {
+	Repo   string `json:"repo"`
+	Branch string `json:"branch"`
+	Commit string `json:"commit"`
+	// Finished is true once the commit can't change again.
+	Finished bool `json:"finished"`
+	// Outcome is set once the commit is finished, to "success", or "failure"
+	// if the commit finished with an error.
+	Outcome string `json:"outcome,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func newCommitStatus(commitInfo *pfs.CommitInfo) *commitStatus {
+	s := &commitStatus{
+		Repo:     commitInfo.Commit.Branch.Repo.Name,
+		Branch:   commitInfo.Commit.Branch.Name,
+		Commit:   commitInfo.Commit.ID,
+		Finished: commitInfo.Finished != nil,
+		Error:    commitInfo.Error,
+	}
+	switch {
+	case !s.Finished:
+	case s.Error != "":
+		s.Outcome = "failure"
+	default:
+		s.Outcome = "success"
+	}
+	return s
+}
+
+func timestamp(ts *types.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t, err := types.TimestampFromProto(ts)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// idempotentResponse is the response to a run with an idempotency key. done
+// is closed once the response has been written.
+type idempotentResponse struct {
+	done    chan struct{}
+	code    int
+	body    []byte
+	expires time.Time
+}
+
+// idempotencyCache holds the responses to runs with idempotency keys. It's
+// per pachd, so retries must reach the same pachd, which they do in the
+// default deployment of a single pachd.
+type idempotencyCache struct {
+	mu        sync.Mutex
+	responses map[string]*idempotentResponse
+}
+
+// do returns the cached response for key if there is one, waiting for it if
+// it's in progress, or otherwise calls f and caches its response if it's
+// successful. replayed is true if the response was cached.
+func (c *idempotencyCache) do(key string, f func() (int, []byte)) (code int, body []byte, replayed bool) {
+	now := time.Now()
+	c.mu.Lock()
+	for k, r := range c.responses {
+		if !r.expires.IsZero() && now.After(r.expires) {
+			delete(c.responses, k)
+		}
+	}
+	if r, ok := c.responses[key]; ok {
+		c.mu.Unlock()
+		<-r.done
+		if r.code != 0 {
+			return r.code, r.body, true
+		}
+		// the first request failed, so this one is tried afresh
+		return c.do(key, f)
+	}
+	r := &idempotentResponse{done: make(chan struct{})}
+	c.responses[key] = r
+	c.mu.Unlock()
+
+	code, body = f()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if code >= 200 && code < 300 {
+		r.code, r.body, r.expires = code, body, time.Now().Add(idempotencyTTL)
+	} else {
+		delete(c.responses, key)
+	}
+	close(r.done)
+	return code, body, false
+}
+
+// orchestrationHandler serves the orchestration API.
+type orchestrationHandler struct {
+	pfsClient   pfs.APIClient
+	ppsClient   pps.APIClient
+	marshaler   *jsonMarshaler
+	idempotency *idempotencyCache
+}
+
+func newOrchestrationHandler(pfsClient pfs.APIClient, ppsClient pps.APIClient, marshaler *jsonMarshaler) *orchestrationHandler {
+	return &orchestrationHandler{
+		pfsClient:   pfsClient,
+		ppsClient:   ppsClient,
+		marshaler:   marshaler,
+		idempotency: &idempotencyCache{responses: make(map[string]*idempotentResponse)},
+	}
+}
+
+func (h *orchestrationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, OrchestrationPrefix+"/"), "/")
+	method, wait := http.MethodGet, false
+	var serve func(ctx context.Context) (interface{}, error)
+	switch {
+	case len(parts) == 3 && parts[0] == "pipelines" && parts[2] == "run":
+		method = http.MethodPost
+		serve = func(ctx context.Context) (interface{}, error) {
+			return h.run(ctx, parts[1], r.URL.Query().Get("reprocess") == "true")
+		}
+	case len(parts) == 4 && parts[0] == "pipelines" && parts[2] == "jobs":
+		serve = func(ctx context.Context) (interface{}, error) {
+			return h.inspectJob(ctx, parts[1], parts[3], false)
+		}
+	case len(parts) == 5 && parts[0] == "pipelines" && parts[2] == "jobs" && parts[4] == "wait":
+		wait = true
+		serve = func(ctx context.Context) (interface{}, error) {
+			return h.inspectJob(ctx, parts[1], parts[3], true)
+		}
+	case len(parts) == 5 && parts[0] == "repos" && parts[2] == "branches" && parts[4] == "wait":
+		wait = true
+		serve = func(ctx context.Context) (interface{}, error) {
+			return h.waitCommit(ctx, parts[1], parts[3], r.URL.Query().Get("commit"))
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := requestContext(r)
+	if wait {
+		timeout, err := waitTimeout(r)
+		if err != nil {
+			h.writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	respond := func() (int, []byte) {
+		result, err := serve(ctx)
+		if err != nil {
+			return h.errorResponse(err)
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return h.errorResponse(err)
+		}
+		return http.StatusOK, data
+	}
+	var code int
+	var body []byte
+	if key := r.Header.Get(idempotencyKeyHeader); key != "" && method == http.MethodPost {
+		var replayed bool
+		code, body, replayed = h.idempotency.do(idempotencyScope(ctx, r.URL.Path, key), respond)
+		if replayed {
+			w.Header().Set("Idempotent-Replayed", "true")
+		}
+	} else {
+		code, body = respond()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body) //nolint:errcheck
+}
+
+// idempotencyScope scopes an idempotency key to the caller's token and the
+// path it was sent to, so that callers can't see each others' responses.
+func idempotencyScope(ctx context.Context, path, key string) string {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	token := sha256.Sum256([]byte(strings.Join(md.Get(auth.ContextTokenKey), ",")))
+	return hex.EncodeToString(token[:]) + "\x00" + path + "\x00" + key
+}
+
+// waitTimeout returns the timeout of a wait, which is set by the timeout query
+// parameter as a duration (e.g. "90s") or a number of seconds.
+func waitTimeout(r *http.Request) (time.Duration, error) {
+	param := r.URL.Query().Get("timeout")
+	if param == "" {
+		return defaultWaitTimeout, nil
+	}
+	timeout, err := time.ParseDuration(param)
+	if err != nil {
+		seconds, serr := strconv.ParseUint(param, 10, 32)
+		if serr != nil {
+			return 0, errors.Errorf("invalid timeout %q, must be a duration such as 90s", param)
+		}
+		timeout = time.Duration(seconds) * time.Second
+	}
+	if timeout <= 0 || timeout > maxWaitTimeout {
+		return 0, errors.Errorf("timeout must be positive and at most %v", maxWaitTimeout)
+	}
+	return timeout, nil
+}
+
+// run starts a job of the pipeline over its current inputs. Pipelines with cron
+// inputs are run by ticking their crons, as RunCron does, and other pipelines
+// are run by updating them with their current spec.
+func (h *orchestrationHandler) run(ctx context.Context, pipeline string, reprocess bool) (*jobStatus, error) {
+	pipelineInfo, err := h.ppsClient.InspectPipeline(ctx, &pps.InspectPipelineRequest{
+		Pipeline: client.NewPipeline(pipeline),
+		Details:  true,
+	})
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	var cronRepo string
+	pps.VisitInput(pipelineInfo.Details.Input, func(in *pps.Input) error { //nolint:errcheck
+		if in.Cron != nil {
+			cronRepo = in.Cron.Repo
+		}
+		return nil
+	})
+	var jobID string
+	if cronRepo != "" {
+		if _, err := h.ppsClient.RunCron(ctx, &pps.RunCronRequest{Pipeline: pipelineInfo.Pipeline}); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		// the last cron to tick makes the last commit, whose job is the one
+		// that runs over all the ticks
+		commitInfo, err := h.pfsClient.InspectCommit(ctx, &pfs.InspectCommitRequest{
+			Commit: client.NewCommit(cronRepo, "master", ""),
+		})
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		jobID = commitInfo.Commit.ID
+	} else {
+		request := ppsutil.PipelineReqFromInfo(pipelineInfo)
+		request.Update = true
+		request.Reprocess = reprocess
+		if _, err := h.ppsClient.CreatePipeline(ctx, request); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		// the job is in the same commit set as the new spec commit
+		pipelineInfo, err = h.ppsClient.InspectPipeline(ctx, &pps.InspectPipelineRequest{
+			Pipeline: pipelineInfo.Pipeline,
+		})
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		jobID = pipelineInfo.SpecCommit.ID
+	}
+	return h.inspectJob(ctx, pipeline, jobID, false)
+}
+
+// inspectJob returns the status of a job, once it's terminal or ctx's deadline
+// passes if wait is true.
+func (h *orchestrationHandler) inspectJob(ctx context.Context, pipeline, id string, wait bool) (*jobStatus, error) {
+	job := client.NewJob(pipeline, id)
+	if wait {
+		jobInfo, err := h.ppsClient.InspectJob(ctx, &pps.InspectJobRequest{Job: job, Wait: true})
+		if err == nil {
+			return newJobStatus(jobInfo), nil
+		}
+		if ctx.Err() == nil && status.Code(err) != codes.DeadlineExceeded {
+			return nil, errors.EnsureStack(err)
+		}
+		// the wait timed out, so the job's current status is returned
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(detachDeadline(ctx), defaultWaitTimeout)
+		defer cancel()
+	}
+	jobInfo, err := h.ppsClient.InspectJob(ctx, &pps.InspectJobRequest{Job: job})
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return newJobStatus(jobInfo), nil
+}
+
+// waitCommit returns the status of a commit to a branch, or of the branch's
+// head if id is empty, once it's finished or ctx's deadline passes.
+func (h *orchestrationHandler) waitCommit(ctx context.Context, repo, branch, id string) (*commitStatus, error) {
+	commit := client.NewCommit(repo, branch, id)
+	commitInfo, err := h.pfsClient.InspectCommit(ctx, &pfs.InspectCommitRequest{
+		Commit: commit,
+		Wait:   pfs.CommitState_FINISHED,
+	})
+	if err == nil {
+		return newCommitStatus(commitInfo), nil
+	}
+	if ctx.Err() == nil && status.Code(err) != codes.DeadlineExceeded {
+		return nil, errors.EnsureStack(err)
+	}
+	// the wait timed out, so the commit's current status is returned
+	ctx, cancel := context.WithTimeout(detachDeadline(ctx), defaultWaitTimeout)
+	defer cancel()
+	commitInfo, err = h.pfsClient.InspectCommit(ctx, &pfs.InspectCommitRequest{Commit: commit})
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return newCommitStatus(commitInfo), nil
+}
+
+// detachDeadline returns a context with ctx's auth token, but without its
+// deadline, for the requests made after a wait times out.
+func detachDeadline(ctx context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	return metadata.NewOutgoingContext(context.Background(), md)
+}
+
+// errorResponse returns the status code and body of the response for err,
+// which match the gateway's.
+func (h *orchestrationHandler) errorResponse(err error) (int, []byte) {
+	return errorResponse(h.marshaler, err)
+}
+
+// errorResponse returns the status code and body of the response for err, for
+// the handlers that aren't served by the gateway itself.
+func errorResponse(marshaler *jsonMarshaler, err error) (int, []byte) {
+	s := status.Convert(err)
+	data, merr := marshaler.Marshal(s.Proto())
+	if merr != nil {
+		data = []byte(`{"code":` + strconv.Itoa(int(s.Code())) + `}`)
+	}
+	return runtime.HTTPStatusFromCode(s.Code()), data
+}
+
+func (h *orchestrationHandler) writeError(w http.ResponseWriter, err error) {
+	code, body := h.errorResponse(err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body) //nolint:errcheck
+}
@@ -0,0 +1,104 @@
+package cmds
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/cmdutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/tabwriter"
+	"github.com/pachyderm/pachyderm/v2/src/search"
+	"github.com/pachyderm/pachyderm/v2/src/server/search/pretty"
+
+	"github.com/spf13/cobra"
+)
+
+// Cmds returns a slice containing search commands.
+func Cmds() []*cobra.Command {
+	var commands []*cobra.Command
+
+	var raw bool
+	var output string
+	outputFlags := cmdutil.OutputFlags(&raw, &output)
+
+	var prefix, regex bool
+	var types []string
+	var pageSize int64
+	var pageToken string
+	searchCmd := &cobra.Command{
+		Use:   "{{alias}} <query>",
+		Short: "Search for repos, files, pipelines and commits.",
+		Long: "Search for the repos, files, pipelines and commits that you can read whose name, path, spec or description contains the query, ignoring case. " +
+			"Files are searched for at the heads of branches, and the search index is updated periodically, so recent changes may not be found yet.",
+		Example: `
+# find anything that mentions "images"
+$ {{alias}} images
+
+# find the files whose path starts with /data/2021
+$ {{alias}} /data/2021 --prefix --type file
+
+# find the commits whose description matches a regex
+$ {{alias}} 'fix(es)? #[0-9]+' --regex --type commit`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			if prefix && regex {
+				return errors.New("cannot set both --prefix and --regex")
+			}
+			mode := search.SearchRequest_SUBSTRING
+			if prefix {
+				mode = search.SearchRequest_PREFIX
+			} else if regex {
+				mode = search.SearchRequest_REGEX
+			}
+			var resultTypes []search.ResultType
+			for _, t := range types {
+				v, ok := search.ResultType_value[strings.ToUpper(t)]
+				if !ok || v == int32(search.ResultType_RESULT_TYPE_UNKNOWN) {
+					return errors.Errorf("unknown type %q, must be one of repo, file, pipeline, commit", t)
+				}
+				resultTypes = append(resultTypes, search.ResultType(v))
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			response, err := c.Search(args[0], mode, resultTypes, pageSize, pageToken)
+			if err != nil {
+				return err
+			}
+			if raw {
+				encoder := cmdutil.Encoder(output, os.Stdout)
+				for _, result := range response.Results {
+					if err := encoder.EncodeProto(result); err != nil {
+						return errors.EnsureStack(err)
+					}
+				}
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			} else {
+				writer := tabwriter.NewWriter(os.Stdout, pretty.SearchResultHeader)
+				for _, result := range response.Results {
+					pretty.PrintSearchResult(writer, result)
+				}
+				if err := writer.Flush(); err != nil {
+					return errors.EnsureStack(err)
+				}
+			}
+			if response.NextPageToken != "" {
+				fmt.Fprintf(os.Stderr, "more results with --page-token %s\n", response.NextPageToken)
+			}
+			return nil
+		}),
+	}
+	searchCmd.Flags().BoolVar(&prefix, "prefix", false, "Match names, paths, specs and descriptions that start with the query.")
+	searchCmd.Flags().BoolVar(&regex, "regex", false, "Match names, paths, specs and descriptions against the query as a regular expression, which is case sensitive.")
+	searchCmd.Flags().StringSliceVar(&types, "type", nil, "Only return results of this type: repo, file, pipeline or commit.")
+	searchCmd.Flags().Int64Var(&pageSize, "page-size", 0, "The number of results to return, 50 if unset.")
+	searchCmd.Flags().StringVar(&pageToken, "page-token", "", "Return the page of results after the one that printed this token.")
+	searchCmd.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(searchCmd, "search"))
+
+	return commands
+}
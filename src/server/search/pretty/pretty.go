@@ -0,0 +1,43 @@
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/v2/src/search"
+)
+
+// SearchResultHeader is the header for search results.
+const SearchResultHeader = "TYPE\tNAME\tMATCH\t\n"
+
+// maxMatchLen is the length that matches are truncated to.
+const maxMatchLen = 80
+
+// PrintSearchResult pretty-prints a search result.
+func PrintSearchResult(w io.Writer, result *search.SearchResult) {
+	fmt.Fprintf(w, "%s\t", strings.ToLower(result.Type.String()))
+	fmt.Fprintf(w, "%s\t", ResultName(result))
+	match := result.Match
+	if len(match) > maxMatchLen {
+		match = match[:maxMatchLen-3] + "..."
+	}
+	fmt.Fprintf(w, "%s\t", match)
+	fmt.Fprintln(w)
+}
+
+// ResultName returns the name of the thing a search result is, in the form
+// pachctl takes it as an argument.
+func ResultName(result *search.SearchResult) string {
+	switch result.Type {
+	case search.ResultType_REPO:
+		return result.Repo.String()
+	case search.ResultType_FILE:
+		return result.File.Commit.Branch.String() + ":" + result.File.Path
+	case search.ResultType_COMMIT:
+		return result.Commit.Branch.Repo.String() + "@" + result.Commit.ID
+	case search.ResultType_PIPELINE:
+		return result.Pipeline.Name
+	}
+	return "-"
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/serviceenv"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/search"
+	authserver "github.com/pachyderm/pachyderm/v2/src/server/auth"
+	pfsserver "github.com/pachyderm/pachyderm/v2/src/server/pfs"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 1000
+)
+
+// Env is the set of dependencies required by the search APIServer and
+// indexer.
+type Env struct {
+	Config     *serviceenv.Configuration
+	Logger     *logrus.Logger
+	DB         *pachsql.DB
+	Listener   col.PostgresListener
+	EtcdClient *etcd.Client
+	AuthServer authserver.APIServer
+	PFSServer  pfsserver.APIServer
+
+	BackgroundContext context.Context
+}
+
+func EnvFromServiceEnv(senv serviceenv.ServiceEnv) Env {
+	return Env{
+		Config:     senv.Config(),
+		Logger:     senv.Logger(),
+		DB:         senv.GetDBClient(),
+		Listener:   senv.GetPostgresListener(),
+		EtcdClient: senv.GetEtcdClient(),
+		AuthServer: senv.AuthServer(),
+		PFSServer:  senv.PfsServer(),
+
+		BackgroundContext: senv.Context(),
+	}
+}
+
+// APIServer represents an APIServer
+type APIServer interface {
+	search.APIServer
+}
+
+// NewAPIServer returns a new search.APIServer
+func NewAPIServer(env Env) APIServer {
+	return &apiServer{env: env}
+}
+
+type apiServer struct {
+	env Env
+}
+
+// Search implements the protobuf search.Search RPC
+func (a *apiServer) Search(ctx context.Context, request *search.SearchRequest) (*search.SearchResponse, error) {
+	q, err := newQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	pageSize := request.PageSize
+	switch {
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize < 0 || pageSize > maxPageSize:
+		return nil, errors.Errorf("page_size must be between 1 and %d", maxPageSize)
+	}
+	cursor, err := parsePageToken(request.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	readable := make(map[string]bool)
+	response := &search.SearchResponse{}
+	// Entries the caller can't read are skipped, so entries are read in
+	// batches until the page is full.
+	for {
+		stmt, args := q.sql(cursor, pageSize+1)
+		var entries []*entry
+		if err := a.env.DB.SelectContext(ctx, &entries, stmt, args...); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		for _, e := range entries {
+			ok, err := a.canRead(ctx, readable, e)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			if int64(len(response.Results)) == pageSize {
+				response.NextPageToken = cursor.token()
+				return response, nil
+			}
+			result := &search.SearchResult{}
+			if err := proto.Unmarshal(e.Result, result); err != nil {
+				return nil, errors.EnsureStack(err)
+			}
+			result.Match = q.match(e.Text)
+			response.Results = append(response.Results, result)
+			cursor = &pageCursor{Type: e.Type, Key: e.Key}
+		}
+		if int64(len(entries)) <= pageSize {
+			return response, nil
+		}
+		last := entries[len(entries)-1]
+		cursor = &pageCursor{Type: last.Type, Key: last.Key}
+	}
+}
+
+// canRead returns whether the caller can read the repo of e, which is cached
+// in readable.
+func (a *apiServer) canRead(ctx context.Context, readable map[string]bool, e *entry) (bool, error) {
+	repo := &pfs.Repo{Name: e.RepoName, Type: e.RepoType}
+	key := repo.String()
+	if ok, cached := readable[key]; cached {
+		return ok, nil
+	}
+	err := a.env.AuthServer.CheckRepoIsAuthorized(ctx, repo, auth.Permission_REPO_READ)
+	switch {
+	case err == nil:
+		readable[key] = true
+	case auth.IsErrNotAuthorized(err) || auth.IsErrNoRoleBinding(err):
+		// the repo may have been deleted since it was indexed
+		readable[key] = false
+	default:
+		return false, errors.EnsureStack(err)
+	}
+	return readable[key], nil
+}
@@ -0,0 +1,332 @@
+package server
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dbutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dlock"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsdb"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	"github.com/pachyderm/pachyderm/v2/src/search"
+)
+
+const (
+	indexerLockPath = "search-indexer-lock"
+	// maxBranchFiles is the number of files indexed at the head of each
+	// branch, so that a repo with millions of files doesn't swamp the index.
+	maxBranchFiles = 100000
+)
+
+// An entry is a row of the search index, from which a result is returned if
+// its text matches a query.
+type entry struct {
+	Type int32  `db:"type"`
+	Key  string `db:"key"`
+	// Source is the branch a file was indexed from, "" for other types.
+	Source string `db:"source"`
+	// RepoName and RepoType are the repo the caller must be able to read to
+	// see the entry.
+	RepoName string `db:"repo_name"`
+	RepoType string `db:"repo_type"`
+	Text     string `db:"text"`
+	// Result is the marshalled SearchResult, without its match.
+	Result []byte `db:"result"`
+}
+
+func newEntry(key, text string, repo *pfs.Repo, result *search.SearchResult) (*entry, error) {
+	data, err := proto.Marshal(result)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return &entry{
+		Type:     int32(result.Type),
+		Key:      key,
+		RepoName: repo.Name,
+		RepoType: repo.Type,
+		Text:     text,
+		Result:   data,
+	}, nil
+}
+
+// SetupPostgresSearchV0 runs SQL to create the tables the search index is
+// stored in.
+// DO NOT MODIFY THIS FUNCTION
+// IT HAS BEEN USED IN A RELEASED MIGRATION
+func SetupPostgresSearchV0(ctx context.Context, tx *pachsql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		CREATE SCHEMA IF NOT EXISTS search;
+		CREATE TABLE search.entries (
+			type INT NOT NULL,
+			key TEXT NOT NULL,
+			source TEXT NOT NULL,
+			repo_name TEXT NOT NULL,
+			repo_type TEXT NOT NULL,
+			text TEXT NOT NULL,
+			result BYTEA NOT NULL,
+			PRIMARY KEY (type, key)
+		);
+		CREATE INDEX entries_source ON search.entries (source);
+		CREATE TABLE search.heads (
+			branch TEXT NOT NULL PRIMARY KEY,
+			commit_id TEXT NOT NULL
+		);
+	`)
+	return errors.EnsureStack(err)
+}
+
+// RunIndexer updates the search index every SearchIndexPeriod seconds, until
+// env.BackgroundContext is done. Only one indexer in the cluster updates the
+// index at a time.
+func RunIndexer(env Env) {
+	period := time.Duration(env.Config.SearchIndexPeriod) * time.Second
+	if period <= 0 {
+		return
+	}
+	ix := &indexer{env: env}
+	ctx := env.BackgroundContext
+	lock := dlock.NewDLock(env.EtcdClient, path.Join(env.Config.EtcdPrefix, indexerLockPath))
+	backoff.RetryUntilCancel(ctx, func() error {
+		lockCtx, err := lock.Lock(ctx)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		defer lock.Unlock(lockCtx)
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			if err := ix.index(lockCtx); err != nil {
+				return err
+			}
+			select {
+			case <-lockCtx.Done():
+				return errors.EnsureStack(lockCtx.Err())
+			case <-ticker.C:
+			}
+		}
+	}, backoff.NewInfiniteBackOff(), func(err error, t time.Duration) error {
+		env.Logger.Errorf("error updating the search index: %v; retrying in %v", err, t)
+		return nil
+	})
+}
+
+type indexer struct {
+	env Env
+}
+
+// index updates the index from the current metadata. Repos, pipelines and
+// commits are re-indexed every time, but a branch's files are only
+// re-indexed when its head changes.
+func (ix *indexer) index(ctx context.Context) error {
+	if err := ix.indexRepos(ctx); err != nil {
+		return err
+	}
+	if err := ix.indexPipelines(ctx); err != nil {
+		return err
+	}
+	if err := ix.indexCommits(ctx); err != nil {
+		return err
+	}
+	return ix.indexFiles(ctx)
+}
+
+func (ix *indexer) indexRepos(ctx context.Context) error {
+	var entries []*entry
+	repoInfo := &pfs.RepoInfo{}
+	if err := pfsdb.Repos(ix.env.DB, ix.env.Listener).ReadOnly(ctx).List(repoInfo, col.DefaultOptions(), func(string) error {
+		e, err := newEntry(pfsdb.RepoKey(repoInfo.Repo), repoInfo.Repo.Name, repoInfo.Repo, &search.SearchResult{
+			Type: search.ResultType_REPO,
+			Repo: repoInfo.Repo,
+		})
+		if err != nil {
+			return err
+		}
+		entries = append(entries, e)
+		return nil
+	}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	return ix.replace(ctx, search.ResultType_REPO, "", entries)
+}
+
+// indexPipelines indexes the current version of each pipeline by its name,
+// description and spec. The caller must be able to read its output repo to
+// find it.
+func (ix *indexer) indexPipelines(ctx context.Context) error {
+	latest := make(map[string]*pps.PipelineInfo)
+	pipelineInfo := &pps.PipelineInfo{}
+	if err := ppsdb.Pipelines(ix.env.DB, ix.env.Listener).ReadOnly(ctx).List(pipelineInfo, col.DefaultOptions(), func(string) error {
+		if pi, ok := latest[pipelineInfo.Pipeline.Name]; !ok || pi.Version < pipelineInfo.Version {
+			latest[pipelineInfo.Pipeline.Name] = proto.Clone(pipelineInfo).(*pps.PipelineInfo)
+		}
+		return nil
+	}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	var entries []*entry
+	for name, pi := range latest {
+		spec, err := (&jsonpb.Marshaler{Indent: "  "}).MarshalToString(pi.Details)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		text := strings.Join([]string{name, pi.Details.GetDescription(), spec}, "\n")
+		e, err := newEntry(name, text, &pfs.Repo{Name: name, Type: pfs.UserRepoType}, &search.SearchResult{
+			Type:     search.ResultType_PIPELINE,
+			Pipeline: pi.Pipeline,
+		})
+		if err != nil {
+			return err
+		}
+		entries = append(entries, e)
+	}
+	return ix.replace(ctx, search.ResultType_PIPELINE, "", entries)
+}
+
+// indexCommits indexes the commits that have a description by it. Aliases
+// are skipped, as they have the description of the commit they alias.
+func (ix *indexer) indexCommits(ctx context.Context) error {
+	var entries []*entry
+	commitInfo := &pfs.CommitInfo{}
+	if err := pfsdb.Commits(ix.env.DB, ix.env.Listener).ReadOnly(ctx).List(commitInfo, col.DefaultOptions(), func(string) error {
+		if commitInfo.Description == "" || commitInfo.Origin.GetKind() == pfs.OriginKind_ALIAS {
+			return nil
+		}
+		e, err := newEntry(pfsdb.CommitKey(commitInfo.Commit), commitInfo.Description, commitInfo.Commit.Branch.Repo, &search.SearchResult{
+			Type:   search.ResultType_COMMIT,
+			Repo:   commitInfo.Commit.Branch.Repo,
+			Commit: commitInfo.Commit,
+		})
+		if err != nil {
+			return err
+		}
+		entries = append(entries, e)
+		return nil
+	}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	return ix.replace(ctx, search.ResultType_COMMIT, "", entries)
+}
+
+// indexFiles indexes the paths of the files at the head of each branch of the
+// user repos, if the head is finished and has changed since it was last
+// indexed, and drops the files of deleted branches.
+func (ix *indexer) indexFiles(ctx context.Context) error {
+	var heads []*pfs.BranchInfo
+	branchInfo := &pfs.BranchInfo{}
+	if err := pfsdb.Branches(ix.env.DB, ix.env.Listener).ReadOnly(ctx).List(branchInfo, col.DefaultOptions(), func(string) error {
+		if branchInfo.Branch.Repo.Type == pfs.UserRepoType && branchInfo.Head != nil {
+			heads = append(heads, proto.Clone(branchInfo).(*pfs.BranchInfo))
+		}
+		return nil
+	}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	indexed := make(map[string]string)
+	var rows []struct {
+		Branch   string `db:"branch"`
+		CommitID string `db:"commit_id"`
+	}
+	if err := ix.env.DB.SelectContext(ctx, &rows, `SELECT branch, commit_id FROM search.heads`); err != nil {
+		return errors.EnsureStack(err)
+	}
+	for _, row := range rows {
+		indexed[row.Branch] = row.CommitID
+	}
+	commits := pfsdb.Commits(ix.env.DB, ix.env.Listener).ReadOnly(ctx)
+	for _, bi := range heads {
+		branch := pfsdb.BranchKey(bi.Branch)
+		commitID, ok := indexed[branch]
+		delete(indexed, branch)
+		if ok && commitID == bi.Head.ID {
+			continue
+		}
+		commitInfo := &pfs.CommitInfo{}
+		if err := commits.Get(bi.Head, commitInfo); err != nil {
+			if col.IsErrNotFound(err) {
+				continue
+			}
+			return errors.EnsureStack(err)
+		}
+		if commitInfo.Finished == nil || commitInfo.Error != "" {
+			continue
+		}
+		if err := ix.indexBranchFiles(ctx, branch, commitInfo); err != nil {
+			return err
+		}
+	}
+	// what's left was indexed from branches that no longer exist
+	for branch := range indexed {
+		if err := dbutil.WithTx(ctx, ix.env.DB, func(tx *pachsql.Tx) error {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM search.entries WHERE type = $1 AND source = $2`, search.ResultType_FILE, branch); err != nil {
+				return errors.EnsureStack(err)
+			}
+			_, err := tx.ExecContext(ctx, `DELETE FROM search.heads WHERE branch = $1`, branch)
+			return errors.EnsureStack(err)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ix *indexer) indexBranchFiles(ctx context.Context, branch string, commitInfo *pfs.CommitInfo) error {
+	var entries []*entry
+	repo := commitInfo.Commit.Branch.Repo
+	if err := ix.env.PFSServer.WalkCommitFiles(ctx, commitInfo, func(fi *pfs.FileInfo) error {
+		if len(entries) == maxBranchFiles {
+			ix.env.Logger.Warnf("only indexing the first %d files of %s", maxBranchFiles, branch)
+			return errutil.ErrBreak
+		}
+		e, err := newEntry(branch+":"+fi.File.Path, fi.File.Path, repo, &search.SearchResult{
+			Type: search.ResultType_FILE,
+			Repo: repo,
+			File: fi.File,
+		})
+		if err != nil {
+			return err
+		}
+		entries = append(entries, e)
+		return nil
+	}); err != nil && !errors.Is(err, errutil.ErrBreak) {
+		return errors.EnsureStack(err)
+	}
+	if err := ix.replace(ctx, search.ResultType_FILE, branch, entries); err != nil {
+		return err
+	}
+	_, err := ix.env.DB.ExecContext(ctx, `
+		INSERT INTO search.heads (branch, commit_id) VALUES ($1, $2)
+		ON CONFLICT (branch) DO UPDATE SET commit_id = EXCLUDED.commit_id
+	`, branch, commitInfo.Commit.ID)
+	return errors.EnsureStack(err)
+}
+
+// replace replaces the entries of type t, from source, with entries.
+func (ix *indexer) replace(ctx context.Context, t search.ResultType, source string, entries []*entry) error {
+	return dbutil.WithTx(ctx, ix.env.DB, func(tx *pachsql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM search.entries WHERE type = $1 AND source = $2`, t, source); err != nil {
+			return errors.EnsureStack(err)
+		}
+		for _, e := range entries {
+			e.Source = source
+			if _, err := tx.NamedExecContext(ctx, `
+				INSERT INTO search.entries (type, key, source, repo_name, repo_type, text, result)
+				VALUES (:type, :key, :source, :repo_name, :repo_type, :text, :result)
+			`, e); err != nil {
+				return errors.EnsureStack(err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/search"
+)
+
+// A query is a validated SearchRequest.
+type query struct {
+	text  string
+	mode  search.SearchRequest_Mode
+	types []search.ResultType
+	re    *regexp.Regexp
+}
+
+func newQuery(request *search.SearchRequest) (*query, error) {
+	if request.Query == "" {
+		return nil, errors.New("must specify a query")
+	}
+	q := &query{text: request.Query, mode: request.Mode}
+	switch request.Mode {
+	case search.SearchRequest_SUBSTRING, search.SearchRequest_PREFIX:
+	case search.SearchRequest_REGEX:
+		var err error
+		if q.re, err = regexp.Compile(request.Query); err != nil {
+			return nil, errors.Wrapf(err, "invalid regex %q", request.Query)
+		}
+	default:
+		return nil, errors.Errorf("unknown search mode %v", request.Mode)
+	}
+	seen := make(map[search.ResultType]bool)
+	for _, t := range request.Types {
+		if _, ok := search.ResultType_name[int32(t)]; !ok || t == search.ResultType_RESULT_TYPE_UNKNOWN {
+			return nil, errors.Errorf("unknown result type %v", t)
+		}
+		if !seen[t] {
+			seen[t] = true
+			q.types = append(q.types, t)
+		}
+	}
+	sort.Slice(q.types, func(i, j int) bool { return q.types[i] < q.types[j] })
+	return q, nil
+}
+
+// sql returns the statement, and its arguments, that selects the first limit
+// entries after cursor that match q, in the order pages are returned in.
+func (q *query) sql(cursor *pageCursor, limit int64) (string, []interface{}) {
+	var where []string
+	args := []interface{}{q.text}
+	switch q.mode {
+	case search.SearchRequest_SUBSTRING:
+		where = append(where, "strpos(lower(text), lower($1)) > 0")
+	case search.SearchRequest_PREFIX:
+		where = append(where, "left(lower(text), length($1)) = lower($1)")
+	case search.SearchRequest_REGEX:
+		where = append(where, "text ~ $1")
+	}
+	if len(q.types) > 0 {
+		var types []string
+		for _, t := range q.types {
+			types = append(types, strconv.Itoa(int(t)))
+		}
+		where = append(where, "type IN ("+strings.Join(types, ", ")+")")
+	}
+	if cursor != nil {
+		where = append(where, "(type, key) > ($2, $3)")
+		args = append(args, cursor.Type, cursor.Key)
+	}
+	return fmt.Sprintf(`
+		SELECT type, key, repo_name, repo_type, text, result
+		FROM search.entries
+		WHERE %s
+		ORDER BY type, key
+		LIMIT %d
+	`, strings.Join(where, " AND "), limit), args
+}
+
+// match returns the line of text that matches q, which is all of it unless
+// it's a pipeline's text.
+func (q *query) match(text string) string {
+	lines := strings.Split(text, "\n")
+	for _, line := range lines {
+		switch q.mode {
+		case search.SearchRequest_SUBSTRING:
+			if strings.Contains(strings.ToLower(line), strings.ToLower(q.text)) {
+				return strings.TrimSpace(line)
+			}
+		case search.SearchRequest_PREFIX:
+			// only the start of the text can match
+			return strings.TrimSpace(line)
+		case search.SearchRequest_REGEX:
+			if q.re.MatchString(line) {
+				return strings.TrimSpace(line)
+			}
+		}
+	}
+	// the match spans lines
+	return strings.TrimSpace(lines[0])
+}
+
+// A pageCursor is the last entry of a page of results, which the next page
+// starts after.
+type pageCursor struct {
+	Type int32
+	Key  string
+}
+
+func (c *pageCursor) token() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d/%s", c.Type, c.Key)))
+}
+
+func parsePageToken(token string) (*pageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.Errorf("invalid page token %q", token)
+	}
+	parts := strings.SplitN(string(raw), "/", 2)
+	if len(parts) != 2 {
+		return nil, errors.Errorf("invalid page token %q", token)
+	}
+	t, err := strconv.ParseInt(parts[0], 10, 32)
+	if err != nil {
+		return nil, errors.Errorf("invalid page token %q", token)
+	}
+	return &pageCursor{Type: int32(t), Key: parts[1]}, nil
+}
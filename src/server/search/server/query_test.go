@@ -0,0 +1,63 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/search"
+)
+
+func TestQuerySQL(t *testing.T) {
+	q, err := newQuery(&search.SearchRequest{
+		Query: "edges",
+		Mode:  search.SearchRequest_PREFIX,
+		Types: []search.ResultType{search.ResultType_PIPELINE, search.ResultType_REPO, search.ResultType_PIPELINE},
+	})
+	require.NoError(t, err)
+	stmt, args := q.sql(nil, 11)
+	require.Matches(t, `left\(lower\(text\), length\(\$1\)\) = lower\(\$1\)`, stmt)
+	require.Matches(t, `type IN \(1, 3\)`, stmt)
+	require.Matches(t, `LIMIT 11`, stmt)
+	require.Equal(t, []interface{}{"edges"}, args)
+
+	stmt, args = q.sql(&pageCursor{Type: 1, Key: "edges.user"}, 11)
+	require.Matches(t, `\(type, key\) > \(\$2, \$3\)`, stmt)
+	require.Equal(t, []interface{}{"edges", int32(1), "edges.user"}, args)
+}
+
+func TestQueryValidation(t *testing.T) {
+	_, err := newQuery(&search.SearchRequest{})
+	require.YesError(t, err)
+	_, err = newQuery(&search.SearchRequest{Query: "(", Mode: search.SearchRequest_REGEX})
+	require.YesError(t, err)
+	_, err = newQuery(&search.SearchRequest{Query: "a", Types: []search.ResultType{search.ResultType_RESULT_TYPE_UNKNOWN}})
+	require.YesError(t, err)
+	_, err = newQuery(&search.SearchRequest{Query: "a", Types: []search.ResultType{42}})
+	require.YesError(t, err)
+}
+
+func TestQueryMatch(t *testing.T) {
+	text := "edges\nFinds the edges of images\n{\n  \"transform\": {\n    \"image\": \"pachyderm/opencv\"\n  }\n}"
+	q, err := newQuery(&search.SearchRequest{Query: "OPENCV"})
+	require.NoError(t, err)
+	require.Equal(t, `"image": "pachyderm/opencv"`, q.match(text))
+	q, err = newQuery(&search.SearchRequest{Query: "IMAGES", Mode: search.SearchRequest_REGEX})
+	require.NoError(t, err)
+	// no line matches, so the first is returned
+	require.Equal(t, "edges", q.match(text))
+	q, err = newQuery(&search.SearchRequest{Query: "of im[a-z]+", Mode: search.SearchRequest_REGEX})
+	require.NoError(t, err)
+	require.Equal(t, "Finds the edges of images", q.match(text))
+}
+
+func TestPageToken(t *testing.T) {
+	cursor := &pageCursor{Type: int32(search.ResultType_FILE), Key: "images.user@master:/a/b.png"}
+	parsed, err := parsePageToken(cursor.token())
+	require.NoError(t, err)
+	require.Equal(t, cursor, parsed)
+	parsed, err = parsePageToken("")
+	require.NoError(t, err)
+	require.Nil(t, parsed)
+	_, err = parsePageToken("not a token")
+	require.YesError(t, err)
+}
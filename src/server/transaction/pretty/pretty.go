@@ -118,6 +118,8 @@ func sprintUpdateJobState(request *pps.UpdateJobStateRequest) string {
 			return "SUCCESS"
 		case pps.JobState_JOB_KILLED:
 			return "KILLED"
+		case pps.JobState_JOB_BUDGET_EXCEEDED:
+			return "BUDGET_EXCEEDED"
 		default:
 			return "<unknown state>"
 		}
@@ -136,6 +138,17 @@ func sprintCreatePipeline(request *pps.CreatePipelineRequest) string {
 	return fmt.Sprintf("%s pipeline %s", verb, request.Pipeline.Name)
 }
 
+func sprintDeletePipeline(request *pps.DeletePipelineRequest) string {
+	flags := ""
+	if request.Force {
+		flags += " --force"
+	}
+	if request.KeepRepo {
+		flags += " --keep-repo"
+	}
+	return fmt.Sprintf("delete pipeline %s%s", request.Pipeline.Name, flags)
+}
+
 func transactionRequests(
 	requests []*transaction.TransactionRequest,
 	responses []*transaction.TransactionResponse,
@@ -169,6 +182,8 @@ func transactionRequests(
 			line = sprintUpdateJobState(request.UpdateJobState)
 		} else if request.CreatePipeline != nil {
 			line = sprintCreatePipeline(request.CreatePipeline)
+		} else if request.DeletePipeline != nil {
+			line = sprintDeletePipeline(request.DeletePipeline)
 		} else {
 			line = "ERROR (unknown request type)"
 		}
@@ -173,6 +173,8 @@ func (d *driver) runTransaction(txnCtx *txncontext.TransactionContext, info *tra
 			err = directTxn.StopJob(request.StopJob)
 		} else if request.CreatePipeline != nil {
 			err = directTxn.CreatePipeline(request.CreatePipeline)
+		} else if request.DeletePipeline != nil {
+			err = directTxn.DeletePipeline(request.DeletePipeline)
 		} else {
 			err = errors.New("unrecognized transaction request type")
 		}
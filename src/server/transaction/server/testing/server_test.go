@@ -502,3 +502,43 @@ func TestCreatePipelineTransaction(t *testing.T) {
 	require.NoError(t, c.GetFile(commitInfo.Commit, "foo", &buf))
 	require.Equal(t, "bar", buf.String())
 }
+
+func TestDeletePipelineTransaction(t *testing.T) {
+	c, _ := minikubetestenv.AcquireCluster(t)
+	repo := testutil.UniqueString("in")
+	pipeline := testutil.UniqueString("pipeline")
+	require.NoError(t, c.CreateRepo(repo))
+	require.NoError(t, c.CreatePipeline(
+		pipeline,
+		"",
+		[]string{"bash"},
+		[]string{fmt.Sprintf("cp /pfs/%s/* /pfs/out", repo)},
+		&pps.ParallelismSpec{Constant: 1},
+		client.NewPFSInput(repo, "/"),
+		"master",
+		false,
+	))
+
+	// The pipeline is only deleted if the rest of the transaction succeeds.
+	_, err := c.ExecuteInTransaction(func(txnClient *client.APIClient) error {
+		if err := txnClient.DeletePipeline(pipeline, false); err != nil {
+			return err
+		}
+		_, err := txnClient.StartCommit(testutil.UniqueString("nonexistent"), "master")
+		return err
+	})
+	require.YesError(t, err)
+	_, err = c.InspectPipeline(pipeline, false)
+	require.NoError(t, err)
+
+	_, err = c.ExecuteInTransaction(func(txnClient *client.APIClient) error {
+		require.NoError(t, txnClient.DeletePipeline(pipeline, false))
+		require.NoError(t, txnClient.DeleteRepo(repo, false))
+		return nil
+	})
+	require.NoError(t, err)
+	_, err = c.InspectPipeline(pipeline, false)
+	require.YesError(t, err)
+	_, err = c.InspectRepo(repo)
+	require.YesError(t, err)
+}
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ProcessUsage is the resource usage of the user code processes run while
+// processing a datum.
+type ProcessUsage struct {
+	mu        sync.Mutex
+	UserCPU   time.Duration
+	SystemCPU time.Duration
+	// MaxRSS is the peak resident memory of any of the processes, in bytes.
+	MaxRSS int64
+}
+
+type processUsageKey struct{}
+
+// WithProcessUsage returns a context that records the resource usage of the
+// user code processes run with it (see RecordProcessUsage) in the returned
+// ProcessUsage.
+func WithProcessUsage(ctx context.Context) (context.Context, *ProcessUsage) {
+	usage := &ProcessUsage{}
+	return context.WithValue(ctx, processUsageKey{}, usage), usage
+}
+
+// RecordProcessUsage adds the resource usage of a finished process to the
+// ProcessUsage of ctx, if it has one.
+func RecordProcessUsage(ctx context.Context, userCPU, systemCPU time.Duration, maxRSS int64) {
+	usage, ok := ctx.Value(processUsageKey{}).(*ProcessUsage)
+	if !ok {
+		return
+	}
+	usage.mu.Lock()
+	defer usage.mu.Unlock()
+	usage.UserCPU += userCPU
+	usage.SystemCPU += systemCPU
+	if maxRSS > usage.MaxRSS {
+		usage.MaxRSS = maxRSS
+	}
+}
+
+// Get returns the usage recorded so far.
+func (u *ProcessUsage) Get() (userCPU, systemCPU time.Duration, maxRSS int64) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.UserCPU, u.SystemCPU, u.MaxRSS
+}
@@ -39,8 +39,15 @@ const (
 	// OutputPrefix is the prefix for the output path.
 	OutputPrefix = "out"
 	// TmpFileName is the name of the tmp file.
-	TmpFileName       = "tmp"
+	TmpFileName = "tmp"
+	// QuarantinePrefix is the prefix for the quarantine path.
+	QuarantinePrefix = "quarantine"
+	// LogsFileName is the name of a quarantined datum's logs file.
+	LogsFileName      = "logs"
 	defaultNumRetries = 3
+	// defaultMaxRetryBackoff is the longest wait between retries if the
+	// retry policy doesn't set one.
+	defaultMaxRetryBackoff = 5 * time.Minute
 )
 
 // SetSpec specifies criteria for creating datum sets.
@@ -157,9 +164,13 @@ func (s *Set) WithDatum(meta *Meta, cb func(*Datum) error, opts ...Option) error
 
 	var err error
 	for i := 0; i <= d.numRetries; i++ {
+		if i > 0 {
+			time.Sleep(d.retryWait(i))
+		}
+		d.timedOut = false
 		err = d.withData(func() (retErr error) {
 			defer func() {
-				if retErr == nil || i == d.numRetries {
+				if retErr == nil || i == d.numRetries || !d.retryable() {
 					retErr = d.finish(retErr)
 				}
 				duration := time.Duration(d.meta.Stats.ProcessTime.GetNanos()) + time.Duration(d.meta.Stats.ProcessTime.GetSeconds())*time.Second
@@ -170,8 +181,8 @@ func (s *Set) WithDatum(meta *Meta, cb func(*Datum) error, opts ...Option) error
 			}()
 			return cb(d)
 		})
-		if err == nil {
-			return nil
+		if err == nil || !d.retryable() {
+			return err
 		}
 	}
 	return err
@@ -187,6 +198,16 @@ type Datum struct {
 	recoveryCallback func(context.Context) error
 	timeout          time.Duration
 	IDPrefix         string
+	retryBackoff     time.Duration
+	maxRetryBackoff  time.Duration
+	noTimeoutRetry   bool
+	// timedOut is set if the datum's current try exceeded its timeout.
+	timedOut       bool
+	quarantine     bool
+	quarantineLogs func() io.Reader
+	gpuSample      func(context.Context) (float64, error)
+	readaheadFiles int
+	readaheadBytes int64
 }
 
 func newDatum(set *Set, meta *Meta, opts ...Option) *Datum {
@@ -205,6 +226,31 @@ func newDatum(set *Set, meta *Meta, opts ...Option) *Datum {
 	return d
 }
 
+// retryWait returns how long to wait before the given retry.
+func (d *Datum) retryWait(retry int) time.Duration {
+	if d.retryBackoff <= 0 {
+		return 0
+	}
+	maxBackoff := d.maxRetryBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxRetryBackoff
+	}
+	wait := d.retryBackoff
+	for i := 1; i < retry && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxBackoff {
+		return maxBackoff
+	}
+	return wait
+}
+
+// retryable returns whether the datum may be retried after its current try
+// failed.
+func (d *Datum) retryable() bool {
+	return !(d.noTimeoutRetry && d.timedOut)
+}
+
 // PFSStorageRoot returns the pfs storage root.
 func (d *Datum) PFSStorageRoot() string {
 	return path.Join(d.storageRoot, PFSPrefix, d.ID)
@@ -223,6 +269,11 @@ func (d *Datum) finish(err error) (retErr error) {
 	}()
 	if err != nil {
 		d.handleFailed(err)
+		if d.meta.State == State_QUARANTINED {
+			if err := d.uploadQuarantine(); err != nil {
+				return err
+			}
+		}
 		return d.uploadMetaOutput()
 	}
 	d.set.stats.Processed++
@@ -234,8 +285,13 @@ func (d *Datum) handleFailed(err error) {
 		d.set.stats.Recovered++
 		return
 	}
-	d.meta.State = State_FAILED
 	d.meta.Reason = err.Error()
+	if d.quarantine {
+		d.meta.State = State_QUARANTINED
+		d.set.stats.Quarantined++
+		return
+	}
+	d.meta.State = State_FAILED
 	d.set.stats.Failed++
 	if d.set.stats.FailedID == "" {
 		d.set.stats.FailedID = d.ID
@@ -287,6 +343,9 @@ func (d *Datum) downloadData(downloader pfssync.Downloader) error {
 		}
 		if input.Lazy {
 			opts = append(opts, pfssync.WithLazy())
+			if d.readaheadFiles > 0 {
+				opts = append(opts, pfssync.WithReadahead(d.readaheadFiles, d.readaheadBytes))
+			}
 		}
 		if input.EmptyFiles {
 			opts = append(opts, pfssync.WithEmpty())
@@ -301,13 +360,28 @@ func (d *Datum) downloadData(downloader pfssync.Downloader) error {
 // Run provides a scoped environment for the processing of a datum.
 func (d *Datum) Run(ctx context.Context, cb func(ctx context.Context) error) error {
 	start := time.Now()
+	ctx, usage := common.WithProcessUsage(ctx)
 	defer func() {
 		d.meta.Stats.ProcessTime = types.DurationProto(time.Since(start))
+		userCPU, systemCPU, maxRSS := usage.Get()
+		d.meta.Stats.UserCpuTime = types.DurationProto(userCPU)
+		d.meta.Stats.SystemCpuTime = types.DurationProto(systemCPU)
+		d.meta.Stats.MaxRSS = maxRSS
 	}()
+	if d.gpuSample != nil {
+		stop := sampleGPUTime(ctx, d.gpuSample)
+		defer func() {
+			d.meta.Stats.GpuTime = types.DurationProto(stop())
+		}()
+	}
 	if d.timeout > 0 {
 		timeoutCtx, cancel := context.WithTimeout(ctx, d.timeout)
 		defer cancel()
-		return d.run(timeoutCtx, cb)
+		err := d.run(timeoutCtx, cb)
+		if err != nil && errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+			d.timedOut = true
+		}
+		return err
 	}
 	return d.run(ctx, cb)
 }
@@ -357,6 +431,30 @@ func (d *Datum) uploadMetaFile(mf client.ModifyFile) error {
 	return errors.EnsureStack(err)
 }
 
+// uploadQuarantine writes the meta and logs of a quarantined datum to the
+// quarantine path of the meta commit.
+func (d *Datum) uploadQuarantine() error {
+	mf := d.set.metaOutputClient
+	if mf == nil {
+		return nil
+	}
+	marshaler := &jsonpb.Marshaler{}
+	buf := &bytes.Buffer{}
+	if err := marshaler.Marshal(buf, d.meta); err != nil {
+		return errors.EnsureStack(err)
+	}
+	quarantinePath := path.Join(QuarantinePrefix, d.ID)
+	if err := mf.PutFile(path.Join(quarantinePath, MetaFileName), buf, client.WithDatumPutFile(d.ID)); err != nil {
+		return errors.EnsureStack(err)
+	}
+	logs := io.Reader(&bytes.Buffer{})
+	if d.quarantineLogs != nil {
+		logs = d.quarantineLogs()
+	}
+	err := mf.PutFile(path.Join(quarantinePath, LogsFileName), logs, client.WithDatumPutFile(d.ID))
+	return errors.EnsureStack(err)
+}
+
 func (d *Datum) uploadOutput() error {
 	if d.set.pfsOutputClient != nil {
 		start := time.Now()
@@ -498,6 +596,12 @@ func NewDeleter(metaFileWalker fileWalkerFunc, metaOutputClient, pfsOutputClient
 		if err := metaOutputClient.DeleteFile(path.Join(MetaPrefix, ID, MetaFileName), tagOption); err != nil {
 			return errors.EnsureStack(err)
 		}
+		// Delete the datum's quarantine files, if it was quarantined.
+		for _, name := range []string{MetaFileName, LogsFileName} {
+			if err := metaOutputClient.DeleteFile(path.Join(QuarantinePrefix, ID, name), tagOption); err != nil {
+				return errors.EnsureStack(err)
+			}
+		}
 		pfsDir := "/" + path.Join(PFSPrefix, ID)
 		outDir := path.Join(pfsDir, OutputPrefix)
 		files, err := metaFileWalker(pfsDir)
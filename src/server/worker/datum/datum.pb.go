@@ -28,21 +28,24 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 type State int32
 
 const (
-	State_PROCESSED State = 0
-	State_FAILED    State = 1
-	State_RECOVERED State = 2
+	State_PROCESSED   State = 0
+	State_FAILED      State = 1
+	State_RECOVERED   State = 2
+	State_QUARANTINED State = 3
 )
 
 var State_name = map[int32]string{
 	0: "PROCESSED",
 	1: "FAILED",
 	2: "RECOVERED",
+	3: "QUARANTINED",
 }
 
 var State_value = map[string]int32{
-	"PROCESSED": 0,
-	"FAILED":    1,
-	"RECOVERED": 2,
+	"PROCESSED":   0,
+	"FAILED":      1,
+	"RECOVERED":   2,
+	"QUARANTINED": 3,
 }
 
 func (x State) String() string {
@@ -163,6 +166,9 @@ type Stats struct {
 	Failed               int64             `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
 	Recovered            int64             `protobuf:"varint,5,opt,name=recovered,proto3" json:"recovered,omitempty"`
 	FailedID             string            `protobuf:"bytes,6,opt,name=failed_id,json=failedId,proto3" json:"failed_id,omitempty"`
+	Quarantined          int64             `protobuf:"varint,7,opt,name=quarantined,proto3" json:"quarantined,omitempty"`
+	Restored             int64             `protobuf:"varint,8,opt,name=restored,proto3" json:"restored,omitempty"`
+	Recomputed           int64             `protobuf:"varint,9,opt,name=recomputed,proto3" json:"recomputed,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -243,6 +249,27 @@ func (m *Stats) GetFailedID() string {
 	return ""
 }
 
+func (m *Stats) GetQuarantined() int64 {
+	if m != nil {
+		return m.Quarantined
+	}
+	return 0
+}
+
+func (m *Stats) GetRestored() int64 {
+	if m != nil {
+		return m.Restored
+	}
+	return 0
+}
+
+func (m *Stats) GetRecomputed() int64 {
+	if m != nil {
+		return m.Recomputed
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("datum.State", State_name, State_value)
 	proto.RegisterType((*Meta)(nil), "datum.Meta")
@@ -252,36 +279,40 @@ func init() {
 func init() { proto.RegisterFile("server/worker/datum/datum.proto", fileDescriptor_96ec7427544ac634) }
 
 var fileDescriptor_96ec7427544ac634 = []byte{
-	// 461 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0x41, 0x6f, 0xd3, 0x30,
-	0x14, 0xc7, 0x71, 0xd3, 0xa6, 0x8d, 0xdb, 0xa2, 0xca, 0xaa, 0x90, 0x99, 0xa0, 0x0b, 0x95, 0x90,
-	0xc2, 0x0e, 0x89, 0x08, 0xa7, 0x1d, 0xd9, 0x9a, 0xa1, 0x20, 0xd0, 0x26, 0x57, 0xe2, 0xc0, 0xa5,
-	0x4a, 0xe3, 0x47, 0x1b, 0x46, 0x6b, 0xcb, 0x4e, 0x0b, 0x7c, 0x43, 0x8e, 0xdc, 0x91, 0x10, 0xea,
-	0xb7, 0xe0, 0x86, 0x6c, 0x67, 0xda, 0x90, 0x10, 0x97, 0xe4, 0xfd, 0xfe, 0xcf, 0xfe, 0x3f, 0xbf,
-	0x67, 0xe3, 0x63, 0x0d, 0x6a, 0x0f, 0x2a, 0xf9, 0x2c, 0xd4, 0x35, 0xa8, 0x84, 0x17, 0xf5, 0x6e,
-	0xe3, 0xbe, 0xb1, 0x54, 0xa2, 0x16, 0xa4, 0x63, 0xe1, 0x68, 0xbc, 0x12, 0x2b, 0x61, 0x95, 0xc4,
-	0x44, 0x2e, 0x79, 0x34, 0x94, 0x52, 0x27, 0x52, 0xea, 0x06, 0x9f, 0xfc, 0x6d, 0x56, 0x8a, 0xcd,
-	0x46, 0x6c, 0x9b, 0x9f, 0x5b, 0x32, 0xfd, 0x8d, 0x70, 0xfb, 0x2d, 0xd4, 0x05, 0x79, 0x8c, 0xbd,
-	0x8f, 0x62, 0x49, 0x51, 0x88, 0xa2, 0x7e, 0xda, 0x8f, 0xa5, 0xd4, 0x8b, 0x7d, 0x1a, 0xbf, 0x16,
-	0x4b, 0x66, 0x74, 0xf2, 0x14, 0xfb, 0xd5, 0x56, 0xee, 0x6a, 0x4d, 0x5b, 0xa1, 0x17, 0xf5, 0xd3,
-	0x61, 0xdc, 0xd8, 0xe4, 0x46, 0x65, 0x4d, 0x92, 0x10, 0xdc, 0x5e, 0x17, 0x7a, 0x4d, 0xbd, 0x10,
-	0x45, 0x01, 0xb3, 0x31, 0x99, 0xe2, 0x8e, 0xae, 0x8b, 0x1a, 0x68, 0x3b, 0x44, 0xd1, 0xfd, 0x74,
-	0x10, 0xbb, 0x76, 0xe6, 0x46, 0x63, 0x2e, 0x45, 0x1e, 0x60, 0x5f, 0x41, 0xa1, 0xc5, 0x96, 0x76,
-	0xec, 0xce, 0x86, 0xc8, 0x89, 0xdb, 0xab, 0xa9, 0x6f, 0xcf, 0x35, 0xbe, 0x39, 0xd7, 0x95, 0x12,
-	0x25, 0x68, 0x6d, 0x3c, 0xb4, 0xf3, 0xd0, 0x64, 0x8c, 0x3b, 0xd5, 0x96, 0xc3, 0x17, 0xda, 0x0d,
-	0x51, 0xe4, 0x31, 0x07, 0xe4, 0x21, 0xee, 0x55, 0x9b, 0x62, 0x05, 0x8b, 0x8a, 0xd3, 0x9e, 0xf5,
-	0xee, 0x5a, 0xce, 0xf9, 0xf4, 0x07, 0xc2, 0x1d, 0xeb, 0x40, 0x4e, 0xf1, 0x50, 0x3a, 0xc7, 0x85,
-	0x2b, 0x87, 0xfe, 0x53, 0x6e, 0x20, 0xef, 0x10, 0x79, 0x84, 0x83, 0x86, 0x81, 0xd3, 0x96, 0xad,
-	0x7c, 0x2b, 0x10, 0x8a, 0xbb, 0xfa, 0xba, 0x92, 0x12, 0xb8, 0x1d, 0x89, 0xc7, 0x6e, 0xd0, 0x74,
-	0xfc, 0xa1, 0xa8, 0x3e, 0x01, 0xb7, 0x63, 0xf1, 0x58, 0x43, 0xc6, 0x4f, 0x41, 0x29, 0xf6, 0xa0,
-	0x80, 0xdb, 0x61, 0x78, 0xec, 0x56, 0x20, 0xcf, 0x70, 0xe0, 0xd6, 0x99, 0x76, 0xcc, 0x4c, 0x82,
-	0xb3, 0xc1, 0xe1, 0xe7, 0x71, 0xef, 0xc2, 0x8a, 0xf9, 0x8c, 0xf5, 0x5c, 0x3a, 0xe7, 0x27, 0xcf,
-	0x5d, 0x73, 0x40, 0x86, 0x38, 0xb8, 0x62, 0x97, 0xe7, 0xd9, 0x7c, 0x9e, 0xcd, 0x46, 0xf7, 0x08,
-	0xc6, 0xfe, 0xc5, 0xcb, 0xfc, 0x4d, 0x36, 0x1b, 0x21, 0x93, 0x62, 0xd9, 0xf9, 0xe5, 0xbb, 0x8c,
-	0x65, 0xb3, 0x51, 0xeb, 0xec, 0xd5, 0xb7, 0xc3, 0x04, 0x7d, 0x3f, 0x4c, 0xd0, 0xaf, 0xc3, 0x04,
-	0xbd, 0x3f, 0x5d, 0x55, 0xf5, 0x7a, 0xb7, 0x34, 0x17, 0x9d, 0xc8, 0xa2, 0x5c, 0x7f, 0xe5, 0xa0,
-	0xee, 0x46, 0xfb, 0x34, 0xd1, 0xaa, 0x4c, 0xfe, 0xf1, 0x60, 0x97, 0xbe, 0x7d, 0x5c, 0x2f, 0xfe,
-	0x04, 0x00, 0x00, 0xff, 0xff, 0x7f, 0xe1, 0x0e, 0xde, 0xce, 0x02, 0x00, 0x00,
+	// 515 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0xdd, 0x8e, 0x12, 0x31,
+	0x18, 0xb5, 0x0c, 0x7f, 0xf3, 0x01, 0x4a, 0x1a, 0x62, 0x2a, 0x51, 0x76, 0x24, 0x31, 0xc1, 0xbd,
+	0x60, 0x12, 0xbc, 0xda, 0x4b, 0x58, 0x66, 0xcd, 0x18, 0xdd, 0x5d, 0x8b, 0x7a, 0xe1, 0x0d, 0x19,
+	0x68, 0x85, 0x71, 0x65, 0x5a, 0xdb, 0x01, 0xf5, 0xd1, 0x7c, 0x03, 0x2f, 0x7d, 0x02, 0x63, 0x78,
+	0x0b, 0xef, 0x4c, 0xdb, 0x59, 0x17, 0x13, 0xe3, 0xcd, 0xcc, 0x77, 0xce, 0x69, 0xcf, 0xd7, 0xef,
+	0xa4, 0x85, 0x23, 0xcd, 0xd5, 0x8e, 0xab, 0xf0, 0x93, 0x50, 0x57, 0x5c, 0x85, 0x2c, 0xc9, 0xb7,
+	0x1b, 0xf7, 0x1d, 0x4a, 0x25, 0x72, 0x81, 0x2b, 0x16, 0x74, 0x3b, 0x2b, 0xb1, 0x12, 0x96, 0x09,
+	0x4d, 0xe5, 0xc4, 0x6e, 0x4b, 0x4a, 0x1d, 0x4a, 0xa9, 0x0b, 0xf8, 0xf0, 0x6f, 0xb3, 0xa5, 0xd8,
+	0x6c, 0x44, 0x56, 0xfc, 0xdc, 0x92, 0xfe, 0x2f, 0x04, 0xe5, 0x17, 0x3c, 0x4f, 0xf0, 0x03, 0xf0,
+	0xde, 0x8b, 0x05, 0x41, 0x01, 0x1a, 0x34, 0x46, 0x8d, 0xa1, 0x94, 0x7a, 0xbe, 0x1b, 0x0d, 0x9f,
+	0x89, 0x05, 0x35, 0x3c, 0x7e, 0x04, 0xd5, 0x34, 0x93, 0xdb, 0x5c, 0x93, 0x52, 0xe0, 0x0d, 0x1a,
+	0xa3, 0xd6, 0xb0, 0xb0, 0x89, 0x0d, 0x4b, 0x0b, 0x11, 0x63, 0x28, 0xaf, 0x13, 0xbd, 0x26, 0x5e,
+	0x80, 0x06, 0x3e, 0xb5, 0x35, 0xee, 0x43, 0x45, 0xe7, 0x49, 0xce, 0x49, 0x39, 0x40, 0x83, 0xdb,
+	0xa3, 0xe6, 0xd0, 0x8d, 0x33, 0x33, 0x1c, 0x75, 0x12, 0xbe, 0x0b, 0x55, 0xc5, 0x13, 0x2d, 0x32,
+	0x52, 0xb1, 0x3b, 0x0b, 0x84, 0x8f, 0xdd, 0x5e, 0x4d, 0xaa, 0xf6, 0x5c, 0x9d, 0xeb, 0x73, 0x5d,
+	0x2a, 0xb1, 0xe4, 0x5a, 0x1b, 0x0f, 0xed, 0x3c, 0x34, 0xee, 0x40, 0x25, 0xcd, 0x18, 0xff, 0x4c,
+	0x6a, 0x01, 0x1a, 0x78, 0xd4, 0x01, 0x7c, 0x0f, 0xea, 0xe9, 0x26, 0x59, 0xf1, 0x79, 0xca, 0x48,
+	0xdd, 0x7a, 0xd7, 0x2c, 0x8e, 0x59, 0xff, 0x6b, 0x09, 0x2a, 0xd6, 0x01, 0x9f, 0x40, 0x4b, 0x3a,
+	0xc7, 0xb9, 0x6b, 0x87, 0xfe, 0xd3, 0xae, 0x29, 0x0f, 0x10, 0xbe, 0x0f, 0x7e, 0x81, 0x39, 0x23,
+	0x25, 0xdb, 0xf9, 0x86, 0xc0, 0x04, 0x6a, 0xfa, 0x2a, 0x95, 0x92, 0x33, 0x1b, 0x89, 0x47, 0xaf,
+	0xa1, 0x99, 0xf8, 0x5d, 0x92, 0x7e, 0xe0, 0xcc, 0xc6, 0xe2, 0xd1, 0x02, 0x19, 0x3f, 0xc5, 0x97,
+	0x62, 0xc7, 0x15, 0x67, 0x36, 0x0c, 0x8f, 0xde, 0x10, 0xf8, 0x31, 0xf8, 0x6e, 0x9d, 0x19, 0xc7,
+	0x64, 0xe2, 0x4f, 0x9a, 0xfb, 0x1f, 0x47, 0xf5, 0x33, 0x4b, 0xc6, 0x53, 0x5a, 0x77, 0x72, 0xcc,
+	0x70, 0x00, 0x8d, 0x8f, 0xdb, 0x44, 0x25, 0x59, 0x9e, 0x66, 0x9c, 0x15, 0xa1, 0x1c, 0x52, 0xb8,
+	0x0b, 0x75, 0xc5, 0x75, 0x2e, 0x14, 0x77, 0xd1, 0x78, 0xf4, 0x0f, 0xc6, 0x3d, 0x00, 0xd3, 0x75,
+	0x23, 0xb7, 0x39, 0x67, 0xc4, 0xb7, 0xea, 0x01, 0x73, 0x3c, 0x71, 0xd1, 0x71, 0xdc, 0x02, 0xff,
+	0x92, 0x5e, 0x9c, 0x46, 0xb3, 0x59, 0x34, 0x6d, 0xdf, 0xc2, 0x00, 0xd5, 0xb3, 0x71, 0xfc, 0x3c,
+	0x9a, 0xb6, 0x91, 0x91, 0x68, 0x74, 0x7a, 0xf1, 0x26, 0xa2, 0xd1, 0xb4, 0x5d, 0xc2, 0x77, 0xa0,
+	0xf1, 0xf2, 0xf5, 0x98, 0x8e, 0xcf, 0x5f, 0xc5, 0xe7, 0xd1, 0xb4, 0xed, 0x4d, 0x9e, 0x7e, 0xdb,
+	0xf7, 0xd0, 0xf7, 0x7d, 0x0f, 0xfd, 0xdc, 0xf7, 0xd0, 0xdb, 0x93, 0x55, 0x9a, 0xaf, 0xb7, 0x0b,
+	0x73, 0xaf, 0x42, 0x99, 0x2c, 0xd7, 0x5f, 0x18, 0x57, 0x87, 0xd5, 0x6e, 0x14, 0x6a, 0xb5, 0x0c,
+	0xff, 0xf1, 0x3e, 0x16, 0x55, 0x7b, 0x97, 0x9f, 0xfc, 0x1e, 0x00, 0x37, 0x02, 0x0d, 0x01, 0x3d,
+	0x03, 0x00, 0x00,
 }
 
 func (m *Meta) Marshal() (dAtA []byte, err error) {
@@ -404,6 +435,21 @@ func (m *Stats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Recomputed != 0 {
+		i = encodeVarintDatum(dAtA, i, uint64(m.Recomputed))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.Restored != 0 {
+		i = encodeVarintDatum(dAtA, i, uint64(m.Restored))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.Quarantined != 0 {
+		i = encodeVarintDatum(dAtA, i, uint64(m.Quarantined))
+		i--
+		dAtA[i] = 0x38
+	}
 	if len(m.FailedID) > 0 {
 		i -= len(m.FailedID)
 		copy(dAtA[i:], m.FailedID)
@@ -527,6 +573,15 @@ func (m *Stats) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovDatum(uint64(l))
 	}
+	if m.Quarantined != 0 {
+		n += 1 + sovDatum(uint64(m.Quarantined))
+	}
+	if m.Restored != 0 {
+		n += 1 + sovDatum(uint64(m.Restored))
+	}
+	if m.Recomputed != 0 {
+		n += 1 + sovDatum(uint64(m.Recomputed))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1003,6 +1058,63 @@ func (m *Stats) Unmarshal(dAtA []byte) error {
 			}
 			m.FailedID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Quarantined", wireType)
+			}
+			m.Quarantined = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatum
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Quarantined |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Restored", wireType)
+			}
+			m.Restored = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatum
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Restored |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Recomputed", wireType)
+			}
+			m.Recomputed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatum
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Recomputed |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDatum(dAtA[iNdEx:])
@@ -0,0 +1,58 @@
+package datum
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// gpuSampleInterval is how often the utilization of a worker's GPUs is
+// sampled while a datum is processed.
+var gpuSampleInterval = 5 * time.Second
+
+// sampleGPUTime samples the utilization of the worker's GPUs with sample
+// until the returned function is called, which returns how long the GPUs
+// were busy. Each sample is assumed to hold until the next one, and failed
+// samples count as idle time.
+func sampleGPUTime(ctx context.Context, sample func(context.Context) (float64, error)) func() time.Duration {
+	ctx, cancel := context.WithCancel(ctx)
+	var mu sync.Mutex
+	var busy time.Duration
+	var utilization float64
+	last := time.Now()
+	// record adds the time since the last sample at the last sample's
+	// utilization.
+	record := func(now time.Time) {
+		busy += time.Duration(utilization * float64(now.Sub(last)))
+		last = now
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(gpuSampleInterval)
+		defer ticker.Stop()
+		for {
+			u, err := sample(ctx)
+			if err != nil {
+				u = 0
+			}
+			mu.Lock()
+			record(time.Now())
+			utilization = u
+			mu.Unlock()
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() time.Duration {
+		cancel()
+		<-done
+		mu.Lock()
+		defer mu.Unlock()
+		record(time.Now())
+		return busy
+	}
+}
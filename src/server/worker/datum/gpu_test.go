@@ -0,0 +1,31 @@
+package datum
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestSampleGPUTime(t *testing.T) {
+	defer func(interval time.Duration) { gpuSampleInterval = interval }(gpuSampleInterval)
+	gpuSampleInterval = 10 * time.Millisecond
+
+	start := time.Now()
+	stop := sampleGPUTime(context.Background(), func(context.Context) (float64, error) {
+		return 0.5, nil
+	})
+	time.Sleep(200 * time.Millisecond)
+	busy := stop()
+	elapsed := time.Since(start)
+	require.True(t, busy > elapsed/4, "busy %v of %v", busy, elapsed)
+	require.True(t, busy <= elapsed/2, "busy %v of %v", busy, elapsed)
+
+	stop = sampleGPUTime(context.Background(), func(context.Context) (float64, error) {
+		return 1, errors.New("no GPUs")
+	})
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, time.Duration(0), stop())
+}
@@ -3,9 +3,11 @@ package datum
 import (
 	"archive/tar"
 	"context"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"io"
+	"math"
 	"path"
 	"strings"
 
@@ -177,6 +179,45 @@ func (ji *jobIterator) Iterate(cb func(*Meta) error) error {
 	return errors.EnsureStack(err)
 }
 
+type sampleIterator struct {
+	iterator Iterator
+	fraction float64
+}
+
+// NewSampleIterator creates an iterator over a fraction of the datums of
+// iterator, chosen by hashing their IDs. The same datums are chosen from every
+// iterator over the same inputs, so a pipeline that processes a sample of its
+// datums processes the same sample in each job.
+func NewSampleIterator(iterator Iterator, fraction float64) Iterator {
+	return &sampleIterator{
+		iterator: iterator,
+		fraction: fraction,
+	}
+}
+
+func (si *sampleIterator) Iterate(cb func(*Meta) error) error {
+	err := si.iterator.Iterate(func(meta *Meta) error {
+		if !Sampled(common.DatumID(meta.Inputs), si.fraction) {
+			return nil
+		}
+		return cb(meta)
+	})
+	return errors.EnsureStack(err)
+}
+
+// Sampled returns true if the datum with the given ID is in the sample of a
+// fraction of datums.
+func Sampled(id string, fraction float64) bool {
+	if fraction >= 1 {
+		return true
+	}
+	b, err := hex.DecodeString(id)
+	if err != nil || len(b) < 8 {
+		return false
+	}
+	return float64(binary.BigEndian.Uint64(b)) < fraction*math.MaxUint64
+}
+
 type fileSetIterator struct {
 	pachClient *client.APIClient
 	commit     *pfs.Commit
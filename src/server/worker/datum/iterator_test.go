@@ -1,6 +1,7 @@
 package datum
 
 import (
+	"encoding/hex"
 	"fmt"
 	"strings"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/require"
 	"github.com/pachyderm/pachyderm/v2/src/internal/testpachd"
 	tu "github.com/pachyderm/pachyderm/v2/src/internal/testutil"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
 )
 
 func TestIterators(t *testing.T) {
@@ -376,3 +378,31 @@ func computeKey(meta *Meta) string {
 	}
 	return key
 }
+
+func TestSampled(t *testing.T) {
+	var ids []string
+	for i := 0; i < 1000; i++ {
+		hash := pfs.NewHash()
+		hash.Write([]byte(fmt.Sprint(i)))
+		ids = append(ids, hex.EncodeToString(hash.Sum(nil)))
+	}
+	count := func(fraction float64) int {
+		var n int
+		for _, id := range ids {
+			if Sampled(id, fraction) {
+				n++
+			}
+		}
+		return n
+	}
+	require.Equal(t, 0, count(0))
+	require.Equal(t, len(ids), count(1))
+	n := count(0.1)
+	require.True(t, n > 50 && n < 150, "sampled %d of %d datums", n, len(ids))
+	// a larger sample includes the datums of every smaller one
+	for _, id := range ids {
+		if Sampled(id, 0.1) {
+			require.True(t, Sampled(id, 0.5))
+		}
+	}
+}
@@ -3,6 +3,7 @@ package datum
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/pachyderm/pachyderm/v2/src/client"
@@ -62,3 +63,46 @@ func WithPrefixIndex() Option {
 		d.IDPrefix = fmt.Sprintf("%016d", d.meta.Index) + "-"
 	}
 }
+
+// WithRetryBackoff waits before retrying a failed datum. The wait starts at
+// backoff and doubles with each retry, up to maxBackoff.
+func WithRetryBackoff(backoff, maxBackoff time.Duration) Option {
+	return func(d *Datum) {
+		d.retryBackoff = backoff
+		d.maxRetryBackoff = maxBackoff
+	}
+}
+
+// WithoutTimeoutRetry stops retrying a datum once it exceeds its timeout.
+func WithoutTimeoutRetry() Option {
+	return func(d *Datum) {
+		d.noTimeoutRetry = true
+	}
+}
+
+// WithGPUSampler measures the utilization of the worker's GPUs while the
+// datum is processed, by calling sample, which returns their current
+// utilization from 0 to 1.
+func WithGPUSampler(sample func(context.Context) (float64, error)) Option {
+	return func(d *Datum) {
+		d.gpuSample = sample
+	}
+}
+
+// WithQuarantine quarantines the datum if it fails every try, rather than
+// failing it. The logs of its last try are read from logs.
+func WithQuarantine(logs func() io.Reader) Option {
+	return func(d *Datum) {
+		d.quarantine = true
+		d.quarantineLogs = logs
+	}
+}
+
+// WithReadahead prefetches the content of up to files of the datum's lazy
+// input files after the one being read, buffering at most sizeBytes.
+func WithReadahead(files int, sizeBytes int64) Option {
+	return func(d *Datum) {
+		d.readaheadFiles = files
+		d.readaheadBytes = sizeBytes
+	}
+}
@@ -0,0 +1,56 @@
+package datum
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+func TestRetryWait(t *testing.T) {
+	d := &Datum{}
+	require.Equal(t, time.Duration(0), d.retryWait(1))
+
+	WithRetryBackoff(time.Second, 5*time.Second)(d)
+	require.Equal(t, time.Second, d.retryWait(1))
+	require.Equal(t, 2*time.Second, d.retryWait(2))
+	require.Equal(t, 4*time.Second, d.retryWait(3))
+	require.Equal(t, 5*time.Second, d.retryWait(4))
+	require.Equal(t, 5*time.Second, d.retryWait(100))
+
+	WithRetryBackoff(time.Minute, 0)(d)
+	require.Equal(t, defaultMaxRetryBackoff, d.retryWait(10))
+}
+
+func TestRetryable(t *testing.T) {
+	d := &Datum{timedOut: true}
+	require.True(t, d.retryable())
+	WithoutTimeoutRetry()(d)
+	require.False(t, d.retryable())
+	d.timedOut = false
+	require.True(t, d.retryable())
+}
+
+func TestHandleFailedQuarantine(t *testing.T) {
+	newSet := func() *Set {
+		return &Set{stats: &Stats{ProcessStats: &pps.ProcessStats{}}}
+	}
+	s := newSet()
+	d := &Datum{set: s, meta: &Meta{}, ID: "a"}
+	d.handleFailed(errors.New("boom"))
+	require.Equal(t, State_FAILED, d.meta.State)
+	require.Equal(t, int64(1), s.stats.Failed)
+	require.Equal(t, "a", s.stats.FailedID)
+
+	s = newSet()
+	d = &Datum{set: s, meta: &Meta{}, ID: "b"}
+	WithQuarantine(nil)(d)
+	d.handleFailed(errors.New("boom"))
+	require.Equal(t, State_QUARANTINED, d.meta.State)
+	require.Equal(t, "boom", d.meta.Reason)
+	require.Equal(t, int64(1), s.stats.Quarantined)
+	require.Equal(t, int64(0), s.stats.Failed)
+	require.Equal(t, "", s.stats.FailedID)
+}
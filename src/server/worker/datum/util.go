@@ -17,6 +17,9 @@ func MergeStats(x, y *Stats) error {
 	x.Skipped += y.Skipped
 	x.Failed += y.Failed
 	x.Recovered += y.Recovered
+	x.Quarantined += y.Quarantined
+	x.Restored += y.Restored
+	x.Recomputed += y.Recomputed
 	if x.FailedID == "" {
 		x.FailedID = y.FailedID
 	}
@@ -35,8 +38,26 @@ func MergeProcessStats(x, y *pps.ProcessStats) error {
 	if x.UploadTime, err = plusDuration(x.UploadTime, y.UploadTime); err != nil {
 		return err
 	}
+	if y.GpuTime != nil {
+		if x.GpuTime, err = plusDuration(x.GpuTime, y.GpuTime); err != nil {
+			return err
+		}
+	}
+	if y.UserCpuTime != nil {
+		if x.UserCpuTime, err = plusDuration(x.UserCpuTime, y.UserCpuTime); err != nil {
+			return err
+		}
+	}
+	if y.SystemCpuTime != nil {
+		if x.SystemCpuTime, err = plusDuration(x.SystemCpuTime, y.SystemCpuTime); err != nil {
+			return err
+		}
+	}
 	x.DownloadBytes += y.DownloadBytes
 	x.UploadBytes += y.UploadBytes
+	if y.MaxRSS > x.MaxRSS {
+		x.MaxRSS = y.MaxRSS
+	}
 	return nil
 }
 
@@ -31,6 +31,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/server/worker/common"
 	"github.com/pachyderm/pachyderm/v2/src/server/worker/logs"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
 // TODO(2.0 optional):
@@ -69,6 +70,9 @@ type Driver interface {
 	// Returns the pachd API client for the driver
 	PachClient() *client.APIClient
 
+	// Returns the kubernetes client for the driver
+	KubeClient() kubernetes.Interface
+
 	// Returns the number of workers to be used
 	ExpectedNumWorkers() (int64, error)
 
@@ -309,6 +313,10 @@ func (d *driver) PachClient() *client.APIClient {
 	return d.pachClient.WithCtx(d.ctx)
 }
 
+func (d *driver) KubeClient() kubernetes.Interface {
+	return d.env.GetKubeClient()
+}
+
 func (d *driver) NewSQLTx(cb func(*pachsql.Tx) error) error {
 	return dbutil.WithTx(d.ctx, d.env.GetDBClient(), cb)
 }
@@ -335,8 +343,8 @@ func (d *driver) RunUserCode(
 	if d.pipelineInfo.Details.Transform.Stdin != nil {
 		cmd.Stdin = strings.NewReader(strings.Join(d.pipelineInfo.Details.Transform.Stdin, "\n") + "\n")
 	}
-	cmd.Stdout = logger.WithUserCode()
-	cmd.Stderr = logger.WithUserCode()
+	cmd.Stdout = logger.WithUserCode(pps.LogStream_LOG_STDOUT)
+	cmd.Stderr = logger.WithUserCode(pps.LogStream_LOG_STDERR)
 	cmd.Env = environ
 	if d.uid != nil && d.gid != nil {
 		cmd.SysProcAttr = makeCmdCredentials(*d.uid, *d.gid)
@@ -357,6 +365,7 @@ func (d *driver) RunUserCode(
 	if err != nil {
 		return errors.EnsureStack(err)
 	}
+	common.RecordProcessUsage(ctx, state.UserTime(), state.SystemTime(), maxRSS(state))
 	if common.IsDone(ctx) {
 		if err = ctx.Err(); err != nil {
 			return errors.EnsureStack(err)
@@ -408,8 +417,8 @@ func (d *driver) RunUserErrorHandlingCode(
 	if d.pipelineInfo.Details.Transform.ErrStdin != nil {
 		cmd.Stdin = strings.NewReader(strings.Join(d.pipelineInfo.Details.Transform.ErrStdin, "\n") + "\n")
 	}
-	cmd.Stdout = logger.WithUserCode()
-	cmd.Stderr = logger.WithUserCode()
+	cmd.Stdout = logger.WithUserCode(pps.LogStream_LOG_STDOUT)
+	cmd.Stderr = logger.WithUserCode(pps.LogStream_LOG_STDERR)
 	cmd.Env = environ
 	if d.uid != nil && d.gid != nil {
 		cmd.SysProcAttr = makeCmdCredentials(*d.uid, *d.gid)
@@ -425,6 +434,7 @@ func (d *driver) RunUserErrorHandlingCode(
 	if err != nil {
 		return errors.EnsureStack(err)
 	}
+	common.RecordProcessUsage(ctx, state.UserTime(), state.SystemTime(), maxRSS(state))
 	if common.IsDone(ctx) {
 		if err = ctx.Err(); err != nil {
 			return errors.EnsureStack(err)
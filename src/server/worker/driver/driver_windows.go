@@ -19,6 +19,10 @@ func makeCmdCredentials(uid uint32, gid uint32) *syscall.SysProcAttr {
 	return nil
 }
 
+func maxRSS(state *os.ProcessState) int64 {
+	return 0
+}
+
 // WithActiveData is implemented differently in unix vs windows because of how
 // symlinks work on windows. Here, we move inputs into place before the
 // callback, then move them back to the scratch space before returning.
@@ -0,0 +1,90 @@
+package logs
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/common"
+)
+
+// maxCaptureSize is the most log output a Capture keeps. Older output is
+// dropped once it's exceeded.
+const maxCaptureSize = 1024 * 1024
+
+// Capture records the log output of a TaggedLogger, and of the loggers derived
+// from it, so that it can be saved alongside a datum.
+type Capture struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Reset drops the output captured so far.
+func (c *Capture) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.buf.Reset()
+}
+
+// Reader returns a reader of the output captured so far.
+func (c *Capture) Reader() io.Reader {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return bytes.NewReader(append([]byte(nil), c.buf.Bytes()...))
+}
+
+func (c *Capture) write(p []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.buf.Write(p)
+	if c.buf.Len() > maxCaptureSize {
+		c.buf.Next(c.buf.Len() - maxCaptureSize)
+	}
+}
+
+// WithCapture returns a TaggedLogger that logs to logger and also records its
+// output, and the output of the loggers derived from it, in c.
+func WithCapture(logger TaggedLogger, c *Capture) TaggedLogger {
+	return &capturingLogger{TaggedLogger: logger, capture: c}
+}
+
+type capturingLogger struct {
+	TaggedLogger
+	capture *Capture
+}
+
+func (l *capturingLogger) Write(p []byte) (int, error) {
+	l.capture.write(p)
+	return l.TaggedLogger.Write(p)
+}
+
+func (l *capturingLogger) Logf(formatString string, args ...interface{}) {
+	l.capture.write([]byte(fmt.Sprintf(formatString, args...) + "\n"))
+	l.TaggedLogger.Logf(formatString, args...)
+}
+
+func (l *capturingLogger) LogStep(name string, cb func() error) (retErr error) {
+	l.capture.write([]byte(fmt.Sprintf("started %v\n", name)))
+	defer func() {
+		if retErr != nil {
+			l.capture.write([]byte(fmt.Sprintf("errored %v: %v\n", name, retErr)))
+		} else {
+			l.capture.write([]byte(fmt.Sprintf("finished %v\n", name)))
+		}
+	}()
+	return l.TaggedLogger.LogStep(name, cb)
+}
+
+func (l *capturingLogger) WithJob(jobID string) TaggedLogger {
+	return WithCapture(l.TaggedLogger.WithJob(jobID), l.capture)
+}
+
+func (l *capturingLogger) WithData(data []*common.Input) TaggedLogger {
+	return WithCapture(l.TaggedLogger.WithData(data), l.capture)
+}
+
+func (l *capturingLogger) WithUserCode(stream pps.LogStream) TaggedLogger {
+	return WithCapture(l.TaggedLogger.WithUserCode(stream), l.capture)
+}
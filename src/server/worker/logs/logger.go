@@ -40,7 +40,7 @@ type TaggedLogger interface {
 	// includes the given metadata in log messages.
 	WithJob(jobID string) TaggedLogger
 	WithData(data []*common.Input) TaggedLogger
-	WithUserCode() TaggedLogger
+	WithUserCode(stream pps.LogStream) TaggedLogger
 
 	JobID() string
 }
@@ -133,13 +133,15 @@ func (logger *taggedLogger) WithData(data []*common.Input) TaggedLogger {
 }
 
 // WithUserCode clones the current logger and returns a new one that will
-// include the 'User' flag in log statement metadata. The 'Master' flag is set
-// to false to maintain the invariant that 'User' and 'Master' are mutually
-// exclusive, which is done to make it easier to query for specific logs.
-func (logger *taggedLogger) WithUserCode() TaggedLogger {
+// include the 'User' flag and the stream the user code writes to in log
+// statement metadata. The 'Master' flag is set to false to maintain the
+// invariant that 'User' and 'Master' are mutually exclusive, which is done to
+// make it easier to query for specific logs.
+func (logger *taggedLogger) WithUserCode(stream pps.LogStream) TaggedLogger {
 	result := logger.clone()
 	result.template.User = true
 	result.template.Master = false
+	result.template.Stream = stream
 	return result
 }
 
@@ -161,7 +163,12 @@ func (logger *taggedLogger) clone() *taggedLogger {
 //
 // Note: this is not thread-safe, as it modifies fields of 'logger.template'
 func (logger *taggedLogger) Logf(formatString string, args ...interface{}) {
-	logger.template.Message = fmt.Sprintf(formatString, args...)
+	logger.log(pps.LogLevel_LOG_INFO, fmt.Sprintf(formatString, args...))
+}
+
+func (logger *taggedLogger) log(level pps.LogLevel, message string) {
+	logger.template.Message = message
+	logger.template.Level = level
 	if ts, err := types.TimestampProto(time.Now()); err == nil {
 		logger.template.Ts = ts
 	} else {
@@ -182,7 +189,7 @@ func (logger *taggedLogger) LogStep(name string, cb func() error) (retErr error)
 	logger.Logf("started %v", name)
 	defer func() {
 		if retErr != nil {
-			logger.Logf("errored %v: %v", name, retErr)
+			logger.log(pps.LogLevel_LOG_ERROR, fmt.Sprintf("errored %v: %v", name, retErr))
 		} else {
 			logger.Logf("finished %v", name)
 		}
@@ -213,10 +220,8 @@ func (logger *taggedLogger) Write(p []byte) (_ int, retErr error) {
 			// the only error bufio.Reader can return when using a buffer.
 			return 0, errors.Wrap(err, "ReadString")
 		}
-		// We don't want to make this call as:
-		// logger.Logf(message)
-		// because if the message has format characters like %s in it those
-		// will result in errors being logged.
-		logger.Logf("%s", strings.TrimSuffix(message, "\n"))
+		// User code's messages have the level they start with, if any.
+		message = strings.TrimSuffix(message, "\n")
+		logger.log(pps.ParseLogLevel(message), message)
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
 	"github.com/pachyderm/pachyderm/v2/src/server/worker/common"
 )
 
@@ -101,7 +102,7 @@ func (ml *MockLogger) WithData(data []*common.Input) TaggedLogger {
 
 // WithUserCode duplicates the MockLogger and returns a new one tagged to
 // indicate that the log statements came from user code.
-func (ml *MockLogger) WithUserCode() TaggedLogger {
+func (ml *MockLogger) WithUserCode(stream pps.LogStream) TaggedLogger {
 	result := ml.clone()
 	result.UserCode = true
 	return result
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/logs"
+)
+
+// Service pipelines' user code may serve these endpoints on the service's
+// internal port. The worker re-exports the metrics served at
+// userCodeMetricsPath with the pipeline's labels, and restarts the user code
+// when it fails userCodeUnhealthyThreshold health checks in a row, after
+// having passed one. User code that doesn't serve an endpoint (i.e. responds
+// with 404) isn't health checked or scraped.
+const (
+	userCodeHealthPath  = "/healthz"
+	userCodeMetricsPath = "/metrics"
+)
+
+var (
+	userCodeHealthInterval     = 10 * time.Second
+	userCodeHealthTimeout      = 5 * time.Second
+	userCodeUnhealthyThreshold = 3
+)
+
+func userCodeURL(port int32, path string) string {
+	return fmt.Sprintf("http://localhost:%d%s", port, path)
+}
+
+// monitorHealth checks the health of user code at url until ctx is done, and
+// returns true if the user code became unhealthy, or false if ctx is done or
+// the user code doesn't serve a health endpoint.
+func monitorHealth(ctx context.Context, logger logs.TaggedLogger, url string) bool {
+	client := &http.Client{Timeout: userCodeHealthTimeout}
+	ticker := time.NewTicker(userCodeHealthInterval)
+	defer ticker.Stop()
+	var healthy bool
+	var failures int
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return false
+		}
+		status, err := checkHealth(ctx, client, url)
+		switch {
+		case ctx.Err() != nil:
+			return false
+		case err == nil && status == http.StatusNotFound:
+			logger.Logf("user code doesn't serve %s, not health checking it", userCodeHealthPath)
+			return false
+		case err == nil && status >= 200 && status < 300:
+			healthy, failures = true, 0
+		case !healthy:
+			// The user code may still be starting up.
+		default:
+			failures++
+			if err != nil {
+				logger.Logf("user code health check failed (%d/%d): %v", failures, userCodeUnhealthyThreshold, err)
+			} else {
+				logger.Logf("user code health check failed (%d/%d): status %d", failures, userCodeUnhealthyThreshold, status)
+			}
+			if failures >= userCodeUnhealthyThreshold {
+				return true
+			}
+		}
+	}
+}
+
+func checkHealth(ctx context.Context, client *http.Client, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, errors.EnsureStack(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, errors.EnsureStack(err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/logs"
+)
+
+func TestMonitorHealth(t *testing.T) {
+	defer func(interval time.Duration) { userCodeHealthInterval = interval }(userCodeHealthInterval)
+	userCodeHealthInterval = time.Millisecond
+
+	// User code that stops passing health checks is unhealthy.
+	var checks int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&checks, 1) > 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	require.True(t, monitorHealth(context.Background(), logs.NewMockLogger(), server.URL))
+	require.Equal(t, int32(2+userCodeUnhealthyThreshold), atomic.LoadInt32(&checks))
+
+	// User code without a health endpoint isn't monitored.
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+	require.False(t, monitorHealth(context.Background(), logs.NewMockLogger(), notFound.URL))
+
+	// User code that hasn't passed a health check yet may still be starting.
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.False(t, monitorHealth(ctx, logs.NewMockLogger(), unavailable.URL))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"path/filepath"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/pachyderm/pachyderm/v2/src/client"
@@ -17,6 +18,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/server/worker/datum"
 	"github.com/pachyderm/pachyderm/v2/src/server/worker/driver"
 	"github.com/pachyderm/pachyderm/v2/src/server/worker/logs"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/stats"
 )
 
 // Run will run a service pipeline until the driver is canceled.
@@ -25,6 +27,9 @@ import (
 func Run(driver driver.Driver, logger logs.TaggedLogger) error {
 	pachClient := driver.PachClient()
 	pipelineInfo := driver.PipelineInfo()
+	if port := pipelineInfo.Details.Service.InternalPort; port != 0 {
+		stats.ExportUserCodeMetrics(userCodeURL(port, userCodeMetricsPath), prometheus.Labels{"pipeline": pipelineInfo.Pipeline.Name})
+	}
 	return forEachJob(pachClient, pipelineInfo, logger, func(ctx context.Context, jobInfo *pps.JobInfo) (retErr error) {
 		driver := driver.WithContext(ctx)
 		if err := driver.UpdateJobState(jobInfo.Job, pps.JobState_JOB_RUNNING, ""); err != nil {
@@ -65,7 +70,7 @@ func Run(driver driver.Driver, logger logs.TaggedLogger) error {
 				return s.WithDatum(meta, func(d *datum.Datum) error {
 					err := driver.WithActiveData(inputs, d.PFSStorageRoot(), func() error {
 						return d.Run(ctx, func(runCtx context.Context) error {
-							return errors.EnsureStack(driver.RunUserCode(runCtx, logger, env))
+							return runUserCode(runCtx, driver, logger, env)
 						})
 					})
 					return errors.EnsureStack(err)
@@ -76,6 +81,33 @@ func Run(driver driver.Driver, logger logs.TaggedLogger) error {
 	})
 }
 
+// runUserCode runs the service's user code, restarting it whenever it becomes
+// unhealthy.
+func runUserCode(ctx context.Context, driver driver.Driver, logger logs.TaggedLogger, env []string) error {
+	port := driver.PipelineInfo().Details.Service.InternalPort
+	for {
+		userCtx, cancel := context.WithCancel(ctx)
+		unhealthy := make(chan bool, 1)
+		go func() {
+			if port == 0 {
+				unhealthy <- false
+				return
+			}
+			isUnhealthy := monitorHealth(userCtx, logger, userCodeURL(port, userCodeHealthPath))
+			if isUnhealthy {
+				cancel()
+			}
+			unhealthy <- isUnhealthy
+		}()
+		err := driver.RunUserCode(userCtx, logger, env)
+		cancel()
+		if !<-unhealthy || ctx.Err() != nil {
+			return errors.EnsureStack(err)
+		}
+		logger.Logf("restarting user code, it failed %d health checks in a row", userCodeUnhealthyThreshold)
+	}
+}
+
 // Repeatedly runs the given callback with the latest job for the pipeline.
 // The given context will be canceled if a newer job is ready, then this will
 // wait for the previous callback to return before calling the callback again
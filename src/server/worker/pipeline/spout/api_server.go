@@ -0,0 +1,236 @@
+package spout
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/renew"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/logs"
+)
+
+// apiServer implements the Spout API. The files written to an open commit are
+// kept in temporary file sets, which are only added to the spout's output
+// branch when the commit is finished.
+type apiServer struct {
+	pachClient *client.APIClient
+	branch     *pfs.Branch
+	logger     logs.TaggedLogger
+
+	mu   sync.Mutex
+	open *openCommit
+}
+
+type openCommit struct {
+	description string
+	// fileSets renews the file sets written to the commit until it's
+	// finished or aborted.
+	fileSets *renew.StringSet
+	empty    bool
+}
+
+func newAPIServer(pachClient *client.APIClient, branch *pfs.Branch, logger logs.TaggedLogger) *apiServer {
+	return &apiServer{
+		pachClient: pachClient,
+		branch:     branch,
+		logger:     logger,
+	}
+}
+
+// StartCommit implements the protobuf spout.StartCommit RPC
+func (a *apiServer) StartCommit(ctx context.Context, request *StartCommitRequest) (*types.Empty, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.open != nil {
+		return nil, errors.New("a commit is already open; finish or abort it first")
+	}
+	rf := func(ctx context.Context, p string, ttl time.Duration) error {
+		return a.pachClient.WithCtx(ctx).RenewFileSet(p, ttl)
+	}
+	cf := func(ctx context.Context, ps []string, ttl time.Duration) (string, error) {
+		return a.pachClient.WithCtx(ctx).ComposeFileSet(ps, ttl)
+	}
+	a.open = &openCommit{
+		description: request.Description,
+		fileSets:    renew.NewStringSet(a.pachClient.Ctx(), client.DefaultTTL, rf, cf),
+		empty:       true,
+	}
+	return &types.Empty{}, nil
+}
+
+// PutFile implements the protobuf spout.PutFile RPC
+func (a *apiServer) PutFile(server Spout_PutFileServer) error {
+	open, err := a.openCommit()
+	if err != nil {
+		return err
+	}
+	resp, err := a.pachClient.WithCtx(server.Context()).WithCreateFileSetClient(func(mf client.ModifyFile) error {
+		var path string
+		for {
+			request, err := server.Recv()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				return errors.EnsureStack(err)
+			}
+			var opts []client.PutFileOption
+			// consecutive requests for a path write consecutive parts of it
+			if request.Append || request.Path == path {
+				opts = append(opts, client.WithAppendPutFile())
+			}
+			path = request.Path
+			if err := mf.PutFile(request.Path, bytes.NewReader(request.Data), opts...); err != nil {
+				return errors.EnsureStack(err)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if err := a.addFileSet(server.Context(), open, resp.FileSetId); err != nil {
+		return err
+	}
+	return errors.EnsureStack(server.SendAndClose(&types.Empty{}))
+}
+
+// DeleteFile implements the protobuf spout.DeleteFile RPC
+func (a *apiServer) DeleteFile(ctx context.Context, request *DeleteFileRequest) (*types.Empty, error) {
+	open, err := a.openCommit()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.pachClient.WithCtx(ctx).WithCreateFileSetClient(func(mf client.ModifyFile) error {
+		return errors.EnsureStack(mf.DeleteFile(request.Path))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := a.addFileSet(ctx, open, resp.FileSetId); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// FinishCommit implements the protobuf spout.FinishCommit RPC
+func (a *apiServer) FinishCommit(ctx context.Context, request *FinishCommitRequest) (response *FinishCommitResponse, retErr error) {
+	open, err := a.takeOpenCommit()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := open.fileSets.Close(); retErr == nil {
+			retErr = err
+		}
+	}()
+	description := open.description
+	if request.Description != "" {
+		description = request.Description
+	}
+	pachClient := a.pachClient.WithCtx(ctx)
+	// compose the commit's file sets first, so that nothing is committed if
+	// that fails
+	var fileSet string
+	if !open.empty {
+		fileSet, err = open.fileSets.Compose(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	commit, err := pachClient.PfsAPIClient.StartCommit(ctx, &pfs.StartCommitRequest{
+		Branch:      a.branch,
+		Description: description,
+	})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	finish := &pfs.FinishCommitRequest{Commit: commit}
+	if fileSet != "" {
+		if err := pachClient.AddFileSet(a.branch.Repo.Name, a.branch.Name, commit.ID, fileSet); err != nil {
+			// the commit is finished with the error, rather than with some
+			// of its files
+			finish.Error = err.Error()
+			if _, finishErr := pachClient.PfsAPIClient.FinishCommit(ctx, finish); finishErr != nil {
+				a.logger.Logf("could not finish failed spout commit %s: %v", commit.ID, finishErr)
+			}
+			return nil, err
+		}
+	}
+	if _, err := pachClient.PfsAPIClient.FinishCommit(ctx, finish); err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return &FinishCommitResponse{Commit: commit}, nil
+}
+
+// AbortCommit implements the protobuf spout.AbortCommit RPC
+func (a *apiServer) AbortCommit(ctx context.Context, request *AbortCommitRequest) (*types.Empty, error) {
+	open, err := a.takeOpenCommit()
+	if err != nil {
+		return nil, err
+	}
+	if request.Error != "" {
+		a.logger.Logf("spout aborted its commit: %s", request.Error)
+	} else {
+		a.logger.Logf("spout aborted its commit")
+	}
+	// the commit's file sets expire once they're no longer renewed
+	if err := open.fileSets.Close(); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+func (a *apiServer) openCommit() (*openCommit, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.open == nil {
+		return nil, errors.New("no commit is open; start one first")
+	}
+	return a.open, nil
+}
+
+func (a *apiServer) takeOpenCommit() (*openCommit, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.open == nil {
+		return nil, errors.New("no commit is open; start one first")
+	}
+	open := a.open
+	a.open = nil
+	return open, nil
+}
+
+// addFileSet adds a file set to open. It fails if open has been finished or
+// aborted since the file set was written.
+func (a *apiServer) addFileSet(ctx context.Context, open *openCommit, id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.open != open {
+		return errors.New("the commit was finished or aborted while the files were written")
+	}
+	if err := open.fileSets.Add(ctx, id); err != nil {
+		return err
+	}
+	open.empty = false
+	return nil
+}
+
+// close aborts the open commit, if there is one.
+func (a *apiServer) close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.open == nil {
+		return nil
+	}
+	err := a.open.fileSets.Close()
+	a.open = nil
+	return err
+}
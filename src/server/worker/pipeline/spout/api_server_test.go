@@ -0,0 +1,105 @@
+package spout
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dockertestenv"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/internal/testpachd"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/logs"
+)
+
+func newTestClient(t *testing.T) (*client.APIClient, SpoutClient) {
+	env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+	require.NoError(t, env.PachClient.CreateRepo("spout"))
+	api := newAPIServer(env.PachClient, client.NewBranch("spout", "master"), logs.NewMockLogger())
+	t.Cleanup(func() { require.NoError(t, api.close()) })
+	socket := filepath.Join(t.TempDir(), "spout.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	RegisterSpoutServer(server, api)
+	go server.Serve(listener) //nolint:errcheck
+	t.Cleanup(server.Stop)
+	conn, err := grpc.Dial("unix://"+socket, grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return env.PachClient, NewSpoutClient(conn)
+}
+
+func putFiles(ctx context.Context, t *testing.T, c SpoutClient, requests ...*PutFileRequest) {
+	put, err := c.PutFile(ctx)
+	require.NoError(t, err)
+	for _, request := range requests {
+		require.NoError(t, put.Send(request))
+	}
+	_, err = put.CloseAndRecv()
+	require.NoError(t, err)
+}
+
+func TestFinishCommit(t *testing.T) {
+	t.Parallel()
+	pachClient, c := newTestClient(t)
+	ctx := pachClient.Ctx()
+	_, err := c.StartCommit(ctx, &StartCommitRequest{Description: "first"})
+	require.NoError(t, err)
+	putFiles(ctx, t, c,
+		&PutFileRequest{Path: "/a", Data: []byte("foo")},
+		&PutFileRequest{Path: "/a", Data: []byte("bar")},
+		&PutFileRequest{Path: "/b", Data: []byte("baz")},
+	)
+	// nothing is committed until the commit is finished
+	commits, err := pachClient.ListCommit(client.NewRepo("spout"), nil, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(commits))
+	resp, err := c.FinishCommit(ctx, &FinishCommitRequest{})
+	require.NoError(t, err)
+	commitInfo, err := pachClient.InspectCommit("spout", "master", resp.Commit.ID)
+	require.NoError(t, err)
+	require.Equal(t, "first", commitInfo.Description)
+	buf := &bytes.Buffer{}
+	require.NoError(t, pachClient.GetFile(resp.Commit, "/a", buf))
+	require.Equal(t, "foobar", buf.String())
+
+	// the next commit appends to and deletes from the previous one
+	_, err = c.StartCommit(ctx, &StartCommitRequest{})
+	require.NoError(t, err)
+	putFiles(ctx, t, c, &PutFileRequest{Path: "/a", Data: []byte("qux"), Append: true})
+	_, err = c.DeleteFile(ctx, &DeleteFileRequest{Path: "/b"})
+	require.NoError(t, err)
+	resp, err = c.FinishCommit(ctx, &FinishCommitRequest{})
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, pachClient.GetFile(resp.Commit, "/a", buf))
+	require.Equal(t, "foobarqux", buf.String())
+	fileInfos, err := pachClient.ListFileAll(resp.Commit, "/")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(fileInfos))
+}
+
+func TestAbortCommit(t *testing.T) {
+	t.Parallel()
+	pachClient, c := newTestClient(t)
+	ctx := pachClient.Ctx()
+	_, err := c.StartCommit(ctx, &StartCommitRequest{})
+	require.NoError(t, err)
+	// only one commit can be open at a time
+	_, err = c.StartCommit(ctx, &StartCommitRequest{})
+	require.YesError(t, err)
+	putFiles(ctx, t, c, &PutFileRequest{Path: "/a", Data: []byte("foo")})
+	_, err = c.AbortCommit(ctx, &AbortCommitRequest{Error: "source unavailable"})
+	require.NoError(t, err)
+	commits, err := pachClient.ListCommit(client.NewRepo("spout"), nil, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(commits))
+	// the aborted commit can't be written to or finished
+	_, err = c.FinishCommit(ctx, &FinishCommitRequest{})
+	require.YesError(t, err)
+}
@@ -1,13 +1,54 @@
 package spout
 
 import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/grpc"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
 	"github.com/pachyderm/pachyderm/v2/src/server/worker/driver"
 	"github.com/pachyderm/pachyderm/v2/src/server/worker/logs"
 )
 
+// SocketEnv is the environment variable that holds the path of the unix
+// socket that the Spout API is served on to the spout's code.
+const SocketEnv = "PACH_SPOUT_SOCKET"
+
 // Run will run a spout pipeline until the driver is canceled.
-func Run(driver driver.Driver, logger logs.TaggedLogger) error {
+func Run(driver driver.Driver, logger logs.TaggedLogger) (retErr error) {
 	logger = logger.WithJob("spout")
-	return errors.EnsureStack(driver.RunUserCode(driver.PachClient().Ctx(), logger, nil))
+	pachClient := driver.PachClient()
+	pipelineInfo := driver.PipelineInfo()
+	api := newAPIServer(pachClient, client.NewBranch(pipelineInfo.Pipeline.Name, pipelineInfo.Details.OutputBranch), logger)
+	defer func() {
+		if err := api.close(); retErr == nil {
+			retErr = err
+		}
+	}()
+	socket := filepath.Join(os.TempDir(), "pachyderm-spout.sock")
+	if err := os.Remove(socket); err != nil && !os.IsNotExist(err) {
+		return errors.EnsureStack(err)
+	}
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	// the spout's code may run as a different user than the worker
+	if err := os.Chmod(socket, 0777); err != nil {
+		return errors.EnsureStack(err)
+	}
+	server := grpc.NewServer(
+		grpc.MaxRecvMsgSize(grpcutil.MaxMsgSize),
+		grpc.MaxSendMsgSize(grpcutil.MaxMsgSize),
+	)
+	RegisterSpoutServer(server, api)
+	go server.Serve(listener) //nolint:errcheck
+	defer server.Stop()
+	env := append(os.Environ(), fmt.Sprintf("%s=%s", SocketEnv, socket))
+	return errors.EnsureStack(driver.RunUserCode(pachClient.Ctx(), logger, env))
 }
@@ -0,0 +1,55 @@
+package transform
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsdb"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/common"
+)
+
+// datumCheckpoints checkpoints the output of the datums a job processes, so
+// that when a datum set is restarted on another worker, because its worker
+// was lost, the datums that were processed are restored from their
+// checkpoints rather than processed again. Checkpoints are stored like the
+// entries of the datum cache, and are cleared with the job's task cache.
+type datumCheckpoints struct {
+	*datumCache
+}
+
+func newDatumCheckpoints(pachClient *client.APIClient, job *pps.Job) *datumCheckpoints {
+	return &datumCheckpoints{&datumCache{
+		pachClient: pachClient,
+		tag:        ppsdb.JobKey(job) + "/datum-checkpoints/",
+	}}
+}
+
+// key returns the key of the checkpoint of the datum with inputs.
+func (c *datumCheckpoints) key(inputs []*common.Input) string {
+	return c.tag + common.DatumID(inputs)
+}
+
+// start records that the datum set with fileSetID is being processed, and
+// returns whether it was started before, by a worker that was lost.
+func (c *datumCheckpoints) start(ctx context.Context, fileSetID string) (bool, error) {
+	pachClient := c.pachClient.WithCtx(ctx)
+	key := c.tag + "datum-sets/" + fileSetID
+	if _, err := pachClient.PfsAPIClient.GetCache(pachClient.Ctx(), &pfs.GetCacheRequest{Key: key}); err == nil {
+		return true, nil
+	}
+	value, err := types.MarshalAny(&DatumCacheEntry{})
+	if err != nil {
+		return false, errors.EnsureStack(err)
+	}
+	_, err = pachClient.PfsAPIClient.PutCache(pachClient.Ctx(), &pfs.PutCacheRequest{
+		Key:   key,
+		Value: value,
+		Tag:   c.tag,
+	})
+	return false, errors.EnsureStack(err)
+}
@@ -0,0 +1,28 @@
+package transform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/common"
+)
+
+func TestDatumCheckpointKey(t *testing.T) {
+	inputs := func(path string) []*common.Input {
+		return []*common.Input{{
+			Name:     "input",
+			FileInfo: &pfs.FileInfo{File: client.NewCommit("input", "master", "").NewFile(path)},
+		}}
+	}
+	job := client.NewJob("pipeline", "1")
+	key := newDatumCheckpoints(nil, job).key(inputs("/a"))
+	// Checkpoints are cleared with the job's task cache.
+	require.True(t, strings.HasPrefix(key, ppsdb.JobKey(job)))
+	require.Equal(t, key, newDatumCheckpoints(nil, job).key(inputs("/a")))
+	require.NotEqual(t, key, newDatumCheckpoints(nil, job).key(inputs("/b")))
+	require.NotEqual(t, key, newDatumCheckpoints(nil, client.NewJob("pipeline", "2")).key(inputs("/a")))
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/server/worker/common"
 	"github.com/pachyderm/pachyderm/v2/src/server/worker/driver"
 	"github.com/pachyderm/pachyderm/v2/src/server/worker/logs"
+	"k8s.io/client-go/kubernetes"
 )
 
 // Set this to true to enable worker log statements to go to stdout
@@ -90,6 +91,9 @@ func (td *testDriver) InputDir() string {
 func (td *testDriver) PachClient() *client.APIClient {
 	return td.inner.PachClient()
 }
+func (td *testDriver) KubeClient() kubernetes.Interface {
+	return td.inner.KubeClient()
+}
 func (td *testDriver) ExpectedNumWorkers() (int64, error) {
 	res, err := td.inner.ExpectedNumWorkers()
 	return res, errors.EnsureStack(err)
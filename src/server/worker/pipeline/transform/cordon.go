@@ -0,0 +1,98 @@
+package transform
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/watch"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// cordoner holds back the jobs of a registry that haven't started while the
+// pipeline is cordoned.
+type cordoner struct {
+	mu       sync.Mutex
+	cordoned bool
+	// uncordoned is closed when the pipeline is uncordoned.
+	uncordoned chan struct{}
+}
+
+func newCordoner(cordoned bool) *cordoner {
+	c := &cordoner{}
+	c.set(cordoned)
+	return c
+}
+
+func (c *cordoner) set(cordoned bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch {
+	case cordoned && !c.cordoned:
+		c.uncordoned = make(chan struct{})
+	case !cordoned && c.cordoned:
+		close(c.uncordoned)
+	}
+	c.cordoned = cordoned
+}
+
+// wait returns once the pipeline isn't cordoned, or ctx is done.
+func (c *cordoner) wait(ctx context.Context) error {
+	c.mu.Lock()
+	cordoned, uncordoned := c.cordoned, c.uncordoned
+	c.mu.Unlock()
+	if !cordoned {
+		return nil
+	}
+	select {
+	case <-uncordoned:
+		return nil
+	case <-ctx.Done():
+		return errors.EnsureStack(ctx.Err())
+	}
+}
+
+// waitUncordoned holds back jobInfo's job, if it hasn't started, until the
+// pipeline is uncordoned. Jobs that have started keep running. It returns the
+// job's latest info, or nil if the job finished while it was held back.
+func (reg *registry) waitUncordoned(ctx context.Context, jobInfo *pps.JobInfo) (*pps.JobInfo, error) {
+	reg.cordoner.mu.Lock()
+	cordoned := reg.cordoner.cordoned
+	reg.cordoner.mu.Unlock()
+	if !cordoned || jobInfo.State != pps.JobState_JOB_CREATED {
+		return jobInfo, nil
+	}
+	reg.logger.Logf("job %s waits to start until the pipeline is uncordoned", jobInfo.Job.ID)
+	if err := reg.cordoner.wait(ctx); err != nil {
+		return nil, err
+	}
+	// the job may have been killed or deleted while it was held back
+	jobInfo, err := reg.driver.PachClient().WithCtx(ctx).InspectJob(jobInfo.Job.Pipeline.Name, jobInfo.Job.ID, true)
+	if err != nil {
+		if errutil.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.EnsureStack(err)
+	}
+	if pps.IsTerminal(jobInfo.State) {
+		return nil, nil
+	}
+	return jobInfo, nil
+}
+
+// watchCordon follows whether the pipeline is cordoned with CordonPipeline.
+func (reg *registry) watchCordon(ctx context.Context) error {
+	return errors.EnsureStack(reg.driver.Pipelines().ReadOnly(ctx).WatchOneF(reg.driver.PipelineInfo().SpecCommit, func(ev *watch.Event) error {
+		if ev.Type == watch.EventDelete {
+			return nil
+		}
+		var key string
+		pipelineInfo := &pps.PipelineInfo{}
+		if err := ev.Unmarshal(&key, pipelineInfo); err != nil {
+			return errors.Wrapf(err, "unmarshal")
+		}
+		reg.cordoner.set(pipelineInfo.Cordon != nil)
+		return nil
+	}))
+}
@@ -0,0 +1,29 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestCordoner(t *testing.T) {
+	c := newCordoner(false)
+	require.NoError(t, c.wait(context.Background()))
+
+	c.set(true)
+	// setting the same state twice is a no-op
+	c.set(true)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.YesError(t, c.wait(ctx))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.wait(context.Background())
+	}()
+	c.set(false)
+	require.NoError(t, <-done)
+	c.set(false)
+	require.NoError(t, c.wait(context.Background()))
+}
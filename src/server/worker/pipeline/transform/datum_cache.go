@@ -0,0 +1,151 @@
+package transform
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/tarutil"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/common"
+)
+
+// datumCache caches the output of a pipeline's datums, so that datums that
+// are processed again with the same input files, command and image reuse
+// their output rather than running the pipeline's code.
+type datumCache struct {
+	pachClient *client.APIClient
+	tag        string
+	transform  *pps.Transform
+	imageID    string
+}
+
+func newDatumCache(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo, imageID string) *datumCache {
+	return &datumCache{
+		pachClient: pachClient,
+		tag:        ppsutil.DatumCacheTag(pipelineInfo.Pipeline.Name),
+		transform:  pipelineInfo.Details.Transform,
+		imageID:    imageID,
+	}
+}
+
+// key returns the key of the cache entry for the datum with inputs.
+func (c *datumCache) key(inputs []*common.Input) string {
+	hash := pfs.NewHash()
+	hash.Write([]byte(common.HashDatum("", inputs)))
+	for _, strs := range [][]string{c.transform.Cmd, c.transform.Stdin} {
+		binary.Write(hash, binary.BigEndian, int64(len(strs)))
+		for _, s := range strs {
+			hash.Write([]byte(s))
+			binary.Write(hash, binary.BigEndian, int64(len(s)))
+		}
+	}
+	hash.Write([]byte(c.imageID))
+	return c.tag + hex.EncodeToString(hash.Sum(nil))
+}
+
+// restore writes the cached output of the datum with key to outputDir, and
+// returns whether its output was cached. If it fails, outputDir is left
+// empty.
+func (c *datumCache) restore(ctx context.Context, key string, outputDir string) (_ bool, retErr error) {
+	pachClient := c.pachClient.WithCtx(ctx)
+	resp, err := pachClient.PfsAPIClient.GetCache(pachClient.Ctx(), &pfs.GetCacheRequest{Key: key})
+	if err != nil {
+		// The cache returns an error for keys it doesn't have.
+		return false, nil
+	}
+	entry := &DatumCacheEntry{}
+	if err := types.UnmarshalAny(resp.Value, entry); err != nil {
+		return false, errors.EnsureStack(err)
+	}
+	if entry.FileSetId == "" {
+		// The datum had no output.
+		return true, nil
+	}
+	r, err := pachClient.GetFileTAR(client.NewRepo(client.FileSetsRepoName).NewCommit("", entry.FileSetId), "/")
+	if err != nil {
+		return false, err
+	}
+	defer r.Close()
+	defer func() {
+		if retErr != nil {
+			if err := os.RemoveAll(outputDir); err != nil {
+				retErr = errors.EnsureStack(err)
+				return
+			}
+			if err := os.MkdirAll(outputDir, 0777); err != nil {
+				retErr = errors.EnsureStack(err)
+			}
+		}
+	}()
+	if err := tarutil.Import(outputDir, r); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// save caches the output of the datum with key, which is in outputDir.
+// Outputs that contain symlinks aren't cached, as they may link to the
+// datum's inputs rather than holding its output.
+func (c *datumCache) save(ctx context.Context, key string, outputDir string) error {
+	var hasFiles, hasSymlinks bool
+	if err := filepath.Walk(outputDir, func(file string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		hasFiles = hasFiles || fi.Mode().IsRegular()
+		hasSymlinks = hasSymlinks || fi.Mode()&os.ModeSymlink != 0
+		return nil
+	}); err != nil {
+		return err
+	}
+	if hasSymlinks {
+		return nil
+	}
+	pachClient := c.pachClient.WithCtx(ctx)
+	entry := &DatumCacheEntry{}
+	var fileSetIDs []string
+	if hasFiles {
+		resp, err := pachClient.WithCreateFileSetClient(func(mf client.ModifyFile) error {
+			return miscutil.WithPipe(func(w io.Writer) (retErr error) {
+				bufW := bufio.NewWriterSize(w, grpcutil.MaxMsgPayloadSize)
+				defer func() {
+					if err := bufW.Flush(); retErr == nil {
+						retErr = errors.EnsureStack(err)
+					}
+				}()
+				return tarutil.Export(outputDir, bufW)
+			}, func(r io.Reader) error {
+				return errors.EnsureStack(mf.PutFileTAR(r))
+			})
+		})
+		if err != nil {
+			return err
+		}
+		entry.FileSetId = resp.FileSetId
+		fileSetIDs = append(fileSetIDs, resp.FileSetId)
+	}
+	value, err := types.MarshalAny(entry)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	_, err = pachClient.PfsAPIClient.PutCache(pachClient.Ctx(), &pfs.PutCacheRequest{
+		Key:        key,
+		Value:      value,
+		FileSetIds: fileSetIDs,
+		Tag:        c.tag,
+	})
+	return errors.EnsureStack(err)
+}
@@ -0,0 +1,39 @@
+package transform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/common"
+)
+
+func TestDatumCacheKey(t *testing.T) {
+	pipelineInfo := func(cmd ...string) *pps.PipelineInfo {
+		return &pps.PipelineInfo{
+			Pipeline: client.NewPipeline("pipeline"),
+			Details:  &pps.PipelineInfo_Details{Transform: &pps.Transform{Cmd: cmd}},
+		}
+	}
+	inputs := func(hash string) []*common.Input {
+		return []*common.Input{{
+			Name: "input",
+			FileInfo: &pfs.FileInfo{
+				File: client.NewCommit("input", "master", "").NewFile("/file"),
+				Hash: []byte(hash),
+			},
+		}}
+	}
+	key := newDatumCache(nil, pipelineInfo("cat", "/pfs/input/file"), "image@sha256:1").key(inputs("a"))
+	require.True(t, strings.HasPrefix(key, ppsutil.DatumCacheTag("pipeline")))
+	// The key is the same for the same inputs, command and image.
+	require.Equal(t, key, newDatumCache(nil, pipelineInfo("cat", "/pfs/input/file"), "image@sha256:1").key(inputs("a")))
+	// And changes with any of them.
+	require.NotEqual(t, key, newDatumCache(nil, pipelineInfo("cat", "/pfs/input/file"), "image@sha256:1").key(inputs("b")))
+	require.NotEqual(t, key, newDatumCache(nil, pipelineInfo("cat /pfs/input/file"), "image@sha256:1").key(inputs("a")))
+	require.NotEqual(t, key, newDatumCache(nil, pipelineInfo("cat", "/pfs/input/file"), "image@sha256:2").key(inputs("a")))
+}
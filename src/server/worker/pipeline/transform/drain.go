@@ -0,0 +1,128 @@
+package transform
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/watch"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// errDrained stops the processing of a job when its pipeline is drained. The
+// job isn't finished, and the datum sets it has processed are in its task
+// cache, so it continues from there when the pipeline is started again.
+var errDrained = errors.New("pipeline drained")
+
+// drainer tracks the running jobs of a registry, so that the pipeline's drain
+// can be completed once they've stopped.
+type drainer struct {
+	mu      sync.Mutex
+	running int
+	// draining is closed when the pipeline starts draining, and drained is
+	// closed once it's draining and no job is running.
+	draining, drained chan struct{}
+}
+
+func newDrainer() *drainer {
+	return &drainer{
+		draining: make(chan struct{}),
+		drained:  make(chan struct{}),
+	}
+}
+
+func (d *drainer) isDraining() bool {
+	select {
+	case <-d.draining:
+		return true
+	default:
+		return false
+	}
+}
+
+// jobStarted records that a job is running. It returns false, and doesn't
+// record the job, if the pipeline is draining, as jobs mustn't start then.
+func (d *drainer) jobStarted() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.isDraining() {
+		return false
+	}
+	d.running++
+	return true
+}
+
+func (d *drainer) jobStopped() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.running--
+	if d.running == 0 && d.isDraining() {
+		close(d.drained)
+	}
+}
+
+func (d *drainer) drain() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.isDraining() {
+		return
+	}
+	close(d.draining)
+	if d.running == 0 {
+		close(d.drained)
+	}
+}
+
+// watchDrain drains the registry's jobs when the pipeline is stopped with
+// StopPipelineRequest.drain. If the pipeline is started before the drain
+// completes, it returns an error, so that the master restarts and continues
+// the jobs.
+func (reg *registry) watchDrain(ctx context.Context) error {
+	return errors.EnsureStack(reg.driver.Pipelines().ReadOnly(ctx).WatchOneF(reg.driver.PipelineInfo().SpecCommit, func(ev *watch.Event) error {
+		if ev.Type == watch.EventDelete {
+			return nil
+		}
+		var key string
+		pipelineInfo := &pps.PipelineInfo{}
+		if err := ev.Unmarshal(&key, pipelineInfo); err != nil {
+			return errors.Wrapf(err, "unmarshal")
+		}
+		switch {
+		case pipelineInfo.Drain != nil:
+			if !reg.drainer.isDraining() {
+				reg.logger.Logf("pipeline is draining, finishing the datums being processed")
+				reg.drainer.drain()
+			}
+		case reg.drainer.isDraining():
+			return errors.New("pipeline was started while draining, restarting to continue its jobs")
+		}
+		return nil
+	}))
+}
+
+// completeDrain marks the pipeline's drain as complete once its jobs have
+// stopped.
+func (reg *registry) completeDrain(ctx context.Context) error {
+	select {
+	case <-reg.drainer.drained:
+	case <-ctx.Done():
+		return errors.EnsureStack(ctx.Err())
+	}
+	if err := reg.driver.NewSQLTx(func(sqlTx *pachsql.Tx) error {
+		pipelineInfo := &pps.PipelineInfo{}
+		return errors.EnsureStack(reg.driver.Pipelines().ReadWrite(sqlTx).Update(reg.driver.PipelineInfo().SpecCommit, pipelineInfo, func() error {
+			if pipelineInfo.Drain != nil && pipelineInfo.Drain.State == pps.DrainState_DRAIN_IN_PROGRESS {
+				pipelineInfo.Drain.State = pps.DrainState_DRAIN_COMPLETE
+				pipelineInfo.Drain.Finished = types.TimestampNow()
+			}
+			return nil
+		}))
+	}); err != nil {
+		return err
+	}
+	reg.logger.Logf("pipeline drained, its jobs continue from their checkpoints when it's started")
+	return nil
+}
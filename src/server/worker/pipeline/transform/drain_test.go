@@ -0,0 +1,33 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestDrainer(t *testing.T) {
+	d := newDrainer()
+	require.True(t, d.jobStarted())
+	require.True(t, d.jobStarted())
+	d.drain()
+	require.True(t, d.isDraining())
+	// jobs can't start while the pipeline is draining
+	require.False(t, d.jobStarted())
+	d.jobStopped()
+	select {
+	case <-d.drained:
+		t.Fatal("drained with a job running")
+	default:
+	}
+	d.jobStopped()
+	<-d.drained
+	// draining again is a no-op
+	d.drain()
+}
+
+func TestDrainerIdle(t *testing.T) {
+	d := newDrainer()
+	d.drain()
+	<-d.drained
+}
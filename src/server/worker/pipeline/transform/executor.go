@@ -0,0 +1,219 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/renew"
+	"github.com/pachyderm/pachyderm/v2/src/internal/tarutil"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/common"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/driver"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/logs"
+)
+
+// An executor runs the user code of datums outside of the worker.
+type executor interface {
+	// run runs the user code of a datum of a job, with the datum's inputs in
+	// the file set inputFileSetID, and returns the file set with the datum's
+	// output, or "" if it has none.
+	run(ctx context.Context, logger logs.TaggedLogger, jobID, datumID, inputFileSetID string) (string, error)
+}
+
+// newExecutor returns the executor of the pipeline, or nil if the worker runs
+// the pipeline's user code.
+func newExecutor(driver driver.Driver) executor {
+	spec := driver.PipelineInfo().Details.Executor
+	if spec.GetArgo() != nil {
+		return newArgoExecutor(driver, spec.Argo)
+	}
+	return nil
+}
+
+// runExecutor runs the user code of the datum with inputs with exec, and
+// writes the datum's output to outputDir.
+func runExecutor(ctx context.Context, pachClient *client.APIClient, renewer *renew.StringSet, exec executor, logger logs.TaggedLogger, inputs []*common.Input, outputDir string) error {
+	pachClient = pachClient.WithCtx(ctx)
+	// The inputs are laid out in the file set the way they are in /pfs.
+	resp, err := pachClient.WithCreateFileSetClient(func(mf client.ModifyFile) error {
+		for _, input := range inputs {
+			if err := mf.CopyFile(path.Join(input.Name, input.FileInfo.File.Path), input.FileInfo.File); err != nil {
+				return errors.EnsureStack(err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := renewer.Add(ctx, resp.FileSetId); err != nil {
+		return err
+	}
+	outputFileSetID, err := exec.run(ctx, logger, logger.JobID(), common.DatumID(inputs), resp.FileSetId)
+	if err != nil {
+		return err
+	}
+	if outputFileSetID == "" {
+		return nil
+	}
+	r, err := pachClient.GetFileTAR(client.NewRepo(client.FileSetsRepoName).NewCommit("", outputFileSetID), "/")
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return tarutil.Import(outputDir, r)
+}
+
+// The parameters passed to, and returned by, the workflows of an
+// argoExecutor.
+const (
+	argoInputFileSetParam  = "input-file-set"
+	argoJobParam           = "job"
+	argoDatumParam         = "datum"
+	argoSecretParam        = "pachctl-secret"
+	argoOutputFileSetParam = "output-file-set"
+)
+
+// argoExecutor runs each datum as an Argo Workflow, submitted from the
+// pipeline's WorkflowTemplate.
+type argoExecutor struct {
+	kubeClient   kubernetes.Interface
+	spec         *pps.ArgoExecutor
+	namespace    string
+	pipeline     string
+	pollInterval time.Duration
+}
+
+func newArgoExecutor(driver driver.Driver, spec *pps.ArgoExecutor) *argoExecutor {
+	namespace := spec.Namespace
+	if namespace == "" {
+		namespace = driver.Namespace()
+	}
+	return &argoExecutor{
+		kubeClient:   driver.KubeClient(),
+		spec:         spec,
+		namespace:    namespace,
+		pipeline:     driver.PipelineInfo().Pipeline.Name,
+		pollInterval: pps.ArgoPollInterval(spec),
+	}
+}
+
+// argoWorkflow holds the fields of an Argo Workflow that the executor uses.
+type argoWorkflow struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name         string            `json:"name,omitempty"`
+		GenerateName string            `json:"generateName,omitempty"`
+		Labels       map[string]string `json:"labels,omitempty"`
+	} `json:"metadata"`
+	Spec struct {
+		WorkflowTemplateRef struct {
+			Name string `json:"name"`
+		} `json:"workflowTemplateRef"`
+		Arguments struct {
+			Parameters []argoParameter `json:"parameters"`
+		} `json:"arguments"`
+	} `json:"spec"`
+	Status struct {
+		Phase   string              `json:"phase,omitempty"`
+		Message string              `json:"message,omitempty"`
+		Nodes   map[string]argoNode `json:"nodes,omitempty"`
+	} `json:"status,omitempty"`
+}
+
+type argoNode struct {
+	Outputs struct {
+		Parameters []argoParameter `json:"parameters,omitempty"`
+	} `json:"outputs,omitempty"`
+}
+
+type argoParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (e *argoExecutor) run(ctx context.Context, logger logs.TaggedLogger, jobID, datumID, inputFileSetID string) (string, error) {
+	wf := &argoWorkflow{APIVersion: "argoproj.io/v1alpha1", Kind: "Workflow"}
+	wf.Metadata.GenerateName = e.pipeline + "-"
+	wf.Metadata.Labels = map[string]string{
+		"pipelineName": e.pipeline,
+		"jobID":        jobID,
+	}
+	wf.Spec.WorkflowTemplateRef.Name = e.spec.WorkflowTemplate
+	wf.Spec.Arguments.Parameters = []argoParameter{
+		{Name: argoInputFileSetParam, Value: inputFileSetID},
+		{Name: argoJobParam, Value: jobID},
+		{Name: argoDatumParam, Value: datumID},
+		{Name: argoSecretParam, Value: ppsutil.ExecutorSecretName(e.pipeline)},
+	}
+	body, err := json.Marshal(wf)
+	if err != nil {
+		return "", errors.EnsureStack(err)
+	}
+	raw, err := e.kubeClient.Discovery().RESTClient().Post().
+		AbsPath("/apis/argoproj.io/v1alpha1/namespaces", e.namespace, "workflows").
+		SetHeader("Content-Type", "application/json").
+		Body(body).
+		DoRaw(ctx)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not submit workflow from template %q", e.spec.WorkflowTemplate)
+	}
+	if err := json.Unmarshal(raw, wf); err != nil {
+		return "", errors.EnsureStack(err)
+	}
+	name := wf.Metadata.Name
+	logger.Logf("submitted argo workflow %s", name)
+	ticker := time.NewTicker(e.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// The datum was cancelled or timed out, so its workflow is
+			// stopped as the user code would be.
+			deleteCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			err := e.kubeClient.Discovery().RESTClient().Delete().
+				AbsPath("/apis/argoproj.io/v1alpha1/namespaces", e.namespace, "workflows", name).
+				Do(deleteCtx).Error()
+			cancel()
+			if err != nil {
+				logger.Logf("could not delete argo workflow %s: %v", name, err)
+			}
+			return "", errors.EnsureStack(ctx.Err())
+		case <-ticker.C:
+		}
+		raw, err := e.kubeClient.Discovery().RESTClient().Get().
+			AbsPath("/apis/argoproj.io/v1alpha1/namespaces", e.namespace, "workflows", name).
+			DoRaw(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			return "", errors.Wrapf(err, "could not get workflow %s", name)
+		}
+		wf := &argoWorkflow{}
+		if err := json.Unmarshal(raw, wf); err != nil {
+			return "", errors.EnsureStack(err)
+		}
+		switch wf.Status.Phase {
+		case "Succeeded":
+			logger.Logf("argo workflow %s succeeded", name)
+			// The workflow's outputs are the outputs of its root node.
+			for _, param := range wf.Status.Nodes[name].Outputs.Parameters {
+				if param.Name == argoOutputFileSetParam {
+					return param.Value, nil
+				}
+			}
+			return "", nil
+		case "Failed", "Error":
+			return "", errors.Errorf("argo workflow %s %s: %s", name, wf.Status.Phase, wf.Status.Message)
+		}
+	}
+}
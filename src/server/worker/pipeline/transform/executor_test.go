@@ -0,0 +1,84 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/logs"
+)
+
+// fakeArgo serves a single workflow, which succeeds once it's been polled
+// polls times.
+func fakeArgo(t *testing.T, phase string, polls int) (*httptest.Server, *argoWorkflow) {
+	submitted := &argoWorkflow{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/apis/argoproj.io/v1alpha1/namespaces/argo/workflows":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(submitted))
+			submitted.Metadata.Name = submitted.Metadata.GenerateName + "abc"
+			require.NoError(t, json.NewEncoder(w).Encode(submitted))
+		case r.Method == http.MethodGet && r.URL.Path == "/apis/argoproj.io/v1alpha1/namespaces/argo/workflows/edges-abc":
+			wf := &argoWorkflow{}
+			wf.Metadata.Name = "edges-abc"
+			wf.Status.Phase = "Running"
+			if polls--; polls <= 0 {
+				wf.Status.Phase = phase
+				wf.Status.Message = "exit code 1"
+				var node argoNode
+				node.Outputs.Parameters = []argoParameter{{Name: argoOutputFileSetParam, Value: "output"}}
+				wf.Status.Nodes = map[string]argoNode{"edges-abc": node}
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(wf))
+		default:
+			http.NotFound(w, r)
+		}
+	})), submitted
+}
+
+func newTestArgoExecutor(t *testing.T, url string) *argoExecutor {
+	kubeClient, err := kubernetes.NewForConfig(&rest.Config{Host: url})
+	require.NoError(t, err)
+	return &argoExecutor{
+		kubeClient:   kubeClient,
+		spec:         &pps.ArgoExecutor{WorkflowTemplate: "edges-template"},
+		namespace:    "argo",
+		pipeline:     "edges",
+		pollInterval: time.Millisecond,
+	}
+}
+
+func TestArgoExecutor(t *testing.T) {
+	srv, submitted := fakeArgo(t, "Succeeded", 3)
+	defer srv.Close()
+	e := newTestArgoExecutor(t, srv.URL)
+	output, err := e.run(context.Background(), logs.NewMockLogger(), "job", "datum", "input")
+	require.NoError(t, err)
+	require.Equal(t, "output", output)
+	require.Equal(t, "edges-template", submitted.Spec.WorkflowTemplateRef.Name)
+	params := make(map[string]string)
+	for _, p := range submitted.Spec.Arguments.Parameters {
+		params[p.Name] = p.Value
+	}
+	require.Equal(t, "input", params[argoInputFileSetParam])
+	require.Equal(t, "job", params[argoJobParam])
+	require.Equal(t, "datum", params[argoDatumParam])
+	require.Equal(t, "executor-pachctl-secret-edges", params[argoSecretParam])
+}
+
+func TestArgoExecutorFailure(t *testing.T) {
+	srv, _ := fakeArgo(t, "Failed", 1)
+	defer srv.Close()
+	e := newTestArgoExecutor(t, srv.URL)
+	_, err := e.run(context.Background(), logs.NewMockLogger(), "job", "datum", "input")
+	require.YesError(t, err)
+	require.Matches(t, "exit code 1", err.Error())
+}
@@ -0,0 +1,61 @@
+package transform
+
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+)
+
+// gpuSampler returns a function sampling the utilization of the worker's
+// GPUs, or nil if the pipeline doesn't request NVIDIA GPUs or nvidia-smi
+// isn't available to measure them.
+func gpuSampler(details *pps.PipelineInfo_Details) func(context.Context) (float64, error) {
+	var requestsGPU bool
+	for _, gpu := range []*pps.GPUSpec{details.ResourceRequests.GetGpu(), details.ResourceLimits.GetGpu()} {
+		if gpu != nil && (gpu.Type == "" || gpu.Type == pps.NvidiaGPU) {
+			requestsGPU = true
+		}
+	}
+	if !requestsGPU {
+		return nil
+	}
+	nvidiaSMI, err := exec.LookPath("nvidia-smi")
+	if err != nil {
+		return nil
+	}
+	return func(ctx context.Context) (float64, error) {
+		out, err := exec.CommandContext(ctx, nvidiaSMI, "--query-gpu=utilization.gpu", "--format=csv,noheader,nounits").Output()
+		if err != nil {
+			return 0, errors.EnsureStack(err)
+		}
+		return parseGPUUtilization(string(out))
+	}
+}
+
+// parseGPUUtilization returns the mean utilization, from 0 to 1, of the GPUs
+// listed in the output of nvidia-smi --query-gpu=utilization.gpu. GPUs that
+// don't report their utilization, such as MIG instances, are skipped.
+func parseGPUUtilization(out string) (float64, error) {
+	var total float64
+	var gpus int
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		percent, err := strconv.ParseFloat(line, 64)
+		if err != nil {
+			continue
+		}
+		total += percent / 100
+		gpus++
+	}
+	if gpus == 0 {
+		return 0, errors.New("no GPU reported its utilization")
+	}
+	return total / float64(gpus), nil
+}
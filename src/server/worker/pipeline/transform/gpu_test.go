@@ -0,0 +1,21 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestParseGPUUtilization(t *testing.T) {
+	u, err := parseGPUUtilization("50\n100\n")
+	require.NoError(t, err)
+	require.Equal(t, 0.75, u)
+
+	// MIG instances report [N/A]
+	u, err = parseGPUUtilization("[N/A]\n50\n")
+	require.NoError(t, err)
+	require.Equal(t, 0.5, u)
+
+	_, err = parseGPUUtilization("[N/A]\n")
+	require.YesError(t, err)
+}
@@ -0,0 +1,114 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/mlflow"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	pfsserver "github.com/pachyderm/pachyderm/v2/src/server/pfs"
+)
+
+// Tags that link runs and model versions in the model registry back to the
+// job that created them.
+const (
+	modelPipelineTag = "pachyderm.pipeline"
+	modelJobTag      = "pachyderm.job"
+	modelCommitTag   = "pachyderm.commit"
+)
+
+// registerModel registers the output commit of a job as a version of the
+// pipeline's model, under a run that holds the job's metrics. Registration is
+// idempotent, so a job that's retried after it registered its output doesn't
+// create another run or version.
+func (reg *registry) registerModel(pj *pendingJob) error {
+	pipelineInfo := pj.driver.PipelineInfo()
+	spec := pipelineInfo.Details.ModelRegistry
+	pachClient := pj.driver.PachClient()
+	ctx := pachClient.Ctx()
+	var err error
+	var metrics []mlflow.Metric
+	if spec.MetricsFile != "" {
+		var buf bytes.Buffer
+		if err = pachClient.GetFile(pj.commitInfo.Commit, spec.MetricsFile, &buf); err != nil {
+			if !pfsserver.IsFileNotFoundErr(err) {
+				return err
+			}
+			pj.logger.Logf("metrics file %q not found in the output commit, registering the model without metrics", spec.MetricsFile)
+		} else if metrics, err = mlflow.ParseMetrics(buf.Bytes(), time.Now()); err != nil {
+			return errors.Wrapf(err, "invalid metrics file %q", spec.MetricsFile)
+		}
+	}
+	started, err := types.TimestampFromProto(pj.ji.Started)
+	if err != nil {
+		started = time.Now()
+	}
+	client := mlflow.NewClient(spec.Url, os.Getenv(pps.ModelRegistryTokenEnv))
+	var mv *mlflow.ModelVersion
+	if err := backoff.RetryUntilCancel(ctx, func() error {
+		var err error
+		mv, err = registerModelVersion(ctx, client, pj, spec, started, metrics)
+		return err
+	}, backoff.NewExponentialBackOff(), func(err error, d time.Duration) error {
+		if !mlflow.IsRetryable(err) {
+			return err
+		}
+		pj.logger.Logf("error registering model: %v, retrying in %v", err, d)
+		return nil
+	}); err != nil {
+		return err
+	}
+	pj.logger.Logf("registered output as version %s of model %q (run %s)", mv.Version, mv.Name, mv.RunID)
+	return nil
+}
+
+func registerModelVersion(ctx context.Context, client *mlflow.Client, pj *pendingJob, spec *pps.ModelRegistry, started time.Time, metrics []mlflow.Metric) (*mlflow.ModelVersion, error) {
+	pipeline := pj.driver.PipelineInfo().Pipeline.Name
+	commit := pj.commitInfo.Commit
+	tags := []mlflow.Tag{
+		{Key: modelPipelineTag, Value: pipeline},
+		{Key: modelJobTag, Value: pj.ji.Job.ID},
+		{Key: modelCommitTag, Value: commit.String()},
+	}
+	experimentID, err := client.ExperimentID(ctx, spec.ExperimentOrDefault(pipeline))
+	if err != nil {
+		return nil, err
+	}
+	run, err := client.FindRun(ctx, experimentID, tags[1])
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		if run, err = client.CreateRun(ctx, experimentID, fmt.Sprintf("%s@%s", pipeline, pj.ji.Job.ID), started, tags); err != nil {
+			return nil, err
+		}
+	}
+	if run.Info.Status != mlflow.RunStatusFinished {
+		if err := client.LogMetrics(ctx, run.Info.RunID, metrics); err != nil {
+			return nil, err
+		}
+		if err := client.FinishRun(ctx, run.Info.RunID, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	if err := client.CreateRegisteredModel(ctx, spec.Model); err != nil {
+		return nil, err
+	}
+	mv, err := client.FindModelVersion(ctx, spec.Model, run.Info.RunID)
+	if err != nil || mv != nil {
+		return mv, err
+	}
+	return client.CreateModelVersion(ctx, &mlflow.ModelVersion{
+		Name:   spec.Model,
+		Source: fmt.Sprintf("pfs://%s", commit),
+		RunID:  run.Info.RunID,
+		Tags:   tags,
+	}, fmt.Sprintf("Output of job %s of pipeline %s", pj.ji.Job.ID, pipeline))
+}
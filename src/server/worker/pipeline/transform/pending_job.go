@@ -27,6 +27,8 @@ type pendingJob struct {
 	baseMetaCommit             *pfs.Commit
 	noSkip                     bool
 	cache                      *cache
+	// draining is closed when the pipeline starts draining.
+	draining <-chan struct{}
 }
 
 func (pj *pendingJob) writeJobInfo() error {
@@ -45,6 +47,9 @@ func (pj *pendingJob) saveJobStats(stats *datum.Stats) {
 	pj.ji.DataSkipped += stats.Skipped
 	pj.ji.DataFailed += stats.Failed
 	pj.ji.DataRecovered += stats.Recovered
+	pj.ji.DataQuarantined += stats.Quarantined
+	pj.ji.DataRestored += stats.Restored
+	pj.ji.DataRecomputed += stats.Recomputed
 }
 
 func (pj *pendingJob) load() error {
@@ -124,6 +129,9 @@ func (pj *pendingJob) clearJobStats() {
 	pj.ji.DataSkipped = 0
 	pj.ji.DataFailed = 0
 	pj.ji.DataRecovered = 0
+	pj.ji.DataQuarantined = 0
+	pj.ji.DataRestored = 0
+	pj.ji.DataRecomputed = 0
 	pj.ji.DataTotal = 0
 }
 
@@ -30,16 +30,29 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/server/worker/datum"
 	"github.com/pachyderm/pachyderm/v2/src/server/worker/driver"
 	"github.com/pachyderm/pachyderm/v2/src/server/worker/logs"
+	workerStats "github.com/pachyderm/pachyderm/v2/src/server/worker/stats"
 )
 
 const (
 	defaultDatumSetsPerWorker int64 = 4
 )
 
+// errBudgetExceeded stops the processing of a job's datums when the job has
+// exceeded its budget.
+type errBudgetExceeded struct {
+	reason string
+}
+
+func (e errBudgetExceeded) Error() string {
+	return "job budget exceeded: " + e.reason
+}
+
 type registry struct {
-	driver  driver.Driver
-	logger  logs.TaggedLogger
-	limiter limit.ConcurrencyLimiter
+	driver   driver.Driver
+	logger   logs.TaggedLogger
+	limiter  limit.ConcurrencyLimiter
+	drainer  *drainer
+	cordoner *cordoner
 }
 
 // TODO:
@@ -52,13 +65,23 @@ func newRegistry(driver driver.Driver, logger logs.TaggedLogger) (*registry, err
 		return nil, errors.EnsureStack(err)
 	}
 	return &registry{
-		driver:  driver,
-		logger:  logger,
-		limiter: limit.New(int(concurrency)),
+		driver:   driver,
+		logger:   logger,
+		limiter:  limit.New(int(concurrency)),
+		drainer:  newDrainer(),
+		cordoner: newCordoner(driver.PipelineInfo().Cordon != nil),
 	}, nil
 }
 
 func (reg *registry) succeedJob(pj *pendingJob) error {
+	if pj.driver.PipelineInfo().Details.ModelRegistry != nil {
+		if err := reg.registerModel(pj); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			return reg.failJob(pj, fmt.Sprintf("could not register the output in the model registry: %v", err))
+		}
+	}
 	pj.logger.Logf("job successful, closing commits")
 	// Use the registry's driver so that the job's supervision goroutine cannot cancel us
 	if err := ppsutil.FinishJob(reg.driver.PachClient(), pj.ji, pps.JobState_JOB_FINISHING, ""); err != nil {
@@ -88,6 +111,16 @@ func (reg *registry) markJobUnrunnable(pj *pendingJob, reason string) error {
 	return nil
 }
 
+func (reg *registry) exceedJobBudget(pj *pendingJob, reason string) error {
+	pj.logger.Logf("killing job for exceeding its budget: %s", reason)
+	// Use the registry's driver so that the job's supervision goroutine cannot cancel us
+	if err := ppsutil.FinishJob(reg.driver.PachClient(), pj.ji, pps.JobState_JOB_BUDGET_EXCEEDED, reason); err != nil {
+		return err
+	}
+	pj.clearCache()
+	return nil
+}
+
 func (reg *registry) killJob(pj *pendingJob, reason string) error {
 	pj.logger.Logf("killing job with reason: %s", reason)
 	// Use the registry's driver so that the job's supervision goroutine cannot cancel us
@@ -102,6 +135,10 @@ func (reg *registry) killJob(pj *pendingJob, reason string) error {
 }
 
 func (reg *registry) startJob(jobInfo *pps.JobInfo) (retErr error) {
+	if !reg.drainer.jobStarted() {
+		reg.logger.Logf("not starting job %s, the pipeline is draining", jobInfo.Job.ID)
+		return nil
+	}
 	reg.limiter.Acquire()
 	defer func() {
 		// TODO(2.0 optional): The error handling during job setup needs more work.
@@ -109,15 +146,17 @@ func (reg *registry) startJob(jobInfo *pps.JobInfo) (retErr error) {
 		// For transient errors, we would want to retry, not just give up on the job.
 		if retErr != nil {
 			reg.limiter.Release()
+			reg.drainer.jobStopped()
 		}
 	}()
 	pi := reg.driver.PipelineInfo()
 	pj := &pendingJob{
-		driver: reg.driver,
-		logger: reg.logger.WithJob(jobInfo.Job.ID),
-		ji:     jobInfo,
-		noSkip: pi.Details.ReprocessSpec == client.ReprocessSpecEveryJob || pi.Details.S3Out,
-		cache:  newCache(reg.driver.PachClient(), ppsdb.JobKey(jobInfo.Job)),
+		driver:   reg.driver,
+		logger:   reg.logger.WithJob(jobInfo.Job.ID),
+		ji:       jobInfo,
+		noSkip:   pi.Details.ReprocessSpec == client.ReprocessSpecEveryJob || pi.Details.S3Out,
+		cache:    newCache(reg.driver.PachClient(), ppsdb.JobKey(jobInfo.Job)),
+		draining: reg.drainer.draining,
 	}
 	if pj.ji.State == pps.JobState_JOB_CREATED {
 		pj.ji.State = pps.JobState_JOB_STARTING
@@ -149,6 +188,7 @@ func (reg *registry) startJob(jobInfo *pps.JobInfo) (retErr error) {
 	}
 	go func() {
 		defer reg.limiter.Release()
+		defer reg.drainer.jobStopped()
 		if pj.ji.Details.JobTimeout != nil {
 			pj.logger.Logf("cancelling job at: %+v", afterTime)
 			timer := time.AfterFunc(afterTime, func() {
@@ -175,7 +215,14 @@ func (reg *registry) startJob(jobInfo *pps.JobInfo) (retErr error) {
 				}
 				return err
 			})
-			return errors.EnsureStack(eg.Wait())
+			if err := eg.Wait(); err != nil {
+				if errors.Is(err, errDrained) {
+					pj.logger.Logf("job drained, it continues from its checkpoint when the pipeline is started")
+					return nil
+				}
+				return errors.EnsureStack(err)
+			}
+			return nil
 		}, backoff.NewInfiniteBackOff(), func(err error, d time.Duration) error {
 			pj.logger.Logf("error processing job: %v, retrying in %v", err, d)
 			for err != nil {
@@ -270,7 +317,14 @@ func (reg *registry) processJobStarting(pj *pendingJob) error {
 		return reg.markJobUnrunnable(pj, reason)
 	}
 	pj.ji.State = pps.JobState_JOB_RUNNING
-	return pj.writeJobInfo()
+	if err := pj.writeJobInfo(); err != nil {
+		return err
+	}
+	if created, err := types.TimestampFromProto(pj.ji.Created); err == nil {
+		priority := pj.driver.PipelineInfo().Details.Priority
+		workerStats.JobQueueTime.With(workerStats.JobQueueLabels(pj.ji.Job, priority)).Observe(time.Since(created).Seconds())
+	}
+	return nil
 }
 
 func (reg *registry) processJobRunning(pj *pendingJob) error {
@@ -320,6 +374,13 @@ func (reg *registry) processDatums(ctx context.Context, pj *pendingJob, taskDoer
 		}
 		return processDatumSets(pachClient.WithCtx(ctx), pj, taskDoer, datumSetsFileSetID, stats)
 	}); err != nil {
+		var budgetErr errBudgetExceeded
+		if errors.As(err, &budgetErr) {
+			if err := reg.exceedJobBudget(pj, budgetErr.reason); err != nil {
+				return err
+			}
+			return errutil.ErrBreak
+		}
 		return errors.EnsureStack(err)
 	}
 	if stats.FailedID != "" {
@@ -369,6 +430,7 @@ func processDatumSets(pachClient *client.APIClient, pj *pendingJob, taskDoer tas
 		eg, ctx := errgroup.WithContext(pachClient.Ctx())
 		pachClient := pachClient.WithCtx(ctx)
 		inputChan := make(chan *types.Any)
+		var drained bool
 		eg.Go(func() error {
 			defer close(inputChan)
 			commit := client.NewRepo(client.FileSetsRepoName).NewCommit("", fileSetID)
@@ -386,12 +448,17 @@ func processDatumSets(pachClient *client.APIClient, pj *pendingJob, taskDoer tas
 					return errors.EnsureStack(err)
 				}
 				select {
+				case <-pj.draining:
+					// stop handing out datum sets, but let the ones being
+					// processed finish, so that they're cached
+					drained = true
+					return errutil.ErrBreak
 				case inputChan <- input:
 				case <-ctx.Done():
 					return errors.EnsureStack(ctx.Err())
 				}
 				return nil
-			}, true); err != nil && !pfsserver.IsFileNotFoundErr(err) {
+			}, true); err != nil && !errors.Is(err, errutil.ErrBreak) && !pfsserver.IsFileNotFoundErr(err) {
 				return err
 			}
 			return nil
@@ -430,11 +497,23 @@ func processDatumSets(pachClient *client.APIClient, pj *pendingJob, taskDoer tas
 						return err
 					}
 					pj.saveJobStats(data.Stats)
-					return pj.writeJobInfo()
+					if err := pj.writeJobInfo(); err != nil {
+						return err
+					}
+					if reason := pj.ji.Details.Budget.Exceeded(pps.GetJobUsage(pj.ji)); reason != "" {
+						return errors.EnsureStack(errBudgetExceeded{reason: reason})
+					}
+					return nil
 				},
 			))
 		})
-		return errors.EnsureStack(eg.Wait())
+		if err := eg.Wait(); err != nil {
+			return errors.EnsureStack(err)
+		}
+		if drained {
+			return errDrained
+		}
+		return nil
 	}))
 }
 
@@ -486,14 +565,18 @@ func (reg *registry) processJobEgressing(pj *pendingJob) error {
 			request.Target = &pfs.EgressRequest_ObjectStorage{ObjectStorage: egress.GetObjectStorage()}
 		case *pps.Egress_SqlDatabase:
 			request.Target = &pfs.EgressRequest_SqlDatabase{SqlDatabase: egress.GetSqlDatabase()}
+		case *pps.Egress_Table:
+			request.Target = &pfs.EgressRequest_Table{Table: egress.GetTable()}
 		}
 	}
 	if err := backoff.RetryUntilCancel(client.Ctx(), func() error {
 		// file not found means the commit is empty, nothing to egress
 		// TODO explicitly handle/swallow more unrecoverable errors from SqlDatabase, to prevent job being stuck in egressing.
-		if _, err := client.Egress(client.Ctx(), &request); err != nil && !pfsserver.IsFileNotFoundErr(err) {
+		resp, err := client.Egress(client.Ctx(), &request)
+		if err != nil && !pfsserver.IsFileNotFoundErr(err) {
 			return err
 		}
+		pj.ji.EgressBytes = resp.GetObjectStorage().GetBytesWritten() + resp.GetTable().GetBytesWritten()
 		return nil
 	}, backoff.NewInfiniteBackOff(), func(err error, d time.Duration) error {
 		pj.logger.Logf("error processing egress: %v, retrying in %v", err, d)
@@ -2,7 +2,9 @@ package transform
 
 import (
 	"github.com/gogo/protobuf/proto"
+	"golang.org/x/sync/errgroup"
 
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/pps"
 	"github.com/pachyderm/pachyderm/v2/src/server/worker/driver"
 	"github.com/pachyderm/pachyderm/v2/src/server/worker/logs"
@@ -16,18 +18,35 @@ func Run(driver driver.Driver, logger logs.TaggedLogger) error {
 	}
 	logger.Logf("transform spawner started")
 
-	return driver.PachClient().SubscribeJob(
-		driver.PipelineInfo().Pipeline.Name,
-		true,
-		func(jobInfo *pps.JobInfo) error {
-			if jobInfo.PipelineVersion != driver.PipelineInfo().Version {
-				// Skip this job - we should be shut down soon, but don't error out in the meantime
-				return nil
-			}
-			if jobInfo.State == pps.JobState_JOB_FINISHING {
-				return nil
-			}
-			return reg.startJob(proto.Clone(jobInfo).(*pps.JobInfo))
-		},
-	)
+	eg, ctx := errgroup.WithContext(driver.PachClient().Ctx())
+	eg.Go(func() error {
+		return reg.watchDrain(ctx)
+	})
+	eg.Go(func() error {
+		return reg.completeDrain(ctx)
+	})
+	eg.Go(func() error {
+		return reg.watchCordon(ctx)
+	})
+	eg.Go(func() error {
+		return driver.PachClient().WithCtx(ctx).SubscribeJob(
+			driver.PipelineInfo().Pipeline.Name,
+			true,
+			func(jobInfo *pps.JobInfo) error {
+				if jobInfo.PipelineVersion != driver.PipelineInfo().Version {
+					// Skip this job - we should be shut down soon, but don't error out in the meantime
+					return nil
+				}
+				if jobInfo.State == pps.JobState_JOB_FINISHING {
+					return nil
+				}
+				jobInfo, err := reg.waitUncordoned(ctx, jobInfo)
+				if err != nil || jobInfo == nil {
+					return err
+				}
+				return reg.startJob(proto.Clone(jobInfo).(*pps.JobInfo))
+			},
+		)
+	})
+	return errors.EnsureStack(eg.Wait())
 }
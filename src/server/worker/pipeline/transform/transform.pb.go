@@ -579,6 +579,56 @@ func (m *CreateDatumSetsTaskResult) GetInputFileSetsId() string {
 	return ""
 }
 
+// DatumCacheEntry is the value of a datum's entry in its pipeline's datum
+// cache.
+type DatumCacheEntry struct {
+	// file_set_id is the file set holding the datum's output.
+	FileSetId            string   `protobuf:"bytes,1,opt,name=file_set_id,json=fileSetId,proto3" json:"file_set_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DatumCacheEntry) Reset()         { *m = DatumCacheEntry{} }
+func (m *DatumCacheEntry) String() string { return proto.CompactTextString(m) }
+func (*DatumCacheEntry) ProtoMessage()    {}
+func (*DatumCacheEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_21583a759eb7fa97, []int{9}
+}
+func (m *DatumCacheEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DatumCacheEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DatumCacheEntry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DatumCacheEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DatumCacheEntry.Merge(m, src)
+}
+func (m *DatumCacheEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *DatumCacheEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_DatumCacheEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DatumCacheEntry proto.InternalMessageInfo
+
+func (m *DatumCacheEntry) GetFileSetId() string {
+	if m != nil {
+		return m.FileSetId
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*DatumSet)(nil), "pachyderm.worker.pipeline.transform.DatumSet")
 	proto.RegisterType((*UploadDatumsTask)(nil), "pachyderm.worker.pipeline.transform.UploadDatumsTask")
@@ -589,6 +639,7 @@ func init() {
 	proto.RegisterType((*ComputeSerialDatumsTaskResult)(nil), "pachyderm.worker.pipeline.transform.ComputeSerialDatumsTaskResult")
 	proto.RegisterType((*CreateDatumSetsTask)(nil), "pachyderm.worker.pipeline.transform.CreateDatumSetsTask")
 	proto.RegisterType((*CreateDatumSetsTaskResult)(nil), "pachyderm.worker.pipeline.transform.CreateDatumSetsTaskResult")
+	proto.RegisterType((*DatumCacheEntry)(nil), "pachyderm.worker.pipeline.transform.DatumCacheEntry")
 }
 
 func init() {
@@ -596,44 +647,44 @@ func init() {
 }
 
 var fileDescriptor_21583a759eb7fa97 = []byte{
-	// 580 bytes of a gzipped FileDescriptorProto
+	// 592 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0xcf, 0x6a, 0xdb, 0x4e,
-	0x10, 0x66, 0xa3, 0x9f, 0x9d, 0x78, 0x1d, 0x27, 0x46, 0xbf, 0xd0, 0x38, 0x81, 0xd8, 0x46, 0x3d,
-	0x24, 0x10, 0x90, 0x5a, 0xe7, 0xd2, 0x6b, 0xe3, 0x10, 0x70, 0xa0, 0xa5, 0xc8, 0xe9, 0xa5, 0x17,
-	0xb3, 0xb2, 0xc6, 0xb6, 0x6c, 0x49, 0xbb, 0xec, 0xae, 0x5c, 0x7a, 0x2e, 0x14, 0xfa, 0x2c, 0x7d,
-	0x91, 0x1e, 0xfb, 0x04, 0xa5, 0xf8, 0x35, 0x7a, 0x29, 0xbb, 0xeb, 0xff, 0x6d, 0x1a, 0x1d, 0x7a,
-	0x11, 0x3b, 0x33, 0xdf, 0xac, 0xbe, 0xf9, 0x66, 0x66, 0xf1, 0x33, 0x01, 0x7c, 0x0a, 0xdc, 0x7b,
-	0x4f, 0xf9, 0x04, 0xb8, 0xc7, 0x22, 0x06, 0x71, 0x94, 0x82, 0x27, 0x39, 0x49, 0xc5, 0x80, 0xf2,
-	0x64, 0x75, 0x72, 0x19, 0xa7, 0x92, 0xda, 0x4f, 0x19, 0xe9, 0x8f, 0x3e, 0x84, 0xc0, 0x13, 0xd7,
-	0x24, 0xb9, 0x8b, 0x24, 0x77, 0x09, 0x3d, 0x3d, 0x1a, 0xd2, 0x21, 0xd5, 0x78, 0x4f, 0x9d, 0x4c,
-	0xea, 0x69, 0x85, 0x0d, 0x84, 0xc7, 0x06, 0x62, 0x69, 0x32, 0xe1, 0x31, 0xb6, 0x30, 0x1b, 0x9b,
-	0x54, 0x42, 0x22, 0xb3, 0xc4, 0x7c, 0x0d, 0xc0, 0xf9, 0x89, 0xf0, 0xde, 0x8d, 0xb2, 0xbb, 0x20,
-	0xed, 0x26, 0x2e, 0x8e, 0x69, 0xd0, 0x8b, 0xc2, 0x1a, 0x6a, 0xa2, 0x8b, 0xd2, 0x75, 0x69, 0xf6,
-	0xbd, 0x51, 0xb8, 0xa3, 0x41, 0xe7, 0xc6, 0x2f, 0x8c, 0x69, 0xd0, 0x09, 0xed, 0x3a, 0x2e, 0x0f,
-	0xa2, 0x18, 0x7a, 0x02, 0xa4, 0x82, 0xed, 0x28, 0x98, 0x5f, 0x52, 0xae, 0x2e, 0xc8, 0x4e, 0x68,
-	0x5f, 0xe1, 0x0a, 0xcd, 0x24, 0xcb, 0x64, 0xaf, 0x4f, 0x93, 0x24, 0x92, 0x35, 0xab, 0x89, 0x2e,
-	0xca, 0xad, 0x03, 0x97, 0x0d, 0x44, 0x6f, 0xda, 0x72, 0xdb, 0xda, 0xeb, 0xef, 0x1b, 0x90, 0xb1,
-	0xec, 0x4b, 0x6c, 0xcf, 0x93, 0xd6, 0xef, 0xfe, 0x4f, 0xdf, 0x7d, 0x68, 0x22, 0xb7, 0xcb, 0x3f,
-	0x9c, 0xe3, 0x6a, 0x02, 0x92, 0x6c, 0x40, 0x0b, 0x1a, 0x5a, 0x51, 0xfe, 0x15, 0xd0, 0xc1, 0x05,
-	0x21, 0x89, 0x14, 0xb5, 0xa2, 0xa6, 0xb0, 0xef, 0x9a, 0xb2, 0xbb, 0xca, 0xe7, 0x9b, 0x90, 0xf3,
-	0x1c, 0x57, 0xdf, 0xb2, 0x98, 0x92, 0x50, 0x4b, 0x20, 0xee, 0x89, 0x98, 0xd8, 0x67, 0xd8, 0x1a,
-	0xd3, 0x40, 0x2b, 0x50, 0x6e, 0x95, 0x5d, 0xc6, 0x34, 0xf1, 0x3b, 0x1a, 0xf8, 0xca, 0xef, 0xbc,
-	0xc6, 0x4f, 0xb6, 0x53, 0x7c, 0x10, 0x59, 0x2c, 0xb7, 0xb5, 0x41, 0xdb, 0xda, 0x1c, 0xe1, 0x42,
-	0x9f, 0x66, 0xa9, 0xd4, 0xaa, 0x59, 0xbe, 0x31, 0x9c, 0x8f, 0x08, 0x9f, 0xb4, 0x69, 0xc2, 0x32,
-	0x09, 0x6f, 0x08, 0x27, 0x71, 0x0c, 0x71, 0x6e, 0x32, 0x8f, 0xb6, 0xe3, 0x1c, 0x57, 0x03, 0x22,
-	0x60, 0x43, 0x2c, 0xcb, 0x88, 0xa5, 0xfc, 0x4b, 0xb1, 0x9c, 0x97, 0xb8, 0xf1, 0x20, 0x89, 0x7c,
-	0xe5, 0x39, 0x5f, 0x10, 0x3e, 0x9e, 0xdf, 0xd1, 0x05, 0x1e, 0x91, 0x7f, 0x58, 0xc6, 0x8b, 0x79,
-	0x19, 0xba, 0xf1, 0x7f, 0x1d, 0xac, 0x03, 0x85, 0x7b, 0x05, 0x92, 0xcc, 0x47, 0xeb, 0x18, 0xef,
-	0xa6, 0xb4, 0x27, 0x26, 0x11, 0xd3, 0xf3, 0xb4, 0xe7, 0x17, 0x53, 0xda, 0x9d, 0x44, 0xcc, 0xf9,
-	0x84, 0xf0, 0xd9, 0x03, 0x6c, 0x73, 0xb6, 0xf3, 0x12, 0xdb, 0x21, 0xc4, 0x20, 0x37, 0xd5, 0x35,
-	0xdc, 0x0f, 0x4d, 0x64, 0x35, 0x8c, 0x35, 0xbc, 0xab, 0x48, 0x30, 0x30, 0xfa, 0x5b, 0xfe, 0xc2,
-	0x74, 0x3e, 0x23, 0xfc, 0x7f, 0x9b, 0x03, 0x91, 0xb0, 0x58, 0xc3, 0x5c, 0x92, 0xfd, 0xb6, 0x68,
-	0x3b, 0x39, 0x16, 0x6d, 0xab, 0x24, 0x6b, 0xbb, 0x85, 0x23, 0x7c, 0xf2, 0x07, 0x2a, 0xf9, 0xf5,
-	0x88, 0xd2, 0xf5, 0x25, 0x16, 0x6b, 0x7a, 0xe8, 0xc8, 0x5c, 0x0e, 0xd1, 0x09, 0xaf, 0xef, 0xbf,
-	0xce, 0xea, 0xe8, 0xdb, 0xac, 0x8e, 0x7e, 0xcc, 0xea, 0xe8, 0xdd, 0xed, 0x30, 0x92, 0xa3, 0x2c,
-	0x70, 0xfb, 0x34, 0xf1, 0x96, 0x2f, 0xe1, 0xda, 0x69, 0xda, 0xf2, 0x04, 0xef, 0x7b, 0x8f, 0x3d,
-	0xab, 0x41, 0x51, 0xbf, 0x69, 0x57, 0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0xa9, 0x3a, 0x53, 0xe2,
-	0x81, 0x05, 0x00, 0x00,
+	0x10, 0x66, 0xa3, 0x9f, 0x9d, 0x64, 0x9d, 0x7f, 0xe8, 0x17, 0x1a, 0x27, 0x10, 0xdb, 0xa8, 0x87,
+	0x04, 0x02, 0x52, 0xe3, 0x5c, 0x7a, 0x6d, 0x9c, 0x06, 0x1c, 0x68, 0x29, 0x72, 0x7a, 0xe9, 0x45,
+	0xac, 0xa4, 0xb1, 0x2d, 0x5b, 0xd2, 0x2e, 0xbb, 0x2b, 0x97, 0x9c, 0x0b, 0x85, 0x3e, 0x4b, 0x5f,
+	0xa4, 0xc7, 0x3e, 0x41, 0x29, 0x7e, 0x8d, 0x5e, 0x8a, 0x76, 0xfd, 0x3f, 0x4d, 0xad, 0x43, 0x2f,
+	0x62, 0x67, 0xf6, 0x9b, 0xd1, 0x37, 0xdf, 0xcc, 0x2c, 0x7e, 0x21, 0x80, 0x8f, 0x80, 0x3b, 0x1f,
+	0x29, 0x1f, 0x02, 0x77, 0x58, 0xc4, 0x20, 0x8e, 0x52, 0x70, 0x24, 0x27, 0xa9, 0xe8, 0x52, 0x9e,
+	0xcc, 0x4f, 0x36, 0xe3, 0x54, 0x52, 0xf3, 0x39, 0x23, 0x41, 0xff, 0x21, 0x04, 0x9e, 0xd8, 0x3a,
+	0xc8, 0x9e, 0x06, 0xd9, 0x33, 0xe8, 0xc9, 0x61, 0x8f, 0xf6, 0xa8, 0xc2, 0x3b, 0xf9, 0x49, 0x87,
+	0x9e, 0xec, 0xb2, 0xae, 0x70, 0x58, 0x57, 0xcc, 0x4c, 0x26, 0x1c, 0xc6, 0xa6, 0x66, 0x7d, 0x99,
+	0x4a, 0x48, 0x64, 0x96, 0xe8, 0xaf, 0x06, 0x58, 0xbf, 0x10, 0xde, 0xba, 0xc9, 0xed, 0x0e, 0x48,
+	0xb3, 0x81, 0xcb, 0x03, 0xea, 0x7b, 0x51, 0x58, 0x45, 0x0d, 0x74, 0xbe, 0x7d, 0xbd, 0x3d, 0xfe,
+	0x51, 0x2f, 0xdd, 0x51, 0xbf, 0x7d, 0xe3, 0x96, 0x06, 0xd4, 0x6f, 0x87, 0x66, 0x0d, 0x57, 0xba,
+	0x51, 0x0c, 0x9e, 0x00, 0x99, 0xc3, 0x36, 0x72, 0x98, 0xbb, 0x9d, 0xbb, 0x3a, 0x20, 0xdb, 0xa1,
+	0x79, 0x85, 0x77, 0x69, 0x26, 0x59, 0x26, 0xbd, 0x80, 0x26, 0x49, 0x24, 0xab, 0x46, 0x03, 0x9d,
+	0x57, 0x9a, 0x7b, 0x36, 0xeb, 0x0a, 0x6f, 0xd4, 0xb4, 0x5b, 0xca, 0xeb, 0xee, 0x68, 0x90, 0xb6,
+	0xcc, 0x0b, 0x6c, 0x4e, 0x82, 0x16, 0x73, 0xff, 0xa7, 0x72, 0xef, 0xeb, 0x9b, 0xdb, 0xd9, 0x1f,
+	0xce, 0xf0, 0x41, 0x02, 0x92, 0x2c, 0x41, 0x4b, 0x0a, 0xba, 0x9b, 0xfb, 0xe7, 0x40, 0x0b, 0x97,
+	0x84, 0x24, 0x52, 0x54, 0xcb, 0x8a, 0xc2, 0x8e, 0xad, 0xcb, 0xee, 0xe4, 0x3e, 0x57, 0x5f, 0x59,
+	0x97, 0xf8, 0xe0, 0x3d, 0x8b, 0x29, 0x09, 0x95, 0x04, 0xe2, 0x9e, 0x88, 0xa1, 0x79, 0x8a, 0x8d,
+	0x01, 0xf5, 0x95, 0x02, 0x95, 0x66, 0xc5, 0x66, 0x4c, 0x11, 0xbf, 0xa3, 0xbe, 0x9b, 0xfb, 0xad,
+	0xb7, 0xf8, 0xd9, 0x6a, 0x88, 0x0b, 0x22, 0x8b, 0xe5, 0xaa, 0x36, 0x68, 0x55, 0x9b, 0x43, 0x5c,
+	0x0a, 0x68, 0x96, 0x4a, 0xa5, 0x9a, 0xe1, 0x6a, 0xc3, 0xfa, 0x84, 0xf0, 0x71, 0x8b, 0x26, 0x2c,
+	0x93, 0xf0, 0x8e, 0x70, 0x12, 0xc7, 0x10, 0x17, 0x26, 0xb3, 0xb6, 0x1d, 0x67, 0xf8, 0xc0, 0x27,
+	0x02, 0x96, 0xc4, 0x32, 0xb4, 0x58, 0xb9, 0x7f, 0x26, 0x96, 0xf5, 0x0a, 0xd7, 0x9f, 0x24, 0x51,
+	0xac, 0x3c, 0xeb, 0x2b, 0xc2, 0x47, 0x93, 0x1c, 0x1d, 0xe0, 0x11, 0xf9, 0x87, 0x65, 0xbc, 0x9c,
+	0x94, 0xa1, 0x1a, 0xff, 0xd7, 0xc1, 0xda, 0xcb, 0x71, 0x6f, 0x40, 0x92, 0xc9, 0x68, 0x1d, 0xe1,
+	0xcd, 0x94, 0x7a, 0x62, 0x18, 0x31, 0x35, 0x4f, 0x5b, 0x6e, 0x39, 0xa5, 0x9d, 0x61, 0xc4, 0xac,
+	0xcf, 0x08, 0x9f, 0x3e, 0xc1, 0xb6, 0x60, 0x3b, 0x2f, 0xb0, 0x19, 0x42, 0x0c, 0x12, 0xbc, 0xc7,
+	0xdc, 0xf7, 0xf5, 0xcd, 0x7c, 0x18, 0xab, 0x78, 0x33, 0x27, 0xc1, 0x40, 0xeb, 0x6f, 0xb8, 0x53,
+	0xd3, 0xfa, 0x82, 0xf0, 0xff, 0x2d, 0x0e, 0x44, 0xc2, 0x74, 0x0d, 0x0b, 0x49, 0xf6, 0x68, 0xd1,
+	0x36, 0x0a, 0x2c, 0xda, 0x4a, 0x49, 0xc6, 0x6a, 0x0b, 0xfb, 0xf8, 0xf8, 0x0f, 0x54, 0x8a, 0xeb,
+	0x11, 0xa5, 0x8b, 0x4b, 0x2c, 0x16, 0xf4, 0x50, 0x37, 0x13, 0x39, 0x44, 0x3b, 0xb4, 0x2e, 0xf1,
+	0xbe, 0xfa, 0x47, 0x8b, 0x04, 0x7d, 0x78, 0x9d, 0x4a, 0xfe, 0xb0, 0x2e, 0xff, 0xf5, 0xfd, 0xb7,
+	0x71, 0x0d, 0x7d, 0x1f, 0xd7, 0xd0, 0xcf, 0x71, 0x0d, 0x7d, 0xb8, 0xed, 0x45, 0xb2, 0x9f, 0xf9,
+	0x76, 0x40, 0x13, 0x67, 0xf6, 0x78, 0x2e, 0x9c, 0x46, 0x4d, 0x47, 0xf0, 0xc0, 0x59, 0xf7, 0x12,
+	0xfb, 0x65, 0xf5, 0x0c, 0x5e, 0xfd, 0x1e, 0x00, 0x3a, 0xb6, 0xea, 0x24, 0xb4, 0x05, 0x00, 0x00,
 }
 
 func (m *DatumSet) Marshal() (dAtA []byte, err error) {
@@ -1093,6 +1144,40 @@ func (m *CreateDatumSetsTaskResult) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	return len(dAtA) - i, nil
 }
 
+func (m *DatumCacheEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DatumCacheEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DatumCacheEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.FileSetId) > 0 {
+		i -= len(m.FileSetId)
+		copy(dAtA[i:], m.FileSetId)
+		i = encodeVarintTransform(dAtA, i, uint64(len(m.FileSetId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTransform(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTransform(v)
 	base := offset
@@ -1309,6 +1394,22 @@ func (m *CreateDatumSetsTaskResult) Size() (n int) {
 	return n
 }
 
+func (m *DatumCacheEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.FileSetId)
+	if l > 0 {
+		n += 1 + l + sovTransform(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovTransform(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -2568,6 +2669,89 @@ func (m *CreateDatumSetsTaskResult) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *DatumCacheEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransform
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DatumCacheEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DatumCacheEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileSetId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransform
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FileSetId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransform(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTransform(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,209 @@
+package transform
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/common"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/datum"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/driver"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/logs"
+)
+
+// SocketEnv is the environment variable that holds the path of the unix
+// socket that the code of a TRANSFORM_GRPC transform serves the UserCode
+// service on.
+const SocketEnv = "PACH_TRANSFORM_SOCKET"
+
+// userCodeStartTimeout is how long the user code has to start serving the
+// UserCode service after it's started. It's generous, since the code may
+// load large models before it serves.
+const userCodeStartTimeout = 10 * time.Minute
+
+// userCodeServer runs the code of a TRANSFORM_GRPC transform, which serves
+// the UserCode service, for the lifetime of a worker. The code is started
+// when the worker processes its first datum, and started again if it exits.
+type userCodeServer struct {
+	ctx    context.Context
+	driver driver.Driver
+	logger logs.TaggedLogger
+	socket string
+
+	mu sync.Mutex
+	// exited is closed when the running code exits, and is nil if no code
+	// has been started.
+	exited chan struct{}
+	stop   context.CancelFunc
+	conn   *grpc.ClientConn
+}
+
+// newUserCodeServer returns the userCodeServer of the pipeline, or nil if
+// its transform doesn't use the TRANSFORM_GRPC protocol.
+func newUserCodeServer(ctx context.Context, driver driver.Driver, logger logs.TaggedLogger) *userCodeServer {
+	if driver.PipelineInfo().Details.Transform.GetProtocol() != pps.TransformProtocol_TRANSFORM_GRPC {
+		return nil
+	}
+	return &userCodeServer{
+		ctx:    ctx,
+		driver: driver,
+		logger: logger,
+		socket: filepath.Join(os.TempDir(), "pachyderm-transform.sock"),
+	}
+}
+
+// client starts the user code, if it isn't running, and returns a client of
+// the UserCode service it serves.
+func (s *userCodeServer) client(ctx context.Context) (UserCodeClient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.exited != nil {
+		select {
+		case <-s.exited:
+			s.logger.Logf("restarting user code, it exited")
+			s.closeConn()
+			s.exited = nil
+		default:
+		}
+	}
+	if s.exited == nil {
+		if err := s.startLocked(); err != nil {
+			return nil, err
+		}
+	}
+	if s.conn == nil {
+		if err := s.dialLocked(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return NewUserCodeClient(s.conn), nil
+}
+
+func (s *userCodeServer) startLocked() error {
+	if err := os.Remove(s.socket); err != nil && !os.IsNotExist(err) {
+		return errors.EnsureStack(err)
+	}
+	ctx, stop := context.WithCancel(s.ctx)
+	exited := make(chan struct{})
+	env := append(os.Environ(), fmt.Sprintf("%s=%s", SocketEnv, s.socket))
+	go func() {
+		defer close(exited)
+		// RunUserCode logs why the code exited.
+		s.driver.RunUserCode(ctx, s.logger, env) //nolint:errcheck
+	}()
+	s.exited, s.stop = exited, stop
+	return nil
+}
+
+// dialLocked waits for the running code to serve the UserCode service, and
+// connects to it.
+func (s *userCodeServer) dialLocked(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, userCodeStartTimeout)
+	defer cancel()
+	exited := s.exited
+	go func() {
+		select {
+		case <-exited:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	conn, err := grpc.DialContext(ctx, "unix://"+s.socket,
+		grpc.WithInsecure(),
+		grpc.WithBlock(),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcutil.MaxMsgSize)),
+	)
+	if err != nil {
+		select {
+		case <-exited:
+			return errors.New("user code exited before serving the UserCode service")
+		default:
+		}
+		return errors.Wrapf(err, "could not connect to the UserCode service on %s", s.socket)
+	}
+	s.conn = conn
+	return nil
+}
+
+func (s *userCodeServer) closeConn() {
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+// close stops the user code, and waits for it to exit.
+func (s *userCodeServer) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeConn()
+	if s.exited != nil {
+		s.stop()
+		<-s.exited
+		s.exited = nil
+	}
+}
+
+// processDatum processes a datum with the user code, and writes the logs it
+// sends to logger.
+func (s *userCodeServer) processDatum(ctx context.Context, logger logs.TaggedLogger, request *ProcessDatumRequest) error {
+	c, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	stream, err := c.ProcessDatum(ctx, request)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	userLogger := logger.WithUserCode(pps.LogStream_LOG_STDOUT)
+	for {
+		response, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return errors.New("user code ended the datum without a result")
+			}
+			return errors.EnsureStack(err)
+		}
+		switch response := response.Response.(type) {
+		case *ProcessDatumResponse_Log:
+			fmt.Fprintln(userLogger, response.Log)
+		case *ProcessDatumResponse_Result:
+			if response.Result.State == DatumResultState_DATUM_RESULT_FAILURE {
+				return errors.Errorf("user code failed the datum: %s", response.Result.Message)
+			}
+			return nil
+		}
+	}
+}
+
+// newProcessDatumRequest returns the request that processes a datum with
+// inputs. Its paths are the ones the datum's inputs and output have in the
+// worker's filesystem while the datum is active.
+func newProcessDatumRequest(driver driver.Driver, jobID string, outputCommit *pfs.Commit, inputs []*common.Input) *ProcessDatumRequest {
+	request := &ProcessDatumRequest{
+		JobId:        jobID,
+		DatumId:      common.DatumID(inputs),
+		OutputDir:    filepath.Join(driver.InputDir(), datum.OutputPrefix),
+		OutputCommit: outputCommit,
+	}
+	for _, input := range inputs {
+		request.Inputs = append(request.Inputs, &DatumInput{
+			Name:     input.Name,
+			Path:     filepath.Join(driver.InputDir(), input.Name, input.FileInfo.File.Path),
+			FileInfo: input.FileInfo,
+			JoinOn:   input.JoinOn,
+			GroupBy:  input.GroupBy,
+		})
+	}
+	return request
+}
@@ -0,0 +1,1654 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: server/worker/pipeline/transform/usercode.proto
+
+package transform
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	pfs "github.com/pachyderm/pachyderm/v2/src/pfs"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type DatumResultState int32
+
+const (
+	// DATUM_RESULT_SUCCESS means that the datum was processed, and its output
+	// is uploaded.
+	DatumResultState_DATUM_RESULT_SUCCESS DatumResultState = 0
+	// DATUM_RESULT_FAILURE means that the datum failed. It's retried, and
+	// then recovered or failed, as it would be if cmd had exited with an
+	// error.
+	DatumResultState_DATUM_RESULT_FAILURE DatumResultState = 1
+)
+
+var DatumResultState_name = map[int32]string{
+	0: "DATUM_RESULT_SUCCESS",
+	1: "DATUM_RESULT_FAILURE",
+}
+
+var DatumResultState_value = map[string]int32{
+	"DATUM_RESULT_SUCCESS": 0,
+	"DATUM_RESULT_FAILURE": 1,
+}
+
+func (x DatumResultState) String() string {
+	return proto.EnumName(DatumResultState_name, int32(x))
+}
+
+func (DatumResultState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a6c69ff6f4adc3c7, []int{0}
+}
+
+type DatumInput struct {
+	// name is the name of the input, as in the pipeline's input spec.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// path is where the input's file is in the worker's filesystem, under
+	// /pfs/<name>.
+	Path                 string        `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	FileInfo             *pfs.FileInfo `protobuf:"bytes,3,opt,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
+	JoinOn               string        `protobuf:"bytes,4,opt,name=join_on,json=joinOn,proto3" json:"join_on,omitempty"`
+	GroupBy              string        `protobuf:"bytes,5,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *DatumInput) Reset()         { *m = DatumInput{} }
+func (m *DatumInput) String() string { return proto.CompactTextString(m) }
+func (*DatumInput) ProtoMessage()    {}
+func (*DatumInput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a6c69ff6f4adc3c7, []int{0}
+}
+func (m *DatumInput) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DatumInput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DatumInput.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DatumInput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DatumInput.Merge(m, src)
+}
+func (m *DatumInput) XXX_Size() int {
+	return m.Size()
+}
+func (m *DatumInput) XXX_DiscardUnknown() {
+	xxx_messageInfo_DatumInput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DatumInput proto.InternalMessageInfo
+
+func (m *DatumInput) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DatumInput) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *DatumInput) GetFileInfo() *pfs.FileInfo {
+	if m != nil {
+		return m.FileInfo
+	}
+	return nil
+}
+
+func (m *DatumInput) GetJoinOn() string {
+	if m != nil {
+		return m.JoinOn
+	}
+	return ""
+}
+
+func (m *DatumInput) GetGroupBy() string {
+	if m != nil {
+		return m.GroupBy
+	}
+	return ""
+}
+
+type ProcessDatumRequest struct {
+	JobId   string        `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	DatumId string        `protobuf:"bytes,2,opt,name=datum_id,json=datumId,proto3" json:"datum_id,omitempty"`
+	Inputs  []*DatumInput `protobuf:"bytes,3,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// output_dir is the directory that the datum's output is written to.
+	OutputDir            string      `protobuf:"bytes,4,opt,name=output_dir,json=outputDir,proto3" json:"output_dir,omitempty"`
+	OutputCommit         *pfs.Commit `protobuf:"bytes,5,opt,name=output_commit,json=outputCommit,proto3" json:"output_commit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ProcessDatumRequest) Reset()         { *m = ProcessDatumRequest{} }
+func (m *ProcessDatumRequest) String() string { return proto.CompactTextString(m) }
+func (*ProcessDatumRequest) ProtoMessage()    {}
+func (*ProcessDatumRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a6c69ff6f4adc3c7, []int{1}
+}
+func (m *ProcessDatumRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProcessDatumRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProcessDatumRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProcessDatumRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProcessDatumRequest.Merge(m, src)
+}
+func (m *ProcessDatumRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProcessDatumRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProcessDatumRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProcessDatumRequest proto.InternalMessageInfo
+
+func (m *ProcessDatumRequest) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *ProcessDatumRequest) GetDatumId() string {
+	if m != nil {
+		return m.DatumId
+	}
+	return ""
+}
+
+func (m *ProcessDatumRequest) GetInputs() []*DatumInput {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *ProcessDatumRequest) GetOutputDir() string {
+	if m != nil {
+		return m.OutputDir
+	}
+	return ""
+}
+
+func (m *ProcessDatumRequest) GetOutputCommit() *pfs.Commit {
+	if m != nil {
+		return m.OutputCommit
+	}
+	return nil
+}
+
+type DatumResult struct {
+	State DatumResultState `protobuf:"varint,1,opt,name=state,proto3,enum=pachyderm.worker.pipeline.transform.DatumResultState" json:"state,omitempty"`
+	// message is why the datum failed.
+	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DatumResult) Reset()         { *m = DatumResult{} }
+func (m *DatumResult) String() string { return proto.CompactTextString(m) }
+func (*DatumResult) ProtoMessage()    {}
+func (*DatumResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a6c69ff6f4adc3c7, []int{2}
+}
+func (m *DatumResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DatumResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DatumResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DatumResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DatumResult.Merge(m, src)
+}
+func (m *DatumResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *DatumResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_DatumResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DatumResult proto.InternalMessageInfo
+
+func (m *DatumResult) GetState() DatumResultState {
+	if m != nil {
+		return m.State
+	}
+	return DatumResultState_DATUM_RESULT_SUCCESS
+}
+
+func (m *DatumResult) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type ProcessDatumResponse struct {
+	// Types that are valid to be assigned to Response:
+	//	*ProcessDatumResponse_Log
+	//	*ProcessDatumResponse_Result
+	Response             isProcessDatumResponse_Response `protobuf_oneof:"response"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *ProcessDatumResponse) Reset()         { *m = ProcessDatumResponse{} }
+func (m *ProcessDatumResponse) String() string { return proto.CompactTextString(m) }
+func (*ProcessDatumResponse) ProtoMessage()    {}
+func (*ProcessDatumResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a6c69ff6f4adc3c7, []int{3}
+}
+func (m *ProcessDatumResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProcessDatumResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProcessDatumResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProcessDatumResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProcessDatumResponse.Merge(m, src)
+}
+func (m *ProcessDatumResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProcessDatumResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProcessDatumResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProcessDatumResponse proto.InternalMessageInfo
+
+type isProcessDatumResponse_Response interface {
+	isProcessDatumResponse_Response()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type ProcessDatumResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof" json:"log,omitempty"`
+}
+type ProcessDatumResponse_Result struct {
+	Result *DatumResult `protobuf:"bytes,2,opt,name=result,proto3,oneof" json:"result,omitempty"`
+}
+
+func (*ProcessDatumResponse_Log) isProcessDatumResponse_Response()    {}
+func (*ProcessDatumResponse_Result) isProcessDatumResponse_Response() {}
+
+func (m *ProcessDatumResponse) GetResponse() isProcessDatumResponse_Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *ProcessDatumResponse) GetLog() string {
+	if x, ok := m.GetResponse().(*ProcessDatumResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (m *ProcessDatumResponse) GetResult() *DatumResult {
+	if x, ok := m.GetResponse().(*ProcessDatumResponse_Result); ok {
+		return x.Result
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ProcessDatumResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ProcessDatumResponse_Log)(nil),
+		(*ProcessDatumResponse_Result)(nil),
+	}
+}
+
+func init() {
+	proto.RegisterEnum("pachyderm.worker.pipeline.transform.DatumResultState", DatumResultState_name, DatumResultState_value)
+	proto.RegisterType((*DatumInput)(nil), "pachyderm.worker.pipeline.transform.DatumInput")
+	proto.RegisterType((*ProcessDatumRequest)(nil), "pachyderm.worker.pipeline.transform.ProcessDatumRequest")
+	proto.RegisterType((*DatumResult)(nil), "pachyderm.worker.pipeline.transform.DatumResult")
+	proto.RegisterType((*ProcessDatumResponse)(nil), "pachyderm.worker.pipeline.transform.ProcessDatumResponse")
+}
+
+func init() {
+	proto.RegisterFile("server/worker/pipeline/transform/usercode.proto", fileDescriptor_a6c69ff6f4adc3c7)
+}
+
+var fileDescriptor_a6c69ff6f4adc3c7 = []byte{
+	// 545 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0xcb, 0x6e, 0xd3, 0x4c,
+	0x18, 0xcd, 0xfc, 0x69, 0x6e, 0x5f, 0xda, 0x2a, 0x9a, 0xbf, 0x08, 0x53, 0x89, 0x28, 0x0a, 0x9b,
+	0x08, 0x09, 0xbb, 0x72, 0x85, 0x04, 0xcb, 0xe6, 0x46, 0x03, 0x45, 0x20, 0x27, 0xd9, 0xb0, 0xb1,
+	0x1c, 0x7b, 0x9c, 0x4c, 0x88, 0x3d, 0xc3, 0xcc, 0x38, 0x28, 0x5b, 0x36, 0xbc, 0x00, 0x12, 0xaf,
+	0xc4, 0x92, 0x47, 0x40, 0x11, 0x0f, 0x82, 0xec, 0x71, 0x4a, 0xb9, 0x48, 0x04, 0x76, 0xdf, 0x65,
+	0xce, 0xf1, 0x39, 0x67, 0x3c, 0x60, 0x49, 0x22, 0xd6, 0x44, 0x58, 0x6f, 0x99, 0x78, 0x4d, 0x84,
+	0xc5, 0x29, 0x27, 0x2b, 0x1a, 0x13, 0x4b, 0x09, 0x2f, 0x96, 0x21, 0x13, 0x91, 0x95, 0x48, 0x22,
+	0x7c, 0x16, 0x10, 0x93, 0x0b, 0xa6, 0x18, 0xbe, 0xc7, 0x3d, 0x7f, 0xb1, 0x09, 0x88, 0x88, 0x4c,
+	0x8d, 0x31, 0x77, 0x18, 0xf3, 0x1a, 0x73, 0x7a, 0xc4, 0x43, 0x69, 0xf1, 0x50, 0x6a, 0x4c, 0xfb,
+	0x23, 0x02, 0xe8, 0x7b, 0x2a, 0x89, 0x46, 0x31, 0x4f, 0x14, 0xc6, 0x70, 0x10, 0x7b, 0x11, 0x31,
+	0x50, 0x0b, 0x75, 0x6a, 0x4e, 0x56, 0xa7, 0x33, 0xee, 0xa9, 0x85, 0xf1, 0x9f, 0x9e, 0xa5, 0x35,
+	0x7e, 0x00, 0xb5, 0x90, 0xae, 0x88, 0x4b, 0xe3, 0x90, 0x19, 0xc5, 0x16, 0xea, 0xd4, 0xed, 0x86,
+	0xc9, 0x43, 0xe9, 0xae, 0x6d, 0x73, 0x48, 0x57, 0x64, 0x14, 0x87, 0xcc, 0xa9, 0x86, 0x79, 0x85,
+	0x6f, 0x43, 0x65, 0xc9, 0x68, 0xec, 0xb2, 0xd8, 0x38, 0xc8, 0x58, 0xca, 0x69, 0xfb, 0x22, 0xc6,
+	0x77, 0xa0, 0x3a, 0x17, 0x2c, 0xe1, 0xee, 0x6c, 0x63, 0x94, 0xb2, 0x4d, 0x25, 0xeb, 0xbb, 0x9b,
+	0xf6, 0x57, 0x04, 0xff, 0xbf, 0x14, 0xcc, 0x27, 0x52, 0x66, 0x02, 0x1d, 0xf2, 0x26, 0x21, 0x52,
+	0xe1, 0x5b, 0x50, 0x5e, 0xb2, 0x99, 0x4b, 0x83, 0x5c, 0x64, 0x69, 0xc9, 0x66, 0xa3, 0x20, 0x65,
+	0x0a, 0xd2, 0x63, 0xe9, 0x42, 0x2b, 0xad, 0x64, 0xfd, 0x28, 0xc0, 0x4f, 0xa0, 0x4c, 0x53, 0x77,
+	0xd2, 0x28, 0xb6, 0x8a, 0x9d, 0xba, 0x6d, 0x99, 0x7b, 0x04, 0x65, 0x7e, 0x4f, 0xc5, 0xc9, 0xe1,
+	0xf8, 0x2e, 0x00, 0x4b, 0x14, 0x4f, 0x94, 0x1b, 0x50, 0x91, 0x3b, 0xa9, 0xe9, 0x49, 0x9f, 0x0a,
+	0x7c, 0x0e, 0x47, 0xf9, 0xda, 0x67, 0x51, 0x44, 0x55, 0xe6, 0xa8, 0x6e, 0x1f, 0xef, 0x82, 0xe9,
+	0x65, 0x53, 0xe7, 0x50, 0x1f, 0xd2, 0x5d, 0x5b, 0x41, 0x3d, 0xb7, 0x27, 0x93, 0x95, 0xc2, 0xcf,
+	0xa0, 0x24, 0x95, 0xa7, 0xf4, 0x0d, 0x1c, 0xdb, 0x0f, 0xf7, 0x97, 0xaa, 0x09, 0xc6, 0x29, 0xd8,
+	0xd1, 0x1c, 0xd8, 0x80, 0x4a, 0x44, 0xa4, 0xf4, 0xe6, 0x64, 0x17, 0x49, 0xde, 0xb6, 0xdf, 0x21,
+	0x38, 0xf9, 0x31, 0x5c, 0xc9, 0x59, 0x2c, 0xd3, 0xcb, 0x2e, 0xae, 0xd8, 0x5c, 0x47, 0x7b, 0x59,
+	0x70, 0xd2, 0x06, 0x3f, 0x85, 0xb2, 0xc8, 0xc8, 0x33, 0x96, 0xba, 0x7d, 0xf6, 0xb7, 0xa2, 0x2e,
+	0x0b, 0x4e, 0xce, 0xd0, 0x05, 0xa8, 0x8a, 0xfc, 0x5b, 0xf7, 0x87, 0xd0, 0xf8, 0x59, 0x39, 0x36,
+	0xe0, 0xa4, 0x7f, 0x31, 0x99, 0x3e, 0x77, 0x9d, 0xc1, 0x78, 0x7a, 0x35, 0x71, 0xc7, 0xd3, 0x5e,
+	0x6f, 0x30, 0x1e, 0x37, 0x0a, 0xbf, 0x6c, 0x86, 0x17, 0xa3, 0xab, 0xa9, 0x33, 0x68, 0x20, 0xfb,
+	0x03, 0x82, 0xea, 0x54, 0x12, 0xd1, 0x63, 0x01, 0xc1, 0xef, 0x11, 0x1c, 0xde, 0x74, 0x86, 0x1f,
+	0xed, 0xa5, 0xf6, 0x37, 0x7f, 0xda, 0xe9, 0xe3, 0x7f, 0x40, 0x6a, 0x6b, 0xed, 0xc2, 0x19, 0xea,
+	0x4e, 0x3e, 0x6d, 0x9b, 0xe8, 0xf3, 0xb6, 0x89, 0xbe, 0x6c, 0x9b, 0xe8, 0xd5, 0x70, 0x4e, 0xd5,
+	0x22, 0x99, 0x99, 0x3e, 0x8b, 0xac, 0x6b, 0xda, 0x1b, 0xd5, 0xda, 0xb6, 0xa4, 0xf0, 0xff, 0xf8,
+	0xe6, 0x67, 0xe5, 0xec, 0xdd, 0x9e, 0x7f, 0x1b, 0x00, 0x23, 0xbb, 0x7f, 0xa7, 0x1e, 0x04, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// UserCodeClient is the client API for UserCode service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type UserCodeClient interface {
+	// ProcessDatum processes a datum, whose inputs are in /pfs as they are for
+	// exec transforms, and streams the datum's logs followed by its result.
+	ProcessDatum(ctx context.Context, in *ProcessDatumRequest, opts ...grpc.CallOption) (UserCode_ProcessDatumClient, error)
+}
+
+type userCodeClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewUserCodeClient(cc *grpc.ClientConn) UserCodeClient {
+	return &userCodeClient{cc}
+}
+
+func (c *userCodeClient) ProcessDatum(ctx context.Context, in *ProcessDatumRequest, opts ...grpc.CallOption) (UserCode_ProcessDatumClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_UserCode_serviceDesc.Streams[0], "/pachyderm.worker.pipeline.transform.UserCode/ProcessDatum", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userCodeProcessDatumClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserCode_ProcessDatumClient interface {
+	Recv() (*ProcessDatumResponse, error)
+	grpc.ClientStream
+}
+
+type userCodeProcessDatumClient struct {
+	grpc.ClientStream
+}
+
+func (x *userCodeProcessDatumClient) Recv() (*ProcessDatumResponse, error) {
+	m := new(ProcessDatumResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UserCodeServer is the server API for UserCode service.
+type UserCodeServer interface {
+	// ProcessDatum processes a datum, whose inputs are in /pfs as they are for
+	// exec transforms, and streams the datum's logs followed by its result.
+	ProcessDatum(*ProcessDatumRequest, UserCode_ProcessDatumServer) error
+}
+
+// UnimplementedUserCodeServer can be embedded to have forward compatible implementations.
+type UnimplementedUserCodeServer struct {
+}
+
+func (*UnimplementedUserCodeServer) ProcessDatum(req *ProcessDatumRequest, srv UserCode_ProcessDatumServer) error {
+	return status.Errorf(codes.Unimplemented, "method ProcessDatum not implemented")
+}
+
+func RegisterUserCodeServer(s *grpc.Server, srv UserCodeServer) {
+	s.RegisterService(&_UserCode_serviceDesc, srv)
+}
+
+func _UserCode_ProcessDatum_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ProcessDatumRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserCodeServer).ProcessDatum(m, &userCodeProcessDatumServer{stream})
+}
+
+type UserCode_ProcessDatumServer interface {
+	Send(*ProcessDatumResponse) error
+	grpc.ServerStream
+}
+
+type userCodeProcessDatumServer struct {
+	grpc.ServerStream
+}
+
+func (x *userCodeProcessDatumServer) Send(m *ProcessDatumResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _UserCode_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pachyderm.worker.pipeline.transform.UserCode",
+	HandlerType: (*UserCodeServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ProcessDatum",
+			Handler:       _UserCode_ProcessDatum_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "server/worker/pipeline/transform/usercode.proto",
+}
+
+func (m *DatumInput) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DatumInput) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DatumInput) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.GroupBy) > 0 {
+		i -= len(m.GroupBy)
+		copy(dAtA[i:], m.GroupBy)
+		i = encodeVarintUsercode(dAtA, i, uint64(len(m.GroupBy)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.JoinOn) > 0 {
+		i -= len(m.JoinOn)
+		copy(dAtA[i:], m.JoinOn)
+		i = encodeVarintUsercode(dAtA, i, uint64(len(m.JoinOn)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.FileInfo != nil {
+		{
+			size, err := m.FileInfo.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintUsercode(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintUsercode(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintUsercode(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProcessDatumRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProcessDatumRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProcessDatumRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.OutputCommit != nil {
+		{
+			size, err := m.OutputCommit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintUsercode(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.OutputDir) > 0 {
+		i -= len(m.OutputDir)
+		copy(dAtA[i:], m.OutputDir)
+		i = encodeVarintUsercode(dAtA, i, uint64(len(m.OutputDir)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Inputs) > 0 {
+		for iNdEx := len(m.Inputs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Inputs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintUsercode(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.DatumId) > 0 {
+		i -= len(m.DatumId)
+		copy(dAtA[i:], m.DatumId)
+		i = encodeVarintUsercode(dAtA, i, uint64(len(m.DatumId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.JobId) > 0 {
+		i -= len(m.JobId)
+		copy(dAtA[i:], m.JobId)
+		i = encodeVarintUsercode(dAtA, i, uint64(len(m.JobId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DatumResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DatumResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DatumResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintUsercode(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.State != 0 {
+		i = encodeVarintUsercode(dAtA, i, uint64(m.State))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProcessDatumResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProcessDatumResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProcessDatumResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Response != nil {
+		{
+			size := m.Response.Size()
+			i -= size
+			if _, err := m.Response.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProcessDatumResponse_Log) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProcessDatumResponse_Log) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i -= len(m.Log)
+	copy(dAtA[i:], m.Log)
+	i = encodeVarintUsercode(dAtA, i, uint64(len(m.Log)))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+func (m *ProcessDatumResponse_Result) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProcessDatumResponse_Result) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Result != nil {
+		{
+			size, err := m.Result.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintUsercode(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func encodeVarintUsercode(dAtA []byte, offset int, v uint64) int {
+	offset -= sovUsercode(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *DatumInput) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovUsercode(uint64(l))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovUsercode(uint64(l))
+	}
+	if m.FileInfo != nil {
+		l = m.FileInfo.Size()
+		n += 1 + l + sovUsercode(uint64(l))
+	}
+	l = len(m.JoinOn)
+	if l > 0 {
+		n += 1 + l + sovUsercode(uint64(l))
+	}
+	l = len(m.GroupBy)
+	if l > 0 {
+		n += 1 + l + sovUsercode(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ProcessDatumRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.JobId)
+	if l > 0 {
+		n += 1 + l + sovUsercode(uint64(l))
+	}
+	l = len(m.DatumId)
+	if l > 0 {
+		n += 1 + l + sovUsercode(uint64(l))
+	}
+	if len(m.Inputs) > 0 {
+		for _, e := range m.Inputs {
+			l = e.Size()
+			n += 1 + l + sovUsercode(uint64(l))
+		}
+	}
+	l = len(m.OutputDir)
+	if l > 0 {
+		n += 1 + l + sovUsercode(uint64(l))
+	}
+	if m.OutputCommit != nil {
+		l = m.OutputCommit.Size()
+		n += 1 + l + sovUsercode(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DatumResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.State != 0 {
+		n += 1 + sovUsercode(uint64(m.State))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovUsercode(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ProcessDatumResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Response != nil {
+		n += m.Response.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ProcessDatumResponse_Log) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Log)
+	n += 1 + l + sovUsercode(uint64(l))
+	return n
+}
+func (m *ProcessDatumResponse_Result) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Result != nil {
+		l = m.Result.Size()
+		n += 1 + l + sovUsercode(uint64(l))
+	}
+	return n
+}
+
+func sovUsercode(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozUsercode(x uint64) (n int) {
+	return sovUsercode(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *DatumInput) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowUsercode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DatumInput: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DatumInput: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUsercode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUsercode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUsercode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.FileInfo == nil {
+				m.FileInfo = &pfs.FileInfo{}
+			}
+			if err := m.FileInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JoinOn", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUsercode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JoinOn = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupBy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUsercode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GroupBy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipUsercode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProcessDatumRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowUsercode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProcessDatumRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProcessDatumRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUsercode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DatumId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUsercode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DatumId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Inputs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUsercode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Inputs = append(m.Inputs, &DatumInput{})
+			if err := m.Inputs[len(m.Inputs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OutputDir", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUsercode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OutputDir = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OutputCommit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUsercode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.OutputCommit == nil {
+				m.OutputCommit = &pfs.Commit{}
+			}
+			if err := m.OutputCommit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipUsercode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DatumResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowUsercode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DatumResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DatumResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUsercode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= DatumResultState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUsercode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipUsercode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProcessDatumResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowUsercode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProcessDatumResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProcessDatumResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUsercode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Response = &ProcessDatumResponse_Log{string(dAtA[iNdEx:postIndex])}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUsercode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &DatumResult{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Response = &ProcessDatumResponse_Result{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipUsercode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthUsercode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipUsercode(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowUsercode
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowUsercode
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowUsercode
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthUsercode
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupUsercode
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthUsercode
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthUsercode        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowUsercode          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupUsercode = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,112 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/pps"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/driver"
+	"github.com/pachyderm/pachyderm/v2/src/server/worker/logs"
+)
+
+// testUserCode is user code that serves the UserCode service. It fails
+// datums whose first input is named "bad", and blocks on datums whose first
+// input is named "slow" until they're canceled.
+type testUserCode struct{}
+
+func (testUserCode) ProcessDatum(request *ProcessDatumRequest, server UserCode_ProcessDatumServer) error {
+	if err := server.Send(&ProcessDatumResponse{Response: &ProcessDatumResponse_Log{Log: "processing " + request.DatumId}}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	result := &DatumResult{}
+	switch request.Inputs[0].Name {
+	case "bad":
+		result = &DatumResult{State: DatumResultState_DATUM_RESULT_FAILURE, Message: "bad input"}
+	case "slow":
+		<-server.Context().Done()
+		return errors.EnsureStack(server.Context().Err())
+	}
+	return errors.EnsureStack(server.Send(&ProcessDatumResponse{Response: &ProcessDatumResponse_Result{Result: result}}))
+}
+
+// userCodeTestDriver runs testUserCode as the user code, until the user code
+// is stopped or exit is closed.
+type userCodeTestDriver struct {
+	driver.Driver
+	starts int
+	exit   chan struct{}
+}
+
+func (d *userCodeTestDriver) PipelineInfo() *pps.PipelineInfo {
+	return &pps.PipelineInfo{Details: &pps.PipelineInfo_Details{
+		Transform: &pps.Transform{Cmd: []string{"serve"}, Protocol: pps.TransformProtocol_TRANSFORM_GRPC},
+	}}
+}
+
+func (d *userCodeTestDriver) RunUserCode(ctx context.Context, logger logs.TaggedLogger, env []string) error {
+	d.starts++
+	var socket string
+	for _, e := range env {
+		if strings.HasPrefix(e, SocketEnv+"=") {
+			socket = strings.TrimPrefix(e, SocketEnv+"=")
+		}
+	}
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	server := grpc.NewServer()
+	RegisterUserCodeServer(server, testUserCode{})
+	go server.Serve(listener) //nolint:errcheck
+	defer server.Stop()
+	select {
+	case <-ctx.Done():
+	case <-d.exit:
+	}
+	return nil
+}
+
+func TestUserCodeServer(t *testing.T) {
+	ctx := context.Background()
+	d := &userCodeTestDriver{exit: make(chan struct{})}
+	logger := logs.NewMockLogger()
+	buf := &bytes.Buffer{}
+	logger.Writer = buf
+	s := newUserCodeServer(ctx, d, logger)
+	require.NotNil(t, s)
+	s.socket = filepath.Join(t.TempDir(), "transform.sock")
+	defer s.close()
+
+	request := func(name string) *ProcessDatumRequest {
+		return &ProcessDatumRequest{DatumId: name + "-datum", Inputs: []*DatumInput{{Name: name}}}
+	}
+	require.NoError(t, s.processDatum(ctx, logger, request("good")))
+	require.True(t, strings.Contains(buf.String(), "processing good-datum"))
+	err := s.processDatum(ctx, logger, request("bad"))
+	require.YesError(t, err)
+	require.True(t, strings.Contains(err.Error(), "bad input"))
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	require.YesError(t, s.processDatum(timeoutCtx, logger, request("slow")))
+	// The code is only started once for all of the datums.
+	require.Equal(t, 1, d.starts)
+
+	// The code is started again after it exits.
+	close(d.exit)
+	s.mu.Lock()
+	exited := s.exited
+	s.mu.Unlock()
+	<-exited
+	d.exit = make(chan struct{})
+	require.NoError(t, s.processDatum(ctx, logger, request("good")))
+	require.Equal(t, 2, d.starts)
+}
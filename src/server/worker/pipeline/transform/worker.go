@@ -35,6 +35,10 @@ func (h *hasher) Hash(inputs []*common.Input) string {
 }
 
 func Worker(ctx context.Context, driver driver.Driver, logger logs.TaggedLogger, status *Status) error {
+	userCode := newUserCodeServer(ctx, driver, logger)
+	if userCode != nil {
+		defer userCode.close()
+	}
 	return errors.EnsureStack(driver.NewTaskSource().Iterate(
 		ctx,
 		func(ctx context.Context, input *types.Any) (*types.Any, error) {
@@ -69,7 +73,7 @@ func Worker(ctx context.Context, driver driver.Driver, logger logs.TaggedLogger,
 				return processCreateDatumSetsTask(driver, createDatumSetsTask)
 			case types.Is(input, &DatumSet{}):
 				driver := driver.WithContext(ctx)
-				return processDatumSet(driver, logger, input, status)
+				return processDatumSet(driver, logger, input, status, userCode)
 			default:
 				return nil, errors.Errorf("unrecognized any type (%v) in transform worker", input.TypeUrl)
 			}
@@ -98,6 +102,9 @@ func processUploadDatumsTask(pachClient *client.APIClient, task *UploadDatumsTas
 		if err != nil {
 			return nil, err
 		}
+		if canary := jobInfo.Details.Canary; canary != nil {
+			dit = datum.NewSampleIterator(dit, canary.Fraction)
+		}
 		dit = datum.NewJobIterator(dit, jobInfo.Job, &hasher{salt: jobInfo.Details.Salt})
 	}
 	fileSetID, count, err := uploadDatumFileSet(pachClient, dit)
@@ -292,7 +299,7 @@ func createSetSpec(driver driver.Driver, fileSetID string) (*datum.SetSpec, erro
 // datum queuing (probably should be handled by datum package).
 // capture datum logs.
 // git inputs.
-func processDatumSet(driver driver.Driver, logger logs.TaggedLogger, input *types.Any, status *Status) (*types.Any, error) {
+func processDatumSet(driver driver.Driver, logger logs.TaggedLogger, input *types.Any, status *Status, userCode *userCodeServer) (*types.Any, error) {
 	datumSet, err := deserializeDatumSet(input)
 	if err != nil {
 		return nil, err
@@ -306,7 +313,7 @@ func processDatumSet(driver driver.Driver, logger logs.TaggedLogger, input *type
 					return err
 				}
 			}
-			return handleDatumSet(driver, logger, datumSet, status)
+			return handleDatumSet(driver, logger, datumSet, status, userCode)
 		}); err != nil {
 			return errors.EnsureStack(err)
 		}
@@ -340,7 +347,7 @@ func checkS3Gateway(driver driver.Driver, logger logs.TaggedLogger) error {
 	// return nil
 }
 
-func handleDatumSet(driver driver.Driver, logger logs.TaggedLogger, datumSet *DatumSet, status *Status) error {
+func handleDatumSet(driver driver.Driver, logger logs.TaggedLogger, datumSet *DatumSet, status *Status, userCode *userCodeServer) error {
 	pachClient := driver.PachClient()
 	// TODO: Can this just be refactored into the datum package such that we don't need to specify a storage root for the sets?
 	// The sets would just create a temporary directory under /tmp.
@@ -350,6 +357,23 @@ func handleDatumSet(driver driver.Driver, logger logs.TaggedLogger, datumSet *Da
 	if err != nil {
 		return errors.Wrap(err, "could not get user image ID")
 	}
+	sampleGPU := gpuSampler(driver.PipelineInfo().Details)
+	exec := newExecutor(driver)
+	var cache *datumCache
+	if driver.PipelineInfo().Details.DatumCache {
+		cache = newDatumCache(pachClient, driver.PipelineInfo(), userImageID)
+	}
+	var checkpoints *datumCheckpoints
+	var restarted bool
+	if driver.PipelineInfo().Details.DatumCheckpoints {
+		checkpoints = newDatumCheckpoints(pachClient, client.NewJob(driver.PipelineInfo().Pipeline.Name, datumSet.JobID))
+		if restarted, err = checkpoints.start(pachClient.Ctx(), datumSet.FileSetId); err != nil {
+			return errors.Wrap(err, "could not record the start of the datum set")
+		}
+		if restarted {
+			logger.Logf("datum set was restarted, restoring its processed datums from their checkpoints")
+		}
+	}
 	return pachClient.WithRenewer(func(ctx context.Context, renewer *renew.StringSet) error {
 		// Setup file operation client for output meta commit.
 		resp, err := pachClient.WithCreateFileSetClient(func(mfMeta client.ModifyFile) error {
@@ -371,7 +395,13 @@ func handleDatumSet(driver driver.Driver, logger logs.TaggedLogger, datumSet *Da
 						meta = proto.Clone(meta).(*datum.Meta)
 						meta.ImageId = userImageID
 						inputs := meta.Inputs
-						logger = logger.WithData(inputs)
+						logger := logger.WithData(inputs)
+						// capture holds the logs of the datum's current try, in
+						// case it's quarantined.
+						capture := &logs.Capture{}
+						if policy := driver.PipelineInfo().Details.DatumRetryPolicy; policy != nil && policy.Quarantine {
+							logger = logs.WithCapture(logger, capture)
+						}
 						env := driver.UserCodeEnv(logger.JobID(), datumSet.OutputCommit, inputs)
 						var opts []datum.Option
 						if driver.PipelineInfo().Details.DatumTimeout != nil {
@@ -384,23 +414,82 @@ func handleDatumSet(driver driver.Driver, logger logs.TaggedLogger, datumSet *Da
 						if driver.PipelineInfo().Details.DatumTries > 0 {
 							opts = append(opts, datum.WithRetry(int(driver.PipelineInfo().Details.DatumTries)-1))
 						}
+						if policy := driver.PipelineInfo().Details.DatumRetryPolicy; policy != nil {
+							policyOpts, err := retryPolicyOptions(policy, capture)
+							if err != nil {
+								return err
+							}
+							opts = append(opts, policyOpts...)
+						}
+						if sampleGPU != nil {
+							opts = append(opts, datum.WithGPUSampler(sampleGPU))
+						}
+						if readahead := driver.PipelineInfo().Details.Readahead; readahead != nil {
+							opts = append(opts, datum.WithReadahead(int(readahead.Files), readahead.SizeBytesOrDefault()))
+						}
 						if driver.PipelineInfo().Details.Transform.ErrCmd != nil {
 							opts = append(opts, datum.WithRecoveryCallback(func(runCtx context.Context) error {
 								return errors.EnsureStack(driver.RunUserErrorHandlingCode(runCtx, logger, env))
 							}))
 						}
+						var cacheKey, checkpointKey string
+						if cache != nil {
+							cacheKey = cache.key(inputs)
+						}
+						if checkpoints != nil {
+							checkpointKey = checkpoints.key(inputs)
+						}
+						// only the datum's first try is restored from its checkpoint
+						restore := restarted
 						return s.WithDatum(meta, func(d *datum.Datum) error {
+							capture.Reset()
+							outputDir := filepath.Join(d.PFSStorageRoot(), datum.OutputPrefix)
+							if restore {
+								restore = false
+								if ok, err := checkpoints.restore(ctx, checkpointKey, outputDir); err != nil {
+									logger.Logf("could not restore datum output from its checkpoint: %v", err)
+								} else if ok {
+									logger.Logf("restored datum output from its checkpoint")
+									datumSet.Stats.Restored++
+									return nil
+								}
+								datumSet.Stats.Recomputed++
+							}
+							if cache != nil {
+								if ok, err := cache.restore(ctx, cacheKey, outputDir); err != nil {
+									logger.Logf("could not restore datum output from the datum cache: %v", err)
+								} else if ok {
+									logger.Logf("restored datum output from the datum cache")
+									return nil
+								}
+							}
 							cancelCtx, cancel := context.WithCancel(ctx)
 							defer cancel()
 							err := status.withDatum(inputs, cancel, func() error {
 								err := driver.WithActiveData(inputs, d.PFSStorageRoot(), func() error {
 									err := d.Run(cancelCtx, func(runCtx context.Context) error {
+										if exec != nil {
+											return runExecutor(runCtx, pachClient, renewer, exec, logger, inputs, outputDir)
+										}
+										if userCode != nil {
+											return userCode.processDatum(runCtx, logger, newProcessDatumRequest(driver, logger.JobID(), datumSet.OutputCommit, inputs))
+										}
 										return errors.EnsureStack(driver.RunUserCode(runCtx, logger, env))
 									})
 									return errors.EnsureStack(err)
 								})
 								return errors.EnsureStack(err)
 							})
+							if err == nil && cache != nil {
+								if err := cache.save(ctx, cacheKey, outputDir); err != nil {
+									logger.Logf("could not add datum output to the datum cache: %v", err)
+								}
+							}
+							if err == nil && checkpoints != nil {
+								if err := checkpoints.save(ctx, checkpointKey, outputDir); err != nil {
+									logger.Logf("could not checkpoint datum output: %v", err)
+								}
+							}
 							return errors.EnsureStack(err)
 						}, opts...)
 					})
@@ -421,6 +510,33 @@ func handleDatumSet(driver driver.Driver, logger logs.TaggedLogger, datumSet *Da
 	})
 }
 
+// retryPolicyOptions returns the datum options that implement a pipeline's
+// datum retry policy.
+func retryPolicyOptions(policy *pps.DatumRetryPolicy, capture *logs.Capture) ([]datum.Option, error) {
+	var opts []datum.Option
+	if policy.Backoff != nil {
+		backoff, err := types.DurationFromProto(policy.Backoff)
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		var maxBackoff time.Duration
+		if policy.MaxBackoff != nil {
+			maxBackoff, err = types.DurationFromProto(policy.MaxBackoff)
+			if err != nil {
+				return nil, errors.EnsureStack(err)
+			}
+		}
+		opts = append(opts, datum.WithRetryBackoff(backoff, maxBackoff))
+	}
+	if policy.NoRetryOnTimeout {
+		opts = append(opts, datum.WithoutTimeoutRetry())
+	}
+	if policy.Quarantine {
+		opts = append(opts, datum.WithQuarantine(capture.Reader))
+	}
+	return opts, nil
+}
+
 func deserializeUploadDatumsTask(taskAny *types.Any) (*UploadDatumsTask, error) {
 	task := &UploadDatumsTask{}
 	if err := types.UnmarshalAny(taskAny, task); err != nil {
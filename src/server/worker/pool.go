@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/serviceenv"
+)
+
+// poolAssignmentPollInterval is how often an idle pool worker checks whether
+// it has been claimed.
+const poolAssignmentPollInterval = time.Second
+
+// WaitForPoolAssignment waits until the worker, if it was started by a warm
+// worker pool, is claimed by a pipeline, and then sets the pipeline name and
+// spec commit of env's configuration to the pipeline's, so that the worker
+// runs it. It returns immediately for workers started by their pipeline.
+func WaitForPoolAssignment(ctx context.Context, env serviceenv.ServiceEnv) error {
+	config := env.Config()
+	if config.PPSWorkerPool == "" {
+		return nil
+	}
+	log.Infof("waiting to be claimed from worker pool %s", config.PPSWorkerPool)
+	pods := env.GetKubeClient().CoreV1().Pods(config.Namespace)
+	for {
+		pod, err := pods.Get(ctx, config.PodName, metav1.GetOptions{})
+		if err != nil {
+			log.Errorf("could not read pod %s: %v", config.PodName, err)
+		} else if assignment, ok := pod.Annotations[ppsutil.WorkerPoolAssignmentAnnotation]; ok {
+			pipelineName, specCommitID, err := ppsutil.ParseWorkerPoolAssignment(assignment)
+			if err != nil {
+				return err
+			}
+			log.Infof("claimed from worker pool %s by pipeline %s", config.PPSWorkerPool, pipelineName)
+			config.PPSPipelineName, config.PPSSpecCommitID = pipelineName, specCommitID
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.EnsureStack(ctx.Err())
+		case <-time.After(poolAssignmentPollInterval):
+		}
+	}
+}
@@ -95,15 +95,20 @@ func (c *Client) Close() error {
 }
 
 // NewClient returns a worker client for the worker at the IP address passed in.
+// Unlike the clients that WithClient passes to its callback, it has its own
+// connection, which it closes.
 func NewClient(address string) (Client, error) {
 	port, err := strconv.Atoi(os.Getenv(client.PPSWorkerPortEnv))
 	if err != nil {
 		return Client{}, errors.EnsureStack(err)
 	}
+	opts, err := dialOptions()
+	if err != nil {
+		return Client{}, err
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
 	defer cancel()
-	conn, err := grpc.DialContext(ctx, fmt.Sprintf("%s:%d", address, port),
-		append(client.DefaultDialOptions(), grpc.WithInsecure())...)
+	conn, err := grpc.DialContext(ctx, fmt.Sprintf("%s:%d", address, port), opts...)
 	if err != nil {
 		return Client{}, errors.EnsureStack(err)
 	}
@@ -125,19 +130,16 @@ func ForEachWorker(ctx context.Context, pipelineRcName string, etcdClient *etcd.
 	return nil
 }
 
-func WithClient(ctx context.Context, address string, port uint16, cb func(Client) error) (retErr error) {
-	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+// WithClient calls cb with a client for the worker at address. The client's
+// connection is pooled, and reused by later calls for the same worker, so cb
+// must not close it.
+func WithClient(ctx context.Context, address string, port uint16, cb func(Client) error) error {
+	target := fmt.Sprintf("%s:%d", address, port)
+	dialCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
 	defer cancel()
-	conn, err := grpc.DialContext(ctx, fmt.Sprintf("%s:%d", address, port),
-		append(client.DefaultDialOptions(), grpc.WithInsecure())...)
+	conn, err := pool.get(dialCtx, target)
 	if err != nil {
-		return errors.EnsureStack(err)
+		return err
 	}
-	c := newClient(conn)
-	defer func() {
-		if err := c.Close(); retErr == nil {
-			retErr = err
-		}
-	}()
-	return cb(c)
+	return cb(newClient(conn))
 }
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/tls"
+)
+
+// connIdleTimeout is how long a pooled connection to a worker is kept after
+// it was last used. Workers come and go as pipelines are scaled, so the
+// connections to the ones that are gone are closed once they're idle.
+const connIdleTimeout = 5 * time.Minute
+
+// pool is the pool of connections to workers that WithClient uses.
+var pool = &connPool{conns: make(map[string]*pooledConn)}
+
+// connPool reuses connections to workers across calls, so that pachd doesn't
+// dial each worker, and do a TLS handshake with it, every time it checks the
+// workers' statuses.
+type connPool struct {
+	mu    sync.Mutex
+	conns map[string]*pooledConn
+}
+
+type pooledConn struct {
+	conn     *grpc.ClientConn
+	lastUsed time.Time
+}
+
+// get returns the pooled connection to target, or dials it if there isn't
+// one, or if the one there is has failed.
+func (p *connPool) get(ctx context.Context, target string) (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	p.closeIdle()
+	if pc, ok := p.conns[target]; ok {
+		switch pc.conn.GetState() {
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			// redial rather than wait for the connection to reconnect, since
+			// the worker may be gone
+			pc.conn.Close()
+			delete(p.conns, target)
+		default:
+			pc.lastUsed = time.Now()
+			p.mu.Unlock()
+			return pc.conn, nil
+		}
+	}
+	p.mu.Unlock()
+	opts, err := dialOptions()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.DialContext(ctx, target, opts...)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pc, ok := p.conns[target]; ok {
+		// another call dialed target at the same time
+		conn.Close()
+		pc.lastUsed = time.Now()
+		return pc.conn, nil
+	}
+	p.conns[target] = &pooledConn{conn: conn, lastUsed: time.Now()}
+	return conn, nil
+}
+
+// closeIdle closes the connections that haven't been used for
+// connIdleTimeout. p.mu must be held.
+func (p *connPool) closeIdle() {
+	for target, pc := range p.conns {
+		if time.Since(pc.lastUsed) > connIdleTimeout {
+			pc.conn.Close()
+			delete(p.conns, target)
+		}
+	}
+}
+
+var (
+	peerCredsOnce sync.Once
+	peerCreds     credentials.TransportCredentials
+	peerCredsErr  error
+)
+
+// dialOptions returns the options that workers are dialed with. If a peer
+// certificate is mounted, workers are dialed over mutual TLS.
+func dialOptions() ([]grpc.DialOption, error) {
+	opts := client.DefaultDialOptions()
+	if !tls.PeerTLSEnabled() {
+		return append(opts, grpc.WithInsecure()), nil
+	}
+	peerCredsOnce.Do(func() {
+		config, err := tls.NewPeerClientConfig()
+		if err != nil {
+			peerCredsErr = err
+			return
+		}
+		peerCreds = credentials.NewTLS(config)
+	})
+	if peerCredsErr != nil {
+		return nil, peerCredsErr
+	}
+	return append(opts, grpc.WithTransportCredentials(peerCreds)), nil
+}
+
+// ServerOptions returns the options of the worker's gRPC server. If a peer
+// certificate is mounted, the server only accepts clients over mutual TLS.
+func ServerOptions() ([]grpc.ServerOption, error) {
+	if !tls.PeerTLSEnabled() {
+		return nil, nil
+	}
+	config, err := tls.NewPeerServerConfig()
+	if err != nil {
+		return nil, err
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(config))}, nil
+}
@@ -31,6 +31,14 @@ func DatumLabels(job *pps.Job, state string) prometheus.Labels {
 	}
 }
 
+// JobQueueLabels returns the labels of the JobQueueTime metric.
+func JobQueueLabels(job *pps.Job, priority string) prometheus.Labels {
+	return prometheus.Labels{
+		"pipeline": job.Pipeline.Name,
+		"priority": priority,
+	}
+}
+
 var (
 	bucketFactor = 2.0
 	bucketCount  = 20 // Which makes the max bucket 2^20 seconds or ~12 days in size
@@ -196,13 +204,35 @@ var (
 			"job",
 		},
 	)
+
+	// JobQueueTime is a histogram tracking how long jobs wait between being
+	// created and starting to run, by pipeline priority
+	JobQueueTime = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "pachyderm",
+			Subsystem: "worker",
+			Name:      "job_queue_time",
+			Help:      "Time between a job being created and it starting to run",
+			Buckets:   prometheus.ExponentialBuckets(1.0, bucketFactor, bucketCount),
+		},
+		[]string{
+			"pipeline",
+			"priority",
+		},
+	)
 )
 
 // InitPrometheus sets up the default datum stats collectors for use by worker
-// code, and exposes the stats on an http endpoint.
+// code, and exposes the stats, along with any user code metrics, on an http
+// endpoint.
 func InitPrometheus() {
 	logrus.Infof("registering prometheus collectors")
-	http.Handle("/metrics", promhttp.Handler())
+	// User code metrics that clash with the worker's are dropped rather than
+	// failing the whole scrape.
+	http.Handle("/metrics", promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer, promhttp.HandlerFor(
+		prometheus.Gatherers{prometheus.DefaultGatherer, userCodeMetrics},
+		promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError},
+	)))
 	go func() {
 		if err := http.ListenAndServe(fmt.Sprintf(":%v", PrometheusPort), nil); err != nil {
 			logrus.Errorf("error serving prometheus metrics: %v", err)
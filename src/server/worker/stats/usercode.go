@@ -0,0 +1,100 @@
+package stats
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// userCodeScrapeTimeout bounds how long scraping user code's metrics may
+// delay the worker's own metrics.
+const userCodeScrapeTimeout = 5 * time.Second
+
+// userCodeGatherer gathers the metrics that user code serves in the
+// Prometheus text format, adding the worker's labels to them.
+type userCodeGatherer struct {
+	mu     sync.Mutex
+	url    string
+	labels []*dto.LabelPair
+	client *http.Client
+}
+
+var userCodeMetrics = &userCodeGatherer{
+	client: &http.Client{Timeout: userCodeScrapeTimeout},
+}
+
+// ExportUserCodeMetrics re-exports the metrics served at url by user code,
+// e.g. a service pipeline's /metrics endpoint, alongside the worker's own
+// metrics, with the given labels added to them.
+func ExportUserCodeMetrics(url string, labels prometheus.Labels) {
+	userCodeMetrics.mu.Lock()
+	defer userCodeMetrics.mu.Unlock()
+	userCodeMetrics.url = url
+	userCodeMetrics.labels = nil
+	for name, value := range labels {
+		name, value := name, value
+		userCodeMetrics.labels = append(userCodeMetrics.labels, &dto.LabelPair{Name: &name, Value: &value})
+	}
+}
+
+// Gather implements prometheus.Gatherer. It returns no metrics if user code
+// doesn't serve any.
+func (g *userCodeGatherer) Gather() ([]*dto.MetricFamily, error) {
+	g.mu.Lock()
+	url, labels := g.url, g.labels
+	g.mu.Unlock()
+	if url == "" {
+		return nil, nil
+	}
+	resp, err := g.client.Get(url)
+	if err != nil {
+		// The user code isn't up, or doesn't serve metrics.
+		return nil, nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil
+	}
+	return parseUserCodeMetrics(resp, labels)
+}
+
+func parseUserCodeMetrics(resp *http.Response, labels []*dto.LabelPair) ([]*dto.MetricFamily, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse user code metrics")
+	}
+	var result []*dto.MetricFamily
+	for _, family := range families {
+		for _, metric := range family.Metric {
+			metric.Label = withLabels(metric.Label, labels)
+		}
+		result = append(result, family)
+	}
+	return result, nil
+}
+
+// withLabels adds labels to pairs, replacing any pairs with the same names.
+func withLabels(pairs, labels []*dto.LabelPair) []*dto.LabelPair {
+	result := append([]*dto.LabelPair{}, labels...)
+	for _, pair := range pairs {
+		replaced := false
+		for _, label := range labels {
+			if pair.GetName() == label.GetName() {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			result = append(result, pair)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].GetName() < result[j].GetName() })
+	return result
+}
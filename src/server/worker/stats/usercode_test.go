@@ -0,0 +1,38 @@
+package stats
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestUserCodeMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "# TYPE predictions_total counter")
+		fmt.Fprintln(w, `predictions_total{model="resnet",pipeline="spoofed"} 42`)
+	}))
+	defer server.Close()
+
+	g := &userCodeGatherer{client: http.DefaultClient}
+	families, err := g.Gather()
+	require.NoError(t, err)
+	require.Equal(t, 0, len(families))
+
+	defer ExportUserCodeMetrics("", nil)
+	ExportUserCodeMetrics(server.URL, prometheus.Labels{"pipeline": "serve"})
+	families, err = userCodeMetrics.Gather()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(families))
+	require.Equal(t, "predictions_total", families[0].GetName())
+	metric := families[0].Metric[0]
+	require.Equal(t, 42.0, metric.GetCounter().GetValue())
+	labels := make(map[string]string)
+	for _, pair := range metric.Label {
+		labels[pair.GetName()] = pair.GetValue()
+	}
+	require.Equal(t, map[string]string{"model": "resnet", "pipeline": "serve"}, labels)
+}
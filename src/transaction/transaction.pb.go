@@ -81,6 +81,7 @@ type TransactionRequest struct {
 	UpdateJobState       *pps.UpdateJobStateRequest  `protobuf:"bytes,8,opt,name=update_job_state,json=updateJobState,proto3" json:"update_job_state,omitempty"`
 	CreatePipeline       *pps.CreatePipelineRequest  `protobuf:"bytes,9,opt,name=create_pipeline,json=createPipeline,proto3" json:"create_pipeline,omitempty"`
 	StopJob              *pps.StopJobRequest         `protobuf:"bytes,10,opt,name=stop_job,json=stopJob,proto3" json:"stop_job,omitempty"`
+	DeletePipeline       *pps.DeletePipelineRequest  `protobuf:"bytes,11,opt,name=delete_pipeline,json=deletePipeline,proto3" json:"delete_pipeline,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
 	XXX_unrecognized     []byte                      `json:"-"`
 	XXX_sizecache        int32                       `json:"-"`
@@ -189,6 +190,13 @@ func (m *TransactionRequest) GetStopJob() *pps.StopJobRequest {
 	return nil
 }
 
+func (m *TransactionRequest) GetDeletePipeline() *pps.DeletePipelineRequest {
+	if m != nil {
+		return m.DeletePipeline
+	}
+	return nil
+}
+
 type TransactionResponse struct {
 	// At most, one of these fields should be set (most responses are empty)
 	Commit               *pfs.Commit `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
@@ -694,60 +702,61 @@ func init() {
 func init() { proto.RegisterFile("transaction/transaction.proto", fileDescriptor_284c03442be38d9f) }
 
 var fileDescriptor_284c03442be38d9f = []byte{
-	// 840 bytes of a gzipped FileDescriptorProto
+	// 853 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdd, 0x8e, 0xdb, 0x44,
-	0x14, 0xde, 0x64, 0xbb, 0x9b, 0xcd, 0x49, 0xbb, 0xc9, 0x0e, 0x28, 0xf5, 0x7a, 0xd5, 0x6c, 0x64,
-	0x44, 0x59, 0x6e, 0x6c, 0x35, 0x70, 0x85, 0x54, 0x60, 0xd3, 0xd2, 0x2a, 0x11, 0x17, 0x95, 0x53,
-	0x84, 0x76, 0x25, 0x1a, 0xfc, 0x33, 0x4e, 0x8c, 0x12, 0xcf, 0xd4, 0x33, 0x89, 0xd4, 0x37, 0xe0,
-	0x35, 0x10, 0x2f, 0xc3, 0x25, 0x4f, 0x80, 0x50, 0x9e, 0x04, 0x79, 0x66, 0xec, 0xd8, 0xce, 0x4f,
-	0x8b, 0xba, 0x77, 0x9e, 0x73, 0xce, 0xf7, 0xf9, 0x3b, 0x3f, 0x73, 0x34, 0xf0, 0x88, 0xc7, 0x4e,
-	0xc4, 0x1c, 0x8f, 0x87, 0x24, 0xb2, 0x72, 0xdf, 0x26, 0x8d, 0x09, 0x27, 0xe8, 0x34, 0x67, 0x1a,
-	0x2f, 0x7b, 0xfa, 0xc5, 0x84, 0x90, 0xc9, 0x0c, 0x5b, 0xc2, 0xeb, 0x2e, 0x02, 0x0b, 0xcf, 0x29,
-	0x7f, 0x27, 0x83, 0xf5, 0xcb, 0xb2, 0x93, 0x87, 0x73, 0xcc, 0xb8, 0x33, 0xa7, 0x2a, 0xe0, 0xd3,
-	0x09, 0x99, 0x10, 0xf1, 0x69, 0x25, 0x5f, 0xca, 0xfa, 0x80, 0x06, 0xcc, 0xa2, 0x01, 0xcb, 0x8e,
-	0x94, 0x59, 0x94, 0xaa, 0xa3, 0x81, 0xa0, 0xf5, 0x1c, 0xcf, 0x30, 0xc7, 0xd7, 0xb3, 0x99, 0x8d,
-	0xdf, 0x2e, 0x30, 0xe3, 0xc6, 0x1f, 0x47, 0x80, 0x5e, 0xaf, 0x85, 0x29, 0x33, 0xfa, 0x06, 0x1a,
-	0x5e, 0x8c, 0x1d, 0x8e, 0xc7, 0x31, 0xa6, 0x44, 0xab, 0x74, 0x2b, 0x57, 0x8d, 0xde, 0xb9, 0x49,
-	0x03, 0x36, 0x5e, 0xf6, 0xcc, 0x67, 0xc2, 0x65, 0x63, 0x4a, 0x54, 0xbc, 0x0d, 0x5e, 0x66, 0x4a,
-	0xb0, 0xbe, 0xf8, 0x8d, 0xc4, 0x56, 0x8b, 0x58, 0xa9, 0xa0, 0x80, 0xf5, 0x33, 0x13, 0x7a, 0x0a,
-	0xf7, 0x19, 0x77, 0x62, 0x3e, 0xf6, 0xc8, 0x7c, 0x1e, 0x72, 0xed, 0x50, 0x80, 0xf5, 0x14, 0x3c,
+	0x14, 0xde, 0x64, 0xbb, 0x7f, 0x27, 0xed, 0x26, 0x3b, 0xa0, 0xd4, 0xeb, 0x55, 0xb3, 0x91, 0x11,
+	0x65, 0xb9, 0xb1, 0xd5, 0xc0, 0x15, 0x52, 0x81, 0x4d, 0x4b, 0xab, 0x44, 0x5c, 0x54, 0x4e, 0x11,
+	0xda, 0x95, 0x68, 0xf0, 0xcf, 0x38, 0x31, 0x4a, 0x3c, 0x53, 0xcf, 0x24, 0x52, 0xdf, 0x80, 0xf7,
+	0xe0, 0x65, 0xb8, 0xe4, 0x09, 0x10, 0xca, 0x1d, 0x6f, 0x81, 0x3c, 0x33, 0x76, 0xfc, 0x93, 0xa4,
+	0x45, 0xdd, 0x3b, 0xfb, 0x9c, 0xf3, 0x7d, 0xf3, 0x9d, 0x9f, 0x39, 0x1a, 0x78, 0xc4, 0x63, 0x27,
+	0x62, 0x8e, 0xc7, 0x43, 0x12, 0x59, 0xb9, 0x6f, 0x93, 0xc6, 0x84, 0x13, 0x74, 0x9a, 0x33, 0x8d,
+	0x97, 0x3d, 0xfd, 0x62, 0x42, 0xc8, 0x64, 0x86, 0x2d, 0xe1, 0x75, 0x17, 0x81, 0x85, 0xe7, 0x94,
+	0xbf, 0x93, 0xc1, 0xfa, 0x65, 0xd9, 0xc9, 0xc3, 0x39, 0x66, 0xdc, 0x99, 0x53, 0x15, 0xf0, 0xe9,
+	0x84, 0x4c, 0x88, 0xf8, 0xb4, 0x92, 0x2f, 0x65, 0x7d, 0x40, 0x03, 0x66, 0xd1, 0x80, 0x65, 0xbf,
+	0x94, 0x59, 0x94, 0xaa, 0x5f, 0x03, 0x41, 0xeb, 0x39, 0x9e, 0x61, 0x8e, 0xaf, 0x67, 0x33, 0x1b,
+	0xbf, 0x5d, 0x60, 0xc6, 0x8d, 0x7f, 0x0f, 0x00, 0xbd, 0x5e, 0x0b, 0x53, 0x66, 0xf4, 0x0d, 0x34,
+	0xbc, 0x18, 0x3b, 0x1c, 0x8f, 0x63, 0x4c, 0x89, 0x56, 0xeb, 0xd6, 0xae, 0x1a, 0xbd, 0x73, 0x93,
+	0x06, 0x6c, 0xbc, 0xec, 0x99, 0xcf, 0x84, 0xcb, 0xc6, 0x94, 0xa8, 0x78, 0x1b, 0xbc, 0xcc, 0x94,
+	0x60, 0x7d, 0x71, 0x8c, 0xc4, 0xd6, 0x8b, 0x58, 0xa9, 0xa0, 0x80, 0xf5, 0x33, 0x13, 0x7a, 0x0a,
+	0xf7, 0x19, 0x77, 0x62, 0x3e, 0xf6, 0xc8, 0x7c, 0x1e, 0x72, 0x6d, 0x5f, 0x80, 0xf5, 0x14, 0x3c,
 	0x4a, 0x7c, 0xcf, 0x84, 0x2b, 0x45, 0x37, 0xd8, 0xda, 0x86, 0xbe, 0x87, 0x07, 0x41, 0x18, 0x85,
 	0x6c, 0x9a, 0xe2, 0xef, 0x09, 0xfc, 0x45, 0x8a, 0x7f, 0x21, 0x9c, 0x45, 0x82, 0xfb, 0x41, 0xce,
-	0x88, 0x86, 0x70, 0xc6, 0xde, 0x2e, 0x9c, 0x8c, 0x61, 0xcc, 0x30, 0xd7, 0x8e, 0x04, 0x4b, 0x27,
+	0x88, 0x86, 0x70, 0xc6, 0xde, 0x2e, 0x9c, 0x8c, 0x61, 0xcc, 0x30, 0xd7, 0x0e, 0x04, 0x4b, 0x27,
 	0x53, 0x21, 0x02, 0x24, 0x60, 0x84, 0x33, 0xa2, 0x26, 0x2b, 0xda, 0x13, 0x35, 0xaa, 0x88, 0x6e,
-	0xec, 0x44, 0xde, 0x54, 0x3b, 0x2e, 0xaa, 0x91, 0x65, 0xec, 0x0b, 0x5f, 0xa6, 0xc6, 0xcb, 0x19,
-	0x13, 0x06, 0x55, 0x4a, 0xc5, 0x50, 0x2b, 0x32, 0xc8, 0x62, 0x96, 0x18, 0xfc, 0x9c, 0x11, 0xbd,
-	0x84, 0xd6, 0x82, 0xfa, 0x89, 0x86, 0xdf, 0x88, 0x3b, 0x66, 0xdc, 0xe1, 0x58, 0x3b, 0x11, 0x24,
-	0x8f, 0x4c, 0x4a, 0x05, 0xc9, 0x4f, 0xc2, 0x3f, 0x24, 0xee, 0x88, 0x8b, 0x16, 0x4a, 0x9a, 0xd3,
-	0x45, 0xc1, 0x8c, 0x5e, 0x40, 0x53, 0x25, 0x43, 0x43, 0x8a, 0x67, 0x61, 0x84, 0xb5, 0x7a, 0x91,
-	0x47, 0xa6, 0xf3, 0x4a, 0x79, 0x33, 0x1e, 0xaf, 0x60, 0x46, 0x4f, 0xe0, 0x84, 0x71, 0x42, 0x13,
-	0x39, 0x1a, 0x08, 0x82, 0x76, 0x4a, 0x30, 0xe2, 0x84, 0x0e, 0x89, 0x9b, 0x22, 0x6b, 0x4c, 0x9e,
-	0x8d, 0xa7, 0xf0, 0x49, 0x61, 0x44, 0x19, 0x25, 0x11, 0xc3, 0xe8, 0x31, 0x1c, 0xab, 0x2e, 0xcb,
-	0xf1, 0x3c, 0xcd, 0xea, 0x2a, 0xfb, 0xab, 0xbc, 0xc6, 0xe7, 0xd0, 0xc8, 0xc1, 0x51, 0x1b, 0xaa,
-	0xa1, 0x2f, 0x20, 0xf5, 0xfe, 0xf1, 0xea, 0x9f, 0xcb, 0xea, 0xe0, 0xb9, 0x5d, 0x0d, 0x7d, 0xe3,
-	0xcf, 0x2a, 0x34, 0x73, 0x71, 0x83, 0x28, 0x48, 0xc6, 0xb1, 0x91, 0xbb, 0xb5, 0xea, 0x3f, 0x17,
-	0x66, 0xf1, 0x26, 0x9b, 0x79, 0x71, 0xf9, 0x78, 0xf4, 0x2d, 0x9c, 0xc4, 0x32, 0x19, 0xa6, 0x55,
-	0xbb, 0x87, 0x57, 0x8d, 0x9e, 0xb1, 0x0f, 0xab, 0xf2, 0xce, 0x30, 0xe8, 0x1a, 0xea, 0xb1, 0xca,
-	0x96, 0x69, 0x87, 0x82, 0xe0, 0xb3, 0xbd, 0x04, 0x32, 0xd6, 0x5e, 0xa3, 0xd0, 0xd7, 0x50, 0x13,
-	0x17, 0x04, 0xfb, 0xea, 0x2e, 0xe8, 0xa6, 0x5c, 0x2d, 0x66, 0xba, 0x5a, 0xcc, 0xd7, 0xe9, 0x6a,
-	0xb1, 0xd3, 0x50, 0xa4, 0x41, 0x6d, 0x89, 0x63, 0x96, 0xe4, 0x9c, 0xcc, 0xfe, 0x3d, 0x3b, 0x3d,
-	0x1a, 0x6f, 0xa0, 0x55, 0x2a, 0x12, 0x43, 0x43, 0x68, 0xe5, 0x45, 0x85, 0x51, 0x90, 0x6c, 0x8c,
-	0x44, 0xed, 0xe5, 0x1e, 0xb5, 0x09, 0xd6, 0x6e, 0xf2, 0xa2, 0xc1, 0xb8, 0x81, 0x87, 0x7d, 0x87,
-	0x7b, 0xd3, 0x2d, 0x3b, 0x29, 0x5f, 0xcd, 0xca, 0xff, 0xaf, 0xa6, 0x71, 0x0e, 0x0f, 0xc5, 0xfe,
-	0xd8, 0x0c, 0x32, 0x6e, 0xe1, 0x7c, 0x10, 0x31, 0x8a, 0xbd, 0x2d, 0xce, 0x8f, 0x1c, 0x02, 0xe3,
-	0x06, 0x34, 0x79, 0x4d, 0xef, 0x9e, 0x5a, 0x83, 0xf6, 0x8f, 0x21, 0xdb, 0x96, 0xd0, 0x0d, 0x68,
-	0x72, 0xd7, 0xdd, 0xf9, 0x4f, 0x7b, 0xbf, 0x1f, 0xc1, 0xe1, 0xf5, 0xab, 0x01, 0x7a, 0x03, 0xad,
-	0x72, 0xa7, 0xd0, 0x17, 0x65, 0x96, 0x1d, 0xbd, 0xd4, 0xdf, 0x37, 0x18, 0xc6, 0x01, 0xba, 0x85,
-	0x56, 0xb9, 0x5d, 0x9b, 0xfc, 0x3b, 0x1a, 0xaa, 0xef, 0x4b, 0xc7, 0x38, 0x40, 0x2e, 0xa0, 0xcd,
-	0x7e, 0xa3, 0x2f, 0xcb, 0xa0, 0x9d, 0x33, 0xf1, 0x21, 0xfa, 0x7f, 0x86, 0xb3, 0x8d, 0xbe, 0xa3,
-	0xab, 0x32, 0x6e, 0xd7, 0x68, 0xe8, 0xed, 0x8d, 0x7b, 0xfa, 0x43, 0xf2, 0x3e, 0x30, 0x0e, 0xd0,
-	0x2f, 0xd0, 0x2c, 0x75, 0x1d, 0x3d, 0x2e, 0xd3, 0x6e, 0x1f, 0x0b, 0xbd, 0xfb, 0x1e, 0xd9, 0xcc,
-	0x38, 0x40, 0xbf, 0xc2, 0xd9, 0xc6, 0xe8, 0x6c, 0xea, 0xde, 0x35, 0x5d, 0x1f, 0x52, 0x99, 0x97,
-	0x50, 0xcf, 0xde, 0x21, 0xa8, 0xbb, 0xbd, 0x22, 0xeb, 0x27, 0xca, 0xee, 0x4a, 0xf4, 0xbf, 0xfb,
-	0x6b, 0xd5, 0xa9, 0xfc, 0xbd, 0xea, 0x54, 0xfe, 0x5d, 0x75, 0x2a, 0xb7, 0x4f, 0x26, 0x21, 0x9f,
+	0xec, 0x44, 0xde, 0x54, 0x3b, 0x2c, 0xaa, 0x91, 0x65, 0xec, 0x0b, 0x5f, 0xa6, 0xc6, 0xcb, 0x19,
+	0x13, 0x06, 0x55, 0x4a, 0xc5, 0x70, 0x54, 0x64, 0x90, 0xc5, 0x2c, 0x31, 0xf8, 0x39, 0x23, 0x7a,
+	0x09, 0xad, 0x05, 0xf5, 0x13, 0x0d, 0xbf, 0x11, 0x77, 0xcc, 0xb8, 0xc3, 0xb1, 0x76, 0x2c, 0x48,
+	0x1e, 0x99, 0x94, 0x0a, 0x92, 0x9f, 0x84, 0x7f, 0x48, 0xdc, 0x11, 0x17, 0x2d, 0x94, 0x34, 0xa7,
+	0x8b, 0x82, 0x19, 0xbd, 0x80, 0xa6, 0x4a, 0x86, 0x86, 0x14, 0xcf, 0xc2, 0x08, 0x6b, 0x27, 0x45,
+	0x1e, 0x99, 0xce, 0x2b, 0xe5, 0xcd, 0x78, 0xbc, 0x82, 0x19, 0x3d, 0x81, 0x63, 0xc6, 0x09, 0x4d,
+	0xe4, 0x68, 0x20, 0x08, 0xda, 0x29, 0xc1, 0x88, 0x13, 0x3a, 0x24, 0x6e, 0x8a, 0x3c, 0x62, 0xf2,
+	0x3f, 0x39, 0x5a, 0x55, 0x21, 0x3b, 0xba, 0x51, 0x3c, 0x5a, 0xd6, 0xa1, 0x72, 0xb4, 0x5f, 0x30,
+	0x1b, 0x4f, 0xe1, 0x93, 0xc2, 0xa8, 0x33, 0x4a, 0x22, 0x86, 0xd1, 0x63, 0x38, 0x54, 0xd3, 0x22,
+	0xc7, 0xfc, 0x34, 0xeb, 0x8f, 0x9c, 0x13, 0xe5, 0x35, 0x3e, 0x87, 0x46, 0x0e, 0x8e, 0xda, 0x50,
+	0x0f, 0x7d, 0x01, 0x39, 0xe9, 0x1f, 0xae, 0xfe, 0xbe, 0xac, 0x0f, 0x9e, 0xdb, 0xf5, 0xd0, 0x37,
+	0xfe, 0xa8, 0x43, 0x33, 0x17, 0x37, 0x88, 0x82, 0x64, 0xac, 0x1b, 0xb9, 0xdb, 0xaf, 0xce, 0xb9,
+	0x30, 0x8b, 0x1b, 0xc1, 0xcc, 0x8b, 0xcb, 0xc7, 0xa3, 0x6f, 0xe1, 0x38, 0x96, 0x39, 0x31, 0xad,
+	0xde, 0xdd, 0xbf, 0x6a, 0xf4, 0x8c, 0x5d, 0x58, 0x95, 0x7e, 0x86, 0x41, 0xd7, 0x70, 0x12, 0xab,
+	0x6c, 0x99, 0xb6, 0x2f, 0x08, 0x3e, 0xdb, 0x49, 0x20, 0x63, 0xed, 0x35, 0x0a, 0x7d, 0x0d, 0x47,
+	0xe2, 0xa2, 0x61, 0x5f, 0xdd, 0x29, 0xdd, 0x94, 0x2b, 0xca, 0x4c, 0x57, 0x94, 0xf9, 0x3a, 0x5d,
+	0x51, 0x76, 0x1a, 0x8a, 0x34, 0x38, 0x5a, 0xe2, 0x98, 0x25, 0x39, 0x27, 0x77, 0xe8, 0x9e, 0x9d,
+	0xfe, 0x1a, 0x6f, 0xa0, 0x55, 0x2a, 0x12, 0x43, 0x43, 0x68, 0xe5, 0x45, 0x85, 0x51, 0x90, 0x6c,
+	0x9e, 0x44, 0xed, 0xe5, 0x0e, 0xb5, 0x09, 0xd6, 0x6e, 0xf2, 0xa2, 0xc1, 0xb8, 0x81, 0x87, 0x7d,
+	0x87, 0x7b, 0xd3, 0x0d, 0xbb, 0x2d, 0x5f, 0xcd, 0xda, 0xff, 0xaf, 0xa6, 0x71, 0x0e, 0x0f, 0xc5,
+	0x1e, 0xaa, 0x06, 0x19, 0xb7, 0x70, 0x3e, 0x88, 0x18, 0xc5, 0xde, 0x06, 0xe7, 0x47, 0x0e, 0x81,
+	0x71, 0x03, 0x9a, 0x1c, 0xf3, 0xbb, 0xa7, 0xd6, 0xa0, 0xfd, 0x63, 0xc8, 0x36, 0x25, 0x74, 0x03,
+	0x9a, 0xdc, 0x99, 0x77, 0x7e, 0x68, 0xef, 0xf7, 0x03, 0xd8, 0xbf, 0x7e, 0x35, 0x40, 0x6f, 0xa0,
+	0x55, 0xee, 0x14, 0xfa, 0xa2, 0xcc, 0xb2, 0xa5, 0x97, 0xfa, 0xfb, 0x06, 0xc3, 0xd8, 0x43, 0xb7,
+	0xd0, 0x2a, 0xb7, 0xab, 0xca, 0xbf, 0xa5, 0xa1, 0xfa, 0xae, 0x74, 0x8c, 0x3d, 0xe4, 0x02, 0xaa,
+	0xf6, 0x1b, 0x7d, 0x59, 0x06, 0x6d, 0x9d, 0x89, 0x0f, 0xd1, 0xff, 0x33, 0x9c, 0x55, 0xfa, 0x8e,
+	0xae, 0xca, 0xb8, 0x6d, 0xa3, 0xa1, 0xb7, 0x2b, 0xf7, 0xf4, 0x87, 0xe4, 0x9d, 0x61, 0xec, 0xa1,
+	0x5f, 0xa0, 0x59, 0xea, 0x3a, 0x7a, 0x5c, 0xa6, 0xdd, 0x3c, 0x16, 0x7a, 0xf7, 0x3d, 0xb2, 0x99,
+	0xb1, 0x87, 0x7e, 0x85, 0xb3, 0xca, 0xe8, 0x54, 0x75, 0x6f, 0x9b, 0xae, 0x0f, 0xa9, 0xcc, 0x4b,
+	0x38, 0xc9, 0xde, 0x33, 0xa8, 0xbb, 0xb9, 0x22, 0xeb, 0xa7, 0xce, 0xf6, 0x4a, 0xf4, 0xbf, 0xfb,
+	0x73, 0xd5, 0xa9, 0xfd, 0xb5, 0xea, 0xd4, 0xfe, 0x59, 0x75, 0x6a, 0xb7, 0x4f, 0x26, 0x21, 0x9f,
 	0x2e, 0x5c, 0xd3, 0x23, 0x73, 0x8b, 0x3a, 0xde, 0xf4, 0x9d, 0x8f, 0xe3, 0xfc, 0xd7, 0xb2, 0x67,
-	0xb1, 0xd8, 0xcb, 0xbf, 0xcc, 0xdc, 0x63, 0x41, 0xf9, 0xd5, 0x7f, 0x01, 0x00, 0x00, 0xff, 0xff,
-	0xc8, 0x02, 0x52, 0x48, 0xbb, 0x09, 0x00, 0x00,
+	0xb1, 0xd8, 0xcb, 0xbf, 0xf0, 0xdc, 0x43, 0x41, 0xf9, 0xd5, 0x7f, 0x03, 0x00, 0x28, 0x88, 0x10,
+	0x07, 0x03, 0x0a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1099,6 +1108,18 @@ func (m *TransactionRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.DeletePipeline != nil {
+		{
+			size, err := m.DeletePipeline.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTransaction(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
 	if m.StopJob != nil {
 		{
 			size, err := m.StopJob.MarshalToSizedBuffer(dAtA[:i])
@@ -1701,6 +1722,10 @@ func (m *TransactionRequest) Size() (n int) {
 		l = m.StopJob.Size()
 		n += 1 + l + sovTransaction(uint64(l))
 	}
+	if m.DeletePipeline != nil {
+		l = m.DeletePipeline.Size()
+		n += 1 + l + sovTransaction(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2328,6 +2353,42 @@ func (m *TransactionRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeletePipeline", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransaction
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransaction
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransaction
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DeletePipeline == nil {
+				m.DeletePipeline = &pps.DeletePipelineRequest{}
+			}
+			if err := m.DeletePipeline.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTransaction(dAtA[iNdEx:])